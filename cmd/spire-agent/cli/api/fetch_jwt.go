@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -20,8 +21,11 @@ func newFetchJWTCommand(env *common_cli.Env, clientMaker workloadClientMaker) cl
 }
 
 type fetchJWTCommand struct {
-	audience common_cli.CommaStringsFlag
-	spiffeID string
+	audience  common_cli.CommaStringsFlag
+	spiffeID  string
+	output    string
+	writePath string
+	files     jwtSVIDFiles
 }
 
 func (c *fetchJWTCommand) name() string {
@@ -36,6 +40,9 @@ func (c *fetchJWTCommand) run(ctx context.Context, env *common_cli.Env, client *
 	if len(c.audience) == 0 {
 		return errors.New("audience must be specified")
 	}
+	if err := validateOutputFormat(c.output, outputText, outputJSON); err != nil {
+		return err
+	}
 
 	bundlesResp, err := c.fetchJWTBundles(ctx, client)
 	if err != nil {
@@ -46,11 +53,33 @@ func (c *fetchJWTCommand) run(ctx context.Context, env *common_cli.Env, client *
 		return err
 	}
 
+	var svids []jwtSVIDJSON
 	for _, svid := range svidResp.Svids {
-		fmt.Printf("token(%s):\n\t%s\n", svid.SpiffeId, svid.Svid)
+		svids = append(svids, jwtSVIDJSON{SPIFFEID: svid.SpiffeId, SVID: svid.Svid})
 	}
-
+	bundles := make(map[string]json.RawMessage, len(bundlesResp.Bundles))
 	for trustDomainID, jwksJSON := range bundlesResp.Bundles {
+		bundles[trustDomainID] = jwksJSON
+	}
+
+	switch {
+	case c.writePath != "":
+		return c.files.write(c.writePath, svids, bundles)
+	case c.output == outputJSON:
+		return env.PrintJSON(struct {
+			SVIDs   []jwtSVIDJSON              `json:"svids"`
+			Bundles map[string]json.RawMessage `json:"bundles"`
+		}{
+			SVIDs:   svids,
+			Bundles: bundles,
+		})
+	}
+
+	for _, svid := range svids {
+		fmt.Printf("token(%s):\n\t%s\n", svid.SPIFFEID, svid.SVID)
+	}
+
+	for trustDomainID, jwksJSON := range bundles {
 		fmt.Printf("bundle(%s):\n\t%s\n", trustDomainID, string(jwksJSON))
 	}
 
@@ -58,8 +87,17 @@ func (c *fetchJWTCommand) run(ctx context.Context, env *common_cli.Env, client *
 }
 
 func (c *fetchJWTCommand) appendFlags(fs *flag.FlagSet) {
+	c.files.svidMode = 0600
+	c.files.bundleMode = 0644
+
 	fs.Var(&c.audience, "audience", "comma separated list of audience values")
 	fs.StringVar(&c.spiffeID, "spiffeID", "", "SPIFFE ID subject (optional)")
+	fs.StringVar(&c.output, "output", outputText, "Output format: text or json")
+	fs.StringVar(&c.writePath, "write", "", "Write the SVIDs and JWT bundles to the specified path (optional)")
+	fs.StringVar(&c.files.svidName, "svidFileName", "jwt_svid", "Base name of the SVID files")
+	fs.StringVar(&c.files.bundleName, "bundleFileName", "jwt_bundle", "Base name of the bundle files")
+	fs.Var(&c.files.svidMode, "svidFileMode", "Permissions of the written SVID files, in octal")
+	fs.Var(&c.files.bundleMode, "bundleFileMode", "Permissions of the written bundle files, in octal")
 }
 
 func (c *fetchJWTCommand) fetchJWTSVID(ctx context.Context, client *workloadClient) (*workload.JWTSVIDResponse, error) {
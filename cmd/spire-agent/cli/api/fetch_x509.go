@@ -4,12 +4,9 @@ import (
 	"context"
 	"crypto"
 	"crypto/x509"
-	"encoding/pem"
 	"errors"
 	"flag"
 	"fmt"
-	"io/ioutil"
-	"path"
 	"time"
 
 	"github.com/mitchellh/cli"
@@ -29,6 +26,8 @@ func newFetchX509Command(env *common_cli.Env, clientMaker workloadClientMaker) c
 type fetchX509Command struct {
 	silent    bool
 	writePath string
+	output    string
+	files     x509SVIDFiles
 }
 
 func (*fetchX509Command) name() string {
@@ -40,6 +39,16 @@ func (*fetchX509Command) synopsis() string {
 }
 
 func (c *fetchX509Command) run(ctx context.Context, env *common_cli.Env, client *workloadClient) error {
+	if err := validateOutputFormat(c.output, outputText, outputJSON, outputPEM, outputDER); err != nil {
+		return err
+	}
+	if c.output == outputDER && c.writePath == "" {
+		return errors.New("der output requires -write")
+	}
+	if c.files.pkcs12 && c.writePath == "" {
+		return errors.New("-pkcs12 requires -write")
+	}
+
 	start := time.Now()
 	resp, err := c.fetchX509SVID(ctx, client)
 	respTime := time.Since(start)
@@ -52,12 +61,23 @@ func (c *fetchX509Command) run(ctx context.Context, env *common_cli.Env, client
 		return err
 	}
 
+	// When writing to files, the output format applies to the files and a
+	// summary is printed to stdout
 	if !c.silent {
-		printX509SVIDResponse(svids, respTime)
+		switch {
+		case c.writePath != "" || c.output == outputText:
+			printX509SVIDResponse(svids, respTime)
+		case c.output == outputJSON:
+			if err := printX509SVIDsJSON(env, svids); err != nil {
+				return err
+			}
+		case c.output == outputPEM:
+			printX509SVIDsPEM(svids)
+		}
 	}
 
 	if c.writePath != "" {
-		if err := c.writeResponse(svids); err != nil {
+		if err := c.files.write(c.writePath, c.output, svids); err != nil {
 			return err
 		}
 	}
@@ -66,8 +86,20 @@ func (c *fetchX509Command) run(ctx context.Context, env *common_cli.Env, client
 }
 
 func (c *fetchX509Command) appendFlags(fs *flag.FlagSet) {
+	c.files.certMode = 0644
+	c.files.keyMode = 0600
+
 	fs.BoolVar(&c.silent, "silent", false, "Suppress stdout")
 	fs.StringVar(&c.writePath, "write", "", "Write SVID data to the specified path (optional)")
+	fs.StringVar(&c.output, "output", outputText, "Output format: text, json, pem or der. When writing files, the format of the files (text writes PEM files)")
+	fs.StringVar(&c.files.svidName, "svidFileName", "svid", "Base name of the SVID files")
+	fs.StringVar(&c.files.keyName, "keyFileName", "svid", "Base name of the private key files")
+	fs.StringVar(&c.files.bundleName, "bundleFileName", "bundle", "Base name of the bundle files")
+	fs.StringVar(&c.files.federatedBundleName, "federatedBundleFileName", "federated_bundle", "Base name of the federated bundle files")
+	fs.Var(&c.files.certMode, "certFileMode", "Permissions of the written certificate files, in octal")
+	fs.Var(&c.files.keyMode, "keyFileMode", "Permissions of the written files containing private keys, in octal")
+	fs.BoolVar(&c.files.pkcs12, "pkcs12", false, "Also write each SVID, its private key and the trust bundle as a PKCS#12 file")
+	fs.StringVar(&c.files.pkcs12Password, "pkcs12Password", "", "Password protecting the PKCS#12 files")
 }
 
 func (c *fetchX509Command) fetchX509SVID(ctx context.Context, client *workloadClient) (*workload.X509SVIDResponse, error) {
@@ -82,83 +114,6 @@ func (c *fetchX509Command) fetchX509SVID(ctx context.Context, client *workloadCl
 	return stream.Recv()
 }
 
-func (c *fetchX509Command) writeResponse(svids []*X509SVID) error {
-	for i, svid := range svids {
-		svidPath := path.Join(c.writePath, fmt.Sprintf("svid.%v.pem", i))
-		keyPath := path.Join(c.writePath, fmt.Sprintf("svid.%v.key", i))
-		bundlePath := path.Join(c.writePath, fmt.Sprintf("bundle.%v.pem", i))
-
-		fmt.Printf("Writing SVID #%d to file %s.\n", i, svidPath)
-		err := c.writeCerts(svidPath, svid.Certificates)
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("Writing key #%d to file %s.\n", i, keyPath)
-		err = c.writeKey(keyPath, svid.PrivateKey)
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("Writing bundle #%d to file %s.\n", i, bundlePath)
-		err = c.writeCerts(bundlePath, svid.Bundle)
-		if err != nil {
-			return err
-		}
-
-		// sort and write the keys by trust domain so the output is consistent
-		federatedDomains := make([]string, 0, len(svid.FederatedBundles))
-		for trustDomain := range svid.FederatedBundles {
-			federatedDomains = append(federatedDomains, trustDomain)
-		}
-
-		for j, trustDomain := range federatedDomains {
-			bundlePath := path.Join(c.writePath, fmt.Sprintf("federated_bundle.%d.%d.pem", i, j))
-			fmt.Printf("Writing federated bundle #%d for trust domain %s to file %s.\n", j, trustDomain, bundlePath)
-			err = c.writeCerts(bundlePath, svid.FederatedBundles[trustDomain])
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-// writeCerts takes a slice of data, which may contain multiple certificates,
-// and encodes them as PEM blocks, writing them to filename
-func (c *fetchX509Command) writeCerts(filename string, certs []*x509.Certificate) error {
-	pemData := []byte{}
-	for _, cert := range certs {
-		b := &pem.Block{
-			Type:  "CERTIFICATE",
-			Bytes: cert.Raw,
-		}
-		pemData = append(pemData, pem.EncodeToMemory(b)...)
-	}
-
-	return c.writeFile(filename, pemData)
-}
-
-// writeKey takes a private key, formats as PEM, and writes it to filename
-func (c *fetchX509Command) writeKey(filename string, privateKey crypto.PrivateKey) error {
-	data, err := x509.MarshalPKCS8PrivateKey(privateKey)
-	if err != nil {
-		return err
-	}
-	b := &pem.Block{
-		Type:  "PRIVATE KEY",
-		Bytes: data,
-	}
-
-	return c.writeFile(filename, pem.EncodeToMemory(b))
-}
-
-// writeFile creates or truncates filename, and writes data to it
-func (c *fetchX509Command) writeFile(filename string, data []byte) error {
-	return ioutil.WriteFile(filename, data, 0644)
-}
-
 type X509SVID struct {
 	SPIFFEID         string
 	Certificates     []*x509.Certificate
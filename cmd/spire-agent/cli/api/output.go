@@ -0,0 +1,271 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// Output formats supported by the fetch commands
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputPEM  = "pem"
+	outputDER  = "der"
+)
+
+func validateOutputFormat(format string, supported ...string) error {
+	for _, s := range supported {
+		if format == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
+
+// fileModeFlag is a flag holding file permissions, expressed in octal.
+type fileModeFlag os.FileMode
+
+func (f *fileModeFlag) String() string {
+	return fmt.Sprintf("%#o", os.FileMode(*f))
+}
+
+func (f *fileModeFlag) Set(v string) error {
+	mode, err := strconv.ParseUint(v, 8, 32)
+	if err != nil || mode > 0777 {
+		return fmt.Errorf("invalid file mode %q", v)
+	}
+	*f = fileModeFlag(mode)
+	return nil
+}
+
+// writeFile creates or truncates filename, and writes data to it with the
+// given permissions. Permissions are applied even if the file already exists.
+func writeFile(filename string, data []byte, mode fileModeFlag) error {
+	if err := ioutil.WriteFile(filename, data, os.FileMode(mode)); err != nil {
+		return err
+	}
+	return os.Chmod(filename, os.FileMode(mode))
+}
+
+// x509SVIDFiles holds the names and permissions of the files X509-SVIDs are
+// written to. Names are suffixed with the SVID index and an extension that
+// depends on the output format.
+type x509SVIDFiles struct {
+	svidName            string
+	keyName             string
+	bundleName          string
+	federatedBundleName string
+	certMode            fileModeFlag
+	keyMode             fileModeFlag
+
+	pkcs12         bool
+	pkcs12Password string
+}
+
+func (f *x509SVIDFiles) write(dir, format string, svids []*X509SVID) error {
+	for i, svid := range svids {
+		if err := f.writeSVID(dir, format, i, svid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *x509SVIDFiles) writeSVID(dir, format string, i int, svid *X509SVID) error {
+	if format == outputJSON {
+		data, err := json.MarshalIndent(newX509SVIDJSON(svid), "", "  ")
+		if err != nil {
+			return err
+		}
+		// The document holds the private key
+		svidPath := filepath.Join(dir, fmt.Sprintf("%s.%d.json", f.svidName, i))
+		fmt.Printf("Writing SVID #%d to file %s.\n", i, svidPath)
+		if err := writeFile(svidPath, data, f.keyMode); err != nil {
+			return err
+		}
+	} else {
+		encodeCerts, ext := pemEncodeCerts, "pem"
+		if format == outputDER {
+			encodeCerts, ext = derEncodeCerts, "der"
+		}
+
+		svidPath := filepath.Join(dir, fmt.Sprintf("%s.%d.%s", f.svidName, i, ext))
+		keyPath := filepath.Join(dir, fmt.Sprintf("%s.%d.key", f.keyName, i))
+		bundlePath := filepath.Join(dir, fmt.Sprintf("%s.%d.%s", f.bundleName, i, ext))
+
+		fmt.Printf("Writing SVID #%d to file %s.\n", i, svidPath)
+		if err := writeFile(svidPath, encodeCerts(svid.Certificates), f.certMode); err != nil {
+			return err
+		}
+
+		keyData, err := x509.MarshalPKCS8PrivateKey(svid.PrivateKey)
+		if err != nil {
+			return err
+		}
+		if format != outputDER {
+			keyData = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyData})
+		}
+		fmt.Printf("Writing key #%d to file %s.\n", i, keyPath)
+		if err := writeFile(keyPath, keyData, f.keyMode); err != nil {
+			return err
+		}
+
+		fmt.Printf("Writing bundle #%d to file %s.\n", i, bundlePath)
+		if err := writeFile(bundlePath, encodeCerts(svid.Bundle), f.certMode); err != nil {
+			return err
+		}
+
+		// write the bundles sorted by trust domain so the output is consistent
+		for j, trustDomain := range sortedTrustDomains(svid.FederatedBundles) {
+			bundlePath := filepath.Join(dir, fmt.Sprintf("%s.%d.%d.%s", f.federatedBundleName, i, j, ext))
+			fmt.Printf("Writing federated bundle #%d for trust domain %s to file %s.\n", j, trustDomain, bundlePath)
+			if err := writeFile(bundlePath, encodeCerts(svid.FederatedBundles[trustDomain]), f.certMode); err != nil {
+				return err
+			}
+		}
+	}
+
+	if f.pkcs12 {
+		// The SVID intermediates and the trust bundle are added as CA
+		// certificates so legacy applications can build the full chain.
+		caCerts := append(append([]*x509.Certificate{}, svid.Certificates[1:]...), svid.Bundle...)
+		data, err := pkcs12.Encode(rand.Reader, svid.PrivateKey, svid.Certificates[0], caCerts, f.pkcs12Password)
+		if err != nil {
+			return fmt.Errorf("failed to encode PKCS#12 bundle: %v", err)
+		}
+		p12Path := filepath.Join(dir, fmt.Sprintf("%s.%d.p12", f.svidName, i))
+		fmt.Printf("Writing PKCS#12 bundle #%d to file %s.\n", i, p12Path)
+		if err := writeFile(p12Path, data, f.keyMode); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// x509SVIDJSON is the JSON representation of an X509-SVID. Certificates and
+// keys are PEM encoded.
+type x509SVIDJSON struct {
+	SPIFFEID         string            `json:"spiffe_id"`
+	ExpiresAt        string            `json:"expires_at"`
+	X509SVID         string            `json:"x509_svid"`
+	X509SVIDKey      string            `json:"x509_svid_key"`
+	Bundle           string            `json:"bundle"`
+	FederatedBundles map[string]string `json:"federated_bundles,omitempty"`
+}
+
+func newX509SVIDJSON(svid *X509SVID) *x509SVIDJSON {
+	out := &x509SVIDJSON{
+		SPIFFEID:  svid.SPIFFEID,
+		ExpiresAt: svid.Certificates[0].NotAfter.UTC().Format(time.RFC3339),
+		X509SVID:  string(pemEncodeCerts(svid.Certificates)),
+		Bundle:    string(pemEncodeCerts(svid.Bundle)),
+	}
+	// the key was parsed from PKCS#8 so it can always be marshaled back
+	keyData, _ := x509.MarshalPKCS8PrivateKey(svid.PrivateKey)
+	out.X509SVIDKey = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyData}))
+
+	if len(svid.FederatedBundles) > 0 {
+		out.FederatedBundles = make(map[string]string, len(svid.FederatedBundles))
+		for trustDomain, bundle := range svid.FederatedBundles {
+			out.FederatedBundles[trustDomain] = string(pemEncodeCerts(bundle))
+		}
+	}
+	return out
+}
+
+func printX509SVIDsJSON(env *common_cli.Env, svids []*X509SVID) error {
+	doc := struct {
+		SVIDs []*x509SVIDJSON `json:"svids"`
+	}{}
+	for _, svid := range svids {
+		doc.SVIDs = append(doc.SVIDs, newX509SVIDJSON(svid))
+	}
+	return env.PrintJSON(doc)
+}
+
+func printX509SVIDsPEM(svids []*X509SVID) {
+	for _, svid := range svids {
+		doc := newX509SVIDJSON(svid)
+		fmt.Print(doc.X509SVID)
+		fmt.Print(doc.X509SVIDKey)
+		fmt.Print(doc.Bundle)
+	}
+}
+
+// jwtSVIDFiles holds the names and permissions of the files JWT-SVIDs and
+// JWT bundles are written to.
+type jwtSVIDFiles struct {
+	svidName   string
+	bundleName string
+	svidMode   fileModeFlag
+	bundleMode fileModeFlag
+}
+
+func (f *jwtSVIDFiles) write(dir string, svids []jwtSVIDJSON, bundles map[string]json.RawMessage) error {
+	for i, svid := range svids {
+		svidPath := filepath.Join(dir, fmt.Sprintf("%s.%d.token", f.svidName, i))
+		fmt.Printf("Writing SVID #%d to file %s.\n", i, svidPath)
+		if err := writeFile(svidPath, []byte(svid.SVID), f.svidMode); err != nil {
+			return err
+		}
+	}
+
+	trustDomains := make([]string, 0, len(bundles))
+	for trustDomain := range bundles {
+		trustDomains = append(trustDomains, trustDomain)
+	}
+	sort.Strings(trustDomains)
+
+	for i, trustDomain := range trustDomains {
+		bundlePath := filepath.Join(dir, fmt.Sprintf("%s.%d.json", f.bundleName, i))
+		fmt.Printf("Writing bundle #%d for trust domain %s to file %s.\n", i, trustDomain, bundlePath)
+		if err := writeFile(bundlePath, bundles[trustDomain], f.bundleMode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jwtSVIDJSON is the JSON representation of a JWT-SVID
+type jwtSVIDJSON struct {
+	SPIFFEID string `json:"spiffe_id"`
+	SVID     string `json:"svid"`
+}
+
+func pemEncodeCerts(certs []*x509.Certificate) []byte {
+	var data []byte
+	for _, cert := range certs {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return data
+}
+
+func derEncodeCerts(certs []*x509.Certificate) []byte {
+	var data []byte
+	for _, cert := range certs {
+		data = append(data, cert.Raw...)
+	}
+	return data
+}
+
+func sortedTrustDomains(bundles map[string][]*x509.Certificate) []string {
+	trustDomains := make([]string, 0, len(bundles))
+	for trustDomain := range bundles {
+		trustDomains = append(trustDomains, trustDomain)
+	}
+	sort.Strings(trustDomains)
+	return trustDomains
+}
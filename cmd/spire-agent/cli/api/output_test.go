@@ -0,0 +1,196 @@
+package api
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+func TestFileModeFlag(t *testing.T) {
+	mode := fileModeFlag(0600)
+	assert.Equal(t, "0600", mode.String())
+
+	require.NoError(t, mode.Set("0440"))
+	assert.Equal(t, fileModeFlag(0440), mode)
+	require.NoError(t, mode.Set("755"))
+	assert.Equal(t, fileModeFlag(0755), mode)
+
+	assert.EqualError(t, mode.Set("0999"), `invalid file mode "0999"`)
+	assert.EqualError(t, mode.Set("01777"), `invalid file mode "01777"`)
+	assert.EqualError(t, mode.Set("rw"), `invalid file mode "rw"`)
+}
+
+func TestWriteX509SVIDFiles(t *testing.T) {
+	svid := loadTestX509SVID(t)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(svid.PrivateKey)
+	require.NoError(t, err)
+
+	files := x509SVIDFiles{
+		svidName:            "svid",
+		keyName:             "key",
+		bundleName:          "bundle",
+		federatedBundleName: "federated",
+		certMode:            0640,
+		keyMode:             0600,
+	}
+
+	t.Run("pem", func(t *testing.T) {
+		dir := makeTempDir(t)
+		defer os.RemoveAll(dir)
+
+		require.NoError(t, files.write(dir, outputPEM, []*X509SVID{svid}))
+
+		assertFile(t, filepath.Join(dir, "svid.0.pem"), pemEncodeCerts(svid.Certificates), 0640)
+		assertFile(t, filepath.Join(dir, "key.0.key"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600)
+		assertFile(t, filepath.Join(dir, "bundle.0.pem"), pemEncodeCerts(svid.Bundle), 0640)
+		assertFile(t, filepath.Join(dir, "federated.0.0.pem"), pemEncodeCerts(svid.FederatedBundles["spiffe://a.test"]), 0640)
+		assertFile(t, filepath.Join(dir, "federated.0.1.pem"), pemEncodeCerts(svid.FederatedBundles["spiffe://b.test"]), 0640)
+	})
+
+	t.Run("der", func(t *testing.T) {
+		dir := makeTempDir(t)
+		defer os.RemoveAll(dir)
+
+		require.NoError(t, files.write(dir, outputDER, []*X509SVID{svid}))
+
+		assertFile(t, filepath.Join(dir, "svid.0.der"), svid.Certificates[0].Raw, 0640)
+		assertFile(t, filepath.Join(dir, "key.0.key"), keyDER, 0600)
+		assertFile(t, filepath.Join(dir, "bundle.0.der"), derEncodeCerts(svid.Bundle), 0640)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		dir := makeTempDir(t)
+		defer os.RemoveAll(dir)
+
+		require.NoError(t, files.write(dir, outputJSON, []*X509SVID{svid}))
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, "svid.0.json"))
+		require.NoError(t, err)
+		doc := new(x509SVIDJSON)
+		require.NoError(t, json.Unmarshal(data, doc))
+		assert.Equal(t, newX509SVIDJSON(svid), doc)
+		assertFileMode(t, filepath.Join(dir, "svid.0.json"), 0600)
+	})
+
+	t.Run("pkcs12", func(t *testing.T) {
+		dir := makeTempDir(t)
+		defer os.RemoveAll(dir)
+
+		files := files
+		files.pkcs12 = true
+		files.pkcs12Password = "secret"
+		require.NoError(t, files.write(dir, outputPEM, []*X509SVID{svid}))
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, "svid.0.p12"))
+		require.NoError(t, err)
+		assertFileMode(t, filepath.Join(dir, "svid.0.p12"), 0600)
+
+		key, cert, caCerts, err := pkcs12.DecodeChain(data, "secret")
+		require.NoError(t, err)
+		assert.Equal(t, svid.PrivateKey, key)
+		assert.Equal(t, svid.Certificates[0].Raw, cert.Raw)
+		require.Len(t, caCerts, len(svid.Bundle))
+		assert.Equal(t, svid.Bundle[0].Raw, caCerts[0].Raw)
+
+		_, _, _, err = pkcs12.DecodeChain(data, "wrong")
+		assert.Error(t, err)
+	})
+
+	t.Run("existing files get the configured permissions", func(t *testing.T) {
+		dir := makeTempDir(t)
+		defer os.RemoveAll(dir)
+
+		keyPath := filepath.Join(dir, "key.0.key")
+		require.NoError(t, ioutil.WriteFile(keyPath, nil, 0644))
+		require.NoError(t, files.write(dir, outputPEM, []*X509SVID{svid}))
+		assertFileMode(t, keyPath, 0600)
+	})
+}
+
+func TestNewX509SVIDJSON(t *testing.T) {
+	svid := loadTestX509SVID(t)
+
+	doc := newX509SVIDJSON(svid)
+	assert.Equal(t, svid.SPIFFEID, doc.SPIFFEID)
+	assert.Equal(t, svid.Certificates[0].NotAfter.UTC().Format("2006-01-02T15:04:05Z07:00"), doc.ExpiresAt)
+	assert.Equal(t, string(pemEncodeCerts(svid.Certificates)), doc.X509SVID)
+	assert.Equal(t, string(pemEncodeCerts(svid.Bundle)), doc.Bundle)
+	assert.Len(t, doc.FederatedBundles, 2)
+
+	block, _ := pem.Decode([]byte(doc.X509SVIDKey))
+	require.NotNil(t, block)
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	require.NoError(t, err)
+	assert.Equal(t, svid.PrivateKey, key)
+}
+
+func TestWriteJWTSVIDFiles(t *testing.T) {
+	dir := makeTempDir(t)
+	defer os.RemoveAll(dir)
+
+	files := jwtSVIDFiles{
+		svidName:   "token",
+		bundleName: "jwks",
+		svidMode:   0600,
+		bundleMode: 0644,
+	}
+
+	svids := []jwtSVIDJSON{
+		{SPIFFEID: "spiffe://example.org/foo", SVID: "header.payload.signature"},
+	}
+	bundles := map[string]json.RawMessage{
+		"spiffe://example.org": json.RawMessage(`{"keys":[]}`),
+		"spiffe://a.test":      json.RawMessage(`{"keys":[{}]}`),
+	}
+	require.NoError(t, files.write(dir, svids, bundles))
+
+	assertFile(t, filepath.Join(dir, "token.0.token"), []byte("header.payload.signature"), 0600)
+	assertFile(t, filepath.Join(dir, "jwks.0.json"), []byte(`{"keys":[{}]}`), 0644)
+	assertFile(t, filepath.Join(dir, "jwks.1.json"), []byte(`{"keys":[]}`), 0644)
+}
+
+func loadTestX509SVID(t *testing.T) *X509SVID {
+	svid, key, err := util.LoadSVIDFixture()
+	require.NoError(t, err)
+	ca, _, err := util.LoadCAFixture()
+	require.NoError(t, err)
+
+	return &X509SVID{
+		SPIFFEID:     "spiffe://example.org/test",
+		Certificates: []*x509.Certificate{svid},
+		PrivateKey:   key,
+		Bundle:       []*x509.Certificate{ca},
+		FederatedBundles: map[string][]*x509.Certificate{
+			"spiffe://b.test": {ca},
+			"spiffe://a.test": {ca, ca},
+		},
+	}
+}
+
+func makeTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "spire-agent-api-")
+	require.NoError(t, err)
+	return dir
+}
+
+func assertFile(t *testing.T, path string, expected []byte, mode os.FileMode) {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, data, "unexpected content in %s", path)
+	assertFileMode(t, path, mode)
+}
+
+func assertFileMode(t *testing.T, path string, mode os.FileMode) {
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, mode, info.Mode().Perm(), "unexpected permissions on %s", path)
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -25,6 +26,7 @@ func newValidateJWTCommand(env *common_cli.Env, clientMaker workloadClientMaker)
 type validateJWTCommand struct {
 	audience string
 	svid     string
+	output   common_cli.OutputFlag
 }
 
 func (*validateJWTCommand) name() string {
@@ -38,6 +40,7 @@ func (*validateJWTCommand) synopsis() string {
 func (c *validateJWTCommand) appendFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.audience, "audience", "", "expected audience value")
 	fs.StringVar(&c.svid, "svid", "", "JWT SVID")
+	c.output.AddFlag(fs)
 }
 
 func (c *validateJWTCommand) run(ctx context.Context, env *common_cli.Env, client *workloadClient) error {
@@ -53,16 +56,24 @@ func (c *validateJWTCommand) run(ctx context.Context, env *common_cli.Env, clien
 		return err
 	}
 
+	claims, err := (&jsonpb.Marshaler{}).MarshalToString(resp.Claims)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal claims: %v", err)
+	}
+
+	if c.output.JSON() {
+		return common_cli.PrintJSON(env.Stdout, struct {
+			SPIFFEID string          `json:"spiffe_id"`
+			Claims   json.RawMessage `json:"claims"`
+		}{SPIFFEID: resp.SpiffeId, Claims: json.RawMessage(claims)})
+	}
+
 	if err := env.Println("SVID is valid."); err != nil {
 		return err
 	}
 	if err := env.Println("SPIFFE ID :", resp.SpiffeId); err != nil {
 		return err
 	}
-	claims, err := (&jsonpb.Marshaler{}).MarshalToString(resp.Claims)
-	if err != nil {
-		return fmt.Errorf("unable to unmarshal claims: %v", err)
-	}
 	if err := env.Println("Claims    :", claims); err != nil {
 		return err
 	}
@@ -11,10 +11,12 @@ import (
 	"time"
 
 	"github.com/spiffe/spire/api/workload"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 )
 
 type WatchConfig struct {
 	socketPath string
+	output     common_cli.OutputFlag
 }
 
 type WatchCLI struct {
@@ -54,10 +56,16 @@ func (w *WatchCLI) Run(args []string) int {
 			return 1
 		case u := <-client.UpdateChan():
 			svids, err := parseAndValidateX509SVIDResponse(u)
-			if err == nil {
-				printX509SVIDResponse(svids, time.Since(updateTime))
-			} else {
+			switch {
+			case err != nil:
 				fmt.Fprintln(os.Stderr, err)
+			case w.config.output.JSON():
+				// Each update is printed as a JSON document of its own
+				if err := printX509SVIDsJSON(common_cli.DefaultEnv, svids); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+				}
+			default:
+				printX509SVIDResponse(svids, time.Since(updateTime))
 			}
 			updateTime = time.Now()
 		}
@@ -68,6 +76,7 @@ func (w *WatchCLI) parseConfig(args []string) error {
 	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
 	c := &WatchConfig{}
 	fs.StringVar(&c.socketPath, "socketPath", "/tmp/agent.sock", "Path to the Workload API socket")
+	c.output.AddFlag(fs)
 
 	w.config = c
 	return fs.Parse(args)
@@ -8,6 +8,7 @@ import (
 	"github.com/spiffe/spire/cmd/spire-agent/cli/healthcheck"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/run"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/validate"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/version"
 )
@@ -45,6 +46,9 @@ func (cc *CLI) Run(args []string) int {
 			return validate.NewValidateCommand(), nil
 		},
 	}
+	c.Commands["completion"] = func() (cli.Command, error) {
+		return common_cli.NewCompletionCommand(newCompleter(c.Commands)), nil
+	}
 
 	exitStatus, err := c.Run()
 	if err != nil {
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"sort"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+)
+
+// newCompleter returns the completer of the commands.
+func newCompleter(commands map[string]cli.CommandFactory) *common_cli.Completer {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &common_cli.Completer{
+		Name:     "spire-agent",
+		Commands: names,
+		Values: map[string]common_cli.CompleteFunc{
+			"-output":                common_cli.CompleteValues(common_cli.OutputText, common_cli.OutputJSON),
+			"-logFormat":             common_cli.CompleteValues("text", "json"),
+			"-logLevel":              common_cli.CompleteValues("DEBUG", "INFO", "WARN", "ERROR"),
+			"api fetch -output":      common_cli.CompleteValues("text", "json", "pem", "der"),
+			"api fetch x509 -output": common_cli.CompleteValues("text", "json", "pem", "der"),
+		},
+	}
+}
@@ -1,19 +1,31 @@
 package healthcheck
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"flag"
+	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/mitchellh/cli"
 	api_workload "github.com/spiffe/spire/api/workload"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/common"
+	"github.com/spiffe/spire/pkg/agent"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/health"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
 func NewHealthCheckCommand() cli.Command {
 	return newHealthCheckCommand(common_cli.DefaultEnv)
 }
@@ -31,7 +43,9 @@ type healthCheckCommand struct {
 	socketPath string
 	timeout    common_cli.DurationFlag
 	shallow    bool
+	ready      bool
 	verbose    bool
+	output     string
 }
 
 func (c *healthCheckCommand) Help() string {
@@ -48,25 +62,81 @@ func (c *healthCheckCommand) Run(args []string) int {
 	if err := c.parseFlags(args); err != nil {
 		return 1
 	}
-	if err := c.run(); err != nil {
-		// Ignore error since a failure to write to stderr cannot very well
-		// be reported
-		_ = c.env.ErrPrintln(err)
+	switch c.output {
+	case outputText:
+	case outputJSON:
+		// Verbose output would corrupt the JSON document
+		c.verbose = false
+	default:
+		_ = c.env.ErrPrintf("Invalid output format %q: must be %q or %q\n", c.output, outputText, outputJSON)
 		return 1
 	}
-	return 0
+
+	report := c.run()
+	if c.output == outputJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return health.ExitNotLive
+		}
+		if err := c.env.Println(string(data)); err != nil {
+			return health.ExitNotLive
+		}
+		return report.ExitCode()
+	}
+
+	// Ignore errors writing to stderr since they cannot very well be
+	// reported
+	switch {
+	case !report.Live:
+		_ = c.env.ErrPrintln(report.Error)
+	case !report.Ready:
+		if failing := report.FailingSubsystems(); len(failing) > 0 {
+			_ = c.env.ErrPrintf("Agent is not ready: failing subsystems: %s\n", strings.Join(failing, ", "))
+		} else {
+			_ = c.env.ErrPrintln("Agent is not ready.")
+		}
+	default:
+		if err := c.env.Println("Agent is healthy."); err != nil {
+			return health.ExitNotLive
+		}
+	}
+	return report.ExitCode()
 }
 
 func (c *healthCheckCommand) parseFlags(args []string) error {
 	fs := flag.NewFlagSet("health", flag.ContinueOnError)
 	fs.SetOutput(c.env.Stderr)
 	fs.StringVar(&c.socketPath, "socketPath", common.DefaultSocketPath, "Path to Workload API socket")
-	fs.BoolVar(&c.shallow, "shallow", false, "Perform a less stringent health check")
+	fs.BoolVar(&c.ready, "ready", false, "Also check that the agent is ready by querying the subsystem checks")
+	fs.BoolVar(&c.shallow, "shallow", false, "Only check that the agent is live, skipping the subsystem checks even if -ready is set")
 	fs.BoolVar(&c.verbose, "verbose", false, "Print verbose information")
+	fs.StringVar(&c.output, "output", outputText, "Output format: text or json")
 	return fs.Parse(args)
 }
 
-func (c *healthCheckCommand) run() error {
+// run checks the agent is live by contacting the Workload API, and if asked
+// for and not shallow, that it is ready by querying the subsystem checks.
+func (c *healthCheckCommand) run() *health.Report {
+	report := new(health.Report)
+
+	if err := c.checkLive(); err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Live = true
+
+	if !c.ready || c.shallow {
+		report.Ready = true
+		return report
+	}
+
+	if err := c.checkReady(report); err != nil {
+		report.Error = err.Error()
+	}
+	return report
+}
+
+func (c *healthCheckCommand) checkLive() error {
 	addr := &net.UnixAddr{
 		Name: c.socketPath,
 		Net:  "unix",
@@ -102,9 +172,43 @@ func (c *healthCheckCommand) run() error {
 			}
 		}
 	}
+	return nil
+}
+
+func (c *healthCheckCommand) checkReady(report *health.Report) error {
+	if c.verbose {
+		if err := c.env.Println("Checking subsystems via health service..."); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.timeout))
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, c.socketPath, grpc.WithInsecure(), grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, "unix", addr)
+	}))
+	if err != nil {
+		return fmt.Errorf("unable to check subsystems: %v", err)
+	}
+	defer conn.Close()
 
-	if err := c.env.Println("Agent is healthy."); err != nil {
-		return err
+	ready, subsystems, err := health.CheckSubsystems(ctx, conn, agent.HealthChecks)
+	if err != nil {
+		if c.verbose {
+			c.env.Printf("Health service returned %s\n", err)
+		}
+		return fmt.Errorf("unable to check subsystems: %v", err)
+	}
+	report.Ready = ready
+	report.Subsystems = subsystems
+
+	if c.verbose {
+		for _, subsystem := range subsystems {
+			if err := c.env.Printf("Subsystem %s is %s.\n", subsystem.Name, subsystem.Status); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
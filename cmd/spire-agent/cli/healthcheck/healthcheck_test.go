@@ -10,6 +10,7 @@ import (
 	"github.com/spiffe/spire/test/fakes/fakeworkloadapi"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 )
 
@@ -46,8 +47,12 @@ func (s *HealthCheckSuite) TestSynopsis() {
 func (s *HealthCheckSuite) TestHelp() {
 	s.Equal("", s.cmd.Help())
 	s.Equal(`Usage of health:
+  -output string
+    	Output format: text or json (default "text")
+  -ready
+    	Also check that the agent is ready by querying the subsystem checks
   -shallow
-    	Perform a less stringent health check
+    	Only check that the agent is live, skipping the subsystem checks even if -ready is set
   -socketPath string
     	Path to Workload API socket (default "/tmp/agent.sock")
   -verbose
@@ -61,8 +66,12 @@ func (s *HealthCheckSuite) TestBadFlags() {
 	s.Equal("", s.stdout.String(), "stdout")
 	s.Equal(`flag provided but not defined: -badflag
 Usage of health:
+  -output string
+    	Output format: text or json (default "text")
+  -ready
+    	Also check that the agent is ready by querying the subsystem checks
   -shallow
-    	Perform a less stringent health check
+    	Only check that the agent is live, skipping the subsystem checks even if -ready is set
   -socketPath string
     	Path to Workload API socket (default "/tmp/agent.sock")
   -verbose
@@ -125,6 +134,107 @@ Agent is healthy.
 	s.Equal("", s.stderr.String(), "stderr")
 }
 
+func (s *HealthCheckSuite) TestFailsOnInvalidOutput() {
+	code := s.cmd.Run([]string{"--output", "yaml"})
+	s.Equal(1, code, "exit code")
+	s.Equal("", s.stdout.String(), "stdout")
+	s.Equal("Invalid output format \"yaml\": must be \"text\" or \"json\"\n", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestLiveOnFailingSubsystem() {
+	w := s.makeWorkloadAPIWithHealth(healthpb.HealthCheckResponse_NOT_SERVING)
+	defer w.Close()
+	code := s.cmd.Run([]string{"--socketPath", w.Addr().Name})
+	s.Equal(0, code, "exit code")
+	s.Equal("Agent is healthy.\n", s.stdout.String(), "stdout")
+	s.Equal("", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestReadyOnGoodResponseVerbose() {
+	w := s.makeGoodWorkloadAPI()
+	defer w.Close()
+	code := s.cmd.Run([]string{"--socketPath", w.Addr().Name, "--ready", "--verbose"})
+	s.Equal(0, code, "exit code")
+	s.Equal(`Contacting Workload API...
+SVID received over Workload API.
+Checking subsystems via health service...
+Agent is healthy.
+`, s.stdout.String(), "stdout")
+	s.Equal("", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestNotReadyOnFailingSubsystem() {
+	w := s.makeWorkloadAPIWithHealth(healthpb.HealthCheckResponse_NOT_SERVING)
+	defer w.Close()
+	code := s.cmd.Run([]string{"--socketPath", w.Addr().Name, "--ready"})
+	s.Equal(2, code, "exit code")
+	s.Equal("", s.stdout.String(), "stdout")
+	s.Equal("Agent is not ready: failing subsystems: bundle, plugins\n", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestNotReadyOnFailingSubsystemVerbose() {
+	w := s.makeWorkloadAPIWithHealth(healthpb.HealthCheckResponse_NOT_SERVING)
+	defer w.Close()
+	code := s.cmd.Run([]string{"--socketPath", w.Addr().Name, "--ready", "--verbose"})
+	s.Equal(2, code, "exit code")
+	s.Equal(`Contacting Workload API...
+SVID received over Workload API.
+Checking subsystems via health service...
+Subsystem svid is SERVING.
+Subsystem bundle is NOT_SERVING.
+Subsystem plugins is UNKNOWN.
+`, s.stdout.String(), "stdout")
+	s.Equal("Agent is not ready: failing subsystems: bundle, plugins\n", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestShallowSkipsSubsystems() {
+	w := s.makeWorkloadAPIWithHealth(healthpb.HealthCheckResponse_NOT_SERVING)
+	defer w.Close()
+	code := s.cmd.Run([]string{"--socketPath", w.Addr().Name, "--ready", "--shallow"})
+	s.Equal(0, code, "exit code")
+	s.Equal("Agent is healthy.\n", s.stdout.String(), "stdout")
+	s.Equal("", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestJSONOutput() {
+	w := s.makeWorkloadAPIWithHealth(healthpb.HealthCheckResponse_NOT_SERVING)
+	defer w.Close()
+	code := s.cmd.Run([]string{"--socketPath", w.Addr().Name, "--ready", "--output", "json", "--verbose"})
+	s.Equal(2, code, "exit code")
+	s.Equal(`{
+  "live": true,
+  "ready": false,
+  "subsystems": [
+    {
+      "name": "svid",
+      "status": "SERVING"
+    },
+    {
+      "name": "bundle",
+      "status": "NOT_SERVING"
+    },
+    {
+      "name": "plugins",
+      "status": "UNKNOWN"
+    }
+  ]
+}
+`, s.stdout.String(), "stdout")
+	s.Equal("", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestJSONOutputOnUnavailable() {
+	code := s.cmd.Run([]string{"--socketPath", "doesnotexist.sock", "--output", "json"})
+	s.Equal(1, code, "exit code")
+	s.Equal(`{
+  "live": false,
+  "ready": false,
+  "error": "Agent is unavailable."
+}
+`, s.stdout.String(), "stdout")
+	s.Equal("", s.stderr.String(), "stderr")
+}
+
 func (s *HealthCheckSuite) makeFailedWorkloadAPI(err error) *fakeworkloadapi.WorkloadAPI {
 	return fakeworkloadapi.New(s.T(), fakeworkloadapi.FetchX509SVIDErrorOnce(err))
 }
@@ -132,3 +242,14 @@ func (s *HealthCheckSuite) makeFailedWorkloadAPI(err error) *fakeworkloadapi.Wor
 func (s *HealthCheckSuite) makeGoodWorkloadAPI() *fakeworkloadapi.WorkloadAPI {
 	return fakeworkloadapi.New(s.T(), fakeworkloadapi.FetchX509SVIDResponses(&workload.X509SVIDResponse{}))
 }
+
+func (s *HealthCheckSuite) makeWorkloadAPIWithHealth(overall healthpb.HealthCheckResponse_ServingStatus) *fakeworkloadapi.WorkloadAPI {
+	return fakeworkloadapi.New(s.T(),
+		fakeworkloadapi.FetchX509SVIDResponses(&workload.X509SVIDResponse{}),
+		fakeworkloadapi.HealthStatuses(map[string]healthpb.HealthCheckResponse_ServingStatus{
+			"":       overall,
+			"svid":   healthpb.HealthCheckResponse_SERVING,
+			"bundle": healthpb.HealthCheckResponse_NOT_SERVING,
+		}),
+	)
+}
@@ -0,0 +1,12 @@
+// +build !windows
+
+package run
+
+import (
+	"os"
+	"syscall"
+)
+
+// reloadSignals are the signals that make the agent reload its plugin
+// configuration
+var reloadSignals = []os.Signal{syscall.SIGHUP}
@@ -0,0 +1,11 @@
+// +build windows
+
+package run
+
+import (
+	"os"
+)
+
+// reloadSignals are the signals that make the agent reload its plugin
+// configuration. There is no suitable signal on Windows.
+var reloadSignals []os.Signal
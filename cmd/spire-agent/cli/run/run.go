@@ -12,21 +12,31 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/hcl"
 	"github.com/imdario/mergo"
 	"github.com/mitchellh/cli"
 	"github.com/sirupsen/logrus"
+	spiffe_tls "github.com/spiffe/go-spiffe/tls"
 	"github.com/spiffe/spire/pkg/agent"
+	"github.com/spiffe/spire/pkg/agent/bundlesource"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/common/backoff"
+	"github.com/spiffe/spire/pkg/agent/endpoints"
+	"github.com/spiffe/spire/pkg/agent/endpoints/workload"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
 )
@@ -34,6 +44,9 @@ import (
 const (
 	commandName = "run"
 
+	// envPrefix prefixes the environment variables overriding the config
+	envPrefix = "SPIRE_AGENT"
+
 	defaultConfigPath = "conf/agent/agent.conf"
 	defaultSocketPath = "./spire_api"
 
@@ -42,6 +55,10 @@ const (
 	defaultLogLevel          = "INFO"
 	defaultDefaultSVIDName   = "default"
 	defaultDefaultBundleName = "ROOTCA"
+
+	// Formats of the initial trust bundle
+	bundleFormatPEM    = "pem"
+	bundleFormatSPIFFE = "spiffe"
 )
 
 // Config contains all available configurables, arranged by section
@@ -54,41 +71,122 @@ type Config struct {
 }
 
 type agentConfig struct {
-	DataDir             string    `hcl:"data_dir"`
-	DeprecatedEnableSDS *bool     `hcl:"enable_sds"`
-	InsecureBootstrap   bool      `hcl:"insecure_bootstrap"`
-	JoinToken           string    `hcl:"join_token"`
-	LogFile             string    `hcl:"log_file"`
-	LogFormat           string    `hcl:"log_format"`
-	LogLevel            string    `hcl:"log_level"`
-	SDS                 sdsConfig `hcl:"sds"`
-	ServerAddress       string    `hcl:"server_address"`
-	ServerPort          int       `hcl:"server_port"`
-	SocketPath          string    `hcl:"socket_path"`
-	TrustBundlePath     string    `hcl:"trust_bundle_path"`
-	TrustBundleURL      string    `hcl:"trust_bundle_url"`
-	TrustDomain         string    `hcl:"trust_domain"`
+	AllowUnauthenticatedVerifiers bool                `hcl:"allow_unauthenticated_verifiers"`
+	DataDir                       string              `hcl:"data_dir"`
+	DeprecatedEnableSDS           *bool               `hcl:"enable_sds"`
+	EnableRegistrationAPIProxy    bool                `hcl:"enable_registration_api_proxy"`
+	InsecureBootstrap             bool                `hcl:"insecure_bootstrap"`
+	JoinToken                     string              `hcl:"join_token"`
+	LogFields                     map[string]string   `hcl:"log_fields"`
+	LogFile                       string              `hcl:"log_file"`
+	LogFormat                     string              `hcl:"log_format"`
+	LogLevel                      string              `hcl:"log_level"`
+	LogRotation                   *log.RotationConfig `hcl:"log_rotation"`
+	LogSyslog                     *log.SyslogConfig   `hcl:"log_syslog"`
+	ReenableInsecureBootstrap     bool                `hcl:"reenable_insecure_bootstrap"`
+	SDS                           sdsConfig           `hcl:"sds"`
+	ServerAddress                 string              `hcl:"server_address"`
+	ServerAddresses               []string            `hcl:"server_addresses"`
+	ServerPort                    int                 `hcl:"server_port"`
+	ServerProxyURL                string              `hcl:"server_proxy_url"`
+	ServerResolveInterval         string              `hcl:"server_resolve_interval"`
+	SocketPath                    string              `hcl:"socket_path"`
+	SVIDRenewalThreshold          float64             `hcl:"svid_renewal_threshold"`
+	TrustBundleFormat             string              `hcl:"trust_bundle_format"`
+	TrustBundlePath               string              `hcl:"trust_bundle_path"`
+	TrustBundleURL                string              `hcl:"trust_bundle_url"`
+	TrustBundleURLBundlePath      string              `hcl:"trust_bundle_url_bundle_path"`
+	TrustBundleURLSPIFFEID        string              `hcl:"trust_bundle_url_spiffe_id"`
+	TrustDomain                   string              `hcl:"trust_domain"`
+
+	GRPC                 grpcConfig                `hcl:"grpc"`
+	ServerBackoff        serverBackoffConfig       `hcl:"server_backoff"`
+	TrustBundleSource    *trustBundleSourceConfig  `hcl:"trust_bundle_source"`
+	WorkloadAPISockets   []workloadAPISocketConfig `hcl:"workload_api_sockets"`
+	WorkloadAPIRateLimit rateLimitConfig           `hcl:"workload_api_rate_limit"`
+
+	WorkloadAPIReflectionEnabled bool `hcl:"workload_api_reflection_enabled"`
 
 	ConfigPath string
 	ExpandEnv  bool
 
 	// Undocumented configurables
-	ProfilingEnabled bool               `hcl:"profiling_enabled"`
-	ProfilingPort    int                `hcl:"profiling_port"`
-	ProfilingFreq    int                `hcl:"profiling_freq"`
-	ProfilingNames   []string           `hcl:"profiling_names"`
-	Experimental     experimentalConfig `hcl:"experimental"`
+	ProfilingEnabled    bool               `hcl:"profiling_enabled"`
+	ProfilingPort       int                `hcl:"profiling_port"`
+	ProfilingSocketPath string             `hcl:"profiling_socket_path"`
+	ProfilingFreq       int                `hcl:"profiling_freq"`
+	ProfilingNames      []string           `hcl:"profiling_names"`
+	Experimental        experimentalConfig `hcl:"experimental"`
 
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
 type sdsConfig struct {
-	DefaultSVIDName   string `hcl:"default_svid_name"`
-	DefaultBundleName string `hcl:"default_bundle_name"`
+	DefaultSVIDName   string            `hcl:"default_svid_name"`
+	DefaultBundleName string            `hcl:"default_bundle_name"`
+	SVIDNames         map[string]string `hcl:"svid_names"`
+	BundleNames       map[string]string `hcl:"bundle_names"`
+}
+
+type workloadAPISocketConfig struct {
+	Path     string `hcl:"path"`
+	OwnerUID *int   `hcl:"owner_uid"`
+	OwnerGID *int   `hcl:"owner_gid"`
+	Mode     string `hcl:"mode"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type trustBundleSourceConfig struct {
+	Type     string `hcl:"type"`
+	Name     string `hcl:"name"`
+	Region   string `hcl:"region"`
+	Endpoint string `hcl:"endpoint"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type grpcConfig struct {
+	DialTimeout                  string `hcl:"dial_timeout"`
+	KeepaliveTime                string `hcl:"keepalive_time"`
+	KeepaliveTimeout             string `hcl:"keepalive_timeout"`
+	KeepalivePermitWithoutStream bool   `hcl:"keepalive_permit_without_stream"`
+	MaxRecvMsgSize               int    `hcl:"max_recv_msg_size"`
+	MaxSendMsgSize               int    `hcl:"max_send_msg_size"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type serverBackoffConfig struct {
+	AttestationRetryInterval string  `hcl:"attestation_retry_interval"`
+	MaxInterval              string  `hcl:"max_interval"`
+	Multiplier               float64 `hcl:"multiplier"`
+	Jitter                   float64 `hcl:"jitter"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type rateLimitConfig struct {
+	FetchX509SVID   int  `hcl:"fetch_x509_svid"`
+	FetchJWTSVID    int  `hcl:"fetch_jwt_svid"`
+	ValidateJWTSVID int  `hcl:"validate_jwt_svid"`
+	PerUID          bool `hcl:"per_uid"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
 type experimentalConfig struct {
-	SyncInterval string `hcl:"sync_interval"`
+	SyncInterval      string `hcl:"sync_interval"`
+	NamedPipeName     string `hcl:"named_pipe_name"`
+	TCPSocket         string `hcl:"tcp_socket"`
+	PIDFDPeerTracking bool   `hcl:"pidfd_peer_tracking"`
+	CachePersistence  bool   `hcl:"cache_persistence"`
+
+	RetainSVIDsDuringOutage bool `hcl:"retain_svids_during_outage"`
+	HardenKeyMemory         bool `hcl:"harden_key_memory"`
+
+	DrainGracePeriod    string `hcl:"drain_grace_period"`
+	JWTSVIDCacheMaxSize int    `hcl:"jwt_svid_cache_max_size"`
 
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
@@ -116,16 +214,29 @@ func (cmd *Command) Help() string {
 
 // Help is a standalone function that prints a help message to writer.
 // It is used by both the run and validate commands, so they can share flag usage messages.
-func Help(name string, writer io.Writer) string {
-	_, err := parseFlags(name, []string{"-h"}, writer)
+// The extraFlags functions add the flags specific to the command.
+func Help(name string, writer io.Writer, extraFlags ...func(*flag.FlagSet)) string {
+	_, err := parseFlags(name, []string{"-h"}, writer, extraFlags...)
 	// Error is always present because -h is passed
 	return err.Error()
 }
 
 func LoadConfig(name string, args []string, logOptions []log.Option, output io.Writer) (*agent.Config, error) {
+	input, err := ParseConfig(name, args, output)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAgentConfig(input, logOptions)
+}
+
+// ParseConfig parses the CLI flags and the config file they point to, and
+// merges them with the defaults. The extraFlags functions add the flags
+// specific to the command.
+func ParseConfig(name string, args []string, output io.Writer, extraFlags ...func(*flag.FlagSet)) (*Config, error) {
 	// First parse the CLI flags so we can get the config
 	// file path, if set
-	cliInput, err := parseFlags(name, args, output)
+	cliInput, err := parseFlags(name, args, output, extraFlags...)
 	if err != nil {
 		return nil, err
 	}
@@ -137,12 +248,7 @@ func LoadConfig(name string, args []string, logOptions []log.Option, output io.W
 		return nil, err
 	}
 
-	input, err := mergeInput(fileInput, cliInput)
-	if err != nil {
-		return nil, err
-	}
-
-	return NewAgentConfig(input, logOptions)
+	return mergeInput(fileInput, cliInput)
 }
 
 func (cmd *Command) Run(args []string) int {
@@ -152,13 +258,25 @@ func (cmd *Command) Run(args []string) int {
 		return 1
 	}
 
-	// Create uds dir and parents if not exists
-	dir := filepath.Dir(c.BindAddress.String())
-	if _, statErr := os.Stat(dir); os.IsNotExist(statErr) {
-		c.Log.WithField("dir", dir).Infof("Creating spire agent UDS directory")
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			fmt.Fprintln(cmd.env.Stderr, err)
-			return 1
+	// Create uds dirs and parents if not exists
+	var udsAddrs []*net.UnixAddr
+	if c.NamedPipeName == "" {
+		udsAddrs = append(udsAddrs, c.BindAddress)
+	}
+	for _, uds := range c.AdditionalBindAddresses {
+		udsAddrs = append(udsAddrs, uds.Addr)
+	}
+	for _, addr := range udsAddrs {
+		if util.IsAbstractUDS(addr.String()) {
+			continue
+		}
+		dir := filepath.Dir(addr.String())
+		if _, statErr := os.Stat(dir); os.IsNotExist(statErr) {
+			c.Log.WithField("dir", dir).Infof("Creating spire agent UDS directory")
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				fmt.Fprintln(cmd.env.Stderr, err)
+				return 1
+			}
 		}
 	}
 
@@ -170,6 +288,7 @@ func (cmd *Command) Run(args []string) int {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	util.SignalListener(ctx, cancel)
+	go reloadOnSignal(ctx, args, c.Log, a)
 
 	err = a.Run(ctx)
 	if err != nil {
@@ -185,6 +304,48 @@ func (*Command) Synopsis() string {
 	return "Runs the agent"
 }
 
+// reloadOnSignal reconfigures the plugins with the plugin data from the
+// configuration each time the agent receives one of the reload signals, so it
+// can be changed without restarting the agent.
+func reloadOnSignal(ctx context.Context, args []string, log logrus.FieldLogger, plugins pluginReconfigurer) {
+	if len(reloadSignals) == 0 {
+		return
+	}
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, reloadSignals...)
+	defer signal.Stop(signalCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signalCh:
+			if err := reloadPlugins(ctx, args, plugins); err != nil {
+				log.WithError(err).Error("Unable to reconfigure plugins")
+			}
+		}
+	}
+}
+
+// pluginReconfigurer reconfigures the plugins of a running agent.
+type pluginReconfigurer interface {
+	ReconfigurePlugins(ctx context.Context, pluginConfig catalog.HCLPluginConfigMap) error
+}
+
+// reloadPlugins reconfigures the plugins with the plugin data from the
+// configuration, loaded as on startup.
+func reloadPlugins(ctx context.Context, args []string, plugins pluginReconfigurer) error {
+	input, err := ParseConfig(commandName, args, ioutil.Discard)
+	if err != nil {
+		return err
+	}
+	if input.Plugins == nil {
+		return errors.New("plugins section must be configured")
+	}
+	return plugins.ReconfigurePlugins(ctx, *input.Plugins)
+}
+
 func ParseFile(path string, expandEnv bool) (*Config, error) {
 	c := &Config{}
 
@@ -221,7 +382,7 @@ func ParseFile(path string, expandEnv bool) (*Config, error) {
 	return c, nil
 }
 
-func parseFlags(name string, args []string, output io.Writer) (*agentConfig, error) {
+func parseFlags(name string, args []string, output io.Writer, extraFlags ...func(*flag.FlagSet)) (*agentConfig, error) {
 	flags := flag.NewFlagSet(name, flag.ContinueOnError)
 	flags.SetOutput(output)
 	c := &agentConfig{}
@@ -238,8 +399,12 @@ func parseFlags(name string, args []string, output io.Writer) (*agentConfig, err
 	flags.StringVar(&c.TrustDomain, "trustDomain", "", "The trust domain that this agent belongs to")
 	flags.StringVar(&c.TrustBundlePath, "trustBundle", "", "Path to the SPIRE server CA bundle")
 	flags.StringVar(&c.TrustBundleURL, "trustBundleUrl", "", "URL to download the SPIRE server CA bundle")
+	flags.StringVar(&c.TrustBundleFormat, "trustBundleFormat", "", "Format of the initial trust bundle, 'pem' or 'spiffe'")
 	flags.BoolVar(&c.InsecureBootstrap, "insecureBootstrap", false, "If true, the agent bootstraps without verifying the server's identity")
 	flags.BoolVar(&c.ExpandEnv, "expandEnv", false, "Expand environment variables in SPIRE config file")
+	for _, addFlags := range extraFlags {
+		addFlags(flags)
+	}
 
 	err := flags.Parse(args)
 	if err != nil {
@@ -252,6 +417,12 @@ func parseFlags(name string, args []string, output io.Writer) (*agentConfig, err
 func mergeInput(fileInput *Config, cliInput *agentConfig) (*Config, error) {
 	c := &Config{Agent: &agentConfig{}}
 
+	// Environment variables take precedence over the config file, but not
+	// over the CLI flags
+	if err := overrideFromEnv(fileInput); err != nil {
+		return nil, err
+	}
+
 	// Highest precedence first
 	err := mergo.Merge(c.Agent, cliInput)
 	if err != nil {
@@ -271,11 +442,27 @@ func mergeInput(fileInput *Config, cliInput *agentConfig) (*Config, error) {
 	return c, nil
 }
 
-func downloadTrustBundle(trustBundleURL string) ([]*x509.Certificate, error) {
+// overrideFromEnv overrides the config with the SPIRE_AGENT_* environment
+// variables. The variables of the agent section are not prefixed with the
+// section name (e.g. SPIRE_AGENT_LOG_LEVEL), unlike the variables of the other
+// sections (e.g. SPIRE_AGENT_TELEMETRY_ALLOWED_PREFIXES). The plugins cannot be
+// configured from the environment.
+func overrideFromEnv(c *Config) error {
+	if c.Agent == nil {
+		c.Agent = &agentConfig{}
+	}
+	if err := common_cli.OverrideFromEnv(envPrefix, c.Agent); err != nil {
+		return err
+	}
+	if err := common_cli.OverrideFromEnv(envPrefix+"_TELEMETRY", &c.Telemetry); err != nil {
+		return err
+	}
+	return common_cli.OverrideFromEnv(envPrefix+"_HEALTH_CHECKS", &c.HealthChecks)
+}
+
+func downloadTrustBundle(trustBundleURL, bundleFormat, trustDomain string, client *http.Client) ([]*x509.Certificate, error) {
 	// Download the trust bundle URL from the user specified URL
-	// We use gosec -- the annotation below will disable a security check that URLs are not tainted
-	/* #nosec G107 */
-	resp, err := http.Get(trustBundleURL)
+	resp, err := client.Get(trustBundleURL)
 	if err != nil {
 		return nil, fmt.Errorf("unable to fetch trust bundle URL %s: %v", trustBundleURL, err)
 	}
@@ -285,33 +472,72 @@ func downloadTrustBundle(trustBundleURL string) ([]*x509.Certificate, error) {
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("error downloading trust bundle: %s", resp.Status)
 	}
-	pemBytes, err := ioutil.ReadAll(resp.Body)
+	bundleBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read from trust bundle URL %s: %v", trustBundleURL, err)
 	}
 
-	bundle, err := pemutil.ParseCertificates(pemBytes)
+	return parseTrustBundleBytes(bundleBytes, bundleFormat, trustDomain)
+}
+
+// newTrustBundleHTTPClient returns the client used to download the trust
+// bundle. Unless an endpoint SPIFFE ID is configured, the endpoint is
+// authenticated via Web PKI. Otherwise the endpoint must present an X509-SVID
+// for that SPIFFE ID, signed by the configured bootstrap bundle.
+func newTrustBundleHTTPClient(c *agentConfig) (*http.Client, error) {
+	if c.TrustBundleURLSPIFFEID == "" {
+		return http.DefaultClient, nil
+	}
+
+	rootCAs, err := pemutil.LoadCertificates(c.TrustBundleURLBundlePath)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("could not parse trust bundle URL bundle: %v", err)
+	}
+	if len(rootCAs) == 0 {
+		return nil, errors.New("no certificates found in trust bundle URL bundle")
 	}
 
-	return bundle, nil
+	peer := &spiffe_tls.TLSPeer{
+		SpiffeIDs:  []string{c.TrustBundleURLSPIFFEID},
+		TrustRoots: util.NewCertPool(rootCAs...),
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: peer.NewTLSConfig(nil),
+		},
+	}, nil
 }
 
 func setupTrustBundle(ac *agent.Config, c *Config) error {
-	// Either download the turst bundle if TrustBundleURL is set, or read it
-	// from disk if TrustBundlePath is set
+	// Either download the turst bundle if TrustBundleURL is set, read it
+	// from cloud metadata if TrustBundleSource is set, or read it from disk
+	// if TrustBundlePath is set
 	ac.InsecureBootstrap = c.Agent.InsecureBootstrap
+	ac.ReenableInsecureBootstrap = c.Agent.ReenableInsecureBootstrap
 
 	switch {
 	case c.Agent.TrustBundleURL != "":
-		bundle, err := downloadTrustBundle(c.Agent.TrustBundleURL)
+		client, err := newTrustBundleHTTPClient(c.Agent)
 		if err != nil {
 			return err
 		}
+		bundle, err := downloadTrustBundle(c.Agent.TrustBundleURL, c.Agent.TrustBundleFormat, c.Agent.TrustDomain, client)
+		if err != nil {
+			return err
+		}
+		ac.TrustBundle = bundle
+	case c.Agent.TrustBundleSource != nil:
+		bundleBytes, err := bundlesource.Fetch(context.Background(), c.Agent.TrustBundleSource.config())
+		if err != nil {
+			return err
+		}
+		bundle, err := parseTrustBundleBytes(bundleBytes, c.Agent.TrustBundleFormat, c.Agent.TrustDomain)
+		if err != nil {
+			return fmt.Errorf("could not parse trust bundle: %v", err)
+		}
 		ac.TrustBundle = bundle
 	case c.Agent.TrustBundlePath != "":
-		bundle, err := parseTrustBundle(c.Agent.TrustBundlePath)
+		bundle, err := parseTrustBundle(c.Agent.TrustBundlePath, c.Agent.TrustBundleFormat, c.Agent.TrustDomain)
 		if err != nil {
 			return fmt.Errorf("could not parse trust bundle: %v", err)
 		}
@@ -336,8 +562,59 @@ func NewAgentConfig(c *Config, logOptions []log.Option) (*agent.Config, error) {
 		}
 	}
 
-	serverHostPort := net.JoinHostPort(c.Agent.ServerAddress, strconv.Itoa(c.Agent.ServerPort))
-	ac.ServerAddress = fmt.Sprintf("dns:///%s", serverHostPort)
+	if c.Agent.Experimental.DrainGracePeriod != "" {
+		var err error
+		ac.DrainGracePeriod, err = time.ParseDuration(c.Agent.Experimental.DrainGracePeriod)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse drain grace period: %v", err)
+		}
+		if ac.DrainGracePeriod <= 0 {
+			return nil, errors.New("drain grace period must be positive")
+		}
+	}
+
+	serverAddresses := c.Agent.ServerAddresses
+	if len(serverAddresses) == 0 {
+		serverAddresses = []string{net.JoinHostPort(c.Agent.ServerAddress, strconv.Itoa(c.Agent.ServerPort))}
+	}
+	var resolveInterval time.Duration
+	if c.Agent.ServerResolveInterval != "" {
+		var err error
+		resolveInterval, err = time.ParseDuration(c.Agent.ServerResolveInterval)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse server resolve interval: %v", err)
+		}
+		if resolveInterval <= 0 {
+			return nil, errors.New("server resolve interval must be positive")
+		}
+	}
+
+	serverGRPC, err := parseGRPCConfig(c.Agent.GRPC)
+	if err != nil {
+		return nil, err
+	}
+	ac.ServerGRPC = serverGRPC
+
+	ac.ServerBackoff, ac.AttestationRetryInterval, err = parseServerBackoffConfig(c.Agent.ServerBackoff)
+	if err != nil {
+		return nil, err
+	}
+
+	ac.ServerProxy = client.NewProxyFunc(c.Agent.ServerProxyURL)
+	proxied, err := isAnyServerProxied(ac.ServerProxy, serverAddresses)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy configuration: %v", err)
+	}
+
+	switch {
+	case proxied:
+		// Server names are resolved by the proxy
+		ac.ServerAddress = client.UnresolvedServersTarget(serverAddresses)
+	case len(c.Agent.ServerAddresses) > 0 || resolveInterval > 0:
+		ac.ServerAddress = client.ServersTarget(serverAddresses, resolveInterval)
+	default:
+		ac.ServerAddress = fmt.Sprintf("dns:///%s", serverAddresses[0])
+	}
 
 	td, err := idutil.ParseSpiffeID("spiffe://"+c.Agent.TrustDomain, idutil.AllowAnyTrustDomain())
 	if err != nil {
@@ -349,16 +626,70 @@ func NewAgentConfig(c *Config, logOptions []log.Option) (*agent.Config, error) {
 		Name: c.Agent.SocketPath,
 		Net:  "unix",
 	}
+	for _, s := range c.Agent.WorkloadAPISockets {
+		uds, err := parseWorkloadAPISocket(s)
+		if err != nil {
+			return nil, err
+		}
+		ac.AdditionalBindAddresses = append(ac.AdditionalBindAddresses, uds)
+	}
+	if c.Agent.Experimental.TCPSocket != "" {
+		addr, err := net.ResolveTCPAddr("tcp", c.Agent.Experimental.TCPSocket)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse tcp_socket: %v", err)
+		}
+		if !addr.IP.IsLoopback() {
+			return nil, fmt.Errorf("tcp_socket %q must be a loopback address", c.Agent.Experimental.TCPSocket)
+		}
+		ac.TCPBindAddress = addr
+	}
+	rl := c.Agent.WorkloadAPIRateLimit
+	if rl.FetchX509SVID < 0 || rl.FetchJWTSVID < 0 || rl.ValidateJWTSVID < 0 {
+		return nil, errors.New("workload_api_rate_limit values must not be negative")
+	}
+	ac.WorkloadAPIRateLimits = workload.RateLimits{
+		FetchX509SVID:   rl.FetchX509SVID,
+		FetchJWTSVID:    rl.FetchJWTSVID,
+		ValidateJWTSVID: rl.ValidateJWTSVID,
+		PerUID:          rl.PerUID,
+	}
+
+	ac.AllowUnauthenticatedVerifiers = c.Agent.AllowUnauthenticatedVerifiers
+
+	ac.NamedPipeName = c.Agent.Experimental.NamedPipeName
+	ac.PIDFDPeerTracking = c.Agent.Experimental.PIDFDPeerTracking
+	ac.CachePersistence = c.Agent.Experimental.CachePersistence
+	if c.Agent.Experimental.JWTSVIDCacheMaxSize < 0 {
+		return nil, errors.New("jwt_svid_cache_max_size must not be negative")
+	}
+	ac.JWTSVIDCacheMaxSize = c.Agent.Experimental.JWTSVIDCacheMaxSize
+
+	if c.Agent.SVIDRenewalThreshold != 0 && !rotationutil.ValidRenewalThreshold(c.Agent.SVIDRenewalThreshold) {
+		return nil, errors.New("svid_renewal_threshold must be greater than 0 and less than 1")
+	}
+	ac.SVIDRenewalThreshold = c.Agent.SVIDRenewalThreshold
+
+	ac.RetainSVIDsDuringOutage = c.Agent.Experimental.RetainSVIDsDuringOutage
+	ac.HardenKeyMemory = c.Agent.Experimental.HardenKeyMemory
+	if ac.HardenKeyMemory && ac.CachePersistence {
+		return nil, errors.New("harden_key_memory cannot be used with cache_persistence, since the persisted cache holds workload private keys")
+	}
 
 	ac.JoinToken = c.Agent.JoinToken
+	ac.EnableRegistrationAPIProxy = c.Agent.EnableRegistrationAPIProxy
+	ac.WorkloadAPIReflectionEnabled = c.Agent.WorkloadAPIReflectionEnabled
 	ac.DataDir = c.Agent.DataDir
 	ac.DefaultSVIDName = c.Agent.SDS.DefaultSVIDName
 	ac.DefaultBundleName = c.Agent.SDS.DefaultBundleName
+	ac.SDSSVIDNames = c.Agent.SDS.SVIDNames
+	ac.SDSBundleNames = c.Agent.SDS.BundleNames
 
 	logOptions = append(logOptions,
 		log.WithLevel(c.Agent.LogLevel),
 		log.WithFormat(c.Agent.LogFormat),
-		log.WithOutputFile(c.Agent.LogFile))
+		log.WithRotatingOutputFile(c.Agent.LogFile, c.Agent.LogRotation),
+		log.WithSyslog(c.Agent.LogSyslog),
+		log.WithFields(c.Agent.LogFields))
 
 	logger, err := log.NewLogger(logOptions...)
 	if err != nil {
@@ -373,6 +704,7 @@ func NewAgentConfig(c *Config, logOptions []log.Option) (*agent.Config, error) {
 
 	ac.ProfilingEnabled = c.Agent.ProfilingEnabled
 	ac.ProfilingPort = c.Agent.ProfilingPort
+	ac.ProfilingSocketPath = c.Agent.ProfilingSocketPath
 	ac.ProfilingFreq = c.Agent.ProfilingFreq
 	ac.ProfilingNames = c.Agent.ProfilingNames
 
@@ -385,6 +717,10 @@ func NewAgentConfig(c *Config, logOptions []log.Option) (*agent.Config, error) {
 		ac.Log.Warn("SDS support is now always on. The enable_sds configurable is ignored and should be removed.")
 	}
 
+	if c.Agent.ProfilingPort > 0 {
+		ac.Log.Warn("The `profiling_port` configurable is deprecated as the profiling endpoints it serves can be reached by any local user. Please use `profiling_socket_path` instead.")
+	}
+
 	// Warn if we detect unknown config options. We need a logger to do this. In
 	// the future, we can move from warning to bailing out (once folks have had
 	// ample time to detect any pre-existing errors)
@@ -400,31 +736,72 @@ func validateConfig(c *Config) error {
 		return errors.New("agent section must be configured")
 	}
 
-	if c.Agent.ServerAddress == "" {
-		return errors.New("server_address must be configured")
+	if c.Agent.ProfilingPort > 0 && c.Agent.ProfilingSocketPath != "" {
+		return errors.New("profiling_port and profiling_socket_path cannot both be configured")
+	}
+
+	if len(c.Agent.ServerAddresses) > 0 {
+		if c.Agent.ServerAddress != "" || c.Agent.ServerPort != 0 {
+			return errors.New("server_addresses cannot be combined with server_address or server_port")
+		}
+		for _, addr := range c.Agent.ServerAddresses {
+			if err := validateServerAddress(addr); err != nil {
+				return fmt.Errorf("invalid server_addresses entry %q: %v", addr, err)
+			}
+		}
+	} else {
+		if c.Agent.ServerAddress == "" {
+			return errors.New("server_address or server_addresses must be configured")
+		}
+
+		if c.Agent.ServerPort == 0 {
+			return errors.New("server_port must be configured")
+		}
 	}
 
-	if c.Agent.ServerPort == 0 {
-		return errors.New("server_port must be configured")
+	if c.Agent.ServerProxyURL != "" {
+		u, err := url.Parse(c.Agent.ServerProxyURL)
+		if err != nil {
+			return fmt.Errorf("unable to parse server proxy URL: %v", err)
+		}
+		if u.Scheme != "http" || u.Host == "" {
+			return errors.New("server proxy URL must start with http://")
+		}
 	}
 
 	if c.Agent.TrustDomain == "" {
 		return errors.New("trust_domain must be configured")
 	}
 
+	if c.Agent.SocketPath != "" && c.Agent.Experimental.NamedPipeName == "" {
+		if err := util.ValidateUDSPath(c.Agent.SocketPath); err != nil {
+			return fmt.Errorf("invalid socket_path: %v", err)
+		}
+	}
+
 	// If trust_bundle_url is set, download the trust bundle using HTTP and parse it from memory
 	// If trust_bundle_path is set, parse the trust bundle file on disk
 	// Both cannot be set
 	// The trust bundle URL must start with HTTPS
+	// Either can hold a PEM bundle or a SPIFFE bundle document
 
-	if c.Agent.TrustBundlePath == "" && c.Agent.TrustBundleURL == "" && !c.Agent.InsecureBootstrap {
-		return errors.New("trust_bundle_path or trust_bundle_url must be configured unless insecure_bootstrap is set")
+	if c.Agent.TrustBundlePath == "" && c.Agent.TrustBundleURL == "" && c.Agent.TrustBundleSource == nil && !c.Agent.InsecureBootstrap {
+		return errors.New("trust_bundle_path, trust_bundle_url or trust_bundle_source must be configured unless insecure_bootstrap is set")
 	}
 
 	if c.Agent.TrustBundleURL != "" && c.Agent.TrustBundlePath != "" {
 		return errors.New("only one of trust_bundle_url or trust_bundle_path can be specified, not both")
 	}
 
+	if c.Agent.TrustBundleSource != nil {
+		if c.Agent.TrustBundleURL != "" || c.Agent.TrustBundlePath != "" {
+			return errors.New("trust_bundle_source cannot be combined with trust_bundle_url or trust_bundle_path")
+		}
+		if err := bundlesource.Validate(c.Agent.TrustBundleSource.config()); err != nil {
+			return fmt.Errorf("invalid trust_bundle_source: %v", err)
+		}
+	}
+
 	if c.Agent.TrustBundleURL != "" {
 		u, err := url.Parse(c.Agent.TrustBundleURL)
 		if err != nil {
@@ -434,6 +811,26 @@ func validateConfig(c *Config) error {
 			return errors.New("trust bundle URL must start with https://")
 		}
 	}
+
+	switch c.Agent.TrustBundleFormat {
+	case "", bundleFormatPEM, bundleFormatSPIFFE:
+	default:
+		return fmt.Errorf("invalid trust_bundle_format %q: must be %q or %q", c.Agent.TrustBundleFormat, bundleFormatPEM, bundleFormatSPIFFE)
+	}
+
+	// The trust bundle URL can be authenticated with SPIFFE authentication,
+	// which requires both the endpoint SPIFFE ID and a bundle to verify it
+	if c.Agent.TrustBundleURLSPIFFEID != "" || c.Agent.TrustBundleURLBundlePath != "" {
+		if c.Agent.TrustBundleURL == "" {
+			return errors.New("trust_bundle_url_spiffe_id and trust_bundle_url_bundle_path require trust_bundle_url")
+		}
+		if c.Agent.TrustBundleURLSPIFFEID == "" || c.Agent.TrustBundleURLBundlePath == "" {
+			return errors.New("trust_bundle_url_spiffe_id and trust_bundle_url_bundle_path must be configured together")
+		}
+		if err := idutil.ValidateSpiffeID(c.Agent.TrustBundleURLSPIFFEID, idutil.AllowAny()); err != nil {
+			return fmt.Errorf("invalid trust_bundle_url_spiffe_id: %v", err)
+		}
+	}
 	if c.Plugins == nil {
 		return errors.New("plugins section must be configured")
 	}
@@ -441,51 +838,234 @@ func validateConfig(c *Config) error {
 	return nil
 }
 
+func parseGRPCConfig(c grpcConfig) (client.GRPCConfig, error) {
+	gc := client.GRPCConfig{
+		KeepalivePermitWithoutStream: c.KeepalivePermitWithoutStream,
+	}
+	var err error
+	if gc.DialTimeout, err = parseGRPCDuration("dial_timeout", c.DialTimeout); err != nil {
+		return client.GRPCConfig{}, err
+	}
+	if gc.KeepaliveTime, err = parseGRPCDuration("keepalive_time", c.KeepaliveTime); err != nil {
+		return client.GRPCConfig{}, err
+	}
+	if gc.KeepaliveTimeout, err = parseGRPCDuration("keepalive_timeout", c.KeepaliveTimeout); err != nil {
+		return client.GRPCConfig{}, err
+	}
+	if c.MaxRecvMsgSize < 0 || c.MaxSendMsgSize < 0 {
+		return client.GRPCConfig{}, errors.New("grpc message sizes cannot be negative")
+	}
+	gc.MaxRecvMsgSize = c.MaxRecvMsgSize
+	gc.MaxSendMsgSize = c.MaxSendMsgSize
+	return gc, nil
+}
+
+func parseGRPCDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse grpc %s: %v", name, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("grpc %s must be positive", name)
+	}
+	return d, nil
+}
+
+func parseServerBackoffConfig(c serverBackoffConfig) (backoff.Config, time.Duration, error) {
+	var bc backoff.Config
+	retryInterval, err := parseServerBackoffDuration("attestation_retry_interval", c.AttestationRetryInterval)
+	if err != nil {
+		return backoff.Config{}, 0, err
+	}
+	if bc.MaxInterval, err = parseServerBackoffDuration("max_interval", c.MaxInterval); err != nil {
+		return backoff.Config{}, 0, err
+	}
+	if c.Multiplier != 0 && c.Multiplier < 1 {
+		return backoff.Config{}, 0, errors.New("server_backoff multiplier must be greater than or equal to 1")
+	}
+	if c.Jitter < 0 || c.Jitter >= 1 {
+		return backoff.Config{}, 0, errors.New("server_backoff jitter must be greater than or equal to 0 and less than 1")
+	}
+	bc.Multiplier = c.Multiplier
+	bc.Jitter = c.Jitter
+	return bc, retryInterval, nil
+}
+
+func parseServerBackoffDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse server_backoff %s: %v", name, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("server_backoff %s must be positive", name)
+	}
+	return d, nil
+}
+
+func isAnyServerProxied(proxy client.ProxyFunc, serverAddresses []string) (bool, error) {
+	for _, addr := range serverAddresses {
+		proxyURL, err := proxy(addr)
+		if err != nil {
+			return false, err
+		}
+		if proxyURL != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func validateServerAddress(addr string) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	if host == "" || strings.Contains(host, ",") {
+		return errors.New("invalid host")
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return errors.New("invalid port")
+	}
+	return nil
+}
+
+func parseWorkloadAPISocket(c workloadAPISocketConfig) (endpoints.UDSConfig, error) {
+	if c.Path == "" {
+		return endpoints.UDSConfig{}, errors.New("workload_api_sockets path must be configured")
+	}
+	if err := util.ValidateUDSPath(c.Path); err != nil {
+		return endpoints.UDSConfig{}, fmt.Errorf("invalid workload_api_sockets path: %v", err)
+	}
+	if util.IsAbstractUDS(c.Path) && (c.OwnerUID != nil || c.OwnerGID != nil || c.Mode != "") {
+		return endpoints.UDSConfig{}, fmt.Errorf("owner_uid, owner_gid and mode cannot be set for abstract workload API socket %q", c.Path)
+	}
+
+	uds := endpoints.UDSConfig{
+		Addr: &net.UnixAddr{
+			Name: c.Path,
+			Net:  "unix",
+		},
+		UID:  -1,
+		GID:  -1,
+		Mode: os.ModePerm,
+	}
+	if c.OwnerUID != nil {
+		uds.UID = *c.OwnerUID
+	}
+	if c.OwnerGID != nil {
+		uds.GID = *c.OwnerGID
+	}
+	if c.Mode != "" {
+		mode, err := strconv.ParseUint(c.Mode, 8, 32)
+		if err != nil || mode > uint64(os.ModePerm) {
+			return endpoints.UDSConfig{}, fmt.Errorf("invalid mode %q for workload API socket %q", c.Mode, c.Path)
+		}
+		uds.Mode = os.FileMode(mode)
+	}
+	return uds, nil
+}
+
+// unknownConfigWarning formats the warnings logged for unknown config options.
+const unknownConfigWarning = "%s; this will be fatal in a future release."
+
 func warnOnUnknownConfig(c *Config, l logrus.FieldLogger) {
+	for _, unknown := range detectUnknownConfig(c) {
+		l.Warnf(unknownConfigWarning, unknown)
+	}
+}
+
+// detectUnknownConfig returns a message for each configuration section
+// holding unknown options.
+func detectUnknownConfig(c *Config) []string {
+	var unknowns []string
+	detected := func(format string, args ...interface{}) {
+		unknowns = append(unknowns, fmt.Sprintf(format, args...))
+	}
+
 	if len(c.UnusedKeys) != 0 {
-		l.Warnf("Detected unknown top-level config options: %q; this will be fatal in a future release.", c.UnusedKeys)
+		detected("Detected unknown top-level config options: %q", c.UnusedKeys)
 	}
 
 	if a := c.Agent; a != nil && len(a.UnusedKeys) != 0 {
-		l.Warnf("Detected unknown agent config options: %q; this will be fatal in a future release.", a.UnusedKeys)
+		detected("Detected unknown agent config options: %q", a.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && a.TrustBundleSource != nil && len(a.TrustBundleSource.UnusedKeys) != 0 {
+		detected("Detected unknown trust_bundle_source config options: %q", a.TrustBundleSource.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && len(a.GRPC.UnusedKeys) != 0 {
+		detected("Detected unknown grpc config options: %q", a.GRPC.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && len(a.ServerBackoff.UnusedKeys) != 0 {
+		detected("Detected unknown server_backoff config options: %q", a.ServerBackoff.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && len(a.WorkloadAPIRateLimit.UnusedKeys) != 0 {
+		detected("Detected unknown workload_api_rate_limit config options: %q", a.WorkloadAPIRateLimit.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && a.LogRotation != nil && len(a.LogRotation.UnusedKeys) != 0 {
+		detected("Detected unknown log_rotation config options: %q", a.LogRotation.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil && a.LogSyslog != nil && len(a.LogSyslog.UnusedKeys) != 0 {
+		detected("Detected unknown log_syslog config options: %q", a.LogSyslog.UnusedKeys)
+	}
+
+	if a := c.Agent; a != nil {
+		for _, s := range a.WorkloadAPISockets {
+			if len(s.UnusedKeys) != 0 {
+				detected("Detected unknown workload_api_sockets config options: %q", s.UnusedKeys)
+			}
+		}
 	}
 
 	// TODO: Re-enable unused key detection for telemetry. See
 	// https://github.com/spiffe/spire/issues/1101 for more information
 	//
 	//if len(c.Telemetry.UnusedKeys) != 0 {
-	//	l.Warnf("Detected unknown telemetry config options: %q; this will be fatal in a future release.", c.Telemetry.UnusedKeys)
+	//	detected("Detected unknown telemetry config options: %q", c.Telemetry.UnusedKeys)
 	//}
 
 	if p := c.Telemetry.Prometheus; p != nil && len(p.UnusedKeys) != 0 {
-		l.Warnf("Detected unknown Prometheus config options: %q; this will be fatal in a future release.", p.UnusedKeys)
+		detected("Detected unknown Prometheus config options: %q", p.UnusedKeys)
 	}
 
 	for _, v := range c.Telemetry.DogStatsd {
 		if len(v.UnusedKeys) != 0 {
-			l.Warnf("Detected unknown DogStatsd config options: %q; this will be fatal in a future release.", v.UnusedKeys)
+			detected("Detected unknown DogStatsd config options: %q", v.UnusedKeys)
 		}
 	}
 
 	for _, v := range c.Telemetry.Statsd {
 		if len(v.UnusedKeys) != 0 {
-			l.Warnf("Detected unknown Statsd config options: %q; this will be fatal in a future release.", v.UnusedKeys)
+			detected("Detected unknown Statsd config options: %q", v.UnusedKeys)
 		}
 	}
 
 	for _, v := range c.Telemetry.M3 {
 		if len(v.UnusedKeys) != 0 {
-			l.Warnf("Detected unknown M3 config options: %q; this will be fatal in a future release.", v.UnusedKeys)
+			detected("Detected unknown M3 config options: %q", v.UnusedKeys)
 		}
 	}
 
 	if p := c.Telemetry.InMem; p != nil && len(p.UnusedKeys) != 0 {
-		l.Warnf("Detected unknown InMem config options: %q; this will be fatal in a future release.", p.UnusedKeys)
+		detected("Detected unknown InMem config options: %q", p.UnusedKeys)
 	}
 
 	if len(c.HealthChecks.UnusedKeys) != 0 {
-		l.Warnf("Detected unknown health check config options: %q; this will be fatal in a future release.", c.HealthChecks.UnusedKeys)
+		detected("Detected unknown health check config options: %q", c.HealthChecks.UnusedKeys)
 	}
+
+	return unknowns
 }
 
 func defaultConfig() *Config {
@@ -503,12 +1083,45 @@ func defaultConfig() *Config {
 	}
 }
 
-func parseTrustBundle(path string) ([]*x509.Certificate, error) {
-	bundle, err := pemutil.LoadCertificates(path)
+func (c *trustBundleSourceConfig) config() bundlesource.Config {
+	return bundlesource.Config{
+		Type:     c.Type,
+		Name:     c.Name,
+		Region:   c.Region,
+		Endpoint: c.Endpoint,
+	}
+}
+
+func parseTrustBundle(path, bundleFormat, trustDomain string) ([]*x509.Certificate, error) {
+	bundleBytes, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
+	return parseTrustBundleBytes(bundleBytes, bundleFormat, trustDomain)
+}
+
+// parseTrustBundleBytes parses the root CAs out of a PEM encoded bundle or a
+// SPIFFE bundle document for the given trust domain.
+func parseTrustBundleBytes(bundleBytes []byte, bundleFormat, trustDomain string) ([]*x509.Certificate, error) {
+	var bundle []*x509.Certificate
+	switch bundleFormat {
+	case "", bundleFormatPEM:
+		certs, err := pemutil.ParseCertificates(bundleBytes)
+		if err != nil {
+			return nil, err
+		}
+		bundle = certs
+	case bundleFormatSPIFFE:
+		spiffeBundle, err := bundleutil.Unmarshal(idutil.TrustDomainID(trustDomain), bundleBytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse SPIFFE bundle: %v", err)
+		}
+		bundle = spiffeBundle.RootCAs()
+	default:
+		return nil, fmt.Errorf("unsupported trust bundle format %q", bundleFormat)
+	}
+
 	if len(bundle) == 0 {
 		return nil, errors.New("no certificates found in trust bundle")
 	}
@@ -2,21 +2,39 @@ package run
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/hcl/hcl/printer"
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/agent"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/common/backoff"
+	"github.com/spiffe/spire/pkg/agent/endpoints"
+	"github.com/spiffe/spire/pkg/agent/endpoints/workload"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/test/spiretest"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,9 +42,11 @@ import (
 
 func TestDownloadTrustBundle(t *testing.T) {
 	testTB, _ := ioutil.ReadFile(path.Join(util.ProjectRoot(), "conf/agent/dummy_root_ca.crt"))
+	testSPIFFEBundle := marshalTestSPIFFEBundle(t, "example.org")
 	cases := []struct {
 		msg          string
 		status       int
+		format       string
 		fileContents string
 		expectError  bool
 	}{
@@ -60,6 +80,34 @@ func TestDownloadTrustBundle(t *testing.T) {
 			fileContents: string(testTB),
 			expectError:  false,
 		},
+		{
+			msg:          "if file is a valid PEM bundle and the format is pem, should not be an error",
+			status:       http.StatusOK,
+			format:       bundleFormatPEM,
+			fileContents: string(testTB),
+			expectError:  false,
+		},
+		{
+			msg:          "if file is a valid SPIFFE bundle and the format is spiffe, should not be an error",
+			status:       http.StatusOK,
+			format:       bundleFormatSPIFFE,
+			fileContents: string(testSPIFFEBundle),
+			expectError:  false,
+		},
+		{
+			msg:          "if file is a PEM bundle and the format is spiffe, should be an error",
+			status:       http.StatusOK,
+			format:       bundleFormatSPIFFE,
+			fileContents: string(testTB),
+			expectError:  true,
+		},
+		{
+			msg:          "if the SPIFFE bundle has no X.509 authorities, should be an error",
+			status:       http.StatusOK,
+			format:       bundleFormatSPIFFE,
+			fileContents: `{"keys": []}`,
+			expectError:  true,
+		},
 	}
 
 	for _, testCase := range cases {
@@ -75,7 +123,7 @@ func TestDownloadTrustBundle(t *testing.T) {
 					//}
 				}))
 			defer testServer.Close()
-			_, err := downloadTrustBundle(testServer.URL)
+			_, err := downloadTrustBundle(testServer.URL, testCase.format, "example.org", http.DefaultClient)
 			if testCase.expectError {
 				require.Error(t, err)
 			} else {
@@ -85,6 +133,140 @@ func TestDownloadTrustBundle(t *testing.T) {
 	}
 }
 
+func TestDownloadTrustBundleWithSPIFFEAuth(t *testing.T) {
+	endpointID := "spiffe://example.org/bundle-endpoint"
+	serverCert, serverKey := spiretest.SelfSignCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(0),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: "/bundle-endpoint"}},
+	})
+
+	testServer := httptest.NewUnstartedServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(marshalTestSPIFFEBundle(t, "example.org"))
+		}))
+	testServer.TLS = &tls.Config{
+		Certificates: []tls.Certificate{
+			{
+				Certificate: [][]byte{serverCert.Raw},
+				PrivateKey:  serverKey,
+			},
+		},
+	}
+	testServer.StartTLS()
+	defer testServer.Close()
+
+	dir, err := ioutil.TempDir("", "spire-agent-run-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	bootstrapBundlePath := path.Join(dir, "bootstrap.pem")
+	require.NoError(t, ioutil.WriteFile(bootstrapBundlePath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: serverCert.Raw}), 0600))
+
+	cases := []struct {
+		msg         string
+		spiffeID    string
+		bundlePath  string
+		expectError string
+	}{
+		{
+			msg:        "endpoint with the expected SPIFFE ID is trusted",
+			spiffeID:   endpointID,
+			bundlePath: bootstrapBundlePath,
+		},
+		{
+			msg:         "endpoint with an unexpected SPIFFE ID is not trusted",
+			spiffeID:    "spiffe://example.org/other",
+			bundlePath:  bootstrapBundlePath,
+			expectError: "SPIFFE ID mismatch",
+		},
+		{
+			msg:         "endpoint is not authenticated via Web PKI",
+			expectError: "certificate signed by unknown authority",
+		},
+	}
+
+	for _, testCase := range cases {
+		testCase := testCase
+
+		t.Run(testCase.msg, func(t *testing.T) {
+			client, err := newTrustBundleHTTPClient(&agentConfig{
+				TrustBundleURLSPIFFEID:   testCase.spiffeID,
+				TrustBundleURLBundlePath: testCase.bundlePath,
+			})
+			require.NoError(t, err)
+
+			bundle, err := downloadTrustBundle(testServer.URL, bundleFormatSPIFFE, "example.org", client)
+			if testCase.expectError != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), testCase.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, bundle, 1)
+		})
+	}
+}
+
+func TestTrustBundleSource(t *testing.T) {
+	testTB, err := ioutil.ReadFile(path.Join(util.ProjectRoot(), "conf/agent/dummy_root_ca.crt"))
+	require.NoError(t, err)
+
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/computeMetadata/v1/instance/attributes/spire-bundle" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(testTB)
+		}))
+	defer testServer.Close()
+
+	dir, err := ioutil.TempDir("", "spire-agent-run-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	configPath := path.Join(dir, "agent.conf")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(fmt.Sprintf(`
+agent {
+	trust_bundle_source {
+		type = "gcp_metadata"
+		name = "spire-bundle"
+		endpoint = %q
+	}
+}
+`, testServer.URL)), 0600))
+
+	c, err := ParseFile(configPath, false)
+	require.NoError(t, err)
+	require.Equal(t, &trustBundleSourceConfig{
+		Type:     "gcp_metadata",
+		Name:     "spire-bundle",
+		Endpoint: testServer.URL,
+	}, c.Agent.TrustBundleSource)
+
+	input := defaultValidConfig()
+	input.Agent.TrustBundlePath = ""
+	input.Agent.TrustBundleSource = c.Agent.TrustBundleSource
+	ac, err := NewAgentConfig(input, []log.Option{})
+	require.NoError(t, err)
+	require.Len(t, ac.TrustBundle, 1)
+
+	input.Agent.TrustBundleSource.Name = "missing"
+	_, err = NewAgentConfig(input, []log.Option{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to read trust bundle from gcp_metadata")
+}
+
+func marshalTestSPIFFEBundle(t *testing.T, trustDomain string) []byte {
+	rootCAs, err := pemutil.LoadCertificates(path.Join(util.ProjectRoot(), "conf/agent/dummy_root_ca.crt"))
+	require.NoError(t, err)
+
+	data, err := bundleutil.Marshal(bundleutil.BundleFromRootCAs(idutil.TrustDomainID(trustDomain), rootCAs))
+	require.NoError(t, err)
+	return data
+}
+
 func TestParseConfigGood(t *testing.T) {
 	c, err := ParseFile("../../../../test/fixture/config/agent_good.conf", false)
 	require.NoError(t, err)
@@ -96,6 +278,13 @@ func TestParseConfigGood(t *testing.T) {
 	assert.Equal(t, c.Agent.TrustBundlePath, "conf/agent/dummy_root_ca.crt")
 	assert.Equal(t, c.Agent.TrustDomain, "example.org")
 
+	// Check for additional workload API sockets
+	uid, gid := 1000, 1000
+	assert.Equal(t, []workloadAPISocketConfig{
+		{Path: "/tmp/tenant-a/agent.sock", OwnerUID: &uid, OwnerGID: &gid, Mode: "0660"},
+		{Path: "/tmp/tenant-b/agent.sock"},
+	}, c.Agent.WorkloadAPISockets)
+
 	// Check for plugins configurations
 	pluginConfigs := *c.Plugins
 	expectedData := "join_token = \"PLUGIN-AGENT-NOT-A-SECRET\""
@@ -255,6 +444,20 @@ func TestMergeInput(t *testing.T) {
 				require.Equal(t, "foo", c.Agent.SDS.DefaultBundleName)
 			},
 		},
+		{
+			msg: "sds resource names should be configurable by file",
+			fileInput: func(c *Config) {
+				c.Agent.SDS = sdsConfig{
+					SVIDNames:   map[string]string{"spiffe://example.org/web": "web_cert"},
+					BundleNames: map[string]string{"spiffe://example.org": "web_ca"},
+				}
+			},
+			cliInput: func(c *agentConfig) {},
+			test: func(t *testing.T, c *Config) {
+				require.Equal(t, map[string]string{"spiffe://example.org/web": "web_cert"}, c.Agent.SDS.SVIDNames)
+				require.Equal(t, map[string]string{"spiffe://example.org": "web_ca"}, c.Agent.SDS.BundleNames)
+			},
+		},
 		{
 			msg: "insecure_bootstrap should be configurable by file",
 			fileInput: func(c *Config) {
@@ -624,6 +827,41 @@ func TestMergeInput(t *testing.T) {
 	}
 }
 
+func TestMergeInputFromEnv(t *testing.T) {
+	env := map[string]string{
+		"SPIRE_AGENT_LOG_LEVEL":                  "DEBUG",
+		"SPIRE_AGENT_TRUST_DOMAIN":               "env.example.org",
+		"SPIRE_AGENT_LOG_ROTATION_MAX_SIZE_MB":   "10",
+		"SPIRE_AGENT_TELEMETRY_ALLOWED_PREFIXES": "a,b",
+	}
+	for name, value := range env {
+		require.NoError(t, os.Setenv(name, value))
+		defer os.Unsetenv(name)
+	}
+
+	fileInput := &Config{Agent: &agentConfig{
+		LogLevel:    "WARN",
+		TrustDomain: "file.example.org",
+	}}
+	cliInput := &agentConfig{
+		TrustDomain: "cli.example.org",
+	}
+
+	c, err := mergeInput(fileInput, cliInput)
+	require.NoError(t, err)
+
+	// The environment overrides the file, and CLI flags override the environment
+	assert.Equal(t, "DEBUG", c.Agent.LogLevel)
+	assert.Equal(t, "cli.example.org", c.Agent.TrustDomain)
+	require.NotNil(t, c.Agent.LogRotation)
+	assert.Equal(t, 10, c.Agent.LogRotation.MaxSizeMB)
+	assert.Equal(t, []string{"a", "b"}, c.Telemetry.AllowedPrefixes)
+
+	require.NoError(t, os.Setenv("SPIRE_AGENT_LOG_ROTATION_MAX_SIZE_MB", "many"))
+	_, err = mergeInput(&Config{Agent: &agentConfig{}}, &agentConfig{})
+	require.EqualError(t, err, `invalid value for SPIRE_AGENT_LOG_ROTATION_MAX_SIZE_MB: strconv.ParseInt: parsing "many": invalid syntax`)
+}
+
 func TestNewAgentConfig(t *testing.T) {
 	cases := []struct {
 		msg         string
@@ -641,6 +879,82 @@ func TestNewAgentConfig(t *testing.T) {
 				require.Equal(t, "dns:///192.168.1.1:1337", c.ServerAddress)
 			},
 		},
+		{
+			msg: "server_addresses should be correctly parsed",
+			input: func(c *Config) {
+				c.Agent.ServerAddress = ""
+				c.Agent.ServerPort = 0
+				c.Agent.ServerAddresses = []string{"192.168.1.1:1337", "spire-server:8081"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, "spire-servers:///192.168.1.1:1337,spire-server:8081", c.ServerAddress)
+			},
+		},
+		{
+			msg: "server_proxy_url should be correctly parsed",
+			input: func(c *Config) {
+				c.Agent.ServerAddress = "spire-server"
+				c.Agent.ServerProxyURL = "http://proxy:3128"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				// Server names are resolved by the proxy
+				require.Equal(t, "spire-servers:///spire-server:1337?resolve=false", c.ServerAddress)
+				proxyURL, err := c.ServerProxy("spire-server:1337")
+				require.NoError(t, err)
+				require.Equal(t, "http://proxy:3128", proxyURL.String())
+			},
+		},
+		{
+			msg:         "server_proxy_url without http scheme returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.ServerProxyURL = "socks5://proxy:1080"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "server_resolve_interval should be correctly parsed",
+			input: func(c *Config) {
+				c.Agent.ServerResolveInterval = "1m"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, "spire-servers:///192.168.1.1:1337?resolve_interval=1m0s", c.ServerAddress)
+			},
+		},
+		{
+			msg:         "invalid server_resolve_interval returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.ServerResolveInterval = "-1m"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "server_addresses combined with server_address returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.ServerAddresses = []string{"192.168.1.1:1337"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "server_addresses without a port returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.ServerAddress = ""
+				c.Agent.ServerPort = 0
+				c.Agent.ServerAddresses = []string{"192.168.1.1"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "trust_domain should be correctly parsed",
 			input: func(c *Config) {
@@ -688,6 +1002,34 @@ func TestNewAgentConfig(t *testing.T) {
 				require.True(t, c.InsecureBootstrap)
 			},
 		},
+		{
+			msg: "reenable_insecure_bootstrap should be correctly set",
+			input: func(c *Config) {
+				c.Agent.InsecureBootstrap = true
+				c.Agent.ReenableInsecureBootstrap = true
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.ReenableInsecureBootstrap)
+			},
+		},
+		{
+			msg: "enable_registration_api_proxy should be correctly set",
+			input: func(c *Config) {
+				c.Agent.EnableRegistrationAPIProxy = true
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.EnableRegistrationAPIProxy)
+			},
+		},
+		{
+			msg: "workload_api_reflection_enabled should be correctly set",
+			input: func(c *Config) {
+				c.Agent.WorkloadAPIReflectionEnabled = true
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.WorkloadAPIReflectionEnabled)
+			},
+		},
 		{
 			msg: "join_token should be correctly configured",
 			input: func(c *Config) {
@@ -765,6 +1107,276 @@ func TestNewAgentConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg: "trust_bundle_format should be correctly parsed",
+			input: func(c *Config) {
+				c.Agent.TrustBundlePath = path.Join(util.ProjectRoot(), "test/fixture/config/spiffe_bundle.json")
+				c.Agent.TrustBundleFormat = "spiffe"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Len(t, c.TrustBundle, 1)
+			},
+		},
+		{
+			msg:         "invalid trust_bundle_format returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.TrustBundleFormat = "der"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "trust_bundle_url_spiffe_id requires trust_bundle_url",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.TrustBundleURLSPIFFEID = "spiffe://example.org/bundle-endpoint"
+				c.Agent.TrustBundleURLBundlePath = "bootstrap.pem"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "trust_bundle_url_spiffe_id requires trust_bundle_url_bundle_path",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.TrustBundlePath = ""
+				c.Agent.TrustBundleURL = "https://example.org/bundle"
+				c.Agent.TrustBundleURLSPIFFEID = "spiffe://example.org/bundle-endpoint"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "invalid trust_bundle_url_spiffe_id returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.TrustBundlePath = ""
+				c.Agent.TrustBundleURL = "https://example.org/bundle"
+				c.Agent.TrustBundleURLSPIFFEID = "https://example.org/bundle-endpoint"
+				c.Agent.TrustBundleURLBundlePath = "bootstrap.pem"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "trust_bundle_source and trust_bundle_path cannot both be set",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.TrustBundleSource = &trustBundleSourceConfig{Type: "azure_imds"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "invalid trust_bundle_source returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.TrustBundlePath = ""
+				c.Agent.TrustBundleSource = &trustBundleSourceConfig{Type: "aws_ssm"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "drain_grace_period should be correctly parsed",
+			input: func(c *Config) {
+				c.Agent.Experimental.DrainGracePeriod = "2m"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, 2*time.Minute, c.DrainGracePeriod)
+			},
+		},
+		{
+			msg: "drain_grace_period is unset by default",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, time.Duration(0), c.DrainGracePeriod)
+			},
+		},
+		{
+			msg:         "invalid drain_grace_period returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.Experimental.DrainGracePeriod = "-1s"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "jwt_svid_cache_max_size should be correctly parsed",
+			input: func(c *Config) {
+				c.Agent.Experimental.JWTSVIDCacheMaxSize = 50
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, 50, c.JWTSVIDCacheMaxSize)
+			},
+		},
+		{
+			msg:         "negative jwt_svid_cache_max_size returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.Experimental.JWTSVIDCacheMaxSize = -1
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:   "svid_renewal_threshold should default to the manager default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Zero(t, c.SVIDRenewalThreshold)
+			},
+		},
+		{
+			msg: "svid_renewal_threshold should be correctly parsed",
+			input: func(c *Config) {
+				c.Agent.SVIDRenewalThreshold = 0.8
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, 0.8, c.SVIDRenewalThreshold)
+			},
+		},
+		{
+			msg:         "svid_renewal_threshold of 1 returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.SVIDRenewalThreshold = 1
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "negative svid_renewal_threshold returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.SVIDRenewalThreshold = -0.5
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "grpc should be correctly parsed",
+			input: func(c *Config) {
+				c.Agent.GRPC = grpcConfig{
+					DialTimeout:                  "1m",
+					KeepaliveTime:                "30s",
+					KeepaliveTimeout:             "10s",
+					KeepalivePermitWithoutStream: true,
+					MaxRecvMsgSize:               8 << 20,
+					MaxSendMsgSize:               16 << 20,
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, client.GRPCConfig{
+					DialTimeout:                  time.Minute,
+					KeepaliveTime:                30 * time.Second,
+					KeepaliveTimeout:             10 * time.Second,
+					KeepalivePermitWithoutStream: true,
+					MaxRecvMsgSize:               8 << 20,
+					MaxSendMsgSize:               16 << 20,
+				}, c.ServerGRPC)
+			},
+		},
+		{
+			msg:         "invalid grpc duration returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.GRPC.DialTimeout = "forever"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "negative grpc message size returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.GRPC.MaxSendMsgSize = -1
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "server_backoff should be correctly parsed",
+			input: func(c *Config) {
+				c.Agent.ServerBackoff = serverBackoffConfig{
+					AttestationRetryInterval: "2s",
+					MaxInterval:              "5m",
+					Multiplier:               2,
+					Jitter:                   0.2,
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, backoff.Config{
+					MaxInterval: 5 * time.Minute,
+					Multiplier:  2,
+					Jitter:      0.2,
+				}, c.ServerBackoff)
+				require.Equal(t, 2*time.Second, c.AttestationRetryInterval)
+			},
+		},
+		{
+			msg: "server_backoff defaults are left to the agent",
+			input: func(c *Config) {
+				c.Agent.ServerBackoff = serverBackoffConfig{}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, backoff.Config{}, c.ServerBackoff)
+				require.Zero(t, c.AttestationRetryInterval)
+			},
+		},
+		{
+			msg:         "invalid server_backoff duration returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.ServerBackoff.MaxInterval = "-1m"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "server_backoff multiplier less than 1 returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.ServerBackoff.Multiplier = 0.5
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "server_backoff jitter of 1 returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.ServerBackoff.Jitter = 1
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "allow_unauthenticated_verifiers is correctly set",
+			input: func(c *Config) {
+				c.Agent.AllowUnauthenticatedVerifiers = true
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.AllowUnauthenticatedVerifiers)
+			},
+		},
 		{
 			msg:         "invalid log_level returns an error",
 			expectError: true,
@@ -804,6 +1416,229 @@ func TestNewAgentConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg: "pidfd_peer_tracking is configured",
+			input: func(c *Config) {
+				c.Agent.Experimental.PIDFDPeerTracking = true
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.PIDFDPeerTracking)
+			},
+		},
+		{
+			msg: "workload_api_sockets is configured",
+			input: func(c *Config) {
+				uid, gid := 1000, 2000
+				c.Agent.WorkloadAPISockets = []workloadAPISocketConfig{
+					{Path: "/tmp/tenant-a/agent.sock", OwnerUID: &uid, OwnerGID: &gid, Mode: "0660"},
+					{Path: "/tmp/tenant-b/agent.sock"},
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, []endpoints.UDSConfig{
+					{
+						Addr: &net.UnixAddr{Name: "/tmp/tenant-a/agent.sock", Net: "unix"},
+						UID:  1000,
+						GID:  2000,
+						Mode: 0660,
+					},
+					{
+						Addr: &net.UnixAddr{Name: "/tmp/tenant-b/agent.sock", Net: "unix"},
+						UID:  -1,
+						GID:  -1,
+						Mode: os.ModePerm,
+					},
+				}, c.AdditionalBindAddresses)
+			},
+		},
+		{
+			msg:         "workload_api_sockets without path",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.WorkloadAPISockets = []workloadAPISocketConfig{{Mode: "0660"}}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "workload_api_sockets with invalid mode",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.WorkloadAPISockets = []workloadAPISocketConfig{{Path: "/tmp/agent.sock", Mode: "0999"}}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "workload_api_sockets with a path too long for a socket address",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.WorkloadAPISockets = []workloadAPISocketConfig{{Path: "/" + strings.Repeat("a", 200)}}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "workload_api_sockets with permissions on an abstract socket",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.WorkloadAPISockets = []workloadAPISocketConfig{{Path: "@spire-agent", Mode: "0660"}}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "socket_path too long for a socket address",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.SocketPath = "/var/lib/kubelet/pods/" + strings.Repeat("a", 100) + "/volumes/agent.sock"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "workload_api_rate_limit is configured",
+			input: func(c *Config) {
+				c.Agent.WorkloadAPIRateLimit = rateLimitConfig{
+					FetchX509SVID:   10,
+					FetchJWTSVID:    20,
+					ValidateJWTSVID: 30,
+					PerUID:          true,
+				}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, workload.RateLimits{
+					FetchX509SVID:   10,
+					FetchJWTSVID:    20,
+					ValidateJWTSVID: 30,
+					PerUID:          true,
+				}, c.WorkloadAPIRateLimits)
+			},
+		},
+		{
+			msg:         "workload_api_rate_limit with negative value",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.WorkloadAPIRateLimit.FetchJWTSVID = -1
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "tcp_socket is configured",
+			input: func(c *Config) {
+				c.Agent.Experimental.TCPSocket = "127.0.0.1:8082"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8082}, c.TCPBindAddress)
+			},
+		},
+		{
+			msg:         "tcp_socket must be a loopback address",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.Experimental.TCPSocket = "0.0.0.0:8082"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "retain_svids_during_outage is configured",
+			input: func(c *Config) {
+				c.Agent.Experimental.RetainSVIDsDuringOutage = true
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.RetainSVIDsDuringOutage)
+			},
+		},
+		{
+			msg: "harden_key_memory is configured",
+			input: func(c *Config) {
+				c.Agent.Experimental.HardenKeyMemory = true
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.HardenKeyMemory)
+			},
+		},
+		{
+			msg:         "harden_key_memory cannot be combined with cache_persistence",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.Experimental.HardenKeyMemory = true
+				c.Agent.Experimental.CachePersistence = true
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "log_rotation with an invalid max_age returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.LogFile = "agent.log"
+				c.Agent.LogRotation = &log.RotationConfig{MaxAge: "daily"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "log_fields with an empty name returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.LogFields = map[string]string{"": "us-east"}
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "profiling_socket_path should be correctly configured",
+			input: func(c *Config) {
+				c.Agent.ProfilingEnabled = true
+				c.Agent.ProfilingSocketPath = "/tmp/profiling.sock"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.ProfilingEnabled)
+				require.Equal(t, "/tmp/profiling.sock", c.ProfilingSocketPath)
+			},
+		},
+		{
+			msg:         "profiling_port and profiling_socket_path cannot both be configured",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.ProfilingPort = 8080
+				c.Agent.ProfilingSocketPath = "/tmp/profiling.sock"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "cache_persistence is configured",
+			input: func(c *Config) {
+				c.Agent.Experimental.CachePersistence = true
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.CachePersistence)
+			},
+		},
+		{
+			msg: "named_pipe_name is configured",
+			input: func(c *Config) {
+				c.Agent.Experimental.NamedPipeName = `spire-agent\public\api`
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, `spire-agent\public\api`, c.NamedPipeName)
+			},
+		},
 	}
 
 	for _, testCase := range cases {
@@ -968,3 +1803,38 @@ func TestExpandEnv(t *testing.T) {
 		assert.Equal(t, testCase.expectedValue, c.Agent.TrustDomain)
 	}
 }
+
+func TestReloadPlugins(t *testing.T) {
+	fd, err := ioutil.TempFile("", "agent.conf")
+	require.NoError(t, err)
+	defer os.Remove(fd.Name())
+	_, err = fd.WriteString(`agent {}
+plugins {
+	KeyManager "memory" {
+		plugin_data {}
+	}
+}`)
+	require.NoError(t, err)
+	require.NoError(t, fd.Close())
+
+	plugins := new(fakePluginReconfigurer)
+	require.NoError(t, reloadPlugins(context.Background(), []string{"-config", fd.Name()}, plugins))
+	require.Len(t, plugins.configs, 1)
+	assert.Contains(t, plugins.configs[0], "KeyManager")
+	assert.Contains(t, plugins.configs[0]["KeyManager"], "memory")
+
+	// Reconfiguration errors are returned
+	plugins.err = errors.New("oh no")
+	err = reloadPlugins(context.Background(), []string{"-config", fd.Name()}, plugins)
+	require.EqualError(t, err, "oh no")
+}
+
+type fakePluginReconfigurer struct {
+	configs []catalog.HCLPluginConfigMap
+	err     error
+}
+
+func (f *fakePluginReconfigurer) ReconfigurePlugins(ctx context.Context, pluginConfig catalog.HCLPluginConfigMap) error {
+	f.configs = append(f.configs, pluginConfig)
+	return f.err
+}
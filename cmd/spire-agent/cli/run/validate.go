@@ -0,0 +1,75 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	agent_catalog "github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/hostservices/metricsservice"
+	"github.com/spiffe/spire/pkg/common/log"
+	common_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// ValidateOptions tunes the validation of ValidateConfig.
+type ValidateOptions struct {
+	// Strict makes unknown config options errors rather than warnings.
+	Strict bool
+}
+
+// ValidateConfig validates the configuration further than LoadConfig: each
+// plugin validates its own configuration, and unknown config options are
+// reported. The warnings logged while loading the configuration are
+// reported in the result too.
+func ValidateConfig(ctx context.Context, c *Config, opts ValidateOptions) *common_cli.ValidationResult {
+	result := common_cli.NewValidationResult()
+
+	// Unknown config options are reported here rather than through the
+	// warnings logged by NewAgentConfig, so they are reported whatever the
+	// log level and can be made errors.
+	unknowns := detectUnknownConfig(c)
+	loggedUnknowns := make(map[string]bool, len(unknowns))
+	for _, unknown := range unknowns {
+		if opts.Strict {
+			result.AddError(errors.New(unknown))
+		} else {
+			result.AddWarning(unknown)
+		}
+		loggedUnknowns[fmt.Sprintf(unknownConfigWarning, unknown)] = true
+	}
+
+	ac, err := NewAgentConfig(c, []log.Option{result.LogWarnings(loggedUnknowns)})
+	if err != nil {
+		result.AddError(err)
+		return result
+	}
+	if closer, ok := ac.Log.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	pluginErrs, err := agent_catalog.ValidatePlugins(ctx, agent_catalog.Config{
+		Log: ac.Log.WithField(telemetry.SubsystemName, telemetry.Catalog),
+		GlobalConfig: agent_catalog.GlobalConfig{
+			TrustDomain: ac.TrustDomain.Host,
+		},
+		PluginConfig: ac.PluginConfigs,
+		HostServices: []catalog.HostServiceServer{
+			common_services.MetricsServiceHostServiceServer(metricsservice.New(metricsservice.Config{
+				Metrics: telemetry.Blackhole{},
+			})),
+		},
+	})
+	if err != nil {
+		result.AddError(err)
+		return result
+	}
+	for _, pluginErr := range pluginErrs {
+		result.AddError(pluginErr)
+	}
+
+	return result
+}
@@ -0,0 +1,100 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validateTestConfig = `
+agent {
+	trust_domain = "example.org"
+	data_dir = "."
+	server_address = "127.0.0.1"
+	server_port = 8081
+	insecure_bootstrap = true
+	%s
+}
+
+plugins {
+	KeyManager "memory" {
+		plugin_data {}
+	}
+	NodeAttestor "join_token" {
+		plugin_data {}
+	}
+	WorkloadAttestor "unix" {
+		plugin_data {
+			%s
+		}
+	}
+}
+`
+
+func TestValidateConfigWithPlugins(t *testing.T) {
+	dir, err := ioutil.TempDir("", "validate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, tt := range []struct {
+		name           string
+		agent          string
+		unix           string
+		opts           ValidateOptions
+		expectErrors   []string
+		expectWarnings []string
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name: "invalid plugin configuration",
+			unix: `discover_workload_path = "yes"`,
+			expectErrors: []string{
+				`WorkloadAttestor plugin "unix": unable to configure plugin "unix": rpc error: code = Unknown desc = unix: decodeBool: Unknown value for boolean: "yes"`,
+			},
+		},
+		{
+			name:  "unknown options are warnings",
+			agent: `bogus = true`,
+			expectWarnings: []string{
+				`Detected unknown agent config options: ["bogus"]`,
+			},
+		},
+		{
+			name:  "unknown options are errors with strict",
+			agent: `bogus = true`,
+			opts:  ValidateOptions{Strict: true},
+			expectErrors: []string{
+				`Detected unknown agent config options: ["bogus"]`,
+			},
+		},
+		{
+			name:  "invalid agent configuration",
+			agent: `server_proxy_url = "ftp://proxy.example.org"`,
+			expectErrors: []string{
+				`server proxy URL must start with http://`,
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "agent.conf")
+			require.NoError(t, ioutil.WriteFile(path, []byte(fmt.Sprintf(validateTestConfig, tt.agent, tt.unix)), 0600))
+
+			c, err := ParseConfig(commandName, []string{"-config", path}, ioutil.Discard)
+			require.NoError(t, err)
+
+			result := ValidateConfig(context.Background(), c, tt.opts)
+			assert.Equal(t, len(tt.expectErrors) == 0, result.Valid)
+			assert.Equal(t, tt.expectErrors, result.Errors)
+			assert.Equal(t, tt.expectWarnings, result.Warnings)
+		})
+	}
+}
@@ -53,3 +53,22 @@ func (s *ValidateSuite) TestBadFlags() {
 	s.Equal("", s.stdout.String(), "stdout")
 	s.Contains(s.stderr.String(), "flag provided but not defined: -badflag", "stderr")
 }
+
+func (s *ValidateSuite) TestInvalidOutput() {
+	code := s.cmd.Run([]string{"-output", "yaml"})
+	s.Equal(1, code, "exit code")
+	s.Equal("", s.stdout.String(), "stdout")
+	s.Equal("Invalid output format \"yaml\": must be \"text\" or \"json\"\n", s.stderr.String(), "stderr")
+}
+
+func (s *ValidateSuite) TestJSONOutput() {
+	code := s.cmd.Run([]string{"-output", "json", "-config", "/does/not/exist.conf"})
+	s.Equal(1, code, "exit code")
+	s.Equal(`{
+  "valid": false,
+  "errors": [
+    "could not find config file /does/not/exist.conf: please use the -config flag"
+  ]
+}
+`, s.stdout.String(), "stdout")
+}
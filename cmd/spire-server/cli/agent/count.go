@@ -0,0 +1,110 @@
+package agent
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+
+	"golang.org/x/net/context"
+)
+
+// CountConfig holds configuration for CountCLI
+type CountConfig struct {
+	// Socket path of registration API
+	RegistrationUDSPath string
+	// Filter of the counted agents
+	Filter FilterConfig
+	// Output format of the command
+	Output common_cli.OutputFlag
+}
+
+// Validate will perform a basic validation on config fields
+func (c *CountConfig) Validate() (err error) {
+	if c.RegistrationUDSPath == "" {
+		return errors.New("a socket path for registration api is required")
+	}
+	return nil
+}
+
+// CountCLI command for counting attested nodes
+type CountCLI struct {
+	registrationClient registration.RegistrationClient
+	count              int32
+}
+
+func (CountCLI) Synopsis() string {
+	return "Counts attested agents"
+}
+
+func (c CountCLI) Help() string {
+	_, err := c.parseConfig([]string{"-h"})
+	return err.Error()
+}
+
+// Run will count attested agents
+func (c *CountCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := c.parseConfig(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err = config.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	filter, err := config.Filter.toProto()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if c.registrationClient == nil {
+		c.registrationClient, err = util.NewRegistrationClient(config.RegistrationUDSPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error establishing connection to the Registration API: %v \n", err)
+			return 1
+		}
+	}
+
+	countResponse, err := c.registrationClient.CountAgents(ctx, &registration.CountAgentsRequest{Filter: filter})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error counting attested agents: %v \n", err)
+		return 1
+	}
+	c.count = countResponse.Count
+
+	if config.Output.JSON() {
+		out := struct {
+			Count int32 `json:"count"`
+		}{Count: c.count}
+		if err := common_cli.DefaultEnv.PrintJSON(out); err != nil {
+			_ = common_cli.DefaultEnv.ErrPrintln(err)
+			return 1
+		}
+		return 0
+	}
+
+	msg := fmt.Sprintf("%d attested ", c.count)
+	fmt.Println(util.Pluralizer(msg, "agent", "agents", int(c.count)))
+	return 0
+}
+
+func (CountCLI) parseConfig(args []string) (*CountConfig, error) {
+	f := flag.NewFlagSet("agent count", flag.ContinueOnError)
+	c := &CountConfig{}
+
+	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	c.Filter.addFlags(f)
+	c.Output.AddFlag(f)
+
+	return c, f.Parse(args)
+}
@@ -0,0 +1,64 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/stretchr/testify/suite"
+)
+
+type CountTestSuite struct {
+	suite.Suite
+	cli        *CountCLI
+	mockClient *mock_registration.MockRegistrationClient
+	mockCtrl   *gomock.Controller
+}
+
+func (s *CountTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_registration.NewMockRegistrationClient(s.mockCtrl)
+	s.cli = &CountCLI{
+		registrationClient: s.mockClient,
+	}
+}
+
+func (s *CountTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestCountTestSuite(t *testing.T) {
+	suite.Run(t, new(CountTestSuite))
+}
+
+func (s *CountTestSuite) TestRun() {
+	req := &registration.CountAgentsRequest{}
+	resp := &registration.CountAgentsResponse{Count: 2}
+	s.mockClient.EXPECT().CountAgents(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run([]string{}))
+	s.Assert().Equal(int32(2), s.cli.count)
+}
+
+func (s *CountTestSuite) TestRunWithFilter() {
+	req := &registration.CountAgentsRequest{
+		Filter: &registration.AgentFilter{
+			ByAttestationType: "x509pop",
+		},
+	}
+	resp := &registration.CountAgentsResponse{Count: 1}
+	s.mockClient.EXPECT().CountAgents(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run([]string{"-attestationType", "x509pop"}))
+	s.Assert().Equal(int32(1), s.cli.count)
+}
+
+func (s *CountTestSuite) TestRunExitsWithNonZeroCodeOnFailure() {
+	req := &registration.CountAgentsRequest{}
+	s.mockClient.EXPECT().CountAgents(gomock.Any(), req).Return(nil, errors.New("some error"))
+	s.Require().Equal(1, s.cli.Run([]string{}))
+}
+
+func (s *CountTestSuite) TestRunExitsWithNonZeroCodeOnInvalidFilter() {
+	s.Require().Equal(1, s.cli.Run([]string{"-banned", "maybe"}))
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 
@@ -19,6 +20,8 @@ type EvictConfig struct {
 	RegistrationUDSPath string
 	// SpiffeID of the agent being evicted
 	SpiffeID string
+	// Output format of the command
+	Output common_cli.OutputFlag
 }
 
 // Validate will perform a basic validation on config fields
@@ -87,6 +90,14 @@ func (c EvictCLI) Run(args []string) int {
 		return 1
 	}
 
+	if config.Output.JSON() {
+		if err := common_cli.DefaultEnv.PrintJSON(newAgentJSON(evictResponse.Node, nil)); err != nil {
+			_ = common_cli.DefaultEnv.ErrPrintln(err)
+			return 1
+		}
+		return 0
+	}
+
 	fmt.Println("Agent evicted successfully")
 	return 0
 }
@@ -97,6 +108,7 @@ func (EvictCLI) parseConfig(args []string) (*EvictConfig, error) {
 
 	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
 	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID of the agent to evict (agent identity)")
+	c.Output.AddFlag(f)
 
 	return c, f.Parse(args)
 }
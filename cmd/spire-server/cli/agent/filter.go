@@ -0,0 +1,130 @@
+package agent
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// FilterConfig holds the flags used to filter attested agents
+type FilterConfig struct {
+	// SPIFFE ID path prefix of the agents
+	PathPrefix string
+	// Attestation type of the agents
+	AttestationType string
+	// Selectors of the agents, formatted as type:value
+	Selectors StringsFlag
+	// How selectors must match: exact or subset
+	MatchSelectorsOn string
+	// Whether the agents are banned: true or false
+	Banned string
+	// The agent SVID expires after this time (RFC3339)
+	ExpiresAfter string
+	// The agent SVID expires before this time (RFC3339)
+	ExpiresBefore string
+}
+
+func (c *FilterConfig) addFlags(f *flag.FlagSet) {
+	f.StringVar(&c.PathPrefix, "pathPrefix", "", "Filter agents whose SPIFFE ID path starts with this prefix (e.g. /spire/agent/x509pop)")
+	f.StringVar(&c.AttestationType, "attestationType", "", "Filter agents by attestation type")
+	f.Var(&c.Selectors, "selector", "Filter agents by selector, formatted as type:value. Can be used more than once")
+	f.StringVar(&c.MatchSelectorsOn, "matchSelectorsOn", "exact", "The match mode used when filtering by selectors <exact|subset>")
+	f.StringVar(&c.Banned, "banned", "", "Filter agents that are banned (true) or not banned (false)")
+	f.StringVar(&c.ExpiresAfter, "expiresAfter", "", "Filter agents whose SVID expires after this time (RFC3339)")
+	f.StringVar(&c.ExpiresBefore, "expiresBefore", "", "Filter agents whose SVID expires before this time (RFC3339)")
+}
+
+// toProto builds the agent filter for the Registration API. A nil filter is
+// returned when no filter flag is set.
+func (c *FilterConfig) toProto() (*registration.AgentFilter, error) {
+	filter := &registration.AgentFilter{
+		ByPathPrefix:      c.PathPrefix,
+		ByAttestationType: c.AttestationType,
+	}
+	isSet := c.PathPrefix != "" || c.AttestationType != ""
+
+	for _, s := range c.Selectors {
+		selector, err := parseSelector(s)
+		if err != nil {
+			return nil, err
+		}
+		filter.BySelectors = append(filter.BySelectors, selector)
+		isSet = true
+	}
+
+	switch c.MatchSelectorsOn {
+	case "", "exact":
+		filter.SelectorMatch = registration.AgentFilter_MATCH_EXACT
+	case "subset":
+		filter.SelectorMatch = registration.AgentFilter_MATCH_SUBSET
+	default:
+		return nil, fmt.Errorf("match behavior %q unknown", c.MatchSelectorsOn)
+	}
+
+	if c.Banned != "" {
+		banned, err := strconv.ParseBool(c.Banned)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for banned %q: must be true or false", c.Banned)
+		}
+		filter.ByBanned = &wrappers.BoolValue{Value: banned}
+		isSet = true
+	}
+
+	if c.ExpiresAfter != "" {
+		t, err := time.Parse(time.RFC3339, c.ExpiresAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for expiresAfter: %v", err)
+		}
+		filter.ByExpiresAfter = t.Unix()
+		isSet = true
+	}
+
+	if c.ExpiresBefore != "" {
+		t, err := time.Parse(time.RFC3339, c.ExpiresBefore)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for expiresBefore: %v", err)
+		}
+		filter.ByExpiresBefore = t.Unix()
+		isSet = true
+	}
+
+	if !isSet {
+		return nil, nil
+	}
+	return filter, nil
+}
+
+// parseSelector parses a CLI string from type:value into a selector type.
+// Everything to the right of the first ":" is considered a selector value.
+func parseSelector(str string) (*common.Selector, error) {
+	parts := strings.SplitAfterN(str, ":", 2)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("selector \"%s\" must be formatted as type:value", str)
+	}
+
+	s := &common.Selector{
+		// Strip the trailing delimiter
+		Type:  strings.TrimSuffix(parts[0], ":"),
+		Value: parts[1],
+	}
+	return s, nil
+}
+
+// StringsFlag defines a custom type for string lists. Doing
+// this allows us to support repeatable string flags.
+type StringsFlag []string
+
+func (s *StringsFlag) String() string {
+	return fmt.Sprint(*s)
+}
+
+func (s *StringsFlag) Set(val string) error {
+	*s = append(*s, val)
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 
@@ -18,6 +19,10 @@ import (
 type ListConfig struct {
 	// Socket path of registration API
 	RegistrationUDSPath string
+	// Filter of the listed agents
+	Filter FilterConfig
+	// Output format of the command
+	Output common_cli.OutputFlag
 }
 
 // Validate will perform a basic validation on config fields
@@ -58,6 +63,12 @@ func (c *ListCLI) Run(args []string) int {
 		return 1
 	}
 
+	filter, err := config.Filter.toProto()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
 	if c.registrationClient == nil {
 		c.registrationClient, err = util.NewRegistrationClient(config.RegistrationUDSPath)
 		if err != nil {
@@ -66,12 +77,20 @@ func (c *ListCLI) Run(args []string) int {
 		}
 	}
 
-	listResponse, err := c.registrationClient.ListAgents(ctx, &registration.ListAgentsRequest{})
+	listResponse, err := c.registrationClient.ListAgents(ctx, &registration.ListAgentsRequest{Filter: filter})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error listing attested agents: %v \n", err)
 		return 1
 	}
 	c.nodeList = listResponse.Nodes
+	if config.Output.JSON() {
+		if err := common_cli.DefaultEnv.PrintJSON(newAgentsJSON(c.nodeList)); err != nil {
+			_ = common_cli.DefaultEnv.ErrPrintln(err)
+			return 1
+		}
+		return 0
+	}
+
 	c.printAttestedNodes()
 	return 0
 }
@@ -81,6 +100,8 @@ func (ListCLI) parseConfig(args []string) (*ListConfig, error) {
 	c := &ListConfig{}
 
 	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	c.Filter.addFlags(f)
+	c.Output.AddFlag(f)
 
 	return c, f.Parse(args)
 }
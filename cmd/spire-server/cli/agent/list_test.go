@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
@@ -46,6 +47,68 @@ func (s *ListTestSuite) TestRun() {
 	s.Assert().Equal(resp.Nodes, s.cli.nodeList)
 }
 
+func (s *ListTestSuite) TestRunWithFilter() {
+	req := &registration.ListAgentsRequest{
+		Filter: &registration.AgentFilter{
+			ByPathPrefix:      "/spire/agent/x509pop",
+			ByAttestationType: "x509pop",
+			BySelectors: []*common.Selector{
+				{Type: "x509pop", Value: "subject:cn:a"},
+				{Type: "x509pop", Value: "subject:cn:b"},
+			},
+			SelectorMatch:   registration.AgentFilter_MATCH_SUBSET,
+			ByBanned:        &wrappers.BoolValue{Value: false},
+			ByExpiresAfter:  1500000000,
+			ByExpiresBefore: 1500003600,
+		},
+	}
+	resp := &registration.ListAgentsResponse{
+		Nodes: []*common.AttestedNode{
+			{SpiffeId: "spiffe://example.org/spire/agent/x509pop/a"},
+		},
+	}
+	s.mockClient.EXPECT().ListAgents(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run([]string{
+		"-pathPrefix", "/spire/agent/x509pop",
+		"-attestationType", "x509pop",
+		"-selector", "x509pop:subject:cn:a",
+		"-selector", "x509pop:subject:cn:b",
+		"-matchSelectorsOn", "subset",
+		"-banned", "false",
+		"-expiresAfter", "2017-07-14T02:40:00Z",
+		"-expiresBefore", "2017-07-14T03:40:00Z",
+	}))
+	s.Assert().Equal(resp.Nodes, s.cli.nodeList)
+}
+
+func (s *ListTestSuite) TestRunWithJSONOutput() {
+	req := &registration.ListAgentsRequest{}
+	resp := &registration.ListAgentsResponse{
+		Nodes: []*common.AttestedNode{
+			{SpiffeId: "spiffe://example.org/spire/agent/join_token/token_a"},
+		},
+	}
+	s.mockClient.EXPECT().ListAgents(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run([]string{"-output", "json"}))
+	s.Assert().Equal(resp.Nodes, s.cli.nodeList)
+}
+
+func (s *ListTestSuite) TestRunExitsWithNonZeroCodeOnInvalidOutput() {
+	s.Require().Equal(1, s.cli.Run([]string{"-output", "yaml"}))
+}
+
+func (s *ListTestSuite) TestRunExitsWithNonZeroCodeOnInvalidFilter() {
+	for _, args := range [][]string{
+		{"-selector", "no-value"},
+		{"-matchSelectorsOn", "superset"},
+		{"-banned", "maybe"},
+		{"-expiresAfter", "tomorrow"},
+		{"-expiresBefore", "1h"},
+	} {
+		s.Require().Equal(1, s.cli.Run(args), "args: %v", args)
+	}
+}
+
 func (s *ListTestSuite) TestRunWithNoAgentsInDatastore() {
 	req := &registration.ListAgentsRequest{}
 	resp := &registration.ListAgentsResponse{}
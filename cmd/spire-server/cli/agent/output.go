@@ -0,0 +1,47 @@
+package agent
+
+import (
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/nodeutil"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// agentJSON is the JSON output of the commands for an attested agent
+type agentJSON struct {
+	SpiffeID        string         `json:"spiffe_id"`
+	AttestationType string         `json:"attestation_type"`
+	ExpiresAt       time.Time      `json:"expires_at"`
+	SerialNumber    string         `json:"serial_number"`
+	Banned          bool           `json:"banned"`
+	Selectors       []selectorJSON `json:"selectors,omitempty"`
+}
+
+type selectorJSON struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func newAgentJSON(node *common.AttestedNode, selectors []*common.Selector) agentJSON {
+	agent := agentJSON{
+		SpiffeID:        node.SpiffeId,
+		AttestationType: node.AttestationDataType,
+		ExpiresAt:       time.Unix(node.CertNotAfter, 0).UTC(),
+		SerialNumber:    node.CertSerialNumber,
+		Banned:          nodeutil.IsAgentBanned(node),
+	}
+	for _, s := range selectors {
+		agent.Selectors = append(agent.Selectors, selectorJSON{Type: s.Type, Value: s.Value})
+	}
+	return agent
+}
+
+func newAgentsJSON(nodes []*common.AttestedNode) interface{} {
+	agents := []agentJSON{}
+	for _, node := range nodes {
+		agents = append(agents, newAgentJSON(node, nil))
+	}
+	return struct {
+		Agents []agentJSON `json:"agents"`
+	}{Agents: agents}
+}
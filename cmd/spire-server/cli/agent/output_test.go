@@ -0,0 +1,60 @@
+package agent
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentJSON(t *testing.T) {
+	nodes := []*common.AttestedNode{
+		{
+			SpiffeId:            "spiffe://example.org/spire/agent/x509pop/a",
+			AttestationDataType: "x509pop",
+			CertNotAfter:        1500000000,
+			CertSerialNumber:    "1",
+		},
+		{
+			SpiffeId:            "spiffe://example.org/spire/agent/join_token/b",
+			AttestationDataType: "join_token",
+			CertNotAfter:        1500003600,
+		},
+	}
+
+	data, err := json.Marshal(newAgentsJSON(nodes))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"agents": [
+		{
+			"spiffe_id": "spiffe://example.org/spire/agent/x509pop/a",
+			"attestation_type": "x509pop",
+			"expires_at": "2017-07-14T02:40:00Z",
+			"serial_number": "1",
+			"banned": false
+		},
+		{
+			"spiffe_id": "spiffe://example.org/spire/agent/join_token/b",
+			"attestation_type": "join_token",
+			"expires_at": "2017-07-14T03:40:00Z",
+			"serial_number": "",
+			"banned": true
+		}
+	]}`, string(data))
+
+	data, err = json.Marshal(newAgentsJSON(nil))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"agents": []}`, string(data))
+
+	data, err = json.Marshal(newAgentJSON(nodes[0], []*common.Selector{{Type: "x509pop", Value: "subject:cn:a"}}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"spiffe_id": "spiffe://example.org/spire/agent/x509pop/a",
+		"attestation_type": "x509pop",
+		"expires_at": "2017-07-14T02:40:00Z",
+		"serial_number": "1",
+		"banned": false,
+		"selectors": [{"type": "x509pop", "value": "subject:cn:a"}]
+	}`, string(data))
+}
@@ -0,0 +1,114 @@
+package agent
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+
+	"golang.org/x/net/context"
+)
+
+// PruneConfig holds configuration for PruneCLI
+type PruneConfig struct {
+	// Socket path of registration API
+	RegistrationUDSPath string
+	// How long ago the SVID of the agents being pruned must have expired
+	ExpiredFor time.Duration
+	// Output format of the command
+	Output common_cli.OutputFlag
+}
+
+// Validate will perform a basic validation on config fields
+func (c *PruneConfig) Validate() error {
+	if c.RegistrationUDSPath == "" {
+		return errors.New("a socket path for registration api is required")
+	}
+
+	if c.ExpiredFor < 0 {
+		return errors.New("expiredFor cannot be negative")
+	}
+
+	return nil
+}
+
+// PruneCLI command for pruning expired nodes
+type PruneCLI struct {
+	registrationClient registration.RegistrationClient
+	now                func() time.Time
+}
+
+func (PruneCLI) Synopsis() string {
+	return "Removes attested agents whose SVID expired"
+}
+
+func (c PruneCLI) Help() string {
+	_, err := c.parseConfig([]string{"-h"})
+	return err.Error()
+}
+
+// Run will prune the agents whose SVID expired more than expiredFor ago
+func (c PruneCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := c.parseConfig(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err = config.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if c.registrationClient == nil {
+		c.registrationClient, err = util.NewRegistrationClient(config.RegistrationUDSPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error establishing connection to the Registration API: %v \n", err)
+			return 1
+		}
+	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+
+	expiresBefore := c.now().Add(-config.ExpiredFor).Unix()
+	_, err = c.registrationClient.PruneAgents(ctx, &registration.PruneAgentsRequest{
+		ExpiresBefore: expiresBefore,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error pruning agents: %v \n", err)
+		return 1
+	}
+
+	if config.Output.JSON() {
+		out := struct {
+			ExpiredBefore time.Time `json:"expired_before"`
+		}{ExpiredBefore: time.Unix(expiresBefore, 0).UTC()}
+		if err := common_cli.DefaultEnv.PrintJSON(out); err != nil {
+			_ = common_cli.DefaultEnv.ErrPrintln(err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Println("Agents pruned successfully")
+	return 0
+}
+
+func (PruneCLI) parseConfig(args []string) (*PruneConfig, error) {
+	f := flag.NewFlagSet("agent prune", flag.ContinueOnError)
+	c := &PruneConfig{}
+
+	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	f.DurationVar(&c.ExpiredFor, "expiredFor", 0, "Prune agents whose SVID expired more than this duration ago (e.g. 168h)")
+	c.Output.AddFlag(f)
+
+	return c, f.Parse(args)
+}
@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/stretchr/testify/suite"
+)
+
+type PruneTestSuite struct {
+	suite.Suite
+	cli        *PruneCLI
+	mockClient *mock_registration.MockRegistrationClient
+	mockCtrl   *gomock.Controller
+	now        time.Time
+}
+
+func (s *PruneTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_registration.NewMockRegistrationClient(s.mockCtrl)
+	s.now = time.Unix(1500000000, 0)
+	s.cli = &PruneCLI{
+		registrationClient: s.mockClient,
+		now:                func() time.Time { return s.now },
+	}
+}
+
+func (s *PruneTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestPruneTestSuite(t *testing.T) {
+	suite.Run(t, new(PruneTestSuite))
+}
+
+func (s *PruneTestSuite) TestRun() {
+	req := &registration.PruneAgentsRequest{
+		ExpiresBefore: s.now.Add(-168 * time.Hour).Unix(),
+	}
+
+	s.mockClient.EXPECT().PruneAgents(gomock.Any(), req).Return(&registration.PruneAgentsResponse{}, nil)
+	s.Require().Equal(0, s.cli.Run([]string{"-expiredFor", "168h"}))
+}
+
+func (s *PruneTestSuite) TestRunDefaultsToExpiredNow() {
+	req := &registration.PruneAgentsRequest{
+		ExpiresBefore: s.now.Unix(),
+	}
+
+	s.mockClient.EXPECT().PruneAgents(gomock.Any(), req).Return(&registration.PruneAgentsResponse{}, nil)
+	s.Require().Equal(0, s.cli.Run(nil))
+}
+
+func (s *PruneTestSuite) TestRunExitsWithNonZeroCodeOnError() {
+	s.mockClient.EXPECT().PruneAgents(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+	s.Require().Equal(1, s.cli.Run([]string{"-expiredFor", "1h"}))
+}
+
+func (s *PruneTestSuite) TestRunValidatesExpiredFor() {
+	s.Require().Equal(1, s.cli.Run([]string{"-expiredFor", "-1h"}))
+	s.Require().Equal(1, s.cli.Run([]string{"-expiredFor", "not-a-duration"}))
+}
@@ -0,0 +1,122 @@
+package agent
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+
+	"golang.org/x/net/context"
+)
+
+// RotateConfig holds configuration for RotateCLI
+type RotateConfig struct {
+	// Socket path of registration API
+	RegistrationUDSPath string
+	// SpiffeIDs of the agents whose SVID is rotated
+	SpiffeIDs StringsFlag
+	// Whether the SVIDs of all the agents that are not banned are rotated
+	All bool
+	// Output format of the command
+	Output common_cli.OutputFlag
+}
+
+// Validate will perform a basic validation on config fields
+func (c *RotateConfig) Validate() error {
+	if c.RegistrationUDSPath == "" {
+		return errors.New("a socket path for registration api is required")
+	}
+
+	switch {
+	case c.All && len(c.SpiffeIDs) > 0:
+		return errors.New("the -spiffeID and -all flags are mutually exclusive")
+	case !c.All && len(c.SpiffeIDs) == 0:
+		return errors.New("a SPIFFE ID or the -all flag is required")
+	}
+
+	// make sure SPIFFE IDs are well formed
+	for i, spiffeID := range c.SpiffeIDs {
+		var err error
+		c.SpiffeIDs[i], err = idutil.NormalizeSpiffeID(spiffeID, idutil.AllowAnyTrustDomainAgent())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RotateCLI command for forcing the rotation of agent SVIDs
+type RotateCLI struct {
+	registrationClient registration.RegistrationClient
+}
+
+func (RotateCLI) Synopsis() string {
+	return "Makes attested agents rotate their SVID at their next synchronization"
+}
+
+func (c RotateCLI) Help() string {
+	_, err := c.parseConfig([]string{"-h"})
+	return err.Error()
+}
+
+// Run will force the rotation of the SVID of the given agents, or of all the
+// agents
+func (c RotateCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := c.parseConfig(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err = config.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if c.registrationClient == nil {
+		c.registrationClient, err = util.NewRegistrationClient(config.RegistrationUDSPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error establishing connection to the Registration API: %v \n", err)
+			return 1
+		}
+	}
+	resp, err := c.registrationClient.ForceAgentRotation(ctx, &registration.ForceAgentRotationRequest{
+		SpiffeIDs: config.SpiffeIDs,
+		All:       config.All,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error forcing agent SVID rotation: %v \n", err)
+		return 1
+	}
+
+	if config.Output.JSON() {
+		if err := common_cli.DefaultEnv.PrintJSON(newAgentsJSON(resp.Nodes)); err != nil {
+			_ = common_cli.DefaultEnv.ErrPrintln(err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Printf("Forced the SVID rotation of %d agent(s); SVIDs are rotated at the next synchronization of the agents\n", len(resp.Nodes))
+	return 0
+}
+
+func (RotateCLI) parseConfig(args []string) (*RotateConfig, error) {
+	f := flag.NewFlagSet("agent rotate", flag.ContinueOnError)
+	c := &RotateConfig{}
+
+	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	f.Var(&c.SpiffeIDs, "spiffeID", "The SPIFFE ID of an agent whose SVID is rotated (agent identity). Can be used more than once")
+	f.BoolVar(&c.All, "all", false, "Rotate the SVIDs of all the agents that are not banned")
+	c.Output.AddFlag(f)
+
+	return c, f.Parse(args)
+}
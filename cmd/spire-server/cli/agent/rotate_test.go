@@ -0,0 +1,79 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/stretchr/testify/suite"
+)
+
+type RotateTestSuite struct {
+	suite.Suite
+	cli        *RotateCLI
+	mockClient *mock_registration.MockRegistrationClient
+	mockCtrl   *gomock.Controller
+}
+
+func (s *RotateTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_registration.NewMockRegistrationClient(s.mockCtrl)
+	s.cli = &RotateCLI{
+		registrationClient: s.mockClient,
+	}
+}
+
+func (s *RotateTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestRotateTestSuite(t *testing.T) {
+	suite.Run(t, new(RotateTestSuite))
+}
+
+func (s *RotateTestSuite) TestRun() {
+	spiffeIDA := "spiffe://example.org/spire/agent/join_token/token_a"
+	spiffeIDB := "spiffe://example.org/spire/agent/join_token/token_b"
+	args := []string{"-spiffeID", spiffeIDA, "-spiffeID", spiffeIDB}
+
+	req := &registration.ForceAgentRotationRequest{
+		SpiffeIDs: []string{spiffeIDA, spiffeIDB},
+	}
+	resp := &registration.ForceAgentRotationResponse{
+		Nodes: []*common.AttestedNode{
+			{SpiffeId: spiffeIDA, ForceRotation: true},
+			{SpiffeId: spiffeIDB, ForceRotation: true},
+		},
+	}
+
+	s.mockClient.EXPECT().ForceAgentRotation(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run(args))
+}
+
+func (s *RotateTestSuite) TestRunAll() {
+	req := &registration.ForceAgentRotationRequest{
+		All: true,
+	}
+
+	s.mockClient.EXPECT().ForceAgentRotation(gomock.Any(), req).Return(&registration.ForceAgentRotationResponse{}, nil)
+	s.Require().Equal(0, s.cli.Run([]string{"-all"}))
+}
+
+func (s *RotateTestSuite) TestRunExitsWithNonZeroCodeOnError() {
+	s.mockClient.EXPECT().ForceAgentRotation(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+	s.Require().Equal(1, s.cli.Run([]string{"-all"}))
+}
+
+func (s *RotateTestSuite) TestRunValidatesFlags() {
+	// No agent to rotate
+	s.Require().Equal(1, s.cli.Run([]string{}))
+
+	// Both SPIFFE IDs and all the agents
+	s.Require().Equal(1, s.cli.Run([]string{"-all", "-spiffeID", "spiffe://example.org/spire/agent/join_token/token_a"}))
+
+	// Not an agent SPIFFE ID
+	s.Require().Equal(1, s.cli.Run([]string{"-spiffeID", "spiffe://example.org/workload"}))
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -21,6 +22,8 @@ type ShowConfig struct {
 	RegistrationUDSPath string
 	// SpiffeID of the agent being showed
 	SpiffeID string
+	// Output format of the command
+	Output common_cli.OutputFlag
 }
 
 // Validate will perform a basic validation on config fields
@@ -81,7 +84,9 @@ func (c *ShowCLI) Run(args []string) int {
 		}
 	}
 
-	listResponse, err := c.registrationClient.ListAgents(ctx, &registration.ListAgentsRequest{})
+	listResponse, err := c.registrationClient.ListAgents(ctx, &registration.ListAgentsRequest{
+		Filter: &registration.AgentFilter{BySpiffeId: config.SpiffeID},
+	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error listing attested agents: %v \n", err)
 		return 1
@@ -105,6 +110,14 @@ func (c *ShowCLI) Run(args []string) int {
 		return 1
 	}
 
+	if config.Output.JSON() {
+		if err := common_cli.DefaultEnv.PrintJSON(newAgentJSON(c.node, c.selectors)); err != nil {
+			_ = common_cli.DefaultEnv.ErrPrintln(err)
+			return 1
+		}
+		return 0
+	}
+
 	c.printAttestedNode()
 	return 0
 }
@@ -115,6 +128,7 @@ func (ShowCLI) parseConfig(args []string) (*ShowConfig, error) {
 
 	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
 	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID of the agent to show (agent identity)")
+	c.Output.AddFlag(f)
 
 	return c, f.Parse(args)
 }
@@ -42,7 +42,9 @@ func (s *ShowTestSuite) TestRun() {
 		{Type: "k8s_sat", Value: "cluster:demo-cluster"},
 	}
 
-	req1 := &registration.ListAgentsRequest{}
+	req1 := &registration.ListAgentsRequest{
+		Filter: &registration.AgentFilter{BySpiffeId: spiffeID},
+	}
 	resp1 := &registration.ListAgentsResponse{
 		Nodes: []*common.AttestedNode{
 			{SpiffeId: spiffeID},
@@ -69,7 +71,9 @@ func (s *ShowTestSuite) TestRun() {
 func (s *ShowTestSuite) TestRunWithNoSelectorsInDatastore() {
 	spiffeID := "spiffe://example.org/spire/agent/k8s_sat/demo-cluster/c54f273c-f9c2-4d08-9d6f-08879e418aef"
 
-	req1 := &registration.ListAgentsRequest{}
+	req1 := &registration.ListAgentsRequest{
+		Filter: &registration.AgentFilter{BySpiffeId: spiffeID},
+	}
 	resp1 := &registration.ListAgentsResponse{
 		Nodes: []*common.AttestedNode{
 			{SpiffeId: spiffeID},
@@ -94,7 +98,9 @@ func (s *ShowTestSuite) TestRunWithNoSelectorsInDatastore() {
 func (s *ShowTestSuite) TestRunWithNoAgentInDatastore() {
 	spiffeID := "spiffe://example.org/spire/agent/k8s_sat/demo-cluster/c54f273c-f9c2-4d08-9d6f-08879e418aef"
 
-	req1 := &registration.ListAgentsRequest{}
+	req1 := &registration.ListAgentsRequest{
+		Filter: &registration.AgentFilter{BySpiffeId: spiffeID},
+	}
 	resp1 := &registration.ListAgentsResponse{
 		Nodes: []*common.AttestedNode{
 			{SpiffeId: "spiffe://example.org/no-agent"},
@@ -111,7 +117,9 @@ func (s *ShowTestSuite) TestRunWithNoAgentInDatastore() {
 func (s *ShowTestSuite) TestRunListAgentsExitsWithNonZeroCodeOnFailure() {
 	spiffeID := "spiffe://example.org/spire/agent/k8s_sat/demo-cluster/c54f273c-f9c2-4d08-9d6f-08879e418aef"
 
-	req1 := &registration.ListAgentsRequest{}
+	req1 := &registration.ListAgentsRequest{
+		Filter: &registration.AgentFilter{BySpiffeId: spiffeID},
+	}
 	s.mockClient.EXPECT().ListAgents(gomock.Any(), req1).Return(nil, errors.New("some error"))
 
 	args := []string{"-spiffeID", spiffeID}
@@ -123,7 +131,9 @@ func (s *ShowTestSuite) TestRunListAgentsExitsWithNonZeroCodeOnFailure() {
 func (s *ShowTestSuite) TestRunGetNodeSelectorsExitsWithNonZeroCodeOnFailure() {
 	spiffeID := "spiffe://example.org/spire/agent/k8s_sat/demo-cluster/c54f273c-f9c2-4d08-9d6f-08879e418aef"
 
-	req1 := &registration.ListAgentsRequest{}
+	req1 := &registration.ListAgentsRequest{
+		Filter: &registration.AgentFilter{BySpiffeId: spiffeID},
+	}
 	resp1 := &registration.ListAgentsResponse{
 		Nodes: []*common.AttestedNode{
 			{SpiffeId: spiffeID},
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/x509"
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -59,6 +60,7 @@ type BundleSuite struct {
 	setCmd    cli.Command
 	listCmd   cli.Command
 	deleteCmd cli.Command
+	countCmd  cli.Command
 }
 
 func (s *BundleSuite) SetupTest() {
@@ -92,6 +94,7 @@ func (s *BundleSuite) SetupTest() {
 	s.setCmd = newSetCommand(testEnv, clientMaker)
 	s.listCmd = newListCommand(testEnv, clientMaker)
 	s.deleteCmd = newDeleteCommand(testEnv, clientMaker)
+	s.countCmd = newCountCommand(testEnv, clientMaker)
 }
 
 func (s *BundleSuite) TearDownTest() {
@@ -109,6 +112,10 @@ func (s *BundleSuite) AfterTest(suiteName, testName string) {
 func (s *BundleSuite) TestShowHelp() {
 	s.showCmd.Help()
 	s.Require().Equal(`Usage of bundle show:
+  -format string
+    	The format to show the bundle. Either "pem" or "spiffe" (default "pem")
+  -output format
+    	Output format: text or json
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
 `, s.stderr.String())
@@ -136,11 +143,86 @@ dZglS5kKnYigmwDh+/U=
 `)
 }
 
+func (s *BundleSuite) TestShowSPIFFEFormat() {
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://example.test",
+		RootCas: []*common.Certificate{
+			{DerBytes: s.cert1.Raw},
+		},
+		RefreshHint: 60,
+	})
+
+	s.Require().Equal(0, s.showCmd.Run([]string{"-format", "spiffe"}))
+
+	s.Require().Equal(`{
+    "keys": [
+        {
+            "use": "x509-svid",
+            "kty": "EC",
+            "crv": "P-256",
+            "x": "fK-wKTnKL7KFLM27lqq5DC-bxrVaH6rDV-IcCSEOeL4",
+            "y": "wq-g3TQWxYlV51TCPH030yXsRxvujD4hUUaIQrXk4KI",
+            "x5c": [
+                "MIIBKjCB0aADAgECAgEBMAoGCCqGSM49BAMCMAAwIhgPMDAwMTAxMDEwMDAwMDBaGA85OTk5MTIzMTIzNTk1OVowADBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABHyvsCk5yi+yhSzNu5aquQwvm8a1Wh+qw1fiHAkhDni+wq+g3TQWxYlV51TCPH030yXsRxvujD4hUUaIQrXk4KKjODA2MA8GA1UdEwEB/wQFMAMBAf8wIwYDVR0RAQH/BBkwF4YVc3BpZmZlOi8vZG9tYWluMS50ZXN0MAoGCCqGSM49BAMCA0gAMEUCIA2dO09Xmakw2ekuHKWC4hBhCkpr5qY4bI8YUcXfxg/1AiEA67kMyH7bQnr7OVLUrL+b9ylAdZglS5kKnYigmwDh+/U="
+            ]
+        }
+    ],
+    "spiffe_refresh_hint": 60
+}
+`, s.stdout.String())
+}
+
+func (s *BundleSuite) TestShowJSONOutput() {
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://example.test",
+		RootCas: []*common.Certificate{
+			{DerBytes: s.cert1.Raw},
+		},
+		RefreshHint: 60,
+	})
+
+	s.Require().Equal(0, s.showCmd.Run([]string{"-output", "json"}))
+
+	s.Require().Equal(`{
+  "trust_domain_id": "spiffe://example.test",
+  "bundle": {
+    "keys": [
+      {
+        "use": "x509-svid",
+        "kty": "EC",
+        "crv": "P-256",
+        "x": "fK-wKTnKL7KFLM27lqq5DC-bxrVaH6rDV-IcCSEOeL4",
+        "y": "wq-g3TQWxYlV51TCPH030yXsRxvujD4hUUaIQrXk4KI",
+        "x5c": [
+          "MIIBKjCB0aADAgECAgEBMAoGCCqGSM49BAMCMAAwIhgPMDAwMTAxMDEwMDAwMDBaGA85OTk5MTIzMTIzNTk1OVowADBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABHyvsCk5yi+yhSzNu5aquQwvm8a1Wh+qw1fiHAkhDni+wq+g3TQWxYlV51TCPH030yXsRxvujD4hUUaIQrXk4KKjODA2MA8GA1UdEwEB/wQFMAMBAf8wIwYDVR0RAQH/BBkwF4YVc3BpZmZlOi8vZG9tYWluMS50ZXN0MAoGCCqGSM49BAMCA0gAMEUCIA2dO09Xmakw2ekuHKWC4hBhCkpr5qY4bI8YUcXfxg/1AiEA67kMyH7bQnr7OVLUrL+b9ylAdZglS5kKnYigmwDh+/U="
+        ]
+      }
+    ],
+    "spiffe_refresh_hint": 60
+  }
+}
+`, s.stdout.String())
+}
+
+func (s *BundleSuite) TestShowWithUnsupportedOutput() {
+	s.Require().Equal(1, s.showCmd.Run([]string{"-output", "yaml"}))
+	s.Require().Contains(s.stderr.String(), "invalid value \"yaml\" for flag -output: must be \"text\" or \"json\"\n")
+}
+
+func (s *BundleSuite) TestShowWithUnsupportedFormat() {
+	s.Require().Equal(1, s.showCmd.Run([]string{"-format", "der"}))
+	s.Require().Equal("unsupported format \"der\"\n", s.stderr.String())
+}
+
 func (s *BundleSuite) TestSetHelp() {
 	s.setCmd.Help()
 	s.Require().Equal(`Usage of bundle set:
+  -format string
+    	The format of the bundle data. Either "pem" or "spiffe" (default "pem")
   -id string
     	SPIFFE ID of the trust domain
+  -output format
+    	Output format: text or json
   -path string
     	Path to the bundle data
   -registrationUDSPath string
@@ -199,11 +281,40 @@ func (s *BundleSuite) TestSetCreatesBundleFromFile() {
 	s.assertBundleSet("-path", bundlePath)
 }
 
+func (s *BundleSuite) TestSetWithUnsupportedFormat() {
+	rc := s.setCmd.Run([]string{"-id", "spiffe://otherdomain.test", "-format", "der"})
+	s.Require().Equal(1, rc)
+	s.Require().Equal("unsupported format \"der\"\n", s.stderr.String())
+}
+
+func (s *BundleSuite) TestSetWithBadSPIFFEBundleData() {
+	s.stdin.WriteString(cert1PEM)
+	rc := s.setCmd.Run([]string{"-id", "spiffe://otherdomain.test", "-format", "spiffe"})
+	s.Require().Equal(1, rc)
+	s.Require().Contains(s.stderr.String(), "unable to parse bundle data: ")
+}
+
+func (s *BundleSuite) TestSetCreatesBundleFromSPIFFEFormat() {
+	s.stdin.WriteString(otherDomainJWKS)
+	s.assertBundleSet("-format", "spiffe")
+
+	resp, err := s.ds.FetchBundle(context.Background(), &datastore.FetchBundleRequest{
+		TrustDomainId: "spiffe://otherdomain.test",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Bundle.JwtSigningKeys, 1)
+	s.Require().Equal("KID", resp.Bundle.JwtSigningKeys[0].Kid)
+}
+
 func (s *BundleSuite) TestListHelp() {
 	s.listCmd.Help()
 	s.Require().Equal(`Usage of bundle list:
+  -format string
+    	The format to list federated bundles. Either "pem" or "spiffe" (default "pem")
   -id string
     	SPIFFE ID of the trust domain
+  -output format
+    	Output format: text or json
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
 `, s.stderr.String())
@@ -281,6 +392,123 @@ diIqWtxAqBLFrx8zNS4=
 `)
 }
 
+func (s *BundleSuite) TestListAllSPIFFEFormat() {
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://domain1.test",
+		RootCas: []*common.Certificate{
+			{DerBytes: s.cert1.Raw},
+		},
+	})
+
+	s.Require().Equal(0, s.listCmd.Run([]string{"-format", "spiffe"}))
+
+	s.Require().Equal(`****************************************
+* spiffe://domain1.test
+****************************************
+{
+    "keys": [
+        {
+            "use": "x509-svid",
+            "kty": "EC",
+            "crv": "P-256",
+            "x": "fK-wKTnKL7KFLM27lqq5DC-bxrVaH6rDV-IcCSEOeL4",
+            "y": "wq-g3TQWxYlV51TCPH030yXsRxvujD4hUUaIQrXk4KI",
+            "x5c": [
+                "MIIBKjCB0aADAgECAgEBMAoGCCqGSM49BAMCMAAwIhgPMDAwMTAxMDEwMDAwMDBaGA85OTk5MTIzMTIzNTk1OVowADBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABHyvsCk5yi+yhSzNu5aquQwvm8a1Wh+qw1fiHAkhDni+wq+g3TQWxYlV51TCPH030yXsRxvujD4hUUaIQrXk4KKjODA2MA8GA1UdEwEB/wQFMAMBAf8wIwYDVR0RAQH/BBkwF4YVc3BpZmZlOi8vZG9tYWluMS50ZXN0MAoGCCqGSM49BAMCA0gAMEUCIA2dO09Xmakw2ekuHKWC4hBhCkpr5qY4bI8YUcXfxg/1AiEA67kMyH7bQnr7OVLUrL+b9ylAdZglS5kKnYigmwDh+/U="
+            ]
+        }
+    ]
+}
+`, s.stdout.String())
+}
+
+func (s *BundleSuite) TestListJSONOutput() {
+	s.Require().Equal(0, s.listCmd.Run([]string{"-output", "json"}))
+	s.Require().Equal("{\n  \"bundles\": []\n}\n", s.stdout.String())
+
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://domain1.test",
+		RootCas: []*common.Certificate{
+			{DerBytes: s.cert1.Raw},
+		},
+	})
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://domain2.test",
+		RootCas: []*common.Certificate{
+			{DerBytes: s.cert2.Raw},
+		},
+	})
+
+	s.stdout.Reset()
+	s.Require().Equal(0, s.listCmd.Run([]string{"-output", "json"}))
+
+	var out struct {
+		Bundles []struct {
+			TrustDomainID string          `json:"trust_domain_id"`
+			Bundle        json.RawMessage `json:"bundle"`
+		} `json:"bundles"`
+	}
+	s.Require().NoError(json.Unmarshal(s.stdout.Bytes(), &out))
+	s.Require().Len(out.Bundles, 2)
+	s.Require().Equal("spiffe://domain1.test", out.Bundles[0].TrustDomainID)
+	s.Require().Equal("spiffe://domain2.test", out.Bundles[1].TrustDomainID)
+	s.Require().Contains(string(out.Bundles[1].Bundle), `"x": "HxVuaUnxgi431G5D3g9hqeaQhEbsyQZXmaas7qsUC_c"`)
+}
+
+func (s *BundleSuite) TestListWithUnsupportedFormat() {
+	s.Require().Equal(1, s.listCmd.Run([]string{"-format", "der"}))
+	s.Require().Equal("unsupported format \"der\"\n", s.stderr.String())
+}
+
+func (s *BundleSuite) TestCountHelp() {
+	s.countCmd.Help()
+	s.Require().Equal(`Usage of bundle count:
+  -output format
+    	Output format: text or json
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+`, s.stderr.String())
+}
+
+func (s *BundleSuite) TestCount() {
+	s.Require().Equal(0, s.countCmd.Run([]string{}))
+	s.Require().Equal("0 federated bundles\n", s.stdout.String())
+
+	// The bundle for the server trust domain is not counted
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://example.test",
+		RootCas: []*common.Certificate{
+			{DerBytes: s.cert1.Raw},
+		},
+	})
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://domain1.test",
+		RootCas: []*common.Certificate{
+			{DerBytes: s.cert1.Raw},
+		},
+	})
+
+	s.stdout.Reset()
+	s.Require().Equal(0, s.countCmd.Run([]string{}))
+	s.Require().Equal("1 federated bundle\n", s.stdout.String())
+
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://domain2.test",
+		RootCas: []*common.Certificate{
+			{DerBytes: s.cert2.Raw},
+		},
+	})
+
+	s.stdout.Reset()
+	s.Require().Equal(0, s.countCmd.Run([]string{}))
+	s.Require().Equal("2 federated bundles\n", s.stdout.String())
+}
+
+func (s *BundleSuite) TestCountJSONOutput() {
+	s.Require().Equal(0, s.countCmd.Run([]string{"-output", "json"}))
+	s.Require().Equal("{\n  \"count\": 0\n}\n", s.stdout.String())
+}
+
 func (s *BundleSuite) TestDeleteHelp() {
 	s.deleteCmd.Help()
 	s.Require().Equal(`Usage of bundle delete:
@@ -288,6 +516,8 @@ func (s *BundleSuite) TestDeleteHelp() {
     	SPIFFE ID of the trust domain
   -mode string
     	Deletion mode: one of restrict, delete, or dissociate (default "restrict")
+  -output format
+    	Output format: text or json
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
 `, s.stderr.String())
@@ -325,6 +555,18 @@ func (s *BundleSuite) TestDelete() {
 	s.Require().Nil(resp.Bundle)
 }
 
+func (s *BundleSuite) TestDeleteJSONOutput() {
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://domain1.test",
+		RootCas: []*common.Certificate{
+			{DerBytes: s.cert1.Raw},
+		},
+	})
+
+	s.Require().Equal(0, s.deleteCmd.Run([]string{"-id", "spiffe://domain1.test", "-output", "json"}))
+	s.Require().Equal("{\n  \"trust_domain_id\": \"spiffe://domain1.test\"\n}\n", s.stdout.String())
+}
+
 func (s *BundleSuite) TestDeleteWithRestrictMode() {
 	s.createBundle(&common.Bundle{
 		TrustDomainId: "spiffe://domain1.test",
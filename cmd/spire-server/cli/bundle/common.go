@@ -3,6 +3,7 @@ package bundle
 import (
 	"context"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"flag"
 	"fmt"
@@ -12,11 +13,20 @@ import (
 
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/zeebo/errs"
 )
 
+const (
+	// formatPEM is the PEM encoding of the bundle root CA certificates
+	formatPEM = "pem"
+
+	// formatSPIFFE is the SPIFFE bundle (JWKS) encoding of the bundle
+	formatSPIFFE = "spiffe"
+)
+
 var (
 	// this is the default environment used by commands
 	defaultEnv = &env{
@@ -180,6 +190,31 @@ func printCACertsPEM(out io.Writer, caCerts []byte) error {
 	return nil
 }
 
+// validateFormat returns an error if the bundle format is not supported.
+func validateFormat(format string) error {
+	switch format {
+	case formatPEM, formatSPIFFE:
+		return nil
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// printBundleWithFormat prints the bundle in the given format, optionally
+// preceded by a header with the trust domain ID.
+func printBundleWithFormat(out io.Writer, bundle *common.Bundle, format string, header bool) error {
+	if format == formatSPIFFE {
+		return printBundle(out, bundle, header)
+	}
+
+	if header {
+		if _, err := fmt.Fprintf(out, headerFmt, bundle.TrustDomainId); err != nil {
+			return err
+		}
+	}
+	return printCertificates(out, bundle.RootCas)
+}
+
 func parseBundle(trustDomainID string, jwksBytes []byte) (*common.Bundle, error) {
 	bundle, err := bundleutil.Unmarshal(trustDomainID, jwksBytes)
 	if err != nil {
@@ -188,6 +223,38 @@ func parseBundle(trustDomainID string, jwksBytes []byte) (*common.Bundle, error)
 	return bundle.Proto(), nil
 }
 
+// bundleJSON is the JSON output of the commands for a bundle, which holds
+// the bundle as a SPIFFE bundle document whatever the -format flag.
+type bundleJSON struct {
+	TrustDomainID string          `json:"trust_domain_id"`
+	Bundle        json.RawMessage `json:"bundle"`
+}
+
+func newBundleJSON(bundle *common.Bundle) (*bundleJSON, error) {
+	b, err := bundleutil.BundleFromProto(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	docBytes, err := bundleutil.Marshal(b)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	return &bundleJSON{
+		TrustDomainID: bundle.TrustDomainId,
+		Bundle:        docBytes,
+	}, nil
+}
+
+func printBundleJSON(out io.Writer, bundle *common.Bundle) error {
+	b, err := newBundleJSON(bundle)
+	if err != nil {
+		return err
+	}
+	return common_cli.PrintJSON(out, b)
+}
+
 func printBundle(out io.Writer, bundle *common.Bundle, header bool) error {
 	if header {
 		if _, err := fmt.Fprintf(out, headerFmt, bundle.TrustDomainId); err != nil {
@@ -0,0 +1,54 @@
+package bundle
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// NewCountCommand creates a new "count" subcommand for "bundle" command.
+func NewCountCommand() cli.Command {
+	return newCountCommand(defaultEnv, newClients)
+}
+
+func newCountCommand(env *env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(countCommand))
+}
+
+type countCommand struct {
+	// Output format of the command
+	output common_cli.OutputFlag
+}
+
+func (c *countCommand) name() string {
+	return "bundle count"
+}
+
+func (c *countCommand) synopsis() string {
+	return "Counts federated bundles"
+}
+
+func (c *countCommand) appendFlags(fs *flag.FlagSet) {
+	c.output.AddFlag(fs)
+}
+
+func (c *countCommand) run(ctx context.Context, env *env, clients *clients) error {
+	resp, err := clients.r.CountFederatedBundles(ctx, &common.Empty{})
+	if err != nil {
+		return err
+	}
+
+	if c.output.JSON() {
+		return common_cli.PrintJSON(env.stdout, struct {
+			Count int32 `json:"count"`
+		}{Count: resp.Count})
+	}
+
+	msg := fmt.Sprintf("%d federated ", resp.Count)
+	return env.Println(util.Pluralizer(msg, "bundle", "bundles", int(resp.Count)))
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 )
@@ -32,6 +33,8 @@ type deleteCommand struct {
 
 	// Deletion mode.
 	mode string
+	// Output format of the command
+	output common_cli.OutputFlag
 }
 
 func (c *deleteCommand) name() string {
@@ -45,6 +48,7 @@ func (c *deleteCommand) synopsis() string {
 func (c *deleteCommand) appendFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.id, "id", "", "SPIFFE ID of the trust domain")
 	fs.StringVar(&c.mode, "mode", deleteBundleRestrict, fmt.Sprintf("Deletion mode: one of %s, %s, or %s", deleteBundleRestrict, deleteBundleDelete, deleteBundleDissociate))
+	c.output.AddFlag(fs)
 }
 
 func (c *deleteCommand) run(ctx context.Context, env *env, clients *clients) error {
@@ -69,6 +73,11 @@ func (c *deleteCommand) run(ctx context.Context, env *env, clients *clients) err
 		return err
 	}
 
+	if c.output.JSON() {
+		return common_cli.PrintJSON(env.stdout, struct {
+			TrustDomainID string `json:"trust_domain_id"`
+		}{TrustDomainID: id})
+	}
 	return env.Println("bundle deleted.")
 }
 
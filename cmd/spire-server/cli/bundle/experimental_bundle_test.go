@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/x509"
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -119,6 +120,8 @@ func (s *ExperimentalBundleSuite) AfterTest(suiteName, testName string) {
 func (s *ExperimentalBundleSuite) TestShowHelp() {
 	s.showCmd.Help()
 	s.Require().Equal(`Usage of experimental bundle show:
+  -output format
+    	Output format: text or json
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
 `, s.stderr.String())
@@ -153,11 +156,28 @@ func (s *ExperimentalBundleSuite) TestShow() {
 `, s.stdout.String())
 }
 
+func (s *ExperimentalBundleSuite) TestShowJSONOutput() {
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://example.test",
+		RootCas: []*common.Certificate{
+			{DerBytes: s.cert1.Raw},
+		},
+	})
+
+	s.Require().Equal(0, s.showCmd.Run([]string{"-output", "json"}))
+
+	out := s.decodeBundleJSON()
+	s.Require().Equal("spiffe://example.test", out.TrustDomainID)
+	s.Require().Contains(string(out.Bundle), `"x": "fK-wKTnKL7KFLM27lqq5DC-bxrVaH6rDV-IcCSEOeL4"`)
+}
+
 func (s *ExperimentalBundleSuite) TestSetHelp() {
 	s.setCmd.Help()
 	s.Require().Equal(`Usage of experimental bundle set:
   -id string
     	SPIFFE ID of the trust domain
+  -output format
+    	Output format: text or json
   -path string
     	Path to the bundle data
   -registrationUDSPath string
@@ -181,6 +201,15 @@ func (s *ExperimentalBundleSuite) TestSetUpdatesBundle() {
 	s.assertBundleSet("-id", "spiffe://otherdomain.test")
 }
 
+func (s *ExperimentalBundleSuite) TestSetJSONOutput() {
+	s.stdin.WriteString(otherDomainJWKS)
+	s.Require().Equal(0, s.setCmd.Run([]string{"-id", "spiffe://otherdomain.test", "-output", "json"}))
+
+	out := s.decodeBundleJSON()
+	s.Require().Equal("spiffe://otherdomain.test", out.TrustDomainID)
+	s.Require().Contains(string(out.Bundle), `"kid": "KID"`)
+}
+
 func (s *ExperimentalBundleSuite) TestSetRequiresIDFlag() {
 	rc := s.setCmd.Run([]string{})
 	s.Require().Equal(1, rc)
@@ -209,6 +238,8 @@ func (s *ExperimentalBundleSuite) TestListHelp() {
 	s.Require().Equal(`Usage of experimental bundle list:
   -id string
     	SPIFFE ID of the trust domain
+  -output format
+    	Output format: text or json
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
 `, s.stderr.String())
@@ -312,6 +343,47 @@ func (s *ExperimentalBundleSuite) TestListOne() {
 `, s.stdout.String())
 }
 
+func (s *ExperimentalBundleSuite) TestListJSONOutput() {
+	s.Require().Equal(0, s.listCmd.Run([]string{"-output", "json"}))
+	s.Require().Equal("{\n  \"bundles\": []\n}\n", s.stdout.String())
+
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://domain1.test",
+		RootCas: []*common.Certificate{
+			{DerBytes: s.cert1.Raw},
+		},
+	})
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://domain2.test",
+		RootCas: []*common.Certificate{
+			{DerBytes: s.cert2.Raw},
+		},
+	})
+
+	s.stdout.Reset()
+	s.Require().Equal(0, s.listCmd.Run([]string{"-output", "json"}))
+
+	var out struct {
+		Bundles []bundleJSON `json:"bundles"`
+	}
+	s.Require().NoError(json.Unmarshal(s.stdout.Bytes(), &out))
+	s.Require().Len(out.Bundles, 2)
+	s.Require().Equal("spiffe://domain1.test", out.Bundles[0].TrustDomainID)
+	s.Require().Equal("spiffe://domain2.test", out.Bundles[1].TrustDomainID)
+
+	s.stdout.Reset()
+	s.Require().Equal(0, s.listCmd.Run([]string{"-id", "spiffe://domain2.test", "-output", "json"}))
+	one := s.decodeBundleJSON()
+	s.Require().Equal("spiffe://domain2.test", one.TrustDomainID)
+	s.Require().Contains(string(one.Bundle), `"x": "HxVuaUnxgi431G5D3g9hqeaQhEbsyQZXmaas7qsUC_c"`)
+}
+
+func (s *ExperimentalBundleSuite) decodeBundleJSON() bundleJSON {
+	var out bundleJSON
+	s.Require().NoError(json.Unmarshal(s.stdout.Bytes(), &out))
+	return out
+}
+
 func (s *ExperimentalBundleSuite) assertBundleSet(args ...string) {
 	rc := s.setCmd.Run(args)
 	s.Require().Equal(0, rc)
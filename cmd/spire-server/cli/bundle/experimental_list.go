@@ -2,10 +2,12 @@ package bundle
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"io"
 
 	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -23,6 +25,9 @@ func newExperimentalListCommand(env *env, clientsMaker clientsMaker) cli.Command
 type experimentalListCommand struct {
 	// SPIFFE ID of the trust bundle
 	id string
+
+	// Output format of the command
+	output common_cli.OutputFlag
 }
 
 func (c *experimentalListCommand) name() string {
@@ -35,6 +40,7 @@ func (c *experimentalListCommand) synopsis() string {
 
 func (c *experimentalListCommand) appendFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.id, "id", "", "SPIFFE ID of the trust domain")
+	c.output.AddFlag(fs)
 }
 
 func (c *experimentalListCommand) run(ctx context.Context, env *env, clients *clients) error {
@@ -49,6 +55,9 @@ func (c *experimentalListCommand) run(ctx context.Context, env *env, clients *cl
 		if err != nil {
 			return err
 		}
+		if c.output.JSON() {
+			return printBundleJSON(env.stdout, bundle.Bundle)
+		}
 		return printBundle(env.stdout, bundle.Bundle, false)
 	}
 
@@ -57,14 +66,28 @@ func (c *experimentalListCommand) run(ctx context.Context, env *env, clients *cl
 		return err
 	}
 
+	bundles := []*bundleJSON{}
 	for i := 0; ; i++ {
-		bundle, err := stream.Recv()
+		resp, err := stream.Recv()
 		if err != nil {
 			if err == io.EOF {
 				break
 			}
 			return err
 		}
+		bundle := resp.Bundle
+		if bundle == nil {
+			return errors.New("response missing bundle")
+		}
+
+		if c.output.JSON() {
+			b, err := newBundleJSON(bundle)
+			if err != nil {
+				return err
+			}
+			bundles = append(bundles, b)
+			continue
+		}
 
 		if i != 0 {
 			if err := env.Println(); err != nil {
@@ -72,9 +95,15 @@ func (c *experimentalListCommand) run(ctx context.Context, env *env, clients *cl
 			}
 		}
 
-		if err := printBundle(env.stdout, bundle.Bundle, true); err != nil {
+		if err := printBundle(env.stdout, bundle, true); err != nil {
 			return err
 		}
 	}
+
+	if c.output.JSON() {
+		return common_cli.PrintJSON(env.stdout, struct {
+			Bundles []*bundleJSON `json:"bundles"`
+		}{Bundles: bundles})
+	}
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 )
 
@@ -25,6 +26,9 @@ type experimentalSetCommand struct {
 
 	// Path to the bundle on disk (optional). If empty, reads from stdin.
 	path string
+
+	// Output format of the command
+	output common_cli.OutputFlag
 }
 
 func (c *experimentalSetCommand) name() string {
@@ -38,6 +42,7 @@ func (c *experimentalSetCommand) synopsis() string {
 func (c *experimentalSetCommand) appendFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.id, "id", "", "SPIFFE ID of the trust domain")
 	fs.StringVar(&c.path, "path", "", "Path to the bundle data")
+	c.output.AddFlag(fs)
 }
 
 func (c *experimentalSetCommand) run(ctx context.Context, env *env, clients *clients) error {
@@ -74,5 +79,8 @@ func (c *experimentalSetCommand) run(ctx context.Context, env *env, clients *cli
 		return err
 	}
 
+	if c.output.JSON() {
+		return printBundleJSON(env.stdout, bundle)
+	}
 	return env.Println("bundle set.")
 }
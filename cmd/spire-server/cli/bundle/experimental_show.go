@@ -5,6 +5,7 @@ import (
 	"flag"
 
 	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/proto/spire/common"
 )
 
@@ -18,6 +19,8 @@ func newExperimentalShowCommand(env *env, clientsMaker clientsMaker) cli.Command
 }
 
 type experimentalShowCommand struct {
+	// Output format of the command
+	output common_cli.OutputFlag
 }
 
 func (c *experimentalShowCommand) name() string {
@@ -29,6 +32,7 @@ func (c *experimentalShowCommand) synopsis() string {
 }
 
 func (c *experimentalShowCommand) appendFlags(fs *flag.FlagSet) {
+	c.output.AddFlag(fs)
 }
 
 func (c *experimentalShowCommand) run(ctx context.Context, env *env, clients *clients) error {
@@ -36,5 +40,8 @@ func (c *experimentalShowCommand) run(ctx context.Context, env *env, clients *cl
 	if err != nil {
 		return err
 	}
+	if c.output.JSON() {
+		return printBundleJSON(env.stdout, bundle.Bundle)
+	}
 	return printBundle(env.stdout, bundle.Bundle, false)
 }
@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -31,6 +32,11 @@ func newListCommand(env *env, clientsMaker clientsMaker) cli.Command {
 type listCommand struct {
 	// SPIFFE ID of the trust bundle
 	id string
+
+	// Format of the printed bundles (pem or spiffe)
+	format string
+	// Output format of the command
+	output common_cli.OutputFlag
 }
 
 func (c *listCommand) name() string {
@@ -43,9 +49,15 @@ func (c *listCommand) synopsis() string {
 
 func (c *listCommand) appendFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.id, "id", "", "SPIFFE ID of the trust domain")
+	fs.StringVar(&c.format, "format", formatPEM, "The format to list federated bundles. Either \"pem\" or \"spiffe\"")
+	c.output.AddFlag(fs)
 }
 
 func (c *listCommand) run(ctx context.Context, env *env, clients *clients) error {
+	if err := validateFormat(c.format); err != nil {
+		return err
+	}
+
 	if c.id != "" {
 		id, err := idutil.NormalizeSpiffeID(c.id, idutil.AllowAnyTrustDomain())
 		if err != nil {
@@ -57,7 +69,10 @@ func (c *listCommand) run(ctx context.Context, env *env, clients *clients) error
 		if err != nil {
 			return err
 		}
-		return printCertificates(env.stdout, resp.Bundle.RootCas)
+		if c.output.JSON() {
+			return printBundleJSON(env.stdout, resp.Bundle)
+		}
+		return printBundleWithFormat(env.stdout, resp.Bundle, c.format, false)
 	}
 
 	stream, err := clients.r.ListFederatedBundles(ctx, &common.Empty{})
@@ -65,6 +80,7 @@ func (c *listCommand) run(ctx context.Context, env *env, clients *clients) error
 		return err
 	}
 
+	bundles := []*bundleJSON{}
 	for i := 0; ; i++ {
 		resp, err := stream.Recv()
 		if err != nil {
@@ -78,18 +94,30 @@ func (c *listCommand) run(ctx context.Context, env *env, clients *clients) error
 			return errors.New("response missing bundle")
 		}
 
+		if c.output.JSON() {
+			b, err := newBundleJSON(bundle)
+			if err != nil {
+				return err
+			}
+			bundles = append(bundles, b)
+			continue
+		}
+
 		if i != 0 {
 			if err := env.Println(); err != nil {
 				return err
 			}
 		}
 
-		if err := env.Printf(headerFmt, bundle.TrustDomainId); err != nil {
-			return err
-		}
-		if err := printCertificates(env.stdout, bundle.RootCas); err != nil {
+		if err := printBundleWithFormat(env.stdout, bundle, c.format, true); err != nil {
 			return err
 		}
 	}
+
+	if c.output.JSON() {
+		return common_cli.PrintJSON(env.stdout, struct {
+			Bundles []*bundleJSON `json:"bundles"`
+		}{Bundles: bundles})
+	}
 	return nil
 }
@@ -8,9 +8,11 @@ import (
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
 )
 
 // NewSetCommand creates a new "set" subcommand for "bundle" command.
@@ -28,6 +30,11 @@ type setCommand struct {
 
 	// Path to the bundle on disk (optional). If empty, reads from stdin.
 	path string
+
+	// Format of the bundle data (pem or spiffe)
+	format string
+	// Output format of the command
+	output common_cli.OutputFlag
 }
 
 func (c *setCommand) name() string {
@@ -41,29 +48,34 @@ func (c *setCommand) synopsis() string {
 func (c *setCommand) appendFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.id, "id", "", "SPIFFE ID of the trust domain")
 	fs.StringVar(&c.path, "path", "", "Path to the bundle data")
+	fs.StringVar(&c.format, "format", formatPEM, "The format of the bundle data. Either \"pem\" or \"spiffe\"")
+	c.output.AddFlag(fs)
 }
 
 func (c *setCommand) run(ctx context.Context, env *env, clients *clients) error {
 	if c.id == "" {
 		return errors.New("id is required")
 	}
+	if err := validateFormat(c.format); err != nil {
+		return err
+	}
 	id, err := idutil.NormalizeSpiffeID(c.id, idutil.AllowAnyTrustDomain())
 	if err != nil {
 		return err
 	}
 
-	rootCAsPEM, err := loadParamData(env.stdin, c.path)
+	bundleData, err := loadParamData(env.stdin, c.path)
 	if err != nil {
 		return fmt.Errorf("unable to load bundle data: %v", err)
 	}
 
-	rootCAs, err := pemutil.ParseCertificates(rootCAsPEM)
+	bundleProto, err := c.parseBundleData(id, bundleData)
 	if err != nil {
 		return fmt.Errorf("unable to parse bundle data: %v", err)
 	}
 
 	bundle := &registration.FederatedBundle{
-		Bundle: bundleutil.BundleProtoFromRootCAs(id, rootCAs),
+		Bundle: bundleProto,
 	}
 
 	// pull the existing bundle to know if this should be a create or a update.
@@ -82,5 +94,20 @@ func (c *setCommand) run(ctx context.Context, env *env, clients *clients) error
 		return err
 	}
 
+	if c.output.JSON() {
+		return printBundleJSON(env.stdout, bundleProto)
+	}
 	return env.Println("bundle set.")
 }
+
+func (c *setCommand) parseBundleData(id string, bundleData []byte) (*common.Bundle, error) {
+	if c.format == formatSPIFFE {
+		return parseBundle(id, bundleData)
+	}
+
+	rootCAs, err := pemutil.ParseCertificates(bundleData)
+	if err != nil {
+		return nil, err
+	}
+	return bundleutil.BundleProtoFromRootCAs(id, rootCAs), nil
+}
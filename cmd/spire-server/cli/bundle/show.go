@@ -5,6 +5,7 @@ import (
 	"flag"
 
 	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/proto/spire/common"
 )
 
@@ -18,6 +19,10 @@ func newShowCommand(env *env, clientsMaker clientsMaker) cli.Command {
 }
 
 type showCommand struct {
+	// Format of the printed bundle (pem or spiffe)
+	format string
+	// Output format of the command
+	output common_cli.OutputFlag
 }
 
 func (c *showCommand) name() string {
@@ -29,12 +34,21 @@ func (c *showCommand) synopsis() string {
 }
 
 func (c *showCommand) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.format, "format", formatPEM, "The format to show the bundle. Either \"pem\" or \"spiffe\"")
+	c.output.AddFlag(fs)
 }
 
 func (c *showCommand) run(ctx context.Context, env *env, clients *clients) error {
+	if err := validateFormat(c.format); err != nil {
+		return err
+	}
+
 	resp, err := clients.r.FetchBundle(ctx, &common.Empty{})
 	if err != nil {
 		return err
 	}
-	return printCertificates(env.stdout, resp.Bundle.RootCas)
+	if c.output.JSON() {
+		return printBundleJSON(env.stdout, resp.Bundle)
+	}
+	return printBundleWithFormat(env.stdout, resp.Bundle, c.format, false)
 }
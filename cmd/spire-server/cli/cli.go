@@ -6,6 +6,7 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-server/cli/agent"
 	"github.com/spiffe/spire/cmd/spire-server/cli/bundle"
+	"github.com/spiffe/spire/cmd/spire-server/cli/datastore"
 	"github.com/spiffe/spire/cmd/spire-server/cli/entry"
 	"github.com/spiffe/spire/cmd/spire-server/cli/healthcheck"
 	"github.com/spiffe/spire/cmd/spire-server/cli/jwt"
@@ -13,6 +14,7 @@ import (
 	"github.com/spiffe/spire/cmd/spire-server/cli/token"
 	"github.com/spiffe/spire/cmd/spire-server/cli/validate"
 	"github.com/spiffe/spire/cmd/spire-server/cli/x509"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/version"
 )
@@ -25,6 +27,12 @@ func (cc *CLI) Run(args []string) int {
 	c := cli.NewCLI("spire-server", version.Version())
 	c.Args = args
 	c.Commands = map[string]cli.CommandFactory{
+		"agent ban": func() (cli.Command, error) {
+			return &agent.BanCLI{}, nil
+		},
+		"agent count": func() (cli.Command, error) {
+			return &agent.CountCLI{}, nil
+		},
 		"agent evict": func() (cli.Command, error) {
 			return &agent.EvictCLI{}, nil
 		},
@@ -34,6 +42,12 @@ func (cc *CLI) Run(args []string) int {
 		"agent show": func() (cli.Command, error) {
 			return &agent.ShowCLI{}, nil
 		},
+		"agent prune": func() (cli.Command, error) {
+			return &agent.PruneCLI{}, nil
+		},
+		"agent rotate": func() (cli.Command, error) {
+			return &agent.RotateCLI{}, nil
+		},
 		"bundle show": func() (cli.Command, error) {
 			return bundle.NewShowCommand(), nil
 		},
@@ -46,6 +60,9 @@ func (cc *CLI) Run(args []string) int {
 		"bundle delete": func() (cli.Command, error) {
 			return bundle.NewDeleteCommand(), nil
 		},
+		"bundle count": func() (cli.Command, error) {
+			return bundle.NewCountCommand(), nil
+		},
 		"experimental bundle show": func() (cli.Command, error) {
 			return bundle.NewExperimentalShowCommand(), nil
 		},
@@ -55,6 +72,9 @@ func (cc *CLI) Run(args []string) int {
 		"experimental bundle set": func() (cli.Command, error) {
 			return bundle.NewExperimentalSetCommand(), nil
 		},
+		"datastore migrate": func() (cli.Command, error) {
+			return datastore.NewMigrateCommand(), nil
+		},
 		"entry create": func() (cli.Command, error) {
 			return &entry.CreateCLI{}, nil
 		},
@@ -67,6 +87,12 @@ func (cc *CLI) Run(args []string) int {
 		"entry show": func() (cli.Command, error) {
 			return &entry.ShowCLI{}, nil
 		},
+		"entry count": func() (cli.Command, error) {
+			return &entry.CountCLI{}, nil
+		},
+		"migrate trust-domain": func() (cli.Command, error) {
+			return datastore.NewMigrateTrustDomainCommand(), nil
+		},
 		"run": func() (cli.Command, error) {
 			return run.NewRunCommand(cc.LogOptions), nil
 		},
@@ -76,6 +102,9 @@ func (cc *CLI) Run(args []string) int {
 		"healthcheck": func() (cli.Command, error) {
 			return healthcheck.NewHealthCheckCommand(), nil
 		},
+		"x509 list": func() (cli.Command, error) {
+			return x509.NewListCommand(), nil
+		},
 		"x509 mint": func() (cli.Command, error) {
 			return x509.NewMintCommand(), nil
 		},
@@ -86,6 +115,9 @@ func (cc *CLI) Run(args []string) int {
 			return validate.NewValidateCommand(), nil
 		},
 	}
+	c.Commands["completion"] = func() (cli.Command, error) {
+		return common_cli.NewCompletionCommand(newCompleter(c.Commands)), nil
+	}
 
 	exitStatus, err := c.Run()
 	if err != nil {
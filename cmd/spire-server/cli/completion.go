@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"sort"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// newCompleter returns the completer of the commands. Entry IDs, agent SPIFFE
+// IDs and federated trust domains are completed from the registration API.
+func newCompleter(commands map[string]cli.CommandFactory) *common_cli.Completer {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &common_cli.Completer{
+		Name:     "spire-server",
+		Commands: names,
+		Values: map[string]common_cli.CompleteFunc{
+			"-output":                       common_cli.CompleteValues(common_cli.OutputText, common_cli.OutputJSON),
+			"-logFormat":                    common_cli.CompleteValues("text", "json"),
+			"-logLevel":                     common_cli.CompleteValues("DEBUG", "INFO", "WARN", "ERROR"),
+			"-federatesWith":                completeTrustDomains,
+			"agent ban -spiffeID":           completeAgents,
+			"agent evict -spiffeID":         completeAgents,
+			"agent show -spiffeID":          completeAgents,
+			"agent list -banned":            common_cli.CompleteValues("true", "false"),
+			"agent count -banned":           common_cli.CompleteValues("true", "false"),
+			"agent list -matchSelectorsOn":  common_cli.CompleteValues("exact", "subset"),
+			"agent count -matchSelectorsOn": common_cli.CompleteValues("exact", "subset"),
+			"bundle show -format":           common_cli.CompleteValues("pem", "spiffe"),
+			"bundle list -format":           common_cli.CompleteValues("pem", "spiffe"),
+			"bundle set -format":            common_cli.CompleteValues("pem", "spiffe"),
+			"bundle list -id":               completeTrustDomains,
+			"bundle set -id":                completeTrustDomains,
+			"bundle delete -id":             completeTrustDomains,
+			"bundle delete -mode":           common_cli.CompleteValues("restrict", "dissociate", "delete"),
+			"experimental bundle list -id":  completeTrustDomains,
+			"entry show -entryID":           completeEntries,
+			"entry update -entryID":         completeEntries,
+			"entry delete -entryID":         completeEntries,
+		},
+	}
+}
+
+func completionClient(args []string) (registration.RegistrationClient, error) {
+	socketPath := common_cli.LookupFlag(args, "registrationUDSPath")
+	if socketPath == "" {
+		socketPath = util.DefaultSocketPath
+	}
+	return util.NewRegistrationClient(socketPath)
+}
+
+func completeEntries(ctx context.Context, args []string) []string {
+	client, err := completionClient(args)
+	if err != nil {
+		return nil
+	}
+	resp, err := client.FetchEntries(ctx, &common.Empty{})
+	if err != nil {
+		return nil
+	}
+	var ids []string
+	for _, entry := range resp.Entries {
+		ids = append(ids, entry.EntryId)
+	}
+	return ids
+}
+
+func completeAgents(ctx context.Context, args []string) []string {
+	client, err := completionClient(args)
+	if err != nil {
+		return nil
+	}
+	resp, err := client.ListAgents(ctx, &registration.ListAgentsRequest{})
+	if err != nil {
+		return nil
+	}
+	var ids []string
+	for _, node := range resp.Nodes {
+		ids = append(ids, node.SpiffeId)
+	}
+	return ids
+}
+
+func completeTrustDomains(ctx context.Context, args []string) []string {
+	client, err := completionClient(args)
+	if err != nil {
+		return nil
+	}
+	stream, err := client.ListFederatedBundles(ctx, &common.Empty{})
+	if err != nil {
+		return nil
+	}
+	var ids []string
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return ids
+		}
+		if err != nil {
+			return nil
+		}
+		if resp.Bundle != nil {
+			ids = append(ids, resp.Bundle.TrustDomainId)
+		}
+	}
+}
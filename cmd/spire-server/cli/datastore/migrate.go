@@ -0,0 +1,160 @@
+package datastore
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/hcl/printer"
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/cli/run"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore/sql"
+)
+
+func NewMigrateCommand() cli.Command {
+	return newMigrateCommand(common_cli.DefaultEnv)
+}
+
+func newMigrateCommand(env *common_cli.Env) *migrateCommand {
+	return &migrateCommand{
+		env: env,
+	}
+}
+
+type migrateCommand struct {
+	env *common_cli.Env
+
+	configPath string
+	expandEnv  bool
+	dryRun     bool
+	output     common_cli.OutputFlag
+}
+
+func (c *migrateCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *migrateCommand) Synopsis() string {
+	return "Migrates the SQL datastore schema to the version supported by this server"
+}
+
+func (c *migrateCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(); err != nil {
+		// Ignore error since a failure to write to stderr cannot very well be
+		// reported
+		_ = c.env.ErrPrintf("Datastore migration failed: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *migrateCommand) parseFlags(args []string) error {
+	fs := flag.NewFlagSet("datastore migrate", flag.ContinueOnError)
+	fs.SetOutput(c.env.Stderr)
+	fs.StringVar(&c.configPath, "config", "", "Path to a SPIRE config file")
+	fs.BoolVar(&c.expandEnv, "expandEnv", false, "Expand environment variables in SPIRE config file")
+	fs.BoolVar(&c.dryRun, "dryRun", false, "Print the pending migrations without running them")
+	c.output.AddFlag(fs)
+	return fs.Parse(args)
+}
+
+func (c *migrateCommand) run() error {
+	config, err := run.ParseFile(c.configPath, c.expandEnv)
+	if err != nil {
+		return err
+	}
+
+	pluginData, err := sqlPluginData(config)
+	if err != nil {
+		return err
+	}
+
+	log := hclog.New(&hclog.LoggerOptions{
+		Name:   "datastore",
+		Output: c.env.Stderr,
+		Level:  hclog.Warn,
+	})
+
+	plan, err := sql.Migrate(pluginData, c.dryRun, log)
+	if err != nil {
+		return err
+	}
+
+	if c.output.JSON() {
+		return common_cli.PrintJSON(c.env.Stdout, newPlanJSON(plan, c.dryRun))
+	}
+	return c.printPlan(plan)
+}
+
+// planJSON is the JSON output of the command
+type planJSON struct {
+	DryRun        bool  `json:"dry_run"`
+	NewDatabase   bool  `json:"new_database"`
+	SchemaVersion int   `json:"schema_version"`
+	TargetVersion int   `json:"target_version"`
+	Pending       []int `json:"pending"`
+}
+
+func newPlanJSON(plan *sql.MigrationPlan, dryRun bool) planJSON {
+	return planJSON{
+		DryRun:        dryRun,
+		NewDatabase:   plan.NewDatabase,
+		SchemaVersion: plan.SchemaVersion,
+		TargetVersion: plan.TargetVersion,
+		Pending:       append([]int{}, plan.Pending()...),
+	}
+}
+
+func (c *migrateCommand) printPlan(plan *sql.MigrationPlan) error {
+	switch {
+	case plan.NewDatabase && c.dryRun:
+		return c.env.Printf("The database will be initialized at schema version %d.\n", plan.TargetVersion)
+	case plan.NewDatabase:
+		return c.env.Printf("Initialized the database at schema version %d.\n", plan.TargetVersion)
+	case plan.SchemaVersion > plan.TargetVersion:
+		return c.env.Printf("The database schema version %d is newer than the version %d supported by this server.\n", plan.SchemaVersion, plan.TargetVersion)
+	case plan.SchemaVersion == plan.TargetVersion:
+		return c.env.Printf("The database is up to date at schema version %d.\n", plan.SchemaVersion)
+	}
+
+	if c.dryRun {
+		if err := c.env.Printf("Pending migrations for schema version %d:\n", plan.SchemaVersion); err != nil {
+			return err
+		}
+		from := plan.SchemaVersion
+		for _, to := range plan.Pending() {
+			if err := c.env.Printf("  %d -> %d\n", from, to); err != nil {
+				return err
+			}
+			from = to
+		}
+		return nil
+	}
+	return c.env.Printf("Migrated the database from schema version %d to %d.\n", plan.SchemaVersion, plan.TargetVersion)
+}
+
+// sqlPluginData returns the plugin data of the "sql" DataStore plugin
+func sqlPluginData(config *run.Config) (string, error) {
+	if config.Plugins == nil {
+		return "", errors.New("configuration does not contain any plugins")
+	}
+
+	pluginConfig, ok := (*config.Plugins)["DataStore"]["sql"]
+	if !ok {
+		return "", errors.New(`configuration does not contain a "sql" DataStore plugin`)
+	}
+
+	var data bytes.Buffer
+	if err := printer.DefaultConfig.Fprint(&data, pluginConfig.PluginData); err != nil {
+		return "", fmt.Errorf("unable to read DataStore plugin data: %v", err)
+	}
+	return data.String(), nil
+}
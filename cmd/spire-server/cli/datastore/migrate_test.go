@@ -0,0 +1,111 @@
+package datastore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-server-cli-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	configPath := filepath.Join(dir, "server.conf")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(fmt.Sprintf(`
+plugins {
+	DataStore "sql" {
+		plugin_data {
+			database_type = "sqlite3"
+			connection_string = "%s"
+			require_manual_migration = true
+		}
+	}
+}
+`, filepath.Join(dir, "datastore.sqlite3"))), 0600))
+
+	stdout, stderr, code := runMigrate("-config", configPath, "-dryRun")
+	require.Equal(t, 0, code, stderr)
+	require.Regexp(t, `^The database will be initialized at schema version \d+\.\n$`, stdout)
+
+	stdout, stderr, code = runMigrate("-config", configPath)
+	require.Equal(t, 0, code, stderr)
+	require.Regexp(t, `^Initialized the database at schema version \d+\.\n$`, stdout)
+
+	stdout, stderr, code = runMigrate("-config", configPath, "-dryRun")
+	require.Equal(t, 0, code, stderr)
+	require.Regexp(t, `^The database is up to date at schema version \d+\.\n$`, stdout)
+}
+
+func TestMigrateJSONOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-server-cli-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	configPath := filepath.Join(dir, "server.conf")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(fmt.Sprintf(`
+plugins {
+	DataStore "sql" {
+		plugin_data {
+			database_type = "sqlite3"
+			connection_string = %q
+		}
+	}
+}
+`, filepath.Join(dir, "datastore.sqlite3"))), 0600))
+
+	stdout, stderr, code := runMigrate("-config", configPath, "-dryRun", "-output", "json")
+	require.Equal(t, 0, code, stderr)
+	var plan planJSON
+	require.NoError(t, json.Unmarshal([]byte(stdout), &plan))
+	require.True(t, plan.DryRun)
+	require.True(t, plan.NewDatabase)
+	require.NotZero(t, plan.TargetVersion)
+	require.Equal(t, []int{}, plan.Pending)
+}
+
+func TestMigrateWithoutSQLDataStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-server-cli-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	configPath := filepath.Join(dir, "server.conf")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(`
+plugins {
+	KeyManager "memory" {
+		plugin_data {}
+	}
+}
+`), 0600))
+
+	stdout, stderr, code := runMigrate("-config", configPath)
+	require.Equal(t, 1, code)
+	require.Empty(t, stdout)
+	require.Equal(t, "Datastore migration failed: configuration does not contain a \"sql\" DataStore plugin\n", stderr)
+}
+
+func TestMigrateBadFlags(t *testing.T) {
+	stdout, stderr, code := runMigrate("-badflag")
+	require.Equal(t, 1, code)
+	require.Empty(t, stdout)
+	require.Contains(t, stderr, "flag provided but not defined: -badflag")
+}
+
+func runMigrate(args ...string) (string, string, int) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newMigrateCommand(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	code := cmd.Run(args)
+	return stdout.String(), stderr.String(), code
+}
@@ -0,0 +1,200 @@
+package datastore
+
+import (
+	"errors"
+	"flag"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/cli/run"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore/sql"
+)
+
+func NewMigrateTrustDomainCommand() cli.Command {
+	return newMigrateTrustDomainCommand(common_cli.DefaultEnv)
+}
+
+func newMigrateTrustDomainCommand(env *common_cli.Env) *migrateTrustDomainCommand {
+	return &migrateTrustDomainCommand{
+		env: env,
+	}
+}
+
+type migrateTrustDomainCommand struct {
+	env *common_cli.Env
+
+	configPath string
+	expandEnv  bool
+	from       string
+	to         string
+	dryRun     bool
+	output     common_cli.OutputFlag
+}
+
+func (c *migrateTrustDomainCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *migrateTrustDomainCommand) Synopsis() string {
+	return "Moves registration entries, attested nodes and bundles of the SQL datastore to another trust domain"
+}
+
+func (c *migrateTrustDomainCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(); err != nil {
+		// Ignore error since a failure to write to stderr cannot very well be
+		// reported
+		_ = c.env.ErrPrintf("Trust domain migration failed: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *migrateTrustDomainCommand) parseFlags(args []string) error {
+	fs := flag.NewFlagSet("migrate trust-domain", flag.ContinueOnError)
+	fs.SetOutput(c.env.Stderr)
+	fs.StringVar(&c.configPath, "config", "", "Path to a SPIRE config file")
+	fs.BoolVar(&c.expandEnv, "expandEnv", false, "Expand environment variables in SPIRE config file")
+	fs.StringVar(&c.from, "from", "", "The trust domain to migrate from. Defaults to the trust domain of the SPIRE config file")
+	fs.StringVar(&c.to, "to", "", "The trust domain to migrate to")
+	fs.BoolVar(&c.dryRun, "dryRun", false, "Print the changes without making them")
+	c.output.AddFlag(fs)
+	return fs.Parse(args)
+}
+
+func (c *migrateTrustDomainCommand) run() error {
+	if c.to == "" {
+		return errors.New("a trust domain to migrate to is required")
+	}
+
+	config, err := run.ParseFile(c.configPath, c.expandEnv)
+	if err != nil {
+		return err
+	}
+
+	from := c.from
+	if from == "" && config.Server != nil {
+		from = config.Server.TrustDomain
+	}
+	if from == "" {
+		return errors.New("a trust domain to migrate from is required")
+	}
+
+	pluginData, err := sqlPluginData(config)
+	if err != nil {
+		return err
+	}
+
+	log := hclog.New(&hclog.LoggerOptions{
+		Name:   "datastore",
+		Output: c.env.Stderr,
+		Level:  hclog.Warn,
+	})
+
+	migration, err := sql.MigrateTrustDomain(pluginData, from, c.to, c.dryRun, log)
+	if err != nil {
+		return err
+	}
+
+	if c.output.JSON() {
+		return common_cli.PrintJSON(c.env.Stdout, newTrustDomainMigrationJSON(migration, c.dryRun))
+	}
+	return c.printMigration(migration)
+}
+
+func (c *migrateTrustDomainCommand) printMigration(migration *sql.TrustDomainMigration) error {
+	verb := "Migrated"
+	if c.dryRun {
+		verb = "Would migrate"
+	}
+	if err := c.env.Printf("%s %d registration entries, %d attested nodes and %d bundles from %s to %s.\n",
+		verb, len(migration.Entries), len(migration.Nodes), len(migration.Bundles), migration.From, migration.To); err != nil {
+		return err
+	}
+	if !c.dryRun {
+		return nil
+	}
+
+	for _, entry := range migration.Entries {
+		if err := c.env.Printf("  entry %s\n", entry.EntryID); err != nil {
+			return err
+		}
+		if err := c.printRename("SPIFFE ID", entry.SpiffeID); err != nil {
+			return err
+		}
+		if err := c.printRename("Parent ID", entry.ParentID); err != nil {
+			return err
+		}
+	}
+	for _, node := range migration.Nodes {
+		if err := c.env.Printf("  node %s -> %s\n", node.Old, node.New); err != nil {
+			return err
+		}
+	}
+	for _, bundle := range migration.Bundles {
+		if err := c.env.Printf("  bundle %s -> %s\n", bundle.Old, bundle.New); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *migrateTrustDomainCommand) printRename(field string, rename sql.IDRename) error {
+	if rename.Old == rename.New {
+		return nil
+	}
+	return c.env.Printf("    %-9s : %s -> %s\n", field, rename.Old, rename.New)
+}
+
+// trustDomainMigrationJSON is the JSON output of the command
+type trustDomainMigrationJSON struct {
+	DryRun  bool              `json:"dry_run"`
+	From    string            `json:"from"`
+	To      string            `json:"to"`
+	Entries []entryRenameJSON `json:"entries"`
+	Nodes   []idRenameJSON    `json:"nodes"`
+	Bundles []idRenameJSON    `json:"bundles"`
+}
+
+type entryRenameJSON struct {
+	EntryID  string       `json:"entry_id"`
+	SpiffeID idRenameJSON `json:"spiffe_id"`
+	ParentID idRenameJSON `json:"parent_id"`
+}
+
+type idRenameJSON struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+func newTrustDomainMigrationJSON(migration *sql.TrustDomainMigration, dryRun bool) trustDomainMigrationJSON {
+	out := trustDomainMigrationJSON{
+		DryRun:  dryRun,
+		From:    migration.From,
+		To:      migration.To,
+		Entries: []entryRenameJSON{},
+		Nodes:   newIDRenamesJSON(migration.Nodes),
+		Bundles: newIDRenamesJSON(migration.Bundles),
+	}
+	for _, entry := range migration.Entries {
+		out.Entries = append(out.Entries, entryRenameJSON{
+			EntryID:  entry.EntryID,
+			SpiffeID: idRenameJSON(entry.SpiffeID),
+			ParentID: idRenameJSON(entry.ParentID),
+		})
+	}
+	return out
+}
+
+func newIDRenamesJSON(renames []sql.IDRename) []idRenameJSON {
+	out := []idRenameJSON{}
+	for _, rename := range renames {
+		out = append(out, idRenameJSON(rename))
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package datastore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateTrustDomain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-server-cli-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	configPath := filepath.Join(dir, "server.conf")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(fmt.Sprintf(`
+server {
+	trust_domain = "old.test"
+}
+
+plugins {
+	DataStore "sql" {
+		plugin_data {
+			database_type = "sqlite3"
+			connection_string = %q
+		}
+	}
+}
+`, filepath.Join(dir, "datastore.sqlite3"))), 0600))
+
+	// the database must be initialized first
+	stdout, stderr, code := runMigrateTrustDomain("-config", configPath, "-to", "new.test")
+	require.Equal(t, 1, code)
+	require.Empty(t, stdout)
+	require.Contains(t, stderr, "Trust domain migration failed: datastore-sql: database schema version 0 is not the version")
+
+	_, stderr, code = runMigrate("-config", configPath)
+	require.Equal(t, 0, code, stderr)
+
+	stdout, stderr, code = runMigrateTrustDomain("-config", configPath, "-to", "new.test", "-dryRun")
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, "Would migrate 0 registration entries, 0 attested nodes and 0 bundles from spiffe://old.test to spiffe://new.test.\n", stdout)
+
+	stdout, stderr, code = runMigrateTrustDomain("-config", configPath, "-from", "other.test", "-to", "new.test")
+	require.Equal(t, 0, code, stderr)
+	require.Equal(t, "Migrated 0 registration entries, 0 attested nodes and 0 bundles from spiffe://other.test to spiffe://new.test.\n", stdout)
+
+	stdout, stderr, code = runMigrateTrustDomain("-config", configPath, "-to", "new.test", "-dryRun", "-output", "json")
+	require.Equal(t, 0, code, stderr)
+	var migration trustDomainMigrationJSON
+	require.NoError(t, json.Unmarshal([]byte(stdout), &migration))
+	require.Equal(t, trustDomainMigrationJSON{
+		DryRun:  true,
+		From:    "spiffe://old.test",
+		To:      "spiffe://new.test",
+		Entries: []entryRenameJSON{},
+		Nodes:   []idRenameJSON{},
+		Bundles: []idRenameJSON{},
+	}, migration)
+}
+
+func TestMigrateTrustDomainWithoutTo(t *testing.T) {
+	stdout, stderr, code := runMigrateTrustDomain("-config", "server.conf")
+	require.Equal(t, 1, code)
+	require.Empty(t, stdout)
+	require.Equal(t, "Trust domain migration failed: a trust domain to migrate to is required\n", stderr)
+}
+
+func runMigrateTrustDomain(args ...string) (string, string, int) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newMigrateTrustDomainCommand(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	code := cmd.Run(args)
+	return stdout.String(), stderr.String(), code
+}
@@ -0,0 +1,173 @@
+package entry
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+
+	"golang.org/x/net/context"
+)
+
+const defaultExpiringWithin = 24 * time.Hour
+
+// CountConfig is a configuration struct for the
+// `spire-server entry count` CLI command
+type CountConfig struct {
+	// Socket path of registration API
+	RegistrationUDSPath string
+
+	// Entries expiring within this duration are counted as expiring soon
+	ExpiringWithin time.Duration
+
+	// Print the number of entries per parent ID and per selector type
+	Stats bool
+
+	// Output format of the command
+	Output common_cli.OutputFlag
+}
+
+// Validate ensures that the values in CountConfig are valid
+func (c *CountConfig) Validate() error {
+	if c.RegistrationUDSPath == "" {
+		return errors.New("a socket path for registration api is required")
+	}
+	if c.ExpiringWithin < time.Second {
+		return errors.New("expiringWithin must be at least one second")
+	}
+	return nil
+}
+
+// CountCLI is a struct which represents an invocation of the
+// `spire-server entry count` CLI command
+type CountCLI struct {
+	Client registration.RegistrationClient
+
+	Statistics *registration.GetEntryStatisticsResponse
+}
+
+// Synopsis prints a description of the CountCLI command
+func (CountCLI) Synopsis() string {
+	return "Counts registration entries"
+}
+
+// Help prints a help message for the CountCLI command
+func (c CountCLI) Help() string {
+	_, err := c.parseConfig([]string{"-h"})
+	return err.Error()
+}
+
+// Run executes all logic associated with a single invocation of the
+// `spire-server entry count` CLI command
+func (c *CountCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := c.parseConfig(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err = config.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if c.Client == nil {
+		c.Client, err = util.NewRegistrationClient(config.RegistrationUDSPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error establishing connection to the Registration API: %v \n", err)
+			return 1
+		}
+	}
+
+	c.Statistics, err = c.Client.GetEntryStatistics(ctx, &registration.GetEntryStatisticsRequest{
+		ExpiringWithin: int64(config.ExpiringWithin / time.Second),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error counting registration entries: %v \n", err)
+		return 1
+	}
+
+	if config.Output.JSON() {
+		if err := common_cli.DefaultEnv.PrintJSON(newCountJSON(c.Statistics, config)); err != nil {
+			_ = common_cli.DefaultEnv.ErrPrintln(err)
+			return 1
+		}
+		return 0
+	}
+
+	c.printStatistics(config)
+	return 0
+}
+
+func (c *CountCLI) printStatistics(config *CountConfig) {
+	stats := c.Statistics
+
+	msg := fmt.Sprintf("%d registration ", stats.Total)
+	fmt.Println(util.Pluralizer(msg, "entry", "entries", int(stats.Total)))
+	fmt.Printf("%d expiring within %s\n", stats.ExpiringSoon, config.ExpiringWithin)
+	fmt.Printf("%d expired\n", stats.Expired)
+
+	if !config.Stats {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("Entries per parent ID:")
+	printCounts(stats.ByParentId)
+	fmt.Println()
+	fmt.Println("Entries per selector type:")
+	printCounts(stats.BySelectorType)
+}
+
+func printCounts(counts map[string]int32) {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Printf("  %s: %d\n", key, counts[key])
+	}
+}
+
+func (CountCLI) parseConfig(args []string) (*CountConfig, error) {
+	f := flag.NewFlagSet("entry count", flag.ContinueOnError)
+	c := &CountConfig{}
+
+	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	f.DurationVar(&c.ExpiringWithin, "expiringWithin", defaultExpiringWithin, "Entries expiring within this duration are counted as expiring soon")
+	f.BoolVar(&c.Stats, "stats", false, "Print the number of entries per parent ID and per selector type")
+	c.Output.AddFlag(f)
+
+	return c, f.Parse(args)
+}
+
+type countJSON struct {
+	Count          int32            `json:"count"`
+	ExpiringSoon   int32            `json:"expiring_soon"`
+	Expired        int32            `json:"expired"`
+	ByParentID     map[string]int32 `json:"by_parent_id,omitempty"`
+	BySelectorType map[string]int32 `json:"by_selector_type,omitempty"`
+}
+
+func newCountJSON(stats *registration.GetEntryStatisticsResponse, config *CountConfig) *countJSON {
+	out := &countJSON{
+		Count:        stats.Total,
+		ExpiringSoon: stats.ExpiringSoon,
+		Expired:      stats.Expired,
+	}
+	if config.Stats {
+		out.ByParentID = stats.ByParentId
+		out.BySelectorType = stats.BySelectorType
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package entry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCountTestSuite(t *testing.T) {
+	suite.Run(t, new(CountTestSuite))
+}
+
+type CountTestSuite struct {
+	suite.Suite
+
+	cli        *CountCLI
+	mockClient *mock_registration.MockRegistrationClient
+	mockCtrl   *gomock.Controller
+}
+
+func (s *CountTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_registration.NewMockRegistrationClient(s.mockCtrl)
+	s.cli = &CountCLI{
+		Client: s.mockClient,
+	}
+}
+
+func (s *CountTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *CountTestSuite) TestRun() {
+	req := &registration.GetEntryStatisticsRequest{ExpiringWithin: 86400}
+	resp := &registration.GetEntryStatisticsResponse{Total: 3, ExpiringSoon: 1}
+	s.mockClient.EXPECT().GetEntryStatistics(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run([]string{}))
+	s.Assert().Equal(resp, s.cli.Statistics)
+}
+
+func (s *CountTestSuite) TestRunWithStats() {
+	req := &registration.GetEntryStatisticsRequest{ExpiringWithin: 3600}
+	resp := &registration.GetEntryStatisticsResponse{
+		Total:          2,
+		ByParentId:     map[string]int32{"spiffe://example.org/foo": 2},
+		BySelectorType: map[string]int32{"unix": 2, "k8s": 1},
+		Expired:        1,
+	}
+	s.mockClient.EXPECT().GetEntryStatistics(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run([]string{"-expiringWithin", "1h", "-stats"}))
+	s.Assert().Equal(resp, s.cli.Statistics)
+}
+
+func (s *CountTestSuite) TestRunWithJSONOutput() {
+	req := &registration.GetEntryStatisticsRequest{ExpiringWithin: 86400}
+	resp := &registration.GetEntryStatisticsResponse{Total: 1}
+	s.mockClient.EXPECT().GetEntryStatistics(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run([]string{"-output", "json", "-stats"}))
+}
+
+func (s *CountTestSuite) TestRunExitsWithNonZeroCodeOnFailure() {
+	req := &registration.GetEntryStatisticsRequest{ExpiringWithin: 86400}
+	s.mockClient.EXPECT().GetEntryStatistics(gomock.Any(), req).Return(nil, errors.New("some error"))
+	s.Require().Equal(1, s.cli.Run([]string{}))
+}
+
+func (s *CountTestSuite) TestRunExitsWithNonZeroCodeOnInvalidExpiringWithin() {
+	s.Require().Equal(1, s.cli.Run([]string{"-expiringWithin", "0s"}))
+}
@@ -8,7 +8,9 @@ import (
 	"io/ioutil"
 
 	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	commonutil "github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 
@@ -31,6 +33,18 @@ type CreateConfig struct {
 	SpiffeID string
 	TTL      int
 
+	// ID of the entry, generated by the server if empty
+	EntryID string
+
+	// Whether or not to derive the IDs of the entries from their content
+	DeriveEntryID bool
+
+	// TTL of JWT-SVIDs issued based on this entry
+	JWTSVIDTTL int
+
+	// Hint used by workloads to select between their SVIDs
+	Hint string
+
 	// List of SPIFFE IDs of trust domains the registration entry is federated with
 	FederatesWith StringsFlag
 
@@ -48,6 +62,9 @@ type CreateConfig struct {
 
 	// DNSNames entries for SVIDs based on this entry
 	DNSNames StringsFlag
+
+	// Output format of the command
+	Output common_cli.OutputFlag
 }
 
 // Validate performs basic validation, even on fields that we
@@ -57,8 +74,15 @@ func (rc *CreateConfig) Validate() (err error) {
 		return errors.New("a socket path for registration api is required")
 	}
 
+	if rc.EntryID != "" && rc.DeriveEntryID {
+		return errors.New("the -entryID and -deriveEntryID flags can't be combined")
+	}
+
 	// If a path is set, we have all we need
 	if rc.Path != "" {
+		if rc.EntryID != "" {
+			return errors.New("the -entryID flag can't be combined with -data")
+		}
 		return nil
 	}
 
@@ -82,6 +106,10 @@ func (rc *CreateConfig) Validate() (err error) {
 		return errors.New("a TTL is required")
 	}
 
+	if rc.JWTSVIDTTL < 0 {
+		return errors.New("a positive JWT-SVID TTL is required")
+	}
+
 	// make sure all SPIFFE ID's are well formed
 	rc.SpiffeID, err = idutil.NormalizeSpiffeID(rc.SpiffeID, idutil.AllowAny())
 	if err != nil {
@@ -141,18 +169,28 @@ func (c CreateCLI) Run(args []string) int {
 		return 1
 	}
 
+	if config.DeriveEntryID {
+		deriveEntryIDs(entries)
+	}
+
 	cl, err := util.NewRegistrationClient(config.RegistrationUDSPath)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
 
-	err = c.registerEntries(ctx, cl, entries)
+	entries, err = c.registerEntries(ctx, cl, entries, config.Output)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
 
+	if config.Output.JSON() {
+		if err := common_cli.DefaultEnv.PrintJSON(newEntriesJSON(entries)); err != nil {
+			_ = common_cli.DefaultEnv.ErrPrintln(err)
+			return 1
+		}
+	}
 	return 0
 }
 
@@ -162,9 +200,12 @@ func (c CreateCLI) parseConfig(config *CreateConfig) ([]*common.RegistrationEntr
 		ParentId:    config.ParentID,
 		SpiffeId:    config.SpiffeID,
 		Ttl:         int32(config.TTL),
+		JwtSvidTtl:  int32(config.JWTSVIDTTL),
+		Hint:        config.Hint,
 		Downstream:  config.Downstream,
 		EntryExpiry: config.EntryExpiry,
 		DnsNames:    config.DNSNames,
+		EntryId:     config.EntryID,
 	}
 
 	// If the node flag is set, then set the Parent ID to the server's expected SPIFFE ID
@@ -208,20 +249,35 @@ func (CreateCLI) parseFile(path string) ([]*common.RegistrationEntry, error) {
 	return entries.Entries, nil
 }
 
-func (CreateCLI) registerEntries(ctx context.Context, c registration.RegistrationClient, entries []*common.RegistrationEntry) error {
+// deriveEntryIDs derives the IDs of the entries that do not have one from
+// their content, so that creating the same entries twice fails instead of
+// registering duplicates under new IDs.
+func deriveEntryIDs(entries []*common.RegistrationEntry) {
+	for _, e := range entries {
+		if e.EntryId == "" {
+			e.EntryId = commonutil.DeriveRegistrationEntryID(e)
+		}
+	}
+}
+
+// registerEntries creates the entries, and returns them with their IDs. The
+// entries are printed as they are created, unless the output is JSON.
+func (CreateCLI) registerEntries(ctx context.Context, c registration.RegistrationClient, entries []*common.RegistrationEntry, output common_cli.OutputFlag) ([]*common.RegistrationEntry, error) {
 	for _, e := range entries {
 		id, err := c.CreateEntry(ctx, e)
 		if err != nil {
 			fmt.Println("FAILED to create the following entry:")
 			printEntry(e)
-			return err
+			return nil, err
 		}
 
 		e.EntryId = id.Id
-		printEntry(e)
+		if !output.JSON() {
+			printEntry(e)
+		}
 	}
 
-	return nil
+	return entries, nil
 }
 
 func (CreateCLI) newConfig(args []string) (*CreateConfig, error) {
@@ -231,7 +287,11 @@ func (CreateCLI) newConfig(args []string) (*CreateConfig, error) {
 	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
 	f.StringVar(&c.ParentID, "parentID", "", "The SPIFFE ID of this record's parent")
 	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID that this record represents")
-	f.IntVar(&c.TTL, "ttl", 3600, "The lifetime, in seconds, for SVIDs issued based on this registration entry")
+	f.IntVar(&c.TTL, "ttl", 3600, "The lifetime, in seconds, for X509-SVIDs issued based on this registration entry")
+	f.IntVar(&c.JWTSVIDTTL, "jwtSVIDTTL", 0, "The lifetime, in seconds, for JWT-SVIDs issued based on this registration entry. Defaults to the server JWT-SVID TTL")
+	f.StringVar(&c.EntryID, "entryID", "", "The ID of this record. Generated by the server if not set")
+	f.BoolVar(&c.DeriveEntryID, "deriveEntryID", false, "If set, the ID of this record, or of the records without an ID in the data file, is derived from the parent ID, SPIFFE ID and selectors")
+	f.StringVar(&c.Hint, "hint", "", "A hint for workloads to select the SVID issued based on this registration entry when they are issued more than one")
 
 	f.StringVar(&c.Path, "data", "", "Path to a file containing registration JSON (optional)")
 
@@ -244,6 +304,7 @@ func (CreateCLI) newConfig(args []string) (*CreateConfig, error) {
 	f.Int64Var(&c.EntryExpiry, "entryExpiry", 0, "An expiry, from epoch in seconds, for the resulting registration entry to be pruned")
 
 	f.Var(&c.DNSNames, "dns", "A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once")
+	c.Output.AddFlag(f)
 
 	return c, f.Parse(args)
 }
@@ -4,6 +4,8 @@ import (
 	"path"
 	"testing"
 
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	commonutil "github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +23,8 @@ func TestCreateCLI(t *testing.T) {
 		"-parentID", "spiffe://example.org/foo",
 		"-spiffeID", "spiffe://example.org/bar",
 		"-ttl", "60",
+		"-jwtSVIDTTL", "30",
+		"-hint", "external",
 		"-selector", "unix:uid:1000",
 		"-selector", "unix:gid:1000",
 		"-selector", "alpha:alpha:2000",
@@ -35,6 +39,7 @@ func TestCreateCLI(t *testing.T) {
 		"-dns", "ung1000",
 		"-dns", "aa2000",
 		"-dns", "zz2000",
+		"-output", "json",
 	})
 	require.NoError(t, err)
 
@@ -43,11 +48,14 @@ func TestCreateCLI(t *testing.T) {
 		ParentID:            "spiffe://example.org/foo",
 		SpiffeID:            "spiffe://example.org/bar",
 		TTL:                 60,
+		JWTSVIDTTL:          30,
+		Hint:                "external",
 		Selectors:           StringsFlag{"unix:uid:1000", "unix:gid:1000", "alpha:alpha:2000", "zebra:zebra:2000"},
 		FederatesWith:       StringsFlag{"spiffe://domainA.test", "spiffe://domain1.test", "spiffe://domain2.test", "spiffe://domainB.test"},
 		Admin:               true,
 		EntryExpiry:         1552410266,
 		DNSNames:            StringsFlag{"unu1000", "ung1000", "aa2000", "zz2000"},
+		Output:              common_cli.OutputJSON,
 	}
 
 	assert.Equal(t, createdConfig, c)
@@ -59,6 +67,8 @@ func TestCreateParseConfig(t *testing.T) {
 		ParentID:            "spiffe://example.org/foo",
 		SpiffeID:            "spiffe://example.org/bar",
 		TTL:                 60,
+		JWTSVIDTTL:          30,
+		Hint:                "external",
 		Selectors:           StringsFlag{"unix:uid:1000", "unix:gid:1000"},
 		FederatesWith:       StringsFlag{"spiffe://domain1.test", "spiffe://domain2.test"},
 		Admin:               true,
@@ -69,9 +79,11 @@ func TestCreateParseConfig(t *testing.T) {
 	require.NoError(t, err)
 
 	expectedEntry := &common.RegistrationEntry{
-		ParentId: "spiffe://example.org/foo",
-		SpiffeId: "spiffe://example.org/bar",
-		Ttl:      60,
+		ParentId:   "spiffe://example.org/foo",
+		SpiffeId:   "spiffe://example.org/bar",
+		Ttl:        60,
+		JwtSvidTtl: 30,
+		Hint:       "external",
 		Selectors: []*common.Selector{
 			{Type: "unix", Value: "uid:1000"},
 			{Type: "unix", Value: "gid:1000"},
@@ -114,6 +126,42 @@ func TestCreateNodeParseConfig(t *testing.T) {
 	assert.Equal(t, expectedEntries, entries)
 }
 
+func TestCreateConfigValidateEntryID(t *testing.T) {
+	c := &CreateConfig{
+		RegistrationUDSPath: cmdutil.DefaultSocketPath,
+		EntryID:             "foo",
+		DeriveEntryID:       true,
+	}
+	require.EqualError(t, c.Validate(), "the -entryID and -deriveEntryID flags can't be combined")
+
+	c = &CreateConfig{
+		RegistrationUDSPath: cmdutil.DefaultSocketPath,
+		Path:                "entries.json",
+		EntryID:             "foo",
+	}
+	require.EqualError(t, c.Validate(), "the -entryID flag can't be combined with -data")
+}
+
+func TestDeriveEntryIDs(t *testing.T) {
+	entries := []*common.RegistrationEntry{
+		{
+			ParentId:  "spiffe://example.org/foo",
+			SpiffeId:  "spiffe://example.org/bar",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+		{
+			ParentId:  "spiffe://example.org/foo",
+			SpiffeId:  "spiffe://example.org/baz",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			EntryId:   "baz",
+		},
+	}
+
+	deriveEntryIDs(entries)
+	assert.Equal(t, commonutil.DeriveRegistrationEntryID(entries[0]), entries[0].EntryId)
+	assert.Equal(t, "baz", entries[1].EntryId)
+}
+
 func TestRegisterParseFile(t *testing.T) {
 	p := path.Join(util.ProjectRoot(), "test/fixture/registration/good.json")
 	entries, err := CreateCLI{}.parseFile(p)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 
 	"golang.org/x/net/context"
@@ -17,6 +18,13 @@ type DeleteConfig struct {
 
 	// ID of the record to delete
 	EntryID string
+
+	// Revision number the record must be at to be deleted. If negative, the
+	// current revision number of the record is used.
+	RevisionNumber int64
+
+	// Output format of the command
+	Output common_cli.OutputFlag
 }
 
 // Perform basic validation
@@ -60,14 +68,31 @@ func (d DeleteCLI) Run(args []string) int {
 		return d.printErr(err)
 	}
 
+	revisionNumber := config.RevisionNumber
+	if revisionNumber < 0 {
+		revisionNumber, err = fetchRevisionNumber(ctx, cl, config.EntryID)
+		if err != nil {
+			return d.printErr(err)
+		}
+	}
+
 	req := &registration.RegistrationEntryID{
-		Id: config.EntryID,
+		Id:             config.EntryID,
+		RevisionNumber: revisionNumber,
 	}
 	e, err := cl.DeleteEntry(ctx, req)
 	if err != nil {
 		return d.printErr(err)
 	}
 
+	if config.Output.JSON() {
+		if err := common_cli.DefaultEnv.PrintJSON(newEntryJSON(e)); err != nil {
+			_ = common_cli.DefaultEnv.ErrPrintln(err)
+			return 1
+		}
+		return 0
+	}
+
 	fmt.Printf("Deleted the following entry:\n\n")
 	printEntry(e)
 	return 0
@@ -79,6 +104,8 @@ func (DeleteCLI) newConfig(args []string) (*DeleteConfig, error) {
 
 	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
 	f.StringVar(&c.EntryID, "entryID", "", "The Registration Entry ID of the record to delete")
+	f.Int64Var(&c.RevisionNumber, "revisionNumber", -1, "The revision number the record must be at to be deleted. Defaults to the current revision number of the record")
+	c.Output.AddFlag(f)
 
 	return c, f.Parse(args)
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	commonutil "github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/api/registration"
@@ -30,6 +31,9 @@ type ShowConfig struct {
 
 	FederatesWith StringsFlag
 	Downstream    bool
+
+	// Output format of the command
+	Output common_cli.OutputFlag
 }
 
 // Validate ensures that the values in ShowConfig are valid
@@ -90,6 +94,14 @@ func (s *ShowCLI) Run(args []string) int {
 
 	commonutil.SortRegistrationEntries(s.Entries)
 	s.filterEntries()
+	if s.Config.Output.JSON() {
+		if err := common_cli.DefaultEnv.PrintJSON(newEntriesJSON(s.Entries)); err != nil {
+			_ = common_cli.DefaultEnv.ErrPrintln(err)
+			return 1
+		}
+		return 0
+	}
+
 	s.printEntries()
 	return 0
 }
@@ -297,6 +309,7 @@ func (s *ShowCLI) loadConfig(args []string) error {
 
 	f.Var(&c.Selectors, "selector", "A colon-delimited type:value selector. Can be used more than once")
 	f.Var(&c.FederatesWith, "federatesWith", "SPIFFE ID of a trust domain an entry is federate with. Can be used more than once")
+	c.Output.AddFlag(f)
 
 	err := f.Parse(args)
 	if err != nil {
@@ -52,6 +52,24 @@ func (s *ShowTestSuite) TestRunWithEntryID() {
 	s.Assert().Equal(s.registrationEntries(1), s.cli.Entries)
 }
 
+func (s *ShowTestSuite) TestRunWithJSONOutput() {
+	entryID := "123456"
+
+	args := []string{
+		"-entryID",
+		entryID,
+		"-output",
+		"json",
+	}
+
+	req := &registration.RegistrationEntryID{Id: entryID}
+	resp := s.registrationEntries(1)[0]
+	s.mockClient.EXPECT().FetchEntry(gomock.Any(), req).Return(resp, nil)
+
+	s.Require().Equal(0, s.cli.Run(args))
+	s.Assert().Equal(s.registrationEntries(1), s.cli.Entries)
+}
+
 func (s *ShowTestSuite) TestRunWithParentID() {
 	entries := s.registrationEntries(2)
 
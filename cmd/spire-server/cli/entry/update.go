@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 
 	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -26,6 +27,10 @@ type UpdateConfig struct {
 	// Registration entry id to update
 	EntryID string
 
+	// Revision number the entry must be at to be updated. If negative, the
+	// current revision number of the entry is used.
+	RevisionNumber int64
+
 	// Type and value are delimited by a colon (:)
 	// ex. "unix:uid:1000" or "spiffe_id:spiffe://example.org/foo"
 	Selectors StringsFlag
@@ -35,6 +40,12 @@ type UpdateConfig struct {
 	Downstream bool
 	TTL        int
 
+	// TTL of JWT-SVIDs issued based on this entry
+	JWTSVIDTTL int
+
+	// Hint used by workloads to select between their SVIDs
+	Hint string
+
 	// List of SPIFFE IDs of trust domains the registration entry is federated with
 	FederatesWith StringsFlag
 
@@ -46,6 +57,9 @@ type UpdateConfig struct {
 
 	// DNSNames entries for SVIDs based on this entry
 	DNSNames StringsFlag
+
+	// Output format of the command
+	Output common_cli.OutputFlag
 }
 
 // Validate performs basic validation, even on fields that we
@@ -80,6 +94,10 @@ func (rc *UpdateConfig) Validate() (err error) {
 		return errors.New("a TTL is required")
 	}
 
+	if rc.JWTSVIDTTL < 0 {
+		return errors.New("a positive JWT-SVID TTL is required")
+	}
+
 	// make sure all SPIFFE ID's are well formed
 	rc.SpiffeID, err = idutil.NormalizeSpiffeID(rc.SpiffeID, idutil.AllowAny())
 	if err != nil {
@@ -141,25 +159,42 @@ func (c UpdateCLI) Run(args []string) int {
 		return 1
 	}
 
-	err = c.registerEntries(ctx, cl, entries)
+	if config.Path == "" && config.RevisionNumber < 0 {
+		entries[0].RevisionNumber, err = fetchRevisionNumber(ctx, cl, config.EntryID)
+		if err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+	}
+
+	entries, err = c.registerEntries(ctx, cl, entries, config.Output)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
 
+	if config.Output.JSON() {
+		if err := common_cli.DefaultEnv.PrintJSON(newEntriesJSON(entries)); err != nil {
+			_ = common_cli.DefaultEnv.ErrPrintln(err)
+			return 1
+		}
+	}
 	return 0
 }
 
 // parseConfig builds a registration entry from the given config
 func (c UpdateCLI) parseConfig(config *UpdateConfig) ([]*common.RegistrationEntry, error) {
 	e := &common.RegistrationEntry{
-		EntryId:     config.EntryID,
-		ParentId:    config.ParentID,
-		SpiffeId:    config.SpiffeID,
-		Ttl:         int32(config.TTL),
-		Downstream:  config.Downstream,
-		EntryExpiry: config.EntryExpiry,
-		DnsNames:    config.DNSNames,
+		EntryId:        config.EntryID,
+		RevisionNumber: config.RevisionNumber,
+		ParentId:       config.ParentID,
+		SpiffeId:       config.SpiffeID,
+		Ttl:            int32(config.TTL),
+		JwtSvidTtl:     int32(config.JWTSVIDTTL),
+		Hint:           config.Hint,
+		Downstream:     config.Downstream,
+		EntryExpiry:    config.EntryExpiry,
+		DnsNames:       config.DNSNames,
 	}
 
 	selectors := []*common.Selector{}
@@ -192,7 +227,10 @@ func (UpdateCLI) parseFile(path string) ([]*common.RegistrationEntry, error) {
 	return entries.Entries, nil
 }
 
-func (UpdateCLI) registerEntries(ctx context.Context, c registration.RegistrationClient, entries []*common.RegistrationEntry) error {
+// registerEntries updates the entries, and returns them as updated. The
+// entries are printed as they are updated, unless the output is JSON.
+func (UpdateCLI) registerEntries(ctx context.Context, c registration.RegistrationClient, entries []*common.RegistrationEntry, output common_cli.OutputFlag) ([]*common.RegistrationEntry, error) {
+	var updatedEntries []*common.RegistrationEntry
 	for _, e := range entries {
 		updated, err := c.UpdateEntry(ctx, &registration.UpdateEntryRequest{
 			Entry: e,
@@ -200,13 +238,16 @@ func (UpdateCLI) registerEntries(ctx context.Context, c registration.Registratio
 		if err != nil {
 			fmt.Println("FAILED to update the following entry:")
 			printEntry(e)
-			return err
+			return nil, err
 		}
 
-		printEntry(updated)
+		if !output.JSON() {
+			printEntry(updated)
+		}
+		updatedEntries = append(updatedEntries, updated)
 	}
 
-	return nil
+	return updatedEntries, nil
 }
 
 func (UpdateCLI) newConfig(args []string) (*UpdateConfig, error) {
@@ -214,10 +255,13 @@ func (UpdateCLI) newConfig(args []string) (*UpdateConfig, error) {
 	c := &UpdateConfig{}
 
 	f.StringVar(&c.EntryID, "entryID", "", "The Registration Entry ID of the record to update")
+	f.Int64Var(&c.RevisionNumber, "revisionNumber", -1, "The revision number the record must be at to be updated. Defaults to the current revision number of the record")
 	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
 	f.StringVar(&c.ParentID, "parentID", "", "The SPIFFE ID of this record's parent")
 	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID that this record represents")
-	f.IntVar(&c.TTL, "ttl", 3600, "The lifetime, in seconds, for SVIDs issued based on this registration entry")
+	f.IntVar(&c.TTL, "ttl", 3600, "The lifetime, in seconds, for X509-SVIDs issued based on this registration entry")
+	f.IntVar(&c.JWTSVIDTTL, "jwtSVIDTTL", 0, "The lifetime, in seconds, for JWT-SVIDs issued based on this registration entry. Defaults to the server JWT-SVID TTL")
+	f.StringVar(&c.Hint, "hint", "", "A hint for workloads to select the SVID issued based on this registration entry when they are issued more than one")
 
 	f.StringVar(&c.Path, "data", "", "Path to a file containing registration JSON (optional)")
 
@@ -230,6 +274,7 @@ func (UpdateCLI) newConfig(args []string) (*UpdateConfig, error) {
 	f.Int64Var(&c.EntryExpiry, "entryExpiry", 0, "An expiry, from epoch in seconds, for the resulting registration entry to be pruned")
 
 	f.Var(&c.DNSNames, "dns", "A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once")
+	c.Output.AddFlag(f)
 
 	return c, f.Parse(args)
 }
@@ -4,6 +4,7 @@ import (
 	"path"
 	"testing"
 
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/assert"
@@ -18,9 +19,12 @@ import (
 // and DNS rather than sorted in some way
 func TestUpdateCLI(t *testing.T) {
 	updatedConfig, err := UpdateCLI{}.newConfig([]string{
+		"-revisionNumber", "4",
 		"-parentID", "spiffe://example.org/foo",
 		"-spiffeID", "spiffe://example.org/bar",
 		"-ttl", "60",
+		"-jwtSVIDTTL", "30",
+		"-hint", "external",
 		"-selector", "unix:uid:1000",
 		"-selector", "unix:gid:1000",
 		"-selector", "alpha:alpha:2000",
@@ -35,30 +39,45 @@ func TestUpdateCLI(t *testing.T) {
 		"-dns", "ung1000",
 		"-dns", "aa2000",
 		"-dns", "zz2000",
+		"-output", "json",
 	})
 	require.NoError(t, err)
 
 	c := &UpdateConfig{
 		RegistrationUDSPath: cmdutil.DefaultSocketPath,
+		RevisionNumber:      4,
 		ParentID:            "spiffe://example.org/foo",
 		SpiffeID:            "spiffe://example.org/bar",
 		TTL:                 60,
+		JWTSVIDTTL:          30,
+		Hint:                "external",
 		Selectors:           StringsFlag{"unix:uid:1000", "unix:gid:1000", "alpha:alpha:2000", "zebra:zebra:2000"},
 		FederatesWith:       StringsFlag{"spiffe://domainA.test", "spiffe://domain1.test", "spiffe://domain2.test", "spiffe://domainB.test"},
 		Admin:               true,
 		EntryExpiry:         1552410266,
 		DNSNames:            StringsFlag{"unu1000", "ung1000", "aa2000", "zz2000"},
+		Output:              common_cli.OutputJSON,
 	}
 
 	assert.Equal(t, updatedConfig, c)
 }
 
+func TestUpdateCLIDefaultsRevisionNumber(t *testing.T) {
+	config, err := UpdateCLI{}.newConfig([]string{"-entryID", "entry"})
+	require.NoError(t, err)
+	// a negative revision number updates the current revision of the entry
+	assert.Equal(t, int64(-1), config.RevisionNumber)
+}
+
 func TestUpdateParseConfig(t *testing.T) {
 	c := &UpdateConfig{
 		RegistrationUDSPath: cmdutil.DefaultSocketPath,
+		RevisionNumber:      4,
 		ParentID:            "spiffe://example.org/foo",
 		SpiffeID:            "spiffe://example.org/bar",
 		TTL:                 60,
+		JWTSVIDTTL:          30,
+		Hint:                "external",
 		Selectors:           StringsFlag{"unix:uid:1000", "unix:gid:1000"},
 		FederatesWith:       StringsFlag{"spiffe://domain1.test", "spiffe://domain2.test"},
 		Admin:               true,
@@ -69,9 +88,12 @@ func TestUpdateParseConfig(t *testing.T) {
 	require.NoError(t, err)
 
 	expectedEntry := &common.RegistrationEntry{
-		ParentId: "spiffe://example.org/foo",
-		SpiffeId: "spiffe://example.org/bar",
-		Ttl:      60,
+		RevisionNumber: 4,
+		ParentId:       "spiffe://example.org/foo",
+		SpiffeId:       "spiffe://example.org/bar",
+		Ttl:            60,
+		JwtSvidTtl:     30,
+		Hint:           "external",
 		Selectors: []*common.Selector{
 			{Type: "unix", Value: "uid:1000"},
 			{Type: "unix", Value: "gid:1000"},
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
+
+	"golang.org/x/net/context"
 )
 
 // hasSelectors takes a registration entry and a selector flag set. It returns
@@ -56,8 +59,19 @@ func parseSelector(str string) (*common.Selector, error) {
 	return s, nil
 }
 
+// fetchRevisionNumber returns the current revision number of the entry, for
+// commands changing an entry regardless of its revision.
+func fetchRevisionNumber(ctx context.Context, c registration.RegistrationClient, entryID string) (int64, error) {
+	entry, err := c.FetchEntry(ctx, &registration.RegistrationEntryID{Id: entryID})
+	if err != nil {
+		return 0, err
+	}
+	return entry.RevisionNumber, nil
+}
+
 func printEntry(e *common.RegistrationEntry) {
 	fmt.Printf("Entry ID      : %s\n", e.EntryId)
+	fmt.Printf("Revision      : %d\n", e.RevisionNumber)
 	fmt.Printf("SPIFFE ID     : %s\n", e.SpiffeId)
 	fmt.Printf("Parent ID     : %s\n", e.ParentId)
 
@@ -71,6 +85,14 @@ func printEntry(e *common.RegistrationEntry) {
 		fmt.Printf("TTL           : %d\n", e.Ttl)
 	}
 
+	if e.JwtSvidTtl != 0 {
+		fmt.Printf("JWT-SVID TTL  : %d\n", e.JwtSvidTtl)
+	}
+
+	if e.Hint != "" {
+		fmt.Printf("Hint          : %s\n", e.Hint)
+	}
+
 	for _, s := range e.Selectors {
 		fmt.Printf("Selector      : %s:%s\n", s.Type, s.Value)
 	}
@@ -90,6 +112,61 @@ func printEntry(e *common.RegistrationEntry) {
 	fmt.Println()
 }
 
+// entryJSON is the JSON output of the commands for a registration entry.
+// Unlike the text output, every field is present.
+type entryJSON struct {
+	EntryID        string         `json:"entry_id"`
+	RevisionNumber int64          `json:"revision_number"`
+	SpiffeID       string         `json:"spiffe_id"`
+	ParentID       string         `json:"parent_id"`
+	TTL            int32          `json:"ttl"`
+	JWTSVIDTTL     int32          `json:"jwt_svid_ttl"`
+	Hint           string         `json:"hint"`
+	Selectors      []selectorJSON `json:"selectors"`
+	FederatesWith  []string       `json:"federates_with"`
+	DNSNames       []string       `json:"dns_names"`
+	Admin          bool           `json:"admin"`
+	Downstream     bool           `json:"downstream"`
+	EntryExpiry    int64          `json:"entry_expiry"`
+}
+
+type selectorJSON struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func newEntryJSON(e *common.RegistrationEntry) entryJSON {
+	entry := entryJSON{
+		EntryID:        e.EntryId,
+		RevisionNumber: e.RevisionNumber,
+		SpiffeID:       e.SpiffeId,
+		ParentID:       e.ParentId,
+		TTL:            e.Ttl,
+		JWTSVIDTTL:     e.JwtSvidTtl,
+		Hint:           e.Hint,
+		Selectors:      []selectorJSON{},
+		FederatesWith:  append([]string{}, e.FederatesWith...),
+		DNSNames:       append([]string{}, e.DnsNames...),
+		Admin:          e.Admin,
+		Downstream:     e.Downstream,
+		EntryExpiry:    e.EntryExpiry,
+	}
+	for _, s := range e.Selectors {
+		entry.Selectors = append(entry.Selectors, selectorJSON{Type: s.Type, Value: s.Value})
+	}
+	return entry
+}
+
+func newEntriesJSON(entries []*common.RegistrationEntry) interface{} {
+	out := []entryJSON{}
+	for _, e := range entries {
+		out = append(out, newEntryJSON(e))
+	}
+	return struct {
+		Entries []entryJSON `json:"entries"`
+	}{Entries: out}
+}
+
 // StringsFlag defines a custom type for string lists. Doing
 // this allows us to support repeatable string flags.
 type StringsFlag []string
@@ -1,6 +1,7 @@
 package entry
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/spiffe/spire/proto/spire/common"
@@ -45,3 +46,66 @@ func selectorToFlag(t *testing.T, selectors []*common.Selector) StringsFlag {
 
 	return resp
 }
+
+func TestEntryJSON(t *testing.T) {
+	entries := []*common.RegistrationEntry{
+		{
+			EntryId:        "00000000-0000-0000-0000-000000000001",
+			RevisionNumber: 3,
+			ParentId:       "spiffe://example.org/foo",
+			SpiffeId:       "spiffe://example.org/bar",
+			Ttl:            60,
+			JwtSvidTtl:     30,
+			Hint:           "external",
+			Selectors:      []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			FederatesWith:  []string{"spiffe://domain1.test"},
+			DnsNames:       []string{"bar.example.org"},
+			Admin:          true,
+			EntryExpiry:    1552410266,
+		},
+		{
+			EntryId:  "00000000-0000-0000-0000-000000000002",
+			ParentId: "spiffe://example.org/foo",
+			SpiffeId: "spiffe://example.org/baz",
+		},
+	}
+
+	data, err := json.Marshal(newEntriesJSON(entries))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"entries": [
+		{
+			"entry_id": "00000000-0000-0000-0000-000000000001",
+			"revision_number": 3,
+			"spiffe_id": "spiffe://example.org/bar",
+			"parent_id": "spiffe://example.org/foo",
+			"ttl": 60,
+			"jwt_svid_ttl": 30,
+			"hint": "external",
+			"selectors": [{"type": "unix", "value": "uid:1000"}],
+			"federates_with": ["spiffe://domain1.test"],
+			"dns_names": ["bar.example.org"],
+			"admin": true,
+			"downstream": false,
+			"entry_expiry": 1552410266
+		},
+		{
+			"entry_id": "00000000-0000-0000-0000-000000000002",
+			"revision_number": 0,
+			"spiffe_id": "spiffe://example.org/baz",
+			"parent_id": "spiffe://example.org/foo",
+			"ttl": 0,
+			"jwt_svid_ttl": 0,
+			"hint": "",
+			"selectors": [],
+			"federates_with": [],
+			"dns_names": [],
+			"admin": false,
+			"downstream": false,
+			"entry_expiry": 0
+		}
+	]}`, string(data))
+
+	data, err = json.Marshal(newEntriesJSON(nil))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"entries": []}`, string(data))
+}
@@ -2,14 +2,26 @@ package healthcheck
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/server"
+	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
 )
 
 func NewHealthCheckCommand() cli.Command {
@@ -30,6 +42,7 @@ type healthCheckCommand struct {
 	timeout    common_cli.DurationFlag
 	shallow    bool
 	verbose    bool
+	output     string
 }
 
 func (c *healthCheckCommand) Help() string {
@@ -46,43 +59,98 @@ func (c *healthCheckCommand) Run(args []string) int {
 	if err := c.parseFlags(args); err != nil {
 		return 1
 	}
-	if err := c.run(); err != nil {
-		// Ignore error since a failure to write to stderr cannot very well be
-		// reported
-		_ = c.env.ErrPrintf("Server is unhealthy: %v\n", err)
+	switch c.output {
+	case outputText:
+	case outputJSON:
+		// Verbose output would corrupt the JSON document
+		c.verbose = false
+	default:
+		_ = c.env.ErrPrintf("Invalid output format %q: must be %q or %q\n", c.output, outputText, outputJSON)
 		return 1
 	}
-	if err := c.env.Println("Server is healthy."); err != nil {
-		return 1
+
+	report := c.run()
+	if c.output == outputJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return health.ExitNotLive
+		}
+		if err := c.env.Println(string(data)); err != nil {
+			return health.ExitNotLive
+		}
+		return report.ExitCode()
+	}
+
+	// Ignore errors writing to stderr since they cannot very well be
+	// reported
+	switch {
+	case !report.Live:
+		_ = c.env.ErrPrintf("Server is unhealthy: %v\n", report.Error)
+	case !report.Ready:
+		if failing := report.FailingSubsystems(); len(failing) > 0 {
+			_ = c.env.ErrPrintf("Server is not ready: failing subsystems: %s\n", strings.Join(failing, ", "))
+		} else {
+			_ = c.env.ErrPrintln("Server is not ready.")
+		}
+	default:
+		if err := c.env.Println("Server is healthy."); err != nil {
+			return health.ExitNotLive
+		}
 	}
-	return 0
+	return report.ExitCode()
 }
 
 func (c *healthCheckCommand) parseFlags(args []string) error {
 	fs := flag.NewFlagSet("health", flag.ContinueOnError)
 	fs.SetOutput(c.env.Stderr)
 	fs.StringVar(&c.socketPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
-	fs.BoolVar(&c.shallow, "shallow", false, "Perform a less stringent health check")
+	fs.BoolVar(&c.shallow, "shallow", false, "Only check that the server is live, skipping the subsystem checks")
 	fs.BoolVar(&c.verbose, "verbose", false, "Print verbose information")
+	fs.StringVar(&c.output, "output", outputText, "Output format: text or json")
 	return fs.Parse(args)
 }
 
-func (c *healthCheckCommand) run() error {
+// run checks the server is live by fetching the bundle, and unless shallow,
+// that it is ready by querying the subsystem checks.
+func (c *healthCheckCommand) run() *health.Report {
+	report := new(health.Report)
+
+	conn, err := c.checkLive()
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	defer conn.Close()
+	report.Live = true
+
+	if c.shallow {
+		report.Ready = true
+		return report
+	}
+
+	if err := c.checkReady(conn, report); err != nil {
+		report.Error = err.Error()
+	}
+	return report
+}
+
+func (c *healthCheckCommand) checkLive() (*grpc.ClientConn, error) {
 	if c.verbose {
 		if err := c.env.Println("Fetching bundle via Registration API..."); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	client, err := util.NewRegistrationClient(c.socketPath)
+	conn, err := util.Dial(c.socketPath)
 	if err != nil {
 		if c.verbose {
 			// Ignore error since a failure to write to stderr cannot very well
 			// be reported
 			_ = c.env.ErrPrintf("Failed to create client: %v\n", err)
 		}
-		return errors.New("cannot create registration client")
+		return nil, errors.New("cannot create registration client")
 	}
+	client := registration.NewRegistrationClient(conn)
 
 	// Currently using the ability to fetch a bundle as the health check. This
 	// **could** be problematic if the Upstream CA signing process is lengthy.
@@ -94,13 +162,47 @@ func (c *healthCheckCommand) run() error {
 			// be reported
 			_ = c.env.ErrPrintf("Failed to fetch bundle: %v\n", err)
 		}
-		return errors.New("unable to fetch bundle")
+		conn.Close()
+		return nil, errors.New("unable to fetch bundle")
 	}
 	if c.verbose {
 		if err := c.env.Println("Successfully fetched bundle."); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func (c *healthCheckCommand) checkReady(conn *grpc.ClientConn, report *health.Report) error {
+	if c.verbose {
+		if err := c.env.Println("Checking subsystems via health service..."); err != nil {
 			return err
 		}
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.timeout))
+	defer cancel()
+
+	ready, subsystems, err := health.CheckSubsystems(ctx, conn, server.HealthChecks)
+	if err != nil {
+		if c.verbose {
+			// Ignore error since a failure to write to stderr cannot very well
+			// be reported
+			_ = c.env.ErrPrintf("Failed to check subsystems: %v\n", err)
+		}
+		return fmt.Errorf("unable to check subsystems: %v", err)
+	}
+	report.Ready = ready
+	report.Subsystems = subsystems
+
+	if c.verbose {
+		for _, subsystem := range subsystems {
+			if err := c.env.Printf("Subsystem %s is %s.\n", subsystem.Name, subsystem.Status); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
@@ -15,6 +15,8 @@ import (
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func TestHealthCheck(t *testing.T) {
@@ -50,10 +52,12 @@ func (s *HealthCheckSuite) TestSynopsis() {
 func (s *HealthCheckSuite) TestHelp() {
 	s.Equal("", s.cmd.Help())
 	s.Equal(`Usage of health:
+  -output string
+    	Output format: text or json (default "text")
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
   -shallow
-    	Perform a less stringent health check
+    	Only check that the server is live, skipping the subsystem checks
   -verbose
     	Print verbose information
 `, s.stderr.String(), "stderr")
@@ -65,10 +69,12 @@ func (s *HealthCheckSuite) TestBadFlags() {
 	s.Equal("", s.stdout.String(), "stdout")
 	s.Equal(`flag provided but not defined: -badflag
 Usage of health:
+  -output string
+    	Output format: text or json (default "text")
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
   -shallow
-    	Perform a less stringent health check
+    	Only check that the server is live, skipping the subsystem checks
   -verbose
     	Print verbose information
 `, s.stderr.String(), "stderr")
@@ -107,12 +113,101 @@ func (s *HealthCheckSuite) TestSucceedsIfBundleFetchedVerbose() {
 	s.Equal(0, code, "exit code")
 	s.Equal(`Fetching bundle via Registration API...
 Successfully fetched bundle.
+Checking subsystems via health service...
 Server is healthy.
 `, s.stdout.String(), "stdout")
 	s.Equal("", s.stderr.String(), "stderr")
 }
 
-func (s *HealthCheckSuite) serveRegistrationAPI(r registration.RegistrationServer) (string, func()) {
+func (s *HealthCheckSuite) TestFailsOnInvalidOutput() {
+	code := s.cmd.Run([]string{"--output", "yaml"})
+	s.Equal(1, code, "exit code")
+	s.Equal("", s.stdout.String(), "stdout")
+	s.Equal("Invalid output format \"yaml\": must be \"text\" or \"json\"\n", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestSucceedsIfSubsystemsServing() {
+	socketPath, done := s.serveRegistrationAPI(withBundle{}, withHealth(healthpb.HealthCheckResponse_SERVING,
+		"datastore", "ca", "bundle", "plugins"))
+	defer done()
+	code := s.cmd.Run([]string{"--registrationUDSPath", socketPath, "--verbose"})
+	s.Equal(0, code, "exit code")
+	s.Equal(`Fetching bundle via Registration API...
+Successfully fetched bundle.
+Checking subsystems via health service...
+Subsystem datastore is SERVING.
+Subsystem ca is SERVING.
+Subsystem bundle is SERVING.
+Subsystem plugins is SERVING.
+Server is healthy.
+`, s.stdout.String(), "stdout")
+	s.Equal("", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestNotReadyOnFailingSubsystem() {
+	socketPath, done := s.serveRegistrationAPI(withBundle{}, withHealth(healthpb.HealthCheckResponse_NOT_SERVING,
+		"datastore", "plugins"))
+	defer done()
+	code := s.cmd.Run([]string{"--registrationUDSPath", socketPath})
+	s.Equal(2, code, "exit code")
+	s.Equal("", s.stdout.String(), "stdout")
+	s.Equal("Server is not ready: failing subsystems: ca, bundle\n", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestShallowSkipsSubsystems() {
+	socketPath, done := s.serveRegistrationAPI(withBundle{}, withHealth(healthpb.HealthCheckResponse_NOT_SERVING))
+	defer done()
+	code := s.cmd.Run([]string{"--registrationUDSPath", socketPath, "--shallow"})
+	s.Equal(0, code, "exit code")
+	s.Equal("Server is healthy.\n", s.stdout.String(), "stdout")
+	s.Equal("", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestJSONOutput() {
+	socketPath, done := s.serveRegistrationAPI(withBundle{}, withHealth(healthpb.HealthCheckResponse_NOT_SERVING,
+		"datastore", "plugins"))
+	defer done()
+	code := s.cmd.Run([]string{"--registrationUDSPath", socketPath, "--output", "json"})
+	s.Equal(2, code, "exit code")
+	s.Equal(`{
+  "live": true,
+  "ready": false,
+  "subsystems": [
+    {
+      "name": "datastore",
+      "status": "SERVING"
+    },
+    {
+      "name": "ca",
+      "status": "UNKNOWN"
+    },
+    {
+      "name": "bundle",
+      "status": "UNKNOWN"
+    },
+    {
+      "name": "plugins",
+      "status": "SERVING"
+    }
+  ]
+}
+`, s.stdout.String(), "stdout")
+	s.Equal("", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) TestJSONOutputIfBundleCannotBeFetched() {
+	code := s.cmd.Run([]string{"--registrationUDSPath", "doesnotexist.sock", "--output", "json"})
+	s.Equal(1, code, "exit code")
+	s.Equal(`{
+  "live": false,
+  "ready": false,
+  "error": "unable to fetch bundle"
+}
+`, s.stdout.String(), "stdout")
+	s.Equal("", s.stderr.String(), "stderr")
+}
+
+func (s *HealthCheckSuite) serveRegistrationAPI(r registration.RegistrationServer, healthServer ...*health.Server) (string, func()) {
 	dir, err := ioutil.TempDir("", "server-healthcheck-test")
 	s.Require().NoError(err)
 
@@ -126,6 +221,9 @@ func (s *HealthCheckSuite) serveRegistrationAPI(r registration.RegistrationServe
 
 	server := grpc.NewServer()
 	registration.RegisterRegistrationServer(server, r)
+	for _, h := range healthServer {
+		healthpb.RegisterHealthServer(server, h)
+	}
 	errCh := make(chan error, 1)
 	go func() {
 		errCh <- server.Serve(listener)
@@ -144,3 +242,14 @@ type withBundle struct {
 func (withBundle) FetchBundle(context.Context, *common.Empty) (*registration.Bundle, error) {
 	return &registration.Bundle{}, nil
 }
+
+// withHealth returns a health server reporting the given overall status and
+// the given subsystems as serving.
+func withHealth(overall healthpb.HealthCheckResponse_ServingStatus, serving ...string) *health.Server {
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", overall)
+	for _, name := range serving {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	return healthServer
+}
@@ -33,6 +33,7 @@ type mintCommand struct {
 	ttl        time.Duration
 	audience   common_cli.StringsFlag
 	write      string
+	output     common_cli.OutputFlag
 }
 
 func (c *mintCommand) Help() string {
@@ -63,6 +64,7 @@ func (c *mintCommand) parseFlags(args []string) error {
 	fs.DurationVar(&c.ttl, "ttl", 0, "TTL of the JWT-SVID")
 	fs.Var(&c.audience, "audience", "Audience claim that will be included in the SVID. Can be used more than once.")
 	fs.StringVar(&c.write, "write", "", "File to write token to instead of stdout")
+	c.output.AddFlag(fs)
 	return fs.Parse(args)
 }
 
@@ -99,6 +101,9 @@ func (c *mintCommand) run() error {
 	}
 
 	if c.write == "" {
+		if c.output.JSON() {
+			return common_cli.PrintJSON(c.env.Stdout, mintJSON{Token: resp.Token})
+		}
 		if err := c.env.Println(resp.Token); err != nil {
 			return err
 		}
@@ -107,6 +112,9 @@ func (c *mintCommand) run() error {
 		if err := ioutil.WriteFile(tokenPath, []byte(resp.Token), 0600); err != nil {
 			return fmt.Errorf("unable to write token: %v", err)
 		}
+		if c.output.JSON() {
+			return common_cli.PrintJSON(c.env.Stdout, mintJSON{TokenPath: tokenPath})
+		}
 		if err := c.env.Printf("JWT-SVID written to %s\n", tokenPath); err != nil {
 			return err
 		}
@@ -115,6 +123,13 @@ func (c *mintCommand) run() error {
 	return nil
 }
 
+// mintJSON is the JSON output of the command, which holds either the token
+// or the path it was written to.
+type mintJSON struct {
+	Token     string `json:"token,omitempty"`
+	TokenPath string `json:"token_path,omitempty"`
+}
+
 func getJWTSVIDEndOfLife(token string) (time.Time, error) {
 	t, err := jwt.ParseSigned(token)
 	if err != nil {
@@ -37,6 +37,8 @@ const (
 	expectedUsage = `Usage of jwt mint:
   -audience value
     	Audience claim that will be included in the SVID. Can be used more than once.
+  -output format
+    	Output format: text or json
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
   -spiffeID string
@@ -103,6 +105,7 @@ func TestMintRun(t *testing.T) {
 		audience   []string
 		socketPath string
 		write      string
+		output     string
 		extraArgs  []string
 
 		// results
@@ -163,6 +166,27 @@ func TestMintRun(t *testing.T) {
 			},
 			stderr: fmt.Sprintf("JWT-SVID lifetime was capped shorter than specified ttl; expires %q\n", expiry.UTC().Format(time.RFC3339)),
 		},
+		{
+			name:     "success with JSON output",
+			spiffeID: "spiffe://domain.test/workload",
+			audience: []string{"AUDIENCE"},
+			output:   "json",
+			code:     0,
+			resp: &registration.MintJWTSVIDResponse{
+				Token: token,
+			},
+		},
+		{
+			name:     "success with JSON output, output to file",
+			spiffeID: "spiffe://domain.test/workload",
+			audience: []string{"AUDIENCE"},
+			output:   "json",
+			code:     0,
+			write:    "token",
+			resp: &registration.MintJWTSVIDResponse{
+				Token: token,
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -192,6 +216,9 @@ func TestMintRun(t *testing.T) {
 			if testCase.write != "" {
 				args = append(args, "-write", testCase.write)
 			}
+			if testCase.output != "" {
+				args = append(args, "-output", testCase.output)
+			}
 			for _, audience := range testCase.audience {
 				args = append(args, "-audience", audience)
 			}
@@ -216,11 +243,18 @@ func TestMintRun(t *testing.T) {
 
 			// assert output file contents
 			if code == 0 {
-				if testCase.write != "" {
+				switch {
+				case testCase.output == "json" && testCase.write != "":
+					assert.JSONEq(t, fmt.Sprintf(`{"token_path": %q}`, svidPath),
+						stdout.String(), "stdout does not write output path")
+					assertFileData(t, filepath.Join(dir, testCase.write), testCase.resp.Token)
+				case testCase.output == "json":
+					assert.JSONEq(t, fmt.Sprintf(`{"token": %q}`, testCase.resp.Token), stdout.String())
+				case testCase.write != "":
 					assert.Equal(t, fmt.Sprintf("JWT-SVID written to %s\n", svidPath),
 						stdout.String(), "stdout does not write output path")
 					assertFileData(t, filepath.Join(dir, testCase.write), testCase.resp.Token)
-				} else {
+				default:
 					assert.Equal(t, stdout.String(), testCase.resp.Token+"\n")
 				}
 			}
@@ -0,0 +1,277 @@
+package run
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/hashicorp/hcl/hcl/printer"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server"
+	"github.com/spiffe/spire/pkg/server/audit"
+	"github.com/spiffe/spire/pkg/server/fips"
+	"github.com/spiffe/spire/pkg/server/plugin/bundlepublisher"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
+	"github.com/spiffe/spire/pkg/server/plugin/notifier"
+	"github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
+	"github.com/spiffe/spire/pkg/server/plugin/upstreamca"
+)
+
+// hostedTrustDomainConfig is the configuration of an additional trust domain
+// hosted by the server process. Settings not listed here are inherited from
+// the server section.
+type hostedTrustDomainConfig struct {
+	AuditLog            *auditLogConfig             `hcl:"audit_log"`
+	BindPort            int                         `hcl:"bind_port"`
+	DataDir             string                      `hcl:"data_dir"`
+	Federation          *federationConfig           `hcl:"federation"`
+	JWTIssuer           string                      `hcl:"jwt_issuer"`
+	Plugins             *catalog.HCLPluginConfigMap `hcl:"plugins"`
+	RegistrationAPI     registrationAPIConfig       `hcl:"registration_api"`
+	RegistrationUDSPath string                      `hcl:"registration_uds_path"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+// hostedTrustDomainNames returns the names of the hosted trust domains, in
+// order.
+func hostedTrustDomainNames(c *serverConfig) []string {
+	names := make([]string, 0, len(c.HostedTrustDomains))
+	for name := range c.HostedTrustDomains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// serverUnusedKeys returns the unused keys of the server section. HCL reports
+// the names of the hosted trust domains as unused, so they are left out.
+func serverUnusedKeys(c *serverConfig) []string {
+	var unusedKeys []string
+	for _, key := range c.UnusedKeys {
+		if _, ok := c.HostedTrustDomains[key]; !ok {
+			unusedKeys = append(unusedKeys, key)
+		}
+	}
+	return unusedKeys
+}
+
+// validateHostedTrustDomains ensures each hosted trust domain has its own
+// listeners, data directory and datastore.
+func validateHostedTrustDomains(c *Config) error {
+	ports := map[int]string{c.Server.BindPort: "the server"}
+	if c.Server.RegistrationAPI.BindPort != 0 {
+		ports[c.Server.RegistrationAPI.BindPort] = "the server"
+	}
+	socketPaths := map[string]string{c.Server.RegistrationUDSPath: "the server"}
+	dataDirs := map[string]string{c.Server.DataDir: "the server"}
+	dataStores := map[string]string{}
+	for _, config := range dataStoreConfigs(*c.Plugins) {
+		dataStores[config] = "the server"
+	}
+
+	for _, name := range hostedTrustDomainNames(c.Server) {
+		hosted := c.Server.HostedTrustDomains[name]
+		owner := fmt.Sprintf("hosted trust domain %q", name)
+		errorf := func(format string, args ...interface{}) error {
+			return fmt.Errorf("hosted_trust_domain %q: %s", name, fmt.Sprintf(format, args...))
+		}
+
+		if name == c.Server.TrustDomain {
+			return errorf("the trust domain of the server cannot be hosted again")
+		}
+
+		if hosted.BindPort == 0 {
+			return errorf("bind_port must be configured")
+		}
+		if hosted.RegistrationUDSPath == "" {
+			return errorf("registration_uds_path must be configured")
+		}
+		if hosted.DataDir == "" {
+			return errorf("data_dir must be configured")
+		}
+		if hosted.RegistrationAPI.BindAddress != "" && hosted.RegistrationAPI.BindPort == 0 {
+			return errorf("registration_api.bind_port must be configured along with registration_api.bind_address")
+		}
+
+		hostedPorts := []int{hosted.BindPort}
+		if hosted.RegistrationAPI.BindPort != 0 {
+			hostedPorts = append(hostedPorts, hosted.RegistrationAPI.BindPort)
+		}
+		for _, port := range hostedPorts {
+			if other, ok := ports[port]; ok {
+				return errorf("port %d is already used by %s", port, other)
+			}
+			ports[port] = owner
+		}
+		if other, ok := socketPaths[hosted.RegistrationUDSPath]; ok {
+			return errorf("registration_uds_path %q is already used by %s", hosted.RegistrationUDSPath, other)
+		}
+		socketPaths[hosted.RegistrationUDSPath] = owner
+		if other, ok := dataDirs[hosted.DataDir]; ok {
+			return errorf("data_dir %q is already used by %s", hosted.DataDir, other)
+		}
+		dataDirs[hosted.DataDir] = owner
+
+		// The datastore and the keys are not scoped to a trust domain, so
+		// each hosted trust domain must bring its own
+		if hosted.Plugins == nil || len((*hosted.Plugins)[datastore.Type]) == 0 || len((*hosted.Plugins)[keymanager.Type]) == 0 {
+			return errorf("plugins must configure a %s and a %s", datastore.Type, keymanager.Type)
+		}
+		for _, config := range dataStoreConfigs(*hosted.Plugins) {
+			if other, ok := dataStores[config]; ok {
+				return errorf("the %s is already used by %s", datastore.Type, other)
+			}
+			dataStores[config] = owner
+		}
+
+		if hosted.Federation != nil {
+			if err := validateFederationConfig(hosted.Federation); err != nil {
+				return errorf("%v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// dataStoreConfigs returns a key identifying the configuration of each
+// DataStore plugin, so that DataStore plugins sharing the same database can
+// be detected.
+func dataStoreConfigs(plugins catalog.HCLPluginConfigMap) []string {
+	var configs []string
+	for name, config := range plugins[datastore.Type] {
+		var data bytes.Buffer
+		if config.PluginData != nil {
+			if err := printer.DefaultConfig.Fprint(&data, config.PluginData); err != nil {
+				continue
+			}
+		}
+		configs = append(configs, name+"\x00"+config.PluginCmd+"\x00"+config.PluginAddress+"\x00"+data.String())
+	}
+	return configs
+}
+
+// newHostedServerConfigs returns the server configuration of each hosted
+// trust domain, derived from the server configuration sc.
+func newHostedServerConfigs(c *Config, sc *server.Config) ([]server.Config, error) {
+	var configs []server.Config
+	for _, name := range hostedTrustDomainNames(c.Server) {
+		config, err := newHostedServerConfig(sc, name, c.Server.HostedTrustDomains[name])
+		if err != nil {
+			return nil, fmt.Errorf("hosted_trust_domain %q: %v", name, err)
+		}
+		configs = append(configs, *config)
+	}
+	return configs, nil
+}
+
+func newHostedServerConfig(sc *server.Config, name string, hosted hostedTrustDomainConfig) (*server.Config, error) {
+	td, err := idutil.ParseSpiffeID("spiffe://"+name, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, fmt.Errorf("could not parse trust domain: %v", err)
+	}
+
+	hc := *sc
+	hc.TrustDomain = *td
+	hc.Log = sc.Log.WithField(telemetry.TrustDomainID, td.String())
+
+	hc.BindAddress = &net.TCPAddr{
+		IP:   sc.BindAddress.IP,
+		Port: hosted.BindPort,
+	}
+	hc.BindUDSAddress = &net.UnixAddr{
+		Name: hosted.RegistrationUDSPath,
+		Net:  "unix",
+	}
+	hc.BindRegistrationAddress = nil
+	if hosted.RegistrationAPI.BindPort != 0 {
+		registrationIP := sc.BindAddress.IP
+		if hosted.RegistrationAPI.BindAddress != "" {
+			registrationIP = net.ParseIP(hosted.RegistrationAPI.BindAddress)
+			if registrationIP == nil {
+				return nil, fmt.Errorf("could not parse registration_api.bind_address %q", hosted.RegistrationAPI.BindAddress)
+			}
+		}
+		hc.BindRegistrationAddress = &net.TCPAddr{
+			IP:   registrationIP,
+			Port: hosted.RegistrationAPI.BindPort,
+		}
+	}
+
+	hc.DataDir = hosted.DataDir
+	hc.JWTIssuer = hosted.JWTIssuer
+
+	hc.Federation = server.FederationConfig{}
+	if hosted.Federation != nil {
+		hc.Federation, err = newFederationConfig(hosted.Federation, hc.DataDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	hc.Audit = audit.Config{}
+	if hosted.AuditLog != nil {
+		hc.Audit, err = parseAuditLogConfig(hosted.AuditLog)
+		if err != nil {
+			return nil, fmt.Errorf("invalid audit_log: %v", err)
+		}
+	}
+
+	if hosted.Plugins == nil {
+		return nil, errors.New("plugins section must be configured")
+	}
+	hc.PluginConfigs = hostedPluginConfigs(sc.PluginConfigs, *hosted.Plugins)
+	if hc.FIPS {
+		if err := fips.CheckPlugins(hc.PluginConfigs); err != nil {
+			return nil, fmt.Errorf("invalid configuration for FIPS mode: %v", err)
+		}
+	}
+
+	// Telemetry, health checks and profiling are served for the whole
+	// process by the server. The hosted trust domains emit their metrics
+	// through the metrics of the server.
+	hc.Telemetry = telemetry.FileConfig{}
+	hc.HealthChecks = health.Config{}
+	hc.ProfilingEnabled = false
+	hc.ProfilingPort = 0
+	hc.ProfilingSocketPath = ""
+	hc.ProfilingFreq = 0
+	hc.ProfilingNames = nil
+
+	hc.HostedTrustDomains = nil
+	return &hc, nil
+}
+
+// trustDomainPluginTypes are the plugin types that act on behalf of a single
+// trust domain, e.g. publishing its bundle or signing its CA, so they are not
+// inherited by the hosted trust domains from the server.
+var trustDomainPluginTypes = map[string]bool{
+	bundlepublisher.Type:   true,
+	notifier.Type:          true,
+	upstreamauthority.Type: true,
+	upstreamca.Type:        true,
+}
+
+// hostedPluginConfigs returns the plugin configuration of a hosted trust
+// domain: the plugins it configures, and the plugins of the server for the
+// plugin types it does not configure, except the plugin types acting on
+// behalf of the trust domain of the server.
+func hostedPluginConfigs(plugins, hosted catalog.HCLPluginConfigMap) catalog.HCLPluginConfigMap {
+	merged := make(catalog.HCLPluginConfigMap, len(plugins))
+	for pluginType, pluginsForType := range plugins {
+		if trustDomainPluginTypes[pluginType] {
+			continue
+		}
+		merged[pluginType] = pluginsForType
+	}
+	for pluginType, pluginsForType := range hosted {
+		merged[pluginType] = pluginsForType
+	}
+	return merged
+}
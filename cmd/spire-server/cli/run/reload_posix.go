@@ -0,0 +1,12 @@
+// +build !windows
+
+package run
+
+import (
+	"os"
+	"syscall"
+)
+
+// reloadSignals are the signals that make the server reload its log level and
+// plugin configuration
+var reloadSignals = []os.Signal{syscall.SIGHUP}
@@ -0,0 +1,11 @@
+// +build windows
+
+package run
+
+import (
+	"os"
+)
+
+// reloadSignals are the signals that make the server reload its log level and
+// plugin configuration. There is no suitable signal on Windows.
+var reloadSignals []os.Signal
@@ -9,7 +9,9 @@ import (
 	"io"
 	"io/ioutil"
 	"net"
+	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"reflect"
 	"strconv"
@@ -24,24 +26,36 @@ import (
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/tlspolicy"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/server"
+	"github.com/spiffe/spire/pkg/server/audit"
 	bundleClient "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca"
+	server_catalog "github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/endpoints"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
+	"github.com/spiffe/spire/pkg/server/endpoints/node"
+	"github.com/spiffe/spire/pkg/server/fips"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 )
 
 const (
 	commandName = "run"
 
+	// envPrefix prefixes the environment variables overriding the config
+	envPrefix = "SPIRE_SERVER"
+
 	defaultConfigPath         = "conf/server/server.conf"
 	defaultSocketPath         = "/tmp/spire-registration.sock"
 	defaultLogLevel           = "INFO"
 	defaultBundleEndpointPort = 443
 	defaultUpstreamBundle     = true
+	defaultAuditSyslogTag     = "spire-server"
 )
 
 var (
@@ -61,32 +75,58 @@ type Config struct {
 }
 
 type serverConfig struct {
-	BindAddress         string             `hcl:"bind_address"`
-	BindPort            int                `hcl:"bind_port"`
-	CAKeyType           string             `hcl:"ca_key_type"`
-	CASubject           *caSubjectConfig   `hcl:"ca_subject"`
-	CATTL               string             `hcl:"ca_ttl"`
-	DataDir             string             `hcl:"data_dir"`
-	Experimental        experimentalConfig `hcl:"experimental"`
-	Federation          *federationConfig  `hcl:"federation"`
-	JWTIssuer           string             `hcl:"jwt_issuer"`
-	LogFile             string             `hcl:"log_file"`
-	LogLevel            string             `hcl:"log_level"`
-	LogFormat           string             `hcl:"log_format"`
-	RegistrationUDSPath string             `hcl:"registration_uds_path"`
-	DeprecatedSVIDTTL   string             `hcl:"svid_ttl"`
-	DefaultSVIDTTL      string             `hcl:"default_svid_ttl"`
-	TrustDomain         string             `hcl:"trust_domain"`
-	UpstreamBundle      *bool              `hcl:"upstream_bundle"`
+	AllowedJWTClaims    []string              `hcl:"allowed_jwt_svid_claims"`
+	AllowedX509SVIDExts []string              `hcl:"allowed_x509_svid_extensions"`
+	AuditLog            *auditLogConfig       `hcl:"audit_log"`
+	BindAddress         string                `hcl:"bind_address"`
+	BindPort            int                   `hcl:"bind_port"`
+	CAKeyType           string                `hcl:"ca_key_type"`
+	CASubject           *caSubjectConfig      `hcl:"ca_subject"`
+	CATTL               string                `hcl:"ca_ttl"`
+	DataDir             string                `hcl:"data_dir"`
+	DataStoreSlowCall   string                `hcl:"datastore_slow_call_threshold"`
+	Experimental        experimentalConfig    `hcl:"experimental"`
+	Federation          *federationConfig     `hcl:"federation"`
+	FIPS                bool                  `hcl:"fips"`
+	GRPC                grpcConfig            `hcl:"grpc"`
+	JWTIssuer           string                `hcl:"jwt_issuer"`
+	JWTKeyType          string                `hcl:"jwt_key_type"`
+	LogFields           map[string]string     `hcl:"log_fields"`
+	LogFile             string                `hcl:"log_file"`
+	LogDebugSubsystems  []string              `hcl:"log_debug_subsystems"`
+	LogLevel            string                `hcl:"log_level"`
+	LogFormat           string                `hcl:"log_format"`
+	LogRotation         *log.RotationConfig   `hcl:"log_rotation"`
+	LogSyslog           *log.SyslogConfig     `hcl:"log_syslog"`
+	MaxSVIDTTL          string                `hcl:"max_svid_ttl"`
+	PluginSelection     map[string]string     `hcl:"plugin_selection"`
+	PruneAttestedNodes  string                `hcl:"prune_attested_nodes_expired_for"`
+	PruneIssuedSVIDs    string                `hcl:"prune_issued_svids_expired_for"`
+	RateLimit           rateLimitConfig       `hcl:"rate_limit"`
+	RegistrationAPI     registrationAPIConfig `hcl:"registration_api"`
+	RegistrationUDSPath string                `hcl:"registration_uds_path"`
+	ResolveNodes        string                `hcl:"resolve_node_selectors_interval"`
+	SigningConcurrency  int                   `hcl:"signing_concurrency"`
+	TLSPolicy           tlsPolicyConfig       `hcl:"tls_policy"`
+	DeprecatedSVIDTTL   string                `hcl:"svid_ttl"`
+	DefaultSVIDTTL      string                `hcl:"default_svid_ttl"`
+	TrustDomain         string                `hcl:"trust_domain"`
+	TrackIssuedSVIDs    bool                  `hcl:"track_issued_svids"`
+	UpstreamBundle      *bool                 `hcl:"upstream_bundle"`
+	WorkloadKeyPolicy   keyPolicyConfig       `hcl:"workload_key_policy"`
+
+	// Additional trust domains hosted by the server process
+	HostedTrustDomains map[string]hostedTrustDomainConfig `hcl:"hosted_trust_domain"`
 
 	ConfigPath string
 	ExpandEnv  bool
 
 	// Undocumented configurables
-	ProfilingEnabled bool     `hcl:"profiling_enabled"`
-	ProfilingPort    int      `hcl:"profiling_port"`
-	ProfilingFreq    int      `hcl:"profiling_freq"`
-	ProfilingNames   []string `hcl:"profiling_names"`
+	ProfilingEnabled    bool     `hcl:"profiling_enabled"`
+	ProfilingPort       int      `hcl:"profiling_port"`
+	ProfilingSocketPath string   `hcl:"profiling_socket_path"`
+	ProfilingFreq       int      `hcl:"profiling_freq"`
+	ProfilingNames      []string `hcl:"profiling_names"`
 
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
@@ -103,6 +143,83 @@ type experimentalConfig struct {
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
+type grpcConfig struct {
+	KeepaliveTime                string `hcl:"keepalive_time"`
+	KeepaliveTimeout             string `hcl:"keepalive_timeout"`
+	KeepaliveMinTime             string `hcl:"keepalive_min_time"`
+	KeepalivePermitWithoutStream bool   `hcl:"keepalive_permit_without_stream"`
+	MaxConnectionIdle            string `hcl:"max_connection_idle"`
+	MaxConnectionAge             string `hcl:"max_connection_age"`
+	MaxConnectionAgeGrace        string `hcl:"max_connection_age_grace"`
+	MaxRecvMsgSize               int    `hcl:"max_recv_msg_size"`
+	MaxSendMsgSize               int    `hcl:"max_send_msg_size"`
+	ReflectionEnabled            bool   `hcl:"reflection_enabled"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type rateLimitConfig struct {
+	Attestation          float64 `hcl:"attestation"`
+	X509SVIDSigning      float64 `hcl:"x509_svid_signing"`
+	JWTSVIDSigning       float64 `hcl:"jwt_svid_signing"`
+	AgentX509SVIDSigning float64 `hcl:"agent_x509_svid_signing"`
+	AgentJWTSVIDSigning  float64 `hcl:"agent_jwt_svid_signing"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type registrationAPIConfig struct {
+	BindAddress string `hcl:"bind_address"`
+	BindPort    int    `hcl:"bind_port"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type tlsPolicyConfig struct {
+	MinVersion   string   `hcl:"min_version"`
+	CipherSuites []string `hcl:"cipher_suites"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type keyPolicyConfig struct {
+	KeyTypes      []string `hcl:"key_types"`
+	MinRSAKeyBits int      `hcl:"min_rsa_key_bits"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type auditLogConfig struct {
+	File    *auditFileConfig    `hcl:"file"`
+	Syslog  *auditSyslogConfig  `hcl:"syslog"`
+	Webhook *auditWebhookConfig `hcl:"webhook"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type auditFileConfig struct {
+	Path       string `hcl:"path"`
+	MaxSizeMB  int    `hcl:"max_size_mb"`
+	MaxBackups int    `hcl:"max_backups"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type auditSyslogConfig struct {
+	Network string `hcl:"network"`
+	Address string `hcl:"address"`
+	Tag     string `hcl:"tag"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type auditWebhookConfig struct {
+	URL     string `hcl:"url"`
+	Timeout string `hcl:"timeout"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
 type caSubjectConfig struct {
 	Country      []string `hcl:"country"`
 	Organization []string `hcl:"organization"`
@@ -117,10 +234,18 @@ type federationConfig struct {
 }
 
 type bundleEndpointConfig struct {
-	Address    string                    `hcl:"address"`
-	Port       int                       `hcl:"port"`
-	ACME       *bundleEndpointACMEConfig `hcl:"acme"`
-	UnusedKeys []string                  `hcl:",unusedKeys"`
+	Address         string                               `hcl:"address"`
+	Port            int                                  `hcl:"port"`
+	ACME            *bundleEndpointACMEConfig            `hcl:"acme"`
+	ServingCertFile *bundleEndpointServingCertFileConfig `hcl:"serving_cert_file"`
+	RefreshHint     string                               `hcl:"refresh_hint"`
+	UnusedKeys      []string                             `hcl:",unusedKeys"`
+}
+
+type bundleEndpointServingCertFileConfig struct {
+	CertFilePath string   `hcl:"cert_file_path"`
+	KeyFilePath  string   `hcl:"key_file_path"`
+	UnusedKeys   []string `hcl:",unusedKeys"`
 }
 
 type bundleEndpointACMEConfig struct {
@@ -176,16 +301,29 @@ func (cmd *Command) Help() string {
 
 // Help is a standalone function that prints a help message to writer.
 // It is used by both the run and validate commands, so they can share flag usage messages.
-func Help(name string, writer io.Writer) string {
-	_, err := parseFlags(name, []string{"-h"}, writer)
+// The extraFlags functions add the flags specific to the command.
+func Help(name string, writer io.Writer, extraFlags ...func(*flag.FlagSet)) string {
+	_, err := parseFlags(name, []string{"-h"}, writer, extraFlags...)
 	// Error is always present because -h is passed
 	return err.Error()
 }
 
 func LoadConfig(name string, args []string, logOptions []log.Option, output io.Writer) (*server.Config, error) {
+	input, err := ParseConfig(name, args, output)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewServerConfig(input, logOptions)
+}
+
+// ParseConfig parses the CLI flags and the config file they point to, and
+// merges them with the defaults. The extraFlags functions add the flags
+// specific to the command.
+func ParseConfig(name string, args []string, output io.Writer, extraFlags ...func(*flag.FlagSet)) (*Config, error) {
 	// First parse the CLI flags so we can get the config
 	// file path, if set
-	cliInput, err := parseFlags(name, args, output)
+	cliInput, err := parseFlags(name, args, output, extraFlags...)
 	if err != nil {
 		return nil, err
 	}
@@ -197,12 +335,7 @@ func LoadConfig(name string, args []string, logOptions []log.Option, output io.W
 		return nil, err
 	}
 
-	input, err := mergeInput(fileInput, cliInput)
-	if err != nil {
-		return nil, err
-	}
-
-	return NewServerConfig(input, logOptions)
+	return mergeInput(fileInput, cliInput)
 }
 
 // Run the SPIFFE Server
@@ -221,6 +354,7 @@ func (cmd *Command) Run(args []string) int {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	util.SignalListener(ctx, cancel)
+	go reloadOnSignal(ctx, args, c.Log, s)
 
 	err = s.Run(ctx)
 	if err != nil {
@@ -232,11 +366,104 @@ func (cmd *Command) Run(args []string) int {
 	return 0
 }
 
-//Synopsis of the command
+// Synopsis of the command
 func (*Command) Synopsis() string {
 	return "Runs the server"
 }
 
+// reloadOnSignal reloads the log level, the debug subsystems and the plugin
+// configuration from the configuration each time the server receives one of
+// the reload signals, so they can be changed without restarting the server.
+func reloadOnSignal(ctx context.Context, args []string, serverLog logrus.FieldLogger, plugins pluginReconfigurer) {
+	if len(reloadSignals) == 0 {
+		return
+	}
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, reloadSignals...)
+	defer signal.Stop(signalCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signalCh:
+			if logger, ok := serverLog.(*log.Logger); ok {
+				if err := reloadLogLevel(args, logger); err != nil {
+					logger.WithError(err).Error("Unable to reload log level")
+				}
+			}
+			if err := reloadPlugins(ctx, args, plugins); err != nil {
+				serverLog.WithError(err).Error("Unable to reconfigure plugins")
+			}
+		}
+	}
+}
+
+// pluginReconfigurer reconfigures the plugins of a running server and of
+// the trust domains it hosts.
+type pluginReconfigurer interface {
+	ReconfigurePlugins(ctx context.Context, pluginConfig catalog.HCLPluginConfigMap) error
+	ReconfigureHostedPlugins(ctx context.Context, trustDomain string, pluginConfig catalog.HCLPluginConfigMap) error
+}
+
+// reloadLogLevel applies the log_level and log_debug_subsystems settings to
+// the logger. The configuration is loaded as on startup, so the -logLevel
+// flag still takes precedence over the configuration file.
+func reloadLogLevel(args []string, logger *log.Logger) error {
+	cliInput, err := parseFlags(commandName, args, ioutil.Discard)
+	if err != nil {
+		return err
+	}
+	fileInput, err := ParseFile(cliInput.ConfigPath, cliInput.ExpandEnv)
+	if err != nil {
+		return err
+	}
+	input, err := mergeInput(fileInput, cliInput)
+	if err != nil {
+		return err
+	}
+
+	level, err := logrus.ParseLevel(input.Server.LogLevel)
+	if err != nil {
+		return err
+	}
+	logger.SetLevel(level)
+	logger.SetDebugSubsystems(input.Server.LogDebugSubsystems)
+
+	logger.WithFields(logrus.Fields{
+		telemetry.LogLevel:        level.String(),
+		telemetry.DebugSubsystems: strings.Join(logger.DebugSubsystems(), ","),
+	}).Info("Log level reloaded")
+	return nil
+}
+
+// reloadPlugins reconfigures the plugins with the plugin data from the
+// configuration.
+func reloadPlugins(ctx context.Context, args []string, plugins pluginReconfigurer) error {
+	input, err := ParseConfig(commandName, args, ioutil.Discard)
+	if err != nil {
+		return err
+	}
+	if input.Plugins == nil {
+		return errors.New("plugins section must be configured")
+	}
+	if err := plugins.ReconfigurePlugins(ctx, *input.Plugins); err != nil {
+		return err
+	}
+
+	for _, name := range hostedTrustDomainNames(input.Server) {
+		hosted := input.Server.HostedTrustDomains[name]
+		if hosted.Plugins == nil {
+			return fmt.Errorf("hosted_trust_domain %q: plugins section must be configured", name)
+		}
+		if err := plugins.ReconfigureHostedPlugins(ctx, name, hostedPluginConfigs(*input.Plugins, *hosted.Plugins)); err != nil {
+			return fmt.Errorf("hosted_trust_domain %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
 func ParseFile(path string, expandEnv bool) (*Config, error) {
 	c := &Config{}
 
@@ -273,7 +500,7 @@ func ParseFile(path string, expandEnv bool) (*Config, error) {
 	return c, nil
 }
 
-func parseFlags(name string, args []string, output io.Writer) (*serverConfig, error) {
+func parseFlags(name string, args []string, output io.Writer, extraFlags ...func(*flag.FlagSet)) (*serverConfig, error) {
 	flags := flag.NewFlagSet(name, flag.ContinueOnError)
 	flags.SetOutput(output)
 	c := &serverConfig{}
@@ -289,6 +516,9 @@ func parseFlags(name string, args []string, output io.Writer) (*serverConfig, er
 	flags.StringVar(&c.TrustDomain, "trustDomain", "", "The trust domain that this server belongs to")
 	flags.Var(newMaybeBoolValue(&c.UpstreamBundle), "upstreamBundle", "Include upstream CA certificates in the bundle")
 	flags.BoolVar(&c.ExpandEnv, "expandEnv", false, "Expand environment variables in SPIRE config file")
+	for _, addFlags := range extraFlags {
+		addFlags(flags)
+	}
 
 	err := flags.Parse(args)
 	if err != nil {
@@ -301,6 +531,12 @@ func parseFlags(name string, args []string, output io.Writer) (*serverConfig, er
 func mergeInput(fileInput *Config, cliInput *serverConfig) (*Config, error) {
 	c := &Config{Server: &serverConfig{}}
 
+	// Environment variables take precedence over the config file, but not
+	// over the CLI flags
+	if err := overrideFromEnv(fileInput); err != nil {
+		return nil, err
+	}
+
 	// Highest precedence first
 	err := mergo.Merge(c.Server, cliInput)
 	if err != nil {
@@ -320,6 +556,24 @@ func mergeInput(fileInput *Config, cliInput *serverConfig) (*Config, error) {
 	return c, nil
 }
 
+// overrideFromEnv overrides the config with the SPIRE_SERVER_* environment
+// variables. The variables of the server section are not prefixed with the
+// section name (e.g. SPIRE_SERVER_LOG_LEVEL), unlike the variables of the other
+// sections (e.g. SPIRE_SERVER_TELEMETRY_ALLOWED_PREFIXES). The plugins cannot be
+// configured from the environment.
+func overrideFromEnv(c *Config) error {
+	if c.Server == nil {
+		c.Server = &serverConfig{}
+	}
+	if err := common_cli.OverrideFromEnv(envPrefix, c.Server); err != nil {
+		return err
+	}
+	if err := common_cli.OverrideFromEnv(envPrefix+"_TELEMETRY", &c.Telemetry); err != nil {
+		return err
+	}
+	return common_cli.OverrideFromEnv(envPrefix+"_HEALTH_CHECKS", &c.HealthChecks)
+}
+
 func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error) {
 	sc := &server.Config{}
 
@@ -341,6 +595,20 @@ func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error)
 		Net:  "unix",
 	}
 
+	if c.Server.RegistrationAPI.BindPort != 0 {
+		registrationIP := ip
+		if c.Server.RegistrationAPI.BindAddress != "" {
+			registrationIP = net.ParseIP(c.Server.RegistrationAPI.BindAddress)
+			if registrationIP == nil {
+				return nil, fmt.Errorf("could not parse registration_api.bind_address %q", c.Server.RegistrationAPI.BindAddress)
+			}
+		}
+		sc.BindRegistrationAddress = &net.TCPAddr{
+			IP:   registrationIP,
+			Port: c.Server.RegistrationAPI.BindPort,
+		}
+	}
+
 	sc.DataDir = c.Server.DataDir
 
 	td, err := idutil.ParseSpiffeID("spiffe://"+c.Server.TrustDomain, idutil.AllowAnyTrustDomain())
@@ -351,8 +619,11 @@ func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error)
 
 	logOptions = append(logOptions,
 		log.WithLevel(c.Server.LogLevel),
+		log.WithDebugSubsystems(c.Server.LogDebugSubsystems),
 		log.WithFormat(c.Server.LogFormat),
-		log.WithOutputFile(c.Server.LogFile))
+		log.WithRotatingOutputFile(c.Server.LogFile, c.Server.LogRotation),
+		log.WithSyslog(c.Server.LogSyslog),
+		log.WithFields(c.Server.LogFields))
 
 	logger, err := log.NewLogger(logOptions...)
 	if err != nil {
@@ -366,46 +637,58 @@ func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error)
 		sc.UpstreamBundle = defaultUpstreamBundle
 	}
 	sc.Experimental.AllowAgentlessNodeAttestors = c.Server.Experimental.AllowAgentlessNodeAttestors
-	if c.Server.Federation != nil {
-		if c.Server.Federation.BundleEndpoint != nil {
-			sc.Federation.BundleEndpoint = &bundle.EndpointConfig{
-				Address: &net.TCPAddr{
-					IP:   net.ParseIP(c.Server.Federation.BundleEndpoint.Address),
-					Port: c.Server.Federation.BundleEndpoint.Port,
-				},
-			}
 
-			if acme := c.Server.Federation.BundleEndpoint.ACME; acme != nil {
-				sc.Federation.BundleEndpoint.ACME = &bundle.ACMEConfig{
-					DirectoryURL: acme.DirectoryURL,
-					DomainName:   acme.DomainName,
-					CacheDir:     filepath.Join(sc.DataDir, "bundle-acme"),
-					Email:        acme.Email,
-					ToSAccepted:  acme.ToSAccepted,
-				}
-			}
+	sc.GRPC, err = parseGRPCConfig(c.Server.GRPC)
+	if err != nil {
+		return nil, err
+	}
+
+	sc.TLSPolicy, err = tlspolicy.Parse(c.Server.TLSPolicy.MinVersion, c.Server.TLSPolicy.CipherSuites)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tls_policy: %v", err)
+	}
+
+	if c.Server.SigningConcurrency < 0 {
+		return nil, errors.New("signing_concurrency cannot be negative")
+	}
+	sc.SigningConcurrency = c.Server.SigningConcurrency
+
+	sc.NodeAPIRateLimits = node.RateLimits{
+		Attest:   c.Server.RateLimit.Attestation,
+		CSR:      c.Server.RateLimit.X509SVIDSigning,
+		JSR:      c.Server.RateLimit.JWTSVIDSigning,
+		AgentCSR: c.Server.RateLimit.AgentX509SVIDSigning,
+		AgentJSR: c.Server.RateLimit.AgentJWTSVIDSigning,
+	}
+	if err := sc.NodeAPIRateLimits.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rate_limit: %v", err)
+	}
+
+	sc.WorkloadKeyPolicy = node.KeyPolicy{
+		KeyTypes:      c.Server.WorkloadKeyPolicy.KeyTypes,
+		MinRSAKeyBits: c.Server.WorkloadKeyPolicy.MinRSAKeyBits,
+	}
+	if err := sc.WorkloadKeyPolicy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid workload_key_policy: %v", err)
+	}
+
+	if c.Server.AuditLog != nil {
+		sc.Audit, err = parseAuditLogConfig(c.Server.AuditLog)
+		if err != nil {
+			return nil, fmt.Errorf("invalid audit_log: %v", err)
 		}
+	}
 
-		federatesWith := map[string]bundleClient.TrustDomainConfig{}
-		for trustDomain, config := range c.Server.Federation.FederatesWith {
-			port := defaultBundleEndpointPort
-			if config.BundleEndpoint.Port != 0 {
-				port = config.BundleEndpoint.Port
-			}
-			if config.BundleEndpoint.UseWebPKI && config.BundleEndpoint.SpiffeID != "" {
-				return nil, errors.New("usage of `bundle_endpoint.spiffe_id` is not allowed when authenticating with Web PKI")
-			}
-			federatesWith[trustDomain] = bundleClient.TrustDomainConfig{
-				EndpointAddress:  fmt.Sprintf("%s:%d", config.BundleEndpoint.Address, port),
-				EndpointSpiffeID: config.BundleEndpoint.SpiffeID,
-				UseWebPKI:        config.BundleEndpoint.UseWebPKI,
-			}
+	if c.Server.Federation != nil {
+		sc.Federation, err = newFederationConfig(c.Server.Federation, sc.DataDir)
+		if err != nil {
+			return nil, err
 		}
-		sc.Federation.FederatesWith = federatesWith
 	}
 
 	sc.ProfilingEnabled = c.Server.ProfilingEnabled
 	sc.ProfilingPort = c.Server.ProfilingPort
+	sc.ProfilingSocketPath = c.Server.ProfilingSocketPath
 	sc.ProfilingFreq = c.Server.ProfilingFreq
 	sc.ProfilingNames = c.Server.ProfilingNames
 
@@ -417,6 +700,50 @@ func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error)
 		sc.SVIDTTL = ttl
 	}
 
+	if c.Server.MaxSVIDTTL != "" {
+		ttl, err := time.ParseDuration(c.Server.MaxSVIDTTL)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse max_svid_ttl %q: %v", c.Server.MaxSVIDTTL, err)
+		}
+		sc.MaxSVIDTTL = ttl
+		if sc.SVIDTTL > sc.MaxSVIDTTL {
+			return nil, fmt.Errorf("default_svid_ttl %s cannot be greater than max_svid_ttl %s", sc.SVIDTTL, sc.MaxSVIDTTL)
+		}
+	}
+
+	if c.Server.DataStoreSlowCall != "" {
+		threshold, err := time.ParseDuration(c.Server.DataStoreSlowCall)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse datastore slow call threshold %q: %v", c.Server.DataStoreSlowCall, err)
+		}
+		sc.DataStoreSlowCallThreshold = threshold
+	}
+
+	if c.Server.PruneAttestedNodes != "" {
+		period, err := time.ParseDuration(c.Server.PruneAttestedNodes)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse prune_attested_nodes_expired_for %q: %v", c.Server.PruneAttestedNodes, err)
+		}
+		sc.PruneAttestedNodesExpiredFor = period
+	}
+
+	sc.TrackIssuedSVIDs = c.Server.TrackIssuedSVIDs
+	if c.Server.PruneIssuedSVIDs != "" {
+		period, err := time.ParseDuration(c.Server.PruneIssuedSVIDs)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse prune_issued_svids_expired_for %q: %v", c.Server.PruneIssuedSVIDs, err)
+		}
+		sc.PruneIssuedSVIDsExpiredFor = period
+	}
+
+	if c.Server.ResolveNodes != "" {
+		interval, err := time.ParseDuration(c.Server.ResolveNodes)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse resolve_node_selectors_interval %q: %v", c.Server.ResolveNodes, err)
+		}
+		sc.ResolveNodeSelectorsInterval = interval
+	}
+
 	if c.Server.CATTL != "" {
 		ttl, err := time.ParseDuration(c.Server.CATTL)
 		if err != nil {
@@ -430,13 +757,22 @@ func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error)
 	}
 
 	if c.Server.CAKeyType != "" {
-		sc.CAKeyType, err = caKeyTypeFromString(c.Server.CAKeyType)
+		sc.CAKeyType, err = keyTypeFromString(c.Server.CAKeyType)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("error parsing ca_key_type: %v", err)
+		}
+	}
+
+	if c.Server.JWTKeyType != "" {
+		sc.JWTKeyType, err = keyTypeFromString(c.Server.JWTKeyType)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing jwt_key_type: %v", err)
 		}
 	}
 
 	sc.JWTIssuer = c.Server.JWTIssuer
+	sc.AllowedJWTSVIDClaims = c.Server.AllowedJWTClaims
+	sc.AllowedX509SVIDExtensions = c.Server.AllowedX509SVIDExts
 
 	if subject := c.Server.CASubject; subject != nil {
 		sc.CASubject = pkix.Name{
@@ -454,9 +790,21 @@ func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error)
 	}
 
 	sc.PluginConfigs = *c.Plugins
+	sc.PluginSelection = c.Server.PluginSelection
 	sc.Telemetry = c.Telemetry
 	sc.HealthChecks = c.HealthChecks
 
+	if c.Server.FIPS {
+		if err := restrictToFIPS(sc); err != nil {
+			return nil, fmt.Errorf("invalid configuration for FIPS mode: %v", err)
+		}
+	}
+
+	sc.HostedTrustDomains, err = newHostedServerConfigs(c, sc)
+	if err != nil {
+		return nil, err
+	}
+
 	// Write out deprecation warnings
 	warnOnDeprecatedConfig(c, sc.Log)
 
@@ -470,11 +818,72 @@ func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error)
 	return sc, nil
 }
 
+// newFederationConfig returns the federation configuration of a server
+// storing its runtime data in dataDir.
+func newFederationConfig(fc *federationConfig, dataDir string) (server.FederationConfig, error) {
+	var federation server.FederationConfig
+	if fc.BundleEndpoint != nil {
+		federation.BundleEndpoint = &bundle.EndpointConfig{
+			Address: &net.TCPAddr{
+				IP:   net.ParseIP(fc.BundleEndpoint.Address),
+				Port: fc.BundleEndpoint.Port,
+			},
+		}
+
+		if acme := fc.BundleEndpoint.ACME; acme != nil {
+			federation.BundleEndpoint.ACME = &bundle.ACMEConfig{
+				DirectoryURL: acme.DirectoryURL,
+				DomainName:   acme.DomainName,
+				CacheDir:     filepath.Join(dataDir, "bundle-acme"),
+				Email:        acme.Email,
+				ToSAccepted:  acme.ToSAccepted,
+			}
+		}
+
+		if servingCertFile := fc.BundleEndpoint.ServingCertFile; servingCertFile != nil {
+			federation.BundleEndpoint.DiskCertificate = &bundle.DiskCertificateConfig{
+				CertFilePath: servingCertFile.CertFilePath,
+				KeyFilePath:  servingCertFile.KeyFilePath,
+			}
+		}
+
+		if refreshHint := fc.BundleEndpoint.RefreshHint; refreshHint != "" {
+			hint, err := time.ParseDuration(refreshHint)
+			if err != nil {
+				return server.FederationConfig{}, fmt.Errorf("could not parse federation.bundle_endpoint.refresh_hint %q: %v", refreshHint, err)
+			}
+			federation.BundleEndpoint.RefreshHint = hint
+		}
+	}
+
+	federatesWith := map[string]bundleClient.TrustDomainConfig{}
+	for trustDomain, config := range fc.FederatesWith {
+		port := defaultBundleEndpointPort
+		if config.BundleEndpoint.Port != 0 {
+			port = config.BundleEndpoint.Port
+		}
+		if config.BundleEndpoint.UseWebPKI && config.BundleEndpoint.SpiffeID != "" {
+			return server.FederationConfig{}, errors.New("usage of `bundle_endpoint.spiffe_id` is not allowed when authenticating with Web PKI")
+		}
+		federatesWith[trustDomain] = bundleClient.TrustDomainConfig{
+			EndpointAddress:  fmt.Sprintf("%s:%d", config.BundleEndpoint.Address, port),
+			EndpointSpiffeID: config.BundleEndpoint.SpiffeID,
+			UseWebPKI:        config.BundleEndpoint.UseWebPKI,
+		}
+	}
+	federation.FederatesWith = federatesWith
+	return federation, nil
+}
+
 func validateConfig(c *Config) error {
 	if c.Server == nil {
 		return errors.New("server section must be configured")
 	}
 
+	if c.Server.ProfilingPort > 0 && c.Server.ProfilingSocketPath != "" {
+		return errors.New("profiling_port and profiling_socket_path cannot both be configured")
+	}
+
 	if c.Server.BindAddress == "" || c.Server.BindPort == 0 {
 		return errors.New("bind_address and bind_port must be configured")
 	}
@@ -483,6 +892,23 @@ func validateConfig(c *Config) error {
 		return errors.New("registration_uds_path must be configured")
 	}
 
+	if util.IsAbstractUDS(c.Server.RegistrationUDSPath) {
+		return errors.New("registration_uds_path cannot be an abstract socket since access to it is restricted by its permissions")
+	}
+
+	if err := util.ValidateUDSPath(c.Server.RegistrationUDSPath); err != nil {
+		return fmt.Errorf("invalid registration_uds_path: %v", err)
+	}
+
+	if c.Server.RegistrationAPI.BindAddress != "" && c.Server.RegistrationAPI.BindPort == 0 {
+		return errors.New("registration_api.bind_port must be configured along with registration_api.bind_address")
+	}
+
+	if c.Server.RegistrationAPI.BindPort == c.Server.BindPort &&
+		(c.Server.RegistrationAPI.BindAddress == "" || c.Server.RegistrationAPI.BindAddress == c.Server.BindAddress) {
+		return errors.New("registration_api must not be bound to the same address as the server")
+	}
+
 	if c.Server.TrustDomain == "" {
 		return errors.New("trust_domain must be configured")
 	}
@@ -495,6 +921,25 @@ func validateConfig(c *Config) error {
 		return errors.New("plugins section must be configured")
 	}
 
+	if err := server_catalog.ValidatePluginSelection(c.Server.PluginSelection); err != nil {
+		return fmt.Errorf("plugin_selection: %v", err)
+	}
+
+	for _, claim := range c.Server.AllowedJWTClaims {
+		if jwtsvid.IsRegisteredClaim(claim) {
+			return fmt.Errorf("allowed_jwt_svid_claims cannot include the registered claim %q", claim)
+		}
+	}
+
+	for _, oid := range c.Server.AllowedX509SVIDExts {
+		if _, err := x509svid.ParseOID(oid); err != nil {
+			return fmt.Errorf("allowed_x509_svid_extensions: %v", err)
+		}
+		if x509svid.IsReservedExtension(oid) {
+			return fmt.Errorf("allowed_x509_svid_extensions cannot include the reserved extension %q", oid)
+		}
+	}
+
 	if c.Server.Federation != nil {
 		// TODO: Remove this check once the deprecated experimental federation options are removed.
 		if isDeprecatedFederationConfigUsed(c.Server.Experimental) {
@@ -504,23 +949,8 @@ func validateConfig(c *Config) error {
 				"federates_with")
 		}
 
-		if c.Server.Federation.BundleEndpoint != nil &&
-			c.Server.Federation.BundleEndpoint.ACME != nil {
-			acme := c.Server.Federation.BundleEndpoint.ACME
-
-			if acme.DomainName == "" {
-				return errors.New("federation.bundle_endpoint.acme.domain_name must be configured")
-			}
-
-			if acme.Email == "" {
-				return errors.New("federation.bundle_endpoint.acme.email must be configured")
-			}
-		}
-
-		for td, tdConfig := range c.Server.Federation.FederatesWith {
-			if tdConfig.BundleEndpoint.Address == "" {
-				return fmt.Errorf("federation.federates_with[\"%s\"].bundle_endpoint.address must be configured", td)
-			}
+		if err := validateFederationConfig(c.Server.Federation); err != nil {
+			return err
 		}
 	} else { // TODO: Remove this else block once the deprecated experimental federation options are removed.
 		if acme := c.Server.Experimental.DeprecatedBundleEndpointACME; acme != nil {
@@ -547,6 +977,45 @@ func validateConfig(c *Config) error {
 		return errors.New(`the "svid_ttl" configurable has been deprecated and renamed to "default_svid_ttl"; please update your configuration`)
 	}
 
+	return validateHostedTrustDomains(c)
+}
+
+func validateFederationConfig(fc *federationConfig) error {
+	if fc.BundleEndpoint != nil &&
+		fc.BundleEndpoint.ACME != nil {
+		acme := fc.BundleEndpoint.ACME
+
+		if acme.DomainName == "" {
+			return errors.New("federation.bundle_endpoint.acme.domain_name must be configured")
+		}
+
+		if acme.Email == "" {
+			return errors.New("federation.bundle_endpoint.acme.email must be configured")
+		}
+	}
+
+	if fc.BundleEndpoint != nil &&
+		fc.BundleEndpoint.ServingCertFile != nil {
+		if fc.BundleEndpoint.ACME != nil {
+			return errors.New("federation.bundle_endpoint.acme and federation.bundle_endpoint.serving_cert_file are mutually exclusive")
+		}
+
+		servingCertFile := fc.BundleEndpoint.ServingCertFile
+		if servingCertFile.CertFilePath == "" {
+			return errors.New("federation.bundle_endpoint.serving_cert_file.cert_file_path must be configured")
+		}
+
+		if servingCertFile.KeyFilePath == "" {
+			return errors.New("federation.bundle_endpoint.serving_cert_file.key_file_path must be configured")
+		}
+	}
+
+	for td, tdConfig := range fc.FederatesWith {
+		if tdConfig.BundleEndpoint.Address == "" {
+			return fmt.Errorf("federation.federates_with[\"%s\"].bundle_endpoint.address must be configured", td)
+		}
+	}
+
 	return nil
 }
 
@@ -597,27 +1066,186 @@ func warnOnDeprecatedConfig(c *Config, l logrus.FieldLogger) {
 	if isDeprecatedFederationConfigUsed(c.Server.Experimental) {
 		l.Warn("The experimental federation configurables will be deprecated in a future release. Please see issue #1619 and the configuration documentation for more information.")
 	}
+
+	if c.Server.ProfilingPort > 0 {
+		l.Warn("The `profiling_port` configurable is deprecated as the profiling endpoints it serves can be reached by any local user. Please use `profiling_socket_path` instead.")
+	}
+}
+
+func parseGRPCConfig(c grpcConfig) (endpoints.GRPCConfig, error) {
+	gc := endpoints.GRPCConfig{
+		KeepalivePermitWithoutStream: c.KeepalivePermitWithoutStream,
+		ReflectionEnabled:            c.ReflectionEnabled,
+	}
+	var err error
+	if gc.KeepaliveTime, err = parseGRPCDuration("keepalive_time", c.KeepaliveTime); err != nil {
+		return endpoints.GRPCConfig{}, err
+	}
+	if gc.KeepaliveTimeout, err = parseGRPCDuration("keepalive_timeout", c.KeepaliveTimeout); err != nil {
+		return endpoints.GRPCConfig{}, err
+	}
+	if gc.KeepaliveMinTime, err = parseGRPCDuration("keepalive_min_time", c.KeepaliveMinTime); err != nil {
+		return endpoints.GRPCConfig{}, err
+	}
+	if gc.MaxConnectionIdle, err = parseGRPCDuration("max_connection_idle", c.MaxConnectionIdle); err != nil {
+		return endpoints.GRPCConfig{}, err
+	}
+	if gc.MaxConnectionAge, err = parseGRPCDuration("max_connection_age", c.MaxConnectionAge); err != nil {
+		return endpoints.GRPCConfig{}, err
+	}
+	if gc.MaxConnectionAgeGrace, err = parseGRPCDuration("max_connection_age_grace", c.MaxConnectionAgeGrace); err != nil {
+		return endpoints.GRPCConfig{}, err
+	}
+	if c.MaxRecvMsgSize < 0 || c.MaxSendMsgSize < 0 {
+		return endpoints.GRPCConfig{}, errors.New("grpc message sizes cannot be negative")
+	}
+	gc.MaxRecvMsgSize = c.MaxRecvMsgSize
+	gc.MaxSendMsgSize = c.MaxSendMsgSize
+	return gc, nil
 }
 
+func parseAuditLogConfig(c *auditLogConfig) (audit.Config, error) {
+	var ac audit.Config
+
+	if c.File != nil {
+		if c.File.Path == "" {
+			return audit.Config{}, errors.New("file path is required")
+		}
+		if c.File.MaxSizeMB < 0 || c.File.MaxBackups < 0 {
+			return audit.Config{}, errors.New("file max_size_mb and max_backups cannot be negative")
+		}
+		ac.File = &audit.FileConfig{
+			Path:       c.File.Path,
+			MaxSize:    int64(c.File.MaxSizeMB) * 1024 * 1024,
+			MaxBackups: c.File.MaxBackups,
+		}
+	}
+
+	if c.Syslog != nil {
+		tag := c.Syslog.Tag
+		if tag == "" {
+			tag = defaultAuditSyslogTag
+		}
+		ac.Syslog = &audit.SyslogConfig{
+			Network: c.Syslog.Network,
+			Address: c.Syslog.Address,
+			Tag:     tag,
+		}
+	}
+
+	if c.Webhook != nil {
+		u, err := url.Parse(c.Webhook.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return audit.Config{}, fmt.Errorf("webhook url %q must be an http or https URL", c.Webhook.URL)
+		}
+		var timeout time.Duration
+		if c.Webhook.Timeout != "" {
+			timeout, err = time.ParseDuration(c.Webhook.Timeout)
+			if err != nil {
+				return audit.Config{}, fmt.Errorf("could not parse webhook timeout %q: %v", c.Webhook.Timeout, err)
+			}
+		}
+		ac.Webhook = &audit.WebhookConfig{
+			URL:     c.Webhook.URL,
+			Timeout: timeout,
+		}
+	}
+
+	return ac, nil
+}
+
+func parseGRPCDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse grpc %s: %v", name, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("grpc %s must be positive", name)
+	}
+	return d, nil
+}
+
+// unknownConfigWarning formats the warnings logged for unknown config options.
+const unknownConfigWarning = "%s; this will be fatal in a future release."
+
 func warnOnUnknownConfig(c *Config, l logrus.FieldLogger) {
+	for _, unknown := range detectUnknownConfig(c) {
+		l.Warnf(unknownConfigWarning, unknown)
+	}
+}
+
+// detectUnknownConfig returns a message for each configuration section
+// holding unknown options.
+func detectUnknownConfig(c *Config) []string {
+	var unknowns []string
+	detected := func(format string, args ...interface{}) {
+		unknowns = append(unknowns, fmt.Sprintf(format, args...))
+	}
+
 	if len(c.UnusedKeys) != 0 {
-		l.Warnf("Detected unknown top-level config options: %q; this will be fatal in a future release.", c.UnusedKeys)
+		detected("Detected unknown top-level config options: %q", c.UnusedKeys)
 	}
 
 	if c.Server != nil {
-		if len(c.Server.UnusedKeys) != 0 {
-			l.Warnf("Detected unknown server config options: %q; this will be fatal in a future release.", c.Server.UnusedKeys)
+		if unusedKeys := serverUnusedKeys(c.Server); len(unusedKeys) != 0 {
+			detected("Detected unknown server config options: %q", unusedKeys)
 		}
 
 		if cs := c.Server.CASubject; cs != nil && len(cs.UnusedKeys) != 0 {
-			l.Warnf("Detected unknown CA Subject config options: %q; this will be fatal in a future release.", cs.UnusedKeys)
+			detected("Detected unknown CA Subject config options: %q", cs.UnusedKeys)
+		}
+
+		if len(c.Server.GRPC.UnusedKeys) != 0 {
+			detected("Detected unknown grpc config options: %q", c.Server.GRPC.UnusedKeys)
+		}
+
+		if len(c.Server.RateLimit.UnusedKeys) != 0 {
+			detected("Detected unknown rate limit config options: %q", c.Server.RateLimit.UnusedKeys)
+		}
+
+		if len(c.Server.RegistrationAPI.UnusedKeys) != 0 {
+			detected("Detected unknown registration API config options: %q", c.Server.RegistrationAPI.UnusedKeys)
+		}
+
+		if len(c.Server.TLSPolicy.UnusedKeys) != 0 {
+			detected("Detected unknown TLS policy config options: %q", c.Server.TLSPolicy.UnusedKeys)
+		}
+
+		if len(c.Server.WorkloadKeyPolicy.UnusedKeys) != 0 {
+			detected("Detected unknown workload key policy config options: %q", c.Server.WorkloadKeyPolicy.UnusedKeys)
+		}
+
+		if lr := c.Server.LogRotation; lr != nil && len(lr.UnusedKeys) != 0 {
+			detected("Detected unknown log rotation config options: %q", lr.UnusedKeys)
+		}
+
+		if ls := c.Server.LogSyslog; ls != nil && len(ls.UnusedKeys) != 0 {
+			detected("Detected unknown log syslog config options: %q", ls.UnusedKeys)
+		}
+
+		if al := c.Server.AuditLog; al != nil {
+			if len(al.UnusedKeys) != 0 {
+				detected("Detected unknown audit log config options: %q", al.UnusedKeys)
+			}
+			if al.File != nil && len(al.File.UnusedKeys) != 0 {
+				detected("Detected unknown audit log file config options: %q", al.File.UnusedKeys)
+			}
+			if al.Syslog != nil && len(al.Syslog.UnusedKeys) != 0 {
+				detected("Detected unknown audit log syslog config options: %q", al.Syslog.UnusedKeys)
+			}
+			if al.Webhook != nil && len(al.Webhook.UnusedKeys) != 0 {
+				detected("Detected unknown audit log webhook config options: %q", al.Webhook.UnusedKeys)
+			}
 		}
 
 		// TODO: Re-enable unused key detection for experimental config. See
 		// https://github.com/spiffe/spire/issues/1101 for more information
 		//
 		//if len(c.Server.Experimental.UnusedKeys) != 0 {
-		//	l.Warnf("Detected unknown experimental config options: %q; this will be fatal in a future release.", c.Server.Experimental.UnusedKeys)
+		//	detected("Detected unknown experimental config options: %q", c.Server.Experimental.UnusedKeys)
 		//}
 
 		if c.Server.Federation != nil {
@@ -625,63 +1253,79 @@ func warnOnUnknownConfig(c *Config, l logrus.FieldLogger) {
 			// https://github.com/spiffe/spire/issues/1101 for more information
 			//
 			//if len(c.Server.Federation.UnusedKeys) != 0 {
-			//	l.Warnf("Detected unknown federation config options: %q; this will be fatal in a future release.", c.Server.Federation.UnusedKeys)
+			//	detected("Detected unknown federation config options: %q", c.Server.Federation.UnusedKeys)
 			//}
 
 			if c.Server.Federation.BundleEndpoint != nil {
 				if len(c.Server.Federation.BundleEndpoint.UnusedKeys) != 0 {
-					l.Warnf("Detected unknown federation config options: %q; this will be fatal in a future release.", c.Server.Federation.BundleEndpoint.UnusedKeys)
+					detected("Detected unknown federation config options: %q", c.Server.Federation.BundleEndpoint.UnusedKeys)
 				}
 
 				if bea := c.Server.Federation.BundleEndpoint.ACME; bea != nil && len(bea.UnusedKeys) != 0 {
-					l.Warnf("Detected unknown ACME config options: %q; this will be fatal in a future release.", bea.UnusedKeys)
+					detected("Detected unknown ACME config options: %q", bea.UnusedKeys)
+				}
+
+				if scf := c.Server.Federation.BundleEndpoint.ServingCertFile; scf != nil && len(scf.UnusedKeys) != 0 {
+					detected("Detected unknown serving certificate file config options: %q", scf.UnusedKeys)
 				}
 			}
 
 			for k, v := range c.Server.Federation.FederatesWith {
 				if len(v.UnusedKeys) != 0 {
-					l.Warnf("Detected unknown federation config options for %q: %q; this will be fatal in a future release.", k, v.UnusedKeys)
+					detected("Detected unknown federation config options for %q: %q", k, v.UnusedKeys)
 				}
 			}
 		}
+
+		for _, name := range hostedTrustDomainNames(c.Server) {
+			hosted := c.Server.HostedTrustDomains[name]
+			if len(hosted.UnusedKeys) != 0 {
+				detected("Detected unknown hosted trust domain config options for %q: %q", name, hosted.UnusedKeys)
+			}
+			if len(hosted.RegistrationAPI.UnusedKeys) != 0 {
+				detected("Detected unknown registration API config options for hosted trust domain %q: %q", name, hosted.RegistrationAPI.UnusedKeys)
+			}
+		}
 	}
 
 	// TODO: Re-enable unused key detection for telemetry. See
 	// https://github.com/spiffe/spire/issues/1101 for more information
 	//
 	//if len(c.Telemetry.UnusedKeys) != 0 {
-	//	l.Warnf("Detected unknown telemetry config options: %q; this will be fatal in a future release.", c.Telemetry.UnusedKeys)
+	//	detected("Detected unknown telemetry config options: %q", c.Telemetry.UnusedKeys)
 	//}
 
 	if p := c.Telemetry.Prometheus; p != nil && len(p.UnusedKeys) != 0 {
-		l.Warnf("Detected unknown Prometheus config options: %q; this will be fatal in a future release.", p.UnusedKeys)
+		detected("Detected unknown Prometheus config options: %q", p.UnusedKeys)
 	}
 
 	for _, v := range c.Telemetry.DogStatsd {
 		if len(v.UnusedKeys) != 0 {
-			l.Warnf("Detected unknown DogStatsd config options: %q; this will be fatal in a future release.", v.UnusedKeys)
+			detected("Detected unknown DogStatsd config options: %q", v.UnusedKeys)
 		}
 	}
 
 	for _, v := range c.Telemetry.Statsd {
 		if len(v.UnusedKeys) != 0 {
-			l.Warnf("Detected unknown Statsd config options: %q; this will be fatal in a future release.", v.UnusedKeys)
+			detected("Detected unknown Statsd config options: %q", v.UnusedKeys)
 		}
 	}
 
 	for _, v := range c.Telemetry.M3 {
 		if len(v.UnusedKeys) != 0 {
-			l.Warnf("Detected unknown M3 config options: %q; this will be fatal in a future release.", v.UnusedKeys)
+			detected("Detected unknown M3 config options: %q", v.UnusedKeys)
 		}
 	}
 
 	if p := c.Telemetry.InMem; p != nil && len(p.UnusedKeys) != 0 {
-		l.Warnf("Detected unknown InMem config options: %q; this will be fatal in a future release.", p.UnusedKeys)
+		detected("Detected unknown InMem config options: %q", p.UnusedKeys)
 	}
 
 	if len(c.HealthChecks.UnusedKeys) != 0 {
-		l.Warnf("Detected unknown health check config options: %q; this will be fatal in a future release.", c.HealthChecks.UnusedKeys)
+		detected("Detected unknown health check config options: %q", c.HealthChecks.UnusedKeys)
 	}
+
+	return unknowns
 }
 
 func defaultConfig() *Config {
@@ -697,7 +1341,37 @@ func defaultConfig() *Config {
 	}
 }
 
-func caKeyTypeFromString(s string) (keymanager.KeyType, error) {
+// restrictToFIPS restricts the key types, TLS policy and plugins of the
+// server configuration to FIPS-approved choices, failing if any of them was
+// explicitly configured otherwise.
+func restrictToFIPS(sc *server.Config) (err error) {
+	sc.FIPS = true
+
+	if sc.CAKeyType != keymanager.KeyType_UNSPECIFIED_KEY_TYPE {
+		if err := fips.CheckKeyType(sc.CAKeyType); err != nil {
+			return fmt.Errorf("ca_key_type: %v", err)
+		}
+	}
+	if sc.JWTKeyType != keymanager.KeyType_UNSPECIFIED_KEY_TYPE {
+		if err := fips.CheckKeyType(sc.JWTKeyType); err != nil {
+			return fmt.Errorf("jwt_key_type: %v", err)
+		}
+	}
+
+	sc.WorkloadKeyPolicy, err = sc.WorkloadKeyPolicy.RestrictToFIPS(fips.MinRSAKeyBits)
+	if err != nil {
+		return fmt.Errorf("workload_key_policy: %v", err)
+	}
+
+	sc.TLSPolicy, err = fips.TLSPolicy(sc.TLSPolicy)
+	if err != nil {
+		return fmt.Errorf("tls_policy: %v", err)
+	}
+
+	return fips.CheckPlugins(sc.PluginConfigs)
+}
+
+func keyTypeFromString(s string) (keymanager.KeyType, error) {
 	switch strings.ToLower(s) {
 	case "rsa-2048":
 		return keymanager.KeyType_RSA_2048, nil
@@ -708,7 +1382,7 @@ func caKeyTypeFromString(s string) (keymanager.KeyType, error) {
 	case "ec-p384":
 		return keymanager.KeyType_EC_P384, nil
 	default:
-		return keymanager.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("CA key type %q is unknown; must be one of [rsa-2048, rsa-4096, ec-p256, ec-p384]", s)
+		return keymanager.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("key type %q is unknown; must be one of [rsa-2048, rsa-4096, ec-p256, ec-p384]", s)
 	}
 }
 
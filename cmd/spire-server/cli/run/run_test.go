@@ -2,10 +2,14 @@ package run
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"crypto/x509/pkix"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,7 +19,11 @@ import (
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/server"
+	"github.com/spiffe/spire/pkg/server/audit"
 	bundleClient "github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/spiffe/spire/pkg/server/endpoints"
+	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
+	"github.com/spiffe/spire/pkg/server/endpoints/node"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/assert"
@@ -189,6 +197,16 @@ func TestMergeInput(t *testing.T) {
 				require.Equal(t, "rsa-2048", c.Server.CAKeyType)
 			},
 		},
+		{
+			msg: "jwt_key_type should be configurable by file",
+			fileInput: func(c *Config) {
+				c.Server.JWTKeyType = "ec-p384"
+			},
+			cliInput: func(c *serverConfig) {},
+			test: func(t *testing.T, c *Config) {
+				require.Equal(t, "ec-p384", c.Server.JWTKeyType)
+			},
+		},
 		{
 			msg: "ca_subject should be configurable by file",
 			fileInput: func(c *Config) {
@@ -535,6 +553,41 @@ func TestMergeInput(t *testing.T) {
 	}
 }
 
+func TestMergeInputFromEnv(t *testing.T) {
+	env := map[string]string{
+		"SPIRE_SERVER_LOG_LEVEL":                  "DEBUG",
+		"SPIRE_SERVER_TRUST_DOMAIN":               "env.example.org",
+		"SPIRE_SERVER_LOG_ROTATION_MAX_SIZE_MB":   "10",
+		"SPIRE_SERVER_TELEMETRY_ALLOWED_PREFIXES": "a,b",
+	}
+	for name, value := range env {
+		require.NoError(t, os.Setenv(name, value))
+		defer os.Unsetenv(name)
+	}
+
+	fileInput := &Config{Server: &serverConfig{
+		LogLevel:    "WARN",
+		TrustDomain: "file.example.org",
+	}}
+	cliInput := &serverConfig{
+		TrustDomain: "cli.example.org",
+	}
+
+	c, err := mergeInput(fileInput, cliInput)
+	require.NoError(t, err)
+
+	// The environment overrides the file, and CLI flags override the environment
+	assert.Equal(t, "DEBUG", c.Server.LogLevel)
+	assert.Equal(t, "cli.example.org", c.Server.TrustDomain)
+	require.NotNil(t, c.Server.LogRotation)
+	assert.Equal(t, 10, c.Server.LogRotation.MaxSizeMB)
+	assert.Equal(t, []string{"a", "b"}, c.Telemetry.AllowedPrefixes)
+
+	require.NoError(t, os.Setenv("SPIRE_SERVER_LOG_ROTATION_MAX_SIZE_MB", "many"))
+	_, err = mergeInput(&Config{Server: &serverConfig{}}, &serverConfig{})
+	require.EqualError(t, err, `invalid value for SPIRE_SERVER_LOG_ROTATION_MAX_SIZE_MB: strconv.ParseInt: parsing "many": invalid syntax`)
+}
+
 func TestNewServerConfig(t *testing.T) {
 	cases := []struct {
 		msg         string
@@ -553,6 +606,140 @@ func TestNewServerConfig(t *testing.T) {
 				require.Equal(t, 1337, c.BindAddress.Port)
 			},
 		},
+		{
+			msg:   "registration_api should not be served on a dedicated listener by default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c.BindRegistrationAddress)
+			},
+		},
+		{
+			msg: "registration_api.bind_address should default to bind_address",
+			input: func(c *Config) {
+				c.Server.BindAddress = "192.168.1.1"
+				c.Server.RegistrationAPI.BindPort = 8082
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, "192.168.1.1", c.BindRegistrationAddress.IP.String())
+				require.Equal(t, 8082, c.BindRegistrationAddress.Port)
+			},
+		},
+		{
+			msg: "registration_api bind_address and bind_port should be correctly parsed",
+			input: func(c *Config) {
+				c.Server.RegistrationAPI.BindAddress = "127.0.0.1"
+				c.Server.RegistrationAPI.BindPort = 8082
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, "127.0.0.1", c.BindRegistrationAddress.IP.String())
+				require.Equal(t, 8082, c.BindRegistrationAddress.Port)
+			},
+		},
+		{
+			msg:         "invalid registration_api.bind_address should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.RegistrationAPI.BindAddress = "this-is-not-an-ip-address"
+				c.Server.RegistrationAPI.BindPort = 8082
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "registration_api sharing the node API address should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.BindPort = 8081
+				c.Server.RegistrationAPI.BindPort = 8081
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "registration_api.bind_address without bind_port should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.RegistrationAPI.BindAddress = "127.0.0.1"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "tls_policy should be correctly parsed",
+			input: func(c *Config) {
+				c.Server.TLSPolicy.MinVersion = "1.2"
+				c.Server.TLSPolicy.CipherSuites = []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, uint16(tls.VersionTLS12), c.TLSPolicy.MinVersion)
+				require.Equal(t, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}, c.TLSPolicy.CipherSuites)
+			},
+		},
+		{
+			msg:         "invalid tls_policy should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.TLSPolicy.MinVersion = "1.0"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "fips should restrict the TLS policy and workload key policy",
+			input: func(c *Config) {
+				c.Server.FIPS = true
+				c.Server.CAKeyType = "rsa-2048"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.True(t, c.FIPS)
+				require.Equal(t, uint16(tls.VersionTLS12), c.TLSPolicy.MinVersion)
+				require.Equal(t, uint16(tls.VersionTLS12), c.TLSPolicy.MaxVersion)
+				require.NotContains(t, c.TLSPolicy.CipherSuites, tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305)
+				require.NotContains(t, c.WorkloadKeyPolicy.KeyTypes, node.KeyTypeEd25519)
+				require.Equal(t, 2048, c.WorkloadKeyPolicy.MinRSAKeyBits)
+			},
+		},
+		{
+			msg:         "fips with TLS 1.3 should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.FIPS = true
+				c.Server.TLSPolicy.MinVersion = "1.3"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "fips with an ed25519 workload key type should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.FIPS = true
+				c.Server.WorkloadKeyPolicy.KeyTypes = []string{"ed25519"}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "fips with an external plugin should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.FIPS = true
+				c.Plugins = &catalog.HCLPluginConfigMap{
+					"KeyManager": {
+						"hsm": {PluginCmd: "./hsm"},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg:         "invalid bind_address should return an error",
 			expectError: true,
@@ -638,6 +825,57 @@ func TestNewServerConfig(t *testing.T) {
 				require.Equal(t, &logrus.TextFormatter{}, l.Formatter)
 			},
 		},
+		{
+			msg: "grpc should be correctly parsed",
+			input: func(c *Config) {
+				c.Server.GRPC = grpcConfig{
+					KeepaliveTime:                "1m",
+					KeepaliveTimeout:             "10s",
+					KeepaliveMinTime:             "30s",
+					KeepalivePermitWithoutStream: true,
+					MaxConnectionIdle:            "5m",
+					MaxConnectionAge:             "10m",
+					MaxConnectionAgeGrace:        "20s",
+					MaxRecvMsgSize:               8 << 20,
+					MaxSendMsgSize:               16 << 20,
+					ReflectionEnabled:            true,
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, endpoints.GRPCConfig{
+					KeepaliveTime:                time.Minute,
+					KeepaliveTimeout:             10 * time.Second,
+					KeepaliveMinTime:             30 * time.Second,
+					KeepalivePermitWithoutStream: true,
+					MaxConnectionIdle:            5 * time.Minute,
+					MaxConnectionAge:             10 * time.Minute,
+					MaxConnectionAgeGrace:        20 * time.Second,
+					MaxRecvMsgSize:               8 << 20,
+					MaxSendMsgSize:               16 << 20,
+					ReflectionEnabled:            true,
+				}, c.GRPC)
+			},
+		},
+		{
+			msg:         "invalid grpc duration returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.GRPC.MaxConnectionAge = "-1m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "negative grpc message size returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.GRPC.MaxRecvMsgSize = -1
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg:         "invalid log_level returns an error",
 			expectError: true,
@@ -648,6 +886,91 @@ func TestNewServerConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg: "datastore_slow_call_threshold is correctly parsed",
+			input: func(c *Config) {
+				c.Server.DataStoreSlowCall = "250ms"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 250*time.Millisecond, c.DataStoreSlowCallThreshold)
+			},
+		},
+		{
+			msg:         "invalid datastore_slow_call_threshold returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.DataStoreSlowCall = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "prune_attested_nodes_expired_for is correctly parsed",
+			input: func(c *Config) {
+				c.Server.PruneAttestedNodes = "168h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 168*time.Hour, c.PruneAttestedNodesExpiredFor)
+			},
+		},
+		{
+			msg:         "invalid prune_attested_nodes_expired_for returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.PruneAttestedNodes = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "track_issued_svids is correctly set",
+			input: func(c *Config) {
+				c.Server.TrackIssuedSVIDs = true
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.True(t, c.TrackIssuedSVIDs)
+			},
+		},
+		{
+			msg: "prune_issued_svids_expired_for is correctly parsed",
+			input: func(c *Config) {
+				c.Server.PruneIssuedSVIDs = "720h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 720*time.Hour, c.PruneIssuedSVIDsExpiredFor)
+			},
+		},
+		{
+			msg:         "invalid prune_issued_svids_expired_for returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.PruneIssuedSVIDs = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "resolve_node_selectors_interval is correctly parsed",
+			input: func(c *Config) {
+				c.Server.ResolveNodes = "10m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 10*time.Minute, c.ResolveNodeSelectorsInterval)
+			},
+		},
+		{
+			msg:         "invalid resolve_node_selectors_interval returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.ResolveNodes = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg:         "invalid log_format returns an error",
 			expectError: true,
@@ -697,6 +1020,61 @@ func TestNewServerConfig(t *testing.T) {
 			test: func(t *testing.T, c *server.Config) {
 				require.Equal(t, "192.168.1.1", c.Federation.BundleEndpoint.Address.IP.String())
 				require.Equal(t, 1337, c.Federation.BundleEndpoint.Address.Port)
+				require.Nil(t, c.Federation.BundleEndpoint.ACME)
+				require.Nil(t, c.Federation.BundleEndpoint.DiskCertificate)
+			},
+		},
+		{
+			msg: "bundle endpoint serving certificate file is parsed and configured correctly",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						Address: "192.168.1.1",
+						Port:    1337,
+						ServingCertFile: &bundleEndpointServingCertFileConfig{
+							CertFilePath: "/path/to/cert.pem",
+							KeyFilePath:  "/path/to/key.pem",
+						},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, &bundle.DiskCertificateConfig{
+					CertFilePath: "/path/to/cert.pem",
+					KeyFilePath:  "/path/to/key.pem",
+				}, c.Federation.BundleEndpoint.DiskCertificate)
+				require.Nil(t, c.Federation.BundleEndpoint.ACME)
+			},
+		},
+		{
+			msg: "bundle endpoint refresh hint is parsed and configured correctly",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						Address:     "192.168.1.1",
+						Port:        1337,
+						RefreshHint: "10m",
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 10*time.Minute, c.Federation.BundleEndpoint.RefreshHint)
+			},
+		},
+		{
+			msg: "invalid bundle endpoint refresh hint returns an error",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						Address:     "192.168.1.1",
+						Port:        1337,
+						RefreshHint: "foo",
+					},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
 			},
 		},
 		{
@@ -787,77 +1165,347 @@ func TestNewServerConfig(t *testing.T) {
 			},
 		},
 		{
-			msg: "rsa-2048 ca_key_type is correctly parsed",
+			msg: "max_svid_ttl is correctly parsed",
 			input: func(c *Config) {
-				c.Server.CAKeyType = "rsa-2048"
+				c.Server.MaxSVIDTTL = "2h"
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, keymanager.KeyType_RSA_2048, c.CAKeyType)
+				require.Equal(t, 2*time.Hour, c.MaxSVIDTTL)
 			},
 		},
 		{
-			msg: "rsa-4096 ca_key_type is correctly parsed",
+			msg: "plugin_selection is correctly set",
 			input: func(c *Config) {
-				c.Server.CAKeyType = "rsa-4096"
+				c.Server.PluginSelection = map[string]string{"KeyManager": "failover"}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, keymanager.KeyType_RSA_4096, c.CAKeyType)
+				require.Equal(t, map[string]string{"KeyManager": "failover"}, c.PluginSelection)
 			},
 		},
 		{
-			msg: "ec-p256 ca_key_type is correctly parsed",
+			msg:         "invalid max_svid_ttl returns an error",
+			expectError: true,
 			input: func(c *Config) {
-				c.Server.CAKeyType = "ec-p256"
+				c.Server.MaxSVIDTTL = "b"
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, keymanager.KeyType_EC_P256, c.CAKeyType)
+				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "ec-p384 ca_key_type is correctly parsed",
+			msg:         "default_svid_ttl greater than max_svid_ttl returns an error",
+			expectError: true,
 			input: func(c *Config) {
-				c.Server.CAKeyType = "ec-p384"
+				c.Server.DefaultSVIDTTL = "2h"
+				c.Server.MaxSVIDTTL = "1h"
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, keymanager.KeyType_EC_P384, c.CAKeyType)
+				require.Nil(t, c)
 			},
 		},
 		{
-			msg:         "unsupported ca_key_type is rejected",
+			msg: "signing_concurrency is correctly parsed",
+			input: func(c *Config) {
+				c.Server.SigningConcurrency = 8
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 8, c.SigningConcurrency)
+			},
+		},
+		{
+			msg:         "negative signing_concurrency returns an error",
 			expectError: true,
 			input: func(c *Config) {
-				c.Server.CAKeyType = "rsa-1024"
+				c.Server.SigningConcurrency = -1
 			},
 			test: func(t *testing.T, c *server.Config) {
 				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "ca_ttl is correctly parsed",
+			msg: "rate_limit is correctly parsed",
 			input: func(c *Config) {
-				c.Server.CATTL = "1h"
+				c.Server.RateLimit = rateLimitConfig{
+					Attestation:          0.5,
+					X509SVIDSigning:      100,
+					JWTSVIDSigning:       200,
+					AgentX509SVIDSigning: 10,
+					AgentJWTSVIDSigning:  20,
+				}
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, time.Hour, c.CATTL)
+				require.Equal(t, node.RateLimits{
+					Attest:   0.5,
+					CSR:      100,
+					JSR:      200,
+					AgentCSR: 10,
+					AgentJSR: 20,
+				}, c.NodeAPIRateLimits)
 			},
 		},
 		{
-			msg:         "invalid ca_ttl returns an error",
+			msg:         "negative rate_limit returns an error",
 			expectError: true,
 			input: func(c *Config) {
-				c.Server.CATTL = "b"
+				c.Server.RateLimit.AgentX509SVIDSigning = -1
 			},
 			test: func(t *testing.T, c *server.Config) {
 				require.Nil(t, c)
 			},
 		},
 		{
-			msg: "ca_subject is defaulted when unset",
+			msg: "workload_key_policy is correctly parsed",
 			input: func(c *Config) {
-				c.Server.CASubject = nil
+				c.Server.WorkloadKeyPolicy.KeyTypes = []string{"rsa", "ec-p256"}
+				c.Server.WorkloadKeyPolicy.MinRSAKeyBits = 2048
 			},
 			test: func(t *testing.T, c *server.Config) {
-				require.Equal(t, defaultCASubject, c.CASubject)
+				require.Equal(t, node.KeyPolicy{KeyTypes: []string{"rsa", "ec-p256"}, MinRSAKeyBits: 2048}, c.WorkloadKeyPolicy)
+			},
+		},
+		{
+			msg:         "workload_key_policy with unknown key type returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.WorkloadKeyPolicy.KeyTypes = []string{"dsa"}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "audit_log is not configured by default",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, audit.Config{}, c.Audit)
+			},
+		},
+		{
+			msg: "audit_log sinks are correctly parsed",
+			input: func(c *Config) {
+				c.Server.AuditLog = &auditLogConfig{
+					File: &auditFileConfig{
+						Path:       "/var/log/spire/audit.log",
+						MaxSizeMB:  10,
+						MaxBackups: 3,
+					},
+					Syslog: &auditSyslogConfig{
+						Network: "udp",
+						Address: "localhost:514",
+					},
+					Webhook: &auditWebhookConfig{
+						URL:     "https://audit.example.org/events",
+						Timeout: "2s",
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, audit.Config{
+					File: &audit.FileConfig{
+						Path:       "/var/log/spire/audit.log",
+						MaxSize:    10 * 1024 * 1024,
+						MaxBackups: 3,
+					},
+					Syslog: &audit.SyslogConfig{
+						Network: "udp",
+						Address: "localhost:514",
+						Tag:     "spire-server",
+					},
+					Webhook: &audit.WebhookConfig{
+						URL:     "https://audit.example.org/events",
+						Timeout: 2 * time.Second,
+					},
+				}, c.Audit)
+			},
+		},
+		{
+			msg:         "audit_log file without a path returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.AuditLog = &auditLogConfig{
+					File: &auditFileConfig{},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "audit_log webhook with an invalid url returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.AuditLog = &auditLogConfig{
+					Webhook: &auditWebhookConfig{URL: "ftp://audit.example.org"},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "audit_log webhook with an invalid timeout returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.AuditLog = &auditLogConfig{
+					Webhook: &auditWebhookConfig{URL: "https://audit.example.org", Timeout: "soon"},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "log_rotation without log_file returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.LogRotation = &log.RotationConfig{MaxSizeMB: 100}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "log_fields with an empty name returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.LogFields = map[string]string{"": "us-east"}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "profiling_socket_path should be correctly configured",
+			input: func(c *Config) {
+				c.Server.ProfilingEnabled = true
+				c.Server.ProfilingSocketPath = "/tmp/profiling.sock"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.True(t, c.ProfilingEnabled)
+				require.Equal(t, "/tmp/profiling.sock", c.ProfilingSocketPath)
+			},
+		},
+		{
+			msg: "rsa-2048 ca_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "rsa-2048"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_RSA_2048, c.CAKeyType)
+			},
+		},
+		{
+			msg: "rsa-4096 ca_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "rsa-4096"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_RSA_4096, c.CAKeyType)
+			},
+		},
+		{
+			msg: "ec-p256 ca_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "ec-p256"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_EC_P256, c.CAKeyType)
+			},
+		},
+		{
+			msg: "ec-p384 ca_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "ec-p384"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_EC_P384, c.CAKeyType)
+			},
+		},
+		{
+			msg:         "unsupported ca_key_type is rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.CAKeyType = "rsa-1024"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "allowed_jwt_svid_claims is correctly parsed",
+			input: func(c *Config) {
+				c.Server.AllowedJWTClaims = []string{"tenant", "environment"}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, []string{"tenant", "environment"}, c.AllowedJWTSVIDClaims)
+			},
+		},
+		{
+			msg: "allowed_x509_svid_extensions is correctly parsed",
+			input: func(c *Config) {
+				c.Server.AllowedX509SVIDExts = []string{"1.3.6.1.4.1.99999.1"}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, []string{"1.3.6.1.4.1.99999.1"}, c.AllowedX509SVIDExtensions)
+			},
+		},
+		{
+			msg: "jwt_key_type is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "ec-p256"
+				c.Server.JWTKeyType = "rsa-2048"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_EC_P256, c.CAKeyType)
+				require.Equal(t, keymanager.KeyType_RSA_2048, c.JWTKeyType)
+			},
+		},
+		{
+			msg: "jwt_key_type is unspecified by default",
+			input: func(c *Config) {
+				c.Server.CAKeyType = "ec-p384"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_UNSPECIFIED_KEY_TYPE, c.JWTKeyType)
+			},
+		},
+		{
+			msg:         "unsupported jwt_key_type is rejected",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.JWTKeyType = "rsa-1024"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "ca_ttl is correctly parsed",
+			input: func(c *Config) {
+				c.Server.CATTL = "1h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, time.Hour, c.CATTL)
+			},
+		},
+		{
+			msg:         "invalid ca_ttl returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.CATTL = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "ca_subject is defaulted when unset",
+			input: func(c *Config) {
+				c.Server.CASubject = nil
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, defaultCASubject, c.CASubject)
 			},
 		},
 		{
@@ -908,6 +1556,100 @@ func TestNewServerConfig(t *testing.T) {
 	}
 }
 
+func TestNewServerConfigHostedTrustDomains(t *testing.T) {
+	c := defaultValidConfig()
+	c.Server.BindAddress = "192.168.1.1"
+	c.Server.JWTIssuer = "https://example.org"
+	c.Server.ProfilingEnabled = true
+	c.Server.ProfilingPort = 8080
+	c.Plugins = &catalog.HCLPluginConfigMap{
+		"BundlePublisher":   {"aws_s3": {}},
+		"DataStore":         {"sql": {}},
+		"KeyManager":        {"disk": {}},
+		"NodeAttestor":      {"join_token": {}},
+		"Notifier":          {"k8sbundle": {}},
+		"UpstreamAuthority": {"disk": {}},
+	}
+	c.Server.HostedTrustDomains = map[string]hostedTrustDomainConfig{
+		"b.test": {
+			BindPort:            9081,
+			DataDir:             "/tmp/b",
+			RegistrationUDSPath: "/tmp/b/registration.sock",
+			RegistrationAPI: registrationAPIConfig{
+				BindPort: 9082,
+			},
+			Plugins: &catalog.HCLPluginConfigMap{
+				"DataStore":         {"sql": {PluginCmd: "b"}},
+				"KeyManager":        {"memory": {}},
+				"Notifier":          {"k8sbundle": {PluginCmd: "b"}},
+				"UpstreamAuthority": {"disk": {PluginCmd: "b"}},
+			},
+			Federation: &federationConfig{
+				BundleEndpoint: &bundleEndpointConfig{
+					Address: "0.0.0.0",
+					Port:    9443,
+				},
+			},
+		},
+		"a.test": {
+			BindPort:            9091,
+			DataDir:             "/tmp/a",
+			RegistrationUDSPath: "/tmp/a/registration.sock",
+			Plugins: &catalog.HCLPluginConfigMap{
+				"DataStore":  {"sql": {PluginCmd: "a"}},
+				"KeyManager": {"memory": {}},
+			},
+		},
+	}
+
+	sc, err := NewServerConfig(c, []log.Option{})
+	require.NoError(t, err)
+	require.Len(t, sc.HostedTrustDomains, 2)
+
+	// hosted trust domains are ordered by name
+	a, b := sc.HostedTrustDomains[0], sc.HostedTrustDomains[1]
+	require.Equal(t, "spiffe://a.test", a.TrustDomain.String())
+	require.Equal(t, "spiffe://b.test", b.TrustDomain.String())
+
+	require.Equal(t, "192.168.1.1", b.BindAddress.IP.String())
+	require.Equal(t, 9081, b.BindAddress.Port)
+	require.Equal(t, "/tmp/b/registration.sock", b.BindUDSAddress.Name)
+	require.Equal(t, "192.168.1.1", b.BindRegistrationAddress.IP.String())
+	require.Equal(t, 9082, b.BindRegistrationAddress.Port)
+	require.Nil(t, a.BindRegistrationAddress)
+	require.Equal(t, "/tmp/b", b.DataDir)
+	require.Empty(t, b.JWTIssuer)
+
+	require.NotNil(t, b.Federation.BundleEndpoint)
+	require.Equal(t, 9443, b.Federation.BundleEndpoint.Address.Port)
+	require.Nil(t, a.Federation.BundleEndpoint)
+
+	// Notifier, BundlePublisher and UpstreamAuthority plugins are not
+	// inherited from the server
+	require.Equal(t, catalog.HCLPluginConfigMap{
+		"DataStore":         {"sql": {PluginCmd: "b"}},
+		"KeyManager":        {"memory": {}},
+		"NodeAttestor":      {"join_token": {}},
+		"Notifier":          {"k8sbundle": {PluginCmd: "b"}},
+		"UpstreamAuthority": {"disk": {PluginCmd: "b"}},
+	}, b.PluginConfigs)
+	require.Equal(t, catalog.HCLPluginConfigMap{
+		"DataStore":    {"sql": {PluginCmd: "a"}},
+		"KeyManager":   {"memory": {}},
+		"NodeAttestor": {"join_token": {}},
+	}, a.PluginConfigs)
+
+	require.False(t, b.ProfilingEnabled)
+	require.Zero(t, b.ProfilingPort)
+	require.Empty(t, b.HostedTrustDomains)
+
+	// the server configuration is left untouched
+	require.Equal(t, "spiffe://example.org", sc.TrustDomain.String())
+	require.Equal(t, "https://example.org", sc.JWTIssuer)
+	require.True(t, sc.ProfilingEnabled)
+	require.Contains(t, sc.PluginConfigs["KeyManager"], "disk")
+}
+
 // defaultValidConfig returns the bare minimum config required to
 // pass validation etc
 func defaultValidConfig() *Config {
@@ -942,11 +1684,29 @@ func TestValidateConfig(t *testing.T) {
 			applyConf:   func(c *Config) { c.Server.BindPort = 0 },
 			expectedErr: "bind_address and bind_port must be configured",
 		},
+		{
+			name: "profiling_port and profiling_socket_path cannot both be configured",
+			applyConf: func(c *Config) {
+				c.Server.ProfilingPort = 8080
+				c.Server.ProfilingSocketPath = "/tmp/profiling.sock"
+			},
+			expectedErr: "profiling_port and profiling_socket_path cannot both be configured",
+		},
 		{
 			name:        "registration_uds_path must be configured",
 			applyConf:   func(c *Config) { c.Server.RegistrationUDSPath = "" },
 			expectedErr: "registration_uds_path must be configured",
 		},
+		{
+			name:        "registration_uds_path cannot be an abstract socket",
+			applyConf:   func(c *Config) { c.Server.RegistrationUDSPath = "@spire-registration" },
+			expectedErr: "registration_uds_path cannot be an abstract socket",
+		},
+		{
+			name:        "registration_uds_path must fit in a socket address",
+			applyConf:   func(c *Config) { c.Server.RegistrationUDSPath = "/" + strings.Repeat("a", 200) },
+			expectedErr: "invalid registration_uds_path: socket path",
+		},
 		{
 			name:        "trust_domain must be configured",
 			applyConf:   func(c *Config) { c.Server.TrustDomain = "" },
@@ -957,11 +1717,39 @@ func TestValidateConfig(t *testing.T) {
 			applyConf:   func(c *Config) { c.Server.DataDir = "" },
 			expectedErr: "data_dir must be configured",
 		},
+		{
+			name: "allowed_jwt_svid_claims cannot include registered claims",
+			applyConf: func(c *Config) {
+				c.Server.AllowedJWTClaims = []string{"tenant", "sub"}
+			},
+			expectedErr: `allowed_jwt_svid_claims cannot include the registered claim "sub"`,
+		},
+		{
+			name: "allowed_x509_svid_extensions must be OIDs",
+			applyConf: func(c *Config) {
+				c.Server.AllowedX509SVIDExts = []string{"tenant"}
+			},
+			expectedErr: `allowed_x509_svid_extensions: invalid OID "tenant": must have at least two arcs`,
+		},
+		{
+			name: "allowed_x509_svid_extensions cannot include reserved extensions",
+			applyConf: func(c *Config) {
+				c.Server.AllowedX509SVIDExts = []string{"1.3.6.1.4.1.99999.1", "2.5.29.17"}
+			},
+			expectedErr: `allowed_x509_svid_extensions cannot include the reserved extension "2.5.29.17"`,
+		},
 		{
 			name:        "plugins section must be configured",
 			applyConf:   func(c *Config) { c.Plugins = nil },
 			expectedErr: "plugins section must be configured",
 		},
+		{
+			name: "plugin_selection must be supported",
+			applyConf: func(c *Config) {
+				c.Server.PluginSelection = map[string]string{"KeyManager": "roundrobin"}
+			},
+			expectedErr: `plugin_selection: unsupported KeyManager plugin selection "roundrobin": must be primary or failover`,
+		},
 		{
 			name: "if ACME is used, federation.bundle_endpoint.acme.domain_name must be configured",
 			applyConf: func(c *Config) {
@@ -986,6 +1774,50 @@ func TestValidateConfig(t *testing.T) {
 			},
 			expectedErr: "federation.bundle_endpoint.acme.email must be configured",
 		},
+		{
+			name: "federation.bundle_endpoint.acme and federation.bundle_endpoint.serving_cert_file are mutually exclusive",
+			applyConf: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						ACME: &bundleEndpointACMEConfig{
+							DomainName: "domain-name",
+							Email:      "admin@domain.test",
+						},
+						ServingCertFile: &bundleEndpointServingCertFileConfig{
+							CertFilePath: "/path/to/cert.pem",
+							KeyFilePath:  "/path/to/key.pem",
+						},
+					},
+				}
+			},
+			expectedErr: "federation.bundle_endpoint.acme and federation.bundle_endpoint.serving_cert_file are mutually exclusive",
+		},
+		{
+			name: "if a serving certificate file is used, federation.bundle_endpoint.serving_cert_file.cert_file_path must be configured",
+			applyConf: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						ServingCertFile: &bundleEndpointServingCertFileConfig{
+							KeyFilePath: "/path/to/key.pem",
+						},
+					},
+				}
+			},
+			expectedErr: "federation.bundle_endpoint.serving_cert_file.cert_file_path must be configured",
+		},
+		{
+			name: "if a serving certificate file is used, federation.bundle_endpoint.serving_cert_file.key_file_path must be configured",
+			applyConf: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						ServingCertFile: &bundleEndpointServingCertFileConfig{
+							CertFilePath: "/path/to/cert.pem",
+						},
+					},
+				}
+			},
+			expectedErr: "federation.bundle_endpoint.serving_cert_file.key_file_path must be configured",
+		},
 		{
 			name: "if FederatesWith is used, federation.bundle_endpoint.address must be configured",
 			applyConf: func(c *Config) {
@@ -1002,6 +1834,84 @@ func TestValidateConfig(t *testing.T) {
 			applyConf:   func(c *Config) { c.Server.DeprecatedSVIDTTL = "1h" },
 			expectedErr: `the "svid_ttl" configurable has been deprecated and renamed to "default_svid_ttl"; please update your configuration`,
 		},
+		{
+			name: "hosted trust domain must not be the trust domain of the server",
+			applyConf: func(c *Config) {
+				c.Server.HostedTrustDomains = map[string]hostedTrustDomainConfig{"example.org": validHostedTrustDomain("a")}
+			},
+			expectedErr: `hosted_trust_domain "example.org": the trust domain of the server cannot be hosted again`,
+		},
+		{
+			name: "hosted trust domain bind_port must be configured",
+			applyConf: func(c *Config) {
+				hosted := validHostedTrustDomain("a")
+				hosted.BindPort = 0
+				c.Server.HostedTrustDomains = map[string]hostedTrustDomainConfig{"a.test": hosted}
+			},
+			expectedErr: `hosted_trust_domain "a.test": bind_port must be configured`,
+		},
+		{
+			name: "hosted trust domain bind_port must not be used by the server",
+			applyConf: func(c *Config) {
+				hosted := validHostedTrustDomain("a")
+				hosted.BindPort = c.Server.BindPort
+				c.Server.HostedTrustDomains = map[string]hostedTrustDomainConfig{"a.test": hosted}
+			},
+			expectedErr: `hosted_trust_domain "a.test": port 8081 is already used by the server`,
+		},
+		{
+			name: "hosted trust domain data_dir must not be used by another hosted trust domain",
+			applyConf: func(c *Config) {
+				b := validHostedTrustDomain("b")
+				b.DataDir = "/tmp/a"
+				c.Server.HostedTrustDomains = map[string]hostedTrustDomainConfig{
+					"a.test": validHostedTrustDomain("a"),
+					"b.test": b,
+				}
+			},
+			expectedErr: `hosted_trust_domain "b.test": data_dir "/tmp/a" is already used by hosted trust domain "a.test"`,
+		},
+		{
+			name: "hosted trust domain must configure a KeyManager",
+			applyConf: func(c *Config) {
+				hosted := validHostedTrustDomain("a")
+				delete(*hosted.Plugins, "KeyManager")
+				c.Server.HostedTrustDomains = map[string]hostedTrustDomainConfig{"a.test": hosted}
+			},
+			expectedErr: `hosted_trust_domain "a.test": plugins must configure a DataStore and a KeyManager`,
+		},
+		{
+			name: "hosted trust domain must not share the DataStore of the server",
+			applyConf: func(c *Config) {
+				c.Plugins = &catalog.HCLPluginConfigMap{"DataStore": {"sql": {}}}
+				hosted := validHostedTrustDomain("a")
+				(*hosted.Plugins)["DataStore"] = map[string]catalog.HCLPluginConfig{"sql": {}}
+				c.Server.HostedTrustDomains = map[string]hostedTrustDomainConfig{"a.test": hosted}
+			},
+			expectedErr: `hosted_trust_domain "a.test": the DataStore is already used by the server`,
+		},
+		{
+			name: "hosted trust domain federation must be valid",
+			applyConf: func(c *Config) {
+				hosted := validHostedTrustDomain("a")
+				hosted.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						ACME: &bundleEndpointACMEConfig{},
+					},
+				}
+				c.Server.HostedTrustDomains = map[string]hostedTrustDomainConfig{"a.test": hosted}
+			},
+			expectedErr: `hosted_trust_domain "a.test": federation.bundle_endpoint.acme.domain_name must be configured`,
+		},
+		{
+			name: "hosted trust domains are valid",
+			applyConf: func(c *Config) {
+				c.Server.HostedTrustDomains = map[string]hostedTrustDomainConfig{
+					"a.test": validHostedTrustDomain("a"),
+					"b.test": validHostedTrustDomain("b"),
+				}
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1020,6 +1930,24 @@ func TestValidateConfig(t *testing.T) {
 	}
 }
 
+// validHostedTrustDomain returns a hosted trust domain config that passes
+// validation, with listeners and a data directory derived from name
+func validHostedTrustDomain(name string) hostedTrustDomainConfig {
+	port := 9000
+	if name == "b" {
+		port = 9100
+	}
+	return hostedTrustDomainConfig{
+		BindPort:            port,
+		DataDir:             "/tmp/" + name,
+		RegistrationUDSPath: "/tmp/" + name + "/registration.sock",
+		Plugins: &catalog.HCLPluginConfigMap{
+			"DataStore":  {"sql": {PluginCmd: name}},
+			"KeyManager": {"memory": {}},
+		},
+	}
+}
+
 func TestWarnOnUnknownConfig(t *testing.T) {
 	testFileDir := "../../../../test/fixture/config"
 	cases := []struct {
@@ -1070,6 +1998,11 @@ func TestWarnOnUnknownConfig(t *testing.T) {
 			testFilePath:   fmt.Sprintf("%v/server_bad_nested_federates_with_block.conf", testFileDir),
 			expectedLogMsg: "Detected unknown federation config options for \"test1\": [\"unknown_option1\" \"unknown_option2\"]; this will be fatal in a future release.",
 		},
+		{
+			msg:            "in nested hosted_trust_domain block",
+			testFilePath:   fmt.Sprintf("%v/server_bad_nested_hosted_trust_domain_block.conf", testFileDir),
+			expectedLogMsg: "Detected unknown hosted trust domain config options for \"test1\": [\"unknown_option1\" \"unknown_option2\"]; this will be fatal in a future release.",
+		},
 		// TODO: Re-enable unused key detection for telemetry. See
 		// https://github.com/spiffe/spire/issues/1101 for more information
 		//
@@ -1167,6 +2100,120 @@ func TestLogOptions(t *testing.T) {
 	require.Equal(t, fd.Name(), logger.Out.(*os.File).Name())
 }
 
+func TestReloadLogLevel(t *testing.T) {
+	fd, err := ioutil.TempFile("", "server.conf")
+	require.NoError(t, err)
+	defer os.Remove(fd.Name())
+	_, err = fd.WriteString(`server {
+	log_level = "WARN"
+	log_debug_subsystems = ["ca_manager"]
+}`)
+	require.NoError(t, err)
+	require.NoError(t, fd.Close())
+
+	logger, err := log.NewLogger(log.WithLevel("INFO"))
+	require.NoError(t, err)
+
+	// The level and debug subsystems are picked up from the file
+	require.NoError(t, reloadLogLevel([]string{"-config", fd.Name()}, logger))
+	assert.Equal(t, logrus.WarnLevel, logger.GetLevel())
+	assert.Equal(t, []string{"ca_manager"}, logger.DebugSubsystems())
+
+	// The -logLevel flag takes precedence over the file
+	require.NoError(t, reloadLogLevel([]string{"-config", fd.Name(), "-logLevel", "DEBUG"}, logger))
+	assert.Equal(t, logrus.DebugLevel, logger.GetLevel())
+
+	// Invalid levels leave the logger untouched
+	err = reloadLogLevel([]string{"-config", fd.Name(), "-logLevel", "LOUD"}, logger)
+	require.Error(t, err)
+	assert.Equal(t, logrus.DebugLevel, logger.GetLevel())
+}
+
+func TestReloadPlugins(t *testing.T) {
+	fd, err := ioutil.TempFile("", "server.conf")
+	require.NoError(t, err)
+	defer os.Remove(fd.Name())
+	_, err = fd.WriteString(`server {}
+plugins {
+	KeyManager "memory" {
+		plugin_data {}
+	}
+}`)
+	require.NoError(t, err)
+	require.NoError(t, fd.Close())
+
+	plugins := new(fakePluginReconfigurer)
+	require.NoError(t, reloadPlugins(context.Background(), []string{"-config", fd.Name()}, plugins))
+	require.Len(t, plugins.configs, 1)
+	assert.Contains(t, plugins.configs[0], "KeyManager")
+	assert.Contains(t, plugins.configs[0]["KeyManager"], "memory")
+
+	// Reconfiguration errors are returned
+	plugins.err = errors.New("oh no")
+	err = reloadPlugins(context.Background(), []string{"-config", fd.Name()}, plugins)
+	require.EqualError(t, err, "oh no")
+}
+
+func TestReloadPluginsHostedTrustDomains(t *testing.T) {
+	fd, err := ioutil.TempFile("", "server.conf")
+	require.NoError(t, err)
+	defer os.Remove(fd.Name())
+	_, err = fd.WriteString(`server {
+	hosted_trust_domain "a.test" {
+		plugins {
+			KeyManager "disk" {
+				plugin_data {}
+			}
+		}
+	}
+}
+plugins {
+	KeyManager "memory" {
+		plugin_data {}
+	}
+	NodeAttestor "join_token" {
+		plugin_data {}
+	}
+}`)
+	require.NoError(t, err)
+	require.NoError(t, fd.Close())
+
+	plugins := new(fakePluginReconfigurer)
+	require.NoError(t, reloadPlugins(context.Background(), []string{"-config", fd.Name()}, plugins))
+	require.Len(t, plugins.configs, 1)
+	require.Contains(t, plugins.configs[0]["KeyManager"], "memory")
+
+	// Hosted trust domains inherit the plugin types they do not configure
+	require.Len(t, plugins.hostedConfigs, 1)
+	hosted := plugins.hostedConfigs["a.test"]
+	require.Contains(t, hosted["KeyManager"], "disk")
+	require.NotContains(t, hosted["KeyManager"], "memory")
+	require.Contains(t, hosted["NodeAttestor"], "join_token")
+
+	plugins.err = errors.New("oh no")
+	err = reloadPlugins(context.Background(), []string{"-config", fd.Name()}, plugins)
+	require.EqualError(t, err, "oh no")
+}
+
+type fakePluginReconfigurer struct {
+	configs       []catalog.HCLPluginConfigMap
+	hostedConfigs map[string]catalog.HCLPluginConfigMap
+	err           error
+}
+
+func (f *fakePluginReconfigurer) ReconfigurePlugins(ctx context.Context, pluginConfig catalog.HCLPluginConfigMap) error {
+	f.configs = append(f.configs, pluginConfig)
+	return f.err
+}
+
+func (f *fakePluginReconfigurer) ReconfigureHostedPlugins(ctx context.Context, trustDomain string, pluginConfig catalog.HCLPluginConfigMap) error {
+	if f.hostedConfigs == nil {
+		f.hostedConfigs = make(map[string]catalog.HCLPluginConfigMap)
+	}
+	f.hostedConfigs[trustDomain] = pluginConfig
+	return f.err
+}
+
 func TestHasExpectedTTLs(t *testing.T) {
 	cases := []struct {
 		msg             string
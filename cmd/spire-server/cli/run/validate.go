@@ -0,0 +1,103 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/hostservices/metricsservice"
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server"
+	server_catalog "github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/hostservices/agentstore"
+	"github.com/spiffe/spire/pkg/server/hostservices/identityprovider"
+)
+
+// ValidateOptions tunes the validation of ValidateConfig.
+type ValidateOptions struct {
+	// Strict makes unknown config options errors rather than warnings.
+	Strict bool
+
+	// CheckDataStore has the DataStore plugin validate its configuration
+	// too, which connects to the database as on startup.
+	CheckDataStore bool
+}
+
+// ValidateConfig validates the configuration further than LoadConfig: each
+// plugin validates its own configuration, and unknown config options are
+// reported. The warnings logged while loading the configuration are
+// reported in the result too.
+func ValidateConfig(ctx context.Context, c *Config, opts ValidateOptions) *common_cli.ValidationResult {
+	result := common_cli.NewValidationResult()
+
+	// Unknown config options are reported here rather than through the
+	// warnings logged by NewServerConfig, so they are reported whatever the
+	// log level and can be made errors.
+	unknowns := detectUnknownConfig(c)
+	loggedUnknowns := make(map[string]bool, len(unknowns))
+	for _, unknown := range unknowns {
+		if opts.Strict {
+			result.AddError(errors.New(unknown))
+		} else {
+			result.AddWarning(unknown)
+		}
+		loggedUnknowns[fmt.Sprintf(unknownConfigWarning, unknown)] = true
+	}
+
+	sc, err := NewServerConfig(c, []log.Option{result.LogWarnings(loggedUnknowns)})
+	if err != nil {
+		result.AddError(err)
+		return result
+	}
+	if closer, ok := sc.Log.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	if err := validatePlugins(ctx, sc, opts.CheckDataStore, result.AddError); err != nil {
+		result.AddError(err)
+		return result
+	}
+	for i := range sc.HostedTrustDomains {
+		hosted := &sc.HostedTrustDomains[i]
+		addError := func(err error) {
+			result.AddError(fmt.Errorf("hosted_trust_domain %q: %v", hosted.TrustDomain.Host, err))
+		}
+		if err := validatePlugins(ctx, hosted, opts.CheckDataStore, addError); err != nil {
+			addError(err)
+			return result
+		}
+	}
+
+	return result
+}
+
+// validatePlugins has each plugin of the server configuration validate its
+// configuration, reporting plugin errors to addError.
+func validatePlugins(ctx context.Context, sc *server.Config, checkDataStore bool, addError func(error)) error {
+	// The host services are not functional, which is fine as plugins are
+	// not expected to call them while being configured.
+	pluginErrs, err := server_catalog.ValidatePlugins(ctx, server_catalog.Config{
+		Log: sc.Log.WithField(telemetry.SubsystemName, telemetry.Catalog),
+		GlobalConfig: server_catalog.GlobalConfig{
+			TrustDomain: sc.TrustDomain.Host,
+		},
+		PluginConfig: sc.PluginConfigs,
+		IdentityProvider: identityprovider.New(identityprovider.Config{
+			TrustDomainID: sc.TrustDomain.String(),
+		}),
+		AgentStore: agentstore.New(),
+		MetricsService: metricsservice.New(metricsservice.Config{
+			Metrics: telemetry.Blackhole{},
+		}),
+	}, checkDataStore)
+	if err != nil {
+		return err
+	}
+	for _, pluginErr := range pluginErrs {
+		addError(pluginErr)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validateTestConfig = `
+server {
+	trust_domain = "example.org"
+	data_dir = "."
+	%s
+}
+
+plugins {
+	DataStore "sql" {
+		plugin_data {
+			database_type = "%s"
+			connection_string = "%s"
+		}
+	}
+	KeyManager "memory" {
+		plugin_data {}
+	}
+	NodeAttestor "join_token" {
+		plugin_data {}
+	}
+	NodeAttestor "x509pop" {
+		plugin_data {
+			%s
+		}
+	}
+}
+`
+
+func TestValidateConfigWithPlugins(t *testing.T) {
+	dir, err := ioutil.TempDir("", "validate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	caBundlePath := "../../../../test/fixture/nodeattestor/x509pop/root-crt.pem"
+	sqlitePath := filepath.Join(dir, "datastore.sqlite3")
+	// Validating the datastore never creates the database
+	require.NoError(t, ioutil.WriteFile(sqlitePath, nil, 0600))
+
+	for _, tt := range []struct {
+		name           string
+		server         string
+		databaseType   string
+		x509pop        string
+		opts           ValidateOptions
+		expectErrors   []string
+		expectWarnings []string
+	}{
+		{
+			name:         "valid",
+			databaseType: "sqlite3",
+			x509pop:      `ca_bundle_path = "` + caBundlePath + `"`,
+		},
+		{
+			name:         "invalid plugin configuration",
+			databaseType: "sqlite3",
+			expectErrors: []string{
+				`NodeAttestor plugin "x509pop": unable to configure plugin "x509pop": rpc error: code = Unknown desc = x509pop: ca_bundle_path is required`,
+			},
+		},
+		{
+			name:         "datastore is not checked by default",
+			databaseType: "bogus",
+			x509pop:      `ca_bundle_path = "` + caBundlePath + `"`,
+		},
+		{
+			name:         "datastore is checked",
+			databaseType: "bogus",
+			x509pop:      `ca_bundle_path = "` + caBundlePath + `"`,
+			opts:         ValidateOptions{CheckDataStore: true},
+			expectErrors: []string{
+				`DataStore plugin "sql": datastore-sql: unsupported database_type: bogus`,
+			},
+		},
+		{
+			name:         "unknown options are warnings",
+			server:       `bogus = true`,
+			databaseType: "sqlite3",
+			x509pop:      `ca_bundle_path = "` + caBundlePath + `"`,
+			expectWarnings: []string{
+				`Detected unknown server config options: ["bogus"]`,
+			},
+		},
+		{
+			name:         "unknown options are errors with strict",
+			server:       `bogus = true`,
+			databaseType: "sqlite3",
+			x509pop:      `ca_bundle_path = "` + caBundlePath + `"`,
+			opts:         ValidateOptions{Strict: true},
+			expectErrors: []string{
+				`Detected unknown server config options: ["bogus"]`,
+			},
+		},
+		{
+			name: "hosted trust domain plugins are validated",
+			server: `hosted_trust_domain "hosted.test" {
+				bind_port = 9081
+				data_dir = "hosted"
+				registration_uds_path = "/tmp/hosted-registration.sock"
+				plugins {
+					DataStore "sql" {
+						plugin_data {
+							database_type = "bogus"
+							connection_string = "hosted"
+						}
+					}
+					KeyManager "memory" {
+						plugin_data {}
+					}
+				}
+			}`,
+			databaseType: "sqlite3",
+			x509pop:      `ca_bundle_path = "` + caBundlePath + `"`,
+			opts:         ValidateOptions{CheckDataStore: true},
+			expectErrors: []string{
+				`hosted_trust_domain "hosted.test": DataStore plugin "sql": datastore-sql: unsupported database_type: bogus`,
+			},
+		},
+		{
+			name:         "invalid server configuration",
+			server:       `ca_key_type = "bogus"`,
+			databaseType: "sqlite3",
+			expectErrors: []string{
+				`error parsing ca_key_type: key type "bogus" is unknown; must be one of [rsa-2048, rsa-4096, ec-p256, ec-p384]`,
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "server.conf")
+			require.NoError(t, ioutil.WriteFile(path, []byte(fmt.Sprintf(validateTestConfig, tt.server, tt.databaseType, sqlitePath, tt.x509pop)), 0600))
+
+			c, err := ParseConfig(commandName, []string{"-config", path}, ioutil.Discard)
+			require.NoError(t, err)
+
+			result := ValidateConfig(context.Background(), c, tt.opts)
+			assert.Equal(t, len(tt.expectErrors) == 0, result.Valid)
+			assert.Equal(t, tt.expectErrors, result.Errors)
+			assert.Equal(t, tt.expectWarnings, result.Warnings)
+		})
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"path"
 
 	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor/jointoken"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 
@@ -25,6 +27,12 @@ type GenerateConfig struct {
 
 	// Token TTL in seconds
 	TTL int
+
+	// Maximum number of times the token can be used
+	MaxUses int
+
+	// Output format of the command
+	Output common_cli.OutputFlag
 }
 
 func (GenerateCLI) Synopsis() string {
@@ -45,37 +53,69 @@ func (g GenerateCLI) Run(args []string) int {
 		return 1
 	}
 
+	if config.MaxUses < 1 {
+		fmt.Println("maxUses must be at least 1")
+		return 1
+	}
+
 	c, err := util.NewRegistrationClient(config.RegistrationUDSPath)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
 
-	token, err := g.createToken(ctx, c, config.TTL)
+	token, err := g.createToken(ctx, c, config.TTL, config.MaxUses)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
-	fmt.Printf("Token: %s\n", token)
+	if !config.Output.JSON() {
+		fmt.Printf("Token: %s\n", token)
+	}
 
 	if config.SpiffeID == "" {
+		if config.Output.JSON() {
+			return g.printJSON(token, "")
+		}
 		fmt.Printf("Warning: Missing SPIFFE ID.\n")
 		return 0
 	}
 
-	err = g.createVanityRecord(ctx, c, token, config.SpiffeID)
+	if config.MaxUses > 1 {
+		err = g.createNodeAlias(ctx, c, token, config.SpiffeID)
+	} else {
+		err = g.createVanityRecord(ctx, c, token, config.SpiffeID)
+	}
 	if err != nil {
 		fmt.Printf("Error assigning SPIFFE ID: %s\n", err.Error())
 		return 1
 	}
 
+	if config.Output.JSON() {
+		return g.printJSON(token, config.SpiffeID)
+	}
+	return 0
+}
+
+// printJSON prints the JSON output of the command, once the token was
+// generated and the SPIFFE ID, if any, assigned to it.
+func (GenerateCLI) printJSON(token, spiffeID string) int {
+	err := common_cli.DefaultEnv.PrintJSON(struct {
+		Token    string `json:"token"`
+		SpiffeID string `json:"spiffe_id"`
+	}{Token: token, SpiffeID: spiffeID})
+	if err != nil {
+		_ = common_cli.DefaultEnv.ErrPrintln(err)
+		return 1
+	}
 	return 0
 }
 
 // createToken calls the registration API and creates a new token
-// with the given TTL. It returns the raw token and an error, if any
-func (GenerateCLI) createToken(ctx context.Context, c registration.RegistrationClient, ttl int) (string, error) {
-	req := &registration.JoinToken{Ttl: int32(ttl)}
+// with the given TTL and maximum number of uses. It returns the raw
+// token and an error, if any
+func (GenerateCLI) createToken(ctx context.Context, c registration.RegistrationClient, ttl, maxUses int) (string, error) {
+	req := &registration.JoinToken{Ttl: int32(ttl), MaxUses: int32(maxUses)}
 	resp, err := c.CreateJoinToken(ctx, req)
 	if err != nil {
 		return "", err
@@ -114,13 +154,41 @@ func (GenerateCLI) createVanityRecord(ctx context.Context, c registration.Regist
 	return nil
 }
 
+// createNodeAlias inserts a registration entry that maps every agent attested
+// with a reusable token to the given SPIFFE ID. Agents attested with a
+// reusable token each get their own ID, so they are matched by the join
+// token selector instead, which holds the token ID rather than the token.
+func (GenerateCLI) createNodeAlias(ctx context.Context, c registration.RegistrationClient, token, spiffeID string) error {
+	id, err := idutil.ParseSpiffeID(spiffeID, idutil.AllowAnyTrustDomainWorkload())
+	if err != nil {
+		return err
+	}
+
+	req := &common.RegistrationEntry{
+		ParentId: idutil.ServerID(id.Host),
+		SpiffeId: id.String(),
+		Selectors: []*common.Selector{
+			{Type: "join_token", Value: jointoken.TokenID(token)},
+		},
+	}
+
+	_, err = c.CreateEntry(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (GenerateCLI) newConfig(args []string) (GenerateConfig, error) {
 	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
 	c := GenerateConfig{}
 
 	flags.IntVar(&c.TTL, "ttl", 600, "Token TTL in seconds")
+	flags.IntVar(&c.MaxUses, "maxUses", 1, "Maximum number of times the token can be used")
 	flags.StringVar(&c.SpiffeID, "spiffeID", "", "Additional SPIFFE ID to assign the token owner (optional)")
 	flags.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	c.Output.AddFlag(flags)
 
 	err := flags.Parse(args)
 	if err != nil {
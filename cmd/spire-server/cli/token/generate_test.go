@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
@@ -21,13 +22,21 @@ func TestCreateToken(t *testing.T) {
 	defer ctrl.Finish()
 
 	c := mock_registration.NewMockRegistrationClient(ctrl)
-	req := &registration.JoinToken{Ttl: 60}
-	resp := &registration.JoinToken{Token: "foobar", Ttl: 60}
+	req := &registration.JoinToken{Ttl: 60, MaxUses: 1}
+	resp := &registration.JoinToken{Token: "foobar", Ttl: 60, MaxUses: 1}
 
 	c.EXPECT().CreateJoinToken(gomock.Any(), req).Return(resp, nil)
-	token, err := GenerateCLI{}.createToken(ctx, c, 60)
+	token, err := GenerateCLI{}.createToken(ctx, c, 60, 1)
 	require.NoError(t, err)
 	assert.Equal(t, "foobar", token)
+
+	req = &registration.JoinToken{Ttl: 60, MaxUses: 10}
+	resp = &registration.JoinToken{Token: "foobaz", Ttl: 60, MaxUses: 10}
+
+	c.EXPECT().CreateJoinToken(gomock.Any(), req).Return(resp, nil)
+	token, err = GenerateCLI{}.createToken(ctx, c, 60, 10)
+	require.NoError(t, err)
+	assert.Equal(t, "foobaz", token)
 }
 
 func TestCreateVanityRecord(t *testing.T) {
@@ -57,3 +66,46 @@ func TestCreateVanityRecord(t *testing.T) {
 	err = GenerateCLI{}.createVanityRecord(ctx, c, token, spiffeID)
 	assert.Error(t, err)
 }
+
+func TestCreateNodeAlias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_registration.NewMockRegistrationClient(ctrl)
+	token := "foobar"
+	spiffeID := "spiffe://example.org/VanityID"
+
+	req := &common.RegistrationEntry{
+		ParentId: "spiffe://example.org/spire/server",
+		SpiffeId: spiffeID,
+		Selectors: []*common.Selector{
+			// SHA-256 of the token
+			{Type: "join_token", Value: "c3ab8ff13720e8ad9047dd39466b3c8974e592c2fa383d4a3960714caef0c4f2"},
+		},
+	}
+
+	c.EXPECT().CreateEntry(gomock.Any(), req)
+	err := GenerateCLI{}.createNodeAlias(ctx, c, token, spiffeID)
+	assert.NoError(t, err)
+
+	// Test a bad spiffe id
+	spiffeID = "badID/foo/bar"
+	c.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).MaxTimes(0)
+	err = GenerateCLI{}.createNodeAlias(ctx, c, token, spiffeID)
+	assert.Error(t, err)
+}
+
+func TestNewConfig(t *testing.T) {
+	config, err := GenerateCLI{}.newConfig([]string{"-spiffeID", "spiffe://example.org/VanityID", "-output", "json"})
+	require.NoError(t, err)
+	assert.Equal(t, GenerateConfig{
+		RegistrationUDSPath: "/tmp/spire-registration.sock",
+		SpiffeID:            "spiffe://example.org/VanityID",
+		TTL:                 600,
+		MaxUses:             1,
+		Output:              common_cli.OutputJSON,
+	}, config)
+
+	_, err = GenerateCLI{}.newConfig([]string{"-output", "yaml"})
+	assert.EqualError(t, err, `invalid value "yaml" for flag -output: must be "text" or "json"`)
+}
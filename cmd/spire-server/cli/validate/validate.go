@@ -1,12 +1,21 @@
 package validate
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
+
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-server/cli/run"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 )
 
-const commandName = "validate"
+const (
+	commandName = "validate"
+
+	outputText = "text"
+	outputJSON = "json"
+)
 
 func NewValidateCommand() cli.Command {
 	return newValidateCommand(common_cli.DefaultEnv)
@@ -20,11 +29,14 @@ func newValidateCommand(env *common_cli.Env) *validateCommand {
 
 type validateCommand struct {
 	env *common_cli.Env
+
+	opts   run.ValidateOptions
+	output string
 }
 
 // Help prints the server cmd usage
 func (c *validateCommand) Help() string {
-	return run.Help(commandName, c.env.Stderr)
+	return run.Help(commandName, c.env.Stderr, c.addFlags)
 }
 
 func (c *validateCommand) Synopsis() string {
@@ -32,11 +44,51 @@ func (c *validateCommand) Synopsis() string {
 }
 
 func (c *validateCommand) Run(args []string) int {
-	if _, err := run.LoadConfig(commandName, args, nil, c.env.Stderr); err != nil {
-		// Ignore error since a failure to write to stderr cannot very well be reported
-		_ = c.env.ErrPrintf("SPIRE server configuration file is invalid: %v\n", err)
+	input, err := run.ParseConfig(commandName, args, c.env.Stderr, c.addFlags)
+	switch c.output {
+	case outputText, outputJSON:
+	default:
+		_ = c.env.ErrPrintf("Invalid output format %q: must be %q or %q\n", c.output, outputText, outputJSON)
+		return 1
+	}
+
+	result := common_cli.NewValidationResult()
+	if err != nil {
+		result.AddError(err)
+	} else {
+		result = run.ValidateConfig(context.Background(), input, c.opts)
+	}
+
+	if c.output == outputJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return 1
+		}
+		if err := c.env.Println(string(data)); err != nil {
+			return 1
+		}
+	} else {
+		// Ignore errors writing to stderr since they cannot very well be
+		// reported
+		for _, warning := range result.Warnings {
+			_ = c.env.ErrPrintf("Warning: %s\n", warning)
+		}
+		for _, err := range result.Errors {
+			_ = c.env.ErrPrintf("SPIRE server configuration file is invalid: %s\n", err)
+		}
+		if result.Valid {
+			_ = c.env.Println("SPIRE server configuration file is valid.")
+		}
+	}
+
+	if !result.Valid {
 		return 1
 	}
-	_ = c.env.Println("SPIRE server configuration file is valid.")
 	return 0
 }
+
+func (c *validateCommand) addFlags(flags *flag.FlagSet) {
+	flags.BoolVar(&c.opts.Strict, "strict", false, "Fail on unknown config options rather than warning about them")
+	flags.BoolVar(&c.opts.CheckDataStore, "checkDataStore", false, "Validate the DataStore plugin configuration too, which connects to the database as on startup")
+	flags.StringVar(&c.output, "output", outputText, "Output format: text or json")
+}
@@ -0,0 +1,188 @@
+package x509
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+)
+
+func NewListCommand() cli.Command {
+	return newListCommand(common_cli.DefaultEnv, time.Now)
+}
+
+func newListCommand(env *common_cli.Env, now func() time.Time) *listCommand {
+	return &listCommand{
+		env: env,
+		now: now,
+	}
+}
+
+type listCommand struct {
+	env *common_cli.Env
+	now func() time.Time
+
+	socketPath string
+	spiffeID   string
+	agentID    string
+	active     bool
+	output     common_cli.OutputFlag
+}
+
+func (c *listCommand) Help() string {
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *listCommand) Synopsis() string {
+	return "Lists the X509-SVIDs issued by the server"
+}
+
+func (c *listCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(); err != nil {
+		c.env.ErrPrintf("error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *listCommand) parseFlags(args []string) error {
+	fs := flag.NewFlagSet("x509 list", flag.ContinueOnError)
+	fs.SetOutput(c.env.Stderr)
+	fs.StringVar(&c.socketPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	fs.StringVar(&c.spiffeID, "spiffeID", "", "SPIFFE ID of the X509-SVIDs to list")
+	fs.StringVar(&c.agentID, "agentID", "", "SPIFFE ID of the agent the X509-SVIDs were issued to")
+	fs.BoolVar(&c.active, "active", false, "Only list X509-SVIDs that have not expired")
+	c.output.AddFlag(fs)
+	return fs.Parse(args)
+}
+
+func (c *listCommand) run() error {
+	client, err := util.NewRegistrationClient(c.env.JoinPath(c.socketPath))
+	if err != nil {
+		return errors.New("cannot create registration client")
+	}
+
+	req := &registration.ListIssuedX509SVIDsRequest{
+		BySpiffeId: c.spiffeID,
+		ByAgentId:  c.agentID,
+	}
+	if c.active {
+		req.ByExpiresAfter = c.now().Unix()
+	}
+
+	var svids []*registration.IssuedX509SVID
+	for {
+		resp, err := client.ListIssuedX509SVIDs(context.Background(), req)
+		if err != nil {
+			return fmt.Errorf("unable to list issued X509-SVIDs: %v", err)
+		}
+		svids = append(svids, resp.Svids...)
+
+		// Stop once the server has no more pages to return
+		if len(resp.Svids) == 0 || resp.Pagination == nil || resp.Pagination.Token == "" {
+			break
+		}
+		req.Pagination = &registration.Pagination{
+			Token:    resp.Pagination.Token,
+			PageSize: resp.Pagination.PageSize,
+		}
+	}
+
+	if c.output.JSON() {
+		out := make([]issuedX509SVIDJSON, 0, len(svids))
+		for _, svid := range svids {
+			out = append(out, newIssuedX509SVIDJSON(svid))
+		}
+		return common_cli.PrintJSON(c.env.Stdout, struct {
+			SVIDs []issuedX509SVIDJSON `json:"svids"`
+		}{
+			SVIDs: out,
+		})
+	}
+
+	msg := fmt.Sprintf("Found %d issued ", len(svids))
+	if len(svids) == 1 {
+		msg += "X509-SVID"
+	} else {
+		msg += "X509-SVIDs"
+	}
+	if err := c.env.Println(msg); err != nil {
+		return err
+	}
+	for _, svid := range svids {
+		if err := c.printIssuedX509SVID(svid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *listCommand) printIssuedX509SVID(svid *registration.IssuedX509SVID) error {
+	if err := c.env.Println(); err != nil {
+		return err
+	}
+	if err := c.env.Printf("SPIFFE ID        : %s\n", svid.SpiffeId); err != nil {
+		return err
+	}
+	if err := c.env.Printf("Serial number    : %s\n", svid.SerialNumber); err != nil {
+		return err
+	}
+	if err := c.env.Printf("Issued at        : %s\n", formatTimestamp(svid.IssuedAt)); err != nil {
+		return err
+	}
+	if err := c.env.Printf("Expires at       : %s\n", formatTimestamp(svid.ExpiresAt)); err != nil {
+		return err
+	}
+	if err := c.env.Printf("CA key ID        : %s\n", svid.CaKeyId); err != nil {
+		return err
+	}
+	if svid.AgentId != "" {
+		if err := c.env.Printf("Agent ID         : %s\n", svid.AgentId); err != nil {
+			return err
+		}
+	}
+	if svid.EntryId != "" {
+		if err := c.env.Printf("Entry ID         : %s\n", svid.EntryId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// issuedX509SVIDJSON is the JSON representation of an issued X509-SVID
+// record.
+type issuedX509SVIDJSON struct {
+	SpiffeID     string `json:"spiffe_id"`
+	SerialNumber string `json:"serial_number"`
+	IssuedAt     string `json:"issued_at"`
+	ExpiresAt    string `json:"expires_at"`
+	CAKeyID      string `json:"ca_key_id,omitempty"`
+	AgentID      string `json:"agent_id,omitempty"`
+	EntryID      string `json:"entry_id,omitempty"`
+}
+
+func newIssuedX509SVIDJSON(svid *registration.IssuedX509SVID) issuedX509SVIDJSON {
+	return issuedX509SVIDJSON{
+		SpiffeID:     svid.SpiffeId,
+		SerialNumber: svid.SerialNumber,
+		IssuedAt:     formatTimestamp(svid.IssuedAt),
+		ExpiresAt:    formatTimestamp(svid.ExpiresAt),
+		CAKeyID:      svid.CaKeyId,
+		AgentID:      svid.AgentId,
+		EntryID:      svid.EntryId,
+	}
+}
+
+func formatTimestamp(ts int64) string {
+	return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+}
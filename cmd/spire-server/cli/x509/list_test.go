@@ -0,0 +1,248 @@
+package x509
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	expectedListUsage = `Usage of x509 list:
+  -active
+    	Only list X509-SVIDs that have not expired
+  -agentID string
+    	SPIFFE ID of the agent the X509-SVIDs were issued to
+  -output format
+    	Output format: text or json
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -spiffeID string
+    	SPIFFE ID of the X509-SVIDs to list
+`
+)
+
+var (
+	issuedWorkloadSVID = &registration.IssuedX509SVID{
+		SpiffeId:     "spiffe://domain.test/workload",
+		SerialNumber: "1",
+		IssuedAt:     1600000000,
+		ExpiresAt:    1600003600,
+		CaKeyId:      "0102",
+		AgentId:      "spiffe://domain.test/spire/agent/test/foo",
+		EntryId:      "entry",
+	}
+	issuedMintedSVID = &registration.IssuedX509SVID{
+		SpiffeId:     "spiffe://domain.test/minted",
+		SerialNumber: "2",
+		IssuedAt:     1600000000,
+		ExpiresAt:    1600003600,
+		CaKeyId:      "0102",
+	}
+)
+
+func TestListSynopsis(t *testing.T) {
+	cmd := NewListCommand()
+	assert.Equal(t, "Lists the X509-SVIDs issued by the server", cmd.Synopsis())
+}
+
+func TestListHelp(t *testing.T) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newListCommand(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	}, time.Now)
+	assert.Empty(t, cmd.Help())
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, expectedListUsage, stderr.String())
+}
+
+func TestListRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Unix(1600001800, 0)
+	api := new(fakeListRegistrationAPI)
+
+	serverDone := spiretest.StartRegistrationAPIOnSocket(t, filepath.Join(dir, util.DefaultSocketPath), api)
+	defer serverDone()
+
+	testCases := []struct {
+		name   string
+		args   []string
+		pages  map[string]*registration.ListIssuedX509SVIDsResponse
+		err    error
+		reqs   []*registration.ListIssuedX509SVIDsRequest
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			name: "no issued SVIDs",
+			pages: map[string]*registration.ListIssuedX509SVIDsResponse{
+				"": {Pagination: &registration.Pagination{PageSize: 50}},
+			},
+			reqs: []*registration.ListIssuedX509SVIDsRequest{{}},
+			stdout: `Found 0 issued X509-SVIDs
+`,
+		},
+		{
+			name: "multiple pages",
+			args: []string{"-spiffeID", "spiffe://domain.test/workload", "-agentID", "spiffe://domain.test/spire/agent/test/foo", "-active"},
+			pages: map[string]*registration.ListIssuedX509SVIDsResponse{
+				"": {
+					Svids:      []*registration.IssuedX509SVID{issuedWorkloadSVID},
+					Pagination: &registration.Pagination{Token: "1", PageSize: 1},
+				},
+				"1": {
+					Svids:      []*registration.IssuedX509SVID{issuedMintedSVID},
+					Pagination: &registration.Pagination{Token: "2", PageSize: 1},
+				},
+				"2": {
+					Pagination: &registration.Pagination{PageSize: 1},
+				},
+			},
+			reqs: []*registration.ListIssuedX509SVIDsRequest{
+				{
+					BySpiffeId:     "spiffe://domain.test/workload",
+					ByAgentId:      "spiffe://domain.test/spire/agent/test/foo",
+					ByExpiresAfter: now.Unix(),
+				},
+				{
+					BySpiffeId:     "spiffe://domain.test/workload",
+					ByAgentId:      "spiffe://domain.test/spire/agent/test/foo",
+					ByExpiresAfter: now.Unix(),
+					Pagination:     &registration.Pagination{Token: "1", PageSize: 1},
+				},
+				{
+					BySpiffeId:     "spiffe://domain.test/workload",
+					ByAgentId:      "spiffe://domain.test/spire/agent/test/foo",
+					ByExpiresAfter: now.Unix(),
+					Pagination:     &registration.Pagination{Token: "2", PageSize: 1},
+				},
+			},
+			stdout: `Found 2 issued X509-SVIDs
+
+SPIFFE ID        : spiffe://domain.test/workload
+Serial number    : 1
+Issued at        : 2020-09-13T12:26:40Z
+Expires at       : 2020-09-13T13:26:40Z
+CA key ID        : 0102
+Agent ID         : spiffe://domain.test/spire/agent/test/foo
+Entry ID         : entry
+
+SPIFFE ID        : spiffe://domain.test/minted
+Serial number    : 2
+Issued at        : 2020-09-13T12:26:40Z
+Expires at       : 2020-09-13T13:26:40Z
+CA key ID        : 0102
+`,
+		},
+		{
+			name: "JSON output",
+			args: []string{"-output", "json"},
+			pages: map[string]*registration.ListIssuedX509SVIDsResponse{
+				"": {Svids: []*registration.IssuedX509SVID{issuedMintedSVID}},
+			},
+			reqs: []*registration.ListIssuedX509SVIDsRequest{{}},
+			stdout: `{
+  "svids": [
+    {
+      "spiffe_id": "spiffe://domain.test/minted",
+      "serial_number": "2",
+      "issued_at": "2020-09-13T12:26:40Z",
+      "expires_at": "2020-09-13T13:26:40Z",
+      "ca_key_id": "0102"
+    }
+  ]
+}
+`,
+		},
+		{
+			name:   "list fails",
+			err:    errors.New("oh no"),
+			reqs:   []*registration.ListIssuedX509SVIDsRequest{{}},
+			code:   1,
+			stderr: "error: unable to list issued X509-SVIDs: rpc error: code = Unknown desc = oh no\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			api.reset(testCase.pages, testCase.err)
+
+			stdout := new(bytes.Buffer)
+			stderr := new(bytes.Buffer)
+			cmd := newListCommand(&common_cli.Env{
+				Stdin:   new(bytes.Buffer),
+				Stdout:  stdout,
+				Stderr:  stderr,
+				BaseDir: dir,
+			}, func() time.Time { return now })
+
+			code := cmd.Run(testCase.args)
+			assert.Equal(t, testCase.code, code, "exit code does not match")
+			assert.Equal(t, testCase.stderr, stderr.String(), "stderr does not match")
+			assert.Equal(t, testCase.stdout, stdout.String(), "stdout does not match")
+			spiretest.AssertProtoListEqual(t, testCase.reqs, api.requests())
+		})
+	}
+}
+
+type fakeListRegistrationAPI struct {
+	registration.RegistrationServer
+
+	mu    sync.Mutex
+	pages map[string]*registration.ListIssuedX509SVIDsResponse
+	err   error
+	reqs  []*registration.ListIssuedX509SVIDsRequest
+}
+
+func (r *fakeListRegistrationAPI) reset(pages map[string]*registration.ListIssuedX509SVIDsResponse, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pages = pages
+	r.err = err
+	r.reqs = nil
+}
+
+func (r *fakeListRegistrationAPI) requests() []*registration.ListIssuedX509SVIDsRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reqs
+}
+
+func (r *fakeListRegistrationAPI) ListIssuedX509SVIDs(ctx context.Context, req *registration.ListIssuedX509SVIDsRequest) (*registration.ListIssuedX509SVIDsResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reqs = append(r.reqs, req)
+	if r.err != nil {
+		return nil, r.err
+	}
+	token := ""
+	if req.Pagination != nil {
+		token = req.Pagination.Token
+	}
+	resp, ok := r.pages[token]
+	if !ok {
+		return nil, errors.New("page not configured in test")
+	}
+	return resp, nil
+}
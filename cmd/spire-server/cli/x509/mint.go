@@ -48,6 +48,7 @@ type mintCommand struct {
 	ttl        time.Duration
 	dnsNames   common_cli.StringsFlag
 	write      string
+	output     common_cli.OutputFlag
 }
 
 func (c *mintCommand) Help() string {
@@ -78,6 +79,7 @@ func (c *mintCommand) parseFlags(args []string) error {
 	fs.DurationVar(&c.ttl, "ttl", 0, "TTL of the X509-SVID")
 	fs.Var(&c.dnsNames, "dns", "DNS name that will be included in SVID. Can be used more than once.")
 	fs.StringVar(&c.write, "write", "", "Directory to write output to instead of stdout")
+	c.output.AddFlag(fs)
 	return fs.Parse(args)
 }
 
@@ -151,6 +153,13 @@ func (c *mintCommand) run() error {
 	}
 
 	if c.write == "" {
+		if c.output.JSON() {
+			return common_cli.PrintJSON(c.env.Stdout, mintJSON{
+				SVID:       svidPEM.String(),
+				PrivateKey: keyPEM.String(),
+				RootCAs:    bundlePEM.String(),
+			})
+		}
 		if err := c.env.Printf("X509-SVID:\n%s\n", svidPEM.String()); err != nil {
 			return err
 		}
@@ -168,28 +177,55 @@ func (c *mintCommand) run() error {
 		if err := ioutil.WriteFile(svidPath, svidPEM.Bytes(), 0644); err != nil {
 			return fmt.Errorf("unable to write SVID: %v", err)
 		}
-		if err := c.env.Printf("X509-SVID written to %s\n", svidPath); err != nil {
+		if err := c.printWritten("X509-SVID", svidPath); err != nil {
 			return err
 		}
 
 		if err := ioutil.WriteFile(keyPath, keyPEM.Bytes(), 0600); err != nil {
 			return fmt.Errorf("unable to write key: %v", err)
 		}
-		if err := c.env.Printf("Private key written to %s\n", keyPath); err != nil {
+		if err := c.printWritten("Private key", keyPath); err != nil {
 			return err
 		}
 
 		if err := ioutil.WriteFile(bundlePath, bundlePEM.Bytes(), 0644); err != nil {
 			return fmt.Errorf("unable to write bundle: %v", err)
 		}
-		if err := c.env.Printf("Root CAs written to %s\n", bundlePath); err != nil {
+		if err := c.printWritten("Root CAs", bundlePath); err != nil {
 			return err
 		}
+
+		if c.output.JSON() {
+			return common_cli.PrintJSON(c.env.Stdout, mintJSON{
+				SVIDPath:       svidPath,
+				PrivateKeyPath: keyPath,
+				RootCAsPath:    bundlePath,
+			})
+		}
 	}
 
 	return nil
 }
 
+// mintJSON is the JSON output of the command, which holds either the PEM
+// encoded SVID, key and root CAs or the paths they were written to.
+type mintJSON struct {
+	SVID           string `json:"svid,omitempty"`
+	PrivateKey     string `json:"private_key,omitempty"`
+	RootCAs        string `json:"root_cas,omitempty"`
+	SVIDPath       string `json:"svid_path,omitempty"`
+	PrivateKeyPath string `json:"private_key_path,omitempty"`
+	RootCAsPath    string `json:"root_cas_path,omitempty"`
+}
+
+// printWritten reports that a file was written, unless the output is JSON.
+func (c *mintCommand) printWritten(what, path string) error {
+	if c.output.JSON() {
+		return nil
+	}
+	return c.env.Printf("%s written to %s\n", what, path)
+}
+
 func getX509SVIDEndOfLife(svidDER []byte) (time.Time, error) {
 	svid, err := x509.ParseCertificate(svidDER)
 	if err != nil {
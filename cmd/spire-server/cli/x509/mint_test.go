@@ -31,6 +31,8 @@ const (
 	expectedUsage = `Usage of x509 mint:
   -dns value
     	DNS name that will be included in SVID. Can be used more than once.
+  -output format
+    	Output format: text or json
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
   -spiffeID string
@@ -115,6 +117,7 @@ func TestMintRun(t *testing.T) {
 		dnsNames   []string
 		socketPath string
 		write      string
+		output     string
 		extraArgs  []string
 
 		// results
@@ -184,6 +187,27 @@ func TestMintRun(t *testing.T) {
 			},
 			stderr: fmt.Sprintf("X509-SVID lifetime was capped shorter than specified ttl; expires %q\n", notAfter.UTC().Format(time.RFC3339)),
 		},
+		{
+			name:     "success with JSON output",
+			spiffeID: "spiffe://domain.test/workload",
+			output:   "json",
+			code:     0,
+			resp: &registration.MintX509SVIDResponse{
+				SvidChain: [][]byte{certDER},
+				RootCas:   [][]byte{{0x01}},
+			},
+		},
+		{
+			name:     "success with JSON output, written to directory",
+			spiffeID: "spiffe://domain.test/workload",
+			output:   "json",
+			code:     0,
+			write:    ".",
+			resp: &registration.MintX509SVIDResponse{
+				SvidChain: [][]byte{certDER},
+				RootCas:   [][]byte{{0x01}},
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -215,6 +239,9 @@ func TestMintRun(t *testing.T) {
 			if testCase.write != "" {
 				args = append(args, "-write", testCase.write)
 			}
+			if testCase.output != "" {
+				args = append(args, "-output", testCase.output)
+			}
 			for _, dnsName := range testCase.dnsNames {
 				args = append(args, "-dns", dnsName)
 			}
@@ -240,7 +267,21 @@ func TestMintRun(t *testing.T) {
 
 			// assert output file contents
 			if code == 0 {
-				if testCase.write != "" {
+				switch {
+				case testCase.output == "json" && testCase.write != "":
+					assert.JSONEq(t, fmt.Sprintf(`{
+						"svid_path": %q,
+						"private_key_path": %q,
+						"root_cas_path": %q
+					}`, svidPath, keyPath, bundlePath), stdout.String(), "stdout does not write output paths")
+					assertFileData(t, filepath.Join(dir, testCase.write, "svid.pem"), svidPEM)
+				case testCase.output == "json":
+					assert.JSONEq(t, fmt.Sprintf(`{
+						"svid": %q,
+						"private_key": %q,
+						"root_cas": %q
+					}`, svidPEM, testKeyPEM, testBundlePEM), stdout.String(), "stdout does not write out PEM")
+				case testCase.write != "":
 					assert.Equal(t, fmt.Sprintf(`X509-SVID written to %s
 Private key written to %s
 Root CAs written to %s
@@ -249,7 +290,7 @@ Root CAs written to %s
 					assertFileData(t, filepath.Join(dir, testCase.write, "svid.pem"), svidPEM)
 					assertFileData(t, filepath.Join(dir, testCase.write, "key.pem"), testKeyPEM)
 					assertFileData(t, filepath.Join(dir, testCase.write, "bundle.pem"), testBundlePEM)
-				} else {
+				default:
 					assert.Equal(t, fmt.Sprintf(`X509-SVID:
 %s
 Private key:
@@ -13,13 +13,18 @@ const (
 )
 
 func NewRegistrationClient(socketPath string) (registration.RegistrationClient, error) {
-	conn, err := grpc.Dial(socketPath, grpc.WithInsecure(), grpc.WithDialer(dialer)) //nolint: staticcheck
+	conn, err := Dial(socketPath)
 	if err != nil {
 		return nil, err
 	}
 	return registration.NewRegistrationClient(conn), err
 }
 
+// Dial returns a client connection to the Registration API UDS.
+func Dial(socketPath string) (*grpc.ClientConn, error) {
+	return grpc.Dial(socketPath, grpc.WithInsecure(), grpc.WithDialer(dialer)) //nolint: staticcheck
+}
+
 func dialer(addr string, timeout time.Duration) (net.Conn, error) {
 	return net.DialTimeout("unix", addr, timeout)
 }
@@ -2,24 +2,30 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof" //nolint: gosec // import registers routes on DefaultServeMux
 	"os"
+	"os/signal"
 	"path"
 	"runtime"
 	"sync"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	attestor "github.com/spiffe/spire/pkg/agent/attestor/node"
 	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/common/keymem"
 	"github.com/spiffe/spire/pkg/agent/endpoints"
 	"github.com/spiffe/spire/pkg/agent/manager"
 	common_catalog "github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/hostservices/metricsservice"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	common_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
 	"github.com/spiffe/spire/pkg/common/profiling"
+	"github.com/spiffe/spire/pkg/common/systemd"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
 	_ "golang.org/x/net/trace" // registers handlers on the DefaultServeMux
@@ -28,6 +34,9 @@ import (
 
 type Agent struct {
 	c *Config
+
+	catalogMtx sync.Mutex
+	catalog    *catalog.Repository
 }
 
 // Run the agent
@@ -39,6 +48,13 @@ func (a *Agent) Run(ctx context.Context) error {
 		return err
 	}
 
+	if a.c.HardenKeyMemory {
+		if err := keymem.LockMemory(); err != nil {
+			return fmt.Errorf("unable to lock agent memory: %v", err)
+		}
+		a.c.Log.Info("Agent memory locked; superseded private keys will be wiped")
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -76,36 +92,95 @@ func (a *Agent) Run(ctx context.Context) error {
 	}
 	defer cat.Close()
 
-	healthChecks := health.NewChecker(a.c.HealthChecks, a.c.Log)
+	a.setCatalog(cat)
+	defer a.setCatalog(nil)
 
-	as, err := a.attest(ctx, cat, metrics)
-	if err != nil {
-		return err
-	}
+	healthChecks := health.NewChecker(a.c.HealthChecks, a.c.Log)
 
-	manager, err := a.newManager(ctx, cat, metrics, as)
+	manager, err := a.attestAndInitManager(ctx, cat, metrics)
 	if err != nil {
 		return err
 	}
 
-	endpoints := a.newEndpoints(cat, metrics, manager)
+	endpointsServer := a.newEndpoints(cat, metrics, manager, a.notifyDrain(ctx), healthChecks)
 
 	if err := healthChecks.AddCheck("agent", a, time.Minute); err != nil {
 		return fmt.Errorf("failed adding healthcheck: %v", err)
 	}
+	subsystems := &subsystemChecks{
+		trustDomainID: a.c.TrustDomain.String(),
+		manager:       manager,
+		keyManager:    cat.GetKeyManager(),
+		plugins:       cat,
+		clock:         clock.New(),
+		metrics:       metrics,
+	}
+	if err := subsystems.register(healthChecks); err != nil {
+		return err
+	}
 
 	err = util.RunTasks(ctx,
 		manager.Run,
-		endpoints.ListenAndServe,
+		endpointsServer.ListenAndServe,
 		metrics.ListenAndServe,
 		healthChecks.ListenAndServe,
+		a.superviseWithSystemd,
 	)
-	if err == context.Canceled {
+	if err == context.Canceled || err == endpoints.ErrDrained {
 		err = nil
 	}
 	return err
 }
 
+// superviseWithSystemd notifies systemd that the agent is ready, since it
+// has attested and is starting its endpoints, and keeps the systemd watchdog
+// alive while the agent runs.
+func (a *Agent) superviseWithSystemd(ctx context.Context) error {
+	return systemd.Supervise(ctx, a.c.Log)
+}
+
+// ReconfigurePlugins configures the plugins of the running agent again with
+// the given plugin configuration, so that plugin settings can change without
+// restarting the agent.
+func (a *Agent) ReconfigurePlugins(ctx context.Context, pluginConfig catalog.HCLPluginConfigMap) error {
+	a.catalogMtx.Lock()
+	cat := a.catalog
+	a.catalogMtx.Unlock()
+
+	if cat == nil {
+		return errors.New("plugins are not loaded")
+	}
+	return cat.Reconfigure(ctx, pluginConfig)
+}
+
+func (a *Agent) setCatalog(cat *catalog.Repository) {
+	a.catalogMtx.Lock()
+	defer a.catalogMtx.Unlock()
+	a.catalog = cat
+}
+
+// notifyDrain returns a channel that is closed once the agent receives one
+// of the drain signals.
+func (a *Agent) notifyDrain(ctx context.Context) <-chan struct{} {
+	drainCh := make(chan struct{})
+	if len(drainSignals) == 0 {
+		return drainCh
+	}
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, drainSignals...)
+	go func() {
+		defer signal.Stop(signalCh)
+		select {
+		case <-ctx.Done():
+		case <-signalCh:
+			a.c.Log.Info("Drain requested; no longer accepting new workload API calls")
+			close(drainCh)
+		}
+	}()
+	return drainCh
+}
+
 func (a *Agent) setupProfiling(ctx context.Context) (stop func()) {
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(ctx)
@@ -113,7 +188,7 @@ func (a *Agent) setupProfiling(ctx context.Context) (stop func()) {
 	if runtime.MemProfileRate == 0 {
 		a.c.Log.Warn("Memory profiles are disabled")
 	}
-	if a.c.ProfilingPort > 0 {
+	if a.c.ProfilingPort > 0 || a.c.ProfilingSocketPath != "" {
 		grpc.EnableTracing = true
 
 		server := http.Server{
@@ -126,7 +201,7 @@ func (a *Agent) setupProfiling(ctx context.Context) (stop func()) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if err := server.ListenAndServe(); err != nil {
+			if err := profiling.Serve(&server, a.c.ProfilingSocketPath); err != nil {
 				a.c.Log.WithError(err).Warn("unable to serve profiling server")
 			}
 		}()
@@ -162,6 +237,33 @@ func (a *Agent) setupProfiling(ctx context.Context) (stop func()) {
 	}
 }
 
+// attestAndInitManager attests the agent and initializes the cache manager.
+// If the server no longer recognizes the agent SVID cached on disk (e.g.
+// because the agent was evicted), the SVID is discarded and node
+// attestation is performed again.
+func (a *Agent) attestAndInitManager(ctx context.Context, cat catalog.Catalog, metrics telemetry.Metrics) (manager.Manager, error) {
+	as, err := a.attest(ctx, cat, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr, err := a.newManager(ctx, cat, metrics, as)
+	if !nodeutil.IsAgentNotAttestedError(err) {
+		return mgr, err
+	}
+
+	a.c.Log.WithError(err).Warn("Agent SVID is no longer valid; re-attesting")
+	if err := manager.DeleteSVID(a.agentSVIDPath()); err != nil {
+		return nil, err
+	}
+
+	as, err = a.attest(ctx, cat, metrics)
+	if err != nil {
+		return nil, err
+	}
+	return a.newManager(ctx, cat, metrics, as)
+}
+
 func (a *Agent) attest(ctx context.Context, cat catalog.Catalog, metrics telemetry.Metrics) (*attestor.AttestationResult, error) {
 	config := attestor.Config{
 		Catalog:           cat,
@@ -174,6 +276,13 @@ func (a *Agent) attest(ctx context.Context, cat catalog.Catalog, metrics telemet
 		SVIDCachePath:     a.agentSVIDPath(),
 		Log:               a.c.Log.WithField(telemetry.SubsystemName, telemetry.Attestor),
 		ServerAddress:     a.c.ServerAddress,
+		ServerProxy:       a.c.ServerProxy,
+		ServerGRPC:        a.c.ServerGRPC,
+		RetryInterval:     a.c.AttestationRetryInterval,
+		Backoff:           a.c.ServerBackoff,
+
+		InsecureBootstrapMarkerPath: a.insecureBootstrapMarkerPath(),
+		ReenableInsecureBootstrap:   a.c.ReenableInsecureBootstrap,
 	}
 	return attestor.New(&config).Attest(ctx)
 }
@@ -186,11 +295,22 @@ func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics tel
 		Catalog:         cat,
 		TrustDomain:     a.c.TrustDomain,
 		ServerAddr:      a.c.ServerAddress,
+		ServerProxy:     a.c.ServerProxy,
+		ServerGRPC:      a.c.ServerGRPC,
 		Log:             a.c.Log.WithField(telemetry.SubsystemName, telemetry.Manager),
 		Metrics:         metrics,
 		BundleCachePath: a.bundleCachePath(),
 		SVIDCachePath:   a.agentSVIDPath(),
 		SyncInterval:    a.c.SyncInterval,
+		Backoff:         a.c.ServerBackoff,
+
+		RetainSVIDsDuringOutage: a.c.RetainSVIDsDuringOutage,
+		WipeSupersededKeys:      a.c.HardenKeyMemory,
+		JWTSVIDCacheMaxSize:     a.c.JWTSVIDCacheMaxSize,
+		SVIDRenewalThreshold:    a.c.SVIDRenewalThreshold,
+	}
+	if a.c.CachePersistence {
+		config.CachePath = a.agentCachePath()
 	}
 
 	mgr, err := manager.New(config)
@@ -205,15 +325,36 @@ func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics tel
 	return mgr, nil
 }
 
-func (a *Agent) newEndpoints(cat catalog.Catalog, metrics telemetry.Metrics, mgr manager.Manager) endpoints.Server {
+func (a *Agent) newEndpoints(cat catalog.Catalog, metrics telemetry.Metrics, mgr manager.Manager, drain <-chan struct{}, healthChecks *health.Checker) endpoints.Server {
 	config := &endpoints.Config{
-		BindAddr:          a.c.BindAddress,
-		Catalog:           cat,
-		Manager:           mgr,
-		Log:               a.c.Log.WithField(telemetry.SubsystemName, telemetry.Endpoints),
-		Metrics:           metrics,
-		DefaultSVIDName:   a.c.DefaultSVIDName,
-		DefaultBundleName: a.c.DefaultBundleName,
+		BindAddr:            a.c.BindAddress,
+		AdditionalBindAddrs: a.c.AdditionalBindAddresses,
+		TCPBindAddr:         a.c.TCPBindAddress,
+		NamedPipeName:       a.c.NamedPipeName,
+		PIDFDPeerTracking:   a.c.PIDFDPeerTracking,
+		Catalog:             cat,
+		Manager:             mgr,
+		Log:                 a.c.Log.WithField(telemetry.SubsystemName, telemetry.Endpoints),
+		Metrics:             metrics,
+		DefaultSVIDName:     a.c.DefaultSVIDName,
+		DefaultBundleName:   a.c.DefaultBundleName,
+		SDSSVIDNames:        a.c.SDSSVIDNames,
+		SDSBundleNames:      a.c.SDSBundleNames,
+
+		WorkloadAPIRateLimits:         a.c.WorkloadAPIRateLimits,
+		AllowUnauthenticatedVerifiers: a.c.AllowUnauthenticatedVerifiers,
+
+		Drain:            drain,
+		DrainGracePeriod: a.c.DrainGracePeriod,
+
+		HealthChecks:      healthChecks,
+		ReflectionEnabled: a.c.WorkloadAPIReflectionEnabled,
+
+		EnableRegistrationAPIProxy: a.c.EnableRegistrationAPIProxy,
+		ServerAddress:              a.c.ServerAddress,
+		ServerProxy:                a.c.ServerProxy,
+		ServerGRPC:                 a.c.ServerGRPC,
+		TrustDomain:                a.c.TrustDomain,
 	}
 
 	return endpoints.New(config)
@@ -227,6 +368,14 @@ func (a *Agent) agentSVIDPath() string {
 	return path.Join(a.c.DataDir, "agent_svid.der")
 }
 
+func (a *Agent) agentCachePath() string {
+	return path.Join(a.c.DataDir, "agent_cache.dat")
+}
+
+func (a *Agent) insecureBootstrapMarkerPath() string {
+	return path.Join(a.c.DataDir, "insecure_bootstrap_completed")
+}
+
 // Status is used as a top-level health check for the Agent.
 func (a *Agent) Status() (interface{}, error) {
 	return nil, nil
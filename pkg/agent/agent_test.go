@@ -0,0 +1,250 @@
+package agent
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/memory"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+const testTrustDomain = "example.org"
+
+func TestAttestAndInitManagerReattestsWhenNotAttested(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-agent-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	clk := clock.New()
+	server := newFakeNodeServer(t, clk)
+	defer server.stop()
+
+	// The agent has an SVID cached from a previous run that the server no
+	// longer recognizes, e.g. because the agent was evicted
+	staleSVID, staleKey := server.newSVID(idutil.AgentID(testTrustDomain, "/join_token/old"))
+	server.setNotAttested(staleSVID.URIs[0].String())
+
+	km := memory.New()
+	keyBytes, err := x509.MarshalECPrivateKey(staleKey)
+	require.NoError(t, err)
+	_, err = km.StorePrivateKey(context.Background(), &keymanager.StorePrivateKeyRequest{PrivateKey: keyBytes})
+	require.NoError(t, err)
+	cat := fakeagentcatalog.New()
+	cat.SetKeyManager(fakeagentcatalog.KeyManager(km))
+
+	log, _ := test.NewNullLogger()
+	a := &Agent{c: &Config{
+		DataDir:       dir,
+		JoinToken:     "new",
+		Log:           log,
+		ServerAddress: server.addr,
+		SyncInterval:  time.Minute,
+		TrustDomain:   url.URL{Scheme: "spiffe", Host: testTrustDomain},
+	}}
+	require.NoError(t, manager.StoreBundle(a.bundleCachePath(), []*x509.Certificate{server.ca}))
+	require.NoError(t, manager.StoreSVID(a.agentSVIDPath(), []*x509.Certificate{staleSVID}))
+
+	mgr, err := a.attestAndInitManager(context.Background(), cat, telemetry.Blackhole{})
+	require.NoError(t, err)
+	require.NotNil(t, mgr)
+
+	// The stale SVID was rejected once, then the agent attested again and
+	// replaced the cached SVID with the one obtained through attestation
+	require.Equal(t, 1, server.notAttestedCount())
+	require.Equal(t, 1, server.attestCount())
+	svid, err := manager.ReadSVID(a.agentSVIDPath())
+	require.NoError(t, err)
+	require.Equal(t, idutil.AgentID(testTrustDomain, "/join_token/new"), svid[0].URIs[0].String())
+}
+
+type fakeNodeServer struct {
+	node.NodeServer
+
+	t      *testing.T
+	clk    clock.Clock
+	ca     *x509.Certificate
+	caKey  *ecdsa.PrivateKey
+	server *grpc.Server
+	addr   string
+
+	mu               sync.Mutex
+	notAttestedID    string
+	notAttestedCalls int
+	attestCalls      int
+}
+
+func newFakeNodeServer(t *testing.T, clk clock.Clock) *fakeNodeServer {
+	caTmpl, err := util.NewCATemplate(clk, testTrustDomain)
+	require.NoError(t, err)
+	ca, caKey, err := util.SelfSign(caTmpl)
+	require.NoError(t, err)
+
+	s := &fakeNodeServer{
+		t:     t,
+		clk:   clk,
+		ca:    ca,
+		caKey: caKey,
+	}
+
+	serverSVID, serverKey := s.newSVID(idutil.ServerID(testTrustDomain))
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	s.server = grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{serverSVID.Raw, ca.Raw},
+			PrivateKey:  serverKey,
+		}},
+		ClientAuth: tls.VerifyClientCertIfGiven,
+		ClientCAs:  roots,
+	})))
+	node.RegisterNodeServer(s.server, s)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	s.addr = listener.Addr().String()
+	go func() {
+		_ = s.server.Serve(listener)
+	}()
+	return s
+}
+
+func (s *fakeNodeServer) stop() {
+	s.server.Stop()
+}
+
+func (s *fakeNodeServer) newSVID(spiffeID string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	tmpl, err := util.NewSVIDTemplate(s.clk, spiffeID)
+	require.NoError(s.t, err)
+	svid, key, err := util.Sign(tmpl, s.ca, s.caKey)
+	require.NoError(s.t, err)
+	return svid, key
+}
+
+// setNotAttested makes the server reject the agent with the given ID as not
+// attested.
+func (s *fakeNodeServer) setNotAttested(agentID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notAttestedID = agentID
+}
+
+func (s *fakeNodeServer) notAttestedCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.notAttestedCalls
+}
+
+func (s *fakeNodeServer) attestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attestCalls
+}
+
+func (s *fakeNodeServer) bundles() map[string]*common.Bundle {
+	td := "spiffe://" + testTrustDomain
+	return map[string]*common.Bundle{
+		td: {
+			TrustDomainId: td,
+			RootCas:       []*common.Certificate{{DerBytes: s.ca.Raw}},
+		},
+	}
+}
+
+func (s *fakeNodeServer) Attest(stream node.Node_AttestServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if req.AttestationData.Type != "join_token" {
+		return errors.New("unexpected attestation type")
+	}
+
+	tmpl, err := util.NewSVIDTemplateFromCSR(s.clk, req.Csr, s.ca, 3600)
+	if err != nil {
+		return err
+	}
+	agentID := idutil.AgentID(testTrustDomain, "/join_token/"+string(req.AttestationData.Data))
+	uri, err := url.Parse(agentID)
+	if err != nil {
+		return err
+	}
+	tmpl.URIs = []*url.URL{uri}
+	tmpl.ExtraExtensions = nil
+	svid, _, err := util.Sign(tmpl, s.ca, s.caKey)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.attestCalls++
+	s.mu.Unlock()
+
+	return stream.Send(&node.AttestResponse{
+		SvidUpdate: &node.X509SVIDUpdate{
+			Svids: map[string]*node.X509SVID{
+				agentID: {
+					CertChain: svid.Raw,
+					ExpiresAt: svid.NotAfter.Unix(),
+				},
+			},
+			Bundles: s.bundles(),
+		},
+	})
+}
+
+func (s *fakeNodeServer) FetchX509SVID(stream node.Node_FetchX509SVIDServer) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+
+	p, ok := peer.FromContext(stream.Context())
+	if !ok {
+		return errors.New("no peer information")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+		return errors.New("no verified client certificate presented by peer")
+	}
+
+	agentCert := tlsInfo.State.VerifiedChains[0][0]
+
+	s.mu.Lock()
+	notAttested := len(agentCert.URIs) == 1 && agentCert.URIs[0].String() == s.notAttestedID
+	if notAttested {
+		s.notAttestedCalls++
+	}
+	s.mu.Unlock()
+	if notAttested {
+		return nodeutil.AgentNotAttestedError()
+	}
+
+	return stream.Send(&node.FetchX509SVIDResponse{
+		SvidUpdate: &node.X509SVIDUpdate{
+			Bundles: s.bundles(),
+		},
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"net"
 	"net/url"
+	"sync/atomic"
 	"testing"
 
 	"github.com/spiffe/spire/pkg/common/idutil"
@@ -17,7 +18,9 @@ import (
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 func startNodeServer(t *testing.T, tlsConfig *tls.Config, apiConfig fakeNodeAPIConfig) (string, func()) {
@@ -48,11 +51,17 @@ type fakeNodeAPIConfig struct {
 	OmitSVIDUpdate     bool
 	OverrideSVIDUpdate *node.X509SVIDUpdate
 	FailAttestCall     bool
+
+	// UnavailableAttestCalls is the number of Attest calls failing with
+	// codes.Unavailable before calls are served
+	UnavailableAttestCalls int32
 }
 
 type fakeNodeAPI struct {
 	node.NodeServer
 	c fakeNodeAPIConfig
+
+	attestCalls int32
 }
 
 func newFakeNodeAPI(config fakeNodeAPIConfig) *fakeNodeAPI {
@@ -66,6 +75,10 @@ func (n *fakeNodeAPI) Attest(stream node.Node_AttestServer) error {
 	ctx, cancel := context.WithCancel(stream.Context())
 	defer cancel()
 
+	if atomic.AddInt32(&n.attestCalls, 1) <= n.c.UnavailableAttestCalls {
+		return status.Error(codes.Unavailable, "server is purposefully unavailable")
+	}
+
 	attestorStream, err := n.c.Attestor.Attest(ctx)
 	if err != nil {
 		return err
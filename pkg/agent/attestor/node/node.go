@@ -9,15 +9,20 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"os"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/common/backoff"
+	"github.com/spiffe/spire/pkg/agent/common/keymem"
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/diskutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
@@ -28,11 +33,17 @@ import (
 	"github.com/zeebo/errs"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	joinTokenType = "join_token"
+
+	// DefaultRetryInterval is the initial interval between attestation
+	// attempts while the server is unavailable
+	DefaultRetryInterval = 5 * time.Second
 )
 
 type AttestationResult struct {
@@ -56,6 +67,27 @@ type Config struct {
 	SVIDCachePath     string
 	Log               logrus.FieldLogger
 	ServerAddress     string
+	ServerProxy       client.ProxyFunc
+	ServerGRPC        client.GRPCConfig
+
+	// RetryInterval is the initial interval between attestation attempts
+	// while the server is unavailable. Defaults to DefaultRetryInterval.
+	RetryInterval time.Duration
+
+	// Backoff tunes how the interval between attestation attempts grows
+	Backoff backoff.Config
+
+	// Clk is the clock the attestor uses to wait between attempts
+	Clk clock.Clock
+
+	// InsecureBootstrapMarkerPath is the path of the file recording that an
+	// insecure bootstrap has already completed. While it exists, insecure
+	// bootstrap is refused unless ReenableInsecureBootstrap is set.
+	InsecureBootstrapMarkerPath string
+
+	// ReenableInsecureBootstrap allows insecure bootstrap to be performed
+	// again after a previous one has completed.
+	ReenableInsecureBootstrap bool
 }
 
 type attestor struct {
@@ -63,6 +95,12 @@ type attestor struct {
 }
 
 func New(config *Config) Attestor {
+	if config.RetryInterval == 0 {
+		config.RetryInterval = DefaultRetryInterval
+	}
+	if config.Clk == nil {
+		config.Clk = clock.New()
+	}
 	return &attestor{c: config}
 }
 
@@ -78,10 +116,16 @@ func (a *attestor) Attest(ctx context.Context) (res *AttestationResult, err erro
 
 	switch {
 	case svid == nil:
-		svid, bundle, err = a.newSVID(ctx, key, bundle)
+		insecureBootstrap := bundle == nil
+		svid, bundle, err = a.newSVIDWithRetries(ctx, key, bundle)
 		if err != nil {
 			return nil, err
 		}
+		if insecureBootstrap {
+			if err := a.completeInsecureBootstrap(bundle); err != nil {
+				return nil, err
+			}
+		}
 	case bundle == nil:
 		// This is a bizarre case where we have an SVID but were unable to
 		// load a bundle from the cache which suggests some tampering with the
@@ -99,6 +143,7 @@ func (a *attestor) loadSVID(ctx context.Context) ([]*x509.Certificate, *ecdsa.Pr
 	if err != nil {
 		return nil, nil, fmt.Errorf("load private key: %v", err)
 	}
+	defer keymem.Wipe(fetchRes.PrivateKey)
 	svid := a.readSVIDFromDisk()
 
 	privateKeyExists := len(fetchRes.PrivateKey) > 0
@@ -126,6 +171,7 @@ func (a *attestor) loadSVID(ctx context.Context) ([]*x509.Certificate, *ecdsa.Pr
 	if err != nil {
 		return nil, nil, fmt.Errorf("generate key pair: %s", err)
 	}
+	defer keymem.Wipe(generateRes.PrivateKey)
 	key, err := x509.ParseECPrivateKey(generateRes.PrivateKey)
 	if err != nil {
 		return nil, nil, fmt.Errorf("parse key from keymanager: %v", key)
@@ -146,6 +192,9 @@ func (a *attestor) loadBundle() (*bundleutil.Bundle, error) {
 	bundle, err := manager.ReadBundle(a.c.BundleCachePath)
 	if err == manager.ErrNotCached {
 		if a.c.InsecureBootstrap {
+			if err := a.checkInsecureBootstrapAllowed(); err != nil {
+				return nil, err
+			}
 			if len(a.c.TrustBundle) > 0 {
 				a.c.Log.Warn("Trust bundle will be ignored; performing insecure bootstrap")
 			}
@@ -167,6 +216,42 @@ func (a *attestor) loadBundle() (*bundleutil.Bundle, error) {
 	return bundleutil.BundleFromRootCAs(a.c.TrustDomain.String(), bundle), nil
 }
 
+// checkInsecureBootstrapAllowed fails if an insecure bootstrap has already
+// completed and has not been explicitly re-enabled, so that an agent that
+// lost its cached bundle cannot be bootstrapped again by a man-in-the-middle.
+func (a *attestor) checkInsecureBootstrapAllowed() error {
+	if a.c.InsecureBootstrapMarkerPath == "" {
+		return nil
+	}
+	_, err := os.Stat(a.c.InsecureBootstrapMarkerPath)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("load bundle: unable to check insecure bootstrap state: %v", err)
+	case a.c.ReenableInsecureBootstrap:
+		a.c.Log.Warn("Insecure bootstrap has been re-enabled; performing insecure bootstrap again")
+		return nil
+	default:
+		return errors.New("load bundle: insecure bootstrap already completed but no bundle is cached; set reenable_insecure_bootstrap to bootstrap insecurely again")
+	}
+}
+
+// completeInsecureBootstrap persists the bundle learned during an insecure
+// bootstrap and records that the bootstrap has completed.
+func (a *attestor) completeInsecureBootstrap(bundle *bundleutil.Bundle) error {
+	if err := manager.StoreBundle(a.c.BundleCachePath, bundle.RootCAs()); err != nil {
+		return fmt.Errorf("store bundle: %v", err)
+	}
+	if a.c.InsecureBootstrapMarkerPath == "" {
+		return nil
+	}
+	if err := diskutil.AtomicWriteFile(a.c.InsecureBootstrapMarkerPath, nil, 0600); err != nil {
+		return fmt.Errorf("record insecure bootstrap state: %v", err)
+	}
+	return nil
+}
+
 func (a *attestor) fetchAttestationData(fetchStream nodeattestor.NodeAttestor_FetchAttestationDataClient, challenge []byte) (*nodeattestor.FetchAttestationDataResponse, error) {
 	// the stream should only be nil if this node attestation is via a join
 	// token.
@@ -215,6 +300,33 @@ func (a *attestor) readSVIDFromDisk() []*x509.Certificate {
 
 // newSVID obtains an agent svid for the given private key by performing node attesatation. The bundle is
 // necessary in order to validate the SPIRE server we are attesting to. Returns the SVID and an updated bundle.
+// newSVIDWithRetries attests the agent, backing off and trying again while
+// the server is unavailable, so that agents do not all attest again at the
+// same time once the server is back.
+func (a *attestor) newSVIDWithRetries(ctx context.Context, key *ecdsa.PrivateKey, bundle *bundleutil.Bundle) ([]*x509.Certificate, *bundleutil.Bundle, error) {
+	retryBackoff := backoff.NewBackoffWithConfig(a.c.Clk, a.c.RetryInterval, a.c.Backoff)
+	for failures := 1; ; failures++ {
+		svid, newBundle, err := a.newSVID(ctx, key, bundle)
+		switch {
+		case err == nil:
+			telemetry_agent.SetNodeAttestorBackoffGauges(a.c.Metrics, 0, 0)
+			return svid, newBundle, nil
+		case !isRetryable(err):
+			return nil, nil, err
+		}
+
+		interval := retryBackoff.NextBackOff()
+		telemetry_agent.SetNodeAttestorBackoffGauges(a.c.Metrics, float32(interval.Seconds()), failures)
+		a.c.Log.WithError(err).WithField(telemetry.RetryInterval, interval).Warn("Server unavailable; retrying attestation")
+
+		select {
+		case <-a.c.Clk.After(interval):
+		case <-ctx.Done():
+			return nil, nil, err
+		}
+	}
+}
+
 func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *bundleutil.Bundle) (newSVID []*x509.Certificate, newBundle *bundleutil.Bundle, err error) {
 	counter := telemetry_agent.StartNodeAttestorNewSVIDCall(a.c.Metrics)
 	attestorName := ""
@@ -241,7 +353,7 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *b
 
 	conn, err := a.serverConn(ctx, bundle)
 	if err != nil {
-		return nil, nil, fmt.Errorf("create attestation client: %v", err)
+		return nil, nil, newServerError("create attestation client", err)
 	}
 	defer conn.Close()
 
@@ -249,7 +361,7 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *b
 
 	attestStream, err := nodeClient.Attest(ctx)
 	if err != nil {
-		return nil, nil, fmt.Errorf("opening stream for attestation: %v", err)
+		return nil, nil, newServerError("opening stream for attestation", err)
 	}
 
 	var deprecatedAgentID string
@@ -300,12 +412,17 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *b
 		}
 
 		if err := attestStream.Send(attestReq); err != nil {
-			return nil, nil, fmt.Errorf("sending attestation request to SPIRE server: %v", err)
+			if err == io.EOF {
+				// The stream was aborted by the server; the status is
+				// returned by Recv
+				_, err = attestStream.Recv()
+			}
+			return nil, nil, newServerError("sending attestation request to SPIRE server", err)
 		}
 
 		attestResp, err = attestStream.Recv()
 		if err != nil {
-			return nil, nil, fmt.Errorf("attesting to SPIRE server: %v", err)
+			return nil, nil, newServerError("attesting to SPIRE server", err)
 		}
 
 		// if the response has no additional data then break out and parse
@@ -349,6 +466,8 @@ func (a *attestor) serverConn(ctx context.Context, bundle *bundleutil.Bundle) (*
 			Address:     a.c.ServerAddress,
 			TrustDomain: a.c.TrustDomain.Host,
 			GetBundle:   bundle.RootCAs,
+			Proxy:       a.c.ServerProxy,
+			GRPC:        a.c.ServerGRPC,
 		})
 	}
 
@@ -383,11 +502,12 @@ func (a *attestor) serverConn(ctx context.Context, bundle *bundleutil.Bundle) (*
 		},
 	}
 
-	return grpc.DialContext(ctx, a.c.ServerAddress,
+	return grpc.DialContext(ctx, a.c.ServerAddress, append([]grpc.DialOption{
 		grpc.WithBalancerName(roundrobin.Name), //nolint:staticcheck
 		grpc.FailOnNonTempDialError(true),
+		grpc.WithContextDialer(client.ProxyDialer(a.c.ServerProxy)),
 		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
-	)
+	}, a.c.ServerGRPC.DialOptions()...)...)
 }
 
 func (a *attestor) parseAttestationResponse(r *node.AttestResponse) (string, []*x509.Certificate, *bundleutil.Bundle, error) {
@@ -425,3 +545,33 @@ func (a *attestor) parseAttestationResponse(r *node.AttestResponse) (string, []*
 
 	return agentID, svid, bundle, nil
 }
+
+// serverError is an error returned by the server, or by the connection to
+// it, while attesting
+type serverError struct {
+	msg string
+	err error
+}
+
+func newServerError(msg string, err error) error {
+	return serverError{msg: msg, err: err}
+}
+
+func (e serverError) Error() string {
+	return fmt.Sprintf("%s: %v", e.msg, e.err)
+}
+
+// isRetryable returns true if the attestation failed because the server is
+// unavailable or overloaded, in which case attestation is tried again.
+func isRetryable(err error) bool {
+	var serverErr serverError
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+	switch status.Code(serverErr.err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
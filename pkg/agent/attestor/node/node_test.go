@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"io/ioutil"
 	"math/big"
 	"net/url"
@@ -16,6 +17,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/memory"
 	agentnodeattestor "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor"
@@ -31,6 +33,8 @@ import (
 	"github.com/spiffe/spire/test/fakes/fakeservernodeattestor"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -73,11 +77,32 @@ func TestAttestor(t *testing.T) {
 		storeKey                    crypto.PrivateKey
 		failFetchingAttestationData bool
 		failAttestCall              bool
+		unavailableAttestCalls      int32
+		insecureBootstrapCompleted  bool
+		reenableInsecureBootstrap   bool
 	}{
 		{
 			name:              "insecure bootstrap",
 			insecureBootstrap: true,
 		},
+		{
+			name:                       "insecure bootstrap refused once completed",
+			insecureBootstrap:          true,
+			insecureBootstrapCompleted: true,
+			err:                        "load bundle: insecure bootstrap already completed but no bundle is cached",
+		},
+		{
+			name:                       "insecure bootstrap re-enabled",
+			insecureBootstrap:          true,
+			insecureBootstrapCompleted: true,
+			reenableInsecureBootstrap:  true,
+		},
+		{
+			name:                       "insecure bootstrap ignored with cached bundle",
+			insecureBootstrap:          true,
+			insecureBootstrapCompleted: true,
+			cachedBundle:               caCert.Raw,
+		},
 		{
 			name:         "cached bundle empty",
 			cachedBundle: []byte(""),
@@ -176,6 +201,11 @@ func TestAttestor(t *testing.T) {
 			bootstrapBundle: caCert,
 			joinToken:       "JOINTOKEN",
 		},
+		{
+			name:                   "success after the server is unavailable",
+			bootstrapBundle:        caCert,
+			unavailableAttestCalls: 2,
+		},
 		{
 			name:              "success with old plugin",
 			bootstrapBundle:   caCert,
@@ -232,6 +262,11 @@ func TestAttestor(t *testing.T) {
 			svidCachePath, bundleCachePath, removeDir := prepareTestDir(t, testCase.cachedSVID, testCase.cachedBundle)
 			defer removeDir()
 
+			insecureBootstrapMarkerPath := filepath.Join(filepath.Dir(bundleCachePath), "insecure_bootstrap_completed")
+			if testCase.insecureBootstrapCompleted {
+				writeFile(t, insecureBootstrapMarkerPath, nil, 0600)
+			}
+
 			// load up the fake agent-side node attestor
 			agentNA, agentNADone := prepareAgentNA(t, fakeagentnodeattestor.Config{
 				Fail:              testCase.failFetchingAttestationData,
@@ -268,6 +303,8 @@ func TestAttestor(t *testing.T) {
 				OmitSVIDUpdate:     testCase.omitSVIDUpdate,
 				OverrideSVIDUpdate: testCase.overrideSVIDUpdate,
 				FailAttestCall:     testCase.failAttestCall,
+
+				UnavailableAttestCalls: testCase.unavailableAttestCalls,
 			})
 			defer serverDone()
 
@@ -287,6 +324,10 @@ func TestAttestor(t *testing.T) {
 				TrustBundle:       makeTrustBundle(testCase.bootstrapBundle),
 				InsecureBootstrap: testCase.insecureBootstrap,
 				ServerAddress:     serverAddr,
+				RetryInterval:     time.Millisecond,
+
+				InsecureBootstrapMarkerPath: insecureBootstrapMarkerPath,
+				ReenableInsecureBootstrap:   testCase.reenableInsecureBootstrap,
 			})
 
 			// perform attestation
@@ -310,6 +351,16 @@ func TestAttestor(t *testing.T) {
 			rootCAs := result.Bundle.RootCAs()
 			require.Len(rootCAs, 1)
 			require.Equal(rootCAs[0].Raw, caCert.Raw)
+
+			if testCase.insecureBootstrap && testCase.cachedBundle == nil {
+				// the learned bundle and the completed bootstrap are persisted
+				// right away so a restarted agent cannot bootstrap insecurely
+				cachedBundle, err := manager.ReadBundle(bundleCachePath)
+				require.NoError(err)
+				require.Len(cachedBundle, 1)
+				require.Equal(caCert.Raw, cachedBundle[0].Raw)
+				require.FileExists(insecureBootstrapMarkerPath)
+			}
 		})
 	}
 }
@@ -483,3 +534,15 @@ func TestIsSVIDValid(t *testing.T) {
 		})
 	}
 }
+
+func TestIsRetryable(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	require.True(t, isRetryable(newServerError("attesting to SPIRE server", unavailable)))
+	require.True(t, isRetryable(newServerError("attesting to SPIRE server", status.Error(codes.ResourceExhausted, "rate limited"))))
+	require.True(t, isRetryable(fmt.Errorf("wrapped: %w", newServerError("attesting to SPIRE server", unavailable))))
+
+	// Attestation rejected by the server
+	require.False(t, isRetryable(newServerError("attesting to SPIRE server", status.Error(codes.PermissionDenied, "denied"))))
+	// Errors not returned by the server
+	require.False(t, isRetryable(unavailable))
+}
@@ -0,0 +1,235 @@
+// Package bundlesource reads the initial agent trust bundle from cloud
+// provider metadata, so agents can bootstrap without a bundle file baked into
+// their image.
+package bundlesource
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+// Supported trust bundle sources
+const (
+	// AWSSSM reads the bundle from an AWS SSM Parameter Store parameter
+	AWSSSM = "aws_ssm"
+	// AWSUserData reads the bundle from the EC2 instance user data
+	AWSUserData = "aws_user_data"
+	// GCPMetadata reads the bundle from a GCP instance or project metadata
+	// attribute
+	GCPMetadata = "gcp_metadata"
+	// AzureIMDS reads the bundle from the Azure VM user data, served by the
+	// Azure Instance Metadata Service
+	AzureIMDS = "azure_imds"
+)
+
+const (
+	defaultGCPMetadataEndpoint = "http://metadata.google.internal"
+	defaultAzureIMDSEndpoint   = "http://169.254.169.254"
+
+	gcpInstanceAttributePath = "/computeMetadata/v1/instance/attributes/"
+	gcpProjectAttributePath  = "/computeMetadata/v1/project/attributes/"
+	azureUserDataPath        = "/metadata/instance/compute/userData?api-version=2021-01-01&format=text"
+)
+
+var (
+	// metadataTimeout bounds each request to the GCP and Azure metadata
+	// services, which are link-local and answer quickly when available.
+	metadataTimeout = 10 * time.Second
+)
+
+// Config configures where the trust bundle is read from
+type Config struct {
+	// Type is the kind of source (e.g. aws_ssm)
+	Type string
+
+	// Name is the SSM parameter name for aws_ssm, or the metadata attribute
+	// name for gcp_metadata. It is unused by other sources.
+	Name string
+
+	// Region is the AWS region of the SSM parameter. If unset, the region
+	// the instance runs in is used.
+	Region string
+
+	// Endpoint overrides the endpoint of the service the bundle is read
+	// from (the SSM API, or the EC2, GCP or Azure metadata service).
+	Endpoint string
+}
+
+// Validate checks that the source configuration is complete
+func Validate(config Config) error {
+	switch config.Type {
+	case AWSSSM, GCPMetadata:
+		if config.Name == "" {
+			return fmt.Errorf("name is required for the %s trust bundle source", config.Type)
+		}
+	case AWSUserData, AzureIMDS:
+	case "":
+		return errors.New("trust bundle source type is required")
+	default:
+		return fmt.Errorf("unsupported trust bundle source type %q", config.Type)
+	}
+	return nil
+}
+
+// Fetch reads the trust bundle from the configured source. The bundle is
+// returned as is; parsing it is up to the caller.
+func Fetch(ctx context.Context, config Config) ([]byte, error) {
+	if err := Validate(config); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	var err error
+	switch config.Type {
+	case AWSSSM:
+		data, err = fetchAWSSSMParameter(ctx, config)
+	case AWSUserData:
+		data, err = fetchAWSUserData(config)
+	case GCPMetadata:
+		data, err = fetchGCPMetadataAttribute(ctx, config)
+	case AzureIMDS:
+		data, err = fetchAzureUserData(ctx, config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read trust bundle from %s: %v", config.Type, err)
+	}
+	return data, nil
+}
+
+func fetchAWSSSMParameter(ctx context.Context, config Config) ([]byte, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, err
+	}
+
+	region := config.Region
+	if region == "" {
+		region, err = ec2metadata.New(sess).Region()
+		if err != nil {
+			return nil, fmt.Errorf("unable to discover region: %v", err)
+		}
+	}
+
+	awsConfig := aws.NewConfig().WithRegion(region)
+	if config.Endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(config.Endpoint)
+	}
+
+	resp, err := ssm.New(sess, awsConfig).GetParameterWithContext(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(config.Name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Parameter == nil || aws.StringValue(resp.Parameter.Value) == "" {
+		return nil, fmt.Errorf("parameter %q is empty", config.Name)
+	}
+	return []byte(aws.StringValue(resp.Parameter.Value)), nil
+}
+
+func fetchAWSUserData(config Config) ([]byte, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, err
+	}
+
+	awsConfig := aws.NewConfig()
+	if config.Endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(config.Endpoint)
+	}
+
+	userData, err := ec2metadata.New(sess, awsConfig).GetUserData()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(userData), nil
+}
+
+// fetchGCPMetadataAttribute reads the attribute from the instance metadata,
+// falling back to the project metadata so a whole fleet can share it.
+func fetchGCPMetadataAttribute(ctx context.Context, config Config) ([]byte, error) {
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = defaultGCPMetadataEndpoint
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	header := http.Header{"Metadata-Flavor": []string{"Google"}}
+	data, status, err := getMetadata(ctx, endpoint+gcpInstanceAttributePath+config.Name, header)
+	if status != http.StatusNotFound {
+		return data, err
+	}
+	data, _, err = getMetadata(ctx, endpoint+gcpProjectAttributePath+config.Name, header)
+	return data, err
+}
+
+func fetchAzureUserData(ctx context.Context, config Config) ([]byte, error) {
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = defaultAzureIMDSEndpoint
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	header := http.Header{"Metadata": []string{"true"}}
+	encoded, _, err := getMetadata(ctx, endpoint+azureUserDataPath, header)
+	if err != nil {
+		return nil, err
+	}
+
+	// user data is served base64 encoded
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode user data: %v", err)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("user data is empty")
+	}
+	return data, nil
+}
+
+func getMetadata(ctx context.Context, url string, header http.Header) ([]byte, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, metadataTimeout)
+	defer cancel()
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req = req.WithContext(ctx)
+	req.Header = header
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, tryRead(resp.Body))
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+	return data, resp.StatusCode, nil
+}
+
+func tryRead(r io.Reader) string {
+	b := make([]byte, 1024)
+	n, _ := r.Read(b)
+	return string(b[:n])
+}
@@ -0,0 +1,195 @@
+package bundlesource
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBundle = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+
+var ctx = context.Background()
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(Config{Type: AWSSSM, Name: "/spire/bundle"}))
+	assert.NoError(t, Validate(Config{Type: AWSUserData}))
+	assert.NoError(t, Validate(Config{Type: GCPMetadata, Name: "spire-bundle"}))
+	assert.NoError(t, Validate(Config{Type: AzureIMDS}))
+
+	assert.EqualError(t, Validate(Config{}), "trust bundle source type is required")
+	assert.EqualError(t, Validate(Config{Type: "foo"}), `unsupported trust bundle source type "foo"`)
+	assert.EqualError(t, Validate(Config{Type: AWSSSM}), "name is required for the aws_ssm trust bundle source")
+	assert.EqualError(t, Validate(Config{Type: GCPMetadata}), "name is required for the gcp_metadata trust bundle source")
+}
+
+func TestFetchAWSSSM(t *testing.T) {
+	defer setAWSTestCredentials(t)()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "AmazonSSM.GetParameter", r.Header.Get("X-Amz-Target"))
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := struct {
+			Name           string
+			WithDecryption bool
+		}{}
+		require.NoError(t, json.Unmarshal(body, &req))
+		assert.True(t, req.WithDecryption)
+
+		if req.Name != "/spire/bundle" {
+			w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"ParameterNotFound","message":"not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"Parameter": map[string]interface{}{
+				"Name":  req.Name,
+				"Value": testBundle,
+			},
+		})
+	}))
+	defer server.Close()
+
+	data, err := Fetch(ctx, Config{Type: AWSSSM, Name: "/spire/bundle", Region: "us-east-1", Endpoint: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, testBundle, string(data))
+
+	_, err = Fetch(ctx, Config{Type: AWSSSM, Name: "/spire/other", Region: "us-east-1", Endpoint: server.URL})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to read trust bundle from aws_ssm: ParameterNotFound")
+}
+
+func TestFetchAWSUserData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/latest/api/token":
+			_, _ = w.Write([]byte("token"))
+		case "/latest/user-data":
+			_, _ = w.Write([]byte(testBundle))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	data, err := Fetch(ctx, Config{Type: AWSUserData, Endpoint: server.URL + "/latest"})
+	require.NoError(t, err)
+	assert.Equal(t, testBundle, string(data))
+}
+
+func TestFetchGCPMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/computeMetadata/v1/instance/attributes/instance-bundle":
+			_, _ = w.Write([]byte("instance"))
+		case "/computeMetadata/v1/project/attributes/project-bundle":
+			_, _ = w.Write([]byte("project"))
+		case "/computeMetadata/v1/instance/attributes/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	data, err := Fetch(ctx, Config{Type: GCPMetadata, Name: "instance-bundle", Endpoint: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, "instance", string(data))
+
+	// falls back to the project metadata
+	data, err = Fetch(ctx, Config{Type: GCPMetadata, Name: "project-bundle", Endpoint: server.URL + "/"})
+	require.NoError(t, err)
+	assert.Equal(t, "project", string(data))
+
+	_, err = Fetch(ctx, Config{Type: GCPMetadata, Name: "missing", Endpoint: server.URL})
+	assert.EqualError(t, err, "unable to read trust bundle from gcp_metadata: unexpected status code 404: ")
+
+	// only missing instance attributes fall back to the project metadata
+	_, err = Fetch(ctx, Config{Type: GCPMetadata, Name: "broken", Endpoint: server.URL})
+	assert.EqualError(t, err, "unable to read trust bundle from gcp_metadata: unexpected status code 500: ")
+}
+
+func TestFetchAzureIMDS(t *testing.T) {
+	userData := base64.StdEncoding.EncodeToString([]byte(testBundle))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, "/metadata/instance/compute/userData", r.URL.Path)
+		assert.Equal(t, "2021-01-01", r.URL.Query().Get("api-version"))
+		_, _ = w.Write([]byte(userData))
+	}))
+	defer server.Close()
+
+	data, err := Fetch(ctx, Config{Type: AzureIMDS, Endpoint: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, testBundle, string(data))
+
+	userData = "not base64!"
+	_, err = Fetch(ctx, Config{Type: AzureIMDS, Endpoint: server.URL})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to decode user data")
+
+	userData = ""
+	_, err = Fetch(ctx, Config{Type: AzureIMDS, Endpoint: server.URL})
+	assert.EqualError(t, err, "unable to read trust bundle from azure_imds: user data is empty")
+}
+
+func TestFetchMetadataTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	oldTimeout := metadataTimeout
+	metadataTimeout = 10 * time.Millisecond
+	defer func() { metadataTimeout = oldTimeout }()
+
+	_, err := Fetch(ctx, Config{Type: AzureIMDS, Endpoint: server.URL})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "context deadline exceeded")
+}
+
+// setAWSTestCredentials sets static AWS credentials in the environment and
+// returns a function restoring the previous environment.
+func setAWSTestCredentials(t *testing.T) func() {
+	var restore []func()
+	for key, value := range map[string]string{
+		"AWS_ACCESS_KEY_ID":     "ACCESSKEY",
+		"AWS_SECRET_ACCESS_KEY": "SECRETKEY",
+	} {
+		key := key
+		prev, ok := os.LookupEnv(key)
+		require.NoError(t, os.Setenv(key, value))
+		restore = append(restore, func() {
+			if ok {
+				os.Setenv(key, prev)
+			} else {
+				os.Unsetenv(key)
+			}
+		})
+	}
+	return func() {
+		for _, fn := range restore {
+			fn()
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	km_disk "github.com/spiffe/spire/pkg/agent/plugin/keymanager/disk"
 	km_memory "github.com/spiffe/spire/pkg/agent/plugin/keymanager/memory"
+	km_tpm "github.com/spiffe/spire/pkg/agent/plugin/keymanager/tpm"
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor"
 	na_aws_iid "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/aws"
 	na_azure_msi "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/azure"
@@ -17,9 +18,11 @@ import (
 	na_sshpop "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/sshpop"
 	na_x509pop "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/x509pop"
 	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	wa_composite "github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/composite"
 	wa_docker "github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/docker"
 	wa_k8s "github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/k8s"
 	wa_unix "github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/unix"
+	wa_windows "github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/windows"
 	"github.com/spiffe/spire/pkg/common/catalog"
 )
 
@@ -49,6 +52,7 @@ func BuiltIns() []catalog.Plugin {
 	return []catalog.Plugin{
 		km_disk.BuiltIn(),
 		km_memory.BuiltIn(),
+		km_tpm.BuiltIn(),
 		na_aws_iid.BuiltIn(),
 		na_join_token.BuiltIn(),
 		na_gcp_iit.BuiltIn(),
@@ -59,7 +63,9 @@ func BuiltIns() []catalog.Plugin {
 		na_k8s_psat.BuiltIn(),
 		wa_k8s.BuiltIn(),
 		wa_unix.BuiltIn(),
+		wa_windows.BuiltIn(),
 		wa_docker.BuiltIn(),
+		wa_composite.BuiltIn(),
 	}
 }
 
@@ -107,6 +113,24 @@ type Config struct {
 type Repository struct {
 	Catalog
 	catalog.Closer
+
+	plugins catalog.Catalog
+}
+
+// Reconfigure configures the loaded plugins again with the given plugin
+// configuration. See catalog.Catalog for the restrictions.
+func (r *Repository) Reconfigure(ctx context.Context, pluginConfig HCLPluginConfigMap) error {
+	pluginConfigs, err := catalog.PluginConfigFromHCL(pluginConfig)
+	if err != nil {
+		return err
+	}
+
+	return r.plugins.Reconfigure(ctx, pluginConfigs)
+}
+
+// CheckHealth probes each of the loaded plugins. See catalog.Catalog.
+func (r *Repository) CheckHealth(ctx context.Context) []catalog.PluginHealth {
+	return r.plugins.CheckHealth(ctx)
 }
 
 func Load(ctx context.Context, config Config) (*Repository, error) {
@@ -116,7 +140,7 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 	}
 
 	p := new(Plugins)
-	closer, err := catalog.Fill(ctx, catalog.Config{
+	cat, err := catalog.Fill(ctx, catalog.Config{
 		Log:           config.Log,
 		GlobalConfig:  config.GlobalConfig,
 		PluginConfig:  pluginConfig,
@@ -131,6 +155,7 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 
 	return &Repository{
 		Catalog: p,
-		Closer:  closer,
+		Closer:  cat,
+		plugins: cat,
 	}, nil
 }
@@ -0,0 +1,26 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/spiffe/spire/pkg/common/catalog"
+)
+
+// ValidatePlugins has each configured plugin validate its configuration, as
+// described in catalog.Validate.
+func ValidatePlugins(ctx context.Context, config Config) ([]catalog.PluginError, error) {
+	pluginConfigs, err := catalog.PluginConfigFromHCL(config.PluginConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return catalog.Validate(ctx, catalog.Config{
+		Log:           config.Log,
+		GlobalConfig:  config.GlobalConfig,
+		PluginConfig:  pluginConfigs,
+		KnownPlugins:  KnownPlugins(),
+		KnownServices: KnownServices(),
+		BuiltIns:      BuiltIns(),
+		HostServices:  config.HostServices,
+	}), nil
+}
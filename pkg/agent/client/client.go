@@ -36,6 +36,11 @@ type Client interface {
 	FetchUpdates(ctx context.Context, req *node.FetchX509SVIDRequest, forRotation bool) (*Update, error)
 	FetchJWTSVID(ctx context.Context, jsr *node.JSR) (*JWTSVID, error)
 
+	// SubscribeToSyncEvents calls onEvent every time the server signals that
+	// registration entries or bundles changed. It blocks until the context
+	// is canceled or the stream fails.
+	SubscribeToSyncEvents(ctx context.Context, onEvent func()) error
+
 	// Release releases any resources that were held by this Client, if any.
 	Release()
 }
@@ -51,6 +56,12 @@ type Config struct {
 
 	// RotMtx is used to prevent the creation of new connections during SVID rotations
 	RotMtx *sync.RWMutex
+
+	// Proxy optionally returns the HTTP proxy used to reach the server
+	Proxy ProxyFunc
+
+	// GRPC optionally tunes the connection to the server
+	GRPC GRPCConfig
 }
 
 type client struct {
@@ -112,6 +123,7 @@ func (c *client) FetchUpdates(ctx context.Context, req *node.FetchX509SVIDReques
 	regEntries := map[string]*common.RegistrationEntry{}
 	svids := map[string]*node.X509SVID{}
 	bundles := map[string]*common.Bundle{}
+	rotateAgentSVID := false
 	// Read all the server responses from the stream.
 	for {
 		resp, err := stream.Recv()
@@ -147,11 +159,19 @@ func (c *client) FetchUpdates(ctx context.Context, req *node.FetchX509SVIDReques
 		for trustDomainID, bundle := range resp.SvidUpdate.Bundles {
 			bundles[trustDomainID] = bundle
 		}
+		for entryID, reason := range resp.SvidUpdate.RejectedCsrs {
+			c.c.Log.WithFields(logrus.Fields{
+				telemetry.RegistrationID: entryID,
+				telemetry.Error:          reason,
+			}).Warn("SPIRE server rejected the CSR")
+		}
+		rotateAgentSVID = rotateAgentSVID || resp.SvidUpdate.RotateAgentSvid
 	}
 	return &Update{
-		Entries: regEntries,
-		SVIDs:   svids,
-		Bundles: bundles,
+		Entries:         regEntries,
+		SVIDs:           svids,
+		Bundles:         bundles,
+		RotateAgentSVID: rotateAgentSVID,
 	}, nil
 }
 
@@ -199,6 +219,48 @@ func (c *client) FetchJWTSVID(ctx context.Context, jsr *node.JSR) (*JWTSVID, err
 	}, nil
 }
 
+func (c *client) SubscribeToSyncEvents(ctx context.Context, onEvent func()) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, nodeConn, err := c.subscribeToSyncEvents(ctx)
+	if err != nil {
+		return err
+	}
+	defer nodeConn.Release()
+
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			c.release(nodeConn)
+			return err
+		}
+		onEvent()
+	}
+}
+
+// subscribeToSyncEvents opens the sync events stream. The rotation lock is
+// only held while the stream is opened since the stream is long lived.
+func (c *client) subscribeToSyncEvents(ctx context.Context) (node.Node_SubscribeToSyncEventsClient, *nodeConn, error) {
+	c.c.RotMtx.RLock()
+	defer c.c.RotMtx.RUnlock()
+
+	nodeClient, nodeConn, err := c.newNodeClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stream, err := nodeClient.SubscribeToSyncEvents(ctx, &node.SubscribeToSyncEventsRequest{})
+	if err != nil {
+		nodeConn.Release()
+		c.release(nodeConn)
+		return nil, nil, err
+	}
+	return stream, nodeConn, nil
+}
+
 // Release the underlying connection.
 func (c *client) Release() {
 	c.release(nil)
@@ -233,6 +295,8 @@ func (c *client) dial(ctx context.Context) (*grpc.ClientConn, error) {
 	return DialServer(ctx, DialServerConfig{
 		Address:     c.c.Addr,
 		TrustDomain: c.c.TrustDomain.Host,
+		Proxy:       c.c.Proxy,
+		GRPC:        c.c.GRPC,
 		GetBundle: func() []*x509.Certificate {
 			_, _, bundle := c.c.KeysAndBundle()
 			return bundle
@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
 	mock_node "github.com/spiffe/spire/test/mock/proto/api/node"
@@ -64,6 +66,7 @@ func TestFetchUpdates(t *testing.T) {
 	require.Nil(t, err)
 	assert.Equal(t, res.SvidUpdate.Bundles, update.Bundles)
 	assert.Equal(t, res.SvidUpdate.Svids, update.SVIDs)
+	assert.True(t, update.RotateAgentSVID)
 	// Only the first registration entry should be returned since the rest are
 	// invalid for one reason or another
 	if assert.Len(t, update.Entries, 1) {
@@ -116,6 +119,7 @@ func newTestFetchX509SVIDResponse() *node.FetchX509SVIDResponse {
 					CertChain: []byte{11, 22, 33},
 				},
 			},
+			RotateAgentSvid: true,
 			Bundles: map[string]*common.Bundle{
 				"spiffe://example.org": {
 					TrustDomainId: "spiffe://example.org",
@@ -128,6 +132,44 @@ func newTestFetchX509SVIDResponse() *node.FetchX509SVIDResponse {
 	}
 }
 
+func TestFetchUpdatesLogsRejectedCSRs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	nodeClient := mock_node.NewMockNodeClient(ctrl)
+	nodeFsc := mock_node.NewMockNode_FetchX509SVIDClient(ctrl)
+	client := createClient(nodeClient)
+	logger, logHook := test.NewNullLogger()
+	client.c.Log = logger
+
+	req := newTestFetchX509SVIDRequest()
+	res := &node.FetchX509SVIDResponse{
+		SvidUpdate: &node.X509SVIDUpdate{
+			RejectedCsrs: map[string]string{
+				"entry-id": "CSR rejected by the workload key policy",
+			},
+		},
+	}
+
+	nodeClient.EXPECT().FetchX509SVID(gomock.Any()).Return(nodeFsc, nil)
+	nodeFsc.EXPECT().Send(req)
+	nodeFsc.EXPECT().CloseSend()
+	nodeFsc.EXPECT().Recv().Return(res, nil)
+	nodeFsc.EXPECT().Recv().Return(nil, io.EOF)
+
+	update, err := client.FetchUpdates(context.Background(), req, false)
+	require.NoError(t, err)
+	assert.Empty(t, update.SVIDs)
+
+	entry := logHook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, logrus.WarnLevel, entry.Level)
+	assert.Equal(t, "SPIRE server rejected the CSR", entry.Message)
+	assert.Equal(t, logrus.Fields{
+		telemetry.RegistrationID: "entry-id",
+		telemetry.Error:          "CSR rejected by the workload key policy",
+	}, entry.Data)
+}
+
 func TestFetchReleaseWaitsForFetchUpdatesToFinish(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -246,6 +288,42 @@ func TestFetchUpdatesReleaseConnectionIfItFailsToReceiveResponse(t *testing.T) {
 	assertNodeConnIsNil(t, client)
 }
 
+func TestSubscribeToSyncEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	nodeClient := mock_node.NewMockNodeClient(ctrl)
+	stream := mock_node.NewMockNode_SubscribeToSyncEventsClient(ctrl)
+	gomock.InOrder(
+		stream.EXPECT().Recv().Return(&node.SyncEvent{EntriesChanged: true}, nil),
+		stream.EXPECT().Recv().Return(&node.SyncEvent{BundlesChanged: true}, nil),
+		stream.EXPECT().Recv().Return(nil, errors.New("an error")),
+	)
+	nodeClient.EXPECT().SubscribeToSyncEvents(gomock.Any(), &node.SubscribeToSyncEventsRequest{}).Return(stream, nil)
+	client := createClient(nodeClient)
+
+	events := 0
+	err := client.SubscribeToSyncEvents(context.Background(), func() {
+		events++
+	})
+	assert.EqualError(t, err, "an error")
+	assert.Equal(t, 2, events)
+	assertNodeConnIsNil(t, client)
+}
+
+func TestSubscribeToSyncEventsReleaseConnectionIfItFailsToSubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	nodeClient := mock_node.NewMockNodeClient(ctrl)
+	nodeClient.EXPECT().SubscribeToSyncEvents(gomock.Any(), gomock.Any()).Return(nil, errors.New("an error"))
+	client := createClient(nodeClient)
+
+	err := client.SubscribeToSyncEvents(context.Background(), func() {
+		assert.Fail(t, "no event expected")
+	})
+	assert.EqualError(t, err, "an error")
+	assertNodeConnIsNil(t, client)
+}
+
 func TestNewNodeClientFailsDial(t *testing.T) {
 	client := newClient(&Config{
 		KeysAndBundle: keysAndBundle,
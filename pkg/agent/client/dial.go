@@ -13,12 +13,65 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer/roundrobin"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 )
 
 const (
 	_defaultDialTimeout = 30 * time.Second
 )
 
+// GRPCConfig tunes the gRPC connections to the server. Zero values keep the
+// defaults.
+type GRPCConfig struct {
+	// DialTimeout is how long dialing the server may take. Defaults to 30
+	// seconds.
+	DialTimeout time.Duration
+
+	// KeepaliveTime is how long a connection may be idle before it is
+	// checked with a ping, e.g. to keep it open through load balancers
+	// closing idle connections. If zero, connections are not pinged.
+	KeepaliveTime time.Duration
+
+	// KeepaliveTimeout is how long to wait for a ping to be acknowledged
+	// before the connection is closed.
+	KeepaliveTimeout time.Duration
+
+	// KeepalivePermitWithoutStream allows pinging connections without calls
+	// in flight. Servers disconnect agents doing so unless they permit it
+	// too.
+	KeepalivePermitWithoutStream bool
+
+	// MaxRecvMsgSize and MaxSendMsgSize bound the size in bytes of the
+	// messages received from and sent to the server.
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+}
+
+// DialOptions returns the gRPC dial options applying the configuration,
+// except for the dial timeout.
+func (c GRPCConfig) DialOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+	if c.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                c.KeepaliveTime,
+			Timeout:             c.KeepaliveTimeout,
+			PermitWithoutStream: c.KeepalivePermitWithoutStream,
+		}))
+	}
+
+	var callOpts []grpc.CallOption
+	if c.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(c.MaxRecvMsgSize))
+	}
+	if c.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(c.MaxSendMsgSize))
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	return opts
+}
+
 type DialServerConfig struct {
 	// Address is the SPIRE server address
 	Address string
@@ -34,6 +87,13 @@ type DialServerConfig struct {
 	// certificate to present to the server during the TLS handshake.
 	GetAgentCertificate func() *tls.Certificate
 
+	// Proxy is an optional callback returning the HTTP proxy used to reach
+	// the server. If nil, the server is reached directly.
+	Proxy ProxyFunc
+
+	// GRPC optionally tunes the connection to the server
+	GRPC GRPCConfig
+
 	// dialContext is an optional constructor for the grpc client connection.
 	dialContext func(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error)
 }
@@ -75,18 +135,23 @@ func DialServer(ctx context.Context, config DialServerConfig) (*grpc.ClientConn,
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, _defaultDialTimeout)
+	dialTimeout := config.GRPC.DialTimeout
+	if dialTimeout <= 0 {
+		dialTimeout = _defaultDialTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
 	defer cancel()
 
 	if config.dialContext == nil {
 		config.dialContext = grpc.DialContext
 	}
-	client, err := config.dialContext(ctx, config.Address,
+	client, err := config.dialContext(ctx, config.Address, append([]grpc.DialOption{
 		grpc.WithBalancerName(roundrobin.Name), //nolint:staticcheck
 		grpc.FailOnNonTempDialError(true),
 		grpc.WithBlock(),
+		grpc.WithContextDialer(ProxyDialer(config.Proxy)),
 		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
-	)
+	}, config.GRPC.DialOptions()...)...)
 	switch {
 	case err == nil:
 	case errors.Is(err, context.Canceled):
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestDialServerAppliesGRPCConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		grpcConfig      GRPCConfig
+		expectTimeout   time.Duration
+		expectExtraOpts int
+	}{
+		{
+			name:          "defaults",
+			expectTimeout: _defaultDialTimeout,
+		},
+		{
+			name: "configured",
+			grpcConfig: GRPCConfig{
+				DialTimeout:                  time.Minute,
+				KeepaliveTime:                30 * time.Second,
+				KeepaliveTimeout:             10 * time.Second,
+				KeepalivePermitWithoutStream: true,
+				MaxRecvMsgSize:               1024,
+			},
+			expectTimeout:   time.Minute,
+			expectExtraOpts: 2,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var numOpts int
+			var timeout time.Duration
+			_, err := DialServer(context.Background(), DialServerConfig{
+				Address:     "spire-server:8081",
+				TrustDomain: "example.org",
+				GetBundle:   func() []*x509.Certificate { return nil },
+				GRPC:        tt.grpcConfig,
+				dialContext: func(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+					deadline, ok := ctx.Deadline()
+					require.True(t, ok)
+					timeout = time.Until(deadline)
+					numOpts = len(opts)
+					return nil, errors.New("oh no")
+				},
+			})
+			require.EqualError(t, err, "failed to dial spire-server:8081: oh no")
+			require.InDelta(t, tt.expectTimeout, timeout, float64(time.Second))
+			require.Equal(t, 5+tt.expectExtraOpts, numOpts)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyFunc returns the URL of the HTTP proxy used to connect to the server
+// at the given address, or nil if the server is reached directly.
+type ProxyFunc func(addr string) (*url.URL, error)
+
+// NewProxyFunc returns a ProxyFunc using the HTTP CONNECT proxy at proxyURL,
+// or the one in the HTTPS_PROXY environment variable if proxyURL is empty.
+// The hosts in the NO_PROXY environment variable are reached directly either
+// way, as are localhost and loopback addresses.
+func NewProxyFunc(proxyURL string) ProxyFunc {
+	config := httpproxy.FromEnvironment()
+	if proxyURL != "" {
+		config.HTTPSProxy = proxyURL
+	}
+	proxyFunc := config.ProxyFunc()
+	return func(addr string) (*url.URL, error) {
+		return proxyFunc(&url.URL{Scheme: "https", Host: addr})
+	}
+}
+
+// ProxyDialer returns a gRPC dialer tunneling connections through the proxy
+// returned by proxy, if any. A nil proxy connects directly.
+func ProxyDialer(proxy ProxyFunc) func(ctx context.Context, addr string) (net.Conn, error) {
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		var proxyURL *url.URL
+		if proxy != nil {
+			var err error
+			proxyURL, err = proxy(addr)
+			if err != nil {
+				return nil, fmt.Errorf("unable to determine proxy for %s: %v", addr, err)
+			}
+		}
+
+		if proxyURL == nil {
+			return new(net.Dialer).DialContext(ctx, "tcp", addr)
+		}
+		return dialThroughProxy(ctx, proxyURL, addr)
+	}
+}
+
+func dialThroughProxy(ctx context.Context, proxyURL *url.URL, addr string) (_ net.Conn, err error) {
+	if proxyURL.Scheme != "http" {
+		return nil, fmt.Errorf("unsupported proxy scheme %q", proxyURL.Scheme)
+	}
+
+	conn, err := new(net.Dialer).DialContext(ctx, "tcp", proxyURL.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial proxy %s: %v", proxyURL.Host, err)
+	}
+	defer func() {
+		if err != nil {
+			conn.Close()
+		}
+	}()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+		defer conn.SetDeadline(time.Time{}) //nolint: errcheck
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Host: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxyURL.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, fmt.Errorf("failed to send CONNECT request to proxy %s: %v", proxyURL.Host, err)
+	}
+
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CONNECT response from proxy %s: %v", proxyURL.Host, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("proxy %s refused to connect to %s: %s", proxyURL.Host, addr, resp.Status)
+	}
+
+	// The server may have already sent data following the CONNECT response
+	if r.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: r}, nil
+	}
+	return conn, nil
+}
+
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
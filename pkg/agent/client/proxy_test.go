@@ -0,0 +1,179 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProxyFunc(t *testing.T) {
+	defer setEnv(t, "HTTPS_PROXY", "http://env-proxy:3128")()
+	defer setEnv(t, "NO_PROXY", "direct.example.org")()
+
+	proxy := NewProxyFunc("")
+	proxyURL, err := proxy("spire-server:8081")
+	require.NoError(t, err)
+	require.Equal(t, "http://env-proxy:3128", proxyURL.String())
+
+	proxyURL, err = proxy("direct.example.org:8081")
+	require.NoError(t, err)
+	require.Nil(t, proxyURL)
+
+	proxyURL, err = proxy("127.0.0.1:8081")
+	require.NoError(t, err)
+	require.Nil(t, proxyURL)
+
+	// The explicit proxy takes precedence over HTTPS_PROXY
+	proxy = NewProxyFunc("http://proxy:3128")
+	proxyURL, err = proxy("spire-server:8081")
+	require.NoError(t, err)
+	require.Equal(t, "http://proxy:3128", proxyURL.String())
+
+	proxyURL, err = proxy("direct.example.org:8081")
+	require.NoError(t, err)
+	require.Nil(t, proxyURL)
+}
+
+func TestProxyDialerConnectsDirectly(t *testing.T) {
+	serverAddr := startEchoServer(t)
+
+	conn, err := ProxyDialer(nil)(context.Background(), serverAddr)
+	require.NoError(t, err)
+	defer conn.Close()
+	requireEcho(t, conn)
+}
+
+func TestProxyDialerConnectsThroughProxy(t *testing.T) {
+	serverAddr := startEchoServer(t)
+	proxyAddr, connects := startConnectProxy(t, "user:pass")
+
+	proxyURL := &url.URL{Scheme: "http", Host: proxyAddr, User: url.UserPassword("user", "pass")}
+	dial := ProxyDialer(func(addr string) (*url.URL, error) {
+		return proxyURL, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	conn, err := dial(ctx, serverAddr)
+	require.NoError(t, err)
+	defer conn.Close()
+	requireEcho(t, conn)
+	require.Equal(t, serverAddr, <-connects)
+}
+
+func TestProxyDialerFailsWhenProxyRefuses(t *testing.T) {
+	serverAddr := startEchoServer(t)
+	proxyAddr, _ := startConnectProxy(t, "user:pass")
+
+	proxyURL := &url.URL{Scheme: "http", Host: proxyAddr}
+	dial := ProxyDialer(func(addr string) (*url.URL, error) {
+		return proxyURL, nil
+	})
+
+	_, err := dial(context.Background(), serverAddr)
+	require.EqualError(t, err, "proxy "+proxyAddr+" refused to connect to "+serverAddr+": 407 Proxy Authentication Required")
+}
+
+func TestProxyDialerFailsWithUnsupportedScheme(t *testing.T) {
+	dial := ProxyDialer(func(addr string) (*url.URL, error) {
+		return &url.URL{Scheme: "socks5", Host: "proxy:1080"}, nil
+	})
+
+	_, err := dial(context.Background(), "spire-server:8081")
+	require.EqualError(t, err, `unsupported proxy scheme "socks5"`)
+}
+
+func setEnv(t *testing.T, key, value string) func() {
+	old, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	return func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func startEchoServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = io.Copy(conn, conn)
+	}()
+	return listener.Addr().String()
+}
+
+func requireEcho(t *testing.T, conn net.Conn) {
+	_, err := conn.Write([]byte("hello"))
+	require.NoError(t, err)
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(buf))
+}
+
+// startConnectProxy starts a proxy handling a single CONNECT request. The
+// addresses it connects to are sent on the returned channel.
+func startConnectProxy(t *testing.T, credentials string) (string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	connects := make(chan string, 1)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil {
+			return
+		}
+		if req.Method != http.MethodConnect {
+			_, _ = io.WriteString(conn, "HTTP/1.1 405 Method Not Allowed\r\n\r\n")
+			return
+		}
+		if username, password, ok := parseProxyAuthorization(req); !ok || username+":"+password != credentials {
+			_, _ = io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+			return
+		}
+
+		backend, err := net.Dial("tcp", req.Host)
+		if err != nil {
+			_, _ = io.WriteString(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+			return
+		}
+		defer backend.Close()
+		connects <- req.Host
+
+		_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+		go func() {
+			_, _ = io.Copy(backend, conn)
+		}()
+		_, _ = io.Copy(conn, backend)
+	}()
+	return listener.Addr().String(), connects
+}
+
+func parseProxyAuthorization(req *http.Request) (string, string, bool) {
+	// Reuse the basic auth parsing of the Authorization header
+	r := &http.Request{Header: http.Header{"Authorization": req.Header["Proxy-Authorization"]}}
+	return r.BasicAuth()
+}
@@ -0,0 +1,235 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+)
+
+// ServersScheme is the scheme of the dial targets resolving to a list of
+// SPIRE servers. See ServersTarget.
+const ServersScheme = "spire-servers"
+
+const (
+	// minResolveInterval rate limits the resolutions requested by gRPC when
+	// connections to the servers fail.
+	minResolveInterval = 5 * time.Second
+
+	lookupTimeout = 10 * time.Second
+)
+
+func init() {
+	resolver.Register(&serversResolverBuilder{
+		lookupHost:         net.DefaultResolver.LookupHost,
+		minResolveInterval: minResolveInterval,
+	})
+}
+
+// ServersTarget returns a dial target for the SPIRE servers at the given
+// "host:port" addresses. DNS names are resolved to all of their addresses,
+// and resolved again when connections fail and every resolveInterval, if not
+// zero. Dialed with the round robin balancer, calls are spread across the
+// reachable servers and fail over to the others when one is lost.
+func ServersTarget(addrs []string, resolveInterval time.Duration) string {
+	target := ServersScheme + ":///" + strings.Join(addrs, ",")
+	if resolveInterval > 0 {
+		target += "?resolve_interval=" + resolveInterval.String()
+	}
+	return target
+}
+
+// UnresolvedServersTarget returns a dial target for the SPIRE servers at the
+// given "host:port" addresses that does not resolve DNS names, e.g. so they
+// are resolved by the proxy the servers are reached through.
+func UnresolvedServersTarget(addrs []string) string {
+	return ServersScheme + ":///" + strings.Join(addrs, ",") + "?resolve=false"
+}
+
+type serversEndpoint struct {
+	addrs           []string
+	resolveInterval time.Duration
+	resolveNames    bool
+}
+
+func parseServersEndpoint(endpoint string) (*serversEndpoint, error) {
+	endpoint, rawQuery := endpoint, ""
+	if i := strings.IndexByte(endpoint, '?'); i >= 0 {
+		endpoint, rawQuery = endpoint[:i], endpoint[i+1:]
+	}
+
+	e := &serversEndpoint{
+		resolveNames: true,
+	}
+	for _, addr := range strings.Split(endpoint, ",") {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return nil, fmt.Errorf("invalid server address %q: %v", addr, err)
+		}
+		e.addrs = append(e.addrs, addr)
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, err
+	}
+	if v := query.Get("resolve_interval"); v != "" {
+		e.resolveInterval, err = time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resolve interval: %v", err)
+		}
+	}
+	if v := query.Get("resolve"); v != "" {
+		e.resolveNames, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resolve option: %v", err)
+		}
+	}
+	return e, nil
+}
+
+type serversResolverBuilder struct {
+	lookupHost         func(ctx context.Context, host string) ([]string, error)
+	minResolveInterval time.Duration
+}
+
+func (b *serversResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	e, err := parseServersEndpoint(target.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &serversResolver{
+		b:               b,
+		cc:              cc,
+		addrs:           e.addrs,
+		resolveInterval: e.resolveInterval,
+		resolveNames:    e.resolveNames,
+		known:           make(map[string][]string),
+		ctx:             ctx,
+		cancel:          cancel,
+		rn:              make(chan struct{}, 1),
+	}
+	r.wg.Add(1)
+	go r.watch()
+	return r, nil
+}
+
+func (b *serversResolverBuilder) Scheme() string {
+	return ServersScheme
+}
+
+type serversResolver struct {
+	b               *serversResolverBuilder
+	cc              resolver.ClientConn
+	addrs           []string
+	resolveInterval time.Duration
+	resolveNames    bool
+
+	// known holds the last addresses each DNS name resolved to, so servers
+	// are not dropped because of a transient lookup failure.
+	known map[string][]string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	rn     chan struct{}
+	wg     sync.WaitGroup
+}
+
+func (r *serversResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.rn <- struct{}{}:
+	default:
+	}
+}
+
+func (r *serversResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *serversResolver) watch() {
+	defer r.wg.Done()
+	for {
+		r.resolve()
+
+		// Don't let failing connections trigger resolutions too often
+		minTimer := time.NewTimer(r.b.minResolveInterval)
+		select {
+		case <-minTimer.C:
+		case <-r.ctx.Done():
+			minTimer.Stop()
+			return
+		}
+
+		if !r.waitForResolution() {
+			return
+		}
+	}
+}
+
+// waitForResolution blocks until gRPC requests a resolution or the resolve
+// interval elapses. It returns false if the resolver is closed.
+func (r *serversResolver) waitForResolution() bool {
+	var intervalCh <-chan time.Time
+	if r.resolveInterval > 0 {
+		intervalTimer := time.NewTimer(r.resolveInterval - r.b.minResolveInterval)
+		defer intervalTimer.Stop()
+		intervalCh = intervalTimer.C
+	}
+	select {
+	case <-r.rn:
+		return true
+	case <-intervalCh:
+		return true
+	case <-r.ctx.Done():
+		return false
+	}
+}
+
+func (r *serversResolver) resolve() {
+	var addrs []resolver.Address
+	var lookupErr error
+	seen := make(map[string]bool)
+	for _, addr := range r.addrs {
+		// addresses were validated when the resolver was built
+		host, port, _ := net.SplitHostPort(addr)
+
+		hosts := []string{host}
+		if r.resolveNames && net.ParseIP(host) == nil {
+			ctx, cancel := context.WithTimeout(r.ctx, lookupTimeout)
+			ips, err := r.b.lookupHost(ctx, host)
+			cancel()
+			if err == nil {
+				r.known[host] = ips
+			} else {
+				lookupErr = err
+			}
+			hosts = r.known[host]
+		}
+
+		for _, h := range hosts {
+			hostPort := net.JoinHostPort(h, port)
+			if seen[hostPort] {
+				continue
+			}
+			seen[hostPort] = true
+			addrs = append(addrs, resolver.Address{
+				Addr:       hostPort,
+				ServerName: host,
+			})
+		}
+	}
+
+	if len(addrs) == 0 {
+		r.cc.ReportError(fmt.Errorf("unable to resolve any server address: %v", lookupErr))
+		return
+	}
+	r.cc.UpdateState(resolver.State{Addresses: addrs})
+}
@@ -0,0 +1,210 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/resolver"
+)
+
+func TestServersTarget(t *testing.T) {
+	require.Equal(t, "spire-servers:///a:1,b:2", ServersTarget([]string{"a:1", "b:2"}, 0))
+	require.Equal(t, "spire-servers:///a:1?resolve_interval=30s", ServersTarget([]string{"a:1"}, 30*time.Second))
+	require.Equal(t, "spire-servers:///a:1,b:2?resolve=false", UnresolvedServersTarget([]string{"a:1", "b:2"}))
+}
+
+func TestParseServersEndpoint(t *testing.T) {
+	e, err := parseServersEndpoint("10.0.0.1:8081,[::1]:8081,spire-server:8081?resolve_interval=1m")
+	require.NoError(t, err)
+	require.Equal(t, &serversEndpoint{
+		addrs:           []string{"10.0.0.1:8081", "[::1]:8081", "spire-server:8081"},
+		resolveInterval: time.Minute,
+		resolveNames:    true,
+	}, e)
+
+	e, err = parseServersEndpoint("spire-server:8081?resolve=false")
+	require.NoError(t, err)
+	require.Equal(t, &serversEndpoint{
+		addrs: []string{"spire-server:8081"},
+	}, e)
+
+	_, err = parseServersEndpoint("10.0.0.1")
+	require.EqualError(t, err, `invalid server address "10.0.0.1": address 10.0.0.1: missing port in address`)
+
+	_, err = parseServersEndpoint("10.0.0.1:8081?resolve_interval=foo")
+	require.EqualError(t, err, `invalid resolve interval: time: invalid duration "foo"`)
+}
+
+func TestServersResolver(t *testing.T) {
+	lookup := newFakeLookup(map[string][]string{
+		"spire-server": {"10.0.0.2", "10.0.0.3"},
+	})
+	cc := newFakeClientConn()
+	r := buildServersResolver(t, lookup, cc, "10.0.0.1:8081,spire-server:8081,10.0.0.2:8081")
+	defer r.Close()
+
+	require.Equal(t, []resolver.Address{
+		{Addr: "10.0.0.1:8081", ServerName: "10.0.0.1"},
+		{Addr: "10.0.0.2:8081", ServerName: "spire-server"},
+		{Addr: "10.0.0.3:8081", ServerName: "spire-server"},
+	}, cc.nextState(t).Addresses)
+
+	// A server replica is replaced and gRPC requests a resolution
+	lookup.set("spire-server", []string{"10.0.0.3", "10.0.0.4"}, nil)
+	r.ResolveNow(resolver.ResolveNowOptions{})
+	require.Equal(t, []resolver.Address{
+		{Addr: "10.0.0.1:8081", ServerName: "10.0.0.1"},
+		{Addr: "10.0.0.3:8081", ServerName: "spire-server"},
+		{Addr: "10.0.0.4:8081", ServerName: "spire-server"},
+		{Addr: "10.0.0.2:8081", ServerName: "10.0.0.2"},
+	}, cc.nextState(t).Addresses)
+
+	// Failed lookups keep the last known addresses
+	lookup.set("spire-server", nil, errors.New("oh no"))
+	r.ResolveNow(resolver.ResolveNowOptions{})
+	require.Equal(t, []resolver.Address{
+		{Addr: "10.0.0.1:8081", ServerName: "10.0.0.1"},
+		{Addr: "10.0.0.3:8081", ServerName: "spire-server"},
+		{Addr: "10.0.0.4:8081", ServerName: "spire-server"},
+		{Addr: "10.0.0.2:8081", ServerName: "10.0.0.2"},
+	}, cc.nextState(t).Addresses)
+}
+
+func TestServersResolverResolveInterval(t *testing.T) {
+	lookup := newFakeLookup(map[string][]string{
+		"spire-server": {"10.0.0.1"},
+	})
+	cc := newFakeClientConn()
+	r := buildServersResolver(t, lookup, cc, "spire-server:8081?resolve_interval=10ms")
+	defer r.Close()
+
+	require.Equal(t, []resolver.Address{
+		{Addr: "10.0.0.1:8081", ServerName: "spire-server"},
+	}, cc.nextState(t).Addresses)
+
+	// Resolved again without gRPC requesting it
+	lookup.set("spire-server", []string{"10.0.0.2"}, nil)
+	cc.waitForAddresses(t, []resolver.Address{
+		{Addr: "10.0.0.2:8081", ServerName: "spire-server"},
+	})
+}
+
+func TestServersResolverWithoutResolvingNames(t *testing.T) {
+	lookup := newFakeLookup(nil)
+	lookup.set("spire-server", nil, errors.New("should not be looked up"))
+	cc := newFakeClientConn()
+	r := buildServersResolver(t, lookup, cc, "spire-server:8081,10.0.0.1:8081?resolve=false")
+	defer r.Close()
+
+	require.Equal(t, []resolver.Address{
+		{Addr: "spire-server:8081", ServerName: "spire-server"},
+		{Addr: "10.0.0.1:8081", ServerName: "10.0.0.1"},
+	}, cc.nextState(t).Addresses)
+}
+
+func TestServersResolverReportsErrorWhenNothingResolves(t *testing.T) {
+	lookup := newFakeLookup(nil)
+	lookup.set("spire-server", nil, errors.New("oh no"))
+	cc := newFakeClientConn()
+	r := buildServersResolver(t, lookup, cc, "spire-server:8081")
+	defer r.Close()
+
+	select {
+	case err := <-cc.errs:
+		require.EqualError(t, err, "unable to resolve any server address: oh no")
+	case <-time.After(time.Minute):
+		require.FailNow(t, "timed out waiting for error")
+	}
+}
+
+func buildServersResolver(t *testing.T, lookup *fakeLookup, cc resolver.ClientConn, endpoint string) resolver.Resolver {
+	b := &serversResolverBuilder{
+		lookupHost:         lookup.LookupHost,
+		minResolveInterval: time.Millisecond,
+	}
+	r, err := b.Build(resolver.Target{Scheme: ServersScheme, Endpoint: endpoint}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	return r
+}
+
+type fakeLookup struct {
+	mu    sync.Mutex
+	hosts map[string][]string
+	errs  map[string]error
+}
+
+func newFakeLookup(hosts map[string][]string) *fakeLookup {
+	if hosts == nil {
+		hosts = make(map[string][]string)
+	}
+	return &fakeLookup{
+		hosts: hosts,
+		errs:  make(map[string]error),
+	}
+}
+
+func (l *fakeLookup) set(host string, addrs []string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hosts[host] = addrs
+	l.errs[host] = err
+}
+
+func (l *fakeLookup) LookupHost(ctx context.Context, host string) ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.errs[host]; err != nil {
+		return nil, err
+	}
+	return l.hosts[host], nil
+}
+
+type fakeClientConn struct {
+	resolver.ClientConn
+	states chan resolver.State
+	errs   chan error
+}
+
+func newFakeClientConn() *fakeClientConn {
+	return &fakeClientConn{
+		states: make(chan resolver.State, 100),
+		errs:   make(chan error, 100),
+	}
+}
+
+func (cc *fakeClientConn) UpdateState(state resolver.State) {
+	cc.states <- state
+}
+
+func (cc *fakeClientConn) ReportError(err error) {
+	cc.errs <- err
+}
+
+func (cc *fakeClientConn) nextState(t *testing.T) resolver.State {
+	select {
+	case state := <-cc.states:
+		return state
+	case <-time.After(time.Minute):
+		require.FailNow(t, "timed out waiting for state")
+		return resolver.State{}
+	}
+}
+
+func (cc *fakeClientConn) waitForAddresses(t *testing.T, expected []resolver.Address) {
+	timeout := time.After(time.Minute)
+	for {
+		select {
+		case state := <-cc.states:
+			if reflect.DeepEqual(expected, state.Addresses) {
+				return
+			}
+		case <-timeout:
+			require.FailNow(t, "timed out waiting for addresses")
+		}
+	}
+}
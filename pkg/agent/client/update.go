@@ -12,6 +12,10 @@ type Update struct {
 	Entries map[string]*common.RegistrationEntry
 	SVIDs   map[string]*node.X509SVID
 	Bundles map[string]*common.Bundle
+
+	// RotateAgentSVID is true if the server asked the agent to rotate its
+	// SVID
+	RotateAgentSVID bool
 }
 
 func (u *Update) String() string {
@@ -18,16 +18,54 @@ const (
 	_noMaxElapsedTime    = 0
 )
 
+// Config tunes the growth of the backoffs. Zero values mean the defaults.
+type Config struct {
+	// MaxInterval caps the interval between attempts. Defaults to 24 times
+	// the initial interval.
+	MaxInterval time.Duration
+
+	// Multiplier is the factor by which the interval grows after each
+	// failed attempt. Defaults to 1.5.
+	Multiplier float64
+
+	// Jitter is the fraction of the interval by which each interval is
+	// randomized, so that clients failing together do not retry in
+	// lockstep. Defaults to 0.1.
+	Jitter float64
+}
+
 // NewBackoff returns a new backoff calculator ready for use. Generalizes all backoffs
 // to have the same behavioral pattern, though with different bounds based on given
 // interval.
 func NewBackoff(clk clock.Clock, interval time.Duration) BackOff {
+	return NewBackoffWithConfig(clk, interval, Config{})
+}
+
+// NewBackoffWithConfig is like NewBackoff, with the growth of the backoff
+// tuned by config.
+func NewBackoffWithConfig(clk clock.Clock, interval time.Duration, config Config) BackOff {
+	jitter := config.Jitter
+	if jitter == 0 {
+		jitter = _jitter
+	}
+	multiplier := config.Multiplier
+	if multiplier == 0 {
+		multiplier = _backoffMultiplier
+	}
+	maxInterval := config.MaxInterval
+	if maxInterval == 0 {
+		maxInterval = _maxIntervalMultiple * interval
+	}
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+
 	b := &backoff.ExponentialBackOff{
 		Clock:               clk,
 		InitialInterval:     interval,
-		RandomizationFactor: _jitter,
-		Multiplier:          _backoffMultiplier,
-		MaxInterval:         _maxIntervalMultiple * interval,
+		RandomizationFactor: jitter,
+		Multiplier:          multiplier,
+		MaxInterval:         maxInterval,
 		MaxElapsedTime:      _noMaxElapsedTime,
 	}
 	b.Reset()
@@ -38,3 +38,27 @@ func inRange(t *testing.T, expected time.Duration, b BackOff) {
 		t.Error("error")
 	}
 }
+
+func TestBackOffWithConfig(t *testing.T) {
+	mockClk := clock.NewMock(t)
+	b := NewBackoffWithConfig(mockClk, time.Second, Config{
+		MaxInterval: 5 * time.Second,
+		Multiplier:  2,
+		Jitter:      0.5,
+	})
+
+	expectedResults := []time.Duration{1, 2, 4, 5, 5}
+	for _, d := range expectedResults {
+		expected := d * time.Second
+		actual := b.NextBackOff()
+		if actual < expected/2 || actual > expected*3/2 {
+			t.Errorf("expected backoff within 50%% of %s; got %s", expected, actual)
+		}
+		mockClk.Add(expected)
+	}
+
+	// the maximum interval is never below the initial interval
+	b = NewBackoffWithConfig(mockClk, 10*time.Second, Config{MaxInterval: time.Second})
+	b.NextBackOff()
+	inRange(t, 10*time.Second, b)
+}
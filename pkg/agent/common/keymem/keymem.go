@@ -0,0 +1,32 @@
+// Package keymem provides helpers to limit the exposure of private key
+// material held in agent memory.
+package keymem
+
+import (
+	"crypto/ecdsa"
+	"errors"
+)
+
+// ErrUnsupportedPlatform is returned by LockMemory on platforms where memory
+// locking is not implemented.
+var ErrUnsupportedPlatform = errors.New("memory locking is not supported on this platform")
+
+// Wipe overwrites b with zeros.
+func Wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// WipeECKey overwrites the private scalar of key with zeros. The key is
+// unusable afterwards.
+func WipeECKey(key *ecdsa.PrivateKey) {
+	if key == nil || key.D == nil {
+		return
+	}
+	words := key.D.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+	key.D.SetInt64(0)
+}
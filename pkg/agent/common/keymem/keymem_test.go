@@ -0,0 +1,36 @@
+package keymem
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWipe(t *testing.T) {
+	b := []byte("secret")
+	Wipe(b)
+	assert.Equal(t, make([]byte, 6), b)
+
+	// must not panic
+	Wipe(nil)
+}
+
+func TestWipeECKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	words := key.D.Bits()
+	WipeECKey(key)
+	assert.Equal(t, 0, key.D.Sign())
+	for _, word := range words {
+		assert.Zero(t, word)
+	}
+
+	// must not panic
+	WipeECKey(nil)
+	WipeECKey(&ecdsa.PrivateKey{})
+}
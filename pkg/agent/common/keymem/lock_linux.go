@@ -0,0 +1,12 @@
+package keymem
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// LockMemory locks all current and future pages of the process in memory,
+// preventing private keys from being written to swap. It requires the
+// CAP_IPC_LOCK capability or a sufficient RLIMIT_MEMLOCK.
+func LockMemory() error {
+	return unix.Mlockall(unix.MCL_CURRENT | unix.MCL_FUTURE)
+}
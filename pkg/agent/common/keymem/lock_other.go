@@ -0,0 +1,8 @@
+// +build !linux
+
+package keymem
+
+// LockMemory is not supported on this platform.
+func LockMemory() error {
+	return ErrUnsupportedPlatform
+}
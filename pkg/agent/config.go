@@ -7,6 +7,10 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/common/backoff"
+	"github.com/spiffe/spire/pkg/agent/endpoints"
+	"github.com/spiffe/spire/pkg/agent/endpoints/workload"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/telemetry"
@@ -16,6 +20,50 @@ type Config struct {
 	// Address to bind the workload api to
 	BindAddress *net.UnixAddr
 
+	// Additional UDS to bind the workload api to
+	AdditionalBindAddresses []endpoints.UDSConfig
+
+	// Loopback TCP address to bind the workload api to, in addition to
+	// BindAddress
+	TCPBindAddress *net.TCPAddr
+
+	// Name of the named pipe to bind the workload api to. Only supported on
+	// Windows. If set, it is used instead of BindAddress.
+	NamedPipeName string
+
+	// If true, workload API callers are tracked with pidfds, protecting
+	// workload attestation against PID reuse and callers executing a
+	// different binary. Only supported on Linux 5.3+.
+	PIDFDPeerTracking bool
+
+	// If true, the cached entries, SVIDs and bundles are persisted to the
+	// data directory so workloads can be served right after a restart, even
+	// if the server is unreachable.
+	CachePersistence bool
+
+	// If true, server outages are reported through logs and metrics while
+	// cached SVIDs keep being served past their rotation threshold, up to
+	// their expiration.
+	RetainSVIDsDuringOutage bool
+
+	// If true, the agent memory is locked to keep it from being swapped out,
+	// and superseded private keys are wiped from memory after rotation.
+	HardenKeyMemory bool
+
+	// SVIDRenewalThreshold is the fraction of the lifetime of the agent SVID
+	// and of workload SVIDs after which they are renewed. Zero means the
+	// manager default.
+	SVIDRenewalThreshold float64
+
+	// JWTSVIDCacheMaxSize is the maximum number of JWT-SVIDs cached, across
+	// all SPIFFE IDs and audiences. Zero means the manager default.
+	JWTSVIDCacheMaxSize int
+
+	// DrainGracePeriod is how long calls in flight, like workload API
+	// streams, are kept alive once a drain is requested, before the agent
+	// exits.
+	DrainGracePeriod time.Duration
+
 	// Directory to store runtime data
 	DataDir string
 
@@ -25,9 +73,32 @@ type Config struct {
 	// The TLS Certificate resource name to use for the default X509-SVID with Envoy SDS
 	DefaultSVIDName string
 
+	// Per-caller rate limits for the workload api
+	WorkloadAPIRateLimits workload.RateLimits
+
+	// Allow workloads without an identity to fetch trust bundles
+	AllowUnauthenticatedVerifiers bool
+
+	// Custom TLS Certificate resource names to use with Envoy SDS, keyed by SPIFFE ID
+	SDSSVIDNames map[string]string
+
+	// Custom Validation Context resource names to use with Envoy SDS, keyed by trust domain ID
+	SDSBundleNames map[string]string
+
 	// If true, the agent will bootstrap insecurely with the server
 	InsecureBootstrap bool
 
+	// If true, the agent is allowed to bootstrap insecurely again after a
+	// previous insecure bootstrap has completed
+	ReenableInsecureBootstrap bool
+
+	// If true, the Registration API is proxied to the server over the
+	// workload API socket for workloads entitled to an admin identity
+	EnableRegistrationAPIProxy bool
+
+	// If true, server reflection is served on the workload API sockets
+	WorkloadAPIReflectionEnabled bool
+
 	// HealthChecks provides the configuration for health monitoring
 	HealthChecks health.Config
 
@@ -39,6 +110,20 @@ type Config struct {
 	// Address of SPIRE server
 	ServerAddress string
 
+	// ServerProxy returns the HTTP proxy used to reach the SPIRE server, if any
+	ServerProxy client.ProxyFunc
+
+	// ServerGRPC tunes the gRPC connections to the SPIRE server
+	ServerGRPC client.GRPCConfig
+
+	// ServerBackoff tunes the exponential backoff between retries of the
+	// synchronization with and the attestation to the SPIRE server
+	ServerBackoff backoff.Config
+
+	// AttestationRetryInterval is how long the agent initially waits before
+	// retrying the attestation when the SPIRE server is unavailable
+	AttestationRetryInterval time.Duration
+
 	// SyncInterval controls how often the agent sync synchronizer waits
 	SyncInterval time.Duration
 
@@ -55,6 +140,10 @@ type Config struct {
 	// Port used by the pprof web server when ProfilingEnabled == true
 	ProfilingPort int
 
+	// Path of the unix socket the pprof web server listens on when
+	// ProfilingEnabled == true. It takes the place of ProfilingPort.
+	ProfilingSocketPath string
+
 	// Frequency in seconds by which each profile file will be generated.
 	ProfilingFreq int
 
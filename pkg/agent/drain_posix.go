@@ -0,0 +1,12 @@
+// +build !windows
+
+package agent
+
+import (
+	"os"
+	"syscall"
+)
+
+// drainSignals are the signals that make the agent drain the workload API
+// and exit
+var drainSignals = []os.Signal{syscall.SIGUSR1}
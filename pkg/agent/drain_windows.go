@@ -0,0 +1,11 @@
+// +build windows
+
+package agent
+
+import (
+	"os"
+)
+
+// drainSignals are the signals that make the agent drain the workload API
+// and exit. There is no suitable signal on Windows.
+var drainSignals []os.Signal
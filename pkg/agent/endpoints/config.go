@@ -2,21 +2,69 @@ package endpoints
 
 import (
 	"net"
+	"net/url"
+	"os"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/endpoints/workload"
 	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 
 	"google.golang.org/grpc"
 )
 
+const (
+	defaultDrainGracePeriod = 30 * time.Second
+)
+
 type Config struct {
 	BindAddr *net.UnixAddr
 
+	// AdditionalBindAddrs are additional UDS the workload API is served on,
+	// each with its own ownership and permissions.
+	AdditionalBindAddrs []UDSConfig
+
+	// TCPBindAddr is an optional loopback TCP address to serve the workload
+	// API on, for workloads that cannot use UDS.
+	TCPBindAddr *net.TCPAddr
+
+	// NamedPipeName is the name of the named pipe to listen on instead of
+	// BindAddr. Only supported on Windows.
+	NamedPipeName string
+
+	// PIDFDPeerTracking enables pidfd based tracking of workload API
+	// callers. Only supported on Linux.
+	PIDFDPeerTracking bool
+
+	// WorkloadAPIRateLimits holds the per-caller rate limits for the
+	// workload API
+	WorkloadAPIRateLimits workload.RateLimits
+
+	// AllowUnauthenticatedVerifiers allows workloads without an identity to
+	// fetch trust bundles through the workload API
+	AllowUnauthenticatedVerifiers bool
+
+	// Drain, when closed, makes the workload API reject new calls. Calls in
+	// flight are given DrainGracePeriod to complete before the endpoints
+	// are stopped and ListenAndServe returns ErrDrained.
+	Drain            <-chan struct{}
+	DrainGracePeriod time.Duration
+
 	GRPCHook func(*grpc.Server) error
 
+	// HealthChecks, if set, has its subsystem checks published through the
+	// gRPC health service of the workload API (optional)
+	HealthChecks *health.Checker
+
+	// ReflectionEnabled serves server reflection on the workload API
+	// (optional)
+	ReflectionEnabled bool
+
 	Catalog catalog.Catalog
 	Manager manager.Manager
 
@@ -28,13 +76,58 @@ type Config struct {
 
 	// The Validation Context resource name to use for the default X.509 bundle with Envoy SDS
 	DefaultBundleName string
+
+	// Custom TLS Certificate resource names to use with Envoy SDS, keyed by SPIFFE ID
+	SDSSVIDNames map[string]string
+
+	// Custom Validation Context resource names to use with Envoy SDS, keyed by trust domain ID
+	SDSBundleNames map[string]string
+
+	// If true, the Registration API is proxied to the server for callers
+	// entitled to an admin identity
+	EnableRegistrationAPIProxy bool
+
+	// Address of SPIRE server, used to proxy the Registration API
+	ServerAddress string
+
+	// ServerProxy optionally returns the HTTP proxy used to reach the server
+	ServerProxy client.ProxyFunc
+
+	// ServerGRPC optionally tunes the connection to the server
+	ServerGRPC client.GRPCConfig
+
+	// Trust domain of the agent, used to authenticate the server
+	TrustDomain url.URL
+}
+
+// UDSConfig configures a UDS the workload API is served on.
+type UDSConfig struct {
+	Addr *net.UnixAddr
+
+	// UID and GID of the owner of the socket. A value of -1 leaves the
+	// corresponding owner unchanged.
+	UID int
+	GID int
+
+	// Mode holds the permissions of the socket.
+	Mode os.FileMode
 }
 
 func New(c *Config) *Endpoints {
+	unixListener := &peertracker.ListenerFactory{
+		Log: c.Log,
+	}
+	if c.PIDFDPeerTracking {
+		unixListener.NewTracker = peertracker.NewPIDFDTracker
+	}
+
+	if c.DrainGracePeriod == 0 {
+		c.DrainGracePeriod = defaultDrainGracePeriod
+	}
+
 	return &Endpoints{
-		c: c,
-		unixListener: &peertracker.ListenerFactory{
-			Log: c.Log,
-		},
+		c:            c,
+		unixListener: unixListener,
+		drainer:      new(drainer),
 	}
 }
@@ -0,0 +1,47 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrDrained is returned by ListenAndServe once the endpoints have been
+// drained and stopped.
+var ErrDrained = errors.New("workload API drained")
+
+// drainer rejects new calls once draining has started, while calls already
+// in flight (e.g. workload API streams) are left alone.
+type drainer struct {
+	draining int32
+}
+
+func (d *drainer) start() {
+	atomic.StoreInt32(&d.draining, 1)
+}
+
+func (d *drainer) isDraining() bool {
+	return atomic.LoadInt32(&d.draining) == 1
+}
+
+func (d *drainer) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if d.isDraining() {
+		return nil, errDraining()
+	}
+	return handler(ctx, req)
+}
+
+func (d *drainer) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if d.isDraining() {
+		return errDraining()
+	}
+	return handler(srv, ss)
+}
+
+func errDraining() error {
+	return status.Error(codes.Unavailable, "agent is draining")
+}
@@ -0,0 +1,95 @@
+package endpoints
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestDrain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-agent-endpoints-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log, _ := test.NewNullLogger()
+	drain := make(chan struct{})
+	socketPath := filepath.Join(dir, "agent.sock")
+	e := New(&Config{
+		BindAddr:         &net.UnixAddr{Net: "unix", Name: socketPath},
+		Log:              log,
+		Metrics:          fakemetrics.New(),
+		Drain:            drain,
+		DrainGracePeriod: time.Second,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- e.ListenAndServe(ctx)
+	}()
+
+	conn, err := grpc.DialContext(ctx, "unix://"+socketPath, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	defer conn.Close()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+
+	// open a stream that must survive the start of the drain
+	stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+
+	close(drain)
+
+	// the existing stream is told the agent is no longer serving
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, resp.Status)
+
+	// new calls are rejected, unary or streaming
+	require.Eventually(t, func() bool {
+		_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		return status.Code(err) == codes.Unavailable
+	}, time.Second, 10*time.Millisecond)
+	newStream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = newStream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Contains(t, err.Error(), "agent is draining")
+
+	// the existing stream is kept alive until the grace period elapses
+	select {
+	case err := <-errCh:
+		require.Fail(t, "endpoints stopped before the grace period elapsed", "err=%v", err)
+	default:
+	}
+
+	select {
+	case err := <-errCh:
+		assert.Equal(t, ErrDrained, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "endpoints were not stopped after the grace period")
+	}
+
+	_, err = stream.Recv()
+	assert.Error(t, err)
+}
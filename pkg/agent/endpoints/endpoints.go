@@ -2,16 +2,26 @@ package endpoints
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
 	"os"
+	"time"
 
 	sds_v2 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
 	attestor "github.com/spiffe/spire/pkg/agent/attestor/workload"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/endpoints/registration"
 	"github.com/spiffe/spire/pkg/agent/endpoints/sds"
 	"github.com/spiffe/spire/pkg/agent/endpoints/workload"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/pkg/common/systemd"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/util"
+	registration_pb "github.com/spiffe/spire/proto/spire/api/registration"
+	spire_workload_pb "github.com/spiffe/spire/proto/spire/api/workload"
 
 	"google.golang.org/grpc"
 
@@ -25,42 +35,106 @@ type Server interface {
 type Endpoints struct {
 	c            *Config
 	unixListener *peertracker.ListenerFactory
+	drainer      *drainer
 }
 
 func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	server := grpc.NewServer(
 		grpc.Creds(peertracker.NewCredentials()),
+		grpc.UnaryInterceptor(e.drainer.unaryInterceptor),
+		grpc.StreamInterceptor(e.drainer.streamInterceptor),
 	)
 
 	e.registerWorkloadAPI(server)
 	e.registerSecretDiscoveryService(server)
+	if e.c.EnableRegistrationAPIProxy {
+		e.registerRegistrationAPIProxy(server)
+	}
 
-	l, err := e.createUDSListener()
+	l, err := e.createListener()
 	if err != nil {
 		return err
 	}
 	defer l.Close()
 
+	listeners := []net.Listener{l}
+	for _, uds := range e.c.AdditionalBindAddrs {
+		l, err := e.listenUDS(uds)
+		if err != nil {
+			return err
+		}
+		defer l.Close()
+		listeners = append(listeners, l)
+	}
+
+	if e.c.TCPBindAddr != nil {
+		l, err := e.listenTCP(e.c.TCPBindAddr)
+		if err != nil {
+			return err
+		}
+		defer l.Close()
+		e.c.Log.WithField(telemetry.Address, l.Addr().String()).Warn("Serving the workload API over TCP; any local process able to connect can request its identities")
+		listeners = append(listeners, l)
+	}
+
 	if e.c.GRPCHook != nil {
 		err = e.c.GRPCHook(server)
 		if err != nil {
 			return fmt.Errorf("call grpc hook: %v", err)
 		}
 	}
+	healthServer := util.RegisterHealth(server, e.c.ReflectionEnabled)
+	if e.c.HealthChecks != nil {
+		e.c.HealthChecks.Publish(healthServer)
+	}
 
 	e.c.Log.Info("Starting workload API")
-	errChan := make(chan error)
-	go func() { errChan <- server.Serve(l) }()
+	errChan := make(chan error, len(listeners))
+	for _, l := range listeners {
+		l := l
+		go func() { errChan <- server.Serve(l) }()
+	}
 
 	select {
 	case err = <-errChan:
+		server.Stop()
 		return err
 	case <-ctx.Done():
 		e.c.Log.Info("Stopping workload API")
 		server.Stop()
-		<-errChan
+		for range listeners {
+			<-errChan
+		}
 		return nil
+	case <-e.c.Drain:
+		healthServer.Shutdown()
+		return e.drain(ctx, server, errChan, len(listeners))
+	}
+}
+
+// drain rejects new calls, gives the calls in flight the grace period to
+// complete, and then stops the server.
+func (e *Endpoints) drain(ctx context.Context, server *grpc.Server, errChan chan error, listeners int) error {
+	e.c.Log.WithField(telemetry.GracePeriod, e.c.DrainGracePeriod).Info("Draining workload API")
+	e.drainer.start()
+
+	timer := time.NewTimer(e.c.DrainGracePeriod)
+	defer timer.Stop()
+
+	select {
+	case err := <-errChan:
+		server.Stop()
+		return err
+	case <-ctx.Done():
+	case <-timer.C:
 	}
+
+	e.c.Log.Info("Stopping workload API")
+	server.Stop()
+	for i := 0; i < listeners; i++ {
+		<-errChan
+	}
+	return ErrDrained
 }
 
 func (e *Endpoints) registerWorkloadAPI(server *grpc.Server) {
@@ -69,9 +143,13 @@ func (e *Endpoints) registerWorkloadAPI(server *grpc.Server) {
 		Catalog: e.c.Catalog,
 		Log:     e.c.Log.WithField(telemetry.SubsystemName, telemetry.WorkloadAPI),
 		Metrics: e.c.Metrics,
+
+		RateLimits:                    e.c.WorkloadAPIRateLimits,
+		AllowUnauthenticatedVerifiers: e.c.AllowUnauthenticatedVerifiers,
 	}
 
 	workload_pb.RegisterSpiffeWorkloadAPIServer(server, w)
+	spire_workload_pb.RegisterBundlesServer(server, w)
 }
 
 func (e *Endpoints) registerSecretDiscoveryService(server *grpc.Server) {
@@ -88,20 +166,136 @@ func (e *Endpoints) registerSecretDiscoveryService(server *grpc.Server) {
 		Metrics:           e.c.Metrics,
 		DefaultSVIDName:   e.c.DefaultSVIDName,
 		DefaultBundleName: e.c.DefaultBundleName,
+		SVIDNames:         e.c.SDSSVIDNames,
+		BundleNames:       e.c.SDSBundleNames,
 	})
 	sds_v2.RegisterSecretDiscoveryServiceServer(server, h)
 }
 
+func (e *Endpoints) registerRegistrationAPIProxy(server *grpc.Server) {
+	attestor := attestor.New(&attestor.Config{
+		Catalog: e.c.Catalog,
+		Log:     e.c.Log,
+		Metrics: e.c.Metrics,
+	})
+
+	h := registration.NewHandler(registration.HandlerConfig{
+		Attestor: attestor,
+		Manager:  e.c.Manager,
+		Log:      e.c.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationAPI),
+		Dial:     e.dialServerAs,
+	})
+	registration_pb.RegisterRegistrationServer(server, h)
+}
+
+// dialServerAs dials the server presenting the X509-SVID of the given identity
+func (e *Endpoints) dialServerAs(ctx context.Context, identity cache.Identity) (*grpc.ClientConn, error) {
+	cert := &tls.Certificate{
+		PrivateKey: identity.PrivateKey,
+	}
+	for _, c := range identity.SVID {
+		cert.Certificate = append(cert.Certificate, c.Raw)
+	}
+
+	trustDomainID := e.c.TrustDomain.String()
+	bundles := e.c.Manager.SubscribeToBundleChanges()
+	return client.DialServer(ctx, client.DialServerConfig{
+		Address:     e.c.ServerAddress,
+		TrustDomain: e.c.TrustDomain.Host,
+		Proxy:       e.c.ServerProxy,
+		GRPC:        e.c.ServerGRPC,
+		GetBundle: func() []*x509.Certificate {
+			if bundle, ok := bundles.Value()[trustDomainID]; ok {
+				return bundle.RootCAs()
+			}
+			return nil
+		},
+		GetAgentCertificate: func() *tls.Certificate {
+			return cert
+		},
+	})
+}
+
+func (e *Endpoints) createListener() (net.Listener, error) {
+	if e.c.NamedPipeName != "" {
+		return e.createPipeListener()
+	}
+	return e.createUDSListener()
+}
+
 func (e *Endpoints) createUDSListener() (net.Listener, error) {
+	return e.listenUDS(UDSConfig{
+		Addr: e.c.BindAddr,
+		UID:  -1,
+		GID:  -1,
+		Mode: os.ModePerm,
+	})
+}
+
+func (e *Endpoints) listenTCP(addr *net.TCPAddr) (net.Listener, error) {
+	inherited, err := systemd.InheritedListener(addr)
+	if err != nil {
+		return nil, fmt.Errorf("inherit TCP listener: %v", err)
+	}
+	if inherited != nil {
+		l, err := e.unixListener.TrackListener(inherited)
+		if err != nil {
+			inherited.Close()
+			return nil, fmt.Errorf("create TCP listener: %v", err)
+		}
+		e.c.Log.WithField(telemetry.Address, addr.String()).Info("Using workload API TCP socket inherited from systemd")
+		return l, nil
+	}
+
+	l, err := e.unixListener.ListenTCP(addr.Network(), addr)
+	if err != nil {
+		return nil, fmt.Errorf("create TCP listener: %v", err)
+	}
+	return l, nil
+}
+
+func (e *Endpoints) listenUDS(uds UDSConfig) (net.Listener, error) {
+	// Use the socket passed by systemd socket activation, if any. Its
+	// ownership and permissions are managed by the socket unit.
+	inherited, err := systemd.InheritedListener(uds.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("inherit UDS listener: %v", err)
+	}
+	if inherited != nil {
+		l, err := e.unixListener.TrackListener(inherited)
+		if err != nil {
+			inherited.Close()
+			return nil, fmt.Errorf("create UDS listener: %s", err)
+		}
+		e.c.Log.WithField(telemetry.Address, uds.Addr.String()).Info("Using workload API socket inherited from systemd")
+		return l, nil
+	}
+
+	// Abstract sockets have no file to clean up, own or restrict access to
+	abstract := util.IsAbstractUDS(uds.Addr.String())
+
 	// Remove uds if already exists
-	os.Remove(e.c.BindAddr.String())
+	if !abstract {
+		os.Remove(uds.Addr.String())
+	}
 
-	l, err := e.unixListener.ListenUnix(e.c.BindAddr.Network(), e.c.BindAddr)
+	l, err := e.unixListener.ListenUnix(uds.Addr.Network(), uds.Addr)
 	if err != nil {
 		return nil, fmt.Errorf("create UDS listener: %s", err)
 	}
+	if abstract {
+		return l, nil
+	}
+
+	if uds.UID != -1 || uds.GID != -1 {
+		if err := os.Chown(uds.Addr.String(), uds.UID, uds.GID); err != nil {
+			l.Close()
+			return nil, fmt.Errorf("unable to change UDS ownership: %v", err)
+		}
+	}
 
-	if err := os.Chmod(e.c.BindAddr.String(), os.ModePerm); err != nil {
+	if err := os.Chmod(uds.Addr.String(), uds.Mode); err != nil {
+		l.Close()
 		return nil, fmt.Errorf("unable to change UDS permissions: %v", err)
 	}
 	return l, nil
@@ -0,0 +1,46 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestListenAndServeOnAbstractSocket(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	socketPath := fmt.Sprintf("@spire-agent-endpoints-test-%d", os.Getpid())
+	e := New(&Config{
+		BindAddr: &net.UnixAddr{Net: "unix", Name: socketPath},
+		Log:      log,
+		Metrics:  fakemetrics.New(),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- e.ListenAndServe(ctx)
+	}()
+
+	conn, err := grpc.DialContext(ctx, socketPath, grpc.WithInsecure(), grpc.WithBlock(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", addr)
+		}))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
@@ -0,0 +1,12 @@
+// +build !windows
+
+package endpoints
+
+import (
+	"errors"
+	"net"
+)
+
+func (e *Endpoints) createPipeListener() (net.Listener, error) {
+	return nil, errors.New("named pipes are only supported on windows")
+}
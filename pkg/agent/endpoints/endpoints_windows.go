@@ -0,0 +1,18 @@
+// +build windows
+
+package endpoints
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/Microsoft/go-winio"
+)
+
+func (e *Endpoints) createPipeListener() (net.Listener, error) {
+	l, err := e.unixListener.ListenPipe(`\\.\pipe\`+e.c.NamedPipeName, &winio.PipeConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("create named pipe listener: %v", err)
+	}
+	return l, nil
+}
@@ -0,0 +1,393 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/sirupsen/logrus"
+	attestor "github.com/spiffe/spire/pkg/agent/attestor/workload"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Manager interface {
+	MatchingIdentities(selectors []*common.Selector) []cache.Identity
+}
+
+type HandlerConfig struct {
+	Attestor attestor.Attestor
+	Manager  Manager
+	Log      logrus.FieldLogger
+
+	// Dial opens a connection to the SPIRE server authenticated with the
+	// X509-SVID of the given identity.
+	Dial func(ctx context.Context, identity cache.Identity) (*grpc.ClientConn, error)
+}
+
+// Handler proxies Registration API calls received over the Workload API
+// socket to the SPIRE server. The caller is attested and must be entitled to
+// an admin identity. The call is forwarded to the server using the X509-SVID
+// of that identity so it is subject to the regular server side authorization.
+type Handler struct {
+	c HandlerConfig
+}
+
+func NewHandler(config HandlerConfig) *Handler {
+	return &Handler{c: config}
+}
+
+func (h *Handler) CreateEntry(ctx context.Context, req *common.RegistrationEntry) (*registration.RegistrationEntryID, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.CreateEntry(ctx, req)
+}
+
+func (h *Handler) CreateEntryIfNotExists(ctx context.Context, req *common.RegistrationEntry) (*registration.CreateEntryIfNotExistsResponse, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.CreateEntryIfNotExists(ctx, req)
+}
+
+func (h *Handler) DeleteEntry(ctx context.Context, req *registration.RegistrationEntryID) (*common.RegistrationEntry, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.DeleteEntry(ctx, req)
+}
+
+func (h *Handler) FetchEntry(ctx context.Context, req *registration.RegistrationEntryID) (*common.RegistrationEntry, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.FetchEntry(ctx, req)
+}
+
+func (h *Handler) FetchEntries(ctx context.Context, req *common.Empty) (*common.RegistrationEntries, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.FetchEntries(ctx, req)
+}
+
+func (h *Handler) UpdateEntry(ctx context.Context, req *registration.UpdateEntryRequest) (*common.RegistrationEntry, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.UpdateEntry(ctx, req)
+}
+
+func (h *Handler) ListByParentID(ctx context.Context, req *registration.ParentID) (*common.RegistrationEntries, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.ListByParentID(ctx, req)
+}
+
+func (h *Handler) ListBySelector(ctx context.Context, req *common.Selector) (*common.RegistrationEntries, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.ListBySelector(ctx, req)
+}
+
+func (h *Handler) ListBySelectors(ctx context.Context, req *common.Selectors) (*common.RegistrationEntries, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.ListBySelectors(ctx, req)
+}
+
+func (h *Handler) ListBySpiffeID(ctx context.Context, req *registration.SpiffeID) (*common.RegistrationEntries, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.ListBySpiffeID(ctx, req)
+}
+
+func (h *Handler) ListAllEntriesWithPages(ctx context.Context, req *registration.ListAllEntriesRequest) (*registration.ListAllEntriesResponse, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.ListAllEntriesWithPages(ctx, req)
+}
+
+func (h *Handler) GetEntryStatistics(ctx context.Context, req *registration.GetEntryStatisticsRequest) (*registration.GetEntryStatisticsResponse, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.GetEntryStatistics(ctx, req)
+}
+
+func (h *Handler) CreateFederatedBundle(ctx context.Context, req *registration.FederatedBundle) (*common.Empty, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.CreateFederatedBundle(ctx, req)
+}
+
+func (h *Handler) FetchFederatedBundle(ctx context.Context, req *registration.FederatedBundleID) (*registration.FederatedBundle, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.FetchFederatedBundle(ctx, req)
+}
+
+func (h *Handler) ListFederatedBundles(req *common.Empty, stream registration.Registration_ListFederatedBundlesServer) error {
+	ctx := stream.Context()
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	upstream, err := client.ListFederatedBundles(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		bundle, err := upstream.Recv()
+		switch {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return err
+		}
+		if err := stream.Send(bundle); err != nil {
+			return err
+		}
+	}
+}
+
+func (h *Handler) CountFederatedBundles(ctx context.Context, req *common.Empty) (*registration.CountFederatedBundlesResponse, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.CountFederatedBundles(ctx, req)
+}
+
+func (h *Handler) UpdateFederatedBundle(ctx context.Context, req *registration.FederatedBundle) (*common.Empty, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.UpdateFederatedBundle(ctx, req)
+}
+
+func (h *Handler) DeleteFederatedBundle(ctx context.Context, req *registration.DeleteFederatedBundleRequest) (*common.Empty, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.DeleteFederatedBundle(ctx, req)
+}
+
+func (h *Handler) CreateJoinToken(ctx context.Context, req *registration.JoinToken) (*registration.JoinToken, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.CreateJoinToken(ctx, req)
+}
+
+func (h *Handler) FetchBundle(ctx context.Context, req *common.Empty) (*registration.Bundle, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.FetchBundle(ctx, req)
+}
+
+func (h *Handler) EvictAgent(ctx context.Context, req *registration.EvictAgentRequest) (*registration.EvictAgentResponse, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.EvictAgent(ctx, req)
+}
+
+func (h *Handler) BanAgent(ctx context.Context, req *registration.BanAgentRequest) (*registration.BanAgentResponse, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.BanAgent(ctx, req)
+}
+
+func (h *Handler) PruneAgents(ctx context.Context, req *registration.PruneAgentsRequest) (*registration.PruneAgentsResponse, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.PruneAgents(ctx, req)
+}
+
+func (h *Handler) ForceAgentRotation(ctx context.Context, req *registration.ForceAgentRotationRequest) (*registration.ForceAgentRotationResponse, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.ForceAgentRotation(ctx, req)
+}
+
+func (h *Handler) ListAgents(ctx context.Context, req *registration.ListAgentsRequest) (*registration.ListAgentsResponse, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.ListAgents(ctx, req)
+}
+
+func (h *Handler) CountAgents(ctx context.Context, req *registration.CountAgentsRequest) (*registration.CountAgentsResponse, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.CountAgents(ctx, req)
+}
+
+func (h *Handler) MintX509SVID(ctx context.Context, req *registration.MintX509SVIDRequest) (*registration.MintX509SVIDResponse, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.MintX509SVID(ctx, req)
+}
+
+func (h *Handler) ListIssuedX509SVIDs(ctx context.Context, req *registration.ListIssuedX509SVIDsRequest) (*registration.ListIssuedX509SVIDsResponse, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.ListIssuedX509SVIDs(ctx, req)
+}
+
+func (h *Handler) MintJWTSVID(ctx context.Context, req *registration.MintJWTSVIDRequest) (*registration.MintJWTSVIDResponse, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.MintJWTSVID(ctx, req)
+}
+
+func (h *Handler) GetNodeSelectors(ctx context.Context, req *registration.GetNodeSelectorsRequest) (*registration.GetNodeSelectorsResponse, error) {
+	client, done, err := h.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	return client.GetNodeSelectors(ctx, req)
+}
+
+// newClient attests the caller and returns a registration client connected to
+// the server as the admin identity the caller is entitled to. If no error, the
+// caller must call the returned func() to close the connection.
+func (h *Handler) newClient(ctx context.Context) (registration.RegistrationClient, func(), error) {
+	identity, err := h.authorizeCaller(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log := h.c.Log.WithField(telemetry.SPIFFEID, identity.Entry.SpiffeId)
+	conn, err := h.c.Dial(ctx, *identity)
+	if err != nil {
+		log.WithError(err).Error("Failed to dial server")
+		return nil, nil, status.Errorf(codes.Unavailable, "unable to dial server: %v", err)
+	}
+
+	return registration.NewRegistrationClient(conn), func() { conn.Close() }, nil
+}
+
+// authorizeCaller attests the calling process and returns the first admin
+// identity it is entitled to.
+func (h *Handler) authorizeCaller(ctx context.Context) (*cache.Identity, error) {
+	watcher, err := peerWatcher(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "is this a supported system? Please report this bug: %v", err)
+	}
+
+	pid := watcher.PID()
+	log := h.c.Log.WithField(telemetry.PID, pid)
+
+	selectors := h.c.Attestor.Attest(ctx, pid)
+
+	// Ensure that the original caller is still alive so that we know we didn't
+	// attest some other process that happened to be assigned the original PID
+	if err := watcher.IsAlive(); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "could not verify existence of the original caller: %v", err)
+	}
+
+	for _, identity := range h.c.Manager.MatchingIdentities(selectors) {
+		if identity.Entry.Admin {
+			identity := identity
+			return &identity, nil
+		}
+	}
+
+	log.Error("Caller is not entitled to an admin identity")
+	return nil, status.Error(codes.PermissionDenied, "caller is not entitled to an admin identity")
+}
+
+// peerWatcher takes a grpc context, and returns a Watcher representing the caller which
+// has issued the request. Returns an error if the call was not made locally, if the necessary
+// syscalls aren't unsupported, or if the transport security was not properly configured.
+// See the peertracker package for more information.
+func peerWatcher(ctx context.Context) (peertracker.Watcher, error) {
+	watcher, ok := peertracker.WatcherFromContext(ctx)
+	if !ok {
+		return nil, errors.New("unable to fetch watcher from context")
+	}
+
+	return watcher, nil
+}
@@ -0,0 +1,245 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	workloadSelectors = []*common.Selector{
+		{Type: "unix", Value: "uid:1000"},
+	}
+
+	adminIdentity = cache.Identity{
+		Entry: &common.RegistrationEntry{
+			EntryId:  "admin",
+			SpiffeId: "spiffe://domain.test/admin",
+			Admin:    true,
+		},
+	}
+
+	workloadIdentity = cache.Identity{
+		Entry: &common.RegistrationEntry{
+			EntryId:  "workload",
+			SpiffeId: "spiffe://domain.test/workload",
+		},
+	}
+)
+
+func TestCallerWithAdminIdentity(t *testing.T) {
+	test := setupTest(t, workloadIdentity, adminIdentity)
+	defer test.cleanup()
+
+	entry := &common.RegistrationEntry{SpiffeId: "spiffe://domain.test/foo"}
+	resp, err := test.client.CreateEntry(context.Background(), entry)
+	require.NoError(t, err)
+	require.Equal(t, "ENTRYID", resp.Id)
+
+	// the call is forwarded to the server as the admin identity
+	require.Equal(t, []string{"spiffe://domain.test/admin"}, test.dialedAs)
+	spiretest.RequireProtoEqual(t, entry, test.upstream.createdEntry)
+}
+
+func TestCallerWithoutAdminIdentity(t *testing.T) {
+	test := setupTest(t, workloadIdentity)
+	defer test.cleanup()
+
+	_, err := test.client.CreateEntry(context.Background(), &common.RegistrationEntry{})
+	spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, "caller is not entitled to an admin identity")
+	require.Empty(t, test.dialedAs)
+	require.Nil(t, test.upstream.createdEntry)
+}
+
+func TestUpstreamError(t *testing.T) {
+	test := setupTest(t, adminIdentity)
+	defer test.cleanup()
+
+	_, err := test.client.FetchEntry(context.Background(), &registration.RegistrationEntryID{Id: "missing"})
+	spiretest.RequireGRPCStatus(t, err, codes.NotFound, "no such registration entry")
+}
+
+func TestDialFailure(t *testing.T) {
+	test := setupTest(t, adminIdentity)
+	defer test.cleanup()
+
+	test.dialErr = errors.New("oh no")
+	_, err := test.client.FetchBundle(context.Background(), &common.Empty{})
+	spiretest.RequireGRPCStatus(t, err, codes.Unavailable, "unable to dial server: oh no")
+}
+
+func TestListFederatedBundles(t *testing.T) {
+	test := setupTest(t, adminIdentity)
+	defer test.cleanup()
+
+	stream, err := test.client.ListFederatedBundles(context.Background(), &common.Empty{})
+	require.NoError(t, err)
+
+	var ids []string
+	for {
+		bundle, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		ids = append(ids, bundle.Bundle.TrustDomainId)
+	}
+	require.Equal(t, []string{"spiffe://domain1.test", "spiffe://domain2.test"}, ids)
+}
+
+func TestCountFederatedBundles(t *testing.T) {
+	test := setupTest(t, adminIdentity)
+	defer test.cleanup()
+
+	resp, err := test.client.CountFederatedBundles(context.Background(), &common.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), resp.Count)
+}
+
+type handlerTest struct {
+	client   registration.RegistrationClient
+	upstream *fakeUpstream
+	dialedAs []string
+	dialErr  error
+	cleanup  func()
+}
+
+func setupTest(t *testing.T, identities ...cache.Identity) *handlerTest {
+	log, _ := test.NewNullLogger()
+	ht := &handlerTest{
+		upstream: &fakeUpstream{},
+	}
+
+	upstreamServer := grpc.NewServer()
+	registration.RegisterRegistrationServer(upstreamServer, ht.upstream)
+	upstreamListener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go func() { _ = upstreamServer.Serve(upstreamListener) }()
+
+	handler := NewHandler(HandlerConfig{
+		Attestor: fakeAttestor{t: t},
+		Manager:  fakeManager{t: t, identities: identities},
+		Log:      log,
+		Dial: func(ctx context.Context, identity cache.Identity) (*grpc.ClientConn, error) {
+			if ht.dialErr != nil {
+				return nil, ht.dialErr
+			}
+			ht.dialedAs = append(ht.dialedAs, identity.Entry.SpiffeId)
+			return grpc.DialContext(ctx, upstreamListener.Addr().String(), grpc.WithInsecure())
+		},
+	})
+
+	server := grpc.NewServer(grpc.Creds(fakeCreds{}))
+	registration.RegisterRegistrationServer(server, handler)
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	ht.client = registration.NewRegistrationClient(conn)
+
+	ht.cleanup = func() {
+		conn.Close()
+		server.Stop()
+		upstreamServer.Stop()
+	}
+	return ht
+}
+
+type fakeUpstream struct {
+	registration.UnimplementedRegistrationServer
+
+	createdEntry *common.RegistrationEntry
+}
+
+func (s *fakeUpstream) CreateEntry(ctx context.Context, entry *common.RegistrationEntry) (*registration.RegistrationEntryID, error) {
+	s.createdEntry = entry
+	return &registration.RegistrationEntryID{Id: "ENTRYID"}, nil
+}
+
+func (s *fakeUpstream) FetchEntry(ctx context.Context, req *registration.RegistrationEntryID) (*common.RegistrationEntry, error) {
+	return nil, status.Error(codes.NotFound, "no such registration entry")
+}
+
+func (s *fakeUpstream) ListFederatedBundles(req *common.Empty, stream registration.Registration_ListFederatedBundlesServer) error {
+	for _, id := range []string{"spiffe://domain1.test", "spiffe://domain2.test"} {
+		if err := stream.Send(&registration.FederatedBundle{
+			Bundle: &common.Bundle{TrustDomainId: id},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeUpstream) CountFederatedBundles(ctx context.Context, req *common.Empty) (*registration.CountFederatedBundlesResponse, error) {
+	return &registration.CountFederatedBundlesResponse{Count: 2}, nil
+}
+
+type fakeAttestor struct {
+	t *testing.T
+}
+
+func (a fakeAttestor) Attest(ctx context.Context, pid int32) []*common.Selector {
+	require.Equal(a.t, int32(123), pid)
+	return workloadSelectors
+}
+
+type fakeManager struct {
+	t          *testing.T
+	identities []cache.Identity
+}
+
+func (m fakeManager) MatchingIdentities(selectors []*common.Selector) []cache.Identity {
+	require.Equal(m.t, workloadSelectors, selectors)
+	return m.identities
+}
+
+type fakeCreds struct{}
+
+func (c fakeCreds) ClientHandshake(_ context.Context, _ string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("unexpected")
+}
+
+func (c fakeCreds) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return conn, peertracker.AuthInfo{Watcher: fakeWatcher{}}, nil
+}
+
+func (c fakeCreds) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{
+		SecurityProtocol: "fixed",
+		SecurityVersion:  "0.1",
+		ServerName:       "registration-handler-test",
+	}
+}
+
+func (c fakeCreds) Clone() credentials.TransportCredentials {
+	return &c
+}
+
+func (c fakeCreds) OverrideServerName(_ string) error {
+	return nil
+}
+
+type fakeWatcher struct{}
+
+func (w fakeWatcher) Close() {}
+
+func (w fakeWatcher) IsAlive() error { return nil }
+
+func (w fakeWatcher) PID() int32 { return 123 }
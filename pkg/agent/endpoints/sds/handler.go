@@ -42,6 +42,13 @@ type HandlerConfig struct {
 	Log               logrus.FieldLogger
 	DefaultBundleName string
 	DefaultSVIDName   string
+
+	// SVIDNames maps SPIFFE IDs to custom TLS Certificate resource names
+	SVIDNames map[string]string
+
+	// BundleNames maps trust domain IDs to custom Validation Context
+	// resource names
+	BundleNames map[string]string
 }
 
 type Handler struct {
@@ -298,6 +305,12 @@ func (h *Handler) buildResponse(versionInfo string, req *api_v2.DiscoveryRequest
 				return nil, err
 			}
 			resp.Resources = append(resp.Resources, validationContext)
+		case names[h.c.BundleNames[upd.Bundle.TrustDomainID()]]:
+			validationContext, err := buildValidationContext(upd.Bundle, h.c.BundleNames[upd.Bundle.TrustDomainID()])
+			if err != nil {
+				return nil, err
+			}
+			resp.Resources = append(resp.Resources, validationContext)
 		case names[h.c.DefaultBundleName]:
 			validationContext, err := buildValidationContext(upd.Bundle, h.c.DefaultBundleName)
 			if err != nil {
@@ -308,12 +321,19 @@ func (h *Handler) buildResponse(versionInfo string, req *api_v2.DiscoveryRequest
 	}
 
 	for _, federatedBundle := range upd.FederatedBundles {
-		if len(names) == 0 || names[federatedBundle.TrustDomainID()] {
+		switch {
+		case len(names) == 0 || names[federatedBundle.TrustDomainID()]:
 			validationContext, err := buildValidationContext(federatedBundle, "")
 			if err != nil {
 				return nil, err
 			}
 			resp.Resources = append(resp.Resources, validationContext)
+		case names[h.c.BundleNames[federatedBundle.TrustDomainID()]]:
+			validationContext, err := buildValidationContext(federatedBundle, h.c.BundleNames[federatedBundle.TrustDomainID()])
+			if err != nil {
+				return nil, err
+			}
+			resp.Resources = append(resp.Resources, validationContext)
 		}
 	}
 
@@ -325,6 +345,12 @@ func (h *Handler) buildResponse(versionInfo string, req *api_v2.DiscoveryRequest
 				return nil, err
 			}
 			resp.Resources = append(resp.Resources, tlsCertificate)
+		case names[h.c.SVIDNames[identity.Entry.SpiffeId]]:
+			tlsCertificate, err := buildTLSCertificate(identity, h.c.SVIDNames[identity.Entry.SpiffeId])
+			if err != nil {
+				return nil, err
+			}
+			resp.Resources = append(resp.Resources, tlsCertificate)
 		case i == 0 && names[h.c.DefaultSVIDName]:
 			tlsCertificate, err := buildTLSCertificate(identity, h.c.DefaultSVIDName)
 			if err != nil {
@@ -356,10 +382,10 @@ func peerWatcher(ctx context.Context) (watcher peertracker.Watcher, err error) {
 	return watcher, nil
 }
 
-func buildTLSCertificate(identity cache.Identity, defaultSVIDName string) (*any.Any, error) {
+func buildTLSCertificate(identity cache.Identity, resourceName string) (*any.Any, error) {
 	name := identity.Entry.SpiffeId
-	if defaultSVIDName != "" {
-		name = defaultSVIDName
+	if resourceName != "" {
+		name = resourceName
 	}
 
 	keyPEM, err := pemutil.EncodePKCS8PrivateKey(identity.PrivateKey)
@@ -388,10 +414,10 @@ func buildTLSCertificate(identity cache.Identity, defaultSVIDName string) (*any.
 	})
 }
 
-func buildValidationContext(bundle *bundleutil.Bundle, defaultBundleName string) (*any.Any, error) {
+func buildValidationContext(bundle *bundleutil.Bundle, resourceName string) (*any.Any, error) {
 	name := bundle.TrustDomainID()
-	if defaultBundleName != "" {
-		name = defaultBundleName
+	if resourceName != "" {
+		name = resourceName
 	}
 	caBytes := pemutil.EncodeCertificates(bundle.RootCAs())
 	return ptypes.MarshalAny(&auth_v2.Secret{
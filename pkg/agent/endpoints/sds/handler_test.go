@@ -13,6 +13,7 @@ import (
 	auth_v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2/auth"
 	core_v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	sds_v2 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
@@ -163,6 +164,8 @@ func (s *HandlerSuite) SetupTest() {
 		Manager:           s.manager,
 		DefaultSVIDName:   "default",
 		DefaultBundleName: "ROOTCA",
+		SVIDNames:         map[string]string{"spiffe://domain.test/workload": "workload_cert"},
+		BundleNames:       map[string]string{"spiffe://otherdomain.test": "other_ca"},
 	})
 
 	s.received = make(chan struct{})
@@ -276,6 +279,26 @@ func (s *HandlerSuite) TestStreamSecretsStreamDefaultTLSCertificateOnly() {
 	s.requireSecrets(resp, workloadTLSCertificate3)
 }
 
+func (s *HandlerSuite) TestStreamSecretsStreamCustomResourceNames() {
+	stream, err := s.handler.StreamSecrets(context.Background())
+	s.Require().NoError(err)
+	defer func() {
+		s.Require().NoError(stream.CloseSend())
+	}()
+
+	s.sendAndWait(stream, &api_v2.DiscoveryRequest{
+		ResourceNames: []string{"workload_cert", "other_ca"},
+	})
+	resp, err := stream.Recv()
+	s.Require().NoError(err)
+
+	workloadTLSCertificate := proto.Clone(workloadTLSCertificate1).(*auth_v2.Secret)
+	workloadTLSCertificate.Name = "workload_cert"
+	otherValidationContext := proto.Clone(fedValidationContext).(*auth_v2.Secret)
+	otherValidationContext.Name = "other_ca"
+	s.requireSecrets(resp, otherValidationContext, workloadTLSCertificate)
+}
+
 func (s *HandlerSuite) TestStreamSecretsUnknownResource() {
 	stream, err := s.handler.StreamSecrets(context.Background())
 	s.Require().NoError(err)
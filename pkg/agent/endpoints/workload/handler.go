@@ -8,10 +8,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/proto/spiffe/workload"
@@ -20,12 +22,14 @@ import (
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/apierror"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_workload "github.com/spiffe/spire/pkg/common/telemetry/agent/workloadapi"
 	"github.com/spiffe/spire/pkg/common/x509util"
+	spire_workload "github.com/spiffe/spire/proto/spire/api/workload"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/zeebo/errs"
 	"google.golang.org/grpc/codes"
@@ -40,8 +44,20 @@ type Handler struct {
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
 
+	// RateLimits holds the per-caller rate limits. Zero values disable
+	// rate limiting.
+	RateLimits RateLimits
+
+	// AllowUnauthenticatedVerifiers allows callers without an identity to
+	// fetch the bundles of the agent trust domain, e.g. to validate
+	// SVIDs without being registered.
+	AllowUnauthenticatedVerifiers bool
+
 	// tracks the number of outstanding connections
 	connections int32
+
+	limitersOnce sync.Once
+	limiters     *callerLimiters
 }
 
 // FetchJWTSVID processes request for a JWT-SVID
@@ -51,6 +67,11 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *workload.JWTSVIDRequest
 		return nil, errs.New("audience must be specified")
 	}
 
+	if err := h.checkRateLimit(ctx, telemetry.FetchJWTSVID); err != nil {
+		log.WithError(err).Warn("Rejecting JWT-SVID request")
+		return nil, err
+	}
+
 	_, selectors, metrics, done, err := h.startCall(ctx)
 	if err != nil {
 		return nil, err
@@ -64,7 +85,7 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *workload.JWTSVIDRequest
 	identities := h.Manager.MatchingIdentities(selectors)
 	if len(identities) == 0 {
 		log.WithField(telemetry.Registered, false).Error("No identity issued")
-		return nil, status.Errorf(codes.PermissionDenied, "no identity issued")
+		return nil, apierror.Error(codes.PermissionDenied, apierror.NoEntry, nil, "no identity issued")
 	}
 
 	log = log.WithField(telemetry.Registered, true)
@@ -143,6 +164,53 @@ func (h *Handler) FetchJWTBundles(req *workload.JWTBundlesRequest, stream worklo
 	}
 }
 
+// FetchX509Bundles processes request for X.509 bundles
+func (h *Handler) FetchX509Bundles(_ *spire_workload.X509BundlesRequest, stream spire_workload.Bundles_FetchX509BundlesServer) error {
+	log := h.Log.WithField(telemetry.Method, telemetry.FetchX509Bundles)
+	ctx := stream.Context()
+
+	pid, selectors, metrics, done, err := h.startCall(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch X.509 Bundles during context parsing")
+		return err
+	}
+	defer done()
+
+	telemetry_workload.IncrFetchX509BundlesCounter(metrics)
+	log = log.WithField(telemetry.PID, pid)
+	log.Debug("Fetching X.509 Bundles")
+
+	subscriber := h.Manager.SubscribeToCacheChanges(selectors)
+	defer subscriber.Finish()
+
+	var previous *spire_workload.X509BundlesResponse
+	for {
+		select {
+		case update := <-subscriber.Updates():
+			resp, err := h.composeX509BundlesResponse(update)
+			if err != nil {
+				log.WithError(err).Error("Failed to compose X.509 bundles response")
+				return err
+			}
+			// Updates are also triggered by SVID rotations; only send the
+			// bundles when they change
+			if previous != nil && proto.Equal(previous, resp) {
+				continue
+			}
+
+			telemetry_workload.IncrUpdateX509BundlesCounter(metrics)
+			log.Debug("Sending X.509 Bundles")
+			if err := h.sendX509BundlesResponse(resp, stream, metrics); err != nil {
+				log.WithError(err).Error("Failed to send response")
+				return err
+			}
+			previous = resp
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
 // ValidateJWTSVID processes request for JWT-SVID validation
 func (h *Handler) ValidateJWTSVID(ctx context.Context, req *workload.ValidateJWTSVIDRequest) (*workload.ValidateJWTSVIDResponse, error) {
 	log := h.Log.WithField(telemetry.Method, telemetry.ValidateJWTSVID)
@@ -157,6 +225,11 @@ func (h *Handler) ValidateJWTSVID(ctx context.Context, req *workload.ValidateJWT
 		return nil, status.Error(codes.InvalidArgument, "svid must be specified")
 	}
 
+	if err := h.checkRateLimit(ctx, telemetry.ValidateJWTSVID); err != nil {
+		log.WithError(err).Warn("Rejecting JWT-SVID validation request")
+		return nil, err
+	}
+
 	_, selectors, metrics, done, err := h.startCall(ctx)
 	if err != nil {
 		log.WithError(err).Error("Failed to validate JWT-SVID during context parsing")
@@ -195,6 +268,11 @@ func (h *Handler) ValidateJWTSVID(ctx context.Context, req *workload.ValidateJWT
 func (h *Handler) FetchX509SVID(_ *workload.X509SVIDRequest, stream workload.SpiffeWorkloadAPI_FetchX509SVIDServer) error {
 	ctx := stream.Context()
 
+	if err := h.checkRateLimit(ctx, telemetry.FetchX509SVID); err != nil {
+		h.Log.WithField(telemetry.Method, telemetry.FetchX509SVID).WithError(err).Warn("Rejecting X509-SVID request")
+		return err
+	}
+
 	pid, selectors, metrics, done, err := h.startCall(ctx)
 	if err != nil {
 		return err
@@ -237,7 +315,7 @@ func (h *Handler) sendX509SVIDResponse(update *cache.WorkloadUpdate, stream work
 
 	if len(update.Identities) == 0 {
 		log.WithField(telemetry.Registered, false).WithError(err).Error("No identity issued")
-		return status.Error(codes.PermissionDenied, "no identity issued")
+		return apierror.Error(codes.PermissionDenied, apierror.NoEntry, nil, "no identity issued")
 	}
 
 	log = log.WithField(telemetry.Registered, true)
@@ -304,12 +382,37 @@ func (h *Handler) composeX509SVIDResponse(update *cache.WorkloadUpdate) (*worklo
 	return resp, nil
 }
 
+func (h *Handler) composeX509BundlesResponse(update *cache.WorkloadUpdate) (*spire_workload.X509BundlesResponse, error) {
+	if len(update.Identities) == 0 && !h.AllowUnauthenticatedVerifiers {
+		return nil, apierror.Error(codes.PermissionDenied, apierror.NoEntry, nil, "no identity issued")
+	}
+
+	bundles := make(map[string][]byte)
+	if update.Bundle != nil {
+		bundles[update.Bundle.TrustDomainID()] = marshalBundle(update.Bundle.RootCAs())
+	}
+	for _, federatedBundle := range update.FederatedBundles {
+		bundles[federatedBundle.TrustDomainID()] = marshalBundle(federatedBundle.RootCAs())
+	}
+
+	return &spire_workload.X509BundlesResponse{
+		Bundles: bundles,
+	}, nil
+}
+
+func (h *Handler) sendX509BundlesResponse(resp *spire_workload.X509BundlesResponse, stream spire_workload.Bundles_FetchX509BundlesServer, metrics telemetry.Metrics) (err error) {
+	counter := telemetry_workload.StartFetchX509BundlesCall(metrics)
+	defer counter.Done(&err)
+
+	return stream.Send(resp)
+}
+
 func (h *Handler) sendJWTBundlesResponse(update *cache.WorkloadUpdate, stream workload.SpiffeWorkloadAPI_FetchJWTBundlesServer, metrics telemetry.Metrics) (err error) {
 	counter := telemetry_workload.StartFetchJWTBundlesCall(metrics)
 	defer counter.Done(&err)
 
-	if len(update.Identities) == 0 {
-		return status.Errorf(codes.PermissionDenied, "no identity issued")
+	if len(update.Identities) == 0 && !h.AllowUnauthenticatedVerifiers {
+		return apierror.Error(codes.PermissionDenied, apierror.NoEntry, nil, "no identity issued")
 	}
 
 	resp, err := h.composeJWTBundlesResponse(update)
@@ -349,7 +452,7 @@ func (h *Handler) composeJWTBundlesResponse(update *cache.WorkloadUpdate) (*work
 func (h *Handler) startCall(ctx context.Context) (int32, []*common.Selector, telemetry.Metrics, func(), error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok || len(md["workload.spiffe.io"]) != 1 || md["workload.spiffe.io"][0] != "true" {
-		return 0, nil, nil, nil, status.Errorf(codes.InvalidArgument, "Security header missing from request")
+		return 0, nil, nil, nil, apierror.Error(codes.InvalidArgument, apierror.MissingSecurityHeader, nil, "Security header missing from request")
 	}
 
 	watcher, err := h.peerWatcher(ctx)
@@ -378,7 +481,7 @@ func (h *Handler) startCall(ctx context.Context) (int32, []*common.Selector, tel
 	// attest some other process that happened to be assigned the original PID
 	if err := watcher.IsAlive(); err != nil {
 		done()
-		return 0, nil, nil, nil, status.Errorf(codes.Unauthenticated, "Could not verify existence of the original caller: %v", err)
+		return 0, nil, nil, nil, apierror.Errorf(codes.Unauthenticated, apierror.UnknownCaller, nil, "Could not verify existence of the original caller: %v", err)
 	}
 
 	telemetry_workload.IncrConnectionCounter(h.Metrics)
@@ -386,6 +489,27 @@ func (h *Handler) startCall(ctx context.Context) (int32, []*common.Selector, tel
 	return watcher.PID(), selectors, h.Metrics, done, nil
 }
 
+// checkRateLimit returns a ResourceExhausted error if the caller has exceeded
+// its rate limit for the given method.
+func (h *Handler) checkRateLimit(ctx context.Context, method string) error {
+	h.limitersOnce.Do(func() {
+		h.limiters = newCallerLimiters(h.RateLimits)
+	})
+
+	caller, ok := peertracker.CallerFromContext(ctx)
+	if !ok {
+		// startCall fails the request if the caller is unknown
+		return nil
+	}
+
+	if !h.limiters.Allow(method, caller) {
+		telemetry_workload.IncrThrottledCounter(h.Metrics, method)
+		// the limits are named after the methods
+		return apierror.RateLimitedError(method, fmt.Sprintf("rate limit exceeded for %s", method))
+	}
+	return nil
+}
+
 // peerWatcher takes a grpc context, and returns a Watcher representing the caller which
 // has issued the request. Returns an error if the call was not made locally, if the necessary
 // syscalls aren't unsupported, or if the transport security was not properly configured.
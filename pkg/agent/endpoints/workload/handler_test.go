@@ -13,17 +13,20 @@ import (
 	"github.com/spiffe/go-spiffe/proto/spiffe/workload"
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/apierror"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	spire_workload "github.com/spiffe/spire/proto/spire/api/workload"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
 	"github.com/spiffe/spire/test/fakes/fakeworkloadattestor"
 	mock_manager "github.com/spiffe/spire/test/mock/agent/manager"
 	mock_cache "github.com/spiffe/spire/test/mock/agent/manager/cache"
 	mock_telemetry "github.com/spiffe/spire/test/mock/common/telemetry"
+	mock_bundles "github.com/spiffe/spire/test/mock/proto/api/bundles"
 	mock_workload "github.com/spiffe/spire/test/mock/proto/api/workload"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/spiffe/spire/test/util"
@@ -160,6 +163,7 @@ func (s *HandlerTestSuite) TestSendX509Response() {
 	labels := []telemetry.Label{
 		{Name: telemetry.SVIDType, Value: telemetry.X509},
 		{Name: telemetry.Status, Value: codes.PermissionDenied.String()},
+		{Name: telemetry.Reason, Value: apierror.NoEntry},
 	}
 	s.metrics.EXPECT().IncrCounterWithLabels([]string{telemetry.WorkloadAPI, telemetry.FetchX509SVID}, float32(1), labels)
 	s.metrics.EXPECT().MeasureSinceWithLabels([]string{telemetry.WorkloadAPI, telemetry.FetchX509SVID, telemetry.ElapsedTime}, gomock.Any(), labels)
@@ -248,6 +252,7 @@ func (s *HandlerTestSuite) TestFetchJWTSVID() {
 	labels := []telemetry.Label{
 		{Name: telemetry.SVIDType, Value: telemetry.JWT},
 		statusLabel,
+		{Name: telemetry.Reason, Value: apierror.NoEntry},
 	}
 	s.metrics.EXPECT().IncrCounterWithLabels([]string{telemetry.WorkloadAPI, telemetry.FetchJWTSVID}, float32(1), labels)
 	s.metrics.EXPECT().MeasureSinceWithLabels([]string{telemetry.WorkloadAPI, telemetry.FetchJWTSVID, telemetry.ElapsedTime}, gomock.Any(), labels)
@@ -492,6 +497,94 @@ func (s *HandlerTestSuite) TestComposeJWTBundlesResponse() {
 	}`, string(resp.Bundles["spiffe://no-keys.test"]))
 }
 
+func (s *HandlerTestSuite) TestFetchX509Bundles() {
+	stream := mock_bundles.NewMockBundles_FetchX509BundlesServer(s.ctrl)
+
+	// missing security header
+	stream.EXPECT().Context().Return(context.Background())
+	err := s.h.FetchX509Bundles(&spire_workload.X509BundlesRequest{}, stream)
+	s.requireErrorContains(err, "Security header missing from request")
+
+	// missing peer info
+	stream.EXPECT().Context().Return(makeContext(0))
+	err = s.h.FetchX509Bundles(&spire_workload.X509BundlesRequest{}, stream)
+	s.requireErrorContains(err, "unable to fetch watcher from context")
+
+	// success
+	ctx, cancel := context.WithCancel(makeContext(1))
+	defer cancel()
+	selectors := []*common.Selector{{Type: "foo", Value: "bar"}}
+	subscriber := mock_cache.NewMockSubscriber(s.ctrl)
+	subscription := make(chan *cache.WorkloadUpdate)
+	subscriber.EXPECT().Updates().Return(subscription).AnyTimes()
+	subscriber.EXPECT().Finish()
+	result := make(chan error, 1)
+	stream.EXPECT().Context().Return(ctx).AnyTimes()
+	s.attestor.SetSelectors(1, selectors)
+	s.manager.EXPECT().SubscribeToCacheChanges(cache.Selectors{selectors[0]}).Return(subscriber)
+
+	update := s.workloadUpdate()
+	// The bundles are only sent once since the second update does not
+	// change them
+	stream.EXPECT().Send(&spire_workload.X509BundlesResponse{
+		Bundles: map[string][]byte{
+			"spiffe://example.org":      update.Bundle.RootCAs()[0].Raw,
+			"spiffe://otherdomain.test": update.FederatedBundles["spiffe://otherdomain.test"].RootCAs()[0].Raw,
+		},
+	})
+
+	statusLabel := telemetry.Label{Name: telemetry.Status, Value: codes.OK.String()}
+
+	setupMetricsCommonExpectations(s.metrics, len(selectors), statusLabel)
+	labels := []telemetry.Label{
+		{Name: telemetry.SVIDType, Value: telemetry.X509},
+		statusLabel,
+	}
+	s.metrics.EXPECT().IncrCounter([]string{telemetry.WorkloadAPI, telemetry.FetchX509Bundles}, float32(1))
+	s.metrics.EXPECT().IncrCounter([]string{telemetry.WorkloadAPI, telemetry.BundlesUpdate, telemetry.X509}, float32(1))
+	s.metrics.EXPECT().IncrCounterWithLabels([]string{telemetry.WorkloadAPI, telemetry.FetchX509Bundles}, gomock.Any(), labels)
+	s.metrics.EXPECT().MeasureSinceWithLabels([]string{telemetry.WorkloadAPI, telemetry.FetchX509Bundles, telemetry.ElapsedTime}, gomock.Any(), labels)
+
+	go func() { result <- s.h.FetchX509Bundles(&spire_workload.X509BundlesRequest{}, stream) }()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-time.NewTimer(1 * time.Second).C:
+			s.T().Error("timeout sending update to workload handler")
+		case subscription <- update:
+		}
+	}
+
+	cancel()
+	select {
+	case err := <-result:
+		s.Assert().NoError(err)
+	case <-time.NewTimer(1 * time.Second).C:
+		s.T().Error("workload handler hung, shutdown timer exceeded")
+	}
+}
+
+func (s *HandlerTestSuite) TestComposeX509BundlesResponse() {
+	ca, _, err := util.LoadCAFixture()
+	s.Require().NoError(err)
+	update := &cache.WorkloadUpdate{
+		Bundle: bundleutil.BundleFromRootCA("spiffe://example.org", ca),
+	}
+
+	// no identity issued
+	_, err = s.h.composeX509BundlesResponse(update)
+	s.RequireGRPCStatus(err, codes.PermissionDenied, "no identity issued")
+	s.Equal(apierror.NoEntry, apierror.Reason(err))
+
+	// unauthenticated verifiers allowed
+	s.h.AllowUnauthenticatedVerifiers = true
+	resp, err := s.h.composeX509BundlesResponse(update)
+	s.Require().NoError(err)
+	s.Require().Equal(map[string][]byte{
+		"spiffe://example.org": ca.Raw,
+	}, resp.Bundles)
+}
+
 func (s *HandlerTestSuite) TestValidateJWTSVID() {
 	selectors := []*common.Selector{{Type: "foo", Value: "bar"}}
 	s.attestor.SetSelectors(1, selectors)
@@ -723,6 +816,29 @@ func (s *HandlerTestSuite) TestValidateJWTSVID() {
 	}
 }
 
+func (s *HandlerTestSuite) TestRateLimit() {
+	s.h.RateLimits = RateLimits{FetchJWTSVID: 1}
+
+	// calls are not limited if no limit is configured for the method
+	s.Require().NoError(s.h.checkRateLimit(makeContext(1), telemetry.ValidateJWTSVID))
+	s.Require().NoError(s.h.checkRateLimit(makeContext(1), telemetry.ValidateJWTSVID))
+
+	s.Require().NoError(s.h.checkRateLimit(makeContext(1), telemetry.FetchJWTSVID))
+
+	s.metrics.EXPECT().IncrCounterWithLabels([]string{telemetry.WorkloadAPI, telemetry.Throttled}, float32(1), []telemetry.Label{
+		{Name: telemetry.Method, Value: telemetry.FetchJWTSVID},
+	})
+	resp, err := s.h.FetchJWTSVID(makeContext(1), &workload.JWTSVIDRequest{
+		Audience: []string{"foo"},
+	})
+	s.RequireGRPCStatus(err, codes.ResourceExhausted, "rate limit exceeded for fetch_jwt_svid")
+	s.Require().Nil(resp)
+	details, ok := apierror.Details(err)
+	s.Require().True(ok)
+	s.Equal(apierror.RateLimited, details.Reason)
+	s.Equal(map[string]string{apierror.LimitKey: apierror.LimitFetchJWTSVID}, details.Metadata)
+}
+
 func (s *HandlerTestSuite) TestStructFromValues() {
 	expected := &structpb.Struct{
 		Fields: map[string]*structpb.Value{
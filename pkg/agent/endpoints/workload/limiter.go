@@ -0,0 +1,114 @@
+package workload
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// limiterIdleTimeout is how long the limiter for a caller is kept after
+	// its last call.
+	limiterIdleTimeout = time.Minute
+)
+
+// RateLimits holds the per-caller rate limits for the Workload API, in calls
+// per second. A zero limit disables rate limiting for that method.
+type RateLimits struct {
+	FetchX509SVID   int
+	FetchJWTSVID    int
+	ValidateJWTSVID int
+
+	// PerUID, if true, applies the limits per caller UID instead of per
+	// caller PID, so that a workload cannot escape them by forking.
+	PerUID bool
+}
+
+type limiterKey struct {
+	method string
+	caller string
+}
+
+type callerLimiter struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// callerLimiters rate limits calls to the Workload API per caller.
+type callerLimiters struct {
+	limits RateLimits
+	now    func() time.Time
+
+	mu        sync.Mutex
+	limiters  map[limiterKey]*callerLimiter
+	lastSweep time.Time
+}
+
+func newCallerLimiters(limits RateLimits) *callerLimiters {
+	return &callerLimiters{
+		limits:   limits,
+		now:      time.Now,
+		limiters: make(map[limiterKey]*callerLimiter),
+	}
+}
+
+// Allow returns true if the caller is allowed to call the method.
+func (l *callerLimiters) Allow(method string, caller peertracker.CallerInfo) bool {
+	limit := l.limitFor(method)
+	if limit <= 0 {
+		return true
+	}
+
+	key := limiterKey{method: method, caller: fmt.Sprintf("pid:%d", caller.PID)}
+	if l.limits.PerUID {
+		key.caller = fmt.Sprintf("uid:%d", caller.UID)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	cl, ok := l.limiters[key]
+	if !ok {
+		cl = &callerLimiter{
+			limiter: rate.NewLimiter(rate.Limit(limit), limit),
+		}
+		l.limiters[key] = cl
+	}
+	cl.lastUsed = now
+	return cl.limiter.AllowN(now, 1)
+}
+
+func (l *callerLimiters) limitFor(method string) int {
+	switch method {
+	case telemetry.FetchX509SVID:
+		return l.limits.FetchX509SVID
+	case telemetry.FetchJWTSVID:
+		return l.limits.FetchJWTSVID
+	case telemetry.ValidateJWTSVID:
+		return l.limits.ValidateJWTSVID
+	default:
+		return 0
+	}
+}
+
+// sweep removes the limiters of callers that have been idle for a while, so
+// that short-lived callers don't pile up. A lock must be held on `l` before
+// calling this function.
+func (l *callerLimiters) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < limiterIdleTimeout {
+		return
+	}
+	l.lastSweep = now
+	for key, cl := range l.limiters {
+		if now.Sub(cl.lastUsed) >= limiterIdleTimeout {
+			delete(l.limiters, key)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package workload
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallerLimiters(t *testing.T) {
+	now := time.Now()
+	l := newCallerLimiters(RateLimits{FetchX509SVID: 2})
+	l.now = func() time.Time { return now }
+
+	caller1 := peertracker.CallerInfo{PID: 1, UID: 1000}
+	caller2 := peertracker.CallerInfo{PID: 2, UID: 1000}
+
+	// the burst allows as many calls as the per second limit
+	require.True(t, l.Allow(telemetry.FetchX509SVID, caller1))
+	require.True(t, l.Allow(telemetry.FetchX509SVID, caller1))
+	require.False(t, l.Allow(telemetry.FetchX509SVID, caller1))
+
+	// callers are limited independently
+	require.True(t, l.Allow(telemetry.FetchX509SVID, caller2))
+
+	// methods without a limit are not limited
+	for i := 0; i < 10; i++ {
+		require.True(t, l.Allow(telemetry.FetchJWTSVID, caller1))
+	}
+
+	// tokens are replenished over time
+	now = now.Add(500 * time.Millisecond)
+	require.True(t, l.Allow(telemetry.FetchX509SVID, caller1))
+	require.False(t, l.Allow(telemetry.FetchX509SVID, caller1))
+}
+
+func TestCallerLimitersPerUID(t *testing.T) {
+	now := time.Now()
+	l := newCallerLimiters(RateLimits{ValidateJWTSVID: 1, PerUID: true})
+	l.now = func() time.Time { return now }
+
+	// processes of the same user share the limit
+	require.True(t, l.Allow(telemetry.ValidateJWTSVID, peertracker.CallerInfo{PID: 1, UID: 1000}))
+	require.False(t, l.Allow(telemetry.ValidateJWTSVID, peertracker.CallerInfo{PID: 2, UID: 1000}))
+	require.True(t, l.Allow(telemetry.ValidateJWTSVID, peertracker.CallerInfo{PID: 3, UID: 1001}))
+}
+
+func TestCallerLimitersSweep(t *testing.T) {
+	now := time.Now()
+	l := newCallerLimiters(RateLimits{FetchJWTSVID: 1})
+	l.now = func() time.Time { return now }
+
+	require.True(t, l.Allow(telemetry.FetchJWTSVID, peertracker.CallerInfo{PID: 1}))
+	require.True(t, l.Allow(telemetry.FetchJWTSVID, peertracker.CallerInfo{PID: 2}))
+	require.Len(t, l.limiters, 2)
+
+	now = now.Add(limiterIdleTimeout)
+	require.True(t, l.Allow(telemetry.FetchJWTSVID, peertracker.CallerInfo{PID: 2}))
+	require.Len(t, l.limiters, 1)
+}
@@ -0,0 +1,151 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
+)
+
+// Names of the subsystem health checks. The checks are reported under these
+// names by the gRPC health service of the workload API.
+const (
+	HealthCheckSVID    = "svid"
+	HealthCheckBundle  = "bundle"
+	HealthCheckPlugins = "plugins"
+)
+
+// HealthChecks lists the subsystem health checks in the order they are
+// reported.
+var HealthChecks = []string{
+	HealthCheckSVID,
+	HealthCheckBundle,
+	HealthCheckPlugins,
+}
+
+const (
+	healthCheckInterval = 30 * time.Second
+	healthCheckTimeout  = 10 * time.Second
+)
+
+// subsystemChecks checks the subsystems the agent needs to serve the
+// workload API.
+type subsystemChecks struct {
+	trustDomainID string
+	manager       manager.Manager
+	keyManager    keymanager.KeyManager
+	plugins       pluginHealthChecker
+	clock         clock.Clock
+	metrics       telemetry.Metrics
+
+	bundlesMtx sync.Mutex
+	bundles    *cache.BundleStream
+}
+
+func (c *subsystemChecks) register(checker *health.Checker) error {
+	c.bundles = c.manager.SubscribeToBundleChanges()
+
+	checks := map[string]func(context.Context) error{
+		HealthCheckSVID:    c.checkSVID,
+		HealthCheckBundle:  c.checkBundle,
+		HealthCheckPlugins: c.checkPlugins,
+	}
+	for _, name := range HealthChecks {
+		if err := checker.AddCheck(name, checkFunc(checks[name]), healthCheckInterval); err != nil {
+			return fmt.Errorf("failed adding %s healthcheck: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// checkSVID verifies the agent holds an unexpired SVID.
+func (c *subsystemChecks) checkSVID(ctx context.Context) error {
+	state := c.manager.GetCurrentCredentials()
+	switch {
+	case len(state.SVID) == 0:
+		return errors.New("no agent SVID")
+	case !c.clock.Now().Before(state.SVID[0].NotAfter):
+		return fmt.Errorf("agent SVID expired at %s", state.SVID[0].NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// checkBundle verifies the cached trust domain bundle holds at least one
+// unexpired root CA.
+func (c *subsystemChecks) checkBundle(ctx context.Context) error {
+	c.bundlesMtx.Lock()
+	for c.bundles.HasNext() {
+		c.bundles.Next()
+	}
+	bundle := c.bundles.Value()[c.trustDomainID]
+	c.bundlesMtx.Unlock()
+
+	if bundle == nil {
+		return errors.New("bundle not found")
+	}
+	now := c.clock.Now()
+	for _, rootCA := range bundle.RootCAs() {
+		if now.Before(rootCA.NotAfter) {
+			return nil
+		}
+	}
+	return errors.New("bundle has no unexpired root CAs")
+}
+
+// checkPlugins probes each of the plugins and verifies the key manager,
+// which holds the agent key, is responsive.
+func (c *subsystemChecks) checkPlugins(ctx context.Context) error {
+	if err := c.probePlugins(ctx); err != nil {
+		return err
+	}
+	if _, err := c.keyManager.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{}); err != nil {
+		return fmt.Errorf("key manager is unresponsive: %v", err)
+	}
+	return nil
+}
+
+// probePlugins probes each of the plugins, which restarts the crashed
+// external plugins configured to be restarted, and reports the outcome in
+// the metrics.
+func (c *subsystemChecks) probePlugins(ctx context.Context) error {
+	var unhealthy []string
+	for _, plugin := range c.plugins.CheckHealth(ctx) {
+		telemetry_common.SetPluginHealthyGauge(c.metrics, plugin.Type, plugin.Name, plugin.Err == nil)
+		if plugin.Restarted {
+			telemetry_common.IncrPluginRestartCounter(c.metrics, plugin.Type, plugin.Name)
+		}
+		if plugin.Err != nil {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s plugin %q: %v", plugin.Type, plugin.Name, plugin.Err))
+		}
+	}
+	if len(unhealthy) > 0 {
+		return fmt.Errorf("unhealthy plugins: %s", strings.Join(unhealthy, "; "))
+	}
+	return nil
+}
+
+// pluginHealthChecker probes the loaded plugins.
+type pluginHealthChecker interface {
+	CheckHealth(ctx context.Context) []catalog.PluginHealth
+}
+
+// checkFunc adapts a function to the health.ICheckable interface, bounding
+// each run of the check with healthCheckTimeout.
+type checkFunc func(context.Context) error
+
+func (f checkFunc) Status() (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	return nil, f(ctx)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/common/keymem"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -114,6 +115,10 @@ type Cache struct {
 
 	// bundles holds the trust bundles, keyed by trust domain id (i.e. "spiffe://domain.test")
 	bundles map[string]*bundleutil.Bundle
+
+	// keyWipeDelay, if non-zero, is how long after an X509-SVID is replaced
+	// or removed its private key is wiped from memory.
+	keyWipeDelay time.Duration
 }
 
 // StaleEntry holds stale entries with SVIDs expiration time
@@ -141,8 +146,25 @@ func New(log logrus.FieldLogger, trustDomainID string, bundle *Bundle, metrics t
 	}
 }
 
-// Identities is only used by manager tests
-// TODO: We should remove this and find a better way
+// WipeSupersededKeys enables wiping the private keys of X509-SVIDs that are
+// replaced or removed from the cache once delay has elapsed. The delay gives
+// workload updates that are in flight time to be served before the keys they
+// reference become unusable.
+func (c *Cache) WipeSupersededKeys(delay time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keyWipeDelay = delay
+}
+
+// CountEntries returns the number of cached registration entries, including
+// those that do not have an SVID yet.
+func (c *Cache) CountEntries() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.records)
+}
+
+// Identities returns all of the cached identities that have an SVID.
 func (c *Cache) Identities() []Identity {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -202,11 +224,14 @@ func (c *Cache) UpdateEntries(update *UpdateEntries, checkSVID func(*common.Regi
 	// Remove bundles that no longer exist. The bundle for the agent trust
 	// domain should NOT be removed even if not present (which should only be
 	// the case if there is a bug on the server) since it is necessary to
-	// authenticate the server.
+	// authenticate the server. Removed bundles are tracked in the "changed"
+	// set so workloads federating with them stop receiving them.
 	bundleRemoved := false
+	bundleChanged := make(map[string]bool)
 	for id := range c.bundles {
 		if _, ok := update.Bundles[id]; !ok && id != c.trustDomainID {
 			bundleRemoved = true
+			bundleChanged[id] = true
 			// bundle no longer exists.
 			c.log.WithField(telemetry.TrustDomainID, id).Debug("Bundle removed")
 			delete(c.bundles, id)
@@ -216,7 +241,6 @@ func (c *Cache) UpdateEntries(update *UpdateEntries, checkSVID func(*common.Regi
 	// Update bundles with changes, populating a "changed" set that we can
 	// check when processing registration entries to know if they need to spawn
 	// a notification.
-	bundleChanged := make(map[string]bool)
 	for id, bundle := range update.Bundles {
 		existing, ok := c.bundles[id]
 		if !(ok && existing.EqualTo(bundle)) {
@@ -263,6 +287,7 @@ func (c *Cache) UpdateEntries(update *UpdateEntries, checkSVID func(*common.Regi
 			selRem.Merge(record.entry.Selectors...)
 			c.delSelectorIndicesRecord(selRem, record)
 			notifySet.MergeSet(selRem)
+			c.wipeKeyLater(record.svid)
 			delete(c.records, id)
 			// Remove stale entry since, registration entry is no longer on cache.
 			delete(c.staleEntries, id)
@@ -303,7 +328,8 @@ func (c *Cache) UpdateEntries(update *UpdateEntries, checkSVID func(*common.Regi
 		// Determine if something related to this record changed outside of the
 		// selectors and if so, make sure subscribers for all entry selectors
 		// are notified.
-		if federatedBundlesChanged {
+		hintChanged := existingEntry != nil && existingEntry.Hint != newEntry.Hint
+		if federatedBundlesChanged || hintChanged {
 			notifySet.Merge(newEntry.Selectors...)
 		}
 
@@ -368,6 +394,9 @@ func (c *Cache) UpdateSVIDs(update *UpdateSVIDs) {
 			continue
 		}
 
+		if record.svid != nil && record.svid.PrivateKey != svid.PrivateKey {
+			c.wipeKeyLater(record.svid)
+		}
 		record.svid = svid
 		notifySet.Merge(record.entry.Selectors...)
 		log := c.log.WithFields(logrus.Fields{
@@ -383,6 +412,43 @@ func (c *Cache) UpdateSVIDs(update *UpdateSVIDs) {
 	c.notifyBySelectors(notifySet)
 }
 
+// RemoveExpiredSVIDs drops the expired X509-SVIDs from the cache so they are
+// no longer served to workloads, and marks their entries stale so new
+// X509-SVIDs are fetched on the next synchronization. It returns how many
+// were removed.
+func (c *Cache) RemoveExpiredSVIDs(now time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	notifySet, selSetDone := allocSelectorSet()
+	defer selSetDone()
+
+	removed := 0
+	for entryID, record := range c.records {
+		if record.svid == nil || len(record.svid.Chain) == 0 || now.Before(record.svid.Chain[0].NotAfter) {
+			continue
+		}
+		c.wipeKeyLater(record.svid)
+		record.svid = nil
+		c.staleEntries[entryID] = true
+		notifySet.Merge(record.entry.Selectors...)
+		removed++
+	}
+
+	c.notifyBySelectors(notifySet)
+	return removed
+}
+
+func (c *Cache) wipeKeyLater(svid *X509SVID) {
+	if c.keyWipeDelay <= 0 || svid == nil || svid.PrivateKey == nil {
+		return
+	}
+	key := svid.PrivateKey
+	time.AfterFunc(c.keyWipeDelay, func() {
+		keymem.WipeECKey(key)
+	})
+}
+
 // GetStaleEntries obtains a list of stale entries
 func (c *Cache) GetStaleEntries() []*StaleEntry {
 	c.mu.Lock()
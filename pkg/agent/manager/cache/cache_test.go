@@ -1,6 +1,9 @@
 package cache
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
 	"fmt"
 	"runtime"
@@ -12,6 +15,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -199,6 +203,46 @@ func TestSomeSubscribersNotifiedOnFederatedBundleChange(t *testing.T) {
 	assertNoWorkloadUpdate(t, subB)
 }
 
+func TestSubscribersNotifiedOnFederatedBundleRemoval(t *testing.T) {
+	cache := newTestCache()
+
+	// initialize the cache with an entry FOO that has a valid SVID, selector
+	// "A" and federates with otherdomain.test
+	foo := makeRegistrationEntry("FOO", "A")
+	foo.FederatesWith = makeFederatesWith(otherBundleV1)
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1, otherBundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo),
+	}, nil)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: makeX509SVIDs(foo),
+	})
+
+	subA := cache.SubscribeToWorkloadUpdates(makeSelectors("A"))
+	defer subA.Finish()
+	assertWorkloadUpdateEqual(t, subA, &WorkloadUpdate{
+		Bundle:           bundleV1,
+		FederatedBundles: makeBundles(otherBundleV1),
+		Identities:       []Identity{{Entry: foo}},
+	})
+
+	subB := cache.SubscribeToWorkloadUpdates(makeSelectors("B"))
+	defer subB.Finish()
+	assertAnyWorkloadUpdate(t, subB)
+
+	// remove the federated bundle while FOO still federates with it and
+	// make sure subA is notified that it is gone, but not subB.
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo),
+	}, nil)
+	assertWorkloadUpdateEqual(t, subA, &WorkloadUpdate{
+		Bundle:     bundleV1,
+		Identities: []Identity{{Entry: foo}},
+	})
+	assertNoWorkloadUpdate(t, subB)
+}
+
 func TestSubscribersGetEntriesWithSelectorSubsets(t *testing.T) {
 	cache := newTestCache()
 
@@ -301,6 +345,36 @@ func TestSubscriberNotifiedOnSVIDChanges(t *testing.T) {
 	})
 }
 
+func TestSubscriberNotifiedOnHintChanges(t *testing.T) {
+	cache := newTestCache()
+
+	foo := makeRegistrationEntry("FOO", "A")
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo),
+	}, nil)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: makeX509SVIDs(foo),
+	})
+
+	sub := cache.SubscribeToWorkloadUpdates(makeSelectors("A"))
+	defer sub.Finish()
+	assertAnyWorkloadUpdate(t, sub)
+
+	// Update the hint
+	fooWithHint := makeRegistrationEntry("FOO", "A")
+	fooWithHint.Hint = "external"
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(fooWithHint),
+	}, nil)
+
+	assertWorkloadUpdateEqual(t, sub, &WorkloadUpdate{
+		Bundle:     bundleV1,
+		Identities: []Identity{{Entry: fooWithHint}},
+	})
+}
+
 func TestSubcriberNotificationsOnSelectorChanges(t *testing.T) {
 	cache := newTestCache()
 
@@ -352,6 +426,61 @@ func TestSubcriberNotificationsOnSelectorChanges(t *testing.T) {
 	})
 }
 
+func TestWipeSupersededKeys(t *testing.T) {
+	cache := newTestCache()
+	cache.WipeSupersededKeys(time.Millisecond)
+
+	foo := makeRegistrationEntry("FOO", "A")
+	bar := makeRegistrationEntry("BAR", "B")
+	updateEntries := &UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo, bar),
+	}
+	cache.UpdateEntries(updateEntries, nil)
+
+	fooSVID := makeX509SVIDWithKey(t)
+	barSVID := makeX509SVIDWithKey(t)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: map[string]*X509SVID{"FOO": fooSVID, "BAR": barSVID},
+	})
+
+	// Rotating the FOO SVID wipes the previous key
+	newFooSVID := makeX509SVIDWithKey(t)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: map[string]*X509SVID{"FOO": newFooSVID},
+	})
+	assertKeyWiped(t, fooSVID.PrivateKey)
+
+	// Removing the BAR entry wipes its key
+	updateEntries.RegistrationEntries = makeRegistrationEntries(foo)
+	cache.UpdateEntries(updateEntries, nil)
+	assertKeyWiped(t, barSVID.PrivateKey)
+
+	// The current FOO key is left intact
+	assert.NotZero(t, newFooSVID.PrivateKey.D.Sign())
+}
+
+func TestSupersededKeysAreNotWipedByDefault(t *testing.T) {
+	cache := newTestCache()
+
+	foo := makeRegistrationEntry("FOO", "A")
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo),
+	}, nil)
+
+	fooSVID := makeX509SVIDWithKey(t)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: map[string]*X509SVID{"FOO": fooSVID},
+	})
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: map[string]*X509SVID{"FOO": makeX509SVIDWithKey(t)},
+	})
+
+	time.Sleep(10 * time.Millisecond)
+	assert.NotZero(t, fooSVID.PrivateKey.D.Sign())
+}
+
 func newTestCache() *Cache {
 	log, _ := test.NewNullLogger()
 	return New(log, "spiffe://domain.test", bundleV1, telemetry.Blackhole{})
@@ -428,6 +557,48 @@ func TestSubcriberOnlyGetsEntriesWithSVID(t *testing.T) {
 	})
 }
 
+func TestRemoveExpiredSVIDs(t *testing.T) {
+	cache := newTestCache()
+	now := time.Now()
+
+	foo := makeRegistrationEntry("FOO", "A")
+	bar := makeRegistrationEntry("BAR", "B")
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo, bar),
+	}, nil)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: map[string]*X509SVID{
+			"FOO": {Chain: []*x509.Certificate{{NotAfter: now}}},
+			"BAR": {Chain: []*x509.Certificate{{NotAfter: now.Add(time.Minute)}}},
+		},
+	})
+
+	subA := cache.SubscribeToWorkloadUpdates(makeSelectors("A"))
+	defer subA.Finish()
+	assertAnyWorkloadUpdate(t, subA)
+	subB := cache.SubscribeToWorkloadUpdates(makeSelectors("B"))
+	defer subB.Finish()
+	assertAnyWorkloadUpdate(t, subB)
+
+	require.Equal(t, 1, cache.RemoveExpiredSVIDs(now))
+
+	// the subscribers of the expired SVID are notified that it is gone
+	assertWorkloadUpdateEqual(t, subA, &WorkloadUpdate{
+		Bundle: bundleV1,
+	})
+	assertNoWorkloadUpdate(t, subB)
+	require.Len(t, cache.Identities(), 1)
+
+	// a new SVID is fetched for the entry
+	staleEntries := cache.GetStaleEntries()
+	require.Len(t, staleEntries, 1)
+	require.Equal(t, foo, staleEntries[0].Entry)
+	require.True(t, staleEntries[0].ExpiresAt.IsZero())
+
+	require.Equal(t, 0, cache.RemoveExpiredSVIDs(now))
+}
+
 func TestSubscribersDoNotBlockNotifications(t *testing.T) {
 	cache := newTestCache()
 
@@ -665,6 +836,18 @@ func makeX509SVIDs(entries ...*common.RegistrationEntry) map[string]*X509SVID {
 	return out
 }
 
+func makeX509SVIDWithKey(t *testing.T) *X509SVID {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &X509SVID{PrivateKey: key}
+}
+
+func assertKeyWiped(t *testing.T, key *ecdsa.PrivateKey) {
+	assert.Eventually(t, func() bool {
+		return key.D.Sign() == 0
+	}, time.Second, time.Millisecond, "key was not wiped")
+}
+
 func makeRegistrationEntry(id string, selectors ...string) *common.RegistrationEntry {
 	return &common.RegistrationEntry{
 		EntryId:   id,
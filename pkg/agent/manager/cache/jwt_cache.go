@@ -6,36 +6,146 @@ import (
 	"io"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 )
 
+// JWTSVIDKey identifies a cached JWT-SVID
+type JWTSVIDKey struct {
+	SPIFFEID string
+	Audience []string
+}
+
+type jwtSVIDCacheEntry struct {
+	key  JWTSVIDKey
+	svid *client.JWTSVID
+
+	// lastAccess orders entries by recency of use, for eviction
+	lastAccess uint64
+
+	// usedSinceSet is true if the JWT-SVID was fetched since it was cached;
+	// only JWT-SVIDs still in use are renewed ahead of time.
+	usedSinceSet bool
+}
+
 type JWTSVIDCache struct {
-	mu    sync.Mutex
-	svids map[string]*client.JWTSVID
+	mu       sync.Mutex
+	svids    map[string]*jwtSVIDCacheEntry
+	accesses uint64
+
+	// maxSize, if non-zero, is the maximum number of cached JWT-SVIDs.
+	// The least recently used JWT-SVIDs are evicted to stay under it.
+	maxSize int
 }
 
 func NewJWTSVIDCache() *JWTSVIDCache {
 	return &JWTSVIDCache{
-		svids: make(map[string]*client.JWTSVID),
+		svids: make(map[string]*jwtSVIDCacheEntry),
 	}
 }
 
+// SetJWTSVIDCacheMaxSize caps the number of cached JWT-SVIDs. Zero means
+// unlimited.
+func (c *JWTSVIDCache) SetJWTSVIDCacheMaxSize(maxSize int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxSize = maxSize
+	c.evictLocked()
+}
+
 func (c *JWTSVIDCache) GetJWTSVID(spiffeID string, audience []string) (*client.JWTSVID, bool) {
 	key := jwtSVIDKey(spiffeID, audience)
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	svid, ok := c.svids[key]
-	return svid, ok
+	entry, ok := c.svids[key]
+	if !ok {
+		return nil, false
+	}
+	c.accesses++
+	entry.lastAccess = c.accesses
+	entry.usedSinceSet = true
+	return entry.svid, true
 }
 
-func (c *JWTSVIDCache) SetJWTSVID(spiffeID string, audience []string, svid *client.JWTSVID) {
+// SetJWTSVID caches the JWT-SVID and returns how many JWT-SVIDs were evicted
+// to make room for it.
+func (c *JWTSVIDCache) SetJWTSVID(spiffeID string, audience []string, svid *client.JWTSVID) int {
 	key := jwtSVIDKey(spiffeID, audience)
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.svids[key] = svid
+	c.accesses++
+	c.svids[key] = &jwtSVIDCacheEntry{
+		key: JWTSVIDKey{
+			SPIFFEID: spiffeID,
+			Audience: append([]string(nil), audience...),
+		},
+		svid:       svid,
+		lastAccess: c.accesses,
+	}
+	return c.evictLocked()
+}
+
+// CountJWTSVIDs returns the number of cached JWT-SVIDs
+func (c *JWTSVIDCache) CountJWTSVIDs() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.svids)
+}
+
+// JWTSVIDsToRenew returns the keys of the cached JWT-SVIDs that are past
+// the renewal threshold of their lifetime and were fetched since they were
+// cached. A zero threshold means rotationutil.DefaultRenewalThreshold.
+func (c *JWTSVIDCache) JWTSVIDsToRenew(now time.Time, threshold float64) []JWTSVIDKey {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var keys []JWTSVIDKey
+	for _, entry := range c.svids {
+		if entry.usedSinceSet && !rotationutil.JWTSVIDExpired(entry.svid, now) && rotationutil.JWTSVIDExpiresSoon(entry.svid, now, threshold) {
+			keys = append(keys, entry.key)
+		}
+	}
+	return keys
+}
+
+// RemoveExpiredJWTSVIDs removes the expired JWT-SVIDs from the cache and
+// returns how many were removed.
+func (c *JWTSVIDCache) RemoveExpiredJWTSVIDs(now time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for key, entry := range c.svids {
+		if rotationutil.JWTSVIDExpired(entry.svid, now) {
+			delete(c.svids, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+func (c *JWTSVIDCache) evictLocked() int {
+	if c.maxSize <= 0 {
+		return 0
+	}
+
+	evicted := 0
+	for len(c.svids) > c.maxSize {
+		var lruKey string
+		var lru *jwtSVIDCacheEntry
+		for key, entry := range c.svids {
+			if lru == nil || entry.lastAccess < lru.lastAccess {
+				lruKey, lru = key, entry
+			}
+		}
+		delete(c.svids, lruKey)
+		evicted++
+	}
+	return evicted
 }
 
 func jwtSVIDKey(spiffeID string, audience []string) string {
@@ -25,3 +25,59 @@ func TestJWTSVIDCache(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, expected, actual)
 }
+
+func TestJWTSVIDCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	now := time.Now()
+	svid := &client.JWTSVID{Token: "X", IssuedAt: now, ExpiresAt: now.Add(time.Minute)}
+
+	cache := NewJWTSVIDCache()
+	cache.SetJWTSVIDCacheMaxSize(2)
+
+	assert.Equal(t, 0, cache.SetJWTSVID("spiffe://example.org/a", []string{"aud"}, svid))
+	assert.Equal(t, 0, cache.SetJWTSVID("spiffe://example.org/b", []string{"aud"}, svid))
+
+	// using a makes b the least recently used
+	_, ok := cache.GetJWTSVID("spiffe://example.org/a", []string{"aud"})
+	assert.True(t, ok)
+
+	assert.Equal(t, 1, cache.SetJWTSVID("spiffe://example.org/c", []string{"aud"}, svid))
+	assert.Equal(t, 2, cache.CountJWTSVIDs())
+	_, ok = cache.GetJWTSVID("spiffe://example.org/b", []string{"aud"})
+	assert.False(t, ok)
+	_, ok = cache.GetJWTSVID("spiffe://example.org/a", []string{"aud"})
+	assert.True(t, ok)
+
+	// lowering the cap evicts right away
+	cache.SetJWTSVIDCacheMaxSize(1)
+	assert.Equal(t, 1, cache.CountJWTSVIDs())
+	_, ok = cache.GetJWTSVID("spiffe://example.org/a", []string{"aud"})
+	assert.True(t, ok)
+}
+
+func TestJWTSVIDsToRenew(t *testing.T) {
+	now := time.Now()
+	svid := &client.JWTSVID{Token: "X", IssuedAt: now, ExpiresAt: now.Add(time.Minute)}
+
+	cache := NewJWTSVIDCache()
+	cache.SetJWTSVID("spiffe://example.org/used", []string{"b", "a"}, svid)
+	cache.SetJWTSVID("spiffe://example.org/unused", []string{"a"}, svid)
+	_, ok := cache.GetJWTSVID("spiffe://example.org/used", []string{"a", "b"})
+	assert.True(t, ok)
+
+	// not past half of the lifetime yet
+	assert.Empty(t, cache.JWTSVIDsToRenew(now.Add(29*time.Second), 0))
+
+	// renewed sooner with a lower threshold
+	assert.Len(t, cache.JWTSVIDsToRenew(now.Add(15*time.Second), 0.25), 1)
+
+	// only the JWT-SVID fetched since it was cached is renewed
+	assert.Equal(t, []JWTSVIDKey{
+		{SPIFFEID: "spiffe://example.org/used", Audience: []string{"b", "a"}},
+	}, cache.JWTSVIDsToRenew(now.Add(30*time.Second), 0))
+
+	// expired JWT-SVIDs are not renewed, but removed
+	assert.Empty(t, cache.JWTSVIDsToRenew(now.Add(time.Minute), 0))
+	assert.Equal(t, 0, cache.RemoveExpiredJWTSVIDs(now.Add(59*time.Second)))
+	assert.Equal(t, 2, cache.RemoveExpiredJWTSVIDs(now.Add(time.Minute)))
+	assert.Equal(t, 0, cache.CountJWTSVIDs())
+}
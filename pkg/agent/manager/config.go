@@ -11,11 +11,18 @@ import (
 	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/common/backoff"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/svid"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 )
 
+const (
+	defaultJWTSVIDCacheMaxSize = 1000
+)
+
 // Config holds a cache manager configuration
 type Config struct {
 	// Agent SVID and key resulting from successful attestation.
@@ -27,11 +34,43 @@ type Config struct {
 	Log              logrus.FieldLogger
 	Metrics          telemetry.Metrics
 	ServerAddr       string
+	ServerProxy      client.ProxyFunc
+	ServerGRPC       client.GRPCConfig
 	SVIDCachePath    string
 	BundleCachePath  string
 	SyncInterval     time.Duration
 	RotationInterval time.Duration
 
+	// CachePath, if set, is the path where the cached entries, SVIDs and
+	// bundles are persisted, encrypted with a key derived from the agent
+	// private key. The persisted cache is restored on startup so that
+	// workloads can be served while the server is unreachable.
+	CachePath string
+
+	// RetainSVIDsDuringOutage, if true, reports server outages through logs
+	// and metrics while cached SVIDs keep being served past their rotation
+	// threshold, up to their expiration.
+	RetainSVIDsDuringOutage bool
+
+	// WipeSupersededKeys, if true, wipes the private keys of the agent SVID
+	// and of cached workload SVIDs from memory shortly after they are
+	// rotated or removed.
+	WipeSupersededKeys bool
+
+	// SVIDRenewalThreshold is the fraction of the lifetime of the agent SVID
+	// and of workload SVIDs after which they are renewed. Defaults to
+	// rotationutil.DefaultRenewalThreshold.
+	SVIDRenewalThreshold float64
+
+	// Backoff tunes how the intervals between synchronizations and agent
+	// SVID rotation checks grow after failures
+	Backoff backoff.Config
+
+	// JWTSVIDCacheMaxSize is the maximum number of JWT-SVIDs cached, across
+	// all SPIFFE IDs and audiences. The least recently used ones are evicted
+	// to stay under it. Defaults to 1000.
+	JWTSVIDCacheMaxSize int
+
 	// Clk is the clock the manager will use to get time
 	Clk clock.Clock
 }
@@ -59,7 +98,19 @@ func newManager(c *Config) (*manager, error) {
 		c.Clk = clock.New()
 	}
 
+	if c.JWTSVIDCacheMaxSize == 0 {
+		c.JWTSVIDCacheMaxSize = defaultJWTSVIDCacheMaxSize
+	}
+
+	if c.SVIDRenewalThreshold == 0 {
+		c.SVIDRenewalThreshold = rotationutil.DefaultRenewalThreshold
+	}
+
 	cache := cache.New(c.Log.WithField(telemetry.SubsystemName, telemetry.CacheManager), c.TrustDomain.String(), c.Bundle, c.Metrics)
+	if c.WipeSupersededKeys {
+		cache.WipeSupersededKeys(supersededKeyWipeDelay)
+	}
+	cache.SetJWTSVIDCacheMaxSize(c.JWTSVIDCacheMaxSize)
 
 	rotCfg := &svid.RotatorConfig{
 		Catalog:      c.Catalog,
@@ -70,9 +121,14 @@ func newManager(c *Config) (*manager, error) {
 		SpiffeID:     spiffeID,
 		BundleStream: cache.SubscribeToBundleChanges(),
 		ServerAddr:   c.ServerAddr,
+		ServerProxy:  c.ServerProxy,
+		ServerGRPC:   c.ServerGRPC,
 		TrustDomain:  c.TrustDomain,
 		Interval:     c.RotationInterval,
 		Clk:          c.Clk,
+
+		RenewalThreshold: c.SVIDRenewalThreshold,
+		Backoff:          c.Backoff,
 	}
 	svidRotator, client := svid.NewRotator(rotCfg)
 
@@ -86,6 +142,8 @@ func newManager(c *Config) (*manager, error) {
 		bundleCachePath: c.BundleCachePath,
 		client:          client,
 		clk:             c.Clk,
+		syncNow:         make(chan struct{}, 1),
+		backoff:         backoff.NewBackoffWithConfig(c.Clk, c.SyncInterval, c.Backoff),
 	}
 
 	return m, nil
@@ -7,20 +7,27 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/andres-erbsen/clock"
 	observer "github.com/imkira/go-observer"
+	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/common/backoff"
+	"github.com/spiffe/spire/pkg/agent/common/keymem"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/svid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Cache Manager errors
@@ -28,6 +35,11 @@ var (
 	ErrNotCached = errors.New("not cached")
 )
 
+// supersededKeyWipeDelay is how long a rotated private key is kept intact
+// before being wiped, when WipeSupersededKeys is enabled, so that TLS
+// handshakes and workload updates in flight can complete.
+const supersededKeyWipeDelay = time.Minute
+
 // Manager provides cache management functionalities for agents.
 type Manager interface {
 	// Initialize initializes the manager.
@@ -84,13 +96,34 @@ type manager struct {
 	svidCachePath   string
 	bundleCachePath string
 
+	// storeCacheMtx serializes the writes of the persisted cache, which
+	// happen both on synchronization and on SVID rotation.
+	storeCacheMtx sync.Mutex
+
 	// backoff calculator for fetch interval, backing off if error is returned on
 	// fetch attempt
 	backoff backoff.BackOff
 
+	// syncNow requests the synchronizer to synchronize right away, e.g. when
+	// the server signals that entries or bundles changed.
+	syncNow chan struct{}
+
 	client client.Client
 
 	clk clock.Clock
+
+	// outageSince is the time of the first failed synchronization of an
+	// ongoing server outage, or zero if the server is reachable. Only
+	// accessed by the synchronizer, as are the other outage fields.
+	outageSince time.Time
+
+	// outageExpired is the number of cached SVIDs that expired, and were
+	// dropped, during the ongoing outage.
+	outageExpired int
+
+	// outageNextExpiration is when the next cached SVID expires during the
+	// ongoing outage, so the synchronizer drops it right away.
+	outageNextExpiration time.Time
 }
 
 func (m *manager) Initialize(ctx context.Context) error {
@@ -102,9 +135,20 @@ func (m *manager) Initialize(ctx context.Context) error {
 		return fmt.Errorf("fail to store private key: %v", err)
 	}
 
-	m.backoff = backoff.NewBackoff(m.clk, m.c.SyncInterval)
+	restored := m.restoreCache()
 
-	return m.synchronize(ctx)
+	err = m.synchronize(ctx)
+	m.emitCacheMetrics()
+	switch {
+	case err == nil:
+		return nil
+	case restored && !nodeutil.IsAgentBannedError(err) && !nodeutil.IsAgentNotAttestedError(err):
+		m.c.Log.WithError(err).Warn("Initial synchronization failed; serving identities from the persisted cache")
+		m.reportOutage()
+		return nil
+	default:
+		return err
+	}
 }
 
 func (m *manager) Run(ctx context.Context) error {
@@ -112,9 +156,15 @@ func (m *manager) Run(ctx context.Context) error {
 
 	err := util.RunTasks(ctx,
 		m.runSynchronizer,
+		m.runSyncEventsSubscriber,
 		m.runSVIDObserver,
 		m.runBundleObserver,
+		m.runJWTSVIDRenewer,
 		m.svid.Run)
+	if nodeutil.IsAgentBannedError(err) {
+		m.c.Log.Info("cache manager stopped: agent is banned")
+		return err
+	}
 	if err != nil && err != context.Canceled {
 		m.c.Log.WithError(err).Error("cache manager crashed")
 		return err
@@ -161,9 +211,11 @@ func (m *manager) FetchJWTSVID(ctx context.Context, spiffeID string, audience []
 	now := m.clk.Now()
 
 	cachedSVID, ok := m.cache.GetJWTSVID(spiffeID, audience)
-	if ok && !rotationutil.JWTSVIDExpiresSoon(cachedSVID, now) {
+	if ok && !rotationutil.JWTSVIDExpiresSoon(cachedSVID, now, m.c.SVIDRenewalThreshold) {
+		telemetry_agent.IncrCacheManagerJWTSVIDCacheHitCounter(m.c.Metrics)
 		return cachedSVID, nil
 	}
+	telemetry_agent.IncrCacheManagerJWTSVIDCacheMissCounter(m.c.Metrics)
 
 	newSVID, err := m.client.FetchJWTSVID(ctx, &node.JSR{
 		SpiffeId: spiffeID,
@@ -180,29 +232,237 @@ func (m *manager) FetchJWTSVID(ctx context.Context, spiffeID string, audience []
 		return cachedSVID, nil
 	}
 
-	m.cache.SetJWTSVID(spiffeID, audience, newSVID)
+	m.setJWTSVID(spiffeID, audience, newSVID)
 	return newSVID, nil
 }
 
+func (m *manager) setJWTSVID(spiffeID string, audience []string, svid *client.JWTSVID) {
+	if evicted := m.cache.SetJWTSVID(spiffeID, audience, svid); evicted > 0 {
+		telemetry_agent.AddCacheManagerJWTSVIDCacheEvictedCounter(m.c.Metrics, evicted)
+	}
+}
+
+// runJWTSVIDRenewer periodically renews the cached JWT-SVIDs still in use
+// once they reach half of their lifetime, so workloads fetching them again
+// are served from the cache instead of waiting on the server.
+func (m *manager) runJWTSVIDRenewer(ctx context.Context) error {
+	for {
+		select {
+		case <-m.clk.After(m.c.RotationInterval):
+			m.renewJWTSVIDs(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (m *manager) renewJWTSVIDs(ctx context.Context) {
+	for _, key := range m.cache.JWTSVIDsToRenew(m.clk.Now(), m.c.SVIDRenewalThreshold) {
+		if err := m.renewJWTSVID(ctx, key); err != nil {
+			m.c.Log.WithError(err).WithField(telemetry.SPIFFEID, key.SPIFFEID).Warn("Unable to renew cached JWT-SVID")
+		}
+	}
+	m.cache.RemoveExpiredJWTSVIDs(m.clk.Now())
+	telemetry_agent.SetCacheManagerJWTSVIDCacheSizeGauge(m.c.Metrics, m.cache.CountJWTSVIDs())
+}
+
+func (m *manager) renewJWTSVID(ctx context.Context, key cache.JWTSVIDKey) (err error) {
+	counter := telemetry_agent.StartCacheManagerRenewJWTSVIDCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	svid, err := m.client.FetchJWTSVID(ctx, &node.JSR{
+		SpiffeId: key.SPIFFEID,
+		Audience: key.Audience,
+	})
+	if err != nil {
+		return err
+	}
+	m.setJWTSVID(key.SPIFFEID, key.Audience, svid)
+	return nil
+}
+
 func (m *manager) runSynchronizer(ctx context.Context) error {
+	failures := 0
 	for {
 		select {
-		case <-m.clk.After(m.backoff.NextBackOff()):
+		case <-m.clk.After(m.nextSyncInterval(failures)):
+		case <-m.syncNow:
 		case <-ctx.Done():
 			return nil
 		}
 		err := m.synchronize(ctx)
+		m.emitCacheMetrics()
+		if nodeutil.IsAgentBannedError(err) {
+			// Stop the manager so the agent shuts down
+			m.c.Log.Warn("Agent is banned: shutting down")
+			return err
+		}
 		if err != nil {
 			// Just log the error and wait for next synchronization
+			failures++
 			m.c.Log.WithError(err).Error("synchronize failed")
+			m.reportOutage()
 		} else {
+			failures = 0
 			m.backoff.Reset()
+			m.endOutage()
+		}
+	}
+}
+
+// nextSyncInterval returns how long to wait before the next synchronization,
+// backing off after failed synchronizations, and reports it in metrics along
+// with the number of consecutive failures. During an outage, the next
+// synchronization is made no later than the expiration of the next cached
+// SVID, so it is dropped as soon as it expires.
+func (m *manager) nextSyncInterval(failures int) time.Duration {
+	interval := m.backoff.NextBackOff()
+	telemetry_agent.SetManagerSyncBackoffGauges(m.c.Metrics, float32(interval.Seconds()), failures)
+	if !m.outageNextExpiration.IsZero() {
+		untilExpiration := m.outageNextExpiration.Sub(m.clk.Now())
+		if untilExpiration < 0 {
+			untilExpiration = 0
+		}
+		if untilExpiration < interval {
+			interval = untilExpiration
+		}
+	}
+	return interval
+}
+
+// runSyncEventsSubscriber subscribes to the sync events pushed by the server
+// and requests a synchronization for each of them, so changes are propagated
+// without waiting for the sync interval. Periodic synchronization keeps
+// running regardless, so failures are only logged before resubscribing.
+func (m *manager) runSyncEventsSubscriber(ctx context.Context) error {
+	subscribeBackoff := backoff.NewBackoffWithConfig(m.clk, m.c.SyncInterval, m.c.Backoff)
+	for {
+		err := m.client.SubscribeToSyncEvents(ctx, func() {
+			subscribeBackoff.Reset()
+			m.requestSync()
+		})
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case status.Code(err) == codes.Unimplemented:
+			m.c.Log.Info("Server does not push sync events; relying on periodic synchronization")
+			return nil
+		case err != nil:
+			m.c.Log.WithError(err).Debug("Sync events subscription failed")
+		}
+
+		select {
+		case <-m.clk.After(subscribeBackoff.NextBackOff()):
+		case <-ctx.Done():
+			return nil
 		}
 	}
 }
 
+// requestSync asks the synchronizer to synchronize as soon as possible.
+// Requests made while one is already pending are coalesced.
+func (m *manager) requestSync() {
+	select {
+	case m.syncNow <- struct{}{}:
+	default:
+	}
+}
+
+// emitCacheMetrics sets the gauges for the cache size and the remaining
+// lifetime of the cached and agent SVIDs, so agents falling behind rotation
+// can be alerted on.
+func (m *manager) emitCacheMetrics() {
+	now := m.clk.Now()
+
+	identities := m.cache.Identities()
+	telemetry_agent.SetCacheManagerCacheSizeGauges(m.c.Metrics, m.cache.CountEntries(), len(identities))
+
+	var minNotAfter time.Time
+	for _, identity := range identities {
+		notAfter := identity.SVID[0].NotAfter
+		if minNotAfter.IsZero() || notAfter.Before(minNotAfter) {
+			minNotAfter = notAfter
+		}
+	}
+	if !minNotAfter.IsZero() {
+		telemetry_agent.SetCacheManagerMinSVIDLifetimeGauge(m.c.Metrics, float32(minNotAfter.Sub(now).Seconds()))
+	}
+
+	if agentSVID := m.svid.State().SVID; len(agentSVID) > 0 {
+		telemetry_agent.SetAgentSVIDLifetimeGauge(m.c.Metrics, float32(agentSVID[0].NotAfter.Sub(now).Seconds()))
+	}
+}
+
+// reportOutage reports the cached SVIDs that are served past their rotation
+// threshold because the server is unreachable, and drops the expired ones so
+// workloads are only served valid SVIDs.
+func (m *manager) reportOutage() {
+	if !m.c.RetainSVIDsDuringOutage {
+		return
+	}
+
+	now := m.clk.Now()
+	if m.outageSince.IsZero() {
+		m.outageSince = now
+	}
+
+	m.outageExpired += m.cache.RemoveExpiredSVIDs(now)
+	expired := m.outageExpired
+
+	var expiring int
+	var nextExpiration time.Time
+	m.outageNextExpiration = time.Time{}
+	for _, identity := range m.cache.Identities() {
+		cert := identity.SVID[0]
+		if m.outageNextExpiration.IsZero() || cert.NotAfter.Before(m.outageNextExpiration) {
+			m.outageNextExpiration = cert.NotAfter
+		}
+		if rotationutil.ShouldRotateX509(now, cert, m.c.SVIDRenewalThreshold) {
+			expiring++
+			if nextExpiration.IsZero() || cert.NotAfter.Before(nextExpiration) {
+				nextExpiration = cert.NotAfter
+			}
+		}
+	}
+
+	duration := now.Sub(m.outageSince)
+	telemetry_agent.SetCacheManagerOutageGauges(m.c.Metrics, float32(duration.Seconds()), expiring, expired)
+
+	log := m.c.Log.WithFields(logrus.Fields{
+		telemetry.Seconds:       duration.Seconds(),
+		telemetry.ExpiringSVIDs: expiring,
+		telemetry.ExpiredSVIDs:  expired,
+	})
+	if !nextExpiration.IsZero() {
+		log = log.WithField(telemetry.Expiration, nextExpiration.Format(time.RFC3339))
+	}
+	switch {
+	case expired > 0:
+		log.Error("Server unreachable; some cached SVIDs have expired and can no longer be renewed")
+	case expiring > 0:
+		log.Warn("Server unreachable; serving cached SVIDs past their rotation threshold until they expire")
+	default:
+		log.Warn("Server unreachable; serving cached SVIDs")
+	}
+}
+
+// endOutage reports the end of an ongoing server outage, if any.
+func (m *manager) endOutage() {
+	if m.outageSince.IsZero() {
+		return
+	}
+
+	duration := m.clk.Now().Sub(m.outageSince)
+	m.outageSince = time.Time{}
+	m.outageExpired = 0
+	m.outageNextExpiration = time.Time{}
+	telemetry_agent.SetCacheManagerOutageGauges(m.c.Metrics, 0, 0, 0)
+	m.c.Log.WithField(telemetry.Seconds, duration.Seconds()).Info("Server reachable again; resuming SVID rotation")
+}
+
 func (m *manager) runSVIDObserver(ctx context.Context) error {
 	svidStream := m.SubscribeToSVIDChanges()
+	prevKey := m.svid.State().Key
 	for {
 		select {
 		case <-ctx.Done():
@@ -210,6 +470,11 @@ func (m *manager) runSVIDObserver(ctx context.Context) error {
 		case <-svidStream.Changes():
 			s := svidStream.Next().(svid.State)
 
+			if m.c.WipeSupersededKeys && prevKey != s.Key {
+				m.wipeKeyLater(prevKey)
+			}
+			prevKey = s.Key
+
 			err := m.storePrivateKey(ctx, s.Key)
 			if err != nil {
 				m.c.Log.WithError(err).Error("failed to store private key")
@@ -217,6 +482,9 @@ func (m *manager) runSVIDObserver(ctx context.Context) error {
 			}
 
 			m.storeSVID(s.SVID)
+			// The persisted cache is encrypted with the agent key, so it
+			// needs to be resealed with the rotated one.
+			m.storeCache()
 		}
 	}
 }
@@ -252,12 +520,90 @@ func (m *manager) storeBundle(bundle *bundleutil.Bundle) {
 	}
 }
 
+// restoreCache loads the persisted cache, if any, into the in-memory cache.
+// Expired SVIDs are discarded. Returns true if any identity was restored.
+func (m *manager) restoreCache() bool {
+	if m.c.CachePath == "" {
+		return false
+	}
+
+	snapshot, err := readCacheSnapshot(m.c.CachePath, m.c.SVIDKey)
+	switch {
+	case err == ErrNotCached:
+		return false
+	case err != nil:
+		m.c.Log.WithError(err).Warn("could not restore persisted cache")
+		return false
+	}
+
+	now := m.clk.Now()
+	update := &cache.UpdateEntries{
+		Bundles:             snapshot.Bundles,
+		RegistrationEntries: make(map[string]*common.RegistrationEntry),
+	}
+	// The bundle obtained during attestation is never older than the
+	// persisted one.
+	if m.c.Bundle != nil {
+		update.Bundles[m.c.TrustDomain.String()] = m.c.Bundle
+	}
+
+	svids := &cache.UpdateSVIDs{
+		X509SVIDs: make(map[string]*cache.X509SVID),
+	}
+	for _, identity := range snapshot.Identities {
+		if len(identity.SVID) == 0 || !now.Before(identity.SVID[0].NotAfter) {
+			continue
+		}
+		update.RegistrationEntries[identity.Entry.EntryId] = identity.Entry
+		svids.X509SVIDs[identity.Entry.EntryId] = &cache.X509SVID{
+			Chain:      identity.SVID,
+			PrivateKey: identity.PrivateKey,
+		}
+	}
+
+	m.cache.UpdateEntries(update, func(_, _ *common.RegistrationEntry, svid *cache.X509SVID) bool {
+		return svid == nil
+	})
+	m.cache.UpdateSVIDs(svids)
+
+	m.c.Log.WithField(telemetry.Count, len(svids.X509SVIDs)).Info("Restored identities from the persisted cache")
+	return len(svids.X509SVIDs) > 0
+}
+
+// storeCache persists the cache, encrypted with the current agent key.
+func (m *manager) storeCache() {
+	if m.c.CachePath == "" {
+		return
+	}
+
+	m.storeCacheMtx.Lock()
+	defer m.storeCacheMtx.Unlock()
+
+	// The key is read under the lock so a write sealed with a superseded key
+	// never lands after one sealed with the rotated key.
+	agentKey := m.svid.State().Key
+	snapshot := &cacheSnapshot{
+		Identities: m.cache.Identities(),
+		Bundles:    m.cache.Bundles(),
+	}
+	if err := storeCacheSnapshot(m.c.CachePath, agentKey, snapshot); err != nil {
+		m.c.Log.WithError(err).Warn("could not persist cache")
+	}
+}
+
+func (m *manager) wipeKeyLater(key *ecdsa.PrivateKey) {
+	m.clk.AfterFunc(supersededKeyWipeDelay, func() {
+		keymem.WipeECKey(key)
+	})
+}
+
 func (m *manager) storePrivateKey(ctx context.Context, key *ecdsa.PrivateKey) error {
 	km := m.c.Catalog.GetKeyManager()
 	keyBytes, err := x509.MarshalECPrivateKey(key)
 	if err != nil {
 		return err
 	}
+	defer keymem.Wipe(keyBytes)
 
 	if _, err = km.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{PrivateKey: keyBytes}); err != nil {
 		return err
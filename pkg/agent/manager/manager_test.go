@@ -18,13 +18,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
 	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/common/backoff"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/disk"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/memory"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/proto/spire/api/node"
@@ -33,12 +37,15 @@ import (
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/require"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -331,6 +338,341 @@ func TestHappyPathWithoutSyncNorRotation(t *testing.T) {
 	})
 }
 
+func TestRestoresPersistedCache(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	defer l.Close()
+
+	clk := clock.NewMock(t)
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:             t,
+		trustDomain:   trustDomain,
+		listener:      l,
+		fetchX509SVID: fetchX509SVIDForTestHappyPathWithoutSyncNorRotation,
+		svidTTL:       200,
+	}, clk)
+	apiHandler.start()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+	cat := fakeagentcatalog.New()
+	cat.SetKeyManager(fakeagentcatalog.KeyManager(memory.New()))
+
+	c := &Config{
+		ServerAddr:      l.Addr().String(),
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     trustDomainID,
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		CachePath:       path.Join(dir, "cache.dat"),
+		Bundle:          apiHandler.bundle,
+		Metrics:         &telemetry.Blackhole{},
+		Clk:             clk,
+		Catalog:         cat,
+	}
+
+	m, closer := initializeAndRunNewManager(t, c)
+	selectors := cache.Selectors{{Type: "unix", Value: "uid:1111"}}
+	require.Len(t, m.MatchingIdentities(selectors), 2)
+	closer()
+
+	// With the server gone, a new manager initializes from the persisted
+	// cache.
+	apiHandler.stop()
+	m = makeManager(t, c)
+	require.NoError(t, m.Initialize(context.Background()))
+
+	matches := m.MatchingIdentities(selectors)
+	require.Len(t, matches, 2)
+	compareRegistrationEntries(t,
+		regEntriesMap["resp2"],
+		[]*common.RegistrationEntry{matches[0].Entry, matches[1].Entry})
+
+	// Without a persisted cache, initialization fails.
+	require.NoError(t, os.Remove(c.CachePath))
+	m = makeManager(t, c)
+	require.Error(t, m.Initialize(context.Background()))
+}
+
+func TestWipeKeyLater(t *testing.T) {
+	clk := clock.NewMock(t)
+	ca, cakey := createCA(t, clk, trustDomain)
+	baseSVID, baseSVIDKey := createSVID(t, clk, ca, cakey, "spiffe://"+trustDomain+"/agent", 1*time.Hour)
+	_, oldKey := createSVID(t, clk, ca, cakey, "spiffe://"+trustDomain+"/agent", 1*time.Hour)
+
+	m := makeManager(t, &Config{
+		SVID:               baseSVID,
+		SVIDKey:            baseSVIDKey,
+		Bundle:             bundleutil.BundleFromRootCA(trustDomainID.String(), ca),
+		Log:                testLogger,
+		Metrics:            &telemetry.Blackhole{},
+		TrustDomain:        trustDomainID,
+		Clk:                clk,
+		WipeSupersededKeys: true,
+	})
+
+	m.wipeKeyLater(oldKey)
+
+	// The key is left intact during the grace period
+	clk.Add(supersededKeyWipeDelay - time.Second)
+	require.NotZero(t, oldKey.D.Sign())
+
+	clk.Add(time.Second)
+	require.Zero(t, oldKey.D.Sign())
+	require.NotZero(t, baseSVIDKey.D.Sign())
+}
+
+func TestEmitCacheMetrics(t *testing.T) {
+	clk := clock.NewMock(t)
+	ca, cakey := createCA(t, clk, trustDomain)
+	baseSVID, baseSVIDKey := createSVID(t, clk, ca, cakey, "spiffe://"+trustDomain+"/agent", 1*time.Hour)
+	metrics := fakemetrics.New()
+	bundle := bundleutil.BundleFromRootCA(trustDomainID.String(), ca)
+
+	m := makeManager(t, &Config{
+		SVID:        baseSVID,
+		SVIDKey:     baseSVIDKey,
+		Bundle:      bundle,
+		Log:         testLogger,
+		Metrics:     metrics,
+		TrustDomain: trustDomainID,
+		Clk:         clk,
+	})
+
+	cacheMetrics := func(entries, svids int, minLifetime, agentLifetime float32) []fakemetrics.MetricItem {
+		items := []fakemetrics.MetricItem{
+			{Type: fakemetrics.SetGaugeType, Key: []string{telemetry.CacheManager, telemetry.CachedEntries}, Val: float32(entries)},
+			{Type: fakemetrics.SetGaugeType, Key: []string{telemetry.CacheManager, telemetry.CachedSVIDs}, Val: float32(svids)},
+		}
+		if svids > 0 {
+			items = append(items, fakemetrics.MetricItem{Type: fakemetrics.SetGaugeType, Key: []string{telemetry.CacheManager, telemetry.MinSVIDLifetime, telemetry.Seconds}, Val: minLifetime})
+		}
+		return append(items, fakemetrics.MetricItem{Type: fakemetrics.SetGaugeType, Key: []string{telemetry.AgentSVID, telemetry.RemainingLifetime, telemetry.Seconds}, Val: agentLifetime})
+	}
+
+	// Nothing cached yet
+	m.emitCacheMetrics()
+	require.Equal(t, cacheMetrics(0, 0, 0, 3600), metrics.AllMetrics())
+
+	// Three entries, two of them with an SVID
+	update := &cache.UpdateEntries{
+		Bundles:             map[string]*bundleutil.Bundle{trustDomainID.String(): bundle},
+		RegistrationEntries: make(map[string]*common.RegistrationEntry),
+	}
+	svids := &cache.UpdateSVIDs{X509SVIDs: make(map[string]*cache.X509SVID)}
+	for id, ttl := range map[string]time.Duration{"short": 10 * time.Minute, "long": time.Hour, "pending": 0} {
+		spiffeID := "spiffe://" + trustDomain + "/" + id
+		update.RegistrationEntries[id] = &common.RegistrationEntry{
+			EntryId:   id,
+			SpiffeId:  spiffeID,
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1111"}},
+		}
+		if ttl > 0 {
+			svid, key := createSVID(t, clk, ca, cakey, spiffeID, ttl)
+			svids.X509SVIDs[id] = &cache.X509SVID{Chain: svid, PrivateKey: key}
+		}
+	}
+	m.cache.UpdateEntries(update, nil)
+	m.cache.UpdateSVIDs(svids)
+
+	metrics.Reset()
+	clk.Add(time.Minute)
+	m.emitCacheMetrics()
+	require.Equal(t, cacheMetrics(3, 2, 540, 3540), metrics.AllMetrics())
+}
+
+func TestNextSyncInterval(t *testing.T) {
+	clk := clock.NewMock(t)
+	ca, cakey := createCA(t, clk, trustDomain)
+	baseSVID, baseSVIDKey := createSVID(t, clk, ca, cakey, "spiffe://"+trustDomain+"/agent", 1*time.Hour)
+	metrics := fakemetrics.New()
+
+	m := makeManager(t, &Config{
+		SVID:         baseSVID,
+		SVIDKey:      baseSVIDKey,
+		Bundle:       bundleutil.BundleFromRootCA(trustDomainID.String(), ca),
+		Log:          testLogger,
+		Metrics:      metrics,
+		TrustDomain:  trustDomainID,
+		Clk:          clk,
+		SyncInterval: time.Second,
+		Backoff: backoff.Config{
+			MaxInterval: 3 * time.Second,
+			Multiplier:  2,
+			Jitter:      0.01,
+		},
+	})
+
+	backoffMetrics := func(interval time.Duration, failures int) []fakemetrics.MetricItem {
+		return []fakemetrics.MetricItem{
+			{Type: fakemetrics.SetGaugeType, Key: []string{telemetry.Manager, telemetry.Sync, telemetry.Backoff, telemetry.Seconds}, Val: float32(interval.Seconds())},
+			{Type: fakemetrics.SetGaugeType, Key: []string{telemetry.Manager, telemetry.Sync, telemetry.Backoff, telemetry.ConsecutiveFailures}, Val: float32(failures)},
+		}
+	}
+
+	// The interval grows with each failure, up to the maximum interval
+	for failures, expected := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		metrics.Reset()
+		interval := m.nextSyncInterval(failures)
+		require.InEpsilon(t, expected, interval, 0.02)
+		require.Equal(t, backoffMetrics(interval, failures), metrics.AllMetrics())
+	}
+}
+
+func TestReportOutage(t *testing.T) {
+	clk := clock.NewMock(t)
+	ca, cakey := createCA(t, clk, trustDomain)
+	baseSVID, baseSVIDKey := createSVID(t, clk, ca, cakey, "spiffe://"+trustDomain+"/agent", 1*time.Hour)
+	log, hook := testlog.NewNullLogger()
+	metrics := fakemetrics.New()
+	bundle := bundleutil.BundleFromRootCA(trustDomainID.String(), ca)
+
+	m := makeManager(t, &Config{
+		SVID:                    baseSVID,
+		SVIDKey:                 baseSVIDKey,
+		Bundle:                  bundle,
+		Log:                     log,
+		Metrics:                 metrics,
+		TrustDomain:             trustDomainID,
+		Clk:                     clk,
+		RetainSVIDsDuringOutage: true,
+	})
+
+	// Cache an SVID per entry, with different lifetimes
+	update := &cache.UpdateEntries{
+		Bundles:             map[string]*bundleutil.Bundle{trustDomainID.String(): bundle},
+		RegistrationEntries: make(map[string]*common.RegistrationEntry),
+	}
+	svids := &cache.UpdateSVIDs{X509SVIDs: make(map[string]*cache.X509SVID)}
+	for id, ttl := range map[string]time.Duration{"short": time.Minute, "long": time.Hour} {
+		spiffeID := "spiffe://" + trustDomain + "/" + id
+		update.RegistrationEntries[id] = &common.RegistrationEntry{
+			EntryId:   id,
+			SpiffeId:  spiffeID,
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1111"}},
+		}
+		svid, key := createSVID(t, clk, ca, cakey, spiffeID, ttl)
+		svids.X509SVIDs[id] = &cache.X509SVID{Chain: svid, PrivateKey: key}
+	}
+	m.cache.UpdateEntries(update, func(_, _ *common.RegistrationEntry, svid *cache.X509SVID) bool {
+		return svid == nil
+	})
+	m.cache.UpdateSVIDs(svids)
+
+	outageMetrics := func(seconds float32, expiring, expired int) []fakemetrics.MetricItem {
+		return []fakemetrics.MetricItem{
+			{Type: fakemetrics.SetGaugeType, Key: []string{telemetry.CacheManager, telemetry.Outage, telemetry.Seconds}, Val: seconds},
+			{Type: fakemetrics.SetGaugeType, Key: []string{telemetry.CacheManager, telemetry.Outage, telemetry.ExpiringSVIDs}, Val: float32(expiring)},
+			{Type: fakemetrics.SetGaugeType, Key: []string{telemetry.CacheManager, telemetry.Outage, telemetry.ExpiredSVIDs}, Val: float32(expired)},
+		}
+	}
+
+	// The outage starts while all SVIDs are fresh
+	m.reportOutage()
+	require.Equal(t, outageMetrics(0, 0, 0), metrics.AllMetrics())
+	require.Equal(t, "Server unreachable; serving cached SVIDs", hook.LastEntry().Message)
+
+	// The short lived SVID is past its rotation threshold but still served
+	metrics.Reset()
+	clk.Add(40 * time.Second)
+	m.reportOutage()
+	require.Equal(t, outageMetrics(40, 1, 0), metrics.AllMetrics())
+	require.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+	require.Equal(t, "Server unreachable; serving cached SVIDs past their rotation threshold until they expire", hook.LastEntry().Message)
+	require.Len(t, m.MatchingIdentities([]*common.Selector{{Type: "unix", Value: "uid:1111"}}), 2)
+
+	// The short lived SVID has expired and is no longer served
+	metrics.Reset()
+	clk.Add(40 * time.Second)
+	m.reportOutage()
+	require.Equal(t, outageMetrics(80, 0, 1), metrics.AllMetrics())
+	require.Equal(t, logrus.ErrorLevel, hook.LastEntry().Level)
+	require.Len(t, m.MatchingIdentities([]*common.Selector{{Type: "unix", Value: "uid:1111"}}), 1)
+
+	// The expired SVID is still reported until the outage ends
+	metrics.Reset()
+	clk.Add(time.Second)
+	m.reportOutage()
+	require.Equal(t, outageMetrics(81, 0, 1), metrics.AllMetrics())
+
+	// The server is reachable again
+	metrics.Reset()
+	m.endOutage()
+	require.Equal(t, outageMetrics(0, 0, 0), metrics.AllMetrics())
+	require.Equal(t, "Server reachable again; resuming SVID rotation", hook.LastEntry().Message)
+	require.True(t, m.outageSince.IsZero())
+}
+
+func TestRetainSVIDsDuringOutage(t *testing.T) {
+	selectors := []*common.Selector{{Type: "unix", Value: "uid:1111"}}
+
+	for _, retain := range []bool{false, true} {
+		clk := clock.NewMock(t)
+		ca, cakey := createCA(t, clk, trustDomain)
+		baseSVID, baseSVIDKey := createSVID(t, clk, ca, cakey, "spiffe://"+trustDomain+"/agent", 1*time.Hour)
+		bundle := bundleutil.BundleFromRootCA(trustDomainID.String(), ca)
+		log, _ := testlog.NewNullLogger()
+
+		m := makeManager(t, &Config{
+			SVID:                    baseSVID,
+			SVIDKey:                 baseSVIDKey,
+			Bundle:                  bundle,
+			Log:                     log,
+			Metrics:                 &telemetry.Blackhole{},
+			TrustDomain:             trustDomainID,
+			Clk:                     clk,
+			SyncInterval:            time.Hour,
+			RetainSVIDsDuringOutage: retain,
+		})
+
+		m.cache.UpdateEntries(&cache.UpdateEntries{
+			Bundles: map[string]*bundleutil.Bundle{trustDomainID.String(): bundle},
+			RegistrationEntries: map[string]*common.RegistrationEntry{
+				"short": {
+					EntryId:   "short",
+					SpiffeId:  "spiffe://" + trustDomain + "/short",
+					Selectors: selectors,
+				},
+			},
+		}, func(_, _ *common.RegistrationEntry, svid *cache.X509SVID) bool {
+			return svid == nil
+		})
+		svid, key := createSVID(t, clk, ca, cakey, "spiffe://"+trustDomain+"/short", time.Minute)
+		m.cache.UpdateSVIDs(&cache.UpdateSVIDs{
+			X509SVIDs: map[string]*cache.X509SVID{"short": {Chain: svid, PrivateKey: key}},
+		})
+		sub := m.SubscribeToCacheChanges(selectors)
+		<-sub.Updates()
+
+		// The SVID is served past its rotation threshold
+		clk.Add(40 * time.Second)
+		m.reportOutage()
+		require.Len(t, m.FetchWorkloadUpdate(selectors).Identities, 1, "retain=%t", retain)
+
+		if retain {
+			// the synchronizer wakes up when the SVID expires
+			require.Equal(t, 20*time.Second, m.nextSyncInterval(1))
+		}
+
+		clk.Add(20 * time.Second)
+		m.reportOutage()
+		if retain {
+			// The expired SVID is dropped and workloads are notified
+			require.Empty(t, m.FetchWorkloadUpdate(selectors).Identities)
+			require.Empty(t, (<-sub.Updates()).Identities)
+			require.Len(t, m.cache.GetStaleEntries(), 1)
+		} else {
+			// Without the setting, the cache is served as is
+			require.Len(t, m.FetchWorkloadUpdate(selectors).Identities, 1)
+		}
+		sub.Finish()
+	}
+}
+
 func TestSVIDRotation(t *testing.T) {
 	dir := createTempDir(t)
 	defer removeTempDir(dir)
@@ -659,6 +1001,156 @@ func TestSynchronizationClearsStaleCacheEntries(t *testing.T) {
 		regEntriesFromIdentities(m.cache.Identities()))
 }
 
+func TestSynchronizationRenewsAllStaleEntriesInBatches(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	agentID := "spiffe://" + trustDomain + "/spire/agent/join_token/abcd"
+	var entries []*common.RegistrationEntry
+	for i := 0; i < node.CSRLimit+1; i++ {
+		entries = append(entries, &common.RegistrationEntry{
+			EntryId:   fmt.Sprintf("entry-%d", i),
+			ParentId:  agentID,
+			SpiffeId:  fmt.Sprintf("spiffe://%s/workload-%d", trustDomain, i),
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1111"}},
+		})
+	}
+
+	var batchSizes []int
+	clk := clock.NewMock(t)
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:           t,
+		trustDomain: trustDomain,
+		listener:    l,
+		fetchX509SVID: func(h *mockNodeAPIHandler, req *node.FetchX509SVIDRequest, stream node.Node_FetchX509SVIDServer) error {
+			if len(req.Csrs) > 0 {
+				batchSizes = append(batchSizes, len(req.Csrs))
+			}
+			return stream.Send(&node.FetchX509SVIDResponse{
+				SvidUpdate: &node.X509SVIDUpdate{
+					RegistrationEntries: entries,
+					Svids:               h.makeSvids(req.Csrs),
+					Bundles: map[string]*common.Bundle{
+						h.bundle.TrustDomainID(): h.bundle.Proto(),
+					},
+				},
+			})
+		},
+		svidTTL: 3,
+	}, clk)
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID(agentID, 1*time.Hour)
+	cat := fakeagentcatalog.New()
+	cat.SetKeyManager(fakeagentcatalog.KeyManager(memory.New()))
+
+	c := &Config{
+		ServerAddr:      l.Addr().String(),
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     trustDomainID,
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Bundle:          apiHandler.bundle,
+		Metrics:         &telemetry.Blackhole{},
+		Clk:             clk,
+		Catalog:         cat,
+	}
+
+	m := makeManager(t, c)
+
+	// Initialization synchronizes, renewing all of the entries without
+	// waiting for the next sync interval
+	if err := m.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	require.Equal(t, []int{node.CSRLimit, 1}, batchSizes)
+	require.Len(t, m.cache.Identities(), node.CSRLimit+1)
+	require.Empty(t, m.cache.GetStaleEntries())
+}
+
+func TestSynchronizationStopsBannedAgent(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	mockClk := clock.NewMock(t)
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:             t,
+		trustDomain:   trustDomain,
+		listener:      l,
+		fetchX509SVID: fetchX509SVIDForBannedAgentTest,
+		svidTTL:       3,
+	}, mockClk)
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+	cat := fakeagentcatalog.New()
+	km := disk.New()
+	_, err = km.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`directory = %q`, dir),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cat.SetKeyManager(fakeagentcatalog.KeyManager(km))
+
+	syncInterval := time.Minute
+	c := &Config{
+		ServerAddr:       l.Addr().String(),
+		SVID:             baseSVID,
+		SVIDKey:          baseSVIDKey,
+		Log:              testLogger,
+		TrustDomain:      trustDomainID,
+		SVIDCachePath:    path.Join(dir, "svid.der"),
+		BundleCachePath:  path.Join(dir, "bundle.der"),
+		Bundle:           apiHandler.bundle,
+		Metrics:          &telemetry.Blackhole{},
+		RotationInterval: 1 * time.Hour,
+		SyncInterval:     syncInterval,
+		Clk:              mockClk,
+		Catalog:          cat,
+	}
+
+	m := makeManager(t, c)
+	require.NoError(t, m.Initialize(context.Background()))
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- m.Run(context.Background())
+	}()
+
+	// The next synchronization finds out the agent has been banned, which
+	// stops the manager
+	timeout := time.After(time.Minute)
+	for {
+		mockClk.Add(syncInterval)
+		select {
+		case err := <-errCh:
+			require.True(t, nodeutil.IsAgentBannedError(err), "expected agent banned error; got %v", err)
+			return
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			t.Fatal("timed out waiting for the manager to stop")
+		}
+	}
+}
+
 func TestSynchronizationUpdatesRegistrationEntries(t *testing.T) {
 	dir := createTempDir(t)
 	defer removeTempDir(dir)
@@ -727,6 +1219,82 @@ func TestSynchronizationUpdatesRegistrationEntries(t *testing.T) {
 		regEntriesFromIdentities(m.cache.Identities()))
 }
 
+func TestSyncEventsTriggerSynchronization(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	defer l.Close()
+
+	clk := clock.NewMock(t)
+	syncEvents := make(chan *node.SyncEvent)
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:             t,
+		trustDomain:   trustDomain,
+		listener:      l,
+		fetchX509SVID: fetchX509SVIDForRegistrationEntryUpdateTest,
+		syncEvents:    syncEvents,
+		svidTTL:       3,
+	}, clk)
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+	cat := fakeagentcatalog.New()
+	km := disk.New()
+	_, err = km.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`directory = %q`, dir),
+	})
+	require.NoError(t, err)
+	cat.SetKeyManager(fakeagentcatalog.KeyManager(km))
+
+	c := &Config{
+		ServerAddr:       l.Addr().String(),
+		SVID:             baseSVID,
+		SVIDKey:          baseSVIDKey,
+		Log:              testLogger,
+		TrustDomain:      trustDomainID,
+		SVIDCachePath:    path.Join(dir, "svid.der"),
+		BundleCachePath:  path.Join(dir, "bundle.der"),
+		Bundle:           apiHandler.bundle,
+		Metrics:          &telemetry.Blackhole{},
+		Clk:              clk,
+		Catalog:          cat,
+		SyncInterval:     time.Hour,
+		RotationInterval: time.Hour,
+	}
+
+	m := makeManager(t, c)
+	closer := initializeAndRunManager(t, m)
+	defer closer()
+
+	compareRegistrationEntries(t,
+		regEntriesMap["resp2"],
+		regEntriesFromIdentities(m.cache.Identities()))
+
+	// The sync interval never elapses, so the entries can only be updated
+	// by the synchronization triggered by the event.
+	select {
+	case syncEvents <- &node.SyncEvent{EntriesChanged: true}:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for the agent to subscribe to sync events")
+	}
+
+	require.Eventually(t, func() bool {
+		actual := regEntriesAsMap(regEntriesFromIdentities(m.cache.Identities()))
+		for _, entry := range regEntriesMap["resp3"] {
+			if !proto.Equal(entry, actual[entry.EntryId]) {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	compareRegistrationEntries(t,
+		regEntriesMap["resp3"],
+		regEntriesFromIdentities(m.cache.Identities()))
+}
+
 func TestSubscribersGetUpToDateBundle(t *testing.T) {
 	dir := createTempDir(t)
 	defer removeTempDir(dir)
@@ -978,6 +1546,170 @@ func TestFetchJWTSVID(t *testing.T) {
 	require.Nil(t, svid)
 }
 
+func TestRenewJWTSVIDs(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	defer l.Close()
+
+	mockClk := clock.NewMock(t)
+	var requests []*node.JSR
+	var token string
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:           t,
+		trustDomain: trustDomain,
+		listener:    l,
+		fetchJWTSVID: func(h *mockNodeAPIHandler, req *node.FetchJWTSVIDRequest) (*node.FetchJWTSVIDResponse, error) {
+			requests = append(requests, req.Jsr)
+			now := mockClk.Now()
+			return &node.FetchJWTSVIDResponse{
+				Svid: &node.JWTSVID{
+					Token:     token,
+					IssuedAt:  now.Unix(),
+					ExpiresAt: now.Add(time.Minute).Unix(),
+				},
+			}, nil
+		},
+		svidTTL: 200,
+	}, mockClk)
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	metrics := fakemetrics.New()
+	m := makeManager(t, &Config{
+		ServerAddr:      l.Addr().String(),
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     trustDomainID,
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Bundle:          apiHandler.bundle,
+		Metrics:         metrics,
+		Clk:             mockClk,
+	})
+
+	ctx := context.Background()
+	spiffeID := "spiffe://example.org/workload"
+
+	// cache a JWT-SVID for two audiences, and only keep using the first one
+	token = "A"
+	_, err = m.FetchJWTSVID(ctx, spiffeID, []string{"foo"})
+	require.NoError(t, err)
+	_, err = m.FetchJWTSVID(ctx, spiffeID, []string{"bar"})
+	require.NoError(t, err)
+	svid, err := m.FetchJWTSVID(ctx, spiffeID, []string{"foo"})
+	require.NoError(t, err)
+	require.Equal(t, "A", svid.Token)
+	require.Len(t, requests, 2)
+
+	// nothing to renew before half of the lifetime
+	m.renewJWTSVIDs(ctx)
+	require.Len(t, requests, 2)
+
+	// the JWT-SVID in use is renewed at half of its lifetime
+	mockClk.Add(30 * time.Second)
+	token = "B"
+	m.renewJWTSVIDs(ctx)
+	require.Len(t, requests, 3)
+	require.Equal(t, []string{"foo"}, requests[2].Audience)
+
+	svid, err = m.FetchJWTSVID(ctx, spiffeID, []string{"foo"})
+	require.NoError(t, err)
+	require.Equal(t, "B", svid.Token)
+	require.Len(t, requests, 3)
+
+	// the unused JWT-SVID is dropped once expired
+	mockClk.Add(30 * time.Second)
+	m.renewJWTSVIDs(ctx)
+	require.Equal(t, 1, m.cache.CountJWTSVIDs())
+
+	require.Equal(t, 2, countMetrics(metrics, []string{telemetry.CacheManager, telemetry.JWTSVID, telemetry.CacheMiss}))
+	require.Equal(t, 2, countMetrics(metrics, []string{telemetry.CacheManager, telemetry.JWTSVID, telemetry.CacheHit}))
+	require.Contains(t, metrics.AllMetrics(), fakemetrics.MetricItem{
+		Type: fakemetrics.SetGaugeType,
+		Key:  []string{telemetry.CacheManager, telemetry.CachedJWTSVIDs},
+		Val:  1,
+	})
+}
+
+func TestJWTSVIDCacheMaxSize(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	defer l.Close()
+
+	mockClk := clock.NewMock(t)
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:           t,
+		trustDomain: trustDomain,
+		listener:    l,
+		fetchJWTSVID: func(h *mockNodeAPIHandler, req *node.FetchJWTSVIDRequest) (*node.FetchJWTSVIDResponse, error) {
+			now := mockClk.Now()
+			return &node.FetchJWTSVIDResponse{
+				Svid: &node.JWTSVID{
+					Token:     req.Jsr.Audience[0],
+					IssuedAt:  now.Unix(),
+					ExpiresAt: now.Add(time.Minute).Unix(),
+				},
+			}, nil
+		},
+		svidTTL: 200,
+	}, mockClk)
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	metrics := fakemetrics.New()
+	m := makeManager(t, &Config{
+		ServerAddr:          l.Addr().String(),
+		SVID:                baseSVID,
+		SVIDKey:             baseSVIDKey,
+		Log:                 testLogger,
+		TrustDomain:         trustDomainID,
+		SVIDCachePath:       path.Join(dir, "svid.der"),
+		BundleCachePath:     path.Join(dir, "bundle.der"),
+		Bundle:              apiHandler.bundle,
+		Metrics:             metrics,
+		Clk:                 mockClk,
+		JWTSVIDCacheMaxSize: 2,
+	})
+
+	ctx := context.Background()
+	for _, audience := range []string{"a", "b", "c"} {
+		_, err := m.FetchJWTSVID(ctx, "spiffe://example.org/workload", []string{audience})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, m.cache.CountJWTSVIDs())
+	_, ok := m.cache.GetJWTSVID("spiffe://example.org/workload", []string{"a"})
+	require.False(t, ok)
+
+	require.Contains(t, metrics.AllMetrics(), fakemetrics.MetricItem{
+		Type: fakemetrics.IncrCounterType,
+		Key:  []string{telemetry.CacheManager, telemetry.JWTSVID, telemetry.Evicted},
+		Val:  1,
+	})
+}
+
+func countMetrics(metrics *fakemetrics.FakeMetrics, key []string) int {
+	count := 0
+	for _, metric := range metrics.AllMetrics() {
+		if reflect.DeepEqual(metric.Key, key) {
+			count++
+		}
+	}
+	return count
+}
+
 func fetchX509SVIDForTestHappyPathWithoutSyncNorRotation(h *mockNodeAPIHandler, req *node.FetchX509SVIDRequest, stream node.Node_FetchX509SVIDServer) error {
 	switch h.getCountRequest() {
 	case 1:
@@ -1044,6 +1776,14 @@ func fetchX509SVIDForStaleCacheTest(h *mockNodeAPIHandler, req *node.FetchX509SV
 	return stream.Send(newFetchX509SVIDResponse(nil, nil, h.bundle))
 }
 
+func fetchX509SVIDForBannedAgentTest(h *mockNodeAPIHandler, req *node.FetchX509SVIDRequest, stream node.Node_FetchX509SVIDServer) error {
+	switch h.getCountRequest() {
+	case 1:
+		return stream.Send(newFetchX509SVIDResponse(nil, nil, h.bundle))
+	}
+	return nodeutil.AgentBannedError()
+}
+
 func fetchX509SVIDForRegistrationEntryUpdateTest(h *mockNodeAPIHandler, req *node.FetchX509SVIDRequest, stream node.Node_FetchX509SVIDServer) error {
 	svids := h.makeSvids(req.Csrs)
 
@@ -1157,6 +1897,10 @@ type mockNodeAPIHandlerConfig struct {
 	fetchX509SVID func(*mockNodeAPIHandler, *node.FetchX509SVIDRequest, node.Node_FetchX509SVIDServer) error
 	fetchJWTSVID  func(*mockNodeAPIHandler, *node.FetchJWTSVIDRequest) (*node.FetchJWTSVIDResponse, error)
 
+	// Events sent to the agents subscribed to sync events. If nil, the
+	// handler behaves like a server that does not push sync events.
+	syncEvents chan *node.SyncEvent
+
 	svidTTL int
 }
 
@@ -1265,6 +2009,22 @@ func (h *mockNodeAPIHandler) FetchBundle(ctx context.Context, req *node.FetchBun
 	return nil, errors.New("oh noes")
 }
 
+func (h *mockNodeAPIHandler) SubscribeToSyncEvents(req *node.SubscribeToSyncEventsRequest, stream node.Node_SubscribeToSyncEventsServer) error {
+	if h.c.syncEvents == nil {
+		return status.Error(codes.Unimplemented, "unimplemented")
+	}
+	for {
+		select {
+		case event := <-h.c.syncEvents:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
 func (h *mockNodeAPIHandler) start() {
 	s := grpc.NewServer(h.creds)
 	node.RegisterNodeServer(s, h)
@@ -2,12 +2,23 @@ package manager
 
 import (
 	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/diskutil"
+	"github.com/spiffe/spire/proto/spire/common"
 )
 
 // ReadBundle returns the bundle located at bundleCachePath. Returns nil
@@ -71,3 +82,159 @@ func StoreSVID(svidCachePath string, svidChain []*x509.Certificate) error {
 	}
 	return diskutil.AtomicWriteFile(svidCachePath, data.Bytes(), 0600)
 }
+
+// DeleteSVID removes the SVID stored at svidCachePath. It is not an error if
+// no SVID is stored there.
+func DeleteSVID(svidCachePath string) error {
+	if err := os.Remove(svidCachePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error deleting SVID at %s: %s", svidCachePath, err)
+	}
+	return nil
+}
+
+// cacheSnapshot is the set of identities and bundles persisted to disk so
+// the agent can serve the workload API immediately after a restart.
+type cacheSnapshot struct {
+	Identities []cache.Identity
+	Bundles    map[string]*cache.Bundle
+}
+
+type cacheSnapshotData struct {
+	Identities []identityData `json:"identities"`
+	Bundles    [][]byte       `json:"bundles"`
+}
+
+type identityData struct {
+	Entry      []byte `json:"entry"`
+	SVID       []byte `json:"svid"`
+	PrivateKey []byte `json:"private_key"`
+}
+
+// readCacheSnapshot reads and decrypts the cache snapshot located at
+// cachePath. The snapshot is decrypted with a key derived from the agent
+// private key. Returns ErrNotCached if no snapshot exists.
+func readCacheSnapshot(cachePath string, agentKey *ecdsa.PrivateKey) (*cacheSnapshot, error) {
+	sealed, err := ioutil.ReadFile(cachePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotCached
+		}
+		return nil, fmt.Errorf("error reading cache at %s: %v", cachePath, err)
+	}
+
+	aead, err := newCacheAEAD(agentKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("error decrypting cache at %s: data too short", cachePath)
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	data, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting cache at %s: %v", cachePath, err)
+	}
+
+	snapshotData := new(cacheSnapshotData)
+	if err := json.Unmarshal(data, snapshotData); err != nil {
+		return nil, fmt.Errorf("error parsing cache at %s: %v", cachePath, err)
+	}
+
+	snapshot := &cacheSnapshot{
+		Bundles: make(map[string]*cache.Bundle, len(snapshotData.Bundles)),
+	}
+	for _, bundleBytes := range snapshotData.Bundles {
+		bundle, err := bundleutil.ParseBundle(bundleBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing cached bundle: %v", err)
+		}
+		snapshot.Bundles[bundle.TrustDomainID()] = bundle
+	}
+	for _, identity := range snapshotData.Identities {
+		entry := new(common.RegistrationEntry)
+		if err := proto.Unmarshal(identity.Entry, entry); err != nil {
+			return nil, fmt.Errorf("error parsing cached entry: %v", err)
+		}
+		svid, err := x509.ParseCertificates(identity.SVID)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing cached SVID for entry %q: %v", entry.EntryId, err)
+		}
+		privateKey, err := x509.ParseECPrivateKey(identity.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing cached private key for entry %q: %v", entry.EntryId, err)
+		}
+		snapshot.Identities = append(snapshot.Identities, cache.Identity{
+			Entry:      entry,
+			SVID:       svid,
+			PrivateKey: privateKey,
+		})
+	}
+
+	return snapshot, nil
+}
+
+// storeCacheSnapshot encrypts the cache snapshot with a key derived from the
+// agent private key and writes it to disk into cachePath.
+func storeCacheSnapshot(cachePath string, agentKey *ecdsa.PrivateKey, snapshot *cacheSnapshot) error {
+	snapshotData := new(cacheSnapshotData)
+	for _, bundle := range snapshot.Bundles {
+		bundleBytes, err := proto.Marshal(bundle.Proto())
+		if err != nil {
+			return fmt.Errorf("error marshaling bundle: %v", err)
+		}
+		snapshotData.Bundles = append(snapshotData.Bundles, bundleBytes)
+	}
+	for _, identity := range snapshot.Identities {
+		entryBytes, err := proto.Marshal(identity.Entry)
+		if err != nil {
+			return fmt.Errorf("error marshaling entry: %v", err)
+		}
+		keyBytes, err := x509.MarshalECPrivateKey(identity.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("error marshaling private key for entry %q: %v", identity.Entry.EntryId, err)
+		}
+		svid := &bytes.Buffer{}
+		for _, cert := range identity.SVID {
+			svid.Write(cert.Raw)
+		}
+		snapshotData.Identities = append(snapshotData.Identities, identityData{
+			Entry:      entryBytes,
+			SVID:       svid.Bytes(),
+			PrivateKey: keyBytes,
+		})
+	}
+
+	data, err := json.Marshal(snapshotData)
+	if err != nil {
+		return fmt.Errorf("error marshaling cache: %v", err)
+	}
+
+	aead, err := newCacheAEAD(agentKey)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("error generating nonce: %v", err)
+	}
+
+	return diskutil.AtomicWriteFile(cachePath, aead.Seal(nonce, nonce, data, nil), 0600)
+}
+
+// newCacheAEAD returns the AES-GCM cipher used to seal the cache snapshot.
+// The key is derived from the agent private key, so a snapshot can only be
+// read back by an agent holding the same key.
+func newCacheAEAD(agentKey *ecdsa.PrivateKey) (cipher.AEAD, error) {
+	if agentKey == nil {
+		return nil, errors.New("agent key is required to encrypt the cache")
+	}
+	h := sha256.New()
+	_, _ = h.Write([]byte("spire-agent-cache"))
+	_, _ = h.Write(agentKey.D.Bytes())
+
+	block, err := aes.NewCipher(h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -1,10 +1,19 @@
 package manager
 
 import (
+	"crypto/x509"
+	"io/ioutil"
+	"os"
 	"path"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/testkey"
 	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
 )
 
 func TestReadBundle(t *testing.T) {
@@ -31,3 +40,71 @@ func TestReadBundle(t *testing.T) {
 		}
 	}
 }
+
+func TestDeleteSVID(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-agent-storage-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	svidPath := path.Join(dir, "agent_svid.der")
+	svid, _, err := util.LoadSVIDFixture()
+	require.NoError(t, err)
+	require.NoError(t, StoreSVID(svidPath, []*x509.Certificate{svid}))
+
+	require.NoError(t, DeleteSVID(svidPath))
+	_, err = ReadSVID(svidPath)
+	require.Equal(t, ErrNotCached, err)
+
+	// deleting a missing SVID is not an error
+	require.NoError(t, DeleteSVID(svidPath))
+}
+
+func TestCacheSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-agent-storage-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cachePath := path.Join(dir, "cache.dat")
+	agentKey := testkey.NewEC256(t)
+
+	_, err = readCacheSnapshot(cachePath, agentKey)
+	require.Equal(t, ErrNotCached, err)
+
+	svid, svidKey, err := util.LoadSVIDFixture()
+	require.NoError(t, err)
+	caCert, _, err := util.LoadCAFixture()
+	require.NoError(t, err)
+
+	entry := &common.RegistrationEntry{
+		EntryId:   "ENTRYID",
+		ParentId:  "spiffe://example.org/agent",
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	}
+	bundle := bundleutil.BundleFromRootCA("spiffe://example.org", caCert)
+	snapshot := &cacheSnapshot{
+		Identities: []cache.Identity{
+			{Entry: entry, SVID: []*x509.Certificate{svid}, PrivateKey: svidKey},
+		},
+		Bundles: map[string]*cache.Bundle{"spiffe://example.org": bundle},
+	}
+	require.NoError(t, storeCacheSnapshot(cachePath, agentKey, snapshot))
+
+	// the snapshot is not stored in the clear
+	data, err := ioutil.ReadFile(cachePath)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), entry.SpiffeId)
+
+	restored, err := readCacheSnapshot(cachePath, agentKey)
+	require.NoError(t, err)
+	require.Len(t, restored.Identities, 1)
+	require.True(t, proto.Equal(entry, restored.Identities[0].Entry))
+	require.Equal(t, []*x509.Certificate{svid}, restored.Identities[0].SVID)
+	require.Equal(t, svidKey, restored.Identities[0].PrivateKey)
+	require.Len(t, restored.Bundles, 1)
+	require.True(t, bundle.EqualTo(restored.Bundles["spiffe://example.org"]))
+
+	// the snapshot cannot be read with a different agent key
+	_, err = readCacheSnapshot(cachePath, testkey.NewEC256(t))
+	require.Error(t, err)
+}
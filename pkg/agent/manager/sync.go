@@ -27,6 +27,9 @@ type csrRequest struct {
 
 // synchronize hits the node api, checks for entries we haven't fetched yet, and fetches them.
 func (m *manager) synchronize(ctx context.Context) (err error) {
+	counter := telemetry_agent.StartManagerSyncCall(m.c.Metrics)
+	defer counter.Done(&err)
+
 	update, err := m.fetchEntries(ctx)
 	if err != nil {
 		return err
@@ -36,7 +39,6 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 	// in this interval.
 	//
 	// the values in `update` now belong to the cache. DO NOT MODIFY.
-	var csrs []csrRequest
 	var expiring int
 	var outdated int
 	m.cache.UpdateEntries(update, func(existingEntry, newEntry *common.RegistrationEntry, svid *cache.X509SVID) bool {
@@ -49,7 +51,7 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 				telemetry.RegistrationID: newEntry.EntryId,
 				telemetry.SPIFFEID:       newEntry.SpiffeId,
 			}).Warn("cached X509 SVID is empty")
-		case rotationutil.ShouldRotateX509(m.c.Clk.Now(), svid.Chain[0]):
+		case rotationutil.ShouldRotateX509(m.c.Clk.Now(), svid.Chain[0], m.c.SVIDRenewalThreshold):
 			expiring++
 		case existingEntry != nil && !stringsEqual(existingEntry.DnsNames, newEntry.DnsNames):
 			// DNS Names have changed
@@ -81,26 +83,36 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 			telemetry.Count: len(staleEntries),
 			telemetry.Limit: node.CSRLimit,
 		}).Debug("Renewing stale entries")
-		for _, staleEntry := range staleEntries {
-			// we've exceeded the CSR limit, don't make any more CSRs
-			if len(csrs) >= node.CSRLimit {
-				break
-			}
 
-			csrs = append(csrs, csrRequest{
-				EntryID:              staleEntry.Entry.EntryId,
-				SpiffeID:             staleEntry.Entry.SpiffeId,
-				CurrentSVIDExpiresAt: staleEntry.ExpiresAt,
-			})
-		}
+		// All the stale entries are renewed right away, sending as many CSRs
+		// in each request as the server accepts, instead of leaving the
+		// entries over the limit for the next sync interval.
+		for len(staleEntries) > 0 {
+			batch := staleEntries
+			if len(batch) > node.CSRLimit {
+				batch = batch[:node.CSRLimit]
+			}
+			staleEntries = staleEntries[len(batch):]
+
+			csrs := make([]csrRequest, 0, len(batch))
+			for _, staleEntry := range batch {
+				csrs = append(csrs, csrRequest{
+					EntryID:              staleEntry.Entry.EntryId,
+					SpiffeID:             staleEntry.Entry.SpiffeId,
+					CurrentSVIDExpiresAt: staleEntry.ExpiresAt,
+				})
+			}
 
-		update, err := m.fetchSVIDs(ctx, csrs)
-		if err != nil {
-			return err
+			update, err := m.fetchSVIDs(ctx, csrs)
+			if err != nil {
+				return err
+			}
+			// the values in `update` now belong to the cache. DO NOT MODIFY.
+			m.cache.UpdateSVIDs(update)
 		}
-		// the values in `update` now belong to the cache. DO NOT MODIFY.
-		m.cache.UpdateSVIDs(update)
 	}
+
+	m.storeCache()
 	return nil
 }
 
@@ -175,6 +187,11 @@ func (m *manager) fetchEntries(ctx context.Context) (_ *cache.UpdateEntries, err
 		return nil, err
 	}
 
+	if update.RotateAgentSVID {
+		m.c.Log.Info("Server requested the rotation of the agent SVID")
+		m.svid.ForceRotation()
+	}
+
 	bundles, err := parseBundles(update.Bundles)
 	if err != nil {
 		return nil, err
@@ -13,6 +13,7 @@ import (
 	"sync"
 
 	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/agent/common/keymem"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/diskutil"
@@ -100,6 +101,7 @@ func (d *Plugin) FetchPrivateKey(context.Context, *keymanager.FetchPrivateKeyReq
 	if err != nil {
 		return nil, err
 	}
+	defer keymem.Wipe(data)
 
 	// Check key integrity first
 	key, err := x509.ParseECPrivateKey(data)
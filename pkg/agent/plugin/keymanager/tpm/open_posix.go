@@ -0,0 +1,13 @@
+// +build !windows
+
+package tpm
+
+import (
+	"io"
+
+	"github.com/google/go-tpm/tpm2"
+)
+
+func openTPM(devicePath string) (io.ReadWriteCloser, error) {
+	return tpm2.OpenTPM(devicePath)
+}
@@ -0,0 +1,15 @@
+// +build windows
+
+package tpm
+
+import (
+	"io"
+
+	"github.com/google/go-tpm/tpm2"
+)
+
+// openTPM opens the TPM through the TPM Base Services, which do not take a
+// device path.
+func openTPM(devicePath string) (io.ReadWriteCloser, error) {
+	return tpm2.OpenTPM()
+}
@@ -0,0 +1,134 @@
+package tpm
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpmutil"
+)
+
+var (
+	// srkTemplate is the storage root key template from the TCG TPM v2.0
+	// Provisioning Guidance. Since primary key creation is deterministic,
+	// the same key is recreated on every call without being persisted.
+	srkTemplate = tpm2.Public{
+		Type:       tpm2.AlgECC,
+		NameAlg:    tpm2.AlgSHA256,
+		Attributes: tpm2.FlagStorageDefault | tpm2.FlagNoDA,
+		ECCParameters: &tpm2.ECCParams{
+			Symmetric: &tpm2.SymScheme{
+				Alg:     tpm2.AlgAES,
+				KeyBits: 128,
+				Mode:    tpm2.AlgCFB,
+			},
+			CurveID: tpm2.CurveNISTP256,
+		},
+	}
+)
+
+// sealer seals small secrets so that they can only be recovered on the same
+// machine.
+type sealer interface {
+	Seal(data []byte) (public, private []byte, err error)
+	Unseal(public, private []byte) ([]byte, error)
+}
+
+// tpmSealer seals data to the storage hierarchy of a TPM 2.0. The device is
+// opened for each operation so that other processes can share the TPM.
+type tpmSealer struct {
+	devicePath string
+}
+
+func newTPMSealer(devicePath string) (sealer, error) {
+	rw, err := openTPM(devicePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open TPM at %q: %v", devicePath, err)
+	}
+	rw.Close()
+
+	return &tpmSealer{devicePath: devicePath}, nil
+}
+
+func (s *tpmSealer) Seal(data []byte) (public, private []byte, err error) {
+	err = s.withSRK(func(rw io.ReadWriter, srk tpmutil.Handle) error {
+		policy, err := passwordPolicyDigest(rw)
+		if err != nil {
+			return err
+		}
+
+		private, public, err = tpm2.Seal(rw, srk, "", "", policy, data)
+		return err
+	})
+	return public, private, err
+}
+
+func (s *tpmSealer) Unseal(public, private []byte) (data []byte, err error) {
+	err = s.withSRK(func(rw io.ReadWriter, srk tpmutil.Handle) error {
+		object, _, err := tpm2.Load(rw, srk, "", public, private)
+		if err != nil {
+			return fmt.Errorf("unable to load sealed object: %v", err)
+		}
+		defer tpm2.FlushContext(rw, object) //nolint: errcheck
+
+		session, err := passwordPolicySession(rw, tpm2.SessionPolicy)
+		if err != nil {
+			return err
+		}
+		defer tpm2.FlushContext(rw, session) //nolint: errcheck
+
+		data, err = tpm2.UnsealWithSession(rw, session, object, "")
+		return err
+	})
+	return data, err
+}
+
+func (s *tpmSealer) withSRK(fn func(rw io.ReadWriter, srk tpmutil.Handle) error) error {
+	rw, err := openTPM(s.devicePath)
+	if err != nil {
+		return err
+	}
+	defer rw.Close()
+
+	srk, _, err := tpm2.CreatePrimary(rw, tpm2.HandleOwner, tpm2.PCRSelection{}, "", "", srkTemplate)
+	if err != nil {
+		return fmt.Errorf("unable to create storage root key: %v", err)
+	}
+	defer tpm2.FlushContext(rw, srk) //nolint: errcheck
+
+	return fn(rw, srk)
+}
+
+// passwordPolicyDigest returns the digest of a policy that only requires the
+// (empty) object password.
+func passwordPolicyDigest(rw io.ReadWriter) ([]byte, error) {
+	session, err := passwordPolicySession(rw, tpm2.SessionTrial)
+	if err != nil {
+		return nil, err
+	}
+	defer tpm2.FlushContext(rw, session) //nolint: errcheck
+
+	return tpm2.PolicyGetDigest(rw, session)
+}
+
+func passwordPolicySession(rw io.ReadWriter, sessionType tpm2.SessionType) (tpmutil.Handle, error) {
+	session, _, err := tpm2.StartAuthSession(
+		rw,
+		tpm2.HandleNull,
+		tpm2.HandleNull,
+		make([]byte, 16),
+		nil,
+		sessionType,
+		tpm2.AlgNull,
+		tpm2.AlgSHA256)
+	if err != nil {
+		return tpm2.HandleNull, fmt.Errorf("unable to start auth session: %v", err)
+	}
+
+	if err := tpm2.PolicyPassword(rw, session); err != nil {
+		tpm2.FlushContext(rw, session) //nolint: errcheck
+		return tpm2.HandleNull, fmt.Errorf("unable to apply password policy: %v", err)
+	}
+
+	return session, nil
+}
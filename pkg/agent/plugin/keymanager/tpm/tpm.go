@@ -0,0 +1,258 @@
+package tpm
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/agent/common/keymem"
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/diskutil"
+
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+)
+
+const (
+	pluginName = "tpm"
+
+	keyFileName = "svid.key.tpm"
+
+	defaultDevicePath = "/dev/tpmrm0"
+
+	// dataKeySize is the size of the AES-256 key sealed to the TPM that
+	// encrypts the agent private key on disk.
+	dataKeySize = 32
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin(pluginName, keymanager.PluginServer(p))
+}
+
+type Config struct {
+	Directory  string `hcl:"directory" json:"directory"`
+	DevicePath string `hcl:"device_path" json:"device_path"`
+}
+
+// sealedKey is the on-disk representation of the agent private key. The
+// private key is encrypted with a random data key, which is in turn sealed to
+// the TPM. Only the TPM that sealed the data key can unseal it.
+type sealedKey struct {
+	SealedPublic  []byte `json:"sealed_public"`
+	SealedPrivate []byte `json:"sealed_private"`
+	Ciphertext    []byte `json:"ciphertext"`
+}
+
+type Plugin struct {
+	mtx    sync.RWMutex
+	dir    string
+	sealer sealer
+
+	// hook used by tests to replace the TPM
+	newSealer func(devicePath string) (sealer, error)
+}
+
+func New() *Plugin {
+	return &Plugin{
+		newSealer: newTPMSealer,
+	}
+}
+
+func (p *Plugin) GenerateKeyPair(context.Context, *keymanager.GenerateKeyPairRequest) (*keymanager.GenerateKeyPairResponse, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	privData, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	pubData, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &keymanager.GenerateKeyPairResponse{PublicKey: pubData, PrivateKey: privData}
+	return resp, nil
+}
+
+func (p *Plugin) StorePrivateKey(ctx context.Context, req *keymanager.StorePrivateKeyRequest) (*keymanager.StorePrivateKeyResponse, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.sealer == nil {
+		return nil, errors.New("not configured")
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	defer keymem.Wipe(dataKey)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := encrypt(dataKey, req.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sealedPublic, sealedPrivate, err := p.sealer.Seal(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to seal key to TPM: %v", err)
+	}
+
+	data, err := json.Marshal(&sealedKey{
+		SealedPublic:  sealedPublic,
+		SealedPrivate: sealedPrivate,
+		Ciphertext:    ciphertext,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := diskutil.AtomicWriteFile(filepath.Join(p.dir, keyFileName), data, 0600); err != nil {
+		return nil, err
+	}
+
+	return &keymanager.StorePrivateKeyResponse{}, nil
+}
+
+func (p *Plugin) FetchPrivateKey(context.Context, *keymanager.FetchPrivateKeyRequest) (*keymanager.FetchPrivateKeyResponse, error) {
+	// Start with empty response
+	resp := &keymanager.FetchPrivateKeyResponse{PrivateKey: []byte{}}
+
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	if p.sealer == nil {
+		return nil, errors.New("not configured")
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(p.dir, keyFileName))
+	switch {
+	case os.IsNotExist(err):
+		return resp, nil
+	case err != nil:
+		return nil, err
+	}
+
+	stored := new(sealedKey)
+	if err := json.Unmarshal(data, stored); err != nil {
+		return nil, fmt.Errorf("unable to decode sealed key: %v", err)
+	}
+
+	dataKey, err := p.sealer.Unseal(stored.SealedPublic, stored.SealedPrivate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unseal key from TPM: %v", err)
+	}
+	defer keymem.Wipe(dataKey)
+
+	privData, err := decrypt(dataKey, stored.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt private key: %v", err)
+	}
+	defer keymem.Wipe(privData)
+
+	// Check key integrity first
+	key, err := x509.ParseECPrivateKey(privData)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.PrivateKey, _ = x509.MarshalECPrivateKey(key)
+	return resp, nil
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := &Config{}
+	hclTree, err := hcl.Parse(req.Configuration)
+	if err != nil {
+		return nil, err
+	}
+	err = hcl.DecodeObject(&config, hclTree)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Directory == "" {
+		return nil, errors.New("directory is required")
+	}
+	if config.DevicePath == "" {
+		config.DevicePath = defaultDevicePath
+	}
+
+	s, err := p.newSealer(config.DevicePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create directory in which to store the sealed private key if not exists
+	if err := os.MkdirAll(config.Directory, 0755); err != nil {
+		return nil, err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.dir = config.Directory
+	p.sealer = s
+
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func encrypt(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func decrypt(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,183 @@
+package tpm
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+)
+
+var (
+	ctx = context.Background()
+)
+
+func TestTPM_StoreAndFetchPrivateKey(t *testing.T) {
+	plugin, dir := newTestPlugin(t, newFakeSealer())
+	defer os.RemoveAll(dir)
+
+	genResp, err := plugin.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{})
+	require.NoError(t, err)
+	_, err = x509.ParseECPrivateKey(genResp.PrivateKey)
+	require.NoError(t, err)
+
+	_, err = plugin.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{PrivateKey: genResp.PrivateKey})
+	require.NoError(t, err)
+
+	// The private key must not be recoverable from the data directory alone
+	fileData, err := ioutil.ReadFile(filepath.Join(dir, keyFileName))
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(fileData, genResp.PrivateKey))
+
+	fetchResp, err := plugin.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, genResp.PrivateKey, fetchResp.PrivateKey)
+}
+
+func TestTPM_FetchPrivateKeyWithoutStoredKey(t *testing.T) {
+	plugin, dir := newTestPlugin(t, newFakeSealer())
+	defer os.RemoveAll(dir)
+
+	fetchResp, err := plugin.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, fetchResp.PrivateKey)
+}
+
+func TestTPM_FetchPrivateKeyFromAnotherTPM(t *testing.T) {
+	plugin, dir := newTestPlugin(t, newFakeSealer())
+	defer os.RemoveAll(dir)
+
+	genResp, err := plugin.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{})
+	require.NoError(t, err)
+	_, err = plugin.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{PrivateKey: genResp.PrivateKey})
+	require.NoError(t, err)
+
+	// Simulate the data directory being copied to a different node
+	plugin.sealer = newFakeSealer()
+	_, err = plugin.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{})
+	require.EqualError(t, err, "unable to unseal key from TPM: integrity check failed")
+}
+
+func TestTPM_FetchPrivateKeyTampered(t *testing.T) {
+	plugin, dir := newTestPlugin(t, newFakeSealer())
+	defer os.RemoveAll(dir)
+
+	genResp, err := plugin.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{})
+	require.NoError(t, err)
+	_, err = plugin.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{PrivateKey: genResp.PrivateKey})
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, keyFileName), []byte("{"), 0600))
+	_, err = plugin.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to decode sealed key")
+}
+
+func TestTPM_NotConfigured(t *testing.T) {
+	plugin := New()
+
+	_, err := plugin.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{})
+	require.EqualError(t, err, "not configured")
+
+	_, err = plugin.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{})
+	require.EqualError(t, err, "not configured")
+}
+
+func TestTPM_Configure(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "km-tpm-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	keysDir := filepath.Join(tempDir, "keys")
+
+	var devicePath string
+	plugin := New()
+	plugin.newSealer = func(path string) (sealer, error) {
+		devicePath = path
+		return newFakeSealer(), nil
+	}
+
+	_, err = plugin.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf("directory = %q", keysDir),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, keysDir, plugin.dir)
+	assert.Equal(t, defaultDevicePath, devicePath)
+	assert.DirExists(t, keysDir)
+
+	_, err = plugin.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf("directory = %q\ndevice_path = \"/dev/tpm0\"", keysDir),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/dev/tpm0", devicePath)
+}
+
+func TestTPM_ConfigureErrors(t *testing.T) {
+	plugin := New()
+	plugin.newSealer = func(string) (sealer, error) {
+		return nil, errors.New("no TPM")
+	}
+
+	_, err := plugin.Configure(ctx, &spi.ConfigureRequest{})
+	require.EqualError(t, err, "directory is required")
+
+	_, err = plugin.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: `directory = "/tmp/keys"`,
+	})
+	require.EqualError(t, err, "no TPM")
+	assert.Nil(t, plugin.sealer)
+}
+
+func newTestPlugin(t *testing.T, s sealer) (*Plugin, string) {
+	dir, err := ioutil.TempDir("", "km-tpm-test")
+	require.NoError(t, err)
+
+	plugin := New()
+	plugin.dir = dir
+	plugin.sealer = s
+	return plugin, dir
+}
+
+// fakeSealer emulates a TPM by encrypting sealed data with a secret that never
+// leaves the fake.
+type fakeSealer struct {
+	secret []byte
+}
+
+func newFakeSealer() *fakeSealer {
+	secret := make([]byte, dataKeySize)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+	return &fakeSealer{secret: secret}
+}
+
+func (s *fakeSealer) Seal(data []byte) ([]byte, []byte, error) {
+	private, err := encrypt(s.secret, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []byte("public"), private, nil
+}
+
+func (s *fakeSealer) Unseal(public, private []byte) ([]byte, error) {
+	if string(public) != "public" {
+		return nil, errors.New("bad public area")
+	}
+	data, err := decrypt(s.secret, private)
+	if err != nil {
+		return nil, errors.New("integrity check failed")
+	}
+	return data, nil
+}
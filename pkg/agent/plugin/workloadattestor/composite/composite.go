@@ -0,0 +1,192 @@
+package composite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/hcl/hcl/printer"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/docker"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/k8s"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/unix"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/windows"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/zeebo/errs"
+)
+
+const (
+	pluginName = "composite"
+)
+
+var (
+	compositeErr = errs.Class("composite")
+)
+
+// builtIns are the workload attestors that can be composed, by name.
+var builtIns = map[string]func() workloadattestor.Plugin{
+	"docker":  func() workloadattestor.Plugin { return docker.New() },
+	"k8s":     func() workloadattestor.Plugin { return k8s.New() },
+	"unix":    func() workloadattestor.Plugin { return unix.New() },
+	"windows": func() workloadattestor.Plugin { return windows.New() },
+}
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin(pluginName, workloadattestor.PluginServer(p))
+}
+
+// Configuration is the composite plugin configuration. Each attestor is
+// configured with its own plugin data, the same way it would be configured
+// as a standalone workload attestor.
+type Configuration struct {
+	Attestors map[string]AttestorConfig `hcl:"attestors"`
+}
+
+type AttestorConfig struct {
+	PluginData ast.Node `hcl:"plugin_data"`
+}
+
+type attestor struct {
+	name   string
+	plugin workloadattestor.Plugin
+}
+
+type Plugin struct {
+	log hclog.Logger
+
+	mu        sync.RWMutex
+	attestors []attestor
+
+	// hooks for tests
+	hooks struct {
+		newAttestor func(name string) (workloadattestor.Plugin, bool)
+	}
+}
+
+func New() *Plugin {
+	p := &Plugin{
+		log: hclog.NewNullLogger(),
+	}
+	p.hooks.newAttestor = func(name string) (workloadattestor.Plugin, bool) {
+		newPlugin, ok := builtIns[name]
+		if !ok {
+			return nil, false
+		}
+		return newPlugin(), true
+	}
+	return p
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+// Attest invokes each of the composed attestors. Unlike attestation with
+// standalone attestors, the selectors are only returned if every attestor
+// succeeds, so entries using composite selectors never match on a partial
+// view of the workload.
+func (p *Plugin) Attest(ctx context.Context, req *workloadattestor.AttestRequest) (*workloadattestor.AttestResponse, error) {
+	attestors, err := p.getAttestors()
+	if err != nil {
+		return nil, err
+	}
+
+	var selectors []*common.Selector
+	for _, a := range attestors {
+		resp, err := a.plugin.Attest(ctx, req)
+		if err != nil {
+			return nil, compositeErr.New("%q attestor failed: %v", a.name, err)
+		}
+		for _, selector := range resp.Selectors {
+			selectors = append(selectors, &common.Selector{
+				Type:  pluginName,
+				Value: fmt.Sprintf("%s:%s", selector.Type, selector.Value),
+			})
+		}
+	}
+
+	return &workloadattestor.AttestResponse{
+		Selectors: selectors,
+	}, nil
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(Configuration)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, compositeErr.New("unable to decode configuration: %v", err)
+	}
+
+	if len(config.Attestors) == 0 {
+		return nil, compositeErr.New("at least one attestor must be configured")
+	}
+
+	names := make([]string, 0, len(config.Attestors))
+	for name := range config.Attestors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attestors := make([]attestor, 0, len(names))
+	for _, name := range names {
+		plugin, ok := p.hooks.newAttestor(name)
+		if !ok {
+			return nil, compositeErr.New("unknown attestor %q", name)
+		}
+
+		if withLogger, ok := plugin.(interface{ SetLogger(hclog.Logger) }); ok {
+			withLogger.SetLogger(p.log.Named(name))
+		}
+
+		var data bytes.Buffer
+		if pluginData := config.Attestors[name].PluginData; pluginData != nil {
+			if err := printer.DefaultConfig.Fprint(&data, pluginData); err != nil {
+				return nil, compositeErr.New("unable to encode %q attestor configuration: %v", name, err)
+			}
+		}
+
+		if _, err := plugin.Configure(ctx, &spi.ConfigureRequest{
+			Configuration: data.String(),
+			GlobalConfig:  req.GlobalConfig,
+		}); err != nil {
+			return nil, compositeErr.New("unable to configure %q attestor: %v", name, err)
+		}
+
+		attestors = append(attestors, attestor{
+			name:   name,
+			plugin: plugin,
+		})
+	}
+
+	p.setAttestors(attestors)
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *Plugin) getAttestors() ([]attestor, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.attestors == nil {
+		return nil, compositeErr.New("not configured")
+	}
+	return p.attestors, nil
+}
+
+func (p *Plugin) setAttestors(attestors []attestor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.attestors = attestors
+}
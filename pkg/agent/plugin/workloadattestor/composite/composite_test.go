@@ -0,0 +1,192 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	ctx = context.Background()
+)
+
+func TestPlugin(t *testing.T) {
+	spiretest.Run(t, new(Suite))
+}
+
+type Suite struct {
+	spiretest.Suite
+
+	fakes map[string]*fakeAttestor
+	p     workloadattestor.Plugin
+}
+
+func (s *Suite) SetupTest() {
+	s.fakes = map[string]*fakeAttestor{
+		"unix": {
+			selectors: []*common.Selector{
+				{Type: "unix", Value: "uid:1000"},
+				{Type: "unix", Value: "gid:1000"},
+			},
+		},
+		"docker": {
+			selectors: []*common.Selector{
+				{Type: "docker", Value: "label:app:web"},
+			},
+		},
+		"k8s": {
+			attestErr: errors.New("oh no"),
+		},
+	}
+
+	p := New()
+	p.hooks.newAttestor = func(name string) (workloadattestor.Plugin, bool) {
+		fake, ok := s.fakes[name]
+		return fake, ok
+	}
+	s.LoadPlugin(builtin(p), &s.p)
+}
+
+func (s *Suite) TestAttestNotConfigured() {
+	resp, err := s.p.Attest(ctx, &workloadattestor.AttestRequest{Pid: 1})
+	s.RequireGRPCStatus(err, codes.Unknown, "composite: not configured")
+	s.Nil(resp)
+}
+
+func (s *Suite) TestAttest() {
+	s.configure(`
+		attestors {
+			unix {
+				plugin_data {
+					discover_workload_path = true
+				}
+			}
+			docker {}
+		}
+	`)
+
+	resp, err := s.p.Attest(ctx, &workloadattestor.AttestRequest{Pid: 1})
+	s.Require().NoError(err)
+	s.Equal([]*common.Selector{
+		{Type: "composite", Value: "docker:label:app:web"},
+		{Type: "composite", Value: "unix:uid:1000"},
+		{Type: "composite", Value: "unix:gid:1000"},
+	}, resp.Selectors)
+
+	s.Contains(s.fakes["unix"].config, "discover_workload_path = true")
+	s.Equal("", s.fakes["docker"].config)
+}
+
+func (s *Suite) TestAttestFailsIfAnyAttestorFails() {
+	s.configure(`
+		attestors {
+			unix {}
+			k8s {}
+		}
+	`)
+
+	resp, err := s.p.Attest(ctx, &workloadattestor.AttestRequest{Pid: 1})
+	s.RequireGRPCStatus(err, codes.Unknown, `composite: "k8s" attestor failed: oh no`)
+	s.Nil(resp)
+}
+
+func (s *Suite) TestConfigure() {
+	testCases := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "malformed configuration",
+			config: "bad",
+			err:    "composite: unable to decode configuration",
+		},
+		{
+			name:   "no attestors",
+			config: "",
+			err:    "composite: at least one attestor must be configured",
+		},
+		{
+			name:   "unknown attestor",
+			config: "attestors { foo {} }",
+			err:    `composite: unknown attestor "foo"`,
+		},
+		{
+			name:   "attestor fails to configure",
+			config: `attestors { unix { plugin_data { fail = true } } }`,
+			err:    `composite: unable to configure "unix" attestor: bad config`,
+		},
+		{
+			name:   "success",
+			config: "attestors { unix {} }",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		s.T().Run(testCase.name, func(t *testing.T) {
+			resp, err := s.p.Configure(ctx, &spi.ConfigureRequest{
+				Configuration: testCase.config,
+				GlobalConfig:  &spi.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+			})
+			if testCase.err != "" {
+				spiretest.RequireGRPCStatusContains(t, err, codes.Unknown, testCase.err)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, &spi.ConfigureResponse{}, resp)
+			require.Equal(t, "example.org", s.fakes["unix"].trustDomain)
+		})
+	}
+}
+
+func (s *Suite) TestGetPluginInfo() {
+	resp, err := s.p.GetPluginInfo(ctx, &spi.GetPluginInfoRequest{})
+	s.NoError(err)
+	s.Equal(&spi.GetPluginInfoResponse{}, resp)
+}
+
+func (s *Suite) configure(config string) {
+	_, err := s.p.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: config,
+	})
+	s.Require().NoError(err)
+}
+
+type fakeAttestor struct {
+	selectors   []*common.Selector
+	attestErr   error
+	config      string
+	trustDomain string
+}
+
+func (f *fakeAttestor) Attest(context.Context, *workloadattestor.AttestRequest) (*workloadattestor.AttestResponse, error) {
+	if f.attestErr != nil {
+		return nil, f.attestErr
+	}
+	return &workloadattestor.AttestResponse{
+		Selectors: f.selectors,
+	}, nil
+}
+
+func (f *fakeAttestor) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	f.config = req.Configuration
+	f.trustDomain = req.GlobalConfig.GetTrustDomain()
+	if strings.Contains(req.Configuration, "fail") {
+		return nil, errors.New("bad config")
+	}
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (f *fakeAttestor) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
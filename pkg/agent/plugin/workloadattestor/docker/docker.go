@@ -23,10 +23,11 @@ import (
 )
 
 const (
-	pluginName         = "docker"
-	subselectorLabel   = "label"
-	subselectorImageID = "image_id"
-	subselectorEnv     = "env"
+	pluginName                   = "docker"
+	subselectorLabel             = "label"
+	subselectorImageID           = "image_id"
+	subselectorImageConfigDigest = "image_config_digest"
+	subselectorEnv               = "env"
 )
 
 func BuiltIn() catalog.Plugin {
@@ -49,6 +50,7 @@ type Plugin struct {
 	mtx               *sync.RWMutex
 	retryer           *retryer
 	containerIDFinder cgroup.ContainerIDFinder
+	allowedEnvVars    map[string]bool
 }
 
 func New() *Plugin {
@@ -71,6 +73,9 @@ type dockerPluginConfig struct {
 	CgroupContainerIndex *int `hcl:"cgroup_container_index"`
 	// ContainerIDCGroupMatchers
 	ContainerIDCGroupMatchers []string `hcl:"container_id_cgroup_matchers"`
+	// AllowedEnvVars is the list of environment variable names considered
+	// for selectors. If not specified, all environment variables are used.
+	AllowedEnvVars []string `hcl:"allowed_env_vars"`
 }
 
 func (p *Plugin) SetLogger(log hclog.Logger) {
@@ -107,12 +112,22 @@ func (p *Plugin) Attest(ctx context.Context, req *workloadattestor.AttestRequest
 		return nil, err
 	}
 
+	selectors := getSelectorsFromConfig(container.Config, p.allowedEnvVars)
+	if container.ContainerJSONBase != nil && container.Image != "" {
+		// The image of a container is the digest of the image configuration,
+		// which identifies the image contents regardless of how it is tagged.
+		selectors = append(selectors, &common.Selector{
+			Type:  pluginName,
+			Value: fmt.Sprintf("%s:%s", subselectorImageConfigDigest, container.Image),
+		})
+	}
+
 	return &workloadattestor.AttestResponse{
-		Selectors: getSelectorsFromConfig(container.Config),
+		Selectors: selectors,
 	}, nil
 }
 
-func getSelectorsFromConfig(cfg *container.Config) []*common.Selector {
+func getSelectorsFromConfig(cfg *container.Config, allowedEnvVars map[string]bool) []*common.Selector {
 	var selectors []*common.Selector
 	for label, value := range cfg.Labels {
 		selectors = append(selectors, &common.Selector{
@@ -121,6 +136,9 @@ func getSelectorsFromConfig(cfg *container.Config) []*common.Selector {
 		})
 	}
 	for _, e := range cfg.Env {
+		if allowedEnvVars != nil && !allowedEnvVars[strings.SplitN(e, "=", 2)[0]] {
+			continue
+		}
 		selectors = append(selectors, &common.Selector{
 			Type:  pluginName,
 			Value: fmt.Sprintf("%s:%s", subselectorEnv, e),
@@ -183,6 +201,14 @@ func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi
 		p.containerIDFinder = &defaultContainerIDFinder{}
 	}
 
+	p.allowedEnvVars = nil
+	if config.AllowedEnvVars != nil {
+		p.allowedEnvVars = make(map[string]bool)
+		for _, name := range config.AllowedEnvVars {
+			p.allowedEnvVars[name] = true
+		}
+	}
+
 	return &spi.ConfigureResponse{}, nil
 }
 
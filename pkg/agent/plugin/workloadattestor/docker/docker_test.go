@@ -35,6 +35,7 @@ func TestDockerSelectors(t *testing.T) {
 		mockContainerLabels map[string]string
 		mockEnv             []string
 		mockImageID         string
+		mockImage           string
 		requireResult       func(*testing.T, *workloadattestor.AttestResponse)
 	}{
 		{
@@ -84,6 +85,15 @@ func TestDockerSelectors(t *testing.T) {
 				require.Equal(t, "image_id:my-docker-image", res.Selectors[0].Value)
 			},
 		},
+		{
+			desc:      "image config digest",
+			mockImage: "sha256:6bb891430fb6e2d3b4db41fd1f7ece08c5fc769d8f4823ec33c7c7ba99679213",
+			requireResult: func(t *testing.T, res *workloadattestor.AttestResponse) {
+				require.Len(t, res.Selectors, 1)
+				require.Equal(t, "docker", res.Selectors[0].Type)
+				require.Equal(t, "image_config_digest:sha256:6bb891430fb6e2d3b4db41fd1f7ece08c5fc769d8f4823ec33c7c7ba99679213", res.Selectors[0].Value)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -106,6 +116,11 @@ func TestDockerSelectors(t *testing.T) {
 					Env:    tt.mockEnv,
 				},
 			}
+			if tt.mockImage != "" {
+				container.ContainerJSONBase = &types.ContainerJSONBase{
+					Image: tt.mockImage,
+				}
+			}
 			mockDocker.EXPECT().ContainerInspect(gomock.Any(), testContainerID).Return(container, nil)
 
 			res, err := p.Attest(ctx, &workloadattestor.AttestRequest{Pid: 123})
@@ -116,6 +131,34 @@ func TestDockerSelectors(t *testing.T) {
 	}
 }
 
+func TestDockerSelectorsAllowedEnvVars(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockDocker := mock_docker.NewMockDocker(mockCtrl)
+
+	fs := newFakeFileSystem(testCgroupEntries)
+
+	p := newTestPlugin(
+		t,
+		withConfig(t, `allowed_env_vars = ["VAR", "UNSET"]`), // this must be the first option
+		withMockDocker(mockDocker),
+		withFileSystem(fs),
+	)
+
+	container := types.ContainerJSON{
+		Config: &container.Config{
+			Env: []string{"VAR=val", "SECRET=shh", "VARIABLE=val"},
+		},
+	}
+	mockDocker.EXPECT().ContainerInspect(gomock.Any(), testContainerID).Return(container, nil)
+
+	res, err := doAttest(t, p, &workloadattestor.AttestRequest{Pid: 123})
+	require.NoError(t, err)
+	require.Len(t, res.Selectors, 1)
+	require.Equal(t, "docker", res.Selectors[0].Type)
+	require.Equal(t, "env:VAR=val", res.Selectors[0].Value)
+}
+
 func TestContainerExtraction(t *testing.T) {
 	tests := []struct {
 		desc      string
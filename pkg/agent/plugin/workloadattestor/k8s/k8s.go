@@ -31,6 +31,7 @@ import (
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/zeebo/errs"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -114,6 +115,11 @@ type HCLConfig struct {
 	// ReloadInterval controls how often TLS and token configuration is loaded
 	// from the disk.
 	ReloadInterval string `hcl:"reload_interval"`
+
+	// PodAnnotations is the list of pod annotation keys that are used to
+	// produce selectors. Annotations are not used unless listed here since
+	// their values are often large and set by cluster components.
+	PodAnnotations []string `hcl:"pod_annotations"`
 }
 
 // k8sConfig holds the configuration distilled from HCL
@@ -129,6 +135,7 @@ type k8sConfig struct {
 	KubeletCAPath           string
 	NodeName                string
 	ReloadInterval          time.Duration
+	PodAnnotations          []string
 
 	Client     *kubeletClient
 	LastReload time.Time
@@ -189,7 +196,7 @@ func (p *Plugin) Attest(ctx context.Context, req *workloadattestor.AttestRequest
 			switch lookup {
 			case containerInPod:
 				return &workloadattestor.AttestResponse{
-					Selectors: getSelectorsFromPodInfo(&item, status),
+					Selectors: getSelectorsFromPodInfo(&item, status, config.PodAnnotations),
 				}, nil
 			case containerNotInPod:
 			}
@@ -283,6 +290,7 @@ func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (resp
 		KubeletCAPath:           config.KubeletCAPath,
 		NodeName:                nodeName,
 		ReloadInterval:          reloadInterval,
+		PodAnnotations:          config.PodAnnotations,
 	}
 	if err := p.reloadKubeletClient(c); err != nil {
 		return nil, err
@@ -572,6 +580,11 @@ func getContainerIDFromCGroupPath(cgroupPath string) (string, bool) {
 	// - /docker/8d461fa5765781bcf5f7eb192f101bc3103d4b932e26236f43feecfa20664f96/kubepods/besteffort/poddaa5c7ee-3484-4533-af39-3591564fd03e/aff34703e5e1f89443e9a1bffcc80f43f74d4808a2dd22c8f88c08547b323934
 	// - /kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod2c48913c-b29f-11e7-9350-020968147796.slice/docker-9bca8d63d5fa610783847915bcff0ecac1273e5b4bed3f6fa1b07350e0135961.scope
 	// - /kubepods-besteffort-pod72f7f152_440c_66ac_9084_e0fc1d8a910c.slice:cri-containerd:b2a102854b4969b2ce98dc329c86b4fb2b06e4ad2cc8da9d8a7578c9cd2004a2"
+	// - /kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod2c48913c_b29f_11e7_9350_020968147796.slice/crio-9bca8d63d5fa610783847915bcff0ecac1273e5b4bed3f6fa1b07350e0135961.scope
+	//
+	// The same paths are found in the single unified hierarchy entry (i.e.
+	// "0::<path>") on hosts using cgroup v2. When the agent runs in its own
+	// cgroup namespace, the path may be relative (e.g. prefixed by "/../..").
 
 	// First trim off any .scope suffix. This allows for a cleaner regex since
 	// we don't have to muck with greediness. TrimSuffix is no-copy so this
@@ -635,7 +648,7 @@ func getPodImages(containerStatusArray []corev1.ContainerStatus) map[string]bool
 	return podImages
 }
 
-func getSelectorsFromPodInfo(pod *corev1.Pod, status *corev1.ContainerStatus) []*common.Selector {
+func getSelectorsFromPodInfo(pod *corev1.Pod, status *corev1.ContainerStatus, podAnnotations []string) []*common.Selector {
 	podImages := getPodImages(pod.Status.ContainerStatuses)
 	podInitImages := getPodImages(pod.Status.InitContainerStatuses)
 
@@ -650,6 +663,9 @@ func getSelectorsFromPodInfo(pod *corev1.Pod, status *corev1.ContainerStatus) []
 		makeSelector("pod-image-count:%s", strconv.Itoa(len(podImages))),
 		makeSelector("pod-init-image-count:%s", strconv.Itoa(len(podInitImages))),
 	}
+	if digest := getImageDigest(status.ImageID); digest != "" {
+		selectors = append(selectors, makeSelector("container-image-digest:%s", digest))
+	}
 	for podImage := range podImages {
 		selectors = append(selectors, makeSelector("pod-image:%s", podImage))
 	}
@@ -663,11 +679,51 @@ func getSelectorsFromPodInfo(pod *corev1.Pod, status *corev1.ContainerStatus) []
 	for _, ownerReference := range pod.OwnerReferences {
 		selectors = append(selectors, makeSelector("pod-owner:%s:%s", ownerReference.Kind, ownerReference.Name))
 		selectors = append(selectors, makeSelector("pod-owner-uid:%s:%s", ownerReference.Kind, ownerReference.UID))
+		if deployment, ok := getDeploymentName(pod, ownerReference); ok {
+			selectors = append(selectors, makeSelector("pod-deployment:%s", deployment))
+		}
+	}
+	for _, key := range podAnnotations {
+		if value, ok := pod.Annotations[key]; ok {
+			selectors = append(selectors, makeSelector("pod-annotation:%s:%s", key, value))
+		}
 	}
 
 	return selectors
 }
 
+// getImageDigest returns the digest of the image from the image ID reported
+// in the container status, e.g. "docker-pullable://example.org/foo@sha256:...".
+// Runtimes that report the image ID as a bare digest (e.g. "sha256:...") are
+// also supported.
+func getImageDigest(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		return imageID[i+1:]
+	}
+	if strings.HasPrefix(imageID, "sha256:") {
+		return imageID
+	}
+	return ""
+}
+
+// getDeploymentName returns the name of the deployment that manages the pod
+// through the given owner reference. Deployments own pods through a replica
+// set named after the deployment and the pod template hash.
+func getDeploymentName(pod *corev1.Pod, ownerReference metav1.OwnerReference) (string, bool) {
+	if ownerReference.Kind != "ReplicaSet" {
+		return "", false
+	}
+	hash, ok := pod.Labels["pod-template-hash"]
+	if !ok || hash == "" {
+		return "", false
+	}
+	suffix := "-" + hash
+	if !strings.HasSuffix(ownerReference.Name, suffix) {
+		return "", false
+	}
+	return strings.TrimSuffix(ownerReference.Name, suffix), true
+}
+
 func makeSelector(format string, args ...interface{}) *common.Selector {
 	return &common.Selector{
 		Type:  pluginName,
@@ -30,6 +30,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -44,6 +46,7 @@ const (
 	cgInitPidInPodFilePath    = "testdata/cgroups_init_pid_in_pod.txt"
 	cgPidNotInPodFilePath     = "testdata/cgroups_pid_not_in_pod.txt"
 	cgSystemdPidInPodFilePath = "testdata/systemd_cgroups_pid_in_pod.txt"
+	cgV2PidInPodFilePath      = "testdata/cgroups_v2_pid_in_pod.txt"
 
 	certPath = "cert.pem"
 	keyPath  = "key.pem"
@@ -67,6 +70,7 @@ FwOGLt+I3+9beT0vo+pn9Rq0squewFYe3aJbwpkyfP2xOovQCdm4PC8y
 `))
 
 	testPodSelectors = []*common.Selector{
+		{Type: "k8s", Value: "container-image-digest:sha256:0cfdaced91cb46dd7af48309799a3c351e4ca2d5e1ee9737ca0cbd932cb79898"},
 		{Type: "k8s", Value: "container-image:localhost/spiffe/blog:latest"},
 		{Type: "k8s", Value: "container-name:blog"},
 		{Type: "k8s", Value: "node-name:k8s-node-1"},
@@ -85,10 +89,12 @@ FwOGLt+I3+9beT0vo+pn9Rq0squewFYe3aJbwpkyfP2xOovQCdm4PC8y
 	}
 
 	testKindPodSelectors = []*common.Selector{
+		{Type: "k8s", Value: "container-image-digest:sha256:1e4c481d76e9ecbd3d8684891e0e46aa021a30920ca04936e1fdcc552747d941"},
 		{Type: "k8s", Value: "container-image:gcr.io/spiffe-io/spire-agent:0.8.1"},
 		{Type: "k8s", Value: "container-name:workload-api-client"},
 		{Type: "k8s", Value: "node-name:kind-control-plane"},
 		{Type: "k8s", Value: "ns:default"},
+		{Type: "k8s", Value: "pod-deployment:sample-workload"},
 		{Type: "k8s", Value: "pod-image-count:1"},
 		{Type: "k8s", Value: "pod-image:gcr.io/spiffe-io/spire-agent@sha256:1e4c481d76e9ecbd3d8684891e0e46aa021a30920ca04936e1fdcc552747d941"},
 		{Type: "k8s", Value: "pod-init-image-count:0"},
@@ -102,6 +108,7 @@ FwOGLt+I3+9beT0vo+pn9Rq0squewFYe3aJbwpkyfP2xOovQCdm4PC8y
 	}
 
 	testInitPodSelectors = []*common.Selector{
+		{Type: "k8s", Value: "container-image-digest:sha256:1b401bf0c30bada9a539389c3be652b58fe38463361edf488e6543c8761d4970"},
 		{Type: "k8s", Value: "container-image:quay.io/coreos/flannel:v0.9.0-amd64"},
 		{Type: "k8s", Value: "container-name:install-cni"},
 		{Type: "k8s", Value: "node-name:k8s-node-1"},
@@ -185,6 +192,13 @@ func (s *Suite) TestAttestWithPidInPodSystemdCgroups() {
 	s.requireAttestSuccessWithPodSystemdCgroups()
 }
 
+func (s *Suite) TestAttestWithPidInPodCgroupsV2() {
+	s.startInsecureKubelet()
+	s.configureInsecure()
+
+	s.requireAttestSuccessWithPodCgroupsV2()
+}
+
 func (s *Suite) TestAttestWithInitPidInPod() {
 	s.startInsecureKubelet()
 	s.configureInsecure()
@@ -363,6 +377,7 @@ func (s *Suite) TestConfigure() {
 		MaxPollAttempts   int
 		PollRetryInterval time.Duration
 		ReloadInterval    time.Duration
+		PodAnnotations    []string
 	}
 
 	testCases := []struct {
@@ -420,6 +435,7 @@ func (s *Suite) TestConfigure() {
 				max_poll_attempts = 1
 				poll_retry_interval = "2s"
 				reload_interval = "3s"
+				pod_annotations = ["example.org/role"]
 			`,
 			config: &config{
 				VerifyKubelet:     true,
@@ -428,6 +444,7 @@ func (s *Suite) TestConfigure() {
 				MaxPollAttempts:   1,
 				PollRetryInterval: 2 * time.Second,
 				ReloadInterval:    3 * time.Second,
+				PodAnnotations:    []string{"example.org/role"},
 			},
 		},
 		{
@@ -608,6 +625,7 @@ func (s *Suite) TestConfigure() {
 			assert.Equal(t, testCase.config.MaxPollAttempts, c.MaxPollAttempts)
 			assert.Equal(t, testCase.config.PollRetryInterval, c.PollRetryInterval)
 			assert.Equal(t, testCase.config.ReloadInterval, c.ReloadInterval)
+			assert.Equal(t, testCase.config.PodAnnotations, c.PodAnnotations)
 		})
 	}
 }
@@ -809,6 +827,12 @@ func (s *Suite) requireAttestSuccessWithPodSystemdCgroups() {
 	s.requireAttestSuccess(testPodSelectors)
 }
 
+func (s *Suite) requireAttestSuccessWithPodCgroupsV2() {
+	s.addPodListResponse(podListFilePath)
+	s.addCgroupsResponse(cgV2PidInPodFilePath)
+	s.requireAttestSuccess(testPodSelectors)
+}
+
 func (s *Suite) requireAttestSuccessWithInitPod() {
 	s.addPodListResponse(podListFilePath)
 	s.addCgroupsResponse(cgInitPidInPodFilePath)
@@ -867,6 +891,64 @@ func (s *Suite) addCgroupsResponse(fixturePath string) {
 	s.Require().NoError(os.Symlink(filepath.Join(wd, fixturePath), cgroupPath))
 }
 
+func TestGetSelectorsFromPodInfo(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-7d9c5b8f6-abcde",
+			Namespace: "default",
+			Labels: map[string]string{
+				"pod-template-hash": "7d9c5b8f6",
+			},
+			Annotations: map[string]string{
+				"example.org/role":          "frontend",
+				"kubernetes.io/config.seen": "2020-01-01T00:00:00Z",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "foo-7d9c5b8f6", UID: "uid"},
+			},
+		},
+	}
+	status := &corev1.ContainerStatus{
+		Name:    "foo",
+		Image:   "foo:latest",
+		ImageID: "sha256:2af2a2f4e5b0c0a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d",
+	}
+
+	selectors := getSelectorsFromPodInfo(pod, status, []string{"example.org/role", "example.org/missing"})
+
+	var values []string
+	for _, selector := range selectors {
+		values = append(values, selector.Value)
+	}
+	assert.Contains(t, values, "container-image-digest:sha256:2af2a2f4e5b0c0a1b2c3d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d")
+	assert.Contains(t, values, "pod-deployment:foo")
+	assert.Contains(t, values, "pod-annotation:example.org/role:frontend")
+	assert.NotContains(t, values, "pod-annotation:kubernetes.io/config.seen:2020-01-01T00:00:00Z")
+}
+
+func TestGetDeploymentName(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"pod-template-hash": "7d9c5b8f6",
+			},
+		},
+	}
+
+	name, ok := getDeploymentName(pod, metav1.OwnerReference{Kind: "ReplicaSet", Name: "foo-7d9c5b8f6"})
+	assert.True(t, ok)
+	assert.Equal(t, "foo", name)
+
+	_, ok = getDeploymentName(pod, metav1.OwnerReference{Kind: "ReplicaSet", Name: "bar"})
+	assert.False(t, ok)
+
+	_, ok = getDeploymentName(pod, metav1.OwnerReference{Kind: "StatefulSet", Name: "foo-7d9c5b8f6"})
+	assert.False(t, ok)
+
+	_, ok = getDeploymentName(&corev1.Pod{}, metav1.OwnerReference{Kind: "ReplicaSet", Name: "foo-7d9c5b8f6"})
+	assert.False(t, ok)
+}
+
 func TestGetContainerIDFromCGroups(t *testing.T) {
 	makeCGroups := func(groupPaths []string) []cgroups.Cgroup {
 		var out []cgroups.Cgroup
@@ -988,6 +1070,26 @@ func TestGetContainerIDFromCGroupPath(t *testing.T) {
 			cgroupPath:  "/kubepods-besteffort-pod72f7f152_440c_66ac_9084_e0fc1d8a910c.slice:cri-containerd:b2a102854b4969b2ce98dc329c86b4fb2b06e4ad2cc8da9d8a7578c9cd2004a2",
 			containerID: "b2a102854b4969b2ce98dc329c86b4fb2b06e4ad2cc8da9d8a7578c9cd2004a2",
 		},
+		{
+			name:        "cri-containerd systemd scope",
+			cgroupPath:  "/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod72f7f152_440c_66ac_9084_e0fc1d8a910c.slice/cri-containerd-b2a102854b4969b2ce98dc329c86b4fb2b06e4ad2cc8da9d8a7578c9cd2004a2.scope",
+			containerID: "b2a102854b4969b2ce98dc329c86b4fb2b06e4ad2cc8da9d8a7578c9cd2004a2",
+		},
+		{
+			name:        "cri-o",
+			cgroupPath:  "/kubepods/burstable/pod2c48913c-b29f-11e7-9350-020968147796/crio-9bca8d63d5fa610783847915bcff0ecac1273e5b4bed3f6fa1b07350e0135961",
+			containerID: "9bca8d63d5fa610783847915bcff0ecac1273e5b4bed3f6fa1b07350e0135961",
+		},
+		{
+			name:        "cri-o systemd scope",
+			cgroupPath:  "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod2c48913c_b29f_11e7_9350_020968147796.slice/crio-9bca8d63d5fa610783847915bcff0ecac1273e5b4bed3f6fa1b07350e0135961.scope",
+			containerID: "9bca8d63d5fa610783847915bcff0ecac1273e5b4bed3f6fa1b07350e0135961",
+		},
+		{
+			name:        "cgroup v2 namespaced path",
+			cgroupPath:  "/../../kubepods-besteffort-pod72f7f152_440c_66ac_9084_e0fc1d8a910c.slice/cri-containerd-b2a102854b4969b2ce98dc329c86b4fb2b06e4ad2cc8da9d8a7578c9cd2004a2.scope",
+			containerID: "b2a102854b4969b2ce98dc329c86b4fb2b06e4ad2cc8da9d8a7578c9cd2004a2",
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
@@ -2,10 +2,18 @@ package unix
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
 	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"math/big"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -16,6 +24,7 @@ import (
 	"github.com/shirou/gopsutil/process"
 	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/proto/spire/common"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/zeebo/errs"
@@ -42,6 +51,7 @@ type processInfo interface {
 	Gids() ([]int32, error)
 	Exe() (string, error)
 	NamespacedExe() string
+	NamespacedPath(path string) string
 }
 
 type PSProcessInfo struct {
@@ -56,9 +66,22 @@ func (ps PSProcessInfo) NamespacedExe() string {
 	return filepath.Join(procPath, strconv.Itoa(int(ps.Pid)), "exe")
 }
 
+// NamespacedPath returns the path to the given file as seen from the mount
+// namespace of the process.
+func (ps PSProcessInfo) NamespacedPath(path string) string {
+	procPath := os.Getenv("HOST_PROC")
+	if procPath == "" {
+		procPath = "/proc"
+	}
+	return filepath.Join(procPath, strconv.Itoa(int(ps.Pid)), "root", path)
+}
+
 type Configuration struct {
-	DiscoverWorkloadPath bool  `hcl:"discover_workload_path"`
-	WorkloadSizeLimit    int64 `hcl:"workload_size_limit"`
+	DiscoverWorkloadPath    bool   `hcl:"discover_workload_path"`
+	WorkloadSizeLimit       int64  `hcl:"workload_size_limit"`
+	CodeSigningCABundlePath string `hcl:"code_signing_ca_bundle_path"`
+
+	codeSigningRoots *x509.CertPool
 }
 
 type Plugin struct {
@@ -131,7 +154,17 @@ func (p *Plugin) Attest(ctx context.Context, req *workloadattestor.AttestRequest
 				return nil, err
 			}
 
-			selectors = append(selectors, makeSelector("sha256", sha256Digest))
+			selectors = append(selectors, makeSelector("sha256", hex.EncodeToString(sha256Digest)))
+
+			if config.codeSigningRoots != nil {
+				signer, ok, err := p.getSigner(proc, processPath, sha256Digest, config.codeSigningRoots)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					selectors = append(selectors, makeSelector("signer", signer))
+				}
+			}
 		}
 	}
 
@@ -145,6 +178,21 @@ func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi
 	if err := hcl.Decode(config, req.Configuration); err != nil {
 		return nil, unixErr.Wrap(err)
 	}
+
+	if config.CodeSigningCABundlePath != "" {
+		if !config.DiscoverWorkloadPath || config.WorkloadSizeLimit < 0 {
+			return nil, unixErr.New("code signing verification requires discover_workload_path and a non-negative workload_size_limit")
+		}
+		roots, err := pemutil.LoadCertificates(config.CodeSigningCABundlePath)
+		if err != nil {
+			return nil, unixErr.New("unable to load code signing CA bundle: %v", err)
+		}
+		config.codeSigningRoots = x509.NewCertPool()
+		for _, root := range roots {
+			config.codeSigningRoots.AddCert(root)
+		}
+	}
+
 	p.setConfig(config)
 	return &spi.ConfigureResponse{}, nil
 }
@@ -230,28 +278,89 @@ func (p *Plugin) getNamespacedPath(proc processInfo) string {
 	return proc.NamespacedExe()
 }
 
-func getSHA256Digest(path string, limit int64) (string, error) {
+// getSigner verifies the detached code signature of the workload binary and
+// returns the common name of the signing certificate. The signature is read
+// from "<path>.sig" and holds the signature over the SHA256 digest of the
+// binary (e.g. as produced by "openssl dgst -sha256 -sign"). The signing
+// certificate, followed by any intermediates, is read from "<path>.crt". If
+// the binary has no signature, false is returned.
+func (p *Plugin) getSigner(proc processInfo, path string, digest []byte, roots *x509.CertPool) (string, bool, error) {
+	signature, err := ioutil.ReadFile(proc.NamespacedPath(path + ".sig"))
+	switch {
+	case os.IsNotExist(err):
+		return "", false, nil
+	case err != nil:
+		return "", false, unixErr.New("code signature: %v", err)
+	}
+
+	certs, err := pemutil.LoadCertificates(proc.NamespacedPath(path + ".crt"))
+	if err != nil {
+		return "", false, unixErr.New("code signature: unable to load signing certificates: %v", err)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return "", false, unixErr.New("code signature: unable to verify signing certificate: %v", err)
+	}
+
+	if err := verifyDigestSignature(certs[0].PublicKey, digest, signature); err != nil {
+		return "", false, unixErr.New("code signature: %v", err)
+	}
+
+	return certs[0].Subject.CommonName, true, nil
+}
+
+func verifyDigestSignature(publicKey crypto.PublicKey, digest, signature []byte) error {
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest, signature); err != nil {
+			return errors.New("invalid RSA signature")
+		}
+	case *ecdsa.PublicKey:
+		var sig struct {
+			R, S *big.Int
+		}
+		if rest, err := asn1.Unmarshal(signature, &sig); err != nil || len(rest) > 0 {
+			return errors.New("malformed ECDSA signature")
+		}
+		if !ecdsa.Verify(publicKey, digest, sig.R, sig.S) {
+			return errors.New("invalid ECDSA signature")
+		}
+	default:
+		return fmt.Errorf("unsupported signing key type %T", publicKey)
+	}
+	return nil
+}
+
+func getSHA256Digest(path string, limit int64) ([]byte, error) {
 	f, err := os.Open(path)
 	if err != nil {
-		return "", unixErr.New("SHA256 digest: %v", err)
+		return nil, unixErr.New("SHA256 digest: %v", err)
 	}
 	defer f.Close()
 
 	if limit > 0 {
 		fi, err := f.Stat()
 		if err != nil {
-			return "", unixErr.New("SHA256 digest: %v", err)
+			return nil, unixErr.New("SHA256 digest: %v", err)
 		}
 		if fi.Size() > limit {
-			return "", unixErr.New("SHA256 digest: workload %s exceeds size limit (%d > %d)", path, fi.Size(), limit)
+			return nil, unixErr.New("SHA256 digest: workload %s exceeds size limit (%d > %d)", path, fi.Size(), limit)
 		}
 	}
 
 	h := sha256.New()
 	if _, err := io.Copy(h, f); err != nil {
-		return "", unixErr.New("SHA256 digest: %v", err)
+		return nil, unixErr.New("SHA256 digest: %v", err)
 	}
-	return hex.EncodeToString(h.Sum(nil)), nil
+	return h.Sum(nil), nil
 }
 
 func makeSelector(kind, value string) *common.Selector {
@@ -2,16 +2,28 @@ package unix
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
 	"io/ioutil"
+	"math/big"
+	"os"
 	"os/user"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	"github.com/spiffe/spire/pkg/common/pemutil"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testkey"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 )
@@ -197,12 +209,135 @@ func (s *Suite) TestAttest() {
 	}
 }
 
+func (s *Suite) TestAttestCodeSigning() {
+	caKey := testkey.NewEC256(s.T())
+	caCert := s.createCertificate(&x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "CA"},
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, caKey, caKey)
+	signerKey := testkey.NewEC256(s.T())
+	signerCert := s.createCertificate(&x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "signer"},
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}, caCert, signerKey, caKey)
+	otherKey := testkey.NewEC256(s.T())
+	otherCert := s.createCertificate(&x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "other"},
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}, nil, otherKey, otherKey)
+
+	digest := sha256.Sum256([]byte("data"))
+	signature, err := signerKey.Sign(rand.Reader, digest[:], crypto.SHA256)
+	s.Require().NoError(err)
+	otherSignature, err := otherKey.Sign(rand.Reader, digest[:], crypto.SHA256)
+	s.Require().NoError(err)
+
+	s.writeFile("exe", []byte("data"))
+	s.writeFile("ca.pem", pemutil.EncodeCertificate(caCert))
+	config := fmt.Sprintf("discover_workload_path = true\ncode_signing_ca_bundle_path = %q", filepath.Join(s.dir, "ca.pem"))
+
+	testCases := []struct {
+		name      string
+		signature []byte
+		certs     []*x509.Certificate
+		err       string
+		signer    string
+	}{
+		{
+			name: "unsigned binary",
+		},
+		{
+			name:      "signed binary",
+			signature: signature,
+			certs:     []*x509.Certificate{signerCert},
+			signer:    "signer",
+		},
+		{
+			name:      "missing signing certificate",
+			signature: signature,
+			err:       "unix: code signature: unable to load signing certificates",
+		},
+		{
+			name:      "untrusted signing certificate",
+			signature: otherSignature,
+			certs:     []*x509.Certificate{otherCert},
+			err:       "unix: code signature: unable to verify signing certificate",
+		},
+		{
+			name:      "signature does not match binary",
+			signature: otherSignature,
+			certs:     []*x509.Certificate{signerCert},
+			err:       "unix: code signature: invalid ECDSA signature",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		s.T().Run(testCase.name, func(t *testing.T) {
+			s.removeFile("exe.sig")
+			s.removeFile("exe.crt")
+			if testCase.signature != nil {
+				s.writeFile("exe.sig", testCase.signature)
+			}
+			if testCase.certs != nil {
+				s.writeFile("exe.crt", pemutil.EncodeCertificates(testCase.certs))
+			}
+
+			s.configure(config)
+			resp, err := s.p.Attest(ctx, &workloadattestor.AttestRequest{
+				Pid: 12,
+			})
+			if testCase.err != "" {
+				spiretest.RequireGRPCStatusContains(t, err, codes.Unknown, testCase.err)
+				require.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			var signers []string
+			for _, selector := range resp.Selectors {
+				if strings.HasPrefix(selector.Value, "signer:") {
+					signers = append(signers, strings.TrimPrefix(selector.Value, "signer:"))
+				}
+			}
+			if testCase.signer == "" {
+				require.Empty(t, signers)
+				return
+			}
+			require.Equal(t, []string{testCase.signer}, signers)
+		})
+	}
+}
+
 func (s *Suite) TestConfigure() {
 	resp, err := s.p.Configure(ctx, &spi.ConfigureRequest{})
 	s.NoError(err)
 	s.Equal(&spi.ConfigureResponse{}, resp)
 }
 
+func (s *Suite) TestConfigureCodeSigningRequiresWorkloadDigest() {
+	_, err := s.p.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: `code_signing_ca_bundle_path = "ca.pem"`,
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, "unix: code signing verification requires discover_workload_path and a non-negative workload_size_limit")
+
+	_, err = s.p.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: `discover_workload_path = true
+		code_signing_ca_bundle_path = "/does/not/exist"`,
+	})
+	s.RequireGRPCStatusContains(err, codes.Unknown, "unix: unable to load code signing CA bundle")
+}
+
 func (s *Suite) TestGetPluginInfo() {
 	resp, e := s.p.GetPluginInfo(ctx, &spi.GetPluginInfoRequest{})
 	s.NoError(e)
@@ -220,6 +355,24 @@ func (s *Suite) writeFile(path string, data []byte) {
 	s.Require().NoError(ioutil.WriteFile(filepath.Join(s.dir, path), data, 0644))
 }
 
+func (s *Suite) removeFile(path string) {
+	err := os.Remove(filepath.Join(s.dir, path))
+	if !os.IsNotExist(err) {
+		s.Require().NoError(err)
+	}
+}
+
+func (s *Suite) createCertificate(tmpl, parent *x509.Certificate, key *ecdsa.PrivateKey, parentKey crypto.Signer) *x509.Certificate {
+	if parent == nil {
+		parent = tmpl
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+	s.Require().NoError(err)
+	cert, err := x509.ParseCertificate(certDER)
+	s.Require().NoError(err)
+	return cert
+}
+
 type fakeProcess struct {
 	pid int32
 	dir string
@@ -281,6 +434,10 @@ func (p fakeProcess) NamespacedExe() string {
 	}
 }
 
+func (p fakeProcess) NamespacedPath(path string) string {
+	return path
+}
+
 func newFakeProcess(pid int32, dir string) processInfo {
 	return fakeProcess{pid: pid, dir: dir}
 }
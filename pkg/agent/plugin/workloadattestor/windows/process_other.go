@@ -0,0 +1,11 @@
+// +build !windows
+
+package windows
+
+import (
+	"errors"
+)
+
+func queryProcess(pid int32) (*processInfo, error) {
+	return nil, errors.New("only supported on windows")
+}
@@ -0,0 +1,59 @@
+// +build windows
+
+package windows
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	kernel32                       = windows.NewLazySystemDLL("kernel32.dll")
+	procQueryFullProcessImageNameW = kernel32.NewProc("QueryFullProcessImageNameW")
+)
+
+func queryProcess(pid int32) (*processInfo, error) {
+	h, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open process: %v", err)
+	}
+	defer windows.CloseHandle(h)
+
+	var token windows.Token
+	if err := windows.OpenProcessToken(h, windows.TOKEN_QUERY, &token); err != nil {
+		return nil, fmt.Errorf("unable to open process token: %v", err)
+	}
+	defer token.Close()
+
+	tokenUser, err := token.GetTokenUser()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get token user: %v", err)
+	}
+
+	info := &processInfo{
+		UserSID: tokenUser.User.Sid.String(),
+	}
+
+	if account, domain, _, err := tokenUser.User.Sid.LookupAccount(""); err == nil {
+		info.UserName = domain + `\` + account
+	}
+
+	info.Path, err = queryFullProcessImageName(h)
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+func queryFullProcessImageName(h windows.Handle) (string, error) {
+	buf := make([]uint16, windows.MAX_LONG_PATH)
+	size := uint32(len(buf))
+	r, _, err := procQueryFullProcessImageNameW.Call(uintptr(h), 0, uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size)))
+	if r == 0 {
+		return "", fmt.Errorf("unable to get process image name: %v", err)
+	}
+	return windows.UTF16ToString(buf[:size]), nil
+}
@@ -0,0 +1,124 @@
+package windows
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/zeebo/errs"
+)
+
+const (
+	pluginName = "windows"
+)
+
+var (
+	windowsErr = errs.Class("windows")
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin(pluginName, workloadattestor.PluginServer(p))
+}
+
+// processInfo holds the information about the workload process used to
+// produce selectors.
+type processInfo struct {
+	// UserSID is the security identifier of the user the process runs as.
+	UserSID string
+	// UserName is the account name of the user, in the "DOMAIN\user" form.
+	// It is empty if the account could not be looked up.
+	UserName string
+	// Path is the full path to the process executable.
+	Path string
+}
+
+type Configuration struct {
+	DiscoverWorkloadPath bool `hcl:"discover_workload_path"`
+}
+
+type Plugin struct {
+	mu     sync.Mutex
+	config *Configuration
+
+	// hooks for tests
+	hooks struct {
+		queryProcess func(pid int32) (*processInfo, error)
+	}
+}
+
+func New() *Plugin {
+	p := &Plugin{}
+	p.hooks.queryProcess = queryProcess
+	return p
+}
+
+func (p *Plugin) Attest(ctx context.Context, req *workloadattestor.AttestRequest) (*workloadattestor.AttestResponse, error) {
+	config, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := p.hooks.queryProcess(req.Pid)
+	if err != nil {
+		return nil, windowsErr.New("querying process: %v", err)
+	}
+
+	selectors := []*common.Selector{
+		makeSelector("user_sid", info.UserSID),
+	}
+	if info.UserName != "" {
+		selectors = append(selectors, makeSelector("user_name", info.UserName))
+	}
+	if config.DiscoverWorkloadPath {
+		selectors = append(selectors, makeSelector("path", info.Path))
+	}
+
+	return &workloadattestor.AttestResponse{
+		Selectors: selectors,
+	}, nil
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(Configuration)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, windowsErr.Wrap(err)
+	}
+	p.setConfig(config)
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *Plugin) getConfig() (*Configuration, error) {
+	p.mu.Lock()
+	config := p.config
+	p.mu.Unlock()
+	if config == nil {
+		return nil, windowsErr.New("not configured")
+	}
+	return config, nil
+}
+
+func (p *Plugin) setConfig(config *Configuration) {
+	p.mu.Lock()
+	p.config = config
+	p.mu.Unlock()
+}
+
+func makeSelector(kind, value string) *common.Selector {
+	return &common.Selector{
+		Type:  pluginName,
+		Value: fmt.Sprintf("%s:%s", kind, value),
+	}
+}
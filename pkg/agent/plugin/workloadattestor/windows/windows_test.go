@@ -0,0 +1,146 @@
+package windows
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	ctx = context.Background()
+)
+
+func TestPlugin(t *testing.T) {
+	spiretest.Run(t, new(Suite))
+}
+
+type Suite struct {
+	spiretest.Suite
+
+	p workloadattestor.Plugin
+}
+
+func (s *Suite) SetupTest() {
+	p := New()
+	p.hooks.queryProcess = fakeQueryProcess
+	s.LoadPlugin(builtin(p), &s.p)
+}
+
+func (s *Suite) TestAttestNotConfigured() {
+	resp, err := s.p.Attest(ctx, &workloadattestor.AttestRequest{Pid: 1})
+	s.RequireGRPCStatus(err, codes.Unknown, "windows: not configured")
+	s.Nil(resp)
+}
+
+func (s *Suite) TestAttest() {
+	testCases := []struct {
+		name      string
+		pid       int32
+		config    string
+		err       string
+		selectors []string
+	}{
+		{
+			name: "fail to query process",
+			pid:  1,
+			err:  "windows: querying process: unable to open process: access denied",
+		},
+		{
+			name: "user name lookup fails",
+			pid:  2,
+			selectors: []string{
+				"user_sid:S-1-5-21-1004336348-1177238915-682003330-1001",
+			},
+		},
+		{
+			name: "user sid and name",
+			pid:  3,
+			selectors: []string{
+				"user_sid:S-1-5-18",
+				`user_name:NT AUTHORITY\SYSTEM`,
+			},
+		},
+		{
+			name:   "workload path",
+			pid:    3,
+			config: "discover_workload_path = true",
+			selectors: []string{
+				"user_sid:S-1-5-18",
+				`user_name:NT AUTHORITY\SYSTEM`,
+				`path:C:\Program Files\workload\workload.exe`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		s.T().Run(testCase.name, func(t *testing.T) {
+			s.configure(testCase.config)
+			resp, err := s.p.Attest(ctx, &workloadattestor.AttestRequest{
+				Pid: testCase.pid,
+			})
+			if testCase.err != "" {
+				spiretest.RequireGRPCStatus(t, err, codes.Unknown, testCase.err)
+				require.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			var selectors []string
+			for _, selector := range resp.Selectors {
+				require.Equal(t, "windows", selector.Type)
+				selectors = append(selectors, selector.Value)
+			}
+			require.Equal(t, testCase.selectors, selectors)
+		})
+	}
+}
+
+func (s *Suite) TestConfigure() {
+	resp, err := s.p.Configure(ctx, &spi.ConfigureRequest{})
+	s.NoError(err)
+	s.Equal(&spi.ConfigureResponse{}, resp)
+
+	_, err = s.p.Configure(ctx, &spi.ConfigureRequest{Configuration: "bad"})
+	s.RequireGRPCStatusContains(err, codes.Unknown, "windows:")
+}
+
+func (s *Suite) TestGetPluginInfo() {
+	resp, err := s.p.GetPluginInfo(ctx, &spi.GetPluginInfoRequest{})
+	s.NoError(err)
+	s.Equal(&spi.GetPluginInfoResponse{}, resp)
+}
+
+func (s *Suite) configure(config string) {
+	_, err := s.p.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: config,
+	})
+	s.Require().NoError(err)
+}
+
+func fakeQueryProcess(pid int32) (*processInfo, error) {
+	switch pid {
+	case 1:
+		return nil, errors.New("unable to open process: access denied")
+	case 2:
+		return &processInfo{
+			UserSID: "S-1-5-21-1004336348-1177238915-682003330-1001",
+			Path:    `C:\workload.exe`,
+		}, nil
+	case 3:
+		return &processInfo{
+			UserSID:  "S-1-5-18",
+			UserName: `NT AUTHORITY\SYSTEM`,
+			Path:     `C:\Program Files\workload\workload.exe`,
+		}, nil
+	default:
+		return nil, errors.New("unhandled test case")
+	}
+}
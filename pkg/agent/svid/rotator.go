@@ -12,6 +12,7 @@ import (
 	observer "github.com/imkira/go-observer"
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/common/backoff"
+	"github.com/spiffe/spire/pkg/agent/common/keymem"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/common/rotationutil"
 	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
@@ -26,6 +27,10 @@ type Rotator interface {
 	Subscribe() observer.Stream
 	GetRotationMtx() *sync.RWMutex
 	SetRotationFinishedHook(func())
+
+	// ForceRotation makes the rotator rotate the agent SVID right away,
+	// regardless of its lifetime
+	ForceRotation()
 }
 
 type rotator struct {
@@ -47,6 +52,9 @@ type rotator struct {
 
 	// Hook that will be called when the SVID rotation finishes
 	rotationFinishedHook func()
+
+	// forceRotation is signaled when the rotation of the SVID is forced
+	forceRotation chan struct{}
 }
 
 type State struct {
@@ -64,20 +72,32 @@ func (r *rotator) Run(ctx context.Context) error {
 }
 
 func (r *rotator) runRotation(ctx context.Context) error {
+	failures := 0
+	// forced is true until a forced rotation succeeds
+	forced := false
 	for {
+		interval := r.backoff.NextBackOff()
+		telemetry_agent.SetRotateAgentSVIDBackoffGauges(r.c.Metrics, float32(interval.Seconds()), failures)
+
 		select {
 		case <-ctx.Done():
 			return nil
-		case <-r.clk.After(r.backoff.NextBackOff()):
-			if err := r.rotateSVID(ctx); err != nil {
-				r.c.Log.WithError(err).Error("Could not rotate agent SVID")
-				if rotationutil.X509Expired(r.clk.Now(), r.state.Value().(State).SVID[0]) {
-					// Since our X509 cert has expired, and we weren't able to carry out a rotation request, we're probably unrecoverable without re-attesting.
-					return fmt.Errorf("current SVID has already expired and rotation failed: %v", err)
-				}
-			} else {
-				r.backoff.Reset()
+		case <-r.forceRotation:
+			forced = true
+		case <-r.clk.After(interval):
+		}
+
+		if err := r.rotateSVID(ctx, forced); err != nil {
+			failures++
+			r.c.Log.WithError(err).Error("Could not rotate agent SVID")
+			if rotationutil.X509Expired(r.clk.Now(), r.state.Value().(State).SVID[0]) {
+				// Since our X509 cert has expired, and we weren't able to carry out a rotation request, we're probably unrecoverable without re-attesting.
+				return fmt.Errorf("current SVID has already expired and rotation failed: %v", err)
 			}
+		} else {
+			forced = false
+			failures = 0
+			r.backoff.Reset()
 		}
 	}
 }
@@ -111,9 +131,18 @@ func (r *rotator) SetRotationFinishedHook(f func()) {
 	r.rotationFinishedHook = f
 }
 
-// rotateSVID asks SPIRE's server for a new agent's SVID.
-func (r *rotator) rotateSVID(ctx context.Context) (err error) {
-	if !rotationutil.ShouldRotateX509(r.clk.Now(), r.state.Value().(State).SVID[0]) {
+func (r *rotator) ForceRotation() {
+	select {
+	case r.forceRotation <- struct{}{}:
+	default:
+		// a forced rotation is already pending
+	}
+}
+
+// rotateSVID asks SPIRE's server for a new agent's SVID. Unless force is true,
+// the SVID is only rotated when it is about to expire.
+func (r *rotator) rotateSVID(ctx context.Context, force bool) (err error) {
+	if !force && !rotationutil.ShouldRotateX509(r.clk.Now(), r.state.Value().(State).SVID[0], r.c.RenewalThreshold) {
 		return nil
 	}
 
@@ -124,7 +153,11 @@ func (r *rotator) rotateSVID(ctx context.Context) (err error) {
 	// In this way, the client do not create new connections until the new SVID is received
 	r.rotMtx.Lock()
 	defer r.rotMtx.Unlock()
-	r.c.Log.Debug("Rotating agent SVID")
+	if force {
+		r.c.Log.Info("Rotating agent SVID as requested by the server")
+	} else {
+		r.c.Log.Debug("Rotating agent SVID")
+	}
 
 	key, err := r.newKey(ctx)
 	if err != nil {
@@ -187,6 +220,7 @@ func (r *rotator) newKey(ctx context.Context) (*ecdsa.PrivateKey, error) {
 	if err != nil {
 		return nil, fmt.Errorf("generate key pair: %v", err)
 	}
+	defer keymem.Wipe(resp.PrivateKey)
 
 	return x509.ParseECPrivateKey(resp.PrivateKey)
 }
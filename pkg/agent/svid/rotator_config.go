@@ -25,6 +25,8 @@ type RotatorConfig struct {
 	Metrics     telemetry.Metrics
 	TrustDomain url.URL
 	ServerAddr  string
+	ServerProxy client.ProxyFunc
+	ServerGRPC  client.GRPCConfig
 	// Initial SVID and key
 	SVID    []*x509.Certificate
 	SVIDKey *ecdsa.PrivateKey
@@ -36,6 +38,14 @@ type RotatorConfig struct {
 	// How long to wait between expiry checks
 	Interval time.Duration
 
+	// RenewalThreshold is the fraction of the lifetime of the SVID after
+	// which it is rotated. Zero means rotationutil.DefaultRenewalThreshold.
+	RenewalThreshold float64
+
+	// Backoff tunes how the interval between checks grows after failed
+	// rotations
+	Backoff backoff.Config
+
 	// Clk is the clock that the rotator will use to create a ticker
 	Clk clock.Clock
 }
@@ -65,6 +75,8 @@ func newRotator(c *RotatorConfig) (*rotator, client.Client) {
 		TrustDomain: c.TrustDomain,
 		Log:         c.Log,
 		Addr:        c.ServerAddr,
+		Proxy:       c.ServerProxy,
+		GRPC:        c.ServerGRPC,
 		RotMtx:      rotMtx,
 		KeysAndBundle: func() ([]*x509.Certificate, *ecdsa.PrivateKey, []*x509.Certificate) {
 			s := state.Value().(State)
@@ -87,8 +99,10 @@ func newRotator(c *RotatorConfig) (*rotator, client.Client) {
 		client:  client,
 		state:   state,
 		clk:     c.Clk,
-		backoff: backoff.NewBackoff(c.Clk, c.Interval),
+		backoff: backoff.NewBackoffWithConfig(c.Clk, c.Interval, c.Backoff),
 		bsm:     bsm,
 		rotMtx:  rotMtx,
+
+		forceRotation: make(chan struct{}, 1),
 	}, client
 }
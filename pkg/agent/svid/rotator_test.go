@@ -138,7 +138,7 @@ func (s *RotatorTestSuite) TestRunWithUpdates() {
 	})
 
 	s.mockClock.Add(s.r.c.Interval)
-	err = s.r.rotateSVID(context.Background())
+	err = s.r.rotateSVID(context.Background(), false)
 	s.Require().NoError(err)
 
 	select {
@@ -174,7 +174,7 @@ func (s *RotatorTestSuite) TestRotateSVID() {
 
 	stream := s.r.Subscribe()
 	s.expectSVIDRotation(goodCert)
-	err = s.r.rotateSVID(context.Background())
+	err = s.r.rotateSVID(context.Background(), false)
 	s.Assert().NoError(err)
 	s.Require().True(stream.HasNext())
 
@@ -183,6 +183,76 @@ func (s *RotatorTestSuite) TestRotateSVID() {
 	s.Assert().True(goodCert.Equal(state.SVID[0]))
 }
 
+func (s *RotatorTestSuite) TestRotateSVIDWithRenewalThreshold() {
+	// Cert that's valid for 1hr, and is past a quarter of its lifetime
+	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/test")
+	s.Require().NoError(err)
+	temp.NotBefore = s.mockClock.Now().Add(-20 * time.Minute)
+	temp.NotAfter = s.mockClock.Now().Add(40 * time.Minute)
+	cert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	state := State{
+		SVID: []*x509.Certificate{cert},
+	}
+	s.r.state = observer.NewProperty(state)
+	stream := s.r.Subscribe()
+
+	// Not rotated with the default threshold
+	err = s.r.rotateSVID(context.Background(), false)
+	s.Require().NoError(err)
+	s.Require().False(stream.HasNext())
+
+	// Rotated with a lower threshold
+	s.r.c.RenewalThreshold = 0.25
+	s.expectSVIDRotation(cert)
+	err = s.r.rotateSVID(context.Background(), false)
+	s.Require().NoError(err)
+	s.Require().True(stream.HasNext())
+}
+
+func (s *RotatorTestSuite) TestForceRotation() {
+	// Cert that's valid for 1hr, so it is not about to expire
+	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/test")
+	s.Require().NoError(err)
+	cert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+	newCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	s.r.state = observer.NewProperty(State{
+		SVID: []*x509.Certificate{cert},
+	})
+	stream := s.r.Subscribe()
+
+	// Not rotated unless forced
+	s.Require().NoError(s.r.rotateSVID(context.Background(), false))
+	s.Require().False(stream.HasNext())
+
+	s.expectSVIDRotation(newCert)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t := new(tomb.Tomb)
+	t.Go(func() error {
+		return s.r.Run(ctx)
+	})
+
+	// The rotation happens right away, without waiting for the interval
+	s.r.ForceRotation()
+
+	select {
+	case <-time.After(time.Second):
+		s.T().Error("timed out while waiting for expected SVID rotation")
+	case <-stream.Changes():
+		state := stream.Next().(State)
+		s.Require().Len(state.SVID, 1)
+		s.Assert().Equal(newCert, state.SVID[0])
+	}
+
+	cancel()
+	s.Require().Equal(context.Canceled, t.Wait())
+}
+
 // expectSVIDRotation sets the appropriate expectations for an SVID rotation, and returns
 // the the provided certificate to the client.Client caller.
 func (s *RotatorTestSuite) expectSVIDRotation(cert *x509.Certificate) {
@@ -0,0 +1,129 @@
+// Package apierror defines the stable reasons describing why Node and
+// Workload API calls fail. The reason, and any metadata qualifying it, is
+// attached to the gRPC status as a common.ErrorDetails so that clients and
+// dashboards can tell failure modes apart without parsing error messages,
+// which are not part of the API contract.
+package apierror
+
+import (
+	"fmt"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reasons, grouped by the status code they are returned with.
+const (
+	// PermissionDenied: the caller is not entitled to the requested
+	// identity, i.e. no registration entry matches it.
+	NoEntry = "no_entry"
+
+	// PermissionDenied: the agent is banned.
+	AgentBanned = "agent_banned"
+
+	// PermissionDenied: the agent SVID does not belong to an attested node,
+	// e.g. because the agent was evicted.
+	AgentNotAttested = "agent_not_attested"
+
+	// PermissionDenied: the caller is not a downstream SPIRE server.
+	NotDownstream = "not_downstream"
+
+	// Unauthenticated: the caller did not present the SVID required by the
+	// method.
+	MissingSVID = "missing_svid"
+
+	// Unauthenticated: the Workload API could not verify the calling
+	// process.
+	UnknownCaller = "unknown_caller"
+
+	// InvalidArgument: the Workload API request lacks the security header.
+	MissingSecurityHeader = "missing_security_header"
+
+	// InvalidArgument: a CSR in the request cannot be parsed or does not
+	// conform to the key policy.
+	InvalidCSR = "invalid_csr"
+
+	// ResourceExhausted: the caller exceeded a rate limit, named by the
+	// LimitKey metadata.
+	RateLimited = "rate_limited"
+)
+
+const (
+	// LimitKey is the metadata key naming the limit a RateLimited call
+	// exceeded.
+	LimitKey = "limit"
+)
+
+// Limits exceeded by RateLimited calls.
+const (
+	// Node attestations per IP address.
+	LimitAttestation = "attestation"
+
+	// X509-SVID (and downstream X509 CA) signing requests.
+	LimitSigning = "signing"
+
+	// JWT-SVID signing requests.
+	LimitJWTSigning = "jwt_signing"
+
+	// JWT authority publications by downstream servers.
+	LimitJWTKeyPublishing = "jwt_key_publishing"
+
+	// Workload API calls to FetchX509SVID, FetchJWTSVID and ValidateJWTSVID.
+	LimitFetchX509SVID   = "fetch_x509_svid"
+	LimitFetchJWTSVID    = "fetch_jwt_svid"
+	LimitValidateJWTSVID = "validate_jwt_svid"
+)
+
+// Error returns a status error with the code and message, carrying the
+// reason and metadata as details.
+func Error(code codes.Code, reason string, metadata map[string]string, msg string) error {
+	st := status.New(code, msg)
+	withDetails, err := st.WithDetails(&common.ErrorDetails{
+		Reason:   reason,
+		Metadata: metadata,
+	})
+	if err != nil {
+		// only fails on marshaling errors, which cannot happen with
+		// ErrorDetails
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// Errorf is like Error, formatting the message.
+func Errorf(code codes.Code, reason string, metadata map[string]string, format string, args ...interface{}) error {
+	return Error(code, reason, metadata, fmt.Sprintf(format, args...))
+}
+
+// RateLimitedError returns the ResourceExhausted error of a call exceeding
+// the named limit.
+func RateLimitedError(limit string, msg string) error {
+	return Error(codes.ResourceExhausted, RateLimited, map[string]string{LimitKey: limit}, msg)
+}
+
+// Details returns the details attached to a status error, if any.
+func Details(err error) (*common.ErrorDetails, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil, false
+	}
+	for _, detail := range st.Details() {
+		if details, ok := detail.(*common.ErrorDetails); ok {
+			return details, true
+		}
+	}
+	return nil, false
+}
+
+// Reason returns the reason attached to a status error, or an empty string
+// if there is none.
+func Reason(err error) string {
+	details, _ := Details(err)
+	return details.GetReason()
+}
+
+// Is determines if the error is a status error with the code and reason.
+func Is(err error, code codes.Code, reason string) bool {
+	return status.Code(err) == code && Reason(err) == reason
+}
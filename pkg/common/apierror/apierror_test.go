@@ -0,0 +1,45 @@
+package apierror
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestError(t *testing.T) {
+	err := Errorf(codes.PermissionDenied, NoEntry, nil, "no identity issued for %q", "workload")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Equal(t, `no identity issued for "workload"`, status.Convert(err).Message())
+	require.Equal(t, NoEntry, Reason(err))
+	require.True(t, Is(err, codes.PermissionDenied, NoEntry))
+	require.False(t, Is(err, codes.Unauthenticated, NoEntry))
+	require.False(t, Is(err, codes.PermissionDenied, AgentBanned))
+}
+
+func TestRateLimitedError(t *testing.T) {
+	err := RateLimitedError(LimitSigning, "rate limit exceeded")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// the details survive the round trip through the status proto, as when
+	// sent over the wire
+	details, ok := Details(status.FromProto(status.Convert(err).Proto()).Err())
+	require.True(t, ok)
+	require.Equal(t, RateLimited, details.Reason)
+	require.Equal(t, map[string]string{LimitKey: LimitSigning}, details.Metadata)
+}
+
+func TestNoDetails(t *testing.T) {
+	for _, err := range []error{
+		nil,
+		errors.New("ohno"),
+		status.Error(codes.PermissionDenied, "ohno"),
+	} {
+		details, ok := Details(err)
+		require.False(t, ok)
+		require.Nil(t, details)
+		require.Empty(t, Reason(err))
+	}
+}
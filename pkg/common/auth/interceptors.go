@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"strings"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -19,29 +20,62 @@ func (fn AuthorizerFunc) AuthorizeCall(ctx context.Context, fullMethod string) (
 }
 
 func UnaryAuthorizeCall(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	ctx, err := authorizeCall(ctx, info.Server, info.FullMethod)
-	if err != nil {
-		return nil, err
-	}
-	return handler(ctx, req)
+	return UnaryAuthorizeCallWithFallback(nil)(ctx, req, info, handler)
 }
 
 func StreamAuthorizeCall(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-	ctx, err := authorizeCall(ss.Context(), srv, info.FullMethod)
-	if err != nil {
-		return err
+	return StreamAuthorizeCallWithFallback(nil)(srv, ss, info, handler)
+}
+
+// UnaryAuthorizeCallWithFallback returns an interceptor authorizing calls
+// like UnaryAuthorizeCall, except that calls to services unable to provide
+// authorization themselves (e.g. server reflection) are authorized by the
+// fallback authorizer instead of being denied.
+func UnaryAuthorizeCallWithFallback(fallback Authorizer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authorizeCall(ctx, info.Server, info.FullMethod, fallback)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthorizeCallWithFallback is the streaming counterpart of
+// UnaryAuthorizeCallWithFallback.
+func StreamAuthorizeCallWithFallback(fallback Authorizer) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorizeCall(ss.Context(), srv, info.FullMethod, fallback)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, serverStream{
+			ServerStream: ss,
+			ctx:          ctx,
+		})
 	}
+}
 
-	return handler(srv, serverStream{
-		ServerStream: ss,
-		ctx:          ctx,
-	})
+// publicServices are the standard gRPC services that any caller can use,
+// since they only expose the health of the server.
+var publicServices = []string{
+	"/grpc.health.v1.Health/",
 }
 
-func authorizeCall(ctx context.Context, srv interface{}, fullMethod string) (context.Context, error) {
+func authorizeCall(ctx context.Context, srv interface{}, fullMethod string, fallback Authorizer) (context.Context, error) {
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return ctx, nil
+		}
+	}
+
 	authorizer, ok := srv.(Authorizer)
 	if !ok {
-		return nil, status.Errorf(codes.PermissionDenied, "server unable to provide authorization for method %q", fullMethod)
+		if fallback == nil {
+			return nil, status.Errorf(codes.PermissionDenied, "server unable to provide authorization for method %q", fullMethod)
+		}
+		authorizer = fallback
 	}
 	return authorizer.AuthorizeCall(ctx, fullMethod)
 }
@@ -47,6 +47,17 @@ func TestUnaryAuthorizeCall(t *testing.T) {
 	}, handler)
 	require.EqualError(t, err, "error")
 	require.Equal(t, "resp", resp)
+
+	// public services do not need authorization
+	handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "resp", nil
+	}
+	resp, err = UnaryAuthorizeCall(context.Background(), "req", &grpc.UnaryServerInfo{
+		Server:     nil,
+		FullMethod: "/grpc.health.v1.Health/Check",
+	}, handler)
+	require.NoError(t, err)
+	require.Equal(t, "resp", resp)
 }
 
 func TestStreamAuthorizeCall(t *testing.T) {
@@ -76,4 +87,50 @@ func TestStreamAuthorizeCall(t *testing.T) {
 	}
 	err = StreamAuthorizeCall(server, stream, info, handler)
 	require.EqualError(t, err, "error")
+
+	// public services do not need authorization
+	info = &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch"}
+	err = StreamAuthorizeCall(nil, stream, info, func(interface{}, grpc.ServerStream) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	// server reflection is not public
+	info = &grpc.StreamServerInfo{FullMethod: "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo"}
+	err = StreamAuthorizeCall(nil, stream, info, nil)
+	require.EqualError(t, err, `rpc error: code = PermissionDenied desc = server unable to provide authorization for method "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo"`)
+}
+
+func TestAuthorizeCallWithFallback(t *testing.T) {
+	fallback := AuthorizerFunc(func(ctx context.Context, fullMethod string) (context.Context, error) {
+		if fullMethod != "FOO" {
+			return nil, errors.New("no auth for you")
+		}
+		return context.WithValue(ctx, testKey{}, "fallback"), nil
+	})
+
+	// the fallback authorizes calls to servers unable to authorize them
+	resp, err := UnaryAuthorizeCallWithFallback(fallback)(context.Background(), "req", &grpc.UnaryServerInfo{
+		Server:     nil,
+		FullMethod: "FOO",
+	}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		require.Equal(t, "fallback", ctx.Value(testKey{}))
+		return "resp", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "resp", resp)
+
+	stream := serverStream{ctx: context.Background()}
+	err = StreamAuthorizeCallWithFallback(fallback)(nil, stream, &grpc.StreamServerInfo{FullMethod: "BAR"}, nil)
+	require.EqualError(t, err, "no auth for you")
+
+	// servers able to authorize calls are not overridden by the fallback
+	server := AuthorizerFunc(func(ctx context.Context, fullMethod string) (context.Context, error) {
+		return context.WithValue(ctx, testKey{}, "server"), nil
+	})
+	err = StreamAuthorizeCallWithFallback(fallback)(server, stream, &grpc.StreamServerInfo{FullMethod: "BAR"}, func(_ interface{}, stream grpc.ServerStream) error {
+		require.Equal(t, "server", stream.Context().Value(testKey{}))
+		return nil
+	})
+	require.NoError(t, err)
 }
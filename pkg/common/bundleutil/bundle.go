@@ -100,6 +100,16 @@ func (b *Bundle) SetRefreshHint(d time.Duration) {
 	b.b.RefreshHint = int64((d + (time.Second - 1)) / time.Second)
 }
 
+// SequenceNumber returns the bundle sequence number.
+func (b *Bundle) SequenceNumber() uint64 {
+	return b.b.SequenceNumber
+}
+
+// SetSequenceNumber sets the bundle sequence number.
+func (b *Bundle) SetSequenceNumber(sequenceNumber uint64) {
+	b.b.SequenceNumber = sequenceNumber
+}
+
 func (b *Bundle) AppendRootCA(rootCA *x509.Certificate) {
 	b.b.RootCas = append(b.b.RootCas, &common.Certificate{
 		DerBytes: rootCA.Raw,
@@ -209,6 +219,9 @@ func MergeBundles(a, b *common.Bundle) (*common.Bundle, bool) {
 			changed = true
 		}
 	}
+	if changed {
+		c.SequenceNumber++
+	}
 	return c, changed
 }
 
@@ -226,7 +239,9 @@ func PruneBundle(bundle *common.Bundle, expiration time.Time, log hclog.Logger)
 
 	// Creates new bundle with non expired certs only
 	newBundle := &common.Bundle{
-		TrustDomainId: bundle.TrustDomainId,
+		TrustDomainId:  bundle.TrustDomainId,
+		RefreshHint:    bundle.RefreshHint,
+		SequenceNumber: bundle.SequenceNumber,
 	}
 	changed := false
 pruneRootCA:
@@ -266,6 +281,10 @@ pruneRootCA:
 		return nil, false, errors.New("would prune all JWT signing keys")
 	}
 
+	if changed {
+		newBundle.SequenceNumber++
+	}
+
 	return newBundle, changed, nil
 }
 
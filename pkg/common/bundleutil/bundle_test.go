@@ -95,11 +95,15 @@ func (s *BundleUtilSuite) TestPruneBundleSucceeds() {
 		[]*x509.Certificate{s.certNotExpired, s.certExpired},
 		[]*common.PublicKey{s.jwtKeyNotExpired, s.jwtKeyExpired},
 	)
+	bundle.RefreshHint = 60
+	bundle.SequenceNumber = 1
 
 	expectedBundle := s.createBundle(
 		[]*x509.Certificate{s.certNotExpired},
 		[]*common.PublicKey{s.jwtKeyNotExpired},
 	)
+	expectedBundle.RefreshHint = 60
+	expectedBundle.SequenceNumber = 2
 
 	newBundle, changed, err := PruneBundle(bundle, s.currentTime, hclog.NewNullLogger())
 	s.NoError(err)
@@ -107,6 +111,35 @@ func (s *BundleUtilSuite) TestPruneBundleSucceeds() {
 	s.True(changed)
 }
 
+func (s *BundleUtilSuite) TestMergeBundles() {
+	bundle := s.createBundle(
+		[]*x509.Certificate{s.certNotExpired},
+		[]*common.PublicKey{s.jwtKeyNotExpired},
+	)
+	bundle.SequenceNumber = 1
+
+	// merging a bundle with no new contents does not change it
+	merged, changed := MergeBundles(bundle, s.createBundle(
+		[]*x509.Certificate{s.certNotExpired},
+		nil,
+	))
+	s.False(changed)
+	s.Equal(bundle, merged)
+
+	// merging new contents increments the sequence number
+	merged, changed = MergeBundles(bundle, s.createBundle(
+		[]*x509.Certificate{s.certExpired},
+		[]*common.PublicKey{s.jwtKeyExpired},
+	))
+	s.True(changed)
+	expectedBundle := s.createBundle(
+		[]*x509.Certificate{s.certNotExpired, s.certExpired},
+		[]*common.PublicKey{s.jwtKeyNotExpired, s.jwtKeyExpired},
+	)
+	expectedBundle.SequenceNumber = 2
+	s.Equal(expectedBundle, merged)
+}
+
 func (s *BundleUtilSuite) createBundle(certs []*x509.Certificate, jwtKeys []*common.PublicKey) *common.Bundle {
 	bundle := BundleProtoFromRootCAs("spiffe://foo", certs)
 	bundle.JwtSigningKeys = jwtKeys
@@ -59,6 +59,7 @@ func Marshal(bundle *Bundle, opts ...MarshalOption) ([]byte, error) {
 	}
 
 	doc := bundleDoc{
+		Sequence:    bundle.SequenceNumber(),
 		RefreshHint: int(c.refreshHint / time.Second),
 	}
 
@@ -12,10 +12,11 @@ func TestMarshal(t *testing.T) {
 	rootCA := createCACertificate(t)
 
 	testCases := []struct {
-		name  string
-		empty bool
-		opts  []MarshalOption
-		out   string
+		name           string
+		empty          bool
+		sequenceNumber uint64
+		opts           []MarshalOption
+		out            string
 	}{
 		{
 			name:  "empty bundle",
@@ -30,6 +31,12 @@ func TestMarshal(t *testing.T) {
 			},
 			out: `{"keys":null, "spiffe_refresh_hint": 10}`,
 		},
+		{
+			name:           "with sequence number",
+			empty:          true,
+			sequenceNumber: 3,
+			out:            `{"keys":null, "spiffe_refresh_hint": 60, "spiffe_sequence": 3}`,
+		},
 		{
 			name: "without X509 SVID keys",
 			opts: []MarshalOption{
@@ -104,6 +111,7 @@ func TestMarshal(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			bundle := New("spiffe://domain.test")
 			bundle.SetRefreshHint(time.Minute)
+			bundle.SetSequenceNumber(testCase.sequenceNumber)
 			if !testCase.empty {
 				bundle.AppendRootCA(rootCA)
 				require.NoError(t, bundle.AppendJWTSigningKey("FOO", testKey.Public()))
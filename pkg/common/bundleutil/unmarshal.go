@@ -28,6 +28,7 @@ func Unmarshal(trustDomainID string, data []byte) (*Bundle, error) {
 func unmarshal(trustDomainID string, doc *bundleDoc) (*Bundle, error) {
 	bundle := New(trustDomainID)
 	bundle.SetRefreshHint(time.Second * time.Duration(doc.RefreshHint))
+	bundle.SetSequenceNumber(doc.Sequence)
 
 	for i, key := range doc.Keys {
 		switch key.Use {
@@ -3,6 +3,7 @@ package bundleutil
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -21,6 +22,20 @@ func TestUnmarshal(t *testing.T) {
 			doc:    "{}",
 			bundle: New("spiffe://domain.test"),
 		},
+		{
+			name: "with refresh hint and sequence number",
+			doc: `{
+				"keys": null,
+				"spiffe_refresh_hint": 60,
+				"spiffe_sequence": 3
+			}`,
+			bundle: func() *Bundle {
+				bundle := New("spiffe://domain.test")
+				bundle.SetRefreshHint(time.Minute)
+				bundle.SetSequenceNumber(3)
+				return bundle
+			}(),
+		},
 		{
 			name: "entry missing use",
 			doc: `{
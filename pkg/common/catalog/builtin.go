@@ -82,7 +82,7 @@ func LoadBuiltInPlugin(ctx context.Context, builtin BuiltInPlugin) (plugin *Load
 		Name: telemetry.PluginBuiltIn,
 	}).Named(builtin.Plugin.Name)
 
-	initPluginServer(
+	impls := initPluginServer(
 		builtinServer,
 		&builtinDialer{hostConn: hostConn},
 		logger,
@@ -90,6 +90,17 @@ func LoadBuiltInPlugin(ctx context.Context, builtin BuiltInPlugin) (plugin *Load
 		builtin.Plugin.Services,
 	)
 
+	// close the implementations that hold resources (e.g. background
+	// goroutines) once the plugin is unloaded. the plugin and service
+	// interface might be implemented by the same underlying struct.
+	closed := make(map[interface{}]bool)
+	for _, impl := range impls {
+		if closer, ok := impl.(io.Closer); ok && !closed[impl] {
+			closed[impl] = true
+			closers.AddCloser(closer)
+		}
+	}
+
 	// now start the built in server
 	wg.Add(1)
 	go func() {
@@ -2,6 +2,8 @@ package catalog
 
 import (
 	"context"
+	"reflect"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/telemetry"
@@ -106,16 +108,42 @@ type Catalog interface {
 	//
 	Fill(x interface{}) error
 
+	// Reconfigure configures the loaded plugins again with the given plugin
+	// configuration, so their configuration can change without restarting.
+	// Only the plugins whose data changed are reconfigured. Adding,
+	// removing or replacing plugins is not supported; nothing is
+	// reconfigured if the set of enabled plugins, or how they are loaded,
+	// differs from the loaded ones.
+	Reconfigure(ctx context.Context, pluginConfig []PluginConfig) error
+
+	// CheckHealth probes each of the loaded plugins. Crashed external
+	// plugins configured to be restarted are restarted and configured
+	// again before being reported.
+	CheckHealth(ctx context.Context) []PluginHealth
+
 	// Close() closes the catalog, shutting down servers and killing external
 	// plugin processes.
 	Close()
 }
 
+// PluginHealth is the outcome of the health probe of a loaded plugin.
+type PluginHealth struct {
+	Type string
+	Name string
+
+	// Err is the reason the plugin is unhealthy, or nil if it is healthy
+	Err error
+
+	// Restarted is true if the plugin process had crashed and was
+	// restarted, in which case Err is the reason the restart failed, if any
+	Restarted bool
+}
+
 type Closer interface {
 	Close()
 }
 
-func Fill(ctx context.Context, config Config, x interface{}) (Closer, error) {
+func Fill(ctx context.Context, config Config, x interface{}) (Catalog, error) {
 	c, err := Load(ctx, config)
 	if err != nil {
 		return nil, err
@@ -143,19 +171,26 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 	}
 
 	// close the plugins if there is an error.
-	cat := new(catalog)
+	cat := &catalog{
+		log:          config.Log,
+		globalConfig: config.GlobalConfig,
+	}
 	defer func() {
 		if err != nil {
 			cat.Close()
 		}
 	}()
 
+	if err := checkPrimaryPlugins(config.PluginConfig); err != nil {
+		return nil, err
+	}
+
 	for _, c := range config.PluginConfig {
 		// configure a logger for the plugin
 		pluginLog := config.Log.WithFields(logrus.Fields{
 			telemetry.PluginName:    c.Name,
 			telemetry.PluginType:    c.Type,
-			telemetry.PluginBuiltIn: c.Path == "",
+			telemetry.PluginBuiltIn: c.Path == "" && c.Address == "",
 		})
 
 		if c.Disabled {
@@ -164,7 +199,25 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 		}
 
 		var plugin *LoadedPlugin
-		if c.Path == "" {
+		switch {
+		case c.Address != "":
+			remotePlugin, ok := knownPluginsMap[c.Type]
+			if !ok {
+				return nil, errs.New("unknown plugin type %q", c.Type)
+			}
+			if c.TLS == nil {
+				return nil, errs.New("no TLS configuration for remote %s plugin %q", c.Type, c.Name)
+			}
+
+			plugin, err = LoadRemotePlugin(ctx, RemotePlugin{
+				Log:           config.Log,
+				Name:          c.Name,
+				Address:       c.Address,
+				TLS:           *c.TLS,
+				Plugin:        remotePlugin,
+				KnownServices: config.KnownServices,
+			})
+		case c.Path == "":
 			builtin, ok := builtinsMap.Lookup(c.Name, c.Type)
 			if !ok {
 				return nil, errs.New("no such %s builtin %q", c.Type, c.Name)
@@ -174,7 +227,7 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 				Plugin:       builtin,
 				HostServices: config.HostServices,
 			})
-		} else {
+		default:
 			extPlugin, ok := knownPluginsMap[c.Type]
 			if !ok {
 				return nil, errs.New("unknown plugin type %q", c.Type)
@@ -185,6 +238,7 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 				Name:          c.Name,
 				Path:          c.Path,
 				Checksum:      c.Checksum,
+				Signature:     c.Signature,
 				Plugin:        extPlugin,
 				KnownServices: config.KnownServices,
 				HostServices:  config.HostServices,
@@ -203,15 +257,44 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 			return nil, errs.New("unable to configure plugin %q: %v", c.Name, err)
 		}
 
+		plugin.role = c.Role
 		pluginLog.WithField(telemetry.PluginServices, plugin.serviceNames).Info("Plugin loaded.")
 		cat.plugins = append(cat.plugins, plugin)
+		cat.pluginConfigs = append(cat.pluginConfigs, c)
 	}
 
 	return cat, nil
 }
 
+// checkPrimaryPlugins fails if more than one enabled plugin of the same type
+// is designated as the primary instance.
+func checkPrimaryPlugins(pluginConfigs []PluginConfig) error {
+	primaries := make(map[string]string)
+	for _, c := range pluginConfigs {
+		if c.Disabled || c.Role != PluginRolePrimary {
+			continue
+		}
+		if name, ok := primaries[c.Type]; ok {
+			// plugin configs parsed from HCL are in no particular order
+			a, b := name, c.Name
+			if a > b {
+				a, b = b, a
+			}
+			return errs.New("%s plugins %q and %q cannot both be the primary instance", c.Type, a, b)
+		}
+		primaries[c.Type] = c.Name
+	}
+	return nil
+}
+
 type catalog struct {
-	plugins []*LoadedPlugin
+	log          logrus.FieldLogger
+	globalConfig GlobalConfig
+	plugins      []*LoadedPlugin
+
+	// pluginConfigs holds the configuration of each of the plugins, by index
+	pluginConfigsMtx sync.Mutex
+	pluginConfigs    []PluginConfig
 }
 
 func (c *catalog) Fill(x interface{}) (err error) {
@@ -219,8 +302,128 @@ func (c *catalog) Fill(x interface{}) (err error) {
 	return f.fill(x)
 }
 
+func (c *catalog) Reconfigure(ctx context.Context, pluginConfigs []PluginConfig) error {
+	c.pluginConfigsMtx.Lock()
+	defer c.pluginConfigsMtx.Unlock()
+
+	newConfigs := make(map[pluginKey]PluginConfig)
+	for _, pluginConfig := range pluginConfigs {
+		if !pluginConfig.Disabled {
+			newConfigs[pluginKeyOf(pluginConfig)] = pluginConfig
+		}
+	}
+	if len(newConfigs) != len(c.pluginConfigs) {
+		return errs.New("plugins cannot be added or removed without restarting")
+	}
+	for _, oldConfig := range c.pluginConfigs {
+		newConfig, ok := newConfigs[pluginKeyOf(oldConfig)]
+		if !ok {
+			return errs.New("plugins cannot be added or removed without restarting")
+		}
+		if !sameLoading(oldConfig, newConfig) {
+			return errs.New("%s plugin %q cannot be replaced without restarting", oldConfig.Type, oldConfig.Name)
+		}
+	}
+
+	var pluginErrs []error
+	for i, oldConfig := range c.pluginConfigs {
+		newConfig := newConfigs[pluginKeyOf(oldConfig)]
+		// whether the plugin is restarted only matters to the health probe
+		c.pluginConfigs[i].Restart = newConfig.Restart
+		if newConfig.Data == oldConfig.Data {
+			continue
+		}
+
+		pluginLog := c.log.WithFields(logrus.Fields{
+			telemetry.PluginName: oldConfig.Name,
+			telemetry.PluginType: oldConfig.Type,
+		})
+		if err := c.plugins[i].Configure(ctx, &spi.ConfigureRequest{
+			GlobalConfig:  &c.globalConfig,
+			Configuration: newConfig.Data,
+		}); err != nil {
+			pluginLog.WithError(err).Error("Failed to reconfigure plugin.")
+			pluginErrs = append(pluginErrs, PluginError{
+				Type: oldConfig.Type,
+				Name: oldConfig.Name,
+				Err:  err,
+			})
+			continue
+		}
+
+		pluginLog.Info("Plugin reconfigured.")
+		c.pluginConfigs[i] = newConfig
+	}
+	return errs.Combine(pluginErrs...)
+}
+
+func (c *catalog) CheckHealth(ctx context.Context) []PluginHealth {
+	// hold the lock so that plugins are not restarted while reconfigured
+	c.pluginConfigsMtx.Lock()
+	defer c.pluginConfigsMtx.Unlock()
+
+	health := make([]PluginHealth, 0, len(c.plugins))
+	for i, plugin := range c.plugins {
+		config := c.pluginConfigs[i]
+		pluginHealth := PluginHealth{
+			Type: config.Type,
+			Name: config.Name,
+			Err:  plugin.CheckHealth(ctx),
+		}
+		if pluginHealth.Err != nil && config.Restart && plugin.Crashed() {
+			pluginHealth.Err = c.restartPlugin(ctx, plugin, config)
+			pluginHealth.Restarted = true
+		}
+		health = append(health, pluginHealth)
+	}
+	return health
+}
+
+func (c *catalog) restartPlugin(ctx context.Context, plugin *LoadedPlugin, config PluginConfig) error {
+	pluginLog := c.log.WithFields(logrus.Fields{
+		telemetry.PluginName: config.Name,
+		telemetry.PluginType: config.Type,
+	})
+	pluginLog.Warn("Plugin process exited; restarting.")
+
+	if err := plugin.Restart(ctx); err != nil {
+		pluginLog.WithError(err).Error("Failed to restart plugin.")
+		return errs.New("unable to restart plugin: %v", err)
+	}
+	if err := plugin.Configure(ctx, &spi.ConfigureRequest{
+		GlobalConfig:  &c.globalConfig,
+		Configuration: config.Data,
+	}); err != nil {
+		pluginLog.WithError(err).Error("Failed to configure restarted plugin.")
+		return errs.New("unable to configure restarted plugin: %v", err)
+	}
+
+	pluginLog.Info("Plugin restarted.")
+	return plugin.CheckHealth(ctx)
+}
+
 func (c *catalog) Close() {
 	for _, p := range c.plugins {
 		p.Close()
 	}
 }
+
+type pluginKey struct {
+	Type string
+	Name string
+}
+
+func pluginKeyOf(c PluginConfig) pluginKey {
+	return pluginKey{Type: c.Type, Name: c.Name}
+}
+
+// sameLoading returns true if both configurations load the same plugin in
+// the same way, regardless of the plugin data.
+func sameLoading(a, b PluginConfig) bool {
+	return a.Path == b.Path &&
+		a.Checksum == b.Checksum &&
+		reflect.DeepEqual(a.Signature, b.Signature) &&
+		a.Address == b.Address &&
+		a.Role == b.Role &&
+		reflect.DeepEqual(a.TLS, b.TLS)
+}
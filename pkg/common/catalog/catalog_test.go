@@ -2,16 +2,30 @@ package catalog_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"math/big"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/sirupsen/logrus"
 	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/catalog"
@@ -243,6 +257,309 @@ func (s *CatalogSuite) TestConfigureFailure() {
 	s.assertFillCatalogFails(`unable to configure plugin "testext": rpc error: code = InvalidArgument desc = BAD configuration`)
 }
 
+func (s *CatalogSuite) TestPrimaryPlugin() {
+	s.builtins = []catalog.Plugin{testBuiltInNoService()}
+	s.pluginConfig = append(s.extPluginConfig(), s.builtinConfig()...)
+	s.pluginConfig[0].Role = catalog.PluginRoleSecondary
+	s.pluginConfig[1].Role = catalog.PluginRolePrimary
+
+	type pluginWithInfo struct {
+		catalog.PluginInfo
+		catalogtest.Plugin
+	}
+	c := &struct {
+		Plugin   pluginWithInfo
+		Optional *pluginWithInfo
+		Plugins  []pluginWithInfo
+	}{}
+	closer, err := s.fillCatalog(c)
+	s.Require().NoError(err)
+	defer closer.Close()
+
+	s.Equal("testbuiltin", c.Plugin.Name())
+	s.Require().NotNil(c.Optional)
+	s.Equal("testbuiltin", c.Optional.Name())
+	s.Len(c.Plugins, 2)
+}
+
+func (s *CatalogSuite) TestMoreThanOnePrimaryPlugin() {
+	s.builtins = []catalog.Plugin{testBuiltInNoService()}
+	s.pluginConfig = append(s.extPluginConfig(), s.builtinConfig()...)
+	s.pluginConfig[0].Role = catalog.PluginRolePrimary
+	s.pluginConfig[1].Role = catalog.PluginRolePrimary
+
+	s.assertFillCatalogFails(`Plugin plugins "testbuiltin" and "testext" cannot both be the primary instance`)
+}
+
+func (s *CatalogSuite) TestValidate() {
+	s.builtins = []catalog.Plugin{testBuiltIn()}
+	s.pluginConfig = append(s.extPluginConfig(), s.builtinConfig()...)
+	s.pluginConfig[1].Data = "BAD"
+	s.pluginConfig = append(s.pluginConfig, catalog.PluginConfig{
+		Name: "other",
+		Type: catalogtest.PluginType,
+	})
+
+	// External plugins are not launched, so their configuration is not
+	// validated, but their binary is checked
+	ext := s.extPluginConfig()[0]
+	ext.Name = "testext2"
+	ext.Data = "BAD"
+	s.pluginConfig = append(s.pluginConfig, ext)
+	ext.Name = "testext3"
+	ext.Checksum = strings.Repeat("0", 64)
+	s.pluginConfig = append(s.pluginConfig, ext)
+	ext.Name = "testext4"
+	ext.Path = filepath.Join(s.dir, "missing")
+	ext.Checksum = ""
+	s.pluginConfig = append(s.pluginConfig, ext)
+
+	// Every failing plugin is reported, not just the first one
+	pluginErrs := catalog.Validate(context.Background(), catalog.Config{
+		Log:           s.log,
+		PluginConfig:  s.pluginConfig,
+		KnownPlugins:  s.knownPlugins,
+		KnownServices: s.knownServices,
+		HostServices:  s.hostServices,
+		BuiltIns:      s.builtins,
+	})
+	s.Require().Len(pluginErrs, 4)
+	s.EqualError(pluginErrs[0], `Plugin plugin "other": no such Plugin builtin "other"`)
+	s.EqualError(pluginErrs[1], `Plugin plugin "testbuiltin": unable to configure plugin "testbuiltin": rpc error: code = InvalidArgument desc = BAD configuration`)
+	s.EqualError(pluginErrs[2], `Plugin plugin "testext3": checksums did not match`)
+	s.Contains(pluginErrs[3].Error(), `Plugin plugin "testext4": unable to stat plugin binary:`)
+}
+
+func (s *CatalogSuite) TestExternalPluginSignature() {
+	s.pluginConfig = s.extPluginConfig()
+	s.pluginConfig[0].Checksum = ""
+	s.pluginConfig[0].Signature = s.signPlugin(s.path)
+
+	c := new(testCatalog)
+	closer, err := s.fillCatalog(c)
+	s.Require().NoError(err)
+	closer.Close()
+}
+
+func (s *CatalogSuite) TestExternalPluginTamperedSignature() {
+	tampered := filepath.Join(s.dir, "tampered")
+	s.Require().NoError(ioutil.WriteFile(tampered, []byte("TAMPERED"), 0600))
+
+	s.pluginConfig = s.extPluginConfig()
+	s.pluginConfig[0].Checksum = ""
+	s.pluginConfig[0].Signature = s.signPlugin(tampered)
+	s.pluginConfig[0].Signature.SignatureFile = tampered + ".sig"
+
+	s.assertFillCatalogFails("unable to verify plugin signature: cosign signature does not match the plugin binary")
+}
+
+func (s *CatalogSuite) TestRemotePlugin() {
+	creds := s.newRemoteCredentials("remote")
+	address, stop := s.serveRemotePlugin(creds)
+	defer stop()
+
+	s.pluginConfig = []catalog.PluginConfig{
+		{
+			Name:    "testremote",
+			Type:    catalogtest.PluginType,
+			Data:    "CONFIG",
+			Address: address,
+			TLS:     creds.clientTLS,
+		},
+	}
+
+	c := new(testCatalog)
+	closer, err := s.fillCatalog(c)
+	s.Require().NoError(err)
+	defer closer.Close()
+
+	// Host services are not available to remote plugins
+	resp, err := c.Plugin.CallPlugin(context.Background(), &catalogtest.Request{
+		In: "hello-to-plugin",
+	})
+	s.Require().NoError(err)
+	s.Equal("plugin(hello-to-plugin)", resp.Out)
+
+	s.Require().NotNil(c.Service)
+	resp, err = (*c.Service).CallService(context.Background(), &catalogtest.Request{
+		In: "hello-to-service",
+	})
+	s.Require().NoError(err)
+	s.Equal("service(hello-to-service)", resp.Out)
+
+	s.assertHasLogEntry(testLogEntry{
+		Level:   logrus.InfoLevel,
+		Message: "Plugin loaded.",
+		Data: logrus.Fields{
+			telemetry.PluginBuiltIn:  false,
+			telemetry.PluginName:     "testremote",
+			telemetry.PluginServices: []string{"Service"},
+			telemetry.PluginType:     "Plugin",
+		},
+	})
+}
+
+func (s *CatalogSuite) TestRemotePluginUntrustedServer() {
+	creds := s.newRemoteCredentials("remote")
+	address, stop := s.serveRemotePlugin(creds)
+	defer stop()
+
+	// Authenticate the plugin with a different CA
+	otherCreds := s.newRemoteCredentials("other")
+	s.pluginConfig = []catalog.PluginConfig{
+		{
+			Name:    "testremote",
+			Type:    catalogtest.PluginType,
+			Data:    "CONFIG",
+			Address: address,
+			TLS: &catalog.RemoteTLSConfig{
+				CertFile: creds.clientTLS.CertFile,
+				KeyFile:  creds.clientTLS.KeyFile,
+				CAFile:   otherCreds.clientTLS.CAFile,
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := catalog.Load(ctx, catalog.Config{
+		Log:           s.log,
+		PluginConfig:  s.pluginConfig,
+		KnownPlugins:  s.knownPlugins,
+		KnownServices: s.knownServices,
+	})
+	s.Require().Error(err)
+	s.Contains(err.Error(), fmt.Sprintf("unable to connect to remote plugin at %q", address))
+}
+
+func (s *CatalogSuite) TestReconfigure() {
+	s.builtins = []catalog.Plugin{testBuiltIn()}
+	s.pluginConfig = s.builtinConfig()
+	cat := s.loadCatalog()
+	defer cat.Close()
+
+	// Unchanged plugins are not reconfigured
+	s.Require().NoError(cat.Reconfigure(context.Background(), s.builtinConfig()))
+	s.Empty(s.logEntriesWithMessage("Plugin reconfigured."))
+
+	newConfig := s.builtinConfig()
+	newConfig[0].Data = "NEWCONFIG"
+	s.Require().NoError(cat.Reconfigure(context.Background(), newConfig))
+	s.assertHasLogEntry(testLogEntry{
+		Level:   logrus.InfoLevel,
+		Message: "Configure called.",
+		Data: logrus.Fields{
+			"config":                "NEWCONFIG",
+			telemetry.SubsystemName: "pluginimpl",
+			"trustdomain":           "domain.test",
+		},
+	})
+	s.assertHasLogEntry(testLogEntry{
+		Level:   logrus.InfoLevel,
+		Message: "Plugin reconfigured.",
+		Data: logrus.Fields{
+			telemetry.PluginName: "testbuiltin",
+			telemetry.PluginType: "Plugin",
+		},
+	})
+
+	// Configuration failures are reported
+	newConfig[0].Data = "BAD"
+	err := cat.Reconfigure(context.Background(), newConfig)
+	s.EqualError(err, `Plugin plugin "testbuiltin": rpc error: code = InvalidArgument desc = BAD configuration`)
+}
+
+func (s *CatalogSuite) TestReconfigureRequiresRestart() {
+	s.builtins = []catalog.Plugin{testBuiltIn()}
+	s.pluginConfig = s.builtinConfig()
+	cat := s.loadCatalog()
+	defer cat.Close()
+
+	err := cat.Reconfigure(context.Background(), nil)
+	s.EqualError(err, "plugins cannot be added or removed without restarting")
+
+	disabled := s.builtinConfig()
+	disabled[0].Disabled = true
+	err = cat.Reconfigure(context.Background(), disabled)
+	s.EqualError(err, "plugins cannot be added or removed without restarting")
+
+	renamed := s.builtinConfig()
+	renamed[0].Name = "other"
+	err = cat.Reconfigure(context.Background(), renamed)
+	s.EqualError(err, "plugins cannot be added or removed without restarting")
+
+	replaced := s.builtinConfig()
+	replaced[0].Path = s.path
+	err = cat.Reconfigure(context.Background(), replaced)
+	s.EqualError(err, `Plugin plugin "testbuiltin" cannot be replaced without restarting`)
+}
+
+func (s *CatalogSuite) TestCheckHealth() {
+	s.builtins = []catalog.Plugin{testBuiltIn()}
+	s.pluginConfig = append(s.builtinConfig(), s.extPluginConfig()...)
+	cat := s.loadCatalog()
+	defer cat.Close()
+
+	s.Equal([]catalog.PluginHealth{
+		{Type: "Plugin", Name: "testbuiltin"},
+		{Type: "Plugin", Name: "testext"},
+	}, cat.CheckHealth(context.Background()))
+
+	s.crashExternalPlugin(cat)
+	s.Eventually(func() bool {
+		health := cat.CheckHealth(context.Background())
+		return health[1].Err != nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	health := cat.CheckHealth(context.Background())
+	s.NoError(health[0].Err)
+	s.EqualError(health[1].Err, "plugin process exited")
+	s.False(health[1].Restarted)
+}
+
+func (s *CatalogSuite) TestCheckHealthRestartsCrashedPlugin() {
+	s.pluginConfig = s.extPluginConfig()
+	s.pluginConfig[0].Restart = true
+	cat := s.loadCatalog()
+	defer cat.Close()
+
+	s.crashExternalPlugin(cat)
+	var health []catalog.PluginHealth
+	s.Eventually(func() bool {
+		health = cat.CheckHealth(context.Background())
+		return health[0].Restarted
+	}, 10*time.Second, 10*time.Millisecond)
+	s.Require().NoError(health[0].Err)
+
+	// The restarted plugin is configured again and reached through the
+	// same clients, with the host services brokered again
+	s.Len(s.logEntriesWithMessage("Plugin restarted."), 1)
+	s.Len(s.logEntriesWithMessage("Configure called."), 2)
+
+	var plugin catalogtest.Plugin
+	s.Require().NoError(cat.Fill(&plugin))
+	resp, err := plugin.CallPlugin(context.Background(), &catalogtest.Request{
+		In: "hello-to-plugin",
+	})
+	s.Require().NoError(err)
+	s.Equal("plugin(hostservice[plugin=testext](hello-to-plugin))", resp.Out)
+
+	s.Equal([]catalog.PluginHealth{
+		{Type: "Plugin", Name: "testext"},
+	}, cat.CheckHealth(context.Background()))
+}
+
+func (s *CatalogSuite) crashExternalPlugin(cat catalog.Catalog) {
+	var c struct {
+		Plugins map[string]catalogtest.Plugin
+	}
+	s.Require().NoError(cat.Fill(&c))
+	_, err := c.Plugins["testext"].CallPlugin(context.Background(), &catalogtest.Request{
+		In: "CRASH",
+	})
+	s.Require().Error(err)
+}
+
 func (s *CatalogSuite) TestDuplicateKnownPlugins() {
 	s.knownPlugins = []catalog.PluginClient{
 		catalogtest.PluginPluginClient,
@@ -734,6 +1051,16 @@ func (s *CatalogSuite) builtinConfig() []catalog.PluginConfig {
 	}
 }
 
+func (s *CatalogSuite) logEntriesWithMessage(message string) []*logrus.Entry {
+	var entries []*logrus.Entry
+	for _, entry := range s.logHook.AllEntries() {
+		if entry.Message == message {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
 func (s *CatalogSuite) assertHasLogEntries(entries []testLogEntry) {
 	for _, e := range entries {
 		s.assertHasLogEntry(e)
@@ -771,6 +1098,121 @@ func testBuiltInNoService() catalog.Plugin {
 	)
 }
 
+// signPlugin signs the file at the given path with cosign, writing the
+// signature next to it.
+func (s *CatalogSuite) signPlugin(path string) *catalog.SignatureConfig {
+	require := s.Require()
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(err)
+
+	key := s.newKey()
+	hash := sha256.Sum256(data)
+	signature, err := key.Sign(rand.Reader, hash[:], nil)
+	require.NoError(err)
+	require.NoError(ioutil.WriteFile(path+".sig", []byte(base64.StdEncoding.EncodeToString(signature)), 0600))
+
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(err)
+	publicKeyPath := path + ".pub"
+	require.NoError(ioutil.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER}), 0600))
+
+	return &catalog.SignatureConfig{
+		Format:        catalog.SignatureFormatCosign,
+		PublicKeyFile: publicKeyPath,
+	}
+}
+
+type remoteCredentials struct {
+	serverTLS *tls.Config
+	clientTLS *catalog.RemoteTLSConfig
+}
+
+// newRemoteCredentials creates a CA, under the given name, that issues the
+// certificates of the remote plugin and of the host.
+func (s *CatalogSuite) newRemoteCredentials(name string) remoteCredentials {
+	require := s.Require()
+
+	caKey := s.newKey()
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name + "-ca"},
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, caKey.Public(), caKey)
+	require.NoError(err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(err)
+
+	issue := func(serial int64, extKeyUsage x509.ExtKeyUsage) tls.Certificate {
+		key := s.newKey()
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			NotAfter:     time.Now().Add(time.Hour),
+			IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+			ExtKeyUsage:  []x509.ExtKeyUsage{extKeyUsage},
+		}
+		certDER, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, key.Public(), caKey)
+		require.NoError(err)
+		return tls.Certificate{Certificate: [][]byte{certDER}, PrivateKey: key}
+	}
+	serverCert := issue(2, x509.ExtKeyUsageServerAuth)
+	clientCert := issue(3, x509.ExtKeyUsageClientAuth)
+
+	writePEM := func(file, blockType string, der []byte) string {
+		path := filepath.Join(s.dir, name+"-"+file)
+		require.NoError(ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+		return path
+	}
+	clientKeyDER, err := x509.MarshalECPrivateKey(clientCert.PrivateKey.(*ecdsa.PrivateKey))
+	require.NoError(err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+	return remoteCredentials{
+		serverTLS: &tls.Config{
+			Certificates: []tls.Certificate{serverCert},
+			ClientCAs:    pool,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+		},
+		clientTLS: &catalog.RemoteTLSConfig{
+			CertFile: writePEM("client.crt", "CERTIFICATE", clientCert.Certificate[0]),
+			KeyFile:  writePEM("client.key", "EC PRIVATE KEY", clientKeyDER),
+			CAFile:   writePEM("ca.crt", "CERTIFICATE", caDER),
+		},
+	}
+}
+
+func (s *CatalogSuite) newKey() *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	return key
+}
+
+// serveRemotePlugin serves the test plugin on a local listener. It returns
+// the address of the listener and a function to stop serving.
+func (s *CatalogSuite) serveRemotePlugin(creds remoteCredentials) (string, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- catalog.ServeRemotePlugin(ctx, catalog.MakePlugin("test",
+			catalogtest.PluginPluginServer(test.NewPlugin()),
+			catalogtest.ServiceServiceServer(test.NewService()),
+		), listener, creds.serverTLS, hclog.NewNullLogger())
+	}()
+
+	return listener.Addr().String(), func() {
+		cancel()
+		s.NoError(<-errCh)
+	}
+}
+
 func calculateChecksum(path string) (string, error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -9,6 +9,14 @@ import (
 	"github.com/zeebo/errs"
 )
 
+// PluginRolePrimary and PluginRoleSecondary designate one of several plugins
+// of the same type as the primary instance, used where a single plugin of the
+// type is expected, or as a secondary instance.
+const (
+	PluginRolePrimary   = "primary"
+	PluginRoleSecondary = "secondary"
+)
+
 type PluginConfig struct {
 	Name     string
 	Type     string
@@ -16,16 +24,58 @@ type PluginConfig struct {
 	Checksum string
 	Data     string
 	Disabled bool
+
+	// Address is the address of a remote plugin, served over gRPC by a
+	// separate service instead of being launched from Path.
+	Address string
+
+	// TLS holds the mTLS credentials used to connect to a remote plugin.
+	TLS *RemoteTLSConfig
+
+	// Signature, if set, configures the verification of the signature of
+	// the plugin binary before it is launched.
+	Signature *SignatureConfig
+
+	// Restart, if true, restarts the plugin process when the health probe
+	// finds it has exited.
+	Restart bool
+
+	// Role designates the plugin as the primary or a secondary instance of
+	// its type, when more than one plugin of the type is configured.
+	Role string
+}
+
+// RemoteTLSConfig holds the paths to the mTLS credentials used to connect to
+// a remote plugin.
+type RemoteTLSConfig struct {
+	// CertFile is the path to the client certificate presented to the plugin
+	CertFile string `hcl:"cert_file"`
+
+	// KeyFile is the path to the private key of the client certificate
+	KeyFile string `hcl:"key_file"`
+
+	// CAFile is the path to the CA certificates used to authenticate the
+	// plugin
+	CAFile string `hcl:"ca_file"`
+
+	// ServerName, if set, overrides the name used to verify the certificate
+	// of the plugin, which otherwise is the host of the plugin address.
+	ServerName string `hcl:"server_name"`
 }
 
 // HCLPluginConfig serves as an intermediary struct. We pass this to the
 // HCL library for parsing, except the parser won't parse pluginData
 // as a string.
 type HCLPluginConfig struct {
-	PluginCmd      string   `hcl:"plugin_cmd"`
-	PluginChecksum string   `hcl:"plugin_checksum"`
-	PluginData     ast.Node `hcl:"plugin_data"`
-	Enabled        *bool    `hcl:"enabled"`
+	PluginCmd       string           `hcl:"plugin_cmd"`
+	PluginChecksum  string           `hcl:"plugin_checksum"`
+	PluginSignature *SignatureConfig `hcl:"plugin_signature"`
+	PluginAddress   string           `hcl:"plugin_address"`
+	PluginTLS       *RemoteTLSConfig `hcl:"plugin_tls"`
+	PluginRestart   bool             `hcl:"plugin_restart"`
+	PluginRole      string           `hcl:"plugin_role"`
+	PluginData      ast.Node         `hcl:"plugin_data"`
+	Enabled         *bool            `hcl:"enabled"`
 }
 
 func (c HCLPluginConfig) IsEnabled() bool {
@@ -50,21 +100,70 @@ func PluginConfigFromHCL(hclPlugins HCLPluginConfigMap) ([]PluginConfig, error)
 	var pluginConfigs []PluginConfig
 	for pluginType, pluginsForType := range hclPlugins {
 		for pluginName, hclPluginConfig := range pluginsForType {
+			if err := validatePluginConfig(hclPluginConfig); err != nil {
+				return nil, errs.New("invalid configuration for %s plugin %q: %v", pluginType, pluginName, err)
+			}
+
 			var data bytes.Buffer
 			if err := printer.DefaultConfig.Fprint(&data, hclPluginConfig.PluginData); err != nil {
 				return nil, err
 			}
 
 			pluginConfigs = append(pluginConfigs, PluginConfig{
-				Name:     pluginName,
-				Type:     pluginType,
-				Path:     hclPluginConfig.PluginCmd,
-				Checksum: hclPluginConfig.PluginChecksum,
-				Data:     data.String(),
-				Disabled: !hclPluginConfig.IsEnabled(),
+				Name:      pluginName,
+				Type:      pluginType,
+				Path:      hclPluginConfig.PluginCmd,
+				Checksum:  hclPluginConfig.PluginChecksum,
+				Data:      data.String(),
+				Disabled:  !hclPluginConfig.IsEnabled(),
+				Address:   hclPluginConfig.PluginAddress,
+				TLS:       hclPluginConfig.PluginTLS,
+				Signature: hclPluginConfig.PluginSignature,
+				Restart:   hclPluginConfig.PluginRestart,
+				Role:      hclPluginConfig.PluginRole,
 			})
 		}
 	}
 
 	return pluginConfigs, nil
 }
+
+func validatePluginConfig(c HCLPluginConfig) error {
+	switch c.PluginRole {
+	case "", PluginRolePrimary, PluginRoleSecondary:
+	default:
+		return errs.New("unsupported plugin_role %q: must be %s or %s", c.PluginRole, PluginRolePrimary, PluginRoleSecondary)
+	}
+
+	if c.PluginRestart && c.PluginCmd == "" {
+		return errs.New("plugin_restart can only be configured along with plugin_cmd")
+	}
+
+	if c.PluginSignature != nil {
+		if c.PluginCmd == "" {
+			return errs.New("plugin_signature can only be configured along with plugin_cmd")
+		}
+		if err := c.PluginSignature.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.PluginAddress == "" {
+		if c.PluginTLS != nil {
+			return errs.New("plugin_tls can only be configured along with plugin_address")
+		}
+		return nil
+	}
+
+	switch {
+	case c.PluginCmd != "":
+		return errs.New("plugin_cmd and plugin_address cannot both be configured")
+	case c.PluginChecksum != "":
+		return errs.New("plugin_checksum cannot be configured along with plugin_address")
+	case c.PluginTLS == nil:
+		return errs.New("plugin_tls must be configured along with plugin_address")
+	case c.PluginTLS.CertFile == "" || c.PluginTLS.KeyFile == "" || c.PluginTLS.CAFile == "":
+		return errs.New("plugin_tls cert_file, key_file and ca_file must be configured")
+	}
+	return nil
+}
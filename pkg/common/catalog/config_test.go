@@ -21,6 +21,8 @@ func TestParsePluginConfigFromHCLSuccess(t *testing.T) {
 	TYPE2 "NAME2" {
 		plugin_cmd = "CMD2"
 		plugin_checksum = "CHECKSUM2"
+		plugin_restart = true
+		plugin_role = "secondary"
 		plugin_data = "DATA2"
 		enabled = true
 	}
@@ -47,6 +49,8 @@ func TestParsePluginConfigFromHCLSuccess(t *testing.T) {
 			Checksum: "CHECKSUM2",
 			Data:     `"DATA2"`,
 			Disabled: false,
+			Restart:  true,
+			Role:     "secondary",
 		},
 		{
 			Name:     "NAME3",
@@ -57,6 +61,121 @@ func TestParsePluginConfigFromHCLSuccess(t *testing.T) {
 	}, config)
 }
 
+func TestParsePluginConfigFromHCLRemote(t *testing.T) {
+	config, err := ParsePluginConfigFromHCL(`
+	TYPE "NAME" {
+		plugin_address = "hsm.example.org:8443"
+		plugin_tls {
+			cert_file = "client.crt"
+			key_file = "client.key"
+			ca_file = "ca.crt"
+			server_name = "hsm"
+		}
+		plugin_data = "DATA"
+	}
+`)
+	require.NoError(t, err)
+	require.Equal(t, []PluginConfig{
+		{
+			Name:    "NAME",
+			Type:    "TYPE",
+			Data:    `"DATA"`,
+			Address: "hsm.example.org:8443",
+			TLS: &RemoteTLSConfig{
+				CertFile:   "client.crt",
+				KeyFile:    "client.key",
+				CAFile:     "ca.crt",
+				ServerName: "hsm",
+			},
+		},
+	}, config)
+}
+
+func TestParsePluginConfigFromHCLRemoteFailure(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name: "command and address",
+			config: `TYPE "NAME" {
+				plugin_cmd = "CMD"
+				plugin_address = "localhost:8443"
+				plugin_tls { cert_file = "c" key_file = "k" ca_file = "ca" }
+			}`,
+			err: `invalid configuration for TYPE plugin "NAME": plugin_cmd and plugin_address cannot both be configured`,
+		},
+		{
+			name: "checksum",
+			config: `TYPE "NAME" {
+				plugin_address = "localhost:8443"
+				plugin_checksum = "CHECKSUM"
+				plugin_tls { cert_file = "c" key_file = "k" ca_file = "ca" }
+			}`,
+			err: `invalid configuration for TYPE plugin "NAME": plugin_checksum cannot be configured along with plugin_address`,
+		},
+		{
+			name: "no TLS",
+			config: `TYPE "NAME" {
+				plugin_address = "localhost:8443"
+			}`,
+			err: `invalid configuration for TYPE plugin "NAME": plugin_tls must be configured along with plugin_address`,
+		},
+		{
+			name: "incomplete TLS",
+			config: `TYPE "NAME" {
+				plugin_address = "localhost:8443"
+				plugin_tls { cert_file = "c" key_file = "k" }
+			}`,
+			err: `invalid configuration for TYPE plugin "NAME": plugin_tls cert_file, key_file and ca_file must be configured`,
+		},
+		{
+			name: "signature without command",
+			config: `TYPE "NAME" {
+				plugin_signature { format = "cosign" public_key_file = "cosign.pub" }
+			}`,
+			err: `invalid configuration for TYPE plugin "NAME": plugin_signature can only be configured along with plugin_cmd`,
+		},
+		{
+			name: "unsupported signature format",
+			config: `TYPE "NAME" {
+				plugin_cmd = "CMD"
+				plugin_signature { format = "gpg" public_key_file = "key.asc" }
+			}`,
+			err: `invalid configuration for TYPE plugin "NAME": unsupported signature format "gpg": must be minisign or cosign`,
+		},
+		{
+			name: "restart without command",
+			config: `TYPE "NAME" {
+				plugin_restart = true
+			}`,
+			err: `invalid configuration for TYPE plugin "NAME": plugin_restart can only be configured along with plugin_cmd`,
+		},
+		{
+			name: "unsupported role",
+			config: `TYPE "NAME" {
+				plugin_role = "backup"
+			}`,
+			err: `invalid configuration for TYPE plugin "NAME": unsupported plugin_role "backup": must be primary or secondary`,
+		},
+		{
+			name: "TLS without address",
+			config: `TYPE "NAME" {
+				plugin_cmd = "CMD"
+				plugin_tls { cert_file = "c" key_file = "k" ca_file = "ca" }
+			}`,
+			err: `invalid configuration for TYPE plugin "NAME": plugin_tls can only be configured along with plugin_address`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePluginConfigFromHCL(tt.config)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
 func sortPluginConfig(c []PluginConfig) {
 	sort.Slice(c, func(i, j int) bool {
 		a := c[i]
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"sync"
@@ -15,8 +16,11 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/zeebo/errs"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -24,6 +28,8 @@ const (
 	hostServicesID = 1
 )
 
+var errPluginExited = errors.New("plugin process exited")
+
 func PluginMain(plugin Plugin) {
 	logger := hclog.New(&hclog.LoggerOptions{
 		Level:      hclog.Trace,
@@ -83,6 +89,7 @@ type ExternalPlugin struct {
 	Name          string
 	Path          string
 	Checksum      string
+	Signature     *SignatureConfig
 	Data          string
 	Plugin        PluginClient
 	KnownServices []ServiceClient
@@ -96,35 +103,111 @@ func LoadExternalPlugin(ctx context.Context, ext ExternalPlugin) (plugin *Loaded
 		return nil, errs.Wrap(err)
 	}
 
-	cmd := pluginCmd(ext.Path)
+	process := &externalProcess{ext: ext}
+	if err := process.start(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			process.kill()
+		}
+	}()
+
+	// The plugin clients use a connection of their own, which dials the
+	// running plugin process, so that they keep working when a crashed
+	// process is restarted.
+	conn, err := grpc.DialContext(ctx, ext.Name,
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(process.dial))
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	defer func() {
+		if err != nil {
+			conn.Close()
+		}
+	}()
+	process.conn = conn
+
+	plugin, err = newCatalogPlugin(ctx, conn, catalogPluginConfig{
+		Log:           ext.Log,
+		Name:          ext.Name,
+		Plugin:        ext.Plugin,
+		KnownServices: ext.KnownServices,
+		HostServices:  ext.HostServices,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plugin.process = process
+	plugin.closer = func() {
+		conn.Close()
+		process.kill()
+	}
+	return plugin, nil
+}
+
+// externalProcess is the process of an external plugin.
+type externalProcess struct {
+	ext  ExternalPlugin
+	conn *grpc.ClientConn
+
+	mtx      sync.Mutex
+	client   *goplugin.Client
+	protocol goplugin.ClientProtocol
+	hcPlugin *hcClientPlugin
 
+	addrMtx sync.RWMutex
+	addr    net.Addr
+}
+
+// start launches the plugin process and serves the host services to it.
+func (p *externalProcess) start() (err error) {
 	var secureConfig *goplugin.SecureConfig
-	if ext.Checksum != "" {
-		secureConfig, err = buildSecureConfig(ext.Checksum)
+	if p.ext.Checksum != "" {
+		secureConfig, err = buildSecureConfig(p.ext.Checksum)
 		if err != nil {
-			return nil, err
+			return err
 		}
-	} else {
-		ext.Log.Warn("Plugin checksum not configured")
+	}
+
+	if p.ext.Signature != nil {
+		sum, err := verifyPluginSignature(p.ext.Path, *p.ext.Signature)
+		if err != nil {
+			return errs.New("unable to verify plugin signature: %v", err)
+		}
+		// Unless a checksum was configured, make sure the binary launched is
+		// the one whose signature was verified.
+		if secureConfig == nil {
+			secureConfig = &goplugin.SecureConfig{
+				Checksum: sum,
+				Hash:     sha256.New(),
+			}
+		}
+	}
+
+	if secureConfig == nil {
+		p.ext.Log.Warn("Plugin checksum not configured")
 	}
 
 	logger := log.HCLogAdapter{
-		Log:  ext.Log,
+		Log:  p.ext.Log,
 		Name: telemetry.PluginExternal,
 	}
 
 	hcPlugin := &hcClientPlugin{
-		ext: ext,
+		ext: p.ext,
 	}
 
 	// start the external plugin. ensure it is killed if there is an error.
 	pluginClient := goplugin.NewClient(&goplugin.ClientConfig{
 		HandshakeConfig: goplugin.HandshakeConfig{
 			ProtocolVersion:  1,
-			MagicCookieKey:   ext.Plugin.PluginType(),
-			MagicCookieValue: ext.Plugin.PluginType(),
+			MagicCookieKey:   p.ext.Plugin.PluginType(),
+			MagicCookieValue: p.ext.Plugin.PluginType(),
 		},
-		Cmd: cmd,
+		Cmd: pluginCmd(p.ext.Path),
 		// TODO: enable AutoMTLS if it is fixed to work with brokering.
 		// See https://github.com/hashicorp/go-plugin/issues/109
 		AutoMTLS:         false,
@@ -132,7 +215,7 @@ func LoadExternalPlugin(ctx context.Context, ext ExternalPlugin) (plugin *Loaded
 		Plugins: map[string]goplugin.Plugin{
 			"external": hcPlugin,
 		},
-		Logger:       logger.Named(ext.Name),
+		Logger:       logger.Named(p.ext.Name),
 		SecureConfig: secureConfig,
 	})
 	defer func() {
@@ -142,30 +225,100 @@ func LoadExternalPlugin(ctx context.Context, ext ExternalPlugin) (plugin *Loaded
 	}()
 
 	// create the GRPC client and ensure it is closed on error
-	grpcClient, err := pluginClient.Client()
+	protocol, err := pluginClient.Client()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// the primary interface is dispensed via the plugin name
-	pluginRaw, err := grpcClient.Dispense("external")
-	if err != nil {
-		return nil, err
+	// dispensing the plugin serves the host services over the broker
+	if _, err := protocol.Dispense("external"); err != nil {
+		return err
 	}
 
-	plugin, ok := pluginRaw.(*LoadedPlugin)
-	if !ok {
+	reattach := pluginClient.ReattachConfig()
+	if reattach == nil {
 		// shouldn't happen.
-		return nil, errs.New("expected %T, got %T", plugin, pluginRaw)
+		return errs.New("plugin process address is unknown")
 	}
 
-	// Kill also closes the gRPC client
-	plugin.closer = func() {
-		pluginClient.Kill()
-		hcPlugin.WaitUntilBrokerDone()
+	p.client = pluginClient
+	p.protocol = protocol
+	p.hcPlugin = hcPlugin
+	p.addrMtx.Lock()
+	p.addr = reattach.Addr
+	p.addrMtx.Unlock()
+	return nil
+}
+
+// restart kills the plugin process, if still running, and launches it
+// again. The new process is initialized over the connection used by the
+// plugin clients.
+func (p *externalProcess) restart(ctx context.Context) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.killLocked()
+	if err := p.start(); err != nil {
+		return err
 	}
 
-	return plugin, nil
+	hostServiceTypes, err := makeHostServiceTypes(p.ext.HostServices)
+	if err != nil {
+		return err
+	}
+
+	// the connection is likely backing off after losing the previous
+	// process, so reconnect right away
+	p.conn.ResetConnectBackoff()
+	initClient := spi.NewPluginInitClient(p.conn)
+	if _, err := initClient.Init(ctx, &spi.InitRequest{
+		HostServices: hostServiceTypes,
+	}, grpc.WaitForReady(true)); err != nil && status.Code(err) != codes.Unimplemented {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+// checkHealth fails if the plugin process has exited or does not answer
+// pings.
+func (p *externalProcess) checkHealth() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.client.Exited() {
+		return errPluginExited
+	}
+	if err := p.protocol.Ping(); err != nil {
+		return errs.New("plugin process is unresponsive: %v", err)
+	}
+	return nil
+}
+
+func (p *externalProcess) exited() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.client.Exited()
+}
+
+func (p *externalProcess) dial(ctx context.Context, _ string) (net.Conn, error) {
+	p.addrMtx.RLock()
+	addr := p.addr
+	p.addrMtx.RUnlock()
+
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, addr.Network(), addr.String())
+}
+
+func (p *externalProcess) kill() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.killLocked()
+}
+
+func (p *externalProcess) killLocked() {
+	// Kill also closes the gRPC client
+	p.client.Kill()
+	p.hcPlugin.WaitUntilBrokerDone()
 }
 
 func buildSecureConfig(checksum string) (*goplugin.SecureConfig, error) {
@@ -217,17 +370,7 @@ func (p *hcClientPlugin) GRPCClient(ctx context.Context, b *goplugin.GRPCBroker,
 		server.Stop()
 	}()
 
-	plugin, err := newCatalogPlugin(ctx, c, catalogPluginConfig{
-		Log:           p.ext.Log,
-		Name:          p.ext.Name,
-		Plugin:        p.ext.Plugin,
-		KnownServices: p.ext.KnownServices,
-		HostServices:  p.ext.HostServices,
-	})
-	if err != nil {
-		return nil, err
-	}
-	return plugin, nil
+	return p, nil
 }
 
 func (p *hcClientPlugin) WaitUntilBrokerDone() {
@@ -105,7 +105,7 @@ func (cf *catalogFiller) getFieldValue(ft reflect.Type, min, max int) (reflect.V
 		if !isInterfaceOrStructOfInterfaces(et) {
 			return reflect.Value{}, fmt.Errorf("pointers must be to an interface or struct (of interfaces)")
 		}
-		_, values, err := cf.getValues(et, 0, 1)
+		values, err := cf.getSingleValue(et, 0)
 		if err != nil {
 			return reflect.Value{}, err
 		}
@@ -158,7 +158,7 @@ func (cf *catalogFiller) getFieldValue(ft reflect.Type, min, max int) (reflect.V
 
 	// This represents a field that is a struct (of interfaces) or an interface
 	case reflect.Struct, reflect.Interface:
-		_, values, err := cf.getValues(ft, 1, 1)
+		values, err := cf.getSingleValue(ft, 1)
 		if err != nil {
 			return reflect.Value{}, err
 		}
@@ -169,7 +169,7 @@ func (cf *catalogFiller) getFieldValue(ft reflect.Type, min, max int) (reflect.V
 }
 
 func (cf *catalogFiller) fillInterface(sv reflect.Value) (err error) {
-	_, values, err := cf.getValues(sv.Type(), 1, 1)
+	values, err := cf.getSingleValue(sv.Type(), 1)
 	if err != nil {
 		return err
 	}
@@ -197,6 +197,28 @@ func (cf *catalogFiller) getValues(t reflect.Type, min, max int) ([]string, []re
 	return names, values, nil
 }
 
+// getSingleValue returns the value for a field that holds at most one
+// plugin. If several plugins match, the one designated as the primary
+// instance is used.
+func (cf *catalogFiller) getSingleValue(t reflect.Type, min int) ([]reflect.Value, error) {
+	_, values, err := cf.getValues(t, min, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) > 1 {
+		for _, plugin := range cf.plugins {
+			if plugin.p.role != PluginRolePrimary {
+				continue
+			}
+			if value, ok := plugin.getValue(t); ok {
+				return []reflect.Value{value}, nil
+			}
+		}
+		return nil, fmt.Errorf("requires at most 1 %s(s); got %d", t.Name(), len(values))
+	}
+	return values, nil
+}
+
 func isInterfaceOrStructOfInterfaces(t reflect.Type) bool {
 	switch t.Kind() {
 	case reflect.Interface:
@@ -73,7 +73,7 @@ func newCatalogPlugin(ctx context.Context, c *grpc.ClientConn, config catalogPlu
 	}, nil
 }
 
-func initPluginServer(s *grpc.Server, dialer hostDialer, logger hclog.Logger, plugin PluginServer, services []ServiceServer) {
+func initPluginServer(s *grpc.Server, dialer hostDialer, logger hclog.Logger, plugin PluginServer, services []ServiceServer) []interface{} {
 	var impls []interface{}
 	var pluginServices []string
 	impls = append(impls, plugin.RegisterPluginServer(s))
@@ -87,6 +87,7 @@ func initPluginServer(s *grpc.Server, dialer hostDialer, logger hclog.Logger, pl
 		impls:          impls,
 		pluginServices: pluginServices,
 	})
+	return impls
 }
 
 type initServer struct {
@@ -153,6 +154,9 @@ func newHostServiceBroker(dialer hostDialer, hostServices []string) *hostService
 }
 
 func (b *hostServiceBroker) GetHostService(hostService HostServiceClient) (bool, error) {
+	if !b.hostServices[hostService.HostServiceType()] {
+		return false, nil
+	}
 	if b.c == nil {
 		var err error
 		b.c, err = b.dialer.DialHost()
@@ -160,9 +164,6 @@ func (b *hostServiceBroker) GetHostService(hostService HostServiceClient) (bool,
 			return false, errs.New("unable to dial service broker on host: %v", err)
 		}
 	}
-	if !b.hostServices[hostService.HostServiceType()] {
-		return false, nil
-	}
 	hostService.InitHostServiceClient(b.c)
 	return true, nil
 }
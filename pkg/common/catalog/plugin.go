@@ -6,6 +6,8 @@ import (
 
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/zeebo/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type LoadedPlugin struct {
@@ -14,6 +16,13 @@ type LoadedPlugin struct {
 	all          []interface{}
 	serviceNames []string
 
+	// role is the role of the plugin among the plugins of its type, if any
+	role string
+
+	// process is the process of an external plugin, or nil for built-in and
+	// remote plugins.
+	process *externalProcess
+
 	closeOnce sync.Once
 	closer    func()
 }
@@ -37,6 +46,43 @@ func (p *LoadedPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest)
 	return nil
 }
 
+// CheckHealth probes the plugin. External plugins fail the probe if their
+// process has exited or does not answer pings. Plugins that provide plugin
+// information fail the probe if they cannot be queried for it.
+func (p *LoadedPlugin) CheckHealth(ctx context.Context) error {
+	if p.process != nil {
+		if err := p.process.checkHealth(); err != nil {
+			return err
+		}
+	}
+
+	type pluginInfoProvider interface {
+		GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error)
+	}
+	if c, ok := p.plugin.(pluginInfoProvider); ok {
+		// a plugin that does not implement the RPC still answered
+		if _, err := c.GetPluginInfo(ctx, &spi.GetPluginInfoRequest{}); err != nil && status.Code(err) != codes.Unimplemented {
+			return errs.New("plugin is unresponsive: %v", err)
+		}
+	}
+	return nil
+}
+
+// Crashed returns true if the plugin is an external plugin whose process
+// has exited.
+func (p *LoadedPlugin) Crashed() bool {
+	return p.process != nil && p.process.exited()
+}
+
+// Restart launches the process of a crashed external plugin again. The
+// plugin must be configured again once restarted.
+func (p *LoadedPlugin) Restart(ctx context.Context) error {
+	if p.process == nil {
+		return errs.New("only external plugins can be restarted")
+	}
+	return p.process.restart(ctx)
+}
+
 func (p *LoadedPlugin) Fill(x interface{}) (err error) {
 	cf := newPluginFiller(p)
 	return cf.fill(x)
@@ -0,0 +1,132 @@
+package catalog
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net"
+	"time"
+
+	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"github.com/hashicorp/go-hclog"
+	"github.com/sirupsen/logrus"
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// remoteDialTimeout is how long the host waits for the connection to a remote
+// plugin to be established.
+const remoteDialTimeout = 30 * time.Second
+
+type RemotePlugin struct {
+	Log           logrus.FieldLogger
+	Name          string
+	Address       string
+	TLS           RemoteTLSConfig
+	Plugin        PluginClient
+	KnownServices []ServiceClient
+}
+
+// LoadRemotePlugin connects to a plugin served over gRPC by a separate
+// service (see ServeRemotePlugin). The connection is authenticated with mTLS.
+// Host services are not available to remote plugins.
+func LoadRemotePlugin(ctx context.Context, remote RemotePlugin) (plugin *LoadedPlugin, err error) {
+	tlsConfig, err := remote.TLS.clientTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, remoteDialTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(dialCtx, remote.Address,
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithBlock())
+	if err != nil {
+		return nil, errs.New("unable to connect to remote plugin at %q: %v", remote.Address, err)
+	}
+	defer func() {
+		if err != nil {
+			conn.Close()
+		}
+	}()
+
+	plugin, err = newCatalogPlugin(ctx, conn, catalogPluginConfig{
+		Log:           remote.Log,
+		Name:          remote.Name,
+		Plugin:        remote.Plugin,
+		KnownServices: remote.KnownServices,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plugin.closer = func() {
+		conn.Close()
+	}
+	return plugin, nil
+}
+
+// ServeRemotePlugin serves the plugin over gRPC on the listener, requiring
+// and verifying client certificates according to the TLS configuration,
+// until the context is canceled.
+func ServeRemotePlugin(ctx context.Context, plugin Plugin, listener net.Listener, tlsConfig *tls.Config, logger hclog.Logger) error {
+	server := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(tlsConfig)),
+		grpc.StreamInterceptor(grpc_recovery.StreamServerInterceptor()),
+		grpc.UnaryInterceptor(grpc_recovery.UnaryServerInterceptor()),
+	)
+	initPluginServer(
+		server,
+		remoteDialer{},
+		logger,
+		plugin.Plugin,
+		plugin.Services,
+	)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		server.Stop()
+		<-errCh
+		return nil
+	}
+}
+
+type remoteDialer struct{}
+
+func (remoteDialer) DialHost() (*grpc.ClientConn, error) {
+	return nil, errors.New("host services are not available to remote plugins")
+}
+
+func (c RemoteTLSConfig) clientTLSConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, errs.New("unable to load plugin_tls client certificate: %v", err)
+	}
+
+	caPEM, err := ioutil.ReadFile(c.CAFile)
+	if err != nil {
+		return nil, errs.New("unable to read plugin_tls CA certificates: %v", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return nil, errs.New("no CA certificates found in %q", c.CAFile)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      roots,
+		ServerName:   c.ServerName,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
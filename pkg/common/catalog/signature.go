@@ -0,0 +1,206 @@
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"golang.org/x/crypto/blake2b"
+)
+
+// SignatureFormatMinisign and SignatureFormatCosign are the supported formats
+// of plugin binary signatures.
+const (
+	SignatureFormatMinisign = "minisign"
+	SignatureFormatCosign   = "cosign"
+)
+
+const (
+	minisignTrustedCommentPrefix = "trusted comment: "
+	minisignCommentPrefix        = "untrusted comment: "
+)
+
+// SignatureConfig configures the verification of the signature of an
+// external plugin binary.
+type SignatureConfig struct {
+	// Format is the format of the signature, either "minisign" or "cosign"
+	Format string `hcl:"format"`
+
+	// PublicKeyFile is the path to the public key the binary must be signed
+	// with. It is a minisign public key or a PEM-encoded public key, as
+	// produced by "cosign generate-key-pair".
+	PublicKeyFile string `hcl:"public_key_file"`
+
+	// SignatureFile is the path to the signature of the binary. Defaults to
+	// the path of the binary with a ".minisig" extension for minisign or a
+	// ".sig" extension for cosign.
+	SignatureFile string `hcl:"signature_file"`
+}
+
+func (c SignatureConfig) validate() error {
+	switch c.Format {
+	case SignatureFormatMinisign, SignatureFormatCosign:
+	default:
+		return errs.New("unsupported signature format %q: must be %s or %s", c.Format, SignatureFormatMinisign, SignatureFormatCosign)
+	}
+	if c.PublicKeyFile == "" {
+		return errs.New("signature public_key_file must be configured")
+	}
+	return nil
+}
+
+func (c SignatureConfig) signatureFile(binaryPath string) string {
+	switch {
+	case c.SignatureFile != "":
+		return c.SignatureFile
+	case c.Format == SignatureFormatMinisign:
+		return binaryPath + ".minisig"
+	default:
+		return binaryPath + ".sig"
+	}
+}
+
+// verifyPluginSignature verifies the signature of the plugin binary. It
+// returns the SHA-256 checksum of the binary that was verified.
+func verifyPluginSignature(binaryPath string, config SignatureConfig) ([]byte, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	binary, err := ioutil.ReadFile(binaryPath)
+	if err != nil {
+		return nil, errs.New("unable to read plugin binary: %v", err)
+	}
+	publicKey, err := ioutil.ReadFile(config.PublicKeyFile)
+	if err != nil {
+		return nil, errs.New("unable to read signature public key: %v", err)
+	}
+	signature, err := ioutil.ReadFile(config.signatureFile(binaryPath))
+	if err != nil {
+		return nil, errs.New("unable to read signature: %v", err)
+	}
+
+	switch config.Format {
+	case SignatureFormatMinisign:
+		err = verifyMinisign(binary, publicKey, signature)
+	default:
+		err = verifyCosign(binary, publicKey, signature)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(binary)
+	return sum[:], nil
+}
+
+// verifyMinisign verifies a minisign signature, either of the binary itself
+// ("Ed") or of its BLAKE2b-512 hash ("ED"), along with the global signature
+// of the trusted comment.
+func verifyMinisign(binary, publicKeyData, signatureData []byte) error {
+	publicKeyLines := minisignLines(publicKeyData)
+	if len(publicKeyLines) != 1 {
+		return errs.New("malformed minisign public key")
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(publicKeyLines[0])
+	if err != nil || len(publicKey) != 2+8+ed25519.PublicKeySize || string(publicKey[:2]) != "Ed" {
+		return errs.New("malformed minisign public key")
+	}
+	keyID, key := publicKey[2:10], ed25519.PublicKey(publicKey[10:])
+
+	signatureLines := minisignLines(signatureData)
+	if len(signatureLines) != 3 || !strings.HasPrefix(signatureLines[1], minisignTrustedCommentPrefix) {
+		return errs.New("malformed minisign signature")
+	}
+	signature, err := base64.StdEncoding.DecodeString(signatureLines[0])
+	if err != nil || len(signature) != 2+8+ed25519.SignatureSize {
+		return errs.New("malformed minisign signature")
+	}
+	globalSignature, err := base64.StdEncoding.DecodeString(signatureLines[2])
+	if err != nil || len(globalSignature) != ed25519.SignatureSize {
+		return errs.New("malformed minisign signature")
+	}
+
+	if !bytes.Equal(signature[2:10], keyID) {
+		return errs.New("minisign signature was made with a different key")
+	}
+
+	message := binary
+	switch string(signature[:2]) {
+	case "Ed":
+	case "ED":
+		hash := blake2b.Sum512(binary)
+		message = hash[:]
+	default:
+		return errs.New("unsupported minisign signature algorithm %q", signature[:2])
+	}
+	if !ed25519.Verify(key, message, signature[10:]) {
+		return errs.New("minisign signature does not match the plugin binary")
+	}
+
+	trustedComment := strings.TrimPrefix(signatureLines[1], minisignTrustedCommentPrefix)
+	if !ed25519.Verify(key, append(signature[10:], trustedComment...), globalSignature) {
+		return errs.New("minisign trusted comment signature is invalid")
+	}
+	return nil
+}
+
+// minisignLines returns the lines of a minisign file, without the untrusted
+// comment and blank lines.
+func minisignLines(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, minisignCommentPrefix) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// verifyCosign verifies a signature produced by "cosign sign-blob" with an
+// ECDSA key, i.e. a base64-encoded ASN.1 ECDSA signature of the SHA-256 hash
+// of the binary.
+func verifyCosign(binary, publicKeyData, signatureData []byte) error {
+	block, _ := pem.Decode(publicKeyData)
+	if block == nil {
+		return errs.New("malformed cosign public key: no PEM block found")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return errs.New("malformed cosign public key: %v", err)
+	}
+	key, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return errs.New("unsupported cosign public key type %T", publicKey)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signatureData)))
+	if err != nil {
+		return errs.New("malformed cosign signature: %v", err)
+	}
+	var esig struct {
+		R, S *big.Int
+	}
+	if rest, err := asn1.Unmarshal(signature, &esig); err != nil || len(rest) != 0 {
+		return errs.New("malformed cosign signature")
+	}
+
+	hash := sha256.Sum256(binary)
+	if !ecdsa.Verify(key, hash[:], esig.R, esig.S) {
+		return errs.New("cosign signature does not match the plugin binary")
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package catalog
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestVerifyPluginSignatureMinisign(t *testing.T) {
+	dir := makeTempDir(t)
+	defer os.RemoveAll(dir)
+
+	binaryPath := writeFile(t, dir, "plugin", []byte("PLUGIN"))
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyID := []byte("KEYID123")
+	publicKeyPath := writeFile(t, dir, "minisign.pub", []byte(fmt.Sprintf("untrusted comment: minisign public key\n%s\n",
+		base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), publicKey...)))))
+
+	config := SignatureConfig{
+		Format:        SignatureFormatMinisign,
+		PublicKeyFile: publicKeyPath,
+	}
+
+	// signature of the binary itself, at the default location
+	writeFile(t, dir, "plugin.minisig", minisign(privateKey, "Ed", keyID, []byte("PLUGIN")))
+	sum, err := verifyPluginSignature(binaryPath, config)
+	require.NoError(t, err)
+	expectedSum := sha256.Sum256([]byte("PLUGIN"))
+	require.Equal(t, expectedSum[:], sum)
+
+	// signature of the prehashed binary
+	hash := blake2b.Sum512([]byte("PLUGIN"))
+	config.SignatureFile = writeFile(t, dir, "prehashed.minisig", minisign(privateKey, "ED", keyID, hash[:]))
+	_, err = verifyPluginSignature(binaryPath, config)
+	require.NoError(t, err)
+
+	// signature of another binary
+	config.SignatureFile = writeFile(t, dir, "tampered.minisig", minisign(privateKey, "Ed", keyID, []byte("TAMPERED")))
+	_, err = verifyPluginSignature(binaryPath, config)
+	require.EqualError(t, err, "minisign signature does not match the plugin binary")
+
+	// signature made with another key
+	config.SignatureFile = writeFile(t, dir, "otherkey.minisig", minisign(privateKey, "Ed", []byte("OTHERKEY"), []byte("PLUGIN")))
+	_, err = verifyPluginSignature(binaryPath, config)
+	require.EqualError(t, err, "minisign signature was made with a different key")
+
+	// missing signature
+	config.SignatureFile = filepath.Join(dir, "missing.minisig")
+	_, err = verifyPluginSignature(binaryPath, config)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to read signature")
+}
+
+func TestVerifyPluginSignatureCosign(t *testing.T) {
+	dir := makeTempDir(t)
+	defer os.RemoveAll(dir)
+
+	binaryPath := writeFile(t, dir, "plugin", []byte("PLUGIN"))
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	publicKeyPath := writeFile(t, dir, "cosign.pub", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER}))
+
+	config := SignatureConfig{
+		Format:        SignatureFormatCosign,
+		PublicKeyFile: publicKeyPath,
+	}
+
+	// signature at the default location
+	writeFile(t, dir, "plugin.sig", cosign(t, key, []byte("PLUGIN")))
+	sum, err := verifyPluginSignature(binaryPath, config)
+	require.NoError(t, err)
+	expectedSum := sha256.Sum256([]byte("PLUGIN"))
+	require.Equal(t, expectedSum[:], sum)
+
+	// signature of another binary
+	config.SignatureFile = writeFile(t, dir, "tampered.sig", cosign(t, key, []byte("TAMPERED")))
+	_, err = verifyPluginSignature(binaryPath, config)
+	require.EqualError(t, err, "cosign signature does not match the plugin binary")
+
+	// malformed signature
+	config.SignatureFile = writeFile(t, dir, "malformed.sig", []byte("bm90IGEgc2lnbmF0dXJl"))
+	_, err = verifyPluginSignature(binaryPath, config)
+	require.EqualError(t, err, "malformed cosign signature")
+}
+
+func TestVerifyPluginSignatureUnsupportedFormat(t *testing.T) {
+	_, err := verifyPluginSignature("plugin", SignatureConfig{Format: "gpg", PublicKeyFile: "key"})
+	require.EqualError(t, err, `unsupported signature format "gpg": must be minisign or cosign`)
+}
+
+func minisign(privateKey ed25519.PrivateKey, algorithm string, keyID, message []byte) []byte {
+	signature := ed25519.Sign(privateKey, message)
+	trustedComment := "timestamp:1600000000"
+	globalSignature := ed25519.Sign(privateKey, append(signature, trustedComment...))
+	return []byte(fmt.Sprintf("untrusted comment: signature\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(append(append([]byte(algorithm), keyID...), signature...)),
+		trustedComment,
+		base64.StdEncoding.EncodeToString(globalSignature)))
+}
+
+func cosign(t *testing.T, key *ecdsa.PrivateKey, message []byte) []byte {
+	hash := sha256.Sum256(message)
+	signature, err := key.Sign(rand.Reader, hash[:], nil)
+	require.NoError(t, err)
+	return []byte(base64.StdEncoding.EncodeToString(signature))
+}
+
+func makeTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "catalog-signature-")
+	require.NoError(t, err)
+	return dir
+}
+
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+	return path
+}
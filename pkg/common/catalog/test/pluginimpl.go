@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/spiffe/spire/pkg/common/catalog"
@@ -53,6 +54,11 @@ func (s *testPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (
 }
 
 func (s *testPlugin) CallPlugin(ctx context.Context, req *catalogtest.Request) (*catalogtest.Response, error) {
+	if req.In == "CRASH" {
+		// only used to crash external plugin processes
+		os.Exit(1)
+	}
+
 	out := req.In
 	if s.hs != nil {
 		resp, err := s.hs.CallHostService(ctx, &catalogtest.Request{
@@ -0,0 +1,102 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/zeebo/errs"
+)
+
+// PluginError is the failure of a plugin to load or to accept its
+// configuration.
+type PluginError struct {
+	Type string
+	Name string
+	Err  error
+}
+
+func (e PluginError) Error() string {
+	return fmt.Sprintf("%s plugin %q: %v", e.Type, e.Name, e.Err)
+}
+
+// Validate loads and configures each of the configured plugins on its own,
+// so that the plugins validate their configuration, and closes them right
+// away. External plugins are not launched: only their binary, and its
+// checksum and signature if configured, are checked. Unlike Load, it does not
+// stop at the first failure: it returns the failure of every plugin, sorted
+// by plugin type and name.
+func Validate(ctx context.Context, config Config) []PluginError {
+	var pluginErrs []PluginError
+	for _, pluginConfig := range config.PluginConfig {
+		if pluginConfig.Disabled {
+			continue
+		}
+		if pluginConfig.Path != "" {
+			if err := validateExternalPlugin(pluginConfig); err != nil {
+				pluginErrs = append(pluginErrs, PluginError{
+					Type: pluginConfig.Type,
+					Name: pluginConfig.Name,
+					Err:  err,
+				})
+			}
+			continue
+		}
+
+		c := config
+		c.PluginConfig = []PluginConfig{pluginConfig}
+
+		cat, err := Load(ctx, c)
+		if err != nil {
+			pluginErrs = append(pluginErrs, PluginError{
+				Type: pluginConfig.Type,
+				Name: pluginConfig.Name,
+				Err:  err,
+			})
+			continue
+		}
+		cat.Close()
+	}
+
+	sort.Slice(pluginErrs, func(i, j int) bool {
+		if pluginErrs[i].Type != pluginErrs[j].Type {
+			return pluginErrs[i].Type < pluginErrs[j].Type
+		}
+		return pluginErrs[i].Name < pluginErrs[j].Name
+	})
+	return pluginErrs
+}
+
+// validateExternalPlugin checks the binary of an external plugin the way it
+// is checked before being launched, without launching it.
+func validateExternalPlugin(c PluginConfig) error {
+	info, err := os.Stat(c.Path)
+	if err != nil {
+		return errs.New("unable to stat plugin binary: %v", err)
+	}
+	if !info.Mode().IsRegular() {
+		return errs.New("plugin binary %q is not a regular file", c.Path)
+	}
+
+	if c.Signature != nil {
+		if _, err := verifyPluginSignature(c.Path, *c.Signature); err != nil {
+			return errs.New("unable to verify plugin signature: %v", err)
+		}
+	}
+
+	if c.Checksum != "" {
+		secureConfig, err := buildSecureConfig(c.Checksum)
+		if err != nil {
+			return err
+		}
+		ok, err := secureConfig.Check(c.Path)
+		if err != nil {
+			return errs.New("unable to verify plugin checksum: %v", err)
+		}
+		if !ok {
+			return errs.New("checksums did not match")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,331 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mitchellh/cli"
+)
+
+const (
+	// completeArg is the hidden argument of the completion command used by the
+	// completion scripts to get the candidates of the word being completed
+	completeArg = "__complete"
+
+	completionTimeout = 2 * time.Second
+)
+
+// Shells the completion scripts can be generated for
+var completionShells = []string{"bash", "fish", "zsh"}
+
+// CompleteFunc returns the candidate values of a flag. The arguments of the
+// command being completed are passed, e.g. to find the socket to query.
+type CompleteFunc func(ctx context.Context, args []string) []string
+
+// CompleteValues returns a CompleteFunc completing a fixed set of values.
+func CompleteValues(values ...string) CompleteFunc {
+	return func(context.Context, []string) []string {
+		return values
+	}
+}
+
+// LookupFlag returns the last value given to the flag in args, or the empty
+// string if the flag is not set.
+func LookupFlag(args []string, name string) string {
+	var value string
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		switch {
+		case arg == "-"+name || arg == "--"+name:
+			if i+1 < len(args) {
+				value = args[i+1]
+			}
+		case strings.HasPrefix(arg, "-"+name+"="):
+			value = strings.TrimPrefix(arg, "-"+name+"=")
+		case strings.HasPrefix(arg, "--"+name+"="):
+			value = strings.TrimPrefix(arg, "--"+name+"=")
+		}
+	}
+	return value
+}
+
+// Completer completes the command lines of a CLI.
+type Completer struct {
+	// Name of the program
+	Name string
+
+	// Commands of the CLI, e.g. "entry show"
+	Commands []string
+
+	// Values completes the values of the flags. Keys are either the flag name
+	// prefixed with the command, e.g. "entry show -entryID", or the flag name
+	// alone, e.g. "-output", for the completion to apply to every command.
+	Values map[string]CompleteFunc
+
+	// Usage returns the usage of a command, which the flags are read from.
+	// If unset, the usage printed by the program for "<command> -h" is used.
+	Usage func(ctx context.Context, command string) string
+}
+
+// NewCompletionCommand returns the command generating the completion scripts
+// of the CLI.
+func NewCompletionCommand(completer *Completer) cli.Command {
+	return &completionCommand{
+		completer: completer,
+		env:       DefaultEnv,
+	}
+}
+
+type completionCommand struct {
+	completer *Completer
+	env       *Env
+}
+
+func (c *completionCommand) Help() string {
+	return fmt.Sprintf(`Usage: %s completion <%s>
+
+  Prints the completion script of the shell, completing commands, flags and
+  flag values. Load it in the current shell with e.g.:
+
+    source <(%s completion bash)
+`, c.completer.Name, strings.Join(completionShells, "|"), c.completer.Name)
+}
+
+func (c *completionCommand) Synopsis() string {
+	return "Generates the shell completion scripts"
+}
+
+func (c *completionCommand) Run(args []string) int {
+	if len(args) > 0 && args[0] == completeArg {
+		args = args[1:]
+		if len(args) > 0 && args[0] == "--" {
+			args = args[1:]
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+		defer cancel()
+		for _, candidate := range c.completer.Complete(ctx, args) {
+			_ = c.env.Println(candidate)
+		}
+		return 0
+	}
+
+	if len(args) != 1 {
+		_ = c.env.ErrPrintln(c.Help())
+		return 1
+	}
+	if err := c.completer.WriteScript(c.env, args[0]); err != nil {
+		_ = c.env.ErrPrintln(err)
+		return 1
+	}
+	return 0
+}
+
+// WriteScript writes the completion script of the shell to the environment
+// stdout.
+func (c *Completer) WriteScript(env *Env, shell string) error {
+	tmpl, ok := completionScripts[shell]
+	if !ok {
+		return fmt.Errorf("unsupported shell %q: must be one of %s", shell, strings.Join(completionShells, ", "))
+	}
+	return tmpl.Execute(env.Stdout, struct {
+		Name     string
+		Function string
+	}{
+		Name:     c.Name,
+		Function: "_" + strings.NewReplacer("-", "_", ".", "_").Replace(c.Name),
+	})
+}
+
+// Complete returns the candidates of the last word of words, which are the
+// arguments of the command line being completed. The last word may be empty
+// or partially typed. No candidates are returned when the shell should fall
+// back to completing file names.
+func (c *Completer) Complete(ctx context.Context, words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	current := words[len(words)-1]
+	words = words[:len(words)-1]
+
+	command, args := c.findCommand(words)
+
+	var candidates []string
+	switch {
+	case command == "completion" && len(args) == 0:
+		candidates = completionShells
+	case command != "" && len(args) > 0 && c.takesValue(ctx, command, args[len(args)-1]):
+		flag := args[len(args)-1]
+		candidates = c.completeValues(ctx, command, strings.TrimLeft(flag, "-"), args)
+	case command != "" && strings.HasPrefix(current, "-"):
+		for _, flag := range c.flags(ctx, command) {
+			candidates = append(candidates, "-"+flag.name)
+		}
+	case command == "" || len(args) == 0:
+		candidates = c.subcommands(words)
+	}
+
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, current) {
+			matches = append(matches, candidate)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// findCommand returns the longest command the words start with and the
+// remaining words.
+func (c *Completer) findCommand(words []string) (string, []string) {
+	var command string
+	var n int
+	for _, candidate := range c.Commands {
+		fields := strings.Fields(candidate)
+		if len(fields) > len(words) || len(fields) <= n {
+			continue
+		}
+		if strings.Join(words[:len(fields)], " ") == candidate {
+			command, n = candidate, len(fields)
+		}
+	}
+	return command, words[n:]
+}
+
+// subcommands returns the words that can follow words to form a command.
+func (c *Completer) subcommands(words []string) []string {
+	seen := make(map[string]bool)
+	var subcommands []string
+	for _, command := range c.Commands {
+		fields := strings.Fields(command)
+		if len(fields) <= len(words) || strings.Join(fields[:len(words)], " ") != strings.Join(words, " ") {
+			continue
+		}
+		next := fields[len(words)]
+		if !seen[next] {
+			seen[next] = true
+			subcommands = append(subcommands, next)
+		}
+	}
+	return subcommands
+}
+
+func (c *Completer) takesValue(ctx context.Context, command, arg string) bool {
+	if !strings.HasPrefix(arg, "-") || strings.Contains(arg, "=") {
+		return false
+	}
+	name := strings.TrimLeft(arg, "-")
+	for _, flag := range c.flags(ctx, command) {
+		if flag.name == name {
+			return flag.takesValue
+		}
+	}
+	return false
+}
+
+func (c *Completer) completeValues(ctx context.Context, command, flag string, args []string) []string {
+	complete, ok := c.Values[command+" -"+flag]
+	if !ok {
+		complete, ok = c.Values["-"+flag]
+	}
+	if !ok {
+		return nil
+	}
+	return complete(ctx, args)
+}
+
+type completionFlag struct {
+	name       string
+	takesValue bool
+}
+
+// usageFlagRE matches the flags in the usage printed by the flag package,
+// e.g. "  -ttl int" or "  -downstream"
+var usageFlagRE = regexp.MustCompile(`(?m)^[ \t]{1,4}-(\w[\w.-]*)([ \t]+\S+)?[ \t]*$`)
+
+func (c *Completer) flags(ctx context.Context, command string) []completionFlag {
+	usage := c.Usage
+	if usage == nil {
+		usage = c.programUsage
+	}
+
+	var flags []completionFlag
+	for _, match := range usageFlagRE.FindAllStringSubmatch(usage(ctx, command), -1) {
+		flags = append(flags, completionFlag{
+			name:       match[1],
+			takesValue: match[2] != "",
+		})
+	}
+	return flags
+}
+
+// programUsage runs the program to get the usage of the command, since most
+// commands print it to stderr rather than returning it from Help()
+func (c *Completer) programUsage(ctx context.Context, command string) string {
+	path, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	args := append(strings.Fields(command), "-h")
+	out, _ := exec.CommandContext(ctx, path, args...).CombinedOutput()
+	return string(out)
+}
+
+var completionScripts = map[string]*template.Template{
+	"bash": template.Must(template.New("bash").Parse(`# bash completion for {{.Name}}
+{{.Function}}() {
+    local line="${COMP_LINE:0:COMP_POINT}" words
+    read -ra words <<< "$line"
+    if [[ -z "$line" || "$line" == *[[:space:]] ]]; then
+        words+=("")
+    fi
+    local cur="${words[${#words[@]}-1]}"
+
+    local IFS=$'\n'
+    COMPREPLY=($("${words[0]}" completion __complete -- "${words[@]:1}" 2>/dev/null))
+
+    # bash splits words on colons, e.g. in spiffe://example.org, so the part
+    # of the word before the last colon is not replaced
+    if [[ "$cur" == *:* && "$COMP_WORDBREAKS" == *:* ]]; then
+        local prefix="${cur%"${cur##*:}"}"
+        COMPREPLY=("${COMPREPLY[@]#"$prefix"}")
+    fi
+}
+complete -o default -F {{.Function}} {{.Name}}
+`)),
+	"zsh": template.Must(template.New("zsh").Parse(`#compdef {{.Name}}
+# zsh completion for {{.Name}}
+{{.Function}}() {
+    local -a candidates
+    candidates=(${(f)"$(${words[1]} completion __complete -- "${(@)words[2,CURRENT]}" 2>/dev/null)"})
+    if (( ${#candidates} )); then
+        compadd -a candidates
+    else
+        _files
+    fi
+}
+compdef {{.Function}} {{.Name}}
+`)),
+	"fish": template.Must(template.New("fish").Parse(`# fish completion for {{.Name}}
+function {{.Function}}
+    set -l words (commandline -opc)
+    set -l current (commandline -ct)
+    set -l candidates ($words[1] completion __complete -- $words[2..-1] "$current" 2>/dev/null)
+    if test (count $candidates) -gt 0
+        printf '%s\n' $candidates
+    else
+        __fish_complete_path "$current"
+    end
+end
+complete -c {{.Name}} -f -a '({{.Function}})'
+`)),
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComplete(t *testing.T) {
+	completer := &Completer{
+		Name:     "spire-test",
+		Commands: []string{"api fetch", "api fetch x509", "completion", "entry show", "run"},
+		Values: map[string]CompleteFunc{
+			"-output": CompleteValues("text", "json"),
+			"entry show -entryID": func(ctx context.Context, args []string) []string {
+				return []string{"entry-1", "entry-2", LookupFlag(args, "socketPath")}
+			},
+		},
+		Usage: func(ctx context.Context, command string) string {
+			switch command {
+			case "entry show":
+				return `Usage of entry show:
+  -downstream
+    	A boolean value that, when set, indicates that the entry describes a downstream SPIRE server
+  -entryID string
+    	The Entry ID of the records to show
+  -output format
+    	Output format: text or json
+  -socketPath string
+    	Path
+`
+			case "run":
+				return "Usage of run:\n  -config string\n    \tPath to a SPIRE config file\n"
+			}
+			return ""
+		},
+	}
+
+	for _, tt := range []struct {
+		name   string
+		words  []string
+		expect []string
+	}{
+		{
+			name:   "commands",
+			words:  []string{""},
+			expect: []string{"api", "completion", "entry", "run"},
+		},
+		{
+			name:   "partial command",
+			words:  []string{"en"},
+			expect: []string{"entry"},
+		},
+		{
+			name:   "subcommands",
+			words:  []string{"api", ""},
+			expect: []string{"fetch"},
+		},
+		{
+			name:   "subcommands of a command",
+			words:  []string{"api", "fetch", ""},
+			expect: []string{"x509"},
+		},
+		{
+			name:   "unknown command",
+			words:  []string{"bogus", ""},
+			expect: nil,
+		},
+		{
+			name:   "flags",
+			words:  []string{"entry", "show", "-"},
+			expect: []string{"-downstream", "-entryID", "-output", "-socketPath"},
+		},
+		{
+			name:   "partial flag",
+			words:  []string{"entry", "show", "-e"},
+			expect: []string{"-entryID"},
+		},
+		{
+			name:   "flag values of every command",
+			words:  []string{"entry", "show", "-output", ""},
+			expect: []string{"json", "text"},
+		},
+		{
+			name:   "flag values of the command",
+			words:  []string{"entry", "show", "-socketPath", "/tmp/api.sock", "-entryID", "entry-"},
+			expect: []string{"entry-1", "entry-2"},
+		},
+		{
+			name:   "flag values use the other arguments",
+			words:  []string{"entry", "show", "-socketPath", "/tmp/api.sock", "-entryID", "/tmp"},
+			expect: []string{"/tmp/api.sock"},
+		},
+		{
+			name:   "flag without completion falls back to files",
+			words:  []string{"run", "-config", ""},
+			expect: nil,
+		},
+		{
+			name:   "boolean flag takes no value",
+			words:  []string{"entry", "show", "-downstream", ""},
+			expect: nil,
+		},
+		{
+			name:   "shells",
+			words:  []string{"completion", ""},
+			expect: []string{"bash", "fish", "zsh"},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, completer.Complete(context.Background(), tt.words))
+		})
+	}
+}
+
+func TestLookupFlag(t *testing.T) {
+	assert.Equal(t, "", LookupFlag([]string{"-entryID", "1"}, "socketPath"))
+	assert.Equal(t, "a", LookupFlag([]string{"-socketPath", "a"}, "socketPath"))
+	assert.Equal(t, "b", LookupFlag([]string{"-socketPath", "a", "--socketPath=b"}, "socketPath"))
+	assert.Equal(t, "", LookupFlag([]string{"-socketPath"}, "socketPath"))
+	assert.Equal(t, "", LookupFlag([]string{"--", "-socketPath", "a"}, "socketPath"))
+}
+
+func TestCompletionCommand(t *testing.T) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := &completionCommand{
+		completer: &Completer{
+			Name:     "spire-test",
+			Commands: []string{"entry show", "run"},
+		},
+		env: &Env{Stdout: stdout, Stderr: stderr},
+	}
+
+	for _, shell := range []string{"bash", "fish", "zsh"} {
+		stdout.Reset()
+		require.Equal(t, 0, cmd.Run([]string{shell}))
+		assert.Contains(t, stdout.String(), "_spire_test")
+		assert.Contains(t, stdout.String(), "completion __complete --")
+	}
+
+	assert.Equal(t, 1, cmd.Run([]string{"ksh"}))
+	assert.Equal(t, "unsupported shell \"ksh\": must be one of bash, fish, zsh\n", stderr.String())
+
+	stdout.Reset()
+	require.Equal(t, 0, cmd.Run([]string{"__complete", "--", "e"}))
+	assert.Equal(t, "entry\n", stdout.String())
+}
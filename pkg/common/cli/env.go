@@ -45,6 +45,11 @@ func (e *Env) ErrPrintln(args ...interface{}) error {
 	return err
 }
 
+// PrintJSON writes v to the standard output as an indented JSON document.
+func (e *Env) PrintJSON(v interface{}) error {
+	return PrintJSON(e.Stdout, v)
+}
+
 func (e *Env) JoinPath(parts ...string) string {
 	if e.BaseDir == "" {
 		return filepath.Join(parts...)
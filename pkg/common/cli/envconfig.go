@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// OverrideFromEnv overrides the fields of config, a pointer to a struct
+// decoded from HCL, with the environment variables named after their HCL
+// keys: the prefix followed by the keys of the enclosing sections and the
+// key of the field, upper-cased and joined with underscores (e.g.
+// SPIRE_SERVER_LOG_ROTATION_MAX_SIZE_MB for the max_size_mb key of the
+// log_rotation section under the SPIRE_SERVER prefix).
+//
+// Lists are set as comma separated values and maps as comma separated
+// key=value pairs. Repeated sections (e.g. a map or list of sections)
+// cannot be set from the environment.
+func OverrideFromEnv(prefix string, config interface{}) error {
+	_, err := overrideStructFromEnv(prefix, reflect.ValueOf(config).Elem())
+	return err
+}
+
+// overrideStructFromEnv overrides the fields of the struct, and returns
+// whether any was overridden.
+func overrideStructFromEnv(prefix string, v reflect.Value) (bool, error) {
+	overridden := false
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		key := strings.Split(field.Tag.Get("hcl"), ",")[0]
+		if key == "" || key == "-" || field.PkgPath != "" {
+			continue
+		}
+		name := prefix + "_" + strings.ToUpper(strings.Replace(key, "-", "_", -1))
+
+		ok, err := overrideValueFromEnv(name, v.Field(i))
+		if err != nil {
+			return false, err
+		}
+		overridden = overridden || ok
+	}
+	return overridden, nil
+}
+
+func overrideValueFromEnv(name string, v reflect.Value) (bool, error) {
+	switch {
+	case v.Kind() == reflect.Struct:
+		return overrideStructFromEnv(name, v)
+	case v.Kind() == reflect.Ptr:
+		// Only allocate the value if it is overridden, so that unset
+		// sections stay unset
+		elem := reflect.New(v.Type().Elem())
+		if !v.IsNil() {
+			elem.Elem().Set(v.Elem())
+		}
+		ok, err := overrideValueFromEnv(name, elem.Elem())
+		if ok {
+			v.Set(elem)
+		}
+		return ok, err
+	}
+
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return false, nil
+	}
+	if err := setFromEnv(v, value); err != nil {
+		return false, fmt.Errorf("invalid value for %s: %v", name, err)
+	}
+	return true, nil
+}
+
+func setFromEnv(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("cannot set %s from the environment", v.Type())
+		}
+		v.Set(reflect.ValueOf(splitEnvList(value)).Convert(v.Type()))
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String || v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("cannot set %s from the environment", v.Type())
+		}
+		m := reflect.MakeMap(v.Type())
+		for _, pair := range splitEnvList(value) {
+			parts := strings.SplitN(pair, "=", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				return fmt.Errorf("expected key=value pairs, got %q", pair)
+			}
+			m.SetMapIndex(reflect.ValueOf(parts[0]), reflect.ValueOf(parts[1]))
+		}
+		v.Set(m)
+	default:
+		return fmt.Errorf("cannot set %s from the environment", v.Type())
+	}
+	return nil
+}
+
+// splitEnvList splits a comma separated list, ignoring the blanks around
+// the items. An empty value is an empty list.
+func splitEnvList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
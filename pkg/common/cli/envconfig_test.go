@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type envTestConfig struct {
+	Name     string            `hcl:"name"`
+	Port     int               `hcl:"port"`
+	Enabled  *bool             `hcl:"enabled"`
+	Rate     float64           `hcl:"rate"`
+	Names    []string          `hcl:"names"`
+	Labels   map[string]string `hcl:"labels"`
+	Section  envTestSection    `hcl:"section"`
+	Optional *envTestSection   `hcl:"optional"`
+	Repeated []envTestSection  `hcl:"repeated"`
+
+	ConfigPath string
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type envTestSection struct {
+	MaxSizeMB int `hcl:"max_size_mb"`
+}
+
+func TestOverrideFromEnv(t *testing.T) {
+	setEnv := func(t *testing.T, env map[string]string) func() {
+		for name, value := range env {
+			require.NoError(t, os.Setenv(name, value))
+		}
+		return func() {
+			for name := range env {
+				os.Unsetenv(name)
+			}
+		}
+	}
+	enabled := true
+
+	for _, tt := range []struct {
+		name      string
+		env       map[string]string
+		expect    envTestConfig
+		expectErr string
+	}{
+		{
+			name:   "nothing set",
+			expect: envTestConfig{Name: "file", Port: 1},
+		},
+		{
+			name: "fields set",
+			env: map[string]string{
+				"TEST_NAME":                 "env",
+				"TEST_PORT":                 "2",
+				"TEST_ENABLED":              "true",
+				"TEST_RATE":                 "0.5",
+				"TEST_NAMES":                "a, b,",
+				"TEST_LABELS":               "region=us-east-1,zone=a=b",
+				"TEST_SECTION_MAX_SIZE_MB":  "10",
+				"TEST_OPTIONAL_MAX_SIZE_MB": "20",
+			},
+			expect: envTestConfig{
+				Name:     "env",
+				Port:     2,
+				Enabled:  &enabled,
+				Rate:     0.5,
+				Names:    []string{"a", "b"},
+				Labels:   map[string]string{"region": "us-east-1", "zone": "a=b"},
+				Section:  envTestSection{MaxSizeMB: 10},
+				Optional: &envTestSection{MaxSizeMB: 20},
+			},
+		},
+		{
+			name:   "empty values are set",
+			env:    map[string]string{"TEST_NAME": ""},
+			expect: envTestConfig{Port: 1},
+		},
+		{
+			name:      "invalid value",
+			env:       map[string]string{"TEST_PORT": "many"},
+			expectErr: `invalid value for TEST_PORT: strconv.ParseInt: parsing "many": invalid syntax`,
+		},
+		{
+			name:      "invalid map",
+			env:       map[string]string{"TEST_LABELS": "region"},
+			expectErr: `invalid value for TEST_LABELS: expected key=value pairs, got "region"`,
+		},
+		{
+			name:      "repeated sections cannot be set",
+			env:       map[string]string{"TEST_REPEATED": "1"},
+			expectErr: "invalid value for TEST_REPEATED: cannot set []cli.envTestSection from the environment",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer setEnv(t, tt.env)()
+
+			config := envTestConfig{Name: "file", Port: 1}
+			err := OverrideFromEnv("TEST", &config)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, config)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+)
+
+// Output formats of the commands
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// OutputFlag is the -output flag of the commands, selecting between the
+// human readable text output and a JSON document meant for automation. The
+// zero value is the text output.
+type OutputFlag string
+
+// AddFlag adds the -output flag to the flag set.
+func (f *OutputFlag) AddFlag(fs *flag.FlagSet) {
+	fs.Var(f, "output", "Output `format`: text or json")
+}
+
+// JSON returns whether the JSON output is selected.
+func (f OutputFlag) JSON() bool {
+	return f == OutputJSON
+}
+
+func (f *OutputFlag) String() string {
+	if *f == "" {
+		return OutputText
+	}
+	return string(*f)
+}
+
+func (f *OutputFlag) Set(v string) error {
+	switch v {
+	case OutputText, OutputJSON:
+		*f = OutputFlag(v)
+		return nil
+	default:
+		return fmt.Errorf("must be %q or %q", OutputText, OutputJSON)
+	}
+}
+
+// PrintJSON writes v to w as an indented JSON document.
+func PrintJSON(w io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
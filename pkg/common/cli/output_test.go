@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputFlag(t *testing.T) {
+	parse := func(args ...string) (OutputFlag, error) {
+		var output OutputFlag
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		output.AddFlag(fs)
+		return output, fs.Parse(args)
+	}
+
+	output, err := parse()
+	require.NoError(t, err)
+	assert.False(t, output.JSON())
+	assert.Equal(t, OutputText, output.String())
+
+	output, err = parse("-output", "json")
+	require.NoError(t, err)
+	assert.True(t, output.JSON())
+
+	output, err = parse("-output", "text")
+	require.NoError(t, err)
+	assert.False(t, output.JSON())
+
+	_, err = parse("-output", "yaml")
+	require.EqualError(t, err, `invalid value "yaml" for flag -output: must be "text" or "json"`)
+}
+
+func TestPrintJSON(t *testing.T) {
+	out := new(bytes.Buffer)
+	require.NoError(t, PrintJSON(out, map[string]int{"count": 1}))
+	assert.Equal(t, "{\n  \"count\": 1\n}\n", out.String())
+}
+
+func TestEnvPrintJSON(t *testing.T) {
+	out := new(bytes.Buffer)
+	env := &Env{Stdout: out}
+	require.NoError(t, env.PrintJSON(map[string]int{"count": 1}))
+	assert.Equal(t, "{\n  \"count\": 1\n}\n", out.String())
+
+	// Values that cannot be encoded are reported instead of printed
+	out.Reset()
+	require.Error(t, env.PrintJSON(make(chan int)))
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"io/ioutil"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/log"
+)
+
+// ValidationResult is the result of the validate commands, as printed with
+// the JSON output.
+type ValidationResult struct {
+	mu sync.Mutex
+
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// NewValidationResult returns a valid result, with no errors or warnings.
+func NewValidationResult() *ValidationResult {
+	return &ValidationResult{Valid: true}
+}
+
+// AddError records a validation error, which makes the result invalid.
+func (r *ValidationResult) AddError(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Errors = append(r.Errors, err.Error())
+	r.Valid = false
+}
+
+// AddWarning records a validation warning, which leaves the result valid.
+func (r *ValidationResult) AddWarning(warning string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Warnings = append(r.Warnings, warning)
+}
+
+// LogWarnings returns a log option that records the warnings logged by the
+// logger as validation warnings, instead of writing them out. The warnings
+// with a message in skip are dropped, e.g. because they are already
+// recorded otherwise.
+func (r *ValidationResult) LogWarnings(skip map[string]bool) log.Option {
+	return func(logger *log.Logger) error {
+		logger.SetOutput(ioutil.Discard)
+		logger.AddHook(warningHook{result: r, skip: skip})
+		return nil
+	}
+}
+
+type warningHook struct {
+	result *ValidationResult
+	skip   map[string]bool
+}
+
+func (h warningHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.WarnLevel}
+}
+
+func (h warningHook) Fire(entry *logrus.Entry) error {
+	if !h.skip[entry.Message] {
+		h.result.AddWarning(entry.Message)
+	}
+	return nil
+}
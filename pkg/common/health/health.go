@@ -10,6 +10,8 @@ import (
 	"github.com/InVisionApp/go-health/handlers"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // health.Checker is responsible for running health checks and serving the healthcheck HTTP paths
@@ -21,6 +23,12 @@ type Checker struct {
 	hc    *health.Health
 	mutex sync.Mutex // Mutex protects non-threadsafe hc
 
+	// statusMutex protects the fields below, which mirror the outcome of the
+	// checks into gRPC health servers.
+	statusMutex sync.Mutex
+	healthy     map[string]bool
+	publishers  []*grpchealth.Server
+
 	log logrus.FieldLogger
 }
 
@@ -46,22 +54,84 @@ func NewChecker(config Config, log logrus.FieldLogger) *Checker {
 		}
 	}
 
-	hc.StatusListener = &statusListener{}
-	hc.Logger = &logadapter{FieldLogger: log.WithField(telemetry.SubsystemName, "health")}
+	healthLog := log.WithField(telemetry.SubsystemName, "health")
+	hc.StatusListener = &statusListener{log: healthLog}
+	hc.Logger = &logadapter{FieldLogger: healthLog}
 
-	return &Checker{config: config, server: server, hc: hc, log: log}
+	return &Checker{
+		config:  config,
+		server:  server,
+		hc:      hc,
+		healthy: make(map[string]bool),
+		log:     log,
+	}
 }
 
+// AddCheck adds a check that is run every interval. Checks are reported as
+// failing until they have run for the first time.
 func (c *Checker) AddCheck(name string, checker health.ICheckable, interval time.Duration) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	return c.hc.AddCheck(&health.Config{
-		Name:     name,
-		Checker:  checker,
-		Interval: interval,
-		Fatal:    true,
-	})
+	if err := c.hc.AddCheck(&health.Config{
+		Name:       name,
+		Checker:    checker,
+		Interval:   interval,
+		Fatal:      true,
+		OnComplete: c.checkCompleted,
+	}); err != nil {
+		return err
+	}
+
+	c.setHealthy(name, false)
+	return nil
+}
+
+// Publish mirrors the outcome of the checks into the given gRPC health
+// server. Each check is reported as a service named after the check, and
+// the overall ("") status is only serving while every check passes, which
+// makes it a readiness signal.
+func (c *Checker) Publish(server *grpchealth.Server) {
+	c.statusMutex.Lock()
+	defer c.statusMutex.Unlock()
+
+	c.publishers = append(c.publishers, server)
+	for name, healthy := range c.healthy {
+		server.SetServingStatus(name, servingStatus(healthy))
+	}
+	server.SetServingStatus("", servingStatus(c.allHealthy()))
+}
+
+func (c *Checker) checkCompleted(state *health.State) {
+	c.setHealthy(state.Name, state.Err == "")
+}
+
+func (c *Checker) setHealthy(name string, healthy bool) {
+	c.statusMutex.Lock()
+	defer c.statusMutex.Unlock()
+
+	c.healthy[name] = healthy
+	overall := c.allHealthy()
+	for _, publisher := range c.publishers {
+		publisher.SetServingStatus(name, servingStatus(healthy))
+		publisher.SetServingStatus("", servingStatus(overall))
+	}
+}
+
+func (c *Checker) allHealthy() bool {
+	for _, healthy := range c.healthy {
+		if !healthy {
+			return false
+		}
+	}
+	return true
+}
+
+func servingStatus(healthy bool) healthpb.HealthCheckResponse_ServingStatus {
+	if healthy {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
 }
 
 func (c *Checker) ListenAndServe(ctx context.Context) error {
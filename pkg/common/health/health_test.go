@@ -1,10 +1,16 @@
 package health
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/InVisionApp/go-health"
 	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func TestServerDisabledByDefault(t *testing.T) {
@@ -20,3 +26,43 @@ func TestServerEnabled(t *testing.T) {
 
 	assert.NotNil(t, checker.server)
 }
+
+func TestPublish(t *testing.T) {
+	log, _ := logtest.NewNullLogger()
+	checker := NewChecker(Config{}, log)
+	require.NoError(t, checker.AddCheck("a", nil, time.Minute))
+	require.NoError(t, checker.AddCheck("b", nil, time.Minute))
+
+	server := grpchealth.NewServer()
+	checker.Publish(server)
+
+	assertStatus := func(service string, expected healthpb.HealthCheckResponse_ServingStatus) {
+		resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, expected, resp.Status, "service %q", service)
+	}
+
+	// Checks fail until they have run
+	assertStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	assertStatus("a", healthpb.HealthCheckResponse_NOT_SERVING)
+	assertStatus("b", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	checker.checkCompleted(&health.State{Name: "a"})
+	assertStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	assertStatus("a", healthpb.HealthCheckResponse_SERVING)
+
+	checker.checkCompleted(&health.State{Name: "b"})
+	assertStatus("", healthpb.HealthCheckResponse_SERVING)
+	assertStatus("b", healthpb.HealthCheckResponse_SERVING)
+
+	checker.checkCompleted(&health.State{Name: "b", Err: "oh no"})
+	assertStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	assertStatus("a", healthpb.HealthCheckResponse_SERVING)
+	assertStatus("b", healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestReportExitCode(t *testing.T) {
+	assert.Equal(t, ExitNotLive, (&Report{}).ExitCode())
+	assert.Equal(t, ExitNotReady, (&Report{Live: true}).ExitCode())
+	assert.Equal(t, ExitHealthy, (&Report{Live: true, Ready: true}).ExitCode())
+}
@@ -0,0 +1,99 @@
+package health
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Exit codes of the healthcheck commands, which distinguish a process that
+// cannot be reached (not live) from one that is up but has failing
+// subsystems (not ready).
+const (
+	ExitHealthy  = 0
+	ExitNotLive  = 1
+	ExitNotReady = 2
+)
+
+// Status reported for subsystems the health service does not know about.
+const StatusUnknown = "UNKNOWN"
+
+// SubsystemStatus is the status of a single subsystem check, as reported by
+// the gRPC health service.
+type SubsystemStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// Healthy returns true if the subsystem is serving.
+func (s SubsystemStatus) Healthy() bool {
+	return s.Status == healthpb.HealthCheckResponse_SERVING.String()
+}
+
+// Report is the outcome of a healthcheck command.
+type Report struct {
+	Live       bool              `json:"live"`
+	Ready      bool              `json:"ready"`
+	Subsystems []SubsystemStatus `json:"subsystems,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// ExitCode returns the exit code the healthcheck command exits with.
+func (r *Report) ExitCode() int {
+	switch {
+	case !r.Live:
+		return ExitNotLive
+	case !r.Ready:
+		return ExitNotReady
+	default:
+		return ExitHealthy
+	}
+}
+
+// FailingSubsystems returns the names of the subsystems that are not
+// serving.
+func (r *Report) FailingSubsystems() []string {
+	var names []string
+	for _, subsystem := range r.Subsystems {
+		if !subsystem.Healthy() {
+			names = append(names, subsystem.Name)
+		}
+	}
+	return names
+}
+
+// CheckSubsystems queries the gRPC health service on the connection for the
+// overall readiness and the status of each of the named subsystems. Servers
+// that do not serve the health service are reported as ready with no
+// subsystems.
+func CheckSubsystems(ctx context.Context, conn *grpc.ClientConn, names []string) (bool, []SubsystemStatus, error) {
+	client := healthpb.NewHealthClient(conn)
+
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.Unimplemented:
+		return true, nil, nil
+	default:
+		return false, nil, err
+	}
+	ready := resp.Status == healthpb.HealthCheckResponse_SERVING
+
+	subsystems := make([]SubsystemStatus, 0, len(names))
+	for _, name := range names {
+		subsystem := SubsystemStatus{Name: name, Status: StatusUnknown}
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: name})
+		switch status.Code(err) {
+		case codes.OK:
+			subsystem.Status = resp.Status.String()
+		case codes.NotFound:
+		default:
+			return false, nil, err
+		}
+		subsystems = append(subsystems, subsystem)
+	}
+	return ready, subsystems, nil
+}
@@ -8,6 +8,24 @@ import (
 	"gopkg.in/square/go-jose.v2/jwt"
 )
 
+// registeredClaims are the claims registered by RFC 7519, which are set by
+// SPIRE when signing JWT-SVIDs.
+var registeredClaims = map[string]bool{
+	"iss": true,
+	"sub": true,
+	"aud": true,
+	"exp": true,
+	"nbf": true,
+	"iat": true,
+	"jti": true,
+}
+
+// IsRegisteredClaim returns true if the claim name is one of the claims
+// registered by RFC 7519.
+func IsRegisteredClaim(name string) bool {
+	return registeredClaims[name]
+}
+
 func GetTokenExpiry(token string) (time.Time, time.Time, error) {
 	tok, err := jwt.ParseSigned(token)
 	if err != nil {
@@ -36,6 +36,12 @@ func NewSigner(config SignerConfig) *Signer {
 }
 
 func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time, signer crypto.Signer, kid string) (string, error) {
+	return s.SignTokenWithClaims(spiffeID, audience, expires, signer, kid, nil)
+}
+
+// SignTokenWithClaims signs a token like SignToken, including the given
+// additional claims. Registered claims cannot be overridden.
+func (s *Signer) SignTokenWithClaims(spiffeID string, audience []string, expires time.Time, signer crypto.Signer, kid string, additionalClaims map[string]interface{}) (string, error) {
 	if err := idutil.ValidateSpiffeID(spiffeID, idutil.AllowAnyTrustDomainWorkload()); err != nil {
 		return "", err
 	}
@@ -51,6 +57,11 @@ func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time
 	if len(kid) == 0 {
 		return "", errors.New("kid is required")
 	}
+	for name := range additionalClaims {
+		if IsRegisteredClaim(name) {
+			return "", errs.New("additional claim %q overrides a registered claim", name)
+		}
+	}
 
 	claims := jwt.Claims{
 		Subject:  spiffeID,
@@ -96,7 +107,12 @@ func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time
 		return "", errs.Wrap(err)
 	}
 
-	signedToken, err := jwt.Signed(jwtSigner).Claims(claims).CompactSerialize()
+	builder := jwt.Signed(jwtSigner).Claims(claims)
+	if len(additionalClaims) > 0 {
+		builder = builder.Claims(additionalClaims)
+	}
+
+	signedToken, err := builder.CompactSerialize()
 	if err != nil {
 		return "", errs.Wrap(err)
 	}
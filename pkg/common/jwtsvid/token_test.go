@@ -212,6 +212,25 @@ func (s *TokenSuite) TestSignAndValidateWithAudienceList() {
 	s.Require().NotEmpty(claims)
 }
 
+func (s *TokenSuite) TestSignAndValidateWithAdditionalClaims() {
+	token, err := s.signer.SignTokenWithClaims(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), ec256Key, "ec256Key", map[string]interface{}{
+		"tenant": "acme",
+	})
+	s.Require().NoError(err)
+
+	spiffeID, claims, err := ValidateToken(ctx, token, s.bundle, fakeAudience[0:1])
+	s.Require().NoError(err)
+	s.Require().Equal(fakeSpiffeID, spiffeID)
+	s.Require().Equal("acme", claims["tenant"])
+}
+
+func (s *TokenSuite) TestSignWithAdditionalRegisteredClaim() {
+	_, err := s.signer.SignTokenWithClaims(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), ec256Key, "ec256Key", map[string]interface{}{
+		"sub": "spiffe://example.org/impostor",
+	})
+	s.Require().EqualError(err, `additional claim "sub" overrides a registered claim`)
+}
+
 func (s *TokenSuite) TestSignWithNoExpiration() {
 	_, err := s.signer.SignToken(fakeSpiffeID, fakeAudience, time.Time{}, ec256Key, "ec256Key")
 	s.Require().EqualError(err, "expiration is required")
@@ -0,0 +1,23 @@
+package log
+
+// RotationConfig configures the rotation of the log file. The file is
+// rotated once it would grow over MaxSizeMB megabytes or has been written to
+// for longer than MaxAge (e.g. "24h"), whichever comes first. Unset limits
+// are not enforced.
+type RotationConfig struct {
+	MaxSizeMB  int    `hcl:"max_size_mb"`
+	MaxAge     string `hcl:"max_age"`
+	MaxBackups int    `hcl:"max_backups"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+// SyslogConfig configures sending logs to syslog. If Network is empty, logs
+// are sent to the local syslog daemon (or journald on systemd hosts).
+type SyslogConfig struct {
+	Network string `hcl:"network"`
+	Address string `hcl:"address"`
+	Tag     string `hcl:"tag"`
+
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
@@ -0,0 +1,156 @@
+package log
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// subsystemField is the field naming the subsystem an entry comes from. It
+// matches telemetry.SubsystemName, which cannot be imported from here.
+const subsystemField = "subsystem_name"
+
+// levels decides which entries are logged. Entries at or above the level
+// are always logged. Debug entries of the debug subsystems are logged too,
+// so debug logging can be enabled for a few subsystems without flooding the
+// logs with the debug entries of the others.
+type levels struct {
+	mu              sync.RWMutex
+	level           logrus.Level
+	debugSubsystems map[string]bool
+}
+
+func (l *levels) effectiveLevel() logrus.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if len(l.debugSubsystems) > 0 && l.level < logrus.DebugLevel {
+		return logrus.DebugLevel
+	}
+	return l.level
+}
+
+func (l *levels) allows(entry *logrus.Entry) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if entry.Level <= l.level {
+		return true
+	}
+	if entry.Level > logrus.DebugLevel {
+		return false
+	}
+
+	// Subsystems are named hierarchically, e.g. "built-in_plugin.sql", so
+	// enabling a subsystem enables its children too
+	subsystem, _ := entry.Data[subsystemField].(string)
+	for subsystem != "" {
+		if l.debugSubsystems[subsystem] {
+			return true
+		}
+		i := strings.LastIndex(subsystem, ".")
+		if i < 0 {
+			break
+		}
+		subsystem = subsystem[:i]
+	}
+	return false
+}
+
+// SetLevel sets the level of the logger.
+func (l *Logger) SetLevel(level logrus.Level) {
+	l.levels.mu.Lock()
+	l.levels.level = level
+	l.levels.mu.Unlock()
+
+	l.Logger.SetLevel(l.levels.effectiveLevel())
+}
+
+// GetLevel returns the level of the logger, regardless of the debug
+// subsystems.
+func (l *Logger) GetLevel() logrus.Level {
+	l.levels.mu.RLock()
+	defer l.levels.mu.RUnlock()
+	return l.levels.level
+}
+
+// SetDebugSubsystems enables debug logging for the given subsystems (e.g.
+// "ca_manager"), whatever the level of the logger. It replaces the
+// previously enabled subsystems.
+func (l *Logger) SetDebugSubsystems(subsystems []string) {
+	l.setDebugSubsystems(subsystems)
+	if len(subsystems) > 0 {
+		l.filterLevels()
+	}
+}
+
+func (l *Logger) setDebugSubsystems(subsystems []string) {
+	debugSubsystems := make(map[string]bool, len(subsystems))
+	for _, subsystem := range subsystems {
+		debugSubsystems[subsystem] = true
+	}
+
+	l.levels.mu.Lock()
+	l.levels.debugSubsystems = debugSubsystems
+	l.levels.mu.Unlock()
+
+	l.Logger.SetLevel(l.levels.effectiveLevel())
+}
+
+// filterLevels installs the levelFormatter, unless already installed. It is
+// only installed once debug subsystems are enabled so that loggers without
+// them keep their formatter untouched.
+func (l *Logger) filterLevels() {
+	if _, ok := l.Formatter.(*levelFormatter); ok {
+		return
+	}
+	l.SetFormatter(&levelFormatter{
+		Formatter: l.Formatter,
+		levels:    l.levels,
+	})
+}
+
+// DebugSubsystems returns the subsystems debug logging is enabled for,
+// sorted by name.
+func (l *Logger) DebugSubsystems() []string {
+	l.levels.mu.RLock()
+	defer l.levels.mu.RUnlock()
+
+	subsystems := make([]string, 0, len(l.levels.debugSubsystems))
+	for subsystem := range l.levels.debugSubsystems {
+		subsystems = append(subsystems, subsystem)
+	}
+	sort.Strings(subsystems)
+	return subsystems
+}
+
+// levelFormatter drops the entries that are only logged by logrus because
+// debug logging is enabled for some subsystem. Formatting an entry to
+// nothing is the only way to drop it once logrus decided to log it.
+type levelFormatter struct {
+	logrus.Formatter
+	levels *levels
+}
+
+func (f *levelFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if !f.levels.allows(entry) {
+		return nil, nil
+	}
+	return f.Formatter.Format(entry)
+}
+
+// levelHook keeps hooks from firing for the entries dropped by
+// levelFormatter.
+type levelHook struct {
+	logrus.Hook
+	levels *levels
+}
+
+func (h levelHook) Fire(entry *logrus.Entry) error {
+	if !h.levels.allows(entry) {
+		return nil
+	}
+	return h.Hook.Fire(entry)
+}
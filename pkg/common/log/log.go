@@ -10,6 +10,11 @@ import (
 type Logger struct {
 	*logrus.Logger
 	io.Closer
+
+	// fields are the static fields added to every entry
+	fields logrus.Fields
+
+	levels *levels
 }
 
 func NewLogger(options ...Option) (*Logger, error) {
@@ -17,6 +22,7 @@ func NewLogger(options ...Option) (*Logger, error) {
 		Logger: logrus.New(),
 		Closer: nopCloser{},
 	}
+	logger.levels = &levels{level: logger.Logger.GetLevel()}
 	logger.SetOutput(os.Stdout)
 
 	for _, option := range options {
@@ -25,6 +31,18 @@ func NewLogger(options ...Option) (*Logger, error) {
 		}
 	}
 
+	// Wrap the formatter once all options are applied so the format can be
+	// set in any order relative to the fields
+	if len(logger.fields) > 0 {
+		logger.Formatter = &fieldsFormatter{
+			Formatter: logger.Formatter,
+			fields:    logger.fields,
+		}
+	}
+	if len(logger.DebugSubsystems()) > 0 {
+		logger.filterLevels()
+	}
+
 	return logger, nil
 }
 
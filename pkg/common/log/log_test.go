@@ -1,12 +1,17 @@
 package log
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -67,3 +72,183 @@ func TestOutputFile(t *testing.T) {
 		}
 	}
 }
+
+func TestFields(t *testing.T) {
+	f, err := ioutil.TempFile("", "testfields")
+	require.NoError(t, err)
+	tmpfile := f.Name()
+	defer os.Remove(tmpfile)
+
+	// Fields are applied regardless of the order of the options
+	logger, err := NewLogger(WithFields(map[string]string{"region": "us-east", "subsystem_name": "static"}), WithOutputFile(tmpfile), WithFormat(JSONFormat))
+	require.NoError(t, err)
+
+	logger.WithField("subsystem_name", "catalog").Warning("hello")
+	require.NoError(t, logger.Close())
+
+	log, err := ioutil.ReadAll(f)
+	require.NoError(t, err)
+
+	var data map[string]string
+	require.NoError(t, json.Unmarshal(log, &data))
+	assert.Equal(t, "us-east", data["region"])
+	assert.Equal(t, "catalog", data["subsystem_name"], "entry fields take precedence")
+
+	_, err = NewLogger(WithFields(map[string]string{"": "value"}))
+	require.EqualError(t, err, "log field names cannot be empty")
+}
+
+func TestRotatingOutputFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testrotatingoutputfile")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "spire.log")
+
+	f, err := OpenRotatingFile(path, 0640, 10, time.Hour, 2)
+	require.NoError(t, err)
+	defer f.Close()
+	now := time.Now()
+	f.now = func() time.Time { return now }
+
+	write := func(s string) {
+		_, err := f.Write([]byte(s))
+		require.NoError(t, err)
+	}
+	assertFile := func(path, expected string) {
+		actual, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(actual))
+	}
+
+	// Rotated by size
+	write("12345")
+	write("67890")
+	write("abc")
+	assertFile(path, "abc")
+	assertFile(path+".1", "1234567890")
+
+	// Rotated by age
+	now = now.Add(time.Hour)
+	write("def")
+	assertFile(path, "def")
+	assertFile(path+".1", "abc")
+	assertFile(path+".2", "1234567890")
+
+	// Backups over the limit are removed
+	now = now.Add(time.Hour)
+	write("ghi")
+	assertFile(path+".2", "abc")
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRotatingOutputFileRotationFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testrotatingoutputfilerotationfailure")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "spire.log")
+
+	// The oldest backup can't be removed
+	require.NoError(t, os.MkdirAll(filepath.Join(path+".2", "dir"), 0755))
+
+	f, err := OpenRotatingFile(path, 0640, 10, 0, 2)
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.Write([]byte("1234567890"))
+	require.NoError(t, err)
+
+	// The write is not lost and the file is reopened
+	n, err := f.Write([]byte("abc"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to remove file backup")
+	assert.Equal(t, 3, n)
+
+	require.NoError(t, os.RemoveAll(path+".2"))
+	_, err = f.Write([]byte("def"))
+	require.NoError(t, err)
+
+	actual, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "def", string(actual))
+	actual, err = ioutil.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890abc", string(actual))
+}
+
+func TestRotatingOutputFileConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testrotatingoutputfileconfig")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "spire.log")
+
+	for _, tt := range []struct {
+		name   string
+		file   string
+		config RotationConfig
+		err    string
+	}{
+		{name: "no file", config: RotationConfig{MaxSizeMB: 1}, err: "log rotation requires a log file"},
+		{name: "negative size", file: path, config: RotationConfig{MaxSizeMB: -1}, err: "log rotation max_size_mb cannot be negative"},
+		{name: "negative backups", file: path, config: RotationConfig{MaxBackups: -1}, err: "log rotation max_backups cannot be negative"},
+		{name: "invalid age", file: path, config: RotationConfig{MaxAge: "daily"}, err: `invalid log rotation max_age: time: invalid duration "daily"`},
+		{name: "valid", file: path, config: RotationConfig{MaxSizeMB: 1, MaxAge: "24h", MaxBackups: 3}},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			logger, err := NewLogger(WithRotatingOutputFile(tt.file, &tt.config))
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, logger.Close())
+		})
+	}
+}
+
+func TestDebugSubsystems(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger, err := NewLogger(WithLevel("info"), WithDebugSubsystems([]string{"ca_manager", "built-in_plugin"}),
+		func(logger *Logger) error {
+			logger.SetOutput(buf)
+			logger.Formatter = &logrus.TextFormatter{DisableTimestamp: true}
+			return nil
+		})
+	require.NoError(t, err)
+
+	logEntries := func() {
+		logger.WithField("subsystem_name", "ca_manager").Debug("ca_manager debug")
+		logger.WithField("subsystem_name", "built-in_plugin.sql").Debug("plugin debug")
+		logger.WithField("subsystem_name", "node_api").Debug("node_api debug")
+		logger.WithField("subsystem_name", "node_api").Info("node_api info")
+		logger.Debug("debug")
+	}
+
+	logEntries()
+	assert.Equal(t, `level=debug msg="ca_manager debug" subsystem_name=ca_manager
+level=debug msg="plugin debug" subsystem_name=built-in_plugin.sql
+level=info msg="node_api info" subsystem_name=node_api
+`, buf.String())
+	assert.Equal(t, logrus.InfoLevel, logger.GetLevel())
+	assert.Equal(t, []string{"built-in_plugin", "ca_manager"}, logger.DebugSubsystems())
+
+	// Changes apply at runtime
+	buf.Reset()
+	logger.SetDebugSubsystems([]string{"node_api"})
+	logEntries()
+	assert.Equal(t, `level=debug msg="node_api debug" subsystem_name=node_api
+level=info msg="node_api info" subsystem_name=node_api
+`, buf.String())
+
+	buf.Reset()
+	logger.SetDebugSubsystems(nil)
+	logger.SetLevel(logrus.WarnLevel)
+	logEntries()
+	assert.Empty(t, buf.String())
+
+	buf.Reset()
+	logger.SetLevel(logrus.DebugLevel)
+	logEntries()
+	assert.Equal(t, 5, strings.Count(buf.String(), "\n"))
+}
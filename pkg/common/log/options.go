@@ -1,9 +1,12 @@
 package log
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -41,6 +44,88 @@ func WithOutputFile(file string) Option {
 	}
 }
 
+// WithRotatingOutputFile is like WithOutputFile, but rotates the file as
+// configured. If rotation is nil, the file is not rotated.
+func WithRotatingOutputFile(file string, rotation *RotationConfig) Option {
+	if rotation == nil {
+		return WithOutputFile(file)
+	}
+	return func(logger *Logger) error {
+		if file == "" {
+			return errors.New("log rotation requires a log file")
+		}
+		if rotation.MaxSizeMB < 0 {
+			return errors.New("log rotation max_size_mb cannot be negative")
+		}
+		if rotation.MaxBackups < 0 {
+			return errors.New("log rotation max_backups cannot be negative")
+		}
+		var maxAge time.Duration
+		if rotation.MaxAge != "" {
+			var err error
+			maxAge, err = time.ParseDuration(rotation.MaxAge)
+			if err != nil {
+				return fmt.Errorf("invalid log rotation max_age: %v", err)
+			}
+			if maxAge < 0 {
+				return errors.New("log rotation max_age cannot be negative")
+			}
+		}
+
+		f, err := OpenRotatingFile(file, 0640, int64(rotation.MaxSizeMB)<<20, maxAge, rotation.MaxBackups)
+		if err != nil {
+			return err
+		}
+
+		logger.SetOutput(f)
+
+		// If, for some reason, there's another closer set, close it first.
+		if logger.Closer != nil {
+			if err := logger.Closer.Close(); err != nil {
+				return err
+			}
+		}
+
+		logger.Closer = f
+		return nil
+	}
+}
+
+// WithSyslog sends the logs to syslog, in addition to the configured output.
+// If config is nil, logs are not sent to syslog.
+func WithSyslog(config *SyslogConfig) Option {
+	return func(logger *Logger) error {
+		if config == nil {
+			return nil
+		}
+		hook, err := newSyslogHook(config.Network, config.Address, config.Tag)
+		if err != nil {
+			return fmt.Errorf("unable to connect to syslog: %v", err)
+		}
+		logger.AddHook(levelHook{Hook: hook, levels: logger.levels})
+		logger.Closer = multiCloser{logger.Closer, hook}
+		return nil
+	}
+}
+
+// WithFields adds the given static fields to every log entry, e.g. to tell
+// apart the logs of several deployments. Fields set on an entry take
+// precedence.
+func WithFields(fields map[string]string) Option {
+	return func(logger *Logger) error {
+		for k, v := range fields {
+			if k == "" {
+				return errors.New("log field names cannot be empty")
+			}
+			if logger.fields == nil {
+				logger.fields = make(logrus.Fields, len(fields))
+			}
+			logger.fields[k] = v
+		}
+		return nil
+	}
+}
+
 func WithFormat(format string) Option {
 	return func(logger *Logger) error {
 		switch strings.ToUpper(format) {
@@ -57,6 +142,16 @@ func WithFormat(format string) Option {
 	}
 }
 
+// WithDebugSubsystems enables debug logging for the given subsystems,
+// whatever the level of the logger.
+func WithDebugSubsystems(subsystems []string) Option {
+	return func(logger *Logger) error {
+		// The formatter is wrapped by NewLogger once all options are applied
+		logger.setDebugSubsystems(subsystems)
+		return nil
+	}
+}
+
 func WithLevel(logLevel string) Option {
 	return func(logger *Logger) error {
 		level, err := logrus.ParseLevel(logLevel)
@@ -67,3 +162,44 @@ func WithLevel(logLevel string) Option {
 		return nil
 	}
 }
+
+// syslogHook is a logrus hook that sends entries to syslog
+type syslogHook interface {
+	logrus.Hook
+	io.Closer
+}
+
+// fieldsFormatter adds static fields to every entry before formatting it.
+// The entry is copied since its fields may be shared with other entries.
+type fieldsFormatter struct {
+	logrus.Formatter
+	fields logrus.Fields
+}
+
+func (f *fieldsFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	data := make(logrus.Fields, len(f.fields)+len(entry.Data))
+	for k, v := range f.fields {
+		data[k] = v
+	}
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+	withFields := *entry
+	withFields.Data = data
+	return f.Formatter.Format(&withFields)
+}
+
+type multiCloser []io.Closer
+
+func (closers multiCloser) Close() error {
+	var firstErr error
+	for _, closer := range closers {
+		if closer == nil {
+			continue
+		}
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
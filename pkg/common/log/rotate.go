@@ -0,0 +1,158 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// RotatingFile is an io.WriteCloser that appends to a file and rotates it
+// once it would grow over maxSize bytes or has been written to for longer
+// than maxAge. On rotation the file is renamed with a ".1" suffix, previous
+// backups are shifted (".1" to ".2" and so on) and backups over maxBackups
+// are removed. It is used for the log file and the server audit file.
+type RotatingFile struct {
+	path       string
+	perm       os.FileMode
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	now        func() time.Time
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// OpenRotatingFile opens the file at path for appending, creating it with
+// perm if needed. A zero maxSize or maxAge disables the rotation on size or
+// age.
+func OpenRotatingFile(path string, perm os.FileMode, maxSize int64, maxAge time.Duration, maxBackups int) (*RotatingFile, error) {
+	f := &RotatingFile{
+		path:       path,
+		perm:       perm,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+		now:        time.Now,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write writes p to the file, rotating it first if needed. If the rotation
+// fails, p is still written to the file, which is left in place, and the
+// rotation error is returned.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, fmt.Errorf("file %q is closed", f.path)
+	}
+
+	var rotateErr error
+	if f.size > 0 && f.shouldRotate(int64(len(p))) {
+		rotateErr = f.rotate()
+		if f.file == nil {
+			return 0, rotateErr
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	if err != nil {
+		return n, err
+	}
+	return n, rotateErr
+}
+
+// Close closes the file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// A lock must be held on `f` before calling this function
+func (f *RotatingFile) shouldRotate(n int64) bool {
+	if f.maxSize > 0 && f.size+n > f.maxSize {
+		return true
+	}
+	return f.maxAge > 0 && f.now().Sub(f.opened) >= f.maxAge
+}
+
+// A lock must be held on `f` before calling this function
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, f.perm)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.opened = f.now()
+	return nil
+}
+
+// rotate rotates the file. If the file can't be moved aside, the file is
+// reopened so the writes keep going to it.
+// A lock must be held on `f` before calling this function
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("unable to close file for rotation: %v", err)
+	}
+	f.file = nil
+
+	if err := f.moveAside(); err != nil {
+		if openErr := f.open(); openErr != nil {
+			return fmt.Errorf("%v; unable to reopen file: %v", err, openErr)
+		}
+		return err
+	}
+	return f.open()
+}
+
+// moveAside moves the file to the first backup, shifting the other backups,
+// or removes it if no backups are kept.
+// A lock must be held on `f` before calling this function
+func (f *RotatingFile) moveAside() error {
+	if f.maxBackups == 0 {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("unable to rotate file: %v", err)
+		}
+		return nil
+	}
+
+	// Drop the oldest backup and shift the rest
+	if err := os.Remove(f.backupPath(f.maxBackups)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("unable to remove file backup: %v", err)
+	}
+	for i := f.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(f.backupPath(i), f.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("unable to rotate file backup: %v", err)
+		}
+	}
+	if err := os.Rename(f.path, f.backupPath(1)); err != nil {
+		return fmt.Errorf("unable to rotate file: %v", err)
+	}
+	return nil
+}
+
+func (f *RotatingFile) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", f.path, n)
+}
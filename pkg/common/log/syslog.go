@@ -0,0 +1,11 @@
+package log
+
+// SyslogFacility is the facility messages are sent to syslog with.
+type SyslogFacility int
+
+const (
+	// SyslogDaemon is the facility of the log entries.
+	SyslogDaemon SyslogFacility = iota
+	// SyslogAuth is the facility of the audit events.
+	SyslogAuth
+)
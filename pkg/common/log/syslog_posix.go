@@ -0,0 +1,61 @@
+// +build !windows
+
+package log
+
+import (
+	"log/syslog"
+
+	lSyslog "github.com/sirupsen/logrus/hooks/syslog"
+)
+
+// SyslogWriter sends messages to syslog.
+type SyslogWriter struct {
+	w *syslog.Writer
+}
+
+// DialSyslog connects to the syslog daemon at address using network (e.g.
+// "udp"). If network is empty, it connects to the local syslog daemon,
+// which on systemd hosts is journald.
+func DialSyslog(network, address, tag string, facility SyslogFacility) (*SyslogWriter, error) {
+	priority := syslog.LOG_INFO | syslog.LOG_DAEMON
+	if facility == SyslogAuth {
+		priority = syslog.LOG_INFO | syslog.LOG_AUTH
+	}
+	w, err := syslog.Dial(network, address, priority, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogWriter{w: w}, nil
+}
+
+// Info sends the message with the INFO severity.
+func (w *SyslogWriter) Info(msg string) error {
+	return w.w.Info(msg)
+}
+
+// Close closes the connection to syslog.
+func (w *SyslogWriter) Close() error {
+	return w.w.Close()
+}
+
+type posixSyslogHook struct {
+	*lSyslog.SyslogHook
+}
+
+func (h posixSyslogHook) Close() error {
+	return h.Writer.Close()
+}
+
+func newSyslogHook(network, address, tag string) (syslogHook, error) {
+	w, err := DialSyslog(network, address, tag, SyslogDaemon)
+	if err != nil {
+		return nil, err
+	}
+	return posixSyslogHook{
+		SyslogHook: &lSyslog.SyslogHook{
+			Writer:        w.w,
+			SyslogNetwork: network,
+			SyslogRaddr:   address,
+		},
+	}, nil
+}
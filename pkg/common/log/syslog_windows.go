@@ -0,0 +1,31 @@
+// +build windows
+
+package log
+
+import (
+	"errors"
+)
+
+var errSyslogNotSupported = errors.New("syslog is not supported on windows")
+
+// SyslogWriter sends messages to syslog. Syslog is not supported on Windows.
+type SyslogWriter struct{}
+
+// DialSyslog always fails since syslog is not supported on Windows.
+func DialSyslog(network, address, tag string, facility SyslogFacility) (*SyslogWriter, error) {
+	return nil, errSyslogNotSupported
+}
+
+// Info is never called since a SyslogWriter cannot be created on Windows.
+func (w *SyslogWriter) Info(msg string) error {
+	return errSyslogNotSupported
+}
+
+// Close is never called since a SyslogWriter cannot be created on Windows.
+func (w *SyslogWriter) Close() error {
+	return nil
+}
+
+func newSyslogHook(network, address, tag string) (syslogHook, error) {
+	return nil, errSyslogNotSupported
+}
@@ -1,7 +1,15 @@
 package nodeutil
 
 import (
+	"github.com/spiffe/spire/pkg/common/apierror"
 	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	agentBannedMsg      = "agent is banned"
+	agentNotAttestedMsg = "agent is not attested or no longer valid"
 )
 
 // IsAgentBanned determines if a given attested node is banned or not.
@@ -9,3 +17,38 @@ import (
 func IsAgentBanned(node *common.AttestedNode) bool {
 	return node.CertSerialNumber == ""
 }
+
+// AgentBannedError returns the error the server responds with when a banned
+// agent calls the Node API.
+func AgentBannedError() error {
+	return apierror.Error(codes.PermissionDenied, apierror.AgentBanned, nil, agentBannedMsg)
+}
+
+// IsAgentBannedError determines if an error returned by the Node API means
+// that the calling agent is banned. Servers that predate the error reasons
+// are recognized by the error message.
+func IsAgentBannedError(err error) bool {
+	if apierror.Is(err, codes.PermissionDenied, apierror.AgentBanned) {
+		return true
+	}
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.PermissionDenied && st.Message() == agentBannedMsg
+}
+
+// AgentNotAttestedError returns the error the server responds with when an
+// agent calls the Node API with an SVID that does not belong to an attested
+// node, e.g. because the agent was evicted.
+func AgentNotAttestedError() error {
+	return apierror.Error(codes.PermissionDenied, apierror.AgentNotAttested, nil, agentNotAttestedMsg)
+}
+
+// IsAgentNotAttestedError determines if an error returned by the Node API
+// means that the calling agent is not attested. Servers that predate the
+// error reasons are recognized by the error message.
+func IsAgentNotAttestedError(err error) bool {
+	if apierror.Is(err, codes.PermissionDenied, apierror.AgentNotAttested) {
+		return true
+	}
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.PermissionDenied && st.Message() == agentNotAttestedMsg
+}
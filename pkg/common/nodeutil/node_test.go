@@ -1,14 +1,33 @@
 package nodeutil_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestIsAgentBanned(t *testing.T) {
 	require.True(t, nodeutil.IsAgentBanned(&common.AttestedNode{}))
 	require.False(t, nodeutil.IsAgentBanned(&common.AttestedNode{CertSerialNumber: "non-empty-serial"}))
 }
+
+func TestIsAgentBannedError(t *testing.T) {
+	require.True(t, nodeutil.IsAgentBannedError(nodeutil.AgentBannedError()))
+	require.True(t, nodeutil.IsAgentBannedError(status.Error(codes.PermissionDenied, "agent is banned")))
+	require.False(t, nodeutil.IsAgentBannedError(nil))
+	require.False(t, nodeutil.IsAgentBannedError(errors.New("agent is banned")))
+	require.False(t, nodeutil.IsAgentBannedError(nodeutil.AgentNotAttestedError()))
+}
+
+func TestIsAgentNotAttestedError(t *testing.T) {
+	require.True(t, nodeutil.IsAgentNotAttestedError(nodeutil.AgentNotAttestedError()))
+	require.True(t, nodeutil.IsAgentNotAttestedError(status.Error(codes.PermissionDenied, "agent is not attested or no longer valid")))
+	require.False(t, nodeutil.IsAgentNotAttestedError(nil))
+	require.False(t, nodeutil.IsAgentNotAttestedError(errors.New("agent is not attested or no longer valid")))
+	require.False(t, nodeutil.IsAgentNotAttestedError(nodeutil.AgentBannedError()))
+}
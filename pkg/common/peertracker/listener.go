@@ -34,6 +34,28 @@ func (lf *ListenerFactory) ListenUnix(network string, laddr *net.UnixAddr) (*Lis
 	return lf.listenUnix(network, laddr)
 }
 
+// TrackListener tracks the processes that connect to an existing listener,
+// e.g. one inherited from systemd socket activation.
+func (lf *ListenerFactory) TrackListener(l net.Listener) (*Listener, error) {
+	if lf.NewTracker == nil {
+		lf.NewTracker = NewTracker
+	}
+	if lf.Log == nil {
+		lf.Log = newNoopLogger()
+	}
+
+	tracker, err := lf.NewTracker()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Listener{
+		l:       l,
+		Tracker: tracker,
+		log:     lf.Log,
+	}, nil
+}
+
 func newNoopLogger() *logrus.Logger {
 	logger := logrus.New()
 	logger.Out = ioutil.Discard
@@ -73,6 +95,10 @@ func (l *Listener) Accept() (net.Conn, error) {
 		switch conn.RemoteAddr().Network() {
 		case "unix":
 			caller, err = CallerFromUDSConn(conn)
+		case "pipe":
+			caller, err = CallerFromNamedPipeConn(conn)
+		case "tcp":
+			caller, err = CallerFromTCPConn(conn)
 		default:
 			err = ErrUnsupportedTransport
 		}
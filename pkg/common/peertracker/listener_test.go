@@ -151,6 +151,19 @@ func (p *ListenerTestSuite) TestAcceptFailsWhenUnderlyingAcceptFails() {
 	p.Require().Error(err)
 }
 
+func (p *ListenerTestSuite) TestTrackListener() {
+	l, err := net.ListenUnix(p.unixAddr.Network(), p.unixAddr)
+	p.Require().NoError(err)
+
+	lf := ListenerFactory{
+		NewTracker: newFailingMockTracker,
+	}
+	p.ul, err = lf.TrackListener(l)
+	p.Require().NoError(err)
+	p.Require().Equal(p.unixAddr.String(), p.ul.Addr().String())
+	p.Require().IsType(failingMockTracker{}, p.ul.Tracker)
+}
+
 // returns an empty unix listener that will fail any call to Accept()
 func newFailingMockListenUnix(network string, laddr *net.UnixAddr) (*net.UnixListener, error) {
 	return &net.UnixListener{}, nil
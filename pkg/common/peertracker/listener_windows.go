@@ -0,0 +1,35 @@
+// +build windows
+
+package peertracker
+
+import (
+	"github.com/Microsoft/go-winio"
+)
+
+// ListenPipe listens on the named pipe and tracks the processes that connect
+// to it.
+func (lf *ListenerFactory) ListenPipe(pipe string, pipeConfig *winio.PipeConfig) (*Listener, error) {
+	if lf.NewTracker == nil {
+		lf.NewTracker = NewTracker
+	}
+	if lf.Log == nil {
+		lf.Log = newNoopLogger()
+	}
+
+	l, err := winio.ListenPipe(pipe, pipeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker, err := lf.NewTracker()
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+
+	return &Listener{
+		l:       l,
+		Tracker: tracker,
+		log:     lf.Log,
+	}, nil
+}
@@ -0,0 +1,24 @@
+package peertracker
+
+import (
+	"net"
+)
+
+// CallerFromNamedPipeConn returns the caller information for a connection
+// accepted on a Windows named pipe.
+func CallerFromNamedPipeConn(conn net.Conn) (CallerInfo, error) {
+	var info CallerInfo
+
+	fdconn, ok := conn.(interface{ Fd() uintptr })
+	if !ok {
+		return info, ErrInvalidConnection
+	}
+
+	info, err := getNamedPipeCallerInfo(fdconn.Fd())
+	if err != nil {
+		return info, err
+	}
+
+	info.Addr = conn.RemoteAddr()
+	return info, nil
+}
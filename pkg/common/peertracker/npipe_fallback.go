@@ -0,0 +1,7 @@
+// +build !windows
+
+package peertracker
+
+func getNamedPipeCallerInfo(fd uintptr) (CallerInfo, error) {
+	return CallerInfo{}, ErrUnsupportedPlatform
+}
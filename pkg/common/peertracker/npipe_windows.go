@@ -0,0 +1,26 @@
+// +build windows
+
+package peertracker
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	kernel32                        = windows.NewLazySystemDLL("kernel32.dll")
+	procGetNamedPipeClientProcessID = kernel32.NewProc("GetNamedPipeClientProcessId")
+)
+
+func getNamedPipeCallerInfo(fd uintptr) (CallerInfo, error) {
+	var pid uint32
+	r, _, err := procGetNamedPipeClientProcessID.Call(fd, uintptr(unsafe.Pointer(&pid)))
+	if r == 0 {
+		return CallerInfo{}, err
+	}
+
+	return CallerInfo{
+		PID: int32(pid),
+	}, nil
+}
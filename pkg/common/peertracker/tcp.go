@@ -0,0 +1,70 @@
+package peertracker
+
+import (
+	"errors"
+	"net"
+)
+
+// ErrNonLoopbackPeer is returned when a TCP connection does not originate
+// from the loopback interface.
+var ErrNonLoopbackPeer = errors.New("peer is not on the loopback interface")
+
+// ListenTCP listens on the TCP address and tracks the processes that connect
+// to it. Only loopback addresses are allowed since callers are identified by
+// looking up the owner of the connection on the local host.
+func (lf *ListenerFactory) ListenTCP(network string, laddr *net.TCPAddr) (*Listener, error) {
+	if lf.NewTracker == nil {
+		lf.NewTracker = NewTracker
+	}
+	if lf.Log == nil {
+		lf.Log = newNoopLogger()
+	}
+
+	if !laddr.IP.IsLoopback() {
+		return nil, ErrNonLoopbackPeer
+	}
+
+	l, err := net.ListenTCP(network, laddr)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker, err := lf.NewTracker()
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+
+	return &Listener{
+		l:       l,
+		Tracker: tracker,
+		log:     lf.Log,
+	}, nil
+}
+
+// CallerFromTCPConn returns the caller information for a connection accepted
+// on a loopback TCP listener. The caller is the process owning the client end
+// of the connection.
+func CallerFromTCPConn(conn net.Conn) (CallerInfo, error) {
+	var info CallerInfo
+
+	local, ok := conn.LocalAddr().(*net.TCPAddr)
+	if !ok {
+		return info, ErrInvalidConnection
+	}
+	remote, ok := conn.RemoteAddr().(*net.TCPAddr)
+	if !ok {
+		return info, ErrInvalidConnection
+	}
+	if !remote.IP.IsLoopback() {
+		return info, ErrNonLoopbackPeer
+	}
+
+	info, err := getTCPCallerInfo(local, remote)
+	if err != nil {
+		return info, err
+	}
+
+	info.Addr = remote
+	return info, nil
+}
@@ -0,0 +1,11 @@
+// +build !linux
+
+package peertracker
+
+import (
+	"net"
+)
+
+func getTCPCallerInfo(local, remote *net.TCPAddr) (CallerInfo, error) {
+	return CallerInfo{}, ErrUnsupportedPlatform
+}
@@ -0,0 +1,193 @@
+// +build linux
+
+package peertracker
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+const procPath = "/proc"
+
+func getTCPCallerInfo(local, remote *net.TCPAddr) (CallerInfo, error) {
+	table := "tcp"
+	if local.IP.To4() == nil {
+		table = "tcp6"
+	}
+
+	f, err := os.Open(filepath.Join(procPath, "net", table))
+	if err != nil {
+		return CallerInfo{}, err
+	}
+	defer f.Close()
+
+	// The client end of the connection has the remote address as its local
+	// address and vice versa.
+	uid, inode, err := findTCPSocket(f, remote, local)
+	if err != nil {
+		return CallerInfo{}, err
+	}
+
+	pid, err := findSocketOwner(inode)
+	if err != nil {
+		return CallerInfo{}, err
+	}
+
+	gid, err := getProcessGID(pid)
+	if err != nil {
+		return CallerInfo{}, err
+	}
+
+	return CallerInfo{
+		PID: pid,
+		UID: uid,
+		GID: gid,
+	}, nil
+}
+
+// findTCPSocket looks up the socket with the given local and remote
+// addresses in a /proc/net/tcp{,6} table, returning its owner UID and inode.
+func findTCPSocket(r io.Reader, local, remote *net.TCPAddr) (uint32, uint64, error) {
+	scanner := bufio.NewScanner(r)
+	// Skip the header
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		l, err := parseProcNetAddr(fields[1])
+		if err != nil {
+			return 0, 0, err
+		}
+		rm, err := parseProcNetAddr(fields[2])
+		if err != nil {
+			return 0, 0, err
+		}
+		if !tcpAddrEqual(l, local) || !tcpAddrEqual(rm, remote) {
+			continue
+		}
+
+		uid, err := strconv.ParseUint(fields[7], 10, 32)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid socket uid %q: %v", fields[7], err)
+		}
+		inode, err := strconv.ParseUint(fields[9], 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid socket inode %q: %v", fields[9], err)
+		}
+		return uint32(uid), inode, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, err
+	}
+	return 0, 0, errors.New("no socket found for TCP peer")
+}
+
+// parseProcNetAddr parses an address of the form "0100007F:1F90". The IP
+// address is made of 32-bit words in host byte order.
+func parseProcNetAddr(s string) (*net.TCPAddr, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid address %q", s)
+	}
+	ip, err := hex.DecodeString(parts[0])
+	if err != nil || (len(ip) != net.IPv4len && len(ip) != net.IPv6len) {
+		return nil, fmt.Errorf("invalid address %q", s)
+	}
+	port, err := strconv.ParseUint(parts[1], 16, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q", s)
+	}
+	if isLittleEndian() {
+		for i := 0; i < len(ip); i += 4 {
+			ip[i], ip[i+1], ip[i+2], ip[i+3] = ip[i+3], ip[i+2], ip[i+1], ip[i]
+		}
+	}
+	return &net.TCPAddr{IP: net.IP(ip), Port: int(port)}, nil
+}
+
+func tcpAddrEqual(a, b *net.TCPAddr) bool {
+	return a.Port == b.Port && a.IP.Equal(b.IP)
+}
+
+func isLittleEndian() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}
+
+// findSocketOwner returns the process holding a file descriptor for the
+// socket with the given inode. Sockets shared between several processes
+// are rejected since the caller would be ambiguous.
+func findSocketOwner(inode uint64) (int32, error) {
+	target := fmt.Sprintf("socket:[%d]", inode)
+
+	procs, err := ioutil.ReadDir(procPath)
+	if err != nil {
+		return 0, err
+	}
+
+	owner := int32(-1)
+	for _, proc := range procs {
+		pid, err := strconv.ParseInt(proc.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join(procPath, proc.Name(), "fd")
+		fds, err := ioutil.ReadDir(fdDir)
+		if err != nil {
+			// The process may have exited or belong to another user
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil || link != target {
+				continue
+			}
+			if owner != -1 && owner != int32(pid) {
+				return 0, errors.New("TCP peer socket is shared by multiple processes")
+			}
+			owner = int32(pid)
+			break
+		}
+	}
+	if owner == -1 {
+		return 0, errors.New("no process found for TCP peer")
+	}
+	return owner, nil
+}
+
+func getProcessGID(pid int32) (uint32, error) {
+	f, err := os.Open(filepath.Join(procPath, strconv.Itoa(int(pid)), "status"))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "Gid:" {
+			continue
+		}
+		gid, err := strconv.ParseUint(fields[1], 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid gid %q: %v", fields[1], err)
+		}
+		return uint32(gid), nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("no gid found for process %d", pid)
+}
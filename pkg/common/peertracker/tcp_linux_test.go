@@ -0,0 +1,75 @@
+// +build linux
+
+package peertracker
+
+import (
+	"net"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const procNetTCP = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0100007F:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1111 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:D431 0100007F:1F90 01 00000000:00000000 00:00000000 00000000  1000        0 2222 1 0000000000000000 20 4 30 10 -1
+   2: 0100007F:1F90 0100007F:D431 01 00000000:00000000 00:00000000 00000000     0        0 3333 1 0000000000000000 20 4 30 10 -1
+`
+
+func TestFindTCPSocket(t *testing.T) {
+	if !isLittleEndian() {
+		t.Skip("fixture uses little endian addresses")
+	}
+
+	server := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080}
+	client := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 54321}
+
+	uid, inode, err := findTCPSocket(strings.NewReader(procNetTCP), client, server)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1000), uid)
+	require.Equal(t, uint64(2222), inode)
+
+	other := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 12345}
+	_, _, err = findTCPSocket(strings.NewReader(procNetTCP), other, server)
+	require.EqualError(t, err, "no socket found for TCP peer")
+}
+
+func TestParseProcNetAddrIPv6(t *testing.T) {
+	if !isLittleEndian() {
+		t.Skip("fixture uses little endian addresses")
+	}
+
+	addr, err := parseProcNetAddr("00000000000000000000000001000000:1F90")
+	require.NoError(t, err)
+	require.True(t, addr.IP.Equal(net.IPv6loopback))
+	require.Equal(t, 8080, addr.Port)
+
+	_, err = parseProcNetAddr("0100007F")
+	require.Error(t, err)
+}
+
+func TestListenTCP(t *testing.T) {
+	lf := &ListenerFactory{}
+
+	_, err := lf.ListenTCP("tcp", &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1)})
+	require.Equal(t, ErrNonLoopbackPeer, err)
+
+	l, err := lf.ListenTCP("tcp", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer l.Close()
+
+	client, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	conn, err := l.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	info := conn.(*Conn).Info
+	require.Equal(t, int32(os.Getpid()), info.Caller.PID)
+	require.Equal(t, uint32(os.Getuid()), info.Caller.UID)
+	require.Equal(t, uint32(os.Getgid()), info.Caller.GID)
+	require.Equal(t, client.LocalAddr().String(), info.Caller.Addr.String())
+}
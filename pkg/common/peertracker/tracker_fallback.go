@@ -3,6 +3,7 @@
 // +build !freebsd
 // +build !netbsd
 // +build !openbsd
+// +build !windows
 
 package peertracker
 
@@ -4,9 +4,12 @@ package peertracker
 
 import (
 	"errors"
+	"os"
+	"os/exec"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseTaskStat(t *testing.T) {
@@ -39,3 +42,58 @@ func TestParseTaskStat(t *testing.T) {
 		assert.Equal(err, tt.err)
 	}
 }
+
+func TestPIDFDWatcher(t *testing.T) {
+	tracker, err := NewPIDFDTracker()
+	if err != nil {
+		t.Skipf("pidfd not supported: %v", err)
+	}
+	defer tracker.Close()
+
+	self := CallerInfo{
+		PID: int32(os.Getpid()),
+		UID: uint32(os.Getuid()),
+		GID: uint32(os.Getgid()),
+	}
+
+	t.Run("alive caller", func(t *testing.T) {
+		watcher, err := tracker.NewWatcher(self)
+		require.NoError(t, err)
+		require.Equal(t, self.PID, watcher.PID())
+		require.NoError(t, watcher.IsAlive())
+
+		watcher.Close()
+		require.EqualError(t, watcher.IsAlive(), "caller is no longer being watched")
+	})
+
+	t.Run("no caller pid", func(t *testing.T) {
+		_, err := tracker.NewWatcher(CallerInfo{})
+		require.EqualError(t, err, "could not resolve caller information")
+	})
+
+	t.Run("caller uid mismatch", func(t *testing.T) {
+		caller := self
+		caller.UID++
+		_, err := tracker.NewWatcher(caller)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match original caller")
+	})
+
+	t.Run("exited caller", func(t *testing.T) {
+		cmd := exec.Command("sleep", "60")
+		require.NoError(t, cmd.Start())
+
+		watcher, err := tracker.NewWatcher(CallerInfo{
+			PID: int32(cmd.Process.Pid),
+			UID: uint32(os.Getuid()),
+			GID: uint32(os.Getgid()),
+		})
+		require.NoError(t, err)
+		defer watcher.Close()
+		require.NoError(t, watcher.IsAlive())
+
+		require.NoError(t, cmd.Process.Kill())
+		_ = cmd.Wait()
+		require.Error(t, watcher.IsAlive())
+	})
+}
@@ -0,0 +1,165 @@
+// +build linux
+
+package peertracker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+type pidfdTracker struct{}
+
+// NewPIDFDTracker creates a peer tracker that pins the identity of callers
+// with a pidfd (Linux 5.3+). Unlike the default tracker, exit detection does
+// not rely on the proc filesystem, and callers that execute a different
+// binary after connecting are no longer considered alive. An error is
+// returned if pidfds are not supported by the kernel.
+func NewPIDFDTracker() (PeerTracker, error) {
+	pidfd, err := pidfdOpen(int32(os.Getpid()))
+	if err != nil {
+		return nil, fmt.Errorf("pidfd is not supported: %v", err)
+	}
+	syscall.Close(pidfd)
+	return pidfdTracker{}, nil
+}
+
+func (pidfdTracker) NewWatcher(info CallerInfo) (Watcher, error) {
+	return newPIDFDWatcher(info)
+}
+
+func (pidfdTracker) Close() {
+}
+
+type exeIdentity struct {
+	dev uint64
+	ino uint64
+}
+
+type pidfdWatcher struct {
+	mtx   sync.Mutex
+	pid   int32
+	pidfd int
+	uid   uint32
+	gid   uint32
+	exe   exeIdentity
+}
+
+func newPIDFDWatcher(info CallerInfo) (*pidfdWatcher, error) {
+	// If PID == 0, something is wrong...
+	if info.PID == 0 {
+		return nil, errors.New("could not resolve caller information")
+	}
+
+	pidfd, err := pidfdOpen(info.PID)
+	if err != nil {
+		return nil, fmt.Errorf("could not open caller pidfd: %v", err)
+	}
+
+	w := &pidfdWatcher{
+		pid:   info.PID,
+		pidfd: pidfd,
+		uid:   info.UID,
+		gid:   info.GID,
+	}
+
+	w.exe, err = getExeIdentity(info.PID)
+	if err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	// The PID may have been reused between the time the caller connected
+	// and the pidfd was opened. Ensure the pinned process belongs to the
+	// same user and group as the original caller.
+	if err := w.IsAlive(); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *pidfdWatcher) Close() {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if w.pidfd < 0 {
+		return
+	}
+
+	syscall.Close(w.pidfd)
+	w.pidfd = -1
+}
+
+func (w *pidfdWatcher) IsAlive() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if w.pidfd < 0 {
+		return errors.New("caller is no longer being watched")
+	}
+
+	exe, err := getExeIdentity(w.pid)
+	if err != nil {
+		return fmt.Errorf("caller exit suspected: %v", err)
+	}
+	if exe != w.exe {
+		return errors.New("new process image detected: caller executed a different binary")
+	}
+
+	var stat syscall.Stat_t
+	if err := syscall.Stat(fmt.Sprintf("/proc/%v", w.pid), &stat); err != nil {
+		return fmt.Errorf("caller exit suspected due to failed proc stat: %v", err)
+	}
+	if stat.Uid != w.uid {
+		return fmt.Errorf("new process detected: process uid %v does not match original caller %v", stat.Uid, w.uid)
+	}
+	if stat.Gid != w.gid {
+		return fmt.Errorf("new process detected: process gid %v does not match original caller %v", stat.Gid, w.gid)
+	}
+
+	// Check liveness through the pidfd last. If the pinned process is still
+	// alive, the PID cannot have been reused and the checks above were made
+	// against the original caller.
+	if err := pidfdSendSignal(w.pidfd, 0); err != nil {
+		return fmt.Errorf("caller exit detected via pidfd: %v", err)
+	}
+
+	return nil
+}
+
+func (w *pidfdWatcher) PID() int32 {
+	return w.pid
+}
+
+func getExeIdentity(pid int32) (exeIdentity, error) {
+	var stat syscall.Stat_t
+	if err := syscall.Stat(fmt.Sprintf("/proc/%v/exe", pid), &stat); err != nil {
+		return exeIdentity{}, fmt.Errorf("could not stat caller executable: %v", err)
+	}
+	return exeIdentity{
+		dev: uint64(stat.Dev), //nolint: unconvert // type differs between architectures
+		ino: stat.Ino,
+	}, nil
+}
+
+func pidfdOpen(pid int32) (int, error) {
+	fd, _, errno := unix.Syscall(unix.SYS_PIDFD_OPEN, uintptr(pid), 0, 0)
+	if errno != 0 {
+		return -1, errno
+	}
+	return int(fd), nil
+}
+
+func pidfdSendSignal(pidfd int, sig syscall.Signal) error {
+	_, _, errno := unix.Syscall6(unix.SYS_PIDFD_SEND_SIGNAL, uintptr(pidfd), uintptr(sig), 0, 0, 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+// +build !linux
+
+package peertracker
+
+func NewPIDFDTracker() (PeerTracker, error) {
+	return nil, ErrUnsupportedPlatform
+}
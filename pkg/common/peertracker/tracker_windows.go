@@ -0,0 +1,82 @@
+// +build windows
+
+package peertracker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sys/windows"
+)
+
+type windowsTracker struct{}
+
+func newTracker() (*windowsTracker, error) {
+	return &windowsTracker{}, nil
+}
+
+func (*windowsTracker) Close() {
+}
+
+func (*windowsTracker) NewWatcher(info CallerInfo) (Watcher, error) {
+	// If PID == 0, something is wrong...
+	if info.PID == 0 {
+		return nil, errors.New("could not resolve caller information")
+	}
+
+	// Holding a handle to the process prevents its PID from being reused
+	// until the handle is closed, which allows exit detection without racing
+	// against PID reuse.
+	h, err := windows.OpenProcess(windows.SYNCHRONIZE|windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(info.PID))
+	if err != nil {
+		return nil, fmt.Errorf("could not create watcher: %v", err)
+	}
+
+	return &windowsWatcher{
+		h:   h,
+		pid: info.PID,
+	}, nil
+}
+
+type windowsWatcher struct {
+	mtx    sync.Mutex
+	closed bool
+	h      windows.Handle
+	pid    int32
+}
+
+func (w *windowsWatcher) Close() {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if w.closed {
+		return
+	}
+	w.closed = true
+	windows.CloseHandle(w.h)
+}
+
+func (w *windowsWatcher) IsAlive() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if w.closed {
+		return errors.New("caller is no longer being watched")
+	}
+
+	// The process handle is signaled when the process exits.
+	event, err := windows.WaitForSingleObject(w.h, 0)
+	if err != nil {
+		return fmt.Errorf("unable to determine caller liveness: %v", err)
+	}
+	if event != uint32(windows.WAIT_TIMEOUT) {
+		return errors.New("caller exit detected via process handle")
+	}
+
+	return nil
+}
+
+func (w *windowsWatcher) PID() int32 {
+	return w.pid
+}
@@ -1,12 +1,14 @@
 package apiserver
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
 	authv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -22,6 +24,15 @@ type Client interface {
 
 	// ValidateToken queries k8s token review API and returns information about the given token
 	ValidateToken(token string, audiences []string) (*authv1.TokenReviewStatus, error)
+
+	// LabelNode sets a label on the node with the given node name
+	LabelNode(nodeName, key, value string) error
+
+	// UnlabelNode removes a label from the node with the given node name
+	UnlabelNode(nodeName, key string) error
+
+	// GetNodesWithLabel returns the nodes having the given label set to the given value
+	GetNodesWithLabel(key, value string) ([]v1.Node, error)
 }
 
 type client struct {
@@ -128,6 +139,90 @@ func (c *client) ValidateToken(token string, audiences []string) (*authv1.TokenR
 	return &resp.Status, nil
 }
 
+func (c *client) LabelNode(nodeName, key, value string) error {
+	// Validate inputs
+	if nodeName == "" {
+		return errors.New("empty node name")
+	}
+
+	// Reload config
+	clientset, err := c.loadClientHook(c.kubeConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("unable to get clientset: %v", err)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]string{key: value},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to marshal node patch: %v", err)
+	}
+
+	// Patch node
+	if _, err := clientset.CoreV1().Nodes().Patch(nodeName, types.StrategicMergePatchType, patch); err != nil {
+		return fmt.Errorf("unable to patch node: %v", err)
+	}
+	return nil
+}
+
+func (c *client) UnlabelNode(nodeName, key string) error {
+	// Validate inputs
+	if nodeName == "" {
+		return errors.New("empty node name")
+	}
+
+	// Reload config
+	clientset, err := c.loadClientHook(c.kubeConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("unable to get clientset: %v", err)
+	}
+
+	// A null label value removes the label
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{key: nil},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to marshal node patch: %v", err)
+	}
+
+	// Patch node
+	if _, err := clientset.CoreV1().Nodes().Patch(nodeName, types.StrategicMergePatchType, patch); err != nil {
+		return fmt.Errorf("unable to patch node: %v", err)
+	}
+	return nil
+}
+
+func (c *client) GetNodesWithLabel(key, value string) ([]v1.Node, error) {
+	// Validate inputs
+	if key == "" {
+		return nil, errors.New("empty label key")
+	}
+
+	// Reload config
+	clientset, err := c.loadClientHook(c.kubeConfigFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get clientset: %v", err)
+	}
+
+	// List nodes
+	nodeList, err := clientset.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", key, value),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to query nodes API: %v", err)
+	}
+
+	if nodeList == nil {
+		return nil, errors.New("got nil node list")
+	}
+
+	return nodeList.Items, nil
+}
+
 func loadClient(kubeConfigFilePath string) (kubernetes.Interface, error) {
 	var config *rest.Config
 	var err error
@@ -19,6 +19,7 @@ import (
 	authv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -256,6 +257,107 @@ func (s *ClientSuite) TestGetNodeSucceeds() {
 	s.Equal(expectedNode, node)
 }
 
+func (s *ClientSuite) TestLabelNodeFailsIfNodeNameIsEmpty() {
+	client := s.createClient()
+	err := client.LabelNode("", "KEY", "VALUE")
+	s.EqualError(err, "empty node name")
+}
+
+func (s *ClientSuite) TestLabelNodeFailsToLoadClient() {
+	client := s.createDefectiveClient("")
+	err := client.LabelNode("NODENAME", "KEY", "VALUE")
+	s.AssertErrorContains(err, "unable to get clientset")
+}
+
+func (s *ClientSuite) TestLabelNodeFailsIfGetsErrorFromAPIServer() {
+	s.mockClientset.EXPECT().CoreV1().Return(s.mockCoreV1).Times(1)
+	s.mockCoreV1.EXPECT().Nodes().Return(s.mockNodes).Times(1)
+	s.mockNodes.EXPECT().Patch("NODENAME", types.StrategicMergePatchType, gomock.Any()).Return(nil, errors.New("an error"))
+
+	client := s.createClient()
+	err := client.LabelNode("NODENAME", "KEY", "VALUE")
+	s.EqualError(err, "unable to patch node: an error")
+}
+
+func (s *ClientSuite) TestLabelNodeSucceeds() {
+	s.mockClientset.EXPECT().CoreV1().Return(s.mockCoreV1).Times(1)
+	s.mockCoreV1.EXPECT().Nodes().Return(s.mockNodes).Times(1)
+	s.mockNodes.EXPECT().Patch("NODENAME", types.StrategicMergePatchType, []byte(`{"metadata":{"labels":{"KEY":"VALUE"}}}`)).Return(createNode("NODENAME"), nil)
+
+	client := s.createClient()
+	err := client.LabelNode("NODENAME", "KEY", "VALUE")
+	s.NoError(err)
+}
+
+func (s *ClientSuite) TestUnlabelNodeFailsIfNodeNameIsEmpty() {
+	client := s.createClient()
+	err := client.UnlabelNode("", "KEY")
+	s.EqualError(err, "empty node name")
+}
+
+func (s *ClientSuite) TestUnlabelNodeFailsToLoadClient() {
+	client := s.createDefectiveClient("")
+	err := client.UnlabelNode("NODENAME", "KEY")
+	s.AssertErrorContains(err, "unable to get clientset")
+}
+
+func (s *ClientSuite) TestUnlabelNodeFailsIfGetsErrorFromAPIServer() {
+	s.mockClientset.EXPECT().CoreV1().Return(s.mockCoreV1).Times(1)
+	s.mockCoreV1.EXPECT().Nodes().Return(s.mockNodes).Times(1)
+	s.mockNodes.EXPECT().Patch("NODENAME", types.StrategicMergePatchType, gomock.Any()).Return(nil, errors.New("an error"))
+
+	client := s.createClient()
+	err := client.UnlabelNode("NODENAME", "KEY")
+	s.EqualError(err, "unable to patch node: an error")
+}
+
+func (s *ClientSuite) TestUnlabelNodeSucceeds() {
+	s.mockClientset.EXPECT().CoreV1().Return(s.mockCoreV1).Times(1)
+	s.mockCoreV1.EXPECT().Nodes().Return(s.mockNodes).Times(1)
+	s.mockNodes.EXPECT().Patch("NODENAME", types.StrategicMergePatchType, []byte(`{"metadata":{"labels":{"KEY":null}}}`)).Return(createNode("NODENAME"), nil)
+
+	client := s.createClient()
+	err := client.UnlabelNode("NODENAME", "KEY")
+	s.NoError(err)
+}
+
+func (s *ClientSuite) TestGetNodesWithLabelFailsIfKeyIsEmpty() {
+	client := s.createClient()
+	nodes, err := client.GetNodesWithLabel("", "VALUE")
+	s.EqualError(err, "empty label key")
+	s.Nil(nodes)
+}
+
+func (s *ClientSuite) TestGetNodesWithLabelFailsToLoadClient() {
+	client := s.createDefectiveClient("")
+	nodes, err := client.GetNodesWithLabel("KEY", "VALUE")
+	s.AssertErrorContains(err, "unable to get clientset")
+	s.Nil(nodes)
+}
+
+func (s *ClientSuite) TestGetNodesWithLabelFailsIfGetsErrorFromAPIServer() {
+	s.mockClientset.EXPECT().CoreV1().Return(s.mockCoreV1).Times(1)
+	s.mockCoreV1.EXPECT().Nodes().Return(s.mockNodes).Times(1)
+	s.mockNodes.EXPECT().List(metav1.ListOptions{LabelSelector: "KEY=VALUE"}).Return(nil, errors.New("an error"))
+
+	client := s.createClient()
+	nodes, err := client.GetNodesWithLabel("KEY", "VALUE")
+	s.EqualError(err, "unable to query nodes API: an error")
+	s.Nil(nodes)
+}
+
+func (s *ClientSuite) TestGetNodesWithLabelSucceeds() {
+	expectedNodes := []v1.Node{*createNode("NODENAME")}
+	s.mockClientset.EXPECT().CoreV1().Return(s.mockCoreV1).Times(1)
+	s.mockCoreV1.EXPECT().Nodes().Return(s.mockNodes).Times(1)
+	s.mockNodes.EXPECT().List(metav1.ListOptions{LabelSelector: "KEY=VALUE"}).Return(&v1.NodeList{Items: expectedNodes}, nil)
+
+	client := s.createClient()
+	nodes, err := client.GetNodesWithLabel("KEY", "VALUE")
+	s.NoError(err)
+	s.Equal(expectedNodes, nodes)
+}
+
 func (s *ClientSuite) TestValidateTokenFailsToLoadClient() {
 	client := s.createDefectiveClient("")
 	status, err := client.ValidateToken(testToken, []string{"aud1", "aud2"})
@@ -13,8 +13,10 @@ import (
 )
 
 const (
-	k8sPodNameKey = "authentication.kubernetes.io/pod-name"
-	k8sPodUIDKey  = "authentication.kubernetes.io/pod-uid"
+	k8sPodNameKey  = "authentication.kubernetes.io/pod-name"
+	k8sPodUIDKey   = "authentication.kubernetes.io/pod-uid"
+	k8sNodeNameKey = "authentication.kubernetes.io/node-name"
+	k8sNodeUIDKey  = "authentication.kubernetes.io/node-uid"
 )
 
 // SATClaims represents claims in a service account token, for example:
@@ -156,3 +158,25 @@ func GetPodUIDFromTokenStatus(tokenStatus *authv1.TokenReviewStatus) (string, er
 
 	return podUID[0], nil
 }
+
+// GetNodeFromTokenStatus extracts the name and UID of the node a token is
+// bound to from a tokenReviewStatus type. Empty strings are returned if the
+// token is not bound to a node. Recent Kubernetes versions bind projected
+// service account tokens to the node of their pod.
+func GetNodeFromTokenStatus(tokenStatus *authv1.TokenReviewStatus) (string, string, error) {
+	nodeName, hasName := tokenStatus.User.Extra[k8sNodeNameKey]
+	nodeUID, hasUID := tokenStatus.User.Extra[k8sNodeUIDKey]
+	if !hasName && !hasUID {
+		return "", "", nil
+	}
+
+	if len(nodeName) != 1 || nodeName[0] == "" {
+		return "", "", errors.New("expected 1 non-empty node name")
+	}
+
+	if len(nodeUID) != 1 || nodeUID[0] == "" {
+		return "", "", errors.New("expected 1 non-empty node UID")
+	}
+
+	return nodeName[0], nodeUID[0], nil
+}
@@ -193,3 +193,43 @@ func createTokenStatusWithExtraValues(values map[string]authv1.ExtraValue) *auth
 		},
 	}
 }
+
+func TestGetNodeFromTokenStatusWithoutNode(t *testing.T) {
+	status := createTokenStatusWithExtraValues(make(map[string]authv1.ExtraValue))
+
+	nodeName, nodeUID, err := GetNodeFromTokenStatus(status)
+	assert.NoError(t, err)
+	assert.Empty(t, nodeName)
+	assert.Empty(t, nodeUID)
+}
+
+func TestGetNodeFromTokenStatusFailsIfNodeUIDIsMissing(t *testing.T) {
+	values := make(map[string]authv1.ExtraValue)
+	values[k8sNodeNameKey] = authv1.ExtraValue([]string{"NODE-NAME"})
+	status := createTokenStatusWithExtraValues(values)
+
+	_, _, err := GetNodeFromTokenStatus(status)
+	assert.EqualError(t, err, "expected 1 non-empty node UID")
+}
+
+func TestGetNodeFromTokenStatusFailsIfMoreThanOneNodeNameExists(t *testing.T) {
+	values := make(map[string]authv1.ExtraValue)
+	values[k8sNodeNameKey] = authv1.ExtraValue([]string{"NODE-NAME-1", "NODE-NAME-2"})
+	values[k8sNodeUIDKey] = authv1.ExtraValue([]string{"NODE-UID"})
+	status := createTokenStatusWithExtraValues(values)
+
+	_, _, err := GetNodeFromTokenStatus(status)
+	assert.EqualError(t, err, "expected 1 non-empty node name")
+}
+
+func TestGetNodeFromTokenStatusSucceeds(t *testing.T) {
+	values := make(map[string]authv1.ExtraValue)
+	values[k8sNodeNameKey] = authv1.ExtraValue([]string{"NODE-NAME"})
+	values[k8sNodeUIDKey] = authv1.ExtraValue([]string{"NODE-UID"})
+	status := createTokenStatusWithExtraValues(values)
+
+	nodeName, nodeUID, err := GetNodeFromTokenStatus(status)
+	assert.NoError(t, err)
+	assert.Equal(t, "NODE-NAME", nodeName)
+	assert.Equal(t, "NODE-UID", nodeUID)
+}
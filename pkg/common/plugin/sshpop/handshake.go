@@ -13,6 +13,7 @@ import (
 	"text/template"
 
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/proto/spire/common"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -198,6 +199,15 @@ func (s *ServerHandshake) AgentID() (string, error) {
 	return makeAgentID(s.s.trustDomain, s.s.agentPathTemplate, s.cert, s.hostname)
 }
 
+// Selectors returns selectors for the valid principals and key ID of the
+// verified host certificate.
+func (s *ServerHandshake) Selectors() ([]*common.Selector, error) {
+	if s.state != stateChallengeVerified {
+		return nil, Errorf("server must verify the challenge response to produce selectors")
+	}
+	return buildSelectors(s.cert), nil
+}
+
 func newNonce() ([]byte, error) {
 	b := make([]byte, nonceLen)
 	if _, err := rand.Read(b); err != nil {
@@ -234,6 +244,21 @@ func makeAgentID(trustDomain string, agentPathTemplate *template.Template, cert
 	return idutil.AgentURI(trustDomain, agentPath.String()).String(), nil
 }
 
+func buildSelectors(cert *ssh.Certificate) []*common.Selector {
+	var selectors []*common.Selector
+	if cert.KeyId != "" {
+		selectors = append(selectors, &common.Selector{
+			Type: PluginName, Value: "key_id:" + cert.KeyId,
+		})
+	}
+	for _, principal := range cert.ValidPrincipals {
+		selectors = append(selectors, &common.Selector{
+			Type: PluginName, Value: "principal:" + principal,
+		})
+	}
+	return selectors
+}
+
 // urlSafeSSHFingerprintSHA256 is a modified version of ssh.FingerprintSHA256
 // that returns an unpadded, url-safe version of the fingerprint.
 func urlSafeSSHFingerprintSHA256(pubKey ssh.PublicKey) string {
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"text/template"
 
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/ssh"
 )
@@ -112,6 +113,28 @@ func TestServerSpiffeID(t *testing.T) {
 	require.Equal(t, "spiffe://foo.local/spire/agent/static/ec2abcdef-uswest1", spiffeid)
 }
 
+func TestServerSelectors(t *testing.T) {
+	tt := newTest(t, principal("ec2abcdef-uswest1"), principal("ec2abcdef-uswest1.test.internal"), func(cert *ssh.Certificate) {
+		cert.KeyId = "ec2abcdef"
+	})
+
+	s := &ServerHandshake{
+		s:    &Server{},
+		cert: tt.Certificate,
+	}
+	_, err := s.Selectors()
+	require.EqualError(t, err, "sshpop: server must verify the challenge response to produce selectors")
+
+	s.state = stateChallengeVerified
+	selectors, err := s.Selectors()
+	require.NoError(t, err)
+	require.Equal(t, []*common.Selector{
+		{Type: "sshpop", Value: "key_id:ec2abcdef"},
+		{Type: "sshpop", Value: "principal:ec2abcdef-uswest1"},
+		{Type: "sshpop", Value: "principal:ec2abcdef-uswest1.test.internal"},
+	}, selectors)
+}
+
 func newTestHandshake(t *testing.T) (*ClientHandshake, *ServerHandshake) {
 	tt := newTest(t, principal("ec2abcdef-uswest1.test.internal"))
 	trustDomain := "foo.local"
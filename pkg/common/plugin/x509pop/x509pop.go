@@ -30,9 +30,10 @@ var DefaultAgentPathTemplate = template.Must(template.New("agent-svid").Parse("{
 
 type agentPathTemplateData struct {
 	*x509.Certificate
-	Fingerprint string
-	PluginName  string
-	TrustDomain string
+	Fingerprint     string
+	PluginName      string
+	SerialNumberHex string
+	TrustDomain     string
 }
 
 type AttestationData struct {
@@ -268,9 +269,11 @@ func Fingerprint(cert *x509.Certificate) string {
 func MakeSpiffeID(trustDomain string, agentPathTemplate *template.Template, cert *x509.Certificate) (string, error) {
 	var agentPath bytes.Buffer
 	if err := agentPathTemplate.Execute(&agentPath, agentPathTemplateData{
-		Certificate: cert,
-		PluginName:  PluginName,
-		Fingerprint: Fingerprint(cert),
+		Certificate:     cert,
+		PluginName:      PluginName,
+		Fingerprint:     Fingerprint(cert),
+		SerialNumberHex: serialNumberHex(cert),
+		TrustDomain:     trustDomain,
 	}); err != nil {
 		return "", err
 	}
@@ -278,6 +281,15 @@ func MakeSpiffeID(trustDomain string, agentPathTemplate *template.Template, cert
 	return idutil.AgentURI(trustDomain, agentPath.String()).String(), nil
 }
 
+// serialNumberHex returns the certificate serial number as a lowercase hex
+// string, or an empty string if the certificate has no serial number.
+func serialNumberHex(cert *x509.Certificate) string {
+	if cert.SerialNumber == nil {
+		return ""
+	}
+	return cert.SerialNumber.Text(16)
+}
+
 func generateNonce() ([]byte, error) {
 	b := make([]byte, nonceLen)
 	if _, err := rand.Read(b); err != nil {
@@ -145,6 +145,21 @@ func TestMakeSPIFFEID(t *testing.T) {
 			template:     template.Must(template.New("test").Parse("foo/{{ .Subject.CommonName }}")),
 			expectSPIFFE: "spiffe://example.org/spire/agent/foo/test-cert",
 		},
+		{
+			desc:         "custom template with subject alternative names",
+			template:     template.Must(template.New("test").Parse("san/{{ index .DNSNames 0 }}")),
+			expectSPIFFE: "spiffe://example.org/spire/agent/san/node1.example.org",
+		},
+		{
+			desc:         "custom template with serial number",
+			template:     template.Must(template.New("test").Parse("serial/{{ .SerialNumberHex }}")),
+			expectSPIFFE: "spiffe://example.org/spire/agent/serial/3ade68b1",
+		},
+		{
+			desc:         "custom template with trust domain",
+			template:     template.Must(template.New("test").Parse("{{ .TrustDomain }}/{{ .Subject.CommonName }}")),
+			expectSPIFFE: "spiffe://example.org/spire/agent/example.org/test-cert",
+		},
 		{
 			desc:      "custom template with nonexistant fields",
 			template:  template.Must(template.New("test").Parse("{{ .Foo }}")),
@@ -159,6 +174,8 @@ func TestMakeSPIFFEID(t *testing.T) {
 				Subject: pkix.Name{
 					CommonName: "test-cert",
 				},
+				DNSNames:     []string{"node1.example.org"},
+				SerialNumber: big.NewInt(0x3ade68b1),
 			}
 			spiffeid, err := MakeSpiffeID("example.org", tt.template, cert)
 			if tt.expectErr != "" {
@@ -0,0 +1,45 @@
+package profiling
+
+import (
+	_ "expvar" // import registers /debug/vars on DefaultServeMux
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+)
+
+// Serve serves the profiling endpoints (pprof, expvar and gRPC traces
+// registered on the server handler) over the unix socket at socketPath, or
+// on the server address when no socket path is set. It blocks until the
+// server is shut down.
+func Serve(server *http.Server, socketPath string) error {
+	if socketPath == "" {
+		return server.ListenAndServe()
+	}
+
+	l, err := ListenSocket(socketPath)
+	if err != nil {
+		return err
+	}
+	return server.Serve(l)
+}
+
+// ListenSocket listens on the unix socket at path. Profiles expose the
+// memory of the process, so access to the socket is restricted to the user
+// the process runs as, from the moment the socket is created.
+func ListenSocket(path string) (net.Listener, error) {
+	// Remove the socket left behind by a previous run, but never anything
+	// else that happens to be at the path
+	switch info, err := os.Lstat(path); {
+	case err == nil && info.Mode()&os.ModeSocket == 0:
+		return nil, fmt.Errorf("refusing to replace %q: not a socket", path)
+	case err == nil:
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+
+	return listenPrivateSocket(path)
+}
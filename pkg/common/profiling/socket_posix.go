@@ -0,0 +1,54 @@
+// +build !windows
+
+package profiling
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// listenPrivateSocket listens on the unix socket at path. The socket is
+// created inside a directory only the user the process runs as can access,
+// restricted to that user and only then moved to path, so it is never
+// accessible to others.
+func listenPrivateSocket(path string) (net.Listener, error) {
+	dir, err := ioutil.TempDir(filepath.Dir(path), ".profiling")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	tmpPath := filepath.Join(dir, "sock")
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmpPath, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	// The socket is moved, so it is unlinked from its final path on close
+	l.SetUnlinkOnClose(false)
+
+	if err := os.Chmod(tmpPath, 0600); err != nil {
+		l.Close()
+		return nil, err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		l.Close()
+		return nil, err
+	}
+	return &unlinkListener{Listener: l, path: path}, nil
+}
+
+// unlinkListener removes the socket at path when the listener is closed.
+type unlinkListener struct {
+	net.Listener
+	path string
+}
+
+func (l *unlinkListener) Close() error {
+	err := l.Listener.Close()
+	if rmErr := os.Remove(l.path); err == nil && rmErr != nil && !os.IsNotExist(rmErr) {
+		err = rmErr
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+// +build !windows
+
+package profiling
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "profiling")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "profiling.sock")
+
+	// Anything else than a socket is never replaced
+	require.NoError(t, ioutil.WriteFile(path, nil, 0644))
+	_, err = ListenSocket(path)
+	require.EqualError(t, err, fmt.Sprintf("refusing to replace %q: not a socket", path))
+	require.NoError(t, os.Remove(path))
+
+	// A socket left behind by a previous run is replaced
+	previous, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	previous.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, previous.Close())
+
+	l, err := ListenSocket(path)
+	require.NoError(t, err)
+	defer l.Close()
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeSocket, info.Mode()&os.ModeSocket)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	server := &http.Server{Handler: http.DefaultServeMux}
+	go func() { _ = server.Serve(l) }()
+	defer server.Close()
+
+	client := http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		},
+	}
+	resp, err := client.Get("http://localhost/debug/vars")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The private directory the socket was created in is not left behind
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	if assert.Len(t, files, 1) {
+		assert.Equal(t, "profiling.sock", files[0].Name())
+	}
+
+	// The socket is removed when the listener is closed
+	require.NoError(t, l.Close())
+	_, err = os.Lstat(path)
+	assert.True(t, os.IsNotExist(err), "socket should be removed: %v", err)
+}
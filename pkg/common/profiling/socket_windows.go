@@ -0,0 +1,12 @@
+// +build windows
+
+package profiling
+
+import "net"
+
+// listenPrivateSocket listens on the unix socket at path. Windows has no
+// unix file modes: access to the socket is restricted by the ACLs of its
+// directory.
+func listenPrivateSocket(path string) (net.Listener, error) {
+	return net.Listen("unix", path)
+}
@@ -7,10 +7,15 @@ import (
 	"github.com/spiffe/spire/pkg/agent/client"
 )
 
+// DefaultRenewalThreshold is the fraction of the lifetime of an SVID after
+// which it is rotated, unless a different threshold is given.
+const DefaultRenewalThreshold = 0.5
+
 // ShouldRotateX509 determines if a given SVID should be rotated, based
-// on presented current time, and the certificate's expiration.
-func ShouldRotateX509(now time.Time, cert *x509.Certificate) bool {
-	return shouldRotate(now, cert.NotBefore, cert.NotAfter)
+// on presented current time, the certificate's expiration and the renewal
+// threshold. A zero threshold means DefaultRenewalThreshold.
+func ShouldRotateX509(now time.Time, cert *x509.Certificate, threshold float64) bool {
+	return shouldRotate(now, cert.NotBefore, cert.NotAfter, threshold)
 }
 
 // X509Expired returns true if the given X509 cert has expired
@@ -19,16 +24,17 @@ func X509Expired(now time.Time, cert *x509.Certificate) bool {
 }
 
 // JWTSVIDExpiresSoon determines if the given JWT SVID should be rotated
-// based on presented current time, the JWT's expiration.
+// based on presented current time, the JWT's expiration and the renewal
+// threshold. A zero threshold means DefaultRenewalThreshold.
 // Also returns true if the JWT is already expired.
-func JWTSVIDExpiresSoon(svid *client.JWTSVID, now time.Time) bool {
+func JWTSVIDExpiresSoon(svid *client.JWTSVID, now time.Time, threshold float64) bool {
 	if JWTSVIDExpired(svid, now) {
 		return true
 	}
 
-	// if the SVID has less than half of its lifetime left, consider it
-	// as expiring soon
-	return shouldRotate(now, svid.IssuedAt, svid.ExpiresAt)
+	// if the SVID is past the renewal threshold of its lifetime, consider
+	// it as expiring soon
+	return shouldRotate(now, svid.IssuedAt, svid.ExpiresAt, threshold)
 }
 
 // JWTSVIDExpired returns true if the given SVID is expired.
@@ -36,8 +42,17 @@ func JWTSVIDExpired(svid *client.JWTSVID, now time.Time) bool {
 	return !now.Before(svid.ExpiresAt)
 }
 
-func shouldRotate(now, beginTime, expiryTime time.Time) bool {
+// ValidRenewalThreshold returns true if the renewal threshold is a fraction
+// of the lifetime strictly between 0 and 1.
+func ValidRenewalThreshold(threshold float64) bool {
+	return threshold > 0 && threshold < 1
+}
+
+func shouldRotate(now, beginTime, expiryTime time.Time, threshold float64) bool {
+	if threshold == 0 {
+		threshold = DefaultRenewalThreshold
+	}
 	ttl := expiryTime.Sub(now)
 	lifetime := expiryTime.Sub(beginTime)
-	return ttl <= lifetime/2
+	return ttl <= lifetime-time.Duration(float64(lifetime)*threshold)
 }
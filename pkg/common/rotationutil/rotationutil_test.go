@@ -20,7 +20,7 @@ func TestShouldRotateX509(t *testing.T) {
 	require.NoError(t, err)
 
 	// Cert is brand new
-	assert.False(t, ShouldRotateX509(mockClk.Now(), goodCert))
+	assert.False(t, ShouldRotateX509(mockClk.Now(), goodCert, 0))
 
 	// Cert that's almost expired
 	temp.NotBefore = mockClk.Now().Add(-1 * time.Hour)
@@ -28,7 +28,29 @@ func TestShouldRotateX509(t *testing.T) {
 	badCert, _, err := util.SelfSign(temp)
 	require.NoError(t, err)
 
-	assert.True(t, ShouldRotateX509(mockClk.Now(), badCert))
+	assert.True(t, ShouldRotateX509(mockClk.Now(), badCert, 0))
+}
+
+func TestShouldRotateX509WithThreshold(t *testing.T) {
+	// Cert that's valid for 1hr
+	mockClk := clock.NewMock(t)
+	temp, err := util.NewSVIDTemplate(mockClk, "spiffe://example.org/test")
+	require.NoError(t, err)
+	cert, _, err := util.SelfSign(temp)
+	require.NoError(t, err)
+
+	// Default threshold is half of the lifetime
+	assert.False(t, ShouldRotateX509(mockClk.Now().Add(29*time.Minute), cert, 0))
+	assert.True(t, ShouldRotateX509(mockClk.Now().Add(30*time.Minute), cert, 0))
+	assert.True(t, ShouldRotateX509(mockClk.Now().Add(30*time.Minute), cert, DefaultRenewalThreshold))
+
+	// Rotated sooner with a lower threshold
+	assert.False(t, ShouldRotateX509(mockClk.Now().Add(14*time.Minute), cert, 0.25))
+	assert.True(t, ShouldRotateX509(mockClk.Now().Add(15*time.Minute), cert, 0.25))
+
+	// Rotated later with a higher threshold
+	assert.False(t, ShouldRotateX509(mockClk.Now().Add(44*time.Minute), cert, 0.75))
+	assert.True(t, ShouldRotateX509(mockClk.Now().Add(45*time.Minute), cert, 0.75))
 }
 
 func TestX509Expired(t *testing.T) {
@@ -68,7 +90,7 @@ func TestJWTSVIDExpiresSoon(t *testing.T) {
 	}
 
 	// JWT is brand new
-	assert.False(t, JWTSVIDExpiresSoon(goodJWT, mockClk.Now()))
+	assert.False(t, JWTSVIDExpiresSoon(goodJWT, mockClk.Now(), 0))
 
 	// JWT that's almost expired
 	badJWT := &client.JWTSVID{
@@ -76,7 +98,7 @@ func TestJWTSVIDExpiresSoon(t *testing.T) {
 		ExpiresAt: mockClk.Now().Add(1 * time.Minute),
 	}
 
-	assert.True(t, JWTSVIDExpiresSoon(badJWT, mockClk.Now()))
+	assert.True(t, JWTSVIDExpiresSoon(badJWT, mockClk.Now(), 0))
 
 	// JWT that is expired
 	expiredJWT := &client.JWTSVID{
@@ -84,5 +106,22 @@ func TestJWTSVIDExpiresSoon(t *testing.T) {
 		ExpiresAt: mockClk.Now().Add(-30 * time.Minute),
 	}
 
-	assert.True(t, JWTSVIDExpiresSoon(expiredJWT, mockClk.Now()))
+	assert.True(t, JWTSVIDExpiresSoon(expiredJWT, mockClk.Now(), 0))
+
+	// JWT that is past a quarter of its lifetime
+	jwt := &client.JWTSVID{
+		IssuedAt:  mockClk.Now().Add(-20 * time.Minute),
+		ExpiresAt: mockClk.Now().Add(40 * time.Minute),
+	}
+
+	assert.False(t, JWTSVIDExpiresSoon(jwt, mockClk.Now(), 0))
+	assert.True(t, JWTSVIDExpiresSoon(jwt, mockClk.Now(), 0.25))
+}
+
+func TestValidRenewalThreshold(t *testing.T) {
+	assert.True(t, ValidRenewalThreshold(DefaultRenewalThreshold))
+	assert.True(t, ValidRenewalThreshold(0.9))
+	assert.False(t, ValidRenewalThreshold(0))
+	assert.False(t, ValidRenewalThreshold(1))
+	assert.False(t, ValidRenewalThreshold(-0.5))
 }
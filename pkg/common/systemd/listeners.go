@@ -0,0 +1,117 @@
+package systemd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+)
+
+const (
+	listenPIDEnv     = "LISTEN_PID"
+	listenFDsEnv     = "LISTEN_FDS"
+	listenFDNamesEnv = "LISTEN_FDNAMES"
+
+	// listenFDsStart is the first file descriptor passed by systemd socket
+	// activation
+	listenFDsStart = 3
+)
+
+var (
+	inheritedOnce sync.Once
+	inheritedMtx  sync.Mutex
+	inherited     []net.Listener
+	inheritedErr  error
+)
+
+// InheritedListener returns the listener passed to the process by systemd
+// socket activation that is bound to addr, or nil if there is none. Each
+// inherited listener is returned at most once, so that the caller owns it.
+func InheritedListener(addr net.Addr) (net.Listener, error) {
+	inheritedOnce.Do(func() {
+		inherited, inheritedErr = inheritedListeners(listenFDsStart)
+	})
+	if inheritedErr != nil {
+		return nil, inheritedErr
+	}
+
+	inheritedMtx.Lock()
+	defer inheritedMtx.Unlock()
+	for i, l := range inherited {
+		if addrsMatch(l.Addr(), addr) {
+			inherited = append(inherited[:i], inherited[i+1:]...)
+			return l, nil
+		}
+	}
+	return nil, nil
+}
+
+// inheritedListeners takes ownership of the sockets passed to the process
+// by systemd, starting at file descriptor firstFD. The socket activation
+// environment is cleared so that it doesn't leak into child processes, such
+// as external plugins.
+func inheritedListeners(firstFD int) ([]net.Listener, error) {
+	pidValue := os.Getenv(listenPIDEnv)
+	fdsValue := os.Getenv(listenFDsEnv)
+	if pidValue == "" || fdsValue == "" {
+		return nil, nil
+	}
+	defer func() {
+		os.Unsetenv(listenPIDEnv)
+		os.Unsetenv(listenFDsEnv)
+		os.Unsetenv(listenFDNamesEnv)
+	}()
+
+	pid, err := strconv.Atoi(pidValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %v", listenPIDEnv, pidValue, err)
+	}
+	if pid != os.Getpid() {
+		return nil, nil
+	}
+
+	fds, err := strconv.Atoi(fdsValue)
+	if err != nil || fds < 0 {
+		return nil, fmt.Errorf("invalid %s %q", listenFDsEnv, fdsValue)
+	}
+
+	var listeners []net.Listener
+	for fd := firstFD; fd < firstFD+fds; fd++ {
+		f := os.NewFile(uintptr(fd), "systemd-listen-fd-"+strconv.Itoa(fd))
+		// FileListener duplicates the descriptor, which leaves the original
+		// one to be closed whether or not it is a listening socket.
+		l, err := net.FileListener(f)
+		f.Close()
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return nil, fmt.Errorf("inherited file descriptor %d is not a listening socket: %v", fd, err)
+		}
+		listeners = append(listeners, l)
+	}
+	return listeners, nil
+}
+
+// addrsMatch returns true if the address a socket is bound to matches the
+// configured address. TCP addresses match if they have the same port and
+// either the same IP address or are both bound to all interfaces.
+func addrsMatch(bound, configured net.Addr) bool {
+	switch configured := configured.(type) {
+	case *net.TCPAddr:
+		bound, ok := bound.(*net.TCPAddr)
+		if !ok || bound.Port != configured.Port {
+			return false
+		}
+		if bound.IP.IsUnspecified() || len(bound.IP) == 0 {
+			return configured.IP.IsUnspecified() || len(configured.IP) == 0
+		}
+		return bound.IP.Equal(configured.IP)
+	case *net.UnixAddr:
+		bound, ok := bound.(*net.UnixAddr)
+		return ok && bound.Name == configured.Name
+	default:
+		return bound.Network() == configured.Network() && bound.String() == configured.String()
+	}
+}
@@ -0,0 +1,174 @@
+// +build !windows
+
+package systemd
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInheritedListeners(t *testing.T) {
+	pid := strconv.Itoa(os.Getpid())
+
+	t.Run("not socket activated", func(t *testing.T) {
+		defer setEnv(t, listenPIDEnv, "")()
+		defer setEnv(t, listenFDsEnv, "")()
+
+		listeners, err := inheritedListeners(listenFDsStart)
+		require.NoError(t, err)
+		require.Empty(t, listeners)
+	})
+
+	t.Run("activated for another process", func(t *testing.T) {
+		defer setEnv(t, listenPIDEnv, "1")()
+		defer setEnv(t, listenFDsEnv, "1")()
+
+		listeners, err := inheritedListeners(listenFDsStart)
+		require.NoError(t, err)
+		require.Empty(t, listeners)
+		requireEnvCleared(t)
+	})
+
+	t.Run("invalid file descriptor count", func(t *testing.T) {
+		defer setEnv(t, listenPIDEnv, pid)()
+		defer setEnv(t, listenFDsEnv, "many")()
+
+		_, err := inheritedListeners(listenFDsStart)
+		require.EqualError(t, err, `invalid LISTEN_FDS "many"`)
+		requireEnvCleared(t)
+	})
+
+	t.Run("TCP socket", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer l.Close()
+		f, err := l.(*net.TCPListener).File()
+		require.NoError(t, err)
+		defer f.Close()
+		fd, err := syscall.Dup(int(f.Fd()))
+		require.NoError(t, err)
+
+		defer setEnv(t, listenPIDEnv, pid)()
+		defer setEnv(t, listenFDsEnv, "1")()
+		defer setEnv(t, listenFDNamesEnv, "spire-server")()
+
+		listeners, err := inheritedListeners(fd)
+		require.NoError(t, err)
+		require.Len(t, listeners, 1)
+		defer listeners[0].Close()
+		require.True(t, addrsMatch(listeners[0].Addr(), l.Addr()))
+		requireEnvCleared(t)
+	})
+
+	t.Run("UDS", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "systemd-listeners")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		addr := &net.UnixAddr{Name: filepath.Join(dir, "api.sock"), Net: "unix"}
+		l, err := net.ListenUnix(addr.Net, addr)
+		require.NoError(t, err)
+		defer l.Close()
+		f, err := l.File()
+		require.NoError(t, err)
+		defer f.Close()
+		fd, err := syscall.Dup(int(f.Fd()))
+		require.NoError(t, err)
+
+		defer setEnv(t, listenPIDEnv, pid)()
+		defer setEnv(t, listenFDsEnv, "1")()
+
+		listeners, err := inheritedListeners(fd)
+		require.NoError(t, err)
+		require.Len(t, listeners, 1)
+		defer listeners[0].Close()
+		require.True(t, addrsMatch(listeners[0].Addr(), addr))
+	})
+
+	t.Run("not a socket", func(t *testing.T) {
+		f, err := ioutil.TempFile("", "systemd-listeners")
+		require.NoError(t, err)
+		defer os.Remove(f.Name())
+		defer f.Close()
+		fd, err := syscall.Dup(int(f.Fd()))
+		require.NoError(t, err)
+
+		defer setEnv(t, listenPIDEnv, pid)()
+		defer setEnv(t, listenFDsEnv, "1")()
+
+		_, err = inheritedListeners(fd)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not a listening socket")
+	})
+}
+
+func TestAddrsMatch(t *testing.T) {
+	tcpAddr := func(ip string, port int) *net.TCPAddr {
+		return &net.TCPAddr{IP: net.ParseIP(ip), Port: port}
+	}
+
+	for _, tt := range []struct {
+		name       string
+		bound      net.Addr
+		configured net.Addr
+		match      bool
+	}{
+		{
+			name:       "same TCP address",
+			bound:      tcpAddr("127.0.0.1", 8081),
+			configured: tcpAddr("127.0.0.1", 8081),
+			match:      true,
+		},
+		{
+			name:       "TCP address on all interfaces",
+			bound:      tcpAddr("::", 8081),
+			configured: tcpAddr("0.0.0.0", 8081),
+			match:      true,
+		},
+		{
+			name:       "TCP address with another port",
+			bound:      tcpAddr("127.0.0.1", 8081),
+			configured: tcpAddr("127.0.0.1", 8082),
+		},
+		{
+			name:       "TCP address with another IP",
+			bound:      tcpAddr("::", 8081),
+			configured: tcpAddr("127.0.0.1", 8081),
+		},
+		{
+			name:       "same UDS",
+			bound:      &net.UnixAddr{Name: "/run/spire/api.sock", Net: "unix"},
+			configured: &net.UnixAddr{Name: "/run/spire/api.sock", Net: "unix"},
+			match:      true,
+		},
+		{
+			name:       "another UDS",
+			bound:      &net.UnixAddr{Name: "/run/spire/api.sock", Net: "unix"},
+			configured: &net.UnixAddr{Name: "/run/spire/other.sock", Net: "unix"},
+		},
+		{
+			name:       "UDS and TCP address",
+			bound:      &net.UnixAddr{Name: "/run/spire/api.sock", Net: "unix"},
+			configured: tcpAddr("127.0.0.1", 8081),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.match, addrsMatch(tt.bound, tt.configured))
+		})
+	}
+}
+
+func requireEnvCleared(t *testing.T) {
+	for _, key := range []string{listenPIDEnv, listenFDsEnv, listenFDNamesEnv} {
+		_, ok := os.LookupEnv(key)
+		require.False(t, ok, "%s should be cleared", key)
+	}
+}
@@ -0,0 +1,124 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+const (
+	// Ready tells the service manager that the service finished starting up
+	Ready = "READY=1"
+
+	// Stopping tells the service manager that the service is shutting down
+	Stopping = "STOPPING=1"
+
+	// Watchdog keeps the service alive when the systemd watchdog is enabled
+	Watchdog = "WATCHDOG=1"
+
+	notifySocketEnv = "NOTIFY_SOCKET"
+	watchdogUSecEnv = "WATCHDOG_USEC"
+	watchdogPIDEnv  = "WATCHDOG_PID"
+)
+
+// Notify sends the state to the service manager through the socket named by
+// the NOTIFY_SOCKET environment variable. It returns false, without error,
+// if the process is not supervised by systemd.
+func Notify(state string) (bool, error) {
+	socketAddr := os.Getenv(notifySocketEnv)
+	if socketAddr == "" {
+		return false, nil
+	}
+
+	// Abstract sockets are prefixed with '@', which the net package
+	// translates into the leading NUL byte expected by the kernel.
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socketAddr, Net: "unixgram"})
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// WatchdogInterval returns the interval within which the service manager
+// expects watchdog keep-alives from the process, or zero if the watchdog is
+// not enabled for the process.
+func WatchdogInterval() (time.Duration, error) {
+	usecValue := os.Getenv(watchdogUSecEnv)
+	if usecValue == "" {
+		return 0, nil
+	}
+
+	if pidValue := os.Getenv(watchdogPIDEnv); pidValue != "" {
+		pid, err := strconv.Atoi(pidValue)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q: %v", watchdogPIDEnv, pidValue, err)
+		}
+		if pid != os.Getpid() {
+			return 0, nil
+		}
+	}
+
+	usec, err := strconv.ParseUint(usecValue, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %v", watchdogUSecEnv, usecValue, err)
+	}
+	if usec == 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be greater than zero", watchdogUSecEnv, usecValue)
+	}
+	return time.Duration(usec) * time.Microsecond, nil
+}
+
+// Supervise notifies the service manager that the process is ready and, if
+// the watchdog is enabled, sends keep-alives at half the watchdog interval
+// until the context is done. The service manager is then notified that the
+// process is stopping. Supervise returns right away if the process is not
+// supervised by systemd. Failures to notify the service manager are logged
+// but not returned, since they don't prevent the process from running.
+func Supervise(ctx context.Context, log logrus.FieldLogger) error {
+	notified, err := Notify(Ready)
+	switch {
+	case err != nil:
+		log.WithError(err).Warn("Unable to notify systemd of readiness")
+		return nil
+	case !notified:
+		return nil
+	}
+	log.Debug("Notified systemd of readiness")
+
+	var keepAlive <-chan time.Time
+	interval, err := WatchdogInterval()
+	switch {
+	case err != nil:
+		log.WithError(err).Warn("Ignoring the systemd watchdog")
+	case interval > 0:
+		ticker := time.NewTicker(interval / 2)
+		defer ticker.Stop()
+		keepAlive = ticker.C
+		log.WithField(telemetry.WatchdogInterval, interval).Info("Sending keep-alives to the systemd watchdog")
+	}
+
+	for {
+		select {
+		case <-keepAlive:
+			if _, err := Notify(Watchdog); err != nil {
+				log.WithError(err).Warn("Unable to send keep-alive to the systemd watchdog")
+			}
+		case <-ctx.Done():
+			if _, err := Notify(Stopping); err != nil {
+				log.WithError(err).Warn("Unable to notify systemd of shutdown")
+			}
+			return nil
+		}
+	}
+}
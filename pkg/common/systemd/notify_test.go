@@ -0,0 +1,179 @@
+// +build !windows
+
+package systemd
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotify(t *testing.T) {
+	defer setEnv(t, notifySocketEnv, "")()
+
+	notified, err := Notify(Ready)
+	require.NoError(t, err)
+	require.False(t, notified, "should not notify without a notify socket")
+
+	conn, done := listenNotifySocket(t)
+	defer done()
+
+	notified, err = Notify(Ready)
+	require.NoError(t, err)
+	require.True(t, notified)
+	require.Equal(t, Ready, readState(t, conn))
+
+	defer setEnv(t, notifySocketEnv, filepath.Join(filepath.Dir(conn.LocalAddr().String()), "missing.sock"))()
+	_, err = Notify(Ready)
+	require.Error(t, err)
+}
+
+func TestWatchdogInterval(t *testing.T) {
+	pid := strconv.Itoa(os.Getpid())
+	for _, tt := range []struct {
+		name     string
+		usec     string
+		pid      string
+		interval time.Duration
+		err      string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:     "enabled",
+			usec:     "30000000",
+			interval: 30 * time.Second,
+		},
+		{
+			name:     "enabled for the process",
+			usec:     "30000000",
+			pid:      pid,
+			interval: 30 * time.Second,
+		},
+		{
+			name: "enabled for another process",
+			usec: "30000000",
+			pid:  "1",
+		},
+		{
+			name: "invalid interval",
+			usec: "soon",
+			err:  `invalid WATCHDOG_USEC "soon"`,
+		},
+		{
+			name: "zero interval",
+			usec: "0",
+			err:  `invalid WATCHDOG_USEC "0": must be greater than zero`,
+		},
+		{
+			name: "invalid pid",
+			usec: "30000000",
+			pid:  "me",
+			err:  `invalid WATCHDOG_PID "me"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer setEnv(t, watchdogUSecEnv, tt.usec)()
+			defer setEnv(t, watchdogPIDEnv, tt.pid)()
+
+			interval, err := WatchdogInterval()
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.interval, interval)
+		})
+	}
+}
+
+func TestSupervise(t *testing.T) {
+	conn, done := listenNotifySocket(t)
+	defer done()
+	defer setEnv(t, watchdogUSecEnv, "20000")()
+	defer setEnv(t, watchdogPIDEnv, "")()
+
+	log, _ := test.NewNullLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Supervise(ctx, log)
+	}()
+
+	require.Equal(t, Ready, readState(t, conn))
+	require.Equal(t, Watchdog, readState(t, conn))
+
+	cancel()
+	require.NoError(t, <-errCh)
+	for {
+		if state := readState(t, conn); state != Watchdog {
+			require.Equal(t, Stopping, state)
+			break
+		}
+	}
+}
+
+func TestSuperviseWithoutSystemd(t *testing.T) {
+	defer setEnv(t, notifySocketEnv, "")()
+
+	log, _ := test.NewNullLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, Supervise(ctx, log))
+}
+
+func listenNotifySocket(t *testing.T) (*net.UnixConn, func()) {
+	dir, err := ioutil.TempDir("", "systemd-notify")
+	require.NoError(t, err)
+
+	addr := &net.UnixAddr{Name: filepath.Join(dir, "notify.sock"), Net: "unixgram"}
+	conn, err := net.ListenUnixgram(addr.Net, addr)
+	if err != nil {
+		os.RemoveAll(dir)
+		require.NoError(t, err)
+	}
+	unsetEnv := setEnv(t, notifySocketEnv, addr.Name)
+
+	return conn, func() {
+		unsetEnv()
+		conn.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func readState(t *testing.T, conn *net.UnixConn) string {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, 256)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	return string(buf[:n])
+}
+
+// setEnv sets the environment variable, or unsets it if the value is
+// empty, and returns a function that restores its previous value.
+func setEnv(t *testing.T, key, value string) func() {
+	previous, existed := os.LookupEnv(key)
+	if value == "" {
+		require.NoError(t, os.Unsetenv(key))
+	} else {
+		require.NoError(t, os.Setenv(key, value))
+	}
+	return func() {
+		if existed {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
@@ -21,8 +21,43 @@ func StartManagerFetchSVIDsUpdatesCall(m telemetry.Metrics) *telemetry.CallCount
 	return telemetry.StartCall(m, telemetry.Manager, telemetry.Sync, telemetry.FetchSVIDsUpdates)
 }
 
+// StartManagerSyncCall returns metric for a full synchronization round trip
+// of the agent's synchronization manager with the server
+func StartManagerSyncCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Manager, telemetry.Sync)
+}
+
+// StartCacheManagerRenewJWTSVIDCall returns metric for when agent's cache
+// manager renews a cached JWT-SVID ahead of its expiration
+func StartCacheManagerRenewJWTSVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.CacheManager, telemetry.JWTSVID, telemetry.Rotate)
+}
+
 // End Call Counters
 
+// Counters (literal increments, not call counters)
+
+// IncrCacheManagerJWTSVIDCacheHitCounter indicates a JWT-SVID was served
+// from the agent cache manager JWT-SVID cache
+func IncrCacheManagerJWTSVIDCacheHitCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.CacheManager, telemetry.JWTSVID, telemetry.CacheHit}, 1)
+}
+
+// IncrCacheManagerJWTSVIDCacheMissCounter indicates a JWT-SVID had to be
+// minted by the server because it was missing from, or expiring soon in, the
+// agent cache manager JWT-SVID cache
+func IncrCacheManagerJWTSVIDCacheMissCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.CacheManager, telemetry.JWTSVID, telemetry.CacheMiss}, 1)
+}
+
+// AddCacheManagerJWTSVIDCacheEvictedCounter counts the JWT-SVIDs evicted
+// from the agent cache manager JWT-SVID cache to stay under its size cap
+func AddCacheManagerJWTSVIDCacheEvictedCounter(m telemetry.Metrics, count int) {
+	m.IncrCounter([]string{telemetry.CacheManager, telemetry.JWTSVID, telemetry.Evicted}, float32(count))
+}
+
+// End Counters
+
 // Add Samples (metric on count of some object, entries, event...)
 
 // AddCacheManagerExpiredSVIDsSample count of expiring SVIDs according to
@@ -38,3 +73,49 @@ func AddCacheManagerOutdatedSVIDsSample(m telemetry.Metrics, count float32) {
 }
 
 // End Add Samples
+
+// Gauge (remember previous value set)
+
+// SetCacheManagerOutageGauges sets the duration of the current server outage,
+// and the number of cached SVIDs past their rotation threshold and expired
+// since it started, according to agent cache manager
+func SetCacheManagerOutageGauges(m telemetry.Metrics, seconds float32, expiring, expired int) {
+	m.SetGauge([]string{telemetry.CacheManager, telemetry.Outage, telemetry.Seconds}, seconds)
+	m.SetGauge([]string{telemetry.CacheManager, telemetry.Outage, telemetry.ExpiringSVIDs}, float32(expiring))
+	m.SetGauge([]string{telemetry.CacheManager, telemetry.Outage, telemetry.ExpiredSVIDs}, float32(expired))
+}
+
+// SetManagerSyncBackoffGauges sets the interval, in seconds, until the next
+// synchronization with the server and the number of consecutive failed
+// synchronizations, according to agent manager
+func SetManagerSyncBackoffGauges(m telemetry.Metrics, seconds float32, failures int) {
+	m.SetGauge([]string{telemetry.Manager, telemetry.Sync, telemetry.Backoff, telemetry.Seconds}, seconds)
+	m.SetGauge([]string{telemetry.Manager, telemetry.Sync, telemetry.Backoff, telemetry.ConsecutiveFailures}, float32(failures))
+}
+
+// SetCacheManagerCacheSizeGauges sets the number of cached registration
+// entries and SVIDs, according to agent cache manager
+func SetCacheManagerCacheSizeGauges(m telemetry.Metrics, entries, svids int) {
+	m.SetGauge([]string{telemetry.CacheManager, telemetry.CachedEntries}, float32(entries))
+	m.SetGauge([]string{telemetry.CacheManager, telemetry.CachedSVIDs}, float32(svids))
+}
+
+// SetCacheManagerJWTSVIDCacheSizeGauge sets the number of cached JWT-SVIDs,
+// according to agent cache manager
+func SetCacheManagerJWTSVIDCacheSizeGauge(m telemetry.Metrics, svids int) {
+	m.SetGauge([]string{telemetry.CacheManager, telemetry.CachedJWTSVIDs}, float32(svids))
+}
+
+// SetCacheManagerMinSVIDLifetimeGauge sets the shortest remaining lifetime,
+// in seconds, of the cached workload SVIDs, according to agent cache manager
+func SetCacheManagerMinSVIDLifetimeGauge(m telemetry.Metrics, seconds float32) {
+	m.SetGauge([]string{telemetry.CacheManager, telemetry.MinSVIDLifetime, telemetry.Seconds}, seconds)
+}
+
+// SetAgentSVIDLifetimeGauge sets the remaining lifetime, in seconds, of the
+// agent SVID
+func SetAgentSVIDLifetimeGauge(m telemetry.Metrics, seconds float32) {
+	m.SetGauge([]string{telemetry.AgentSVID, telemetry.RemainingLifetime, telemetry.Seconds}, seconds)
+}
+
+// End Gauge
@@ -13,3 +13,15 @@ func StartNodeAttestorNewSVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
 }
 
 // End Call Counters
+
+// Gauge (remember previous value set)
+
+// SetNodeAttestorBackoffGauges sets the interval, in seconds, until the next
+// attestation attempt and the number of consecutive failed attempts, for
+// the agent node attestor
+func SetNodeAttestorBackoffGauges(m telemetry.Metrics, seconds float32, failures int) {
+	m.SetGauge([]string{telemetry.Node, telemetry.Attestor, telemetry.Backoff, telemetry.Seconds}, seconds)
+	m.SetGauge([]string{telemetry.Node, telemetry.Attestor, telemetry.Backoff, telemetry.ConsecutiveFailures}, float32(failures))
+}
+
+// End Gauge
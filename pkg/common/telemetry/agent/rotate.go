@@ -14,3 +14,15 @@ func StartRotateAgentSVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
 }
 
 // End Call Counters
+
+// Gauge (remember previous value set)
+
+// SetRotateAgentSVIDBackoffGauges sets the interval, in seconds, until the
+// next check of the agent SVID and the number of consecutive failed
+// rotations
+func SetRotateAgentSVIDBackoffGauges(m telemetry.Metrics, seconds float32, failures int) {
+	m.SetGauge([]string{telemetry.AgentSVID, telemetry.Rotate, telemetry.Backoff, telemetry.Seconds}, seconds)
+	m.SetGauge([]string{telemetry.AgentSVID, telemetry.Rotate, telemetry.Backoff, telemetry.ConsecutiveFailures}, float32(failures))
+}
+
+// End Gauge
@@ -48,6 +48,14 @@ func StartFetchX509SVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return cc
 }
 
+// StartFetchX509BundlesCall return metric
+// for agent's Workload API, on fetching the workload's X.509 Bundles
+func StartFetchX509BundlesCall(m telemetry.Metrics) *telemetry.CallCounter {
+	cc := telemetry.StartCall(m, telemetry.WorkloadAPI, telemetry.FetchX509Bundles)
+	cc.AddLabel(telemetry.SVIDType, telemetry.X509)
+	return cc
+}
+
 // End Call Counters
 
 // Counters (literal increments, not call counters)
@@ -75,6 +83,18 @@ func IncrUpdateJWTBundlesCounter(m telemetry.Metrics) {
 	m.IncrCounter([]string{telemetry.WorkloadAPI, telemetry.BundlesUpdate, telemetry.JWT}, 1)
 }
 
+// IncrFetchX509BundlesCounter indicate call to Workload
+// API, on fetching X.509 bundles.
+func IncrFetchX509BundlesCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.WorkloadAPI, telemetry.FetchX509Bundles}, 1)
+}
+
+// IncrUpdateX509BundlesCounter indicate call to Workload
+// API, on updating X.509 bundles
+func IncrUpdateX509BundlesCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.WorkloadAPI, telemetry.BundlesUpdate, telemetry.X509}, 1)
+}
+
 // IncrValidJWTSVIDCounter indicate call to Workload
 // API, on validating JWT SVID. Takes SVID SPIFFE ID and request audience
 func IncrValidJWTSVIDCounter(m telemetry.Metrics, id string, aud string) {
@@ -90,6 +110,14 @@ func IncrValidJWTSVIDErrCounter(m telemetry.Metrics) {
 	m.IncrCounter([]string{telemetry.WorkloadAPI, telemetry.ValidateJWTSVID}, 1)
 }
 
+// IncrThrottledCounter indicate call to Workload
+// API rejected because the caller exceeded its rate limit.
+func IncrThrottledCounter(m telemetry.Metrics, method string) {
+	m.IncrCounterWithLabels([]string{telemetry.WorkloadAPI, telemetry.Throttled}, 1, []telemetry.Label{
+		{Name: telemetry.Method, Value: method},
+	})
+}
+
 // End Counters
 
 // Gauge (remember previous value set)
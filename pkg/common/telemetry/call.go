@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/spiffe/spire/pkg/common/apierror"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -56,6 +57,7 @@ func (c *CallCounter) AddLabel(name, value string) {
 // thread-safe and is intended to be the final call to the CallCounter struct.
 // Emits latency and counter metrics, including adding a Status label according
 // to gRPC code of the given error. If nil error, the code is OK (success).
+// Errors carrying an apierror reason also get a Reason label.
 func (c *CallCounter) Done(errp *error) {
 	if c.done {
 		return
@@ -68,6 +70,11 @@ func (c *CallCounter) Done(errp *error) {
 		code = status.Code(*errp)
 	}
 	c.AddLabel(Status, code.String())
+	if errp != nil {
+		if reason := apierror.Reason(*errp); reason != "" {
+			c.AddLabel(Reason, reason)
+		}
+	}
 
 	c.metrics.IncrCounterWithLabels(key, 1, c.labels)
 	c.metrics.MeasureSinceWithLabels(append(key, ElapsedTime), c.start, c.labels)
@@ -0,0 +1,61 @@
+package telemetry_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/apierror"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCallCounterLabels(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		err    error
+		labels []telemetry.Label
+	}{
+		{
+			name: "success",
+			labels: []telemetry.Label{
+				{Name: telemetry.Status, Value: "OK"},
+			},
+		},
+		{
+			name: "error",
+			err:  errors.New("ohno"),
+			labels: []telemetry.Label{
+				{Name: telemetry.Status, Value: "Unknown"},
+			},
+		},
+		{
+			name: "error with reason",
+			err:  apierror.RateLimitedError(apierror.LimitSigning, "ohno"),
+			labels: []telemetry.Label{
+				{Name: telemetry.Status, Value: "ResourceExhausted"},
+				{Name: telemetry.Reason, Value: apierror.RateLimited},
+			},
+		},
+		{
+			name: "error without reason",
+			err:  apierror.Error(codes.Internal, "", nil, "ohno"),
+			labels: []telemetry.Label{
+				{Name: telemetry.Status, Value: "Internal"},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := fakemetrics.New()
+			counter := telemetry.StartCall(metrics, "foo")
+			counter.Done(&tt.err)
+
+			items := metrics.AllMetrics()
+			require.Len(t, items, 2)
+			require.Equal(t, []string{"foo"}, items[0].Key)
+			require.Equal(t, tt.labels, items[0].Labels)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package common
+
+import (
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// SetPluginHealthyGauge sets the gauge reporting whether a plugin passes its
+// health probe, 1 if it does and 0 otherwise.
+func SetPluginHealthyGauge(m telemetry.Metrics, pluginType, pluginName string, healthy bool) {
+	var val float32
+	if healthy {
+		val = 1
+	}
+	m.SetGaugeWithLabels(
+		[]string{telemetry.Catalog, telemetry.Healthy},
+		val,
+		pluginLabels(pluginType, pluginName))
+}
+
+// IncrPluginRestartCounter indicates a crashed plugin was restarted.
+func IncrPluginRestartCounter(m telemetry.Metrics, pluginType, pluginName string) {
+	m.IncrCounterWithLabels(
+		[]string{telemetry.Catalog, telemetry.Restart},
+		1,
+		pluginLabels(pluginType, pluginName))
+}
+
+func pluginLabels(pluginType, pluginName string) []telemetry.Label {
+	return []telemetry.Label{
+		{Name: telemetry.PluginType, Value: pluginType},
+		{Name: telemetry.PluginName, Value: pluginName},
+	}
+}
@@ -18,6 +18,17 @@ type FileConfig struct {
 	M3         []M3Config        `hcl:"M3"`
 	InMem      *InMem            `hcl:"InMem"`
 
+	// AllowedPrefixes and BlockedPrefixes filter the emitted metrics by the
+	// prefixes of their names, e.g. "node_api" or "node_api.attest".
+	AllowedPrefixes []string `hcl:"allowed_prefixes"`
+	BlockedPrefixes []string `hcl:"blocked_prefixes"`
+
+	// BlockedLabels are label names removed from all metrics.
+	BlockedLabels []string `hcl:"blocked_labels"`
+
+	// Labels are static labels added to all metrics (e.g. cluster, region).
+	Labels map[string]string `hcl:"labels"`
+
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
@@ -27,8 +38,13 @@ type DogStatsdConfig struct {
 }
 
 type PrometheusConfig struct {
-	Host       string   `hcl:"host"`
-	Port       int      `hcl:"port"`
+	Host string `hcl:"host"`
+	Port int    `hcl:"port"`
+
+	// HistogramBuckets are the upper bounds, in milliseconds, of the buckets
+	// of the API latency histograms. Defaults to DefaultHistogramBuckets.
+	HistogramBuckets []float64 `hcl:"histogram_buckets"`
+
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
@@ -0,0 +1,77 @@
+package telemetry
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// metricFilter decides which metrics are emitted based on the prefixes of
+// their names, and holds the static labels added to every metric.
+type metricFilter struct {
+	allowedPrefixes []string
+	blockedPrefixes []string
+	labels          []Label
+}
+
+func newMetricFilter(c FileConfig) (*metricFilter, error) {
+	f := &metricFilter{
+		allowedPrefixes: c.AllowedPrefixes,
+		blockedPrefixes: c.BlockedPrefixes,
+	}
+
+	for name, value := range c.Labels {
+		if name == "" {
+			return nil, errors.New("telemetry label names cannot be empty")
+		}
+		f.labels = append(f.labels, Label{Name: name, Value: value})
+	}
+	sort.Slice(f.labels, func(i, j int) bool {
+		return f.labels[i].Name < f.labels[j].Name
+	})
+	f.labels = SanitizeLabels(f.labels)
+
+	return f, nil
+}
+
+// allow returns whether a metric is emitted. The longest allowed or blocked
+// prefix matching the metric name wins. Metrics not matching any prefix are
+// emitted unless allowed prefixes are configured.
+func (f *metricFilter) allow(key []string) bool {
+	if len(f.allowedPrefixes) == 0 && len(f.blockedPrefixes) == 0 {
+		return true
+	}
+
+	name := strings.Join(key, ".")
+	allowed := len(f.allowedPrefixes) == 0
+	longest := -1
+	for _, prefix := range f.allowedPrefixes {
+		if hasMetricPrefix(name, prefix) && len(prefix) > longest {
+			allowed, longest = true, len(prefix)
+		}
+	}
+	for _, prefix := range f.blockedPrefixes {
+		if hasMetricPrefix(name, prefix) && len(prefix) > longest {
+			allowed, longest = false, len(prefix)
+		}
+	}
+	return allowed
+}
+
+// addLabels returns the labels with the static labels added.
+func (f *metricFilter) addLabels(labels []Label) []Label {
+	if len(f.labels) == 0 {
+		return labels
+	}
+	combined := make([]Label, 0, len(f.labels)+len(labels))
+	combined = append(combined, f.labels...)
+	return append(combined, labels...)
+}
+
+// hasMetricPrefix returns whether prefix matches whole components of the
+// metric name, e.g. "node_api" matches "node_api.attest" but not
+// "node_api_limit".
+func hasMetricPrefix(name, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, ".")
+	return name == prefix || strings.HasPrefix(name, prefix+".")
+}
@@ -0,0 +1,133 @@
+package telemetry
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/armon/go-metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricFilterAllow(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		blocked []string
+		key     []string
+		expect  bool
+	}{
+		{
+			name:   "no filter",
+			key:    []string{"node_api", "attest"},
+			expect: true,
+		},
+		{
+			name:    "blocked prefix",
+			blocked: []string{"node_api"},
+			key:     []string{"node_api", "attest"},
+			expect:  false,
+		},
+		{
+			name:    "prefix matches whole components only",
+			blocked: []string{"node_api"},
+			key:     []string{"node_api_limit"},
+			expect:  true,
+		},
+		{
+			name:    "not in allowed prefixes",
+			allowed: []string{"server_ca"},
+			key:     []string{"node_api", "attest"},
+			expect:  false,
+		},
+		{
+			name:    "allowed prefix",
+			allowed: []string{"server_ca"},
+			key:     []string{"server_ca", "sign"},
+			expect:  true,
+		},
+		{
+			name:    "longer allowed prefix wins",
+			allowed: []string{"node_api.attest"},
+			blocked: []string{"node_api"},
+			key:     []string{"node_api", "attest"},
+			expect:  true,
+		},
+		{
+			name:    "longer blocked prefix wins",
+			allowed: []string{"node_api"},
+			blocked: []string{"node_api.fetch_x509_svid"},
+			key:     []string{"node_api", "fetch_x509_svid"},
+			expect:  false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newMetricFilter(FileConfig{
+				AllowedPrefixes: tt.allowed,
+				BlockedPrefixes: tt.blocked,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, f.allow(tt.key))
+		})
+	}
+}
+
+func TestMetricFilterLabels(t *testing.T) {
+	f, err := newMetricFilter(FileConfig{
+		Labels: map[string]string{
+			"region":  "us-east-1",
+			"cluster": "prod",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Label{
+		{Name: "cluster", Value: "prod"},
+		{Name: "region", Value: "us_east_1"},
+		{Name: "method", Value: "attest"},
+	}, f.addLabels([]Label{{Name: "method", Value: "attest"}}))
+
+	_, err = newMetricFilter(FileConfig{
+		Labels: map[string]string{"": "value"},
+	})
+	assert.EqualError(t, err, "telemetry label names cannot be empty")
+}
+
+func TestMetricsFilterAndLabels(t *testing.T) {
+	config := testInmemConfig()
+	config.FileConfig = FileConfig{
+		BlockedPrefixes: []string{"blocked"},
+		BlockedLabels:   []string{"caller_id"},
+		Labels:          map[string]string{"cluster": "prod"},
+	}
+	m, err := NewMetrics(config)
+	require.NoError(t, err)
+
+	m.IncrCounter([]string{"blocked", "counter"}, 1)
+	m.IncrCounter([]string{"allowed", "counter"}, 1)
+	m.IncrCounterWithLabels([]string{"allowed", "labeled"}, 1, []Label{
+		{Name: "caller_id", Value: "spiffe://example.org/foo"},
+		{Name: "method", Value: "attest"},
+	})
+
+	require.Len(t, m.runners, 1)
+	sink := m.runners[0].sinks()[0].(*metrics.InmemSink)
+	intervals := sink.Data()
+	require.NotEmpty(t, intervals)
+
+	var names []string
+	for name, counter := range intervals[0].Counters {
+		names = append(names, strings.Split(name, ";")[0])
+		labels := make(map[string]string)
+		for _, label := range counter.Labels {
+			labels[label.Name] = label.Value
+		}
+		assert.Equal(t, "prod", labels["cluster"], name)
+		assert.NotContains(t, labels, "caller_id", name)
+	}
+	assert.ElementsMatch(t, []string{
+		"foo.allowed.counter",
+		"foo.allowed.labeled",
+	}, names)
+}
@@ -43,6 +43,7 @@ type MetricsImpl struct {
 	*metrics.Metrics
 
 	c       *MetricsConfig
+	filter  *metricFilter
 	runners []sinkRunner
 	// Each instance of metrics.Metrics in the slice corresponds to one metrics sink type
 	metricsSinks []*metrics.Metrics
@@ -56,7 +57,12 @@ func NewMetrics(c *MetricsConfig) (*MetricsImpl, error) {
 		return nil, errors.New("logger must be configured")
 	}
 
-	impl := &MetricsImpl{c: c}
+	filter, err := newMetricFilter(c.FileConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	impl := &MetricsImpl{c: c, filter: filter}
 
 	for _, f := range sinkRunnerFactories {
 		runner, err := f(c)
@@ -75,6 +81,7 @@ func NewMetrics(c *MetricsConfig) (*MetricsImpl, error) {
 		conf.EnableHostname = false
 		conf.EnableHostnameLabel = true
 		conf.EnableTypePrefix = runner.requiresTypePrefix()
+		conf.BlockedLabels = c.FileConfig.BlockedLabels
 
 		metricsSink, err := metrics.New(conf, fanout)
 		if err != nil {
@@ -99,62 +106,109 @@ func (m *MetricsImpl) ListenAndServe(ctx context.Context) error {
 }
 
 func (m *MetricsImpl) SetGauge(key []string, val float32) {
+	if len(m.filter.labels) > 0 {
+		m.SetGaugeWithLabels(key, val, nil)
+		return
+	}
+	if !m.filter.allow(key) {
+		return
+	}
 	for _, s := range m.metricsSinks {
 		s.SetGauge(key, val)
 	}
 }
 
-// SetGaugeWithLabels delegates to embedded metrics, sanitizing labels
+// SetGaugeWithLabels delegates to embedded metrics, filtering the metric and
+// sanitizing labels
 func (m *MetricsImpl) SetGaugeWithLabels(key []string, val float32, labels []Label) {
-	sanitizedLabels := SanitizeLabels(labels)
+	if !m.filter.allow(key) {
+		return
+	}
+	sanitizedLabels := m.filter.addLabels(SanitizeLabels(labels))
 	for _, s := range m.metricsSinks {
 		s.SetGaugeWithLabels(key, val, sanitizedLabels)
 	}
 }
 
 func (m *MetricsImpl) EmitKey(key []string, val float32) {
+	if !m.filter.allow(key) {
+		return
+	}
 	for _, s := range m.metricsSinks {
 		s.EmitKey(key, val)
 	}
 }
 
 func (m *MetricsImpl) IncrCounter(key []string, val float32) {
+	if len(m.filter.labels) > 0 {
+		m.IncrCounterWithLabels(key, val, nil)
+		return
+	}
+	if !m.filter.allow(key) {
+		return
+	}
 	for _, s := range m.metricsSinks {
 		s.IncrCounter(key, val)
 	}
 }
 
-// IncrCounterWithLabels delegates to embedded metrics, sanitizing labels
+// IncrCounterWithLabels delegates to embedded metrics, filtering the metric
+// and sanitizing labels
 func (m *MetricsImpl) IncrCounterWithLabels(key []string, val float32, labels []Label) {
-	sanitizedLabels := SanitizeLabels(labels)
+	if !m.filter.allow(key) {
+		return
+	}
+	sanitizedLabels := m.filter.addLabels(SanitizeLabels(labels))
 	for _, s := range m.metricsSinks {
 		s.IncrCounterWithLabels(key, val, sanitizedLabels)
 	}
 }
 
 func (m *MetricsImpl) AddSample(key []string, val float32) {
+	if len(m.filter.labels) > 0 {
+		m.AddSampleWithLabels(key, val, nil)
+		return
+	}
+	if !m.filter.allow(key) {
+		return
+	}
 	for _, s := range m.metricsSinks {
 		s.AddSample(key, val)
 	}
 }
 
-// AddSampleWithLabels delegates to embedded metrics, sanitizing labels
+// AddSampleWithLabels delegates to embedded metrics, filtering the metric and
+// sanitizing labels
 func (m *MetricsImpl) AddSampleWithLabels(key []string, val float32, labels []Label) {
-	sanitizedLabels := SanitizeLabels(labels)
+	if !m.filter.allow(key) {
+		return
+	}
+	sanitizedLabels := m.filter.addLabels(SanitizeLabels(labels))
 	for _, s := range m.metricsSinks {
 		s.AddSampleWithLabels(key, val, sanitizedLabels)
 	}
 }
 
 func (m *MetricsImpl) MeasureSince(key []string, start time.Time) {
+	if len(m.filter.labels) > 0 {
+		m.MeasureSinceWithLabels(key, start, nil)
+		return
+	}
+	if !m.filter.allow(key) {
+		return
+	}
 	for _, s := range m.metricsSinks {
 		s.MeasureSince(key, start)
 	}
 }
 
-// MeasureSinceWithLabels delegates to embedded metrics, sanitizing labels
+// MeasureSinceWithLabels delegates to embedded metrics, filtering the metric
+// and sanitizing labels
 func (m *MetricsImpl) MeasureSinceWithLabels(key []string, start time.Time, labels []Label) {
-	sanitizedLabels := SanitizeLabels(labels)
+	if !m.filter.allow(key) {
+		return
+	}
+	sanitizedLabels := m.filter.addLabels(SanitizeLabels(labels))
 	for _, s := range m.metricsSinks {
 		s.MeasureSinceWithLabels(key, start, sanitizedLabels)
 	}
@@ -22,6 +22,10 @@ const (
 	// to add clarity
 	Attest = "attest"
 
+	// Consume functionality related to consuming some entity; should be used with other tags
+	// to add clarity
+	Consume = "consume"
+
 	// Create functionality related to creating some entity; should be used with other tags
 	// to add clarity
 	Create = "create"
@@ -55,6 +59,22 @@ const (
 	// to add clarity
 	Push = "push"
 
+	// RateLimit functionality related to rate limiting some caller; should be
+	// used with other tags to add clarity
+	RateLimit = "rate_limit"
+
+	// Reject functionality related to rejecting some request that fails
+	// a policy; should be used with other tags to add clarity
+	Reject = "reject"
+
+	// Resolve functionality related to resolving some entity (such as node
+	// selectors); should be used with other tags to add clarity
+	Resolve = "resolve"
+
+	// Restart functionality related to restarting some entity, such as a
+	// crashed plugin; should be used with other tags to add clarity
+	Restart = "restart"
+
 	// Rotate functionality related to rotation of SVID; should be used with other tags
 	// to add clarity
 	Rotate = "rotate"
@@ -98,9 +118,16 @@ const (
 	// to add clarity
 	CallerID = "caller_id"
 
+	// CallerType tags the kind of an API caller, such as an IP address or
+	// an agent
+	CallerType = "caller_type"
+
 	// CGroupPath tags a linux CGroup path, most likely for use in attestation
 	CGroupPath = "cgroup_path"
 
+	// Claim tags the name of a JWT claim
+	Claim = "claim"
+
 	// Connection functionality related to some connection; should be used with other tags
 	// to add clarity
 	Connection = "connection"
@@ -121,6 +148,9 @@ const (
 	// DatabaseType labels a database type (MySQL, postgres...)
 	DatabaseType = "db_type"
 
+	// DebugSubsystems tags the subsystems debug logging is enabled for
+	DebugSubsystems = "debug_subsystems"
+
 	// DiscoveredSelectors tags selectors for some registration
 	DiscoveredSelectors = "discovered_selectors"
 
@@ -146,6 +176,9 @@ const (
 	// to add clarity
 	ExpiryCheckDuration = "expiry_check_duration"
 
+	// Extension tags the OID of an X509 certificate extension
+	Extension = "extension"
+
 	// FederatedAdded labels some count of federated bundles that have been added to an entity
 	FederatedAdded = "fed_add"
 
@@ -155,6 +188,13 @@ const (
 	// Generation represents an objection generation (i.e. version)
 	Generation = "generation"
 
+	// GracePeriod tags a grace period given before an action is taken
+	GracePeriod = "grace_period"
+
+	// Healthy tags whether some entity, such as a plugin, passes its health
+	// probe
+	Healthy = "healthy"
+
 	// IDType tags some type of ID (eg. registration ID, SPIFFE ID...)
 	IDType = "id_type"
 
@@ -171,6 +211,9 @@ const (
 	// Kid tags some key ID
 	Kid = "kid"
 
+	// LogLevel tags a log level
+	LogLevel = "log_level"
+
 	// NodeAttestorType declares the type of node attestation.
 	NodeAttestorType = "node_attestor_type"
 
@@ -212,6 +255,13 @@ const (
 	// Pruned flagging something has been pruned
 	Pruned = "pruned"
 
+	// QueueDepth tags the number of callers waiting in some queue
+	QueueDepth = "queue_depth"
+
+	// Reason tags the stable reason of a failed API call, see the apierror
+	// package
+	Reason = "reason"
+
 	// RegistrationID tags some registration entry ID
 	RegistrationID = "entry_id"
 
@@ -301,6 +351,12 @@ const (
 	// VersionInfo tags some version information
 	VersionInfo = "version_info"
 
+	// WaitTime tags how long a caller waited for its turn, e.g. in a queue
+	WaitTime = "wait_time"
+
+	// WatchdogInterval tags the interval of the systemd watchdog
+	WatchdogInterval = "watchdog_interval"
+
 	// WorkloadAttestation tags call of overall workload attestation
 	WorkloadAttestation = "workload_attestation"
 
@@ -324,10 +380,17 @@ const (
 	// Attestor tags an attestor plugin/type (eg. gcp, aws...)
 	Attestor = "attestor"
 
+	// Audit functionality related to the audit log of the server APIs
+	Audit = "audit"
+
 	// Bundle functionality related to a bundle; should be used with other tags
 	// to add clarity
 	Bundle = "bundle"
 
+	// BundlePublisher functionality related to a bundle publisher; should be
+	// used with other tags to add clarity
+	BundlePublisher = "bundle_publisher"
+
 	// BundlesUpdate functionality related to updating bundles
 	BundlesUpdate = "bundles_update"
 
@@ -363,10 +426,50 @@ const (
 	// OutdatedSVIDs tags SVID with outdated attributes count/list
 	OutdatedSVIDs = "outdated_svids"
 
+	// ExpiredSVIDs tags expired SVID count/list
+	ExpiredSVIDs = "expired_svids"
+
+	// Outage tags a period during which the server is unreachable
+	Outage = "outage"
+
+	// Backoff tags the state of some retry loop backing off after failures
+	Backoff = "backoff"
+
+	// ConsecutiveFailures tags some count of consecutive failed attempts
+	ConsecutiveFailures = "consecutive_failures"
+
+	// CachedEntries tags the number of cached registration entries
+	CachedEntries = "cached_entries"
+
+	// CachedSVIDs tags the number of cached SVIDs
+	CachedSVIDs = "cached_svids"
+
+	// CachedJWTSVIDs tags the number of cached JWT-SVIDs
+	CachedJWTSVIDs = "cached_jwt_svids"
+
+	// CacheHit tags a lookup that was served from a cache
+	CacheHit = "cache_hit"
+
+	// CacheMiss tags a lookup that could not be served from a cache
+	CacheMiss = "cache_miss"
+
+	// Evicted tags something evicted from a cache
+	Evicted = "evicted"
+
+	// MinSVIDLifetime tags the shortest remaining lifetime of a set of SVIDs
+	MinSVIDLifetime = "min_svid_lifetime"
+
+	// RemainingLifetime tags the remaining lifetime of an SVID
+	RemainingLifetime = "remaining_lifetime"
+
 	// FederatedBundle functionality related to a federated bundle; should be used
 	// with other tags to add clarity
 	FederatedBundle = "federated_bundle"
 
+	// IssuedX509SVID functionality related to the record of an issued x509
+	// SVID; should be used with other tags to add clarity
+	IssuedX509SVID = "issued_x509_svid"
+
 	// JoinToken functionality related to a join token; should be used
 	// with other tags to add clarity
 	JoinToken = "join_token"
@@ -423,6 +526,9 @@ const (
 	// Telemetry tags a telemetry module
 	Telemetry = "telemetry"
 
+	// Throttled tags calls rejected by a rate limiter
+	Throttled = "throttled"
+
 	// X509CA functionality related to an x509 CA; should be used with other tags
 	// to add clarity
 	X509CA = "x509_ca"
@@ -442,6 +548,15 @@ const (
 	// AuthorizeCall functionality related to authorizing an incoming call
 	AuthorizeCall = "authorize_call"
 
+	// BanAgent functionality related to banning an agent
+	BanAgent = "ban_agent"
+
+	// CountAgents functionality related to counting agents
+	CountAgents = "count_agents"
+
+	// CountFederatedBundles functionality related to counting federated bundles
+	CountFederatedBundles = "count_federated_bundles"
+
 	// CreateFederatedBundle functionality related to creating a federated bundle
 	CreateFederatedBundle = "create_federated_bundle"
 
@@ -492,12 +607,22 @@ const (
 	// with other tags to add clarity
 	FetchSVIDsUpdates = "fetch_svids_updates"
 
+	// FetchX509Bundles functionality related to fetching X.509 bundles
+	FetchX509Bundles = "fetch_x509_bundles"
+
 	// FetchX509CASVID functionality related to fetching an X509 SVID
 	FetchX509CASVID = "fetch_x509_ca_svid"
 
 	// FetchX509SVID functionality related to fetching an X509 SVID
 	FetchX509SVID = "fetch_x509_svid"
 
+	// ForceAgentRotation functionality related to forcing the rotation of
+	// agent SVIDs
+	ForceAgentRotation = "force_agent_rotation"
+
+	// GetEntryStatistics functionality related to getting registration entry statistics
+	GetEntryStatistics = "get_entry_statistics"
+
 	// GetNodeSelectors functionality related to getting node selectors
 	GetNodeSelectors = "get_node_selectors"
 
@@ -507,6 +632,9 @@ const (
 	// ListAllEntriesWithPages functionality related to listing all registration entries with pagination
 	ListAllEntriesWithPages = "list_all_entries_with_pages"
 
+	// ListIssuedX509SVIDs functionality related to listing issued X.509 SVIDs
+	ListIssuedX509SVIDs = "list_issued_x509_svids"
+
 	// ListFederatedBundles functionality related to listing federated bundles
 	ListFederatedBundles = "list_federated_bundles"
 
@@ -531,6 +659,9 @@ const (
 	// NodeAPI functionality related to attested/attesting nodes (agents)
 	NodeAPI = "node_api"
 
+	// PruneAgents functionality related to pruning expired agents
+	PruneAgents = "prune_agents"
+
 	// PushJWTKeyUpstream functionality related to pushing a public JWT Key to an upstream server.
 	PushJWTKeyUpstream = "push_jwtkey_upstream"
 
@@ -545,6 +676,9 @@ const (
 	// StreamSecrets functionality related to streaming secrets
 	StreamSecrets = "stream_secrets"
 
+	// SubscribeToSyncEvents functionality related to streaming sync events to agents
+	SubscribeToSyncEvents = "subscribe_to_sync_events"
+
 	// SubsystemName declares field for some subsystem name (an API, module...)
 	SubsystemName = "subsystem_name"
 
@@ -17,6 +17,9 @@ type prometheusRunner struct {
 	log    logrus.FieldLogger
 	server *http.Server
 	sink   Sink
+
+	// histograms wraps sink to also record API latencies as histograms
+	histograms *histogramSink
 }
 
 func newPrometheusRunner(c *MetricsConfig) (sinkRunner, error) {
@@ -29,11 +32,20 @@ func newPrometheusRunner(c *MetricsConfig) (sinkRunner, error) {
 		return runner, nil
 	}
 
-	var err error
-	runner.sink, err = prommetrics.NewPrometheusSink()
+	promSink, err := prommetrics.NewPrometheusSink()
 	if err != nil {
 		return runner, err
 	}
+	runner.sink = promSink
+
+	runner.histograms, err = newHistogramSink(promSink, runner.c.HistogramBuckets)
+	if err == nil {
+		err = prometheus.Register(runner.histograms)
+	}
+	if err != nil {
+		prometheus.Unregister(promSink)
+		return runner, fmt.Errorf("unable to set up prometheus histograms: %v", err)
+	}
 
 	handlerOpts := promhttp.HandlerOpts{
 		ErrorLog: runner.log,
@@ -65,7 +77,7 @@ func (p *prometheusRunner) sinks() []Sink {
 		return []Sink{}
 	}
 
-	return []Sink{p.sink}
+	return []Sink{p.histograms}
 }
 
 func (p *prometheusRunner) run(ctx context.Context) error {
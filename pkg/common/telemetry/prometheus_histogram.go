@@ -0,0 +1,130 @@
+package telemetry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// histogramSuffix is appended to the names of the histograms so they
+	// don't clash with the summaries recorded for the same samples.
+	histogramSuffix = "histogram"
+)
+
+var (
+	// DefaultHistogramBuckets are the upper bounds, in milliseconds, of the
+	// buckets of the API latency histograms.
+	DefaultHistogramBuckets = []float64{1, 2.5, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+	// histogramAPIs are the APIs whose latencies are recorded as histograms.
+	histogramAPIs = []string{NodeAPI, RegistrationAPI, WorkloadAPI}
+
+	// prometheusForbiddenChars replaces the characters the Prometheus sink
+	// replaces in metric names.
+	prometheusForbiddenChars = strings.NewReplacer(" ", "_", ".", "_", "=", "_", "-", "_", "/", "_")
+)
+
+// histogramSink wraps the Prometheus sink to also record the latencies of
+// the API calls as Prometheus histograms. The Prometheus sink records all
+// samples as summaries, which cannot be aggregated across servers or agents
+// and so cannot be used to measure latency SLOs.
+type histogramSink struct {
+	Sink
+
+	buckets []float64
+
+	mu sync.Mutex
+	// histograms are keyed by name and labels. They are kept for the
+	// lifetime of the process: dropping an idle histogram would reset its
+	// counters, which breaks the rate computations over them.
+	histograms map[string]prometheus.Histogram
+}
+
+func newHistogramSink(sink Sink, buckets []float64) (*histogramSink, error) {
+	if len(buckets) == 0 {
+		buckets = DefaultHistogramBuckets
+	}
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			return nil, errors.New("histogram buckets must be in increasing order")
+		}
+	}
+	return &histogramSink{
+		Sink:       sink,
+		buckets:    buckets,
+		histograms: make(map[string]prometheus.Histogram),
+	}, nil
+}
+
+// AddSample records the sample with the wrapped sink and, if it is an API
+// latency, in a histogram.
+func (s *histogramSink) AddSample(key []string, val float32) {
+	s.AddSampleWithLabels(key, val, nil)
+}
+
+// AddSampleWithLabels records the sample with the wrapped sink and, if it is
+// an API latency, in a histogram.
+func (s *histogramSink) AddSampleWithLabels(key []string, val float32, labels []Label) {
+	s.Sink.AddSampleWithLabels(key, val, labels)
+	if !isAPISample(key) {
+		return
+	}
+
+	name := strings.Join(append(key[:len(key):len(key)], histogramSuffix), "_")
+	name = prometheusForbiddenChars.Replace(name)
+	hash := name
+	constLabels := make(prometheus.Labels, len(labels))
+	for _, label := range labels {
+		hash += fmt.Sprintf(";%s=%s", label.Name, label.Value)
+		constLabels[label.Name] = label.Value
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.histograms[hash]
+	if !ok {
+		h = prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:        name,
+			Help:        name,
+			Buckets:     s.buckets,
+			ConstLabels: constLabels,
+		})
+		s.histograms[hash] = h
+	}
+	h.Observe(float64(val))
+}
+
+// Describe meets the prometheus.Collector interface. The histograms are
+// created on demand so they can't be described up front: no descriptor is
+// emitted, which registers the sink as an unchecked collector.
+func (s *histogramSink) Describe(c chan<- *prometheus.Desc) {
+}
+
+// Collect meets the prometheus.Collector interface.
+func (s *histogramSink) Collect(c chan<- prometheus.Metric) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, h := range s.histograms {
+		h.Collect(c)
+	}
+}
+
+// isAPISample returns whether the sample key, which starts with the
+// service name, belongs to one of the APIs latencies are recorded as
+// histograms for.
+func isAPISample(key []string) bool {
+	if len(key) < 2 {
+		return false
+	}
+	for _, api := range histogramAPIs {
+		if key[1] == api {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogramSinkRecordsAPILatencies(t *testing.T) {
+	inner := metrics.NewInmemSink(time.Minute, time.Minute)
+	sink, err := newHistogramSink(inner, []float64{10, 100})
+	require.NoError(t, err)
+
+	labels := []Label{{Name: "status", Value: "OK"}}
+	sink.AddSampleWithLabels([]string{"spire_server", NodeAPI, "attest", ElapsedTime}, 5, labels)
+	sink.AddSampleWithLabels([]string{"spire_server", NodeAPI, "attest", ElapsedTime}, 50, labels)
+	sink.AddSampleWithLabels([]string{"spire_server", NodeAPI, "attest", ElapsedTime}, 500, labels)
+	sink.AddSample([]string{"spire_server", "datastore", "fetch_bundle", ElapsedTime}, 5)
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(sink))
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	// Only the API latency is recorded as a histogram
+	require.Len(t, families, 1)
+	family := families[0]
+	assert.Equal(t, "spire_server_node_api_attest_elapsed_time_histogram", family.GetName())
+	require.Len(t, family.Metric, 1)
+	metric := family.Metric[0]
+	require.Len(t, metric.Label, 1)
+	assert.Equal(t, "status", metric.Label[0].GetName())
+	assert.Equal(t, "OK", metric.Label[0].GetValue())
+
+	histogram := metric.GetHistogram()
+	assert.Equal(t, uint64(3), histogram.GetSampleCount())
+	assert.Equal(t, 555.0, histogram.GetSampleSum())
+	require.Len(t, histogram.Bucket, 2)
+	assert.Equal(t, uint64(1), histogram.Bucket[0].GetCumulativeCount())
+	assert.Equal(t, uint64(2), histogram.Bucket[1].GetCumulativeCount())
+
+	// The sink is unchecked, so no descriptor is emitted
+	descs := make(chan *prometheus.Desc, 1)
+	sink.Describe(descs)
+	close(descs)
+	assert.Empty(t, descs)
+
+	// The samples are still recorded by the wrapped sink
+	data := inner.Data()
+	require.NotEmpty(t, data)
+	assert.Len(t, data[0].Samples, 2)
+}
+
+func TestHistogramSinkBuckets(t *testing.T) {
+	sink, err := newHistogramSink(&metrics.BlackholeSink{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultHistogramBuckets, sink.buckets)
+
+	_, err = newHistogramSink(&metrics.BlackholeSink{}, []float64{10, 5})
+	assert.EqualError(t, err, "histogram buckets must be in increasing order")
+}
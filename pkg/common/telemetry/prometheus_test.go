@@ -98,6 +98,9 @@ func newTestPrometheusRunner(c *MetricsConfig) (sinkRunner, error) {
 		pr := runner.(*prometheusRunner)
 		sink := pr.sink.(*prommetrics.PrometheusSink)
 		prometheus.Unregister(sink)
+		if pr.histograms != nil {
+			prometheus.Unregister(pr.histograms)
+		}
 	}
 
 	return runner, err
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"time"
+
 	"github.com/spiffe/spire/pkg/common/telemetry"
 )
 
@@ -40,6 +42,12 @@ func SetX509CARotateGauge(m telemetry.Metrics, trustDomain string, val float32)
 		})
 }
 
+// SetServerCASigningQueueDepthGauge set gauge for the number
+// of callers waiting for the Server CA to sign an SVID
+func SetServerCASigningQueueDepthGauge(m telemetry.Metrics, val float32) {
+	m.SetGauge([]string{telemetry.ServerCA, telemetry.Sign, telemetry.QueueDepth}, val)
+}
+
 // End Gauge
 
 // Counters (literal increments, not call counters)
@@ -87,3 +95,13 @@ func IncrServerCASignX509Counter(m telemetry.Metrics, id string) {
 }
 
 // End Counters
+
+// Samples
+
+// MeasureServerCASigningWait measures how long a caller
+// waited for the Server CA to start signing an SVID
+func MeasureServerCASigningWait(m telemetry.Metrics, start time.Time) {
+	m.MeasureSince([]string{telemetry.ServerCA, telemetry.Sign, telemetry.WaitTime}, start)
+}
+
+// End Samples
@@ -0,0 +1,28 @@
+package datastore
+
+import (
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Call Counters (timing and success metrics)
+// Allows adding labels in-code
+
+// StartCreateIssuedX509SVIDCall return metric
+// for server's datastore, on recording an issued X509-SVID.
+func StartCreateIssuedX509SVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.IssuedX509SVID, telemetry.Create)
+}
+
+// StartListIssuedX509SVIDsCall return metric
+// for server's datastore, on listing issued X509-SVIDs.
+func StartListIssuedX509SVIDsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.IssuedX509SVID, telemetry.List)
+}
+
+// StartPruneIssuedX509SVIDsCall return metric
+// for server's datastore, on pruning issued X509-SVID records.
+func StartPruneIssuedX509SVIDsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.IssuedX509SVID, telemetry.Prune)
+}
+
+// End Call Counters
@@ -7,6 +7,12 @@ import (
 // Call Counters (timing and success metrics)
 // Allows adding labels in-code
 
+// StartConsumeJoinTokenCall return metric
+// for server's datastore, on consuming a join token.
+func StartConsumeJoinTokenCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.JoinToken, telemetry.Consume)
+}
+
 // StartCreateJoinTokenCall return metric
 // for server's datastore, on creating a join token.
 func StartCreateJoinTokenCall(m telemetry.Metrics) *telemetry.CallCounter {
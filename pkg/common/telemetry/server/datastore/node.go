@@ -19,6 +19,12 @@ func StartDeleteNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.Delete)
 }
 
+// StartPruneNodeCall return metric
+// for server's datastore, on pruning nodes.
+func StartPruneNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.Prune)
+}
+
 // StartFetchNodeCall return metric
 // for server's datastore, on fetching a node.
 func StartFetchNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -31,6 +37,12 @@ func StartListNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.List)
 }
 
+// StartForceNodeRotationCall return metric
+// for server's datastore, on forcing the rotation of a node's SVID.
+func StartForceNodeRotationCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.Rotate)
+}
+
 // StartGetNodeSelectorsCall return metric
 // for server's datastore, on getting selectors for a node.
 func StartGetNodeSelectorsCall(m telemetry.Metrics) *telemetry.CallCounter {
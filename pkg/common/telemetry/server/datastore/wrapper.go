@@ -2,169 +2,284 @@ package datastore
 
 import (
 	"context"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
 
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"google.golang.org/grpc/status"
 )
 
 // WithMetrics wraps a datastore interface and provides per-call metrics. The
 // metrics produced include a call counter and elapsed time measurement with
-// labels for the status code.
+// labels for the status code, and an error counter labeled with the method.
 func WithMetrics(ds datastore.DataStore, metrics telemetry.Metrics) datastore.DataStore {
-	return metricsWrapper{ds: ds, m: metrics}
+	return WithTelemetry(ds, Config{Metrics: metrics})
+}
+
+// Config is the configuration for the datastore telemetry wrapper
+type Config struct {
+	Metrics telemetry.Metrics
+
+	// Log is used to log slow calls, and every call at debug level. Required
+	// if SlowCallThreshold is set.
+	Log logrus.FieldLogger
+
+	// SlowCallThreshold is the elapsed time after which a call is logged as
+	// slow. Zero disables slow call logging.
+	SlowCallThreshold time.Duration
+
+	// Clock is used to measure calls for slow call logging (optional)
+	Clock clock.Clock
+}
+
+// WithTelemetry wraps a datastore interface and provides the same per-call
+// metrics as WithMetrics. Additionally, calls taking longer than the
+// configured threshold are logged at warning level, and the other calls at
+// debug level.
+func WithTelemetry(ds datastore.DataStore, config Config) datastore.DataStore {
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
+	return metricsWrapper{ds: ds, m: config.Metrics, c: config}
 }
 
 type metricsWrapper struct {
 	ds datastore.DataStore
 	m  telemetry.Metrics
+	c  Config
+}
+
+func (w metricsWrapper) startCall(method string, counter *telemetry.CallCounter) *callCounter {
+	return &callCounter{
+		w:       w,
+		method:  method,
+		counter: counter,
+		start:   w.c.Clock.Now(),
+	}
+}
+
+// callCounter extends the telemetry call counter with error counting and slow
+// call logging
+type callCounter struct {
+	w       metricsWrapper
+	method  string
+	counter *telemetry.CallCounter
+	start   time.Time
+}
+
+func (c *callCounter) Done(errp *error) {
+	c.counter.Done(errp)
+
+	if errp != nil && *errp != nil {
+		c.w.m.IncrCounterWithLabels([]string{telemetry.Datastore, telemetry.Error}, 1, []telemetry.Label{
+			{Name: telemetry.Method, Value: c.method},
+			{Name: telemetry.Status, Value: status.Code(*errp).String()},
+		})
+	}
+
+	if c.w.c.Log == nil {
+		return
+	}
+	elapsed := c.w.c.Clock.Now().Sub(c.start)
+	log := c.w.c.Log.WithFields(logrus.Fields{
+		telemetry.Method:      c.method,
+		telemetry.ElapsedTime: elapsed,
+	})
+	if errp != nil && *errp != nil {
+		log = log.WithError(*errp)
+	}
+	if c.w.c.SlowCallThreshold > 0 && elapsed >= c.w.c.SlowCallThreshold {
+		log.Warn("Slow datastore call")
+		return
+	}
+	log.Debug("Datastore call")
 }
 
 func (w metricsWrapper) AppendBundle(ctx context.Context, req *datastore.AppendBundleRequest) (_ *datastore.AppendBundleResponse, err error) {
-	callCounter := StartAppendBundleCall(w.m)
+	callCounter := w.startCall("AppendBundle", StartAppendBundleCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.AppendBundle(ctx, req)
 }
 
+func (w metricsWrapper) ConsumeJoinToken(ctx context.Context, req *datastore.ConsumeJoinTokenRequest) (_ *datastore.ConsumeJoinTokenResponse, err error) {
+	callCounter := w.startCall("ConsumeJoinToken", StartConsumeJoinTokenCall(w.m))
+	defer callCounter.Done(&err)
+	return w.ds.ConsumeJoinToken(ctx, req)
+}
+
 func (w metricsWrapper) CreateAttestedNode(ctx context.Context, req *datastore.CreateAttestedNodeRequest) (_ *datastore.CreateAttestedNodeResponse, err error) {
-	callCounter := StartCreateNodeCall(w.m)
+	callCounter := w.startCall("CreateAttestedNode", StartCreateNodeCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.CreateAttestedNode(ctx, req)
 }
 
 func (w metricsWrapper) CreateBundle(ctx context.Context, req *datastore.CreateBundleRequest) (_ *datastore.CreateBundleResponse, err error) {
-	callCounter := StartCreateBundleCall(w.m)
+	callCounter := w.startCall("CreateBundle", StartCreateBundleCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.CreateBundle(ctx, req)
 }
 
+func (w metricsWrapper) CreateIssuedX509SVID(ctx context.Context, req *datastore.CreateIssuedX509SVIDRequest) (_ *datastore.CreateIssuedX509SVIDResponse, err error) {
+	callCounter := w.startCall("CreateIssuedX509SVID", StartCreateIssuedX509SVIDCall(w.m))
+	defer callCounter.Done(&err)
+	return w.ds.CreateIssuedX509SVID(ctx, req)
+}
+
 func (w metricsWrapper) CreateJoinToken(ctx context.Context, req *datastore.CreateJoinTokenRequest) (_ *datastore.CreateJoinTokenResponse, err error) {
-	callCounter := StartCreateJoinTokenCall(w.m)
+	callCounter := w.startCall("CreateJoinToken", StartCreateJoinTokenCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.CreateJoinToken(ctx, req)
 }
 
 func (w metricsWrapper) CreateRegistrationEntry(ctx context.Context, req *datastore.CreateRegistrationEntryRequest) (_ *datastore.CreateRegistrationEntryResponse, err error) {
-	callCounter := StartCreateRegistrationCall(w.m)
+	callCounter := w.startCall("CreateRegistrationEntry", StartCreateRegistrationCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.CreateRegistrationEntry(ctx, req)
 }
 
 func (w metricsWrapper) DeleteAttestedNode(ctx context.Context, req *datastore.DeleteAttestedNodeRequest) (_ *datastore.DeleteAttestedNodeResponse, err error) {
-	callCounter := StartDeleteNodeCall(w.m)
+	callCounter := w.startCall("DeleteAttestedNode", StartDeleteNodeCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.DeleteAttestedNode(ctx, req)
 }
 
 func (w metricsWrapper) DeleteBundle(ctx context.Context, req *datastore.DeleteBundleRequest) (_ *datastore.DeleteBundleResponse, err error) {
-	callCounter := StartDeleteBundleCall(w.m)
+	callCounter := w.startCall("DeleteBundle", StartDeleteBundleCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.DeleteBundle(ctx, req)
 }
 
 func (w metricsWrapper) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoinTokenRequest) (_ *datastore.DeleteJoinTokenResponse, err error) {
-	callCounter := StartDeleteJoinTokenCall(w.m)
+	callCounter := w.startCall("DeleteJoinToken", StartDeleteJoinTokenCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.DeleteJoinToken(ctx, req)
 }
 
 func (w metricsWrapper) DeleteRegistrationEntry(ctx context.Context, req *datastore.DeleteRegistrationEntryRequest) (_ *datastore.DeleteRegistrationEntryResponse, err error) {
-	callCounter := StartDeleteRegistrationCall(w.m)
+	callCounter := w.startCall("DeleteRegistrationEntry", StartDeleteRegistrationCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.DeleteRegistrationEntry(ctx, req)
 }
 
 func (w metricsWrapper) FetchAttestedNode(ctx context.Context, req *datastore.FetchAttestedNodeRequest) (_ *datastore.FetchAttestedNodeResponse, err error) {
-	callCounter := StartFetchNodeCall(w.m)
+	callCounter := w.startCall("FetchAttestedNode", StartFetchNodeCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.FetchAttestedNode(ctx, req)
 }
 
 func (w metricsWrapper) FetchBundle(ctx context.Context, req *datastore.FetchBundleRequest) (_ *datastore.FetchBundleResponse, err error) {
-	callCounter := StartFetchBundleCall(w.m)
+	callCounter := w.startCall("FetchBundle", StartFetchBundleCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.FetchBundle(ctx, req)
 }
 
 func (w metricsWrapper) FetchJoinToken(ctx context.Context, req *datastore.FetchJoinTokenRequest) (_ *datastore.FetchJoinTokenResponse, err error) {
-	callCounter := StartFetchJoinTokenCall(w.m)
+	callCounter := w.startCall("FetchJoinToken", StartFetchJoinTokenCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.FetchJoinToken(ctx, req)
 }
 
 func (w metricsWrapper) FetchRegistrationEntry(ctx context.Context, req *datastore.FetchRegistrationEntryRequest) (_ *datastore.FetchRegistrationEntryResponse, err error) {
-	callCounter := StartFetchRegistrationCall(w.m)
+	callCounter := w.startCall("FetchRegistrationEntry", StartFetchRegistrationCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.FetchRegistrationEntry(ctx, req)
 }
 
+func (w metricsWrapper) ForceAttestedNodeRotation(ctx context.Context, req *datastore.ForceAttestedNodeRotationRequest) (_ *datastore.ForceAttestedNodeRotationResponse, err error) {
+	callCounter := w.startCall("ForceAttestedNodeRotation", StartForceNodeRotationCall(w.m))
+	defer callCounter.Done(&err)
+	return w.ds.ForceAttestedNodeRotation(ctx, req)
+}
+
 func (w metricsWrapper) GetNodeSelectors(ctx context.Context, req *datastore.GetNodeSelectorsRequest) (_ *datastore.GetNodeSelectorsResponse, err error) {
-	callCounter := StartGetNodeSelectorsCall(w.m)
+	callCounter := w.startCall("GetNodeSelectors", StartGetNodeSelectorsCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.GetNodeSelectors(ctx, req)
 }
 
 func (w metricsWrapper) ListAttestedNodes(ctx context.Context, req *datastore.ListAttestedNodesRequest) (_ *datastore.ListAttestedNodesResponse, err error) {
-	callCounter := StartListNodeCall(w.m)
+	callCounter := w.startCall("ListAttestedNodes", StartListNodeCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.ListAttestedNodes(ctx, req)
 }
 
 func (w metricsWrapper) ListBundles(ctx context.Context, req *datastore.ListBundlesRequest) (_ *datastore.ListBundlesResponse, err error) {
-	callCounter := StartListBundleCall(w.m)
+	callCounter := w.startCall("ListBundles", StartListBundleCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.ListBundles(ctx, req)
 }
 
+func (w metricsWrapper) ListIssuedX509SVIDs(ctx context.Context, req *datastore.ListIssuedX509SVIDsRequest) (_ *datastore.ListIssuedX509SVIDsResponse, err error) {
+	callCounter := w.startCall("ListIssuedX509SVIDs", StartListIssuedX509SVIDsCall(w.m))
+	defer callCounter.Done(&err)
+	return w.ds.ListIssuedX509SVIDs(ctx, req)
+}
+
 func (w metricsWrapper) ListRegistrationEntries(ctx context.Context, req *datastore.ListRegistrationEntriesRequest) (_ *datastore.ListRegistrationEntriesResponse, err error) {
-	callCounter := StartListRegistrationCall(w.m)
+	callCounter := w.startCall("ListRegistrationEntries", StartListRegistrationCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.ListRegistrationEntries(ctx, req)
 }
 
+func (w metricsWrapper) PruneAttestedNodes(ctx context.Context, req *datastore.PruneAttestedNodesRequest) (_ *datastore.PruneAttestedNodesResponse, err error) {
+	callCounter := w.startCall("PruneAttestedNodes", StartPruneNodeCall(w.m))
+	defer callCounter.Done(&err)
+	return w.ds.PruneAttestedNodes(ctx, req)
+}
+
 func (w metricsWrapper) PruneBundle(ctx context.Context, req *datastore.PruneBundleRequest) (_ *datastore.PruneBundleResponse, err error) {
-	callCounter := StartPruneBundleCall(w.m)
+	callCounter := w.startCall("PruneBundle", StartPruneBundleCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.PruneBundle(ctx, req)
 }
 
+func (w metricsWrapper) PruneIssuedX509SVIDs(ctx context.Context, req *datastore.PruneIssuedX509SVIDsRequest) (_ *datastore.PruneIssuedX509SVIDsResponse, err error) {
+	callCounter := w.startCall("PruneIssuedX509SVIDs", StartPruneIssuedX509SVIDsCall(w.m))
+	defer callCounter.Done(&err)
+	return w.ds.PruneIssuedX509SVIDs(ctx, req)
+}
+
 func (w metricsWrapper) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (_ *datastore.PruneJoinTokensResponse, err error) {
-	callCounter := StartPruneJoinTokenCall(w.m)
+	callCounter := w.startCall("PruneJoinTokens", StartPruneJoinTokenCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.PruneJoinTokens(ctx, req)
 }
 
 func (w metricsWrapper) PruneRegistrationEntries(ctx context.Context, req *datastore.PruneRegistrationEntriesRequest) (_ *datastore.PruneRegistrationEntriesResponse, err error) {
-	callCounter := StartPruneRegistrationCall(w.m)
+	callCounter := w.startCall("PruneRegistrationEntries", StartPruneRegistrationCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.PruneRegistrationEntries(ctx, req)
 }
 
 func (w metricsWrapper) SetBundle(ctx context.Context, req *datastore.SetBundleRequest) (_ *datastore.SetBundleResponse, err error) {
-	callCounter := StartSetBundleCall(w.m)
+	callCounter := w.startCall("SetBundle", StartSetBundleCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.SetBundle(ctx, req)
 }
 
 func (w metricsWrapper) SetNodeSelectors(ctx context.Context, req *datastore.SetNodeSelectorsRequest) (_ *datastore.SetNodeSelectorsResponse, err error) {
-	callCounter := StartSetNodeSelectorsCall(w.m)
+	callCounter := w.startCall("SetNodeSelectors", StartSetNodeSelectorsCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.SetNodeSelectors(ctx, req)
 }
 
 func (w metricsWrapper) UpdateAttestedNode(ctx context.Context, req *datastore.UpdateAttestedNodeRequest) (_ *datastore.UpdateAttestedNodeResponse, err error) {
-	callCounter := StartUpdateNodeCall(w.m)
+	callCounter := w.startCall("UpdateAttestedNode", StartUpdateNodeCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.UpdateAttestedNode(ctx, req)
 }
 
 func (w metricsWrapper) UpdateBundle(ctx context.Context, req *datastore.UpdateBundleRequest) (_ *datastore.UpdateBundleResponse, err error) {
-	callCounter := StartUpdateBundleCall(w.m)
+	callCounter := w.startCall("UpdateBundle", StartUpdateBundleCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.UpdateBundle(ctx, req)
 }
 
 func (w metricsWrapper) UpdateRegistrationEntry(ctx context.Context, req *datastore.UpdateRegistrationEntryRequest) (_ *datastore.UpdateRegistrationEntryResponse, err error) {
-	callCounter := StartUpdateRegistrationCall(w.m)
+	callCounter := w.startCall("UpdateRegistrationEntry", StartUpdateRegistrationCall(w.m))
 	defer callCounter.Done(&err)
 	return w.ds.UpdateRegistrationEntry(ctx, req)
 }
@@ -6,10 +6,16 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
@@ -37,6 +43,10 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.bundle.append",
 			methodName: "AppendBundle",
 		},
+		{
+			key:        "datastore.join_token.consume",
+			methodName: "ConsumeJoinToken",
+		},
 		{
 			key:        "datastore.node.create",
 			methodName: "CreateAttestedNode",
@@ -45,6 +55,10 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.bundle.create",
 			methodName: "CreateBundle",
 		},
+		{
+			key:        "datastore.issued_x509_svid.create",
+			methodName: "CreateIssuedX509SVID",
+		},
 		{
 			key:        "datastore.join_token.create",
 			methodName: "CreateJoinToken",
@@ -85,6 +99,10 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.registration_entry.fetch",
 			methodName: "FetchRegistrationEntry",
 		},
+		{
+			key:        "datastore.node.rotate",
+			methodName: "ForceAttestedNodeRotation",
+		},
 		{
 			key:        "datastore.node.selectors.fetch",
 			methodName: "GetNodeSelectors",
@@ -97,14 +115,26 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.bundle.list",
 			methodName: "ListBundles",
 		},
+		{
+			key:        "datastore.issued_x509_svid.list",
+			methodName: "ListIssuedX509SVIDs",
+		},
 		{
 			key:        "datastore.registration_entry.list",
 			methodName: "ListRegistrationEntries",
 		},
+		{
+			key:        "datastore.node.prune",
+			methodName: "PruneAttestedNodes",
+		},
 		{
 			key:        "datastore.bundle.prune",
 			methodName: "PruneBundle",
 		},
+		{
+			key:        "datastore.issued_x509_svid.prune",
+			methodName: "PruneIssuedX509SVIDs",
+		},
 		{
 			key:        "datastore.join_token.prune",
 			methodName: "PruneJoinTokens",
@@ -159,7 +189,7 @@ func TestWithMetrics(t *testing.T) {
 
 		expectedMetrics := func(code codes.Code) []fakemetrics.MetricItem {
 			key := strings.Split(tt.key, ".")
-			return []fakemetrics.MetricItem{
+			items := []fakemetrics.MetricItem{
 				{
 					Type: fakemetrics.IncrCounterWithLabelsType,
 					Key:  key,
@@ -176,6 +206,18 @@ func TestWithMetrics(t *testing.T) {
 					},
 				},
 			}
+			if code != codes.OK {
+				items = append(items, fakemetrics.MetricItem{
+					Type: fakemetrics.IncrCounterWithLabelsType,
+					Key:  []string{"datastore", "error"},
+					Labels: []telemetry.Label{
+						{Name: "method", Value: tt.methodName},
+						{Name: "status", Value: code.String()},
+					},
+					Val: 1,
+				})
+			}
+			return items
 		}
 
 		t.Run(tt.key+"(success)", func(t *testing.T) {
@@ -196,8 +238,41 @@ func TestWithMetrics(t *testing.T) {
 	}
 }
 
+func TestWithTelemetrySlowCallLog(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	clk := clock.NewMock(t)
+	ds := &fakeDataStore{}
+	w := WithTelemetry(ds, Config{
+		Metrics:           fakemetrics.New(),
+		Log:               log,
+		SlowCallThreshold: time.Second,
+		Clock:             clk,
+	})
+
+	// fast calls are not logged
+	ds.onCall = func() { clk.Add(time.Second - time.Millisecond) }
+	_, err := w.FetchBundle(context.Background(), &datastore.FetchBundleRequest{})
+	require.NoError(t, err)
+	require.Empty(t, hook.AllEntries())
+
+	ds.onCall = func() { clk.Add(2 * time.Second) }
+	_, err = w.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+	require.NoError(t, err)
+	spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.WarnLevel,
+			Message: "Slow datastore call",
+			Data: logrus.Fields{
+				telemetry.Method:      "ListRegistrationEntries",
+				telemetry.ElapsedTime: "2s",
+			},
+		},
+	})
+}
+
 type fakeDataStore struct {
-	err error
+	err    error
+	onCall func()
 }
 
 func (ds *fakeDataStore) SetError(err error) {
@@ -208,6 +283,10 @@ func (ds *fakeDataStore) AppendBundle(context.Context, *datastore.AppendBundleRe
 	return &datastore.AppendBundleResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) ConsumeJoinToken(context.Context, *datastore.ConsumeJoinTokenRequest) (*datastore.ConsumeJoinTokenResponse, error) {
+	return &datastore.ConsumeJoinTokenResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) CreateAttestedNode(context.Context, *datastore.CreateAttestedNodeRequest) (*datastore.CreateAttestedNodeResponse, error) {
 	return &datastore.CreateAttestedNodeResponse{}, ds.err
 }
@@ -216,6 +295,10 @@ func (ds *fakeDataStore) CreateBundle(context.Context, *datastore.CreateBundleRe
 	return &datastore.CreateBundleResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) CreateIssuedX509SVID(context.Context, *datastore.CreateIssuedX509SVIDRequest) (*datastore.CreateIssuedX509SVIDResponse, error) {
+	return &datastore.CreateIssuedX509SVIDResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) CreateJoinToken(context.Context, *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
 	return &datastore.CreateJoinTokenResponse{}, ds.err
 }
@@ -245,6 +328,7 @@ func (ds *fakeDataStore) FetchAttestedNode(context.Context, *datastore.FetchAtte
 }
 
 func (ds *fakeDataStore) FetchBundle(context.Context, *datastore.FetchBundleRequest) (*datastore.FetchBundleResponse, error) {
+	ds.call()
 	return &datastore.FetchBundleResponse{}, ds.err
 }
 
@@ -256,6 +340,10 @@ func (ds *fakeDataStore) FetchRegistrationEntry(context.Context, *datastore.Fetc
 	return &datastore.FetchRegistrationEntryResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) ForceAttestedNodeRotation(context.Context, *datastore.ForceAttestedNodeRotationRequest) (*datastore.ForceAttestedNodeRotationResponse, error) {
+	return &datastore.ForceAttestedNodeRotationResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) GetNodeSelectors(context.Context, *datastore.GetNodeSelectorsRequest) (*datastore.GetNodeSelectorsResponse, error) {
 	return &datastore.GetNodeSelectorsResponse{}, ds.err
 }
@@ -268,14 +356,27 @@ func (ds *fakeDataStore) ListBundles(context.Context, *datastore.ListBundlesRequ
 	return &datastore.ListBundlesResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) ListIssuedX509SVIDs(context.Context, *datastore.ListIssuedX509SVIDsRequest) (*datastore.ListIssuedX509SVIDsResponse, error) {
+	return &datastore.ListIssuedX509SVIDsResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) ListRegistrationEntries(context.Context, *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
+	ds.call()
 	return &datastore.ListRegistrationEntriesResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) PruneAttestedNodes(context.Context, *datastore.PruneAttestedNodesRequest) (*datastore.PruneAttestedNodesResponse, error) {
+	return &datastore.PruneAttestedNodesResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) PruneBundle(context.Context, *datastore.PruneBundleRequest) (*datastore.PruneBundleResponse, error) {
 	return &datastore.PruneBundleResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) PruneIssuedX509SVIDs(context.Context, *datastore.PruneIssuedX509SVIDsRequest) (*datastore.PruneIssuedX509SVIDsResponse, error) {
+	return &datastore.PruneIssuedX509SVIDsResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) PruneJoinTokens(context.Context, *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
 	return &datastore.PruneJoinTokensResponse{}, ds.err
 }
@@ -303,3 +404,9 @@ func (ds *fakeDataStore) UpdateBundle(context.Context, *datastore.UpdateBundleRe
 func (ds *fakeDataStore) UpdateRegistrationEntry(context.Context, *datastore.UpdateRegistrationEntryRequest) (*datastore.UpdateRegistrationEntryResponse, error) {
 	return &datastore.UpdateRegistrationEntryResponse{}, ds.err
 }
+
+func (ds *fakeDataStore) call() {
+	if ds.onCall != nil {
+		ds.onCall()
+	}
+}
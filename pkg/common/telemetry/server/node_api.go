@@ -46,4 +46,29 @@ func StartNodeAPIFetchBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.NodeAPI, telemetry.FetchBundle, telemetry.Fetch)
 }
 
+// StartNodeAPISubscribeToSyncEventsCall return metric for
+// the server's Node API, Subscribe to sync events.
+func StartNodeAPISubscribeToSyncEventsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.NodeAPI, telemetry.SubscribeToSyncEvents)
+}
+
 // End Call Counters
+
+// Counters (literal increments, not call counters)
+
+// IncrNodeAPIRateLimitCounter indicate a Node API caller
+// was rate limited. Takes the limited action and the caller type.
+func IncrNodeAPIRateLimitCounter(m telemetry.Metrics, action, callerType string) {
+	m.IncrCounterWithLabels([]string{telemetry.NodeAPI, telemetry.RateLimit}, 1, []telemetry.Label{
+		{Name: telemetry.Action, Value: action},
+		{Name: telemetry.CallerType, Value: callerType},
+	})
+}
+
+// IncrNodeAPIRejectedCSRCounter indicate a workload CSR sent
+// to the Node API was rejected by the workload key policy.
+func IncrNodeAPIRejectedCSRCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.NodeAPI, telemetry.X509SVID, telemetry.Reject}, 1)
+}
+
+// End Counters
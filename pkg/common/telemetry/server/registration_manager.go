@@ -11,4 +11,22 @@ func StartRegistrationManagerPruneEntryCall(m telemetry.Metrics) *telemetry.Call
 	return telemetry.StartCall(m, telemetry.RegistrationEntry, telemetry.Manager, telemetry.Prune)
 }
 
+// StartRegistrationManagerPruneNodeCall returns metric for
+// for server registration manager attested node pruning
+func StartRegistrationManagerPruneNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Node, telemetry.Manager, telemetry.Prune)
+}
+
+// StartRegistrationManagerPruneIssuedX509SVIDsCall returns metric for
+// for server registration manager issued X509-SVID record pruning
+func StartRegistrationManagerPruneIssuedX509SVIDsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.IssuedX509SVID, telemetry.Manager, telemetry.Prune)
+}
+
+// StartRegistrationManagerResolveNodeSelectorsCall returns metric for
+// for server registration manager node selector resolution
+func StartRegistrationManagerResolveNodeSelectorsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Node, telemetry.Manager, telemetry.Resolve)
+}
+
 // End Call Counters
@@ -25,6 +25,12 @@ func StartCreateEntryIfNotExistsCall(m telemetry.Metrics) *telemetry.CallCounter
 	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.Entry, telemetry.CreateIfNotExists)
 }
 
+// StartCountFedBundlesCall return metric
+// for server's registration API, on counting federated bundles
+func StartCountFedBundlesCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.FederatedBundle, telemetry.Count)
+}
+
 // StartCreateFedBundleCall return metric
 // for server's registration API, on creating a federated bundle
 func StartCreateFedBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -67,12 +73,24 @@ func StartFetchFedBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.FederatedBundle, telemetry.Fetch)
 }
 
+// StartGetEntryStatisticsCall return metric
+// for server's registration API, on getting entry statistics
+func StartGetEntryStatisticsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.GetEntryStatistics)
+}
+
 // StartListEntriesCall return metric
 // for server's registration API, on listing entries
 func StartListEntriesCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.Entry, telemetry.List)
 }
 
+// StartListIssuedX509SVIDsCall return metric
+// for server's registration API, on listing issued X509SVIDs
+func StartListIssuedX509SVIDsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.IssuedX509SVID, telemetry.List)
+}
+
 // StartListFedBundlesCall return metric
 // for server's registration API, on listing federated bundles
 func StartListFedBundlesCall(m telemetry.Metrics) *telemetry.CallCounter {
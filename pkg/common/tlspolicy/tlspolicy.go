@@ -0,0 +1,107 @@
+// Package tlspolicy restricts the TLS versions and cipher suites negotiated
+// by the TLS listeners.
+package tlspolicy
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var versions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// cipherSuites are the TLS 1.2 cipher suites that can be permitted. Suites
+// without forward secrecy or using broken primitives are not supported.
+var cipherSuites = map[string]uint16{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA":    tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256": tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA":    tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384": tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305":  tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+	"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA":      tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":   tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA":      tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":   tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305":    tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+}
+
+// Policy restricts the TLS versions and cipher suites of a listener. The zero
+// value leaves the TLS configuration of the listener unchanged.
+type Policy struct {
+	// MinVersion is the minimum TLS version accepted
+	MinVersion uint16
+
+	// MaxVersion is the maximum TLS version accepted
+	MaxVersion uint16
+
+	// CipherSuites are the TLS 1.2 cipher suites permitted. TLS 1.3 cipher
+	// suites are not configurable.
+	CipherSuites []uint16
+}
+
+// Parse parses a policy from the minimum TLS version (e.g. "1.2") and the
+// names of the permitted cipher suites (e.g.
+// "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"). Empty values are not restricted.
+func Parse(minVersion string, cipherSuiteNames []string) (Policy, error) {
+	var policy Policy
+
+	if minVersion != "" {
+		version, ok := versions[minVersion]
+		if !ok {
+			return Policy{}, fmt.Errorf("unsupported min_version %q: must be one of %s", minVersion, strings.Join(names(versions), ", "))
+		}
+		policy.MinVersion = version
+	}
+
+	if len(cipherSuiteNames) > 0 && policy.MinVersion == tls.VersionTLS13 {
+		return Policy{}, errors.New("cipher_suites cannot be configured along with min_version 1.3 since TLS 1.3 cipher suites are not configurable")
+	}
+	for _, name := range cipherSuiteNames {
+		suite, ok := cipherSuites[name]
+		if !ok {
+			return Policy{}, fmt.Errorf("unsupported cipher suite %q: must be one of %s", name, strings.Join(names(cipherSuites), ", "))
+		}
+		policy.CipherSuites = append(policy.CipherSuites, suite)
+	}
+
+	return policy, nil
+}
+
+// Apply restricts the TLS configuration according to the policy.
+func (p Policy) Apply(c *tls.Config) {
+	if p.MinVersion != 0 {
+		c.MinVersion = p.MinVersion
+	}
+	if p.MaxVersion != 0 {
+		c.MaxVersion = p.MaxVersion
+	}
+	if len(p.CipherSuites) > 0 {
+		c.CipherSuites = p.CipherSuites
+		c.PreferServerCipherSuites = true
+	}
+}
+
+// CipherSuiteName returns the name of a cipher suite, or its hexadecimal value
+// if it is not one of the cipher suites that can be permitted.
+func CipherSuiteName(suite uint16) string {
+	for name, candidate := range cipherSuites {
+		if candidate == suite {
+			return name
+		}
+	}
+	return fmt.Sprintf("0x%04X", suite)
+}
+
+func names(m map[string]uint16) []string {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
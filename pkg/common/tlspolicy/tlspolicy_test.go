@@ -0,0 +1,81 @@
+package tlspolicy
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		minVersion   string
+		cipherSuites []string
+		expect       Policy
+		err          string
+	}{
+		{
+			name: "unrestricted",
+		},
+		{
+			name:       "min version",
+			minVersion: "1.3",
+			expect:     Policy{MinVersion: tls.VersionTLS13},
+		},
+		{
+			name:         "cipher suites",
+			minVersion:   "1.2",
+			cipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+			expect: Policy{
+				MinVersion:   tls.VersionTLS12,
+				CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384, tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+			},
+		},
+		{
+			name:       "unsupported min version",
+			minVersion: "1.1",
+			err:        `unsupported min_version "1.1": must be one of 1.2, 1.3`,
+		},
+		{
+			name:         "unsupported cipher suite",
+			cipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"},
+			err:          `unsupported cipher suite "TLS_RSA_WITH_RC4_128_SHA": must be one of`,
+		},
+		{
+			name:         "cipher suites with TLS 1.3",
+			minVersion:   "1.3",
+			cipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+			err:          "cipher_suites cannot be configured along with min_version 1.3",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := Parse(tt.minVersion, tt.cipherSuites)
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, policy)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	c := &tls.Config{MinVersion: tls.VersionTLS12}
+	Policy{}.Apply(c)
+	assert.Equal(t, &tls.Config{MinVersion: tls.VersionTLS12}, c)
+
+	Policy{
+		MinVersion:   tls.VersionTLS12,
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+	}.Apply(c)
+	assert.Equal(t, uint16(tls.VersionTLS12), c.MinVersion)
+	assert.Equal(t, uint16(tls.VersionTLS12), c.MaxVersion)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}, c.CipherSuites)
+	assert.True(t, c.PreferServerCipherSuites)
+}
@@ -0,0 +1,59 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// maxRegistrationEntryIDLength matches the size of the column the datastore
+// stores entry IDs in.
+const maxRegistrationEntryIDLength = 255
+
+var isRegistrationEntryID = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._:-]*$`).MatchString
+
+// ValidateRegistrationEntryID validates an entry ID supplied by the creator
+// of a registration entry. IDs are made of letters, digits, dots, dashes,
+// underscores and colons, e.g. UUIDs or IDs derived with
+// DeriveRegistrationEntryID.
+func ValidateRegistrationEntryID(id string) error {
+	switch {
+	case id == "":
+		return fmt.Errorf("entry ID is empty")
+	case len(id) > maxRegistrationEntryIDLength:
+		return fmt.Errorf("entry ID is longer than %d bytes", maxRegistrationEntryIDLength)
+	case !isRegistrationEntryID(id):
+		return fmt.Errorf("entry ID %q contains characters other than letters, digits, dots, dashes, underscores and colons", id)
+	}
+	return nil
+}
+
+// DeriveRegistrationEntryID derives an entry ID from the parent ID, SPIFFE ID
+// and selectors of a registration entry, the content that makes an entry
+// unique. Deriving the ID of an entry twice yields the same ID, regardless of
+// the order of the selectors, so that registrars can create entries
+// idempotently and find the entries they created.
+func DeriveRegistrationEntryID(entry *common.RegistrationEntry) string {
+	selectors := append([]*common.Selector(nil), entry.Selectors...)
+	SortSelectors(selectors)
+
+	// fields are NUL terminated so that fields sharing the same
+	// concatenation, e.g. selector type "a" and value "bc" versus type "ab"
+	// and value "c", do not yield the same ID
+	h := sha256.New()
+	writeField := func(s string) {
+		_, _ = h.Write([]byte(s))
+		_, _ = h.Write([]byte{0})
+	}
+	writeField(entry.ParentId)
+	writeField(entry.SpiffeId)
+	for _, selector := range selectors {
+		writeField(selector.Type)
+		writeField(selector.Value)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,77 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRegistrationEntryID(t *testing.T) {
+	for _, tt := range []struct {
+		id  string
+		err string
+	}{
+		{id: "2a9e1cbb-1c4a-4ba5-9e3e-cd17e3d6e4c5"},
+		{id: "payments:frontend.v1_blue"},
+		{id: "", err: "entry ID is empty"},
+		{id: strings.Repeat("a", 256), err: "entry ID is longer than 255 bytes"},
+		{id: "-foo", err: `entry ID "-foo" contains characters other than letters, digits, dots, dashes, underscores and colons`},
+		{id: "foo/bar", err: `entry ID "foo/bar" contains characters other than letters, digits, dots, dashes, underscores and colons`},
+	} {
+		err := ValidateRegistrationEntryID(tt.id)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, "id %q", tt.id)
+			continue
+		}
+		assert.NoError(t, err, "id %q", tt.id)
+	}
+}
+
+func TestDeriveRegistrationEntryID(t *testing.T) {
+	entry := &common.RegistrationEntry{
+		ParentId: "spiffe://example.org/parent",
+		SpiffeId: "spiffe://example.org/workload",
+		Selectors: []*common.Selector{
+			{Type: "unix", Value: "uid:1000"},
+			{Type: "k8s", Value: "ns:default"},
+		},
+		Ttl: 60,
+	}
+	id := DeriveRegistrationEntryID(entry)
+	require.Len(t, id, 64)
+	require.NoError(t, ValidateRegistrationEntryID(id))
+
+	// the selectors of the entry are left untouched
+	assert.Equal(t, "unix", entry.Selectors[0].Type)
+
+	// the order of the selectors and fields other than the parent ID, SPIFFE
+	// ID and selectors do not matter
+	assert.Equal(t, id, DeriveRegistrationEntryID(&common.RegistrationEntry{
+		ParentId: "spiffe://example.org/parent",
+		SpiffeId: "spiffe://example.org/workload",
+		Selectors: []*common.Selector{
+			{Type: "k8s", Value: "ns:default"},
+			{Type: "unix", Value: "uid:1000"},
+		},
+		Ttl:      3600,
+		EntryId:  "foo",
+		DnsNames: []string{"example.org"},
+	}))
+
+	assert.NotEqual(t, id, DeriveRegistrationEntryID(&common.RegistrationEntry{
+		ParentId: "spiffe://example.org/parent",
+		SpiffeId: "spiffe://example.org/other",
+		Selectors: []*common.Selector{
+			{Type: "unix", Value: "uid:1000"},
+			{Type: "k8s", Value: "ns:default"},
+		},
+	}))
+
+	assert.NotEqual(t,
+		DeriveRegistrationEntryID(&common.RegistrationEntry{Selectors: []*common.Selector{{Type: "a", Value: "bc"}}}),
+		DeriveRegistrationEntryID(&common.RegistrationEntry{Selectors: []*common.Selector{{Type: "ab", Value: "c"}}}),
+	)
+}
@@ -0,0 +1,27 @@
+package util
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// RegisterHealth registers the standard gRPC health service on the server,
+// so generic tooling (e.g. grpcurl or Kubernetes gRPC probes) can check it.
+// If reflectionEnabled is true, server reflection is registered too, so the
+// services can be listed and described. The server as a whole and each
+// service already registered are reported as serving. It must be called
+// after the other services are registered and before the server is started.
+// The returned health server can be used to update the reported status.
+func RegisterHealth(server *grpc.Server, reflectionEnabled bool) *health.Server {
+	healthServer := health.NewServer()
+	for name := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+	if reflectionEnabled {
+		reflection.Register(server)
+	}
+	return healthServer
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestRegisterHealth(t *testing.T) {
+	conn, healthServer, done := serveHealth(t, false)
+	defer done()
+
+	ctx := context.Background()
+	healthClient := healthpb.NewHealthClient(conn)
+	checkStatus := func(service string, expected healthpb.HealthCheckResponse_ServingStatus) {
+		resp, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		require.Equal(t, expected, resp.Status)
+	}
+
+	// The server and the registered services are serving
+	checkStatus("", healthpb.HealthCheckResponse_SERVING)
+	checkStatus("spire.api.registration.Registration", healthpb.HealthCheckResponse_SERVING)
+
+	// Unknown services are not found
+	_, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// The status reflects a shutdown
+	healthServer.Shutdown()
+	checkStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	checkStatus("spire.api.registration.Registration", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	// Server reflection is not registered
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestRegisterHealthWithReflection(t *testing.T) {
+	conn, _, done := serveHealth(t, true)
+	defer done()
+
+	// The registered services can be listed with reflection
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	var services []string
+	for _, service := range resp.GetListServicesResponse().Service {
+		services = append(services, service.Name)
+	}
+	require.ElementsMatch(t, []string{
+		"grpc.health.v1.Health",
+		"grpc.reflection.v1alpha.ServerReflection",
+		"spire.api.registration.Registration",
+	}, services)
+}
+
+func serveHealth(t *testing.T, reflectionEnabled bool) (*grpc.ClientConn, *health.Server, func()) {
+	server := grpc.NewServer()
+	registration.RegisterRegistrationServer(server, &registration.UnimplementedRegistrationServer{})
+	healthServer := RegisterHealth(server, reflectionEnabled)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(listener) }()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}))
+	require.NoError(t, err)
+	return conn, healthServer, func() {
+		conn.Close()
+		server.Stop()
+	}
+}
@@ -0,0 +1,35 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// IsAbstractUDS returns true if the UDS path names a socket in the Linux
+// abstract namespace, i.e. it starts with '@'. Abstract sockets have no
+// file on disk, and therefore no ownership or permissions.
+func IsAbstractUDS(path string) bool {
+	return strings.HasPrefix(path, "@")
+}
+
+// ValidateUDSPath returns an error if a UDS cannot be bound to the path on
+// this platform. Paths are limited by the size of sun_path in the socket
+// address, which is otherwise reported as a cryptic bind failure.
+func ValidateUDSPath(path string) error {
+	switch {
+	case path == "":
+		return errors.New("socket path is empty")
+	case IsAbstractUDS(path):
+		if !abstractUDSSupported {
+			return fmt.Errorf("abstract socket %q is only supported on Linux", path)
+		}
+		if len(path) > maxUDSPathLen {
+			return fmt.Errorf("abstract socket name %q is %d bytes long; the maximum is %d bytes", path, len(path), maxUDSPathLen)
+		}
+	case len(path) >= maxUDSPathLen:
+		// Paths on disk are NUL terminated within sun_path
+		return fmt.Errorf("socket path %q is %d bytes long; the maximum on this platform is %d bytes", path, len(path), maxUDSPathLen-1)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package util
+
+const (
+	// maxUDSPathLen is the size of sun_path in the socket address
+	maxUDSPathLen = 108
+
+	abstractUDSSupported = true
+)
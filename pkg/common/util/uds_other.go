@@ -0,0 +1,11 @@
+// +build !linux
+
+package util
+
+const (
+	// maxUDSPathLen is the size of sun_path in the socket address on macOS
+	// and the BSDs, which is the smallest among the supported platforms
+	maxUDSPathLen = 104
+
+	abstractUDSSupported = false
+)
@@ -0,0 +1,38 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsAbstractUDS(t *testing.T) {
+	require.True(t, IsAbstractUDS("@spire-agent"))
+	require.False(t, IsAbstractUDS("/run/spire/sockets/agent.sock"))
+	require.False(t, IsAbstractUDS(""))
+}
+
+func TestValidateUDSPath(t *testing.T) {
+	longest := "/" + strings.Repeat("a", maxUDSPathLen-2)
+
+	require.NoError(t, ValidateUDSPath("/run/spire/sockets/agent.sock"))
+	require.NoError(t, ValidateUDSPath("./spire_api"))
+	require.NoError(t, ValidateUDSPath(longest))
+	require.EqualError(t, ValidateUDSPath(""), "socket path is empty")
+
+	err := ValidateUDSPath(longest + "a")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bytes long; the maximum on this platform is")
+
+	abstract := "@" + strings.Repeat("a", maxUDSPathLen-1)
+	if !abstractUDSSupported {
+		require.EqualError(t, ValidateUDSPath("@spire-agent"), `abstract socket "@spire-agent" is only supported on Linux`)
+		return
+	}
+	require.NoError(t, ValidateUDSPath("@spire-agent"))
+	require.NoError(t, ValidateUDSPath(abstract))
+	err = ValidateUDSPath(abstract + "a")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bytes long; the maximum is")
+}
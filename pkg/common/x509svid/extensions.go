@@ -0,0 +1,48 @@
+package x509svid
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// reservedExtensions are the extensions set by SPIRE when signing
+// X509-SVIDs, or that would change how the X509-SVID is validated.
+var reservedExtensions = map[string]bool{
+	"2.5.29.14":          true, // subject key identifier
+	"2.5.29.15":          true, // key usage
+	"2.5.29.17":          true, // subject alternative name
+	"2.5.29.19":          true, // basic constraints
+	"2.5.29.30":          true, // name constraints
+	"2.5.29.31":          true, // CRL distribution points
+	"2.5.29.32":          true, // certificate policies
+	"2.5.29.35":          true, // authority key identifier
+	"2.5.29.37":          true, // extended key usage
+	"1.3.6.1.5.5.7.1.1":  true, // authority information access
+	"1.3.6.1.5.5.7.1.11": true, // subject information access
+}
+
+// IsReservedExtension returns true if the extension, identified by its OID
+// in dotted notation, is set by SPIRE and cannot be added to X509-SVIDs.
+func IsReservedExtension(oid string) bool {
+	return reservedExtensions[oid]
+}
+
+// ParseOID parses an object identifier in dotted notation
+// (e.g. "1.3.6.1.4.1.99999.1").
+func ParseOID(s string) (asn1.ObjectIdentifier, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid OID %q: must have at least two arcs", s)
+	}
+	oid := make(asn1.ObjectIdentifier, 0, len(parts))
+	for _, part := range parts {
+		arc, err := strconv.Atoi(part)
+		if err != nil || arc < 0 {
+			return nil, fmt.Errorf("invalid OID %q", s)
+		}
+		oid = append(oid, arc)
+	}
+	return oid, nil
+}
@@ -0,0 +1,29 @@
+package x509svid
+
+import (
+	"encoding/asn1"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsReservedExtension(t *testing.T) {
+	require.True(t, IsReservedExtension("2.5.29.17"))
+	require.True(t, IsReservedExtension("2.5.29.37"))
+	require.False(t, IsReservedExtension("1.3.6.1.4.1.99999.1"))
+}
+
+func TestParseOID(t *testing.T) {
+	oid, err := ParseOID("1.3.6.1.4.1.99999.1")
+	require.NoError(t, err)
+	require.Equal(t, asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}, oid)
+
+	_, err = ParseOID("1")
+	require.EqualError(t, err, `invalid OID "1": must have at least two arcs`)
+
+	_, err = ParseOID("1.2.x")
+	require.EqualError(t, err, `invalid OID "1.2.x"`)
+
+	_, err = ParseOID("1..2")
+	require.EqualError(t, err, `invalid OID "1..2"`)
+}
@@ -326,7 +326,7 @@ func TestAppendBundle(t *testing.T) {
 				TrustDomain:     defaultBundle.TrustDomain,
 				RefreshHint:     defaultBundle.RefreshHint,
 				JwtAuthorities:  append(defaultBundle.JwtAuthorities, jwtKey2),
-				SequenceNumber:  defaultBundle.SequenceNumber,
+				SequenceNumber:  defaultBundle.SequenceNumber + 1,
 				X509Authorities: append(defaultBundle.X509Authorities, x509Cert),
 			},
 		},
@@ -356,7 +356,7 @@ func TestAppendBundle(t *testing.T) {
 				TrustDomain:     defaultBundle.TrustDomain,
 				RefreshHint:     defaultBundle.RefreshHint,
 				JwtAuthorities:  defaultBundle.JwtAuthorities,
-				SequenceNumber:  defaultBundle.SequenceNumber,
+				SequenceNumber:  defaultBundle.SequenceNumber + 1,
 				X509Authorities: append(defaultBundle.X509Authorities, x509Cert),
 			},
 			inputMask: &types.BundleMask{
@@ -26,9 +26,10 @@ func TestBundleToProto(t *testing.T) {
 		{
 			name: "success",
 			bundle: &common.Bundle{
-				TrustDomainId: td.String(),
-				RefreshHint:   10,
-				RootCas:       []*common.Certificate{{DerBytes: []byte("cert-bytes")}},
+				TrustDomainId:  td.String(),
+				RefreshHint:    10,
+				SequenceNumber: 42,
+				RootCas:        []*common.Certificate{{DerBytes: []byte("cert-bytes")}},
 				JwtSigningKeys: []*common.PublicKey{
 					{
 						Kid:       "key-id-1",
@@ -38,8 +39,9 @@ func TestBundleToProto(t *testing.T) {
 				},
 			},
 			expectBundle: &types.Bundle{
-				TrustDomain: td.String(),
-				RefreshHint: 10,
+				TrustDomain:    td.String(),
+				RefreshHint:    10,
+				SequenceNumber: 42,
 				X509Authorities: []*types.X509Certificate{
 					{
 						Asn1: []byte("cert-bytes"),
@@ -102,8 +104,9 @@ func TestProtoToBundle(t *testing.T) {
 		{
 			name: "success",
 			bundle: &types.Bundle{
-				TrustDomain: td.String(),
-				RefreshHint: 10,
+				TrustDomain:    td.String(),
+				RefreshHint:    10,
+				SequenceNumber: 42,
 				X509Authorities: []*types.X509Certificate{
 					{
 						Asn1: rootCA.Raw,
@@ -118,9 +121,10 @@ func TestProtoToBundle(t *testing.T) {
 				},
 			},
 			expectBundle: &common.Bundle{
-				TrustDomainId: td.IDString(),
-				RefreshHint:   10,
-				RootCas:       []*common.Certificate{{DerBytes: rootCA.Raw}},
+				TrustDomainId:  td.IDString(),
+				RefreshHint:    10,
+				SequenceNumber: 42,
+				RootCas:        []*common.Certificate{{DerBytes: rootCA.Raw}},
 				JwtSigningKeys: []*common.PublicKey{
 					{
 						PkixBytes: pkixBytes,
@@ -45,6 +45,8 @@ func RegistrationEntryToProto(e *common.RegistrationEntry) (*types.Entry, error)
 		Downstream:    e.Downstream,
 		ExpiresAt:     e.EntryExpiry,
 		DnsNames:      e.DnsNames,
+		JwtSvidTtl:    e.JwtSvidTtl,
+		Hint:          e.Hint,
 	}, nil
 }
 
@@ -95,5 +97,7 @@ func ProtoToRegistrationEntry(e *types.Entry) (*common.RegistrationEntry, error)
 		FederatesWith: e.FederatesWith,
 		Selectors:     selectors,
 		Ttl:           e.Ttl,
+		JwtSvidTtl:    e.JwtSvidTtl,
+		Hint:          e.Hint,
 	}, nil
 }
@@ -142,7 +142,13 @@ func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *t
 	resp, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
 		Entry: cEntry,
 	})
-	if err != nil {
+	switch {
+	case status.Code(err) == codes.AlreadyExists:
+		log.WithError(err).Error("Entry ID already in use")
+		return &entry.BatchCreateEntryResponse_Result{
+			Status: api.CreateStatus(codes.AlreadyExists, "%s", status.Convert(err).Message()),
+		}
+	case err != nil:
 		log.WithError(err).Error("Failed to create entry")
 		return &entry.BatchCreateEntryResponse_Result{
 			Status: api.CreateStatus(codes.Internal, "failed to create entry: %v", err),
@@ -315,6 +321,14 @@ func applyMask(e *types.Entry, mask *types.EntryMask) {
 	if !mask.DnsNames {
 		e.DnsNames = nil
 	}
+
+	if !mask.JwtSvidTtl {
+		e.JwtSvidTtl = 0
+	}
+
+	if !mask.Hint {
+		e.Hint = ""
+	}
 }
 
 func buildListEntriesRequest(req *entry.ListEntriesRequest) (*datastore.ListRegistrationEntriesRequest, error) {
@@ -763,6 +763,32 @@ func TestBatchCreateEntry(t *testing.T) {
 			dsError:         errors.New("creating error"),
 			dsResults:       map[string]*common.RegistrationEntry{"entry1": nil},
 		},
+		{
+			name: "entry ID already in use",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Entry ID already in use",
+					Data: logrus.Fields{
+						logrus.ErrorKey:    `rpc error: code = AlreadyExists desc = registration entry ID "entry1" is already in use`,
+						telemetry.SPIFFEID: "spiffe://example.org/workload",
+					},
+				},
+			},
+			expectResults: []*entrypb.BatchCreateEntryResponse_Result{
+				{
+					Status: &types.Status{
+						Code:    int32(codes.AlreadyExists),
+						Message: `registration entry ID "entry1" is already in use`,
+					},
+				},
+			},
+
+			reqEntries:      []*types.Entry{testEntry},
+			expectDsEntries: map[string]*common.RegistrationEntry{"entry1": testDSEntry},
+			dsError:         status.Error(codes.AlreadyExists, `registration entry ID "entry1" is already in use`),
+			dsResults:       map[string]*common.RegistrationEntry{"entry1": nil},
+		},
 		{
 			name: "ds returns malformed entry",
 			expectLogs: []spiretest.LogEntry{
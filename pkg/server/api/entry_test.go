@@ -39,6 +39,7 @@ func TestRegistrationEntryToProto(t *testing.T) {
 				EntryExpiry: expiresAt,
 				DnsNames:    []string{"dns1", "dns2"},
 				Downstream:  true,
+				Hint:        "external",
 			},
 			expectEntry: &types.Entry{
 				Id:       "entry1",
@@ -57,6 +58,7 @@ func TestRegistrationEntryToProto(t *testing.T) {
 				ExpiresAt:  expiresAt,
 				DnsNames:   []string{"dns1", "dns2"},
 				Downstream: true,
+				Hint:       "external",
 			},
 		},
 		{
@@ -124,6 +126,7 @@ func TestProtoToRegistrationEntry(t *testing.T) {
 				ExpiresAt:  expiresAt,
 				DnsNames:   []string{"dns1", "dns2"},
 				Downstream: true,
+				Hint:       "external",
 			},
 			expectEntry: &common.RegistrationEntry{
 				EntryId:  "entry1",
@@ -142,6 +145,7 @@ func TestProtoToRegistrationEntry(t *testing.T) {
 				EntryExpiry: expiresAt,
 				DnsNames:    []string{"dns1", "dns2"},
 				Downstream:  true,
+				Hint:        "external",
 			},
 		},
 		{
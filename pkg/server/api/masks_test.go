@@ -33,5 +33,7 @@ func TestAllTrueMasks(t *testing.T) {
 		Downstream:    true,
 		ExpiresAt:     true,
 		DnsNames:      true,
+		JwtSvidTtl:    true,
+		Hint:          true,
 	}, AllTrueEntryMask)
 }
@@ -321,7 +321,7 @@ func (s *Service) NewJWTSVID(ctx context.Context, req *svid.NewJWTSVIDRequest) (
 		return nil, status.Error(codes.NotFound, "entry not found or not authorized")
 	}
 
-	jwtsvid, err := s.mintJWTSVID(ctx, entry.SpiffeId, req.Audience, entry.Ttl)
+	jwtsvid, err := s.mintJWTSVID(ctx, entry.SpiffeId, req.Audience, entry.JwtSvidTtl)
 	if err != nil {
 		return nil, err
 	}
@@ -342,7 +342,7 @@ func (s *Service) NewDownstreamX509CA(ctx context.Context, req *svid.NewDownstre
 	downstreamEntries, isDownstream := rpccontext.CallerDownstreamEntries(ctx)
 	if !isDownstream {
 		log.Error("Caller is not a downstream workload")
-		return nil, status.Error(codes.Internal, "caller is not a downstream workload")
+		return nil, status.Error(codes.PermissionDenied, "caller is not a downstream workload")
 	}
 
 	entry := downstreamEntries[0]
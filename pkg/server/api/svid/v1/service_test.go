@@ -393,10 +393,11 @@ func TestServiceNewJWTSVID(t *testing.T) {
 		SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"},
 	}
 	entryWithTTL := &types.Entry{
-		Id:       "agent-entry-ttl-id",
-		ParentId: api.ProtoFromID(agentID),
-		SpiffeId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent-ttl"},
-		Ttl:      10,
+		Id:         "agent-entry-ttl-id",
+		ParentId:   api.ProtoFromID(agentID),
+		SpiffeId:   &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent-ttl"},
+		Ttl:        10,
+		JwtSvidTtl: 10,
 	}
 	invalidEntry := &types.Entry{
 		Id:       "invalid-entry",
@@ -980,7 +981,7 @@ func TestNewDownstreamX509CA(t *testing.T) {
 			err:            "caller is not a downstream workload",
 			failSigning:    false,
 			csr:            []byte{1, 2, 3},
-			code:           codes.Internal,
+			code:           codes.PermissionDenied,
 			fetcherErr:     "",
 			entry:          nil,
 		},
@@ -0,0 +1,192 @@
+// Package audit records structured audit events for the changes made through
+// the server APIs and the identities issued by the server.
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// Actions recorded in audit events
+const (
+	AttestAgent           = "attest_agent"
+	BanAgent              = "ban_agent"
+	CreateEntry           = "create_registration_entry"
+	CreateFederatedBundle = "create_federated_bundle"
+	CreateJoinToken       = "create_join_token"
+	DeleteEntry           = "delete_registration_entry"
+	DeleteFederatedBundle = "delete_federated_bundle"
+	EvictAgent            = "evict_agent"
+	ForceAgentRotation    = "force_agent_rotation"
+	MintJWTSVID           = "mint_jwt_svid"
+	MintX509SVID          = "mint_x509_svid"
+	PruneAgents           = "prune_agents"
+	SignX509CASVID        = "sign_x509_ca_svid"
+	UpdateEntry           = "update_registration_entry"
+	UpdateFederatedBundle = "update_federated_bundle"
+)
+
+// Results recorded in audit events
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+const callerUnknown = "unknown"
+
+// Sink is where audit events are written to. Each event is a JSON document.
+type Sink interface {
+	Write(event []byte) error
+	Close() error
+}
+
+// Logger writes audit events to its sinks. A nil Logger discards the events,
+// so callers don't need to check whether auditing is enabled.
+type Logger struct {
+	log   logrus.FieldLogger
+	clock clock.Clock
+
+	mu    sync.Mutex
+	sinks []Sink
+}
+
+// New returns a logger writing audit events to the given sinks. Failures to
+// write an event are logged to log.
+func New(log logrus.FieldLogger, sinks ...Sink) *Logger {
+	return &Logger{
+		log:   log,
+		clock: clock.New(),
+		sinks: sinks,
+	}
+}
+
+// Start starts an audit event for an action performed by the caller. The
+// event is written once Done is called.
+func (l *Logger) Start(caller, action string) *Event {
+	if l == nil {
+		return nil
+	}
+	if caller == "" {
+		caller = callerUnknown
+	}
+	return &Event{
+		logger: l,
+		Caller: caller,
+		Action: action,
+	}
+}
+
+// Close closes the sinks of the logger.
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var errs []string
+	for _, sink := range l.sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	l.sinks = nil
+	if len(errs) > 0 {
+		return errors.New("unable to close audit sinks: " + strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (l *Logger) write(event *Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		l.log.WithError(err).WithField(telemetry.Action, event.Action).Error("Failed to write audit event")
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, sink := range l.sinks {
+		if err := sink.Write(data); err != nil {
+			l.log.WithError(err).WithField(telemetry.Action, event.Action).Error("Failed to write audit event")
+		}
+	}
+}
+
+// Event is an audit event: who (the caller) did what (the action and its
+// parameters) and with which result.
+type Event struct {
+	logger *Logger
+
+	Time   time.Time              `json:"time"`
+	Caller string                 `json:"caller"`
+	Action string                 `json:"action"`
+	Result string                 `json:"result"`
+	Error  string                 `json:"error,omitempty"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// AddParam adds a request parameter, or some other detail of the action, to
+// the event. Secrets must never be added.
+func (e *Event) AddParam(name string, value interface{}) {
+	if e == nil {
+		return
+	}
+	if e.Params == nil {
+		e.Params = make(map[string]interface{})
+	}
+	e.Params[name] = value
+}
+
+// Done records the result of the action and writes the event. It is meant
+// to be deferred with a pointer to the named error returned by the caller.
+func (e *Event) Done(errp *error) {
+	if e == nil {
+		return
+	}
+
+	e.Time = e.logger.clock.Now().UTC()
+	e.Result = ResultSuccess
+	if errp != nil && *errp != nil {
+		e.Result = ResultFailure
+		e.Error = (*errp).Error()
+	}
+	e.logger.write(e)
+}
+
+// AddRegistrationEntry adds the fields of a registration entry to the event.
+func (e *Event) AddRegistrationEntry(entry *common.RegistrationEntry) {
+	if e == nil || entry == nil {
+		return
+	}
+
+	selectors := make([]string, 0, len(entry.Selectors))
+	for _, selector := range entry.Selectors {
+		selectors = append(selectors, selector.Type+":"+selector.Value)
+	}
+
+	if entry.EntryId != "" {
+		e.AddParam("entry_id", entry.EntryId)
+	}
+	e.AddParam("spiffe_id", entry.SpiffeId)
+	e.AddParam("parent_id", entry.ParentId)
+	e.AddParam("selectors", selectors)
+	e.AddParam("ttl", entry.Ttl)
+	e.AddParam("admin", entry.Admin)
+	e.AddParam("downstream", entry.Downstream)
+	if len(entry.DnsNames) > 0 {
+		e.AddParam("dns_names", entry.DnsNames)
+	}
+	if len(entry.FederatesWith) > 0 {
+		e.AddParam("federates_with", entry.FederatesWith)
+	}
+}
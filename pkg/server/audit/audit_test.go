@@ -0,0 +1,160 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSuccess(t *testing.T) {
+	logger, sink, clk := newTestLogger(t)
+
+	var err error
+	event := logger.Start("spiffe://example.org/admin", CreateJoinToken)
+	event.AddParam("ttl", 60)
+	event.Done(&err)
+
+	events := sink.Events(t)
+	require.Len(t, events, 1)
+	assert.Equal(t, map[string]interface{}{
+		"time":   clk.Now().UTC().Format(time.RFC3339Nano),
+		"caller": "spiffe://example.org/admin",
+		"action": CreateJoinToken,
+		"result": ResultSuccess,
+		"params": map[string]interface{}{
+			"ttl": 60.0,
+		},
+	}, events[0])
+}
+
+func TestEventFailure(t *testing.T) {
+	logger, sink, _ := newTestLogger(t)
+
+	err := errors.New("oh no")
+	logger.Start("", DeleteEntry).Done(&err)
+
+	events := sink.Events(t)
+	require.Len(t, events, 1)
+	assert.Equal(t, callerUnknown, events[0]["caller"])
+	assert.Equal(t, ResultFailure, events[0]["result"])
+	assert.Equal(t, "oh no", events[0]["error"])
+	assert.NotContains(t, events[0], "params")
+}
+
+func TestEventAddRegistrationEntry(t *testing.T) {
+	logger, sink, _ := newTestLogger(t)
+
+	var err error
+	event := logger.Start("caller", CreateEntry)
+	event.AddRegistrationEntry(&common.RegistrationEntry{
+		EntryId:  "ENTRYID",
+		SpiffeId: "spiffe://example.org/workload",
+		ParentId: "spiffe://example.org/agent",
+		Selectors: []*common.Selector{
+			{Type: "unix", Value: "uid:1000"},
+		},
+		Ttl:           3600,
+		FederatesWith: []string{"spiffe://otherdomain.test"},
+	})
+	event.Done(&err)
+
+	events := sink.Events(t)
+	require.Len(t, events, 1)
+	assert.Equal(t, map[string]interface{}{
+		"entry_id":       "ENTRYID",
+		"spiffe_id":      "spiffe://example.org/workload",
+		"parent_id":      "spiffe://example.org/agent",
+		"selectors":      []interface{}{"unix:uid:1000"},
+		"ttl":            3600.0,
+		"admin":          false,
+		"downstream":     false,
+		"federates_with": []interface{}{"spiffe://otherdomain.test"},
+	}, events[0]["params"])
+}
+
+func TestNilLogger(t *testing.T) {
+	var logger *Logger
+	var err error
+
+	event := logger.Start("caller", BanAgent)
+	assert.Nil(t, event)
+	event.AddParam("spiffe_id", "spiffe://example.org/spire/agent/foo")
+	event.AddRegistrationEntry(&common.RegistrationEntry{})
+	event.Done(&err)
+	assert.NoError(t, logger.Close())
+}
+
+func TestWriteFailureIsLogged(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	sink := &fakeSink{err: errors.New("disk full")}
+	logger := New(log, sink)
+
+	var err error
+	logger.Start("caller", PruneAgents).Done(&err)
+
+	require.Len(t, hook.AllEntries(), 1)
+	assert.Equal(t, "Failed to write audit event", hook.LastEntry().Message)
+	assert.Equal(t, "disk full", hook.LastEntry().Data["error"].(error).Error())
+}
+
+func TestClose(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	sinkA := &fakeSink{}
+	sinkB := &fakeSink{closeErr: errors.New("oops")}
+	logger := New(log, sinkA, sinkB)
+
+	assert.EqualError(t, logger.Close(), "unable to close audit sinks: oops")
+	assert.True(t, sinkA.closed)
+	assert.True(t, sinkB.closed)
+
+	// Events written after closing go nowhere
+	var err error
+	logger.Start("caller", BanAgent).Done(&err)
+	assert.Empty(t, sinkA.events)
+}
+
+func newTestLogger(t *testing.T) (*Logger, *fakeSink, *clock.Mock) {
+	log, _ := test.NewNullLogger()
+	clk := clock.NewMock(t)
+	sink := &fakeSink{}
+	logger := New(log, sink)
+	logger.clock = clk
+	return logger, sink, clk
+}
+
+type fakeSink struct {
+	events   [][]byte
+	err      error
+	closeErr error
+	closed   bool
+}
+
+func (s *fakeSink) Write(event []byte) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	s.closed = true
+	return s.closeErr
+}
+
+func (s *fakeSink) Events(t *testing.T) []map[string]interface{} {
+	var events []map[string]interface{}
+	for _, data := range s.events {
+		event := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal(data, &event))
+		events = append(events, event)
+	}
+	return events
+}
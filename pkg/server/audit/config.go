@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Config configures the sinks audit events are written to. Auditing is
+// disabled when no sink is configured.
+type Config struct {
+	File    *FileConfig
+	Syslog  *SyslogConfig
+	Webhook *WebhookConfig
+}
+
+// FileConfig configures a FileSink.
+type FileConfig struct {
+	Path       string
+	MaxSize    int64
+	MaxBackups int
+}
+
+// SyslogConfig configures a syslog sink. An empty network and address log
+// to the local syslog daemon.
+type SyslogConfig struct {
+	Network string
+	Address string
+	Tag     string
+}
+
+// WebhookConfig configures a WebhookSink.
+type WebhookConfig struct {
+	URL     string
+	Timeout time.Duration
+}
+
+// Open creates the sinks described by the configuration and returns a logger
+// writing to them. It returns a nil logger, which discards events, if no
+// sink is configured.
+func Open(log logrus.FieldLogger, config Config) (*Logger, error) {
+	var sinks []Sink
+	closeSinks := func() {
+		for _, sink := range sinks {
+			sink.Close()
+		}
+	}
+
+	if config.File != nil {
+		sink, err := NewFileSink(config.File.Path, config.File.MaxSize, config.File.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	if config.Syslog != nil {
+		sink, err := NewSyslogSink(config.Syslog.Network, config.Syslog.Address, config.Syslog.Tag)
+		if err != nil {
+			closeSinks()
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	if config.Webhook != nil {
+		sinks = append(sinks, NewWebhookSink(log, config.Webhook.URL, config.Webhook.Timeout))
+	}
+
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+	return New(log, sinks...), nil
+}
@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenWithoutSinks(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	logger, err := Open(log, Config{})
+	require.NoError(t, err)
+	assert.Nil(t, logger)
+}
+
+func TestOpenWithFileSink(t *testing.T) {
+	dir, path := newTempAuditFile(t)
+	defer os.RemoveAll(dir)
+
+	log, _ := test.NewNullLogger()
+	logger, err := Open(log, Config{
+		File: &FileConfig{Path: path},
+	})
+	require.NoError(t, err)
+
+	var callErr error
+	logger.Start("caller", EvictAgent).Done(&callErr)
+	require.NoError(t, logger.Close())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.NotZero(t, info.Size())
+}
+
+func TestOpenFailsOnBadFilePath(t *testing.T) {
+	dir, path := newTempAuditFile(t)
+	defer os.RemoveAll(dir)
+
+	log, _ := test.NewNullLogger()
+	_, err := Open(log, Config{
+		File: &FileConfig{Path: filepath.Join(path, "missing", "audit.log")},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/spiffe/spire/pkg/common/log"
+)
+
+// FileSink appends audit events to a file, one JSON document per line. When
+// the file would grow over MaxSize bytes it is rotated: the file is renamed
+// with a ".1" suffix, previous backups are shifted (".1" to ".2" and so on)
+// and backups over MaxBackups are removed.
+type FileSink struct {
+	f *log.RotatingFile
+}
+
+// NewFileSink opens the file at path for appending audit events. A maxSize
+// of zero disables rotation.
+func NewFileSink(path string, maxSize int64, maxBackups int) (*FileSink, error) {
+	f, err := log.OpenRotatingFile(path, 0600, maxSize, 0, maxBackups)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open audit file: %v", err)
+	}
+	return &FileSink{f: f}, nil
+}
+
+// Write writes an event to the file, rotating it first if needed.
+func (s *FileSink) Write(event []byte) error {
+	line := make([]byte, 0, len(event)+1)
+	line = append(append(line, event...), '\n')
+	_, err := s.f.Write(line)
+	return err
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	return s.f.Close()
+}
@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSinkWrite(t *testing.T) {
+	dir, path := newTempAuditFile(t)
+	defer os.RemoveAll(dir)
+
+	sink, err := NewFileSink(path, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, sink.Write([]byte(`{"a":1}`)))
+	require.NoError(t, sink.Write([]byte(`{"b":2}`)))
+	require.NoError(t, sink.Close())
+
+	assertFileContents(t, path, "{\"a\":1}\n{\"b\":2}\n")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	assert.EqualError(t, sink.Write([]byte(`{}`)), `file "`+path+`" is closed`)
+}
+
+func TestFileSinkAppends(t *testing.T) {
+	dir, path := newTempAuditFile(t)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(path, []byte("existing\n"), 0600))
+
+	sink, err := NewFileSink(path, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, sink.Write([]byte("new")))
+	require.NoError(t, sink.Close())
+
+	assertFileContents(t, path, "existing\nnew\n")
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	dir, path := newTempAuditFile(t)
+	defer os.RemoveAll(dir)
+
+	// Each line is 4 bytes so two lines fit in a file
+	sink, err := NewFileSink(path, 8, 2)
+	require.NoError(t, err)
+	for _, event := range []string{"aaa", "bbb", "ccc", "ddd", "eee", "fff", "ggg"} {
+		require.NoError(t, sink.Write([]byte(event)))
+	}
+	require.NoError(t, sink.Close())
+
+	assertFileContents(t, path, "ggg\n")
+	assertFileContents(t, path+".1", "eee\nfff\n")
+	assertFileContents(t, path+".2", "ccc\nddd\n")
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFileSinkRotationWithoutBackups(t *testing.T) {
+	dir, path := newTempAuditFile(t)
+	defer os.RemoveAll(dir)
+
+	sink, err := NewFileSink(path, 8, 0)
+	require.NoError(t, err)
+	for _, event := range []string{"aaa", "bbb", "ccc"} {
+		require.NoError(t, sink.Write([]byte(event)))
+	}
+	require.NoError(t, sink.Close())
+
+	assertFileContents(t, path, "ccc\n")
+	_, err = os.Stat(path + ".1")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestNewFileSinkFailsOnBadPath(t *testing.T) {
+	dir, path := newTempAuditFile(t)
+	defer os.RemoveAll(dir)
+
+	_, err := NewFileSink(filepath.Join(path, "missing", "audit.log"), 0, 0)
+	assert.Error(t, err)
+}
+
+func newTempAuditFile(t *testing.T) (string, string) {
+	dir, err := ioutil.TempDir("", "audit-test")
+	require.NoError(t, err)
+	return dir, filepath.Join(dir, "audit.log")
+}
+
+func assertFileContents(t *testing.T, path, expected string) {
+	actual, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+}
@@ -0,0 +1,33 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/spiffe/spire/pkg/common/log"
+)
+
+// SyslogSink writes audit events to syslog with the AUTH facility.
+type SyslogSink struct {
+	w *log.SyslogWriter
+}
+
+// NewSyslogSink connects to the syslog daemon at address using network
+// (e.g. "udp"). If network is empty, it connects to the local syslog
+// daemon.
+func NewSyslogSink(network, address, tag string) (*SyslogSink, error) {
+	w, err := log.DialSyslog(network, address, tag, log.SyslogAuth)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to syslog: %v", err)
+	}
+	return &SyslogSink{w: w}, nil
+}
+
+// Write writes an event to syslog.
+func (s *SyslogSink) Write(event []byte) error {
+	return s.w.Info(string(event))
+}
+
+// Close closes the connection to syslog.
+func (s *SyslogSink) Close() error {
+	return s.w.Close()
+}
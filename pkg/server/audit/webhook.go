@@ -0,0 +1,89 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultWebhookTimeout is how long posting an event to a webhook may
+	// take when no timeout is configured.
+	DefaultWebhookTimeout = 5 * time.Second
+
+	webhookQueueSize = 1024
+)
+
+// WebhookSink posts audit events as JSON to a URL. Events are posted in the
+// background so API calls are not held up by the webhook. Events are dropped
+// when the webhook falls too far behind.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+	log    logrus.FieldLogger
+
+	queue chan []byte
+	done  chan struct{}
+}
+
+// NewWebhookSink returns a sink posting audit events to url.
+func NewWebhookSink(log logrus.FieldLogger, url string, timeout time.Duration) *WebhookSink {
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	s := &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+		log:    log,
+		queue:  make(chan []byte, webhookQueueSize),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Write queues an event to be posted to the webhook.
+func (s *WebhookSink) Write(event []byte) error {
+	select {
+	case s.queue <- event:
+		return nil
+	default:
+		return errors.New("audit webhook queue is full; event dropped")
+	}
+}
+
+// Close waits for the queued events to be posted.
+func (s *WebhookSink) Close() error {
+	close(s.queue)
+	<-s.done
+	return nil
+}
+
+func (s *WebhookSink) run() {
+	defer close(s.done)
+	for event := range s.queue {
+		if err := s.post(event); err != nil {
+			s.log.WithError(err).Error("Failed to post audit event to webhook")
+		}
+	}
+}
+
+func (s *WebhookSink) post(event []byte) error {
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(event))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected webhook response status: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSink(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		bodies = append(bodies, string(body))
+	}))
+	defer server.Close()
+
+	log, hook := test.NewNullLogger()
+	sink := NewWebhookSink(log, server.URL, 0)
+	require.NoError(t, sink.Write([]byte(`{"a":1}`)))
+	require.NoError(t, sink.Write([]byte(`{"b":2}`)))
+
+	// Close waits for the queued events to be posted
+	require.NoError(t, sink.Close())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{`{"a":1}`, `{"b":2}`}, bodies)
+	assert.Empty(t, hook.AllEntries())
+}
+
+func TestWebhookSinkLogsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	log, hook := test.NewNullLogger()
+	sink := NewWebhookSink(log, server.URL, 0)
+	require.NoError(t, sink.Write([]byte(`{}`)))
+	require.NoError(t, sink.Close())
+
+	require.Len(t, hook.AllEntries(), 1)
+	assert.Equal(t, "Failed to post audit event to webhook", hook.LastEntry().Message)
+	assert.EqualError(t, hook.LastEntry().Data["error"].(error), "unexpected webhook response status: 500 Internal Server Error")
+}
+
+func TestWebhookSinkQueueFull(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	// Build the sink without starting the posting goroutine so the queue
+	// fills up.
+	sink := &WebhookSink{
+		log:   log,
+		queue: make(chan []byte, 1),
+	}
+	require.NoError(t, sink.Write([]byte(`{}`)))
+	assert.EqualError(t, sink.Write([]byte(`{}`)), "audit webhook queue is full; event dropped")
+}
@@ -17,6 +17,13 @@ const (
 	// bundle. It is important to try more than once within a refresh hint
 	// period so we can be resilient to temporary downtime or failures.
 	attemptsPerRefreshHint = 4
+
+	// maxFailureBackoff is the longest the manager waits before retrying a
+	// failed update when there is no local bundle to calculate the refresh
+	// hint from. It is kept short since, with SPIFFE authentication, the
+	// update cannot succeed until the local bundle is bootstrapped and this
+	// determines how fast the manager responds to that.
+	maxFailureBackoff = 5 * time.Minute
 )
 
 type TrustDomainConfig struct {
@@ -88,27 +95,25 @@ func (m *Manager) Run(ctx context.Context) error {
 
 func (m *Manager) runUpdater(ctx context.Context, trustDomain string, updater BundleUpdater) error {
 	log := m.log.WithField("trust_domain", trustDomain)
+	failures := 0
 	for {
 		var nextRefresh time.Duration
 		log.Debug("Polling for bundle update")
 		localBundle, endpointBundle, err := updater.UpdateBundle(ctx)
-		if err != nil {
-			log.WithError(err).Error("Error updating bundle")
-		}
 
 		switch {
+		case err != nil:
+			failures++
+			log.WithError(err).WithField("failures", failures).Error("Error updating bundle")
+			nextRefresh = calculateFailureBackoff(failures, localBundle)
 		case endpointBundle != nil:
+			failures = 0
 			log.Info("Bundle refreshed")
 			nextRefresh = calculateNextUpdate(endpointBundle)
 		case localBundle != nil:
+			failures = 0
 			nextRefresh = calculateNextUpdate(localBundle)
 		default:
-			// We have no bundle to use to calculate the refresh hint. Since
-			// the endpoint cannot be reached without the local bundle (until
-			// we implement web auth), we can retry more aggressively. This
-			// refresh period determines how fast we'll respond to the local
-			// bundle being bootstrapped.
-			// TODO: reevaluate once we support web auth
 			nextRefresh = bundleutil.MinimumRefreshHint
 		}
 
@@ -129,3 +134,23 @@ func (m *Manager) runUpdater(ctx context.Context, trustDomain string, updater Bu
 func calculateNextUpdate(b *bundleutil.Bundle) time.Duration {
 	return bundleutil.CalculateRefreshHint(b) / attemptsPerRefreshHint
 }
+
+// calculateFailureBackoff returns how long to wait before retrying after the
+// given number of consecutive failed updates. The wait starts at the minimum
+// refresh hint and doubles with each failure, but never exceeds the regular
+// refresh period calculated from the local bundle, if any.
+func calculateFailureBackoff(failures int, localBundle *bundleutil.Bundle) time.Duration {
+	maxBackoff := maxFailureBackoff
+	if localBundle != nil {
+		maxBackoff = calculateNextUpdate(localBundle)
+	}
+
+	backoff := bundleutil.MinimumRefreshHint
+	for i := 1; i < failures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
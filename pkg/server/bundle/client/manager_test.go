@@ -28,22 +28,52 @@ func TestManager(t *testing.T) {
 		name           string
 		localBundle    *bundleutil.Bundle
 		endpointBundle *bundleutil.Bundle
-		nextRefresh    time.Duration
+		updateErr      error
+		nextRefreshes  []time.Duration
 	}{
 		{
-			name:        "update failed to obtain local bundle",
-			nextRefresh: bundleutil.MinimumRefreshHint,
+			name:      "update failed to obtain local bundle",
+			updateErr: errors.New("failed to fetch local bundle"),
+			// backs off from the minimum refresh hint up to maxFailureBackoff
+			nextRefreshes: []time.Duration{
+				time.Minute,
+				2 * time.Minute,
+				4 * time.Minute,
+				maxFailureBackoff,
+				maxFailureBackoff,
+			},
 		},
 		{
 			name:        "update failed to obtain endpoint bundle",
 			localBundle: localBundle,
-			nextRefresh: calculateNextUpdate(localBundle),
+			updateErr:   errors.New("failed to fetch endpoint bundle"),
+			// backs off from the minimum refresh hint up to the local
+			// bundle refresh period
+			nextRefreshes: []time.Duration{
+				time.Minute,
+				2 * time.Minute,
+				4 * time.Minute,
+				8 * time.Minute,
+				calculateNextUpdate(localBundle),
+				calculateNextUpdate(localBundle),
+			},
+		},
+		{
+			name:        "update found no changes",
+			localBundle: localBundle,
+			nextRefreshes: []time.Duration{
+				calculateNextUpdate(localBundle),
+				calculateNextUpdate(localBundle),
+			},
 		},
 		{
 			name:           "update obtained endpoint bundle",
 			localBundle:    localBundle,
 			endpointBundle: endpointBundle,
-			nextRefresh:    calculateNextUpdate(endpointBundle),
+			nextRefreshes: []time.Duration{
+				calculateNextUpdate(endpointBundle),
+				calculateNextUpdate(endpointBundle),
+			},
 		},
 	}
 
@@ -52,23 +82,60 @@ func TestManager(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			clock := clock.NewMock(t)
 
-			updater := newFakeBundleUpdater(testCase.localBundle, testCase.endpointBundle)
+			updater := newFakeBundleUpdater(testCase.localBundle, testCase.endpointBundle, testCase.updateErr)
 
 			done := startManager(t, clock, updater)
 			defer done()
 
-			// wait for the initial refresh
-			waitForRefresh(t, clock, testCase.nextRefresh)
-			require.Equal(t, 1, updater.UpdateCount())
-
-			// advance time and make sure another refresh happens
-			clock.Add(testCase.nextRefresh + time.Millisecond)
-			waitForRefresh(t, clock, testCase.nextRefresh)
-			require.Equal(t, 2, updater.UpdateCount())
+			for i, nextRefresh := range testCase.nextRefreshes {
+				if i > 0 {
+					// advance time and make sure another refresh happens
+					clock.Add(testCase.nextRefreshes[i-1] + time.Millisecond)
+				}
+				waitForRefresh(t, clock, nextRefresh)
+				require.Equal(t, i+1, updater.UpdateCount())
+			}
 		})
 	}
 }
 
+func TestManagerResetsBackoffOnSuccess(t *testing.T) {
+	localBundle := bundleutil.BundleFromRootCA("spiffe://domain.test", createCACertificate(t, "local"))
+	localBundle.SetRefreshHint(time.Hour)
+
+	clock := clock.NewMock(t)
+	updater := newFakeBundleUpdater(localBundle, nil, errors.New("failed to fetch endpoint bundle"))
+
+	done := startManager(t, clock, updater)
+	defer done()
+
+	waitForRefresh(t, clock, time.Minute)
+	clock.Add(time.Minute + time.Millisecond)
+	waitForRefresh(t, clock, 2*time.Minute)
+
+	// once an update succeeds, the regular refresh period is used
+	updater.SetErr(nil)
+	clock.Add(2*time.Minute + time.Millisecond)
+	waitForRefresh(t, clock, calculateNextUpdate(localBundle))
+
+	// and the backoff starts over on the next failure
+	updater.SetErr(errors.New("failed to fetch endpoint bundle"))
+	clock.Add(calculateNextUpdate(localBundle) + time.Millisecond)
+	waitForRefresh(t, clock, time.Minute)
+}
+
+func TestCalculateFailureBackoff(t *testing.T) {
+	localBundle := bundleutil.BundleFromRootCA("spiffe://domain.test", createCACertificate(t, "local"))
+	localBundle.SetRefreshHint(time.Minute * 2)
+
+	// the regular refresh period is shorter than the minimum refresh hint
+	require.Equal(t, calculateNextUpdate(localBundle), calculateFailureBackoff(1, localBundle))
+	require.Equal(t, calculateNextUpdate(localBundle), calculateFailureBackoff(10, localBundle))
+
+	// large failure counts are capped
+	require.Equal(t, maxFailureBackoff, calculateFailureBackoff(1000, nil))
+}
+
 func startManager(t *testing.T, clock clock.Clock, updater BundleUpdater) func() {
 	log, _ := test.NewNullLogger()
 	ds := fakedatastore.New(t)
@@ -128,16 +195,24 @@ type fakeBundleUpdater struct {
 	endpointBundle *bundleutil.Bundle
 
 	mu          sync.Mutex
+	err         error
 	updateCount int
 }
 
-func newFakeBundleUpdater(localBundle, endpointBundle *bundleutil.Bundle) *fakeBundleUpdater {
+func newFakeBundleUpdater(localBundle, endpointBundle *bundleutil.Bundle, err error) *fakeBundleUpdater {
 	return &fakeBundleUpdater{
 		localBundle:    localBundle,
 		endpointBundle: endpointBundle,
+		err:            err,
 	}
 }
 
+func (u *fakeBundleUpdater) SetErr(err error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.err = err
+}
+
 func (u *fakeBundleUpdater) UpdateCount() int {
 	u.mu.Lock()
 	defer u.mu.Unlock()
@@ -148,5 +223,5 @@ func (u *fakeBundleUpdater) UpdateBundle(context.Context) (*bundleutil.Bundle, *
 	u.mu.Lock()
 	defer u.mu.Unlock()
 	u.updateCount++
-	return u.localBundle, u.endpointBundle, errors.New("UNUSED")
+	return u.localBundle, u.endpointBundle, u.err
 }
@@ -62,6 +62,10 @@ func (u *bundleUpdater) UpdateBundle(ctx context.Context) (*bundleutil.Bundle, *
 		return localBundleOrNil, nil, fmt.Errorf("failed to fetch endpoint bundle: %v", err)
 	}
 
+	if err := validateEndpointBundle(endpointBundle); err != nil {
+		return localBundleOrNil, nil, fmt.Errorf("invalid endpoint bundle: %v", err)
+	}
+
 	if localBundleOrNil != nil && endpointBundle.EqualTo(localBundleOrNil) {
 		return localBundleOrNil, nil, nil
 	}
@@ -93,6 +97,17 @@ func (u *bundleUpdater) newClient(localBundleOrNil *bundleutil.Bundle) (Client,
 	return u.c.newClient(config), nil
 }
 
+// validateEndpointBundle makes sure the bundle downloaded from the endpoint
+// can replace the local bundle. A bundle without X.509 authorities is rejected
+// since it could not be used to authenticate the endpoint or workloads in the
+// trust domain.
+func validateEndpointBundle(bundle *bundleutil.Bundle) error {
+	if len(bundle.RootCAs()) == 0 {
+		return errs.New("no X.509 authorities")
+	}
+	return nil
+}
+
 func fetchBundleIfExists(ctx context.Context, ds datastore.DataStore, trustDomain string) (*bundleutil.Bundle, error) {
 	// Load the current bundle and extract the root CA certificates
 	resp, err := ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
@@ -58,6 +58,16 @@ func TestBundleUpdater(t *testing.T) {
 				bundle: bundle2,
 			},
 		},
+		{
+			name:           "endpoint bundle has no X.509 authorities",
+			localBundle:    bundle1,
+			endpointBundle: nil,
+			storedBundle:   bundle1,
+			client: fakeClient{
+				bundle: bundleutil.New("spiffe://domain.test"),
+			},
+			err: "invalid endpoint bundle: no X.509 authorities",
+		},
 		{
 			name:           "bundle fails to download",
 			localBundle:    bundle1,
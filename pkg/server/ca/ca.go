@@ -1,24 +1,35 @@
 package ca
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/andres-erbsen/clock"
+	"github.com/golang/protobuf/jsonpb"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/credentialcomposer"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/zeebo/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -80,6 +91,10 @@ type X509SVIDParams struct {
 
 	// Subject of the SVID. Default subject is used if it is empty.
 	Subject pkix.Name
+
+	// Selectors of the registration entry the SVID is signed for, if any.
+	// They are provided to the credential composers.
+	Selectors []*common.Selector
 }
 
 // X509CASVIDParams are parameters relevant to X509 CA SVID creation
@@ -112,6 +127,10 @@ type JWTSVIDParams struct {
 
 	// Audience is used for audience claims
 	Audience []string
+
+	// Selectors of the registration entry the SVID is minted for, if any.
+	// They are provided to the credential composers.
+	Selectors []*common.Selector
 }
 
 type X509CA struct {
@@ -148,6 +167,29 @@ type Config struct {
 	JWTIssuer   string
 	Clock       clock.Clock
 	CASubject   pkix.Name
+
+	// CredentialComposers are asked for additional claims to include in
+	// the JWT-SVIDs, and for the attributes of the X509-SVIDs, signed by the
+	// CA.
+	CredentialComposers []catalog.CredentialComposer
+
+	// AllowedJWTSVIDClaims are the additional claims that credential
+	// composers are allowed to include in JWT-SVIDs. Other claims returned
+	// by the composers are discarded.
+	AllowedJWTSVIDClaims []string
+
+	// AllowedX509SVIDExtensions are the OIDs of the extensions that
+	// credential composers are allowed to add to X509-SVIDs. Other
+	// extensions returned by the composers are discarded.
+	AllowedX509SVIDExtensions []string
+
+	// MaxSVIDTTL, if non-zero, is the maximum time-to-live of the X509 and
+	// JWT SVIDs signed by the CA. Longer requested TTLs are clamped.
+	MaxSVIDTTL time.Duration
+
+	// SigningConcurrency, if non-zero, bounds the number of X509 and JWT
+	// SVIDs signed concurrently. Callers over the bound wait for their turn.
+	SigningConcurrency int
 }
 
 type CA struct {
@@ -158,6 +200,15 @@ type CA struct {
 	jwtKey *JWTKey
 
 	jwtSigner *jwtsvid.Signer
+
+	allowedJWTSVIDClaims      map[string]bool
+	allowedX509SVIDExtensions map[string]bool
+
+	// signingSlots bounds the number of concurrent signings when
+	// SigningConcurrency is set. signingQueueDepth counts the callers
+	// waiting for a slot.
+	signingSlots      chan struct{}
+	signingQueueDepth int32
 }
 
 func NewCA(config Config) *CA {
@@ -171,12 +222,30 @@ func NewCA(config Config) *CA {
 		config.Clock = clock.New()
 	}
 
+	allowedJWTSVIDClaims := make(map[string]bool, len(config.AllowedJWTSVIDClaims))
+	for _, claim := range config.AllowedJWTSVIDClaims {
+		allowedJWTSVIDClaims[claim] = true
+	}
+
+	allowedX509SVIDExtensions := make(map[string]bool, len(config.AllowedX509SVIDExtensions))
+	for _, oid := range config.AllowedX509SVIDExtensions {
+		allowedX509SVIDExtensions[oid] = true
+	}
+
+	var signingSlots chan struct{}
+	if config.SigningConcurrency > 0 {
+		signingSlots = make(chan struct{}, config.SigningConcurrency)
+	}
+
 	return &CA{
 		c: config,
 		jwtSigner: jwtsvid.NewSigner(jwtsvid.SignerConfig{
 			Clock:  config.Clock,
 			Issuer: config.JWTIssuer,
 		}),
+		allowedJWTSVIDClaims:      allowedJWTSVIDClaims,
+		allowedX509SVIDExtensions: allowedX509SVIDExtensions,
+		signingSlots:              signingSlots,
 	}
 }
 
@@ -205,16 +274,22 @@ func (ca *CA) SetJWTKey(jwtKey *JWTKey) {
 }
 
 func (ca *CA) SignX509SVID(ctx context.Context, params X509SVIDParams) ([]*x509.Certificate, error) {
-	return ca.signX509SVID(params, ca.X509CA())
+	release, err := ca.acquireSigningSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return ca.signX509SVID(ctx, params, ca.X509CA(), true)
 }
 
 func (ca *CA) SignServerX509SVID(ctx context.Context, params ServerX509SVIDParams) ([]*x509.Certificate, error) {
 	x509CA := ca.X509CA()
 
-	certs, err := ca.signX509SVID(X509SVIDParams{
+	certs, err := ca.signX509SVID(ctx, X509SVIDParams{
 		SpiffeID:  idutil.ServerID(ca.c.TrustDomain.Host),
 		PublicKey: params.PublicKey,
-	}, x509CA)
+	}, x509CA, false)
 	if err != nil {
 		return nil, err
 	}
@@ -230,7 +305,10 @@ func (ca *CA) SignServerX509SVID(ctx context.Context, params ServerX509SVIDParam
 	return certs, nil
 }
 
-func (ca *CA) signX509SVID(params X509SVIDParams, x509CA *X509CA) ([]*x509.Certificate, error) {
+// signX509SVID signs an X509-SVID. The credential composers are only asked
+// for its attributes when compose is true, which is not the case for the
+// SVID of the server itself.
+func (ca *CA) signX509SVID(ctx context.Context, params X509SVIDParams, x509CA *X509CA, compose bool) ([]*x509.Certificate, error) {
 	if x509CA == nil {
 		return nil, errs.New("X509 CA is not available for signing")
 	}
@@ -239,7 +317,7 @@ func (ca *CA) signX509SVID(params X509SVIDParams, x509CA *X509CA) ([]*x509.Certi
 		params.TTL = ca.c.X509SVIDTTL
 	}
 
-	notBefore, notAfter := ca.capLifetime(params.TTL, x509CA.Certificate.NotAfter)
+	notBefore, notAfter := ca.capSVIDLifetime(params.SpiffeID, params.TTL, x509CA.Certificate.NotAfter)
 	serialNumber, err := x509util.NewSerialNumber()
 	if err != nil {
 		return nil, err
@@ -266,6 +344,12 @@ func (ca *CA) signX509SVID(params X509SVIDParams, x509CA *X509CA) ([]*x509.Certi
 		template.DNSNames = params.DNSList
 	}
 
+	if compose {
+		if err := ca.composeX509SVID(ctx, template, params.Selectors); err != nil {
+			return nil, err
+		}
+	}
+
 	cert, err := createCertificate(template, x509CA.Certificate, template.PublicKey, x509CA.Signer)
 	if err != nil {
 		return nil, errs.New("unable to create X509 SVID: %v", err)
@@ -284,6 +368,12 @@ func (ca *CA) signX509SVID(params X509SVIDParams, x509CA *X509CA) ([]*x509.Certi
 }
 
 func (ca *CA) SignX509CASVID(ctx context.Context, params X509CASVIDParams) ([]*x509.Certificate, error) {
+	release, err := ca.acquireSigningSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	x509CA := ca.X509CA()
 	if x509CA == nil {
 		return nil, errs.New("X509 CA is not available for signing")
@@ -341,13 +431,24 @@ func (ca *CA) SignJWTSVID(ctx context.Context, params JWTSVIDParams) (string, er
 		return "", err
 	}
 
+	release, err := ca.acquireSigningSlot(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
 	ttl := params.TTL
 	if ttl <= 0 {
 		ttl = ca.c.JWTSVIDTTL
 	}
-	_, expiresAt := ca.capLifetime(ttl, jwtKey.NotAfter)
+	_, expiresAt := ca.capSVIDLifetime(params.SpiffeID, ttl, jwtKey.NotAfter)
+
+	claims, err := ca.composeJWTSVIDClaims(ctx, params)
+	if err != nil {
+		return "", err
+	}
 
-	token, err := ca.jwtSigner.SignToken(params.SpiffeID, params.Audience, expiresAt, jwtKey.Signer, jwtKey.Kid)
+	token, err := ca.jwtSigner.SignTokenWithClaims(params.SpiffeID, params.Audience, expiresAt, jwtKey.Signer, jwtKey.Kid, claims)
 	if err != nil {
 		return "", errs.New("unable to sign JWT SVID: %v", err)
 	}
@@ -362,6 +463,201 @@ func (ca *CA) SignJWTSVID(ctx context.Context, params JWTSVIDParams) (string, er
 	return token, nil
 }
 
+// composeJWTSVIDClaims asks the credential composers for additional claims
+// to include in the JWT-SVID. Claims that are not allowed by the
+// configuration are discarded.
+func (ca *CA) composeJWTSVIDClaims(ctx context.Context, params JWTSVIDParams) (map[string]interface{}, error) {
+	var claims map[string]interface{}
+	for _, composer := range ca.c.CredentialComposers {
+		resp, err := composer.ComposeJWTSVID(ctx, &credentialcomposer.ComposeJWTSVIDRequest{
+			SpiffeId:  params.SpiffeID,
+			Audience:  params.Audience,
+			Selectors: params.Selectors,
+		})
+		if err != nil {
+			return nil, errs.New("credential composer %q failed to compose JWT SVID: %v", composer.Name(), err)
+		}
+
+		composed, err := structToMap(resp.Claims)
+		if err != nil {
+			return nil, errs.New("credential composer %q returned invalid claims: %v", composer.Name(), err)
+		}
+
+		for name, value := range composed {
+			if !ca.allowedJWTSVIDClaims[name] {
+				ca.c.Log.WithFields(logrus.Fields{
+					telemetry.PluginName: composer.Name(),
+					telemetry.Claim:      name,
+				}).Warn("Discarding JWT SVID claim that is not allowed")
+				continue
+			}
+			if claims == nil {
+				claims = make(map[string]interface{})
+			}
+			claims[name] = value
+		}
+	}
+	return claims, nil
+}
+
+// composeX509SVID asks the credential composers for the attributes of the
+// X509-SVID and applies them to the template. The subject returned by a
+// composer replaces the current one and its DNS names are added to the
+// current ones. Extensions that are not allowed by the configuration are
+// discarded. Composers that don't implement ComposeX509SVID are skipped.
+func (ca *CA) composeX509SVID(ctx context.Context, template *x509.Certificate, selectors []*common.Selector) error {
+	for _, composer := range ca.c.CredentialComposers {
+		resp, err := composer.ComposeX509SVID(ctx, &credentialcomposer.ComposeX509SVIDRequest{
+			SpiffeId:   template.URIs[0].String(),
+			Selectors:  selectors,
+			Attributes: x509SVIDAttributesFromTemplate(template),
+		})
+		switch {
+		case status.Code(err) == codes.Unimplemented:
+			continue
+		case err != nil:
+			return errs.New("credential composer %q failed to compose X509 SVID: %v", composer.Name(), err)
+		case resp.Attributes == nil:
+			continue
+		}
+
+		log := ca.c.Log.WithField(telemetry.PluginName, composer.Name())
+
+		if subject := resp.Attributes.Subject; subject != nil {
+			template.Subject = pkix.Name{
+				Country:            subject.Country,
+				Organization:       subject.Organization,
+				OrganizationalUnit: subject.OrganizationalUnit,
+				Locality:           subject.Locality,
+				Province:           subject.Province,
+				StreetAddress:      subject.StreetAddress,
+				PostalCode:         subject.PostalCode,
+				SerialNumber:       subject.SerialNumber,
+				CommonName:         subject.CommonName,
+			}
+		}
+
+		for _, dnsName := range resp.Attributes.DnsNames {
+			if err := x509util.ValidateDNS(dnsName); err != nil {
+				log.WithError(err).WithField(telemetry.DNSName, dnsName).Warn("Discarding invalid X509 SVID DNS name")
+				continue
+			}
+			if !containsString(template.DNSNames, dnsName) {
+				// copy on append so the DNS names of the caller are untouched
+				template.DNSNames = append(append([]string(nil), template.DNSNames...), dnsName)
+			}
+		}
+
+		for _, extension := range resp.Attributes.Extensions {
+			if !ca.allowedX509SVIDExtensions[extension.Oid] {
+				log.WithField(telemetry.Extension, extension.Oid).Warn("Discarding X509 SVID extension that is not allowed")
+				continue
+			}
+			oid, err := x509svid.ParseOID(extension.Oid)
+			if err != nil {
+				return errs.New("credential composer %q returned an invalid extension: %v", composer.Name(), err)
+			}
+			template.ExtraExtensions = append(template.ExtraExtensions, pkix.Extension{
+				Id:       oid,
+				Critical: extension.Critical,
+				Value:    extension.Value,
+			})
+		}
+	}
+	return nil
+}
+
+func x509SVIDAttributesFromTemplate(template *x509.Certificate) *credentialcomposer.X509SVIDAttributes {
+	attributes := &credentialcomposer.X509SVIDAttributes{
+		Subject: &credentialcomposer.X509Subject{
+			Country:            template.Subject.Country,
+			Organization:       template.Subject.Organization,
+			OrganizationalUnit: template.Subject.OrganizationalUnit,
+			Locality:           template.Subject.Locality,
+			Province:           template.Subject.Province,
+			StreetAddress:      template.Subject.StreetAddress,
+			PostalCode:         template.Subject.PostalCode,
+			SerialNumber:       template.Subject.SerialNumber,
+			CommonName:         template.Subject.CommonName,
+		},
+		DnsNames: template.DNSNames,
+	}
+	for _, extension := range template.ExtraExtensions {
+		attributes.Extensions = append(attributes.Extensions, &credentialcomposer.X509Extension{
+			Oid:      extension.Id.String(),
+			Critical: extension.Critical,
+			Value:    extension.Value,
+		})
+	}
+	return attributes
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func structToMap(s *structpb.Struct) (map[string]interface{}, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	buf := new(bytes.Buffer)
+	if err := new(jsonpb.Marshaler).Marshal(buf, s); err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// acquireSigningSlot waits until fewer than SigningConcurrency SVIDs are
+// being signed. The returned function releases the slot once signing is done.
+func (ca *CA) acquireSigningSlot(ctx context.Context) (func(), error) {
+	if ca.signingSlots == nil {
+		return func() {}, nil
+	}
+
+	start := time.Now()
+	telemetry_server.SetServerCASigningQueueDepthGauge(ca.c.Metrics, float32(atomic.AddInt32(&ca.signingQueueDepth, 1)))
+	defer func() {
+		telemetry_server.SetServerCASigningQueueDepthGauge(ca.c.Metrics, float32(atomic.AddInt32(&ca.signingQueueDepth, -1)))
+		telemetry_server.MeasureServerCASigningWait(ca.c.Metrics, start)
+	}()
+
+	select {
+	case ca.signingSlots <- struct{}{}:
+		return func() { <-ca.signingSlots }, nil
+	case <-ctx.Done():
+		return nil, errs.New("gave up waiting to sign: %v", ctx.Err())
+	}
+}
+
+// capSVIDLifetime returns the lifetime of an SVID with the given TTL. The TTL
+// is clamped to the configured maximum SVID TTL and the lifetime is capped to
+// the expiration of the signing key so no SVID outlives the key that signed it.
+func (ca *CA) capSVIDLifetime(spiffeID string, ttl time.Duration, expirationCap time.Time) (notBefore, notAfter time.Time) {
+	if ca.c.MaxSVIDTTL > 0 && ttl > ca.c.MaxSVIDTTL {
+		ttl = ca.c.MaxSVIDTTL
+	}
+	notBefore, notAfter = ca.capLifetime(ttl, expirationCap)
+	if notAfter.Equal(expirationCap) {
+		ca.c.Log.WithFields(logrus.Fields{
+			telemetry.SPIFFEID:   spiffeID,
+			telemetry.TTL:        ttl.Seconds(),
+			telemetry.Expiration: expirationCap.Format(time.RFC3339),
+		}).Warn("SVID TTL exceeds the remaining lifetime of the signing key; SVID lifetime capped to the signing key expiration")
+	}
+	return notBefore, notAfter
+}
+
 func (ca *CA) capLifetime(ttl time.Duration, expirationCap time.Time) (notBefore, notAfter time.Time) {
 	now := ca.c.Clock.Now()
 	notBefore = now.Add(-backdate)
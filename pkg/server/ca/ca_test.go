@@ -5,19 +5,31 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"errors"
 	"math/big"
 	"net/url"
+	"reflect"
 	"testing"
 	"time"
 
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/credentialcomposer"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 var (
@@ -255,6 +267,63 @@ func (s *CATestSuite) TestSignX509SVIDCapsTTLToCATTL() {
 	s.Require().Equal(s.clock.Now().Add(10*time.Minute), svid[0].NotAfter)
 }
 
+func (s *CATestSuite) TestSignX509SVIDWarnsWhenCappedToCAExpiration() {
+	params := s.createX509SVIDParams()
+	params.TTL = time.Hour
+	_, err := s.ca.SignX509SVID(ctx, params)
+	s.Require().NoError(err)
+	if entry := s.logHook.LastEntry(); s.NotNil(entry) {
+		s.Equal(logrus.WarnLevel, entry.Level)
+		s.Equal("SVID TTL exceeds the remaining lifetime of the signing key; SVID lifetime capped to the signing key expiration", entry.Message)
+		s.Equal("spiffe://example.org/workload", entry.Data[telemetry.SPIFFEID])
+	}
+}
+
+func (s *CATestSuite) TestSignX509SVIDClampsTTLToMaxSVIDTTL() {
+	s.ca.c.MaxSVIDTTL = time.Minute
+	params := s.createX509SVIDParams()
+	params.TTL = 5 * time.Minute
+	svid, err := s.ca.SignX509SVID(ctx, params)
+	s.Require().NoError(err)
+	s.Require().Len(svid, 1)
+	s.Require().Equal(s.clock.Now().Add(time.Minute), svid[0].NotAfter)
+	s.Require().Empty(s.logHook.AllEntries())
+}
+
+func (s *CATestSuite) TestSigningConcurrency() {
+	metrics := fakemetrics.New()
+	s.ca.c.Metrics = metrics
+	s.ca.signingSlots = make(chan struct{}, 1)
+
+	// Take the only slot
+	release, err := s.ca.acquireSigningSlot(ctx)
+	s.Require().NoError(err)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = s.ca.SignX509SVID(waitCtx, s.createX509SVIDParams())
+	s.Require().EqualError(err, "gave up waiting to sign: context deadline exceeded")
+	_, err = s.ca.SignJWTSVID(waitCtx, s.createJWTSVIDParams("example.org", 0))
+	s.Require().EqualError(err, "gave up waiting to sign: context deadline exceeded")
+
+	release()
+	_, err = s.ca.SignX509SVID(ctx, s.createX509SVIDParams())
+	s.Require().NoError(err)
+
+	var queueDepths []float32
+	var waits int
+	for _, metric := range metrics.AllMetrics() {
+		switch {
+		case reflect.DeepEqual(metric.Key, []string{telemetry.ServerCA, telemetry.Sign, telemetry.QueueDepth}):
+			queueDepths = append(queueDepths, metric.Val)
+		case reflect.DeepEqual(metric.Key, []string{telemetry.ServerCA, telemetry.Sign, telemetry.WaitTime}):
+			waits++
+		}
+	}
+	s.Equal([]float32{1, 0, 1, 0, 1, 0, 1, 0}, queueDepths)
+	s.Equal(4, waits)
+}
+
 func (s *CATestSuite) TestSignX509SVIDValidatesTrustDomain() {
 	_, err := s.ca.SignX509SVID(ctx, s.createX509SVIDParamsInDomain("foo.com"))
 	s.Require().EqualError(err, `"spiffe://foo.com/workload" does not belong to trust domain "example.org"`)
@@ -303,6 +372,15 @@ func (s *CATestSuite) TestSignJWTSVIDCapsTTLToKeyExpiry() {
 	s.Require().Equal(s.clock.Now().Add(10*time.Minute), expiresAt)
 }
 
+func (s *CATestSuite) TestSignJWTSVIDClampsTTLToMaxSVIDTTL() {
+	s.ca.c.MaxSVIDTTL = time.Minute
+	token, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("example.org", 5*time.Minute))
+	s.Require().NoError(err)
+	_, expiresAt, err := jwtsvid.GetTokenExpiry(token)
+	s.Require().NoError(err)
+	s.Require().Equal(s.clock.Now().Add(time.Minute), expiresAt)
+}
+
 func (s *CATestSuite) TestSignJWTSVIDValidatesJSR() {
 	// spiffe id for wrong trust domain
 	_, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("foo.com", 0))
@@ -315,6 +393,167 @@ func (s *CATestSuite) TestSignJWTSVIDValidatesJSR() {
 	s.Require().EqualError(err, "unable to sign JWT SVID: audience is required")
 }
 
+func (s *CATestSuite) TestSignJWTSVIDWithCredentialComposers() {
+	composer := &fakeCredentialComposer{
+		claims: map[string]*structpb.Value{
+			"tenant":      {Kind: &structpb.Value_StringValue{StringValue: "acme"}},
+			"environment": {Kind: &structpb.Value_StringValue{StringValue: "prod"}},
+		},
+	}
+	s.ca.c.CredentialComposers = []catalog.CredentialComposer{
+		fakeservercatalog.CredentialComposer("fake", composer),
+	}
+	s.ca.allowedJWTSVIDClaims = map[string]bool{"tenant": true}
+
+	params := s.createJWTSVIDParams("example.org", 0)
+	params.Selectors = []*common.Selector{{Type: "unix", Value: "uid:1000"}}
+	token, err := s.ca.SignJWTSVID(ctx, params)
+	s.Require().NoError(err)
+
+	// the composer is provided with the details of the JWT-SVID
+	s.Require().Equal(&credentialcomposer.ComposeJWTSVIDRequest{
+		SpiffeId:  params.SpiffeID,
+		Audience:  params.Audience,
+		Selectors: params.Selectors,
+	}, composer.lastReq)
+
+	// only the allowed claims are included
+	claims := s.parseClaims(token)
+	s.Equal("acme", claims["tenant"])
+	s.NotContains(claims, "environment")
+	s.Equal(params.SpiffeID, claims["sub"])
+
+	if entry := s.logHook.LastEntry(); s.NotNil(entry) {
+		s.Equal("Discarding JWT SVID claim that is not allowed", entry.Message)
+		s.Equal("environment", entry.Data[telemetry.Claim])
+	}
+}
+
+func (s *CATestSuite) TestSignJWTSVIDFailsIfCredentialComposerFails() {
+	s.ca.c.CredentialComposers = []catalog.CredentialComposer{
+		fakeservercatalog.CredentialComposer("fake", &fakeCredentialComposer{
+			err: errors.New("oh no"),
+		}),
+	}
+
+	_, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("example.org", 0))
+	s.Require().EqualError(err, `credential composer "fake" failed to compose JWT SVID: oh no`)
+}
+
+func (s *CATestSuite) TestSignX509SVIDWithCredentialComposers() {
+	composer := &fakeCredentialComposer{
+		x509Attributes: &credentialcomposer.X509SVIDAttributes{
+			Subject: &credentialcomposer.X509Subject{
+				Organization:       []string{"Acme"},
+				OrganizationalUnit: []string{"Payments"},
+				CommonName:         "legacy-app",
+			},
+			DnsNames: []string{"workload.example.org", "legacy.example.org", "not a DNS name"},
+			Extensions: []*credentialcomposer.X509Extension{
+				{Oid: "1.3.6.1.4.1.99999.1", Value: []byte{0x05, 0x00}},
+				{Oid: "1.3.6.1.4.1.99999.2", Value: []byte{0x05, 0x00}},
+				{Oid: "2.5.29.17", Value: []byte{0x05, 0x00}},
+			},
+		},
+	}
+	s.ca.c.CredentialComposers = []catalog.CredentialComposer{
+		fakeservercatalog.CredentialComposer("fake", composer),
+	}
+	s.ca.allowedX509SVIDExtensions = map[string]bool{"1.3.6.1.4.1.99999.1": true}
+
+	dnsList := []string{"workload.example.org"}
+	params := s.createX509SVIDParams()
+	params.DNSList = dnsList
+	params.Selectors = []*common.Selector{{Type: "unix", Value: "uid:1000"}}
+	svidChain, err := s.ca.SignX509SVID(ctx, params)
+	s.Require().NoError(err)
+	svid := svidChain[0]
+
+	// the composer is provided with the details of the X509-SVID
+	s.Require().Equal(&credentialcomposer.ComposeX509SVIDRequest{
+		SpiffeId:  params.SpiffeID,
+		Selectors: params.Selectors,
+		Attributes: &credentialcomposer.X509SVIDAttributes{
+			Subject: &credentialcomposer.X509Subject{
+				Country:      []string{"US"},
+				Organization: []string{"SPIRE"},
+				CommonName:   "workload.example.org",
+			},
+			DnsNames: []string{"workload.example.org"},
+		},
+	}, composer.lastX509SVIDReq)
+
+	// the subject is replaced and the valid DNS names are added
+	s.Equal([]string{"Acme"}, svid.Subject.Organization)
+	s.Equal([]string{"Payments"}, svid.Subject.OrganizationalUnit)
+	s.Empty(svid.Subject.Country)
+	s.Equal("legacy-app", svid.Subject.CommonName)
+	s.Equal([]string{"workload.example.org", "legacy.example.org"}, svid.DNSNames)
+	s.Equal([]string{"workload.example.org"}, dnsList, "DNS names of the caller were modified")
+	if s.Len(svid.URIs, 1) {
+		s.Equal(params.SpiffeID, svid.URIs[0].String())
+	}
+
+	// only the allowed extensions are attached
+	var extensionOIDs []string
+	for _, extension := range svid.Extensions {
+		extensionOIDs = append(extensionOIDs, extension.Id.String())
+		if extension.Id.String() == "1.3.6.1.4.1.99999.1" {
+			s.Equal([]byte{0x05, 0x00}, extension.Value)
+		}
+	}
+	s.Contains(extensionOIDs, "1.3.6.1.4.1.99999.1")
+	s.NotContains(extensionOIDs, "1.3.6.1.4.1.99999.2")
+
+	var warnings []string
+	for _, entry := range s.logHook.AllEntries() {
+		if entry.Level == logrus.WarnLevel {
+			warnings = append(warnings, entry.Message)
+		}
+	}
+	s.Equal([]string{
+		"Discarding invalid X509 SVID DNS name",
+		"Discarding X509 SVID extension that is not allowed",
+		"Discarding X509 SVID extension that is not allowed",
+	}, warnings)
+}
+
+func (s *CATestSuite) TestSignX509SVIDFailsIfCredentialComposerFails() {
+	s.ca.c.CredentialComposers = []catalog.CredentialComposer{
+		fakeservercatalog.CredentialComposer("fake", &fakeCredentialComposer{
+			err: errors.New("oh no"),
+		}),
+	}
+
+	_, err := s.ca.SignX509SVID(ctx, s.createX509SVIDParams())
+	s.Require().EqualError(err, `credential composer "fake" failed to compose X509 SVID: oh no`)
+}
+
+func (s *CATestSuite) TestSignX509SVIDSkipsCredentialComposersNotComposingX509SVIDs() {
+	composer := &fakeCredentialComposer{}
+	s.ca.c.CredentialComposers = []catalog.CredentialComposer{
+		fakeservercatalog.CredentialComposer("fake", composer),
+	}
+
+	svidChain, err := s.ca.SignX509SVID(ctx, s.createX509SVIDParams())
+	s.Require().NoError(err)
+	s.Equal([]string{"SPIRE"}, svidChain[0].Subject.Organization)
+	s.NotNil(composer.lastX509SVIDReq)
+}
+
+func (s *CATestSuite) TestSignServerX509SVIDIsNotComposed() {
+	composer := &fakeCredentialComposer{
+		err: errors.New("should not be called"),
+	}
+	s.ca.c.CredentialComposers = []catalog.CredentialComposer{
+		fakeservercatalog.CredentialComposer("fake", composer),
+	}
+
+	_, err := s.ca.SignServerX509SVID(ctx, s.createServerX509SVIDParams())
+	s.Require().NoError(err)
+	s.Nil(composer.lastX509SVIDReq)
+}
+
 func (s *CATestSuite) TestSignX509CASVIDNoCASet() {
 	s.ca.SetX509CA(nil)
 	_, err := s.ca.SignX509CASVID(ctx, s.createX509CASVIDParams("example.org"))
@@ -411,6 +650,14 @@ func (s *CATestSuite) createJWTSVIDParams(trustDomain string, ttl time.Duration)
 	}
 }
 
+func (s *CATestSuite) parseClaims(token string) map[string]interface{} {
+	tok, err := jwt.ParseSigned(token)
+	s.Require().NoError(err)
+	claims := make(map[string]interface{})
+	s.Require().NoError(tok.UnsafeClaimsWithoutVerification(&claims))
+	return claims
+}
+
 func (s *CATestSuite) createCACertificate(cn string, parent *x509.Certificate) *x509.Certificate {
 	keyID, err := x509util.GetSubjectKeyID(testSigner.Public())
 	s.Require().NoError(err)
@@ -442,3 +689,37 @@ func makeWorkloadID(trustDomain string) string {
 func makeTrustDomainID(trustDomain string) string {
 	return (&url.URL{Scheme: "spiffe", Host: trustDomain}).String()
 }
+
+type fakeCredentialComposer struct {
+	claims  map[string]*structpb.Value
+	err     error
+	lastReq *credentialcomposer.ComposeJWTSVIDRequest
+
+	// x509Attributes are returned by ComposeX509SVID, which is
+	// unimplemented if they are not set
+	x509Attributes  *credentialcomposer.X509SVIDAttributes
+	lastX509SVIDReq *credentialcomposer.ComposeX509SVIDRequest
+}
+
+func (c *fakeCredentialComposer) ComposeJWTSVID(ctx context.Context, req *credentialcomposer.ComposeJWTSVIDRequest) (*credentialcomposer.ComposeJWTSVIDResponse, error) {
+	c.lastReq = req
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &credentialcomposer.ComposeJWTSVIDResponse{
+		Claims: &structpb.Struct{Fields: c.claims},
+	}, nil
+}
+
+func (c *fakeCredentialComposer) ComposeX509SVID(ctx context.Context, req *credentialcomposer.ComposeX509SVIDRequest) (*credentialcomposer.ComposeX509SVIDResponse, error) {
+	c.lastX509SVIDReq = req
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.x509Attributes == nil {
+		return nil, status.Error(codes.Unimplemented, "not implemented")
+	}
+	return &credentialcomposer.ComposeX509SVIDResponse{
+		Attributes: c.x509Attributes,
+	}, nil
+}
@@ -22,6 +22,8 @@ import (
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/fips"
+	"github.com/spiffe/spire/pkg/server/plugin/bundlepublisher"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/server/plugin/notifier"
@@ -45,6 +47,10 @@ const (
 	activationThresholdCap = sevenDays
 
 	publishJWKTimeout = 5 * time.Second
+
+	// caActivatedBacklog is the number of CA activation notifications that
+	// can be pending before new ones are dropped
+	caActivatedBacklog = 8
 )
 
 type ManagedCA interface {
@@ -65,11 +71,16 @@ type ManagerConfig struct {
 	Log            logrus.FieldLogger
 	Metrics        telemetry.Metrics
 	Clock          clock.Clock
+
+	// FIPS, if true, rejects X509 CAs signed by the UpstreamAuthority with
+	// algorithms that are not FIPS-approved.
+	FIPS bool
 }
 
 type Manager struct {
 	c                  ManagerConfig
 	bundleUpdatedCh    chan struct{}
+	caActivatedCh      chan *notifier.NotifyRequest
 	upstreamClient     *UpstreamClient
 	upstreamPluginName string
 
@@ -101,6 +112,7 @@ func NewManager(c ManagerConfig) *Manager {
 	m := &Manager{
 		c:               c,
 		bundleUpdatedCh: make(chan struct{}, 1),
+		caActivatedCh:   make(chan *notifier.NotifyRequest, caActivatedBacklog),
 	}
 
 	if upstreamAuthority, ok := c.Catalog.GetUpstreamAuthority(); ok {
@@ -140,6 +152,9 @@ func (m *Manager) Run(ctx context.Context) error {
 	if err := m.notifyBundleLoaded(ctx); err != nil {
 		return err
 	}
+	if err := m.publishBundle(ctx); err != nil {
+		m.c.Log.WithError(err).Warn("failed to publish the bundle")
+	}
 	err := util.RunTasks(ctx,
 		func(ctx context.Context) error {
 			return m.rotateEvery(ctx, rotateInterval)
@@ -153,6 +168,12 @@ func (m *Manager) Run(ctx context.Context) error {
 			m.notifyOnBundleUpdate(ctx)
 			return nil
 		},
+		func(ctx context.Context) error {
+			// notifyOnCAActivation does not fail but rather logs any errors
+			// encountered while notifying
+			m.notifyOnCAActivation(ctx)
+			return nil
+		},
 	)
 	if err == context.Canceled {
 		err = nil
@@ -242,6 +263,11 @@ func (m *Manager) prepareX509CA(ctx context.Context, slot *x509CASlot) (err erro
 		if err != nil {
 			return err
 		}
+		if m.c.FIPS {
+			if err := fips.CheckCertificate(x509CA.Certificate); err != nil {
+				return fmt.Errorf("X509 CA minted by the upstream authority is not allowed in FIPS mode: %v", err)
+			}
+		}
 	} else {
 		notBefore := now.Add(-backdate)
 		notAfter := now.Add(m.c.CATTL)
@@ -288,6 +314,14 @@ func (m *Manager) activateX509CA() {
 	}).Debug("Successfully rotated X.509 CA")
 
 	m.c.CA.SetX509CA(m.currentX509CA.x509CA)
+
+	m.caActivated(&notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_X509CaActivated{
+			X509CaActivated: &notifier.X509CAActivated{
+				Certificate: m.currentX509CA.x509CA.Certificate.Raw,
+			},
+		},
+	})
 }
 
 func (m *Manager) rotateJWTKey(ctx context.Context) error {
@@ -418,6 +452,19 @@ func (m *Manager) activateJWTKey() {
 	}).Info("JWT key activated")
 	telemetry_server.IncrActivateJWTKeyManagerCounter(m.c.Metrics)
 	m.c.CA.SetJWTKey(m.currentJWTKey.jwtKey)
+
+	publicKey, err := publicKeyFromJWTKey(m.currentJWTKey.jwtKey)
+	if err != nil {
+		m.c.Log.WithError(err).Error("Unable to notify the JWT key activation")
+		return
+	}
+	m.caActivated(&notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_JwtKeyActivated{
+			JwtKeyActivated: &notifier.JWTKeyActivated{
+				JwtKey: publicKey,
+			},
+		},
+	})
 }
 
 func (m *Manager) pruneBundleEvery(ctx context.Context, interval time.Duration) error {
@@ -732,6 +779,36 @@ func (m *Manager) notifyOnBundleUpdate(ctx context.Context) {
 			if err := m.notifyBundleUpdated(ctx); err != nil {
 				m.c.Log.WithError(err).Warn("failed to notify on bundle update")
 			}
+			if err := m.publishBundle(ctx); err != nil {
+				m.c.Log.WithError(err).Warn("failed to publish the bundle")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// caActivated queues the notification of a CA activation. Activations happen
+// while rotating, so the notifiers are invoked by notifyOnCAActivation instead
+// of holding up the rotation.
+func (m *Manager) caActivated(req *notifier.NotifyRequest) {
+	if len(m.c.Catalog.GetNotifiers()) == 0 {
+		return
+	}
+	select {
+	case m.caActivatedCh <- req:
+	default:
+		m.c.Log.Warn("Too many pending CA activation notifications; dropping notification")
+	}
+}
+
+func (m *Manager) notifyOnCAActivation(ctx context.Context) {
+	for {
+		select {
+		case req := <-m.caActivatedCh:
+			if err := m.notifyCAActivated(ctx, req); err != nil {
+				m.c.Log.WithError(err).Warn("failed to notify on CA activation")
+			}
 		case <-ctx.Done():
 			return
 		}
@@ -784,6 +861,19 @@ func (m *Manager) notifyBundleUpdated(ctx context.Context) error {
 	)
 }
 
+func (m *Manager) notifyCAActivated(ctx context.Context, req *notifier.NotifyRequest) error {
+	event := "x509 ca activated"
+	if _, ok := req.Event.(*notifier.NotifyRequest_JwtKeyActivated); ok {
+		event = "jwt key activated"
+	}
+	return m.notify(ctx, event, false, nil,
+		func(ctx context.Context, n notifier.Notifier) error {
+			_, err := n.Notify(ctx, req)
+			return err
+		},
+	)
+}
+
 func (m *Manager) notify(ctx context.Context, event string, advise bool, pre func(context.Context) error, do func(context.Context, notifier.Notifier) error) error {
 	notifiers := m.c.Catalog.GetNotifiers()
 	if len(notifiers) == 0 {
@@ -832,6 +922,47 @@ func (m *Manager) notify(ctx context.Context, event string, advise bool, pre fun
 	return nil
 }
 
+// publishBundle publishes the trust domain bundle through the
+// BundlePublisher plugins.
+func (m *Manager) publishBundle(ctx context.Context) error {
+	publishers := m.c.Catalog.GetBundlePublishers()
+	if len(publishers) == 0 {
+		return nil
+	}
+
+	bundle, err := m.fetchRequiredBundle(ctx)
+	if err != nil {
+		return err
+	}
+
+	errsCh := make(chan error, len(publishers))
+	for _, p := range publishers {
+		go func(p catalog.BundlePublisher) {
+			_, err := p.PublishBundle(ctx, &bundlepublisher.PublishBundleRequest{
+				Bundle: bundle,
+			})
+			f := m.c.Log.WithField(telemetry.BundlePublisher, p.Name())
+			if err == nil {
+				f.Debug("Bundle publisher published the bundle")
+			} else {
+				f.WithError(err).Warn("Bundle publisher failed to publish the bundle")
+			}
+			errsCh <- err
+		}(p)
+	}
+
+	var allErrs errs.Group
+	for i := 0; i < len(publishers); i++ {
+		if err := <-errsCh; err != nil {
+			allErrs.Add(err)
+		}
+	}
+	if err := allErrs.Err(); err != nil {
+		return errs.New("one or more bundle publishers returned an error: %v", err)
+	}
+	return nil
+}
+
 func (m *Manager) fetchRequiredBundle(ctx context.Context) (*common.Bundle, error) {
 	bundle, err := m.fetchOptionalBundle(ctx)
 	if err != nil {
@@ -22,6 +22,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/bundlepublisher"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager/memory"
@@ -538,6 +539,84 @@ func (s *ManagerSuite) TestRunFailsIfNotifierFails() {
 	s.Equal("Notifier failed to handle event", entry.Message)
 }
 
+func (s *ManagerSuite) TestRunNotifiesCAActivation() {
+	notifyCh := make(chan *notifier.NotifyRequest, 2)
+	s.setNotifier(fakenotifier.New(fakenotifier.Config{
+		OnNotify: fakenotifier.SendOnNotify(notifyCh),
+	}))
+	s.initSelfSignedManager()
+
+	// time out in a minute if the activations are never notified
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.m.Run(ctx)
+	}()
+
+	var x509CA *notifier.X509CAActivated
+	var jwtKey *notifier.JWTKeyActivated
+	for x509CA == nil || jwtKey == nil {
+		select {
+		case req := <-notifyCh:
+			switch event := req.Event.(type) {
+			case *notifier.NotifyRequest_X509CaActivated:
+				x509CA = event.X509CaActivated
+			case *notifier.NotifyRequest_JwtKeyActivated:
+				jwtKey = event.JwtKeyActivated
+			}
+		case <-ctx.Done():
+			s.FailNow("timed out waiting for the CA activation notifications")
+		}
+	}
+	cancel()
+	s.Require().NoError(<-done)
+
+	s.Equal(s.currentX509CA().Certificate.Raw, x509CA.Certificate)
+	s.Equal(s.currentJWTKey().Kid, jwtKey.JwtKey.Kid)
+}
+
+func (s *ManagerSuite) TestRunPublishesBundle() {
+	s.initSelfSignedManager()
+
+	// time out in a minute if the bundle is never published
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	var actual *common.Bundle
+	s.cat.AddBundlePublisher(fakeservercatalog.BundlePublisher("fake", fakeBundlePublisher(
+		func(req *bundlepublisher.PublishBundleRequest) error {
+			actual = req.Bundle
+			// cancel immediately
+			cancel()
+			return nil
+		},
+	)))
+
+	s.Require().NoError(s.m.Run(ctx))
+
+	expected := s.fetchBundle()
+	s.RequireProtoEqual(expected, actual)
+}
+
+func (s *ManagerSuite) TestPublishBundleFailure() {
+	s.initSelfSignedManager()
+	s.cat.AddBundlePublisher(fakeservercatalog.BundlePublisher("fake", fakeBundlePublisher(
+		func(req *bundlepublisher.PublishBundleRequest) error {
+			return errors.New("ohno")
+		},
+	)))
+
+	err := s.m.publishBundle(ctx)
+	s.Require().EqualError(err, "one or more bundle publishers returned an error: ohno")
+
+	entry := s.logHook.LastEntry()
+	s.Equal("fake", entry.Data["bundle_publisher"])
+	s.Equal("ohno", fmt.Sprintf("%v", entry.Data["error"]))
+	s.Equal("Bundle publisher failed to publish the bundle", entry.Message)
+}
+
 func (s *ManagerSuite) TestPreparationThresholdCap() {
 	issuedAt := time.Now()
 	notAfter := issuedAt.Add(365 * 24 * time.Hour)
@@ -972,3 +1051,12 @@ func (s *fakeCA) SetJWTKey(jwtKey *JWTKey) {
 	defer s.mu.Unlock()
 	s.jwtKey = jwtKey
 }
+
+type fakeBundlePublisher func(*bundlepublisher.PublishBundleRequest) error
+
+func (f fakeBundlePublisher) PublishBundle(ctx context.Context, req *bundlepublisher.PublishBundleRequest) (*bundlepublisher.PublishBundleResponse, error) {
+	if err := f(req); err != nil {
+		return nil, err
+	}
+	return &bundlepublisher.PublishBundleResponse{}, nil
+}
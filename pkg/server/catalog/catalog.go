@@ -4,12 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	common_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	datastore_telemetry "github.com/spiffe/spire/pkg/common/telemetry/server/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/bundlepublisher"
+	bp_aws_s3 "github.com/spiffe/spire/pkg/server/plugin/bundlepublisher/awss3"
+	bp_aws_ssm "github.com/spiffe/spire/pkg/server/plugin/bundlepublisher/awsssm"
+	bp_gcp_cloudstorage "github.com/spiffe/spire/pkg/server/plugin/bundlepublisher/gcpcloudstorage"
+	"github.com/spiffe/spire/pkg/server/plugin/credentialcomposer"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	ds_sql "github.com/spiffe/spire/pkg/server/plugin/datastore/sql"
 	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
@@ -30,8 +36,10 @@ import (
 	nr_azure_msi "github.com/spiffe/spire/pkg/server/plugin/noderesolver/azure"
 	nr_noop "github.com/spiffe/spire/pkg/server/plugin/noderesolver/noop"
 	"github.com/spiffe/spire/pkg/server/plugin/notifier"
+	no_entrypolicy "github.com/spiffe/spire/pkg/server/plugin/notifier/entrypolicy"
 	no_gcs_bundle "github.com/spiffe/spire/pkg/server/plugin/notifier/gcsbundle"
 	no_k8sbundle "github.com/spiffe/spire/pkg/server/plugin/notifier/k8sbundle"
+	no_webhook "github.com/spiffe/spire/pkg/server/plugin/notifier/webhook"
 	"github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
 	up_awspca "github.com/spiffe/spire/pkg/server/plugin/upstreamauthority/awspca"
 	up_awssecret "github.com/spiffe/spire/pkg/server/plugin/upstreamauthority/awssecret"
@@ -39,6 +47,7 @@ import (
 	up_spire "github.com/spiffe/spire/pkg/server/plugin/upstreamauthority/spire"
 	up_vault "github.com/spiffe/spire/pkg/server/plugin/upstreamauthority/vault"
 	"github.com/spiffe/spire/pkg/server/plugin/upstreamca"
+	"github.com/spiffe/spire/pkg/server/syncevents"
 )
 
 var (
@@ -78,6 +87,12 @@ var (
 		// Notifiers
 		no_k8sbundle.BuiltIn(),
 		no_gcs_bundle.BuiltIn(),
+		no_entrypolicy.BuiltIn(),
+		no_webhook.BuiltIn(),
+		// BundlePublishers
+		bp_aws_s3.BuiltIn(),
+		bp_aws_ssm.BuiltIn(),
+		bp_gcp_cloudstorage.BuiltIn(),
 	}
 )
 
@@ -87,6 +102,8 @@ type Catalog interface {
 	GetNodeResolverNamed(name string) (noderesolver.NodeResolver, bool)
 	GetKeyManager() keymanager.KeyManager
 	GetNotifiers() []Notifier
+	GetCredentialComposers() []CredentialComposer
+	GetBundlePublishers() []BundlePublisher
 	GetUpstreamAuthority() (*UpstreamAuthority, bool)
 }
 
@@ -103,6 +120,8 @@ func KnownPlugins() []catalog.PluginClient {
 		upstreamca.PluginClient,
 		keymanager.PluginClient,
 		notifier.PluginClient,
+		credentialcomposer.PluginClient,
+		bundlepublisher.PluginClient,
 	}
 }
 
@@ -119,6 +138,16 @@ type Notifier struct {
 	notifier.Notifier
 }
 
+type CredentialComposer struct {
+	catalog.PluginInfo
+	credentialcomposer.CredentialComposer
+}
+
+type BundlePublisher struct {
+	catalog.PluginInfo
+	bundlepublisher.BundlePublisher
+}
+
 type UpstreamCA struct {
 	catalog.PluginInfo
 	upstreamca.UpstreamCA
@@ -137,6 +166,9 @@ type Plugins struct {
 	KeyManager    keymanager.KeyManager
 	Notifiers     []Notifier
 
+	CredentialComposers []CredentialComposer
+	BundlePublishers    []BundlePublisher
+
 	UpstreamAuthority *UpstreamAuthority
 }
 
@@ -164,6 +196,14 @@ func (p *Plugins) GetNotifiers() []Notifier {
 	return p.Notifiers
 }
 
+func (p *Plugins) GetCredentialComposers() []CredentialComposer {
+	return p.CredentialComposers
+}
+
+func (p *Plugins) GetBundlePublishers() []BundlePublisher {
+	return p.BundlePublishers
+}
+
 func (p *Plugins) GetUpstreamAuthority() (*UpstreamAuthority, bool) {
 	return p.UpstreamAuthority, p.UpstreamAuthority != nil
 }
@@ -177,11 +217,47 @@ type Config struct {
 	IdentityProvider hostservices.IdentityProvider
 	AgentStore       hostservices.AgentStore
 	MetricsService   common_services.MetricsService
+
+	// SyncEvents, if set, is notified of the changes to registration entries
+	// and bundles made through the DataStore.
+	SyncEvents *syncevents.Broadcaster
+
+	// DataStoreSlowCallThreshold is the elapsed time after which datastore
+	// calls are logged as slow. Zero disables slow call logging.
+	DataStoreSlowCallThreshold time.Duration
+
+	// PluginSelection is the selection policy, keyed by plugin type, used
+	// for plugin types configured with a primary and a secondary instance.
+	// The default policy is SelectionPrimary.
+	PluginSelection map[string]string
 }
 
 type Repository struct {
 	Catalog
 	catalog.Closer
+
+	log     logrus.FieldLogger
+	plugins catalog.Catalog
+}
+
+// Reconfigure configures the loaded plugins again with the given plugin
+// configuration. See catalog.Catalog for the restrictions.
+func (r *Repository) Reconfigure(ctx context.Context, pluginConfig HCLPluginConfigMap) error {
+	if err := reclassifyPortedUpstreamCAs(pluginConfig, r.log); err != nil {
+		return err
+	}
+
+	pluginConfigs, err := catalog.PluginConfigFromHCL(pluginConfig)
+	if err != nil {
+		return err
+	}
+
+	return r.plugins.Reconfigure(ctx, pluginConfigs)
+}
+
+// CheckHealth probes each of the loaded plugins. See catalog.Catalog.
+func (r *Repository) CheckHealth(ctx context.Context) []catalog.PluginHealth {
+	return r.plugins.CheckHealth(ctx)
 }
 
 // reclassifyPortedUpstreamCAs reclassify ported UpstreamCA plugins into UpstreamAuthority
@@ -189,7 +265,7 @@ func reclassifyPortedUpstreamCAs(pluginConfig catalog.HCLPluginConfigMap, log lo
 	// We only expect one UpstreamCA configuration
 	for name, config := range pluginConfig[upstreamca.Type] {
 		// in case configured UpstreamCA is ported update configuration to process it as an UpstreamAuthority
-		if !portedUpstreamCA[name] || config.PluginCmd != "" {
+		if !portedUpstreamCA[name] || config.PluginCmd != "" || config.PluginAddress != "" {
 			continue
 		}
 
@@ -209,6 +285,10 @@ func reclassifyPortedUpstreamCAs(pluginConfig catalog.HCLPluginConfigMap, log lo
 }
 
 func Load(ctx context.Context, config Config) (*Repository, error) {
+	if err := ValidatePluginSelection(config.PluginSelection); err != nil {
+		return nil, err
+	}
+
 	if err := reclassifyPortedUpstreamCAs(config.PluginConfig, config.Log); err != nil {
 		return nil, err
 	}
@@ -219,7 +299,7 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 	}
 
 	p := new(Plugins)
-	closer, err := catalog.Fill(ctx, catalog.Config{
+	cat, err := catalog.Fill(ctx, catalog.Config{
 		Log:           config.Log,
 		GlobalConfig:  config.GlobalConfig,
 		PluginConfig:  pluginConfigs,
@@ -236,7 +316,24 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 		return nil, err
 	}
 
-	p.DataStore = datastore_telemetry.WithMetrics(p.DataStore, config.Metrics)
+	// The DataStore can be an external plugin, so make sure it speaks the
+	// same version of the DataStore service.
+	if plugin, ok := p.DataStore.(datastore.Plugin); ok {
+		if err := datastore.CheckInterfaceVersion(ctx, plugin); err != nil {
+			cat.Close()
+			return nil, err
+		}
+	}
+
+	if config.SyncEvents != nil {
+		p.DataStore = syncevents.WithNotifications(p.DataStore, config.SyncEvents)
+	}
+
+	p.DataStore = datastore_telemetry.WithTelemetry(p.DataStore, datastore_telemetry.Config{
+		Metrics:           config.Metrics,
+		Log:               config.Log.WithField(telemetry.SubsystemName, telemetry.Datastore),
+		SlowCallThreshold: config.DataStoreSlowCallThreshold,
+	})
 
 	switch {
 	case p.UpstreamCA == nil:
@@ -250,8 +347,15 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 		}
 	}
 
+	if err := applyPluginSelection(config.Log, p, cat, pluginConfigs, config.PluginSelection); err != nil {
+		cat.Close()
+		return nil, err
+	}
+
 	return &Repository{
 		Catalog: p,
-		Closer:  closer,
+		Closer:  cat,
+		log:     config.Log,
+		plugins: cat,
 	}, nil
 }
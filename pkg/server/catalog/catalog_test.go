@@ -3,6 +3,7 @@ package catalog
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/sirupsen/logrus/hooks/test"
@@ -28,15 +29,29 @@ func TestLoad(t *testing.T) {
 		// Fake Datastore
 		catalog.MakePlugin("fake_ds",
 			datastore.PluginServer(fakedatastore.New(t))),
+		// Fake Datastore implementing another interface version
+		catalog.MakePlugin("fake_next_ds",
+			datastore.PluginServer(fakeNextVersionDataStore{DataStore: fakedatastore.New(t)})),
 		// Fake key manager
 		catalog.MakePlugin("fake_km",
 			keymanager.PluginServer(&fakeKeyManager{})),
+		// Another fake key manager
+		catalog.MakePlugin("fake_km2",
+			keymanager.PluginServer(&fakeKeyManager{})),
+		// Fake key manager named after a built-in one keeping keys local
+		catalog.MakePlugin("memory",
+			keymanager.PluginServer(&fakeKeyManager{})),
 		// Fake UpstreamCA
 		catalog.MakePlugin("fake_up",
 			upstreamca.PluginServer(&fakeUpstreamCAPlugin{})),
 		// Fake UpstreamAuthority
 		catalog.MakePlugin("fake_up",
 			upstreamauthority.PluginServer(&fakeUpstreamAuthorityPlugin{})),
+		// Another fake UpstreamAuthority
+		catalog.MakePlugin("fake_up2",
+			upstreamauthority.PluginServer(&fakeUpstreamAuthorityPlugin{})),
+		catalog.MakePlugin("fake_up3",
+			upstreamauthority.PluginServer(&fakeUpstreamAuthorityPlugin{})),
 	}
 
 	// Create all fakes needed on Load method
@@ -55,6 +70,8 @@ func TestLoad(t *testing.T) {
 		createHclConfig func() HCLPluginConfigMap
 		// Ported UpstreamCAs
 		ported map[string]bool
+		// Plugin selection policies
+		pluginSelection map[string]string
 		// Expected error
 		err string
 		// Expect an upstream
@@ -123,6 +140,90 @@ func TestLoad(t *testing.T) {
 			ported: map[string]bool{"fake_up": true},
 			err:    "\"fake_up\" cannot be configured as both an UpstreamCA and UpstreamAuthority",
 		},
+		{
+			name: "DataStore with incompatible interface version",
+			createHclConfig: func() HCLPluginConfigMap {
+				c := createDefaultConfig()
+				c[datastore.Type] = map[string]HCLPluginConfig{"fake_next_ds": {}}
+				return c
+			},
+			err: fmt.Sprintf("DataStore plugin implements interface version %d; version %d is required", datastore.InterfaceVersion+1, datastore.InterfaceVersion),
+		},
+		{
+			name:            "primary and secondary KeyManagers",
+			createHclConfig: createPrimaryAndSecondaryKeyManagersConfig,
+		},
+		{
+			name:            "KeyManager failover",
+			createHclConfig: createPrimaryAndSecondaryKeyManagersConfig,
+			pluginSelection: map[string]string{keymanager.Type: SelectionFailover},
+		},
+		{
+			name:            "KeyManager failover without secondary",
+			createHclConfig: createDefaultConfig,
+			pluginSelection: map[string]string{keymanager.Type: SelectionFailover},
+			err:             `KeyManager plugin selection "failover" requires a secondary KeyManager plugin`,
+		},
+		{
+			name: "KeyManager failover with a local KeyManager",
+			createHclConfig: func() HCLPluginConfigMap {
+				c := createDefaultConfig()
+				c[keymanager.Type] = map[string]HCLPluginConfig{
+					"fake_km": {PluginRole: catalog.PluginRolePrimary},
+					"memory":  {PluginRole: catalog.PluginRoleSecondary},
+				}
+				return c
+			},
+			pluginSelection: map[string]string{keymanager.Type: SelectionFailover},
+			err:             `KeyManager plugin selection "failover" is not supported with the "memory" KeyManager plugin since its keys are not replicated`,
+		},
+		{
+			name: "several KeyManagers without role",
+			createHclConfig: func() HCLPluginConfigMap {
+				c := createPrimaryAndSecondaryKeyManagersConfig()
+				c[keymanager.Type]["fake_km2"] = HCLPluginConfig{}
+				return c
+			},
+			err: `KeyManager plugin "fake_km2" must be configured with a plugin_role since several KeyManager plugins are configured`,
+		},
+		{
+			name: "UpstreamAuthority failover",
+			createHclConfig: func() HCLPluginConfigMap {
+				c := createDefaultConfig()
+				c[upstreamauthority.Type] = map[string]HCLPluginConfig{
+					"fake_up":  {PluginRole: catalog.PluginRolePrimary},
+					"fake_up2": {PluginRole: catalog.PluginRoleSecondary},
+				}
+				return c
+			},
+			pluginSelection: map[string]string{upstreamauthority.Type: SelectionFailover},
+			expectUpstream:  true,
+		},
+		{
+			name: "secondary UpstreamAuthorities",
+			createHclConfig: func() HCLPluginConfigMap {
+				c := createDefaultConfig()
+				c[upstreamauthority.Type] = map[string]HCLPluginConfig{
+					"fake_up":  {PluginRole: catalog.PluginRolePrimary},
+					"fake_up2": {PluginRole: catalog.PluginRoleSecondary},
+					"fake_up3": {PluginRole: catalog.PluginRoleSecondary},
+				}
+				return c
+			},
+			err: `UpstreamAuthority plugins "fake_up2" and "fake_up3" cannot both be the secondary instance`,
+		},
+		{
+			name:            "unsupported plugin selection",
+			createHclConfig: createDefaultConfig,
+			pluginSelection: map[string]string{datastore.Type: SelectionFailover},
+			err:             "plugin selection is not supported for DataStore plugins",
+		},
+		{
+			name:            "unsupported plugin selection policy",
+			createHclConfig: createDefaultConfig,
+			pluginSelection: map[string]string{keymanager.Type: "random"},
+			err:             `unsupported KeyManager plugin selection "random": must be primary or failover`,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -140,6 +241,7 @@ func TestLoad(t *testing.T) {
 				IdentityProvider: identityProvider,
 				AgentStore:       agentStore,
 				MetricsService:   metricsService,
+				PluginSelection:  testCase.pluginSelection,
 			})
 
 			if testCase.err != "" {
@@ -173,6 +275,25 @@ func createDefaultConfig() HCLPluginConfigMap {
 	}
 }
 
+// createPrimaryAndSecondaryKeyManagersConfig creates a HclPluginConfigMap
+// with a primary and a secondary KeyManager
+func createPrimaryAndSecondaryKeyManagersConfig() HCLPluginConfigMap {
+	c := createDefaultConfig()
+	c[keymanager.Type] = map[string]HCLPluginConfig{
+		"fake_km":  {PluginRole: catalog.PluginRolePrimary},
+		"fake_km2": {PluginRole: catalog.PluginRoleSecondary},
+	}
+	return c
+}
+
+type fakeNextVersionDataStore struct {
+	*fakedatastore.DataStore
+}
+
+func (fakeNextVersionDataStore) GetInterfaceVersion(context.Context, *datastore.GetInterfaceVersionRequest) (*datastore.GetInterfaceVersionResponse, error) {
+	return &datastore.GetInterfaceVersionResponse{Version: datastore.InterfaceVersion + 1}, nil
+}
+
 type fakeUpstreamAuthorityPlugin struct {
 	upstreamauthority.UpstreamAuthorityServer
 }
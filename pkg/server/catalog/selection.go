@@ -0,0 +1,154 @@
+package catalog
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
+	"github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
+)
+
+const (
+	// SelectionPrimary only uses the primary instance of a plugin type. The
+	// secondary instance is loaded, and health checked, but otherwise unused.
+	SelectionPrimary = "primary"
+
+	// SelectionFailover uses the primary instance of a plugin type and fails
+	// over to the secondary instance when a call to the primary fails.
+	SelectionFailover = "failover"
+)
+
+// selectablePluginTypes are the plugin types that support a selection policy.
+var selectablePluginTypes = map[string]bool{
+	keymanager.Type:        true,
+	upstreamauthority.Type: true,
+}
+
+// localKeyManagers are the built-in KeyManagers that keep their keys local to
+// the server, so no other KeyManager holds their keys and they can't take part
+// in a failover.
+var localKeyManagers = map[string]bool{
+	"disk":   true,
+	"memory": true,
+}
+
+type KeyManager struct {
+	catalog.PluginInfo
+	keymanager.KeyManager
+}
+
+// instances holds every loaded instance of the plugin types that support a
+// selection policy.
+type instances struct {
+	KeyManagers         []KeyManager
+	UpstreamAuthorities []UpstreamAuthority
+}
+
+// ValidatePluginSelection validates the selection policies, keyed by plugin
+// type.
+func ValidatePluginSelection(policies map[string]string) error {
+	for pluginType, policy := range policies {
+		if !selectablePluginTypes[pluginType] {
+			return fmt.Errorf("plugin selection is not supported for %s plugins", pluginType)
+		}
+		switch policy {
+		case SelectionPrimary, SelectionFailover:
+		default:
+			return fmt.Errorf("unsupported %s plugin selection %q: must be %s or %s", pluginType, policy, SelectionPrimary, SelectionFailover)
+		}
+	}
+	return nil
+}
+
+// applyPluginSelection checks the roles of the loaded instances of each
+// selectable plugin type and applies the selection policies to the plugins
+// filled by the catalog, which are the primary instances.
+func applyPluginSelection(log logrus.FieldLogger, p *Plugins, cat catalog.Catalog, pluginConfigs []catalog.PluginConfig, policies map[string]string) error {
+	roles := make(map[string]map[string]string)
+	for _, c := range pluginConfigs {
+		if c.Disabled {
+			continue
+		}
+		if roles[c.Type] == nil {
+			roles[c.Type] = make(map[string]string)
+		}
+		roles[c.Type][c.Name] = c.Role
+	}
+
+	var all instances
+	if err := cat.Fill(&all); err != nil {
+		return err
+	}
+
+	var kmNames []string
+	for _, km := range all.KeyManagers {
+		kmNames = append(kmNames, km.Name())
+	}
+	kmSecondary, err := findSecondary(keymanager.Type, kmNames, roles[keymanager.Type], policies[keymanager.Type])
+	if err != nil {
+		return err
+	}
+	if kmSecondary >= 0 {
+		for _, name := range kmNames {
+			if localKeyManagers[name] {
+				return fmt.Errorf("%s plugin selection %q is not supported with the %q %s plugin since its keys are not replicated", keymanager.Type, SelectionFailover, name, keymanager.Type)
+			}
+		}
+		secondary := all.KeyManagers[kmSecondary]
+		p.KeyManager = keymanager.Failover(log.WithField(telemetry.PluginType, keymanager.Type), p.KeyManager, secondary.KeyManager)
+	}
+
+	var uaNames []string
+	for _, ua := range all.UpstreamAuthorities {
+		uaNames = append(uaNames, ua.Name())
+	}
+	uaSecondary, err := findSecondary(upstreamauthority.Type, uaNames, roles[upstreamauthority.Type], policies[upstreamauthority.Type])
+	if err != nil {
+		return err
+	}
+	if uaSecondary >= 0 {
+		secondary := all.UpstreamAuthorities[uaSecondary]
+		p.UpstreamAuthority = &UpstreamAuthority{
+			PluginInfo:        p.UpstreamAuthority.PluginInfo,
+			UpstreamAuthority: upstreamauthority.Failover(log.WithField(telemetry.PluginType, upstreamauthority.Type), p.UpstreamAuthority.UpstreamAuthority, secondary.UpstreamAuthority),
+		}
+	}
+
+	return nil
+}
+
+// findSecondary checks the roles of the instances of a plugin type and
+// returns the index of the secondary instance to fail over to, or -1 if the
+// policy does not fail over.
+func findSecondary(pluginType string, names []string, roles map[string]string, policy string) (int, error) {
+	secondary := -1
+	if len(names) > 1 {
+		for i, name := range names {
+			switch roles[name] {
+			case catalog.PluginRolePrimary:
+			case catalog.PluginRoleSecondary:
+				if secondary >= 0 {
+					// plugins are loaded in no particular order
+					a, b := names[secondary], name
+					if a > b {
+						a, b = b, a
+					}
+					return -1, fmt.Errorf("%s plugins %q and %q cannot both be the secondary instance", pluginType, a, b)
+				}
+				secondary = i
+			default:
+				return -1, fmt.Errorf("%s plugin %q must be configured with a plugin_role since several %s plugins are configured", pluginType, name, pluginType)
+			}
+		}
+	}
+
+	if policy != SelectionFailover {
+		return -1, nil
+	}
+	if secondary < 0 {
+		return -1, fmt.Errorf("%s plugin selection %q requires a secondary %s plugin", pluginType, policy, pluginType)
+	}
+	return secondary, nil
+}
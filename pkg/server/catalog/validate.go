@@ -0,0 +1,76 @@
+package catalog
+
+import (
+	"context"
+	"sort"
+
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/log"
+	common_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	ds_sql "github.com/spiffe/spire/pkg/server/plugin/datastore/sql"
+	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
+)
+
+// ValidatePlugins has each configured plugin validate its configuration, as
+// described in catalog.Validate. Validating the DataStore plugin connects to
+// the database, so DataStore plugins are only validated if checkDataStore is
+// true. The builtin SQL DataStore is not configured, which would initialize
+// or migrate the database: its configuration is checked and the database is
+// connected to, without being modified.
+func ValidatePlugins(ctx context.Context, config Config, checkDataStore bool) ([]catalog.PluginError, error) {
+	if err := reclassifyPortedUpstreamCAs(config.PluginConfig, config.Log); err != nil {
+		return nil, err
+	}
+
+	pluginConfigs, err := catalog.PluginConfigFromHCL(config.PluginConfig)
+	if err != nil {
+		return nil, err
+	}
+	var pluginErrs []catalog.PluginError
+	for i := range pluginConfigs {
+		c := &pluginConfigs[i]
+		if c.Type != datastore.Type || c.Disabled {
+			continue
+		}
+		switch {
+		case !checkDataStore:
+			c.Disabled = true
+		case c.Name == "sql" && c.Path == "" && c.Address == "":
+			c.Disabled = true
+			logger := &log.HCLogAdapter{
+				Log:  config.Log.WithField(telemetry.PluginName, c.Name),
+				Name: c.Name,
+			}
+			if err := ds_sql.Validate(c.Data, logger); err != nil {
+				pluginErrs = append(pluginErrs, catalog.PluginError{
+					Type: c.Type,
+					Name: c.Name,
+					Err:  err,
+				})
+			}
+		}
+	}
+
+	pluginErrs = append(pluginErrs, catalog.Validate(ctx, catalog.Config{
+		Log:           config.Log,
+		GlobalConfig:  config.GlobalConfig,
+		PluginConfig:  pluginConfigs,
+		KnownPlugins:  KnownPlugins(),
+		KnownServices: KnownServices(),
+		BuiltIns:      BuiltIns(),
+		HostServices: []catalog.HostServiceServer{
+			hostservices.IdentityProviderHostServiceServer(config.IdentityProvider),
+			hostservices.AgentStoreHostServiceServer(config.AgentStore),
+			common_services.MetricsServiceHostServiceServer(config.MetricsService),
+		},
+	})...)
+	sort.SliceStable(pluginErrs, func(i, j int) bool {
+		if pluginErrs[i].Type != pluginErrs[j].Type {
+			return pluginErrs[i].Type < pluginErrs[j].Type
+		}
+		return pluginErrs[i].Name < pluginErrs[j].Name
+	})
+	return pluginErrs, nil
+}
@@ -10,8 +10,12 @@ import (
 	common "github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/tlspolicy"
+	"github.com/spiffe/spire/pkg/server/audit"
 	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/spiffe/spire/pkg/server/endpoints"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
+	"github.com/spiffe/spire/pkg/server/endpoints/node"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 )
 
@@ -19,6 +23,10 @@ type Config struct {
 	// Configurations for server plugins
 	PluginConfigs common.HCLPluginConfigMap
 
+	// PluginSelection is the selection policy, keyed by plugin type, for the
+	// plugin types configured with a primary and a secondary instance.
+	PluginSelection map[string]string
+
 	Log logrus.FieldLogger
 
 	// Address of SPIRE server
@@ -27,6 +35,11 @@ type Config struct {
 	// Address of the UDS SPIRE server
 	BindUDSAddress *net.UnixAddr
 
+	// Address of the dedicated mTLS listener of the registration API
+	// (optional). If set, the registration API is no longer served on
+	// BindAddress.
+	BindRegistrationAddress *net.TCPAddr
+
 	// Directory to store runtime data
 	DataDir string
 
@@ -37,12 +50,27 @@ type Config struct {
 
 	Experimental ExperimentalConfig
 
+	// GRPC tunes the gRPC servers
+	GRPC endpoints.GRPCConfig
+
+	// TLSPolicy restricts the TLS versions and cipher suites of the server
+	// listeners
+	TLSPolicy tlspolicy.Policy
+
+	// FIPS, if true, restricts the server to FIPS-approved algorithms. The
+	// rest of the configuration is expected to be restricted accordingly.
+	FIPS bool
+
 	// If true enables profiling.
 	ProfilingEnabled bool
 
 	// Port used by the pprof web server when ProfilingEnabled == true
 	ProfilingPort int
 
+	// Path of the unix socket the pprof web server listens on when
+	// ProfilingEnabled == true. It takes the place of ProfilingPort.
+	ProfilingSocketPath string
+
 	// Frequency in seconds by which each profile file will be generated.
 	ProfilingFreq int
 
@@ -52,6 +80,31 @@ type Config struct {
 	// SVIDTTL is default time-to-live for SVIDs
 	SVIDTTL time.Duration
 
+	// MaxSVIDTTL, if non-zero, is the maximum time-to-live for SVIDs.
+	// Longer TTLs requested by registration entries are clamped.
+	MaxSVIDTTL time.Duration
+
+	// DataStoreSlowCallThreshold is the elapsed time after which datastore
+	// calls are logged as slow. Zero disables slow call logging.
+	DataStoreSlowCallThreshold time.Duration
+
+	// PruneAttestedNodesExpiredFor is how long after their SVID expires
+	// attested nodes are pruned. Zero disables attested node pruning.
+	PruneAttestedNodesExpiredFor time.Duration
+
+	// TrackIssuedSVIDs enables recording every X509-SVID issued to agents
+	// and workloads, or minted through the registration API, in the
+	// datastore.
+	TrackIssuedSVIDs bool
+
+	// PruneIssuedSVIDsExpiredFor is how long after their expiration the
+	// records of issued X509-SVIDs are pruned. Zero disables pruning.
+	PruneIssuedSVIDsExpiredFor time.Duration
+
+	// ResolveNodeSelectorsInterval is how often the selectors of attested
+	// nodes are resolved again. Zero only resolves them on attestation.
+	ResolveNodeSelectorsInterval time.Duration
+
 	// CATTL is the time-to-live for the server CA. This only applies to
 	// self-signed CA certificates, otherwise it is up to the upstream CA.
 	CATTL time.Duration
@@ -60,6 +113,14 @@ type Config struct {
 	// If unset, the JWT-SVID will not have an issuer claim.
 	JWTIssuer string
 
+	// AllowedJWTSVIDClaims are the additional claims that CredentialComposer
+	// plugins are allowed to include in JWT-SVIDs.
+	AllowedJWTSVIDClaims []string
+
+	// AllowedX509SVIDExtensions are the OIDs of the extensions that
+	// CredentialComposer plugins are allowed to add to X509-SVIDs.
+	AllowedX509SVIDExtensions []string
+
 	// CASubject is the subject used in the CA certificate
 	CASubject pkix.Name
 
@@ -72,9 +133,35 @@ type Config struct {
 	// CAKeyType is the key type used for the X509 and JWT signing keys
 	CAKeyType keymanager.KeyType
 
+	// JWTKeyType is the key type used for the JWT signing keys, which
+	// determines the algorithm used to sign JWT-SVIDs. If unset, CAKeyType
+	// is used.
+	JWTKeyType keymanager.KeyType
+
 	// Federation holds the configuration needed to federate with other
 	// trust domains.
 	Federation FederationConfig
+
+	// WorkloadKeyPolicy restricts the public keys accepted in the CSRs of
+	// workload SVIDs.
+	WorkloadKeyPolicy node.KeyPolicy
+
+	// SigningConcurrency bounds the number of X509 and JWT SVIDs signed
+	// concurrently. Zero means unbounded.
+	SigningConcurrency int
+
+	// NodeAPIRateLimits configures the per IP address and per agent rate
+	// limits of the node API.
+	NodeAPIRateLimits node.RateLimits
+
+	// Audit configures where audit events are written. Auditing is
+	// disabled if no sink is configured.
+	Audit audit.Config
+
+	// HostedTrustDomains are the configurations of the additional trust
+	// domains hosted by the server process. Each is served by its own
+	// server, with its own plugins, CA, listeners and bundle endpoint.
+	HostedTrustDomains []Config
 }
 
 type ExperimentalConfig struct {
@@ -91,7 +178,13 @@ type FederationConfig struct {
 }
 
 func New(config Config) *Server {
+	var hosted []*Server
+	for _, hostedConfig := range config.HostedTrustDomains {
+		hosted = append(hosted, New(hostedConfig))
+	}
 	return &Server{
 		config: config,
+		hosted: hosted,
+		ready:  make(chan struct{}),
 	}
 }
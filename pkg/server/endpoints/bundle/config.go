@@ -1,12 +1,25 @@
 package bundle
 
-import "net"
+import (
+	"net"
+	"time"
+)
 
 type EndpointConfig struct {
 	// Address is the address on which to serve the federation bundle endpoint.
 	Address *net.TCPAddr
 
 	// ACME is the ACME configuration for the bundle endpoint.
-	// If unset, the bundle endpoint will use SPIFFE auth.
+	// If neither ACME nor DiskCertificate are set, the bundle endpoint will
+	// use SPIFFE auth.
 	ACME *ACMEConfig
+
+	// DiskCertificate is the configuration for serving the bundle endpoint
+	// with an existing certificate stored on disk. It is mutually exclusive
+	// with ACME.
+	DiskCertificate *DiskCertificateConfig
+
+	// RefreshHint is the refresh hint advertised in the served bundle. If
+	// unset, the refresh hint is calculated from the bundle contents.
+	RefreshHint time.Duration
 }
@@ -0,0 +1,64 @@
+package bundle
+
+import (
+	"crypto/tls"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/zeebo/errs"
+)
+
+// DiskCertificateConfig is the configuration for serving the bundle endpoint
+// with an existing certificate and private key stored on disk (i.e. a
+// certificate issued by a Web PKI CA).
+type DiskCertificateConfig struct {
+	// CertFilePath is the path to the PEM encoded certificate chain.
+	CertFilePath string
+
+	// KeyFilePath is the path to the PEM encoded private key.
+	KeyFilePath string
+}
+
+// DiskAuth returns a ServerAuth that serves the certificate and private key
+// stored on disk. The files are loaded on each handshake so that they can be
+// rotated without restarting the server. If they can no longer be loaded, the
+// last certificate successfully loaded is served.
+func DiskAuth(log logrus.FieldLogger, config DiskCertificateConfig) ServerAuth {
+	return &diskAuth{
+		log:    log,
+		config: config,
+	}
+}
+
+type diskAuth struct {
+	log    logrus.FieldLogger
+	config DiskCertificateConfig
+
+	mu   sync.Mutex
+	cert *tls.Certificate
+}
+
+func (a *diskAuth) GetTLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: a.getCertificate,
+	}
+}
+
+func (a *diskAuth) getCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(a.config.CertFilePath, a.config.KeyFilePath)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err != nil {
+		if a.cert == nil {
+			a.log.WithError(err).Error("Unable to load serving certificate")
+			return nil, errs.New("unable to load serving certificate: %v", err)
+		}
+		a.log.WithError(err).Warn("Unable to reload serving certificate; serving previously loaded certificate")
+		return a.cert, nil
+	}
+
+	a.cert = &cert
+	return a.cert, nil
+}
@@ -6,9 +6,12 @@ import (
 	"crypto/x509"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/systemd"
+	"github.com/spiffe/spire/pkg/common/tlspolicy"
 	"github.com/zeebo/errs"
 )
 
@@ -32,6 +35,13 @@ type ServerConfig struct {
 	Getter     Getter
 	ServerAuth ServerAuth
 
+	// RefreshHint, if non-zero, overrides the refresh hint calculated from
+	// the bundle contents.
+	RefreshHint time.Duration
+
+	// TLSPolicy restricts the TLS versions and cipher suites negotiated.
+	TLSPolicy tlspolicy.Policy
+
 	// test hooks
 	listen func(network, address string) (net.Listener, error)
 }
@@ -42,7 +52,7 @@ type Server struct {
 
 func NewServer(config ServerConfig) *Server {
 	if config.listen == nil {
-		config.listen = net.Listen
+		config.listen = listen
 	}
 	return &Server{
 		c: config,
@@ -57,9 +67,12 @@ func (s *Server) Run(ctx context.Context) error {
 		return errs.Wrap(err)
 	}
 
+	tlsConfig := s.c.ServerAuth.GetTLSConfig()
+	s.c.TLSPolicy.Apply(tlsConfig)
+
 	server := &http.Server{
 		Handler:   http.HandlerFunc(s.serveHTTP),
-		TLSConfig: s.c.ServerAuth.GetTLSConfig(),
+		TLSConfig: tlsConfig,
 	}
 
 	errCh := make(chan error, 1)
@@ -76,6 +89,20 @@ func (s *Server) Run(ctx context.Context) error {
 	}
 }
 
+// listen listens on the address, using the socket passed by systemd socket
+// activation if there is one bound to it.
+func listen(network, address string) (net.Listener, error) {
+	addr, err := net.ResolveTCPAddr(network, address)
+	if err != nil {
+		return nil, err
+	}
+	l, err := systemd.InheritedListener(addr)
+	if err != nil || l != nil {
+		return l, err
+	}
+	return net.Listen(network, address)
+}
+
 func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
 	if req.Method != "GET" {
 		http.Error(w, "405 method not allowed", http.StatusMethodNotAllowed)
@@ -93,9 +120,11 @@ func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	refreshHint := bundleutil.CalculateRefreshHint(b)
+	refreshHint := s.c.RefreshHint
+	if refreshHint <= 0 {
+		refreshHint = bundleutil.CalculateRefreshHint(b)
+	}
 
-	// TODO: bundle sequence number?
 	opts := []bundleutil.MarshalOption{
 		bundleutil.OverrideRefreshHint(refreshHint),
 	}
@@ -13,6 +13,8 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -20,6 +22,8 @@ import (
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/tlspolicy"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle/internal/acmetest"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager/memory"
@@ -41,6 +45,10 @@ func TestServer(t *testing.T) {
 	bundle := bundleutil.New("spiffe://domain.test")
 	bundle.AppendRootCA(serverCert)
 
+	sequencedBundle := bundleutil.New("spiffe://domain.test")
+	sequencedBundle.AppendRootCA(serverCert)
+	sequencedBundle.SetSequenceNumber(3)
+
 	// even though this will be SPIFFE authentication in production, there is
 	// no functional change in the code based on the server certificate
 	// returned from the getter, so for test purposes we'll just use a
@@ -56,14 +64,15 @@ func TestServer(t *testing.T) {
 	}
 
 	testCases := []struct {
-		name       string
-		method     string
-		path       string
-		status     int
-		body       string
-		bundle     *bundleutil.Bundle
-		serverCert *x509.Certificate
-		reqErr     string
+		name        string
+		method      string
+		path        string
+		status      int
+		body        string
+		bundle      *bundleutil.Bundle
+		serverCert  *x509.Certificate
+		refreshHint time.Duration
+		reqErr      string
 	}{
 		{
 			name:   "success",
@@ -86,6 +95,29 @@ func TestServer(t *testing.T) {
 			bundle:     bundle,
 			serverCert: serverCert,
 		},
+		{
+			name:   "success with sequence number and configured refresh hint",
+			method: "GET",
+			path:   "/",
+			status: http.StatusOK,
+			body: fmt.Sprintf(`{
+				"keys": [
+					{
+						"crv":"P-256",
+						"kty":"EC",
+						"use":"x509-svid",
+						"x":"kkEn5E2Hd_rvCRDCVMNj3deN0ADij9uJVmN-El0CJz0",
+						"y":"qNrnjhtzrtTR0bRgI2jPIC1nEgcWNX63YcZOEzyo1iA",
+						"x5c": [%q]
+					}
+				],
+				"spiffe_refresh_hint": 60,
+				"spiffe_sequence": 3
+			}`, base64.StdEncoding.EncodeToString(serverCert.Raw)),
+			bundle:      sequencedBundle,
+			serverCert:  serverCert,
+			refreshHint: time.Minute,
+		},
 		{
 			name:       "invalid method",
 			method:     "POST",
@@ -122,6 +154,7 @@ func TestServer(t *testing.T) {
 			addr, done := newTestServer(t,
 				testGetter(testCase.bundle),
 				testSPIFFEAuth(testCase.serverCert, serverKey),
+				testCase.refreshHint,
 			)
 			defer done()
 
@@ -151,6 +184,43 @@ func TestServer(t *testing.T) {
 	}
 }
 
+func TestServerTLSPolicy(t *testing.T) {
+	serverCert, serverKey := createServerCertificate(t)
+	bundle := bundleutil.New("spiffe://domain.test")
+	bundle.AppendRootCA(serverCert)
+
+	addr, done := startTestServer(t, ServerConfig{
+		Getter:     testGetter(bundle),
+		ServerAuth: testSPIFFEAuth(serverCert, serverKey),
+		TLSPolicy:  tlspolicy.Policy{MinVersion: tls.VersionTLS13},
+	})
+	defer done()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(serverCert)
+	get := func(maxVersion uint16) error {
+		client := http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs:    rootCAs,
+					MaxVersion: maxVersion,
+				},
+			},
+		}
+		resp, err := client.Get(fmt.Sprintf("https://%s", addr))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	require.NoError(t, get(tls.VersionTLS13))
+	err := get(tls.VersionTLS12)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "protocol version not supported")
+}
+
 func TestACMEAuth(t *testing.T) {
 	dir, err := ioutil.TempDir("", "spire-server-endpoints-bundle-acme-")
 	require.NoError(t, err)
@@ -182,6 +252,7 @@ func TestACMEAuth(t *testing.T) {
 				Email:        "admin@domain.test",
 				ToSAccepted:  false,
 			}),
+			0,
 		)
 		defer done()
 
@@ -214,6 +285,7 @@ func TestACMEAuth(t *testing.T) {
 				Email:        "admin@domain.test",
 				ToSAccepted:  true,
 			}),
+			0,
 		)
 		defer done()
 
@@ -257,6 +329,7 @@ func TestACMEAuth(t *testing.T) {
 				Email:        "admin@domain.test",
 				ToSAccepted:  true,
 			}),
+			0,
 		)
 		defer done()
 
@@ -268,7 +341,86 @@ func TestACMEAuth(t *testing.T) {
 	})
 }
 
-func newTestServer(t *testing.T, getter Getter, serverAuth ServerAuth) (net.Addr, func()) {
+func TestDiskAuth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-server-endpoints-bundle-disk-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+
+	serverCert, serverKey := createServerCertificate(t)
+	keyPEM, err := pemutil.EncodePKCS8PrivateKey(serverKey)
+	require.NoError(t, err)
+
+	bundle := bundleutil.New("spiffe://domain.test")
+	bundle.AppendRootCA(serverCert)
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(serverCert)
+	client := http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: rootCAs,
+			},
+			// Force a new handshake for each request so the certificate
+			// is reloaded from disk
+			DisableKeepAlives: true,
+		},
+	}
+
+	log, hook := test.NewNullLogger()
+	addr, done := newTestServer(t, testGetter(bundle),
+		DiskAuth(log, DiskCertificateConfig{
+			CertFilePath: certPath,
+			KeyFilePath:  keyPath,
+		}),
+		0,
+	)
+	defer done()
+
+	// The handshake fails if the certificate cannot be loaded
+	_, err = client.Get(fmt.Sprintf("https://%s", addr)) //nolint: bodyclose // request should fail so no body to close
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "remote error: tls: internal error")
+	if entry := hook.LastEntry(); assert.NotNil(t, entry) {
+		assert.Equal(t, "Unable to load serving certificate", entry.Message)
+		assert.Equal(t, logrus.ErrorLevel, entry.Level)
+	}
+
+	// The certificate on disk is served once it is available
+	require.NoError(t, ioutil.WriteFile(certPath, pemutil.EncodeCertificate(serverCert), 0600))
+	require.NoError(t, ioutil.WriteFile(keyPath, keyPEM, 0600))
+
+	resp, err := client.Get(fmt.Sprintf("https://%s", addr))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The previously loaded certificate is served if the files can no longer
+	// be loaded
+	require.NoError(t, os.Remove(keyPath))
+	hook.Reset()
+
+	resp, err = client.Get(fmt.Sprintf("https://%s", addr))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	if entry := hook.LastEntry(); assert.NotNil(t, entry) {
+		assert.Equal(t, "Unable to reload serving certificate; serving previously loaded certificate", entry.Message)
+		assert.Equal(t, logrus.WarnLevel, entry.Level)
+	}
+}
+
+func newTestServer(t *testing.T, getter Getter, serverAuth ServerAuth, refreshHint time.Duration) (net.Addr, func()) {
+	return startTestServer(t, ServerConfig{
+		Getter:      getter,
+		ServerAuth:  serverAuth,
+		RefreshHint: refreshHint,
+	})
+}
+
+func startTestServer(t *testing.T, config ServerConfig) (net.Addr, func()) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	addrCh := make(chan net.Addr, 1)
@@ -282,13 +434,10 @@ func newTestServer(t *testing.T, getter Getter, serverAuth ServerAuth) (net.Addr
 	}
 
 	log, _ := test.NewNullLogger()
-	server := NewServer(ServerConfig{
-		Log:        log,
-		Address:    "localhost:0",
-		Getter:     getter,
-		ServerAuth: serverAuth,
-		listen:     listen,
-	})
+	config.Log = log
+	config.Address = "localhost:0"
+	config.listen = listen
+	server := NewServer(config)
 
 	errCh := make(chan error, 1)
 	go func() {
@@ -3,13 +3,19 @@ package endpoints
 import (
 	"net"
 	"net/url"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/tlspolicy"
+	"github.com/spiffe/spire/pkg/server/audit"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
+	"github.com/spiffe/spire/pkg/server/endpoints/node"
 	"github.com/spiffe/spire/pkg/server/svid"
+	"github.com/spiffe/spire/pkg/server/syncevents"
 
 	"google.golang.org/grpc"
 )
@@ -20,6 +26,11 @@ type Config struct {
 	TCPAddr *net.TCPAddr
 	UDSAddr *net.UnixAddr
 
+	// RegistrationTCPAddr is the address of a dedicated mTLS listener for the
+	// registration API (optional). If set, the registration API is served on
+	// it and on UDSAddr, but no longer on TCPAddr.
+	RegistrationTCPAddr *net.TCPAddr
+
 	// A hook allowing the consumer to customize the gRPC server before it starts.
 	GRPCHook func(*grpc.Server) error
 
@@ -38,16 +49,79 @@ type Config struct {
 	// Allow agentless spiffeIds when doing node attestation
 	AllowAgentlessNodeAttestors bool
 
+	// WorkloadKeyPolicy restricts the public keys accepted in workload CSRs
+	WorkloadKeyPolicy node.KeyPolicy
+
+	// TrackIssuedSVIDs records the issued X509-SVIDs in the datastore
+	TrackIssuedSVIDs bool
+
+	// NodeAPIRateLimits configures the rate limits of the node API
+	NodeAPIRateLimits node.RateLimits
+
 	// Bundle endpoint configuration
 	BundleEndpoint bundle.EndpointConfig
 
 	// CA Manager
 	Manager *ca.Manager
 
+	// SyncEvents notifies the agents subscribed through the node API of
+	// changes to registration entries and bundles (optional)
+	SyncEvents *syncevents.Broadcaster
+
+	// GRPC tunes the gRPC servers (optional)
+	GRPC GRPCConfig
+
+	// TLSPolicy restricts the TLS versions and cipher suites of the TCP
+	// listeners and of the bundle endpoint (optional)
+	TLSPolicy tlspolicy.Policy
+
+	// Audit records the changes made through the APIs (optional)
+	Audit *audit.Logger
+
+	// HealthChecks, if set, has its subsystem checks published through the
+	// gRPC health service of the UDS server (optional)
+	HealthChecks *health.Checker
+
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
 }
 
+// GRPCConfig tunes the gRPC servers. Zero values keep the defaults.
+type GRPCConfig struct {
+	// KeepaliveTime is how long a connection may be idle before the server
+	// checks it with a ping, and KeepaliveTimeout how long the server waits
+	// for the ping to be acknowledged before closing the connection.
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+
+	// KeepaliveMinTime is the minimum interval clients may ping the server
+	// at. Clients pinging more often are disconnected. If
+	// KeepalivePermitWithoutStream is true, clients may ping connections
+	// without active calls.
+	KeepaliveMinTime             time.Duration
+	KeepalivePermitWithoutStream bool
+
+	// MaxConnectionIdle is how long a connection may go without calls
+	// before it is closed.
+	MaxConnectionIdle time.Duration
+
+	// MaxConnectionAge is how long a connection may exist before the server
+	// asks the client to reconnect, so agents spread across servers as they
+	// come and go. Defaults to 3 minutes. MaxConnectionAgeGrace is how long
+	// calls in flight are then given to complete.
+	MaxConnectionAge      time.Duration
+	MaxConnectionAgeGrace time.Duration
+
+	// MaxRecvMsgSize and MaxSendMsgSize bound the size in bytes of the
+	// messages received and sent.
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+
+	// ReflectionEnabled serves server reflection on the gRPC servers. Callers
+	// are authorized like registration API callers.
+	ReflectionEnabled bool
+}
+
 // New creates new endpoints struct
 func New(c *Config) *Endpoints {
 	return &Endpoints{
@@ -17,6 +17,7 @@ import (
 
 	"github.com/spiffe/spire/pkg/common/auth"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/systemd"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
@@ -59,7 +60,6 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	e.registerRegistrationAPI(tcpServer, udsServer)
 
 	tasks := []func(context.Context) error{
 		func(ctx context.Context) error {
@@ -70,6 +70,16 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 		},
 	}
 
+	if e.c.RegistrationTCPAddr != nil {
+		registrationServer := e.createRegistrationTCPServer(ctx)
+		e.registerRegistrationAPI(registrationServer, udsServer)
+		tasks = append(tasks, func(ctx context.Context) error {
+			return e.runRegistrationTCPServer(ctx, registrationServer)
+		})
+	} else {
+		e.registerRegistrationAPI(tcpServer, udsServer)
+	}
+
 	if bundleServer, enabled := e.createBundleEndpointServer(); enabled {
 		tasks = append(tasks, bundleServer.Run)
 	}
@@ -82,25 +92,70 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 }
 
 func (e *Endpoints) createTCPServer(ctx context.Context) *grpc.Server {
+	// When bootstrapping, the agent does not yet have an SVID. In order to
+	// include the bootstrap endpoint in the same server as the rest of the
+	// Node API, request but don't require a client certificate
+	return e.newTCPServer(ctx, tls.VerifyClientCertIfGiven)
+}
+
+// createRegistrationTCPServer creates the server of the dedicated registration
+// API listener. Callers must present an SVID, which the registration API then
+// authorizes against the admin entries.
+func (e *Endpoints) createRegistrationTCPServer(ctx context.Context) *grpc.Server {
+	return e.newTCPServer(ctx, tls.RequireAndVerifyClientCert)
+}
+
+func (e *Endpoints) newTCPServer(ctx context.Context, clientAuth tls.ClientAuthType) *grpc.Server {
 	tlsConfig := &tls.Config{
-		GetConfigForClient: e.getTLSConfig(ctx),
+		GetConfigForClient: e.getTLSConfig(ctx, clientAuth),
+	}
+
+	maxConnectionAge := e.c.GRPC.MaxConnectionAge
+	if maxConnectionAge <= 0 {
+		maxConnectionAge = defaultMaxConnectionAge
 	}
 
-	return grpc.NewServer(
-		grpc.UnaryInterceptor(auth.UnaryAuthorizeCall),
-		grpc.StreamInterceptor(auth.StreamAuthorizeCall),
+	reflectionAuthorizer := e.newRegistrationHandler()
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(auth.UnaryAuthorizeCallWithFallback(reflectionAuthorizer)),
+		grpc.StreamInterceptor(auth.StreamAuthorizeCallWithFallback(reflectionAuthorizer)),
 		grpc.Creds(credentials.NewTLS(tlsConfig)),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
-			MaxConnectionAge: defaultMaxConnectionAge,
+			MaxConnectionIdle:     e.c.GRPC.MaxConnectionIdle,
+			MaxConnectionAge:      maxConnectionAge,
+			MaxConnectionAgeGrace: e.c.GRPC.MaxConnectionAgeGrace,
+			Time:                  e.c.GRPC.KeepaliveTime,
+			Timeout:               e.c.GRPC.KeepaliveTimeout,
 		}),
-	)
+	}
+	if e.c.GRPC.KeepaliveMinTime > 0 || e.c.GRPC.KeepalivePermitWithoutStream {
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             e.c.GRPC.KeepaliveMinTime,
+			PermitWithoutStream: e.c.GRPC.KeepalivePermitWithoutStream,
+		}))
+	}
+	opts = append(opts, e.messageSizeOptions()...)
+	return grpc.NewServer(opts...)
 }
 
 func (e *Endpoints) createUDSServer() *grpc.Server {
-	return grpc.NewServer(
-		grpc.UnaryInterceptor(auth.UnaryAuthorizeCall),
-		grpc.StreamInterceptor(auth.StreamAuthorizeCall),
-		grpc.Creds(auth.UntrackedUDSCredentials()))
+	reflectionAuthorizer := e.newRegistrationHandler()
+	return grpc.NewServer(append([]grpc.ServerOption{
+		grpc.UnaryInterceptor(auth.UnaryAuthorizeCallWithFallback(reflectionAuthorizer)),
+		grpc.StreamInterceptor(auth.StreamAuthorizeCallWithFallback(reflectionAuthorizer)),
+		grpc.Creds(auth.UntrackedUDSCredentials()),
+	}, e.messageSizeOptions()...)...)
+}
+
+func (e *Endpoints) messageSizeOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if e.c.GRPC.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(e.c.GRPC.MaxRecvMsgSize))
+	}
+	if e.c.GRPC.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(e.c.GRPC.MaxSendMsgSize))
+	}
+	return opts
 }
 
 func (e *Endpoints) createBundleEndpointServer() (*bundle.Server, bool) {
@@ -110,9 +165,12 @@ func (e *Endpoints) createBundleEndpointServer() (*bundle.Server, bool) {
 	e.c.Log.WithField("addr", e.c.BundleEndpoint.Address).Info("Serving bundle endpoint")
 
 	var serverAuth bundle.ServerAuth
-	if e.c.BundleEndpoint.ACME != nil {
+	switch {
+	case e.c.BundleEndpoint.ACME != nil:
 		serverAuth = bundle.ACMEAuth(e.c.Log.WithField(telemetry.SubsystemName, "bundle_acme"), e.c.Catalog.GetKeyManager(), *e.c.BundleEndpoint.ACME)
-	} else {
+	case e.c.BundleEndpoint.DiskCertificate != nil:
+		serverAuth = bundle.DiskAuth(e.c.Log.WithField(telemetry.SubsystemName, "bundle_disk_certificate"), *e.c.BundleEndpoint.DiskCertificate)
+	default:
 		serverAuth = bundle.SPIFFEAuth(func() ([]*x509.Certificate, crypto.PrivateKey, error) {
 			state := e.c.SVIDObserver.State()
 			return state.SVID, state.Key, nil
@@ -121,8 +179,9 @@ func (e *Endpoints) createBundleEndpointServer() (*bundle.Server, bool) {
 
 	ds := e.c.Catalog.GetDataStore()
 	return bundle.NewServer(bundle.ServerConfig{
-		Log:     e.c.Log.WithField(telemetry.SubsystemName, "bundle_endpoint"),
-		Address: e.c.BundleEndpoint.Address.String(),
+		Log:         e.c.Log.WithField(telemetry.SubsystemName, "bundle_endpoint"),
+		Address:     e.c.BundleEndpoint.Address.String(),
+		RefreshHint: e.c.BundleEndpoint.RefreshHint,
 		Getter: bundle.GetterFunc(func(ctx context.Context) (*bundleutil.Bundle, error) {
 			resp, err := ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
 				TrustDomainId: e.c.TrustDomain.String(),
@@ -136,6 +195,7 @@ func (e *Endpoints) createBundleEndpointServer() (*bundle.Server, bool) {
 			return bundleutil.BundleFromProto(resp.Bundle)
 		}),
 		ServerAuth: serverAuth,
+		TLSPolicy:  e.c.TLSPolicy,
 	}), true
 }
 
@@ -149,8 +209,13 @@ func (e *Endpoints) registerNodeAPI(tcpServer *grpc.Server) error {
 		TrustDomain: e.c.TrustDomain,
 		ServerCA:    e.c.ServerCA,
 		Manager:     e.c.Manager,
+		SyncEvents:  e.c.SyncEvents,
+		Audit:       e.c.Audit,
 
 		AllowAgentlessNodeAttestors: e.c.AllowAgentlessNodeAttestors,
+		WorkloadKeyPolicy:           e.c.WorkloadKeyPolicy,
+		RateLimits:                  e.c.NodeAPIRateLimits,
+		TrackIssuedSVIDs:            e.c.TrackIssuedSVIDs,
 	})
 	if err != nil {
 		return err
@@ -160,23 +225,33 @@ func (e *Endpoints) registerNodeAPI(tcpServer *grpc.Server) error {
 }
 
 // registerRegistrationAPI creates a Registration API handler and registers
-// it against the provided gRPC.
-func (e *Endpoints) registerRegistrationAPI(tcpServer, udpServer *grpc.Server) {
-	r := &registration.Handler{
+// it against the provided gRPC servers.
+func (e *Endpoints) registerRegistrationAPI(servers ...*grpc.Server) {
+	r := e.newRegistrationHandler()
+	for _, server := range servers {
+		registration_pb.RegisterRegistrationServer(server, r)
+	}
+}
+
+// newRegistrationHandler creates a Registration API handler. Besides serving
+// the registration API, it authorizes the calls to the services that can't
+// authorize calls themselves (i.e. server reflection) on the gRPC servers.
+func (e *Endpoints) newRegistrationHandler() *registration.Handler {
+	return &registration.Handler{
 		Log:         e.c.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationAPI),
 		Metrics:     e.c.Metrics,
 		Catalog:     e.c.Catalog,
 		TrustDomain: e.c.TrustDomain,
 		ServerCA:    e.c.ServerCA,
-	}
+		Audit:       e.c.Audit,
 
-	registration_pb.RegisterRegistrationServer(tcpServer, r)
-	registration_pb.RegisterRegistrationServer(udpServer, r)
+		TrackIssuedSVIDs: e.c.TrackIssuedSVIDs,
+	}
 }
 
 // runTCPServer will start the server and block until it exits or we are dying.
 func (e *Endpoints) runTCPServer(ctx context.Context, server *grpc.Server) error {
-	l, err := net.Listen(e.c.TCPAddr.Network(), e.c.TCPAddr.String())
+	l, err := e.listenTCP(e.c.TCPAddr)
 	if err != nil {
 		return err
 	}
@@ -188,41 +263,93 @@ func (e *Endpoints) runTCPServer(ctx context.Context, server *grpc.Server) error
 			return fmt.Errorf("call grpc hook: %v", err)
 		}
 	}
+	healthServer := util.RegisterHealth(server, e.c.GRPC.ReflectionEnabled)
+	if e.c.HealthChecks != nil {
+		e.c.HealthChecks.Publish(healthServer)
+	}
 
-	// Skip use of tomb here so we don't pollute a clean shutdown with errors
-	e.c.Log.WithField(telemetry.Address, l.Addr().String()).Info("Starting TCP server")
-	errChan := make(chan error)
-	go func() { errChan <- server.Serve(l) }()
+	return e.serve(ctx, server, l, "TCP")
+}
 
-	select {
-	case err = <-errChan:
+// runRegistrationTCPServer will start the dedicated registration API server
+// and block until it exits or we are dying.
+func (e *Endpoints) runRegistrationTCPServer(ctx context.Context, server *grpc.Server) error {
+	l, err := e.listenTCP(e.c.RegistrationTCPAddr)
+	if err != nil {
 		return err
-	case <-ctx.Done():
-		e.c.Log.Info("Stopping TCP server")
-		server.Stop()
-		<-errChan
-		e.c.Log.Info("TCP server has stopped.")
-		return nil
 	}
+	defer l.Close()
+
+	healthServer := util.RegisterHealth(server, e.c.GRPC.ReflectionEnabled)
+	if e.c.HealthChecks != nil {
+		e.c.HealthChecks.Publish(healthServer)
+	}
+
+	return e.serve(ctx, server, l, "registration API TCP")
 }
 
 // runUDSServer  will start the server and block until it exits or we are dying.
 func (e *Endpoints) runUDSServer(ctx context.Context, server *grpc.Server) error {
-	os.Remove(e.c.UDSAddr.String())
-	l, err := net.ListenUnix(e.c.UDSAddr.Network(), e.c.UDSAddr)
+	l, err := e.listenUDS(e.c.UDSAddr)
 	if err != nil {
 		return err
 	}
 	defer l.Close()
 
+	healthServer := util.RegisterHealth(server, e.c.GRPC.ReflectionEnabled)
+	if e.c.HealthChecks != nil {
+		e.c.HealthChecks.Publish(healthServer)
+	}
+
+	return e.serve(ctx, server, l, "UDS")
+}
+
+// serve serves the listener until the server fails or the context is done.
+// listenTCP listens on the TCP address, using the socket passed by systemd
+// socket activation if there is one bound to it.
+func (e *Endpoints) listenTCP(addr *net.TCPAddr) (net.Listener, error) {
+	l, err := systemd.InheritedListener(addr)
+	switch {
+	case err != nil:
+		return nil, err
+	case l != nil:
+		e.c.Log.WithField(telemetry.Address, addr.String()).Info("Using TCP socket inherited from systemd")
+		return l, nil
+	}
+	return net.Listen(addr.Network(), addr.String())
+}
+
+// listenUDS listens on the UDS, using the socket passed by systemd socket
+// activation if there is one bound to it. The ownership and permissions of
+// inherited sockets are managed by the socket unit.
+func (e *Endpoints) listenUDS(addr *net.UnixAddr) (net.Listener, error) {
+	l, err := systemd.InheritedListener(addr)
+	switch {
+	case err != nil:
+		return nil, err
+	case l != nil:
+		e.c.Log.WithField(telemetry.Address, addr.String()).Info("Using UDS inherited from systemd")
+		return l, nil
+	}
+
+	os.Remove(addr.String())
+	l, err = net.ListenUnix(addr.Network(), addr)
+	if err != nil {
+		return nil, err
+	}
+
 	// Restrict access to the UDS to processes running as the same user or
 	// group as the server.
-	if err := os.Chmod(e.c.UDSAddr.String(), 0770); err != nil {
-		return err
+	if err := os.Chmod(addr.String(), 0770); err != nil {
+		l.Close()
+		return nil, err
 	}
+	return l, nil
+}
 
+func (e *Endpoints) serve(ctx context.Context, server *grpc.Server, l net.Listener, name string) error {
 	// Skip use of tomb here so we don't pollute a clean shutdown with errors
-	e.c.Log.WithField(telemetry.Address, l.Addr().String()).Info("Starting UDS server")
+	e.c.Log.WithField(telemetry.Address, l.Addr().String()).Infof("Starting %s server", name)
 	errChan := make(chan error)
 	go func() { errChan <- server.Serve(l) }()
 
@@ -230,16 +357,17 @@ func (e *Endpoints) runUDSServer(ctx context.Context, server *grpc.Server) error
 	case err := <-errChan:
 		return err
 	case <-ctx.Done():
-		e.c.Log.Info("Stopping UDS server")
+		e.c.Log.Infof("Stopping %s server", name)
 		server.Stop()
 		<-errChan
-		e.c.Log.Info("UDS server has stopped.")
+		e.c.Log.Infof("%s server has stopped.", name)
 		return nil
 	}
 }
 
-// getTLSConfig returns a TLS Config hook for the gRPC server
-func (e *Endpoints) getTLSConfig(ctx context.Context) func(*tls.ClientHelloInfo) (*tls.Config, error) {
+// getTLSConfig returns a TLS Config hook for the gRPC server, authenticating
+// the clients according to clientAuth
+func (e *Endpoints) getTLSConfig(ctx context.Context, clientAuth tls.ClientAuthType) func(*tls.ClientHelloInfo) (*tls.Config, error) {
 	return func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
 		certs, roots, err := e.getCerts(ctx)
 		if err != nil {
@@ -248,17 +376,14 @@ func (e *Endpoints) getTLSConfig(ctx context.Context) func(*tls.ClientHelloInfo)
 		}
 
 		c := &tls.Config{
-			// When bootstrapping, the agent does not yet have
-			// an SVID. In order to include the bootstrap endpoint
-			// in the same server as the rest of the Node API,
-			// request but don't require a client certificate
-			ClientAuth: tls.VerifyClientCertIfGiven,
+			ClientAuth: clientAuth,
 
 			Certificates: certs,
 			ClientCAs:    roots,
 
 			MinVersion: tls.VersionTLS12,
 		}
+		e.c.TLSPolicy.Apply(c)
 		return c, nil
 	}
 }
@@ -14,8 +14,11 @@ import (
 
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/tlspolicy"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/svid"
+	registration_pb "github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
@@ -25,6 +28,11 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -116,6 +124,102 @@ func (s *EndpointsTestSuite) TestListenAndServe() {
 	}
 }
 
+func (s *EndpointsTestSuite) TestHealthService() {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() { _ = s.e.ListenAndServe(ctx) }()
+
+	conn, err := grpc.DialContext(ctx, "unix://"+s.e.c.UDSAddr.String(), grpc.WithInsecure(), grpc.WithBlock())
+	s.Require().NoError(err)
+	defer conn.Close()
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{
+		Service: "spire.api.registration.Registration",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+}
+
+func (s *EndpointsTestSuite) TestReflectionDisabled() {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() { _ = s.e.ListenAndServe(ctx) }()
+
+	conn, err := grpc.DialContext(ctx, "unix://"+s.e.c.UDSAddr.String(), grpc.WithInsecure(), grpc.WithBlock())
+	s.Require().NoError(err)
+	defer conn.Close()
+
+	_, err = s.listServices(ctx, conn)
+	s.Require().Equal(codes.Unimplemented, status.Code(err))
+}
+
+func (s *EndpointsTestSuite) TestReflectionEnabled() {
+	caTmpl, err := util.NewCATemplate(s.mockClock, "example.org")
+	s.Require().NoError(err)
+	caCert, caKey, err := util.SelfSign(caTmpl)
+	s.Require().NoError(err)
+	svidTmpl, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/spire/server")
+	s.Require().NoError(err)
+	svidCert, svidKey, err := util.Sign(svidTmpl, caCert, caKey)
+	s.Require().NoError(err)
+
+	_, err = s.ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
+		Bundle: bundleutil.BundleProtoFromRootCA(s.e.c.TrustDomain.String(), caCert),
+	})
+	s.Require().NoError(err)
+	s.svidState = svid.State{
+		SVID: []*x509.Certificate{svidCert},
+		Key:  svidKey,
+	}
+
+	s.e.c.GRPC.ReflectionEnabled = true
+	s.e.c.Metrics = telemetry.Blackhole{}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() { _ = s.e.ListenAndServe(ctx) }()
+
+	// local callers are authorized like registration API callers
+	udsConn, err := grpc.DialContext(ctx, "unix://"+s.e.c.UDSAddr.String(), grpc.WithInsecure(), grpc.WithBlock())
+	s.Require().NoError(err)
+	defer udsConn.Close()
+	services, err := s.listServices(ctx, udsConn)
+	s.Require().NoError(err)
+	s.Require().Contains(services, "spire.api.registration.Registration")
+
+	// callers without an admin SVID are not
+	tcpConn, err := grpc.DialContext(ctx, "127.0.0.1:8000", grpc.WithBlock(), grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		// the server is not authenticated since that is outside the
+		// scope of this test
+		InsecureSkipVerify: true, // nolint: gosec // test only
+	})))
+	s.Require().NoError(err)
+	defer tcpConn.Close()
+	_, err = s.listServices(ctx, tcpConn)
+	s.Require().Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (s *EndpointsTestSuite) listServices(ctx context.Context, conn *grpc.ClientConn) ([]string, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		return nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	var services []string
+	for _, service := range resp.GetListServicesResponse().Service {
+		services = append(services, service.Name)
+	}
+	return services, nil
+}
+
 func (s *EndpointsTestSuite) TestGRPCHook() {
 	snitchChan := make(chan struct{}, 1)
 	hook := func(g *grpc.Server) error {
@@ -158,7 +262,7 @@ func (s *EndpointsTestSuite) TestGRPCHookFailure() {
 func (s *EndpointsTestSuite) TestGetTLSConfig() {
 	certs, pool := s.configureBundle()
 
-	tlsConfig, err := s.e.getTLSConfig(ctx)(nil)
+	tlsConfig, err := s.e.getTLSConfig(ctx, tls.VerifyClientCertIfGiven)(nil)
 	require.NoError(s.T(), err)
 
 	s.Assert().Equal(tls.VerifyClientCertIfGiven, tlsConfig.ClientAuth)
@@ -167,6 +271,92 @@ func (s *EndpointsTestSuite) TestGetTLSConfig() {
 	s.Assert().EqualValues(tls.VersionTLS12, tlsConfig.MinVersion)
 }
 
+func (s *EndpointsTestSuite) TestRegistrationTCPListener() {
+	caTmpl, err := util.NewCATemplate(s.mockClock, "example.org")
+	s.Require().NoError(err)
+	caCert, caKey, err := util.SelfSign(caTmpl)
+	s.Require().NoError(err)
+	svidTmpl, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/admin")
+	s.Require().NoError(err)
+	svidCert, svidKey, err := util.Sign(svidTmpl, caCert, caKey)
+	s.Require().NoError(err)
+
+	_, err = s.ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
+		Bundle: bundleutil.BundleProtoFromRootCA(s.e.c.TrustDomain.String(), caCert),
+	})
+	s.Require().NoError(err)
+	s.svidState = svid.State{
+		SVID: []*x509.Certificate{svidCert},
+		Key:  svidKey,
+	}
+	certs := []tls.Certificate{
+		{
+			Certificate: [][]byte{svidCert.Raw},
+			PrivateKey:  svidKey,
+		},
+	}
+
+	s.e.c.RegistrationTCPAddr = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8001}
+	s.e.c.Metrics = telemetry.Blackhole{}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errChan := make(chan error, 1)
+	go func() { errChan <- s.e.ListenAndServe(ctx) }()
+
+	dial := func(addr string, certs []tls.Certificate) *grpc.ClientConn {
+		conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			// the server is not authenticated since that is outside the
+			// scope of this test
+			InsecureSkipVerify: true, // nolint: gosec // test only
+			Certificates:       certs,
+		})))
+		s.Require().NoError(err)
+		return conn
+	}
+
+	// the registration API is no longer served on the node API listener
+	nodeConn := dial("127.0.0.1:8000", nil)
+	defer nodeConn.Close()
+	s.Require().Eventually(func() bool {
+		_, err := registration_pb.NewRegistrationClient(nodeConn).FetchBundle(ctx, &common.Empty{})
+		return status.Code(err) == codes.Unimplemented
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the dedicated listener requires a client certificate
+	anonymousConn := dial("127.0.0.1:8001", nil)
+	defer anonymousConn.Close()
+	_, err = registration_pb.NewRegistrationClient(anonymousConn).FetchBundle(ctx, &common.Empty{})
+	s.Require().Equal(codes.Unavailable, status.Code(err))
+
+	// callers presenting an SVID reach the registration API, which authorizes
+	// them against the admin entries
+	registrationConn := dial("127.0.0.1:8001", certs)
+	defer registrationConn.Close()
+	_, err = registration_pb.NewRegistrationClient(registrationConn).FetchBundle(ctx, &common.Empty{})
+	s.Require().Equal(codes.PermissionDenied, status.Code(err))
+
+	cancel()
+	s.Require().NoError(<-errChan)
+}
+
+func (s *EndpointsTestSuite) TestGetTLSConfigAppliesTLSPolicy() {
+	s.configureBundle()
+	s.e.c.TLSPolicy = tlspolicy.Policy{
+		CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384},
+	}
+
+	tlsConfig, err := s.e.getTLSConfig(ctx, tls.VerifyClientCertIfGiven)(nil)
+	s.Require().NoError(err)
+	s.Assert().EqualValues(tls.VersionTLS12, tlsConfig.MinVersion)
+	s.Assert().Equal([]uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384}, tlsConfig.CipherSuites)
+
+	s.e.c.TLSPolicy = tlspolicy.Policy{MinVersion: tls.VersionTLS13}
+	tlsConfig, err = s.e.getTLSConfig(ctx, tls.VerifyClientCertIfGiven)(nil)
+	s.Require().NoError(err)
+	s.Assert().EqualValues(tls.VersionTLS13, tlsConfig.MinVersion)
+}
+
 // configureBundle sets the bundle in the datastore, and returns the served
 // certificates plus an svid in the form of TLS certificate chain and CA pool.
 func (s *EndpointsTestSuite) configureBundle() ([]tls.Certificate, *x509.CertPool) {
@@ -3,29 +3,36 @@ package node
 import (
 	"crypto"
 	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/andres-erbsen/clock"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/apierror"
 	"github.com/spiffe/spire/pkg/common/errorutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/server/audit"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor/jointoken"
 	"github.com/spiffe/spire/pkg/server/plugin/noderesolver"
+	"github.com/spiffe/spire/pkg/server/syncevents"
 	"github.com/spiffe/spire/pkg/server/util/regentryutil"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -39,6 +46,8 @@ import (
 // Number of agentIDs that can be cached
 const fetchSVIDCacheSize = 500_000
 
+var errAgentBanned = errors.New("agent is banned")
+
 type HandlerConfig struct {
 	Log         logrus.FieldLogger
 	Metrics     telemetry.Metrics
@@ -48,8 +57,26 @@ type HandlerConfig struct {
 	Clock       clock.Clock
 	Manager     *ca.Manager
 
+	// SyncEvents notifies subscribed agents that they need to synchronize.
+	// If nil, SubscribeToSyncEvents is not available.
+	SyncEvents *syncevents.Broadcaster
+
 	// Allow agentless SPIFFE IDs when doing node attestation
 	AllowAgentlessNodeAttestors bool
+
+	// WorkloadKeyPolicy restricts the public keys accepted in workload CSRs
+	WorkloadKeyPolicy KeyPolicy
+
+	// RateLimits configures the rate limits of the node API
+	RateLimits RateLimits
+
+	// TrackIssuedSVIDs records the X509-SVIDs issued to agents and
+	// workloads in the datastore
+	TrackIssuedSVIDs bool
+
+	// Audit records agent attestations and downstream CA SVID signing. If
+	// nil, nothing is audited.
+	Audit *audit.Logger
 }
 
 type Handler struct {
@@ -71,7 +98,7 @@ func NewHandler(config HandlerConfig) (*Handler, error) {
 
 	return &Handler{
 		c:                             config,
-		limiter:                       NewLimiter(config.Log),
+		limiter:                       NewLimiter(config.Log, config.RateLimits, config.Metrics),
 		dsCache:                       newDatastoreCache(config.Catalog.GetDataStore(), config.Clock),
 		fetchRegistrationEntriesCache: fetchX509SVIDCache,
 	}, nil
@@ -92,6 +119,13 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 	ctx, cancel := context.WithCancel(stream.Context())
 	defer cancel()
 
+	callerAddress := ""
+	if peerAddress, ok := getPeerAddress(ctx); ok {
+		callerAddress = peerAddress.String()
+	}
+	event := h.c.Audit.Start(callerAddress, audit.AttestAgent)
+	defer event.Done(&err)
+
 	// pull off the initial request
 	request, err := stream.Recv()
 	if err != nil {
@@ -102,7 +136,7 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 	err = h.limiter.Limit(ctx, AttestMsg, 1)
 	if err != nil {
 		log.WithError(err).Error("Rejecting request due to node attestation rate limiting")
-		return status.Error(codes.ResourceExhausted, err.Error())
+		return apierror.RateLimitedError(apierror.LimitAttestation, err.Error())
 	}
 
 	if request.AttestationData == nil {
@@ -114,6 +148,7 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 		return status.Error(codes.InvalidArgument, "request missing attestation data type")
 	}
 	attestorName = request.AttestationData.Type
+	event.AddParam("attestor_type", attestorName)
 	log = log.WithField(telemetry.Attestor, request.AttestationData.Type)
 
 	if len(request.Csr) == 0 {
@@ -124,7 +159,7 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 	csr, err := h.parseAttestCSR(request.Csr)
 	if err != nil {
 		log.WithError(err).Error("Failed to parse CSR")
-		return status.Errorf(codes.InvalidArgument, "request CSR is invalid: %v", err)
+		return apierror.Errorf(codes.InvalidArgument, apierror.InvalidCSR, nil, "request CSR is invalid: %v", err)
 	}
 
 	// Pick the right node attestor
@@ -185,6 +220,17 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 
 	agentID := attestResponse.AgentId
 	log = log.WithField(telemetry.SPIFFEID, agentID)
+	event.AddParam("agent_id", agentID)
+
+	isBanned, err := h.isBanned(ctx, agentID)
+	switch {
+	case err != nil:
+		log.WithError(err).Error("Failed to determine if agent is banned")
+		return status.Error(codes.Internal, "failed to determine if agent is banned")
+	case isBanned:
+		log.Error("Agent is banned")
+		return nodeutil.AgentBannedError()
+	}
 
 	if csr.SpiffeID != "" && agentID != csr.SpiffeID {
 		log.WithField(telemetry.CsrSpiffeID, csr.SpiffeID).Error("Attested SPIFFE ID does not match CSR")
@@ -201,6 +247,11 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 		return status.Error(codes.Internal, "failed to sign CSR")
 	}
 
+	if err := h.recordIssuedX509SVID(ctx, svid, agentID, ""); err != nil {
+		log.WithError(err).Error("Failed to record issued SVID")
+		return status.Error(codes.Internal, "failed to record issued SVID")
+	}
+
 	if err := h.updateNodeSelectors(ctx, agentID, attestResponse, request.AttestationData.Type); err != nil {
 		log.WithError(err).Error("Failed to update node selectors")
 		return status.Error(codes.Internal, "failed to update node selectors")
@@ -279,7 +330,7 @@ func (h *Handler) FetchX509SVID(server node.Node_FetchX509SVIDServer) (err error
 		err = h.limiter.Limit(ctx, CSRMsg, max(csrsLen, csrsLenDeprecated))
 		if err != nil {
 			log.WithError(err).Error("Rejecting request due to certificate signing rate limiting")
-			return status.Error(codes.ResourceExhausted, err.Error())
+			return apierror.RateLimitedError(apierror.LimitSigning, err.Error())
 		}
 
 		agentID, err := getSpiffeIDFromCert(peerCert)
@@ -308,19 +359,20 @@ func (h *Handler) FetchX509SVID(server node.Node_FetchX509SVIDServer) (err error
 
 		// Select how to sign the SVIDs based on the agent version
 		var svids map[string]*node.X509SVID
+		var rejectedCSRs map[string]string
 
 		switch {
 		case csrsLen != 0:
 			// Current agent, use regular signCSRs (it returns svids keyed by entryID)
 			// drop spiffe IDs
-			svids, err = h.signCSRs(ctx, peerCert, request.Csrs, regEntries)
+			svids, rejectedCSRs, err = h.signCSRs(ctx, peerCert, request.Csrs, regEntries)
 			if err != nil {
 				log.WithError(err).Error("Failed to sign CSRs")
 				return status.Error(codes.Internal, "failed to sign CSRs")
 			}
 		case csrsLenDeprecated != 0:
 			// Legacy agent, use legacy SignCSRs (it returns svids keyed by spiffeID)
-			svids, err = h.signCSRsLegacy(ctx, peerCert, request.DEPRECATEDCsrs, regEntries)
+			svids, rejectedCSRs, err = h.signCSRsLegacy(ctx, peerCert, request.DEPRECATEDCsrs, regEntries)
 			if err != nil {
 				log.WithError(err).Error("Failed to sign CSRs for legacy agent")
 				return status.Error(codes.Internal, "failed to sign CSRs")
@@ -330,11 +382,19 @@ func (h *Handler) FetchX509SVID(server node.Node_FetchX509SVIDServer) (err error
 			svids = make(map[string]*node.X509SVID)
 		}
 
+		rotateAgentSVID, err := h.isAgentRotationForced(ctx, agentID)
+		if err != nil {
+			log.WithError(err).Error("Failed to determine if the agent SVID rotation is forced")
+			return status.Error(codes.Internal, "failed to determine if the agent SVID rotation is forced")
+		}
+
 		err = server.Send(&node.FetchX509SVIDResponse{
 			SvidUpdate: &node.X509SVIDUpdate{
 				Svids:               svids,
 				RegistrationEntries: regEntries,
 				Bundles:             bundles,
+				RotateAgentSvid:     rotateAgentSVID,
+				RejectedCsrs:        rejectedCSRs,
 			},
 		})
 		if err != nil {
@@ -364,7 +424,7 @@ func (h *Handler) FetchX509CASVID(ctx context.Context, req *node.FetchX509CASVID
 	err = h.limiter.Limit(ctx, CSRMsg, 1)
 	if err != nil {
 		log.WithError(err).Error("Rejecting request due to certificate signing rate limiting")
-		return nil, status.Error(codes.ResourceExhausted, err.Error())
+		return nil, apierror.RateLimitedError(apierror.LimitSigning, err.Error())
 	}
 
 	downstreamID, err := getSpiffeIDFromCert(peerCert)
@@ -383,11 +443,17 @@ func (h *Handler) FetchX509CASVID(ctx context.Context, req *node.FetchX509CASVID
 		telemetry.Address:  sourceAddress,
 	})
 
+	event := h.c.Audit.Start(downstreamID, audit.SignX509CASVID)
+	event.AddParam("address", sourceAddress)
+	event.AddParam("entry_id", entry.EntryId)
+	defer event.Done(&err)
+
 	csr, err := h.parseX509CACSR(req.Csr)
 	if err != nil {
 		log.WithError(err).Error("Failed to parse X.509 CA certificate signing request")
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	event.AddParam("spiffe_id", csr.SpiffeID)
 
 	signLog.Debug("Signing downstream CA SVID")
 	svid, err := h.buildCASVID(ctx, ca.X509CASVIDParams{
@@ -418,7 +484,7 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *node.FetchJWTSVIDReques
 	log := h.c.Log.WithField(telemetry.Method, telemetry.FetchJWTSVID)
 	if err := h.limiter.Limit(ctx, JSRMsg, 1); err != nil {
 		log.WithError(err).Error("Rejecting request due to JWT signing request rate limiting")
-		return nil, status.Error(codes.ResourceExhausted, err.Error())
+		return nil, apierror.RateLimitedError(apierror.LimitJWTSigning, err.Error())
 	}
 
 	peerCert, ok := getPeerCertificate(ctx)
@@ -458,23 +524,31 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *node.FetchJWTSVIDReques
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	found := false
+	var entry *common.RegistrationEntry
 	for _, candidateEntry := range regEntries {
 		if candidateEntry.SpiffeId == req.Jsr.SpiffeId {
-			found = true
+			entry = candidateEntry
 			break
 		}
 	}
 
-	if !found {
+	if entry == nil {
 		log.Error("Caller is not authorized")
-		return nil, status.Error(codes.PermissionDenied, "caller is not authorized")
+		return nil, apierror.Error(codes.PermissionDenied, apierror.NoEntry, nil, "caller is not authorized")
+	}
+
+	// fall back to the entry JWT-SVID TTL when the request does not ask for
+	// a specific TTL
+	ttl := req.Jsr.Ttl
+	if ttl == 0 {
+		ttl = entry.JwtSvidTtl
 	}
 
 	token, err := h.c.ServerCA.SignJWTSVID(ctx, ca.JWTSVIDParams{
-		SpiffeID: req.Jsr.SpiffeId,
-		TTL:      time.Duration(req.Jsr.Ttl) * time.Second,
-		Audience: req.Jsr.Audience,
+		SpiffeID:  req.Jsr.SpiffeId,
+		TTL:       time.Duration(ttl) * time.Second,
+		Audience:  req.Jsr.Audience,
+		Selectors: entry.Selectors,
 	})
 	if err != nil {
 		log.WithError(err).Error("Failed to sign JWT-SVID")
@@ -516,7 +590,7 @@ func (h *Handler) PushJWTKeyUpstream(ctx context.Context, req *node.PushJWTKeyUp
 	err = h.limiter.Limit(ctx, PushJWTKey, 1)
 	if err != nil {
 		log.WithError(err).Error("Rejecting request due to JWK push rate limiting")
-		return nil, status.Error(codes.ResourceExhausted, err.Error())
+		return nil, apierror.RateLimitedError(apierror.LimitJWTKeyPublishing, err.Error())
 	}
 
 	jwtSigningKeys, err := h.c.Manager.PublishJWTKey(ctx, req.JwtKey)
@@ -555,6 +629,43 @@ func (h *Handler) FetchBundle(ctx context.Context, req *node.FetchBundleRequest)
 	}, nil
 }
 
+// SubscribeToSyncEvents streams an event to the agent every time registration
+// entries or bundles change, until the agent disconnects.
+func (h *Handler) SubscribeToSyncEvents(req *node.SubscribeToSyncEventsRequest, stream node.Node_SubscribeToSyncEventsServer) (err error) {
+	counter := telemetry_server.StartNodeAPISubscribeToSyncEventsCall(h.c.Metrics)
+	defer counter.Done(&err)
+	log := h.c.Log.WithField(telemetry.Method, telemetry.SubscribeToSyncEvents)
+
+	if h.c.SyncEvents == nil {
+		return status.Error(codes.Unimplemented, "sync events are not enabled")
+	}
+
+	ctx := stream.Context()
+	if _, ok := getPeerCertificate(ctx); !ok {
+		log.Error("Request missing client SVID")
+		return status.Error(codes.InvalidArgument, "client SVID is required for this request")
+	}
+
+	sub := h.c.SyncEvents.Subscribe()
+	defer sub.Close()
+
+	for {
+		select {
+		case event := <-sub.Events():
+			err := stream.Send(&node.SyncEvent{
+				EntriesChanged: event.EntriesChanged,
+				BundlesChanged: event.BundlesChanged,
+			})
+			if err != nil {
+				log.WithError(err).Error("Error sending SyncEvent")
+				return status.Error(codes.Internal, err.Error())
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
 func (h *Handler) AuthorizeCall(ctx context.Context, fullMethod string) (_ context.Context, err error) {
 	counter := telemetry_server.StartNodeAPIAuthorizeCall(h.c.Metrics, fullMethod)
 	defer counter.Done(&err)
@@ -566,18 +677,22 @@ func (h *Handler) AuthorizeCall(ctx context.Context, fullMethod string) (_ conte
 
 	// peer certificate required for SVID fetching
 	case "/spire.api.node.Node/FetchX509SVID",
-		"/spire.api.node.Node/FetchJWTSVID":
+		"/spire.api.node.Node/FetchJWTSVID",
+		"/spire.api.node.Node/SubscribeToSyncEvents":
 		peerCert, err := getPeerCertificateFromRequestContext(ctx)
 		if err != nil {
 			log.WithError(err).Error("Agent SVID is required for this request")
-			return nil, status.Error(codes.Unauthenticated, "agent SVID is required for this request")
+			return nil, apierror.Error(codes.Unauthenticated, apierror.MissingSVID, nil, "agent SVID is required for this request")
 		}
 
 		if err := h.validateAgentSVID(ctx, peerCert); err != nil {
 			log.WithError(err).WithFields(logrus.Fields{
 				telemetry.AgentID: tryGetSpiffeIDFromCert(peerCert),
 			}).Error("Agent is not attested or no longer valid")
-			return nil, status.Error(codes.PermissionDenied, "agent is not attested or no longer valid")
+			if err == errAgentBanned {
+				return nil, nodeutil.AgentBannedError()
+			}
+			return nil, nodeutil.AgentNotAttestedError()
 		}
 
 		ctx = withPeerCertificate(ctx, peerCert)
@@ -586,12 +701,12 @@ func (h *Handler) AuthorizeCall(ctx context.Context, fullMethod string) (_ conte
 		peerCert, err := getPeerCertificateFromRequestContext(ctx)
 		if err != nil {
 			log.WithError(err).Error("Downstream SVID is required for this request")
-			return nil, status.Error(codes.Unauthenticated, "downstream SVID is required for this request")
+			return nil, apierror.Error(codes.Unauthenticated, apierror.MissingSVID, nil, "downstream SVID is required for this request")
 		}
 		entry, err := h.validateDownstreamSVID(ctx, peerCert)
 		if err != nil {
 			log.WithError(err).Error("Peer is not a valid downstream SPIRE server")
-			return nil, status.Error(codes.PermissionDenied, "peer is not a valid downstream SPIRE server")
+			return nil, apierror.Error(codes.PermissionDenied, apierror.NotDownstream, nil, "peer is not a valid downstream SPIRE server")
 		}
 
 		ctx = withPeerCertificate(ctx, peerCert)
@@ -600,7 +715,7 @@ func (h *Handler) AuthorizeCall(ctx context.Context, fullMethod string) (_ conte
 		peerCert, err := getPeerCertificateFromRequestContext(ctx)
 		if err != nil {
 			log.WithError(err).Error("Client certificate required for this request")
-			return nil, status.Error(codes.Unauthenticated, "client certificate required for this request")
+			return nil, apierror.Error(codes.Unauthenticated, apierror.MissingSVID, nil, "client certificate required for this request")
 		}
 
 		ctx = withPeerCertificate(ctx, peerCert)
@@ -633,6 +748,36 @@ func (h *Handler) isAttested(ctx context.Context, baseSpiffeID string) (bool, er
 	return false, nil
 }
 
+func (h *Handler) isBanned(ctx context.Context, agentID string) (bool, error) {
+	ds := h.c.Catalog.GetDataStore()
+
+	fetchResponse, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: agentID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	n := fetchResponse.Node
+	return n != nil && nodeutil.IsAgentBanned(n), nil
+}
+
+// isAgentRotationForced returns whether the agent must rotate its SVID at the
+// next synchronization. The flag is cleared once the agent renews its SVID.
+func (h *Handler) isAgentRotationForced(ctx context.Context, agentID string) (bool, error) {
+	ds := h.c.Catalog.GetDataStore()
+
+	fetchResponse, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: agentID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	n := fetchResponse.Node
+	return n != nil && n.ForceRotation, nil
+}
+
 func (h *Handler) validateAgentSVID(ctx context.Context, cert *x509.Certificate) error {
 	ds := h.c.Catalog.GetDataStore()
 
@@ -662,6 +807,10 @@ func (h *Handler) validateAgentSVID(ctx context.Context, cert *x509.Certificate)
 		return errors.New("agent is not attested")
 	}
 
+	if nodeutil.IsAgentBanned(n) {
+		return errAgentBanned
+	}
+
 	if n.CertSerialNumber != "" && n.CertSerialNumber == cert.SerialNumber.String() {
 		return nil
 	}
@@ -673,6 +822,9 @@ func (h *Handler) validateAgentSVID(ctx context.Context, cert *x509.Certificate)
 			SpiffeId:         n.SpiffeId,
 			CertSerialNumber: n.NewCertSerialNumber,
 			CertNotAfter:     n.NewCertNotAfter,
+			// The rotation may have been forced after the new SVID was
+			// signed
+			ForceRotation: n.ForceRotation,
 		})
 
 		if err != nil {
@@ -764,7 +916,7 @@ func (h *Handler) attestToken(ctx context.Context, attestationData *common.Attes
 	}
 
 	ds := h.c.Catalog.GetDataStore()
-	resp, err := ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+	resp, err := ds.ConsumeJoinToken(ctx, &datastore.ConsumeJoinTokenRequest{
 		Token: tokenValue,
 	})
 	if err != nil {
@@ -779,20 +931,31 @@ func (h *Handler) attestToken(ctx context.Context, attestationData *common.Attes
 		return nil, errors.New("invalid join token")
 	}
 
-	_, err = ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
-		Token: tokenValue,
-	})
-	if err != nil {
-		return nil, err
-	}
-
 	if time.Unix(t.Expiry, 0).Before(h.c.Clock.Now()) {
 		return nil, errors.New("join token expired")
 	}
 
+	// Each node attesting with a reusable token gets its own agent ID. The
+	// token ID is provided as a selector so all of them can be grouped by a
+	// node alias. The token is left out of both since it can still be used.
+	var selectors []*common.Selector
+	if t.MaxUses > 1 {
+		tokenID := jointoken.TokenID(tokenValue)
+		agentID = (&url.URL{
+			Scheme: "spiffe",
+			Host:   h.c.TrustDomain.Host,
+			Path:   path.Join("spire", "agent", "join_token", tokenID, strconv.Itoa(int(t.UseCount))),
+		}).String()
+		selectors = append(selectors, &common.Selector{
+			Type:  "join_token",
+			Value: tokenID,
+		})
+	}
+
 	// If we're here, the token is valid
 	return &nodeattestor.AttestResponse{
-		AgentId: agentID,
+		AgentId:   agentID,
+		Selectors: selectors,
 	}, nil
 }
 
@@ -895,11 +1058,12 @@ func (h *Handler) getDownstreamEntry(ctx context.Context, callerID string) (*com
 // signCSRsLegacy receives CSRs as a slice of []bytes in contrast with 'SignCSRs'.
 // This function is used to handle legacy agents request that use
 // the 'DEPRECATED_csrs' field of the 'FetchX509SVIDRequest' message.
+// Rejected CSRs are returned along with the reason, keyed by SPIFFE ID.
 // TODO: remove this function when 'DEPRECATED_csrs' gets removed
-func (h *Handler) signCSRsLegacy(ctx context.Context, peerCert *x509.Certificate, csrs [][]byte, regEntries []*common.RegistrationEntry) (map[string]*node.X509SVID, error) {
+func (h *Handler) signCSRsLegacy(ctx context.Context, peerCert *x509.Certificate, csrs [][]byte, regEntries []*common.RegistrationEntry) (map[string]*node.X509SVID, map[string]string, error) {
 	callerID, err := getSpiffeIDFromCert(peerCert)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	//convert registration entries into a map for easy lookup
@@ -910,11 +1074,12 @@ func (h *Handler) signCSRsLegacy(ctx context.Context, peerCert *x509.Certificate
 
 	ds := h.c.Catalog.GetDataStore()
 	svids := make(map[string]*node.X509SVID)
+	rejectedCSRs := make(map[string]string)
 	//iterate the CSRs and sign them
 	for _, csrBytes := range csrs {
 		csr, err := h.parseCSR(csrBytes, idutil.AllowAny())
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		baseSpiffeIDPrefix := fmt.Sprintf("%s/spire/agent", h.c.TrustDomain.String())
@@ -935,7 +1100,7 @@ func (h *Handler) signCSRsLegacy(ctx context.Context, peerCert *x509.Certificate
 				SpiffeId: csr.SpiffeID,
 			})
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			// attested node discrepancies are not likely since the agent
 			// certificate is checked against the attested nodes during the
@@ -943,16 +1108,16 @@ func (h *Handler) signCSRsLegacy(ctx context.Context, peerCert *x509.Certificate
 			// evicted between authentication and here so these checks should
 			// remain.
 			if res.Node == nil {
-				return nil, errors.New("no record of attested node")
+				return nil, nil, errors.New("no record of attested node")
 			}
 			if res.Node.CertSerialNumber != peerCert.SerialNumber.String() {
-				return nil, errors.New("SVID serial number does not match")
+				return nil, nil, errors.New("SVID serial number does not match")
 			}
 
 			signLog.Debug("Renewing agent SVID")
 			svid, svidCert, err := h.buildBaseSVID(ctx, csr)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			svids[csr.SpiffeID] = svid
 
@@ -965,25 +1130,34 @@ func (h *Handler) signCSRsLegacy(ctx context.Context, peerCert *x509.Certificate
 			}
 
 			if err := h.updateAttestedNode(ctx, req); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		} else {
+			if err := h.checkWorkloadKey(csr); err != nil {
+				// Reject the CSR without failing the others in the batch
+				signLog.WithError(err).Warn("Skipping workload CSR")
+				telemetry_server.IncrNodeAPIRejectedCSRCounter(h.c.Metrics)
+				rejectedCSRs[csr.SpiffeID] = err.Error()
+				continue
+			}
 			signLog.Debug("Signing SVID")
-			svid, err := h.buildSVID(ctx, csr.SpiffeID, csr, regEntriesMap)
+			svid, err := h.buildSVID(ctx, callerID, csr.SpiffeID, csr, regEntriesMap)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			svids[csr.SpiffeID] = svid
 		}
 	}
 
-	return svids, nil
+	return svids, rejectedCSRs, nil
 }
 
-func (h *Handler) signCSRs(ctx context.Context, peerCert *x509.Certificate, csrs map[string][]byte, regEntries []*common.RegistrationEntry) (map[string]*node.X509SVID, error) {
+// signCSRs signs the CSRs keyed by entry ID. CSRs rejected by the workload
+// key policy are returned along with the reason, keyed by entry ID.
+func (h *Handler) signCSRs(ctx context.Context, peerCert *x509.Certificate, csrs map[string][]byte, regEntries []*common.RegistrationEntry) (map[string]*node.X509SVID, map[string]string, error) {
 	callerID, err := getSpiffeIDFromCert(peerCert)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	//convert registration entries into a map for easy lookup
@@ -994,11 +1168,12 @@ func (h *Handler) signCSRs(ctx context.Context, peerCert *x509.Certificate, csrs
 
 	ds := h.c.Catalog.GetDataStore()
 	svids := make(map[string]*node.X509SVID)
+	rejectedCSRs := make(map[string]string)
 	//iterate the CSRs and sign them
 	for entryID, csrBytes := range csrs {
 		csr, err := h.parseCSR(csrBytes, idutil.AllowAny())
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		baseSpiffeIDPrefix := fmt.Sprintf("%s/spire/agent", h.c.TrustDomain.String())
@@ -1019,7 +1194,7 @@ func (h *Handler) signCSRs(ctx context.Context, peerCert *x509.Certificate, csrs
 				SpiffeId: csr.SpiffeID,
 			})
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			// attested node discrepancies are not likely since the agent
 			// certificate is checked against the attested nodes during the
@@ -1027,16 +1202,16 @@ func (h *Handler) signCSRs(ctx context.Context, peerCert *x509.Certificate, csrs
 			// evicted between authentication and here so these checks should
 			// remain.
 			if res.Node == nil {
-				return nil, errors.New("no record of attested node")
+				return nil, nil, errors.New("no record of attested node")
 			}
 			if res.Node.CertSerialNumber != peerCert.SerialNumber.String() {
-				return nil, errors.New("SVID serial number does not match")
+				return nil, nil, errors.New("SVID serial number does not match")
 			}
 
 			signLog.Debug("Renewing agent SVID")
 			svid, svidCert, err := h.buildBaseSVID(ctx, csr)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			svids[entryID] = svid
 
@@ -1049,22 +1224,29 @@ func (h *Handler) signCSRs(ctx context.Context, peerCert *x509.Certificate, csrs
 			}
 
 			if err := h.updateAttestedNode(ctx, req); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		} else {
+			if err := h.checkWorkloadKey(csr); err != nil {
+				// Reject the CSR without failing the others in the batch
+				signLog.WithError(err).Warn("Skipping workload CSR")
+				telemetry_server.IncrNodeAPIRejectedCSRCounter(h.c.Metrics)
+				rejectedCSRs[entryID] = err.Error()
+				continue
+			}
 			signLog.Debug("Signing SVID")
-			svid, err := h.buildSVID(ctx, entryID, csr, regEntriesMap)
+			svid, err := h.buildSVID(ctx, callerID, entryID, csr, regEntriesMap)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			svids[entryID] = svid
 		}
 	}
 
-	return svids, nil
+	return svids, rejectedCSRs, nil
 }
 
-func (h *Handler) buildSVID(ctx context.Context, id string, csr *CSR, regEntries map[string]*common.RegistrationEntry) (*node.X509SVID, error) {
+func (h *Handler) buildSVID(ctx context.Context, agentID, id string, csr *CSR, regEntries map[string]*common.RegistrationEntry) (*node.X509SVID, error) {
 	entry, ok := regEntries[id]
 	if !ok {
 		var idType string
@@ -1085,10 +1267,14 @@ func (h *Handler) buildSVID(ctx context.Context, id string, csr *CSR, regEntries
 		PublicKey: csr.PublicKey,
 		TTL:       time.Duration(entry.Ttl) * time.Second,
 		DNSList:   entry.DnsNames,
+		Selectors: entry.Selectors,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if err := h.recordIssuedX509SVID(ctx, svid, agentID, entry.EntryId); err != nil {
+		return nil, fmt.Errorf("failed to record issued SVID: %v", err)
+	}
 	return makeX509SVID(svid), nil
 }
 
@@ -1100,10 +1286,39 @@ func (h *Handler) buildBaseSVID(ctx context.Context, csr *CSR) (*node.X509SVID,
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := h.recordIssuedX509SVID(ctx, svid, csr.SpiffeID, ""); err != nil {
+		return nil, nil, fmt.Errorf("failed to record issued SVID: %v", err)
+	}
 
 	return makeX509SVID(svid), svid[0], nil
 }
 
+// recordIssuedX509SVID records the X509-SVID issued to the agent in the
+// datastore, if tracking of issued SVIDs is enabled. The entry ID is empty
+// for agent SVIDs.
+func (h *Handler) recordIssuedX509SVID(ctx context.Context, svid []*x509.Certificate, agentID, entryID string) error {
+	if !h.c.TrackIssuedSVIDs {
+		return nil
+	}
+
+	spiffeID, err := getSpiffeIDFromCert(svid[0])
+	if err != nil {
+		return err
+	}
+	_, err = h.c.Catalog.GetDataStore().CreateIssuedX509SVID(ctx, &datastore.CreateIssuedX509SVIDRequest{
+		Svid: &datastore.IssuedX509SVID{
+			SpiffeId:     spiffeID,
+			SerialNumber: svid[0].SerialNumber.String(),
+			IssuedAt:     svid[0].NotBefore.Unix(),
+			ExpiresAt:    svid[0].NotAfter.Unix(),
+			CaKeyId:      hex.EncodeToString(svid[0].AuthorityKeyId),
+			AgentId:      agentID,
+			EntryId:      entryID,
+		},
+	})
+	return err
+}
+
 func (h *Handler) buildCASVID(ctx context.Context, params ca.X509CASVIDParams) (*node.X509SVID, error) {
 	svid, err := h.c.ServerCA.SignX509CASVID(ctx, params)
 	if err != nil {
@@ -1141,6 +1356,7 @@ func (h *Handler) getBundlesForEntries(ctx context.Context, regEntries []*common
 func (h *Handler) getBundle(ctx context.Context, trustDomainID string) (*common.Bundle, error) {
 	resp, err := h.dsCache.FetchBundle(ctx, &datastore.FetchBundleRequest{
 		TrustDomainId: trustDomainID,
+		TolerateStale: true,
 	})
 	if err != nil {
 		h.c.Log.WithError(err).Error("Failed to fetch bundle")
@@ -1175,6 +1391,15 @@ func (h *Handler) parseX509CACSR(csrBytes []byte) (*CSR, error) {
 	return csr, nil
 }
 
+// checkWorkloadKey checks the public key of a workload CSR against the
+// workload key policy.
+func (h *Handler) checkWorkloadKey(csr *CSR) error {
+	if err := h.c.WorkloadKeyPolicy.Check(csr.PublicKey); err != nil {
+		return fmt.Errorf("CSR for %q rejected by the workload key policy: %w", csr.SpiffeID, err)
+	}
+	return nil
+}
+
 func (h *Handler) parseCSR(csrBytes []byte, mode idutil.ValidationMode) (*CSR, error) {
 	csr, err := x509.ParseCertificateRequest(csrBytes)
 	if err != nil {
@@ -0,0 +1,132 @@
+package node
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+)
+
+// KeyTypeRSA, KeyTypeECP256, KeyTypeECP384, KeyTypeECP521 and KeyTypeEd25519
+// are the key types that can be accepted by a KeyPolicy.
+const (
+	KeyTypeRSA     = "rsa"
+	KeyTypeECP256  = "ec-p256"
+	KeyTypeECP384  = "ec-p384"
+	KeyTypeECP521  = "ec-p521"
+	KeyTypeEd25519 = "ed25519"
+)
+
+var knownKeyTypes = []string{KeyTypeRSA, KeyTypeECP256, KeyTypeECP384, KeyTypeECP521, KeyTypeEd25519}
+
+// fipsKeyTypes are the key types approved by FIPS 186-4.
+var fipsKeyTypes = []string{KeyTypeRSA, KeyTypeECP256, KeyTypeECP384, KeyTypeECP521}
+
+// KeyPolicy restricts the public keys accepted in the CSRs of workload SVIDs.
+// The zero value accepts any key.
+type KeyPolicy struct {
+	// KeyTypes are the accepted key types. If empty, any key type is
+	// accepted.
+	KeyTypes []string
+
+	// MinRSAKeyBits is the minimum size of accepted RSA keys. Zero means
+	// no minimum.
+	MinRSAKeyBits int
+}
+
+// Validate returns an error if the policy refers to unknown key types.
+func (p KeyPolicy) Validate() error {
+	for _, keyType := range p.KeyTypes {
+		if !isKnownKeyType(keyType) {
+			return fmt.Errorf("key type %q is unknown; must be one of [%s]", keyType, strings.Join(knownKeyTypes, ", "))
+		}
+	}
+	if p.MinRSAKeyBits < 0 {
+		return fmt.Errorf("minimum RSA key size %d cannot be negative", p.MinRSAKeyBits)
+	}
+	return nil
+}
+
+// RestrictToFIPS returns a copy of the policy that only accepts the key types
+// approved by FIPS 186-4 and RSA keys of at least minRSAKeyBits. An error is
+// returned if the policy explicitly accepts a key type that is not approved.
+func (p KeyPolicy) RestrictToFIPS(minRSAKeyBits int) (KeyPolicy, error) {
+	for _, keyType := range p.KeyTypes {
+		if !containsKeyType(fipsKeyTypes, keyType) {
+			return KeyPolicy{}, fmt.Errorf("key type %q is not FIPS-approved", keyType)
+		}
+	}
+	if len(p.KeyTypes) == 0 {
+		p.KeyTypes = fipsKeyTypes
+	}
+	if p.MinRSAKeyBits < minRSAKeyBits {
+		p.MinRSAKeyBits = minRSAKeyBits
+	}
+	return p, nil
+}
+
+// Check returns a KeyPolicyError if the public key is not accepted by the
+// policy.
+func (p KeyPolicy) Check(publicKey crypto.PublicKey) error {
+	keyType, err := keyTypeOf(publicKey)
+	if err != nil {
+		return &KeyPolicyError{msg: err.Error()}
+	}
+
+	if len(p.KeyTypes) > 0 && !containsKeyType(p.KeyTypes, keyType) {
+		return &KeyPolicyError{msg: fmt.Sprintf("key type %q is not allowed; must be one of [%s]", keyType, strings.Join(p.KeyTypes, ", "))}
+	}
+
+	if key, ok := publicKey.(*rsa.PublicKey); ok && key.N.BitLen() < p.MinRSAKeyBits {
+		return &KeyPolicyError{msg: fmt.Sprintf("RSA key size %d is smaller than the minimum of %d bits", key.N.BitLen(), p.MinRSAKeyBits)}
+	}
+
+	return nil
+}
+
+// KeyPolicyError is returned when a public key is rejected by a KeyPolicy.
+type KeyPolicyError struct {
+	msg string
+}
+
+func (e *KeyPolicyError) Error() string {
+	return e.msg
+}
+
+func keyTypeOf(publicKey crypto.PublicKey) (string, error) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return KeyTypeRSA, nil
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return KeyTypeECP256, nil
+		case elliptic.P384():
+			return KeyTypeECP384, nil
+		case elliptic.P521():
+			return KeyTypeECP521, nil
+		default:
+			return "", fmt.Errorf("unsupported elliptic curve %q", key.Curve.Params().Name)
+		}
+	case ed25519.PublicKey:
+		return KeyTypeEd25519, nil
+	default:
+		return "", fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
+
+func isKnownKeyType(keyType string) bool {
+	return containsKeyType(knownKeyTypes, keyType)
+}
+
+func containsKeyType(keyTypes []string, keyType string) bool {
+	for _, candidate := range keyTypes {
+		if candidate == keyType {
+			return true
+		}
+	}
+	return false
+}
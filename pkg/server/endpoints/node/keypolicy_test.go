@@ -0,0 +1,116 @@
+package node
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyPolicyValidate(t *testing.T) {
+	require.NoError(t, KeyPolicy{}.Validate())
+	require.NoError(t, KeyPolicy{KeyTypes: []string{"rsa", "ec-p256", "ec-p384", "ec-p521", "ed25519"}, MinRSAKeyBits: 2048}.Validate())
+	require.EqualError(t, KeyPolicy{KeyTypes: []string{"dsa"}}.Validate(), `key type "dsa" is unknown; must be one of [rsa, ec-p256, ec-p384, ec-p521, ed25519]`)
+	require.EqualError(t, KeyPolicy{MinRSAKeyBits: -1}.Validate(), "minimum RSA key size -1 cannot be negative")
+}
+
+func TestKeyPolicyRestrictToFIPS(t *testing.T) {
+	policy, err := KeyPolicy{}.RestrictToFIPS(2048)
+	require.NoError(t, err)
+	require.Equal(t, KeyPolicy{KeyTypes: []string{KeyTypeRSA, KeyTypeECP256, KeyTypeECP384, KeyTypeECP521}, MinRSAKeyBits: 2048}, policy)
+
+	policy, err = KeyPolicy{KeyTypes: []string{KeyTypeRSA}, MinRSAKeyBits: 3072}.RestrictToFIPS(2048)
+	require.NoError(t, err)
+	require.Equal(t, KeyPolicy{KeyTypes: []string{KeyTypeRSA}, MinRSAKeyBits: 3072}, policy)
+
+	_, err = KeyPolicy{KeyTypes: []string{KeyTypeECP256, KeyTypeEd25519}}.RestrictToFIPS(2048)
+	require.EqualError(t, err, `key type "ed25519" is not FIPS-approved`)
+}
+
+func TestKeyPolicyCheck(t *testing.T) {
+	rsa1024 := generateRSAKey(t, 1024)
+	rsa2048 := generateRSAKey(t, 2048)
+	ecP256 := generateECKey(t, elliptic.P256())
+	ecP384 := generateECKey(t, elliptic.P384())
+	ed25519Key, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name      string
+		policy    KeyPolicy
+		key       crypto.PublicKey
+		expectErr string
+	}{
+		{
+			name:   "zero policy accepts any key",
+			policy: KeyPolicy{},
+			key:    rsa1024,
+		},
+		{
+			name:   "allowed key type",
+			policy: KeyPolicy{KeyTypes: []string{KeyTypeECP256, KeyTypeEd25519}},
+			key:    ecP256,
+		},
+		{
+			name:   "allowed ed25519 key",
+			policy: KeyPolicy{KeyTypes: []string{KeyTypeEd25519}},
+			key:    ed25519Key,
+		},
+		{
+			name:      "unexpected curve",
+			policy:    KeyPolicy{KeyTypes: []string{KeyTypeECP256}},
+			key:       ecP384,
+			expectErr: `key type "ec-p384" is not allowed; must be one of [ec-p256]`,
+		},
+		{
+			name:   "RSA key meets minimum size",
+			policy: KeyPolicy{MinRSAKeyBits: 2048},
+			key:    rsa2048,
+		},
+		{
+			name:      "weak RSA key",
+			policy:    KeyPolicy{KeyTypes: []string{KeyTypeRSA}, MinRSAKeyBits: 2048},
+			key:       rsa1024,
+			expectErr: "RSA key size 1024 is smaller than the minimum of 2048 bits",
+		},
+		{
+			name:   "minimum RSA key size does not apply to other key types",
+			policy: KeyPolicy{MinRSAKeyBits: 2048},
+			key:    ecP256,
+		},
+		{
+			name:      "unsupported key",
+			policy:    KeyPolicy{},
+			key:       "not a key",
+			expectErr: "unsupported public key type string",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.key)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				require.IsType(t, &KeyPolicyError{}, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func generateRSAKey(t *testing.T, bits int) crypto.PublicKey {
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	require.NoError(t, err)
+	return key.Public()
+}
+
+func generateECKey(t *testing.T, curve elliptic.Curve) crypto.PublicKey {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	return key.Public()
+}
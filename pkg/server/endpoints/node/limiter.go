@@ -10,6 +10,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc/peer"
@@ -26,34 +27,84 @@ type Limiter interface {
 	Limit(ctx context.Context, msgType, count int) error
 }
 
+// RateLimits configures the sustained rates, in messages per second, allowed
+// by the node API limiter. Per IP address limits left at zero use the
+// default rates. Per agent limits left at zero are disabled. Bursts up to the
+// default limits are always allowed so agents can send their CSRs in batches.
+type RateLimits struct {
+	Attest float64
+	CSR    float64
+	JSR    float64
+
+	AgentCSR float64
+	AgentJSR float64
+}
+
+// Validate returns an error if any of the limits is negative.
+func (r RateLimits) Validate() error {
+	for _, limit := range []float64{r.Attest, r.CSR, r.JSR, r.AgentCSR, r.AgentJSR} {
+		if limit < 0 {
+			return errors.New("rate limits cannot be negative")
+		}
+	}
+	return nil
+}
+
+const (
+	callerTypeIP    = "ip"
+	callerTypeAgent = "agent"
+)
+
+// agentLimiterIdleTimeout is how long the limiter of an agent must go unused
+// before it can be evicted. Limiters are also only evicted once their burst
+// is replenished, so evicting them does not relax the limits.
+const agentLimiterIdleTimeout = time.Minute
+
 // Newlimiter returns a new node api rate.Limiter
-func NewLimiter(l logrus.FieldLogger) Limiter {
-	return newLimiter(l)
+func NewLimiter(l logrus.FieldLogger, limits RateLimits, metrics telemetry.Metrics) Limiter {
+	return newLimiter(l, limits, metrics)
 }
 
-func newLimiter(l logrus.FieldLogger) *limiter {
+func newLimiter(l logrus.FieldLogger, limits RateLimits, metrics telemetry.Metrics) *limiter {
 	return &limiter{
-		attestRate:   rate.Limit(node.AttestLimit),
-		csrRate:      rate.Limit(node.CSRLimit),
-		jsrRate:      rate.Limit(node.JSRLimit),
-		jwtKeyRate:   rate.Limit(node.PushJWTKeyLimit),
-		lastNotified: make(map[string]time.Time),
-		limiters:     make(map[int]map[string]*rate.Limiter),
-		log:          l,
+		attestRate:    rateOrDefault(limits.Attest, node.AttestLimit),
+		csrRate:       rateOrDefault(limits.CSR, node.CSRLimit),
+		jsrRate:       rateOrDefault(limits.JSR, node.JSRLimit),
+		jwtKeyRate:    rate.Limit(node.PushJWTKeyLimit),
+		agentCSRRate:  rate.Limit(limits.AgentCSR),
+		agentJSRRate:  rate.Limit(limits.AgentJSR),
+		lastNotified:  make(map[string]time.Time),
+		limiters:      make(map[int]map[string]*rate.Limiter),
+		agentLimiters: make(map[int]map[string]*agentLimiter),
+		log:           l,
+		metrics:       metrics,
 	}
 }
 
 type limiter struct {
-	// Allowed number of messages per second
+	// Allowed number of messages per second per IP address
 	attestRate rate.Limit
 	csrRate    rate.Limit
 	jsrRate    rate.Limit
 	jwtKeyRate rate.Limit
 
-	lastNotified map[string]time.Time
-	limiters     map[int]map[string]*rate.Limiter
-	log          logrus.FieldLogger
-	mtx          sync.Mutex
+	// Allowed number of messages per second per agent. Zero disables the
+	// limit.
+	agentCSRRate rate.Limit
+	agentJSRRate rate.Limit
+
+	lastNotified  map[string]time.Time
+	limiters      map[int]map[string]*rate.Limiter
+	agentLimiters map[int]map[string]*agentLimiter
+	lastEviction  time.Time
+	log           logrus.FieldLogger
+	metrics       telemetry.Metrics
+	mtx           sync.Mutex
+}
+
+type agentLimiter struct {
+	*rate.Limiter
+	lastUsed time.Time
 }
 
 // Limit enforces rate limiting policy by blocking until the specified number of messages can
@@ -71,7 +122,28 @@ func (l *limiter) Limit(ctx context.Context, msgType, count int) error {
 		return err
 	}
 
+	if err := l.wait(ctx, rl, msgType, callerID, callerTypeIP, count); err != nil {
+		return err
+	}
+
+	// Agents are additionally limited by their SPIFFE ID, so agents sharing an
+	// IP address (e.g. behind a NAT) can be given a lower per-IP limit.
+	agentID, ok := agentIDFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	rl, ok = l.agentLimiterFor(msgType, agentID)
+	if !ok {
+		return nil
+	}
+	return l.wait(ctx, rl, msgType, agentID, callerTypeAgent, count)
+}
+
+func (l *limiter) wait(ctx context.Context, rl *rate.Limiter, msgType int, callerID, callerType string, count int) error {
 	res := rl.ReserveN(time.Now(), count)
+	if !res.OK() || res.Delay() > 0 {
+		telemetry_server.IncrNodeAPIRateLimitCounter(l.metrics, msgTypeLabel(msgType), callerType)
+	}
 	if res.Delay() > 0 {
 		l.notify(callerID, msgType)
 	}
@@ -101,7 +173,7 @@ func (l *limiter) limiterFor(msgType int, callerID string) (*rate.Limiter, error
 	l.mtx.Lock()
 	defer l.mtx.Unlock()
 
-	limiters := l.limitersFor(msgType)
+	limiters := limitersFor(l.limiters, msgType)
 
 	var err error
 	rl, ok := limiters[callerID]
@@ -117,12 +189,69 @@ func (l *limiter) limiterFor(msgType int, callerID string) (*rate.Limiter, error
 	return rl, nil
 }
 
+// agentLimiterFor returns the limiter of the agent for the message type, or
+// false if agents are not limited for it.
+func (l *limiter) agentLimiterFor(msgType int, agentID string) (*rate.Limiter, bool) {
+	var agentRate rate.Limit
+	var burst int
+	switch msgType {
+	case CSRMsg:
+		agentRate, burst = l.agentCSRRate, node.CSRLimit
+	case JSRMsg:
+		agentRate, burst = l.agentJSRRate, node.JSRLimit
+	}
+	if agentRate <= 0 {
+		return nil, false
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastEviction) >= agentLimiterIdleTimeout {
+		l.evictIdleAgentLimiters(now)
+		l.lastEviction = now
+	}
+
+	limiters, ok := l.agentLimiters[msgType]
+	if !ok {
+		limiters = make(map[string]*agentLimiter)
+		l.agentLimiters[msgType] = limiters
+	}
+	rl, ok := limiters[agentID]
+	if !ok {
+		rl = &agentLimiter{Limiter: rate.NewLimiter(agentRate, burst)}
+		limiters[agentID] = rl
+	}
+	rl.lastUsed = now
+	return rl.Limiter, true
+}
+
+// evictIdleAgentLimiters removes the limiters of agents that have been idle
+// long enough to replenish their burst, e.g. because they were evicted, so
+// they are no different from the limiter the agent would get back.
+// A lock must be held on `l` before calling this function
+func (l *limiter) evictIdleAgentLimiters(now time.Time) {
+	for _, limiters := range l.agentLimiters {
+		for agentID, rl := range limiters {
+			if now.Sub(rl.lastUsed) < agentLimiterIdleTimeout {
+				continue
+			}
+			// Taking the whole burst only succeeds if the burst was
+			// replenished, and the limiter is discarded anyway.
+			if rl.AllowN(now, rl.Burst()) {
+				delete(limiters, agentID)
+			}
+		}
+	}
+}
+
 // A lock must be held on `l` before calling this function
-func (l *limiter) limitersFor(msgType int) map[string]*rate.Limiter {
-	limiters, ok := l.limiters[msgType]
+func limitersFor(byMsgType map[int]map[string]*rate.Limiter, msgType int) map[string]*rate.Limiter {
+	limiters, ok := byMsgType[msgType]
 	if !ok {
 		limiters = make(map[string]*rate.Limiter)
-		l.limiters[msgType] = limiters
+		byMsgType[msgType] = limiters
 	}
 
 	return limiters
@@ -161,6 +290,42 @@ func (l *limiter) callerID(ctx context.Context) (string, error) {
 	return addr, nil
 }
 
+// agentIDFromContext returns the SPIFFE ID of the caller presenting a
+// verified SVID, if any.
+func agentIDFromContext(ctx context.Context) (string, bool) {
+	cert, err := getPeerCertificateFromRequestContext(ctx)
+	if err != nil {
+		return "", false
+	}
+	agentID, err := getSpiffeIDFromCert(cert)
+	if err != nil {
+		return "", false
+	}
+	return agentID, true
+}
+
+func rateOrDefault(limit float64, defaultLimit int) rate.Limit {
+	if limit > 0 {
+		return rate.Limit(limit)
+	}
+	return rate.Limit(defaultLimit)
+}
+
+func msgTypeLabel(msgType int) string {
+	switch msgType {
+	case AttestMsg:
+		return telemetry.Attest
+	case CSRMsg:
+		return telemetry.X509SVID
+	case JSRMsg:
+		return telemetry.JWTSVID
+	case PushJWTKey:
+		return telemetry.JWTKey
+	default:
+		return "unknown"
+	}
+}
+
 func (l *limiter) notify(callerID string, msgType int) {
 	l.mtx.Lock()
 	if time.Since(l.lastNotified[callerID]) > 1*time.Hour {
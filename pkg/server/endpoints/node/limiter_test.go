@@ -2,15 +2,22 @@ package node
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"net"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
 )
 
@@ -47,6 +54,112 @@ func TestLimit(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestLimitRecordsMetrics(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	metrics := fakemetrics.New()
+	l := newLimiter(log, RateLimits{}, metrics)
+
+	require.NoError(t, l.Limit(newTestContext(), AttestMsg, 1))
+	assert.Empty(t, metrics.AllMetrics())
+
+	ctx, cancel := context.WithTimeout(newTestContext(), 50*time.Millisecond)
+	defer cancel()
+	require.Error(t, l.Limit(ctx, AttestMsg, 1))
+
+	expected := fakemetrics.New()
+	telemetry_server.IncrNodeAPIRateLimitCounter(expected, telemetry.Attest, callerTypeIP)
+	assert.Equal(t, expected.AllMetrics(), metrics.AllMetrics())
+}
+
+func TestLimitPerAgent(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	metrics := fakemetrics.New()
+	l := newLimiter(log, RateLimits{AgentCSR: 1}, metrics)
+
+	// Exhaust the burst of the first agent
+	require.NoError(t, l.Limit(newTestAgentContext(t, "spiffe://example.org/agent1"), CSRMsg, node.CSRLimit))
+
+	// The first agent is limited even though its IP address is not
+	ctx, cancel := context.WithTimeout(newTestAgentContext(t, "spiffe://example.org/agent1"), 50*time.Millisecond)
+	defer cancel()
+	require.EqualError(t, l.Limit(ctx, CSRMsg, 1), "limiter: throttle delay exceeds deadline")
+
+	// Other agents on the same IP address are not affected
+	ctx, cancel = context.WithTimeout(newTestAgentContext(t, "spiffe://example.org/agent2"), 50*time.Millisecond)
+	defer cancel()
+	require.NoError(t, l.Limit(ctx, CSRMsg, 1))
+
+	// Per agent limits are not configured for JWT-SVIDs
+	_, ok := l.agentLimiterFor(JSRMsg, "spiffe://example.org/agent1")
+	assert.False(t, ok)
+
+	var agentHits int
+	for _, metric := range metrics.AllMetrics() {
+		for _, label := range metric.Labels {
+			if label.Name == telemetry.CallerType && label.Value == callerTypeAgent {
+				agentHits++
+			}
+		}
+	}
+	assert.Equal(t, 1, agentHits)
+}
+
+func TestEvictIdleAgentLimiters(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	l := newLimiter(log, RateLimits{AgentCSR: 1}, telemetry.Blackhole{})
+
+	for _, agentID := range []string{"spiffe://example.org/idle", "spiffe://example.org/busy", "spiffe://example.org/recent"} {
+		_, ok := l.agentLimiterFor(CSRMsg, agentID)
+		require.True(t, ok)
+	}
+
+	now := time.Now().Add(agentLimiterIdleTimeout)
+
+	// The busy agent has not replenished its burst, and the recent agent
+	// has not been idle long enough
+	require.True(t, l.agentLimiters[CSRMsg]["spiffe://example.org/busy"].AllowN(now.Add(-agentLimiterIdleTimeout), node.CSRLimit))
+	l.agentLimiters[CSRMsg]["spiffe://example.org/recent"].lastUsed = now
+
+	l.evictIdleAgentLimiters(now)
+
+	var agentIDs []string
+	for agentID := range l.agentLimiters[CSRMsg] {
+		agentIDs = append(agentIDs, agentID)
+	}
+	assert.ElementsMatch(t, []string{"spiffe://example.org/busy", "spiffe://example.org/recent"}, agentIDs)
+}
+
+func TestConfiguredRateLimits(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	l := newLimiter(log, RateLimits{Attest: 5, CSR: 10, AgentJSR: 2}, telemetry.Blackhole{})
+
+	li, err := l.limiterFor(AttestMsg, "evan")
+	require.NoError(t, err)
+	assert.Equal(t, rate.Limit(5), li.Limit())
+	assert.Equal(t, node.AttestLimit, li.Burst())
+
+	li, err = l.limiterFor(CSRMsg, "evan")
+	require.NoError(t, err)
+	assert.Equal(t, rate.Limit(10), li.Limit())
+	assert.Equal(t, node.CSRLimit, li.Burst())
+
+	// Unset limits use the defaults
+	li, err = l.limiterFor(JSRMsg, "evan")
+	require.NoError(t, err)
+	assert.Equal(t, rate.Limit(node.JSRLimit), li.Limit())
+
+	li, ok := l.agentLimiterFor(JSRMsg, "spiffe://example.org/agent")
+	require.True(t, ok)
+	assert.Equal(t, rate.Limit(2), li.Limit())
+	assert.Equal(t, node.JSRLimit, li.Burst())
+}
+
+func TestRateLimitsValidate(t *testing.T) {
+	assert.NoError(t, RateLimits{}.Validate())
+	assert.NoError(t, RateLimits{Attest: 0.5, AgentCSR: 10}.Validate())
+	assert.EqualError(t, RateLimits{AgentJSR: -1}.Validate(), "rate limits cannot be negative")
+}
+
 func TestLimiterFor(t *testing.T) {
 	l, _ := newTestLimiter()
 
@@ -113,7 +226,20 @@ func newTestPeer() *peer.Peer {
 	}
 }
 
+func newTestAgentContext(t *testing.T, agentID string) context.Context {
+	u, err := url.Parse(agentID)
+	require.NoError(t, err)
+
+	p := newTestPeer()
+	p.AuthInfo = credentials.TLSInfo{
+		State: tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{{URIs: []*url.URL{u}}}},
+		},
+	}
+	return peer.NewContext(context.Background(), p)
+}
+
 func newTestLimiter() (*limiter, *test.Hook) {
 	log, hook := test.NewNullLogger()
-	return newLimiter(log), hook
+	return newLimiter(log, RateLimits{}, telemetry.Blackhole{}), hook
 }
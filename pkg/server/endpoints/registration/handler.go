@@ -2,6 +2,7 @@ package registration
 
 import (
 	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/url"
@@ -15,12 +16,16 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/auth"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
 	telemetry_registrationapi "github.com/spiffe/spire/pkg/common/telemetry/server/registrationapi"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/audit"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/notifier"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 	"golang.org/x/net/context"
@@ -32,7 +37,14 @@ import (
 
 var isDNSLabel = regexp.MustCompile(`^[a-zA-Z0-9]([-]*[a-zA-Z0-9])+$`).MatchString
 
-const defaultListEntriesPageSize = 50
+const (
+	defaultListEntriesPageSize = 50
+
+	// defaultEntryExpiringWithin is how soon an entry has to expire to be
+	// counted as expiring soon by GetEntryStatistics, unless the request
+	// says otherwise.
+	defaultEntryExpiringWithin = 24 * time.Hour
+)
 
 //Handler service is used to register SPIFFE IDs, and the attestation logic that should
 //be performed on a workload before those IDs can be issued.
@@ -42,6 +54,10 @@ type Handler struct {
 	Catalog     catalog.Catalog
 	TrustDomain url.URL
 	ServerCA    ca.ServerCA
+	Audit       *audit.Logger
+
+	// TrackIssuedSVIDs records the minted X509-SVIDs in the datastore
+	TrackIssuedSVIDs bool
 }
 
 //CreateEntry creates an entry in the Registration table,
@@ -50,6 +66,9 @@ func (h *Handler) CreateEntry(ctx context.Context, request *common.RegistrationE
 	counter := telemetry_registrationapi.StartCreateEntryCall(h.Metrics)
 	defer counter.Done(&err)
 	telemetry_common.AddCallerID(counter, getCallerID(ctx))
+	event := h.Audit.Start(getCallerID(ctx), audit.CreateEntry)
+	event.AddRegistrationEntry(request)
+	defer event.Done(&err)
 	log := h.Log.WithField(telemetry.Method, telemetry.CreateRegistrationEntry)
 
 	entry, preexisting, err := h.createRegistrationEntry(ctx, request)
@@ -69,6 +88,7 @@ func (h *Handler) CreateEntry(ctx context.Context, request *common.RegistrationE
 		return nil, status.Error(codes.AlreadyExists, "entry already exists")
 	}
 
+	event.AddParam("entry_id", entry.EntryId)
 	return &registration.RegistrationEntryID{Id: entry.EntryId}, nil
 }
 
@@ -76,6 +96,9 @@ func (h *Handler) CreateEntryIfNotExists(ctx context.Context, request *common.Re
 	counter := telemetry_registrationapi.StartCreateEntryIfNotExistsCall(h.Metrics)
 	defer counter.Done(&err)
 	telemetry_common.AddCallerID(counter, getCallerID(ctx))
+	event := h.Audit.Start(getCallerID(ctx), audit.CreateEntry)
+	event.AddRegistrationEntry(request)
+	defer event.Done(&err)
 	log := h.Log.WithField(telemetry.Method, telemetry.CreateRegistrationEntryIfNotExists)
 
 	entry, preexisting, err := h.createRegistrationEntry(ctx, request)
@@ -90,6 +113,8 @@ func (h *Handler) CreateEntryIfNotExists(ctx context.Context, request *common.Re
 		return nil, err
 	}
 
+	event.AddParam("entry_id", entry.EntryId)
+	event.AddParam("preexisting", preexisting)
 	resp = &registration.CreateEntryIfNotExistsResponse{
 		Entry:       entry,
 		Preexisting: preexisting,
@@ -103,14 +128,22 @@ func (h *Handler) DeleteEntry(ctx context.Context, request *registration.Registr
 	counter := telemetry_registrationapi.StartDeleteEntryCall(h.Metrics)
 	telemetry_common.AddCallerID(counter, getCallerID(ctx))
 	defer counter.Done(&err)
+	event := h.Audit.Start(getCallerID(ctx), audit.DeleteEntry)
+	event.AddParam("entry_id", request.Id)
+	defer event.Done(&err)
 	log := h.Log.WithField(telemetry.Method, telemetry.DeleteRegistrationEntry)
 
 	ds := h.getDataStore()
 	req := &datastore.DeleteRegistrationEntryRequest{
-		EntryId: request.Id,
+		EntryId:        request.Id,
+		RevisionNumber: &wrappers.Int64Value{Value: request.RevisionNumber},
 	}
 	resp, err := ds.DeleteRegistrationEntry(ctx, req)
-	if err != nil {
+	switch {
+	case status.Code(err) == codes.Aborted:
+		log.WithError(err).Error("Registration entry changed since the revision to delete")
+		return &common.RegistrationEntry{}, status.Error(codes.Aborted, status.Convert(err).Message())
+	case err != nil:
 		log.WithError(err).Error("Error deleting registration entry")
 		return &common.RegistrationEntry{}, status.Error(codes.Internal, err.Error())
 	}
@@ -163,6 +196,9 @@ func (h *Handler) UpdateEntry(ctx context.Context, request *registration.UpdateE
 	counter := telemetry_registrationapi.StartUpdateEntryCall(h.Metrics)
 	telemetry_common.AddCallerID(counter, getCallerID(ctx))
 	defer counter.Done(&err)
+	event := h.Audit.Start(getCallerID(ctx), audit.UpdateEntry)
+	event.AddRegistrationEntry(request.Entry)
+	defer event.Done(&err)
 	log := h.Log.WithField(telemetry.Method, telemetry.UpdateRegistrationEntry)
 
 	if request.Entry == nil {
@@ -176,11 +212,27 @@ func (h *Handler) UpdateEntry(ctx context.Context, request *registration.UpdateE
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if err := h.adviseEntry(ctx, &notifier.NotifyAndAdviseRequest{
+		Event: &notifier.NotifyAndAdviseRequest_EntryUpdating{
+			EntryUpdating: &notifier.EntryUpdating{
+				Entry: request.Entry,
+			},
+		},
+	}); err != nil {
+		log.WithError(err).Error("Registration entry rejected by policy")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	ds := h.getDataStore()
 	resp, err := ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
-		Entry: request.Entry,
+		Entry:          request.Entry,
+		RevisionNumber: &wrappers.Int64Value{Value: request.Entry.RevisionNumber},
 	})
-	if err != nil {
+	switch {
+	case status.Code(err) == codes.Aborted:
+		log.WithError(err).Error("Registration entry changed since the revision to update")
+		return nil, status.Error(codes.Aborted, status.Convert(err).Message())
+	case err != nil:
 		log.WithError(err).Error("Failed to update registration entry")
 		return nil, status.Errorf(codes.Internal, "failed to update registration entry: %v", err)
 	}
@@ -338,10 +390,79 @@ func (h *Handler) ListAllEntriesWithPages(ctx context.Context, request *registra
 	}, nil
 }
 
+//GetEntryStatistics returns statistics about the registration entries.
+func (h *Handler) GetEntryStatistics(ctx context.Context, request *registration.GetEntryStatisticsRequest) (_ *registration.GetEntryStatisticsResponse, err error) {
+	counter := telemetry_registrationapi.StartGetEntryStatisticsCall(h.Metrics)
+	telemetry_common.AddCallerID(counter, getCallerID(ctx))
+	defer counter.Done(&err)
+	log := h.Log.WithField(telemetry.Method, telemetry.GetEntryStatistics)
+
+	if request.ExpiringWithin < 0 {
+		log.Error("Expiring within cannot be negative")
+		return nil, status.Error(codes.InvalidArgument, "expiring within cannot be negative")
+	}
+	expiringWithin := defaultEntryExpiringWithin
+	if request.ExpiringWithin > 0 {
+		expiringWithin = time.Duration(request.ExpiringWithin) * time.Second
+	}
+	now := time.Now()
+	expiringBefore := now.Add(expiringWithin).Unix()
+
+	resp := &registration.GetEntryStatisticsResponse{
+		ByParentId:     make(map[string]int32),
+		BySelectorType: make(map[string]int32),
+	}
+
+	ds := h.getDataStore()
+	var token string
+	for {
+		fetchResponse, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+			Pagination: &datastore.Pagination{
+				Token:    token,
+				PageSize: defaultListEntriesPageSize,
+			},
+		})
+		if err != nil {
+			log.WithError(err).Error("Error trying to fetch entries")
+			return nil, status.Errorf(codes.Internal, "error trying to fetch entries: %v", err)
+		}
+
+		for _, entry := range fetchResponse.Entries {
+			resp.Total++
+			resp.ByParentId[entry.ParentId]++
+
+			selectorTypes := make(map[string]bool)
+			for _, selector := range entry.Selectors {
+				if !selectorTypes[selector.Type] {
+					selectorTypes[selector.Type] = true
+					resp.BySelectorType[selector.Type]++
+				}
+			}
+
+			switch {
+			case entry.EntryExpiry == 0:
+			case entry.EntryExpiry <= now.Unix():
+				resp.Expired++
+			case entry.EntryExpiry <= expiringBefore:
+				resp.ExpiringSoon++
+			}
+		}
+
+		if len(fetchResponse.Entries) == 0 || fetchResponse.Pagination == nil || fetchResponse.Pagination.Token == "" {
+			break
+		}
+		token = fetchResponse.Pagination.Token
+	}
+
+	return resp, nil
+}
+
 func (h *Handler) CreateFederatedBundle(ctx context.Context, request *registration.FederatedBundle) (_ *common.Empty, err error) {
 	counter := telemetry_registrationapi.StartCreateFedBundleCall(h.Metrics)
 	telemetry_common.AddCallerID(counter, getCallerID(ctx))
 	defer counter.Done(&err)
+	event := h.Audit.Start(getCallerID(ctx), audit.CreateFederatedBundle)
+	defer event.Done(&err)
 	log := h.Log.WithField(telemetry.Method, telemetry.CreateFederatedBundle)
 
 	bundle := request.Bundle
@@ -361,6 +482,7 @@ func (h *Handler) CreateFederatedBundle(ctx context.Context, request *registrati
 	}
 
 	ds := h.getDataStore()
+	event.AddParam("trust_domain_id", bundle.TrustDomainId)
 	if _, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
 		Bundle: bundle,
 	}); err != nil {
@@ -434,10 +556,35 @@ func (h *Handler) ListFederatedBundles(request *common.Empty, stream registratio
 	return nil
 }
 
+func (h *Handler) CountFederatedBundles(ctx context.Context, request *common.Empty) (_ *registration.CountFederatedBundlesResponse, err error) {
+	counter := telemetry_registrationapi.StartCountFedBundlesCall(h.Metrics)
+	telemetry_common.AddCallerID(counter, getCallerID(ctx))
+	defer counter.Done(&err)
+	log := h.Log.WithField(telemetry.Method, telemetry.CountFederatedBundles)
+
+	ds := h.getDataStore()
+	resp, err := ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	if err != nil {
+		log.WithError(err).Error("Failed to list bundles")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var count int32
+	for _, bundle := range resp.Bundles {
+		if bundle.TrustDomainId != h.TrustDomain.String() {
+			count++
+		}
+	}
+
+	return &registration.CountFederatedBundlesResponse{Count: count}, nil
+}
+
 func (h *Handler) UpdateFederatedBundle(ctx context.Context, request *registration.FederatedBundle) (_ *common.Empty, err error) {
 	counter := telemetry_registrationapi.StartUpdateFedBundleCall(h.Metrics)
 	telemetry_common.AddCallerID(counter, getCallerID(ctx))
 	defer counter.Done(&err)
+	event := h.Audit.Start(getCallerID(ctx), audit.UpdateFederatedBundle)
+	defer event.Done(&err)
 	log := h.Log.WithField(telemetry.Method, telemetry.UpdateFederatedBundle)
 
 	bundle := request.Bundle
@@ -457,6 +604,7 @@ func (h *Handler) UpdateFederatedBundle(ctx context.Context, request *registrati
 	}
 
 	ds := h.getDataStore()
+	event.AddParam("trust_domain_id", bundle.TrustDomainId)
 	if _, err := ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
 		Bundle: bundle,
 	}); err != nil {
@@ -471,6 +619,10 @@ func (h *Handler) DeleteFederatedBundle(ctx context.Context, request *registrati
 	counter := telemetry_registrationapi.StartDeleteFedBundleCall(h.Metrics)
 	telemetry_common.AddCallerID(counter, getCallerID(ctx))
 	defer counter.Done(&err)
+	event := h.Audit.Start(getCallerID(ctx), audit.DeleteFederatedBundle)
+	event.AddParam("trust_domain_id", request.Id)
+	event.AddParam("mode", request.Mode.String())
+	defer event.Done(&err)
 	log := h.Log.WithField(telemetry.Method, telemetry.DeleteFederatedBundle)
 
 	request.Id, err = idutil.NormalizeSpiffeID(request.Id, idutil.AllowAnyTrustDomain())
@@ -506,6 +658,11 @@ func (h *Handler) CreateJoinToken(ctx context.Context, request *registration.Joi
 	counter := telemetry_registrationapi.StartCreateJoinTokenCall(h.Metrics)
 	telemetry_common.AddCallerID(counter, getCallerID(ctx))
 	defer counter.Done(&err)
+	// The token itself is a secret and is never audited
+	event := h.Audit.Start(getCallerID(ctx), audit.CreateJoinToken)
+	event.AddParam("ttl", request.Ttl)
+	event.AddParam("max_uses", request.MaxUses)
+	defer event.Done(&err)
 	log := h.Log.WithField(telemetry.Method, telemetry.CreateJoinToken)
 
 	if request.Ttl < 1 {
@@ -513,6 +670,11 @@ func (h *Handler) CreateJoinToken(ctx context.Context, request *registration.Joi
 		return nil, status.Error(codes.InvalidArgument, "ttl is required, you must provide one")
 	}
 
+	if request.MaxUses < 0 {
+		log.Error("Max uses cannot be negative")
+		return nil, status.Error(codes.InvalidArgument, "max uses cannot be negative")
+	}
+
 	// Generate a token if one wasn't specified
 	if request.Token == "" {
 		u, err := uuid.NewV4()
@@ -528,8 +690,9 @@ func (h *Handler) CreateJoinToken(ctx context.Context, request *registration.Joi
 
 	_, err = ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
 		JoinToken: &datastore.JoinToken{
-			Token:  request.Token,
-			Expiry: expiry,
+			Token:   request.Token,
+			Expiry:  expiry,
+			MaxUses: request.MaxUses,
 		},
 	})
 	if err != nil {
@@ -566,8 +729,11 @@ func (h *Handler) FetchBundle(ctx context.Context, request *common.Empty) (_ *re
 }
 
 //EvictAgent removes a node from the attested nodes store
-func (h *Handler) EvictAgent(ctx context.Context, evictRequest *registration.EvictAgentRequest) (*registration.EvictAgentResponse, error) {
+func (h *Handler) EvictAgent(ctx context.Context, evictRequest *registration.EvictAgentRequest) (_ *registration.EvictAgentResponse, err error) {
 	spiffeID := evictRequest.GetSpiffeID()
+	event := h.Audit.Start(getCallerID(ctx), audit.EvictAgent)
+	event.AddParam("spiffe_id", spiffeID)
+	defer event.Done(&err)
 	log := h.Log.WithFields(logrus.Fields{
 		telemetry.Method:   telemetry.EvictAgent,
 		telemetry.SPIFFEID: spiffeID,
@@ -585,23 +751,184 @@ func (h *Handler) EvictAgent(ctx context.Context, evictRequest *registration.Evi
 	}, nil
 }
 
-//ListAgents returns the list of attested nodes
+//BanAgent bans a node so it can neither renew its SVID nor attest again until
+//it is evicted
+func (h *Handler) BanAgent(ctx context.Context, banRequest *registration.BanAgentRequest) (_ *registration.BanAgentResponse, err error) {
+	spiffeID := banRequest.GetSpiffeID()
+	event := h.Audit.Start(getCallerID(ctx), audit.BanAgent)
+	event.AddParam("spiffe_id", spiffeID)
+	defer event.Done(&err)
+	log := h.Log.WithFields(logrus.Fields{
+		telemetry.Method:   telemetry.BanAgent,
+		telemetry.SPIFFEID: spiffeID,
+	})
+
+	bannedNode, err := h.banAttestedNode(ctx, spiffeID)
+	if err != nil {
+		log.WithError(err).Warn("Failed to ban agent")
+		return nil, err
+	}
+
+	log.Debug("Successfully banned agent")
+	return &registration.BanAgentResponse{
+		Node: bannedNode,
+	}, nil
+}
+
+//PruneAgents removes the nodes whose SVID expired before the given time from
+//the attested nodes store
+func (h *Handler) PruneAgents(ctx context.Context, req *registration.PruneAgentsRequest) (_ *registration.PruneAgentsResponse, err error) {
+	event := h.Audit.Start(getCallerID(ctx), audit.PruneAgents)
+	event.AddParam("expires_before", req.ExpiresBefore)
+	defer event.Done(&err)
+	log := h.Log.WithFields(logrus.Fields{
+		telemetry.Method:     telemetry.PruneAgents,
+		telemetry.Expiration: req.ExpiresBefore,
+	})
+
+	ds := h.Catalog.GetDataStore()
+	if _, err := ds.PruneAttestedNodes(ctx, &datastore.PruneAttestedNodesRequest{
+		ExpiresBefore: req.ExpiresBefore,
+	}); err != nil {
+		log.WithError(err).Error("Failed to prune agents")
+		return nil, err
+	}
+
+	log.Debug("Successfully pruned agents")
+	return &registration.PruneAgentsResponse{}, nil
+}
+
+//ForceAgentRotation makes the given agents, or all the agents that are not
+//banned, rotate their SVID at their next synchronization
+func (h *Handler) ForceAgentRotation(ctx context.Context, req *registration.ForceAgentRotationRequest) (_ *registration.ForceAgentRotationResponse, err error) {
+	event := h.Audit.Start(getCallerID(ctx), audit.ForceAgentRotation)
+	event.AddParam("spiffe_ids", req.SpiffeIDs)
+	event.AddParam("all", req.All)
+	defer event.Done(&err)
+	log := h.Log.WithField(telemetry.Method, telemetry.ForceAgentRotation)
+
+	nodes, err := h.nodesToRotate(ctx, req)
+	if err != nil {
+		log.WithError(err).Warn("Failed to force agent rotation")
+		return nil, err
+	}
+
+	ds := h.Catalog.GetDataStore()
+	rotated := make([]*common.AttestedNode, 0, len(nodes))
+	for _, n := range nodes {
+		resp, err := ds.ForceAttestedNodeRotation(ctx, &datastore.ForceAttestedNodeRotationRequest{
+			SpiffeId: n.SpiffeId,
+		})
+		if err != nil {
+			log.WithError(err).WithField(telemetry.SPIFFEID, n.SpiffeId).Warn("Failed to force agent rotation")
+			return nil, err
+		}
+		rotated = append(rotated, resp.Node)
+	}
+
+	log.WithField(telemetry.Count, len(rotated)).Debug("Successfully forced agent rotation")
+	return &registration.ForceAgentRotationResponse{
+		Nodes: rotated,
+	}, nil
+}
+
+//ListAgents returns the list of attested nodes matching the filter
 func (h *Handler) ListAgents(ctx context.Context, listReq *registration.ListAgentsRequest) (*registration.ListAgentsResponse, error) {
 	log := h.Log.WithField(telemetry.Method, telemetry.ListAgents)
-	ds := h.Catalog.GetDataStore()
-	req := &datastore.ListAttestedNodesRequest{}
-	resp, err := ds.ListAttestedNodes(ctx, req)
+	nodes, err := h.listAgents(ctx, listReq.Filter)
 	if err != nil {
 		log.WithError(err).Error("Failed to list attested nodes")
 		return nil, err
 	}
-	return &registration.ListAgentsResponse{Nodes: resp.Nodes}, nil
+	return &registration.ListAgentsResponse{Nodes: nodes}, nil
+}
+
+//CountAgents returns the number of attested nodes matching the filter
+func (h *Handler) CountAgents(ctx context.Context, countReq *registration.CountAgentsRequest) (*registration.CountAgentsResponse, error) {
+	log := h.Log.WithField(telemetry.Method, telemetry.CountAgents)
+	nodes, err := h.listAgents(ctx, countReq.Filter)
+	if err != nil {
+		log.WithError(err).Error("Failed to count attested nodes")
+		return nil, err
+	}
+	return &registration.CountAgentsResponse{Count: int32(len(nodes))}, nil
+}
+
+// listAgents lists the attested nodes matching the filter. Filters supported
+// by the datastore are applied there, the rest are applied to the results.
+func (h *Handler) listAgents(ctx context.Context, filter *registration.AgentFilter) ([]*common.AttestedNode, error) {
+	req := &datastore.ListAttestedNodesRequest{}
+	if filter == nil {
+		filter = &registration.AgentFilter{}
+	}
+
+	spiffeID := filter.BySpiffeId
+	if spiffeID != "" {
+		var err error
+		spiffeID, err = idutil.NormalizeSpiffeID(spiffeID, idutil.AllowAnyTrustDomainAgent())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	req.ByAttestationType = filter.ByAttestationType
+	req.ByBanned = filter.ByBanned
+	if filter.ByExpiresAfter != 0 {
+		req.ByExpiresAfter = &wrappers.Int64Value{Value: filter.ByExpiresAfter}
+	}
+	if filter.ByExpiresBefore != 0 {
+		req.ByExpiresBefore = &wrappers.Int64Value{Value: filter.ByExpiresBefore}
+	}
+	if len(filter.BySelectors) > 0 {
+		req.BySelectorMatch = &datastore.BySelectors{
+			Selectors: filter.BySelectors,
+		}
+		switch filter.SelectorMatch {
+		case registration.AgentFilter_MATCH_EXACT:
+			req.BySelectorMatch.Match = datastore.BySelectors_MATCH_EXACT
+		case registration.AgentFilter_MATCH_SUBSET:
+			req.BySelectorMatch.Match = datastore.BySelectors_MATCH_SUBSET
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unhandled selector match behavior %q", filter.SelectorMatch)
+		}
+	}
+
+	resp, err := h.Catalog.GetDataStore().ListAttestedNodes(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if spiffeID == "" && filter.ByPathPrefix == "" {
+		return resp.Nodes, nil
+	}
+
+	var nodes []*common.AttestedNode
+	for _, node := range resp.Nodes {
+		if spiffeID != "" && node.SpiffeId != spiffeID {
+			continue
+		}
+		if filter.ByPathPrefix != "" {
+			u, err := url.Parse(node.SpiffeId)
+			if err != nil || !strings.HasPrefix(u.Path, filter.ByPathPrefix) {
+				continue
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
 }
 
 func (h *Handler) MintX509SVID(ctx context.Context, req *registration.MintX509SVIDRequest) (_ *registration.MintX509SVIDResponse, err error) {
 	counter := telemetry_registrationapi.StartMintX509SVIDCall(h.Metrics)
 	telemetry_common.AddCallerID(counter, getCallerID(ctx))
 	defer counter.Done(&err)
+	event := h.Audit.Start(getCallerID(ctx), audit.MintX509SVID)
+	event.AddParam("spiffe_id", req.SpiffeId)
+	event.AddParam("ttl", req.Ttl)
+	if len(req.DnsNames) > 0 {
+		event.AddParam("dns_names", req.DnsNames)
+	}
+	defer event.Done(&err)
 	log := h.Log.WithField(telemetry.Method, telemetry.MintX509SVID)
 
 	spiffeID, err := h.normalizeSPIFFEIDForMinting(req.SpiffeId)
@@ -643,6 +970,11 @@ func (h *Handler) MintX509SVID(ctx context.Context, req *registration.MintX509SV
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	if err := h.recordIssuedX509SVID(ctx, spiffeID, svid[0]); err != nil {
+		log.WithError(err).Error("Failed to record issued X.509 SVID")
+		return nil, status.Errorf(codes.Internal, "failed to record issued X.509 SVID: %v", err)
+	}
+
 	resp, err := h.getDataStore().FetchBundle(ctx, &datastore.FetchBundleRequest{
 		TrustDomainId: h.TrustDomain.String(),
 	})
@@ -671,10 +1003,80 @@ func (h *Handler) MintX509SVID(ctx context.Context, req *registration.MintX509SV
 	}, nil
 }
 
+//ListIssuedX509SVIDs lists the records of the X509-SVIDs issued by the server
+func (h *Handler) ListIssuedX509SVIDs(ctx context.Context, req *registration.ListIssuedX509SVIDsRequest) (_ *registration.ListIssuedX509SVIDsResponse, err error) {
+	counter := telemetry_registrationapi.StartListIssuedX509SVIDsCall(h.Metrics)
+	telemetry_common.AddCallerID(counter, getCallerID(ctx))
+	defer counter.Done(&err)
+	log := h.Log.WithField(telemetry.Method, telemetry.ListIssuedX509SVIDs)
+
+	dsReq := &datastore.ListIssuedX509SVIDsRequest{
+		Pagination: &datastore.Pagination{
+			PageSize: defaultListEntriesPageSize,
+		},
+	}
+	if req.Pagination != nil {
+		if req.Pagination.PageSize != 0 {
+			dsReq.Pagination.PageSize = req.Pagination.PageSize
+		}
+		dsReq.Pagination.Token = req.Pagination.Token
+	}
+	if req.BySpiffeId != "" {
+		spiffeID, err := idutil.NormalizeSpiffeID(req.BySpiffeId, idutil.AllowAny())
+		if err != nil {
+			log.WithError(err).Error("Invalid SPIFFE ID filter")
+			return nil, status.Errorf(codes.InvalidArgument, "invalid SPIFFE ID filter: %v", err)
+		}
+		dsReq.BySpiffeId = &wrappers.StringValue{Value: spiffeID}
+	}
+	if req.ByAgentId != "" {
+		agentID, err := idutil.NormalizeSpiffeID(req.ByAgentId, idutil.AllowAnyTrustDomainAgent())
+		if err != nil {
+			log.WithError(err).Error("Invalid agent ID filter")
+			return nil, status.Errorf(codes.InvalidArgument, "invalid agent ID filter: %v", err)
+		}
+		dsReq.ByAgentId = &wrappers.StringValue{Value: agentID}
+	}
+	if req.ByExpiresAfter != 0 {
+		dsReq.ByExpiresAfter = &wrappers.Int64Value{Value: req.ByExpiresAfter}
+	}
+
+	resp, err := h.getDataStore().ListIssuedX509SVIDs(ctx, dsReq)
+	if err != nil {
+		log.WithError(err).Error("Failed to list issued X.509 SVIDs")
+		return nil, status.Errorf(codes.Internal, "failed to list issued X.509 SVIDs: %v", err)
+	}
+
+	svids := make([]*registration.IssuedX509SVID, 0, len(resp.Svids))
+	for _, svid := range resp.Svids {
+		svids = append(svids, &registration.IssuedX509SVID{
+			SpiffeId:     svid.SpiffeId,
+			SerialNumber: svid.SerialNumber,
+			IssuedAt:     svid.IssuedAt,
+			ExpiresAt:    svid.ExpiresAt,
+			CaKeyId:      svid.CaKeyId,
+			AgentId:      svid.AgentId,
+			EntryId:      svid.EntryId,
+		})
+	}
+	return &registration.ListIssuedX509SVIDsResponse{
+		Svids: svids,
+		Pagination: &registration.Pagination{
+			Token:    resp.Pagination.Token,
+			PageSize: resp.Pagination.PageSize,
+		},
+	}, nil
+}
+
 func (h *Handler) MintJWTSVID(ctx context.Context, req *registration.MintJWTSVIDRequest) (_ *registration.MintJWTSVIDResponse, err error) {
 	counter := telemetry_registrationapi.StartMintJWTSVIDCall(h.Metrics)
 	telemetry_common.AddCallerID(counter, getCallerID(ctx))
 	defer counter.Done(&err)
+	event := h.Audit.Start(getCallerID(ctx), audit.MintJWTSVID)
+	event.AddParam("spiffe_id", req.SpiffeId)
+	event.AddParam("ttl", req.Ttl)
+	event.AddParam("audience", req.Audience)
+	defer event.Done(&err)
 	log := h.Log.WithField(telemetry.Method, telemetry.MintJWTSVID)
 
 	spiffeID, err := h.normalizeSPIFFEIDForMinting(req.SpiffeId)
@@ -741,6 +1143,78 @@ func (h *Handler) deleteAttestedNode(ctx context.Context, agentID string) (*comm
 	return resp.Node, nil
 }
 
+// nodesToRotate returns the attested nodes whose SVID rotation is requested.
+// Banned nodes cannot rotate their SVID.
+func (h *Handler) nodesToRotate(ctx context.Context, req *registration.ForceAgentRotationRequest) ([]*common.AttestedNode, error) {
+	ds := h.Catalog.GetDataStore()
+	if req.All {
+		if len(req.SpiffeIDs) > 0 {
+			return nil, status.Error(codes.InvalidArgument, "agent SPIFFE IDs cannot be set when all agents are rotated")
+		}
+		resp, err := ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+			ByBanned: &wrappers.BoolValue{Value: false},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Nodes, nil
+	}
+
+	if len(req.SpiffeIDs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one agent SPIFFE ID is required")
+	}
+	nodes := make([]*common.AttestedNode, 0, len(req.SpiffeIDs))
+	for _, spiffeID := range req.SpiffeIDs {
+		agentID, err := idutil.NormalizeSpiffeID(spiffeID, idutil.AllowAnyTrustDomainAgent())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		resp, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+			SpiffeId: agentID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.Node == nil {
+			return nil, status.Errorf(codes.NotFound, "no attested node with SPIFFE ID %q", agentID)
+		}
+		if nodeutil.IsAgentBanned(resp.Node) {
+			return nil, status.Errorf(codes.FailedPrecondition, "agent %q is banned", agentID)
+		}
+		nodes = append(nodes, resp.Node)
+	}
+	return nodes, nil
+}
+
+// banAttestedNode bans a node by clearing its current and new SVID serial
+// numbers, which invalidates the SVIDs it holds
+func (h *Handler) banAttestedNode(ctx context.Context, agentID string) (*common.AttestedNode, error) {
+	if agentID == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty agent ID")
+	}
+
+	ds := h.Catalog.GetDataStore()
+	fetchResp, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: agentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fetchResp.Node == nil {
+		return nil, status.Errorf(codes.NotFound, "no attested node with SPIFFE ID %q", agentID)
+	}
+
+	resp, err := ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:     agentID,
+		CertNotAfter: fetchResp.Node.CertNotAfter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Node, nil
+}
+
 func (h *Handler) normalizeSPIFFEIDForMinting(spiffeID string) (string, error) {
 	if spiffeID == "" {
 		return "", status.Error(codes.InvalidArgument, "request missing SPIFFE ID")
@@ -754,6 +1228,24 @@ func (h *Handler) normalizeSPIFFEIDForMinting(spiffeID string) (string, error) {
 	return spiffeID, nil
 }
 
+// recordIssuedX509SVID records the minted X509-SVID in the datastore, if
+// tracking of issued SVIDs is enabled
+func (h *Handler) recordIssuedX509SVID(ctx context.Context, spiffeID string, svid *x509.Certificate) error {
+	if !h.TrackIssuedSVIDs {
+		return nil
+	}
+	_, err := h.getDataStore().CreateIssuedX509SVID(ctx, &datastore.CreateIssuedX509SVIDRequest{
+		Svid: &datastore.IssuedX509SVID{
+			SpiffeId:     spiffeID,
+			SerialNumber: svid.SerialNumber.String(),
+			IssuedAt:     svid.NotBefore.Unix(),
+			ExpiresAt:    svid.NotAfter.Unix(),
+			CaKeyId:      hex.EncodeToString(svid.AuthorityKeyId),
+		},
+	})
+	return err
+}
+
 func (h *Handler) isEntryUnique(ctx context.Context, ds datastore.DataStore, entry *common.RegistrationEntry) (*common.RegistrationEntry, bool, error) {
 	// First we get all the entries that matches the entry's spiffe id.
 	req := &datastore.ListRegistrationEntriesRequest{
@@ -800,23 +1292,59 @@ func (h *Handler) createRegistrationEntry(ctx context.Context, requestedEntry *c
 	}
 
 	if !unique {
+		// an entry ID supplied by the caller has to match the ID of the
+		// existing entry for the creation to be idempotent
+		if requestedEntry.EntryId != "" && requestedEntry.EntryId != existingEntry.EntryId {
+			return nil, false, status.Errorf(codes.AlreadyExists, "entry already exists with ID %q", existingEntry.EntryId)
+		}
 		return existingEntry, true, nil
 	}
 
+	if err := h.adviseEntry(ctx, &notifier.NotifyAndAdviseRequest{
+		Event: &notifier.NotifyAndAdviseRequest_EntryCreating{
+			EntryCreating: &notifier.EntryCreating{
+				Entry: requestedEntry,
+			},
+		},
+	}); err != nil {
+		return nil, false, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	createResponse, err := ds.CreateRegistrationEntry(ctx,
 		&datastore.CreateRegistrationEntryRequest{Entry: requestedEntry},
 	)
-	if err != nil {
+	switch {
+	case status.Code(err) == codes.AlreadyExists:
+		// the entry ID supplied by the caller is used by another entry
+		return nil, false, status.Error(codes.AlreadyExists, status.Convert(err).Message())
+	case err != nil:
 		return nil, false, status.Errorf(codes.Internal, "error trying to create entry: %v", err)
 	}
 
 	return createResponse.Entry, false, nil
 }
+
+// adviseEntry gives the configured notifiers the opportunity to reject a
+// registration entry before it is persisted.
+func (h *Handler) adviseEntry(ctx context.Context, req *notifier.NotifyAndAdviseRequest) error {
+	for _, n := range h.Catalog.GetNotifiers() {
+		if _, err := n.NotifyAndAdvise(ctx, req); err != nil {
+			return fmt.Errorf("entry rejected by notifier %q: %v", n.Name(), status.Convert(err).Message())
+		}
+	}
+	return nil
+}
+
 func (h *Handler) prepareRegistrationEntry(entry *common.RegistrationEntry, forUpdate bool) (*common.RegistrationEntry, error) {
 	entry = cloneRegistrationEntry(entry)
 	if forUpdate && entry.EntryId == "" {
 		return nil, errors.New("missing registration entry id")
 	}
+	if !forUpdate && entry.EntryId != "" {
+		if err := util.ValidateRegistrationEntryID(entry.EntryId); err != nil {
+			return nil, err
+		}
+	}
 
 	var err error
 	for _, dns := range entry.DnsNames {
@@ -7,22 +7,31 @@ import (
 	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
 	"encoding/pem"
+	"fmt"
 	"net"
 	"net/url"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/auth"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/audit"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/notifier"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakenotifier"
 	"github.com/spiffe/spire/test/fakes/fakeserverca"
 	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
 	"github.com/spiffe/spire/test/spiretest"
@@ -72,7 +81,9 @@ type HandlerSuite struct {
 	server *grpc.Server
 
 	ds       *fakedatastore.DataStore
+	catalog  *fakeservercatalog.Catalog
 	serverCA *fakeserverca.CA
+	audit    *fakeAuditSink
 	handler  registration.RegistrationClient
 }
 
@@ -84,6 +95,9 @@ func (s *HandlerSuite) SetupTest() {
 
 	catalog := fakeservercatalog.New()
 	catalog.SetDataStore(s.ds)
+	s.catalog = catalog
+
+	s.audit = &fakeAuditSink{}
 
 	handler := &Handler{
 		Log:         log,
@@ -91,6 +105,9 @@ func (s *HandlerSuite) SetupTest() {
 		TrustDomain: url.URL{Scheme: "spiffe", Host: "example.org"},
 		Catalog:     catalog,
 		ServerCA:    s.serverCA,
+		Audit:       audit.New(log, s.audit),
+
+		TrackIssuedSVIDs: true,
 	}
 
 	// we need to test a streaming API. without doing the same codegen we
@@ -225,6 +242,37 @@ func (s *HandlerSuite) TestListFederatedBundles() {
 	s.Require().EqualError(err, "EOF")
 }
 
+func (s *HandlerSuite) TestCountFederatedBundles() {
+	response, err := s.handler.CountFederatedBundles(context.Background(), &common.Empty{})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(0), response.Count)
+
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas: []*common.Certificate{
+			{DerBytes: []byte("EXAMPLE")},
+		},
+	})
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://example2.org",
+		RootCas: []*common.Certificate{
+			{DerBytes: []byte("EXAMPLE2")},
+		},
+	})
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://example3.org",
+		RootCas: []*common.Certificate{
+			{DerBytes: []byte("EXAMPLE3")},
+		},
+	})
+
+	// Assert that the count does not include the bundle for the server
+	// trust domain
+	response, err = s.handler.CountFederatedBundles(context.Background(), &common.Empty{})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(2), response.Count)
+}
+
 func (s *HandlerSuite) TestUpdateFederatedBundle() {
 	// create a bundle to be updated
 	s.createBundle(&common.Bundle{
@@ -478,6 +526,57 @@ func (s *HandlerSuite) TestCreateEntryIfNotExists() {
 	}
 }
 
+func (s *HandlerSuite) TestCreateEntryIfNotExistsWithEntryID() {
+	entry := &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/child",
+		Selectors: []*common.Selector{{Type: "B", Value: "b"}},
+	}
+	entry.EntryId = util.DeriveRegistrationEntryID(entry)
+
+	// the supplied ID is used
+	resp, err := s.handler.CreateEntryIfNotExists(context.Background(), entry)
+	s.Require().NoError(err)
+	s.Require().False(resp.Preexisting)
+	s.Require().Equal(entry.EntryId, resp.Entry.EntryId)
+
+	// creating the same entry with the same ID again is idempotent
+	resp, err = s.handler.CreateEntryIfNotExists(context.Background(), entry)
+	s.Require().NoError(err)
+	s.Require().True(resp.Preexisting)
+	s.Require().Equal(entry.EntryId, resp.Entry.EntryId)
+
+	// the same entry with another ID conflicts with the existing entry
+	resp, err = s.handler.CreateEntryIfNotExists(context.Background(), &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/child",
+		Selectors: []*common.Selector{{Type: "B", Value: "b"}},
+		EntryId:   "other",
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.AlreadyExists, fmt.Sprintf("entry already exists with ID %q", entry.EntryId))
+	s.Require().Nil(resp)
+
+	// another entry with the same ID conflicts with the existing entry
+	resp, err = s.handler.CreateEntryIfNotExists(context.Background(), &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/other",
+		Selectors: []*common.Selector{{Type: "B", Value: "b"}},
+		EntryId:   entry.EntryId,
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.AlreadyExists, fmt.Sprintf("registration entry ID %q is already in use", entry.EntryId))
+	s.Require().Nil(resp)
+
+	// malformed IDs are rejected
+	resp, err = s.handler.CreateEntryIfNotExists(context.Background(), &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/other",
+		Selectors: []*common.Selector{{Type: "B", Value: "b"}},
+		EntryId:   "foo bar",
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, `entry ID "foo bar" contains characters other than letters, digits, dots, dashes, underscores and colons`)
+	s.Require().Nil(resp)
+}
+
 func (s *HandlerSuite) TestUpdateEntry() {
 	original := s.createRegistrationEntry(&common.RegistrationEntry{
 		ParentId:  "spiffe://example.org/foo",
@@ -547,12 +646,91 @@ func (s *HandlerSuite) TestUpdateEntry() {
 				return
 			}
 			require.NoError(t, err)
+			entry.RevisionNumber++
 			t.Logf("actual=%+v expected=%+v", resp, entry)
 			require.True(t, proto.Equal(resp, entry))
 		})
 	}
 }
 
+func (s *HandlerSuite) TestUpdateEntryWithStaleRevisionNumber() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/foo",
+		SpiffeId:  "spiffe://example.org/bar",
+		Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+	})
+
+	// the first update bumps the revision number
+	updated, err := s.handler.UpdateEntry(context.Background(), &registration.UpdateEntryRequest{
+		Entry: entry,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(entry.RevisionNumber+1, updated.RevisionNumber)
+
+	// a second update based on the original revision is rejected
+	_, err = s.handler.UpdateEntry(context.Background(), &registration.UpdateEntryRequest{
+		Entry: entry,
+	})
+	s.Require().Equal(codes.Aborted, status.Code(err))
+	s.Require().Contains(status.Convert(err).Message(), "is not at revision 0")
+
+	// as is a deletion
+	_, err = s.handler.DeleteEntry(context.Background(), &registration.RegistrationEntryID{
+		Id:             entry.EntryId,
+		RevisionNumber: entry.RevisionNumber,
+	})
+	s.Require().Equal(codes.Aborted, status.Code(err))
+
+	// deleting the current revision succeeds
+	_, err = s.handler.DeleteEntry(context.Background(), &registration.RegistrationEntryID{
+		Id:             entry.EntryId,
+		RevisionNumber: updated.RevisionNumber,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *HandlerSuite) TestEntryRejectedByNotifier() {
+	original := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/foo",
+		SpiffeId:  "spiffe://example.org/bar",
+		Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+	})
+
+	var events []*notifier.NotifyAndAdviseRequest
+	s.catalog.AddNotifier(fakeservercatalog.Notifier("policy", fakenotifier.New(fakenotifier.Config{
+		OnNotifyAndAdvise: func(req *notifier.NotifyAndAdviseRequest) (*notifier.NotifyAndAdviseResponse, error) {
+			events = append(events, req)
+			return nil, status.Error(codes.PermissionDenied, "ohno")
+		},
+	})))
+
+	_, err := s.handler.CreateEntry(context.Background(), &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/foo",
+		SpiffeId:  "spiffe://example.org/baz",
+		Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+	})
+	s.requireErrorContains(err, `entry rejected by notifier "policy": ohno`)
+	s.requireGRPCStatusCode(err, codes.InvalidArgument)
+	s.Require().Len(events, 1)
+	s.Require().Equal("spiffe://example.org/baz", events[0].GetEntryCreating().Entry.SpiffeId)
+
+	updated := proto.Clone(original).(*common.RegistrationEntry)
+	updated.Selectors = []*common.Selector{{Type: "B", Value: "b"}}
+	_, err = s.handler.UpdateEntry(context.Background(), &registration.UpdateEntryRequest{
+		Entry: updated,
+	})
+	s.requireErrorContains(err, `entry rejected by notifier "policy": ohno`)
+	s.requireGRPCStatusCode(err, codes.InvalidArgument)
+	s.Require().Len(events, 2)
+	s.Require().Equal(original.EntryId, events[1].GetEntryUpdating().Entry.EntryId)
+
+	// neither request should have touched the datastore
+	resp, err := s.ds.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Entries, 1)
+	s.Require().True(proto.Equal(original, resp.Entries[0]))
+}
+
 func (s *HandlerSuite) TestDeleteEntry() {
 	entry := s.createRegistrationEntry(&common.RegistrationEntry{
 		ParentId:  "spiffe://example.org/foo",
@@ -965,12 +1143,112 @@ func (s *HandlerSuite) TestListAllEntriesWithPages() {
 	s.Require().Empty(resp.Pagination.Token)
 }
 
+func (s *HandlerSuite) TestGetEntryStatistics() {
+	now := time.Now()
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:    "spiffe://example.org/foo",
+		SpiffeId:    "spiffe://example.org/expired",
+		Selectors:   []*common.Selector{{Type: "A", Value: "a"}, {Type: "A", Value: "b"}},
+		EntryExpiry: now.Add(-time.Minute).Unix(),
+	})
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:    "spiffe://example.org/foo",
+		SpiffeId:    "spiffe://example.org/expiring",
+		Selectors:   []*common.Selector{{Type: "A", Value: "a"}, {Type: "B", Value: "b"}},
+		EntryExpiry: now.Add(time.Hour).Unix(),
+	})
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:    "spiffe://example.org/bar",
+		SpiffeId:    "spiffe://example.org/later",
+		Selectors:   []*common.Selector{{Type: "B", Value: "b"}},
+		EntryExpiry: now.Add(48 * time.Hour).Unix(),
+	})
+	// span more than one datastore page
+	for i := 0; i < defaultListEntriesPageSize; i++ {
+		s.createRegistrationEntry(&common.RegistrationEntry{
+			ParentId:  "spiffe://example.org/bar",
+			SpiffeId:  fmt.Sprintf("spiffe://example.org/workload-%d", i),
+			Selectors: []*common.Selector{{Type: "C", Value: "c"}},
+		})
+	}
+
+	tests := []struct {
+		name     string
+		request  *registration.GetEntryStatisticsRequest
+		expected *registration.GetEntryStatisticsResponse
+		err      string
+	}{
+		{
+			name:    "default expiring within",
+			request: &registration.GetEntryStatisticsRequest{},
+			expected: &registration.GetEntryStatisticsResponse{
+				Total: 53,
+				ByParentId: map[string]int32{
+					"spiffe://example.org/foo": 2,
+					"spiffe://example.org/bar": 51,
+				},
+				BySelectorType: map[string]int32{
+					"A": 2,
+					"B": 2,
+					"C": 50,
+				},
+				ExpiringSoon: 1,
+				Expired:      1,
+			},
+		},
+		{
+			name: "custom expiring within",
+			request: &registration.GetEntryStatisticsRequest{
+				ExpiringWithin: int64((72 * time.Hour) / time.Second),
+			},
+			expected: &registration.GetEntryStatisticsResponse{
+				Total: 53,
+				ByParentId: map[string]int32{
+					"spiffe://example.org/foo": 2,
+					"spiffe://example.org/bar": 51,
+				},
+				BySelectorType: map[string]int32{
+					"A": 2,
+					"B": 2,
+					"C": 50,
+				},
+				ExpiringSoon: 2,
+				Expired:      1,
+			},
+		},
+		{
+			name: "negative expiring within",
+			request: &registration.GetEntryStatisticsRequest{
+				ExpiringWithin: -1,
+			},
+			err: "rpc error: code = InvalidArgument desc = expiring within cannot be negative",
+		},
+	}
+	for _, testCase := range tests {
+		testCase := testCase
+		s.T().Run(testCase.name, func(t *testing.T) {
+			resp, err := s.handler.GetEntryStatistics(context.Background(), testCase.request)
+			if testCase.err != "" {
+				require.EqualError(t, err, testCase.err)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoEqual(t, testCase.expected, resp)
+		})
+	}
+}
+
 func (s *HandlerSuite) TestCreateJoinToken() {
 	// No ttl
 	resp, err := s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{Token: "foo"})
 	s.requireErrorContains(err, "ttl is required")
 	s.Require().Nil(resp)
 
+	// Negative max uses
+	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{Ttl: 1, MaxUses: -1})
+	s.requireErrorContains(err, "max uses cannot be negative")
+	s.Require().Nil(resp)
+
 	// No token specified (one will be generated)
 	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{Ttl: 1})
 	s.Require().NoError(err)
@@ -982,12 +1260,64 @@ func (s *HandlerSuite) TestCreateJoinToken() {
 	s.Require().NoError(err)
 	s.Require().Equal(resp, &registration.JoinToken{Token: "foo", Ttl: 1})
 
+	// Reusable token
+	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{Token: "bar", Ttl: 1, MaxUses: 5})
+	s.Require().NoError(err)
+	s.Require().Equal(resp, &registration.JoinToken{Token: "bar", Ttl: 1, MaxUses: 5})
+	fetchResp, err := s.ds.FetchJoinToken(context.Background(), &datastore.FetchJoinTokenRequest{Token: "bar"})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(5), fetchResp.JoinToken.MaxUses)
+
 	// Already exists
 	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{Token: "foo", Ttl: 1})
 	s.requireErrorContains(err, "Failed to register token")
 	s.Require().Nil(resp)
 }
 
+func (s *HandlerSuite) TestCreateJoinTokenIsAudited() {
+	_, err := s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{Token: "secret", Ttl: 60, MaxUses: 2})
+	s.Require().NoError(err)
+
+	events := s.audit.Events(s.T())
+	s.Require().Len(events, 1)
+	s.Equal(audit.CreateJoinToken, events[0].Action)
+	s.Equal(audit.ResultSuccess, events[0].Result)
+	s.Equal(map[string]interface{}{"ttl": 60.0, "max_uses": 2.0}, events[0].Params)
+	s.NotContains(string(s.audit.events[0]), "secret")
+}
+
+func (s *HandlerSuite) TestCreateEntryIsAudited() {
+	entry := &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/child",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1111"}},
+	}
+	resp, err := s.handler.CreateEntry(context.Background(), entry)
+	s.Require().NoError(err)
+
+	events := s.audit.Events(s.T())
+	s.Require().Len(events, 1)
+	s.Equal(audit.CreateEntry, events[0].Action)
+	s.Equal(audit.ResultSuccess, events[0].Result)
+	s.Equal(resp.Id, events[0].Params["entry_id"])
+	s.Equal("spiffe://example.org/child", events[0].Params["spiffe_id"])
+	s.Equal([]interface{}{"unix:uid:1111"}, events[0].Params["selectors"])
+}
+
+func (s *HandlerSuite) TestFailuresAreAudited() {
+	_, err := s.handler.BanAgent(context.Background(), &registration.BanAgentRequest{
+		SpiffeID: "spiffe://example.org/spire/agent/join_token/token_b",
+	})
+	s.Require().Error(err)
+
+	events := s.audit.Events(s.T())
+	s.Require().Len(events, 1)
+	s.Equal(audit.BanAgent, events[0].Action)
+	s.Equal(audit.ResultFailure, events[0].Result)
+	s.Contains(events[0].Error, "no attested node")
+	s.Equal("spiffe://example.org/spire/agent/join_token/token_b", events[0].Params["spiffe_id"])
+}
+
 func (s *HandlerSuite) TestFetchBundle() {
 	// No bundle
 	resp, err := s.handler.FetchBundle(context.Background(), &common.Empty{})
@@ -1035,6 +1365,146 @@ func (s *HandlerSuite) TestEvictAgentWithNonExistentId() {
 	s.Error(err, "Evict should have failed")
 }
 
+func (s *HandlerSuite) TestBanAgent() {
+	ctx := context.Background()
+	spiffeID := "spiffe://example.org/spire/agent/join_token/token_a"
+	_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+		Node: &common.AttestedNode{
+			SpiffeId:            spiffeID,
+			AttestationDataType: "join_token",
+			CertSerialNumber:    "1",
+			CertNotAfter:        100,
+			NewCertSerialNumber: "2",
+			NewCertNotAfter:     200,
+		},
+	})
+	s.Require().NoError(err)
+
+	banResponse, err := s.handler.BanAgent(ctx, &registration.BanAgentRequest{SpiffeID: spiffeID})
+	s.Require().NoError(err)
+	s.Equal("", banResponse.Node.CertSerialNumber)
+	s.Equal("", banResponse.Node.NewCertSerialNumber)
+	s.Equal(int64(100), banResponse.Node.CertNotAfter)
+	s.Equal(int64(0), banResponse.Node.NewCertNotAfter)
+
+	// The ban is persisted
+	fetchResponse, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: spiffeID})
+	s.Require().NoError(err)
+	s.True(nodeutil.IsAgentBanned(fetchResponse.Node))
+}
+
+func (s *HandlerSuite) TestBanAgentWithNonExistentId() {
+	s.createAttestedNode("spiffe://example.org/spire/agent/join_token/token_a")
+
+	_, err := s.handler.BanAgent(context.Background(), &registration.BanAgentRequest{
+		SpiffeID: "spiffe://example.org/spire/agent/join_token/token_b",
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.NotFound, `no attested node with SPIFFE ID "spiffe://example.org/spire/agent/join_token/token_b"`)
+}
+
+func (s *HandlerSuite) TestPruneAgents() {
+	ctx := context.Background()
+	now := time.Now()
+	for _, node := range []*common.AttestedNode{
+		{SpiffeId: "spiffe://example.org/spire/agent/join_token/expired", CertSerialNumber: "1", CertNotAfter: now.Add(-time.Hour).Unix()},
+		{SpiffeId: "spiffe://example.org/spire/agent/join_token/banned", CertNotAfter: now.Add(-time.Hour).Unix()},
+		{SpiffeId: "spiffe://example.org/spire/agent/join_token/current", CertSerialNumber: "2", CertNotAfter: now.Add(time.Hour).Unix()},
+	} {
+		_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+		s.Require().NoError(err)
+	}
+
+	_, err := s.handler.PruneAgents(ctx, &registration.PruneAgentsRequest{
+		ExpiresBefore: now.Unix(),
+	})
+	s.Require().NoError(err)
+
+	listResponse, err := s.handler.ListAgents(ctx, &registration.ListAgentsRequest{})
+	s.Require().NoError(err)
+	var ids []string
+	for _, node := range listResponse.Nodes {
+		ids = append(ids, node.SpiffeId)
+	}
+	s.Equal([]string{
+		"spiffe://example.org/spire/agent/join_token/banned",
+		"spiffe://example.org/spire/agent/join_token/current",
+	}, ids)
+}
+
+func (s *HandlerSuite) TestForceAgentRotation() {
+	ctx := context.Background()
+	for _, node := range []*common.AttestedNode{
+		{SpiffeId: "spiffe://example.org/spire/agent/join_token/a", CertSerialNumber: "1", CertNotAfter: 100, NewCertSerialNumber: "2", NewCertNotAfter: 200},
+		{SpiffeId: "spiffe://example.org/spire/agent/join_token/b", CertSerialNumber: "3", CertNotAfter: 300},
+		{SpiffeId: "spiffe://example.org/spire/agent/join_token/banned", CertNotAfter: 400},
+	} {
+		_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+		s.Require().NoError(err)
+	}
+
+	resp, err := s.handler.ForceAgentRotation(ctx, &registration.ForceAgentRotationRequest{
+		SpiffeIDs: []string{"spiffe://example.org/spire/agent/join_token/a"},
+	})
+	s.Require().NoError(err)
+	s.Equal([]*common.AttestedNode{
+		{
+			SpiffeId:            "spiffe://example.org/spire/agent/join_token/a",
+			CertSerialNumber:    "1",
+			CertNotAfter:        100,
+			NewCertSerialNumber: "2",
+			NewCertNotAfter:     200,
+			ForceRotation:       true,
+		},
+	}, resp.Nodes)
+
+	// The rotation is persisted and only affects the given agent
+	fetchResponse, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: "spiffe://example.org/spire/agent/join_token/a"})
+	s.Require().NoError(err)
+	s.True(fetchResponse.Node.ForceRotation)
+	fetchResponse, err = s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: "spiffe://example.org/spire/agent/join_token/b"})
+	s.Require().NoError(err)
+	s.False(fetchResponse.Node.ForceRotation)
+
+	// Banned agents cannot rotate their SVID
+	_, err = s.handler.ForceAgentRotation(ctx, &registration.ForceAgentRotationRequest{
+		SpiffeIDs: []string{"spiffe://example.org/spire/agent/join_token/banned"},
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.FailedPrecondition, `agent "spiffe://example.org/spire/agent/join_token/banned" is banned`)
+
+	// All the agents that are not banned are rotated
+	resp, err = s.handler.ForceAgentRotation(ctx, &registration.ForceAgentRotationRequest{
+		All: true,
+	})
+	s.Require().NoError(err)
+	var ids []string
+	for _, node := range resp.Nodes {
+		s.True(node.ForceRotation)
+		ids = append(ids, node.SpiffeId)
+	}
+	s.Equal([]string{
+		"spiffe://example.org/spire/agent/join_token/a",
+		"spiffe://example.org/spire/agent/join_token/b",
+	}, ids)
+}
+
+func (s *HandlerSuite) TestForceAgentRotationWithInvalidRequest() {
+	s.createAttestedNode("spiffe://example.org/spire/agent/join_token/token_a")
+
+	_, err := s.handler.ForceAgentRotation(context.Background(), &registration.ForceAgentRotationRequest{})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, "at least one agent SPIFFE ID is required")
+
+	_, err = s.handler.ForceAgentRotation(context.Background(), &registration.ForceAgentRotationRequest{
+		SpiffeIDs: []string{"spiffe://example.org/spire/agent/join_token/token_a"},
+		All:       true,
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, "agent SPIFFE IDs cannot be set when all agents are rotated")
+
+	_, err = s.handler.ForceAgentRotation(context.Background(), &registration.ForceAgentRotationRequest{
+		SpiffeIDs: []string{"spiffe://example.org/spire/agent/join_token/token_b"},
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.NotFound, `no attested node with SPIFFE ID "spiffe://example.org/spire/agent/join_token/token_b"`)
+}
+
 func (s *HandlerSuite) TestListAgents() {
 	// Creating attested nodes list
 	ctx := context.Background()
@@ -1054,6 +1524,113 @@ func (s *HandlerSuite) TestListAgents() {
 	s.Equal(listResponse.Nodes, expectedNodeList)
 }
 
+func (s *HandlerSuite) TestListAndCountAgentsWithFilter() {
+	ctx := context.Background()
+	now := time.Now()
+
+	nodeA := &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/join_token/token_a",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "1",
+		CertNotAfter:        now.Add(time.Hour).Unix(),
+	}
+	nodeB := &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/x509pop/b",
+		AttestationDataType: "x509pop",
+		CertSerialNumber:    "2",
+		CertNotAfter:        now.Add(-time.Hour).Unix(),
+	}
+	nodeC := &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/x509pop/c",
+		AttestationDataType: "x509pop",
+		CertNotAfter:        now.Add(2 * time.Hour).Unix(),
+	}
+	for _, node := range []*common.AttestedNode{nodeA, nodeB, nodeC} {
+		_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+		s.Require().NoError(err)
+	}
+	_, err := s.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  nodeB.SpiffeId,
+			Selectors: []*common.Selector{{Type: "x509pop", Value: "subject:cn:b"}},
+		},
+	})
+	s.Require().NoError(err)
+
+	for _, tt := range []struct {
+		name        string
+		filter      *registration.AgentFilter
+		expectedIDs []string
+		expectedErr string
+	}{
+		{
+			name:        "no filter",
+			expectedIDs: []string{nodeA.SpiffeId, nodeB.SpiffeId, nodeC.SpiffeId},
+		},
+		{
+			name:        "by SPIFFE ID",
+			filter:      &registration.AgentFilter{BySpiffeId: nodeB.SpiffeId},
+			expectedIDs: []string{nodeB.SpiffeId},
+		},
+		{
+			name:        "by invalid SPIFFE ID",
+			filter:      &registration.AgentFilter{BySpiffeId: "spiffe://example.org/not-an-agent"},
+			expectedErr: `"spiffe://example.org/not-an-agent" is not a valid agent SPIFFE ID: invalid path: expecting "/spire/agent/*"`,
+		},
+		{
+			name:        "by path prefix",
+			filter:      &registration.AgentFilter{ByPathPrefix: "/spire/agent/x509pop"},
+			expectedIDs: []string{nodeB.SpiffeId, nodeC.SpiffeId},
+		},
+		{
+			name:        "by attestation type",
+			filter:      &registration.AgentFilter{ByAttestationType: "join_token"},
+			expectedIDs: []string{nodeA.SpiffeId},
+		},
+		{
+			name: "by selectors",
+			filter: &registration.AgentFilter{
+				BySelectors:   []*common.Selector{{Type: "x509pop", Value: "subject:cn:b"}},
+				SelectorMatch: registration.AgentFilter_MATCH_EXACT,
+			},
+			expectedIDs: []string{nodeB.SpiffeId},
+		},
+		{
+			name:        "banned",
+			filter:      &registration.AgentFilter{ByBanned: &wrappers.BoolValue{Value: true}},
+			expectedIDs: []string{nodeC.SpiffeId},
+		},
+		{
+			name: "by expiry window",
+			filter: &registration.AgentFilter{
+				ByExpiresAfter:  now.Unix(),
+				ByExpiresBefore: now.Add(90 * time.Minute).Unix(),
+			},
+			expectedIDs: []string{nodeA.SpiffeId},
+		},
+	} {
+		tt := tt
+		s.Run(tt.name, func() {
+			listResp, err := s.handler.ListAgents(ctx, &registration.ListAgentsRequest{Filter: tt.filter})
+			countResp, countErr := s.handler.CountAgents(ctx, &registration.CountAgentsRequest{Filter: tt.filter})
+			if tt.expectedErr != "" {
+				spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, tt.expectedErr)
+				spiretest.RequireGRPCStatus(s.T(), countErr, codes.InvalidArgument, tt.expectedErr)
+				return
+			}
+			s.Require().NoError(err)
+			s.Require().NoError(countErr)
+
+			var ids []string
+			for _, node := range listResp.Nodes {
+				ids = append(ids, node.SpiffeId)
+			}
+			s.Equal(tt.expectedIDs, ids)
+			s.Equal(int32(len(tt.expectedIDs)), countResp.Count)
+		})
+	}
+}
+
 func (s *HandlerSuite) TestListWithNoAgents() {
 	// Creating attested nodes list
 	ctx := context.Background()
@@ -1202,6 +1779,113 @@ func (s *HandlerSuite) TestMintX509SVID() {
 			if len(req.DnsNames) > 0 {
 				require.Equal(t, req.DnsNames[0], svid.Subject.CommonName)
 			}
+
+			// assert that the issued SVID has been recorded
+			listResp, err := s.ds.ListIssuedX509SVIDs(context.Background(), &datastore.ListIssuedX509SVIDsRequest{})
+			require.NoError(t, err)
+			require.NotEmpty(t, listResp.Svids)
+			spiretest.RequireProtoEqual(t, &datastore.IssuedX509SVID{
+				SpiffeId:     req.SpiffeId,
+				SerialNumber: svid.SerialNumber.String(),
+				IssuedAt:     svid.NotBefore.Unix(),
+				ExpiresAt:    svid.NotAfter.Unix(),
+				CaKeyId:      hex.EncodeToString(svid.AuthorityKeyId),
+			}, listResp.Svids[len(listResp.Svids)-1])
+		})
+	}
+}
+
+func (s *HandlerSuite) TestListIssuedX509SVIDs() {
+	now := time.Now().Unix()
+	agentID := "spiffe://example.org/spire/agent/test/foo"
+	expired := s.createIssuedX509SVID(&datastore.IssuedX509SVID{
+		SpiffeId:     "spiffe://example.org/workload",
+		SerialNumber: "1",
+		IssuedAt:     now - 7200,
+		ExpiresAt:    now - 3600,
+		AgentId:      agentID,
+		EntryId:      "entry",
+	})
+	active := s.createIssuedX509SVID(&datastore.IssuedX509SVID{
+		SpiffeId:     "spiffe://example.org/workload",
+		SerialNumber: "2",
+		IssuedAt:     now,
+		ExpiresAt:    now + 3600,
+		AgentId:      agentID,
+		EntryId:      "entry",
+	})
+	minted := s.createIssuedX509SVID(&datastore.IssuedX509SVID{
+		SpiffeId:     "spiffe://example.org/minted",
+		SerialNumber: "3",
+		IssuedAt:     now,
+		ExpiresAt:    now + 3600,
+	})
+
+	testCases := []struct {
+		name       string
+		req        *registration.ListIssuedX509SVIDsRequest
+		svids      []*registration.IssuedX509SVID
+		pagination *registration.Pagination
+		err        string
+	}{
+		{
+			name:       "no filter",
+			req:        &registration.ListIssuedX509SVIDsRequest{},
+			svids:      []*registration.IssuedX509SVID{expired, active, minted},
+			pagination: &registration.Pagination{Token: "3", PageSize: 50},
+		},
+		{
+			name: "by SPIFFE ID",
+			req: &registration.ListIssuedX509SVIDsRequest{
+				BySpiffeId: "spiffe://example.org/minted",
+			},
+			svids:      []*registration.IssuedX509SVID{minted},
+			pagination: &registration.Pagination{Token: "3", PageSize: 50},
+		},
+		{
+			name: "by agent ID and expires after",
+			req: &registration.ListIssuedX509SVIDsRequest{
+				ByAgentId:      agentID,
+				ByExpiresAfter: now,
+			},
+			svids:      []*registration.IssuedX509SVID{active},
+			pagination: &registration.Pagination{Token: "2", PageSize: 50},
+		},
+		{
+			name: "with pagination",
+			req: &registration.ListIssuedX509SVIDsRequest{
+				Pagination: &registration.Pagination{Token: "1", PageSize: 1},
+			},
+			svids:      []*registration.IssuedX509SVID{active},
+			pagination: &registration.Pagination{Token: "2", PageSize: 1},
+		},
+		{
+			name: "invalid SPIFFE ID",
+			req: &registration.ListIssuedX509SVIDsRequest{
+				BySpiffeId: "workload",
+			},
+			err: "invalid SPIFFE ID filter",
+		},
+		{
+			name: "invalid agent ID",
+			req: &registration.ListIssuedX509SVIDsRequest{
+				ByAgentId: "spiffe://example.org/workload",
+			},
+			err: "invalid agent ID filter",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase // alias loop variable as it is used in the closure
+		s.T().Run(testCase.name, func(t *testing.T) {
+			resp, err := s.handler.ListIssuedX509SVIDs(context.Background(), testCase.req)
+			if testCase.err != "" {
+				spiretest.RequireGRPCStatusContains(t, err, codes.InvalidArgument, testCase.err)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, testCase.svids, resp.Svids)
+			spiretest.RequireProtoEqual(t, testCase.pagination, resp.Pagination)
 		})
 	}
 }
@@ -1427,6 +2111,34 @@ func (s *HandlerSuite) TestAuthorizeCall() {
 	}
 }
 
+type fakeAuditSink struct {
+	mu     sync.Mutex
+	events [][]byte
+}
+
+func (s *fakeAuditSink) Write(event []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *fakeAuditSink) Close() error {
+	return nil
+}
+
+func (s *fakeAuditSink) Events(t *testing.T) []audit.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []audit.Event
+	for _, data := range s.events {
+		var event audit.Event
+		require.NoError(t, json.Unmarshal(data, &event))
+		events = append(events, event)
+	}
+	return events
+}
+
 func TestDNSValidation(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1519,6 +2231,22 @@ func (s *HandlerSuite) createBundle(bundle *common.Bundle) {
 	s.Require().NoError(err)
 }
 
+func (s *HandlerSuite) createIssuedX509SVID(svid *datastore.IssuedX509SVID) *registration.IssuedX509SVID {
+	_, err := s.ds.CreateIssuedX509SVID(context.Background(), &datastore.CreateIssuedX509SVIDRequest{
+		Svid: svid,
+	})
+	s.Require().NoError(err)
+	return &registration.IssuedX509SVID{
+		SpiffeId:     svid.SpiffeId,
+		SerialNumber: svid.SerialNumber,
+		IssuedAt:     svid.IssuedAt,
+		ExpiresAt:    svid.ExpiresAt,
+		CaKeyId:      svid.CaKeyId,
+		AgentId:      svid.AgentId,
+		EntryId:      svid.EntryId,
+	}
+}
+
 func (s *HandlerSuite) createRegistrationEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
 	resp, err := s.ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
 		Entry: entry,
@@ -0,0 +1,155 @@
+// Package fips restricts the server to the key types, signature algorithms
+// and TLS parameters approved by FIPS 140-2. It only restricts the choice of
+// algorithms; using a validated cryptographic module is a matter of how the
+// server is built.
+package fips
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/tlspolicy"
+	"github.com/spiffe/spire/proto/spire/server/keymanager"
+)
+
+// MinRSAKeyBits is the minimum size of the RSA keys approved for signatures.
+const MinRSAKeyBits = 2048
+
+// cipherSuites are the approved TLS 1.2 cipher suites. TLS 1.3 is not
+// allowed since its cipher suites, which include ChaCha20-Poly1305, cannot be
+// restricted.
+var cipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+var signatureAlgorithms = map[x509.SignatureAlgorithm]bool{
+	x509.SHA256WithRSA:    true,
+	x509.SHA384WithRSA:    true,
+	x509.SHA512WithRSA:    true,
+	x509.SHA256WithRSAPSS: true,
+	x509.SHA384WithRSAPSS: true,
+	x509.SHA512WithRSAPSS: true,
+	x509.ECDSAWithSHA256:  true,
+	x509.ECDSAWithSHA384:  true,
+	x509.ECDSAWithSHA512:  true,
+}
+
+// disallowedPlugins are the built-in plugins, by type, that rely on
+// algorithms that are not approved.
+var disallowedPlugins = map[string]map[string]string{
+	"NodeAttestor": {
+		// SSH certificates and host keys are commonly Ed25519
+		"sshpop": "it accepts SSH keys that are not FIPS-approved",
+	},
+}
+
+// CheckKeyType returns an error if the key type used by the key manager is
+// not approved.
+func CheckKeyType(keyType keymanager.KeyType) error {
+	switch keyType {
+	case keymanager.KeyType_EC_P256, keymanager.KeyType_EC_P384,
+		keymanager.KeyType_RSA_2048, keymanager.KeyType_RSA_4096:
+		return nil
+	default:
+		return fmt.Errorf("key type %s is not FIPS-approved", keyType)
+	}
+}
+
+// TLSPolicy returns the TLS policy of the listeners, which restricts the
+// configured policy to TLS 1.2 and the approved cipher suites. An error is
+// returned if the configured policy contradicts it.
+func TLSPolicy(configured tlspolicy.Policy) (tlspolicy.Policy, error) {
+	if configured.MinVersion > tls.VersionTLS12 {
+		return tlspolicy.Policy{}, errors.New("TLS 1.3 is not allowed in FIPS mode")
+	}
+	for _, suite := range configured.CipherSuites {
+		if !containsCipherSuite(cipherSuites, suite) {
+			return tlspolicy.Policy{}, fmt.Errorf("cipher suite %s is not FIPS-approved", tlspolicy.CipherSuiteName(suite))
+		}
+	}
+
+	policy := tlspolicy.Policy{
+		MinVersion:   tls.VersionTLS12,
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: configured.CipherSuites,
+	}
+	if len(policy.CipherSuites) == 0 {
+		policy.CipherSuites = cipherSuites
+	}
+	return policy, nil
+}
+
+// CheckPlugins returns an error if any of the enabled plugins is not allowed.
+// External and remote plugins are not allowed since the algorithms they use
+// cannot be verified.
+func CheckPlugins(configs catalog.HCLPluginConfigMap) error {
+	var pluginTypes []string
+	for pluginType := range configs {
+		pluginTypes = append(pluginTypes, pluginType)
+	}
+	sort.Strings(pluginTypes)
+
+	for _, pluginType := range pluginTypes {
+		var pluginNames []string
+		for pluginName := range configs[pluginType] {
+			pluginNames = append(pluginNames, pluginName)
+		}
+		sort.Strings(pluginNames)
+
+		for _, pluginName := range pluginNames {
+			config := configs[pluginType][pluginName]
+			if !config.IsEnabled() {
+				continue
+			}
+			if config.PluginCmd != "" || config.PluginAddress != "" {
+				return fmt.Errorf("%s plugin %q is not allowed in FIPS mode: external plugins cannot be verified to use FIPS-approved algorithms", pluginType, pluginName)
+			}
+			if reason, ok := disallowedPlugins[pluginType][pluginName]; ok {
+				return fmt.Errorf("%s plugin %q is not allowed in FIPS mode: %s", pluginType, pluginName, reason)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckCertificate returns an error if the certificate is not signed with an
+// approved signature algorithm or if its public key is not approved.
+func CheckCertificate(cert *x509.Certificate) error {
+	if !signatureAlgorithms[cert.SignatureAlgorithm] {
+		return fmt.Errorf("signature algorithm %s is not FIPS-approved", cert.SignatureAlgorithm)
+	}
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < MinRSAKeyBits {
+			return fmt.Errorf("RSA key size %d is not FIPS-approved", key.N.BitLen())
+		}
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256(), elliptic.P384(), elliptic.P521():
+		default:
+			return fmt.Errorf("elliptic curve %q is not FIPS-approved", key.Curve.Params().Name)
+		}
+	default:
+		return fmt.Errorf("public key type %T is not FIPS-approved", cert.PublicKey)
+	}
+	return nil
+}
+
+func containsCipherSuite(suites []uint16, suite uint16) bool {
+	for _, candidate := range suites {
+		if candidate == suite {
+			return true
+		}
+	}
+	return false
+}
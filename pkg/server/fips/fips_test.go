@@ -0,0 +1,115 @@
+package fips
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/tlspolicy"
+	"github.com/spiffe/spire/proto/spire/server/keymanager"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckKeyType(t *testing.T) {
+	require.NoError(t, CheckKeyType(keymanager.KeyType_EC_P256))
+	require.NoError(t, CheckKeyType(keymanager.KeyType_EC_P384))
+	require.NoError(t, CheckKeyType(keymanager.KeyType_RSA_2048))
+	require.NoError(t, CheckKeyType(keymanager.KeyType_RSA_4096))
+	require.EqualError(t, CheckKeyType(keymanager.KeyType_UNSPECIFIED_KEY_TYPE), "key type UNSPECIFIED_KEY_TYPE is not FIPS-approved")
+}
+
+func TestTLSPolicy(t *testing.T) {
+	policy, err := TLSPolicy(tlspolicy.Policy{})
+	require.NoError(t, err)
+	require.Equal(t, tlspolicy.Policy{
+		MinVersion:   tls.VersionTLS12,
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: cipherSuites,
+	}, policy)
+
+	policy, err = TLSPolicy(tlspolicy.Policy{
+		MinVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384},
+	})
+	require.NoError(t, err)
+	require.Equal(t, tlspolicy.Policy{
+		MinVersion:   tls.VersionTLS12,
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384},
+	}, policy)
+
+	_, err = TLSPolicy(tlspolicy.Policy{MinVersion: tls.VersionTLS13})
+	require.EqualError(t, err, "TLS 1.3 is not allowed in FIPS mode")
+
+	_, err = TLSPolicy(tlspolicy.Policy{CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305}})
+	require.EqualError(t, err, "cipher suite TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305 is not FIPS-approved")
+}
+
+func TestCheckPlugins(t *testing.T) {
+	disabled := false
+	require.NoError(t, CheckPlugins(catalog.HCLPluginConfigMap{
+		"KeyManager": {
+			"disk": {},
+		},
+		"NodeAttestor": {
+			"sshpop": {Enabled: &disabled},
+		},
+	}))
+
+	err := CheckPlugins(catalog.HCLPluginConfigMap{
+		"NodeAttestor": {
+			"sshpop": {},
+		},
+	})
+	require.EqualError(t, err, `NodeAttestor plugin "sshpop" is not allowed in FIPS mode: it accepts SSH keys that are not FIPS-approved`)
+
+	err = CheckPlugins(catalog.HCLPluginConfigMap{
+		"KeyManager": {
+			"hsm": {PluginCmd: "./hsm"},
+		},
+	})
+	require.EqualError(t, err, `KeyManager plugin "hsm" is not allowed in FIPS mode: external plugins cannot be verified to use FIPS-approved algorithms`)
+
+	err = CheckPlugins(catalog.HCLPluginConfigMap{
+		"KeyManager": {
+			"hsm": {PluginAddress: "hsm.example.org:8443"},
+		},
+	})
+	require.EqualError(t, err, `KeyManager plugin "hsm" is not allowed in FIPS mode: external plugins cannot be verified to use FIPS-approved algorithms`)
+}
+
+func TestCheckCertificate(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, CheckCertificate(createCertificate(t, ecKey.Public(), ecKey)))
+
+	p224Key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	require.NoError(t, err)
+	err = CheckCertificate(createCertificate(t, p224Key.Public(), ecKey))
+	require.EqualError(t, err, `elliptic curve "P-224" is not FIPS-approved`)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	err = CheckCertificate(createCertificate(t, ecKey.Public(), ed25519Key))
+	require.EqualError(t, err, "signature algorithm Ed25519 is not FIPS-approved")
+}
+
+func createCertificate(t *testing.T, publicKey crypto.PublicKey, signer crypto.Signer) *x509.Certificate {
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, publicKey, signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certDER)
+	require.NoError(t, err)
+	return cert
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
+)
+
+// Names of the subsystem health checks. The checks are reported under these
+// names by the gRPC health service of the registration API UDS.
+const (
+	HealthCheckDataStore = "datastore"
+	HealthCheckCA        = "ca"
+	HealthCheckBundle    = "bundle"
+	HealthCheckPlugins   = "plugins"
+)
+
+// HealthChecks lists the subsystem health checks in the order they are
+// reported.
+var HealthChecks = []string{
+	HealthCheckDataStore,
+	HealthCheckCA,
+	HealthCheckBundle,
+	HealthCheckPlugins,
+}
+
+const (
+	healthCheckInterval = 30 * time.Second
+	healthCheckTimeout  = 10 * time.Second
+)
+
+// activeCA provides the keys the server CA is currently signing with.
+type activeCA interface {
+	X509CA() *ca.X509CA
+	JWTKey() *ca.JWTKey
+}
+
+// subsystemChecks checks the subsystems the server needs to serve its APIs.
+type subsystemChecks struct {
+	trustDomainID string
+	dataStore     datastore.DataStore
+	keyManager    keymanager.KeyManager
+	plugins       pluginHealthChecker
+	ca            activeCA
+	clock         clock.Clock
+	metrics       telemetry.Metrics
+}
+
+func (c *subsystemChecks) register(checker *health.Checker) error {
+	checks := map[string]func(context.Context) error{
+		HealthCheckDataStore: c.checkDataStore,
+		HealthCheckCA:        c.checkCA,
+		HealthCheckBundle:    c.checkBundle,
+		HealthCheckPlugins:   c.checkPlugins,
+	}
+	for _, name := range HealthChecks {
+		if err := checker.AddCheck(name, checkFunc(checks[name]), healthCheckInterval); err != nil {
+			return fmt.Errorf("failed adding %s healthcheck: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// checkDataStore verifies the datastore is reachable.
+func (c *subsystemChecks) checkDataStore(ctx context.Context) error {
+	if _, err := c.dataStore.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: c.trustDomainID,
+	}); err != nil {
+		return fmt.Errorf("datastore is unreachable: %v", err)
+	}
+	return nil
+}
+
+// checkCA verifies the CA has an unexpired X509 CA and JWT key active.
+func (c *subsystemChecks) checkCA(ctx context.Context) error {
+	now := c.clock.Now()
+
+	x509CA := c.ca.X509CA()
+	switch {
+	case x509CA == nil:
+		return errors.New("no active X509 CA")
+	case !now.Before(x509CA.Certificate.NotAfter):
+		return fmt.Errorf("active X509 CA expired at %s", x509CA.Certificate.NotAfter.Format(time.RFC3339))
+	}
+
+	jwtKey := c.ca.JWTKey()
+	switch {
+	case jwtKey == nil:
+		return errors.New("no active JWT key")
+	case !now.Before(jwtKey.NotAfter):
+		return fmt.Errorf("active JWT key expired at %s", jwtKey.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// checkBundle verifies the trust domain bundle holds at least one unexpired
+// root CA.
+func (c *subsystemChecks) checkBundle(ctx context.Context) error {
+	resp, err := c.dataStore.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: c.trustDomainID,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to fetch bundle: %v", err)
+	}
+	if resp.Bundle == nil {
+		return errors.New("bundle not found")
+	}
+	bundle, err := bundleutil.BundleFromProto(resp.Bundle)
+	if err != nil {
+		return fmt.Errorf("invalid bundle: %v", err)
+	}
+
+	now := c.clock.Now()
+	for _, rootCA := range bundle.RootCAs() {
+		if now.Before(rootCA.NotAfter) {
+			return nil
+		}
+	}
+	return errors.New("bundle has no unexpired root CAs")
+}
+
+// checkPlugins probes each of the plugins and verifies the key manager,
+// which every signing operation depends on, is responsive.
+func (c *subsystemChecks) checkPlugins(ctx context.Context) error {
+	if err := c.probePlugins(ctx); err != nil {
+		return err
+	}
+	if _, err := c.keyManager.GetPublicKeys(ctx, &keymanager.GetPublicKeysRequest{}); err != nil {
+		return fmt.Errorf("key manager is unresponsive: %v", err)
+	}
+	return nil
+}
+
+// probePlugins probes each of the plugins, which restarts the crashed
+// external plugins configured to be restarted, and reports the outcome in
+// the metrics.
+func (c *subsystemChecks) probePlugins(ctx context.Context) error {
+	var unhealthy []string
+	for _, plugin := range c.plugins.CheckHealth(ctx) {
+		telemetry_common.SetPluginHealthyGauge(c.metrics, plugin.Type, plugin.Name, plugin.Err == nil)
+		if plugin.Restarted {
+			telemetry_common.IncrPluginRestartCounter(c.metrics, plugin.Type, plugin.Name)
+		}
+		if plugin.Err != nil {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s plugin %q: %v", plugin.Type, plugin.Name, plugin.Err))
+		}
+	}
+	if len(unhealthy) > 0 {
+		return fmt.Errorf("unhealthy plugins: %s", strings.Join(unhealthy, "; "))
+	}
+	return nil
+}
+
+// pluginHealthChecker probes the loaded plugins.
+type pluginHealthChecker interface {
+	CheckHealth(ctx context.Context) []catalog.PluginHealth
+}
+
+// checkFunc adapts a function to the health.ICheckable interface, bounding
+// each run of the check with healthCheckTimeout.
+type checkFunc func(context.Context) error
+
+func (f checkFunc) Status() (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	return nil, f(ctx)
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubsystemChecks(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewMock()
+	rootCA := createHealthTestCA(t, clk.Now().Add(time.Hour))
+
+	ds := fakedatastore.New(t)
+	c := &subsystemChecks{
+		trustDomainID: "spiffe://example.org",
+		dataStore:     ds,
+		keyManager:    &fakeHealthKeyManager{},
+		plugins:       fakePluginHealthChecker{},
+		ca:            &fakeActiveCA{},
+		clock:         clk,
+		metrics:       fakemetrics.New(),
+	}
+
+	// Nothing is set up yet
+	require.NoError(t, c.checkDataStore(ctx))
+	require.EqualError(t, c.checkCA(ctx), "no active X509 CA")
+	require.EqualError(t, c.checkBundle(ctx), "bundle not found")
+	require.NoError(t, c.checkPlugins(ctx))
+
+	_, err := ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+		Bundle: bundleutil.BundleProtoFromRootCA("spiffe://example.org", rootCA),
+	})
+	require.NoError(t, err)
+	c.ca = &fakeActiveCA{
+		x509CA: &ca.X509CA{Certificate: rootCA},
+		jwtKey: &ca.JWTKey{NotAfter: clk.Now().Add(time.Hour)},
+	}
+	require.NoError(t, c.checkCA(ctx))
+	require.NoError(t, c.checkBundle(ctx))
+
+	// Everything expires
+	clk.Add(time.Hour)
+	require.EqualError(t, c.checkCA(ctx), "active X509 CA expired at "+rootCA.NotAfter.Format(time.RFC3339))
+	require.EqualError(t, c.checkBundle(ctx), "bundle has no unexpired root CAs")
+
+	// Failing dependencies
+	ds.SetNextError(errors.New("oh no"))
+	require.EqualError(t, c.checkDataStore(ctx), "datastore is unreachable: oh no")
+	c.keyManager = &fakeHealthKeyManager{err: errors.New("oh no")}
+	require.EqualError(t, c.checkPlugins(ctx), "key manager is unresponsive: oh no")
+}
+
+func TestSubsystemChecksPluginHealth(t *testing.T) {
+	metrics := fakemetrics.New()
+	c := &subsystemChecks{
+		keyManager: &fakeHealthKeyManager{},
+		plugins: fakePluginHealthChecker{
+			{Type: "KeyManager", Name: "memory"},
+			{Type: "NodeAttestor", Name: "tpm", Err: errors.New("plugin process exited"), Restarted: true},
+			{Type: "Notifier", Name: "webhook", Err: errors.New("plugin is unresponsive: oh no")},
+		},
+		metrics: metrics,
+	}
+
+	err := c.checkPlugins(context.Background())
+	require.EqualError(t, err, `unhealthy plugins: NodeAttestor plugin "tpm": plugin process exited; Notifier plugin "webhook": plugin is unresponsive: oh no`)
+
+	labels := func(pluginType, pluginName string) []telemetry.Label {
+		return []telemetry.Label{
+			{Name: telemetry.PluginType, Value: pluginType},
+			{Name: telemetry.PluginName, Value: pluginName},
+		}
+	}
+	require.Equal(t, []fakemetrics.MetricItem{
+		{Type: fakemetrics.SetGaugeWithLabelsType, Key: []string{telemetry.Catalog, telemetry.Healthy}, Val: 1, Labels: labels("KeyManager", "memory")},
+		{Type: fakemetrics.SetGaugeWithLabelsType, Key: []string{telemetry.Catalog, telemetry.Healthy}, Val: 0, Labels: labels("NodeAttestor", "tpm")},
+		{Type: fakemetrics.IncrCounterWithLabelsType, Key: []string{telemetry.Catalog, telemetry.Restart}, Val: 1, Labels: labels("NodeAttestor", "tpm")},
+		{Type: fakemetrics.SetGaugeWithLabelsType, Key: []string{telemetry.Catalog, telemetry.Healthy}, Val: 0, Labels: labels("Notifier", "webhook")},
+	}, metrics.AllMetrics())
+}
+
+type fakePluginHealthChecker []catalog.PluginHealth
+
+func (c fakePluginHealthChecker) CheckHealth(ctx context.Context) []catalog.PluginHealth {
+	return c
+}
+
+type fakeActiveCA struct {
+	x509CA *ca.X509CA
+	jwtKey *ca.JWTKey
+}
+
+func (c *fakeActiveCA) X509CA() *ca.X509CA {
+	return c.x509CA
+}
+
+func (c *fakeActiveCA) JWTKey() *ca.JWTKey {
+	return c.jwtKey
+}
+
+type fakeHealthKeyManager struct {
+	keymanager.KeyManager
+	err error
+}
+
+func (km *fakeHealthKeyManager) GetPublicKeys(context.Context, *keymanager.GetPublicKeysRequest) (*keymanager.GetPublicKeysResponse, error) {
+	if km.err != nil {
+		return nil, km.err
+	}
+	return &keymanager.GetPublicKeysResponse{}, nil
+}
+
+func createHealthTestCA(t *testing.T, notAfter time.Time) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "CA"},
+		NotBefore:             notAfter.Add(-2 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
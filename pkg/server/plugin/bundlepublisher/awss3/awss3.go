@@ -0,0 +1,163 @@
+package awss3
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/bundlepublisher"
+	"github.com/spiffe/spire/pkg/server/plugin/bundlepublisher/internal/bundleformat"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	pluginName = "aws_s3"
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin(pluginName,
+		bundlepublisher.PluginServer(p),
+	)
+}
+
+type s3Client interface {
+	PutObjectWithContext(aws.Context, *s3.PutObjectInput, ...request.Option) (*s3.PutObjectOutput, error)
+}
+
+type Config struct {
+	Region          string `hcl:"region"`
+	Bucket          string `hcl:"bucket"`
+	ObjectKey       string `hcl:"object_key"`
+	Format          string `hcl:"format"`
+	AccessKeyID     string `hcl:"access_key_id"`
+	SecretAccessKey string `hcl:"secret_access_key"`
+}
+
+// Plugin is a BundlePublisher plugin that publishes the bundle as an object
+// of an AWS S3 bucket.
+type Plugin struct {
+	log hclog.Logger
+
+	mu     sync.RWMutex
+	config *Config
+	client s3Client
+
+	hooks struct {
+		newClient func(config *Config) (s3Client, error)
+	}
+}
+
+func New() *Plugin {
+	p := &Plugin{}
+	p.hooks.newClient = newS3Client
+	return p
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+func (p *Plugin) PublishBundle(ctx context.Context, req *bundlepublisher.PublishBundleRequest) (*bundlepublisher.PublishBundleResponse, error) {
+	config, client, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Bundle == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing bundle")
+	}
+
+	data, err := bundleformat.Encode(req.Bundle, config.Format)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to encode bundle: %v", err)
+	}
+
+	if _, err := client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(config.Bucket),
+		Key:         aws.String(config.ObjectKey),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(bundleformat.ContentType(config.Format)),
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to put bundle object %s/%s: %v", config.Bucket, config.ObjectKey, err)
+	}
+	p.log.Debug("Bundle object published", "bucket", config.Bucket, "object_key", config.ObjectKey)
+
+	return &bundlepublisher.PublishBundleResponse{}, nil
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(Config)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode configuration: %v", err)
+	}
+
+	if config.Region == "" {
+		return nil, status.Error(codes.InvalidArgument, "region must be set")
+	}
+	if config.Bucket == "" {
+		return nil, status.Error(codes.InvalidArgument, "bucket must be set")
+	}
+	if config.ObjectKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "object_key must be set")
+	}
+	if config.Format == "" {
+		config.Format = bundleformat.Default
+	}
+	if err := bundleformat.Validate(config.Format); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	client, err := p.hooks.newClient(config)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to create S3 client: %v", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config = config
+	p.client = client
+
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *Plugin) getConfig() (*Config, s3Client, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.config == nil {
+		return nil, nil, status.Error(codes.FailedPrecondition, "not configured")
+	}
+	return p.config, p.client, nil
+}
+
+func newS3Client(config *Config) (s3Client, error) {
+	awsConfig := &aws.Config{
+		Region: aws.String(config.Region),
+	}
+	if config.AccessKeyID != "" && config.SecretAccessKey != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, "")
+	}
+
+	awsSession, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return s3.New(awsSession), nil
+}
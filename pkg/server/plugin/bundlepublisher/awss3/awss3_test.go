@@ -0,0 +1,200 @@
+package awss3
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/spiffe/spire/pkg/server/plugin/bundlepublisher"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	testBundle = &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("FOO")}},
+	}
+)
+
+func TestConfigure(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config string
+		code   codes.Code
+		desc   string
+	}{
+		{
+			name:   "malformed",
+			config: `MALFORMED`,
+			code:   codes.InvalidArgument,
+			desc:   "unable to decode configuration",
+		},
+		{
+			name: "missing region",
+			config: `
+				bucket = "the-bucket"
+				object_key = "bundle.pem"
+			`,
+			code: codes.InvalidArgument,
+			desc: "region must be set",
+		},
+		{
+			name: "missing bucket",
+			config: `
+				region = "us-east-1"
+				object_key = "bundle.pem"
+			`,
+			code: codes.InvalidArgument,
+			desc: "bucket must be set",
+		},
+		{
+			name: "missing object key",
+			config: `
+				region = "us-east-1"
+				bucket = "the-bucket"
+			`,
+			code: codes.InvalidArgument,
+			desc: "object_key must be set",
+		},
+		{
+			name: "unsupported format",
+			config: `
+				region = "us-east-1"
+				bucket = "the-bucket"
+				object_key = "bundle.der"
+				format = "der"
+			`,
+			code: codes.InvalidArgument,
+			desc: `unsupported format "der"`,
+		},
+		{
+			name: "success",
+			config: `
+				region = "us-east-1"
+				bucket = "the-bucket"
+				object_key = "bundle.json"
+				format = "spiffe"
+				access_key_id = "ACCESS_KEY_ID"
+				secret_access_key = "SECRET_ACCESS_KEY"
+			`,
+			code: codes.OK,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, _, done := newTestPlugin(t)
+			defer done()
+
+			resp, err := plugin.Configure(context.Background(), &spi.ConfigureRequest{Configuration: tt.config})
+			if tt.code != codes.OK {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.desc)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+		})
+	}
+}
+
+func TestGetPluginInfo(t *testing.T) {
+	resp, err := New().GetPluginInfo(context.Background(), &spi.GetPluginInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &spi.GetPluginInfoResponse{}, resp)
+}
+
+func TestPublishBundle(t *testing.T) {
+	plugin, client, done := newTestPlugin(t)
+	defer done()
+	configure(t, plugin)
+
+	resp, err := plugin.PublishBundle(context.Background(), &bundlepublisher.PublishBundleRequest{
+		Bundle: testBundle,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+
+	require.NotNil(t, client.input)
+	require.Equal(t, "the-bucket", aws.StringValue(client.input.Bucket))
+	require.Equal(t, "bundle.pem", aws.StringValue(client.input.Key))
+	require.Equal(t, "application/x-pem-file", aws.StringValue(client.input.ContentType))
+	data, err := ioutil.ReadAll(client.input.Body)
+	require.NoError(t, err)
+	require.Equal(t, "-----BEGIN CERTIFICATE-----\nRk9P\n-----END CERTIFICATE-----\n", string(data))
+}
+
+func TestPublishBundleFailsIfNotConfigured(t *testing.T) {
+	plugin, _, done := newTestPlugin(t)
+	defer done()
+
+	_, err := plugin.PublishBundle(context.Background(), &bundlepublisher.PublishBundleRequest{
+		Bundle: testBundle,
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "not configured")
+}
+
+func TestPublishBundleFailsWithMissingBundle(t *testing.T) {
+	plugin, _, done := newTestPlugin(t)
+	defer done()
+	configure(t, plugin)
+
+	_, err := plugin.PublishBundle(context.Background(), &bundlepublisher.PublishBundleRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "missing bundle")
+}
+
+func TestPublishBundleFailsIfPutFails(t *testing.T) {
+	plugin, client, done := newTestPlugin(t)
+	defer done()
+	configure(t, plugin)
+
+	client.err = errors.New("ohno")
+	_, err := plugin.PublishBundle(context.Background(), &bundlepublisher.PublishBundleRequest{
+		Bundle: testBundle,
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.Internal, "unable to put bundle object the-bucket/bundle.pem: ohno")
+}
+
+func newTestPlugin(t *testing.T) (bundlepublisher.Plugin, *fakeS3Client, func()) {
+	client := new(fakeS3Client)
+	raw := New()
+	raw.hooks.newClient = func(config *Config) (s3Client, error) {
+		return client, nil
+	}
+
+	var plugin bundlepublisher.Plugin
+	done := spiretest.LoadPlugin(t, builtin(raw), &plugin)
+	return plugin, client, done
+}
+
+func configure(t *testing.T, plugin bundlepublisher.Plugin) {
+	_, err := plugin.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `
+			region = "us-east-1"
+			bucket = "the-bucket"
+			object_key = "bundle.pem"
+		`,
+	})
+	require.NoError(t, err)
+}
+
+type fakeS3Client struct {
+	input *s3.PutObjectInput
+	err   error
+}
+
+func (c *fakeS3Client) PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.input = input
+	return &s3.PutObjectOutput{}, nil
+}
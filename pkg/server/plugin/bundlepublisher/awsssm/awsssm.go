@@ -0,0 +1,161 @@
+package awsssm
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/bundlepublisher"
+	"github.com/spiffe/spire/pkg/server/plugin/bundlepublisher/internal/bundleformat"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	pluginName = "aws_ssm"
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin(pluginName,
+		bundlepublisher.PluginServer(p),
+	)
+}
+
+type ssmClient interface {
+	PutParameterWithContext(aws.Context, *ssm.PutParameterInput, ...request.Option) (*ssm.PutParameterOutput, error)
+}
+
+type Config struct {
+	Region          string `hcl:"region"`
+	ParameterName   string `hcl:"parameter_name"`
+	Format          string `hcl:"format"`
+	AccessKeyID     string `hcl:"access_key_id"`
+	SecretAccessKey string `hcl:"secret_access_key"`
+}
+
+// Plugin is a BundlePublisher plugin that publishes the bundle as a parameter
+// of the AWS Systems Manager Parameter Store.
+type Plugin struct {
+	log hclog.Logger
+
+	mu     sync.RWMutex
+	config *Config
+	client ssmClient
+
+	hooks struct {
+		newClient func(config *Config) (ssmClient, error)
+	}
+}
+
+func New() *Plugin {
+	p := &Plugin{}
+	p.hooks.newClient = newSSMClient
+	return p
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+func (p *Plugin) PublishBundle(ctx context.Context, req *bundlepublisher.PublishBundleRequest) (*bundlepublisher.PublishBundleResponse, error) {
+	config, client, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Bundle == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing bundle")
+	}
+
+	data, err := bundleformat.Encode(req.Bundle, config.Format)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to encode bundle: %v", err)
+	}
+
+	// Standard parameters are limited to 4KB, so let the parameter store
+	// use the advanced tier when the bundle grows over it.
+	if _, err := client.PutParameterWithContext(ctx, &ssm.PutParameterInput{
+		Name:      aws.String(config.ParameterName),
+		Value:     aws.String(string(data)),
+		Type:      aws.String(ssm.ParameterTypeString),
+		Tier:      aws.String(ssm.ParameterTierIntelligentTiering),
+		Overwrite: aws.Bool(true),
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to put bundle parameter %s: %v", config.ParameterName, err)
+	}
+	p.log.Debug("Bundle parameter published", "parameter_name", config.ParameterName)
+
+	return &bundlepublisher.PublishBundleResponse{}, nil
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(Config)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode configuration: %v", err)
+	}
+
+	if config.Region == "" {
+		return nil, status.Error(codes.InvalidArgument, "region must be set")
+	}
+	if config.ParameterName == "" {
+		return nil, status.Error(codes.InvalidArgument, "parameter_name must be set")
+	}
+	if config.Format == "" {
+		config.Format = bundleformat.Default
+	}
+	if err := bundleformat.Validate(config.Format); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	client, err := p.hooks.newClient(config)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to create SSM client: %v", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config = config
+	p.client = client
+
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *Plugin) getConfig() (*Config, ssmClient, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.config == nil {
+		return nil, nil, status.Error(codes.FailedPrecondition, "not configured")
+	}
+	return p.config, p.client, nil
+}
+
+func newSSMClient(config *Config) (ssmClient, error) {
+	awsConfig := &aws.Config{
+		Region: aws.String(config.Region),
+	}
+	if config.AccessKeyID != "" && config.SecretAccessKey != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, "")
+	}
+
+	awsSession, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return ssm.New(awsSession), nil
+}
@@ -0,0 +1,185 @@
+package awsssm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/spiffe/spire/pkg/server/plugin/bundlepublisher"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	testBundle = &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("FOO")}},
+	}
+)
+
+func TestConfigure(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config string
+		code   codes.Code
+		desc   string
+	}{
+		{
+			name:   "malformed",
+			config: `MALFORMED`,
+			code:   codes.InvalidArgument,
+			desc:   "unable to decode configuration",
+		},
+		{
+			name: "missing region",
+			config: `
+				parameter_name = "/spire/bundle"
+			`,
+			code: codes.InvalidArgument,
+			desc: "region must be set",
+		},
+		{
+			name: "missing parameter name",
+			config: `
+				region = "us-east-1"
+			`,
+			code: codes.InvalidArgument,
+			desc: "parameter_name must be set",
+		},
+		{
+			name: "unsupported format",
+			config: `
+				region = "us-east-1"
+				parameter_name = "/spire/bundle"
+				format = "der"
+			`,
+			code: codes.InvalidArgument,
+			desc: `unsupported format "der"`,
+		},
+		{
+			name: "success",
+			config: `
+				region = "us-east-1"
+				parameter_name = "/spire/bundle"
+				format = "jwks"
+				access_key_id = "ACCESS_KEY_ID"
+				secret_access_key = "SECRET_ACCESS_KEY"
+			`,
+			code: codes.OK,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, _, done := newTestPlugin(t)
+			defer done()
+
+			resp, err := plugin.Configure(context.Background(), &spi.ConfigureRequest{Configuration: tt.config})
+			if tt.code != codes.OK {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.desc)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+		})
+	}
+}
+
+func TestGetPluginInfo(t *testing.T) {
+	resp, err := New().GetPluginInfo(context.Background(), &spi.GetPluginInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &spi.GetPluginInfoResponse{}, resp)
+}
+
+func TestPublishBundle(t *testing.T) {
+	plugin, client, done := newTestPlugin(t)
+	defer done()
+	configure(t, plugin)
+
+	resp, err := plugin.PublishBundle(context.Background(), &bundlepublisher.PublishBundleRequest{
+		Bundle: testBundle,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+
+	require.Equal(t, &ssm.PutParameterInput{
+		Name:      aws.String("/spire/bundle"),
+		Value:     aws.String("-----BEGIN CERTIFICATE-----\nRk9P\n-----END CERTIFICATE-----\n"),
+		Type:      aws.String("String"),
+		Tier:      aws.String("Intelligent-Tiering"),
+		Overwrite: aws.Bool(true),
+	}, client.input)
+}
+
+func TestPublishBundleFailsIfNotConfigured(t *testing.T) {
+	plugin, _, done := newTestPlugin(t)
+	defer done()
+
+	_, err := plugin.PublishBundle(context.Background(), &bundlepublisher.PublishBundleRequest{
+		Bundle: testBundle,
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "not configured")
+}
+
+func TestPublishBundleFailsWithMissingBundle(t *testing.T) {
+	plugin, _, done := newTestPlugin(t)
+	defer done()
+	configure(t, plugin)
+
+	_, err := plugin.PublishBundle(context.Background(), &bundlepublisher.PublishBundleRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "missing bundle")
+}
+
+func TestPublishBundleFailsIfPutFails(t *testing.T) {
+	plugin, client, done := newTestPlugin(t)
+	defer done()
+	configure(t, plugin)
+
+	client.err = errors.New("ohno")
+	_, err := plugin.PublishBundle(context.Background(), &bundlepublisher.PublishBundleRequest{
+		Bundle: testBundle,
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.Internal, "unable to put bundle parameter /spire/bundle: ohno")
+}
+
+func newTestPlugin(t *testing.T) (bundlepublisher.Plugin, *fakeSSMClient, func()) {
+	client := new(fakeSSMClient)
+	raw := New()
+	raw.hooks.newClient = func(config *Config) (ssmClient, error) {
+		return client, nil
+	}
+
+	var plugin bundlepublisher.Plugin
+	done := spiretest.LoadPlugin(t, builtin(raw), &plugin)
+	return plugin, client, done
+}
+
+func configure(t *testing.T, plugin bundlepublisher.Plugin) {
+	_, err := plugin.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `
+			region = "us-east-1"
+			parameter_name = "/spire/bundle"
+		`,
+	})
+	require.NoError(t, err)
+}
+
+type fakeSSMClient struct {
+	input *ssm.PutParameterInput
+	err   error
+}
+
+func (c *fakeSSMClient) PutParameterWithContext(ctx aws.Context, input *ssm.PutParameterInput, opts ...request.Option) (*ssm.PutParameterOutput, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.input = input
+	return &ssm.PutParameterOutput{}, nil
+}
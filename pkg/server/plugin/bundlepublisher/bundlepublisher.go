@@ -0,0 +1,92 @@
+// Provides interfaces and adapters for the BundlePublisher service
+//
+// Generated code. Do not modify by hand.
+package bundlepublisher
+
+import (
+	"context"
+
+	"github.com/spiffe/spire/pkg/common/catalog"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/proto/spire/server/bundlepublisher"
+	"google.golang.org/grpc"
+)
+
+type BundlePublisherClient = bundlepublisher.BundlePublisherClient                           //nolint: golint
+type BundlePublisherServer = bundlepublisher.BundlePublisherServer                           //nolint: golint
+type PublishBundleRequest = bundlepublisher.PublishBundleRequest                             //nolint: golint
+type PublishBundleResponse = bundlepublisher.PublishBundleResponse                           //nolint: golint
+type UnimplementedBundlePublisherServer = bundlepublisher.UnimplementedBundlePublisherServer //nolint: golint
+
+const (
+	Type = "BundlePublisher"
+)
+
+// BundlePublisher is the client interface for the service type BundlePublisher interface.
+type BundlePublisher interface {
+	PublishBundle(context.Context, *PublishBundleRequest) (*PublishBundleResponse, error)
+}
+
+// Plugin is the client interface for the service with the plugin related methods used by the catalog to initialize the plugin.
+type Plugin interface {
+	PublishBundle(context.Context, *PublishBundleRequest) (*PublishBundleResponse, error)
+	Configure(context.Context, *spi.ConfigureRequest) (*spi.ConfigureResponse, error)
+	GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error)
+}
+
+// PluginServer returns a catalog PluginServer implementation for the BundlePublisher plugin.
+func PluginServer(server BundlePublisherServer) catalog.PluginServer {
+	return &pluginServer{
+		server: server,
+	}
+}
+
+type pluginServer struct {
+	server BundlePublisherServer
+}
+
+func (s pluginServer) PluginType() string {
+	return Type
+}
+
+func (s pluginServer) PluginClient() catalog.PluginClient {
+	return PluginClient
+}
+
+func (s pluginServer) RegisterPluginServer(server *grpc.Server) interface{} {
+	bundlepublisher.RegisterBundlePublisherServer(server, s.server)
+	return s.server
+}
+
+// PluginClient is a catalog PluginClient implementation for the BundlePublisher plugin.
+var PluginClient catalog.PluginClient = pluginClient{}
+
+type pluginClient struct{}
+
+func (pluginClient) PluginType() string {
+	return Type
+}
+
+func (pluginClient) NewPluginClient(conn *grpc.ClientConn) interface{} {
+	return AdaptPluginClient(bundlepublisher.NewBundlePublisherClient(conn))
+}
+
+func AdaptPluginClient(client BundlePublisherClient) BundlePublisher {
+	return pluginClientAdapter{client: client}
+}
+
+type pluginClientAdapter struct {
+	client BundlePublisherClient
+}
+
+func (a pluginClientAdapter) PublishBundle(ctx context.Context, in *PublishBundleRequest) (*PublishBundleResponse, error) {
+	return a.client.PublishBundle(ctx, in)
+}
+
+func (a pluginClientAdapter) Configure(ctx context.Context, in *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	return a.client.Configure(ctx, in)
+}
+
+func (a pluginClientAdapter) GetPluginInfo(ctx context.Context, in *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return a.client.GetPluginInfo(ctx, in)
+}
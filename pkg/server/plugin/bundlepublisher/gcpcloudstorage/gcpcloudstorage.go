@@ -0,0 +1,172 @@
+package gcpcloudstorage
+
+import (
+	"context"
+	"sync"
+
+	"cloud.google.com/go/storage"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/bundlepublisher"
+	"github.com/spiffe/spire/pkg/server/plugin/bundlepublisher/internal/bundleformat"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/zeebo/errs"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	pluginName = "gcp_cloudstorage"
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin(pluginName,
+		bundlepublisher.PluginServer(p),
+	)
+}
+
+type bucketClient interface {
+	PutObject(ctx context.Context, bucket, object, contentType string, data []byte) error
+	Close() error
+}
+
+type Config struct {
+	Bucket             string `hcl:"bucket"`
+	ObjectName         string `hcl:"object_name"`
+	Format             string `hcl:"format"`
+	ServiceAccountFile string `hcl:"service_account_file"`
+}
+
+// Plugin is a BundlePublisher plugin that publishes the bundle as an object
+// of a Google Cloud Storage bucket.
+type Plugin struct {
+	log hclog.Logger
+
+	mu     sync.RWMutex
+	config *Config
+
+	hooks struct {
+		newBucketClient func(ctx context.Context, serviceAccountFile string) (bucketClient, error)
+	}
+}
+
+func New() *Plugin {
+	p := &Plugin{}
+	p.hooks.newBucketClient = newGCSBucketClient
+	return p
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+func (p *Plugin) PublishBundle(ctx context.Context, req *bundlepublisher.PublishBundleRequest) (*bundlepublisher.PublishBundleResponse, error) {
+	config, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Bundle == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing bundle")
+	}
+
+	data, err := bundleformat.Encode(req.Bundle, config.Format)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to encode bundle: %v", err)
+	}
+
+	client, err := p.hooks.newBucketClient(ctx, config.ServiceAccountFile)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to instantiate bucket client: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.PutObject(ctx, config.Bucket, config.ObjectName, bundleformat.ContentType(config.Format), data); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to put bundle object %s/%s: %v", config.Bucket, config.ObjectName, err)
+	}
+	p.log.Debug("Bundle object published", "bucket", config.Bucket, "object_name", config.ObjectName)
+
+	return &bundlepublisher.PublishBundleResponse{}, nil
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(Config)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode configuration: %v", err)
+	}
+
+	if config.Bucket == "" {
+		return nil, status.Error(codes.InvalidArgument, "bucket must be set")
+	}
+	if config.ObjectName == "" {
+		return nil, status.Error(codes.InvalidArgument, "object_name must be set")
+	}
+	if config.Format == "" {
+		config.Format = bundleformat.Default
+	}
+	if err := bundleformat.Validate(config.Format); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config = config
+
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *Plugin) getConfig() (*Config, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.config == nil {
+		return nil, status.Error(codes.FailedPrecondition, "not configured")
+	}
+	return p.config, nil
+}
+
+type gcsBucketClient struct {
+	client *storage.Client
+}
+
+func newGCSBucketClient(ctx context.Context, serviceAccountFile string) (bucketClient, error) {
+	var opts []option.ClientOption
+	if serviceAccountFile != "" {
+		opts = append(opts, option.WithCredentialsFile(serviceAccountFile))
+	}
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	return &gcsBucketClient{
+		client: client,
+	}, nil
+}
+
+func (c *gcsBucketClient) PutObject(ctx context.Context, bucket, object, contentType string, data []byte) error {
+	// If for whatever reason we don't make it to w.Close(), canceling the
+	// context will cleanly release resources held by the writer.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := c.client.Bucket(bucket).Object(object).NewWriter(ctx)
+	w.ContentType = contentType
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+func (c *gcsBucketClient) Close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,196 @@
+package gcpcloudstorage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/plugin/bundlepublisher"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	testBundle = &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("FOO")}},
+	}
+)
+
+func TestConfigure(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config string
+		code   codes.Code
+		desc   string
+	}{
+		{
+			name:   "malformed",
+			config: `MALFORMED`,
+			code:   codes.InvalidArgument,
+			desc:   "unable to decode configuration",
+		},
+		{
+			name: "missing bucket",
+			config: `
+				object_name = "bundle.pem"
+			`,
+			code: codes.InvalidArgument,
+			desc: "bucket must be set",
+		},
+		{
+			name: "missing object name",
+			config: `
+				bucket = "the-bucket"
+			`,
+			code: codes.InvalidArgument,
+			desc: "object_name must be set",
+		},
+		{
+			name: "unsupported format",
+			config: `
+				bucket = "the-bucket"
+				object_name = "bundle.der"
+				format = "der"
+			`,
+			code: codes.InvalidArgument,
+			desc: `unsupported format "der"`,
+		},
+		{
+			name: "success",
+			config: `
+				bucket = "the-bucket"
+				object_name = "bundle.json"
+				format = "spiffe"
+				service_account_file = "the-service-account-file"
+			`,
+			code: codes.OK,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, _, done := newTestPlugin(t)
+			defer done()
+
+			resp, err := plugin.Configure(context.Background(), &spi.ConfigureRequest{Configuration: tt.config})
+			if tt.code != codes.OK {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.desc)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+		})
+	}
+}
+
+func TestGetPluginInfo(t *testing.T) {
+	resp, err := New().GetPluginInfo(context.Background(), &spi.GetPluginInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &spi.GetPluginInfoResponse{}, resp)
+}
+
+func TestPublishBundle(t *testing.T) {
+	plugin, client, done := newTestPlugin(t)
+	defer done()
+	configure(t, plugin)
+
+	resp, err := plugin.PublishBundle(context.Background(), &bundlepublisher.PublishBundleRequest{
+		Bundle: testBundle,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+
+	require.Equal(t, "the-service-account-file", client.serviceAccountFile)
+	require.Equal(t, "the-bucket", client.bucket)
+	require.Equal(t, "bundle.pem", client.object)
+	require.Equal(t, "application/x-pem-file", client.contentType)
+	require.Equal(t, "-----BEGIN CERTIFICATE-----\nRk9P\n-----END CERTIFICATE-----\n", string(client.data))
+	require.True(t, client.closed)
+}
+
+func TestPublishBundleFailsIfNotConfigured(t *testing.T) {
+	plugin, _, done := newTestPlugin(t)
+	defer done()
+
+	_, err := plugin.PublishBundle(context.Background(), &bundlepublisher.PublishBundleRequest{
+		Bundle: testBundle,
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "not configured")
+}
+
+func TestPublishBundleFailsWithMissingBundle(t *testing.T) {
+	plugin, _, done := newTestPlugin(t)
+	defer done()
+	configure(t, plugin)
+
+	_, err := plugin.PublishBundle(context.Background(), &bundlepublisher.PublishBundleRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "missing bundle")
+}
+
+func TestPublishBundleFailsIfPutFails(t *testing.T) {
+	plugin, client, done := newTestPlugin(t)
+	defer done()
+	configure(t, plugin)
+
+	client.err = errors.New("ohno")
+	_, err := plugin.PublishBundle(context.Background(), &bundlepublisher.PublishBundleRequest{
+		Bundle: testBundle,
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.Internal, "unable to put bundle object the-bucket/bundle.pem: ohno")
+	require.True(t, client.closed)
+}
+
+func newTestPlugin(t *testing.T) (bundlepublisher.Plugin, *fakeBucketClient, func()) {
+	client := new(fakeBucketClient)
+	raw := New()
+	raw.hooks.newBucketClient = func(ctx context.Context, serviceAccountFile string) (bucketClient, error) {
+		client.serviceAccountFile = serviceAccountFile
+		return client, nil
+	}
+
+	var plugin bundlepublisher.Plugin
+	done := spiretest.LoadPlugin(t, builtin(raw), &plugin)
+	return plugin, client, done
+}
+
+func configure(t *testing.T, plugin bundlepublisher.Plugin) {
+	_, err := plugin.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `
+			bucket = "the-bucket"
+			object_name = "bundle.pem"
+			service_account_file = "the-service-account-file"
+		`,
+	})
+	require.NoError(t, err)
+}
+
+type fakeBucketClient struct {
+	serviceAccountFile string
+	bucket             string
+	object             string
+	contentType        string
+	data               []byte
+	closed             bool
+	err                error
+}
+
+func (c *fakeBucketClient) PutObject(ctx context.Context, bucket, object, contentType string, data []byte) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.bucket = bucket
+	c.object = object
+	c.contentType = contentType
+	c.data = data
+	return nil
+}
+
+func (c *fakeBucketClient) Close() error {
+	c.closed = true
+	return nil
+}
@@ -0,0 +1,109 @@
+// Package bundleformat encodes the trust domain bundle in the formats
+// supported by the BundlePublisher plugins.
+package bundleformat
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/zeebo/errs"
+	"gopkg.in/square/go-jose.v2"
+)
+
+const (
+	// PEM encodes the X.509 authorities as PEM encoded certificates
+	PEM = "pem"
+
+	// JWKS encodes the JWT authorities as a JSON Web Key Set, e.g. to be
+	// consumed by OIDC relying parties
+	JWKS = "jwks"
+
+	// SPIFFE encodes both the X.509 and JWT authorities as a SPIFFE bundle
+	SPIFFE = "spiffe"
+
+	// Default is the format used when none is configured
+	Default = PEM
+)
+
+// Validate returns an error if the format is not supported.
+func Validate(format string) error {
+	switch format {
+	case PEM, JWKS, SPIFFE:
+		return nil
+	default:
+		return fmt.Errorf("unsupported format %q: must be one of %q, %q or %q", format, PEM, JWKS, SPIFFE)
+	}
+}
+
+// ContentType returns the media type of a bundle encoded in the format.
+func ContentType(format string) string {
+	switch format {
+	case PEM:
+		return "application/x-pem-file"
+	case JWKS:
+		return "application/jwk-set+json"
+	default:
+		return "application/json"
+	}
+}
+
+// Encode encodes the bundle in the format.
+func Encode(bundle *common.Bundle, format string) ([]byte, error) {
+	switch format {
+	case PEM:
+		return encodePEM(bundle), nil
+	case JWKS:
+		return encodeJWKS(bundle)
+	case SPIFFE:
+		b, err := bundleutil.BundleFromProto(bundle)
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+		return bundleutil.Marshal(b)
+	default:
+		return nil, Validate(format)
+	}
+}
+
+func encodePEM(bundle *common.Bundle) []byte {
+	data := new(bytes.Buffer)
+	for _, rootCA := range bundle.RootCas {
+		// no need to check the error since we're encoding into a memory buffer
+		_ = pem.Encode(data, &pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: rootCA.DerBytes,
+		})
+	}
+	return data.Bytes()
+}
+
+func encodeJWKS(bundle *common.Bundle) ([]byte, error) {
+	// the keys are sorted so the document only changes with the keys
+	keys := make([]*common.PublicKey, len(bundle.JwtSigningKeys))
+	copy(keys, bundle.JwtSigningKeys)
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Kid < keys[j].Kid
+	})
+
+	jwks := jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{},
+	}
+	for _, key := range keys {
+		publicKey, err := x509.ParsePKIXPublicKey(key.PkixBytes)
+		if err != nil {
+			return nil, errs.New("unable to parse JWT signing key %q: %v", key.Kid, err)
+		}
+		jwks.Keys = append(jwks.Keys, jose.JSONWebKey{
+			Key:   publicKey,
+			KeyID: key.Kid,
+			Use:   "sig",
+		})
+	}
+	return json.MarshalIndent(jwks, "", "    ")
+}
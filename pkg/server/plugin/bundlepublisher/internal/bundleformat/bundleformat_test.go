@@ -0,0 +1,103 @@
+package bundleformat
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+)
+
+var (
+	jwtKey1 = testkey.MustEC256()
+	jwtKey2 = testkey.MustEC256()
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate("pem"))
+	assert.NoError(t, Validate("jwks"))
+	assert.NoError(t, Validate("spiffe"))
+	assert.EqualError(t, Validate("der"), `unsupported format "der": must be one of "pem", "jwks" or "spiffe"`)
+}
+
+func TestEncodePEM(t *testing.T) {
+	bundle, rootCA := makeBundle(t)
+
+	data, err := Encode(bundle, PEM)
+	require.NoError(t, err)
+
+	block, rest := pem.Decode(data)
+	require.NotNil(t, block)
+	assert.Equal(t, "CERTIFICATE", block.Type)
+	assert.Equal(t, rootCA.Raw, block.Bytes)
+	assert.Empty(t, rest)
+}
+
+func TestEncodeJWKS(t *testing.T) {
+	bundle, _ := makeBundle(t)
+
+	data, err := Encode(bundle, JWKS)
+	require.NoError(t, err)
+
+	jwks := new(jose.JSONWebKeySet)
+	require.NoError(t, json.Unmarshal(data, jwks))
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "KID1", jwks.Keys[0].KeyID)
+	assert.Equal(t, "KID2", jwks.Keys[1].KeyID)
+	assert.Equal(t, "sig", jwks.Keys[0].Use)
+	assert.Empty(t, jwks.Keys[0].Certificates)
+
+	bundle.JwtSigningKeys[0].PkixBytes = []byte("malformed")
+	_, err = Encode(bundle, JWKS)
+	assert.Contains(t, err.Error(), `unable to parse JWT signing key "KID2"`)
+}
+
+func TestEncodeSPIFFE(t *testing.T) {
+	bundle, rootCA := makeBundle(t)
+
+	data, err := Encode(bundle, SPIFFE)
+	require.NoError(t, err)
+
+	decoded, err := bundleutil.Unmarshal("spiffe://example.org", data)
+	require.NoError(t, err)
+	assert.Equal(t, []*x509.Certificate{rootCA}, decoded.RootCAs())
+	assert.Len(t, decoded.JWTSigningKeys(), 2)
+}
+
+func TestEncodeUnsupportedFormat(t *testing.T) {
+	bundle, _ := makeBundle(t)
+
+	_, err := Encode(bundle, "der")
+	assert.EqualError(t, err, `unsupported format "der": must be one of "pem", "jwks" or "spiffe"`)
+}
+
+func makeBundle(t *testing.T) (*common.Bundle, *x509.Certificate) {
+	rootCA, _, err := util.LoadCAFixture()
+	require.NoError(t, err)
+
+	return &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: rootCA.Raw}},
+		JwtSigningKeys: []*common.PublicKey{
+			makePublicKey(t, "KID2", jwtKey2),
+			makePublicKey(t, "KID1", jwtKey1),
+		},
+	}, rootCA
+}
+
+func makePublicKey(t *testing.T, kid string, key crypto.Signer) *common.PublicKey {
+	pkixBytes, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	return &common.PublicKey{
+		PkixBytes: pkixBytes,
+		Kid:       kid,
+	}
+}
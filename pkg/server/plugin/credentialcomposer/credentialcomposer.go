@@ -0,0 +1,103 @@
+// Provides interfaces and adapters for the CredentialComposer service
+//
+// Generated code. Do not modify by hand.
+package credentialcomposer
+
+import (
+	"context"
+
+	"github.com/spiffe/spire/pkg/common/catalog"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/proto/spire/server/credentialcomposer"
+	"google.golang.org/grpc"
+)
+
+type ComposeJWTSVIDRequest = credentialcomposer.ComposeJWTSVIDRequest                                 //nolint: golint
+type ComposeJWTSVIDResponse = credentialcomposer.ComposeJWTSVIDResponse                               //nolint: golint
+type ComposeX509SVIDRequest = credentialcomposer.ComposeX509SVIDRequest                               //nolint: golint
+type ComposeX509SVIDResponse = credentialcomposer.ComposeX509SVIDResponse                             //nolint: golint
+type CredentialComposerClient = credentialcomposer.CredentialComposerClient                           //nolint: golint
+type CredentialComposerServer = credentialcomposer.CredentialComposerServer                           //nolint: golint
+type UnimplementedCredentialComposerServer = credentialcomposer.UnimplementedCredentialComposerServer //nolint: golint
+type X509Extension = credentialcomposer.X509Extension                                                 //nolint: golint
+type X509SVIDAttributes = credentialcomposer.X509SVIDAttributes                                       //nolint: golint
+type X509Subject = credentialcomposer.X509Subject                                                     //nolint: golint
+
+const (
+	Type = "CredentialComposer"
+)
+
+// CredentialComposer is the client interface for the service type CredentialComposer interface.
+type CredentialComposer interface {
+	ComposeJWTSVID(context.Context, *ComposeJWTSVIDRequest) (*ComposeJWTSVIDResponse, error)
+	ComposeX509SVID(context.Context, *ComposeX509SVIDRequest) (*ComposeX509SVIDResponse, error)
+}
+
+// Plugin is the client interface for the service with the plugin related methods used by the catalog to initialize the plugin.
+type Plugin interface {
+	ComposeJWTSVID(context.Context, *ComposeJWTSVIDRequest) (*ComposeJWTSVIDResponse, error)
+	ComposeX509SVID(context.Context, *ComposeX509SVIDRequest) (*ComposeX509SVIDResponse, error)
+	Configure(context.Context, *spi.ConfigureRequest) (*spi.ConfigureResponse, error)
+	GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error)
+}
+
+// PluginServer returns a catalog PluginServer implementation for the CredentialComposer plugin.
+func PluginServer(server CredentialComposerServer) catalog.PluginServer {
+	return &pluginServer{
+		server: server,
+	}
+}
+
+type pluginServer struct {
+	server CredentialComposerServer
+}
+
+func (s pluginServer) PluginType() string {
+	return Type
+}
+
+func (s pluginServer) PluginClient() catalog.PluginClient {
+	return PluginClient
+}
+
+func (s pluginServer) RegisterPluginServer(server *grpc.Server) interface{} {
+	credentialcomposer.RegisterCredentialComposerServer(server, s.server)
+	return s.server
+}
+
+// PluginClient is a catalog PluginClient implementation for the CredentialComposer plugin.
+var PluginClient catalog.PluginClient = pluginClient{}
+
+type pluginClient struct{}
+
+func (pluginClient) PluginType() string {
+	return Type
+}
+
+func (pluginClient) NewPluginClient(conn *grpc.ClientConn) interface{} {
+	return AdaptPluginClient(credentialcomposer.NewCredentialComposerClient(conn))
+}
+
+func AdaptPluginClient(client CredentialComposerClient) CredentialComposer {
+	return pluginClientAdapter{client: client}
+}
+
+type pluginClientAdapter struct {
+	client CredentialComposerClient
+}
+
+func (a pluginClientAdapter) ComposeJWTSVID(ctx context.Context, in *ComposeJWTSVIDRequest) (*ComposeJWTSVIDResponse, error) {
+	return a.client.ComposeJWTSVID(ctx, in)
+}
+
+func (a pluginClientAdapter) ComposeX509SVID(ctx context.Context, in *ComposeX509SVIDRequest) (*ComposeX509SVIDResponse, error) {
+	return a.client.ComposeX509SVID(ctx, in)
+}
+
+func (a pluginClientAdapter) Configure(ctx context.Context, in *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	return a.client.Configure(ctx, in)
+}
+
+func (a pluginClientAdapter) GetPluginInfo(ctx context.Context, in *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return a.client.GetPluginInfo(ctx, in)
+}
@@ -12,65 +12,80 @@ import (
 	"google.golang.org/grpc"
 )
 
-type AppendBundleRequest = datastore.AppendBundleRequest                           //nolint: golint
-type AppendBundleResponse = datastore.AppendBundleResponse                         //nolint: golint
-type BySelectors = datastore.BySelectors                                           //nolint: golint
-type BySelectors_MatchBehavior = datastore.BySelectors_MatchBehavior               //nolint: golint
-type CreateAttestedNodeRequest = datastore.CreateAttestedNodeRequest               //nolint: golint
-type CreateAttestedNodeResponse = datastore.CreateAttestedNodeResponse             //nolint: golint
-type CreateBundleRequest = datastore.CreateBundleRequest                           //nolint: golint
-type CreateBundleResponse = datastore.CreateBundleResponse                         //nolint: golint
-type CreateJoinTokenRequest = datastore.CreateJoinTokenRequest                     //nolint: golint
-type CreateJoinTokenResponse = datastore.CreateJoinTokenResponse                   //nolint: golint
-type CreateRegistrationEntryRequest = datastore.CreateRegistrationEntryRequest     //nolint: golint
-type CreateRegistrationEntryResponse = datastore.CreateRegistrationEntryResponse   //nolint: golint
-type DataStoreClient = datastore.DataStoreClient                                   //nolint: golint
-type DataStoreServer = datastore.DataStoreServer                                   //nolint: golint
-type DeleteAttestedNodeRequest = datastore.DeleteAttestedNodeRequest               //nolint: golint
-type DeleteAttestedNodeResponse = datastore.DeleteAttestedNodeResponse             //nolint: golint
-type DeleteBundleRequest = datastore.DeleteBundleRequest                           //nolint: golint
-type DeleteBundleRequest_Mode = datastore.DeleteBundleRequest_Mode                 //nolint: golint
-type DeleteBundleResponse = datastore.DeleteBundleResponse                         //nolint: golint
-type DeleteJoinTokenRequest = datastore.DeleteJoinTokenRequest                     //nolint: golint
-type DeleteJoinTokenResponse = datastore.DeleteJoinTokenResponse                   //nolint: golint
-type DeleteRegistrationEntryRequest = datastore.DeleteRegistrationEntryRequest     //nolint: golint
-type DeleteRegistrationEntryResponse = datastore.DeleteRegistrationEntryResponse   //nolint: golint
-type FetchAttestedNodeRequest = datastore.FetchAttestedNodeRequest                 //nolint: golint
-type FetchAttestedNodeResponse = datastore.FetchAttestedNodeResponse               //nolint: golint
-type FetchBundleRequest = datastore.FetchBundleRequest                             //nolint: golint
-type FetchBundleResponse = datastore.FetchBundleResponse                           //nolint: golint
-type FetchJoinTokenRequest = datastore.FetchJoinTokenRequest                       //nolint: golint
-type FetchJoinTokenResponse = datastore.FetchJoinTokenResponse                     //nolint: golint
-type FetchRegistrationEntryRequest = datastore.FetchRegistrationEntryRequest       //nolint: golint
-type FetchRegistrationEntryResponse = datastore.FetchRegistrationEntryResponse     //nolint: golint
-type GetNodeSelectorsRequest = datastore.GetNodeSelectorsRequest                   //nolint: golint
-type GetNodeSelectorsResponse = datastore.GetNodeSelectorsResponse                 //nolint: golint
-type JoinToken = datastore.JoinToken                                               //nolint: golint
-type ListAttestedNodesRequest = datastore.ListAttestedNodesRequest                 //nolint: golint
-type ListAttestedNodesResponse = datastore.ListAttestedNodesResponse               //nolint: golint
-type ListBundlesRequest = datastore.ListBundlesRequest                             //nolint: golint
-type ListBundlesResponse = datastore.ListBundlesResponse                           //nolint: golint
-type ListRegistrationEntriesRequest = datastore.ListRegistrationEntriesRequest     //nolint: golint
-type ListRegistrationEntriesResponse = datastore.ListRegistrationEntriesResponse   //nolint: golint
-type NodeSelectors = datastore.NodeSelectors                                       //nolint: golint
-type Pagination = datastore.Pagination                                             //nolint: golint
-type PruneBundleRequest = datastore.PruneBundleRequest                             //nolint: golint
-type PruneBundleResponse = datastore.PruneBundleResponse                           //nolint: golint
-type PruneJoinTokensRequest = datastore.PruneJoinTokensRequest                     //nolint: golint
-type PruneJoinTokensResponse = datastore.PruneJoinTokensResponse                   //nolint: golint
-type PruneRegistrationEntriesRequest = datastore.PruneRegistrationEntriesRequest   //nolint: golint
-type PruneRegistrationEntriesResponse = datastore.PruneRegistrationEntriesResponse //nolint: golint
-type SetBundleRequest = datastore.SetBundleRequest                                 //nolint: golint
-type SetBundleResponse = datastore.SetBundleResponse                               //nolint: golint
-type SetNodeSelectorsRequest = datastore.SetNodeSelectorsRequest                   //nolint: golint
-type SetNodeSelectorsResponse = datastore.SetNodeSelectorsResponse                 //nolint: golint
-type UnimplementedDataStoreServer = datastore.UnimplementedDataStoreServer         //nolint: golint
-type UpdateAttestedNodeRequest = datastore.UpdateAttestedNodeRequest               //nolint: golint
-type UpdateAttestedNodeResponse = datastore.UpdateAttestedNodeResponse             //nolint: golint
-type UpdateBundleRequest = datastore.UpdateBundleRequest                           //nolint: golint
-type UpdateBundleResponse = datastore.UpdateBundleResponse                         //nolint: golint
-type UpdateRegistrationEntryRequest = datastore.UpdateRegistrationEntryRequest     //nolint: golint
-type UpdateRegistrationEntryResponse = datastore.UpdateRegistrationEntryResponse   //nolint: golint
+type AppendBundleRequest = datastore.AppendBundleRequest                             //nolint: golint
+type AppendBundleResponse = datastore.AppendBundleResponse                           //nolint: golint
+type BySelectors = datastore.BySelectors                                             //nolint: golint
+type BySelectors_MatchBehavior = datastore.BySelectors_MatchBehavior                 //nolint: golint
+type ConsumeJoinTokenRequest = datastore.ConsumeJoinTokenRequest                     //nolint: golint
+type ConsumeJoinTokenResponse = datastore.ConsumeJoinTokenResponse                   //nolint: golint
+type CreateAttestedNodeRequest = datastore.CreateAttestedNodeRequest                 //nolint: golint
+type CreateAttestedNodeResponse = datastore.CreateAttestedNodeResponse               //nolint: golint
+type CreateBundleRequest = datastore.CreateBundleRequest                             //nolint: golint
+type CreateBundleResponse = datastore.CreateBundleResponse                           //nolint: golint
+type CreateIssuedX509SVIDRequest = datastore.CreateIssuedX509SVIDRequest             //nolint: golint
+type CreateIssuedX509SVIDResponse = datastore.CreateIssuedX509SVIDResponse           //nolint: golint
+type CreateJoinTokenRequest = datastore.CreateJoinTokenRequest                       //nolint: golint
+type CreateJoinTokenResponse = datastore.CreateJoinTokenResponse                     //nolint: golint
+type CreateRegistrationEntryRequest = datastore.CreateRegistrationEntryRequest       //nolint: golint
+type CreateRegistrationEntryResponse = datastore.CreateRegistrationEntryResponse     //nolint: golint
+type DataStoreClient = datastore.DataStoreClient                                     //nolint: golint
+type DataStoreServer = datastore.DataStoreServer                                     //nolint: golint
+type DeleteAttestedNodeRequest = datastore.DeleteAttestedNodeRequest                 //nolint: golint
+type DeleteAttestedNodeResponse = datastore.DeleteAttestedNodeResponse               //nolint: golint
+type DeleteBundleRequest = datastore.DeleteBundleRequest                             //nolint: golint
+type DeleteBundleRequest_Mode = datastore.DeleteBundleRequest_Mode                   //nolint: golint
+type DeleteBundleResponse = datastore.DeleteBundleResponse                           //nolint: golint
+type DeleteJoinTokenRequest = datastore.DeleteJoinTokenRequest                       //nolint: golint
+type DeleteJoinTokenResponse = datastore.DeleteJoinTokenResponse                     //nolint: golint
+type DeleteRegistrationEntryRequest = datastore.DeleteRegistrationEntryRequest       //nolint: golint
+type DeleteRegistrationEntryResponse = datastore.DeleteRegistrationEntryResponse     //nolint: golint
+type FetchAttestedNodeRequest = datastore.FetchAttestedNodeRequest                   //nolint: golint
+type FetchAttestedNodeResponse = datastore.FetchAttestedNodeResponse                 //nolint: golint
+type FetchBundleRequest = datastore.FetchBundleRequest                               //nolint: golint
+type FetchBundleResponse = datastore.FetchBundleResponse                             //nolint: golint
+type FetchJoinTokenRequest = datastore.FetchJoinTokenRequest                         //nolint: golint
+type FetchJoinTokenResponse = datastore.FetchJoinTokenResponse                       //nolint: golint
+type FetchRegistrationEntryRequest = datastore.FetchRegistrationEntryRequest         //nolint: golint
+type FetchRegistrationEntryResponse = datastore.FetchRegistrationEntryResponse       //nolint: golint
+type ForceAttestedNodeRotationRequest = datastore.ForceAttestedNodeRotationRequest   //nolint: golint
+type ForceAttestedNodeRotationResponse = datastore.ForceAttestedNodeRotationResponse //nolint: golint
+type GetInterfaceVersionRequest = datastore.GetInterfaceVersionRequest               //nolint: golint
+type GetInterfaceVersionResponse = datastore.GetInterfaceVersionResponse             //nolint: golint
+type GetNodeSelectorsRequest = datastore.GetNodeSelectorsRequest                     //nolint: golint
+type GetNodeSelectorsResponse = datastore.GetNodeSelectorsResponse                   //nolint: golint
+type IssuedX509SVID = datastore.IssuedX509SVID                                       //nolint: golint
+type JoinToken = datastore.JoinToken                                                 //nolint: golint
+type ListAttestedNodesRequest = datastore.ListAttestedNodesRequest                   //nolint: golint
+type ListAttestedNodesResponse = datastore.ListAttestedNodesResponse                 //nolint: golint
+type ListBundlesRequest = datastore.ListBundlesRequest                               //nolint: golint
+type ListBundlesResponse = datastore.ListBundlesResponse                             //nolint: golint
+type ListIssuedX509SVIDsRequest = datastore.ListIssuedX509SVIDsRequest               //nolint: golint
+type ListIssuedX509SVIDsResponse = datastore.ListIssuedX509SVIDsResponse             //nolint: golint
+type ListRegistrationEntriesRequest = datastore.ListRegistrationEntriesRequest       //nolint: golint
+type ListRegistrationEntriesResponse = datastore.ListRegistrationEntriesResponse     //nolint: golint
+type NodeSelectors = datastore.NodeSelectors                                         //nolint: golint
+type Pagination = datastore.Pagination                                               //nolint: golint
+type PruneAttestedNodesRequest = datastore.PruneAttestedNodesRequest                 //nolint: golint
+type PruneAttestedNodesResponse = datastore.PruneAttestedNodesResponse               //nolint: golint
+type PruneBundleRequest = datastore.PruneBundleRequest                               //nolint: golint
+type PruneBundleResponse = datastore.PruneBundleResponse                             //nolint: golint
+type PruneIssuedX509SVIDsRequest = datastore.PruneIssuedX509SVIDsRequest             //nolint: golint
+type PruneIssuedX509SVIDsResponse = datastore.PruneIssuedX509SVIDsResponse           //nolint: golint
+type PruneJoinTokensRequest = datastore.PruneJoinTokensRequest                       //nolint: golint
+type PruneJoinTokensResponse = datastore.PruneJoinTokensResponse                     //nolint: golint
+type PruneRegistrationEntriesRequest = datastore.PruneRegistrationEntriesRequest     //nolint: golint
+type PruneRegistrationEntriesResponse = datastore.PruneRegistrationEntriesResponse   //nolint: golint
+type SetBundleRequest = datastore.SetBundleRequest                                   //nolint: golint
+type SetBundleResponse = datastore.SetBundleResponse                                 //nolint: golint
+type SetNodeSelectorsRequest = datastore.SetNodeSelectorsRequest                     //nolint: golint
+type SetNodeSelectorsResponse = datastore.SetNodeSelectorsResponse                   //nolint: golint
+type UnimplementedDataStoreServer = datastore.UnimplementedDataStoreServer           //nolint: golint
+type UpdateAttestedNodeRequest = datastore.UpdateAttestedNodeRequest                 //nolint: golint
+type UpdateAttestedNodeResponse = datastore.UpdateAttestedNodeResponse               //nolint: golint
+type UpdateBundleRequest = datastore.UpdateBundleRequest                             //nolint: golint
+type UpdateBundleResponse = datastore.UpdateBundleResponse                           //nolint: golint
+type UpdateRegistrationEntryRequest = datastore.UpdateRegistrationEntryRequest       //nolint: golint
+type UpdateRegistrationEntryResponse = datastore.UpdateRegistrationEntryResponse     //nolint: golint
 
 const (
 	Type                           = "DataStore"
@@ -84,8 +99,10 @@ const (
 // DataStore is the client interface for the service type DataStore interface.
 type DataStore interface {
 	AppendBundle(context.Context, *AppendBundleRequest) (*AppendBundleResponse, error)
+	ConsumeJoinToken(context.Context, *ConsumeJoinTokenRequest) (*ConsumeJoinTokenResponse, error)
 	CreateAttestedNode(context.Context, *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error)
 	CreateBundle(context.Context, *CreateBundleRequest) (*CreateBundleResponse, error)
+	CreateIssuedX509SVID(context.Context, *CreateIssuedX509SVIDRequest) (*CreateIssuedX509SVIDResponse, error)
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
@@ -96,11 +113,15 @@ type DataStore interface {
 	FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error)
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
+	ForceAttestedNodeRotation(context.Context, *ForceAttestedNodeRotationRequest) (*ForceAttestedNodeRotationResponse, error)
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	ListIssuedX509SVIDs(context.Context, *ListIssuedX509SVIDsRequest) (*ListIssuedX509SVIDsResponse, error)
 	ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error)
+	PruneAttestedNodes(context.Context, *PruneAttestedNodesRequest) (*PruneAttestedNodesResponse, error)
 	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
+	PruneIssuedX509SVIDs(context.Context, *PruneIssuedX509SVIDsRequest) (*PruneIssuedX509SVIDsResponse, error)
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
 	PruneRegistrationEntries(context.Context, *PruneRegistrationEntriesRequest) (*PruneRegistrationEntriesResponse, error)
 	SetBundle(context.Context, *SetBundleRequest) (*SetBundleResponse, error)
@@ -114,8 +135,10 @@ type DataStore interface {
 type Plugin interface {
 	AppendBundle(context.Context, *AppendBundleRequest) (*AppendBundleResponse, error)
 	Configure(context.Context, *spi.ConfigureRequest) (*spi.ConfigureResponse, error)
+	ConsumeJoinToken(context.Context, *ConsumeJoinTokenRequest) (*ConsumeJoinTokenResponse, error)
 	CreateAttestedNode(context.Context, *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error)
 	CreateBundle(context.Context, *CreateBundleRequest) (*CreateBundleResponse, error)
+	CreateIssuedX509SVID(context.Context, *CreateIssuedX509SVIDRequest) (*CreateIssuedX509SVIDResponse, error)
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
@@ -126,12 +149,17 @@ type Plugin interface {
 	FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error)
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
+	ForceAttestedNodeRotation(context.Context, *ForceAttestedNodeRotationRequest) (*ForceAttestedNodeRotationResponse, error)
+	GetInterfaceVersion(context.Context, *GetInterfaceVersionRequest) (*GetInterfaceVersionResponse, error)
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
 	GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	ListIssuedX509SVIDs(context.Context, *ListIssuedX509SVIDsRequest) (*ListIssuedX509SVIDsResponse, error)
 	ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error)
+	PruneAttestedNodes(context.Context, *PruneAttestedNodesRequest) (*PruneAttestedNodesResponse, error)
 	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
+	PruneIssuedX509SVIDs(context.Context, *PruneIssuedX509SVIDsRequest) (*PruneIssuedX509SVIDsResponse, error)
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
 	PruneRegistrationEntries(context.Context, *PruneRegistrationEntriesRequest) (*PruneRegistrationEntriesResponse, error)
 	SetBundle(context.Context, *SetBundleRequest) (*SetBundleResponse, error)
@@ -194,6 +222,10 @@ func (a pluginClientAdapter) Configure(ctx context.Context, in *spi.ConfigureReq
 	return a.client.Configure(ctx, in)
 }
 
+func (a pluginClientAdapter) ConsumeJoinToken(ctx context.Context, in *ConsumeJoinTokenRequest) (*ConsumeJoinTokenResponse, error) {
+	return a.client.ConsumeJoinToken(ctx, in)
+}
+
 func (a pluginClientAdapter) CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error) {
 	return a.client.CreateAttestedNode(ctx, in)
 }
@@ -202,6 +234,10 @@ func (a pluginClientAdapter) CreateBundle(ctx context.Context, in *CreateBundleR
 	return a.client.CreateBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) CreateIssuedX509SVID(ctx context.Context, in *CreateIssuedX509SVIDRequest) (*CreateIssuedX509SVIDResponse, error) {
+	return a.client.CreateIssuedX509SVID(ctx, in)
+}
+
 func (a pluginClientAdapter) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error) {
 	return a.client.CreateJoinToken(ctx, in)
 }
@@ -242,6 +278,14 @@ func (a pluginClientAdapter) FetchRegistrationEntry(ctx context.Context, in *Fet
 	return a.client.FetchRegistrationEntry(ctx, in)
 }
 
+func (a pluginClientAdapter) ForceAttestedNodeRotation(ctx context.Context, in *ForceAttestedNodeRotationRequest) (*ForceAttestedNodeRotationResponse, error) {
+	return a.client.ForceAttestedNodeRotation(ctx, in)
+}
+
+func (a pluginClientAdapter) GetInterfaceVersion(ctx context.Context, in *GetInterfaceVersionRequest) (*GetInterfaceVersionResponse, error) {
+	return a.client.GetInterfaceVersion(ctx, in)
+}
+
 func (a pluginClientAdapter) GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error) {
 	return a.client.GetNodeSelectors(ctx, in)
 }
@@ -258,14 +302,26 @@ func (a pluginClientAdapter) ListBundles(ctx context.Context, in *ListBundlesReq
 	return a.client.ListBundles(ctx, in)
 }
 
+func (a pluginClientAdapter) ListIssuedX509SVIDs(ctx context.Context, in *ListIssuedX509SVIDsRequest) (*ListIssuedX509SVIDsResponse, error) {
+	return a.client.ListIssuedX509SVIDs(ctx, in)
+}
+
 func (a pluginClientAdapter) ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error) {
 	return a.client.ListRegistrationEntries(ctx, in)
 }
 
+func (a pluginClientAdapter) PruneAttestedNodes(ctx context.Context, in *PruneAttestedNodesRequest) (*PruneAttestedNodesResponse, error) {
+	return a.client.PruneAttestedNodes(ctx, in)
+}
+
 func (a pluginClientAdapter) PruneBundle(ctx context.Context, in *PruneBundleRequest) (*PruneBundleResponse, error) {
 	return a.client.PruneBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) PruneIssuedX509SVIDs(ctx context.Context, in *PruneIssuedX509SVIDsRequest) (*PruneIssuedX509SVIDsResponse, error) {
+	return a.client.PruneIssuedX509SVIDs(ctx, in)
+}
+
 func (a pluginClientAdapter) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error) {
 	return a.client.PruneJoinTokens(ctx, in)
 }
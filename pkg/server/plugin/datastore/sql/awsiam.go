@@ -0,0 +1,131 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/rds/rdsutils"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	defaultPostgresPort = "5432"
+)
+
+// authTokenFunc returns a token used as password to authenticate the user
+// against the database listening on endpoint (host:port)
+type authTokenFunc func(endpoint, user string) (string, error)
+
+// newAWSIAMAuthTokenFunc returns an authTokenFunc that generates AWS RDS IAM
+// authentication tokens using the default AWS credential chain
+var newAWSIAMAuthTokenFunc = func(region string) (authTokenFunc, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(region),
+	})
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	creds := sess.Config.Credentials
+	return func(endpoint, user string) (string, error) {
+		return rdsutils.BuildAuthToken(endpoint, region, user, creds)
+	}, nil
+}
+
+// openWithAWSIAMAuth opens a database that authenticates every new connection
+// with a freshly generated AWS RDS IAM authentication token. Tokens are only
+// valid for a short period of time, so they cannot be baked into the
+// connection string once.
+func openWithAWSIAMAuth(cfg *configuration, dialect string, drv driver.Driver, connString string, withToken func(string, authTokenFunc) (string, error)) (*gorm.DB, error) {
+	token, err := newAWSIAMAuthTokenFunc(cfg.AWSRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	// fail early if the connection string cannot be used with tokens
+	if _, err := withToken(connString, token); err != nil {
+		return nil, err
+	}
+
+	raw := sql.OpenDB(tokenConnector{
+		driver: drv,
+		dsn: func() (string, error) {
+			return withToken(connString, token)
+		},
+	})
+	db, err := gorm.Open(dialect, raw)
+	if err != nil {
+		raw.Close()
+		return nil, sqlError.Wrap(err)
+	}
+	return db, nil
+}
+
+// tokenConnector is a driver.Connector that builds a new connection string,
+// and therefore a new authentication token, for every connection
+type tokenConnector struct {
+	driver driver.Driver
+	dsn    func() (string, error)
+}
+
+func (c tokenConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	dsn, err := c.dsn()
+	if err != nil {
+		return nil, err
+	}
+	return c.driver.Open(dsn)
+}
+
+func (c tokenConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// mysqlConnStringWithToken sets a token as the password of the MySQL
+// connection string. RDS requires cleartext passwords to be allowed to
+// authenticate with IAM tokens (the connection itself must use TLS).
+func mysqlConnStringWithToken(connString string, token authTokenFunc) (string, error) {
+	opts, err := mysql.ParseDSN(connString)
+	if err != nil {
+		return "", sqlError.Wrap(err)
+	}
+
+	opts.Passwd, err = token(opts.Addr, opts.User)
+	if err != nil {
+		return "", sqlError.New("unable to generate authentication token: %v", err)
+	}
+	opts.AllowCleartextPasswords = true
+	return opts.FormatDSN(), nil
+}
+
+// postgresConnStringWithToken sets a token as the password of the PostgreSQL
+// connection string, which must be in URL form.
+func postgresConnStringWithToken(connString string, token authTokenFunc) (string, error) {
+	u, err := url.Parse(connString)
+	if err != nil || !isPostgresURL(u) {
+		return "", sqlError.New("AWS IAM authentication requires a postgres connection_string in URL form")
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return "", sqlError.New("AWS IAM authentication requires a user in the connection_string")
+	}
+
+	endpoint := u.Host
+	if u.Port() == "" {
+		endpoint += ":" + defaultPostgresPort
+	}
+
+	user := u.User.Username()
+	password, err := token(endpoint, user)
+	if err != nil {
+		return "", sqlError.New("unable to generate authentication token: %v", err)
+	}
+	u.User = url.UserPassword(user, password)
+	return u.String(), nil
+}
+
+func isPostgresURL(u *url.URL) bool {
+	return u.Scheme == "postgres" || u.Scheme == "postgresql"
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/blang/semver"
 	"github.com/golang/protobuf/proto"
 	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
 	"github.com/jinzhu/gorm"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
@@ -19,7 +20,7 @@ import (
 
 const (
 	// the latest schema version of the database in the code
-	latestSchemaVersion = 14
+	latestSchemaVersion = 19
 )
 
 var (
@@ -27,7 +28,7 @@ var (
 	codeVersion = semver.MustParse(version.Version())
 )
 
-func migrateDB(db *gorm.DB, dbType string, disableMigration bool, log hclog.Logger) (err error) {
+func migrateDB(db *gorm.DB, dbType string, disableMigration, requireManualMigration bool, log hclog.Logger) (err error) {
 	isNew := !db.HasTable(&Bundle{})
 	if err := db.Error; err != nil {
 		return sqlError.Wrap(err)
@@ -105,6 +106,11 @@ func migrateDB(db *gorm.DB, dbType string, disableMigration bool, log hclog.Logg
 		return nil
 	}
 
+	if requireManualMigration {
+		log.Error("DB schema is behind code version and manual migration is required, run `spire-server datastore migrate`")
+		return sqlError.New("schema version %d must be migrated to version %d using `spire-server datastore migrate`", schemaVersion, latestSchemaVersion)
+	}
+
 	// at this point:
 	// - auto-migration is enabled
 	// - schema version of DB is behind
@@ -129,6 +135,140 @@ func migrateDB(db *gorm.DB, dbType string, disableMigration bool, log hclog.Logg
 	return nil
 }
 
+// MigrationPlan describes the schema migrations needed to bring a database up
+// to the schema version supported by this code.
+type MigrationPlan struct {
+	// NewDatabase is true if the database has not been initialized yet. New
+	// databases are created at the target version directly.
+	NewDatabase bool
+
+	// SchemaVersion is the schema version of the database
+	SchemaVersion int
+
+	// TargetVersion is the schema version supported by this code
+	TargetVersion int
+}
+
+// Pending returns the schema versions that migrating the database will
+// produce, in the order they are applied.
+func (p *MigrationPlan) Pending() []int {
+	if p.NewDatabase {
+		return nil
+	}
+	var pending []int
+	for version := p.SchemaVersion + 1; version <= p.TargetVersion; version++ {
+		pending = append(pending, version)
+	}
+	return pending
+}
+
+// Migrate migrates the database described by the plugin configuration to the
+// schema version supported by this code, regardless of the
+// require_manual_migration and disable_migration settings. If dryRun is true,
+// the database is left untouched and only the plan is returned.
+func Migrate(config string, dryRun bool, log hclog.Logger) (*MigrationPlan, error) {
+	cfg := &configuration{}
+	if err := hcl.Decode(cfg, config); err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	db, _, _, _, err := connectDB(cfg, false, log)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	plan, err := planMigration(db)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun || (!plan.NewDatabase && plan.SchemaVersion >= plan.TargetVersion) {
+		return plan, nil
+	}
+
+	if err := migrateDB(db, cfg.DatabaseType, false, false, log); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// Validate checks the plugin configuration and that the database can be
+// connected to, as on startup, but without initializing or migrating the
+// database. It fails if the database would have to be migrated manually.
+func Validate(config string, log hclog.Logger) error {
+	cfg := &configuration{}
+	if err := hcl.Decode(cfg, config); err != nil {
+		return sqlError.Wrap(err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return sqlError.Wrap(err)
+	}
+
+	if cfg.DatabaseType == SQLite {
+		// Connecting would create the database file if it does not exist
+		if err := checkSQLite3DBExists(cfg.ConnectionString); err != nil {
+			return err
+		}
+	}
+
+	db, _, _, _, err := connectDB(cfg, false, log)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if cfg.RoConnectionString != "" {
+		roDB, _, _, _, err := connectDB(cfg, true, log)
+		if err != nil {
+			return err
+		}
+		roDB.Close()
+	}
+
+	plan, err := planMigration(db)
+	if err != nil {
+		return err
+	}
+	if cfg.RequireManualMigration && !plan.NewDatabase && plan.SchemaVersion < plan.TargetVersion {
+		return sqlError.New("schema version %d must be migrated to version %d using `spire-server datastore migrate`", plan.SchemaVersion, plan.TargetVersion)
+	}
+	return nil
+}
+
+// planMigration inspects the database to determine which migrations are
+// pending without modifying it.
+func planMigration(db *gorm.DB) (*MigrationPlan, error) {
+	plan := &MigrationPlan{
+		TargetVersion: latestSchemaVersion,
+	}
+
+	if !db.HasTable(&Bundle{}) {
+		plan.NewDatabase = true
+		return plan, nil
+	}
+
+	// pre-0.9 databases may not have the migrations table
+	if !db.HasTable(&Migration{}) {
+		return plan, nil
+	}
+
+	migration := new(Migration)
+	switch err := db.First(migration).Error; {
+	case err == nil:
+		plan.SchemaVersion = migration.Version
+	case gorm.IsRecordNotFoundError(err):
+	default:
+		return nil, sqlError.Wrap(err)
+	}
+	return plan, nil
+}
+
 func isDisabledMigrationAllowed(dbCodeVersion semver.Version) error {
 	// If auto-migrate is disabled and we are running a compatible version (+/- 1
 	// minor from the stored code version) then we are done here
@@ -178,6 +318,7 @@ func initDB(db *gorm.DB, dbType string, log hclog.Logger) (err error) {
 		&Selector{},
 		&Migration{},
 		&DNSName{},
+		&IssuedX509SVID{},
 	}
 
 	if err := tableOptionsForDialect(tx, dbType).AutoMigrate(tables...).Error; err != nil {
@@ -247,6 +388,16 @@ func migrateVersion(tx *gorm.DB, currVersion int, log hclog.Logger) (versionOut
 		err = migrateToV13(tx)
 	case 13:
 		err = migrateToV14(tx)
+	case 14:
+		err = migrateToV15(tx)
+	case 15:
+		err = migrateToV16(tx)
+	case 16:
+		err = migrateToV17(tx)
+	case 17:
+		err = migrateToV18(tx)
+	case 18:
+		err = migrateToV19(tx)
 	default:
 		err = sqlError.New("no migration support for version %d", currVersion)
 	}
@@ -468,19 +619,58 @@ func migrateToV12(tx *gorm.DB) error {
 }
 
 func migrateToV13(tx *gorm.DB) error {
-	if err := tx.AutoMigrate(&AttestedNode{}).Error; err != nil {
+	if err := tx.AutoMigrate(&V13AttestedNode{}).Error; err != nil {
 		return sqlError.Wrap(err)
 	}
 	return nil
 }
 
 func migrateToV14(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&V14RegisteredEntry{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV15(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&V15RegisteredEntry{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV16(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&JoinToken{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	// Existing tokens are single use and have not been used yet
+	if err := tx.Exec("UPDATE join_tokens SET max_uses = 0, use_count = 0").Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV17(tx *gorm.DB) error {
 	if err := tx.AutoMigrate(&RegisteredEntry{}).Error; err != nil {
 		return sqlError.Wrap(err)
 	}
 	return nil
 }
 
+func migrateToV18(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&AttestedNode{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV19(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&IssuedX509SVID{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
 func addFederatedRegistrationEntriesRegisteredEntryIDIndex(tx *gorm.DB) error {
 	// GORM creates the federated_registration_entries implicitly with a primary
 	// key tuple (bundle_id, registered_entry_id). Unfortunately, MySQL5 does
@@ -670,6 +860,84 @@ func (V10RegisteredEntry) TableName() string {
 	return "registered_entries"
 }
 
+// V13AttestedNode holds an attested node (agent)
+type V13AttestedNode struct {
+	Model
+
+	SpiffeID        string `gorm:"unique_index"`
+	DataType        string
+	SerialNumber    string
+	ExpiresAt       time.Time
+	NewSerialNumber string
+	NewExpiresAt    *time.Time
+
+	Selectors []*NodeSelector
+}
+
+// TableName gets table name for v13 attested node
+func (V13AttestedNode) TableName() string {
+	return "attested_node_entries"
+}
+
+// V14RegisteredEntry holds a registered entity entry
+type V14RegisteredEntry struct {
+	Model
+
+	EntryID  string `gorm:"unique_index"`
+	SpiffeID string `gorm:"index"`
+	ParentID string `gorm:"index"`
+	// TTL of identities derived from this entry
+	TTL           int32
+	Selectors     []Selector
+	FederatesWith []Bundle `gorm:"many2many:federated_registration_entries;"`
+	Admin         bool
+	Downstream    bool
+	// (optional) expiry of this entry
+	Expiry int64 `gorm:"index"`
+	// (optional) DNS entries
+	DNSList []DNSName
+
+	// RevisionNumber is a counter that is incremented when the entry is
+	// updated.
+	RevisionNumber int64
+}
+
+// TableName gets table name for v14 registered entry
+func (V14RegisteredEntry) TableName() string {
+	return "registered_entries"
+}
+
+// V15RegisteredEntry holds a registered entity entry
+type V15RegisteredEntry struct {
+	Model
+
+	EntryID  string `gorm:"unique_index"`
+	SpiffeID string `gorm:"index"`
+	ParentID string `gorm:"index"`
+	// TTL of identities derived from this entry
+	TTL           int32
+	Selectors     []Selector
+	FederatesWith []Bundle `gorm:"many2many:federated_registration_entries;"`
+	Admin         bool
+	Downstream    bool
+	// (optional) expiry of this entry
+	Expiry int64 `gorm:"index"`
+	// (optional) DNS entries
+	DNSList []DNSName
+
+	// RevisionNumber is a counter that is incremented when the entry is
+	// updated.
+	RevisionNumber int64
+
+	// TTL of JWT-SVIDs derived from this entry
+	JWTSvidTTL int32
+}
+
+// TableName gets table name for v15 registered entry
+func (V15RegisteredEntry) TableName() string {
+	return "registered_entries"
+}
+
 type V8Selector struct {
 	Model
 
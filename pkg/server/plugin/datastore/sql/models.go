@@ -32,6 +32,7 @@ type AttestedNode struct {
 	ExpiresAt       time.Time
 	NewSerialNumber string
 	NewExpiresAt    *time.Time
+	ForceRotation   bool
 
 	Selectors []*NodeSelector
 }
@@ -89,6 +90,12 @@ type RegisteredEntry struct {
 	// RevisionNumber is a counter that is incremented when the entry is
 	// updated.
 	RevisionNumber int64
+
+	// TTL of JWT-SVIDs derived from this entry
+	JWTSvidTTL int32
+
+	// (optional) hint used by workloads to select between their SVIDs
+	Hint string
 }
 
 // JoinToken holds a join token
@@ -97,6 +104,38 @@ type JoinToken struct {
 
 	Token  string `gorm:"unique_index"`
 	Expiry int64
+
+	// MaxUses is the number of times the token can be used. Zero means the
+	// token can only be used once.
+	MaxUses int32
+
+	// UseCount is the number of times the token has been used.
+	UseCount int32
+}
+
+// IssuedX509SVID holds the record of an issued X509-SVID
+type IssuedX509SVID struct {
+	Model
+
+	SpiffeID     string `gorm:"index"`
+	SerialNumber string `gorm:"index"`
+	IssuedAt     time.Time
+	ExpiresAt    time.Time `gorm:"index"`
+
+	// CAKeyID is the key ID of the CA that signed the SVID
+	CAKeyID string
+
+	// AgentID is the SPIFFE ID of the agent the SVID was issued to, if any
+	AgentID string `gorm:"index"`
+
+	// EntryID is the ID of the registration entry the SVID was issued for,
+	// if any
+	EntryID string
+}
+
+// TableName gets table name of IssuedX509SVID
+func (IssuedX509SVID) TableName() string {
+	return "issued_x509_svids"
 }
 
 type Selector struct {
@@ -29,7 +29,11 @@ func (my mysqlDB) connect(cfg *configuration, isReadOnly bool) (db *gorm.DB, ver
 		return nil, "", false, err
 	}
 
-	db, err = gorm.Open("mysql", connString)
+	if cfg.UseAWSIAMAuth {
+		db, err = openWithAWSIAMAuth(cfg, "mysql", &mysql.MySQLDriver{}, connString, mysqlConnStringWithToken)
+	} else {
+		db, err = gorm.Open("mysql", connString)
+	}
 	if err != nil {
 		return nil, "", false, err
 	}
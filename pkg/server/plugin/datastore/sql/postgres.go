@@ -1,6 +1,11 @@
 package sql
 
 import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
 	"github.com/jinzhu/gorm"
 	"github.com/lib/pq"
 
@@ -10,8 +15,19 @@ import (
 
 type postgresDB struct{}
 
+var postgresSSLModeRE = regexp.MustCompile(`(^|\s)sslmode\s*=`)
+
 func (p postgresDB) connect(cfg *configuration, isReadOnly bool) (db *gorm.DB, version string, supportsCTE bool, err error) {
-	db, err = gorm.Open("postgres", getConnectionString(cfg, isReadOnly))
+	connString, err := configurePostgresConnection(cfg, isReadOnly)
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	if cfg.UseAWSIAMAuth {
+		db, err = openWithAWSIAMAuth(cfg, "postgres", &pq.Driver{}, connString, postgresConnStringWithToken)
+	} else {
+		db, err = gorm.Open("postgres", connString)
+	}
 	if err != nil {
 		return nil, "", false, sqlError.Wrap(err)
 	}
@@ -31,3 +47,52 @@ func (p postgresDB) isConstraintViolation(err error) bool {
 	// "23xxx" is the constraint violation class for PostgreSQL
 	return ok && e.Code.Class() == "23"
 }
+
+// configurePostgresConnection adds the TLS options from the configuration to
+// the connection string, which can either be in URL or key/value form. When a
+// Root CA is configured and no sslmode is set, the server certificate and
+// hostname are verified.
+func configurePostgresConnection(cfg *configuration, isReadOnly bool) (string, error) {
+	connString := getConnectionString(cfg, isReadOnly)
+	if !hasTLSConfig(cfg) {
+		return connString, nil
+	}
+
+	var params [][2]string
+	if cfg.RootCAPath != "" {
+		params = append(params, [2]string{"sslrootcert", cfg.RootCAPath})
+	}
+	if cfg.ClientCertPath != "" && cfg.ClientKeyPath != "" {
+		params = append(params,
+			[2]string{"sslcert", cfg.ClientCertPath},
+			[2]string{"sslkey", cfg.ClientKeyPath},
+		)
+	}
+
+	if u, err := url.Parse(connString); err == nil && isPostgresURL(u) {
+		query := u.Query()
+		for _, param := range params {
+			query.Set(param[0], param[1])
+		}
+		if cfg.RootCAPath != "" && query.Get("sslmode") == "" {
+			query.Set("sslmode", "verify-full")
+		}
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	}
+
+	if cfg.RootCAPath != "" && !postgresSSLModeRE.MatchString(connString) {
+		params = append(params, [2]string{"sslmode", "verify-full"})
+	}
+	for _, param := range params {
+		connString += fmt.Sprintf(" %s=%s", param[0], quotePostgresValue(param[1]))
+	}
+	return strings.TrimSpace(connString), nil
+}
+
+// quotePostgresValue quotes a value for a key/value connection string
+func quotePostgresValue(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `'`, `\'`, -1)
+	return "'" + value + "'"
+}
@@ -0,0 +1,75 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigurePostgresConnection(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cfg      *configuration
+		expected string
+	}{
+		{
+			name: "no TLS config",
+			cfg: &configuration{
+				ConnectionString: "dbname=spire user=spire",
+			},
+			expected: "dbname=spire user=spire",
+		},
+		{
+			name: "key/value form with root CA",
+			cfg: &configuration{
+				ConnectionString: "dbname=spire user=spire",
+				RootCAPath:       "/path/to/ca.pem",
+			},
+			expected: "dbname=spire user=spire sslrootcert='/path/to/ca.pem' sslmode='verify-full'",
+		},
+		{
+			name: "key/value form with explicit sslmode",
+			cfg: &configuration{
+				ConnectionString: "dbname=spire sslmode=verify-ca",
+				RootCAPath:       "/path/to/ca.pem",
+			},
+			expected: "dbname=spire sslmode=verify-ca sslrootcert='/path/to/ca.pem'",
+		},
+		{
+			name: "key/value form with client certificate",
+			cfg: &configuration{
+				ConnectionString: "dbname=spire",
+				ClientCertPath:   "/path/to/it's cert.pem",
+				ClientKeyPath:    "/path/to/key.pem",
+			},
+			expected: `dbname=spire sslcert='/path/to/it\'s cert.pem' sslkey='/path/to/key.pem'`,
+		},
+		{
+			name: "URL form with root CA and client certificate",
+			cfg: &configuration{
+				ConnectionString: "postgres://spire@db.example.org/spire",
+				RootCAPath:       "/path/to/ca.pem",
+				ClientCertPath:   "/path/to/cert.pem",
+				ClientKeyPath:    "/path/to/key.pem",
+			},
+			expected: "postgres://spire@db.example.org/spire?sslcert=%2Fpath%2Fto%2Fcert.pem&sslkey=%2Fpath%2Fto%2Fkey.pem&sslmode=verify-full&sslrootcert=%2Fpath%2Fto%2Fca.pem",
+		},
+		{
+			name: "URL form with explicit sslmode",
+			cfg: &configuration{
+				ConnectionString: "postgresql://spire@db.example.org/spire?sslmode=require",
+				RootCAPath:       "/path/to/ca.pem",
+			},
+			expected: "postgresql://spire@db.example.org/spire?sslmode=require&sslrootcert=%2Fpath%2Fto%2Fca.pem",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := configurePostgresConnection(testCase.cfg, false)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, actual)
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/hcl"
 	"github.com/jinzhu/gorm"
@@ -23,6 +24,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire/common"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
@@ -50,6 +52,13 @@ const (
 	PostgreSQL = "postgres"
 	// SQLite database type
 	SQLite = "sqlite3"
+
+	// defaultMaxOpenConns bounds the connections opened to the database when
+	// max_open_conns is not configured
+	defaultMaxOpenConns = 100
+
+	// maxHintLength is the size of the registered_entries hint column
+	maxHintLength = 255
 )
 
 func BuiltIn() catalog.Plugin {
@@ -65,16 +74,20 @@ func builtin(p *Plugin) catalog.Plugin {
 // Configuration for the datastore.
 // Pointer values are used to distinguish between "unset" and "zero" values.
 type configuration struct {
-	DatabaseType       string  `hcl:"database_type" json:"database_type"`
-	ConnectionString   string  `hcl:"connection_string" json:"connection_string"`
-	RoConnectionString string  `hcl:"ro_connection_string" json:"ro_connection_string"`
-	RootCAPath         string  `hcl:"root_ca_path" json:"root_ca_path"`
-	ClientCertPath     string  `hcl:"client_cert_path" json:"client_cert_path"`
-	ClientKeyPath      string  `hcl:"client_key_path" json:"client_key_path"`
-	ConnMaxLifetime    *string `hcl:"conn_max_lifetime" json:"conn_max_lifetime"`
-	MaxOpenConns       *int    `hcl:"max_open_conns" json:"max_open_conns"`
-	MaxIdleConns       *int    `hcl:"max_idle_conns" json:"max_idle_conns"`
-	DisableMigration   bool    `hcl:"disable_migration" json:"disable_migration"`
+	DatabaseType           string  `hcl:"database_type" json:"database_type"`
+	ConnectionString       string  `hcl:"connection_string" json:"connection_string"`
+	RoConnectionString     string  `hcl:"ro_connection_string" json:"ro_connection_string"`
+	RootCAPath             string  `hcl:"root_ca_path" json:"root_ca_path"`
+	ClientCertPath         string  `hcl:"client_cert_path" json:"client_cert_path"`
+	ClientKeyPath          string  `hcl:"client_key_path" json:"client_key_path"`
+	ConnMaxLifetime        *string `hcl:"conn_max_lifetime" json:"conn_max_lifetime"`
+	MaxOpenConns           *int    `hcl:"max_open_conns" json:"max_open_conns"`
+	MaxIdleConns           *int    `hcl:"max_idle_conns" json:"max_idle_conns"`
+	QueryTimeout           *string `hcl:"query_timeout" json:"query_timeout"`
+	DisableMigration       bool    `hcl:"disable_migration" json:"disable_migration"`
+	RequireManualMigration bool    `hcl:"require_manual_migration" json:"require_manual_migration"`
+	UseAWSIAMAuth          bool    `hcl:"use_aws_iam_auth" json:"use_aws_iam_auth"`
+	AWSRegion              string  `hcl:"aws_region" json:"aws_region"`
 
 	// Undocumented flags
 	LogSQL bool `hcl:"log_sql" json:"log_sql"`
@@ -105,10 +118,11 @@ func (db *sqlDB) QueryContext(ctx context.Context, query string, args ...interfa
 
 // Plugin is a DataStore plugin implemented via a SQL database
 type Plugin struct {
-	mu   sync.Mutex
-	db   *sqlDB
-	roDb *sqlDB
-	log  hclog.Logger
+	mu           sync.Mutex
+	db           *sqlDB
+	roDb         *sqlDB
+	queryTimeout time.Duration
+	log          hclog.Logger
 }
 
 // New creates a new sql plugin struct. Configure must be called
@@ -179,7 +193,7 @@ func (ds *Plugin) DeleteBundle(ctx context.Context, req *datastore.DeleteBundleR
 
 // FetchBundle returns the bundle matching the specified Trust Domain.
 func (ds *Plugin) FetchBundle(ctx context.Context, req *datastore.FetchBundleRequest) (resp *datastore.FetchBundleResponse, err error) {
-	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+	if err = ds.withStaleReadTx(ctx, req.TolerateStale, func(tx *gorm.DB) (err error) {
 		resp, err = fetchBundle(tx, req)
 		return err
 	}); err != nil {
@@ -190,7 +204,7 @@ func (ds *Plugin) FetchBundle(ctx context.Context, req *datastore.FetchBundleReq
 
 // ListBundles can be used to fetch all existing bundles.
 func (ds *Plugin) ListBundles(ctx context.Context, req *datastore.ListBundlesRequest) (resp *datastore.ListBundlesResponse, err error) {
-	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+	if err = ds.withStaleReadTx(ctx, req.TolerateStale, func(tx *gorm.DB) (err error) {
 		resp, err = listBundles(tx, req)
 		return err
 	}); err != nil {
@@ -242,11 +256,18 @@ func (ds *Plugin) FetchAttestedNode(ctx context.Context,
 // ListAttestedNodes lists all attested nodes (pagination available)
 func (ds *Plugin) ListAttestedNodes(ctx context.Context,
 	req *datastore.ListAttestedNodesRequest) (resp *datastore.ListAttestedNodesResponse, err error) {
-	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
-		resp, err = listAttestedNodes(ctx, ds.db, req)
-		return err
-	}); err != nil {
-		return nil, err
+	// The nodes are listed outside of a transaction, so the query timeout
+	// is applied here instead of by the transaction helpers
+	ctx, cancel := ds.withQueryTimeout(ctx)
+	defer cancel()
+
+	ds.mu.Lock()
+	db := ds.db
+	ds.mu.Unlock()
+
+	resp, err = listAttestedNodes(ctx, db, req)
+	if err != nil {
+		return nil, ds.gormToGRPCStatus(err)
 	}
 	return resp, nil
 }
@@ -263,6 +284,20 @@ func (ds *Plugin) UpdateAttestedNode(ctx context.Context,
 	return resp, nil
 }
 
+// ForceAttestedNodeRotation flags the given node so that it rotates its SVID
+// at its next synchronization. Only the flag is updated, so that a concurrent
+// renewal of the node's SVID is not overwritten.
+func (ds *Plugin) ForceAttestedNodeRotation(ctx context.Context,
+	req *datastore.ForceAttestedNodeRotationRequest) (resp *datastore.ForceAttestedNodeRotationResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = forceAttestedNodeRotation(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // DeleteAttestedNode deletes the given attested node
 func (ds *Plugin) DeleteAttestedNode(ctx context.Context,
 	req *datastore.DeleteAttestedNodeRequest) (resp *datastore.DeleteAttestedNodeResponse, err error) {
@@ -275,6 +310,18 @@ func (ds *Plugin) DeleteAttestedNode(ctx context.Context,
 	return resp, nil
 }
 
+// PruneAttestedNodes deletes all attested nodes, and their selectors, whose
+// SVID expired before the specified time. Banned nodes are not pruned.
+func (ds *Plugin) PruneAttestedNodes(ctx context.Context, req *datastore.PruneAttestedNodesRequest) (resp *datastore.PruneAttestedNodesResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = pruneAttestedNodes(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // SetNodeSelectors sets node (agent) selectors by SPIFFE ID, deleting old selectors first
 func (ds *Plugin) SetNodeSelectors(ctx context.Context, req *datastore.SetNodeSelectorsRequest) (resp *datastore.SetNodeSelectorsResponse, err error) {
 	if req.Selectors == nil {
@@ -293,10 +340,16 @@ func (ds *Plugin) SetNodeSelectors(ctx context.Context, req *datastore.SetNodeSe
 // GetNodeSelectors gets node (agent) selectors by SPIFFE ID
 func (ds *Plugin) GetNodeSelectors(ctx context.Context,
 	req *datastore.GetNodeSelectorsRequest) (resp *datastore.GetNodeSelectorsResponse, err error) {
-	if req.TolerateStale && ds.roDb != nil {
-		return getNodeSelectors(ctx, ds.roDb, req)
+	ctx, cancel := ds.withQueryTimeout(ctx)
+	defer cancel()
+
+	if err = ds.withStaleRead(req.TolerateStale, func(db *sqlDB) (err error) {
+		resp, err = getNodeSelectors(ctx, db, req)
+		return err
+	}); err != nil {
+		return nil, err
 	}
-	return getNodeSelectors(ctx, ds.db, req)
+	return resp, nil
 }
 
 // CreateRegistrationEntry stores the given registration entry
@@ -306,6 +359,11 @@ func (ds *Plugin) CreateRegistrationEntry(ctx context.Context,
 	if err = validateRegistrationEntry(req.Entry); err != nil {
 		return nil, err
 	}
+	if req.Entry.EntryId != "" {
+		if err = util.ValidateRegistrationEntryID(req.Entry.EntryId); err != nil {
+			return nil, sqlError.New("invalid registration entry: %v", err)
+		}
+	}
 
 	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
 		resp, err = createRegistrationEntry(tx, req)
@@ -319,16 +377,25 @@ func (ds *Plugin) CreateRegistrationEntry(ctx context.Context,
 // FetchRegistrationEntry fetches an existing registration by entry ID
 func (ds *Plugin) FetchRegistrationEntry(ctx context.Context,
 	req *datastore.FetchRegistrationEntryRequest) (resp *datastore.FetchRegistrationEntryResponse, err error) {
+	ctx, cancel := ds.withQueryTimeout(ctx)
+	defer cancel()
+
 	return fetchRegistrationEntry(ctx, ds.db, req)
 }
 
 // ListRegistrationEntries lists all registrations (pagination available)
 func (ds *Plugin) ListRegistrationEntries(ctx context.Context,
 	req *datastore.ListRegistrationEntriesRequest) (resp *datastore.ListRegistrationEntriesResponse, err error) {
-	if req.TolerateStale && ds.roDb != nil {
-		return listRegistrationEntries(ctx, ds.roDb, req)
+	ctx, cancel := ds.withQueryTimeout(ctx)
+	defer cancel()
+
+	if err = ds.withStaleRead(req.TolerateStale, func(db *sqlDB) (err error) {
+		resp, err = listRegistrationEntries(ctx, db, req)
+		return err
+	}); err != nil {
+		return nil, err
 	}
-	return listRegistrationEntries(ctx, ds.db, req)
+	return resp, nil
 }
 
 // UpdateRegistrationEntry updates an existing registration entry
@@ -410,6 +477,18 @@ func (ds *Plugin) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoin
 	return resp, nil
 }
 
+// ConsumeJoinToken records a use of the given join token, deleting it once it
+// has been used as many times as allowed
+func (ds *Plugin) ConsumeJoinToken(ctx context.Context, req *datastore.ConsumeJoinTokenRequest) (resp *datastore.ConsumeJoinTokenResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = consumeJoinToken(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // PruneJoinTokens takes a Token message, and deletes all tokens which have expired
 // before the date in the message
 func (ds *Plugin) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (resp *datastore.PruneJoinTokensResponse, err error) {
@@ -422,6 +501,45 @@ func (ds *Plugin) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinT
 	return resp, nil
 }
 
+// CreateIssuedX509SVID records an issued X509-SVID
+func (ds *Plugin) CreateIssuedX509SVID(ctx context.Context, req *datastore.CreateIssuedX509SVIDRequest) (resp *datastore.CreateIssuedX509SVIDResponse, err error) {
+	if req.Svid == nil || req.Svid.SpiffeId == "" || req.Svid.SerialNumber == "" || req.Svid.ExpiresAt == 0 {
+		return nil, sqlError.New("invalid request: SPIFFE ID, serial number and expiry are required")
+	}
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = createIssuedX509SVID(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListIssuedX509SVIDs lists the records of issued X509-SVIDs (pagination
+// available)
+func (ds *Plugin) ListIssuedX509SVIDs(ctx context.Context, req *datastore.ListIssuedX509SVIDsRequest) (resp *datastore.ListIssuedX509SVIDsResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listIssuedX509SVIDs(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneIssuedX509SVIDs deletes the records of all issued X509-SVIDs that
+// expired before the specified time
+func (ds *Plugin) PruneIssuedX509SVIDs(ctx context.Context, req *datastore.PruneIssuedX509SVIDsRequest) (resp *datastore.PruneIssuedX509SVIDsResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = pruneIssuedX509SVIDs(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // Configure parses HCL config payload into config struct, and opens new DB based on the result
 func (ds *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	config := &configuration{}
@@ -433,9 +551,20 @@ func (ds *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*sp
 		return nil, err
 	}
 
+	var queryTimeout time.Duration
+	if config.QueryTimeout != nil {
+		var err error
+		queryTimeout, err = time.ParseDuration(*config.QueryTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse query_timeout %q: %v", *config.QueryTimeout, err)
+		}
+	}
+
 	ds.mu.Lock()
 	defer ds.mu.Unlock()
 
+	ds.queryTimeout = queryTimeout
+
 	if err := ds.openConnection(config, false); err != nil {
 		return nil, err
 	}
@@ -515,6 +644,14 @@ func (*Plugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.G
 	return &pluginInfo, nil
 }
 
+// GetInterfaceVersion returns the version of the DataStore service
+// implemented by the plugin
+func (*Plugin) GetInterfaceVersion(context.Context, *datastore.GetInterfaceVersionRequest) (*datastore.GetInterfaceVersionResponse, error) {
+	return &datastore.GetInterfaceVersionResponse{
+		Version: datastore.InterfaceVersion,
+	}, nil
+}
+
 func (ds *Plugin) withWriteRepeatableReadTx(ctx context.Context, op func(tx *gorm.DB) error) error {
 	return ds.withTx(ctx, op, false, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
 }
@@ -527,11 +664,59 @@ func (ds *Plugin) withReadTx(ctx context.Context, op func(tx *gorm.DB) error) er
 	return ds.withTx(ctx, op, true, nil)
 }
 
+// withStaleReadTx runs op in a read transaction. If stale data is tolerated,
+// the read-only database is used when configured (see withStaleRead).
+func (ds *Plugin) withStaleReadTx(ctx context.Context, tolerateStale bool, op func(tx *gorm.DB) error) error {
+	return ds.withStaleRead(tolerateStale, func(db *sqlDB) error {
+		return ds.withDBTx(ctx, db, op, true, nil)
+	})
+}
+
+// withStaleRead invokes op with the read-only database when stale data is
+// tolerated and a read-only connection is configured. Otherwise, or if the
+// read-only database fails in an unexpected way (e.g. the replica is down), op
+// is invoked with the primary database.
+func (ds *Plugin) withStaleRead(tolerateStale bool, op func(db *sqlDB) error) error {
+	ds.mu.Lock()
+	db, roDb := ds.db, ds.roDb
+	ds.mu.Unlock()
+
+	if tolerateStale && roDb != nil {
+		err := op(roDb)
+		if status.Code(err) != codes.Unknown {
+			return err
+		}
+		ds.log.Warn("Read-only database operation failed; falling back to primary database", telemetry.Error, err)
+	}
+	return op(db)
+}
+
+// withQueryTimeout returns a context that is canceled after the configured
+// query timeout. If no timeout is configured, the context is only canceled by
+// the returned cancel function.
+func (ds *Plugin) withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	ds.mu.Lock()
+	queryTimeout := ds.queryTimeout
+	ds.mu.Unlock()
+
+	if queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, queryTimeout)
+}
+
 func (ds *Plugin) withTx(ctx context.Context, op func(tx *gorm.DB) error, readOnly bool, opts *sql.TxOptions) error {
 	ds.mu.Lock()
 	db := ds.db
 	ds.mu.Unlock()
 
+	return ds.withDBTx(ctx, db, op, readOnly, opts)
+}
+
+func (ds *Plugin) withDBTx(ctx context.Context, db *sqlDB, op func(tx *gorm.DB) error, readOnly bool, opts *sql.TxOptions) error {
+	ctx, cancel := ds.withQueryTimeout(ctx)
+	defer cancel()
+
 	if db.databaseType == SQLite && !readOnly {
 		// sqlite3 can only have one writer at a time. since we're in WAL mode,
 		// there can be concurrent reads and writes, so no lock is necessary
@@ -582,12 +767,28 @@ func (ds *Plugin) gormToGRPCStatus(err error) error {
 }
 
 func (ds *Plugin) openDB(cfg *configuration, isReadOnly bool) (*gorm.DB, string, bool, dialect, error) {
+	db, version, supportsCTE, dialect, err := connectDB(cfg, isReadOnly, ds.log)
+	if err != nil {
+		return nil, "", false, nil, err
+	}
+
+	if err := migrateDB(db, cfg.DatabaseType, cfg.DisableMigration, cfg.RequireManualMigration, ds.log); err != nil {
+		db.Close()
+		return nil, "", false, nil, err
+	}
+
+	return db, version, supportsCTE, dialect, nil
+}
+
+// connectDB opens the database and configures the connection pool. The schema
+// is not migrated.
+func connectDB(cfg *configuration, isReadOnly bool, log hclog.Logger) (*gorm.DB, string, bool, dialect, error) {
 	var dialect dialect
 
-	ds.log.Info("Opening SQL database", telemetry.DatabaseType, cfg.DatabaseType)
+	log.Info("Opening SQL database", telemetry.DatabaseType, cfg.DatabaseType)
 	switch cfg.DatabaseType {
 	case SQLite:
-		dialect = sqliteDB{log: ds.log}
+		dialect = sqliteDB{log: log}
 	case PostgreSQL:
 		dialect = postgresDB{}
 	case MySQL:
@@ -601,30 +802,28 @@ func (ds *Plugin) openDB(cfg *configuration, isReadOnly bool) (*gorm.DB, string,
 		return nil, "", false, nil, err
 	}
 
-	gormLogger := ds.log.Named("gorm")
+	gormLogger := log.Named("gorm")
 	gormLogger.SetLevel(hclog.Debug)
 	db.SetLogger(gormLogger.StandardLogger(&hclog.StandardLoggerOptions{
 		InferLevels: true,
 	}))
+	maxOpenConns := defaultMaxOpenConns
 	if cfg.MaxOpenConns != nil {
-		db.DB().SetMaxOpenConns(*cfg.MaxOpenConns)
+		maxOpenConns = *cfg.MaxOpenConns
 	}
+	db.DB().SetMaxOpenConns(maxOpenConns)
 	if cfg.MaxIdleConns != nil {
 		db.DB().SetMaxIdleConns(*cfg.MaxIdleConns)
 	}
 	if cfg.ConnMaxLifetime != nil {
 		connMaxLifetime, err := time.ParseDuration(*cfg.ConnMaxLifetime)
 		if err != nil {
+			db.Close()
 			return nil, "", false, nil, fmt.Errorf("failed to parse conn_max_lifetime %q: %v", *cfg.ConnMaxLifetime, err)
 		}
 		db.DB().SetConnMaxLifetime(connMaxLifetime)
 	}
 
-	if err := migrateDB(db, cfg.DatabaseType, cfg.DisableMigration, ds.log); err != nil {
-		db.Close()
-		return nil, "", false, nil, err
-	}
-
 	return db, version, supportsCTE, dialect, nil
 }
 
@@ -683,6 +882,10 @@ func applyBundleMask(model *Bundle, newBundle *common.Bundle, inputMask *common.
 		bundle.RefreshHint = newBundle.RefreshHint
 	}
 
+	if inputMask.SequenceNumber {
+		bundle.SequenceNumber = newBundle.SequenceNumber
+	}
+
 	if inputMask.RootCas {
 		bundle.RootCas = newBundle.RootCas
 	}
@@ -937,6 +1140,7 @@ func createAttestedNode(tx *gorm.DB, req *datastore.CreateAttestedNodeRequest) (
 		ExpiresAt:       time.Unix(req.Node.CertNotAfter, 0),
 		NewSerialNumber: req.Node.NewCertSerialNumber,
 		NewExpiresAt:    nullableUnixTimeToDBTime(req.Node.NewCertNotAfter),
+		ForceRotation:   req.Node.ForceRotation,
 	}
 
 	if err := tx.Create(&model).Error; err != nil {
@@ -1133,6 +1337,10 @@ func buildListAttestedNodesQueryCTE(req *datastore.ListAttestedNodesRequest, dbT
 		builder.WriteString("\t\tAND expires_at < ?\n")
 		args = append(args, time.Unix(req.ByExpiresBefore.Value, 0))
 	}
+	if req.ByExpiresAfter != nil {
+		builder.WriteString("\t\tAND expires_at > ?\n")
+		args = append(args, time.Unix(req.ByExpiresAfter.Value, 0))
+	}
 
 	// Filter by Attestation type
 	if req.ByAttestationType != "" {
@@ -1178,7 +1386,8 @@ SELECT
 	serial_number,
 	expires_at,
 	new_serial_number,
-	new_expires_at,`)
+	new_expires_at,
+	force_rotation,`)
 
 	// Add "optional" fields for selectors
 	if fetchSelectors {
@@ -1302,7 +1511,8 @@ SELECT
 	N.serial_number,
 	N.expires_at,
 	N.new_serial_number,
-	N.new_expires_at,`)
+	N.new_expires_at,
+	N.force_rotation,`)
 
 	// Add "optional" fields for selectors
 	if fetchSelectors {
@@ -1341,6 +1551,10 @@ FROM attested_node_entries N
 			builder.WriteString(" AND N.expires_at < ?")
 			args = append(args, time.Unix(req.ByExpiresBefore.Value, 0))
 		}
+		if req.ByExpiresAfter != nil {
+			builder.WriteString(" AND N.expires_at > ?")
+			args = append(args, time.Unix(req.ByExpiresAfter.Value, 0))
+		}
 
 		// Filter by Attestation type
 		if req.ByAttestationType != "" {
@@ -1445,6 +1659,7 @@ func updateAttestedNode(tx *gorm.DB, req *datastore.UpdateAttestedNodeRequest) (
 	model.ExpiresAt = time.Unix(req.CertNotAfter, 0)
 	model.NewSerialNumber = req.NewCertSerialNumber
 	model.NewExpiresAt = nullableUnixTimeToDBTime(req.NewCertNotAfter)
+	model.ForceRotation = req.ForceRotation
 
 	if err := tx.Save(&model).Error; err != nil {
 		return nil, sqlError.Wrap(err)
@@ -1455,6 +1670,21 @@ func updateAttestedNode(tx *gorm.DB, req *datastore.UpdateAttestedNodeRequest) (
 	}, nil
 }
 
+func forceAttestedNodeRotation(tx *gorm.DB, req *datastore.ForceAttestedNodeRotationRequest) (*datastore.ForceAttestedNodeRotationResponse, error) {
+	if err := tx.Model(&AttestedNode{}).Where("spiffe_id = ?", req.SpiffeId).Update("force_rotation", true).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	var model AttestedNode
+	if err := tx.Find(&model, "spiffe_id = ?", req.SpiffeId).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.ForceAttestedNodeRotationResponse{
+		Node: modelToAttestedNode(model),
+	}, nil
+}
+
 func deleteAttestedNode(tx *gorm.DB, req *datastore.DeleteAttestedNodeRequest) (*datastore.DeleteAttestedNodeResponse, error) {
 	var model AttestedNode
 	if err := tx.Find(&model, "spiffe_id = ?", req.SpiffeId).Error; err != nil {
@@ -1470,6 +1700,40 @@ func deleteAttestedNode(tx *gorm.DB, req *datastore.DeleteAttestedNodeRequest) (
 	}, nil
 }
 
+func pruneAttestedNodes(tx *gorm.DB, req *datastore.PruneAttestedNodesRequest) (*datastore.PruneAttestedNodesResponse, error) {
+	expiresBefore := time.Unix(req.ExpiresBefore, 0)
+
+	// Nodes holding a newer SVID that has not expired yet are still alive
+	var spiffeIDs []string
+	if err := tx.Model(&AttestedNode{}).
+		Where("expires_at < ?", expiresBefore).
+		Where("serial_number <> ''").
+		Where("new_expires_at IS NULL OR new_expires_at < ?", expiresBefore).
+		Pluck("spiffe_id", &spiffeIDs).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	if len(spiffeIDs) == 0 {
+		return &datastore.PruneAttestedNodesResponse{}, nil
+	}
+
+	// Delete by primary key to avoid gap locks (see setNodeSelectors)
+	var selectorIDs []int64
+	if err := tx.Model(&NodeSelector{}).Where("spiffe_id IN (?)", spiffeIDs).Pluck("id", &selectorIDs).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	if len(selectorIDs) > 0 {
+		if err := tx.Where("id IN (?)", selectorIDs).Delete(&NodeSelector{}).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	}
+
+	if err := tx.Where("spiffe_id IN (?)", spiffeIDs).Delete(&AttestedNode{}).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.PruneAttestedNodesResponse{}, nil
+}
+
 func setNodeSelectors(tx *gorm.DB, req *datastore.SetNodeSelectorsRequest) (*datastore.SetNodeSelectorsResponse, error) {
 	// Previously the deletion of the previous set of node selectors was
 	// implemented via query like DELETE FROM node_resolver_map_entries WHERE
@@ -1536,9 +1800,23 @@ func getNodeSelectors(ctx context.Context, db *sqlDB, req *datastore.GetNodeSele
 }
 
 func createRegistrationEntry(tx *gorm.DB, req *datastore.CreateRegistrationEntryRequest) (*datastore.CreateRegistrationEntryResponse, error) {
-	entryID, err := newRegistrationEntryID()
-	if err != nil {
-		return nil, err
+	entryID := req.Entry.EntryId
+	if entryID == "" {
+		var err error
+		entryID, err = newRegistrationEntryID()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// the unique index on the entry ID guarantees uniqueness, this only
+		// makes for a friendlier error than a constraint violation
+		var count int
+		if err := tx.Model(&RegisteredEntry{}).Where("entry_id = ?", entryID).Count(&count).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if count > 0 {
+			return nil, status.Errorf(codes.AlreadyExists, "registration entry ID %q is already in use", entryID)
+		}
 	}
 
 	newRegisteredEntry := RegisteredEntry{
@@ -1546,9 +1824,11 @@ func createRegistrationEntry(tx *gorm.DB, req *datastore.CreateRegistrationEntry
 		SpiffeID:   req.Entry.SpiffeId,
 		ParentID:   req.Entry.ParentId,
 		TTL:        req.Entry.Ttl,
+		JWTSvidTTL: req.Entry.JwtSvidTtl,
 		Admin:      req.Entry.Admin,
 		Downstream: req.Entry.Downstream,
 		Expiry:     req.Entry.EntryExpiry,
+		Hint:       req.Entry.Hint,
 	}
 
 	if err := tx.Create(&newRegisteredEntry).Error; err != nil {
@@ -1663,9 +1943,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -1679,7 +1962,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -1692,7 +1975,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1700,7 +1983,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1721,9 +2004,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -1737,7 +2023,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -1750,7 +2036,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1758,7 +2044,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1776,9 +2062,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -1812,9 +2101,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -1828,7 +2120,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -1841,7 +2133,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -1849,7 +2141,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2029,9 +2321,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2048,7 +2343,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2063,7 +2358,7 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 `)
@@ -2074,7 +2369,7 @@ FROM
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -2106,9 +2401,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2125,7 +2423,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2140,7 +2438,7 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 `)
@@ -2151,7 +2449,7 @@ FROM
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -2187,9 +2485,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -2240,9 +2541,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2259,7 +2563,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2274,7 +2578,7 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 `)
@@ -2285,7 +2589,7 @@ FROM
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 `)
@@ -2522,6 +2826,7 @@ type nodeRow struct {
 	ExpiresAt       sql.NullTime
 	NewSerialNumber sql.NullString
 	NewExpiresAt    sql.NullTime
+	ForceRotation   sql.NullBool
 	SelectorType    sql.NullString
 	SelectorValue   sql.NullString
 }
@@ -2535,6 +2840,7 @@ func scanNodeRow(rs *sql.Rows, r *nodeRow) error {
 		&r.ExpiresAt,
 		&r.NewSerialNumber,
 		&r.NewExpiresAt,
+		&r.ForceRotation,
 		&r.SelectorType,
 		&r.SelectorValue,
 	))
@@ -2565,6 +2871,10 @@ func fillNodeFromRow(node *common.AttestedNode, r *nodeRow) error {
 		node.NewCertSerialNumber = r.NewSerialNumber.String
 	}
 
+	if r.ForceRotation.Valid {
+		node.ForceRotation = r.ForceRotation.Bool
+	}
+
 	if r.SelectorType.Valid {
 		if !r.SelectorValue.Valid {
 			return sqlError.New("expected non-nil selector.value value for attested node %s", node.SpiffeId)
@@ -2584,9 +2894,12 @@ type entryRow struct {
 	SpiffeID      sql.NullString
 	ParentID      sql.NullString
 	RegTTL        sql.NullInt64
+	RegJWTSvidTTL sql.NullInt64
 	Admin         sql.NullBool
 	Downstream    sql.NullBool
 	Expiry        sql.NullInt64
+	RegHint       sql.NullString
+	RevisionNum   sql.NullInt64
 	SelectorID    sql.NullInt64
 	SelectorType  sql.NullString
 	SelectorValue sql.NullString
@@ -2602,9 +2915,12 @@ func scanEntryRow(rs *sql.Rows, r *entryRow) error {
 		&r.SpiffeID,
 		&r.ParentID,
 		&r.RegTTL,
+		&r.RegJWTSvidTTL,
 		&r.Admin,
 		&r.Downstream,
 		&r.Expiry,
+		&r.RegHint,
+		&r.RevisionNum,
 		&r.SelectorID,
 		&r.SelectorType,
 		&r.SelectorValue,
@@ -2627,6 +2943,9 @@ func fillEntryFromRow(entry *common.RegistrationEntry, r *entryRow) error {
 	if r.RegTTL.Valid {
 		entry.Ttl = int32(r.RegTTL.Int64)
 	}
+	if r.RegJWTSvidTTL.Valid {
+		entry.JwtSvidTtl = int32(r.RegJWTSvidTTL.Int64)
+	}
 	if r.Admin.Valid {
 		entry.Admin = r.Admin.Bool
 	}
@@ -2636,6 +2955,12 @@ func fillEntryFromRow(entry *common.RegistrationEntry, r *entryRow) error {
 	if r.Expiry.Valid {
 		entry.EntryExpiry = r.Expiry.Int64
 	}
+	if r.RegHint.Valid {
+		entry.Hint = r.RegHint.String
+	}
+	if r.RevisionNum.Valid {
+		entry.RevisionNumber = r.RevisionNum.Int64
+	}
 
 	if r.SelectorType.Valid {
 		if !r.SelectorValue.Valid {
@@ -2682,6 +3007,11 @@ func updateRegistrationEntry(tx *gorm.DB,
 		return nil, sqlError.Wrap(err)
 	}
 
+	revisionNumber, err := incrementRevisionNumber(tx, entry, req.RevisionNumber)
+	if err != nil {
+		return nil, err
+	}
+
 	// Delete existing selectors - we will write new ones
 	if err := tx.Exec("DELETE FROM selectors WHERE registered_entry_id = ?", entry.ID).Error; err != nil {
 		return nil, sqlError.Wrap(err)
@@ -2714,11 +3044,14 @@ func updateRegistrationEntry(tx *gorm.DB,
 	entry.SpiffeID = req.Entry.SpiffeId
 	entry.ParentID = req.Entry.ParentId
 	entry.TTL = req.Entry.Ttl
+	entry.JWTSvidTTL = req.Entry.JwtSvidTtl
 	entry.Selectors = selectors
 	entry.Admin = req.Entry.Admin
 	entry.Downstream = req.Entry.Downstream
 	entry.Expiry = req.Entry.EntryExpiry
+	entry.Hint = req.Entry.Hint
 	entry.DNSList = dnsList
+	entry.RevisionNumber = revisionNumber
 	if err := tx.Save(&entry).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
@@ -2733,6 +3066,7 @@ func updateRegistrationEntry(tx *gorm.DB,
 	}
 
 	req.Entry.EntryId = entry.EntryID
+	req.Entry.RevisionNumber = entry.RevisionNumber
 	return &datastore.UpdateRegistrationEntryResponse{
 		Entry: req.Entry,
 	}, nil
@@ -2744,6 +3078,14 @@ func deleteRegistrationEntry(tx *gorm.DB, req *datastore.DeleteRegistrationEntry
 		return nil, sqlError.Wrap(err)
 	}
 
+	if req.RevisionNumber != nil {
+		// incrementing the revision number of an entry about to be deleted
+		// is only a way to check it and lock the row in a single statement
+		if _, err := incrementRevisionNumber(tx, entry, req.RevisionNumber); err != nil {
+			return nil, err
+		}
+	}
+
 	respEntry, err := modelToEntry(tx, entry)
 	if err != nil {
 		return nil, err
@@ -2759,6 +3101,35 @@ func deleteRegistrationEntry(tx *gorm.DB, req *datastore.DeleteRegistrationEntry
 	}, nil
 }
 
+// incrementRevisionNumber increments the revision number of the entry and
+// returns the new revision number. If expected is set, the entry must be at
+// the expected revision number, which is checked and incremented in a single
+// statement so that concurrent updates of the entry cannot both succeed.
+func incrementRevisionNumber(tx *gorm.DB, entry RegisteredEntry, expected *wrappers.Int64Value) (int64, error) {
+	if expected == nil {
+		if err := tx.Model(&RegisteredEntry{}).Where("id = ?", entry.ID).
+			UpdateColumn("revision_number", gorm.Expr("revision_number + 1")).Error; err != nil {
+			return 0, sqlError.Wrap(err)
+		}
+		// the entry may have been updated since it was read
+		updated := RegisteredEntry{}
+		if err := tx.Select("revision_number").Find(&updated, "id = ?", entry.ID).Error; err != nil {
+			return 0, sqlError.Wrap(err)
+		}
+		return updated.RevisionNumber, nil
+	}
+
+	result := tx.Model(&RegisteredEntry{}).Where("id = ? AND revision_number = ?", entry.ID, expected.Value).
+		UpdateColumn("revision_number", expected.Value+1)
+	if result.Error != nil {
+		return 0, sqlError.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return 0, status.Errorf(codes.Aborted, "registration entry %q is not at revision %d", entry.EntryID, expected.Value)
+	}
+	return expected.Value + 1, nil
+}
+
 func deleteRegistrationEntrySupport(tx *gorm.DB, entry RegisteredEntry) error {
 	if err := tx.Model(&entry).Association("FederatesWith").Clear().Error; err != nil {
 		return err
@@ -2793,8 +3164,9 @@ func pruneRegistrationEntries(tx *gorm.DB, req *datastore.PruneRegistrationEntri
 
 func createJoinToken(tx *gorm.DB, req *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
 	t := JoinToken{
-		Token:  req.JoinToken.Token,
-		Expiry: req.JoinToken.Expiry,
+		Token:   req.JoinToken.Token,
+		Expiry:  req.JoinToken.Expiry,
+		MaxUses: req.JoinToken.MaxUses,
 	}
 
 	if err := tx.Create(&t).Error; err != nil {
@@ -2835,6 +3207,43 @@ func deleteJoinToken(tx *gorm.DB, req *datastore.DeleteJoinTokenRequest) (*datas
 	}, nil
 }
 
+func consumeJoinToken(tx *gorm.DB, req *datastore.ConsumeJoinTokenRequest) (*datastore.ConsumeJoinTokenResponse, error) {
+	var model JoinToken
+	err := tx.Find(&model, "token = ?", req.Token).Error
+	if err == gorm.ErrRecordNotFound {
+		return &datastore.ConsumeJoinTokenResponse{}, nil
+	} else if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	maxUses := model.MaxUses
+	if maxUses < 1 {
+		maxUses = 1
+	}
+
+	// The use is only recorded if no other transaction recorded one since
+	// the token was read, so that two agents never get the same use count.
+	// The token is deleted on its last use.
+	var result *gorm.DB
+	scope := tx.Where("id = ? AND use_count = ?", model.ID, model.UseCount)
+	if model.UseCount+1 >= maxUses {
+		result = scope.Delete(&JoinToken{})
+	} else {
+		result = scope.Model(&JoinToken{}).Update("use_count", model.UseCount+1)
+	}
+	if result.Error != nil {
+		return nil, sqlError.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, sqlError.New("join token was used concurrently")
+	}
+	model.UseCount++
+
+	return &datastore.ConsumeJoinTokenResponse{
+		JoinToken: modelToJoinToken(model),
+	}, nil
+}
+
 func pruneJoinTokens(tx *gorm.DB, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
 	if err := tx.Where("expiry < ?", req.ExpiresBefore).Delete(&JoinToken{}).Error; err != nil {
 		return nil, sqlError.Wrap(err)
@@ -2843,6 +3252,77 @@ func pruneJoinTokens(tx *gorm.DB, req *datastore.PruneJoinTokensRequest) (*datas
 	return &datastore.PruneJoinTokensResponse{}, nil
 }
 
+func createIssuedX509SVID(tx *gorm.DB, req *datastore.CreateIssuedX509SVIDRequest) (*datastore.CreateIssuedX509SVIDResponse, error) {
+	model := IssuedX509SVID{
+		SpiffeID:     req.Svid.SpiffeId,
+		SerialNumber: req.Svid.SerialNumber,
+		IssuedAt:     time.Unix(req.Svid.IssuedAt, 0),
+		ExpiresAt:    time.Unix(req.Svid.ExpiresAt, 0),
+		CAKeyID:      req.Svid.CaKeyId,
+		AgentID:      req.Svid.AgentId,
+		EntryID:      req.Svid.EntryId,
+	}
+
+	if err := tx.Create(&model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.CreateIssuedX509SVIDResponse{
+		Svid: modelToIssuedX509SVID(model),
+	}, nil
+}
+
+func listIssuedX509SVIDs(tx *gorm.DB, req *datastore.ListIssuedX509SVIDsRequest) (*datastore.ListIssuedX509SVIDsResponse, error) {
+	p := req.Pagination
+	var err error
+	if p != nil {
+		tx, err = applyPagination(p, tx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		tx = tx.Order("id asc")
+	}
+
+	if req.BySpiffeId != nil {
+		tx = tx.Where("spiffe_id = ?", req.BySpiffeId.Value)
+	}
+	if req.ByAgentId != nil {
+		tx = tx.Where("agent_id = ?", req.ByAgentId.Value)
+	}
+	if req.ByExpiresAfter != nil {
+		tx = tx.Where("expires_at > ?", time.Unix(req.ByExpiresAfter.Value, 0))
+	}
+
+	var models []IssuedX509SVID
+	if err := tx.Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if p != nil {
+		p.Token = ""
+		if len(models) > 0 {
+			p.Token = fmt.Sprint(models[len(models)-1].ID)
+		}
+	}
+
+	resp := &datastore.ListIssuedX509SVIDsResponse{
+		Pagination: p,
+	}
+	for _, model := range models {
+		resp.Svids = append(resp.Svids, modelToIssuedX509SVID(model))
+	}
+	return resp, nil
+}
+
+func pruneIssuedX509SVIDs(tx *gorm.DB, req *datastore.PruneIssuedX509SVIDsRequest) (*datastore.PruneIssuedX509SVIDsResponse, error) {
+	if err := tx.Where("expires_at < ?", time.Unix(req.ExpiresBefore, 0)).Delete(&IssuedX509SVID{}).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.PruneIssuedX509SVIDsResponse{}, nil
+}
+
 // modelToBundle converts the given bundle model to a Protobuf bundle message. It will also
 // include any embedded CACert models.
 func modelToBundle(model *Bundle) (*common.Bundle, error) {
@@ -2871,6 +3351,14 @@ func validateRegistrationEntry(entry *common.RegistrationEntry) error {
 		return sqlError.New("invalid registration entry: TTL is not set")
 	}
 
+	if entry.JwtSvidTtl < 0 {
+		return sqlError.New("invalid registration entry: negative JWT-SVID TTL")
+	}
+
+	if len(entry.Hint) > maxHintLength {
+		return sqlError.New("invalid registration entry: hint is longer than %d bytes", maxHintLength)
+	}
+
 	return nil
 }
 
@@ -2934,16 +3422,19 @@ func modelToEntry(tx *gorm.DB, model RegisteredEntry) (*common.RegistrationEntry
 	}
 
 	return &common.RegistrationEntry{
-		EntryId:       model.EntryID,
-		Selectors:     selectors,
-		SpiffeId:      model.SpiffeID,
-		ParentId:      model.ParentID,
-		Ttl:           model.TTL,
-		FederatesWith: federatesWith,
-		Admin:         model.Admin,
-		Downstream:    model.Downstream,
-		EntryExpiry:   model.Expiry,
-		DnsNames:      dnsList,
+		EntryId:        model.EntryID,
+		Selectors:      selectors,
+		SpiffeId:       model.SpiffeID,
+		ParentId:       model.ParentID,
+		Ttl:            model.TTL,
+		JwtSvidTtl:     model.JWTSvidTTL,
+		FederatesWith:  federatesWith,
+		Admin:          model.Admin,
+		Downstream:     model.Downstream,
+		EntryExpiry:    model.Expiry,
+		DnsNames:       dnsList,
+		Hint:           model.Hint,
+		RevisionNumber: model.RevisionNumber,
 	}, nil
 }
 
@@ -2963,13 +3454,28 @@ func modelToAttestedNode(model AttestedNode) *common.AttestedNode {
 		CertNotAfter:        model.ExpiresAt.Unix(),
 		NewCertSerialNumber: model.NewSerialNumber,
 		NewCertNotAfter:     nullableDBTimeToUnixTime(model.NewExpiresAt),
+		ForceRotation:       model.ForceRotation,
 	}
 }
 
 func modelToJoinToken(model JoinToken) *datastore.JoinToken {
 	return &datastore.JoinToken{
-		Token:  model.Token,
-		Expiry: model.Expiry,
+		Token:    model.Token,
+		Expiry:   model.Expiry,
+		MaxUses:  model.MaxUses,
+		UseCount: model.UseCount,
+	}
+}
+
+func modelToIssuedX509SVID(model IssuedX509SVID) *datastore.IssuedX509SVID {
+	return &datastore.IssuedX509SVID{
+		SpiffeId:     model.SpiffeID,
+		SerialNumber: model.SerialNumber,
+		IssuedAt:     model.IssuedAt.Unix(),
+		ExpiresAt:    model.ExpiresAt.Unix(),
+		CaKeyId:      model.CAKeyID,
+		AgentId:      model.AgentID,
+		EntryId:      model.EntryID,
 	}
 }
 
@@ -3025,6 +3531,15 @@ func (cfg *configuration) Validate() error {
 		return errors.New("connection_string must be set")
 	}
 
+	if cfg.UseAWSIAMAuth {
+		if cfg.DatabaseType != MySQL && cfg.DatabaseType != PostgreSQL {
+			return errors.New("use_aws_iam_auth is only supported for mysql and postgres")
+		}
+		if cfg.AWSRegion == "" {
+			return errors.New("aws_region must be set when use_aws_iam_auth is enabled")
+		}
+	}
+
 	if cfg.DatabaseType == MySQL {
 		if err := validateMySQLConfig(cfg, false); err != nil {
 			return err
@@ -7,7 +7,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -16,6 +18,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
+	hclog "github.com/hashicorp/go-hclog"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
@@ -216,6 +219,60 @@ func (s *PluginSuite) TestInvalidMySQLConfiguration() {
 	s.RequireErrorContains(error, "rpc error: code = Unknown desc = connection_string must be set")
 }
 
+func (s *PluginSuite) TestStaleReadsFallBackToPrimary() {
+	if TestDialect != "" {
+		s.T().Skip("read-only connection is only simulated with sqlite3")
+	}
+
+	s.createBundle("spiffe://foo")
+	s.setNodeSelectors("spiffe://foo/node", []*common.Selector{{Type: "TYPE", Value: "VALUE"}})
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://foo/node",
+		SpiffeId:  "spiffe://foo/workload",
+		Selectors: []*common.Selector{{Type: "TYPE", Value: "VALUE"}},
+	})
+
+	// configure a read-only database that is down
+	roDB, err := openSQLite3(filepath.Join(s.dir, "replica.sqlite3"))
+	s.Require().NoError(err)
+	s.Require().NoError(roDB.Close())
+	s.sqlPlugin.roDb = &sqlDB{
+		DB:           roDB,
+		raw:          roDB.DB(),
+		databaseType: SQLite,
+		dialect:      sqliteDB{log: s.sqlPlugin.log},
+		stmtCache:    newStmtCache(roDB.DB()),
+	}
+
+	fetchResp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: "spiffe://foo",
+		TolerateStale: true,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(fetchResp.Bundle)
+
+	listResp, err := s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{
+		TolerateStale: true,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(listResp.Bundles, 1)
+
+	s.Require().Equal([]*common.Selector{{Type: "TYPE", Value: "VALUE"}}, s.getNodeSelectors("spiffe://foo/node", true))
+
+	entriesResp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		TolerateStale: true,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.RegistrationEntry{entry}, entriesResp.Entries)
+
+	// errors that are not caused by the database are not retried
+	_, err = s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{
+		Pagination:    &datastore.Pagination{},
+		TolerateStale: true,
+	})
+	s.RequireGRPCStatus(err, codes.InvalidArgument, "cannot paginate with pagesize = 0")
+}
+
 func (s *PluginSuite) TestBundleCRUD() {
 	bundle := bundleutil.BundleProtoFromRootCA("spiffe://foo", s.cert)
 
@@ -264,6 +321,7 @@ func (s *PluginSuite) TestBundleCRUD() {
 	bundle2 := bundleutil.BundleProtoFromRootCA(bundle.TrustDomainId, s.cacert)
 	appendedBundle := bundleutil.BundleProtoFromRootCAs(bundle.TrustDomainId,
 		[]*x509.Certificate{s.cert, s.cacert})
+	appendedBundle.SequenceNumber = 1
 
 	// append
 	aresp, err := s.ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
@@ -289,7 +347,7 @@ func (s *PluginSuite) TestBundleCRUD() {
 	s.Require().NoError(err)
 	s.AssertProtoEqual(bundle3, anresp.Bundle)
 
-	// update with mask: RootCas
+	// update with mask: RootCas (the sequence number set by the append is kept)
 	uresp, err := s.ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
 		Bundle: bundle,
 		InputMask: &common.BundleMask{
@@ -297,6 +355,18 @@ func (s *PluginSuite) TestBundleCRUD() {
 		},
 	})
 	s.Require().NoError(err)
+	bundle.SequenceNumber = 1
+	s.AssertProtoEqual(bundle, uresp.Bundle)
+
+	// update with mask: SequenceNumber
+	bundle.SequenceNumber = 42
+	uresp, err = s.ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
+		Bundle: bundle,
+		InputMask: &common.BundleMask{
+			SequenceNumber: true,
+		},
+	})
+	s.Require().NoError(err)
 	s.AssertProtoEqual(bundle, uresp.Bundle)
 
 	lresp, err = s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
@@ -573,6 +643,7 @@ func (s *PluginSuite) TestBundlePrune() {
 	// Fetch and verify pruned bundle is the expected
 	expectedPrunedBundle := bundleutil.BundleProtoFromRootCAs("spiffe://foo", []*x509.Certificate{s.cert})
 	expectedPrunedBundle.JwtSigningKeys = []*common.PublicKey{{NotAfter: nonExpiredKeyTime.Unix()}}
+	expectedPrunedBundle.SequenceNumber = 1
 	fresp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: "spiffe://foo"})
 	s.Require().NoError(err)
 	s.AssertProtoEqual(expectedPrunedBundle, fresp.Bundle)
@@ -845,6 +916,28 @@ func (s *PluginSuite) TestFetchAttestedNodesWithPagination() {
 			},
 			expectedList: []*common.AttestedNode{aNode1, aNode3, aNode4, aNode5},
 		},
+		{
+			name: "get nodes by expire after no pagination",
+			req: &datastore.ListAttestedNodesRequest{
+				ByExpiresAfter: &wrappers.Int64Value{
+					Value: time.Now().Unix(),
+				},
+			},
+			expectedList: []*common.AttestedNode{aNode2},
+		},
+		{
+			name: "get nodes by expire window",
+			req: &datastore.ListAttestedNodesRequest{
+				ByExpiresAfter: &wrappers.Int64Value{
+					Value: time.Now().Add(-2 * time.Hour).Unix(),
+				},
+				ByExpiresBefore: &wrappers.Int64Value{
+					Value: time.Now().Unix(),
+				},
+				ByBanned: &wrappers.BoolValue{Value: false},
+			},
+			expectedList: []*common.AttestedNode{aNode1, aNode3},
+		},
 		{
 			name: "get nodes by expire before get only page first page",
 			req: &datastore.ListAttestedNodesRequest{
@@ -1142,6 +1235,7 @@ func (s *PluginSuite) TestUpdateAttestedNode() {
 		CertNotAfter:        2,
 		NewCertSerialNumber: "new-cert-serial-number-2",
 		NewCertNotAfter:     2,
+		ForceRotation:       true,
 	}
 
 	updateReq := &datastore.UpdateAttestedNodeRequest{
@@ -1150,6 +1244,7 @@ func (s *PluginSuite) TestUpdateAttestedNode() {
 		CertNotAfter:        updatedNode.CertNotAfter,
 		NewCertSerialNumber: updatedNode.NewCertSerialNumber,
 		NewCertNotAfter:     updatedNode.NewCertNotAfter,
+		ForceRotation:       updatedNode.ForceRotation,
 	}
 
 	updatedNode2 := &common.AttestedNode{
@@ -1185,6 +1280,11 @@ func (s *PluginSuite) TestUpdateAttestedNode() {
 	s.Require().NoError(err)
 	s.RequireProtoEqual(updatedNode, fetchResp.Node)
 
+	// List the attested nodes and assert that the response has the update
+	listResp, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.AttestedNode{updatedNode}, listResp.Nodes)
+
 	// Update the attested node again and assert that the response has the update
 	updateResp2, err := s.ds.UpdateAttestedNode(ctx, updateReq2)
 	s.Require().NoError(err)
@@ -1196,6 +1296,50 @@ func (s *PluginSuite) TestUpdateAttestedNode() {
 	s.RequireProtoEqual(updatedNode2, fetchResp2.Node)
 }
 
+func (s *PluginSuite) TestForceAttestedNodeRotation() {
+	node := &common.AttestedNode{
+		SpiffeId:            "spiffe-id",
+		AttestationDataType: "attestation-data-type",
+		CertSerialNumber:    "cert-serial-number-1",
+		CertNotAfter:        1,
+		NewCertSerialNumber: "new-cert-serial-number-1",
+		NewCertNotAfter:     1,
+	}
+
+	// Force the rotation of an attested node that does not exist and assert
+	// that it fails
+	_, err := s.ds.ForceAttestedNodeRotation(ctx, &datastore.ForceAttestedNodeRotationRequest{SpiffeId: node.SpiffeId})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+
+	_, err = s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+	s.Require().NoError(err)
+
+	// Renew the node SVID, as the agent would, after the rotation was
+	// requested
+	renewed := &common.AttestedNode{
+		SpiffeId:            node.SpiffeId,
+		AttestationDataType: node.AttestationDataType,
+		CertSerialNumber:    node.NewCertSerialNumber,
+		CertNotAfter:        node.NewCertNotAfter,
+	}
+	_, err = s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:         renewed.SpiffeId,
+		CertSerialNumber: renewed.CertSerialNumber,
+		CertNotAfter:     renewed.CertNotAfter,
+	})
+	s.Require().NoError(err)
+
+	// Force the rotation and assert that only the flag is updated
+	renewed.ForceRotation = true
+	resp, err := s.ds.ForceAttestedNodeRotation(ctx, &datastore.ForceAttestedNodeRotationRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(renewed, resp.Node)
+
+	fetchResp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(renewed, fetchResp.Node)
+}
+
 func (s *PluginSuite) TestDeleteAttestedNode() {
 	entry := &common.AttestedNode{
 		SpiffeId:            "foo",
@@ -1220,6 +1364,54 @@ func (s *PluginSuite) TestDeleteAttestedNode() {
 	s.Nil(fresp.Node)
 }
 
+func (s *PluginSuite) TestPruneAttestedNodes() {
+	now := time.Now()
+	newNode := func(spiffeID, serialNumber string, notAfter time.Time) *common.AttestedNode {
+		node := &common.AttestedNode{
+			SpiffeId:            spiffeID,
+			AttestationDataType: "aws-tag",
+			CertSerialNumber:    serialNumber,
+			CertNotAfter:        notAfter.Unix(),
+		}
+		_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+		s.Require().NoError(err)
+		s.setNodeSelectors(spiffeID, []*common.Selector{{Type: "TYPE", Value: spiffeID}})
+		return node
+	}
+
+	expired := newNode("expired", "1", now.Add(-2*time.Hour))
+	banned := newNode("banned", "", now.Add(-2*time.Hour))
+	valid := newNode("valid", "3", now.Add(time.Hour))
+	renewed := newNode("renewed", "4", now.Add(-2*time.Hour))
+	_, err := s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:            renewed.SpiffeId,
+		CertSerialNumber:    renewed.CertSerialNumber,
+		CertNotAfter:        renewed.CertNotAfter,
+		NewCertSerialNumber: "5",
+		NewCertNotAfter:     now.Add(time.Hour).Unix(),
+	})
+	s.Require().NoError(err)
+
+	// nothing expired before the cutoff
+	_, err = s.ds.PruneAttestedNodes(ctx, &datastore.PruneAttestedNodesRequest{
+		ExpiresBefore: now.Add(-3 * time.Hour).Unix(),
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(s.fetchAttestedNode(expired.SpiffeId))
+
+	_, err = s.ds.PruneAttestedNodes(ctx, &datastore.PruneAttestedNodesRequest{
+		ExpiresBefore: now.Add(-time.Hour).Unix(),
+	})
+	s.Require().NoError(err)
+
+	s.Require().Nil(s.fetchAttestedNode(expired.SpiffeId))
+	s.Require().Empty(s.getNodeSelectors(expired.SpiffeId, false))
+	for _, spiffeID := range []string{banned.SpiffeId, valid.SpiffeId, renewed.SpiffeId} {
+		s.Require().NotNil(s.fetchAttestedNode(spiffeID), "node %q should not be pruned", spiffeID)
+		s.Require().NotEmpty(s.getNodeSelectors(spiffeID, false), "selectors of %q should not be pruned", spiffeID)
+	}
+}
+
 func (s *PluginSuite) TestNodeSelectors() {
 	foo1 := []*common.Selector{
 		{Type: "FOO1", Value: "1"},
@@ -1317,6 +1509,47 @@ func (s *PluginSuite) TestCreateRegistrationEntry() {
 	}
 }
 
+func (s *PluginSuite) TestCreateRegistrationEntryWithEntryID() {
+	entry := &common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/bar",
+		EntryId:   "payments-frontend",
+	}
+
+	resp, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{Entry: entry})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(entry, resp.Entry)
+
+	fetchResp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: "payments-frontend"})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(entry, fetchResp.Entry)
+
+	// the entry ID is unique, even across entries with different content
+	resp, err = s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			Selectors: []*common.Selector{{Type: "Type2", Value: "Value2"}},
+			SpiffeId:  "spiffe://example.org/baz",
+			ParentId:  "spiffe://example.org/bar",
+			EntryId:   "payments-frontend",
+		},
+	})
+	s.RequireGRPCStatus(err, codes.AlreadyExists, `registration entry ID "payments-frontend" is already in use`)
+	s.Require().Nil(resp)
+
+	// malformed entry IDs are rejected
+	resp, err = s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			Selectors: []*common.Selector{{Type: "Type2", Value: "Value2"}},
+			SpiffeId:  "spiffe://example.org/baz",
+			ParentId:  "spiffe://example.org/bar",
+			EntryId:   "payments/frontend",
+		},
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, `datastore-sql: invalid registration entry: entry ID "payments/frontend" contains characters other than letters, digits, dots, dashes, underscores and colons`)
+	s.Require().Nil(resp)
+}
+
 func (s *PluginSuite) TestCreateInvalidRegistrationEntry() {
 	var invalidRegistrationEntries []*common.RegistrationEntry
 	s.getTestDataFromJSONFile(filepath.Join("testdata", "invalid_registration_entries.json"), &invalidRegistrationEntries)
@@ -1344,6 +1577,7 @@ func (s *PluginSuite) TestFetchRegistrationEntry() {
 			"abcd.efg",
 			"somehost",
 		},
+		Hint: "internal",
 	}
 
 	createRegistrationEntryResponse, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{Entry: registeredEntry})
@@ -1757,6 +1991,8 @@ func (s *PluginSuite) TestUpdateRegistrationEntry() {
 	})
 
 	entry.Ttl = 2
+	entry.JwtSvidTtl = 3
+	entry.Hint = "external"
 	entry.Admin = true
 	entry.Downstream = true
 
@@ -1766,6 +2002,10 @@ func (s *PluginSuite) TestUpdateRegistrationEntry() {
 	s.Require().NoError(err)
 	s.Require().NotNil(updateRegistrationEntryResponse)
 
+	// updates increment the revision number
+	entry.RevisionNumber = 1
+	s.RequireProtoEqual(entry, updateRegistrationEntryResponse.Entry)
+
 	fetchRegistrationEntryResponse, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
 	s.Require().NoError(err)
 	s.Require().NotNil(fetchRegistrationEntryResponse)
@@ -1779,6 +2019,55 @@ func (s *PluginSuite) TestUpdateRegistrationEntry() {
 	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
 }
 
+func (s *PluginSuite) TestUpdateRegistrationEntryWithRevisionNumber() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/bar",
+		Ttl:       1,
+	})
+	s.Require().Equal(int64(0), entry.RevisionNumber)
+
+	entry.Ttl = 2
+	resp, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry:          entry,
+		RevisionNumber: &wrappers.Int64Value{Value: 0},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), resp.Entry.RevisionNumber)
+
+	// a concurrent update based on the same revision is aborted
+	entry.Ttl = 3
+	_, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry:          entry,
+		RevisionNumber: &wrappers.Int64Value{Value: 0},
+	})
+	s.RequireGRPCStatus(err, codes.Aborted, fmt.Sprintf("registration entry %q is not at revision 0", entry.EntryId))
+
+	fetchResp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(2), fetchResp.Entry.Ttl)
+	s.Require().Equal(int64(1), fetchResp.Entry.RevisionNumber)
+
+	// so is a deletion
+	_, err = s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId:        entry.EntryId,
+		RevisionNumber: &wrappers.Int64Value{Value: 0},
+	})
+	s.RequireGRPCStatus(err, codes.Aborted, fmt.Sprintf("registration entry %q is not at revision 0", entry.EntryId))
+
+	deleteResp, err := s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId:        entry.EntryId,
+		RevisionNumber: &wrappers.Int64Value{Value: 1},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), deleteResp.Entry.RevisionNumber)
+
+	fetchResp, err = s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.Require().NoError(err)
+	s.Require().Nil(fetchResp.Entry)
+}
+
 func (s *PluginSuite) TestDeleteRegistrationEntry() {
 	// delete non-existing
 	_, err := s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{EntryId: "badid"})
@@ -2138,6 +2427,71 @@ func (s *PluginSuite) TestDeleteJoinToken() {
 	s.AssertProtoEqual(joinToken2, resp.JoinToken)
 }
 
+func (s *PluginSuite) TestConsumeJoinToken() {
+	now := time.Now().Unix()
+
+	// Tokens without a maximum number of uses can only be used once
+	_, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{
+			Token:  "single",
+			Expiry: now,
+		},
+	})
+	s.Require().NoError(err)
+
+	resp, err := s.ds.ConsumeJoinToken(ctx, &datastore.ConsumeJoinTokenRequest{
+		Token: "single",
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(&datastore.JoinToken{
+		Token:    "single",
+		Expiry:   now,
+		UseCount: 1,
+	}, resp.JoinToken)
+
+	resp, err = s.ds.ConsumeJoinToken(ctx, &datastore.ConsumeJoinTokenRequest{
+		Token: "single",
+	})
+	s.Require().NoError(err)
+	s.Nil(resp.JoinToken)
+
+	// Reusable tokens are deleted once they reach the maximum number of uses
+	_, err = s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{
+			Token:   "reusable",
+			Expiry:  now,
+			MaxUses: 3,
+		},
+	})
+	s.Require().NoError(err)
+
+	for i := int32(1); i <= 3; i++ {
+		resp, err = s.ds.ConsumeJoinToken(ctx, &datastore.ConsumeJoinTokenRequest{
+			Token: "reusable",
+		})
+		s.Require().NoError(err)
+		s.AssertProtoEqual(&datastore.JoinToken{
+			Token:    "reusable",
+			Expiry:   now,
+			MaxUses:  3,
+			UseCount: i,
+		}, resp.JoinToken)
+	}
+
+	fetchResp, err := s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+		Token: "reusable",
+	})
+	s.Require().NoError(err)
+	s.Nil(fetchResp.JoinToken)
+
+	// Unknown tokens can't be consumed
+	resp, err = s.ds.ConsumeJoinToken(ctx, &datastore.ConsumeJoinTokenRequest{
+		Token: "unknown",
+	})
+	s.Require().NoError(err)
+	s.Nil(resp.JoinToken)
+}
+
 func (s *PluginSuite) TestPruneJoinTokens() {
 	now := time.Now().Unix()
 	joinToken := &datastore.JoinToken{
@@ -2188,12 +2542,219 @@ func (s *PluginSuite) TestPruneJoinTokens() {
 	s.Nil(resp.JoinToken)
 }
 
+func (s *PluginSuite) TestCreateIssuedX509SVID() {
+	now := time.Now().Unix()
+	svid := &datastore.IssuedX509SVID{
+		SpiffeId:     "spiffe://example.org/workload",
+		SerialNumber: "1234",
+		IssuedAt:     now,
+		ExpiresAt:    now + 3600,
+		CaKeyId:      "0a0b0c",
+		AgentId:      "spiffe://example.org/spire/agent/test",
+		EntryId:      "entry-1",
+	}
+
+	resp, err := s.ds.CreateIssuedX509SVID(ctx, &datastore.CreateIssuedX509SVIDRequest{
+		Svid: svid,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(svid, resp.Svid)
+
+	listResp, err := s.ds.ListIssuedX509SVIDs(ctx, &datastore.ListIssuedX509SVIDsRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.IssuedX509SVID{svid}, listResp.Svids)
+
+	// SPIFFE ID, serial number and expiry are required
+	for _, invalid := range []*datastore.IssuedX509SVID{
+		nil,
+		{SerialNumber: "1234", ExpiresAt: now},
+		{SpiffeId: "spiffe://example.org/workload", ExpiresAt: now},
+		{SpiffeId: "spiffe://example.org/workload", SerialNumber: "1234"},
+	} {
+		_, err := s.ds.CreateIssuedX509SVID(ctx, &datastore.CreateIssuedX509SVIDRequest{
+			Svid: invalid,
+		})
+		s.Require().EqualError(err, "rpc error: code = Unknown desc = datastore-sql: invalid request: SPIFFE ID, serial number and expiry are required")
+	}
+}
+
+func (s *PluginSuite) TestListIssuedX509SVIDs() {
+	now := time.Now().Unix()
+	makeSVID := func(spiffeID, serialNumber, agentID string, expiresAt int64) *datastore.IssuedX509SVID {
+		svid := &datastore.IssuedX509SVID{
+			SpiffeId:     spiffeID,
+			SerialNumber: serialNumber,
+			IssuedAt:     now - 60,
+			ExpiresAt:    expiresAt,
+			AgentId:      agentID,
+		}
+		_, err := s.ds.CreateIssuedX509SVID(ctx, &datastore.CreateIssuedX509SVIDRequest{
+			Svid: svid,
+		})
+		s.Require().NoError(err)
+		return svid
+	}
+
+	agentA := "spiffe://example.org/spire/agent/a"
+	agentB := "spiffe://example.org/spire/agent/b"
+	svid1 := makeSVID("spiffe://example.org/foo", "1", agentA, now-10)
+	svid2 := makeSVID("spiffe://example.org/foo", "2", agentA, now+3600)
+	svid3 := makeSVID("spiffe://example.org/bar", "3", agentB, now+3600)
+	svid4 := makeSVID(agentA, "4", "", now+3600)
+
+	tests := []struct {
+		name               string
+		req                *datastore.ListIssuedX509SVIDsRequest
+		expectedList       []*datastore.IssuedX509SVID
+		expectedPagination *datastore.Pagination
+		expectedErr        string
+	}{
+		{
+			name:         "all",
+			req:          &datastore.ListIssuedX509SVIDsRequest{},
+			expectedList: []*datastore.IssuedX509SVID{svid1, svid2, svid3, svid4},
+		},
+		{
+			name: "by SPIFFE ID",
+			req: &datastore.ListIssuedX509SVIDsRequest{
+				BySpiffeId: &wrappers.StringValue{Value: "spiffe://example.org/foo"},
+			},
+			expectedList: []*datastore.IssuedX509SVID{svid1, svid2},
+		},
+		{
+			name: "by agent ID",
+			req: &datastore.ListIssuedX509SVIDsRequest{
+				ByAgentId: &wrappers.StringValue{Value: agentB},
+			},
+			expectedList: []*datastore.IssuedX509SVID{svid3},
+		},
+		{
+			name: "by expires after",
+			req: &datastore.ListIssuedX509SVIDsRequest{
+				ByExpiresAfter: &wrappers.Int64Value{Value: now},
+			},
+			expectedList: []*datastore.IssuedX509SVID{svid2, svid3, svid4},
+		},
+		{
+			name: "by SPIFFE ID and expires after",
+			req: &datastore.ListIssuedX509SVIDsRequest{
+				BySpiffeId:     &wrappers.StringValue{Value: "spiffe://example.org/foo"},
+				ByExpiresAfter: &wrappers.Int64Value{Value: now},
+			},
+			expectedList: []*datastore.IssuedX509SVID{svid2},
+		},
+		{
+			name: "first page",
+			req: &datastore.ListIssuedX509SVIDsRequest{
+				Pagination: &datastore.Pagination{PageSize: 3},
+			},
+			expectedList:       []*datastore.IssuedX509SVID{svid1, svid2, svid3},
+			expectedPagination: &datastore.Pagination{Token: "3", PageSize: 3},
+		},
+		{
+			name: "last page",
+			req: &datastore.ListIssuedX509SVIDsRequest{
+				Pagination: &datastore.Pagination{Token: "3", PageSize: 3},
+			},
+			expectedList:       []*datastore.IssuedX509SVID{svid4},
+			expectedPagination: &datastore.Pagination{Token: "4", PageSize: 3},
+		},
+		{
+			name: "past the last page",
+			req: &datastore.ListIssuedX509SVIDsRequest{
+				Pagination: &datastore.Pagination{Token: "4", PageSize: 3},
+			},
+			expectedPagination: &datastore.Pagination{PageSize: 3},
+		},
+		{
+			name: "filtered page",
+			req: &datastore.ListIssuedX509SVIDsRequest{
+				ByAgentId:  &wrappers.StringValue{Value: agentA},
+				Pagination: &datastore.Pagination{Token: "1", PageSize: 3},
+			},
+			expectedList:       []*datastore.IssuedX509SVID{svid2},
+			expectedPagination: &datastore.Pagination{Token: "2", PageSize: 3},
+		},
+		{
+			name: "page size of zero",
+			req: &datastore.ListIssuedX509SVIDsRequest{
+				Pagination: &datastore.Pagination{PageSize: 0},
+			},
+			expectedErr: "rpc error: code = InvalidArgument desc = cannot paginate with pagesize = 0",
+		},
+		{
+			name: "invalid token",
+			req: &datastore.ListIssuedX509SVIDsRequest{
+				Pagination: &datastore.Pagination{Token: "invalid", PageSize: 3},
+			},
+			expectedErr: "rpc error: code = InvalidArgument desc = could not parse token 'invalid'",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		s.T().Run(test.name, func(t *testing.T) {
+			resp, err := s.ds.ListIssuedX509SVIDs(ctx, test.req)
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, test.expectedList, resp.Svids)
+			spiretest.RequireProtoEqual(t, test.expectedPagination, resp.Pagination)
+		})
+	}
+}
+
+func (s *PluginSuite) TestPruneIssuedX509SVIDs() {
+	now := time.Now().Unix()
+	for i, expiresAt := range []int64{now - 10, now, now + 10} {
+		_, err := s.ds.CreateIssuedX509SVID(ctx, &datastore.CreateIssuedX509SVIDRequest{
+			Svid: &datastore.IssuedX509SVID{
+				SpiffeId:     "spiffe://example.org/workload",
+				SerialNumber: strconv.Itoa(i),
+				ExpiresAt:    expiresAt,
+			},
+		})
+		s.Require().NoError(err)
+	}
+
+	listSerialNumbers := func() []string {
+		resp, err := s.ds.ListIssuedX509SVIDs(ctx, &datastore.ListIssuedX509SVIDsRequest{})
+		s.Require().NoError(err)
+		var serialNumbers []string
+		for _, svid := range resp.Svids {
+			serialNumbers = append(serialNumbers, svid.SerialNumber)
+		}
+		return serialNumbers
+	}
+
+	// Ensure we don't prune on the exact ExpiresBefore
+	_, err := s.ds.PruneIssuedX509SVIDs(ctx, &datastore.PruneIssuedX509SVIDsRequest{
+		ExpiresBefore: now - 10,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"0", "1", "2"}, listSerialNumbers())
+
+	// Ensure we prune expired records
+	_, err = s.ds.PruneIssuedX509SVIDs(ctx, &datastore.PruneIssuedX509SVIDsRequest{
+		ExpiresBefore: now + 1,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"2"}, listSerialNumbers())
+}
+
 func (s *PluginSuite) TestGetPluginInfo() {
 	resp, err := s.ds.GetPluginInfo(ctx, &spi.GetPluginInfoRequest{})
 	s.Require().NoError(err)
 	s.Require().NotNil(resp)
 }
 
+func (s *PluginSuite) TestGetInterfaceVersion() {
+	resp, err := s.ds.GetInterfaceVersion(ctx, &datastore.GetInterfaceVersionRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal(uint32(datastore.InterfaceVersion), resp.Version)
+}
+
 func (s *PluginSuite) TestDisabledMigrationBreakingChanges() {
 	dbVersion := 8
 
@@ -2215,6 +2776,190 @@ func (s *PluginSuite) TestDisabledMigrationBreakingChanges() {
 		" auto-migration must be enabled for current DB")
 }
 
+func (s *PluginSuite) TestRequireManualMigration() {
+	dbVersion := 8
+
+	dbPath := filepath.Join(s.dir, fmt.Sprintf("manual-migration-v%d.sqlite3", dbVersion))
+	dump := migrationDump(dbVersion)
+	s.Require().NotEmpty(dump, "no migration dump set up for version %d", dbVersion)
+	s.Require().NoError(dumpDB(dbPath, dump), "error with DB dump for version %d", dbVersion)
+
+	config := fmt.Sprintf(`
+		database_type = "sqlite3"
+		connection_string = "file://%s"
+		require_manual_migration = true
+	`, dbPath)
+	configure := func() error {
+		_, err := s.ds.Configure(context.Background(), &spi.ConfigureRequest{
+			Configuration: config,
+		})
+		return err
+	}
+
+	s.Require().EqualError(configure(), fmt.Sprintf("rpc error: code = Unknown desc = datastore-sql:"+
+		" schema version 8 must be migrated to version %d using `spire-server datastore migrate`", latestSchemaVersion))
+	s.Require().EqualError(Validate(config, hclog.NewNullLogger()), fmt.Sprintf("datastore-sql:"+
+		" schema version 8 must be migrated to version %d using `spire-server datastore migrate`", latestSchemaVersion))
+
+	// a dry run only reports the pending migrations
+	plan, err := Migrate(config, true, hclog.NewNullLogger())
+	s.Require().NoError(err)
+	s.Require().Equal(&MigrationPlan{SchemaVersion: 8, TargetVersion: latestSchemaVersion}, plan)
+	s.Require().Len(plan.Pending(), latestSchemaVersion-8)
+	s.Require().Equal(9, plan.Pending()[0])
+	s.Require().Error(configure())
+
+	plan, err = Migrate(config, false, hclog.NewNullLogger())
+	s.Require().NoError(err)
+	s.Require().Equal(8, plan.SchemaVersion)
+	s.Require().NoError(configure())
+
+	// nothing left to migrate
+	plan, err = Migrate(config, true, hclog.NewNullLogger())
+	s.Require().NoError(err)
+	s.Require().Empty(plan.Pending())
+}
+
+func (s *PluginSuite) TestValidateDoesNotMigrate() {
+	dbVersion := 8
+
+	dbPath := filepath.Join(s.dir, fmt.Sprintf("validate-v%d.sqlite3", dbVersion))
+	dump := migrationDump(dbVersion)
+	s.Require().NotEmpty(dump, "no migration dump set up for version %d", dbVersion)
+	s.Require().NoError(dumpDB(dbPath, dump), "error with DB dump for version %d", dbVersion)
+
+	config := fmt.Sprintf(`
+		database_type = "sqlite3"
+		connection_string = "file://%s"
+	`, dbPath)
+	s.Require().NoError(Validate(config, hclog.NewNullLogger()))
+
+	// the database is left at its schema version
+	plan, err := Migrate(config, true, hclog.NewNullLogger())
+	s.Require().NoError(err)
+	s.Require().Equal(&MigrationPlan{SchemaVersion: 8, TargetVersion: latestSchemaVersion}, plan)
+
+	s.Require().EqualError(Validate(`database_type = "oracle"`, hclog.NewNullLogger()), "datastore-sql: connection_string must be set")
+}
+
+func (s *PluginSuite) TestValidateDoesNotCreateDatabase() {
+	dbPath := filepath.Join(s.dir, "validate-new.sqlite3")
+	config := fmt.Sprintf(`
+		database_type = "sqlite3"
+		connection_string = "file://%s"
+	`, dbPath)
+
+	s.Require().EqualError(Validate(config, hclog.NewNullLogger()), fmt.Sprintf("datastore-sql: database file %q does not exist", dbPath))
+	_, err := os.Stat(dbPath)
+	s.Require().True(os.IsNotExist(err), "database file should not be created: %v", err)
+}
+
+func (s *PluginSuite) TestRequireManualMigrationNewDatabase() {
+	dbPath := filepath.Join(s.dir, "manual-migration-new.sqlite3")
+	config := fmt.Sprintf(`
+		database_type = "sqlite3"
+		connection_string = "file://%s"
+		require_manual_migration = true
+	`, dbPath)
+
+	plan, err := Migrate(config, true, hclog.NewNullLogger())
+	s.Require().NoError(err)
+	s.Require().True(plan.NewDatabase)
+	s.Require().Empty(plan.Pending())
+
+	// new databases are initialized at the latest schema version
+	_, err = s.ds.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: config,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *PluginSuite) TestMigrateTrustDomain() {
+	dbPath := filepath.Join(s.dir, "trust-domain-migration.sqlite3")
+	config := fmt.Sprintf(`
+		database_type = "sqlite3"
+		connection_string = "%s"
+	`, dbPath)
+	_, err := s.ds.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: config,
+	})
+	s.Require().NoError(err)
+
+	s.createBundle("spiffe://old.test")
+	s.createBundle("spiffe://other.test")
+	node := &common.AttestedNode{
+		SpiffeId:            "spiffe://old.test/spire/agent/test/node",
+		AttestationDataType: "test",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+	_, err = s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+	s.Require().NoError(err)
+	s.setNodeSelectors(node.SpiffeId, []*common.Selector{{Type: "test", Value: "node"}})
+
+	workload := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:      node.SpiffeId,
+		SpiffeId:      "spiffe://old.test/workload",
+		Selectors:     []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		FederatesWith: []string{"spiffe://other.test"},
+	})
+	federated := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://other.test/parent",
+		SpiffeId:  "spiffe://old.test.example/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+
+	_, err = MigrateTrustDomain(config, "old.test", "old.test", true, hclog.NewNullLogger())
+	s.Require().EqualError(err, "datastore-sql: trust domains to migrate from and to are the same")
+
+	expected := &TrustDomainMigration{
+		From: "spiffe://old.test",
+		To:   "spiffe://new.test",
+		Entries: []EntryRename{
+			{
+				EntryID:     workload.EntryId,
+				SpiffeID:    IDRename{Old: "spiffe://old.test/workload", New: "spiffe://new.test/workload"},
+				ParentID:    IDRename{Old: node.SpiffeId, New: "spiffe://new.test/spire/agent/test/node"},
+				NewRevision: 1,
+			},
+		},
+		Nodes:   []IDRename{{Old: node.SpiffeId, New: "spiffe://new.test/spire/agent/test/node"}},
+		Bundles: []IDRename{{Old: "spiffe://old.test", New: "spiffe://new.test"}},
+	}
+
+	// a dry run reports the changes without making them
+	migration, err := MigrateTrustDomain(config, "old.test", "new.test", true, hclog.NewNullLogger())
+	s.Require().NoError(err)
+	s.Require().Equal(expected, migration)
+	s.Require().Equal("spiffe://old.test/workload", s.fetchRegistrationEntry(workload.EntryId).SpiffeId)
+	s.Require().NotNil(s.fetchAttestedNode(node.SpiffeId))
+	s.Require().NotNil(s.fetchBundle("spiffe://old.test"))
+
+	migration, err = MigrateTrustDomain(config, "old.test", "new.test", false, hclog.NewNullLogger())
+	s.Require().NoError(err)
+	s.Require().Equal(expected, migration)
+
+	entry := s.fetchRegistrationEntry(workload.EntryId)
+	s.Require().Equal("spiffe://new.test/workload", entry.SpiffeId)
+	s.Require().Equal("spiffe://new.test/spire/agent/test/node", entry.ParentId)
+	s.Require().Equal([]string{"spiffe://other.test"}, entry.FederatesWith)
+	s.Require().Equal(int64(1), entry.RevisionNumber)
+	s.Require().Equal(federated.SpiffeId, s.fetchRegistrationEntry(federated.EntryId).SpiffeId)
+
+	s.Require().Nil(s.fetchAttestedNode(node.SpiffeId))
+	s.Require().NotNil(s.fetchAttestedNode("spiffe://new.test/spire/agent/test/node"))
+	s.Require().Equal([]*common.Selector{{Type: "test", Value: "node"}},
+		s.getNodeSelectors("spiffe://new.test/spire/agent/test/node", false))
+
+	s.Require().Nil(s.fetchBundle("spiffe://old.test"))
+	s.Require().Equal("spiffe://new.test", s.fetchBundle("spiffe://new.test").TrustDomainId)
+
+	// migrating again fails since the bundle of the new trust domain exists
+	s.createBundle("spiffe://old.test")
+	_, err = MigrateTrustDomain(config, "old.test", "new.test", true, hclog.NewNullLogger())
+	s.Require().EqualError(err, `datastore-sql: bundle "spiffe://old.test" cannot be renamed: bundle "spiffe://new.test" already exists`)
+}
+
 func (s *PluginSuite) TestMigration() {
 	for i := 0; i < latestSchemaVersion; i++ {
 		dbName := fmt.Sprintf("v%d.sqlite3", i)
@@ -2406,6 +3151,64 @@ func (s *PluginSuite) TestMigration() {
 			s.Require().Empty(resp.Node.NewCertNotAfter)
 		case 13:
 			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "revision_number"))
+		case 14:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "jwt_svid_ttl"))
+
+			// pre-existing entries keep their TTL and have no JWT-SVID TTL
+			resp, err := s.ds.FetchRegistrationEntry(context.Background(), &datastore.FetchRegistrationEntryRequest{
+				EntryId: "f0373f87-a0f3-4c94-aa6a-a2f948bfc15a",
+			})
+			s.Require().NoError(err)
+			s.Require().NotNil(resp.Entry)
+			s.Require().Equal(int32(3600), resp.Entry.Ttl)
+			s.Require().Equal(int32(0), resp.Entry.JwtSvidTtl)
+		case 15:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("join_tokens", "max_uses"))
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("join_tokens", "use_count"))
+
+			// pre-existing join tokens can only be used once
+			resp, err := s.ds.ConsumeJoinToken(context.Background(), &datastore.ConsumeJoinTokenRequest{
+				Token: "jointoken",
+			})
+			s.Require().NoError(err)
+			s.Require().NotNil(resp.JoinToken)
+			s.Require().Equal(int32(0), resp.JoinToken.MaxUses)
+			s.Require().Equal(int32(1), resp.JoinToken.UseCount)
+
+			fetchResp, err := s.ds.FetchJoinToken(context.Background(), &datastore.FetchJoinTokenRequest{
+				Token: "jointoken",
+			})
+			s.Require().NoError(err)
+			s.Require().Nil(fetchResp.JoinToken)
+		case 16:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "hint"))
+
+			// pre-existing entries have no hint
+			resp, err := s.ds.FetchRegistrationEntry(context.Background(), &datastore.FetchRegistrationEntryRequest{
+				EntryId: "f0373f87-a0f3-4c94-aa6a-a2f948bfc15a",
+			})
+			s.Require().NoError(err)
+			s.Require().NotNil(resp.Entry)
+			s.Require().Equal(int32(3600), resp.Entry.Ttl)
+			s.Require().Empty(resp.Entry.Hint)
+		case 17:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("attested_node_entries", "force_rotation"))
+
+			// pre-existing nodes are not forced to rotate
+			resp, err := s.ds.FetchAttestedNode(context.Background(), &datastore.FetchAttestedNodeRequest{
+				SpiffeId: "spiffe://example.org/host",
+			})
+			s.Require().NoError(err)
+			s.Require().NotNil(resp.Node)
+			s.Require().Equal("111", resp.Node.CertSerialNumber)
+			s.Require().False(resp.Node.ForceRotation)
+		case 18:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasTable("issued_x509_svids"))
+
+			// there are no records of SVIDs issued before the migration
+			resp, err := s.ds.ListIssuedX509SVIDs(context.Background(), &datastore.ListIssuedX509SVIDsRequest{})
+			s.Require().NoError(err)
+			s.Require().Empty(resp.Svids)
 		default:
 			s.T().Fatalf("no migration test added for version %d", i)
 		}
@@ -2492,6 +3295,15 @@ func makeFederatedRegistrationEntry() *common.RegistrationEntry {
 	}
 }
 
+func (s *PluginSuite) fetchAttestedNode(spiffeID string) *common.AttestedNode {
+	resp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: spiffeID,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	return resp.Node
+}
+
 func (s *PluginSuite) getNodeSelectors(spiffeID string, tolerateStale bool) []*common.Selector {
 	resp, err := s.ds.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{
 		SpiffeId:      spiffeID,
@@ -2524,7 +3336,7 @@ func (s *PluginSuite) TestConfigure() {
 	}{
 		{
 			desc:               "defaults",
-			expectMaxOpenConns: 0,
+			expectMaxOpenConns: 100,
 			// defined in database/sql
 			expectIdle: 2,
 		},
@@ -2543,6 +3355,7 @@ func (s *PluginSuite) TestConfigure() {
 			max_open_conns = 1000
 			max_idle_conns = 50
 			conn_max_lifetime = "1ms"
+			query_timeout = "5s"
 			`,
 			expectMaxOpenConns: 1000,
 			expectIdle:         50,
@@ -2591,6 +3404,42 @@ func (s *PluginSuite) TestConfigure() {
 	}
 }
 
+func (s *PluginSuite) TestConfigureInvalidQueryTimeout() {
+	p := New()
+
+	var ds datastore.Plugin
+	pluginDone := spiretest.LoadPlugin(s.T(), builtin(p), &ds)
+	defer pluginDone()
+
+	_, err := ds.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			database_type = "sqlite3"
+			connection_string = "%s"
+			query_timeout = "forever"
+		`, filepath.Join(s.dir, "test-datastore-configure.sqlite3")),
+	})
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), `failed to parse query_timeout "forever"`)
+}
+
+func (s *PluginSuite) TestQueryTimeout() {
+	s.sqlPlugin.mu.Lock()
+	s.sqlPlugin.queryTimeout = time.Nanosecond
+	s.sqlPlugin.mu.Unlock()
+
+	_, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "context deadline exceeded")
+
+	_, err = s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{})
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "context deadline exceeded")
+
+	_, err = s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "context deadline exceeded")
+}
+
 func TestListRegistrationEntriesQuery(t *testing.T) {
 	testCases := []struct {
 		dialect     string
@@ -2608,9 +3457,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2623,7 +3475,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2634,14 +3486,14 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 
@@ -2661,9 +3513,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2677,7 +3532,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2690,7 +3545,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2698,7 +3553,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2719,9 +3574,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2735,7 +3593,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2748,7 +3606,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2756,7 +3614,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2777,9 +3635,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2793,7 +3654,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2806,7 +3667,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2814,7 +3675,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2835,9 +3696,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2851,7 +3715,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2864,7 +3728,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2872,7 +3736,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2897,9 +3761,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2913,7 +3780,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2926,7 +3793,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2934,7 +3801,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2955,9 +3822,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -2971,7 +3841,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -2984,7 +3854,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -2992,7 +3862,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3017,9 +3887,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3033,7 +3906,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3046,7 +3919,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3054,7 +3927,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3079,9 +3952,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3095,7 +3971,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3108,7 +3984,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3116,7 +3992,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3145,9 +4021,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3161,7 +4040,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3174,7 +4053,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3182,7 +4061,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3207,9 +4086,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3223,7 +4105,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3236,7 +4118,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3244,7 +4126,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3271,9 +4153,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3287,7 +4172,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3300,7 +4185,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3308,7 +4193,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3329,9 +4214,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3345,7 +4233,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3358,7 +4246,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3366,7 +4254,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3387,9 +4275,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3403,7 +4294,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3416,7 +4307,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3424,7 +4315,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3446,9 +4337,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3462,7 +4356,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3475,7 +4369,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3483,7 +4377,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3509,9 +4403,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3525,7 +4422,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3538,7 +4435,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3546,7 +4443,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3563,9 +4460,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3578,7 +4478,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3589,14 +4489,14 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 
@@ -3616,9 +4516,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3632,7 +4535,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3645,7 +4548,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3653,7 +4556,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3674,9 +4577,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3690,7 +4596,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3703,7 +4609,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3711,7 +4617,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3732,9 +4638,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3748,7 +4657,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3761,7 +4670,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3769,7 +4678,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3790,9 +4699,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3806,7 +4718,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3819,7 +4731,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3827,7 +4739,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3852,9 +4764,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3868,7 +4783,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3881,7 +4796,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3889,7 +4804,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3910,9 +4825,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3926,7 +4844,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -3939,7 +4857,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3947,7 +4865,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -3972,9 +4890,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -3988,7 +4909,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4001,7 +4922,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4009,7 +4930,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4034,9 +4955,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -4050,7 +4974,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4063,7 +4987,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4071,7 +4995,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4100,9 +5024,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -4116,7 +5043,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4129,7 +5056,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4137,7 +5064,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4162,9 +5089,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -4178,7 +5108,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4191,7 +5121,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4199,7 +5129,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4226,9 +5156,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -4242,7 +5175,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4255,7 +5188,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4263,7 +5196,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4284,9 +5217,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -4300,7 +5236,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4313,7 +5249,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4321,7 +5257,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4342,9 +5278,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -4358,7 +5297,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4371,7 +5310,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4379,7 +5318,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4401,9 +5340,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -4417,7 +5359,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4430,7 +5372,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4438,7 +5380,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4464,9 +5406,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL ::integer AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -4480,7 +5425,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -4493,7 +5438,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4501,7 +5446,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -4518,9 +5463,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4551,9 +5499,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4586,9 +5537,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4621,9 +5575,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4656,9 +5613,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4691,9 +5651,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4730,9 +5693,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4765,9 +5731,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4805,9 +5774,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4845,9 +5817,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4889,9 +5864,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4929,9 +5907,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -4972,9 +5953,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -5009,9 +5993,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -5047,9 +6034,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -5085,9 +6075,12 @@ SELECT
 	E.spiffe_id,
 	E.parent_id,
 	E.ttl AS reg_ttl,
+	E.jwt_svid_ttl AS reg_jwt_svid_ttl,
 	E.admin,
 	E.downstream,
 	E.expiry,
+	E.hint AS reg_hint,
+	E.revision_number,
 	S.id AS selector_id,
 	S.type AS selector_type,
 	S.value AS selector_value,
@@ -5127,9 +6120,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5142,7 +6138,7 @@ FROM
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5153,14 +6149,14 @@ ON
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 
@@ -5181,9 +6177,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5197,7 +6196,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5210,7 +6209,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5218,7 +6217,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5240,9 +6239,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5256,7 +6258,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5269,7 +6271,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5277,7 +6279,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5299,9 +6301,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5315,7 +6320,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5328,7 +6333,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5336,7 +6341,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5358,9 +6363,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5374,7 +6382,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5387,7 +6395,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5395,7 +6403,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5421,9 +6429,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5437,7 +6448,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5450,7 +6461,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5458,7 +6469,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5480,9 +6491,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5496,7 +6510,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5509,7 +6523,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5517,7 +6531,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5544,9 +6558,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5560,7 +6577,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5573,7 +6590,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5581,7 +6598,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5608,9 +6625,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5624,7 +6644,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5637,7 +6657,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5645,7 +6665,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5676,9 +6696,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5692,7 +6715,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5705,7 +6728,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5713,7 +6736,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5740,9 +6763,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5756,7 +6782,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5769,7 +6795,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5777,7 +6803,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5807,9 +6833,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5823,7 +6852,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5836,7 +6865,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5844,7 +6873,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5868,9 +6897,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5884,7 +6916,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5897,7 +6929,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5905,7 +6937,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5929,9 +6961,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -5945,7 +6980,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -5958,7 +6993,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5966,7 +7001,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -5991,9 +7026,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -6007,7 +7045,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -6020,7 +7058,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6028,7 +7066,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6058,9 +7096,12 @@ SELECT
 	spiffe_id,
 	parent_id,
 	ttl AS reg_ttl,
+	jwt_svid_ttl AS reg_jwt_svid_ttl,
 	admin,
 	downstream,
 	expiry,
+	hint AS reg_hint,
+	revision_number,
 	NULL AS selector_id,
 	NULL AS selector_type,
 	NULL AS selector_value,
@@ -6074,7 +7115,7 @@ WHERE id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
+	F.registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, B.trust_domain, NULL, NULL
 FROM
 	bundles B
 INNER JOIN
@@ -6087,7 +7128,7 @@ WHERE
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, value
 FROM
 	dns_names
 WHERE registered_entry_id IN (SELECT id FROM listing)
@@ -6095,7 +7136,7 @@ WHERE registered_entry_id IN (SELECT id FROM listing)
 UNION
 
 SELECT
-	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
+	registered_entry_id, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, id, type, value, NULL, NULL, NULL
 FROM
 	selectors
 WHERE registered_entry_id IN (SELECT id FROM listing)
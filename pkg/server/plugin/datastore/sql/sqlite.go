@@ -2,6 +2,7 @@ package sql
 
 import (
 	"net/url"
+	"os"
 
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/jinzhu/gorm"
@@ -54,6 +55,33 @@ func openSQLite3(connString string) (*gorm.DB, error) {
 	return db, nil
 }
 
+// checkSQLite3DBExists fails if the database file of the connection string
+// does not exist. In-memory databases always exist.
+func checkSQLite3DBExists(connectionString string) error {
+	u, err := url.Parse(connectionString)
+	if err != nil {
+		return sqlError.Wrap(err)
+	}
+	if u.Query().Get("mode") == "memory" {
+		return nil
+	}
+
+	path := u.Path
+	if u.Opaque != "" {
+		path = u.Opaque
+	}
+	if path == ":memory:" {
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return sqlError.New("database file %q does not exist", path)
+		}
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
 // embellishSQLite3ConnString adds query values supported by
 // github.com/mattn/go-sqlite3 to enable journal mode and foreign key support.
 // These query values MUST be part of the connection string in order to be
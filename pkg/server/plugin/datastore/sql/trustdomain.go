@@ -0,0 +1,238 @@
+package sql
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/jinzhu/gorm"
+	"github.com/spiffe/spire/pkg/common/idutil"
+)
+
+// TrustDomainMigration describes the changes needed to move the registration
+// entries, attested nodes and bundles of a database from one trust domain
+// to another.
+type TrustDomainMigration struct {
+	// From is the trust domain ID data is migrated from
+	From string
+
+	// To is the trust domain ID data is migrated to
+	To string
+
+	// Entries are the registration entries whose SPIFFE ID or parent ID
+	// is rewritten
+	Entries []EntryRename
+
+	// Nodes are the attested nodes whose SPIFFE ID is rewritten
+	Nodes []IDRename
+
+	// Bundles are the bundles whose trust domain ID is rewritten
+	Bundles []IDRename
+}
+
+// EntryRename describes the rewrite of a registration entry
+type EntryRename struct {
+	EntryID     string
+	SpiffeID    IDRename
+	ParentID    IDRename
+	NewRevision int64
+}
+
+// IDRename describes the rewrite of a SPIFFE ID. Old and New are equal if the
+// ID is left untouched.
+type IDRename struct {
+	Old string
+	New string
+}
+
+// MigrateTrustDomain rewrites the SPIFFE IDs of the registration entries and
+// attested nodes, and the trust domain IDs of the bundles, in the trust
+// domain "from" to the trust domain "to", in the database described by the
+// plugin configuration. Everything is rewritten in a single transaction. If
+// dryRun is true, the database is left untouched and only the changes are
+// returned.
+//
+// The database must be at the schema version supported by this code, and
+// should not be used by a running server during the migration.
+func MigrateTrustDomain(config string, from, to string, dryRun bool, log hclog.Logger) (*TrustDomainMigration, error) {
+	cfg := &configuration{}
+	if err := hcl.Decode(cfg, config); err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	fromID, err := trustDomainID(from)
+	if err != nil {
+		return nil, err
+	}
+	toID, err := trustDomainID(to)
+	if err != nil {
+		return nil, err
+	}
+	if fromID == toID {
+		return nil, sqlError.New("trust domains to migrate from and to are the same")
+	}
+
+	db, _, _, _, err := connectDB(cfg, false, log)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	plan, err := planMigration(db)
+	if err != nil {
+		return nil, err
+	}
+	if plan.NewDatabase || plan.SchemaVersion != plan.TargetVersion {
+		return nil, sqlError.New("database schema version %d is not the version %d supported by this server; migrate the schema first", plan.SchemaVersion, plan.TargetVersion)
+	}
+
+	tx := db.Begin()
+	if err := tx.Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	migration, err := migrateTrustDomain(tx, fromID, toID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if dryRun {
+		if err := tx.Rollback().Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		return migration, nil
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return migration, nil
+}
+
+func trustDomainID(trustDomain string) (string, error) {
+	id, err := idutil.NormalizeSpiffeID(idutil.TrustDomainID(trustDomain), idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return "", sqlError.New("invalid trust domain %q: %v", trustDomain, err)
+	}
+	return id, nil
+}
+
+func migrateTrustDomain(tx *gorm.DB, fromID, toID string) (*TrustDomainMigration, error) {
+	migration := &TrustDomainMigration{
+		From: fromID,
+		To:   toID,
+	}
+
+	rename := func(id string) (string, bool) {
+		if id == fromID || strings.HasPrefix(id, fromID+"/") {
+			return toID + strings.TrimPrefix(id, fromID), true
+		}
+		return id, false
+	}
+
+	// LIKE is only used to narrow down the rows, since the trust domain may
+	// contain LIKE wildcards. rename decides which IDs are rewritten.
+	like := fromID + "%"
+
+	var entries []RegisteredEntry
+	if err := tx.Where("spiffe_id LIKE ? OR parent_id LIKE ?", like, like).Order("id").Find(&entries).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	for _, entry := range entries {
+		spiffeID, spiffeIDRenamed := rename(entry.SpiffeID)
+		parentID, parentIDRenamed := rename(entry.ParentID)
+		if !spiffeIDRenamed && !parentIDRenamed {
+			continue
+		}
+
+		// Updates also sets the fields of the model, so the old IDs are
+		// recorded first
+		entryRename := EntryRename{
+			EntryID:     entry.EntryID,
+			SpiffeID:    IDRename{Old: entry.SpiffeID, New: spiffeID},
+			ParentID:    IDRename{Old: entry.ParentID, New: parentID},
+			NewRevision: entry.RevisionNumber + 1,
+		}
+
+		if err := tx.Model(&entry).Updates(map[string]interface{}{
+			"spiffe_id":       spiffeID,
+			"parent_id":       parentID,
+			"revision_number": gorm.Expr("revision_number + 1"),
+		}).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+
+		migration.Entries = append(migration.Entries, entryRename)
+	}
+
+	var nodes []AttestedNode
+	if err := tx.Where("spiffe_id LIKE ?", like).Order("id").Find(&nodes).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	for _, node := range nodes {
+		oldID := node.SpiffeID
+		spiffeID, ok := rename(oldID)
+		if !ok {
+			continue
+		}
+
+		var count int
+		if err := tx.Model(&AttestedNode{}).Where("spiffe_id = ?", spiffeID).Count(&count).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if count > 0 {
+			return nil, sqlError.New("attested node %q cannot be renamed: node %q already exists", oldID, spiffeID)
+		}
+
+		if err := tx.Model(&node).Update("spiffe_id", spiffeID).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if err := tx.Model(&NodeSelector{}).Where("spiffe_id = ?", oldID).Update("spiffe_id", spiffeID).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+
+		migration.Nodes = append(migration.Nodes, IDRename{Old: oldID, New: spiffeID})
+	}
+
+	var bundle Bundle
+	switch err := tx.Where("trust_domain = ?", fromID).First(&bundle).Error; {
+	case gorm.IsRecordNotFoundError(err):
+		return migration, nil
+	case err != nil:
+		return nil, sqlError.Wrap(err)
+	}
+
+	var count int
+	if err := tx.Model(&Bundle{}).Where("trust_domain = ?", toID).Count(&count).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	if count > 0 {
+		return nil, sqlError.New("bundle %q cannot be renamed: bundle %q already exists", fromID, toID)
+	}
+
+	pb, err := modelToBundle(&bundle)
+	if err != nil {
+		return nil, err
+	}
+	pb.TrustDomainId = toID
+	data, err := proto.Marshal(pb)
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if err := tx.Model(&bundle).Updates(map[string]interface{}{
+		"trust_domain": toID,
+		"data":         data,
+	}).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	migration.Bundles = append(migration.Bundles, IDRename{Old: fromID, New: toID})
+
+	return migration, nil
+}
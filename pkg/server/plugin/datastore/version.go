@@ -0,0 +1,30 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+)
+
+// InterfaceVersion is the version of the DataStore service implemented by
+// this version of SPIRE. It must be incremented whenever a backwards
+// incompatible change is made to the service.
+//
+// Version 2 requires ConsumeJoinToken, used to attest agents with join tokens.
+// Version 3 requires honoring the revision number of registration entry
+// updates and deletions.
+// Version 4 requires ForceAttestedNodeRotation, used to force agents to rotate
+// their SVID.
+const InterfaceVersion = 4
+
+// CheckInterfaceVersion returns an error if the plugin does not implement the
+// version of the DataStore service expected by SPIRE server.
+func CheckInterfaceVersion(ctx context.Context, plugin Plugin) error {
+	resp, err := plugin.GetInterfaceVersion(ctx, &GetInterfaceVersionRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to get DataStore interface version: %v", err)
+	}
+	if resp.Version != InterfaceVersion {
+		return fmt.Errorf("DataStore plugin implements interface version %d; version %d is required", resp.Version, InterfaceVersion)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package keymanager
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Failover produces a KeyManager that forwards calls to the primary
+// KeyManager and, when the primary fails, retries them on the secondary.
+// Keys generated through the returned KeyManager are pinned to the KeyManager
+// that generated them: calls for these keys are only forwarded to that
+// KeyManager, since the other one does not hold the same key. Calls for
+// other keys, e.g. generated before the server restarted, fail over as
+// described above, so both KeyManagers are expected to hold the same keys,
+// e.g. replicas of the same HSM.
+func Failover(log logrus.FieldLogger, primary, secondary KeyManager) KeyManager {
+	return &failover{
+		log:       log,
+		primary:   primary,
+		secondary: secondary,
+		pinned:    make(map[string]KeyManager),
+	}
+}
+
+type failover struct {
+	log       logrus.FieldLogger
+	primary   KeyManager
+	secondary KeyManager
+
+	mu sync.RWMutex
+	// pinned maps the ID of the keys generated through the failover to the
+	// KeyManager that generated them
+	pinned map[string]KeyManager
+}
+
+func (f *failover) GenerateKey(ctx context.Context, req *GenerateKeyRequest) (*GenerateKeyResponse, error) {
+	km := f.primary
+	resp, err := km.GenerateKey(ctx, req)
+	if f.shouldFailOver(ctx, "GenerateKey", err) {
+		km = f.secondary
+		resp, err = km.GenerateKey(ctx, req)
+	}
+	if err == nil {
+		f.pin(req.KeyId, km)
+	}
+	return resp, err
+}
+
+func (f *failover) GetPublicKey(ctx context.Context, req *GetPublicKeyRequest) (*GetPublicKeyResponse, error) {
+	if km := f.pinnedTo(req.KeyId); km != nil {
+		return km.GetPublicKey(ctx, req)
+	}
+	resp, err := f.primary.GetPublicKey(ctx, req)
+	if f.shouldFailOver(ctx, "GetPublicKey", err) {
+		return f.secondary.GetPublicKey(ctx, req)
+	}
+	return resp, err
+}
+
+func (f *failover) GetPublicKeys(ctx context.Context, req *GetPublicKeysRequest) (*GetPublicKeysResponse, error) {
+	resp, err := f.primary.GetPublicKeys(ctx, req)
+	if f.shouldFailOver(ctx, "GetPublicKeys", err) {
+		return f.secondary.GetPublicKeys(ctx, req)
+	}
+	return resp, err
+}
+
+func (f *failover) SignData(ctx context.Context, req *SignDataRequest) (*SignDataResponse, error) {
+	if km := f.pinnedTo(req.KeyId); km != nil {
+		return km.SignData(ctx, req)
+	}
+	resp, err := f.primary.SignData(ctx, req)
+	if f.shouldFailOver(ctx, "SignData", err) {
+		return f.secondary.SignData(ctx, req)
+	}
+	return resp, err
+}
+
+func (f *failover) pin(keyID string, km KeyManager) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pinned[keyID] = km
+}
+
+// pinnedTo returns the KeyManager that generated the key, or nil if the key
+// was not generated through the failover.
+func (f *failover) pinnedTo(keyID string) KeyManager {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.pinned[keyID]
+}
+
+// shouldFailOver returns true if the primary failed the call for another
+// reason than the caller giving up.
+func (f *failover) shouldFailOver(ctx context.Context, method string, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	f.log.WithError(err).WithField(telemetry.Method, method).Warn("Primary KeyManager failed; failing over to the secondary")
+	return true
+}
@@ -0,0 +1,115 @@
+package keymanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailover(t *testing.T) {
+	log, hook := test.NewNullLogger()
+
+	primary := &fakeKeyManager{keyID: "primary"}
+	secondary := &fakeKeyManager{keyID: "secondary"}
+	km := Failover(log, primary, secondary)
+
+	// The secondary is unused while the primary succeeds
+	resp, err := km.GetPublicKey(context.Background(), &GetPublicKeyRequest{KeyId: "key"})
+	require.NoError(t, err)
+	require.Equal(t, "primary", resp.PublicKey.Id)
+	require.Empty(t, hook.AllEntries())
+
+	// Calls failing on the primary are retried on the secondary
+	primary.err = errors.New("ohno")
+	resp, err = km.GetPublicKey(context.Background(), &GetPublicKeyRequest{KeyId: "key"})
+	require.NoError(t, err)
+	require.Equal(t, "secondary", resp.PublicKey.Id)
+	require.Len(t, hook.AllEntries(), 1)
+	entry := hook.LastEntry()
+	require.Equal(t, logrus.WarnLevel, entry.Level)
+	require.Equal(t, "Primary KeyManager failed; failing over to the secondary", entry.Message)
+	require.Equal(t, "GetPublicKey", entry.Data["method"])
+
+	// The secondary error is returned when both fail
+	secondary.err = errors.New("ohno again")
+	_, err = km.GetPublicKey(context.Background(), &GetPublicKeyRequest{KeyId: "key"})
+	require.EqualError(t, err, "ohno again")
+
+	// Calls are not retried once the caller gave up
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = km.GetPublicKey(ctx, &GetPublicKeyRequest{KeyId: "key"})
+	require.EqualError(t, err, "ohno")
+}
+
+func TestFailoverPinsGeneratedKeys(t *testing.T) {
+	log, _ := test.NewNullLogger()
+
+	primary := &fakeKeyManager{keyID: "primary"}
+	secondary := &fakeKeyManager{keyID: "secondary"}
+	km := Failover(log, primary, secondary)
+
+	// The key is generated by the secondary while the primary is down
+	primary.err = errors.New("ohno")
+	_, err := km.GenerateKey(context.Background(), &GenerateKeyRequest{KeyId: "key"})
+	require.NoError(t, err)
+
+	// Once the primary is back, calls for the key still go to the secondary
+	primary.err = nil
+	resp, err := km.GetPublicKey(context.Background(), &GetPublicKeyRequest{KeyId: "key"})
+	require.NoError(t, err)
+	require.Equal(t, "secondary", resp.PublicKey.Id)
+	signResp, err := km.SignData(context.Background(), &SignDataRequest{KeyId: "key"})
+	require.NoError(t, err)
+	require.Equal(t, []byte("secondary"), signResp.Signature)
+
+	// ... and don't fail over to the primary, which doesn't hold the key
+	secondary.err = errors.New("ohno again")
+	_, err = km.SignData(context.Background(), &SignDataRequest{KeyId: "key"})
+	require.EqualError(t, err, "ohno again")
+
+	// Keys generated by the primary are pinned to the primary
+	_, err = km.GenerateKey(context.Background(), &GenerateKeyRequest{KeyId: "other"})
+	require.NoError(t, err)
+	primary.err = errors.New("ohno")
+	_, err = km.SignData(context.Background(), &SignDataRequest{KeyId: "other"})
+	require.EqualError(t, err, "ohno")
+}
+
+type fakeKeyManager struct {
+	KeyManager
+
+	keyID string
+	err   error
+}
+
+func (km *fakeKeyManager) GetPublicKey(ctx context.Context, req *GetPublicKeyRequest) (*GetPublicKeyResponse, error) {
+	if km.err != nil {
+		return nil, km.err
+	}
+	return &GetPublicKeyResponse{
+		PublicKey: &PublicKey{Id: km.keyID},
+	}, nil
+}
+
+func (km *fakeKeyManager) GenerateKey(ctx context.Context, req *GenerateKeyRequest) (*GenerateKeyResponse, error) {
+	if km.err != nil {
+		return nil, km.err
+	}
+	return &GenerateKeyResponse{
+		PublicKey: &PublicKey{Id: km.keyID},
+	}, nil
+}
+
+func (km *fakeKeyManager) SignData(ctx context.Context, req *SignDataRequest) (*SignDataResponse, error) {
+	if km.err != nil {
+		return nil, km.err
+	}
+	return &SignDataResponse{
+		Signature: []byte(km.keyID),
+	}, nil
+}
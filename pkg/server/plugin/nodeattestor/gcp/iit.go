@@ -25,6 +25,8 @@ const (
 	pluginName                  = "gcp_iit"
 	tokenAudience               = "spire-gcp-node-attestor" //nolint: gosec // false positive
 	googleCertURL               = "https://www.googleapis.com/oauth2/v1/certs"
+	googleIssuer                = "https://accounts.google.com"
+	googleIssuerNoScheme        = "accounts.google.com"
 	defaultMaxMetadataValueSize = 128
 )
 
@@ -270,7 +272,19 @@ func validateAttestationAndExtractIdentityMetadata(stream nodeattestor.NodeAttes
 		return gcp.ComputeEngine{}, pluginErr.New("unexpected identity token audience %q", identityToken.Audience)
 	}
 
-	return identityToken.Google.ComputeEngine, nil
+	// Google issues identity tokens with either form of the issuer
+	if identityToken.Issuer != googleIssuer && identityToken.Issuer != googleIssuerNoScheme {
+		return gcp.ComputeEngine{}, pluginErr.New("unexpected identity token issuer %q", identityToken.Issuer)
+	}
+
+	// The compute engine claims are only present when the token is requested
+	// in the full format, which is also required to identify the instance
+	computeEngine := identityToken.Google.ComputeEngine
+	if computeEngine.ProjectID == "" || computeEngine.InstanceID == "" {
+		return gcp.ComputeEngine{}, pluginErr.New("identity token is missing compute engine claims")
+	}
+
+	return computeEngine, nil
 }
 
 func getInstanceTags(instance *compute.Instance) []string {
@@ -131,6 +131,48 @@ func (s *IITAttestorSuite) TestErrorOnInvalidAudience() {
 	s.RequireErrorContains(err, `gcp-iit: unexpected identity token audience "invalid"`)
 }
 
+func (s *IITAttestorSuite) TestErrorOnInvalidIssuer() {
+	claims := buildDefaultClaims()
+	claims["iss"] = "https://issuer.example.org"
+	token := buildTokenWithClaims(claims)
+
+	data := &common.AttestationData{
+		Type: gcp.PluginName,
+		Data: s.signToken(token),
+	}
+
+	_, err := s.attest(&nodeattestor.AttestRequest{AttestationData: data})
+	s.RequireErrorContains(err, `gcp-iit: unexpected identity token issuer "https://issuer.example.org"`)
+}
+
+func (s *IITAttestorSuite) TestAttestSuccessWithIssuerWithoutScheme() {
+	claims := buildDefaultClaims()
+	claims["iss"] = "accounts.google.com"
+	token := buildTokenWithClaims(claims)
+
+	data := &common.AttestationData{
+		Type: gcp.PluginName,
+		Data: s.signToken(token),
+	}
+
+	_, err := s.attest(&nodeattestor.AttestRequest{AttestationData: data})
+	s.Require().NoError(err)
+}
+
+func (s *IITAttestorSuite) TestErrorOnMissingComputeEngineClaims() {
+	claims := buildDefaultClaims()
+	delete(claims, "google")
+	token := buildTokenWithClaims(claims)
+
+	data := &common.AttestationData{
+		Type: gcp.PluginName,
+		Data: s.signToken(token),
+	}
+
+	_, err := s.attest(&nodeattestor.AttestRequest{AttestationData: data})
+	s.RequireErrorContains(err, "gcp-iit: identity token is missing compute engine claims")
+}
+
 func (s *IITAttestorSuite) TestErrorOnAttestedBefore() {
 	token := buildToken()
 
@@ -505,6 +547,7 @@ func buildClaims(projectID string, audience string) jwt.MapClaims {
 			},
 		},
 		"aud": audience,
+		"iss": "https://accounts.google.com",
 	}
 }
 
@@ -2,6 +2,8 @@ package jointoken
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 
 	"github.com/spiffe/spire/pkg/common/catalog"
@@ -19,6 +21,14 @@ func builtin(p *Plugin) catalog.Plugin {
 	)
 }
 
+// TokenID returns the identifier of a reusable join token used in the IDs and
+// selectors of the agents attested with it, so the token itself, which can
+// still be used to attest agents, is never disclosed.
+func TokenID(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 type Plugin struct{}
 
 func New() *Plugin {
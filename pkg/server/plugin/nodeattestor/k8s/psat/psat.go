@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/plugin/k8s"
 	"github.com/spiffe/spire/pkg/common/plugin/k8s/apiserver"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
+	nodeattestorbase "github.com/spiffe/spire/pkg/server/plugin/nodeattestor/base"
 	"github.com/spiffe/spire/proto/spire/common"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/zeebo/errs"
@@ -18,6 +22,10 @@ import (
 
 const (
 	pluginName = "k8s_psat"
+
+	// attestedNodeLabelInterval is how often the attested node label is
+	// removed from the nodes whose agent is no longer attested
+	attestedNodeLabelInterval = time.Minute
 )
 
 var (
@@ -60,6 +68,15 @@ type ClusterConfig struct {
 
 	// Pod labels that are allowed to use as selectors
 	AllowedPodLabelKeys []string `hcl:"allowed_pod_label_keys"`
+
+	// Require the token to be bound to the node the agent pod is scheduled on
+	// Attestation is denied if the token has no node claims
+	RequireNodeBoundToken bool `hcl:"require_node_bound_token"`
+
+	// Label set to "true" on the nodes whose agent was successfully attested,
+	// and removed once the agent is evicted
+	// If string is empty, nodes are not labeled
+	AttestedNodeLabel string `hcl:"attested_node_label"`
 }
 
 type attestorConfig struct {
@@ -73,17 +90,34 @@ type clusterConfig struct {
 	client               apiserver.Client
 	allowedNodeLabelKeys map[string]bool
 	allowedPodLabelKeys  map[string]bool
+	requireNodeBound     bool
+	attestedNodeLabel    string
 }
 
 //AttestorPlugin is a PSAT (Projected SAT) node attestor plugin
 type AttestorPlugin struct {
+	nodeattestorbase.Base
+
+	log hclog.Logger
+
 	mu     sync.RWMutex
 	config *attestorConfig
+	// stopUnlabeling stops removing the attested node label for the
+	// current configuration
+	stopUnlabeling context.CancelFunc
+	// labeledAt tracks when the node of each agent was last labeled. The
+	// server stores the attested node only after Attest returns, so nodes
+	// labeled recently are not unlabeled even if the agent does not look
+	// attested yet.
+	labeledAt map[string]time.Time
 }
 
 // New creates a new PSAT node attestor plugin
 func New() *AttestorPlugin {
-	return &AttestorPlugin{}
+	return &AttestorPlugin{
+		log:       hclog.NewNullLogger(),
+		labeledAt: make(map[string]time.Time),
+	}
 }
 
 var _ nodeattestor.NodeAttestorServer = (*AttestorPlugin)(nil)
@@ -163,6 +197,27 @@ func (p *AttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) e
 		return psatError.New("fail to get pod from k8s API server: %v", err)
 	}
 
+	// The pod may have been replaced by another one with the same name
+	// (e.g. a recreated daemonset pod), so make sure the token is bound to
+	// the pod that is currently running.
+	if string(pod.UID) != podUID {
+		return psatError.New("token is bound to pod UID %q but pod \"%s/%s\" has UID %q", podUID, namespace, podName, pod.UID)
+	}
+
+	// Newer API servers bind the token to the node the pod is scheduled on.
+	// The claims must match the scheduling node so a token obtained on one
+	// node can't be used to attest as another one.
+	tokenNodeName, tokenNodeUID, err := k8s.GetNodeFromTokenStatus(tokenStatus)
+	if err != nil {
+		return psatError.New("fail to get node from token review status: %v", err)
+	}
+	if tokenNodeName == "" && cluster.requireNodeBound {
+		return psatError.New("token is not bound to a node")
+	}
+	if tokenNodeName != "" && tokenNodeName != pod.Spec.NodeName {
+		return psatError.New("token is bound to node %q but pod \"%s/%s\" is scheduled on node %q", tokenNodeName, namespace, podName, pod.Spec.NodeName)
+	}
+
 	node, err := cluster.client.GetNode(pod.Spec.NodeName)
 	if err != nil {
 		return psatError.New("fail to get node from k8s API server: %v", err)
@@ -172,6 +227,9 @@ func (p *AttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) e
 	if nodeUID == "" {
 		return psatError.New("node UID is empty")
 	}
+	if tokenNodeUID != "" && tokenNodeUID != nodeUID {
+		return psatError.New("token is bound to node UID %q but node %q has UID %q", tokenNodeUID, pod.Spec.NodeName, nodeUID)
+	}
 
 	selectors := []*common.Selector{
 		k8s.MakeSelector(pluginName, "cluster", attestationData.Cluster),
@@ -195,10 +253,22 @@ func (p *AttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) e
 		}
 	}
 
-	return stream.Send(&nodeattestor.AttestResponse{
-		AgentId:   k8s.AgentID(pluginName, config.trustDomain, attestationData.Cluster, nodeUID),
+	agentID := k8s.AgentID(pluginName, config.trustDomain, attestationData.Cluster, nodeUID)
+	if err := stream.Send(&nodeattestor.AttestResponse{
+		AgentId:   agentID,
 		Selectors: selectors,
-	})
+	}); err != nil {
+		return err
+	}
+
+	// Labeling the node is best-effort: the agent is attested regardless
+	if cluster.attestedNodeLabel != "" {
+		p.setLabeledAt(agentID, time.Now())
+		if err := cluster.client.LabelNode(pod.Spec.NodeName, cluster.attestedNodeLabel, "true"); err != nil {
+			p.log.Warn("Failed to label attested node", "node_name", pod.Spec.NodeName, telemetry.Error, err)
+		}
+	}
+	return nil
 }
 
 func (p *AttestorPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
@@ -255,6 +325,8 @@ func (p *AttestorPlugin) Configure(ctx context.Context, req *spi.ConfigureReques
 			client:               apiserver.New(cluster.KubeConfigFile),
 			allowedNodeLabelKeys: allowedNodeLabelKeys,
 			allowedPodLabelKeys:  allowedPodLabelKeys,
+			requireNodeBound:     cluster.RequireNodeBoundToken,
+			attestedNodeLabel:    cluster.AttestedNodeLabel,
 		}
 	}
 
@@ -266,6 +338,23 @@ func (p *AttestorPlugin) GetPluginInfo(context.Context, *spi.GetPluginInfoReques
 	return &spi.GetPluginInfoResponse{}, nil
 }
 
+// SetLogger sets this plugin's logger
+func (p *AttestorPlugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+// Close stops removing the attested node label from the nodes of evicted
+// agents.
+func (p *AttestorPlugin) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopUnlabeling != nil {
+		p.stopUnlabeling()
+		p.stopUnlabeling = nil
+	}
+	return nil
+}
+
 func (p *AttestorPlugin) getConfig() (*attestorConfig, error) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
@@ -279,4 +368,90 @@ func (p *AttestorPlugin) setConfig(config *attestorConfig) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	p.config = config
+
+	if p.stopUnlabeling != nil {
+		p.stopUnlabeling()
+		p.stopUnlabeling = nil
+	}
+	for _, cluster := range config.clusters {
+		if cluster.attestedNodeLabel != "" {
+			ctx, cancel := context.WithCancel(context.Background())
+			p.stopUnlabeling = cancel
+			go p.unlabelEvictedNodesEvery(ctx, config, attestedNodeLabelInterval)
+			break
+		}
+	}
+}
+
+// unlabelEvictedNodesEvery periodically removes the attested node label from
+// the nodes whose agent is no longer attested, e.g. because it was evicted,
+// until the context is canceled.
+func (p *AttestorPlugin) unlabelEvictedNodesEvery(ctx context.Context, config *attestorConfig, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.unlabelEvictedNodes(ctx, config)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// unlabelEvictedNodes removes the attested node label from the nodes whose
+// agent is no longer attested.
+func (p *AttestorPlugin) unlabelEvictedNodes(ctx context.Context, config *attestorConfig) {
+	for name, cluster := range config.clusters {
+		if cluster.attestedNodeLabel == "" {
+			continue
+		}
+
+		nodes, err := cluster.client.GetNodesWithLabel(cluster.attestedNodeLabel, "true")
+		if err != nil {
+			p.log.Warn("Failed to list attested nodes", "cluster", name, telemetry.Error, err)
+			continue
+		}
+
+		for _, node := range nodes {
+			agentID := k8s.AgentID(pluginName, config.trustDomain, name, string(node.UID))
+			if p.labeledRecently(agentID) {
+				continue
+			}
+			attested, err := p.IsAttested(ctx, agentID)
+			switch {
+			case err != nil:
+				p.log.Warn("Failed to check if the agent of the node is attested", "node_name", node.Name, telemetry.Error, err)
+				continue
+			case attested:
+				continue
+			}
+			if err := cluster.client.UnlabelNode(node.Name, cluster.attestedNodeLabel); err != nil {
+				p.log.Warn("Failed to unlabel node of evicted agent", "node_name", node.Name, telemetry.Error, err)
+			}
+		}
+	}
+}
+
+func (p *AttestorPlugin) setLabeledAt(agentID string, t time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.labeledAt[agentID] = t
+}
+
+// labeledRecently returns true if the node of the agent was labeled within
+// the last attestedNodeLabelInterval, i.e. the server may not have stored
+// the attested node yet.
+func (p *AttestorPlugin) labeledRecently(agentID string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	labeledAt, ok := p.labeledAt[agentID]
+	if !ok {
+		return false
+	}
+	if time.Since(labeledAt) < attestedNodeLabelInterval {
+		return true
+	}
+	delete(p.labeledAt, agentID)
+	return false
 }
@@ -18,11 +18,15 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	sat_common "github.com/spiffe/spire/pkg/common/plugin/k8s"
+	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/fakes/fakeagentstore"
 	k8s_apiserver_mock "github.com/spiffe/spire/test/mock/common/plugin/k8s/apiserver"
 	"github.com/spiffe/spire/test/spiretest"
 	"google.golang.org/grpc/codes"
@@ -73,6 +77,9 @@ type AttestorSuite struct {
 	barSigner  jose.Signer
 	bazSigner  jose.Signer
 	attestor   nodeattestor.Plugin
+	psat       *AttestorPlugin
+	agentStore *fakeagentstore.AgentStore
+	logHook    *test.Hook
 	mockCtrl   *gomock.Controller
 	mockClient *k8s_apiserver_mock.MockClient
 }
@@ -82,6 +89,8 @@ type TokenData struct {
 	serviceAccountName string
 	podName            string
 	podUID             string
+	nodeName           string
+	nodeUID            string
 	issuer             string
 	audience           []string
 	notBefore          time.Time
@@ -123,6 +132,7 @@ func (s *AttestorSuite) SetupSuite() {
 
 func (s *AttestorSuite) SetupTest() {
 	s.mockCtrl = gomock.NewController(s.T())
+	s.agentStore = fakeagentstore.New()
 	s.attestor = s.configureAttestor()
 }
 
@@ -274,6 +284,19 @@ func (s *AttestorSuite) TestAttestFailsIfCannotGetPod() {
 	s.requireAttestError(makeAttestRequest("FOO", token), "fail to get pod from k8s API server")
 }
 
+func (s *AttestorSuite) TestAttestFailsIfPodUIDDoesNotMatch() {
+	tokenData := &TokenData{
+		namespace:          "NS1",
+		serviceAccountName: "SA1",
+		podName:            "PODNAME",
+		podUID:             "PODUID",
+	}
+	token := s.signToken(s.fooSigner, tokenData)
+	s.mockClient.EXPECT().ValidateToken(token, defaultAudience).Return(createTokenStatus(tokenData, true), nil)
+	s.mockClient.EXPECT().GetPod("NS1", "PODNAME").Return(createPod("NODENAME", "OTHERUID"), nil)
+	s.requireAttestError(makeAttestRequest("FOO", token), `token is bound to pod UID "PODUID" but pod "NS1/PODNAME" has UID "OTHERUID"`)
+}
+
 func (s *AttestorSuite) TestAttestFailsIfCannotGetNode() {
 	tokenData := &TokenData{
 		namespace:          "NS1",
@@ -283,7 +306,7 @@ func (s *AttestorSuite) TestAttestFailsIfCannotGetNode() {
 	}
 	token := s.signToken(s.fooSigner, tokenData)
 	s.mockClient.EXPECT().ValidateToken(token, defaultAudience).Return(createTokenStatus(tokenData, true), nil)
-	s.mockClient.EXPECT().GetPod("NS1", "PODNAME").Return(createPod("NODENAME"), nil)
+	s.mockClient.EXPECT().GetPod("NS1", "PODNAME").Return(createPod("NODENAME", "PODUID"), nil)
 	s.mockClient.EXPECT().GetNode("NODENAME").Return(nil, errors.New("an error"))
 	s.requireAttestError(makeAttestRequest("FOO", token), "fail to get node from k8s API server")
 }
@@ -297,11 +320,131 @@ func (s *AttestorSuite) TestAttestFailsIfNodeUIDIsEmpty() {
 	}
 	token := s.signToken(s.fooSigner, tokenData)
 	s.mockClient.EXPECT().ValidateToken(token, defaultAudience).Return(createTokenStatus(tokenData, true), nil)
-	s.mockClient.EXPECT().GetPod("NS1", "PODNAME").Return(createPod("NODENAME"), nil)
+	s.mockClient.EXPECT().GetPod("NS1", "PODNAME").Return(createPod("NODENAME", "PODUID"), nil)
 	s.mockClient.EXPECT().GetNode("NODENAME").Return(createNode(""), nil)
 	s.requireAttestError(makeAttestRequest("FOO", token), "node UID is empty")
 }
 
+func (s *AttestorSuite) TestAttestFailsIfTokenNotBoundToNode() {
+	tokenData := &TokenData{
+		namespace:          "NS3",
+		serviceAccountName: "SA3",
+		podName:            "PODNAME",
+		podUID:             "PODUID",
+	}
+	token := s.signToken(s.fooSigner, tokenData)
+	s.mockClient.EXPECT().ValidateToken(token, defaultAudience).Return(createTokenStatus(tokenData, true), nil)
+	s.mockClient.EXPECT().GetPod("NS3", "PODNAME").Return(createPod("NODENAME", "PODUID"), nil)
+	s.requireAttestError(makeAttestRequest("BAZ", token), "token is not bound to a node")
+}
+
+func (s *AttestorSuite) TestAttestFailsWithMissingNodeUIDClaim() {
+	tokenData := &TokenData{
+		namespace:          "NS1",
+		serviceAccountName: "SA1",
+		podName:            "PODNAME",
+		podUID:             "PODUID",
+		nodeName:           "NODENAME",
+	}
+	token := s.signToken(s.fooSigner, tokenData)
+	s.mockClient.EXPECT().ValidateToken(token, defaultAudience).Return(createTokenStatus(tokenData, true), nil)
+	s.mockClient.EXPECT().GetPod("NS1", "PODNAME").Return(createPod("NODENAME", "PODUID"), nil)
+	s.requireAttestError(makeAttestRequest("FOO", token), "fail to get node from token review status")
+}
+
+func (s *AttestorSuite) TestAttestFailsIfNodeNameDoesNotMatch() {
+	tokenData := &TokenData{
+		namespace:          "NS1",
+		serviceAccountName: "SA1",
+		podName:            "PODNAME",
+		podUID:             "PODUID",
+		nodeName:           "OTHERNODE",
+		nodeUID:            "NODEUID",
+	}
+	token := s.signToken(s.fooSigner, tokenData)
+	s.mockClient.EXPECT().ValidateToken(token, defaultAudience).Return(createTokenStatus(tokenData, true), nil)
+	s.mockClient.EXPECT().GetPod("NS1", "PODNAME").Return(createPod("NODENAME", "PODUID"), nil)
+	s.requireAttestError(makeAttestRequest("FOO", token), `token is bound to node "OTHERNODE" but pod "NS1/PODNAME" is scheduled on node "NODENAME"`)
+}
+
+func (s *AttestorSuite) TestAttestFailsIfNodeUIDDoesNotMatch() {
+	tokenData := &TokenData{
+		namespace:          "NS1",
+		serviceAccountName: "SA1",
+		podName:            "PODNAME",
+		podUID:             "PODUID",
+		nodeName:           "NODENAME",
+		nodeUID:            "NODEUID",
+	}
+	token := s.signToken(s.fooSigner, tokenData)
+	s.mockClient.EXPECT().ValidateToken(token, defaultAudience).Return(createTokenStatus(tokenData, true), nil)
+	s.mockClient.EXPECT().GetPod("NS1", "PODNAME").Return(createPod("NODENAME", "PODUID"), nil)
+	s.mockClient.EXPECT().GetNode("NODENAME").Return(createNode("OTHERUID"), nil)
+	s.requireAttestError(makeAttestRequest("FOO", token), `token is bound to node UID "NODEUID" but node "NODENAME" has UID "OTHERUID"`)
+}
+
+func (s *AttestorSuite) TestAttestSucceedsIfCannotLabelNode() {
+	tokenData := &TokenData{
+		namespace:          "NS3",
+		serviceAccountName: "SA3",
+		podName:            "PODNAME",
+		podUID:             "PODUID",
+		nodeName:           "NODENAME",
+		nodeUID:            "NODEUID",
+	}
+	token := s.signToken(s.fooSigner, tokenData)
+	s.mockClient.EXPECT().ValidateToken(token, defaultAudience).Return(createTokenStatus(tokenData, true), nil)
+	s.mockClient.EXPECT().GetPod("NS3", "PODNAME").Return(createPod("NODENAME", "PODUID"), nil)
+	s.mockClient.EXPECT().GetNode("NODENAME").Return(createNode("NODEUID"), nil)
+	labeled := s.expectLabelNode("NODENAME", errors.New("an error"))
+
+	resp, err := s.doAttest(makeAttestRequest("BAZ", token))
+	s.Require().NoError(err)
+	s.Require().Equal("spiffe://example.org/spire/agent/k8s_psat/BAZ/NODEUID", resp.AgentId)
+
+	// the node is labeled once the agent is attested
+	s.waitForLabelNode(labeled)
+	s.Require().Eventually(func() bool {
+		entry := s.logHook.LastEntry()
+		return entry != nil && entry.Message == "Failed to label attested node"
+	}, time.Second, 10*time.Millisecond)
+	entry := s.logHook.LastEntry()
+	s.Require().Equal(logrus.WarnLevel, entry.Level)
+	s.Require().Equal("NODENAME", entry.Data["node_name"])
+	s.Require().EqualError(entry.Data["error"].(error), "an error")
+}
+
+func (s *AttestorSuite) TestAttestSuccessWithNodeBoundToken() {
+	tokenData := &TokenData{
+		namespace:          "NS3",
+		serviceAccountName: "SA3",
+		podName:            "PODNAME-3",
+		podUID:             "PODUID-3",
+		nodeName:           "NODENAME-3",
+		nodeUID:            "NODEUID-3",
+	}
+	token := s.signToken(s.fooSigner, tokenData)
+	s.mockClient.EXPECT().ValidateToken(token, defaultAudience).Return(createTokenStatus(tokenData, true), nil)
+	s.mockClient.EXPECT().GetPod("NS3", "PODNAME-3").Return(createPod("NODENAME-3", "PODUID-3"), nil)
+	s.mockClient.EXPECT().GetNode("NODENAME-3").Return(createNode("NODEUID-3"), nil)
+	labeled := s.expectLabelNode("NODENAME-3", nil)
+
+	resp, err := s.doAttest(makeAttestRequest("BAZ", token))
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.waitForLabelNode(labeled)
+	s.Require().Equal(resp.AgentId, "spiffe://example.org/spire/agent/k8s_psat/BAZ/NODEUID-3")
+	s.Require().Equal([]*common.Selector{
+		{Type: "k8s_psat", Value: "cluster:BAZ"},
+		{Type: "k8s_psat", Value: "agent_ns:NS3"},
+		{Type: "k8s_psat", Value: "agent_sa:SA3"},
+		{Type: "k8s_psat", Value: "agent_pod_name:PODNAME-3"},
+		{Type: "k8s_psat", Value: "agent_pod_uid:PODUID-3"},
+		{Type: "k8s_psat", Value: "agent_node_name:NODENAME-3"},
+		{Type: "k8s_psat", Value: "agent_node_uid:NODEUID-3"},
+	}, resp.Selectors)
+}
+
 func (s *AttestorSuite) TestAttestSuccess() {
 	// Success with FOO signed token
 	tokenData := &TokenData{
@@ -312,7 +455,7 @@ func (s *AttestorSuite) TestAttestSuccess() {
 	}
 	token := s.signToken(s.fooSigner, tokenData)
 	s.mockClient.EXPECT().ValidateToken(token, defaultAudience).Return(createTokenStatus(tokenData, true), nil)
-	s.mockClient.EXPECT().GetPod("NS1", "PODNAME-1").Return(createPod("NODENAME-1"), nil)
+	s.mockClient.EXPECT().GetPod("NS1", "PODNAME-1").Return(createPod("NODENAME-1", "PODUID-1"), nil)
 	s.mockClient.EXPECT().GetNode("NODENAME-1").Return(createNode("NODEUID-1"), nil)
 
 	resp, err := s.doAttest(makeAttestRequest("FOO", token))
@@ -341,7 +484,7 @@ func (s *AttestorSuite) TestAttestSuccess() {
 	}
 	token = s.signToken(s.barSigner, tokenData)
 	s.mockClient.EXPECT().ValidateToken(token, []string{"AUDIENCE"}).Return(createTokenStatus(tokenData, true), nil)
-	s.mockClient.EXPECT().GetPod("NS2", "PODNAME-2").Return(createPod("NODENAME-2"), nil)
+	s.mockClient.EXPECT().GetPod("NS2", "PODNAME-2").Return(createPod("NODENAME-2", "PODUID-2"), nil)
 	s.mockClient.EXPECT().GetNode("NODENAME-2").Return(createNode("NODEUID-2"), nil)
 
 	// Success with BAR signed token
@@ -361,6 +504,104 @@ func (s *AttestorSuite) TestAttestSuccess() {
 	}, resp.Selectors)
 }
 
+func (s *AttestorSuite) TestUnlabelEvictedNodes() {
+	s.agentStore.SetAgentInfo(&hostservices.AgentInfo{
+		AgentId: "spiffe://example.org/spire/agent/k8s_psat/BAZ/NODEUID-1",
+	})
+
+	s.mockClient.EXPECT().GetNodesWithLabel("spire.io/attested", "true").Return([]v1.Node{
+		*createNamedNode("NODENAME-1", "NODEUID-1"),
+		*createNamedNode("NODENAME-2", "NODEUID-2"),
+		*createNamedNode("NODENAME-3", "NODEUID-3"),
+	}, nil)
+
+	// Only the label of the nodes whose agent is no longer attested is removed
+	s.mockClient.EXPECT().UnlabelNode("NODENAME-2", "spire.io/attested").Return(nil)
+	s.mockClient.EXPECT().UnlabelNode("NODENAME-3", "spire.io/attested").Return(errors.New("an error"))
+
+	s.psat.unlabelEvictedNodes(context.Background(), s.psat.config)
+
+	entry := s.logHook.LastEntry()
+	s.Require().NotNil(entry)
+	s.Require().Equal("Failed to unlabel node of evicted agent", entry.Message)
+	s.Require().Equal("NODENAME-3", entry.Data["node_name"])
+	s.Require().EqualError(entry.Data["error"].(error), "an error")
+}
+
+func (s *AttestorSuite) TestUnlabelEvictedNodesFailsToListNodes() {
+	s.mockClient.EXPECT().GetNodesWithLabel("spire.io/attested", "true").Return(nil, errors.New("an error"))
+
+	s.psat.unlabelEvictedNodes(context.Background(), s.psat.config)
+
+	entry := s.logHook.LastEntry()
+	s.Require().NotNil(entry)
+	s.Require().Equal("Failed to list attested nodes", entry.Message)
+	s.Require().Equal("BAZ", entry.Data["cluster"])
+}
+
+func (s *AttestorSuite) TestUnlabelEvictedNodesSkipsRecentlyLabeledNodes() {
+	tokenData := &TokenData{
+		namespace:          "NS3",
+		serviceAccountName: "SA3",
+		podName:            "PODNAME-3",
+		podUID:             "PODUID-3",
+		nodeName:           "NODENAME-3",
+		nodeUID:            "NODEUID-3",
+	}
+	token := s.signToken(s.fooSigner, tokenData)
+	s.mockClient.EXPECT().ValidateToken(token, defaultAudience).Return(createTokenStatus(tokenData, true), nil)
+	s.mockClient.EXPECT().GetPod("NS3", "PODNAME-3").Return(createPod("NODENAME-3", "PODUID-3"), nil)
+	s.mockClient.EXPECT().GetNode("NODENAME-3").Return(createNode("NODEUID-3"), nil)
+	labeled := s.expectLabelNode("NODENAME-3", nil)
+
+	_, err := s.doAttest(makeAttestRequest("BAZ", token))
+	s.Require().NoError(err)
+	s.waitForLabelNode(labeled)
+
+	// The server has not stored the attested node yet, but the node was just
+	// labeled so the label is kept
+	s.mockClient.EXPECT().GetNodesWithLabel("spire.io/attested", "true").Return([]v1.Node{
+		*createNamedNode("NODENAME-3", "NODEUID-3"),
+	}, nil)
+	s.psat.unlabelEvictedNodes(context.Background(), s.psat.config)
+
+	// Once the interval has passed the label is removed
+	s.psat.setLabeledAt("spiffe://example.org/spire/agent/k8s_psat/BAZ/NODEUID-3", time.Now().Add(-attestedNodeLabelInterval))
+	s.mockClient.EXPECT().GetNodesWithLabel("spire.io/attested", "true").Return([]v1.Node{
+		*createNamedNode("NODENAME-3", "NODEUID-3"),
+	}, nil)
+	s.mockClient.EXPECT().UnlabelNode("NODENAME-3", "spire.io/attested").Return(nil)
+	s.psat.unlabelEvictedNodes(context.Background(), s.psat.config)
+}
+
+func (s *AttestorSuite) TestCloseStopsUnlabeling() {
+	attestor := New()
+	_, err := attestor.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `
+		clusters = {
+			"BAZ" = {
+				service_account_whitelist = ["NS3:SA3"]
+				attested_node_label = "spire.io/attested"
+			}
+		}
+		`,
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(attestor.stopUnlabeling)
+
+	// Unloading the plugin closes it
+	var plugin nodeattestor.Plugin
+	done := spiretest.LoadPlugin(s.T(), builtin(attestor), &plugin,
+		spiretest.HostService(hostservices.AgentStoreHostServiceServer(s.agentStore)),
+	)
+	done()
+
+	attestor.mu.RLock()
+	defer attestor.mu.RUnlock()
+	s.Require().Nil(attestor.stopUnlabeling)
+}
+
 func (s *AttestorSuite) TestConfigure() {
 	// malformed configuration
 	resp, err := s.attestor.Configure(context.Background(), &plugin.ConfigureRequest{
@@ -439,7 +680,9 @@ func (s *AttestorSuite) signToken(signer jose.Signer, tokenData *TokenData) stri
 
 func (s *AttestorSuite) newAttestor() nodeattestor.Plugin {
 	var plugin nodeattestor.Plugin
-	s.LoadPlugin(BuiltIn(), &plugin)
+	s.LoadPlugin(BuiltIn(), &plugin,
+		spiretest.HostService(hostservices.AgentStoreHostServiceServer(s.agentStore)),
+	)
 	return plugin
 }
 
@@ -460,6 +703,12 @@ func (s *AttestorSuite) configureAttestor() nodeattestor.Plugin {
 				kube_config_file= ""
 				audience = ["AUDIENCE"]
 			}
+			"BAZ" = {
+				service_account_whitelist = ["NS3:SA3"]
+				kube_config_file= ""
+				require_node_bound_token = true
+				attested_node_label = "spire.io/attested"
+			}
 		}
 		`),
 		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
@@ -470,12 +719,40 @@ func (s *AttestorSuite) configureAttestor() nodeattestor.Plugin {
 	s.mockClient = k8s_apiserver_mock.NewMockClient(s.mockCtrl)
 	attestor.config.clusters["FOO"].client = s.mockClient
 	attestor.config.clusters["BAR"].client = s.mockClient
+	attestor.config.clusters["BAZ"].client = s.mockClient
+
+	log, logHook := test.NewNullLogger()
+	s.logHook = logHook
+	s.psat = attestor
 
 	var plugin nodeattestor.Plugin
-	s.LoadPlugin(builtin(attestor), &plugin)
+	s.LoadPlugin(builtin(attestor), &plugin,
+		spiretest.Logger(log),
+		spiretest.HostService(hostservices.AgentStoreHostServiceServer(s.agentStore)),
+	)
 	return plugin
 }
 
+// expectLabelNode expects the node to be labeled as attested. The node is
+// labeled after the response is sent, so the returned channel is closed once
+// it is labeled.
+func (s *AttestorSuite) expectLabelNode(nodeName string, err error) <-chan struct{} {
+	labeled := make(chan struct{})
+	s.mockClient.EXPECT().LabelNode(nodeName, "spire.io/attested", "true").DoAndReturn(func(string, string, string) error {
+		close(labeled)
+		return err
+	})
+	return labeled
+}
+
+func (s *AttestorSuite) waitForLabelNode(labeled <-chan struct{}) {
+	select {
+	case <-labeled:
+	case <-time.After(time.Second):
+		s.FailNow("timed out waiting for the node to be labeled")
+	}
+}
+
 func (s *AttestorSuite) doAttest(req *nodeattestor.AttestRequest) (*nodeattestor.AttestResponse, error) {
 	return s.doAttestOnAttestor(s.attestor, req)
 }
@@ -538,6 +815,10 @@ func createTokenStatus(tokenData *TokenData, authenticated bool) *authv1.TokenRe
 	values := make(map[string]authv1.ExtraValue)
 	values["authentication.kubernetes.io/pod-name"] = authv1.ExtraValue([]string{tokenData.podName})
 	values["authentication.kubernetes.io/pod-uid"] = authv1.ExtraValue([]string{tokenData.podUID})
+	if tokenData.nodeName != "" {
+		values["authentication.kubernetes.io/node-name"] = authv1.ExtraValue([]string{tokenData.nodeName})
+		values["authentication.kubernetes.io/node-uid"] = authv1.ExtraValue([]string{tokenData.nodeUID})
+	}
 	return &authv1.TokenReviewStatus{
 		Authenticated: authenticated,
 		User: authv1.UserInfo{
@@ -547,9 +828,10 @@ func createTokenStatus(tokenData *TokenData, authenticated bool) *authv1.TokenRe
 	}
 }
 
-func createPod(nodeName string) *v1.Pod {
+func createPod(nodeName, podUID string) *v1.Pod {
 	return &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
+			UID: types.UID(podUID),
 			Labels: map[string]string{
 				"PODLABEL-A": "A",
 				"PODLABEL-B": "B",
@@ -572,3 +854,9 @@ func createNode(nodeUID string) *v1.Node {
 		},
 	}
 }
+
+func createNamedNode(nodeName, nodeUID string) *v1.Node {
+	node := createNode(nodeUID)
+	node.Name = nodeName
+	return node
+}
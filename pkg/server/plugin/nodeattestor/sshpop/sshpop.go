@@ -71,8 +71,14 @@ func (p *Plugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) error {
 		return err
 	}
 
+	selectors, err := handshaker.Selectors()
+	if err != nil {
+		return err
+	}
+
 	return stream.Send(&nodeattestor.AttestResponse{
-		AgentId: agentID,
+		AgentId:   agentID,
+		Selectors: selectors,
 	})
 }
 
@@ -106,7 +106,10 @@ func (s *Suite) TestAttestSuccess() {
 	require.NoError(err)
 	require.Equal("spiffe://example.org/spire/agent/sshpop/21Aic_muK032oJMhLfU1_CMNcGmfAnvESeuH5zyFw_g", resp.AgentId)
 	require.Nil(resp.Challenge)
-	require.Len(resp.Selectors, 0)
+	require.Equal([]*common.Selector{
+		{Type: "sshpop", Value: "key_id:foo-host"},
+		{Type: "sshpop", Value: "principal:foo-host"},
+	}, resp.Selectors)
 }
 
 func (s *Suite) TestAttestFailure() {
@@ -71,6 +71,11 @@ func (s *Suite) TestAttestSuccess() {
 			giveConfig:    `agent_path_template = "/cn/{{ .Subject.CommonName }}"`,
 			expectAgentID: "spiffe://example.org/spire/agent/cn/some%20common%20name",
 		},
+		{
+			desc:          "success with agent id templated from serial number",
+			giveConfig:    `agent_path_template = "/serial/{{ .SerialNumberHex }}"`,
+			expectAgentID: "spiffe://example.org/spire/agent/serial/1",
+		},
 	}
 
 	for _, tt := range tests {
@@ -165,6 +165,7 @@ func (p *IIDResolverPlugin) resolveSpiffeID(ctx context.Context, spiffeID string
 
 	for _, reservation := range resp.Reservations {
 		for _, instance := range reservation.Instances {
+			addSelectors(resolveInstance(instance))
 			addSelectors(resolveTags(instance.Tags))
 			addSelectors(resolveSecurityGroups(instance.SecurityGroups))
 			if instance.IamInstanceProfile != nil && instance.IamInstanceProfile.Arn != nil {
@@ -230,6 +231,17 @@ func (p *IIDResolverPlugin) getRegionClient(region string) (awsClient, error) {
 	return client, nil
 }
 
+func resolveInstance(instance *ec2.Instance) []string {
+	var values []string
+	if instance.InstanceId != nil {
+		values = append(values, fmt.Sprintf("instance:id:%s", aws.StringValue(instance.InstanceId)))
+	}
+	if instance.Placement != nil && instance.Placement.AvailabilityZone != nil {
+		values = append(values, fmt.Sprintf("az:%s", aws.StringValue(instance.Placement.AvailabilityZone)))
+	}
+	return values
+}
+
 func resolveTags(tags []*ec2.Tag) []string {
 	values := make([]string, 0, len(tags))
 	for _, tag := range tags {
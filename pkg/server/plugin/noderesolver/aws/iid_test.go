@@ -88,6 +88,18 @@ func (s *IIDResolverSuite) TestResolve() {
 	s.client.SetInstance(&ec2.Instance{})
 	s.assertResolveSuccess()
 
+	// instance with ID and placement
+	s.client.SetInstance(&ec2.Instance{
+		InstanceId: aws.String("INSTANCE"),
+		Placement: &ec2.Placement{
+			AvailabilityZone: aws.String("us-west-2b"),
+		},
+	})
+	s.assertResolveSuccess([]string{
+		"az:us-west-2b",
+		"instance:id:INSTANCE",
+	})
+
 	// instance with tags
 	s.client.SetInstance(&ec2.Instance{
 		Tags: []*ec2.Tag{
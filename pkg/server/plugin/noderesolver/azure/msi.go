@@ -35,6 +35,7 @@ var (
 
 	reAgentIDPath            = regexp.MustCompile(`^/spire/agent/azure_msi/([^/]+)/([^/]+)`)
 	reVirtualMachineID       = regexp.MustCompile(`^/subscriptions/[^/]+/resourceGroups/([^/]+)/providers/Microsoft.Compute/virtualMachines/([^/]+)$`)
+	reVMScaleSetID           = regexp.MustCompile(`^/subscriptions/[^/]+/resourceGroups/([^/]+)/providers/Microsoft.Compute/virtualMachineScaleSets/([^/]+)$`)
 	reNetworkSecurityGroupID = regexp.MustCompile(`^/subscriptions/[^/]+/resourceGroups/([^/]+)/providers/Microsoft.Network/networkSecurityGroups/([^/]+)$`)
 	reNetworkInterfaceID     = regexp.MustCompile(`^/subscriptions/[^/]+/resourceGroups/([^/]+)/providers/Microsoft.Network/networkInterfaces/([^/]+)$`)
 	reVirtualNetworkSubnetID = regexp.MustCompile(`^/subscriptions/[^/]+/resourceGroups/([^/]+)/providers/Microsoft.Network/virtualNetworks/([^/]+)/subnets/([^/]+)$`)
@@ -198,13 +199,19 @@ func (p *MSIResolverPlugin) resolveSpiffeID(ctx context.Context, spiffeID string
 	}
 
 	// Retrieve the resource belonging to the principal id.
-	vmResourceID, err := client.GetVirtualMachineResourceID(ctx, principalID)
+	resourceID, err := client.GetPrincipalResourceID(ctx, principalID)
 	if err != nil {
 		return nil, msiError.New("unable to get resource for principal %q: %v", principalID, err)
 	}
 
-	// parse out the resource group and vm name from the resource ID
-	vmResourceGroup, vmName, err := parseVirtualMachineID(vmResourceID)
+	// The principal belongs either to a virtual machine or, when the node is
+	// an instance of a scale set, to the virtual machine scale set.
+	var resourceSelectors []string
+	if vmssResourceGroup, vmssName, ok := parseVirtualMachineScaleSetID(resourceID); ok {
+		resourceSelectors, err = getVirtualMachineScaleSetSelectors(ctx, client, vmssResourceGroup, vmssName)
+	} else {
+		resourceSelectors, err = getVirtualMachineSelectors(ctx, client, resourceID)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -213,12 +220,39 @@ func (p *MSIResolverPlugin) resolveSpiffeID(ctx context.Context, spiffeID string
 	// individual selectors (e.g. the virtual network for each interface)
 	selectorMap := map[string]bool{
 		selectorValue("subscription-id", client.SubscriptionID()): true,
-		selectorValue("vm-name", vmResourceGroup, vmName):         true,
 	}
-	addSelectors := func(values []string) {
-		for _, value := range values {
-			selectorMap[value] = true
-		}
+	for _, value := range resourceSelectors {
+		selectorMap[value] = true
+	}
+
+	// sort and return selectors
+	selectorValues := make([]string, 0, len(selectorMap))
+	for selectorValue := range selectorMap {
+		selectorValues = append(selectorValues, selectorValue)
+	}
+	sort.Strings(selectorValues)
+
+	selectors := &common.Selectors{}
+	for _, selectorValue := range selectorValues {
+		selectors.Entries = append(selectors.Entries, &common.Selector{
+			Type:  pluginName,
+			Value: selectorValue,
+		})
+	}
+
+	return selectors, nil
+}
+
+func getVirtualMachineSelectors(ctx context.Context, client apiClient, vmResourceID string) ([]string, error) {
+	// parse out the resource group and vm name from the resource ID
+	vmResourceGroup, vmName, err := parseVirtualMachineID(vmResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	selectors := []string{
+		selectorValue("resource-group", vmResourceGroup),
+		selectorValue("vm-name", vmResourceGroup, vmName),
 	}
 
 	// pull the VM information and gather selectors
@@ -231,22 +265,69 @@ func (p *MSIResolverPlugin) resolveSpiffeID(ctx context.Context, spiffeID string
 		if err != nil {
 			return nil, err
 		}
-		addSelectors(networkProfileSelectors)
+		selectors = append(selectors, networkProfileSelectors...)
 	}
 
-	// sort and return selectors
-	selectorValues := make([]string, 0, len(selectorMap))
-	for selectorValue := range selectorMap {
-		selectorValues = append(selectorValues, selectorValue)
+	return selectors, nil
+}
+
+func getVirtualMachineScaleSetSelectors(ctx context.Context, client apiClient, vmssResourceGroup, vmssName string) ([]string, error) {
+	selectors := []string{
+		selectorValue("resource-group", vmssResourceGroup),
+		selectorValue("vmss-name", vmssResourceGroup, vmssName),
 	}
-	sort.Strings(selectorValues)
 
-	selectors := &common.Selectors{}
-	for _, selectorValue := range selectorValues {
-		selectors.Entries = append(selectors.Entries, &common.Selector{
-			Type:  pluginName,
-			Value: selectorValue,
-		})
+	// pull the scale set information and gather selectors from the network
+	// configuration shared by its instances
+	vmss, err := client.GetVirtualMachineScaleSet(ctx, vmssResourceGroup, vmssName)
+	if err != nil {
+		return nil, msiError.New("unable to get virtual machine scale set %q: %v", resourceGroupName(vmssResourceGroup, vmssName), err)
+	}
+	if props := vmss.VirtualMachineScaleSetProperties; props != nil {
+		if profile := props.VirtualMachineProfile; profile != nil && profile.NetworkProfile != nil {
+			networkProfileSelectors, err := getScaleSetNetworkProfileSelectors(profile.NetworkProfile)
+			if err != nil {
+				return nil, err
+			}
+			selectors = append(selectors, networkProfileSelectors...)
+		}
+	}
+
+	return selectors, nil
+}
+
+func getScaleSetNetworkProfileSelectors(networkProfile *compute.VirtualMachineScaleSetNetworkProfile) ([]string, error) {
+	if networkProfile.NetworkInterfaceConfigurations == nil {
+		return nil, nil
+	}
+
+	var selectors []string
+	for _, nic := range *networkProfile.NetworkInterfaceConfigurations {
+		props := nic.VirtualMachineScaleSetNetworkConfigurationProperties
+		if props == nil {
+			continue
+		}
+		if nsg := props.NetworkSecurityGroup; nsg != nil && nsg.ID != nil {
+			nsgResourceGroup, nsgName, err := parseNetworkSecurityGroupID(*nsg.ID)
+			if err != nil {
+				return nil, err
+			}
+			selectors = append(selectors, selectorValue("network-security-group", nsgResourceGroup, nsgName))
+		}
+		if ipcs := props.IPConfigurations; ipcs != nil {
+			for _, ipc := range *ipcs {
+				if ipcProps := ipc.VirtualMachineScaleSetIPConfigurationProperties; ipcProps != nil {
+					if subnet := ipcProps.Subnet; subnet != nil && subnet.ID != nil {
+						subResourceGroup, subVirtualNetwork, subName, err := parseVirtualNetworkSubnetID(*subnet.ID)
+						if err != nil {
+							return nil, err
+						}
+						selectors = append(selectors, selectorValue("virtual-network", subResourceGroup, subVirtualNetwork))
+						selectors = append(selectors, selectorValue("virtual-network-subnet", subResourceGroup, subVirtualNetwork, subName))
+					}
+				}
+			}
+		}
 	}
 
 	return selectors, nil
@@ -326,6 +407,14 @@ func parseVirtualMachineID(id string) (resourceGroup, name string, err error) {
 	return m[1], m[2], nil
 }
 
+func parseVirtualMachineScaleSetID(id string) (resourceGroup, name string, ok bool) {
+	m := reVMScaleSetID.FindStringSubmatch(id)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
 func parseNetworkSecurityGroupID(id string) (resourceGroup, name string, err error) {
 	m := reNetworkSecurityGroupID.FindStringSubmatch(id)
 	if m == nil {
@@ -18,8 +18,9 @@ import (
 )
 
 const (
-	azureAgentID = "spiffe://example.org/spire/agent/azure_msi/TENANT/PRINCIPAL"
-	vmResourceID = "/subscriptions/SUBSCRIPTIONID/resourceGroups/RESOURCEGROUP/providers/Microsoft.Compute/virtualMachines/VIRTUALMACHINE"
+	azureAgentID   = "spiffe://example.org/spire/agent/azure_msi/TENANT/PRINCIPAL"
+	vmResourceID   = "/subscriptions/SUBSCRIPTIONID/resourceGroups/RESOURCEGROUP/providers/Microsoft.Compute/virtualMachines/VIRTUALMACHINE"
+	vmssResourceID = "/subscriptions/SUBSCRIPTIONID/resourceGroups/RESOURCEGROUP/providers/Microsoft.Compute/virtualMachineScaleSets/SCALESET"
 )
 
 var (
@@ -31,9 +32,15 @@ var (
 
 	// these are expected selectors
 	vmSelectors = []string{
+		"resource-group:RESOURCEGROUP",
 		"subscription-id:SUBSCRIPTION",
 		"vm-name:RESOURCEGROUP:VIRTUALMACHINE",
 	}
+	vmssSelectors = []string{
+		"resource-group:RESOURCEGROUP",
+		"subscription-id:SUBSCRIPTION",
+		"vmss-name:RESOURCEGROUP:SCALESET",
+	}
 	niSelectors = []string{
 		"network-security-group:NSGRESOURCEGROUP:NETWORKSECURITYGROUP",
 		"virtual-network:NETRESOURCEGROUP:VIRTUALNETWORK",
@@ -100,19 +107,19 @@ func (s *MSIResolverSuite) TestResolveWithUnrecognizedTenant() {
 }
 
 func (s *MSIResolverSuite) TestResolveWithNoVirtualMachineResource() {
-	s.api.SetVirtualMachineResourceID("PRINCIPAL", "")
+	s.api.SetPrincipalResourceID("PRINCIPAL", "")
 	s.assertResolveFailure(azureAgentID,
 		`azure-msi: unable to get resource for principal "PRINCIPAL": not found`)
 }
 
 func (s *MSIResolverSuite) TestResolveWithMalformedResourceID() {
-	s.api.SetVirtualMachineResourceID("PRINCIPAL", malformedResourceID)
+	s.api.SetPrincipalResourceID("PRINCIPAL", malformedResourceID)
 	s.assertResolveFailure(azureAgentID,
 		`azure-msi: malformed virtual machine ID "MALFORMEDRESOURCEID"`)
 }
 
 func (s *MSIResolverSuite) TestResolveWithNoVirtualMachineInfo() {
-	s.api.SetVirtualMachineResourceID("PRINCIPAL", vmResourceID)
+	s.api.SetPrincipalResourceID("PRINCIPAL", vmResourceID)
 	s.assertResolveFailure(azureAgentID,
 		`azure-msi: unable to get virtual machine "RESOURCEGROUP:VIRTUALMACHINE"`)
 }
@@ -185,6 +192,61 @@ func (s *MSIResolverSuite) TestResolveVirtualMachine() {
 	s.assertResolveSuccess(vmSelectors, niSelectors)
 }
 
+func (s *MSIResolverSuite) TestResolveWithNoVirtualMachineScaleSetInfo() {
+	s.api.SetPrincipalResourceID("PRINCIPAL", vmssResourceID)
+	s.assertResolveFailure(azureAgentID,
+		`azure-msi: unable to get virtual machine scale set "RESOURCEGROUP:SCALESET"`)
+}
+
+func (s *MSIResolverSuite) TestResolveVirtualMachineScaleSet() {
+	vmss := &compute.VirtualMachineScaleSet{
+		VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{},
+	}
+	s.setVirtualMachineScaleSet(vmss)
+
+	// no virtual machine profile
+	s.assertResolveSuccess(vmssSelectors)
+
+	// virtual machine profile with no network profile
+	vmss.VirtualMachineProfile = &compute.VirtualMachineScaleSetVMProfile{}
+	s.assertResolveSuccess(vmssSelectors)
+
+	// network profile with no interface configurations
+	networkProfile := &compute.VirtualMachineScaleSetNetworkProfile{}
+	vmss.VirtualMachineProfile.NetworkProfile = networkProfile
+	s.assertResolveSuccess(vmssSelectors)
+
+	// network profile with empty interface configuration
+	networkProfile.NetworkInterfaceConfigurations = &[]compute.VirtualMachineScaleSetNetworkConfiguration{{}}
+	s.assertResolveSuccess(vmssSelectors)
+
+	// interface configuration with malformed security group
+	props := &compute.VirtualMachineScaleSetNetworkConfigurationProperties{
+		NetworkSecurityGroup: &compute.SubResource{ID: &malformedResourceID},
+	}
+	networkProfile.NetworkInterfaceConfigurations = &[]compute.VirtualMachineScaleSetNetworkConfiguration{
+		{VirtualMachineScaleSetNetworkConfigurationProperties: props},
+	}
+	s.assertResolveFailure(azureAgentID,
+		`azure-msi: malformed network security group ID "MALFORMEDRESOURCEID"`)
+
+	// interface configuration with ip configuration with malformed subnet
+	ipcProps := &compute.VirtualMachineScaleSetIPConfigurationProperties{
+		Subnet: &compute.APIEntityReference{ID: &malformedResourceID},
+	}
+	props.NetworkSecurityGroup = nil
+	props.IPConfigurations = &[]compute.VirtualMachineScaleSetIPConfiguration{
+		{VirtualMachineScaleSetIPConfigurationProperties: ipcProps},
+	}
+	s.assertResolveFailure(azureAgentID,
+		`azure-msi: malformed virtual network subnet ID "MALFORMEDRESOURCEID"`)
+
+	// interface configuration with good subnet and security group
+	props.NetworkSecurityGroup = &compute.SubResource{ID: &nsgResourceID}
+	ipcProps.Subnet.ID = &subnetResourceID
+	s.assertResolveSuccess(vmssSelectors, niSelectors)
+}
+
 func (s *MSIResolverSuite) TestConfigure() {
 	resp, err := s.resolver.Configure(context.Background(), &plugin.ConfigureRequest{
 		Configuration: "blah",
@@ -313,10 +375,15 @@ func (s *MSIResolverSuite) doResolve(spiffeID string) (*noderesolver.ResolveResp
 }
 
 func (s *MSIResolverSuite) setVirtualMachine(vm *compute.VirtualMachine) {
-	s.api.SetVirtualMachineResourceID("PRINCIPAL", vmResourceID)
+	s.api.SetPrincipalResourceID("PRINCIPAL", vmResourceID)
 	s.api.SetVirtualMachine("RESOURCEGROUP", "VIRTUALMACHINE", vm)
 }
 
+func (s *MSIResolverSuite) setVirtualMachineScaleSet(vmss *compute.VirtualMachineScaleSet) {
+	s.api.SetPrincipalResourceID("PRINCIPAL", vmssResourceID)
+	s.api.SetVirtualMachineScaleSet("RESOURCEGROUP", "SCALESET", vmss)
+}
+
 func (s *MSIResolverSuite) setNetworkInterface(ni *network.Interface) {
 	s.api.SetNetworkInterface("RESOURCEGROUP", "NETWORKINTERFACE", ni)
 }
@@ -324,16 +391,18 @@ func (s *MSIResolverSuite) setNetworkInterface(ni *network.Interface) {
 type fakeAPIClient struct {
 	t testing.TB
 
-	vmResourceIDs     map[string]string
+	resourceIDs       map[string]string
 	virtualMachines   map[string]*compute.VirtualMachine
+	scaleSets         map[string]*compute.VirtualMachineScaleSet
 	networkInterfaces map[string]*network.Interface
 }
 
 func newFakeAPIClient(t testing.TB) *fakeAPIClient {
 	return &fakeAPIClient{
 		t:                 t,
-		vmResourceIDs:     make(map[string]string),
+		resourceIDs:       make(map[string]string),
 		virtualMachines:   make(map[string]*compute.VirtualMachine),
+		scaleSets:         make(map[string]*compute.VirtualMachineScaleSet),
 		networkInterfaces: make(map[string]*network.Interface),
 	}
 }
@@ -342,12 +411,12 @@ func (c *fakeAPIClient) SubscriptionID() string {
 	return "SUBSCRIPTION"
 }
 
-func (c *fakeAPIClient) SetVirtualMachineResourceID(principalID, resourceID string) {
-	c.vmResourceIDs[principalID] = resourceID
+func (c *fakeAPIClient) SetPrincipalResourceID(principalID, resourceID string) {
+	c.resourceIDs[principalID] = resourceID
 }
 
-func (c *fakeAPIClient) GetVirtualMachineResourceID(ctx context.Context, principalID string) (string, error) {
-	id := c.vmResourceIDs[principalID]
+func (c *fakeAPIClient) GetPrincipalResourceID(ctx context.Context, principalID string) (string, error) {
+	id := c.resourceIDs[principalID]
 	if id == "" {
 		return "", errors.New("not found")
 	}
@@ -366,6 +435,18 @@ func (c *fakeAPIClient) GetVirtualMachine(ctx context.Context, resourceGroup str
 	return vm, nil
 }
 
+func (c *fakeAPIClient) SetVirtualMachineScaleSet(resourceGroup string, name string, vmss *compute.VirtualMachineScaleSet) {
+	c.scaleSets[resourceGroupName(resourceGroup, name)] = vmss
+}
+
+func (c *fakeAPIClient) GetVirtualMachineScaleSet(ctx context.Context, resourceGroup string, name string) (*compute.VirtualMachineScaleSet, error) {
+	vmss := c.scaleSets[resourceGroupName(resourceGroup, name)]
+	if vmss == nil {
+		return nil, errors.New("not found")
+	}
+	return vmss, nil
+}
+
 func (c *fakeAPIClient) SetNetworkInterface(resourceGroup string, name string, ni *network.Interface) {
 	c.networkInterfaces[resourceGroupName(resourceGroup, name)] = ni
 }
@@ -0,0 +1,228 @@
+package entrypolicy
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/notifier"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtIn(New())
+}
+
+func builtIn(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin("entry_policy",
+		notifier.PluginServer(p),
+	)
+}
+
+type pluginConfig struct {
+	SPIFFEIDPatterns   []string `hcl:"spiffe_id_patterns"`
+	ForbiddenSelectors []string `hcl:"forbidden_selectors"`
+	MaxTTL             string   `hcl:"max_ttl"`
+	AllowAdmin         *bool    `hcl:"allow_admin"`
+	AllowDownstream    *bool    `hcl:"allow_downstream"`
+}
+
+// policy is the parsed form of the plugin configuration
+type policy struct {
+	spiffeIDPatterns   []*regexp.Regexp
+	forbiddenSelectors []forbiddenSelector
+	maxTTL             int32
+	allowAdmin         bool
+	allowDownstream    bool
+}
+
+// forbiddenSelector matches selectors of the given type. If value is empty,
+// every selector of that type matches.
+type forbiddenSelector struct {
+	Type  string
+	Value string
+}
+
+func (f forbiddenSelector) matches(s *common.Selector) bool {
+	if s.Type != f.Type {
+		return false
+	}
+	return f.Value == "" || s.Value == f.Value
+}
+
+func (f forbiddenSelector) String() string {
+	if f.Value == "" {
+		return f.Type
+	}
+	return f.Type + ":" + f.Value
+}
+
+// Plugin is a notifier that rejects registration entries that do not
+// conform to the configured policy.
+type Plugin struct {
+	mu     sync.RWMutex
+	policy *policy
+}
+
+func New() *Plugin {
+	return &Plugin{}
+}
+
+func (p *Plugin) Notify(ctx context.Context, req *notifier.NotifyRequest) (*notifier.NotifyResponse, error) {
+	return &notifier.NotifyResponse{}, nil
+}
+
+func (p *Plugin) NotifyAndAdvise(ctx context.Context, req *notifier.NotifyAndAdviseRequest) (*notifier.NotifyAndAdviseResponse, error) {
+	policy, err := p.getPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	var entry *common.RegistrationEntry
+	switch event := req.Event.(type) {
+	case *notifier.NotifyAndAdviseRequest_EntryCreating:
+		entry = event.EntryCreating.Entry
+	case *notifier.NotifyAndAdviseRequest_EntryUpdating:
+		entry = event.EntryUpdating.Entry
+	default:
+		return &notifier.NotifyAndAdviseResponse{}, nil
+	}
+
+	if entry == nil {
+		return nil, status.Error(codes.InvalidArgument, "event is missing entry")
+	}
+	if err := policy.check(entry); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	return &notifier.NotifyAndAdviseResponse{}, nil
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(pluginConfig)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode configuration: %v", err)
+	}
+
+	policy, err := parsePolicy(config)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	p.setPolicy(policy)
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) GetPluginInfo(ctx context.Context, req *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *Plugin) getPolicy() (*policy, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.policy == nil {
+		return nil, status.Error(codes.FailedPrecondition, "not configured")
+	}
+	return p.policy, nil
+}
+
+func (p *Plugin) setPolicy(policy *policy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.policy = policy
+}
+
+func parsePolicy(config *pluginConfig) (*policy, error) {
+	policy := &policy{
+		allowAdmin:      true,
+		allowDownstream: true,
+	}
+
+	for _, pattern := range config.SPIFFEIDPatterns {
+		// patterns must match the entire SPIFFE ID
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid SPIFFE ID pattern %q: %v", pattern, err)
+		}
+		policy.spiffeIDPatterns = append(policy.spiffeIDPatterns, re)
+	}
+
+	for _, selector := range config.ForbiddenSelectors {
+		parts := strings.SplitN(selector, ":", 2)
+		if parts[0] == "" {
+			return nil, fmt.Errorf("invalid forbidden selector %q: type is required", selector)
+		}
+		f := forbiddenSelector{Type: parts[0]}
+		if len(parts) == 2 {
+			f.Value = parts[1]
+		}
+		policy.forbiddenSelectors = append(policy.forbiddenSelectors, f)
+	}
+
+	if config.MaxTTL != "" {
+		maxTTL, err := time.ParseDuration(config.MaxTTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_ttl: %v", err)
+		}
+		if maxTTL < time.Second {
+			return nil, fmt.Errorf("invalid max_ttl: must be at least one second")
+		}
+		policy.maxTTL = int32(maxTTL / time.Second)
+	}
+
+	if config.AllowAdmin != nil {
+		policy.allowAdmin = *config.AllowAdmin
+	}
+	if config.AllowDownstream != nil {
+		policy.allowDownstream = *config.AllowDownstream
+	}
+
+	return policy, nil
+}
+
+func (p *policy) check(entry *common.RegistrationEntry) error {
+	if len(p.spiffeIDPatterns) > 0 && !p.matchesSPIFFEID(entry.SpiffeId) {
+		return fmt.Errorf("SPIFFE ID %q does not match any allowed pattern", entry.SpiffeId)
+	}
+
+	for _, selector := range entry.Selectors {
+		for _, f := range p.forbiddenSelectors {
+			if f.matches(selector) {
+				return fmt.Errorf("selector \"%s:%s\" is forbidden by %q", selector.Type, selector.Value, f)
+			}
+		}
+	}
+
+	if p.maxTTL > 0 {
+		// a TTL of zero means the server default TTL is used, which is not
+		// known to the plugin, so it must be explicitly set under a cap.
+		if entry.Ttl <= 0 || entry.Ttl > p.maxTTL {
+			return fmt.Errorf("TTL must be set and no greater than %d seconds", p.maxTTL)
+		}
+	}
+
+	if entry.Admin && !p.allowAdmin {
+		return fmt.Errorf("admin entries are not allowed")
+	}
+	if entry.Downstream && !p.allowDownstream {
+		return fmt.Errorf("downstream entries are not allowed")
+	}
+
+	return nil
+}
+
+func (p *policy) matchesSPIFFEID(spiffeID string) bool {
+	for _, re := range p.spiffeIDPatterns {
+		if re.MatchString(spiffeID) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,262 @@
+package entrypolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/plugin/notifier"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestConfigure(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config string
+		code   codes.Code
+		desc   string
+	}{
+		{
+			name: "malformed",
+			config: `
+				MALFORMED
+			`,
+			code: codes.InvalidArgument,
+			desc: "unable to decode configuration",
+		},
+		{
+			name: "bad SPIFFE ID pattern",
+			config: `
+				spiffe_id_patterns = ["spiffe://example.org/(["]
+			`,
+			code: codes.InvalidArgument,
+			desc: "invalid SPIFFE ID pattern",
+		},
+		{
+			name: "forbidden selector missing type",
+			config: `
+				forbidden_selectors = [":uid:0"]
+			`,
+			code: codes.InvalidArgument,
+			desc: "type is required",
+		},
+		{
+			name: "bad max TTL",
+			config: `
+				max_ttl = "forever"
+			`,
+			code: codes.InvalidArgument,
+			desc: "invalid max_ttl",
+		},
+		{
+			name: "max TTL too small",
+			config: `
+				max_ttl = "10ms"
+			`,
+			code: codes.InvalidArgument,
+			desc: "must be at least one second",
+		},
+		{
+			name:   "empty",
+			config: ``,
+			code:   codes.OK,
+		},
+		{
+			name: "success",
+			config: `
+				spiffe_id_patterns = ["spiffe://example.org/ns/[^/]+/sa/[^/]+"]
+				forbidden_selectors = ["unix:uid:0", "docker"]
+				max_ttl = "1h"
+				allow_admin = false
+				allow_downstream = false
+			`,
+			code: codes.OK,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var plugin notifier.Plugin
+			pluginDone := spiretest.LoadPlugin(t, BuiltIn(), &plugin)
+			defer pluginDone()
+
+			resp, err := plugin.Configure(context.Background(), &spi.ConfigureRequest{Configuration: tt.config})
+			if tt.code != codes.OK {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.desc)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+		})
+	}
+}
+
+func TestGetPluginInfo(t *testing.T) {
+	resp, err := New().GetPluginInfo(context.Background(), &spi.GetPluginInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &spi.GetPluginInfoResponse{}, resp)
+}
+
+func TestNotifyAndAdviseRequiresConfiguration(t *testing.T) {
+	var plugin notifier.Plugin
+	pluginDone := spiretest.LoadPlugin(t, BuiltIn(), &plugin)
+	defer pluginDone()
+
+	_, err := plugin.NotifyAndAdvise(context.Background(), entryCreating(&common.RegistrationEntry{}))
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "not configured")
+}
+
+func TestNotifyAndAdviseIgnoresOtherEvents(t *testing.T) {
+	plugin, done := loadConfigured(t, `max_ttl = "1h"`)
+	defer done()
+
+	_, err := plugin.NotifyAndAdvise(context.Background(), &notifier.NotifyAndAdviseRequest{
+		Event: &notifier.NotifyAndAdviseRequest_BundleLoaded{
+			BundleLoaded: &notifier.BundleLoaded{},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestNotifyAndAdvise(t *testing.T) {
+	const config = `
+		spiffe_id_patterns = [
+			"spiffe://example.org/ns/[^/]+/sa/[^/]+",
+			"spiffe://example.org/host/.+",
+		]
+		forbidden_selectors = ["unix:uid:0", "docker"]
+		max_ttl = "1h"
+		allow_admin = false
+		allow_downstream = false
+	`
+
+	validEntry := func() *common.RegistrationEntry {
+		return &common.RegistrationEntry{
+			SpiffeId: "spiffe://example.org/ns/foo/sa/bar",
+			ParentId: "spiffe://example.org/spire/agent/x509pop/abc",
+			Selectors: []*common.Selector{
+				{Type: "unix", Value: "uid:1000"},
+			},
+			Ttl: 3600,
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(*common.RegistrationEntry)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(*common.RegistrationEntry) {},
+		},
+		{
+			name: "valid with second pattern",
+			modify: func(e *common.RegistrationEntry) {
+				e.SpiffeId = "spiffe://example.org/host/web-1"
+			},
+		},
+		{
+			name: "SPIFFE ID not matching",
+			modify: func(e *common.RegistrationEntry) {
+				e.SpiffeId = "spiffe://example.org/ns/foo/sa/bar/extra"
+			},
+			err: `SPIFFE ID "spiffe://example.org/ns/foo/sa/bar/extra" does not match any allowed pattern`,
+		},
+		{
+			name: "forbidden selector value",
+			modify: func(e *common.RegistrationEntry) {
+				e.Selectors = append(e.Selectors, &common.Selector{Type: "unix", Value: "uid:0"})
+			},
+			err: `selector "unix:uid:0" is forbidden by "unix:uid:0"`,
+		},
+		{
+			name: "forbidden selector type",
+			modify: func(e *common.RegistrationEntry) {
+				e.Selectors = append(e.Selectors, &common.Selector{Type: "docker", Value: "label:foo:bar"})
+			},
+			err: `selector "docker:label:foo:bar" is forbidden by "docker"`,
+		},
+		{
+			name: "TTL too large",
+			modify: func(e *common.RegistrationEntry) {
+				e.Ttl = 3601
+			},
+			err: "TTL must be set and no greater than 3600 seconds",
+		},
+		{
+			name: "TTL not set",
+			modify: func(e *common.RegistrationEntry) {
+				e.Ttl = 0
+			},
+			err: "TTL must be set and no greater than 3600 seconds",
+		},
+		{
+			name: "admin",
+			modify: func(e *common.RegistrationEntry) {
+				e.Admin = true
+			},
+			err: "admin entries are not allowed",
+		},
+		{
+			name: "downstream",
+			modify: func(e *common.RegistrationEntry) {
+				e.Downstream = true
+			},
+			err: "downstream entries are not allowed",
+		},
+	}
+
+	plugin, done := loadConfigured(t, config)
+	defer done()
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			entry := validEntry()
+			tt.modify(entry)
+
+			for _, req := range []*notifier.NotifyAndAdviseRequest{entryCreating(entry), entryUpdating(entry)} {
+				_, err := plugin.NotifyAndAdvise(context.Background(), req)
+				if tt.err != "" {
+					spiretest.RequireGRPCStatus(t, err, codes.PermissionDenied, tt.err)
+					continue
+				}
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func loadConfigured(t *testing.T, config string) (notifier.Plugin, func()) {
+	var plugin notifier.Plugin
+	pluginDone := spiretest.LoadPlugin(t, BuiltIn(), &plugin)
+
+	_, err := plugin.Configure(context.Background(), &spi.ConfigureRequest{Configuration: config})
+	require.NoError(t, err)
+	return plugin, pluginDone
+}
+
+func entryCreating(entry *common.RegistrationEntry) *notifier.NotifyAndAdviseRequest {
+	return &notifier.NotifyAndAdviseRequest{
+		Event: &notifier.NotifyAndAdviseRequest_EntryCreating{
+			EntryCreating: &notifier.EntryCreating{
+				Entry: entry,
+			},
+		},
+	}
+}
+
+func entryUpdating(entry *common.RegistrationEntry) *notifier.NotifyAndAdviseRequest {
+	return &notifier.NotifyAndAdviseRequest{
+		Event: &notifier.NotifyAndAdviseRequest_EntryUpdating{
+			EntryUpdating: &notifier.EntryUpdating{
+				Entry: entry,
+			},
+		},
+	}
+}
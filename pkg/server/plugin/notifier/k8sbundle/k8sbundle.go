@@ -48,10 +48,19 @@ func builtIn(p *Plugin) catalog.Plugin {
 }
 
 type pluginConfig struct {
-	Namespace          string `hcl:"namespace"`
-	ConfigMap          string `hcl:"config_map"`
-	ConfigMapKey       string `hcl:"config_map_key"`
-	KubeConfigFilePath string `hcl:"kube_config_file_path"`
+	Namespace          string            `hcl:"namespace"`
+	ConfigMap          string            `hcl:"config_map"`
+	ConfigMapKey       string            `hcl:"config_map_key"`
+	KubeConfigFilePath string            `hcl:"kube_config_file_path"`
+	ConfigMaps         []configMapConfig `hcl:"config_maps"`
+	VerifyWrite        bool              `hcl:"verify_write"`
+}
+
+// configMapConfig is a ConfigMap the bundle is pushed to
+type configMapConfig struct {
+	Namespace    string `hcl:"namespace"`
+	ConfigMap    string `hcl:"config_map"`
+	ConfigMapKey string `hcl:"config_map_key"`
 }
 
 type Plugin struct {
@@ -126,6 +135,9 @@ func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (resp
 		return nil, k8sErr.New("unable to decode configuration: %v", err)
 	}
 
+	if len(config.ConfigMaps) > 0 && (config.Namespace != "" || config.ConfigMap != "") {
+		return nil, k8sErr.New("namespace and config_map cannot be set along with config_maps")
+	}
 	if config.Namespace == "" {
 		config.Namespace = defaultNamespace
 	}
@@ -136,6 +148,26 @@ func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (resp
 		config.ConfigMapKey = defaultConfigMapKey
 	}
 
+	// The ConfigMaps the bundle is pushed to are those of config_maps, if
+	// set, or the one of namespace and config_map otherwise
+	if len(config.ConfigMaps) == 0 {
+		config.ConfigMaps = []configMapConfig{
+			{Namespace: config.Namespace, ConfigMap: config.ConfigMap},
+		}
+	}
+	for i := range config.ConfigMaps {
+		target := &config.ConfigMaps[i]
+		if target.ConfigMap == "" {
+			return nil, k8sErr.New("config_map is required in config_maps")
+		}
+		if target.Namespace == "" {
+			target.Namespace = defaultNamespace
+		}
+		if target.ConfigMapKey == "" {
+			target.ConfigMapKey = config.ConfigMapKey
+		}
+	}
+
 	p.setConfig(config)
 	return &spi.ConfigureResponse{}, nil
 }
@@ -165,6 +197,16 @@ func (p *Plugin) updateBundleConfigMap(ctx context.Context, c *pluginConfig) (er
 		return err
 	}
 
+	// Update all of the ConfigMaps, even if some of them fail, so they are as
+	// up to date as possible
+	var errGroup errs.Group
+	for _, target := range c.ConfigMaps {
+		errGroup.Add(p.updateConfigMap(ctx, client, target, c.VerifyWrite))
+	}
+	return errGroup.Err()
+}
+
+func (p *Plugin) updateConfigMap(ctx context.Context, client kubeClient, c configMapConfig, verifyWrite bool) error {
 	for {
 		// Get the config map so we can use the version to resolve conflicts racing
 		// on updates from other servers.
@@ -207,10 +249,41 @@ func (p *Plugin) updateBundleConfigMap(ctx context.Context, c *pluginConfig) (er
 			return k8sErr.New("unable to update config map %s/%s: %v", c.Namespace, c.ConfigMap, err)
 		}
 
+		if verifyWrite {
+			return verifyConfigMap(ctx, client, c, resp.Bundle)
+		}
 		return nil
 	}
 }
 
+// verifyConfigMap reads the ConfigMap back to check it holds all of the root
+// CAs of the bundle. Other servers may have written a more recent bundle
+// meanwhile, so additional root CAs are allowed.
+func verifyConfigMap(ctx context.Context, client kubeClient, c configMapConfig, bundle *common.Bundle) error {
+	configMap, err := client.GetConfigMap(ctx, c.Namespace, c.ConfigMap)
+	if err != nil {
+		return k8sErr.New("unable to get config map %s/%s to verify it: %v", c.Namespace, c.ConfigMap, err)
+	}
+
+	written := make(map[string]bool)
+	rest := []byte(configMap.Data[c.ConfigMapKey])
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		written[string(block.Bytes)] = true
+	}
+
+	for _, rootCA := range bundle.RootCas {
+		if !written[string(rootCA.DerBytes)] {
+			return k8sErr.New("config map %s/%s is missing root CAs of the bundle after the update", c.Namespace, c.ConfigMap)
+		}
+	}
+	return nil
+}
+
 func newKubeClient(configPath string) (kubeClient, error) {
 	config, err := getKubeConfig(configPath)
 	if err != nil {
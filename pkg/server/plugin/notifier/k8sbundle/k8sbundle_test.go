@@ -406,6 +406,127 @@ kube_config_file_path = "/some/file/path"
 	}, s.k.getConfigMap("NAMESPACE", "CONFIGMAP"))
 }
 
+func (s *Suite) TestBundleUpdatedWithMultipleConfigMaps() {
+	s.k.setConfigMap(newConfigMap())
+	s.k.setConfigMap(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "NAMESPACE",
+			Name:            "CONFIGMAP",
+			ResourceVersion: "1",
+		},
+	})
+	// the bundle is fetched for each ConfigMap
+	s.r.AppendBundle(testBundle)
+	s.r.AppendBundle(testBundle)
+
+	s.configure(`
+config_maps = [
+	{ config_map = "spire-bundle" },
+	{ namespace = "NAMESPACE", config_map = "CONFIGMAP", config_map_key = "CONFIGMAPKEY" },
+]
+verify_write = true
+`)
+
+	resp, err := s.p.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_BundleUpdated{
+			BundleUpdated: &notifier.BundleUpdated{
+				Bundle: testBundle,
+			},
+		},
+	})
+	s.Require().NoError(err)
+	s.NotNil(resp)
+
+	s.Equal(map[string]string{"bundle.crt": testBundleData}, s.k.getConfigMap("spire", "spire-bundle").Data)
+	s.Equal(map[string]string{"CONFIGMAPKEY": testBundleData}, s.k.getConfigMap("NAMESPACE", "CONFIGMAP").Data)
+}
+
+func (s *Suite) TestBundleUpdatedWithMultipleConfigMapsUpdatesAllDespiteFailures() {
+	s.k.setConfigMap(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "NAMESPACE",
+			Name:            "CONFIGMAP",
+			ResourceVersion: "1",
+		},
+	})
+	s.r.AppendBundle(testBundle)
+
+	s.configure(`
+config_maps = [
+	{ namespace = "NAMESPACE", config_map = "MISSING" },
+	{ namespace = "NAMESPACE", config_map = "CONFIGMAP" },
+]
+`)
+
+	resp, err := s.p.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_BundleUpdated{
+			BundleUpdated: &notifier.BundleUpdated{
+				Bundle: testBundle,
+			},
+		},
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, "k8s-bundle: unable to get config map NAMESPACE/MISSING: not found")
+	s.Nil(resp)
+
+	s.Equal(map[string]string{"bundle.crt": testBundleData}, s.k.getConfigMap("NAMESPACE", "CONFIGMAP").Data)
+}
+
+func (s *Suite) TestBundleLoadedVerifyWriteFailure() {
+	s.k.setConfigMap(newConfigMap())
+	s.k.setDiscardPatches(true)
+	s.r.AppendBundle(testBundle)
+
+	s.configure(`verify_write = true`)
+
+	resp, err := s.p.NotifyAndAdvise(context.Background(), &notifier.NotifyAndAdviseRequest{
+		Event: &notifier.NotifyAndAdviseRequest_BundleLoaded{
+			BundleLoaded: &notifier.BundleLoaded{
+				Bundle: testBundle,
+			},
+		},
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, "k8s-bundle: config map spire/spire-bundle is missing root CAs of the bundle after the update")
+	s.Nil(resp)
+}
+
+func (s *Suite) TestBundleLoadedVerifyWriteAllowsNewerBundle() {
+	s.k.setConfigMap(newConfigMap())
+	s.r.AppendBundle(testBundle)
+
+	s.configure(`verify_write = true`)
+
+	// another server wrote a bundle with an additional root CA meanwhile
+	s.k.setPatchHook(func(configMap *corev1.ConfigMap) {
+		configMap.Data["bundle.crt"] += "-----BEGIN CERTIFICATE-----\nQkFa\n-----END CERTIFICATE-----\n"
+	})
+
+	_, err := s.p.NotifyAndAdvise(context.Background(), &notifier.NotifyAndAdviseRequest{
+		Event: &notifier.NotifyAndAdviseRequest_BundleLoaded{
+			BundleLoaded: &notifier.BundleLoaded{
+				Bundle: testBundle,
+			},
+		},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *Suite) TestConfigureWithConfigMapsAndConfigMap() {
+	_, err := s.p.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `
+config_map = "CONFIGMAP"
+config_maps = [{ config_map = "OTHER" }]
+`,
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, "k8s-bundle: namespace and config_map cannot be set along with config_maps")
+}
+
+func (s *Suite) TestConfigureWithConfigMapsMissingName() {
+	_, err := s.p.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `config_maps = [{ namespace = "NAMESPACE" }]`,
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, "k8s-bundle: config_map is required in config_maps")
+}
+
 func (s *Suite) TestConfigureWithMalformedConfiguration() {
 	_, err := s.p.Configure(context.Background(), &spi.ConfigureRequest{
 		Configuration: "blah",
@@ -446,9 +567,11 @@ func (s *Suite) configure(configuration string) {
 }
 
 type fakeKubeClient struct {
-	mu         sync.RWMutex
-	configMaps map[string]*corev1.ConfigMap
-	patchErr   error
+	mu             sync.RWMutex
+	configMaps     map[string]*corev1.ConfigMap
+	patchErr       error
+	discardPatches bool
+	patchHook      func(*corev1.ConfigMap)
 }
 
 func newFakeKubeClient(configMaps ...*corev1.ConfigMap) *fakeKubeClient {
@@ -494,12 +617,18 @@ func (c *fakeKubeClient) PatchConfigMap(ctx context.Context, namespace, configMa
 		return errors.New("patch does not have resource version")
 	}
 	entry.ResourceVersion = fmt.Sprint(resourceVersion + 1)
+	if c.discardPatches {
+		return nil
+	}
 	if entry.Data == nil {
 		entry.Data = map[string]string{}
 	}
 	for key, data := range patchedMap.Data {
 		entry.Data[key] = data
 	}
+	if c.patchHook != nil {
+		c.patchHook(entry)
+	}
 	return nil
 }
 
@@ -515,6 +644,18 @@ func (c *fakeKubeClient) setConfigMap(configMap *corev1.ConfigMap) {
 	c.configMaps[configMapKey(configMap.Namespace, configMap.Name)] = configMap
 }
 
+func (c *fakeKubeClient) setDiscardPatches(discard bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.discardPatches = discard
+}
+
+func (c *fakeKubeClient) setPatchHook(hook func(*corev1.ConfigMap)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.patchHook = hook
+}
+
 func (c *fakeKubeClient) setPatchErr(err error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
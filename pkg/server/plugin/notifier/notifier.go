@@ -12,17 +12,25 @@ import (
 	"google.golang.org/grpc"
 )
 
-type BundleLoaded = notifier.BundleLoaded                                               //nolint: golint
-type BundleUpdated = notifier.BundleUpdated                                             //nolint: golint
-type NotifierClient = notifier.NotifierClient                                           //nolint: golint
-type NotifierServer = notifier.NotifierServer                                           //nolint: golint
-type NotifyAndAdviseRequest = notifier.NotifyAndAdviseRequest                           //nolint: golint
-type NotifyAndAdviseRequest_BundleLoaded = notifier.NotifyAndAdviseRequest_BundleLoaded //nolint: golint
-type NotifyAndAdviseResponse = notifier.NotifyAndAdviseResponse                         //nolint: golint
-type NotifyRequest = notifier.NotifyRequest                                             //nolint: golint
-type NotifyRequest_BundleUpdated = notifier.NotifyRequest_BundleUpdated                 //nolint: golint
-type NotifyResponse = notifier.NotifyResponse                                           //nolint: golint
-type UnimplementedNotifierServer = notifier.UnimplementedNotifierServer                 //nolint: golint
+type BundleLoaded = notifier.BundleLoaded                                                 //nolint: golint
+type BundleUpdated = notifier.BundleUpdated                                               //nolint: golint
+type EntryCreating = notifier.EntryCreating                                               //nolint: golint
+type EntryUpdating = notifier.EntryUpdating                                               //nolint: golint
+type JWTKeyActivated = notifier.JWTKeyActivated                                           //nolint: golint
+type NotifierClient = notifier.NotifierClient                                             //nolint: golint
+type NotifierServer = notifier.NotifierServer                                             //nolint: golint
+type NotifyAndAdviseRequest = notifier.NotifyAndAdviseRequest                             //nolint: golint
+type NotifyAndAdviseRequest_BundleLoaded = notifier.NotifyAndAdviseRequest_BundleLoaded   //nolint: golint
+type NotifyAndAdviseRequest_EntryCreating = notifier.NotifyAndAdviseRequest_EntryCreating //nolint: golint
+type NotifyAndAdviseRequest_EntryUpdating = notifier.NotifyAndAdviseRequest_EntryUpdating //nolint: golint
+type NotifyAndAdviseResponse = notifier.NotifyAndAdviseResponse                           //nolint: golint
+type NotifyRequest = notifier.NotifyRequest                                               //nolint: golint
+type NotifyRequest_BundleUpdated = notifier.NotifyRequest_BundleUpdated                   //nolint: golint
+type NotifyRequest_JwtKeyActivated = notifier.NotifyRequest_JwtKeyActivated               //nolint: golint
+type NotifyRequest_X509CaActivated = notifier.NotifyRequest_X509CaActivated               //nolint: golint
+type NotifyResponse = notifier.NotifyResponse                                             //nolint: golint
+type UnimplementedNotifierServer = notifier.UnimplementedNotifierServer                   //nolint: golint
+type X509CAActivated = notifier.X509CAActivated                                           //nolint: golint
 
 const (
 	Type = "Notifier"
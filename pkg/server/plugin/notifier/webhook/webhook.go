@@ -0,0 +1,421 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/notifier"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Events the webhook can be invoked on
+	EventBundleLoaded    = "bundle_loaded"
+	EventBundleUpdated   = "bundle_updated"
+	EventX509CAActivated = "x509_ca_activated"
+	EventJWTKeyActivated = "jwt_key_activated"
+
+	// SignatureHeader holds the HMAC-SHA256 of the request body, keyed with
+	// the configured secret, as "sha256=<hex digest>"
+	SignatureHeader = "X-Spire-Signature"
+
+	// EventHeader holds the event the webhook is invoked on
+	EventHeader = "X-Spire-Event"
+
+	defaultTimeout       = 10 * time.Second
+	defaultMaxRetries    = 3
+	defaultRetryInterval = time.Second
+)
+
+var allEvents = []string{EventBundleLoaded, EventBundleUpdated, EventX509CAActivated, EventJWTKeyActivated}
+
+func BuiltIn() catalog.Plugin {
+	return builtIn(New())
+}
+
+func builtIn(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin("webhook",
+		notifier.PluginServer(p),
+	)
+}
+
+type pluginConfig struct {
+	URL           string            `hcl:"url"`
+	Secret        string            `hcl:"secret"`
+	Events        []string          `hcl:"events"`
+	Headers       map[string]string `hcl:"headers"`
+	Timeout       string            `hcl:"timeout"`
+	MaxRetries    *int              `hcl:"max_retries"`
+	RetryInterval string            `hcl:"retry_interval"`
+}
+
+// webhook is the parsed form of the plugin configuration
+type webhook struct {
+	url           string
+	secret        []byte
+	events        map[string]bool
+	headers       map[string]string
+	client        *http.Client
+	maxRetries    int
+	retryInterval time.Duration
+	trustDomain   string
+}
+
+// Plugin is a notifier that posts the CA and bundle events to an HTTP
+// endpoint, e.g. to wire rotation events into chat or paging systems.
+type Plugin struct {
+	mu      sync.RWMutex
+	log     hclog.Logger
+	webhook *webhook
+}
+
+func New() *Plugin {
+	return &Plugin{
+		log: hclog.NewNullLogger(),
+	}
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+func (p *Plugin) Notify(ctx context.Context, req *notifier.NotifyRequest) (*notifier.NotifyResponse, error) {
+	w, err := p.getWebhook()
+	if err != nil {
+		return nil, err
+	}
+
+	var msg *message
+	switch event := req.Event.(type) {
+	case *notifier.NotifyRequest_BundleUpdated:
+		msg = bundleMessage(EventBundleUpdated, event.BundleUpdated.Bundle)
+	case *notifier.NotifyRequest_X509CaActivated:
+		msg, err = x509CAMessage(event.X509CaActivated)
+	case *notifier.NotifyRequest_JwtKeyActivated:
+		msg = jwtKeyMessage(event.JwtKeyActivated)
+	default:
+		return &notifier.NotifyResponse{}, nil
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := w.send(ctx, msg); err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return &notifier.NotifyResponse{}, nil
+}
+
+func (p *Plugin) NotifyAndAdvise(ctx context.Context, req *notifier.NotifyAndAdviseRequest) (*notifier.NotifyAndAdviseResponse, error) {
+	w, err := p.getWebhook()
+	if err != nil {
+		return nil, err
+	}
+
+	event, ok := req.Event.(*notifier.NotifyAndAdviseRequest_BundleLoaded)
+	if !ok {
+		return &notifier.NotifyAndAdviseResponse{}, nil
+	}
+
+	// An unreachable endpoint must not prevent SPIRE server from starting,
+	// so failures are only logged.
+	if err := w.send(ctx, bundleMessage(EventBundleLoaded, event.BundleLoaded.Bundle)); err != nil {
+		p.log.Warn("Failed to invoke the webhook", "event", EventBundleLoaded, "error", err)
+	}
+	return &notifier.NotifyAndAdviseResponse{}, nil
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(pluginConfig)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode configuration: %v", err)
+	}
+
+	w, err := parseWebhook(config)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.GlobalConfig != nil {
+		w.trustDomain = req.GlobalConfig.TrustDomain
+	}
+
+	p.setWebhook(w)
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) GetPluginInfo(ctx context.Context, req *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *Plugin) getWebhook() (*webhook, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.webhook == nil {
+		return nil, status.Error(codes.FailedPrecondition, "not configured")
+	}
+	return p.webhook, nil
+}
+
+func (p *Plugin) setWebhook(w *webhook) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.webhook = w
+}
+
+func parseWebhook(config *pluginConfig) (*webhook, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("url must be set")
+	}
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid url: scheme must be http or https")
+	}
+
+	w := &webhook{
+		url:           config.URL,
+		secret:        []byte(config.Secret),
+		events:        make(map[string]bool),
+		headers:       config.Headers,
+		client:        &http.Client{Timeout: defaultTimeout},
+		maxRetries:    defaultMaxRetries,
+		retryInterval: defaultRetryInterval,
+	}
+
+	events := config.Events
+	if len(events) == 0 {
+		events = allEvents
+	}
+	for _, event := range events {
+		if !isKnownEvent(event) {
+			return nil, fmt.Errorf("unknown event %q: must be one of %s", event, strings.Join(allEvents, ", "))
+		}
+		w.events[event] = true
+	}
+
+	if config.Timeout != "" {
+		timeout, err := time.ParseDuration(config.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %v", err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("invalid timeout: must be positive")
+		}
+		w.client.Timeout = timeout
+	}
+
+	if config.MaxRetries != nil {
+		if *config.MaxRetries < 0 {
+			return nil, fmt.Errorf("invalid max_retries: must not be negative")
+		}
+		w.maxRetries = *config.MaxRetries
+	}
+
+	if config.RetryInterval != "" {
+		retryInterval, err := time.ParseDuration(config.RetryInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry_interval: %v", err)
+		}
+		if retryInterval <= 0 {
+			return nil, fmt.Errorf("invalid retry_interval: must be positive")
+		}
+		w.retryInterval = retryInterval
+	}
+
+	return w, nil
+}
+
+func isKnownEvent(event string) bool {
+	for _, known := range allEvents {
+		if event == known {
+			return true
+		}
+	}
+	return false
+}
+
+// send posts the message to the webhook, retrying with an exponential backoff
+// on network errors and on responses indicating a transient failure.
+func (w *webhook) send(ctx context.Context, msg *message) error {
+	if !w.events[msg.Event] {
+		return nil
+	}
+
+	msg.TrustDomain = w.trustDomain
+	msg.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("unable to marshal message: %v", err)
+	}
+
+	interval := w.retryInterval
+	for attempt := 0; ; attempt++ {
+		retry, err := w.post(ctx, msg.Event, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= w.maxRetries {
+			return fmt.Errorf("unable to invoke webhook after %d attempt(s): %v", attempt+1, err)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("unable to invoke webhook after %d attempt(s): %v", attempt+1, ctx.Err())
+		case <-timer.C:
+		}
+		interval *= 2
+	}
+}
+
+// post makes a single request to the webhook. It returns whether a failed
+// request should be retried.
+func (w *webhook) post(ctx context.Context, event string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	for name, value := range w.headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	if len(w.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(w.secret, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected status: %s", resp.Status)
+	default:
+		return false, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+}
+
+// Sign returns the value of the signature header for the body, which
+// receivers can use to verify the request was made by SPIRE server.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// message is the JSON body posted to the webhook
+type message struct {
+	Event       string      `json:"event"`
+	TrustDomain string      `json:"trust_domain,omitempty"`
+	Timestamp   string      `json:"timestamp"`
+	Bundle      *bundleInfo `json:"bundle,omitempty"`
+	X509CA      *x509CAInfo `json:"x509_ca,omitempty"`
+	JWTKey      *jwtKeyInfo `json:"jwt_key,omitempty"`
+}
+
+type bundleInfo struct {
+	X509Authorities []x509CAInfo `json:"x509_authorities"`
+	JWTAuthorities  []jwtKeyInfo `json:"jwt_authorities"`
+}
+
+type x509CAInfo struct {
+	Subject      string `json:"subject"`
+	SerialNumber string `json:"serial_number"`
+	NotAfter     string `json:"not_after"`
+}
+
+type jwtKeyInfo struct {
+	KeyID    string `json:"key_id"`
+	NotAfter string `json:"not_after,omitempty"`
+}
+
+func bundleMessage(event string, bundle *common.Bundle) *message {
+	info := &bundleInfo{
+		X509Authorities: []x509CAInfo{},
+		JWTAuthorities:  []jwtKeyInfo{},
+	}
+	for _, rootCA := range bundle.GetRootCas() {
+		cert, err := x509.ParseCertificate(rootCA.DerBytes)
+		if err != nil {
+			// the bundle is validated by SPIRE server; skip rather than
+			// failing the whole notification
+			continue
+		}
+		info.X509Authorities = append(info.X509Authorities, newX509CAInfo(cert))
+	}
+	for _, key := range bundle.GetJwtSigningKeys() {
+		info.JWTAuthorities = append(info.JWTAuthorities, newJWTKeyInfo(key))
+	}
+	return &message{
+		Event:  event,
+		Bundle: info,
+	}
+}
+
+func x509CAMessage(event *notifier.X509CAActivated) (*message, error) {
+	cert, err := x509.ParseCertificate(event.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse X509 CA certificate: %v", err)
+	}
+	info := newX509CAInfo(cert)
+	return &message{
+		Event:  EventX509CAActivated,
+		X509CA: &info,
+	}, nil
+}
+
+func jwtKeyMessage(event *notifier.JWTKeyActivated) *message {
+	info := newJWTKeyInfo(event.JwtKey)
+	return &message{
+		Event:  EventJWTKeyActivated,
+		JWTKey: &info,
+	}
+}
+
+func newX509CAInfo(cert *x509.Certificate) x509CAInfo {
+	return x509CAInfo{
+		Subject:      cert.Subject.String(),
+		SerialNumber: cert.SerialNumber.String(),
+		NotAfter:     cert.NotAfter.UTC().Format(time.RFC3339),
+	}
+}
+
+func newJWTKeyInfo(key *common.PublicKey) jwtKeyInfo {
+	info := jwtKeyInfo{
+		KeyID: key.GetKid(),
+	}
+	if key.GetNotAfter() != 0 {
+		info.NotAfter = time.Unix(key.GetNotAfter(), 0).UTC().Format(time.RFC3339)
+	}
+	return info
+}
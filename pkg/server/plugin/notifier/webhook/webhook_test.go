@@ -0,0 +1,381 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/server/plugin/notifier"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestConfigure(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		config string
+		code   codes.Code
+		desc   string
+	}{
+		{
+			name:   "malformed",
+			config: "MALFORMED",
+			code:   codes.InvalidArgument,
+			desc:   "unable to decode configuration",
+		},
+		{
+			name:   "missing url",
+			config: `secret = "s3cr3t"`,
+			code:   codes.InvalidArgument,
+			desc:   "url must be set",
+		},
+		{
+			name:   "unsupported url scheme",
+			config: `url = "ftp://example.org/hook"`,
+			code:   codes.InvalidArgument,
+			desc:   "invalid url: scheme must be http or https",
+		},
+		{
+			name: "unknown event",
+			config: `
+				url = "https://example.org/hook"
+				events = ["bundle_updated", "entry_created"]
+			`,
+			code: codes.InvalidArgument,
+			desc: `unknown event "entry_created": must be one of bundle_loaded, bundle_updated, x509_ca_activated, jwt_key_activated`,
+		},
+		{
+			name: "invalid timeout",
+			config: `
+				url = "https://example.org/hook"
+				timeout = "soon"
+			`,
+			code: codes.InvalidArgument,
+			desc: "invalid timeout",
+		},
+		{
+			name: "negative max retries",
+			config: `
+				url = "https://example.org/hook"
+				max_retries = -1
+			`,
+			code: codes.InvalidArgument,
+			desc: "invalid max_retries: must not be negative",
+		},
+		{
+			name: "invalid retry interval",
+			config: `
+				url = "https://example.org/hook"
+				retry_interval = "0s"
+			`,
+			code: codes.InvalidArgument,
+			desc: "invalid retry_interval: must be positive",
+		},
+		{
+			name: "success",
+			config: `
+				url = "https://example.org/hook"
+				secret = "s3cr3t"
+				events = ["x509_ca_activated"]
+				headers = {
+					"Authorization" = "Bearer token"
+				}
+				timeout = "5s"
+				max_retries = 0
+				retry_interval = "500ms"
+			`,
+			code: codes.OK,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var plugin notifier.Plugin
+			pluginDone := spiretest.LoadPlugin(t, BuiltIn(), &plugin)
+			defer pluginDone()
+
+			_, err := plugin.Configure(context.Background(), &spi.ConfigureRequest{Configuration: tt.config})
+			if tt.code != codes.OK {
+				spiretest.RequireGRPCStatusContains(t, err, tt.code, tt.desc)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGetPluginInfo(t *testing.T) {
+	resp, err := New().GetPluginInfo(context.Background(), &spi.GetPluginInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &spi.GetPluginInfoResponse{}, resp)
+}
+
+func TestNotifyNotConfigured(t *testing.T) {
+	var plugin notifier.Plugin
+	pluginDone := spiretest.LoadPlugin(t, BuiltIn(), &plugin)
+	defer pluginDone()
+
+	_, err := plugin.Notify(context.Background(), bundleUpdatedRequest())
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "not configured")
+}
+
+func TestNotifyBundleUpdated(t *testing.T) {
+	ca, _, err := util.LoadCAFixture()
+	require.NoError(t, err)
+
+	test := setupTest(t, `secret = "s3cr3t"
+		headers = {
+			"Authorization" = "Bearer token"
+		}`)
+	defer test.Close()
+
+	_, err = test.plugin.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_BundleUpdated{
+			BundleUpdated: &notifier.BundleUpdated{
+				Bundle: &common.Bundle{
+					TrustDomainId: "spiffe://example.org",
+					RootCas:       []*common.Certificate{{DerBytes: ca.Raw}},
+					JwtSigningKeys: []*common.PublicKey{
+						{Kid: "KID", NotAfter: 1600000000},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	reqs := test.Requests()
+	require.Len(t, reqs, 1)
+	req := reqs[0]
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", req.header.Get("Authorization"))
+	assert.Equal(t, EventBundleUpdated, req.header.Get(EventHeader))
+	assert.Equal(t, Sign([]byte("s3cr3t"), req.body), req.header.Get(SignatureHeader))
+
+	msg := req.Message(t)
+	assert.Equal(t, EventBundleUpdated, msg.Event)
+	assert.Equal(t, "spiffe://example.org", msg.TrustDomain)
+	assert.NotEmpty(t, msg.Timestamp)
+	assert.Equal(t, &bundleInfo{
+		X509Authorities: []x509CAInfo{
+			{
+				Subject:      ca.Subject.String(),
+				SerialNumber: ca.SerialNumber.String(),
+				NotAfter:     ca.NotAfter.UTC().Format(time.RFC3339),
+			},
+		},
+		JWTAuthorities: []jwtKeyInfo{
+			{KeyID: "KID", NotAfter: "2020-09-13T12:26:40Z"},
+		},
+	}, msg.Bundle)
+}
+
+func TestNotifyX509CAActivated(t *testing.T) {
+	ca, _, err := util.LoadCAFixture()
+	require.NoError(t, err)
+
+	test := setupTest(t, "")
+	defer test.Close()
+
+	_, err = test.plugin.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_X509CaActivated{
+			X509CaActivated: &notifier.X509CAActivated{
+				Certificate: ca.Raw,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	reqs := test.Requests()
+	require.Len(t, reqs, 1)
+	assert.Empty(t, reqs[0].header.Get(SignatureHeader))
+	msg := reqs[0].Message(t)
+	assert.Equal(t, EventX509CAActivated, msg.Event)
+	assert.Equal(t, &x509CAInfo{
+		Subject:      ca.Subject.String(),
+		SerialNumber: ca.SerialNumber.String(),
+		NotAfter:     ca.NotAfter.UTC().Format(time.RFC3339),
+	}, msg.X509CA)
+
+	_, err = test.plugin.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_X509CaActivated{
+			X509CaActivated: &notifier.X509CAActivated{
+				Certificate: []byte("malformed"),
+			},
+		},
+	})
+	spiretest.RequireGRPCStatusContains(t, err, codes.InvalidArgument, "unable to parse X509 CA certificate")
+}
+
+func TestNotifyJWTKeyActivated(t *testing.T) {
+	test := setupTest(t, "")
+	defer test.Close()
+
+	_, err := test.plugin.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_JwtKeyActivated{
+			JwtKeyActivated: &notifier.JWTKeyActivated{
+				JwtKey: &common.PublicKey{Kid: "KID", NotAfter: 1600000000},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	reqs := test.Requests()
+	require.Len(t, reqs, 1)
+	msg := reqs[0].Message(t)
+	assert.Equal(t, EventJWTKeyActivated, msg.Event)
+	assert.Equal(t, &jwtKeyInfo{KeyID: "KID", NotAfter: "2020-09-13T12:26:40Z"}, msg.JWTKey)
+}
+
+func TestNotifySkipsUnsubscribedEvents(t *testing.T) {
+	test := setupTest(t, `events = ["x509_ca_activated"]`)
+	defer test.Close()
+
+	_, err := test.plugin.Notify(context.Background(), bundleUpdatedRequest())
+	require.NoError(t, err)
+	require.Empty(t, test.Requests())
+}
+
+func TestNotifyRetriesTransientFailures(t *testing.T) {
+	test := setupTest(t, "")
+	defer test.Close()
+	test.SetStatuses(http.StatusServiceUnavailable, http.StatusTooManyRequests)
+
+	_, err := test.plugin.Notify(context.Background(), bundleUpdatedRequest())
+	require.NoError(t, err)
+	require.Len(t, test.Requests(), 3)
+}
+
+func TestNotifyGivesUpAfterMaxRetries(t *testing.T) {
+	test := setupTest(t, "max_retries = 1")
+	defer test.Close()
+	test.SetStatuses(http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+
+	_, err := test.plugin.Notify(context.Background(), bundleUpdatedRequest())
+	spiretest.RequireGRPCStatus(t, err, codes.Unavailable, "unable to invoke webhook after 2 attempt(s): unexpected status: 500 Internal Server Error")
+	require.Len(t, test.Requests(), 2)
+}
+
+func TestNotifyDoesNotRetryClientErrors(t *testing.T) {
+	test := setupTest(t, "")
+	defer test.Close()
+	test.SetStatuses(http.StatusBadRequest)
+
+	_, err := test.plugin.Notify(context.Background(), bundleUpdatedRequest())
+	spiretest.RequireGRPCStatus(t, err, codes.Unavailable, "unable to invoke webhook after 1 attempt(s): unexpected status: 400 Bad Request")
+	require.Len(t, test.Requests(), 1)
+}
+
+func TestNotifyAndAdviseBundleLoaded(t *testing.T) {
+	test := setupTest(t, "max_retries = 0")
+	defer test.Close()
+
+	req := &notifier.NotifyAndAdviseRequest{
+		Event: &notifier.NotifyAndAdviseRequest_BundleLoaded{
+			BundleLoaded: &notifier.BundleLoaded{
+				Bundle: &common.Bundle{TrustDomainId: "spiffe://example.org"},
+			},
+		},
+	}
+
+	_, err := test.plugin.NotifyAndAdvise(context.Background(), req)
+	require.NoError(t, err)
+	reqs := test.Requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, EventBundleLoaded, reqs[0].Message(t).Event)
+
+	// failures do not prevent the server from starting
+	test.SetStatuses(http.StatusInternalServerError)
+	_, err = test.plugin.NotifyAndAdvise(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, test.Requests(), 2)
+}
+
+func bundleUpdatedRequest() *notifier.NotifyRequest {
+	return &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_BundleUpdated{
+			BundleUpdated: &notifier.BundleUpdated{
+				Bundle: &common.Bundle{TrustDomainId: "spiffe://example.org"},
+			},
+		},
+	}
+}
+
+type webhookTest struct {
+	plugin     notifier.Plugin
+	pluginDone func()
+	server     *httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedRequest
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func (r receivedRequest) Message(t *testing.T) *message {
+	msg := new(message)
+	require.NoError(t, json.Unmarshal(r.body, msg))
+	return msg
+}
+
+func setupTest(t *testing.T, config string) *webhookTest {
+	test := &webhookTest{}
+	test.server = httptest.NewServer(http.HandlerFunc(test.serveHTTP))
+	test.pluginDone = spiretest.LoadPlugin(t, BuiltIn(), &test.plugin)
+
+	_, err := test.plugin.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf("url = %q\nretry_interval = \"1ms\"\n%s", test.server.URL, config),
+		GlobalConfig:  &spi.ConfigureRequest_GlobalConfig{TrustDomain: "spiffe://example.org"},
+	})
+	require.NoError(t, err)
+	return test
+}
+
+func (w *webhookTest) Close() {
+	w.pluginDone()
+	w.server.Close()
+}
+
+// SetStatuses sets the statuses of the next responses. Once exhausted,
+// the webhook responds with 200 OK.
+func (w *webhookTest) SetStatuses(statuses ...int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.statuses = statuses
+}
+
+func (w *webhookTest) Requests() []receivedRequest {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.requests
+}
+
+func (w *webhookTest) serveHTTP(rw http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.requests = append(w.requests, receivedRequest{
+		header: req.Header,
+		body:   body,
+	})
+	status := http.StatusOK
+	if len(w.statuses) > 0 {
+		status, w.statuses = w.statuses[0], w.statuses[1:]
+	}
+	rw.WriteHeader(status)
+}
@@ -0,0 +1,118 @@
+package upstreamauthority
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Failover produces an UpstreamAuthority that opens its streams with the
+// primary UpstreamAuthority and, when the primary fails to produce the first
+// response, opens them with the secondary instead. Streams are not moved to
+// the secondary once the primary has responded.
+func Failover(log logrus.FieldLogger, primary, secondary UpstreamAuthority) UpstreamAuthority {
+	return &failover{
+		log:       log,
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+type failover struct {
+	log       logrus.FieldLogger
+	primary   UpstreamAuthority
+	secondary UpstreamAuthority
+}
+
+func (f *failover) MintX509CA(ctx context.Context, req *MintX509CARequest) (UpstreamAuthority_MintX509CAClient, error) {
+	stream, resp, err := mintX509CAFirst(ctx, f.primary, req)
+	if f.shouldFailOver(ctx, "MintX509CA", err) {
+		stream, resp, err = mintX509CAFirst(ctx, f.secondary, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &mintX509CAFirstStream{
+		UpstreamAuthority_MintX509CAClient: stream,
+		first:                              resp,
+	}, nil
+}
+
+func (f *failover) PublishJWTKey(ctx context.Context, req *PublishJWTKeyRequest) (UpstreamAuthority_PublishJWTKeyClient, error) {
+	stream, resp, err := publishJWTKeyFirst(ctx, f.primary, req)
+	if f.shouldFailOver(ctx, "PublishJWTKey", err) {
+		stream, resp, err = publishJWTKeyFirst(ctx, f.secondary, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &publishJWTKeyFirstStream{
+		UpstreamAuthority_PublishJWTKeyClient: stream,
+		first:                                 resp,
+	}, nil
+}
+
+// shouldFailOver returns true if the primary failed the call for another
+// reason than the caller giving up.
+func (f *failover) shouldFailOver(ctx context.Context, method string, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	f.log.WithError(err).WithField(telemetry.Method, method).Warn("Primary UpstreamAuthority failed; failing over to the secondary")
+	return true
+}
+
+func mintX509CAFirst(ctx context.Context, upstreamAuthority UpstreamAuthority, req *MintX509CARequest) (UpstreamAuthority_MintX509CAClient, *MintX509CAResponse, error) {
+	stream, err := upstreamAuthority.MintX509CA(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, nil, err
+	}
+	return stream, resp, nil
+}
+
+func publishJWTKeyFirst(ctx context.Context, upstreamAuthority UpstreamAuthority, req *PublishJWTKeyRequest) (UpstreamAuthority_PublishJWTKeyClient, *PublishJWTKeyResponse, error) {
+	stream, err := upstreamAuthority.PublishJWTKey(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, nil, err
+	}
+	return stream, resp, nil
+}
+
+// mintX509CAFirstStream returns the first response, already received while
+// choosing the UpstreamAuthority, before the rest of the stream.
+type mintX509CAFirstStream struct {
+	UpstreamAuthority_MintX509CAClient
+	first *MintX509CAResponse
+}
+
+func (s *mintX509CAFirstStream) Recv() (*MintX509CAResponse, error) {
+	if resp := s.first; resp != nil {
+		s.first = nil
+		return resp, nil
+	}
+	return s.UpstreamAuthority_MintX509CAClient.Recv()
+}
+
+// publishJWTKeyFirstStream returns the first response, already received
+// while choosing the UpstreamAuthority, before the rest of the stream.
+type publishJWTKeyFirstStream struct {
+	UpstreamAuthority_PublishJWTKeyClient
+	first *PublishJWTKeyResponse
+}
+
+func (s *publishJWTKeyFirstStream) Recv() (*PublishJWTKeyResponse, error) {
+	if resp := s.first; resp != nil {
+		s.first = nil
+		return resp, nil
+	}
+	return s.UpstreamAuthority_PublishJWTKeyClient.Recv()
+}
@@ -0,0 +1,55 @@
+package upstreamauthority
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestFailoverMintX509CA(t *testing.T) {
+	log, hook := test.NewNullLogger()
+
+	primaryCA := &fakeUpstreamCA{t: t, certChain: [][]byte{ca1}, bundle: [][]byte{ca1}}
+	secondaryCA := &fakeUpstreamCA{t: t, certChain: [][]byte{ca2}, bundle: [][]byte{ca2}}
+	upstreamAuthority := Failover(log, Wrap(primaryCA), Wrap(secondaryCA))
+
+	mint := func() ([][]byte, error) {
+		stream, err := upstreamAuthority.MintX509CA(ctx, &MintX509CARequest{})
+		if err != nil {
+			return nil, err
+		}
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, io.EOF, err)
+		return resp.X509CaChain, nil
+	}
+
+	// The secondary is unused while the primary succeeds
+	chain, err := mint()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{ca1}, chain)
+	require.Empty(t, hook.AllEntries())
+
+	// The stream is opened with the secondary when the primary fails
+	primaryCA.err = errors.New("ohno")
+	chain, err = mint()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{ca2}, chain)
+	require.Len(t, hook.AllEntries(), 1)
+	entry := hook.LastEntry()
+	require.Equal(t, logrus.WarnLevel, entry.Level)
+	require.Equal(t, "Primary UpstreamAuthority failed; failing over to the secondary", entry.Message)
+	require.Equal(t, "MintX509CA", entry.Data["method"])
+
+	// The secondary error is returned when both fail
+	secondaryCA.err = errors.New("ohno again")
+	_, err = mint()
+	spiretest.RequireGRPCStatus(t, err, codes.Internal, "upstreamauthority-wrapper: unable to submit csr: ohno again")
+}
@@ -217,6 +217,10 @@ func (h *handler) FetchX509SVID(server node_pb.Node_FetchX509SVIDServer) error {
 	return errors.New("NOT IMPLEMENTED")
 }
 
+func (h *handler) SubscribeToSyncEvents(req *node_pb.SubscribeToSyncEventsRequest, stream node_pb.Node_SubscribeToSyncEventsServer) error {
+	return errors.New("NOT IMPLEMENTED")
+}
+
 func (h *handler) FetchX509CASVID(ctx context.Context, req *node.FetchX509CASVIDRequest) (*node.FetchX509CASVIDResponse, error) {
 	caKey, err := pemutil.LoadPrivateKey(keyFilePath)
 	if err != nil {
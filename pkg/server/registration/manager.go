@@ -6,9 +6,13 @@ import (
 
 	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/noderesolver"
+	"github.com/spiffe/spire/proto/spire/common"
 )
 
 const (
@@ -19,12 +23,35 @@ const (
 type ManagerConfig struct {
 	DataStore datastore.DataStore
 
+	// PruneAttestedNodesExpiredFor is how long after their SVID expires
+	// attested nodes are pruned. Attested nodes are not pruned when zero.
+	PruneAttestedNodesExpiredFor time.Duration
+
+	// PruneIssuedSVIDsExpiredFor is how long after their expiration the
+	// records of issued X509-SVIDs are pruned. They are not pruned when zero.
+	PruneIssuedSVIDsExpiredFor time.Duration
+
+	// ResolveNodeSelectorsInterval is how often the selectors of attested
+	// nodes are resolved again using the node resolver matching their
+	// attestation type, so they follow changes to the nodes (e.g. instance
+	// tags). Node selectors are only resolved on attestation when zero.
+	ResolveNodeSelectorsInterval time.Duration
+
+	// NodeResolvers provides the node resolvers used to resolve node
+	// selectors again. Required when ResolveNodeSelectorsInterval is set.
+	NodeResolvers NodeResolvers
+
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
 
 	Clock clock.Clock
 }
 
+// NodeResolvers provides node resolvers by name
+type NodeResolvers interface {
+	GetNodeResolverNamed(name string) (noderesolver.NodeResolver, bool)
+}
+
 // Manager is the manager of registrations
 type Manager struct {
 	c       ManagerConfig
@@ -47,7 +74,14 @@ func NewManager(c ManagerConfig) *Manager {
 
 // Run runs the registration manager
 func (m *Manager) Run(ctx context.Context) error {
-	return m.pruneEvery(ctx)
+	if m.c.ResolveNodeSelectorsInterval <= 0 || m.c.NodeResolvers == nil {
+		return m.pruneEvery(ctx)
+	}
+	err := util.RunTasks(ctx, m.pruneEvery, m.resolveNodeSelectorsEvery)
+	if err == context.Canceled {
+		err = nil
+	}
+	return err
 }
 
 func (m *Manager) pruneEvery(ctx context.Context) error {
@@ -61,6 +95,16 @@ func (m *Manager) pruneEvery(ctx context.Context) error {
 			if err := m.prune(ctx); err != nil && ctx.Err() == nil {
 				m.log.WithError(err).Error("Failed pruning registration entries")
 			}
+			if m.c.PruneAttestedNodesExpiredFor > 0 {
+				if err := m.pruneAttestedNodes(ctx); err != nil && ctx.Err() == nil {
+					m.log.WithError(err).Error("Failed pruning attested nodes")
+				}
+			}
+			if m.c.PruneIssuedSVIDsExpiredFor > 0 {
+				if err := m.pruneIssuedX509SVIDs(ctx); err != nil && ctx.Err() == nil {
+					m.log.WithError(err).Error("Failed pruning issued X509-SVID records")
+				}
+			}
 		case <-ctx.Done():
 			return nil
 		}
@@ -76,3 +120,131 @@ func (m *Manager) prune(ctx context.Context) (err error) {
 	})
 	return err
 }
+
+func (m *Manager) pruneAttestedNodes(ctx context.Context) (err error) {
+	counter := telemetry_server.StartRegistrationManagerPruneNodeCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	_, err = m.c.DataStore.PruneAttestedNodes(ctx, &datastore.PruneAttestedNodesRequest{
+		ExpiresBefore: m.c.Clock.Now().Add(-m.c.PruneAttestedNodesExpiredFor).Unix(),
+	})
+	return err
+}
+
+func (m *Manager) pruneIssuedX509SVIDs(ctx context.Context) (err error) {
+	counter := telemetry_server.StartRegistrationManagerPruneIssuedX509SVIDsCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	_, err = m.c.DataStore.PruneIssuedX509SVIDs(ctx, &datastore.PruneIssuedX509SVIDsRequest{
+		ExpiresBefore: m.c.Clock.Now().Add(-m.c.PruneIssuedSVIDsExpiredFor).Unix(),
+	})
+	return err
+}
+
+func (m *Manager) resolveNodeSelectorsEvery(ctx context.Context) error {
+	ticker := m.c.Clock.Ticker(m.c.ResolveNodeSelectorsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// Log an error on failure unless we're shutting down
+			if err := m.resolveNodeSelectors(ctx); err != nil && ctx.Err() == nil {
+				m.log.WithError(err).Error("Failed resolving node selectors")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (m *Manager) resolveNodeSelectors(ctx context.Context) (err error) {
+	counter := telemetry_server.StartRegistrationManagerResolveNodeSelectorsCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	resp, err := m.c.DataStore.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{})
+	if err != nil {
+		return err
+	}
+
+	for _, node := range resp.Nodes {
+		if nodeutil.IsAgentBanned(node) {
+			continue
+		}
+		resolver, ok := m.c.NodeResolvers.GetNodeResolverNamed(node.AttestationDataType)
+		if !ok {
+			continue
+		}
+		// A node that cannot be resolved (e.g. the instance is gone) should
+		// not prevent the rest from being resolved
+		if err := m.resolveNodeSelectorsFor(ctx, resolver, node); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			m.log.WithError(err).WithField(telemetry.SPIFFEID, node.SpiffeId).Warn("Failed resolving selectors for node")
+		}
+	}
+	return nil
+}
+
+// resolveNodeSelectorsFor replaces the node selectors previously produced by
+// the resolver with the ones it currently resolves for the node. Selectors of
+// other types (e.g. produced by the node attestor) are kept.
+func (m *Manager) resolveNodeSelectorsFor(ctx context.Context, resolver noderesolver.NodeResolver, node *common.AttestedNode) error {
+	resolved, err := resolver.Resolve(ctx, &noderesolver.ResolveRequest{
+		BaseSpiffeIdList: []string{node.SpiffeId},
+	})
+	if err != nil {
+		return err
+	}
+
+	current, err := m.c.DataStore.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{
+		SpiffeId: node.SpiffeId,
+	})
+	if err != nil {
+		return err
+	}
+
+	var selectors []*common.Selector
+	for _, selector := range current.Selectors.GetSelectors() {
+		if selector.Type != node.AttestationDataType {
+			selectors = append(selectors, selector)
+		}
+	}
+	selectors = append(selectors, resolved.Map[node.SpiffeId].GetEntries()...)
+
+	if selectorsEqual(current.Selectors.GetSelectors(), selectors) {
+		return nil
+	}
+
+	_, err = m.c.DataStore.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  node.SpiffeId,
+			Selectors: selectors,
+		},
+	})
+	return err
+}
+
+func selectorsEqual(a, b []*common.Selector) bool {
+	type selectorKey struct {
+		Type  string
+		Value string
+	}
+
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[selectorKey]int, len(a))
+	for _, selector := range a {
+		set[selectorKey{Type: selector.Type, Value: selector.Value}]++
+	}
+	for _, selector := range b {
+		key := selectorKey{Type: selector.Type, Value: selector.Value}
+		if set[key] == 0 {
+			return false
+		}
+		set[key]--
+	}
+	return true
+}
@@ -8,10 +8,12 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/noderesolver"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/fakes/fakenoderesolver"
 	"github.com/spiffe/spire/test/spiretest"
 )
 
@@ -28,6 +30,10 @@ type ManagerSuite struct {
 	ds      *fakedatastore.DataStore
 	metrics *fakemetrics.FakeMetrics
 
+	pruneAttestedNodesExpiredFor time.Duration
+	resolveNodeSelectorsInterval time.Duration
+	nodeResolvers                fakeNodeResolvers
+
 	m *Manager
 }
 
@@ -36,6 +42,9 @@ func (s *ManagerSuite) SetupTest() {
 	s.log, s.logHook = test.NewNullLogger()
 	s.ds = fakedatastore.New(s.T())
 	s.metrics = fakemetrics.New()
+	s.pruneAttestedNodesExpiredFor = 0
+	s.resolveNodeSelectorsInterval = 0
+	s.nodeResolvers = nil
 }
 
 func (s *ManagerSuite) TestPruning() {
@@ -46,7 +55,6 @@ func (s *ManagerSuite) TestPruning() {
 
 	// expires right on the pruning time
 	entry1 := &common.RegistrationEntry{
-		EntryId:  "some ID 1",
 		ParentId: "spiffe://test.test/testA",
 		SpiffeId: "spiffe://test.test/testA/test1",
 		Selectors: []*common.Selector{
@@ -66,7 +74,6 @@ func (s *ManagerSuite) TestPruning() {
 
 	// expires in pruning time + one minute
 	entry2 := &common.RegistrationEntry{
-		EntryId:  "some ID 1",
 		ParentId: "spiffe://test.test/testA",
 		SpiffeId: "spiffe://test.test/testA/test2",
 		Selectors: []*common.Selector{
@@ -86,7 +93,6 @@ func (s *ManagerSuite) TestPruning() {
 
 	// expires in pruning time + two minutes
 	entry3 := &common.RegistrationEntry{
-		EntryId:  "some ID 1",
 		ParentId: "spiffe://test.test/testA",
 		SpiffeId: "spiffe://test.test/testA/test3",
 		Selectors: []*common.Selector{
@@ -132,12 +138,127 @@ func (s *ManagerSuite) TestPruning() {
 	s.Empty(listResp.Entries)
 }
 
+func (s *ManagerSuite) TestPruningAttestedNodes() {
+	s.pruneAttestedNodesExpiredFor = time.Hour
+	done := s.setupAndRunManager()
+	defer done()
+
+	createNode := func(spiffeID string, expiresAt time.Time) {
+		_, err := s.ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
+			Node: &common.AttestedNode{
+				SpiffeId:            spiffeID,
+				AttestationDataType: "test",
+				CertSerialNumber:    "1234",
+				CertNotAfter:        expiresAt.Unix(),
+			},
+		})
+		s.Require().NoError(err)
+	}
+	listNodes := func() []string {
+		resp, err := s.ds.ListAttestedNodes(context.Background(), &datastore.ListAttestedNodesRequest{})
+		s.Require().NoError(err)
+		var ids []string
+		for _, node := range resp.Nodes {
+			ids = append(ids, node.SpiffeId)
+		}
+		return ids
+	}
+
+	now := s.clock.Now()
+	createNode("spiffe://test.test/spire/agent/test/1", now)
+	createNode("spiffe://test.test/spire/agent/test/2", now.Add(time.Minute))
+
+	// nodes have not been expired for long enough yet
+	s.NoError(s.m.pruneAttestedNodes(context.Background()))
+	s.Equal([]string{"spiffe://test.test/spire/agent/test/1", "spiffe://test.test/spire/agent/test/2"}, listNodes())
+
+	// prune first node
+	s.clock.Add(time.Hour + time.Second)
+	s.NoError(s.m.pruneAttestedNodes(context.Background()))
+	s.Equal([]string{"spiffe://test.test/spire/agent/test/2"}, listNodes())
+
+	// prune second node
+	s.clock.Add(time.Minute)
+	s.NoError(s.m.pruneAttestedNodes(context.Background()))
+	s.Empty(listNodes())
+}
+
+func (s *ManagerSuite) TestResolvingNodeSelectors() {
+	resolved := map[string][]string{
+		"spiffe://test.test/spire/agent/test/1": {"tag:a:1"},
+	}
+	s.resolveNodeSelectorsInterval = time.Minute
+	s.nodeResolvers = fakeNodeResolvers{
+		"test": fakenoderesolver.New("test", fakenoderesolver.Config{Selectors: resolved}),
+	}
+	done := s.setupAndRunManager()
+	defer done()
+
+	createNode := func(spiffeID, attestationType, serialNumber string, selectors ...*common.Selector) {
+		_, err := s.ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
+			Node: &common.AttestedNode{
+				SpiffeId:            spiffeID,
+				AttestationDataType: attestationType,
+				CertSerialNumber:    serialNumber,
+			},
+		})
+		s.Require().NoError(err)
+		_, err = s.ds.SetNodeSelectors(context.Background(), &datastore.SetNodeSelectorsRequest{
+			Selectors: &datastore.NodeSelectors{
+				SpiffeId:  spiffeID,
+				Selectors: selectors,
+			},
+		})
+		s.Require().NoError(err)
+	}
+	getSelectors := func(spiffeID string) []*common.Selector {
+		resp, err := s.ds.GetNodeSelectors(context.Background(), &datastore.GetNodeSelectorsRequest{
+			SpiffeId: spiffeID,
+		})
+		s.Require().NoError(err)
+		return resp.Selectors.Selectors
+	}
+
+	createNode("spiffe://test.test/spire/agent/test/1", "test", "1234",
+		&common.Selector{Type: "other", Value: "kept"},
+		&common.Selector{Type: "test", Value: "tag:a:0"})
+	// nodes without a matching resolver, or banned, are left alone
+	createNode("spiffe://test.test/spire/agent/noresolver/2", "noresolver", "1234",
+		&common.Selector{Type: "noresolver", Value: "kept"})
+	createNode("spiffe://test.test/spire/agent/test/3", "test", "",
+		&common.Selector{Type: "test", Value: "tag:a:0"})
+
+	s.NoError(s.m.resolveNodeSelectors(context.Background()))
+	s.Equal([]*common.Selector{
+		{Type: "other", Value: "kept"},
+		{Type: "test", Value: "tag:a:1"},
+	}, getSelectors("spiffe://test.test/spire/agent/test/1"))
+	s.Equal([]*common.Selector{
+		{Type: "noresolver", Value: "kept"},
+	}, getSelectors("spiffe://test.test/spire/agent/noresolver/2"))
+	s.Equal([]*common.Selector{
+		{Type: "test", Value: "tag:a:0"},
+	}, getSelectors("spiffe://test.test/spire/agent/test/3"))
+
+	// selectors follow changes to the node
+	resolved["spiffe://test.test/spire/agent/test/1"] = []string{"tag:a:2", "tag:b:1"}
+	s.NoError(s.m.resolveNodeSelectors(context.Background()))
+	s.Equal([]*common.Selector{
+		{Type: "other", Value: "kept"},
+		{Type: "test", Value: "tag:a:2"},
+		{Type: "test", Value: "tag:b:1"},
+	}, getSelectors("spiffe://test.test/spire/agent/test/1"))
+}
+
 func (s *ManagerSuite) setupAndRunManager() func() {
 	s.m = NewManager(ManagerConfig{
-		Clock:     s.clock,
-		DataStore: s.ds,
-		Log:       s.log,
-		Metrics:   s.metrics,
+		Clock:                        s.clock,
+		DataStore:                    s.ds,
+		PruneAttestedNodesExpiredFor: s.pruneAttestedNodesExpiredFor,
+		ResolveNodeSelectorsInterval: s.resolveNodeSelectorsInterval,
+		NodeResolvers:                s.nodeResolvers,
+		Log:                          s.log,
+		Metrics:                      s.metrics,
 	})
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -150,3 +271,10 @@ func (s *ManagerSuite) setupAndRunManager() func() {
 		s.Require().NoError(<-errCh)
 	}
 }
+
+type fakeNodeResolvers map[string]noderesolver.NodeResolver
+
+func (r fakeNodeResolvers) GetNodeResolverNamed(name string) (noderesolver.NodeResolver, bool) {
+	resolver, ok := r[name]
+	return resolver, ok
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof" //nolint: gosec // import registers routes on DefaultServeMux
@@ -11,12 +12,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/hostservices/metricsservice"
 	common_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
 	"github.com/spiffe/spire/pkg/common/profiling"
+	"github.com/spiffe/spire/pkg/common/systemd"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/audit"
 	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
@@ -25,8 +29,10 @@ import (
 	"github.com/spiffe/spire/pkg/server/hostservices/identityprovider"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
+	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/server/registration"
 	"github.com/spiffe/spire/pkg/server/svid"
+	"github.com/spiffe/spire/pkg/server/syncevents"
 	"google.golang.org/grpc"
 )
 
@@ -44,22 +50,76 @@ const (
 
 type Server struct {
 	config Config
+
+	// hosted are the servers of the additional trust domains hosted by the
+	// server process
+	hosted []*Server
+
+	// ready is closed once the server is initialized and starts serving
+	ready chan struct{}
+
+	catalogMtx sync.Mutex
+	catalog    *catalog.Repository
 }
 
 // Run the server
 // This method initializes the server, including its plugins,
 // and then blocks until it's shut down or an error is encountered.
+// The servers of the hosted trust domains run alongside; if one of them
+// fails, the others are stopped. They share the metrics of the server, with
+// their trust domain as a label.
 func (s *Server) Run(ctx context.Context) error {
-	if err := s.run(ctx); err != nil {
+	err := s.runWithMetrics(ctx)
+	if err == context.Canceled {
+		err = nil
+	}
+	if err != nil {
 		s.config.Log.WithError(err).Error("fatal run error")
 		return err
 	}
 	return nil
 }
 
-func (s *Server) run(ctx context.Context) (err error) {
+func (s *Server) runWithMetrics(ctx context.Context) error {
+	metrics, err := telemetry.NewMetrics(&telemetry.MetricsConfig{
+		FileConfig:  s.config.Telemetry,
+		Logger:      s.config.Log.WithField(telemetry.SubsystemName, telemetry.Telemetry),
+		ServiceName: telemetry.SpireServer,
+	})
+	if err != nil {
+		return err
+	}
+
+	telemetry.EmitVersion(metrics)
+
+	tasks := []func(context.Context) error{
+		metrics.ListenAndServe,
+		func(ctx context.Context) error {
+			return s.run(ctx, metrics)
+		},
+		s.superviseWithSystemd,
+	}
+	for _, hosted := range s.hosted {
+		hosted := hosted
+		hostedMetrics := telemetry.WithLabels(metrics, []telemetry.Label{
+			{Name: telemetry.TrustDomainID, Value: hosted.config.TrustDomain.String()},
+		})
+		tasks = append(tasks, func(ctx context.Context) error {
+			if err := hosted.run(ctx, hostedMetrics); err != nil {
+				return fmt.Errorf("trust domain %q: %v", hosted.config.TrustDomain.Host, err)
+			}
+			return nil
+		})
+	}
+	return util.RunTasks(ctx, tasks...)
+}
+
+func (s *Server) run(ctx context.Context, metrics telemetry.Metrics) (err error) {
 	// create the data directory if needed
 	s.config.Log.Infof("data directory: %q", s.config.DataDir)
+	if s.config.FIPS {
+		s.config.Log.Info("FIPS mode enabled")
+	}
 	if err := os.MkdirAll(s.config.DataDir, 0755); err != nil {
 		return err
 	}
@@ -69,20 +129,10 @@ func (s *Server) run(ctx context.Context) (err error) {
 		defer stopProfiling()
 	}
 
-	metrics, err := telemetry.NewMetrics(&telemetry.MetricsConfig{
-		FileConfig:  s.config.Telemetry,
-		Logger:      s.config.Log.WithField(telemetry.SubsystemName, telemetry.Telemetry),
-		ServiceName: telemetry.SpireServer,
-	})
-	if err != nil {
-		return err
-	}
 	metricsService := metricsservice.New(metricsservice.Config{
 		Metrics: metrics,
 	})
 
-	telemetry.EmitVersion(metrics)
-
 	// Create the identity provider host service. It will not be functional
 	// until the call to SetDeps() below. There is some tricky initialization
 	// stuff going on since the identity provider host service requires plugins
@@ -96,12 +146,19 @@ func (s *Server) run(ctx context.Context) (err error) {
 	// until the call to SetDeps() below.
 	agentStore := agentstore.New()
 
-	cat, err := s.loadCatalog(ctx, metrics, identityProvider, agentStore, metricsService)
+	// Changes to registration entries and bundles are pushed to the agents
+	// so they don't have to wait for their next sync.
+	syncEvents := syncevents.NewBroadcaster(clock.New(), syncevents.DefaultDelay)
+
+	cat, err := s.loadCatalog(ctx, metrics, identityProvider, agentStore, metricsService, syncEvents)
 	if err != nil {
 		return err
 	}
 	defer cat.Close()
 
+	s.setCatalog(cat)
+	defer s.setCatalog(nil)
+
 	healthChecks := health.NewChecker(s.config.HealthChecks, s.config.Log)
 
 	s.config.Log.Info("plugins started")
@@ -111,7 +168,7 @@ func (s *Server) run(ctx context.Context) (err error) {
 		return err
 	}
 
-	serverCA := s.newCA(metrics)
+	serverCA := s.newCA(cat, metrics)
 
 	// CA manager needs to be initialized before the rotator, otherwise the
 	// server CA plugin won't be able to sign CSRs
@@ -125,7 +182,13 @@ func (s *Server) run(ctx context.Context) (err error) {
 		return err
 	}
 
-	endpointsServer := s.newEndpointsServer(cat, svidRotator, serverCA, metrics, caManager)
+	auditLog, err := audit.Open(s.config.Log.WithField(telemetry.SubsystemName, telemetry.Audit), s.config.Audit)
+	if err != nil {
+		return err
+	}
+	defer auditLog.Close()
+
+	endpointsServer := s.newEndpointsServer(cat, svidRotator, serverCA, metrics, caManager, syncEvents, auditLog, healthChecks)
 
 	// Set the identity provider dependencies
 	if err := identityProvider.SetDeps(identityprovider.Deps{
@@ -156,12 +219,25 @@ func (s *Server) run(ctx context.Context) (err error) {
 	if err := healthChecks.AddCheck("server", s, time.Minute); err != nil {
 		return fmt.Errorf("failed adding healthcheck: %v", err)
 	}
+	subsystems := &subsystemChecks{
+		trustDomainID: s.config.TrustDomain.String(),
+		dataStore:     cat.GetDataStore(),
+		keyManager:    cat.GetKeyManager(),
+		plugins:       cat,
+		ca:            serverCA,
+		clock:         clock.New(),
+		metrics:       metrics,
+	}
+	if err := subsystems.register(healthChecks); err != nil {
+		return err
+	}
+
+	close(s.ready)
 
 	err = util.RunTasks(ctx,
 		caManager.Run,
 		svidRotator.Run,
 		endpointsServer.ListenAndServe,
-		metrics.ListenAndServe,
 		bundleManager.Run,
 		registrationManager.Run,
 		healthChecks.ListenAndServe,
@@ -172,6 +248,20 @@ func (s *Server) run(ctx context.Context) (err error) {
 	return err
 }
 
+// superviseWithSystemd notifies systemd that the server is ready once the
+// servers of all the trust domains it hosts are initialized, and keeps the
+// systemd watchdog alive while the server runs.
+func (s *Server) superviseWithSystemd(ctx context.Context) error {
+	for _, server := range append([]*Server{s}, s.hosted...) {
+		select {
+		case <-server.ready:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	return systemd.Supervise(ctx, s.config.Log)
+}
+
 func (s *Server) setupProfiling(ctx context.Context) (stop func()) {
 	ctx, cancel := context.WithCancel(ctx)
 	var wg sync.WaitGroup
@@ -179,7 +269,7 @@ func (s *Server) setupProfiling(ctx context.Context) (stop func()) {
 	if runtime.MemProfileRate == 0 {
 		s.config.Log.Warn("Memory profiles are disabled")
 	}
-	if s.config.ProfilingPort > 0 {
+	if s.config.ProfilingPort > 0 || s.config.ProfilingSocketPath != "" {
 		grpc.EnableTracing = true
 
 		server := http.Server{
@@ -192,7 +282,7 @@ func (s *Server) setupProfiling(ctx context.Context) (stop func()) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if err := server.ListenAndServe(); err != nil {
+			if err := profiling.Serve(&server, s.config.ProfilingSocketPath); err != nil {
 				s.config.Log.WithError(err).Warn("unable to serve profiling server")
 			}
 		}()
@@ -229,32 +319,76 @@ func (s *Server) setupProfiling(ctx context.Context) (stop func()) {
 }
 
 func (s *Server) loadCatalog(ctx context.Context, metrics telemetry.Metrics, identityProvider hostservices.IdentityProvider, agentStore hostservices.AgentStore,
-	metricsService common_services.MetricsService) (*catalog.Repository, error) {
+	metricsService common_services.MetricsService, syncEvents *syncevents.Broadcaster) (*catalog.Repository, error) {
 	return catalog.Load(ctx, catalog.Config{
 		Log: s.config.Log.WithField(telemetry.SubsystemName, telemetry.Catalog),
 		GlobalConfig: catalog.GlobalConfig{
 			TrustDomain: s.config.TrustDomain.Host,
 		},
-		PluginConfig:     s.config.PluginConfigs,
-		Metrics:          metrics,
-		IdentityProvider: identityProvider,
-		AgentStore:       agentStore,
-		MetricsService:   metricsService,
+		PluginConfig:               s.config.PluginConfigs,
+		Metrics:                    metrics,
+		DataStoreSlowCallThreshold: s.config.DataStoreSlowCallThreshold,
+		IdentityProvider:           identityProvider,
+		AgentStore:                 agentStore,
+		MetricsService:             metricsService,
+		SyncEvents:                 syncEvents,
+		PluginSelection:            s.config.PluginSelection,
 	})
 }
 
-func (s *Server) newCA(metrics telemetry.Metrics) *ca.CA {
+// ReconfigurePlugins configures the plugins of the running server again with
+// the given plugin configuration, so that plugin settings (e.g. upstream CA
+// credentials) can change without restarting the server.
+func (s *Server) ReconfigurePlugins(ctx context.Context, pluginConfig catalog.HCLPluginConfigMap) error {
+	s.catalogMtx.Lock()
+	cat := s.catalog
+	s.catalogMtx.Unlock()
+
+	if cat == nil {
+		return errors.New("plugins are not loaded")
+	}
+	return cat.Reconfigure(ctx, pluginConfig)
+}
+
+// ReconfigureHostedPlugins is like ReconfigurePlugins, for the plugins of a
+// hosted trust domain.
+func (s *Server) ReconfigureHostedPlugins(ctx context.Context, trustDomain string, pluginConfig catalog.HCLPluginConfigMap) error {
+	for _, hosted := range s.hosted {
+		if hosted.config.TrustDomain.Host == trustDomain {
+			return hosted.ReconfigurePlugins(ctx, pluginConfig)
+		}
+	}
+	return fmt.Errorf("trust domain %q is not hosted by the server", trustDomain)
+}
+
+func (s *Server) setCatalog(cat *catalog.Repository) {
+	s.catalogMtx.Lock()
+	defer s.catalogMtx.Unlock()
+	s.catalog = cat
+}
+
+func (s *Server) newCA(cat catalog.Catalog, metrics telemetry.Metrics) *ca.CA {
 	return ca.NewCA(ca.Config{
-		Log:         s.config.Log.WithField(telemetry.SubsystemName, telemetry.CA),
-		Metrics:     metrics,
-		X509SVIDTTL: s.config.SVIDTTL,
-		JWTIssuer:   s.config.JWTIssuer,
-		TrustDomain: s.config.TrustDomain,
-		CASubject:   s.config.CASubject,
+		Log:                       s.config.Log.WithField(telemetry.SubsystemName, telemetry.CA),
+		Metrics:                   metrics,
+		X509SVIDTTL:               s.config.SVIDTTL,
+		MaxSVIDTTL:                s.config.MaxSVIDTTL,
+		SigningConcurrency:        s.config.SigningConcurrency,
+		JWTIssuer:                 s.config.JWTIssuer,
+		TrustDomain:               s.config.TrustDomain,
+		CASubject:                 s.config.CASubject,
+		CredentialComposers:       cat.GetCredentialComposers(),
+		AllowedJWTSVIDClaims:      s.config.AllowedJWTSVIDClaims,
+		AllowedX509SVIDExtensions: s.config.AllowedX509SVIDExtensions,
 	})
 }
 
 func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics telemetry.Metrics, serverCA *ca.CA) (*ca.Manager, error) {
+	jwtKeyType := s.config.JWTKeyType
+	if jwtKeyType == keymanager.KeyType_UNSPECIFIED_KEY_TYPE {
+		jwtKeyType = s.config.CAKeyType
+	}
+
 	caManager := ca.NewManager(ca.ManagerConfig{
 		CA:             serverCA,
 		Catalog:        cat,
@@ -266,7 +400,8 @@ func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics
 		CASubject:      s.config.CASubject,
 		Dir:            s.config.DataDir,
 		X509CAKeyType:  s.config.CAKeyType,
-		JWTKeyType:     s.config.CAKeyType,
+		JWTKeyType:     jwtKeyType,
+		FIPS:           s.config.FIPS,
 	})
 	if err := caManager.Initialize(ctx); err != nil {
 		return nil, err
@@ -276,9 +411,13 @@ func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics
 
 func (s *Server) newRegistrationManager(cat catalog.Catalog, metrics telemetry.Metrics) *registration.Manager {
 	registrationManager := registration.NewManager(registration.ManagerConfig{
-		DataStore: cat.GetDataStore(),
-		Log:       s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationManager),
-		Metrics:   metrics,
+		DataStore:                    cat.GetDataStore(),
+		PruneAttestedNodesExpiredFor: s.config.PruneAttestedNodesExpiredFor,
+		PruneIssuedSVIDsExpiredFor:   s.config.PruneIssuedSVIDsExpiredFor,
+		ResolveNodeSelectorsInterval: s.config.ResolveNodeSelectorsInterval,
+		NodeResolvers:                cat,
+		Log:                          s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationManager),
+		Metrics:                      metrics,
 	})
 	return registrationManager
 }
@@ -296,10 +435,11 @@ func (s *Server) newSVIDRotator(ctx context.Context, serverCA ca.ServerCA, metri
 	return svidRotator, nil
 }
 
-func (s *Server) newEndpointsServer(catalog catalog.Catalog, svidObserver svid.Observer, serverCA ca.ServerCA, metrics telemetry.Metrics, caManager *ca.Manager) endpoints.Server {
+func (s *Server) newEndpointsServer(catalog catalog.Catalog, svidObserver svid.Observer, serverCA ca.ServerCA, metrics telemetry.Metrics, caManager *ca.Manager, syncEvents *syncevents.Broadcaster, auditLog *audit.Logger, healthChecks *health.Checker) endpoints.Server {
 	config := &endpoints.Config{
 		TCPAddr:                     s.config.BindAddress,
 		UDSAddr:                     s.config.BindUDSAddress,
+		RegistrationTCPAddr:         s.config.BindRegistrationAddress,
 		SVIDObserver:                svidObserver,
 		TrustDomain:                 s.config.TrustDomain,
 		Catalog:                     catalog,
@@ -307,11 +447,18 @@ func (s *Server) newEndpointsServer(catalog catalog.Catalog, svidObserver svid.O
 		Log:                         s.config.Log.WithField(telemetry.SubsystemName, telemetry.Endpoints),
 		Metrics:                     metrics,
 		Manager:                     caManager,
+		SyncEvents:                  syncEvents,
+		Audit:                       auditLog,
+		HealthChecks:                healthChecks,
+		GRPC:                        s.config.GRPC,
+		TLSPolicy:                   s.config.TLSPolicy,
 		AllowAgentlessNodeAttestors: s.config.Experimental.AllowAgentlessNodeAttestors,
+		WorkloadKeyPolicy:           s.config.WorkloadKeyPolicy,
+		NodeAPIRateLimits:           s.config.NodeAPIRateLimits,
+		TrackIssuedSVIDs:            s.config.TrackIssuedSVIDs,
 	}
 	if s.config.Federation.BundleEndpoint != nil {
-		config.BundleEndpoint.Address = s.config.Federation.BundleEndpoint.Address
-		config.BundleEndpoint.ACME = s.config.Federation.BundleEndpoint.ACME
+		config.BundleEndpoint = *s.config.Federation.BundleEndpoint
 	}
 	return endpoints.New(config)
 }
@@ -157,3 +157,27 @@ func (suite *ServerTestSuite) TestValidateTrustDomain() {
 	suite.NoError(err)
 	suite.Require().Contains(suite.stdout.String(), invalidSpiffeIDAttestedNode)
 }
+
+func (suite *ServerTestSuite) TestReconfigureHostedPlugins() {
+	server := New(Config{
+		Log:         suite.server.config.Log,
+		TrustDomain: suite.server.config.TrustDomain,
+		HostedTrustDomains: []Config{
+			{
+				Log: suite.server.config.Log,
+				TrustDomain: url.URL{
+					Scheme: "spiffe",
+					Host:   "hosted.test",
+				},
+			},
+		},
+	})
+	suite.Len(server.hosted, 1)
+
+	err := server.ReconfigureHostedPlugins(context.Background(), "unknown.test", nil)
+	suite.EqualError(err, `trust domain "unknown.test" is not hosted by the server`)
+
+	// the hosted trust domain is found, but its server is not running
+	err = server.ReconfigureHostedPlugins(context.Background(), "hosted.test", nil)
+	suite.EqualError(err, "plugins are not loaded")
+}
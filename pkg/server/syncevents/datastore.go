@@ -0,0 +1,87 @@
+package syncevents
+
+import (
+	"context"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+)
+
+// WithNotifications wraps a datastore and notifies the broadcaster of
+// successful changes to registration entries and bundles. Pruning expired
+// registration entries is not notified since agents drop them on their own.
+func WithNotifications(ds datastore.DataStore, b *Broadcaster) datastore.DataStore {
+	return notifyingDataStore{DataStore: ds, b: b}
+}
+
+type notifyingDataStore struct {
+	datastore.DataStore
+	b *Broadcaster
+}
+
+var (
+	entriesChanged = Event{EntriesChanged: true}
+	bundlesChanged = Event{BundlesChanged: true}
+)
+
+func (ds notifyingDataStore) notifyOnSuccess(event Event, err error) {
+	if err == nil {
+		ds.b.Notify(event)
+	}
+}
+
+func (ds notifyingDataStore) CreateRegistrationEntry(ctx context.Context, req *datastore.CreateRegistrationEntryRequest) (*datastore.CreateRegistrationEntryResponse, error) {
+	resp, err := ds.DataStore.CreateRegistrationEntry(ctx, req)
+	ds.notifyOnSuccess(entriesChanged, err)
+	return resp, err
+}
+
+func (ds notifyingDataStore) UpdateRegistrationEntry(ctx context.Context, req *datastore.UpdateRegistrationEntryRequest) (*datastore.UpdateRegistrationEntryResponse, error) {
+	resp, err := ds.DataStore.UpdateRegistrationEntry(ctx, req)
+	ds.notifyOnSuccess(entriesChanged, err)
+	return resp, err
+}
+
+func (ds notifyingDataStore) DeleteRegistrationEntry(ctx context.Context, req *datastore.DeleteRegistrationEntryRequest) (*datastore.DeleteRegistrationEntryResponse, error) {
+	resp, err := ds.DataStore.DeleteRegistrationEntry(ctx, req)
+	ds.notifyOnSuccess(entriesChanged, err)
+	return resp, err
+}
+
+func (ds notifyingDataStore) AppendBundle(ctx context.Context, req *datastore.AppendBundleRequest) (*datastore.AppendBundleResponse, error) {
+	resp, err := ds.DataStore.AppendBundle(ctx, req)
+	ds.notifyOnSuccess(bundlesChanged, err)
+	return resp, err
+}
+
+func (ds notifyingDataStore) CreateBundle(ctx context.Context, req *datastore.CreateBundleRequest) (*datastore.CreateBundleResponse, error) {
+	resp, err := ds.DataStore.CreateBundle(ctx, req)
+	ds.notifyOnSuccess(bundlesChanged, err)
+	return resp, err
+}
+
+func (ds notifyingDataStore) DeleteBundle(ctx context.Context, req *datastore.DeleteBundleRequest) (*datastore.DeleteBundleResponse, error) {
+	resp, err := ds.DataStore.DeleteBundle(ctx, req)
+	// Deleting a bundle may also delete or dissociate registration entries
+	ds.notifyOnSuccess(bundlesChanged.merge(entriesChanged), err)
+	return resp, err
+}
+
+func (ds notifyingDataStore) PruneBundle(ctx context.Context, req *datastore.PruneBundleRequest) (*datastore.PruneBundleResponse, error) {
+	resp, err := ds.DataStore.PruneBundle(ctx, req)
+	if err == nil && resp.BundleChanged {
+		ds.b.Notify(bundlesChanged)
+	}
+	return resp, err
+}
+
+func (ds notifyingDataStore) SetBundle(ctx context.Context, req *datastore.SetBundleRequest) (*datastore.SetBundleResponse, error) {
+	resp, err := ds.DataStore.SetBundle(ctx, req)
+	ds.notifyOnSuccess(bundlesChanged, err)
+	return resp, err
+}
+
+func (ds notifyingDataStore) UpdateBundle(ctx context.Context, req *datastore.UpdateBundleRequest) (*datastore.UpdateBundleResponse, error) {
+	resp, err := ds.DataStore.UpdateBundle(ctx, req)
+	ds.notifyOnSuccess(bundlesChanged, err)
+	return resp, err
+}
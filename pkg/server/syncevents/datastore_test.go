@@ -0,0 +1,91 @@
+package syncevents
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithNotifications(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewMock(t)
+	b := NewBroadcaster(clk, time.Second)
+	sub := b.Subscribe()
+	defer sub.Close()
+
+	fakeDS := fakedatastore.New(t)
+	ds := WithNotifications(fakeDS, b)
+
+	expectEvent := func(expected Event) {
+		clk.Add(time.Second)
+		assert.Equal(t, expected, requireEvent(t, sub))
+	}
+
+	ca, _, err := util.LoadCAFixture()
+	require.NoError(t, err)
+	bundle := &common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas:       []*common.Certificate{{DerBytes: ca.Raw}},
+		JwtSigningKeys: []*common.PublicKey{
+			{Kid: "KID", PkixBytes: []byte("KEY"), NotAfter: ca.NotAfter.Unix()},
+		},
+	}
+	_, err = ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	require.NoError(t, err)
+	expectEvent(Event{BundlesChanged: true})
+
+	entryResp, err := ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			ParentId:  "spiffe://example.org/agent",
+			SpiffeId:  "spiffe://example.org/workload",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+	})
+	require.NoError(t, err)
+	expectEvent(Event{EntriesChanged: true})
+
+	entry := entryResp.Entry
+	entry.Ttl = 60
+	_, err = ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{Entry: entry})
+	require.NoError(t, err)
+	expectEvent(Event{EntriesChanged: true})
+
+	_, err = ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{EntryId: entry.EntryId})
+	require.NoError(t, err)
+	expectEvent(Event{EntriesChanged: true})
+
+	// Failed changes are not notified
+	fakeDS.SetNextError(errors.New("oh no"))
+	_, err = ds.SetBundle(ctx, &datastore.SetBundleRequest{Bundle: bundle})
+	require.EqualError(t, err, "oh no")
+	clk.Add(time.Second)
+	assertNoEvent(t, sub)
+
+	// Pruning is only notified when the bundle changed
+	_, err = ds.PruneBundle(ctx, &datastore.PruneBundleRequest{
+		TrustDomainId: bundle.TrustDomainId,
+		ExpiresBefore: ca.NotBefore.Unix(),
+	})
+	require.NoError(t, err)
+	clk.Add(time.Second)
+	assertNoEvent(t, sub)
+
+	// Reads are not notified
+	_, err = ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	require.NoError(t, err)
+	clk.Add(time.Second)
+	assertNoEvent(t, sub)
+
+	_, err = ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{TrustDomainId: bundle.TrustDomainId})
+	require.NoError(t, err)
+	expectEvent(Event{EntriesChanged: true, BundlesChanged: true})
+}
@@ -0,0 +1,130 @@
+// Package syncevents notifies subscribers, like the agents connected to the
+// node API, that registration entries or bundles have changed so they can
+// synchronize without waiting for their next sync interval.
+package syncevents
+
+import (
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+)
+
+// DefaultDelay is how long changes are coalesced before subscribers are
+// notified. It is longer than the expiry of the node API datastore caches
+// so agents synchronizing on notification observe the changes.
+const DefaultDelay = 2 * time.Second
+
+// Event describes what changed since the last event.
+type Event struct {
+	EntriesChanged bool
+	BundlesChanged bool
+}
+
+func (e Event) merge(other Event) Event {
+	return Event{
+		EntriesChanged: e.EntriesChanged || other.EntriesChanged,
+		BundlesChanged: e.BundlesChanged || other.BundlesChanged,
+	}
+}
+
+// Broadcaster coalesces the changes it is notified about for a short delay
+// and then sends a single event to every subscriber.
+type Broadcaster struct {
+	clk   clock.Clock
+	delay time.Duration
+
+	mu      sync.Mutex
+	pending Event
+	timer   *clock.Timer
+	subs    map[*Subscription]struct{}
+}
+
+// NewBroadcaster creates a broadcaster that waits delay after the first
+// change before notifying subscribers.
+func NewBroadcaster(clk clock.Clock, delay time.Duration) *Broadcaster {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &Broadcaster{
+		clk:   clk,
+		delay: delay,
+		subs:  make(map[*Subscription]struct{}),
+	}
+}
+
+// Notify records a change. Subscribers are notified once the delay elapses.
+func (b *Broadcaster) Notify(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pending = b.pending.merge(event)
+	if b.timer == nil {
+		b.timer = b.clk.AfterFunc(b.delay, b.broadcast)
+	}
+}
+
+// Subscribe returns a subscription receiving the events broadcasted from now
+// on. The subscription must be closed when no longer used.
+func (b *Broadcaster) Subscribe() *Subscription {
+	sub := &Subscription{
+		b:  b,
+		ch: make(chan Event, 1),
+	}
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+	return sub
+}
+
+// CountSubscriptions returns the number of open subscriptions.
+func (b *Broadcaster) CountSubscriptions() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
+
+func (b *Broadcaster) broadcast() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	event := b.pending
+	b.pending = Event{}
+	b.timer = nil
+	for sub := range b.subs {
+		sub.send(event)
+	}
+}
+
+// Subscription receives the events of a broadcaster. Events not yet received
+// are merged, so slow subscribers never block the broadcaster.
+type Subscription struct {
+	b  *Broadcaster
+	ch chan Event
+}
+
+// Events returns the channel events are received on.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Close unsubscribes from the broadcaster.
+func (s *Subscription) Close() {
+	s.b.mu.Lock()
+	delete(s.b.subs, s)
+	s.b.mu.Unlock()
+}
+
+// send is only called with the broadcaster lock held, so the channel can
+// only be drained concurrently, never filled.
+func (s *Subscription) send(event Event) {
+	for {
+		select {
+		case s.ch <- event:
+			return
+		case prev := <-s.ch:
+			event = event.merge(prev)
+		}
+	}
+}
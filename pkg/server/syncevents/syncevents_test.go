@@ -0,0 +1,90 @@
+package syncevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/test/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcasterCoalescesChanges(t *testing.T) {
+	clk := clock.NewMock(t)
+	b := NewBroadcaster(clk, time.Second)
+
+	sub := b.Subscribe()
+	defer sub.Close()
+
+	b.Notify(Event{EntriesChanged: true})
+	clk.Add(500 * time.Millisecond)
+	b.Notify(Event{BundlesChanged: true})
+	assertNoEvent(t, sub)
+
+	// The delay starts with the first change
+	clk.Add(500 * time.Millisecond)
+	assert.Equal(t, Event{EntriesChanged: true, BundlesChanged: true}, requireEvent(t, sub))
+
+	b.Notify(Event{BundlesChanged: true})
+	clk.Add(time.Second)
+	assert.Equal(t, Event{BundlesChanged: true}, requireEvent(t, sub))
+}
+
+func TestBroadcasterMergesUnreceivedEvents(t *testing.T) {
+	clk := clock.NewMock(t)
+	b := NewBroadcaster(clk, time.Second)
+
+	sub := b.Subscribe()
+	defer sub.Close()
+
+	b.Notify(Event{EntriesChanged: true})
+	clk.Add(time.Second)
+	b.Notify(Event{BundlesChanged: true})
+	clk.Add(time.Second)
+
+	assert.Equal(t, Event{EntriesChanged: true, BundlesChanged: true}, requireEvent(t, sub))
+	assertNoEvent(t, sub)
+}
+
+func TestBroadcasterSubscriptions(t *testing.T) {
+	clk := clock.NewMock(t)
+	b := NewBroadcaster(clk, time.Second)
+
+	sub1 := b.Subscribe()
+	sub2 := b.Subscribe()
+	assert.Equal(t, 2, b.CountSubscriptions())
+
+	b.Notify(Event{EntriesChanged: true})
+	clk.Add(time.Second)
+	assert.Equal(t, Event{EntriesChanged: true}, requireEvent(t, sub1))
+	assert.Equal(t, Event{EntriesChanged: true}, requireEvent(t, sub2))
+
+	sub1.Close()
+	assert.Equal(t, 1, b.CountSubscriptions())
+
+	b.Notify(Event{EntriesChanged: true})
+	clk.Add(time.Second)
+	assertNoEvent(t, sub1)
+	assert.Equal(t, Event{EntriesChanged: true}, requireEvent(t, sub2))
+
+	sub2.Close()
+	assert.Equal(t, 0, b.CountSubscriptions())
+}
+
+func requireEvent(t *testing.T, sub *Subscription) Event {
+	select {
+	case event := <-sub.Events():
+		return event
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for event")
+		return Event{}
+	}
+}
+
+func assertNoEvent(t *testing.T, sub *Subscription) {
+	select {
+	case event := <-sub.Events():
+		assert.Fail(t, "unexpected event", "%+v", event)
+	default:
+	}
+}
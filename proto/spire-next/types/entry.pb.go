@@ -35,7 +35,7 @@ type Entry struct {
 	// node attestation. Otherwise, these selectors represent those produced by
 	// workload attestation.
 	Selectors []*Selector `protobuf:"bytes,4,rep,name=selectors,proto3" json:"selectors,omitempty"`
-	// The time to live for identities issued for this entry (in seconds).
+	// The time to live for X509-SVIDs issued for this entry (in seconds).
 	Ttl int32 `protobuf:"varint,5,opt,name=ttl,proto3" json:"ttl,omitempty"`
 	// The names of trust domains the identity described by this entry
 	// federates with.
@@ -52,7 +52,13 @@ type Entry struct {
 	// When the entry expires (seconds since Unix epoch).
 	ExpiresAt int64 `protobuf:"varint,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	// A list of DNS names associated with the identity described by this entry.
-	DnsNames             []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	DnsNames []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	// The time to live for JWT-SVIDs issued for this entry (in seconds). If
+	// unset, the server default JWT-SVID TTL is used.
+	JwtSvidTtl int32 `protobuf:"varint,11,opt,name=jwt_svid_ttl,json=jwtSvidTtl,proto3" json:"jwt_svid_ttl,omitempty"`
+	// An operator-specified string used by workloads to select the right SVID
+	// when they are issued more than one, e.g. "internal" or "external".
+	Hint                 string   `protobuf:"bytes,12,opt,name=hint,proto3" json:"hint,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -153,6 +159,20 @@ func (m *Entry) GetDnsNames() []string {
 	return nil
 }
 
+func (m *Entry) GetJwtSvidTtl() int32 {
+	if m != nil {
+		return m.JwtSvidTtl
+	}
+	return 0
+}
+
+func (m *Entry) GetHint() string {
+	if m != nil {
+		return m.Hint
+	}
+	return ""
+}
+
 // Field mask for Entry fields
 type EntryMask struct {
 	// spiffe_id field mask
@@ -172,7 +192,11 @@ type EntryMask struct {
 	// expires_at field mask
 	ExpiresAt bool `protobuf:"varint,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	// dns_names field mask
-	DnsNames             bool     `protobuf:"varint,10,opt,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	DnsNames bool `protobuf:"varint,10,opt,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	// jwt_svid_ttl field mask
+	JwtSvidTtl bool `protobuf:"varint,11,opt,name=jwt_svid_ttl,json=jwtSvidTtl,proto3" json:"jwt_svid_ttl,omitempty"`
+	// hint field mask
+	Hint                 bool     `protobuf:"varint,12,opt,name=hint,proto3" json:"hint,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -266,6 +290,20 @@ func (m *EntryMask) GetDnsNames() bool {
 	return false
 }
 
+func (m *EntryMask) GetJwtSvidTtl() bool {
+	if m != nil {
+		return m.JwtSvidTtl
+	}
+	return false
+}
+
+func (m *EntryMask) GetHint() bool {
+	if m != nil {
+		return m.Hint
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*Entry)(nil), "spire.types.Entry")
 	proto.RegisterType((*EntryMask)(nil), "spire.types.EntryMask")
@@ -274,29 +312,31 @@ func init() {
 func init() { proto.RegisterFile("spire-next/types/entry.proto", fileDescriptor_e0e2bfec39452b8c) }
 
 var fileDescriptor_e0e2bfec39452b8c = []byte{
-	// 376 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x92, 0xdf, 0x4a, 0xf3, 0x30,
-	0x18, 0x87, 0x69, 0xfb, 0x75, 0x5f, 0x92, 0xf1, 0x8d, 0x8f, 0xa0, 0x10, 0xdc, 0xd4, 0x32, 0x10,
-	0x7a, 0x62, 0x2b, 0xdb, 0x15, 0x28, 0x6e, 0xd0, 0x03, 0x45, 0xea, 0x81, 0xe0, 0x49, 0xe9, 0x96,
-	0xcc, 0x05, 0xd7, 0xb4, 0x34, 0x91, 0x6d, 0x37, 0xe1, 0x2d, 0x7a, 0x2b, 0x92, 0x74, 0xd3, 0xee,
-	0x8f, 0x3b, 0xf3, 0xac, 0x79, 0x7f, 0xcf, 0xdb, 0xbc, 0x7d, 0xfa, 0xa2, 0x8e, 0x2c, 0x78, 0xc9,
-	0x2e, 0x05, 0x5b, 0xa8, 0x50, 0x2d, 0x0b, 0x26, 0x43, 0x26, 0x54, 0xb9, 0x0c, 0x8a, 0x32, 0x57,
-	0x39, 0x6e, 0x9a, 0x34, 0x30, 0xc1, 0xc9, 0xf9, 0x0e, 0x2a, 0xd9, 0x8c, 0x8d, 0x55, 0x5e, 0x56,
-	0xf4, 0x3e, 0xa0, 0xe0, 0x93, 0x09, 0xe3, 0xb4, 0x02, 0xba, 0x1f, 0x36, 0x72, 0x07, 0xfa, 0xf5,
-	0xb8, 0x85, 0x6c, 0x4e, 0x89, 0xe5, 0x59, 0x3e, 0x8c, 0x6d, 0x4e, 0x71, 0x0f, 0xc1, 0x8a, 0x4d,
-	0x38, 0x25, 0xb6, 0x67, 0xf9, 0xcd, 0xde, 0x71, 0x50, 0xbb, 0x3c, 0x78, 0x7c, 0x88, 0x86, 0xc3,
-	0x41, 0x74, 0x1b, 0x83, 0x8a, 0x8b, 0x4c, 0x4f, 0x91, 0x96, 0x4c, 0x28, 0xdd, 0xe3, 0x1c, 0xec,
-	0xa9, 0xb8, 0x88, 0xe2, 0x3e, 0x82, 0xeb, 0xa1, 0x25, 0xf9, 0xe3, 0x39, 0xbb, 0x3d, 0xab, 0x34,
-	0xfe, 0xe6, 0xf0, 0x7f, 0xe4, 0x28, 0x35, 0x23, 0xae, 0x67, 0xf9, 0x6e, 0xac, 0x1f, 0xf1, 0x05,
-	0x6a, 0x4d, 0x18, 0x65, 0x65, 0xaa, 0x98, 0x4c, 0xe6, 0x5c, 0x4d, 0x49, 0xc3, 0x73, 0x7c, 0x18,
-	0xff, 0xfb, 0xaa, 0x3e, 0x71, 0x35, 0xc5, 0x47, 0xc8, 0x4d, 0x69, 0xc6, 0x05, 0xf9, 0xeb, 0x59,
-	0x3e, 0x88, 0xab, 0x03, 0x3e, 0x43, 0x88, 0xe6, 0x73, 0x21, 0x55, 0xc9, 0xd2, 0x8c, 0x00, 0x13,
-	0xd5, 0x2a, 0xf8, 0x14, 0x21, 0xb6, 0xd0, 0x23, 0xc9, 0x24, 0x55, 0x04, 0x7a, 0x96, 0xef, 0xc4,
-	0x70, 0x55, 0xb9, 0x56, 0xb8, 0x8d, 0x20, 0x15, 0x32, 0x11, 0x69, 0xc6, 0x24, 0x41, 0xe6, 0x5a,
-	0x40, 0x85, 0xbc, 0xd7, 0xe7, 0xee, 0xbb, 0x8d, 0xa0, 0x31, 0x7c, 0x97, 0xca, 0x57, 0x8d, 0x6e,
-	0x5a, 0x05, 0x35, 0x7d, 0xed, 0x6d, 0x7d, 0xa0, 0xe6, 0xa9, 0xb3, 0xe9, 0x49, 0x87, 0xfb, 0x85,
-	0x80, 0x9f, 0x85, 0xe8, 0xf0, 0x97, 0x84, 0x80, 0x03, 0x42, 0xcc, 0x87, 0xac, 0x85, 0xdc, 0x5c,
-	0x3d, 0x07, 0x2f, 0x5c, 0x4d, 0xdf, 0x46, 0xc1, 0x38, 0xcf, 0x56, 0xfb, 0x18, 0x9a, 0x1f, 0x1e,
-	0x9a, 0x9d, 0x0c, 0xb7, 0x77, 0x76, 0xd4, 0x30, 0xf5, 0xfe, 0x67, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x23, 0x05, 0x1d, 0xbf, 0x1a, 0x03, 0x00, 0x00,
+	// 415 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x93, 0xdf, 0x6a, 0xd5, 0x40,
+	0x10, 0xc6, 0x49, 0xd2, 0xd4, 0xdd, 0x39, 0xb5, 0xc8, 0xa2, 0xb0, 0xd8, 0xaa, 0x4b, 0x41, 0xc8,
+	0x8d, 0x89, 0xb4, 0x4f, 0xa0, 0xd8, 0x42, 0x2e, 0x14, 0x49, 0x05, 0xc1, 0x9b, 0x90, 0x76, 0xf7,
+	0x98, 0xad, 0x27, 0x9b, 0x90, 0x1d, 0x9b, 0xf6, 0x41, 0x7c, 0x23, 0x1f, 0x4c, 0x76, 0x73, 0xd4,
+	0xb4, 0xe7, 0xcf, 0x5d, 0xef, 0x76, 0xe7, 0xfb, 0x26, 0x33, 0xf9, 0xcd, 0x2c, 0x1c, 0xda, 0x4e,
+	0xf7, 0xea, 0x8d, 0x51, 0x37, 0x98, 0xe1, 0x6d, 0xa7, 0x6c, 0xa6, 0x0c, 0xf6, 0xb7, 0x69, 0xd7,
+	0xb7, 0xd8, 0xb2, 0x99, 0x57, 0x53, 0x2f, 0x3c, 0x7f, 0xb5, 0x62, 0xb5, 0x6a, 0xa1, 0x2e, 0xb1,
+	0xed, 0x47, 0xf7, 0x3a, 0x43, 0xa7, 0xe7, 0x73, 0xa5, 0xe5, 0x68, 0x38, 0xfa, 0x15, 0x41, 0x7c,
+	0xea, 0x3e, 0xcf, 0xf6, 0x21, 0xd4, 0x92, 0x07, 0x22, 0x48, 0x68, 0x11, 0x6a, 0xc9, 0x8e, 0x81,
+	0x8e, 0xde, 0x52, 0x4b, 0x1e, 0x8a, 0x20, 0x99, 0x1d, 0x3f, 0x4b, 0x27, 0xc5, 0xd3, 0xf3, 0xcf,
+	0xf9, 0xd9, 0xd9, 0x69, 0xfe, 0xa1, 0x20, 0xa3, 0x2f, 0xf7, 0x39, 0x5d, 0xd5, 0x2b, 0x83, 0x2e,
+	0x27, 0xda, 0x9a, 0x33, 0xfa, 0x72, 0xc9, 0x4e, 0x80, 0xfe, 0x6d, 0xda, 0xf2, 0x1d, 0x11, 0xad,
+	0xe6, 0x2c, 0xd5, 0xe2, 0xbf, 0x8f, 0x3d, 0x81, 0x08, 0x71, 0xc1, 0x63, 0x11, 0x24, 0x71, 0xe1,
+	0x8e, 0xec, 0x35, 0xec, 0xcf, 0x95, 0x54, 0x7d, 0x85, 0xca, 0x96, 0x83, 0xc6, 0x9a, 0xef, 0x8a,
+	0x28, 0xa1, 0xc5, 0xe3, 0x7f, 0xd1, 0xaf, 0x1a, 0x6b, 0xf6, 0x14, 0xe2, 0x4a, 0x36, 0xda, 0xf0,
+	0x47, 0x22, 0x48, 0x48, 0x31, 0x5e, 0xd8, 0x4b, 0x00, 0xd9, 0x0e, 0xc6, 0x62, 0xaf, 0xaa, 0x86,
+	0x13, 0x2f, 0x4d, 0x22, 0xec, 0x05, 0x80, 0xba, 0x71, 0x2d, 0xd9, 0xb2, 0x42, 0x4e, 0x45, 0x90,
+	0x44, 0x05, 0x5d, 0x46, 0xde, 0x21, 0x3b, 0x00, 0x2a, 0x8d, 0x2d, 0x4d, 0xd5, 0x28, 0xcb, 0xc1,
+	0x97, 0x25, 0xd2, 0xd8, 0x4f, 0xee, 0xce, 0x04, 0xec, 0x5d, 0x0d, 0x58, 0xda, 0x6b, 0x2d, 0x4b,
+	0xd7, 0xf3, 0xcc, 0xf7, 0x0c, 0x57, 0x03, 0x9e, 0x5f, 0x6b, 0xf9, 0x05, 0x17, 0x8c, 0xc1, 0x4e,
+	0xad, 0x0d, 0xf2, 0x3d, 0xcf, 0xde, 0x9f, 0x8f, 0x7e, 0x87, 0x40, 0xfd, 0x5c, 0x3e, 0x56, 0xf6,
+	0x87, 0x2b, 0x70, 0x77, 0x16, 0x64, 0x02, 0xfd, 0xe0, 0x3e, 0x74, 0x32, 0xa1, 0x7b, 0x78, 0x97,
+	0xae, 0x13, 0xd7, 0x63, 0x24, 0x9b, 0x31, 0x3a, 0xf1, 0x81, 0x30, 0x92, 0x2d, 0x18, 0xfd, 0x8f,
+	0x6c, 0xc5, 0x48, 0x36, 0x62, 0x24, 0x23, 0xc6, 0xf7, 0x6f, 0xbf, 0xa5, 0xdf, 0x35, 0xd6, 0x3f,
+	0x2f, 0xd2, 0xcb, 0xb6, 0x59, 0xee, 0x7e, 0xe6, 0x97, 0x2b, 0xf3, 0xfb, 0x9f, 0xdd, 0x7f, 0x1f,
+	0x17, 0xbb, 0x3e, 0x7e, 0xf2, 0x67, 0x00, 0xc2, 0xdf, 0xe2, 0xe3, 0x86, 0x03, 0x00, 0x00,
 }
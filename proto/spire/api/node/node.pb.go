@@ -141,10 +141,16 @@ type X509SVIDUpdate struct {
 	// ID. Bundles included are the trust bundle for the server trust domain
 	// and any federated trust domain bundles applicable to the SVIDs.
 	// Supersedes the deprecated `bundle` field.
-	Bundles              map[string]*common.Bundle `protobuf:"bytes,5,rep,name=bundles,proto3" json:"bundles,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Bundles map[string]*common.Bundle `protobuf:"bytes,5,rep,name=bundles,proto3" json:"bundles,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Whether the agent must rotate its SVID, regardless of its lifetime.
+	// Set until the agent renews its SVID.
+	RotateAgentSvid bool `protobuf:"varint,6,opt,name=rotate_agent_svid,json=rotateAgentSvid,proto3" json:"rotate_agent_svid,omitempty"`
+	// Reasons why CSRs were rejected by the server, keyed like `svids`.
+	// Rejected CSRs have no entry in `svids`.
+	RejectedCsrs         map[string]string `protobuf:"bytes,7,rep,name=rejected_csrs,json=rejectedCsrs,proto3" json:"rejected_csrs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *X509SVIDUpdate) Reset()         { *m = X509SVIDUpdate{} }
@@ -193,6 +199,20 @@ func (m *X509SVIDUpdate) GetBundles() map[string]*common.Bundle {
 	return nil
 }
 
+func (m *X509SVIDUpdate) GetRotateAgentSvid() bool {
+	if m != nil {
+		return m.RotateAgentSvid
+	}
+	return false
+}
+
+func (m *X509SVIDUpdate) GetRejectedCsrs() map[string]string {
+	if m != nil {
+		return m.RejectedCsrs
+	}
+	return nil
+}
+
 // JSR is a JWT SVID signing request.
 type JSR struct {
 	// SPIFFE ID of the workload
@@ -833,11 +853,94 @@ func (m *FetchBundleResponse) GetBundle() *common.Bundle {
 	return nil
 }
 
+type SubscribeToSyncEventsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeToSyncEventsRequest) Reset()         { *m = SubscribeToSyncEventsRequest{} }
+func (m *SubscribeToSyncEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeToSyncEventsRequest) ProtoMessage()    {}
+func (*SubscribeToSyncEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_401cce7859a3d90b, []int{17}
+}
+
+func (m *SubscribeToSyncEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeToSyncEventsRequest.Unmarshal(m, b)
+}
+func (m *SubscribeToSyncEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeToSyncEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeToSyncEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeToSyncEventsRequest.Merge(m, src)
+}
+func (m *SubscribeToSyncEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeToSyncEventsRequest.Size(m)
+}
+func (m *SubscribeToSyncEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeToSyncEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeToSyncEventsRequest proto.InternalMessageInfo
+
+// Notifies the agent that registration entries or bundles have changed on the
+// server. The agent is expected to synchronize through FetchX509SVID.
+type SyncEvent struct {
+	// Registration entries or attested node selectors have changed
+	EntriesChanged bool `protobuf:"varint,1,opt,name=entries_changed,json=entriesChanged,proto3" json:"entries_changed,omitempty"`
+	// Bundles have changed
+	BundlesChanged       bool     `protobuf:"varint,2,opt,name=bundles_changed,json=bundlesChanged,proto3" json:"bundles_changed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncEvent) Reset()         { *m = SyncEvent{} }
+func (m *SyncEvent) String() string { return proto.CompactTextString(m) }
+func (*SyncEvent) ProtoMessage()    {}
+func (*SyncEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_401cce7859a3d90b, []int{18}
+}
+
+func (m *SyncEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncEvent.Unmarshal(m, b)
+}
+func (m *SyncEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncEvent.Marshal(b, m, deterministic)
+}
+func (m *SyncEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncEvent.Merge(m, src)
+}
+func (m *SyncEvent) XXX_Size() int {
+	return xxx_messageInfo_SyncEvent.Size(m)
+}
+func (m *SyncEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncEvent proto.InternalMessageInfo
+
+func (m *SyncEvent) GetEntriesChanged() bool {
+	if m != nil {
+		return m.EntriesChanged
+	}
+	return false
+}
+
+func (m *SyncEvent) GetBundlesChanged() bool {
+	if m != nil {
+		return m.BundlesChanged
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*Bundle)(nil), "spire.api.node.Bundle")
 	proto.RegisterType((*X509SVID)(nil), "spire.api.node.X509SVID")
 	proto.RegisterType((*X509SVIDUpdate)(nil), "spire.api.node.X509SVIDUpdate")
 	proto.RegisterMapType((map[string]*common.Bundle)(nil), "spire.api.node.X509SVIDUpdate.BundlesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "spire.api.node.X509SVIDUpdate.RejectedCsrsEntry")
 	proto.RegisterMapType((map[string]*X509SVID)(nil), "spire.api.node.X509SVIDUpdate.SvidsEntry")
 	proto.RegisterType((*JSR)(nil), "spire.api.node.JSR")
 	proto.RegisterType((*JWTSVID)(nil), "spire.api.node.JWTSVID")
@@ -854,71 +957,81 @@ func init() {
 	proto.RegisterType((*PushJWTKeyUpstreamResponse)(nil), "spire.api.node.PushJWTKeyUpstreamResponse")
 	proto.RegisterType((*FetchBundleRequest)(nil), "spire.api.node.FetchBundleRequest")
 	proto.RegisterType((*FetchBundleResponse)(nil), "spire.api.node.FetchBundleResponse")
+	proto.RegisterType((*SubscribeToSyncEventsRequest)(nil), "spire.api.node.SubscribeToSyncEventsRequest")
+	proto.RegisterType((*SyncEvent)(nil), "spire.api.node.SyncEvent")
 }
 
 func init() { proto.RegisterFile("spire/api/node/node.proto", fileDescriptor_401cce7859a3d90b) }
 
 var fileDescriptor_401cce7859a3d90b = []byte{
-	// 937 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdb, 0x6e, 0xdb, 0x46,
-	0x10, 0x05, 0x4d, 0x5b, 0x96, 0x46, 0x8a, 0x6c, 0xac, 0xd5, 0x46, 0x66, 0xeb, 0xd4, 0x60, 0x92,
-	0xc6, 0x75, 0x0c, 0xca, 0x70, 0x50, 0xf4, 0x82, 0x02, 0x81, 0x2c, 0xab, 0x48, 0x2c, 0xb4, 0x10,
-	0x56, 0x49, 0x9a, 0xb6, 0x0f, 0xea, 0x8a, 0xdc, 0xc8, 0x6b, 0xcb, 0xa4, 0xca, 0x5d, 0xc6, 0xd5,
-	0x17, 0xf4, 0xbd, 0x7f, 0x53, 0xa0, 0x1f, 0x57, 0xec, 0x85, 0x12, 0x29, 0x4b, 0x56, 0x1e, 0xf2,
-	0x62, 0x93, 0x33, 0x67, 0xce, 0xcc, 0x1c, 0x9e, 0xa5, 0x08, 0xbb, 0x7c, 0xcc, 0x62, 0xda, 0x20,
-	0x63, 0xd6, 0x08, 0xa3, 0x80, 0xaa, 0x3f, 0xde, 0x38, 0x8e, 0x44, 0x84, 0xaa, 0x2a, 0xe5, 0x91,
-	0x31, 0xf3, 0x64, 0xd4, 0x31, 0x50, 0x3f, 0xba, 0xbe, 0x8e, 0x42, 0xf3, 0x4f, 0x43, 0xdd, 0x67,
-	0x50, 0x38, 0x4d, 0xc2, 0x60, 0x44, 0x51, 0x15, 0xd6, 0x58, 0x50, 0xb7, 0xf6, 0xad, 0x83, 0x12,
-	0x5e, 0x63, 0x01, 0xda, 0x85, 0xa2, 0x4f, 0xfa, 0x3e, 0x8d, 0x05, 0xaf, 0xaf, 0xed, 0x5b, 0x07,
-	0x15, 0xbc, 0xe9, 0x93, 0x96, 0xbc, 0x75, 0x5f, 0x40, 0xf1, 0xed, 0xd7, 0xc7, 0xdf, 0xf5, 0xde,
-	0xbc, 0x3c, 0x43, 0x7b, 0x00, 0x12, 0xd3, 0xf7, 0x2f, 0x08, 0x0b, 0xeb, 0xb6, 0x02, 0x96, 0x64,
-	0xa4, 0x25, 0x03, 0x32, 0x4d, 0xff, 0x92, 0xdd, 0x79, 0x9f, 0x08, 0xc5, 0x63, 0xe3, 0x92, 0x89,
-	0x34, 0x85, 0xfb, 0x8f, 0x0d, 0xd5, 0x94, 0xea, 0xf5, 0x38, 0x20, 0x82, 0xa2, 0xe7, 0xb0, 0xc1,
-	0xdf, 0xb3, 0x80, 0xd7, 0xad, 0x7d, 0xfb, 0xa0, 0x7c, 0xf2, 0x95, 0x97, 0x5f, 0xc6, 0xcb, 0xc3,
-	0xbd, 0x9e, 0xc4, 0xb6, 0x43, 0x11, 0x4f, 0xb0, 0xae, 0x43, 0x18, 0x6a, 0x31, 0x1d, 0x32, 0x2e,
-	0x62, 0x22, 0x58, 0x14, 0xf6, 0x69, 0x28, 0x62, 0x46, 0x79, 0xdd, 0x56, 0x7c, 0x5f, 0x18, 0x3e,
-	0xa3, 0x02, 0xce, 0x20, 0x35, 0xcb, 0x4e, 0x3c, 0x17, 0x62, 0x94, 0xa3, 0x36, 0x6c, 0x0e, 0x94,
-	0x4c, 0xbc, 0xbe, 0xa1, 0x68, 0x9e, 0xae, 0x18, 0x4b, 0x8b, 0x6a, 0x06, 0x4b, 0x6b, 0x1d, 0x0c,
-	0x30, 0x9b, 0x17, 0x6d, 0x83, 0x7d, 0x45, 0x27, 0x46, 0x72, 0x79, 0x89, 0x3c, 0xd8, 0x78, 0x4f,
-	0x46, 0x09, 0x55, 0x42, 0x95, 0x4f, 0xea, 0xcb, 0x9a, 0x60, 0x0d, 0xfb, 0x7e, 0xed, 0x5b, 0xcb,
-	0xe9, 0x42, 0x25, 0xdb, 0x6c, 0x01, 0xeb, 0x61, 0x9e, 0xb5, 0x96, 0x57, 0x40, 0x17, 0x67, 0x18,
-	0xdd, 0x2e, 0xd8, 0xe7, 0x3d, 0x8c, 0x3e, 0x83, 0x12, 0x1f, 0xb3, 0x77, 0xef, 0x68, 0x7f, 0xea,
-	0x8b, 0xa2, 0x0e, 0xbc, 0x0c, 0x90, 0x03, 0x45, 0x92, 0x04, 0x8c, 0x86, 0xbe, 0xa4, 0xb5, 0x65,
-	0x2e, 0xbd, 0x97, 0x13, 0x08, 0x31, 0x52, 0x5e, 0xd8, 0xc0, 0xf2, 0xd2, 0xfd, 0x1d, 0x36, 0xcf,
-	0x7f, 0x79, 0xa5, 0xfc, 0x52, 0x83, 0x0d, 0x11, 0x5d, 0xd1, 0xd0, 0x30, 0xea, 0x9b, 0x15, 0x36,
-	0x91, 0xa3, 0x30, 0xce, 0x13, 0x1a, 0xc8, 0xac, 0xad, 0xb2, 0x45, 0x1d, 0x68, 0x0a, 0xf7, 0x6f,
-	0x0b, 0xee, 0x35, 0x85, 0xa0, 0x5c, 0x60, 0xfa, 0x67, 0x42, 0xb9, 0x40, 0x2f, 0x60, 0x9b, 0xa8,
-	0x80, 0x36, 0x40, 0x40, 0x04, 0x51, 0xed, 0xca, 0x27, 0x7b, 0xf9, 0xdd, 0x9b, 0x33, 0xd4, 0x19,
-	0x11, 0x04, 0x6f, 0x91, 0x7c, 0x40, 0xae, 0xe2, 0xf3, 0xd8, 0xf8, 0x5f, 0x5e, 0xca, 0xc5, 0x63,
-	0xca, 0xc7, 0x51, 0xc8, 0xa9, 0x71, 0xfb, 0xf4, 0xde, 0x8d, 0xa0, 0x9a, 0x0e, 0xa2, 0x23, 0xe8,
-	0x39, 0x94, 0xa5, 0x29, 0xfb, 0x89, 0x72, 0x85, 0x19, 0xe2, 0xc1, 0xdd, 0xde, 0xc1, 0x20, 0x4b,
-	0xcc, 0x69, 0xf8, 0x1c, 0x4a, 0xfe, 0x05, 0x19, 0x8d, 0x68, 0x38, 0xa4, 0x66, 0x8c, 0x59, 0xc0,
-	0xfd, 0xcf, 0x82, 0xda, 0x8f, 0x54, 0xf8, 0x17, 0x53, 0x63, 0x18, 0x05, 0x9e, 0xc0, 0xd6, 0x59,
-	0xbb, 0x8b, 0xdb, 0xad, 0xe6, 0xab, 0xf6, 0x59, 0xdf, 0xe7, 0x31, 0x57, 0x4f, 0xa9, 0x82, 0xab,
-	0xb3, 0x70, 0x8b, 0xc7, 0x1c, 0x9d, 0xc2, 0xba, 0xca, 0xea, 0xc3, 0xe1, 0xcd, 0x4f, 0xb6, 0x88,
-	0xdc, 0x93, 0x85, 0xda, 0xd8, 0xaa, 0xd6, 0xf9, 0x06, 0x4a, 0xd3, 0xd0, 0x02, 0xfb, 0xd5, 0xb2,
-	0xf6, 0xab, 0x64, 0x8d, 0xf6, 0x16, 0x3e, 0x99, 0x6b, 0xf0, 0x91, 0x64, 0x73, 0x7f, 0x80, 0x1d,
-	0xc5, 0x6c, 0x5c, 0x97, 0xca, 0xf2, 0x18, 0xec, 0x4b, 0x1e, 0x1b, 0xbe, 0x9d, 0x79, 0xbe, 0xf3,
-	0x1e, 0xc6, 0x32, 0xef, 0xb6, 0x8c, 0xaa, 0xd3, 0x6a, 0x33, 0xd6, 0x53, 0x58, 0x97, 0x3d, 0x4c,
-	0xfd, 0xfd, 0x5b, 0xf5, 0x06, 0xae, 0x40, 0xee, 0x21, 0x7c, 0x3a, 0x5d, 0xae, 0xd5, 0xcc, 0x4e,
-	0x61, 0x4c, 0x65, 0x4d, 0x4d, 0xe5, 0x26, 0x70, 0xff, 0x16, 0xd6, 0xf4, 0x3c, 0xca, 0xf5, 0x5c,
-	0xfe, 0x46, 0x50, 0x28, 0x74, 0x04, 0x05, 0xfd, 0xae, 0xb9, 0xf3, 0xac, 0x1b, 0x8c, 0xfb, 0x13,
-	0xec, 0x76, 0x13, 0x2e, 0xd7, 0xec, 0xd0, 0xc9, 0xeb, 0x31, 0x17, 0x31, 0x25, 0xd7, 0xe9, 0x94,
-	0xc7, 0xb0, 0x79, 0x79, 0x23, 0xfa, 0xe9, 0xc3, 0x9c, 0xed, 0x6b, 0xb8, 0xba, 0xc9, 0x60, 0xc4,
-	0xfc, 0x0e, 0x9d, 0xe0, 0xc2, 0xe5, 0x8d, 0xe8, 0xd0, 0x89, 0xdb, 0x07, 0x67, 0x11, 0x9d, 0x59,
-	0xa4, 0x09, 0xdb, 0x92, 0x8f, 0xb3, 0x61, 0xc8, 0xc2, 0xa1, 0xe4, 0x4d, 0x5f, 0xf1, 0x4b, 0x89,
-	0xab, 0x97, 0x37, 0xa2, 0xa7, 0xf1, 0x1d, 0x3a, 0xe1, 0x6e, 0x0d, 0x90, 0x92, 0xc9, 0xac, 0xa1,
-	0x07, 0x75, 0x5b, 0xe6, 0x59, 0xa7, 0xd1, 0xa9, 0x70, 0xa9, 0x14, 0xd6, 0x6a, 0x29, 0x4e, 0xfe,
-	0x5d, 0x87, 0xf5, 0x9f, 0xa3, 0x80, 0xa2, 0x0e, 0x14, 0xf4, 0x19, 0x46, 0x7b, 0xf3, 0x5a, 0xe7,
-	0x5e, 0x32, 0xce, 0x83, 0x65, 0x69, 0xdd, 0xff, 0xc0, 0x3a, 0xb6, 0xd0, 0x1f, 0x70, 0x2f, 0x67,
-	0x70, 0xf4, 0xe8, 0x43, 0x0e, 0x98, 0xf3, 0x78, 0x05, 0x2a, 0xd3, 0xe1, 0x57, 0xa8, 0x64, 0xad,
-	0x8a, 0x1e, 0x2e, 0x2c, 0xcd, 0x1f, 0x03, 0xe7, 0xd1, 0xdd, 0x20, 0x23, 0xe0, 0x00, 0xb6, 0xe6,
-	0x4c, 0x89, 0xbe, 0x5c, 0x3a, 0x58, 0xce, 0xe1, 0xce, 0x93, 0x95, 0x38, 0xd3, 0xe3, 0x0a, 0xd0,
-	0x6d, 0xcb, 0xa0, 0x5b, 0xbf, 0xf9, 0x4b, 0x5d, 0xea, 0x1c, 0x7e, 0x08, 0xd4, 0x34, 0x7b, 0x03,
-	0xe5, 0x8c, 0x51, 0x90, 0xbb, 0x70, 0xc8, 0x9c, 0xb7, 0x9c, 0x87, 0x77, 0x62, 0x34, 0xef, 0xa9,
-	0xf7, 0xdb, 0xd1, 0x90, 0x89, 0x8b, 0x64, 0x20, 0xbd, 0xd5, 0xd0, 0x3f, 0x91, 0x0d, 0xfd, 0xc9,
-	0xa5, 0x3e, 0xb2, 0x1a, 0xf9, 0x2f, 0xb5, 0x41, 0x41, 0x45, 0x9f, 0xfd, 0x1f, 0x00, 0x00, 0xff,
-	0xff, 0x3b, 0x78, 0xc6, 0x4d, 0xc2, 0x09, 0x00, 0x00,
+	// 1069 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xeb, 0x4e, 0xe3, 0x46,
+	0x14, 0x96, 0x09, 0x09, 0xc9, 0x21, 0x1b, 0xd8, 0x21, 0xdb, 0x0d, 0xee, 0x42, 0x91, 0x77, 0xb7,
+	0x4b, 0x59, 0x64, 0x10, 0xab, 0xaa, 0x17, 0x55, 0x42, 0x21, 0xa4, 0xda, 0x05, 0xb5, 0x42, 0x13,
+	0xd8, 0x6e, 0x5b, 0x55, 0xee, 0xc4, 0x9e, 0x0d, 0x0e, 0xc1, 0x4e, 0x3d, 0x63, 0x68, 0x9e, 0xa0,
+	0x4f, 0xd1, 0x37, 0xe9, 0x73, 0xf4, 0x79, 0xaa, 0xb9, 0x38, 0xb1, 0x73, 0xe5, 0x47, 0xff, 0x24,
+	0xf6, 0x99, 0xef, 0x7c, 0xe7, 0x32, 0xdf, 0x19, 0x0f, 0x6c, 0xb2, 0xbe, 0x1f, 0xd1, 0x03, 0xd2,
+	0xf7, 0x0f, 0x82, 0xd0, 0xa3, 0xf2, 0xc7, 0xee, 0x47, 0x21, 0x0f, 0x51, 0x45, 0x2e, 0xd9, 0xa4,
+	0xef, 0xdb, 0xc2, 0x6a, 0x6a, 0xa8, 0x1b, 0xde, 0xde, 0x86, 0x81, 0xfe, 0x53, 0x50, 0xeb, 0x0d,
+	0x14, 0x4e, 0xe2, 0xc0, 0xeb, 0x51, 0x54, 0x81, 0x25, 0xdf, 0xab, 0x19, 0x3b, 0xc6, 0x6e, 0x09,
+	0x2f, 0xf9, 0x1e, 0xda, 0x84, 0xa2, 0x4b, 0x1c, 0x97, 0x46, 0x9c, 0xd5, 0x96, 0x76, 0x8c, 0xdd,
+	0x32, 0x5e, 0x71, 0x49, 0x43, 0xbc, 0x5a, 0x6f, 0xa1, 0xf8, 0xe1, 0xcb, 0xc3, 0x6f, 0x5a, 0xef,
+	0xdf, 0x9d, 0xa2, 0x2d, 0x00, 0x81, 0x71, 0xdc, 0x6b, 0xe2, 0x07, 0xb5, 0x9c, 0x04, 0x96, 0x84,
+	0xa5, 0x21, 0x0c, 0x62, 0x99, 0xfe, 0x29, 0xa2, 0x33, 0x87, 0x70, 0xc9, 0x93, 0xc3, 0x25, 0x6d,
+	0xa9, 0x73, 0xeb, 0xdf, 0x65, 0xa8, 0x24, 0x54, 0x57, 0x7d, 0x8f, 0x70, 0x8a, 0x8e, 0x21, 0xcf,
+	0xee, 0x7c, 0x8f, 0xd5, 0x8c, 0x9d, 0xdc, 0xee, 0xea, 0xd1, 0x17, 0x76, 0xb6, 0x18, 0x3b, 0x0b,
+	0xb7, 0x5b, 0x02, 0xdb, 0x0c, 0x78, 0x34, 0xc0, 0xca, 0x0f, 0x61, 0xa8, 0x46, 0xb4, 0xe3, 0x33,
+	0x1e, 0x11, 0xee, 0x87, 0x81, 0x43, 0x03, 0x1e, 0xf9, 0x94, 0xd5, 0x72, 0x92, 0xef, 0x33, 0xcd,
+	0xa7, 0xbb, 0x80, 0x53, 0x48, 0xc5, 0xb2, 0x11, 0x8d, 0x99, 0x7c, 0xca, 0x50, 0x13, 0x56, 0xda,
+	0xb2, 0x4d, 0xac, 0x96, 0x97, 0x34, 0xaf, 0x17, 0xa4, 0xa5, 0x9a, 0xaa, 0x13, 0x4b, 0x7c, 0xd1,
+	0x1e, 0x3c, 0x8e, 0x42, 0x4e, 0x38, 0x75, 0x48, 0x87, 0x06, 0xdc, 0x11, 0x09, 0xd7, 0x0a, 0x3b,
+	0xc6, 0x6e, 0x11, 0xaf, 0xa9, 0x85, 0xba, 0xb0, 0x8b, 0x9a, 0xd0, 0x15, 0x3c, 0x8a, 0x68, 0x97,
+	0xba, 0x9c, 0x7a, 0x8e, 0xcb, 0x22, 0x56, 0x5b, 0x91, 0x81, 0x0f, 0x17, 0x04, 0xc6, 0xda, 0xa7,
+	0xc1, 0x22, 0x1d, 0xbd, 0x1c, 0xa5, 0x4c, 0x26, 0x06, 0x18, 0xb5, 0x0c, 0xad, 0x43, 0xee, 0x86,
+	0x0e, 0xf4, 0xae, 0x8b, 0x47, 0x64, 0x43, 0xfe, 0x8e, 0xf4, 0x62, 0x2a, 0xf7, 0x6a, 0xf5, 0xa8,
+	0x36, 0x2b, 0x1c, 0x56, 0xb0, 0x6f, 0x97, 0xbe, 0x36, 0xcc, 0x0b, 0x28, 0xa7, 0xeb, 0x9d, 0xc2,
+	0xba, 0x97, 0x65, 0xad, 0x66, 0x37, 0x41, 0x39, 0xa7, 0x19, 0x8f, 0xe1, 0xf1, 0x44, 0x21, 0x53,
+	0x68, 0xab, 0x69, 0xda, 0x52, 0x8a, 0xc0, 0xba, 0x80, 0xdc, 0x59, 0x0b, 0xa3, 0x4f, 0xa1, 0xc4,
+	0xfa, 0xfe, 0xc7, 0x8f, 0xd4, 0x19, 0x6a, 0xbb, 0xa8, 0x0c, 0xef, 0x3c, 0x64, 0x42, 0x91, 0xc4,
+	0x9e, 0x4f, 0x03, 0x57, 0x10, 0xe4, 0xc4, 0x5a, 0xf2, 0x2e, 0x62, 0x71, 0xde, 0x93, 0x7a, 0xce,
+	0x63, 0xf1, 0x68, 0xfd, 0x0a, 0x2b, 0x67, 0x3f, 0x5d, 0x4a, 0xcd, 0x57, 0x21, 0xcf, 0xc3, 0x1b,
+	0x1a, 0x68, 0x46, 0xf5, 0xb2, 0x40, 0xea, 0x22, 0x15, 0x9f, 0xb1, 0x98, 0x7a, 0x62, 0x35, 0x27,
+	0x57, 0x8b, 0xca, 0x50, 0xe7, 0xd6, 0x5f, 0x06, 0x3c, 0xaa, 0x73, 0x4e, 0x19, 0xc7, 0xf4, 0x8f,
+	0x98, 0x32, 0x8e, 0xde, 0xc2, 0x3a, 0x91, 0x06, 0x25, 0x62, 0x8f, 0x70, 0x22, 0xc3, 0xad, 0x1e,
+	0x6d, 0x65, 0x9b, 0x57, 0x1f, 0xa1, 0x4e, 0x09, 0x27, 0x78, 0x8d, 0x64, 0x0d, 0xa2, 0x14, 0x97,
+	0x45, 0x7a, 0x86, 0xc5, 0xa3, 0x28, 0x3c, 0xa2, 0xac, 0x1f, 0x06, 0x8c, 0xea, 0x89, 0x1d, 0xbe,
+	0x5b, 0x21, 0x54, 0x92, 0x44, 0x94, 0x05, 0x1d, 0xc3, 0xaa, 0xd0, 0xa9, 0x13, 0x4b, 0x81, 0xe9,
+	0x24, 0xb6, 0xe7, 0xcb, 0x10, 0x83, 0x70, 0x51, 0xcf, 0xe8, 0x19, 0x94, 0xdc, 0x6b, 0xd2, 0xeb,
+	0xd1, 0xa0, 0x43, 0x75, 0x1a, 0x23, 0x83, 0xf5, 0x8f, 0x01, 0xd5, 0xef, 0x29, 0x77, 0xaf, 0x87,
+	0xca, 0xd2, 0x1d, 0x78, 0x05, 0x6b, 0xa7, 0xcd, 0x0b, 0xdc, 0x6c, 0xd4, 0x2f, 0x9b, 0xa7, 0x6a,
+	0x04, 0xc4, 0x2e, 0x95, 0x71, 0x65, 0x64, 0x16, 0xe2, 0x40, 0x27, 0xb0, 0x2c, 0x57, 0xd5, 0x80,
+	0xdb, 0xe3, 0x99, 0x4d, 0x23, 0xb7, 0x47, 0xe3, 0x21, 0x7d, 0xcd, 0xaf, 0xa0, 0xf4, 0x60, 0xa1,
+	0x95, 0xd3, 0x42, 0xfb, 0x00, 0x4f, 0xc6, 0x02, 0xfc, 0x4f, 0x6d, 0xb3, 0xbe, 0x83, 0x0d, 0xc9,
+	0xac, 0x55, 0x97, 0xb4, 0xe5, 0x25, 0xe4, 0xba, 0x2c, 0xd2, 0x7c, 0x1b, 0xe3, 0x7c, 0x67, 0x2d,
+	0x8c, 0xc5, 0xba, 0xd5, 0x80, 0x6a, 0xd6, 0x5b, 0xa7, 0xf5, 0x1a, 0x96, 0xe5, 0xa9, 0xa3, 0xfc,
+	0x9f, 0x4e, 0xf8, 0x6b, 0xb8, 0x04, 0x59, 0x7b, 0xf0, 0xc9, 0xb0, 0xb8, 0x46, 0x3d, 0x9d, 0x85,
+	0x16, 0x95, 0x31, 0x14, 0x95, 0x15, 0xc3, 0xd3, 0x09, 0xac, 0x8e, 0xb9, 0x9f, 0x89, 0x39, 0xfb,
+	0x48, 0x91, 0x28, 0xb4, 0x0f, 0x05, 0x75, 0x5e, 0xce, 0x3d, 0x2c, 0x34, 0xc6, 0xfa, 0x01, 0x36,
+	0x2f, 0x62, 0x26, 0xca, 0x3c, 0xa7, 0x83, 0xab, 0x3e, 0xe3, 0x11, 0x25, 0xb7, 0x49, 0x96, 0x87,
+	0xb0, 0xd2, 0xbd, 0xe7, 0x4e, 0xb2, 0x99, 0xa3, 0x7a, 0x35, 0xd7, 0x45, 0xdc, 0xee, 0xf9, 0xee,
+	0x39, 0x1d, 0xe0, 0x42, 0xf7, 0x9e, 0x9f, 0xd3, 0x81, 0xe5, 0x80, 0x39, 0x8d, 0x4e, 0x17, 0x52,
+	0x87, 0x75, 0xc1, 0xc7, 0xfc, 0x4e, 0xe0, 0x07, 0x1d, 0xc1, 0x9b, 0x7c, 0xa6, 0x66, 0x12, 0x57,
+	0xba, 0xf7, 0xbc, 0xa5, 0xf0, 0xe7, 0x74, 0xc0, 0xac, 0x2a, 0x20, 0xd9, 0x26, 0x5d, 0x86, 0x4a,
+	0xd4, 0x6a, 0xc0, 0x46, 0xc6, 0x3a, 0x6c, 0x5c, 0xd2, 0x0a, 0xe3, 0x01, 0xad, 0xd8, 0x86, 0x67,
+	0xad, 0xb8, 0xcd, 0xdc, 0xc8, 0x6f, 0xd3, 0xcb, 0xb0, 0x35, 0x08, 0xdc, 0xe6, 0x1d, 0x0d, 0x38,
+	0x4b, 0x82, 0xfc, 0x06, 0xa5, 0xa1, 0x51, 0x4c, 0x97, 0xfe, 0x30, 0x8a, 0x4f, 0x77, 0xd0, 0xa1,
+	0x6a, 0x7b, 0x8a, 0xb8, 0xa2, 0xcd, 0x0d, 0x65, 0x15, 0x40, 0xc5, 0x3f, 0x02, 0x2e, 0x29, 0xa0,
+	0x36, 0x6b, 0xe0, 0xd1, 0xdf, 0x79, 0x58, 0xfe, 0x31, 0xf4, 0x28, 0x3a, 0x87, 0x82, 0x3a, 0x42,
+	0xd0, 0xd6, 0xf8, 0x56, 0x67, 0xce, 0x38, 0x73, 0x7b, 0xd6, 0xb2, 0x2a, 0x7f, 0xd7, 0x38, 0x34,
+	0xd0, 0xef, 0xf0, 0x28, 0x33, 0x5f, 0xe8, 0xc5, 0x43, 0xe6, 0xdb, 0x7c, 0xb9, 0x00, 0x95, 0x8a,
+	0xf0, 0x33, 0x94, 0xd3, 0x93, 0x82, 0x9e, 0x4f, 0x75, 0xcd, 0x4e, 0xa1, 0xf9, 0x62, 0x3e, 0x48,
+	0xef, 0x5f, 0x1b, 0xd6, 0xc6, 0x66, 0x02, 0x7d, 0x3e, 0x33, 0xb1, 0xcc, 0x80, 0x99, 0xaf, 0x16,
+	0xe2, 0x74, 0x8c, 0x1b, 0x40, 0x93, 0x8a, 0x45, 0x13, 0xd7, 0xa6, 0x99, 0x43, 0x62, 0xee, 0x3d,
+	0x04, 0xaa, 0x83, 0xbd, 0x87, 0xd5, 0x94, 0x4e, 0x91, 0x35, 0x35, 0xc9, 0x8c, 0xb4, 0xcd, 0xe7,
+	0x73, 0x31, 0xc3, 0x46, 0x3d, 0x99, 0x2a, 0x5d, 0xb4, 0x3f, 0xee, 0x3d, 0x4f, 0xe1, 0xe6, 0xe6,
+	0x04, 0x3a, 0x81, 0x1c, 0x1a, 0x27, 0xf6, 0x2f, 0xfb, 0x1d, 0x9f, 0x5f, 0xc7, 0x6d, 0x31, 0x3e,
+	0x07, 0xea, 0x16, 0x70, 0xa0, 0x6e, 0xc6, 0xf2, 0x2e, 0x7c, 0x90, 0xbd, 0x50, 0xb7, 0x0b, 0xd2,
+	0xfa, 0xe6, 0xbf, 0x01, 0x00, 0x75, 0x03, 0xc7, 0x6d, 0x69, 0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -949,6 +1062,10 @@ type NodeClient interface {
 	PushJWTKeyUpstream(ctx context.Context, in *PushJWTKeyUpstreamRequest, opts ...grpc.CallOption) (*PushJWTKeyUpstreamResponse, error)
 	// FetchBundle fetches the bundle of the local trust domain
 	FetchBundle(ctx context.Context, in *FetchBundleRequest, opts ...grpc.CallOption) (*FetchBundleResponse, error)
+	// SubscribeToSyncEvents streams an event every time registration entries
+	// or bundles change on the server, so agents can synchronize right away
+	// instead of waiting for their next sync interval.
+	SubscribeToSyncEvents(ctx context.Context, in *SubscribeToSyncEventsRequest, opts ...grpc.CallOption) (Node_SubscribeToSyncEventsClient, error)
 }
 
 type nodeClient struct {
@@ -1057,6 +1174,38 @@ func (c *nodeClient) FetchBundle(ctx context.Context, in *FetchBundleRequest, op
 	return out, nil
 }
 
+func (c *nodeClient) SubscribeToSyncEvents(ctx context.Context, in *SubscribeToSyncEventsRequest, opts ...grpc.CallOption) (Node_SubscribeToSyncEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Node_serviceDesc.Streams[2], "/spire.api.node.Node/SubscribeToSyncEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeSubscribeToSyncEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Node_SubscribeToSyncEventsClient interface {
+	Recv() (*SyncEvent, error)
+	grpc.ClientStream
+}
+
+type nodeSubscribeToSyncEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeSubscribeToSyncEventsClient) Recv() (*SyncEvent, error) {
+	m := new(SyncEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // NodeServer is the server API for Node service.
 type NodeServer interface {
 	// Attest the node, get base node SVID.
@@ -1075,6 +1224,10 @@ type NodeServer interface {
 	PushJWTKeyUpstream(context.Context, *PushJWTKeyUpstreamRequest) (*PushJWTKeyUpstreamResponse, error)
 	// FetchBundle fetches the bundle of the local trust domain
 	FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error)
+	// SubscribeToSyncEvents streams an event every time registration entries
+	// or bundles change on the server, so agents can synchronize right away
+	// instead of waiting for their next sync interval.
+	SubscribeToSyncEvents(*SubscribeToSyncEventsRequest, Node_SubscribeToSyncEventsServer) error
 }
 
 // UnimplementedNodeServer can be embedded to have forward compatible implementations.
@@ -1099,6 +1252,9 @@ func (*UnimplementedNodeServer) PushJWTKeyUpstream(ctx context.Context, req *Pus
 func (*UnimplementedNodeServer) FetchBundle(ctx context.Context, req *FetchBundleRequest) (*FetchBundleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FetchBundle not implemented")
 }
+func (*UnimplementedNodeServer) SubscribeToSyncEvents(req *SubscribeToSyncEventsRequest, srv Node_SubscribeToSyncEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeToSyncEvents not implemented")
+}
 
 func RegisterNodeServer(s *grpc.Server, srv NodeServer) {
 	s.RegisterService(&_Node_serviceDesc, srv)
@@ -1228,6 +1384,27 @@ func _Node_FetchBundle_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Node_SubscribeToSyncEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeToSyncEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeServer).SubscribeToSyncEvents(m, &nodeSubscribeToSyncEventsServer{stream})
+}
+
+type Node_SubscribeToSyncEventsServer interface {
+	Send(*SyncEvent) error
+	grpc.ServerStream
+}
+
+type nodeSubscribeToSyncEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeSubscribeToSyncEventsServer) Send(m *SyncEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Node_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.api.node.Node",
 	HandlerType: (*NodeServer)(nil),
@@ -1262,6 +1439,11 @@ var _Node_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "SubscribeToSyncEvents",
+			Handler:       _Node_SubscribeToSyncEvents_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "spire/api/node/node.proto",
 }
@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	common "github.com/spiffe/spire/proto/spire/common"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -55,13 +56,42 @@ func (x DeleteFederatedBundleRequest_Mode) String() string {
 }
 
 func (DeleteFederatedBundleRequest_Mode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{10, 0}
+	return fileDescriptor_7f325c92bf3cfce0, []int{12, 0}
+}
+
+type AgentFilter_MatchBehavior int32
+
+const (
+	// Agents whose selectors are exactly the given selectors match
+	AgentFilter_MATCH_EXACT AgentFilter_MatchBehavior = 0
+	// Agents whose selectors are a subset of the given selectors match
+	AgentFilter_MATCH_SUBSET AgentFilter_MatchBehavior = 1
+)
+
+var AgentFilter_MatchBehavior_name = map[int32]string{
+	0: "MATCH_EXACT",
+	1: "MATCH_SUBSET",
+}
+
+var AgentFilter_MatchBehavior_value = map[string]int32{
+	"MATCH_EXACT":  0,
+	"MATCH_SUBSET": 1,
+}
+
+func (x AgentFilter_MatchBehavior) String() string {
+	return proto.EnumName(AgentFilter_MatchBehavior_name, int32(x))
+}
+
+func (AgentFilter_MatchBehavior) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{15, 0}
 }
 
 // A type that represents the id of an entry.
 type RegistrationEntryID struct {
 	// RegistrationEntryID.
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Revision number the entry must be at to be deleted by DeleteEntry.
+	RevisionNumber       int64    `protobuf:"varint,2,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -99,6 +129,13 @@ func (m *RegistrationEntryID) GetId() string {
 	return ""
 }
 
+func (m *RegistrationEntryID) GetRevisionNumber() int64 {
+	if m != nil {
+		return m.RevisionNumber
+	}
+	return 0
+}
+
 // A type that represents a parent Id.
 type ParentID struct {
 	// ParentId.
@@ -233,7 +270,8 @@ func (m *CreateEntryIfNotExistsResponse) GetPreexisting() bool {
 
 // A type used to update registration entries
 type UpdateEntryRequest struct {
-	// Registration entry to update
+	// Registration entry to update. Its revision number is the revision
+	// number the entry must be at to be updated.
 	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
@@ -320,7 +358,6 @@ func (m *Pagination) GetPageSize() int32 {
 	return 0
 }
 
-//
 type ListAllEntriesRequest struct {
 	Pagination           *Pagination `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
@@ -408,6 +445,127 @@ func (m *ListAllEntriesResponse) GetPagination() *Pagination {
 	return nil
 }
 
+// Represents a GetEntryStatistics request
+type GetEntryStatisticsRequest struct {
+	// Entries expiring within this many seconds are counted as expiring
+	// soon. Defaults to 24 hours.
+	ExpiringWithin       int64    `protobuf:"varint,1,opt,name=expiring_within,json=expiringWithin,proto3" json:"expiring_within,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEntryStatisticsRequest) Reset()         { *m = GetEntryStatisticsRequest{} }
+func (m *GetEntryStatisticsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEntryStatisticsRequest) ProtoMessage()    {}
+func (*GetEntryStatisticsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{8}
+}
+
+func (m *GetEntryStatisticsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEntryStatisticsRequest.Unmarshal(m, b)
+}
+func (m *GetEntryStatisticsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEntryStatisticsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetEntryStatisticsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEntryStatisticsRequest.Merge(m, src)
+}
+func (m *GetEntryStatisticsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEntryStatisticsRequest.Size(m)
+}
+func (m *GetEntryStatisticsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEntryStatisticsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEntryStatisticsRequest proto.InternalMessageInfo
+
+func (m *GetEntryStatisticsRequest) GetExpiringWithin() int64 {
+	if m != nil {
+		return m.ExpiringWithin
+	}
+	return 0
+}
+
+// Represents a GetEntryStatistics response
+type GetEntryStatisticsResponse struct {
+	// Total number of registration entries
+	Total int32 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	// Number of entries per parent ID
+	ByParentId map[string]int32 `protobuf:"bytes,2,rep,name=by_parent_id,json=byParentId,proto3" json:"by_parent_id,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Number of entries per selector type. Entries with several selectors
+	// of the same type are counted once for that type.
+	BySelectorType map[string]int32 `protobuf:"bytes,3,rep,name=by_selector_type,json=bySelectorType,proto3" json:"by_selector_type,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Number of entries that are not expired yet but expire within
+	// expiring_within seconds
+	ExpiringSoon int32 `protobuf:"varint,4,opt,name=expiring_soon,json=expiringSoon,proto3" json:"expiring_soon,omitempty"`
+	// Number of entries that are expired
+	Expired              int32    `protobuf:"varint,5,opt,name=expired,proto3" json:"expired,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEntryStatisticsResponse) Reset()         { *m = GetEntryStatisticsResponse{} }
+func (m *GetEntryStatisticsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetEntryStatisticsResponse) ProtoMessage()    {}
+func (*GetEntryStatisticsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{9}
+}
+
+func (m *GetEntryStatisticsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEntryStatisticsResponse.Unmarshal(m, b)
+}
+func (m *GetEntryStatisticsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEntryStatisticsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetEntryStatisticsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEntryStatisticsResponse.Merge(m, src)
+}
+func (m *GetEntryStatisticsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetEntryStatisticsResponse.Size(m)
+}
+func (m *GetEntryStatisticsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEntryStatisticsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEntryStatisticsResponse proto.InternalMessageInfo
+
+func (m *GetEntryStatisticsResponse) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *GetEntryStatisticsResponse) GetByParentId() map[string]int32 {
+	if m != nil {
+		return m.ByParentId
+	}
+	return nil
+}
+
+func (m *GetEntryStatisticsResponse) GetBySelectorType() map[string]int32 {
+	if m != nil {
+		return m.BySelectorType
+	}
+	return nil
+}
+
+func (m *GetEntryStatisticsResponse) GetExpiringSoon() int32 {
+	if m != nil {
+		return m.ExpiringSoon
+	}
+	return 0
+}
+
+func (m *GetEntryStatisticsResponse) GetExpired() int32 {
+	if m != nil {
+		return m.Expired
+	}
+	return 0
+}
+
 // A CA bundle for a different Trust Domain than the one used and managed by the Server.
 type FederatedBundle struct {
 	// Common bundle format
@@ -421,7 +579,7 @@ func (m *FederatedBundle) Reset()         { *m = FederatedBundle{} }
 func (m *FederatedBundle) String() string { return proto.CompactTextString(m) }
 func (*FederatedBundle) ProtoMessage()    {}
 func (*FederatedBundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{8}
+	return fileDescriptor_7f325c92bf3cfce0, []int{10}
 }
 
 func (m *FederatedBundle) XXX_Unmarshal(b []byte) error {
@@ -462,7 +620,7 @@ func (m *FederatedBundleID) Reset()         { *m = FederatedBundleID{} }
 func (m *FederatedBundleID) String() string { return proto.CompactTextString(m) }
 func (*FederatedBundleID) ProtoMessage()    {}
 func (*FederatedBundleID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{9}
+	return fileDescriptor_7f325c92bf3cfce0, []int{11}
 }
 
 func (m *FederatedBundleID) XXX_Unmarshal(b []byte) error {
@@ -502,7 +660,7 @@ func (m *DeleteFederatedBundleRequest) Reset()         { *m = DeleteFederatedBun
 func (m *DeleteFederatedBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteFederatedBundleRequest) ProtoMessage()    {}
 func (*DeleteFederatedBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{10}
+	return fileDescriptor_7f325c92bf3cfce0, []int{12}
 }
 
 func (m *DeleteFederatedBundleRequest) XXX_Unmarshal(b []byte) error {
@@ -542,7 +700,10 @@ type JoinToken struct {
 	// The join token. If not set, one will be generated
 	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	// TTL in seconds
-	Ttl                  int32    `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Ttl int32 `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Maximum number of times the token can be used. If not set, the token
+	// can only be used once
+	MaxUses              int32    `protobuf:"varint,3,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -552,7 +713,7 @@ func (m *JoinToken) Reset()         { *m = JoinToken{} }
 func (m *JoinToken) String() string { return proto.CompactTextString(m) }
 func (*JoinToken) ProtoMessage()    {}
 func (*JoinToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{11}
+	return fileDescriptor_7f325c92bf3cfce0, []int{13}
 }
 
 func (m *JoinToken) XXX_Unmarshal(b []byte) error {
@@ -587,6 +748,13 @@ func (m *JoinToken) GetTtl() int32 {
 	return 0
 }
 
+func (m *JoinToken) GetMaxUses() int32 {
+	if m != nil {
+		return m.MaxUses
+	}
+	return 0
+}
+
 // CA Bundle of the server
 type Bundle struct {
 	// Common bundle format
@@ -600,7 +768,7 @@ func (m *Bundle) Reset()         { *m = Bundle{} }
 func (m *Bundle) String() string { return proto.CompactTextString(m) }
 func (*Bundle) ProtoMessage()    {}
 func (*Bundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{12}
+	return fileDescriptor_7f325c92bf3cfce0, []int{14}
 }
 
 func (m *Bundle) XXX_Unmarshal(b []byte) error {
@@ -628,18 +796,126 @@ func (m *Bundle) GetBundle() *common.Bundle {
 	return nil
 }
 
-// Represents a ListAgents request
-type ListAgentsRequest struct {
+// Represents a filter over attested agents. Unset fields match any agent.
+type AgentFilter struct {
+	// Matches the agent with this SPIFFE ID
+	BySpiffeId string `protobuf:"bytes,1,opt,name=by_spiffe_id,json=bySpiffeId,proto3" json:"by_spiffe_id,omitempty"`
+	// Matches agents whose SPIFFE ID path starts with this prefix.
+	// For example: "/spire/agent/aws_iid"
+	ByPathPrefix string `protobuf:"bytes,2,opt,name=by_path_prefix,json=byPathPrefix,proto3" json:"by_path_prefix,omitempty"`
+	// Matches agents attested with this attestation type
+	ByAttestationType string `protobuf:"bytes,3,opt,name=by_attestation_type,json=byAttestationType,proto3" json:"by_attestation_type,omitempty"`
+	// Matches agents by their node selectors, according to selector_match
+	BySelectors   []*common.Selector        `protobuf:"bytes,4,rep,name=by_selectors,json=bySelectors,proto3" json:"by_selectors,omitempty"`
+	SelectorMatch AgentFilter_MatchBehavior `protobuf:"varint,5,opt,name=selector_match,json=selectorMatch,proto3,enum=spire.api.registration.AgentFilter_MatchBehavior" json:"selector_match,omitempty"`
+	// Matches banned agents if true, or agents that are not banned if false
+	ByBanned *wrappers.BoolValue `protobuf:"bytes,6,opt,name=by_banned,json=byBanned,proto3" json:"by_banned,omitempty"`
+	// Matches agents whose SVID expires after this time (seconds since the
+	// Unix epoch)
+	ByExpiresAfter int64 `protobuf:"varint,7,opt,name=by_expires_after,json=byExpiresAfter,proto3" json:"by_expires_after,omitempty"`
+	// Matches agents whose SVID expires before this time (seconds since the
+	// Unix epoch)
+	ByExpiresBefore      int64    `protobuf:"varint,8,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *AgentFilter) Reset()         { *m = AgentFilter{} }
+func (m *AgentFilter) String() string { return proto.CompactTextString(m) }
+func (*AgentFilter) ProtoMessage()    {}
+func (*AgentFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{15}
+}
+
+func (m *AgentFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AgentFilter.Unmarshal(m, b)
+}
+func (m *AgentFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AgentFilter.Marshal(b, m, deterministic)
+}
+func (m *AgentFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentFilter.Merge(m, src)
+}
+func (m *AgentFilter) XXX_Size() int {
+	return xxx_messageInfo_AgentFilter.Size(m)
+}
+func (m *AgentFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentFilter proto.InternalMessageInfo
+
+func (m *AgentFilter) GetBySpiffeId() string {
+	if m != nil {
+		return m.BySpiffeId
+	}
+	return ""
+}
+
+func (m *AgentFilter) GetByPathPrefix() string {
+	if m != nil {
+		return m.ByPathPrefix
+	}
+	return ""
+}
+
+func (m *AgentFilter) GetByAttestationType() string {
+	if m != nil {
+		return m.ByAttestationType
+	}
+	return ""
+}
+
+func (m *AgentFilter) GetBySelectors() []*common.Selector {
+	if m != nil {
+		return m.BySelectors
+	}
+	return nil
+}
+
+func (m *AgentFilter) GetSelectorMatch() AgentFilter_MatchBehavior {
+	if m != nil {
+		return m.SelectorMatch
+	}
+	return AgentFilter_MATCH_EXACT
+}
+
+func (m *AgentFilter) GetByBanned() *wrappers.BoolValue {
+	if m != nil {
+		return m.ByBanned
+	}
+	return nil
+}
+
+func (m *AgentFilter) GetByExpiresAfter() int64 {
+	if m != nil {
+		return m.ByExpiresAfter
+	}
+	return 0
+}
+
+func (m *AgentFilter) GetByExpiresBefore() int64 {
+	if m != nil {
+		return m.ByExpiresBefore
+	}
+	return 0
+}
+
+// Represents a ListAgents request
+type ListAgentsRequest struct {
+	// Filters the listed agents
+	Filter               *AgentFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
 func (m *ListAgentsRequest) Reset()         { *m = ListAgentsRequest{} }
 func (m *ListAgentsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListAgentsRequest) ProtoMessage()    {}
 func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{13}
+	return fileDescriptor_7f325c92bf3cfce0, []int{16}
 }
 
 func (m *ListAgentsRequest) XXX_Unmarshal(b []byte) error {
@@ -660,6 +936,13 @@ func (m *ListAgentsRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ListAgentsRequest proto.InternalMessageInfo
 
+func (m *ListAgentsRequest) GetFilter() *AgentFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
 // Represents a ListAgents response
 type ListAgentsResponse struct {
 	// List of all attested agents
@@ -673,7 +956,7 @@ func (m *ListAgentsResponse) Reset()         { *m = ListAgentsResponse{} }
 func (m *ListAgentsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListAgentsResponse) ProtoMessage()    {}
 func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{14}
+	return fileDescriptor_7f325c92bf3cfce0, []int{17}
 }
 
 func (m *ListAgentsResponse) XXX_Unmarshal(b []byte) error {
@@ -701,6 +984,129 @@ func (m *ListAgentsResponse) GetNodes() []*common.AttestedNode {
 	return nil
 }
 
+// Represents a CountAgents request
+type CountAgentsRequest struct {
+	// Filters the counted agents
+	Filter               *AgentFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CountAgentsRequest) Reset()         { *m = CountAgentsRequest{} }
+func (m *CountAgentsRequest) String() string { return proto.CompactTextString(m) }
+func (*CountAgentsRequest) ProtoMessage()    {}
+func (*CountAgentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{18}
+}
+
+func (m *CountAgentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountAgentsRequest.Unmarshal(m, b)
+}
+func (m *CountAgentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountAgentsRequest.Marshal(b, m, deterministic)
+}
+func (m *CountAgentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountAgentsRequest.Merge(m, src)
+}
+func (m *CountAgentsRequest) XXX_Size() int {
+	return xxx_messageInfo_CountAgentsRequest.Size(m)
+}
+func (m *CountAgentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountAgentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountAgentsRequest proto.InternalMessageInfo
+
+func (m *CountAgentsRequest) GetFilter() *AgentFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+// Represents a CountAgents response
+type CountAgentsResponse struct {
+	// Number of attested agents matching the filter
+	Count                int32    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountAgentsResponse) Reset()         { *m = CountAgentsResponse{} }
+func (m *CountAgentsResponse) String() string { return proto.CompactTextString(m) }
+func (*CountAgentsResponse) ProtoMessage()    {}
+func (*CountAgentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{19}
+}
+
+func (m *CountAgentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountAgentsResponse.Unmarshal(m, b)
+}
+func (m *CountAgentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountAgentsResponse.Marshal(b, m, deterministic)
+}
+func (m *CountAgentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountAgentsResponse.Merge(m, src)
+}
+func (m *CountAgentsResponse) XXX_Size() int {
+	return xxx_messageInfo_CountAgentsResponse.Size(m)
+}
+func (m *CountAgentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountAgentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountAgentsResponse proto.InternalMessageInfo
+
+func (m *CountAgentsResponse) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// Represents a CountFederatedBundles response
+type CountFederatedBundlesResponse struct {
+	// Number of federated bundles
+	Count                int32    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountFederatedBundlesResponse) Reset()         { *m = CountFederatedBundlesResponse{} }
+func (m *CountFederatedBundlesResponse) String() string { return proto.CompactTextString(m) }
+func (*CountFederatedBundlesResponse) ProtoMessage()    {}
+func (*CountFederatedBundlesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{20}
+}
+
+func (m *CountFederatedBundlesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountFederatedBundlesResponse.Unmarshal(m, b)
+}
+func (m *CountFederatedBundlesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountFederatedBundlesResponse.Marshal(b, m, deterministic)
+}
+func (m *CountFederatedBundlesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountFederatedBundlesResponse.Merge(m, src)
+}
+func (m *CountFederatedBundlesResponse) XXX_Size() int {
+	return xxx_messageInfo_CountFederatedBundlesResponse.Size(m)
+}
+func (m *CountFederatedBundlesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountFederatedBundlesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountFederatedBundlesResponse proto.InternalMessageInfo
+
+func (m *CountFederatedBundlesResponse) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 // Represents an evict request
 type EvictAgentRequest struct {
 	// Agent identity of the node to be evicted.
@@ -715,7 +1121,7 @@ func (m *EvictAgentRequest) Reset()         { *m = EvictAgentRequest{} }
 func (m *EvictAgentRequest) String() string { return proto.CompactTextString(m) }
 func (*EvictAgentRequest) ProtoMessage()    {}
 func (*EvictAgentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{15}
+	return fileDescriptor_7f325c92bf3cfce0, []int{21}
 }
 
 func (m *EvictAgentRequest) XXX_Unmarshal(b []byte) error {
@@ -756,7 +1162,7 @@ func (m *EvictAgentResponse) Reset()         { *m = EvictAgentResponse{} }
 func (m *EvictAgentResponse) String() string { return proto.CompactTextString(m) }
 func (*EvictAgentResponse) ProtoMessage()    {}
 func (*EvictAgentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{16}
+	return fileDescriptor_7f325c92bf3cfce0, []int{22}
 }
 
 func (m *EvictAgentResponse) XXX_Unmarshal(b []byte) error {
@@ -784,6 +1190,253 @@ func (m *EvictAgentResponse) GetNode() *common.AttestedNode {
 	return nil
 }
 
+// Represents a ban request
+type BanAgentRequest struct {
+	// Agent identity of the node to be banned.
+	// For example: "spiffe://example.org/spire/agent/join_token/feea6adc-3254-4052-9a18-5eeb74bf214f"
+	SpiffeID             string   `protobuf:"bytes,1,opt,name=spiffeID,proto3" json:"spiffeID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BanAgentRequest) Reset()         { *m = BanAgentRequest{} }
+func (m *BanAgentRequest) String() string { return proto.CompactTextString(m) }
+func (*BanAgentRequest) ProtoMessage()    {}
+func (*BanAgentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{23}
+}
+
+func (m *BanAgentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BanAgentRequest.Unmarshal(m, b)
+}
+func (m *BanAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BanAgentRequest.Marshal(b, m, deterministic)
+}
+func (m *BanAgentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BanAgentRequest.Merge(m, src)
+}
+func (m *BanAgentRequest) XXX_Size() int {
+	return xxx_messageInfo_BanAgentRequest.Size(m)
+}
+func (m *BanAgentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BanAgentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BanAgentRequest proto.InternalMessageInfo
+
+func (m *BanAgentRequest) GetSpiffeID() string {
+	if m != nil {
+		return m.SpiffeID
+	}
+	return ""
+}
+
+// Represents a ban response
+type BanAgentResponse struct {
+	// Node contains the banned node
+	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BanAgentResponse) Reset()         { *m = BanAgentResponse{} }
+func (m *BanAgentResponse) String() string { return proto.CompactTextString(m) }
+func (*BanAgentResponse) ProtoMessage()    {}
+func (*BanAgentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{24}
+}
+
+func (m *BanAgentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BanAgentResponse.Unmarshal(m, b)
+}
+func (m *BanAgentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BanAgentResponse.Marshal(b, m, deterministic)
+}
+func (m *BanAgentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BanAgentResponse.Merge(m, src)
+}
+func (m *BanAgentResponse) XXX_Size() int {
+	return xxx_messageInfo_BanAgentResponse.Size(m)
+}
+func (m *BanAgentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BanAgentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BanAgentResponse proto.InternalMessageInfo
+
+func (m *BanAgentResponse) GetNode() *common.AttestedNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+// Represents a prune agents request
+type PruneAgentsRequest struct {
+	// Attested nodes whose SVID expired before this time (seconds since
+	// the Unix epoch) are removed. Banned nodes are never removed.
+	ExpiresBefore        int64    `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneAgentsRequest) Reset()         { *m = PruneAgentsRequest{} }
+func (m *PruneAgentsRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneAgentsRequest) ProtoMessage()    {}
+func (*PruneAgentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{25}
+}
+
+func (m *PruneAgentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneAgentsRequest.Unmarshal(m, b)
+}
+func (m *PruneAgentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneAgentsRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneAgentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneAgentsRequest.Merge(m, src)
+}
+func (m *PruneAgentsRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneAgentsRequest.Size(m)
+}
+func (m *PruneAgentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneAgentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneAgentsRequest proto.InternalMessageInfo
+
+func (m *PruneAgentsRequest) GetExpiresBefore() int64 {
+	if m != nil {
+		return m.ExpiresBefore
+	}
+	return 0
+}
+
+// Represents a prune agents response
+type PruneAgentsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneAgentsResponse) Reset()         { *m = PruneAgentsResponse{} }
+func (m *PruneAgentsResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneAgentsResponse) ProtoMessage()    {}
+func (*PruneAgentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{26}
+}
+
+func (m *PruneAgentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneAgentsResponse.Unmarshal(m, b)
+}
+func (m *PruneAgentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneAgentsResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneAgentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneAgentsResponse.Merge(m, src)
+}
+func (m *PruneAgentsResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneAgentsResponse.Size(m)
+}
+func (m *PruneAgentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneAgentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneAgentsResponse proto.InternalMessageInfo
+
+type ForceAgentRotationRequest struct {
+	// Agent identities of the nodes whose SVID must be rotated.
+	SpiffeIDs []string `protobuf:"bytes,1,rep,name=spiffeIDs,proto3" json:"spiffeIDs,omitempty"`
+	// If true, the SVIDs of all the attested nodes that are not banned are
+	// rotated, and spiffeIDs must be empty.
+	All                  bool     `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ForceAgentRotationRequest) Reset()         { *m = ForceAgentRotationRequest{} }
+func (m *ForceAgentRotationRequest) String() string { return proto.CompactTextString(m) }
+func (*ForceAgentRotationRequest) ProtoMessage()    {}
+func (*ForceAgentRotationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{27}
+}
+
+func (m *ForceAgentRotationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForceAgentRotationRequest.Unmarshal(m, b)
+}
+func (m *ForceAgentRotationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForceAgentRotationRequest.Marshal(b, m, deterministic)
+}
+func (m *ForceAgentRotationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForceAgentRotationRequest.Merge(m, src)
+}
+func (m *ForceAgentRotationRequest) XXX_Size() int {
+	return xxx_messageInfo_ForceAgentRotationRequest.Size(m)
+}
+func (m *ForceAgentRotationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForceAgentRotationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForceAgentRotationRequest proto.InternalMessageInfo
+
+func (m *ForceAgentRotationRequest) GetSpiffeIDs() []string {
+	if m != nil {
+		return m.SpiffeIDs
+	}
+	return nil
+}
+
+func (m *ForceAgentRotationRequest) GetAll() bool {
+	if m != nil {
+		return m.All
+	}
+	return false
+}
+
+type ForceAgentRotationResponse struct {
+	// Nodes contains the nodes whose SVID will be rotated
+	Nodes                []*common.AttestedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ForceAgentRotationResponse) Reset()         { *m = ForceAgentRotationResponse{} }
+func (m *ForceAgentRotationResponse) String() string { return proto.CompactTextString(m) }
+func (*ForceAgentRotationResponse) ProtoMessage()    {}
+func (*ForceAgentRotationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{28}
+}
+
+func (m *ForceAgentRotationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForceAgentRotationResponse.Unmarshal(m, b)
+}
+func (m *ForceAgentRotationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForceAgentRotationResponse.Marshal(b, m, deterministic)
+}
+func (m *ForceAgentRotationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForceAgentRotationResponse.Merge(m, src)
+}
+func (m *ForceAgentRotationResponse) XXX_Size() int {
+	return xxx_messageInfo_ForceAgentRotationResponse.Size(m)
+}
+func (m *ForceAgentRotationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForceAgentRotationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForceAgentRotationResponse proto.InternalMessageInfo
+
+func (m *ForceAgentRotationResponse) GetNodes() []*common.AttestedNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
 type MintX509SVIDRequest struct {
 	// SPIFFE ID of the X509-SVID
 	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
@@ -807,7 +1460,7 @@ func (m *MintX509SVIDRequest) Reset()         { *m = MintX509SVIDRequest{} }
 func (m *MintX509SVIDRequest) String() string { return proto.CompactTextString(m) }
 func (*MintX509SVIDRequest) ProtoMessage()    {}
 func (*MintX509SVIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{17}
+	return fileDescriptor_7f325c92bf3cfce0, []int{29}
 }
 
 func (m *MintX509SVIDRequest) XXX_Unmarshal(b []byte) error {
@@ -871,7 +1524,7 @@ func (m *MintX509SVIDResponse) Reset()         { *m = MintX509SVIDResponse{} }
 func (m *MintX509SVIDResponse) String() string { return proto.CompactTextString(m) }
 func (*MintX509SVIDResponse) ProtoMessage()    {}
 func (*MintX509SVIDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{18}
+	return fileDescriptor_7f325c92bf3cfce0, []int{30}
 }
 
 func (m *MintX509SVIDResponse) XXX_Unmarshal(b []byte) error {
@@ -926,7 +1579,7 @@ func (m *MintJWTSVIDRequest) Reset()         { *m = MintJWTSVIDRequest{} }
 func (m *MintJWTSVIDRequest) String() string { return proto.CompactTextString(m) }
 func (*MintJWTSVIDRequest) ProtoMessage()    {}
 func (*MintJWTSVIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{19}
+	return fileDescriptor_7f325c92bf3cfce0, []int{31}
 }
 
 func (m *MintJWTSVIDRequest) XXX_Unmarshal(b []byte) error {
@@ -980,7 +1633,7 @@ func (m *MintJWTSVIDResponse) Reset()         { *m = MintJWTSVIDResponse{} }
 func (m *MintJWTSVIDResponse) String() string { return proto.CompactTextString(m) }
 func (*MintJWTSVIDResponse) ProtoMessage()    {}
 func (*MintJWTSVIDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{20}
+	return fileDescriptor_7f325c92bf3cfce0, []int{32}
 }
 
 func (m *MintJWTSVIDResponse) XXX_Unmarshal(b []byte) error {
@@ -1022,7 +1675,7 @@ func (m *NodeSelectors) Reset()         { *m = NodeSelectors{} }
 func (m *NodeSelectors) String() string { return proto.CompactTextString(m) }
 func (*NodeSelectors) ProtoMessage()    {}
 func (*NodeSelectors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{21}
+	return fileDescriptor_7f325c92bf3cfce0, []int{33}
 }
 
 func (m *NodeSelectors) XXX_Unmarshal(b []byte) error {
@@ -1034,111 +1687,326 @@ func (m *NodeSelectors) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 func (m *NodeSelectors) XXX_Merge(src proto.Message) {
 	xxx_messageInfo_NodeSelectors.Merge(m, src)
 }
-func (m *NodeSelectors) XXX_Size() int {
-	return xxx_messageInfo_NodeSelectors.Size(m)
+func (m *NodeSelectors) XXX_Size() int {
+	return xxx_messageInfo_NodeSelectors.Size(m)
+}
+func (m *NodeSelectors) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeSelectors.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeSelectors proto.InternalMessageInfo
+
+func (m *NodeSelectors) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *NodeSelectors) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+// Represents a GetNodeSelectors request
+type GetNodeSelectorsRequest struct {
+	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNodeSelectorsRequest) Reset()         { *m = GetNodeSelectorsRequest{} }
+func (m *GetNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetNodeSelectorsRequest) ProtoMessage()    {}
+func (*GetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{34}
+}
+
+func (m *GetNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNodeSelectorsRequest.Unmarshal(m, b)
+}
+func (m *GetNodeSelectorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNodeSelectorsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetNodeSelectorsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeSelectorsRequest.Merge(m, src)
+}
+func (m *GetNodeSelectorsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetNodeSelectorsRequest.Size(m)
+}
+func (m *GetNodeSelectorsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeSelectorsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeSelectorsRequest proto.InternalMessageInfo
+
+func (m *GetNodeSelectorsRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+// Represents a GetNodeSelectors response
+type GetNodeSelectorsResponse struct {
+	Selectors            *NodeSelectors `protobuf:"bytes,1,opt,name=selectors,proto3" json:"selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetNodeSelectorsResponse) Reset()         { *m = GetNodeSelectorsResponse{} }
+func (m *GetNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetNodeSelectorsResponse) ProtoMessage()    {}
+func (*GetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{35}
+}
+
+func (m *GetNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNodeSelectorsResponse.Unmarshal(m, b)
+}
+func (m *GetNodeSelectorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNodeSelectorsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetNodeSelectorsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeSelectorsResponse.Merge(m, src)
+}
+func (m *GetNodeSelectorsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetNodeSelectorsResponse.Size(m)
+}
+func (m *GetNodeSelectorsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeSelectorsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeSelectorsResponse proto.InternalMessageInfo
+
+func (m *GetNodeSelectorsResponse) GetSelectors() *NodeSelectors {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+// Represents the record of an issued X509-SVID
+type IssuedX509SVID struct {
+	// SPIFFE ID of the X509-SVID
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Serial number of the X509-SVID, in decimal
+	SerialNumber string `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	// Issuance time in seconds since the Unix epoch
+	IssuedAt int64 `protobuf:"varint,3,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	// Expiration time in seconds since the Unix epoch
+	ExpiresAt int64 `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Key ID (hex encoded authority key identifier) of the CA that signed
+	// the X509-SVID
+	CaKeyId string `protobuf:"bytes,5,opt,name=ca_key_id,json=caKeyId,proto3" json:"ca_key_id,omitempty"`
+	// SPIFFE ID of the agent the X509-SVID was issued to. Empty for
+	// X509-SVIDs minted through the registration API.
+	AgentId string `protobuf:"bytes,6,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// ID of the registration entry the X509-SVID was issued for. Empty for
+	// agent SVIDs and minted X509-SVIDs.
+	EntryId              string   `protobuf:"bytes,7,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssuedX509SVID) Reset()         { *m = IssuedX509SVID{} }
+func (m *IssuedX509SVID) String() string { return proto.CompactTextString(m) }
+func (*IssuedX509SVID) ProtoMessage()    {}
+func (*IssuedX509SVID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{36}
+}
+
+func (m *IssuedX509SVID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssuedX509SVID.Unmarshal(m, b)
+}
+func (m *IssuedX509SVID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssuedX509SVID.Marshal(b, m, deterministic)
+}
+func (m *IssuedX509SVID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssuedX509SVID.Merge(m, src)
+}
+func (m *IssuedX509SVID) XXX_Size() int {
+	return xxx_messageInfo_IssuedX509SVID.Size(m)
+}
+func (m *IssuedX509SVID) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssuedX509SVID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssuedX509SVID proto.InternalMessageInfo
+
+func (m *IssuedX509SVID) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *IssuedX509SVID) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
 }
-func (m *NodeSelectors) XXX_DiscardUnknown() {
-	xxx_messageInfo_NodeSelectors.DiscardUnknown(m)
+
+func (m *IssuedX509SVID) GetIssuedAt() int64 {
+	if m != nil {
+		return m.IssuedAt
+	}
+	return 0
 }
 
-var xxx_messageInfo_NodeSelectors proto.InternalMessageInfo
+func (m *IssuedX509SVID) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
 
-func (m *NodeSelectors) GetSpiffeId() string {
+func (m *IssuedX509SVID) GetCaKeyId() string {
 	if m != nil {
-		return m.SpiffeId
+		return m.CaKeyId
 	}
 	return ""
 }
 
-func (m *NodeSelectors) GetSelectors() []*common.Selector {
+func (m *IssuedX509SVID) GetAgentId() string {
 	if m != nil {
-		return m.Selectors
+		return m.AgentId
 	}
-	return nil
+	return ""
 }
 
-// Represents a GetNodeSelectors request
-type GetNodeSelectorsRequest struct {
-	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *IssuedX509SVID) GetEntryId() string {
+	if m != nil {
+		return m.EntryId
+	}
+	return ""
 }
 
-func (m *GetNodeSelectorsRequest) Reset()         { *m = GetNodeSelectorsRequest{} }
-func (m *GetNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
-func (*GetNodeSelectorsRequest) ProtoMessage()    {}
-func (*GetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{22}
+// Represents a ListIssuedX509SVIDs request. Unset filters match any record.
+type ListIssuedX509SVIDsRequest struct {
+	// Matches the X509-SVIDs with this SPIFFE ID
+	BySpiffeId string `protobuf:"bytes,1,opt,name=by_spiffe_id,json=bySpiffeId,proto3" json:"by_spiffe_id,omitempty"`
+	// Matches the X509-SVIDs issued to the agent with this SPIFFE ID
+	ByAgentId string `protobuf:"bytes,2,opt,name=by_agent_id,json=byAgentId,proto3" json:"by_agent_id,omitempty"`
+	// Matches the X509-SVIDs that expire after this time (seconds since the
+	// Unix epoch)
+	ByExpiresAfter       int64       `protobuf:"varint,3,opt,name=by_expires_after,json=byExpiresAfter,proto3" json:"by_expires_after,omitempty"`
+	Pagination           *Pagination `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *GetNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetNodeSelectorsRequest.Unmarshal(m, b)
+func (m *ListIssuedX509SVIDsRequest) Reset()         { *m = ListIssuedX509SVIDsRequest{} }
+func (m *ListIssuedX509SVIDsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListIssuedX509SVIDsRequest) ProtoMessage()    {}
+func (*ListIssuedX509SVIDsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{37}
 }
-func (m *GetNodeSelectorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetNodeSelectorsRequest.Marshal(b, m, deterministic)
+
+func (m *ListIssuedX509SVIDsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListIssuedX509SVIDsRequest.Unmarshal(m, b)
 }
-func (m *GetNodeSelectorsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetNodeSelectorsRequest.Merge(m, src)
+func (m *ListIssuedX509SVIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListIssuedX509SVIDsRequest.Marshal(b, m, deterministic)
 }
-func (m *GetNodeSelectorsRequest) XXX_Size() int {
-	return xxx_messageInfo_GetNodeSelectorsRequest.Size(m)
+func (m *ListIssuedX509SVIDsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListIssuedX509SVIDsRequest.Merge(m, src)
 }
-func (m *GetNodeSelectorsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetNodeSelectorsRequest.DiscardUnknown(m)
+func (m *ListIssuedX509SVIDsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListIssuedX509SVIDsRequest.Size(m)
+}
+func (m *ListIssuedX509SVIDsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListIssuedX509SVIDsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetNodeSelectorsRequest proto.InternalMessageInfo
+var xxx_messageInfo_ListIssuedX509SVIDsRequest proto.InternalMessageInfo
 
-func (m *GetNodeSelectorsRequest) GetSpiffeId() string {
+func (m *ListIssuedX509SVIDsRequest) GetBySpiffeId() string {
 	if m != nil {
-		return m.SpiffeId
+		return m.BySpiffeId
 	}
 	return ""
 }
 
-// Represents a GetNodeSelectors response
-type GetNodeSelectorsResponse struct {
-	Selectors            *NodeSelectors `protobuf:"bytes,1,opt,name=selectors,proto3" json:"selectors,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+func (m *ListIssuedX509SVIDsRequest) GetByAgentId() string {
+	if m != nil {
+		return m.ByAgentId
+	}
+	return ""
 }
 
-func (m *GetNodeSelectorsResponse) Reset()         { *m = GetNodeSelectorsResponse{} }
-func (m *GetNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
-func (*GetNodeSelectorsResponse) ProtoMessage()    {}
-func (*GetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f325c92bf3cfce0, []int{23}
+func (m *ListIssuedX509SVIDsRequest) GetByExpiresAfter() int64 {
+	if m != nil {
+		return m.ByExpiresAfter
+	}
+	return 0
 }
 
-func (m *GetNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetNodeSelectorsResponse.Unmarshal(m, b)
+func (m *ListIssuedX509SVIDsRequest) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
 }
-func (m *GetNodeSelectorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetNodeSelectorsResponse.Marshal(b, m, deterministic)
+
+// Represents a ListIssuedX509SVIDs response
+type ListIssuedX509SVIDsResponse struct {
+	Svids                []*IssuedX509SVID `protobuf:"bytes,1,rep,name=svids,proto3" json:"svids,omitempty"`
+	Pagination           *Pagination       `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
-func (m *GetNodeSelectorsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetNodeSelectorsResponse.Merge(m, src)
+
+func (m *ListIssuedX509SVIDsResponse) Reset()         { *m = ListIssuedX509SVIDsResponse{} }
+func (m *ListIssuedX509SVIDsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListIssuedX509SVIDsResponse) ProtoMessage()    {}
+func (*ListIssuedX509SVIDsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f325c92bf3cfce0, []int{38}
 }
-func (m *GetNodeSelectorsResponse) XXX_Size() int {
-	return xxx_messageInfo_GetNodeSelectorsResponse.Size(m)
+
+func (m *ListIssuedX509SVIDsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListIssuedX509SVIDsResponse.Unmarshal(m, b)
 }
-func (m *GetNodeSelectorsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetNodeSelectorsResponse.DiscardUnknown(m)
+func (m *ListIssuedX509SVIDsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListIssuedX509SVIDsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListIssuedX509SVIDsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListIssuedX509SVIDsResponse.Merge(m, src)
+}
+func (m *ListIssuedX509SVIDsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListIssuedX509SVIDsResponse.Size(m)
+}
+func (m *ListIssuedX509SVIDsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListIssuedX509SVIDsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetNodeSelectorsResponse proto.InternalMessageInfo
+var xxx_messageInfo_ListIssuedX509SVIDsResponse proto.InternalMessageInfo
 
-func (m *GetNodeSelectorsResponse) GetSelectors() *NodeSelectors {
+func (m *ListIssuedX509SVIDsResponse) GetSvids() []*IssuedX509SVID {
 	if m != nil {
-		return m.Selectors
+		return m.Svids
+	}
+	return nil
+}
+
+func (m *ListIssuedX509SVIDsResponse) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
 	}
 	return nil
 }
 
 func init() {
 	proto.RegisterEnum("spire.api.registration.DeleteFederatedBundleRequest_Mode", DeleteFederatedBundleRequest_Mode_name, DeleteFederatedBundleRequest_Mode_value)
+	proto.RegisterEnum("spire.api.registration.AgentFilter_MatchBehavior", AgentFilter_MatchBehavior_name, AgentFilter_MatchBehavior_value)
 	proto.RegisterType((*RegistrationEntryID)(nil), "spire.api.registration.RegistrationEntryID")
 	proto.RegisterType((*ParentID)(nil), "spire.api.registration.ParentID")
 	proto.RegisterType((*SpiffeID)(nil), "spire.api.registration.SpiffeID")
@@ -1147,15 +2015,29 @@ func init() {
 	proto.RegisterType((*Pagination)(nil), "spire.api.registration.Pagination")
 	proto.RegisterType((*ListAllEntriesRequest)(nil), "spire.api.registration.ListAllEntriesRequest")
 	proto.RegisterType((*ListAllEntriesResponse)(nil), "spire.api.registration.ListAllEntriesResponse")
+	proto.RegisterType((*GetEntryStatisticsRequest)(nil), "spire.api.registration.GetEntryStatisticsRequest")
+	proto.RegisterType((*GetEntryStatisticsResponse)(nil), "spire.api.registration.GetEntryStatisticsResponse")
+	proto.RegisterMapType((map[string]int32)(nil), "spire.api.registration.GetEntryStatisticsResponse.ByParentIdEntry")
+	proto.RegisterMapType((map[string]int32)(nil), "spire.api.registration.GetEntryStatisticsResponse.BySelectorTypeEntry")
 	proto.RegisterType((*FederatedBundle)(nil), "spire.api.registration.FederatedBundle")
 	proto.RegisterType((*FederatedBundleID)(nil), "spire.api.registration.FederatedBundleID")
 	proto.RegisterType((*DeleteFederatedBundleRequest)(nil), "spire.api.registration.DeleteFederatedBundleRequest")
 	proto.RegisterType((*JoinToken)(nil), "spire.api.registration.JoinToken")
 	proto.RegisterType((*Bundle)(nil), "spire.api.registration.Bundle")
+	proto.RegisterType((*AgentFilter)(nil), "spire.api.registration.AgentFilter")
 	proto.RegisterType((*ListAgentsRequest)(nil), "spire.api.registration.ListAgentsRequest")
 	proto.RegisterType((*ListAgentsResponse)(nil), "spire.api.registration.ListAgentsResponse")
+	proto.RegisterType((*CountAgentsRequest)(nil), "spire.api.registration.CountAgentsRequest")
+	proto.RegisterType((*CountAgentsResponse)(nil), "spire.api.registration.CountAgentsResponse")
+	proto.RegisterType((*CountFederatedBundlesResponse)(nil), "spire.api.registration.CountFederatedBundlesResponse")
 	proto.RegisterType((*EvictAgentRequest)(nil), "spire.api.registration.EvictAgentRequest")
 	proto.RegisterType((*EvictAgentResponse)(nil), "spire.api.registration.EvictAgentResponse")
+	proto.RegisterType((*BanAgentRequest)(nil), "spire.api.registration.BanAgentRequest")
+	proto.RegisterType((*BanAgentResponse)(nil), "spire.api.registration.BanAgentResponse")
+	proto.RegisterType((*PruneAgentsRequest)(nil), "spire.api.registration.PruneAgentsRequest")
+	proto.RegisterType((*PruneAgentsResponse)(nil), "spire.api.registration.PruneAgentsResponse")
+	proto.RegisterType((*ForceAgentRotationRequest)(nil), "spire.api.registration.ForceAgentRotationRequest")
+	proto.RegisterType((*ForceAgentRotationResponse)(nil), "spire.api.registration.ForceAgentRotationResponse")
 	proto.RegisterType((*MintX509SVIDRequest)(nil), "spire.api.registration.MintX509SVIDRequest")
 	proto.RegisterType((*MintX509SVIDResponse)(nil), "spire.api.registration.MintX509SVIDResponse")
 	proto.RegisterType((*MintJWTSVIDRequest)(nil), "spire.api.registration.MintJWTSVIDRequest")
@@ -1163,6 +2045,9 @@ func init() {
 	proto.RegisterType((*NodeSelectors)(nil), "spire.api.registration.NodeSelectors")
 	proto.RegisterType((*GetNodeSelectorsRequest)(nil), "spire.api.registration.GetNodeSelectorsRequest")
 	proto.RegisterType((*GetNodeSelectorsResponse)(nil), "spire.api.registration.GetNodeSelectorsResponse")
+	proto.RegisterType((*IssuedX509SVID)(nil), "spire.api.registration.IssuedX509SVID")
+	proto.RegisterType((*ListIssuedX509SVIDsRequest)(nil), "spire.api.registration.ListIssuedX509SVIDsRequest")
+	proto.RegisterType((*ListIssuedX509SVIDsResponse)(nil), "spire.api.registration.ListIssuedX509SVIDsResponse")
 }
 
 func init() {
@@ -1170,78 +2055,135 @@ func init() {
 }
 
 var fileDescriptor_7f325c92bf3cfce0 = []byte{
-	// 1135 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x6d, 0x6f, 0xdb, 0x36,
-	0x10, 0x9e, 0x9d, 0x97, 0xda, 0x67, 0x2f, 0x2f, 0xcc, 0x9b, 0xab, 0x6e, 0x9d, 0xcb, 0xa1, 0x58,
-	0xdb, 0x74, 0x76, 0x90, 0xa6, 0x01, 0xf2, 0xa9, 0x48, 0x6c, 0x67, 0x70, 0xdb, 0x64, 0x81, 0xec,
-	0x34, 0x43, 0xf2, 0xc1, 0x50, 0x2c, 0xc6, 0xe1, 0xe6, 0x48, 0xaa, 0x48, 0x17, 0x4d, 0x7f, 0xc0,
-	0xfe, 0xc2, 0x7e, 0xc6, 0xfe, 0xe2, 0x40, 0x91, 0x92, 0x25, 0x59, 0x8a, 0xd5, 0x60, 0xfd, 0x14,
-	0x91, 0xbc, 0x7b, 0xee, 0xb9, 0xe3, 0x91, 0x7c, 0x1c, 0x78, 0xce, 0x1c, 0xea, 0x92, 0xba, 0xe1,
-	0xd0, 0xba, 0x4b, 0x06, 0x94, 0x71, 0xd7, 0xe0, 0xd4, 0xb6, 0x22, 0x83, 0x9a, 0xe3, 0xda, 0xdc,
-	0x46, 0xeb, 0x9e, 0x69, 0xcd, 0x70, 0x68, 0x2d, 0xbc, 0xaa, 0x3d, 0x94, 0x10, 0x7d, 0xfb, 0xe6,
-	0xc6, 0xb6, 0xd4, 0x1f, 0xe9, 0x82, 0x9f, 0xc2, 0x8a, 0x1e, 0x32, 0x6d, 0x59, 0xdc, 0xbd, 0x6d,
-	0x37, 0xd1, 0x02, 0xe4, 0xa9, 0x59, 0xc9, 0x55, 0x73, 0xcf, 0x8a, 0x7a, 0x9e, 0x9a, 0x58, 0x83,
-	0xc2, 0x89, 0xe1, 0x12, 0x8b, 0x27, 0xaf, 0x75, 0x1c, 0x7a, 0x75, 0x45, 0x12, 0xd6, 0x6e, 0xe1,
-	0x71, 0xc3, 0x25, 0x06, 0x27, 0x12, 0xf8, 0xea, 0xd8, 0xe6, 0xad, 0xcf, 0x94, 0x71, 0xa6, 0x13,
-	0xe6, 0xd8, 0x16, 0x23, 0xe8, 0x35, 0xcc, 0x11, 0xb1, 0xe6, 0x39, 0x95, 0xb6, 0x7f, 0xaa, 0xc9,
-	0x1c, 0x14, 0xc9, 0x09, 0x6e, 0xba, 0xb4, 0x46, 0x55, 0x28, 0x39, 0x2e, 0x21, 0x02, 0x8b, 0x5a,
-	0x83, 0x4a, 0xbe, 0x9a, 0x7b, 0x56, 0xd0, 0xc3, 0x53, 0xf8, 0x1d, 0xa0, 0x53, 0xc7, 0xf4, 0x43,
-	0xeb, 0xe4, 0xe3, 0x88, 0x30, 0x7e, 0xcf, 0x70, 0xf8, 0x0d, 0xc0, 0x89, 0x31, 0xa0, 0x96, 0xb7,
-	0x82, 0x56, 0x61, 0x8e, 0xdb, 0x7f, 0x11, 0x4b, 0x25, 0x2a, 0x07, 0xe8, 0x11, 0x14, 0x1d, 0x63,
-	0x40, 0x7a, 0x8c, 0x7e, 0x21, 0x1e, 0xa1, 0x39, 0xbd, 0x20, 0x26, 0x3a, 0xf4, 0x0b, 0xc1, 0x17,
-	0xb0, 0xf6, 0x9e, 0x32, 0xbe, 0x3f, 0x1c, 0x0a, 0x5c, 0x4a, 0x98, 0x4f, 0xe8, 0x00, 0xc0, 0x09,
-	0x90, 0x15, 0x2b, 0x5c, 0x4b, 0xde, 0xc8, 0xda, 0x98, 0x83, 0x1e, 0xf2, 0xc2, 0xff, 0xe4, 0x60,
-	0x3d, 0x8e, 0xae, 0xca, 0xbb, 0x07, 0x0f, 0x88, 0x9c, 0xaa, 0xe4, 0xaa, 0x33, 0x59, 0x32, 0xf6,
-	0xed, 0x63, 0xcc, 0xf2, 0xf7, 0x62, 0xf6, 0x06, 0x16, 0x0f, 0x89, 0x49, 0x5c, 0x83, 0x13, 0xf3,
-	0x60, 0x64, 0x99, 0x43, 0x82, 0x5e, 0xc2, 0xfc, 0xa5, 0xf7, 0x55, 0x99, 0xf1, 0x20, 0x57, 0xa3,
-	0x84, 0xa4, 0x95, 0xae, 0x6c, 0xf0, 0xcf, 0xb0, 0x1c, 0x03, 0x48, 0xe8, 0xb2, 0x7f, 0x73, 0xf0,
-	0x43, 0x93, 0x0c, 0x09, 0x27, 0x31, 0x5b, 0xbf, 0xc8, 0x31, 0x07, 0x74, 0x04, 0xb3, 0x37, 0xb6,
-	0x29, 0x77, 0x69, 0x61, 0x7b, 0x2f, 0x2d, 0xa9, 0xbb, 0x30, 0x6b, 0x47, 0xb6, 0x49, 0x74, 0x0f,
-	0x06, 0x6f, 0xc1, 0xac, 0x18, 0xa1, 0x32, 0x14, 0xf4, 0x56, 0xa7, 0xab, 0xb7, 0x1b, 0xdd, 0xa5,
-	0xef, 0x10, 0xc0, 0x7c, 0xb3, 0xf5, 0xbe, 0xd5, 0x6d, 0x2d, 0xe5, 0xd0, 0x02, 0x40, 0xb3, 0xdd,
-	0xe9, 0xfc, 0xde, 0x68, 0xef, 0x77, 0x5b, 0x4b, 0x79, 0xfc, 0x0a, 0x8a, 0x6f, 0x6d, 0x6a, 0x75,
-	0xbd, 0xc6, 0x49, 0x6e, 0xa7, 0x25, 0x98, 0xe1, 0x7c, 0xa8, 0x1a, 0x49, 0x7c, 0xe2, 0x5d, 0x98,
-	0x9f, 0xa8, 0x61, 0x3e, 0x43, 0x0d, 0x57, 0x60, 0xd9, 0xeb, 0x8e, 0x01, 0xb1, 0xb8, 0xdf, 0x77,
-	0xf8, 0x10, 0x50, 0x78, 0x52, 0xb5, 0xcb, 0x16, 0xcc, 0x59, 0xb6, 0x19, 0x34, 0x8b, 0x16, 0xc5,
-	0xdd, 0xe7, 0x9c, 0x30, 0x4e, 0xcc, 0x63, 0x91, 0xba, 0x34, 0xc4, 0x75, 0x58, 0x6e, 0x7d, 0xa2,
-	0x7d, 0x09, 0xe4, 0xd7, 0x5b, 0x83, 0x02, 0x53, 0x57, 0x82, 0x4a, 0x2a, 0x18, 0xe3, 0x26, 0xa0,
-	0xb0, 0x83, 0x0a, 0x5c, 0x83, 0x59, 0x81, 0xa7, 0x0e, 0xc0, 0x5d, 0x71, 0x3d, 0x3b, 0xcc, 0x60,
-	0xe5, 0x88, 0x5a, 0xfc, 0x8f, 0xd7, 0x5b, 0x7b, 0x9d, 0x0f, 0xed, 0xa6, 0x1f, 0xf8, 0x11, 0x14,
-	0x65, 0xa0, 0x5e, 0xb0, 0xdf, 0x7e, 0x64, 0x53, 0x54, 0xb4, 0xcf, 0x5c, 0xaf, 0x64, 0x65, 0x5d,
-	0x7c, 0xfa, 0x35, 0x9e, 0x09, 0x6a, 0x2c, 0x00, 0x4c, 0x8b, 0xf5, 0x2c, 0xe3, 0x86, 0xb0, 0xca,
-	0x6c, 0x75, 0x46, 0x00, 0x98, 0x16, 0x3b, 0x16, 0x63, 0x7c, 0x02, 0xab, 0xd1, 0xa0, 0x8a, 0xfc,
-	0x8f, 0x00, 0xec, 0x13, 0x35, 0x7b, 0xfd, 0x6b, 0x83, 0x5a, 0x5e, 0xe9, 0xca, 0x7a, 0x51, 0xcc,
-	0x34, 0xc4, 0x04, 0x7a, 0x08, 0x05, 0xd7, 0xb6, 0x79, 0xaf, 0x6f, 0xb0, 0x4a, 0xde, 0x5b, 0x7c,
-	0x20, 0xc6, 0x0d, 0x83, 0xe1, 0x1e, 0x20, 0x81, 0xf8, 0xf6, 0xac, 0xfb, 0x35, 0x59, 0x44, 0xfb,
-	0x42, 0x54, 0xdb, 0x18, 0x99, 0x94, 0x58, 0x7d, 0x71, 0xa6, 0x3c, 0xca, 0xfe, 0x18, 0x6f, 0xca,
-	0x3a, 0x05, 0x01, 0x14, 0xe3, 0xc4, 0x96, 0xc3, 0x97, 0xf0, 0xbd, 0x28, 0x71, 0x87, 0x0c, 0x49,
-	0x9f, 0xdb, 0x2e, 0xbb, 0x9b, 0xc8, 0x0e, 0x14, 0x99, 0x6f, 0xe9, 0xe5, 0x55, 0xda, 0x5e, 0x8f,
-	0xee, 0x9b, 0x0f, 0xa4, 0x8f, 0x0d, 0xf1, 0x2e, 0x6c, 0xfc, 0x46, 0x78, 0x24, 0x4c, 0x96, 0xb4,
-	0x71, 0x0f, 0x2a, 0x93, 0x7e, 0x2a, 0x9b, 0x46, 0x98, 0x89, 0xec, 0xa0, 0xa7, 0x69, 0x67, 0x3a,
-	0x8a, 0x30, 0xf6, 0xdb, 0xfe, 0x7b, 0x19, 0xca, 0xe1, 0xdb, 0x10, 0x5d, 0x40, 0x29, 0xf4, 0x76,
-	0xa1, 0x69, 0x17, 0xa7, 0xb6, 0x99, 0x16, 0x32, 0xe9, 0x81, 0xfd, 0x08, 0xeb, 0xc9, 0x0f, 0xe3,
-	0xf4, 0x38, 0xbb, 0x69, 0x71, 0xa6, 0xbc, 0xb4, 0x17, 0x50, 0x92, 0x17, 0x9a, 0xcc, 0xe7, 0x6b,
-	0xe8, 0x6a, 0xd3, 0x48, 0xa1, 0x73, 0x80, 0x43, 0xc2, 0xfb, 0xd7, 0xdf, 0x02, 0xfb, 0x10, 0xca,
-	0x01, 0xb6, 0x78, 0x98, 0x56, 0xa2, 0x0e, 0xad, 0x1b, 0x87, 0xdf, 0x6a, 0x4f, 0xee, 0x46, 0x11,
-	0x7e, 0xe7, 0x50, 0x0a, 0x29, 0x02, 0xf4, 0x22, 0x8d, 0xe4, 0xa4, 0x6c, 0x98, 0xce, 0xf1, 0x14,
-	0x16, 0xc4, 0x75, 0x7a, 0x70, 0x1b, 0xc8, 0xa4, 0x6a, 0xfa, 0x53, 0x29, 0x2d, 0xb2, 0x50, 0x7e,
-	0xe7, 0xc3, 0xfa, 0x2d, 0x8b, 0x52, 0x8e, 0x58, 0x16, 0xb0, 0x23, 0x58, 0x8c, 0x82, 0x31, 0xb4,
-	0x91, 0x8c, 0xc6, 0xb2, 0xc0, 0x05, 0x29, 0x07, 0xea, 0x2f, 0x35, 0x65, 0xdf, 0x22, 0x0b, 0xec,
-	0x67, 0xd8, 0x88, 0x6a, 0x99, 0x33, 0xca, 0xaf, 0x4f, 0x8c, 0x01, 0x61, 0xe8, 0xd7, 0x34, 0xfc,
-	0x44, 0x69, 0xa5, 0xd5, 0xb2, 0x9a, 0xab, 0x03, 0x72, 0x0a, 0x6b, 0xf2, 0x08, 0xc5, 0x25, 0xcb,
-	0x2f, 0x69, 0x40, 0x31, 0x43, 0x2d, 0xa9, 0x33, 0xd1, 0x9f, 0xb0, 0xea, 0xb5, 0x6f, 0x1c, 0xf5,
-	0x79, 0x46, 0xd4, 0x76, 0x53, 0xcb, 0x4a, 0x00, 0x7d, 0x80, 0x55, 0x91, 0x5c, 0x6c, 0x3a, 0xe5,
-	0xc8, 0x64, 0x45, 0xdd, 0xca, 0x89, 0xd2, 0xc8, 0x53, 0xf1, 0xff, 0x96, 0xe6, 0x12, 0xd6, 0x12,
-	0x35, 0x16, 0xda, 0xb9, 0x8f, 0x24, 0x4b, 0x8e, 0x71, 0x06, 0x8b, 0x72, 0x57, 0xc7, 0x82, 0xeb,
-	0x49, 0x1a, 0x7a, 0x60, 0xa2, 0x4d, 0x37, 0x41, 0x07, 0x50, 0xf2, 0xf6, 0x55, 0x51, 0x4e, 0x2c,
-	0xf1, 0xe3, 0x34, 0x18, 0xe5, 0xd4, 0x07, 0x18, 0x8b, 0xa1, 0xf4, 0x8e, 0x98, 0x50, 0x58, 0xda,
-	0x8b, 0x2c, 0xa6, 0xaa, 0xaf, 0xfb, 0x00, 0x63, 0xa9, 0x97, 0x1e, 0x64, 0x42, 0x23, 0xa6, 0x07,
-	0x49, 0x50, 0x8e, 0x14, 0xca, 0x61, 0x6d, 0x94, 0xfe, 0x04, 0x24, 0xc8, 0x36, 0xed, 0x65, 0x36,
-	0x63, 0x15, 0xea, 0x0a, 0x4a, 0x21, 0x4d, 0x93, 0x7e, 0x8f, 0x4f, 0x2a, 0x2b, 0x6d, 0x33, 0x93,
-	0xad, 0x8a, 0x33, 0x82, 0xa5, 0xb8, 0xe4, 0x40, 0xf5, 0x34, 0x80, 0x14, 0x51, 0xa3, 0x6d, 0x65,
-	0x77, 0x90, 0x61, 0x0f, 0x76, 0xcf, 0x77, 0x06, 0x94, 0x5f, 0x8f, 0x2e, 0x45, 0x2b, 0xd5, 0xa5,
-	0x00, 0xaa, 0xcb, 0x5f, 0xf0, 0xde, 0x6f, 0xf6, 0x7a, 0xf2, 0x3f, 0x04, 0x2e, 0xe7, 0xbd, 0xd5,
-	0x57, 0xff, 0x05, 0x00, 0x00, 0xff, 0xff, 0x48, 0xa1, 0xd2, 0x13, 0x31, 0x10, 0x00, 0x00,
+	// 2034 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xfd, 0x72, 0xdb, 0xc6,
+	0x11, 0x2f, 0x45, 0x7d, 0x90, 0x4b, 0x8a, 0xa2, 0x4f, 0x92, 0x4d, 0xc3, 0x89, 0xab, 0xc0, 0x4d,
+	0xad, 0x58, 0x09, 0xa5, 0x28, 0xb6, 0x5b, 0x37, 0xed, 0x64, 0x48, 0x89, 0x4a, 0x15, 0x47, 0xaa,
+	0x0a, 0x4a, 0xb6, 0xc7, 0x9e, 0x0e, 0x06, 0x20, 0x4e, 0x14, 0x6a, 0x0a, 0x40, 0x70, 0x47, 0x45,
+	0x48, 0x67, 0xfa, 0x14, 0x9d, 0xe9, 0x9f, 0x7d, 0x84, 0x3e, 0x48, 0xfb, 0x0a, 0x9d, 0xe9, 0xa3,
+	0x74, 0xee, 0x03, 0x20, 0x40, 0x02, 0x24, 0xa4, 0x71, 0xff, 0x12, 0x6f, 0x6f, 0xef, 0xb7, 0x1f,
+	0xd8, 0xdd, 0xbb, 0x5d, 0xc1, 0x67, 0xc4, 0xb3, 0x7d, 0xbc, 0x6d, 0x78, 0xf6, 0xb6, 0x8f, 0xfb,
+	0x36, 0xa1, 0xbe, 0x41, 0x6d, 0xd7, 0x49, 0x2c, 0x9a, 0x9e, 0xef, 0x52, 0x17, 0xdd, 0xe5, 0xac,
+	0x4d, 0xc3, 0xb3, 0x9b, 0xf1, 0x5d, 0xe5, 0x61, 0xdf, 0x75, 0xfb, 0x03, 0xbc, 0xcd, 0xb9, 0xcc,
+	0xe1, 0xf9, 0xf6, 0x8f, 0xbe, 0xe1, 0x79, 0xd8, 0x27, 0xe2, 0x9c, 0x72, 0x5f, 0x88, 0xe8, 0xb9,
+	0x97, 0x97, 0xae, 0x23, 0xff, 0x88, 0x2d, 0xf5, 0x18, 0x56, 0xb5, 0x18, 0x54, 0xc7, 0xa1, 0x7e,
+	0x70, 0xb8, 0x8f, 0x6a, 0x30, 0x67, 0x5b, 0x8d, 0xc2, 0x46, 0x61, 0xb3, 0xac, 0xcd, 0xd9, 0x16,
+	0x7a, 0x0c, 0x2b, 0x3e, 0xbe, 0xb2, 0x89, 0xed, 0x3a, 0xba, 0x33, 0xbc, 0x34, 0xb1, 0xdf, 0x98,
+	0xdb, 0x28, 0x6c, 0x16, 0xb5, 0x5a, 0x48, 0x3e, 0xe6, 0x54, 0x55, 0x81, 0xd2, 0x89, 0xe1, 0x63,
+	0x87, 0x4e, 0x82, 0xb0, 0xbd, 0xae, 0x67, 0x9f, 0x9f, 0xe3, 0x94, 0xbd, 0x00, 0x1e, 0xee, 0xf9,
+	0xd8, 0xa0, 0x58, 0x68, 0x70, 0x7e, 0xec, 0xd2, 0xce, 0xb5, 0x4d, 0x28, 0xd1, 0x30, 0xf1, 0x5c,
+	0x87, 0x60, 0xf4, 0x0c, 0x16, 0x30, 0xdb, 0xe3, 0x87, 0x2a, 0xbb, 0x3f, 0x6f, 0x0a, 0x67, 0x48,
+	0x6b, 0x26, 0x8c, 0xd0, 0x04, 0x37, 0xda, 0x80, 0x8a, 0xe7, 0x63, 0xcc, 0xb0, 0x6c, 0xa7, 0xcf,
+	0xb5, 0x2e, 0x69, 0x71, 0x92, 0xfa, 0x12, 0xd0, 0x99, 0x67, 0x85, 0xa2, 0x35, 0xfc, 0xc3, 0x10,
+	0x13, 0x7a, 0x4b, 0x71, 0xea, 0x37, 0x00, 0x27, 0x46, 0xdf, 0x76, 0xf8, 0x0e, 0x5a, 0x83, 0x05,
+	0xea, 0xbe, 0xc7, 0x8e, 0x34, 0x54, 0x2c, 0xd0, 0x03, 0x28, 0x7b, 0x46, 0x1f, 0xeb, 0xc4, 0xfe,
+	0x09, 0x73, 0x85, 0x16, 0xb4, 0x12, 0x23, 0x74, 0xed, 0x9f, 0xb0, 0xfa, 0x0e, 0xd6, 0xbf, 0xb7,
+	0x09, 0x6d, 0x0d, 0x06, 0x0c, 0xd7, 0xc6, 0x24, 0x54, 0xa8, 0x0d, 0xe0, 0x45, 0xc8, 0x52, 0x2b,
+	0xb5, 0x99, 0x1e, 0x11, 0xcd, 0x91, 0x0e, 0x5a, 0xec, 0x94, 0xfa, 0xf7, 0x02, 0xdc, 0x1d, 0x47,
+	0x97, 0xee, 0x7d, 0x01, 0x4b, 0x58, 0x90, 0x1a, 0x85, 0x8d, 0x62, 0x1e, 0x8b, 0x43, 0xfe, 0x31,
+	0xcd, 0xe6, 0x6e, 0xa5, 0xd9, 0x3e, 0xdc, 0xff, 0x16, 0x53, 0x0e, 0xdc, 0xa5, 0x06, 0x65, 0x9f,
+	0xa6, 0x17, 0x99, 0xfe, 0x18, 0x56, 0xf0, 0xb5, 0x67, 0xfb, 0xb6, 0xd3, 0xd7, 0x7f, 0xb4, 0xe9,
+	0x85, 0x2d, 0xec, 0x2f, 0x6a, 0xb5, 0x90, 0xfc, 0x9a, 0x53, 0xd5, 0x7f, 0x15, 0x41, 0x49, 0x83,
+	0x91, 0x36, 0xf2, 0xcf, 0x41, 0x8d, 0x01, 0x3f, 0xbd, 0xa0, 0x89, 0x05, 0xb2, 0xa0, 0x6a, 0x06,
+	0xba, 0xc7, 0xa3, 0x56, 0xb7, 0xad, 0xc6, 0x1c, 0x37, 0xbf, 0x9d, 0x65, 0x40, 0x36, 0x7e, 0xb3,
+	0x1d, 0xc8, 0xd8, 0xb7, 0x84, 0x87, 0xc0, 0x8c, 0x08, 0xc8, 0x83, 0xba, 0x19, 0xe8, 0x04, 0x0f,
+	0x70, 0x8f, 0xba, 0xbe, 0x4e, 0x03, 0x0f, 0x37, 0x8a, 0x5c, 0xd2, 0xc1, 0xad, 0x24, 0x75, 0x25,
+	0xd2, 0x69, 0xe0, 0xc9, 0xc0, 0xad, 0x99, 0x09, 0x22, 0x7a, 0x04, 0xcb, 0x91, 0xd7, 0x88, 0xeb,
+	0x3a, 0x8d, 0x79, 0x6e, 0x75, 0x35, 0x24, 0x76, 0x5d, 0xd7, 0x41, 0x0d, 0x58, 0xe2, 0x6b, 0x6c,
+	0x35, 0x16, 0xf8, 0x76, 0xb8, 0x54, 0x7e, 0x07, 0x2b, 0x63, 0xf6, 0xa0, 0x3a, 0x14, 0xdf, 0xe3,
+	0x40, 0x06, 0x33, 0xfb, 0xc9, 0x3c, 0x7a, 0x65, 0x0c, 0x86, 0x61, 0x18, 0x8b, 0xc5, 0x6f, 0xe6,
+	0x7e, 0x5d, 0x50, 0x5a, 0xb0, 0x9a, 0xa2, 0xe4, 0x4d, 0x20, 0xd4, 0x6f, 0x60, 0xe5, 0x00, 0x5b,
+	0xd8, 0x37, 0x28, 0xb6, 0xda, 0x43, 0xc7, 0x1a, 0x60, 0xf4, 0x39, 0x2c, 0x9a, 0xfc, 0x57, 0xa3,
+	0xc8, 0xc3, 0x6c, 0x2d, 0x19, 0xa4, 0x82, 0x4b, 0x93, 0x3c, 0xea, 0x23, 0xb8, 0x33, 0x06, 0x90,
+	0x52, 0x79, 0xfe, 0x59, 0x80, 0x8f, 0xf6, 0xf1, 0x00, 0x53, 0x3c, 0xc6, 0x1b, 0x46, 0xdf, 0x78,
+	0x2d, 0x3c, 0x82, 0xf9, 0x4b, 0xd7, 0x12, 0xfa, 0xd6, 0x76, 0x5f, 0x64, 0x7d, 0xbd, 0x69, 0x98,
+	0xcd, 0x23, 0xd7, 0xc2, 0x1a, 0x87, 0x51, 0x77, 0x60, 0x9e, 0xad, 0x50, 0x15, 0x4a, 0x5a, 0xa7,
+	0x7b, 0xaa, 0x1d, 0xee, 0x9d, 0xd6, 0x7f, 0x86, 0x00, 0x16, 0xf7, 0x3b, 0xdf, 0x77, 0x4e, 0x3b,
+	0xf5, 0x02, 0xaa, 0x01, 0xec, 0x1f, 0x76, 0xbb, 0x7f, 0xd8, 0x3b, 0x6c, 0x9d, 0x76, 0xea, 0x73,
+	0xea, 0x31, 0x94, 0xbf, 0x73, 0x6d, 0xe7, 0x94, 0x17, 0x93, 0xf4, 0x12, 0x53, 0x87, 0x22, 0xa5,
+	0x03, 0xe9, 0x52, 0xf6, 0x13, 0xdd, 0x87, 0xd2, 0xa5, 0x71, 0xad, 0x0f, 0x09, 0x26, 0xdc, 0x77,
+	0x0b, 0xda, 0xd2, 0xa5, 0x71, 0x7d, 0x46, 0x30, 0x51, 0x9f, 0xc3, 0xe2, 0x84, 0x7b, 0xe7, 0x72,
+	0xb8, 0xf7, 0x3f, 0x45, 0xa8, 0xb4, 0xfa, 0xd8, 0xa1, 0x07, 0xf6, 0x80, 0x62, 0x1f, 0x6d, 0xf0,
+	0x44, 0x22, 0xbc, 0xc4, 0xeb, 0x91, 0xcb, 0xc0, 0x0c, 0x64, 0xd5, 0xb7, 0xd0, 0x2f, 0xa0, 0xc6,
+	0x53, 0x8d, 0x5e, 0xe8, 0x9e, 0x8f, 0xcf, 0xed, 0x6b, 0x2e, 0xa7, 0xac, 0x55, 0x59, 0xa2, 0xd0,
+	0x8b, 0x13, 0x4e, 0x43, 0x4d, 0x58, 0x35, 0x03, 0xdd, 0xa0, 0x14, 0x13, 0xca, 0x7d, 0x19, 0x66,
+	0x0b, 0x63, 0xbd, 0x63, 0x06, 0xad, 0xd1, 0x0e, 0x0f, 0xf4, 0x17, 0x42, 0xae, 0x8c, 0x35, 0xd2,
+	0x98, 0xe7, 0x69, 0x75, 0x37, 0xa9, 0x7b, 0x18, 0x8a, 0x5a, 0x65, 0x94, 0x26, 0x04, 0xbd, 0x81,
+	0x5a, 0x94, 0x92, 0x97, 0x06, 0xed, 0x5d, 0xf0, 0x2c, 0xa8, 0xed, 0x7e, 0x99, 0xf5, 0x55, 0x63,
+	0xf6, 0x36, 0x8f, 0xd8, 0x81, 0x36, 0xbe, 0x30, 0xae, 0x6c, 0xd7, 0xd7, 0x96, 0x43, 0x20, 0x4e,
+	0x46, 0xbf, 0x82, 0xb2, 0x19, 0xe8, 0xa6, 0xe1, 0x38, 0xd8, 0x6a, 0x2c, 0x72, 0x6f, 0x2a, 0x4d,
+	0x71, 0x4f, 0x37, 0xc3, 0x7b, 0xba, 0xd9, 0x76, 0xdd, 0xc1, 0x2b, 0x16, 0xef, 0x5a, 0xc9, 0x0c,
+	0xda, 0x9c, 0x17, 0x6d, 0xf2, 0x42, 0x21, 0xb2, 0x90, 0xe8, 0xc6, 0x39, 0xc5, 0x7e, 0x63, 0x49,
+	0x54, 0x3b, 0x33, 0xe8, 0x08, 0x72, 0x8b, 0x51, 0xd1, 0x13, 0xb8, 0x13, 0xe3, 0x34, 0xf1, 0xb9,
+	0xeb, 0xe3, 0x46, 0x89, 0xb3, 0xae, 0x44, 0xac, 0x6d, 0x4e, 0x56, 0x77, 0x61, 0x39, 0xa1, 0x2e,
+	0x5a, 0x81, 0xca, 0x51, 0xeb, 0x74, 0xef, 0xf7, 0x7a, 0xe7, 0x4d, 0x8b, 0x47, 0x5c, 0x1d, 0xaa,
+	0x82, 0xd0, 0x3d, 0x6b, 0x77, 0x3b, 0xa7, 0xf5, 0x82, 0x7a, 0x02, 0x77, 0xf8, 0x65, 0xc1, 0x4c,
+	0x8e, 0x6a, 0xf1, 0xd7, 0xb0, 0x78, 0xce, 0xcd, 0x97, 0x57, 0xd0, 0xa3, 0x1c, 0x9e, 0xd2, 0xe4,
+	0x11, 0xf5, 0x00, 0x50, 0x1c, 0x51, 0x96, 0xe5, 0x1d, 0x58, 0x70, 0x5c, 0x2b, 0xba, 0x78, 0x94,
+	0xe4, 0x87, 0x13, 0x5f, 0x1b, 0x5b, 0xc7, 0x2c, 0x65, 0x04, 0xa3, 0xfa, 0x47, 0x40, 0x7b, 0xee,
+	0xd0, 0xf9, 0x90, 0xaa, 0x6d, 0xc1, 0x6a, 0x02, 0x72, 0x74, 0x65, 0xf4, 0x18, 0x39, 0xbc, 0x32,
+	0xf8, 0x42, 0x7d, 0x06, 0x1f, 0x73, 0xe6, 0xb1, 0xec, 0x9e, 0x75, 0x6c, 0x1b, 0xee, 0x74, 0xae,
+	0xec, 0x9e, 0x90, 0x11, 0x6a, 0xad, 0x40, 0x89, 0xc8, 0x57, 0x91, 0xcc, 0x98, 0x68, 0xad, 0xee,
+	0x03, 0x8a, 0x1f, 0x90, 0xe0, 0x4d, 0x98, 0x67, 0x6e, 0x90, 0x56, 0x4e, 0x73, 0x17, 0xe7, 0x53,
+	0xbf, 0x80, 0x95, 0xb6, 0xe1, 0xe4, 0x16, 0xda, 0x86, 0xfa, 0x88, 0xfd, 0x96, 0x22, 0xbf, 0x06,
+	0x74, 0xe2, 0x0f, 0x1d, 0x9c, 0xfc, 0x40, 0x9f, 0x42, 0x6d, 0x2c, 0x5a, 0xc5, 0x35, 0xbe, 0x8c,
+	0x13, 0xb1, 0xba, 0x0e, 0xab, 0x89, 0xc3, 0x42, 0x07, 0xf5, 0x25, 0xdc, 0x3f, 0x70, 0xfd, 0x9e,
+	0x20, 0x6b, 0xae, 0x28, 0x00, 0x21, 0xf4, 0x47, 0x50, 0x0e, 0x0d, 0x10, 0x71, 0x54, 0xd6, 0x46,
+	0x04, 0x56, 0x0e, 0x8d, 0xc1, 0x40, 0x3e, 0xfe, 0xd8, 0x4f, 0xf5, 0x18, 0x94, 0x34, 0xb0, 0x5b,
+	0x47, 0x24, 0x81, 0xd5, 0x23, 0xdb, 0xa1, 0x6f, 0x9e, 0xed, 0xbc, 0xe8, 0xbe, 0x3a, 0xdc, 0x0f,
+	0xd5, 0x7a, 0x00, 0xe5, 0xf1, 0x7a, 0x18, 0x3a, 0xda, 0x62, 0x5a, 0xf5, 0x88, 0x78, 0x48, 0x57,
+	0x35, 0xf6, 0x33, 0x2c, 0xdb, 0xc5, 0x51, 0xd9, 0x7e, 0x00, 0x65, 0xcb, 0x21, 0xba, 0x63, 0x5c,
+	0x62, 0x51, 0xd8, 0xca, 0x5a, 0xc9, 0x72, 0xc8, 0x31, 0x5b, 0xab, 0x27, 0xb0, 0x96, 0x14, 0x2a,
+	0xd5, 0xff, 0x18, 0x80, 0x5c, 0xd9, 0x96, 0xde, 0xbb, 0x30, 0xf8, 0x53, 0xa9, 0xb8, 0x59, 0xd5,
+	0xca, 0x8c, 0xb2, 0xc7, 0x08, 0xec, 0x2a, 0xf0, 0x5d, 0x97, 0xea, 0x3d, 0x83, 0xf0, 0xc7, 0x4e,
+	0x55, 0x5b, 0x62, 0xeb, 0x3d, 0x83, 0xa8, 0x3a, 0x20, 0x86, 0xf8, 0xdd, 0xeb, 0xd3, 0x9b, 0x58,
+	0x31, 0x76, 0xd5, 0x28, 0x50, 0x32, 0x86, 0x96, 0x8d, 0x9d, 0x9e, 0x78, 0xe2, 0x94, 0xb5, 0x68,
+	0xcd, 0xd2, 0x2c, 0x21, 0x20, 0xfe, 0x32, 0x1b, 0xbf, 0xc5, 0x54, 0x13, 0x96, 0x99, 0x8f, 0x47,
+	0xe5, 0x7a, 0xaa, 0x22, 0x4f, 0xa1, 0x3c, 0xba, 0x03, 0xe6, 0xa6, 0xde, 0x01, 0x23, 0x46, 0xf5,
+	0x39, 0xdc, 0xfb, 0x16, 0xd3, 0x84, 0x98, 0x3c, 0x66, 0xab, 0x3a, 0x34, 0x26, 0xcf, 0x49, 0x6b,
+	0xf6, 0xe2, 0x9a, 0x88, 0x94, 0xf9, 0x34, 0xab, 0x16, 0x25, 0x11, 0x62, 0x8a, 0xfd, 0xb7, 0x00,
+	0xb5, 0x43, 0x42, 0x86, 0xd8, 0x0a, 0xbf, 0xef, 0x74, 0xf3, 0x1f, 0xc1, 0x32, 0xc1, 0xbe, 0x6d,
+	0x0c, 0xe2, 0x0d, 0x5a, 0x59, 0xab, 0x0a, 0xa2, 0x68, 0xcf, 0x18, 0x82, 0xcd, 0x31, 0x75, 0x83,
+	0xf2, 0x30, 0x2b, 0x6a, 0x25, 0x41, 0x68, 0x51, 0x16, 0x36, 0xd1, 0xb5, 0x43, 0xf9, 0x6b, 0xb1,
+	0xa8, 0x95, 0x25, 0xa5, 0xc5, 0x6a, 0x46, 0xb9, 0x67, 0xe8, 0xef, 0x71, 0xc0, 0xa4, 0x2f, 0x70,
+	0xf0, 0xa5, 0x9e, 0xf1, 0x12, 0x07, 0x87, 0x16, 0x0b, 0x29, 0xa3, 0x2f, 0xdf, 0xcf, 0x8b, 0x62,
+	0x8b, 0xaf, 0xc5, 0x16, 0x6f, 0x8d, 0xd8, 0xd6, 0x92, 0xd8, 0xe2, 0xeb, 0x43, 0x4b, 0xfd, 0x77,
+	0x01, 0x14, 0x76, 0x1f, 0x24, 0xcd, 0x8c, 0xfc, 0x3f, 0xfb, 0x3d, 0xf1, 0x10, 0x2a, 0xec, 0xa5,
+	0xd0, 0x8f, 0x5e, 0xee, 0x8c, 0xa1, 0x6c, 0x06, 0x2d, 0x29, 0x3b, 0xed, 0x2e, 0x2d, 0xa6, 0xde,
+	0xa5, 0xc9, 0x1e, 0x66, 0xfe, 0x56, 0x3d, 0xcc, 0x3f, 0x0a, 0xf0, 0x20, 0xd5, 0x1c, 0x19, 0x16,
+	0xbf, 0x85, 0x05, 0x96, 0x84, 0x61, 0x55, 0xf9, 0x65, 0x16, 0x7c, 0xf2, 0xbc, 0x26, 0x0e, 0x7d,
+	0x88, 0x2e, 0x6b, 0xf7, 0x6f, 0xf7, 0xa0, 0x1a, 0x6f, 0xe4, 0xd0, 0x3b, 0xa8, 0xc4, 0xda, 0x6e,
+	0x34, 0xab, 0xe7, 0x53, 0xb6, 0xb2, 0x04, 0xa6, 0x0d, 0x11, 0x7e, 0x80, 0xbb, 0xe9, 0x3d, 0xfd,
+	0x6c, 0x39, 0xcf, 0xb3, 0xe4, 0xcc, 0x18, 0x12, 0xbc, 0x83, 0x8a, 0x78, 0x77, 0x0b, 0x7b, 0x6e,
+	0xa2, 0xae, 0x32, 0x4b, 0x29, 0xf4, 0x16, 0xe0, 0x00, 0xd3, 0xde, 0xc5, 0xff, 0x03, 0xfb, 0x00,
+	0xaa, 0x11, 0xb6, 0x8d, 0x09, 0x5a, 0x4d, 0x1e, 0xe8, 0x5c, 0x7a, 0x34, 0x50, 0x3e, 0x99, 0x8e,
+	0xc2, 0xce, 0xbd, 0x85, 0x4a, 0x6c, 0x98, 0x81, 0x9e, 0x64, 0x29, 0x39, 0x39, 0xf1, 0x98, 0xad,
+	0xe3, 0x19, 0xd4, 0x58, 0x78, 0x47, 0x5d, 0xe1, 0x3e, 0xda, 0xc8, 0x8e, 0x3f, 0xc1, 0x91, 0x47,
+	0xe5, 0x97, 0x21, 0x6c, 0x58, 0x06, 0x51, 0x46, 0xd9, 0xce, 0x03, 0x76, 0x04, 0x2b, 0x49, 0x30,
+	0x82, 0xee, 0xa5, 0xa3, 0x91, 0x3c, 0x70, 0x91, 0xc9, 0xd1, 0xe0, 0x2a, 0xd3, 0xe4, 0x90, 0x23,
+	0x0f, 0xec, 0x35, 0xdc, 0x4b, 0x8e, 0x61, 0xd8, 0xfc, 0xe2, 0xc4, 0xe8, 0x63, 0x82, 0xbe, 0xc8,
+	0xc2, 0x4f, 0x9d, 0x0a, 0x29, 0xcd, 0xbc, 0xec, 0x32, 0x41, 0xfe, 0x02, 0x68, 0x72, 0xac, 0x80,
+	0xbe, 0xbc, 0xc9, 0x08, 0x42, 0x08, 0xde, 0xbd, 0xf9, 0xd4, 0x02, 0x9d, 0xc1, 0xba, 0xc8, 0xdf,
+	0xf1, 0xb6, 0xfe, 0x71, 0x16, 0xd8, 0x18, 0xa3, 0x92, 0x96, 0x16, 0xe8, 0xcf, 0xb0, 0xc6, 0x73,
+	0x67, 0x1c, 0xf5, 0xb3, 0x9c, 0xa8, 0x87, 0xfb, 0x4a, 0x5e, 0x05, 0xd0, 0x2b, 0x58, 0x63, 0x9e,
+	0x1d, 0x23, 0x67, 0xe4, 0x6b, 0x5e, 0xd4, 0x9d, 0x02, 0xea, 0xc1, 0x7a, 0x6a, 0x47, 0x91, 0x0e,
+	0xfc, 0x2c, 0xb3, 0x3c, 0x4e, 0xed, 0x4a, 0xce, 0x60, 0x5d, 0xe4, 0xfd, 0x87, 0xf5, 0xbf, 0x09,
+	0xeb, 0xa9, 0xc3, 0x0e, 0xf4, 0xf4, 0x36, 0xb3, 0x91, 0x74, 0x19, 0xaf, 0x61, 0x45, 0x84, 0xce,
+	0x68, 0xf2, 0xf1, 0x49, 0x16, 0x7a, 0xc4, 0xa2, 0xcc, 0x66, 0x41, 0x6d, 0xa8, 0xf0, 0xe0, 0x91,
+	0x2a, 0xa7, 0xba, 0xfb, 0x61, 0x16, 0x8c, 0x3c, 0xd4, 0x03, 0x18, 0xb5, 0x69, 0xd9, 0x61, 0x37,
+	0xd1, 0xfb, 0x29, 0x4f, 0xf2, 0xb0, 0xca, 0x8f, 0xf7, 0x27, 0x28, 0x85, 0x6d, 0x59, 0xf6, 0xf7,
+	0x1a, 0xeb, 0xf3, 0x94, 0xcd, 0xd9, 0x8c, 0x12, 0xfe, 0x1c, 0x2a, 0xb1, 0xa6, 0x2b, 0xfb, 0xe2,
+	0x98, 0x6c, 0xeb, 0x94, 0xad, 0x5c, 0xbc, 0xa3, 0x02, 0x34, 0xd9, 0x78, 0x65, 0x17, 0xa0, 0xcc,
+	0x8e, 0x4f, 0xd9, 0xbd, 0xc9, 0x11, 0x29, 0xbc, 0x07, 0x30, 0x9a, 0x3f, 0x64, 0x7f, 0xa8, 0x89,
+	0xa9, 0x87, 0xf2, 0x24, 0x0f, 0xeb, 0xc8, 0x93, 0xb1, 0x49, 0x42, 0xb6, 0x27, 0x27, 0x27, 0x18,
+	0xca, 0x56, 0x2e, 0x5e, 0x29, 0xc7, 0x86, 0x6a, 0xbc, 0xfb, 0xcb, 0x7e, 0x90, 0xa4, 0x34, 0xa6,
+	0xca, 0xe7, 0xf9, 0x98, 0x47, 0x26, 0xc5, 0xba, 0xb6, 0x6c, 0x93, 0x26, 0x7b, 0x47, 0x65, 0x2b,
+	0x17, 0xaf, 0x94, 0xf3, 0x57, 0x58, 0x4d, 0x79, 0x40, 0xa3, 0xdd, 0x69, 0xde, 0x4f, 0x6f, 0x1e,
+	0x94, 0xaf, 0x6e, 0x74, 0x46, 0xca, 0x1f, 0x42, 0x7d, 0xbc, 0xa9, 0x43, 0xdb, 0x53, 0x2e, 0xba,
+	0xb4, 0xb6, 0x51, 0xd9, 0xc9, 0x7f, 0x40, 0x88, 0x6d, 0x3f, 0x7f, 0xfb, 0xb4, 0x6f, 0xd3, 0x8b,
+	0xa1, 0xc9, 0xca, 0xce, 0xb6, 0x68, 0x78, 0xb6, 0xc5, 0xff, 0xec, 0xf8, 0xa8, 0x70, 0x3b, 0xfd,
+	0x5f, 0x84, 0xe6, 0x22, 0xdf, 0xfd, 0xea, 0x7f, 0x03, 0x00, 0x7d, 0x35, 0xc1, 0xb5, 0x43, 0x1c,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1278,12 +2220,17 @@ type RegistrationClient interface {
 	ListBySpiffeID(ctx context.Context, in *SpiffeID, opts ...grpc.CallOption) (*common.RegistrationEntries, error)
 	// Return all registration entries with pagination of default page size of 50.
 	ListAllEntriesWithPages(ctx context.Context, in *ListAllEntriesRequest, opts ...grpc.CallOption) (*ListAllEntriesResponse, error)
+	// Returns statistics about the registration entries, e.g. the number of
+	// entries per parent ID.
+	GetEntryStatistics(ctx context.Context, in *GetEntryStatisticsRequest, opts ...grpc.CallOption) (*GetEntryStatisticsResponse, error)
 	// Creates an entry in the Federated bundle table to store the mappings of Federated SPIFFE IDs and their associated CA bundle.
 	CreateFederatedBundle(ctx context.Context, in *FederatedBundle, opts ...grpc.CallOption) (*common.Empty, error)
 	// Retrieves a single federated bundle
 	FetchFederatedBundle(ctx context.Context, in *FederatedBundleID, opts ...grpc.CallOption) (*FederatedBundle, error)
 	// Retrieves Federated bundles for all the Federated SPIFFE IDs.
 	ListFederatedBundles(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (Registration_ListFederatedBundlesClient, error)
+	// Counts the Federated bundles for all the Federated SPIFFE IDs.
+	CountFederatedBundles(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*CountFederatedBundlesResponse, error)
 	// Updates a particular Federated Bundle. Useful for rotation.
 	UpdateFederatedBundle(ctx context.Context, in *FederatedBundle, opts ...grpc.CallOption) (*common.Empty, error)
 	// Delete a particular Federated Bundle. Used to destroy inter-domain trust.
@@ -1294,12 +2241,27 @@ type RegistrationClient interface {
 	FetchBundle(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*Bundle, error)
 	// EvictAgent removes an attestation entry from the attested nodes store
 	EvictAgent(ctx context.Context, in *EvictAgentRequest, opts ...grpc.CallOption) (*EvictAgentResponse, error)
-	// ListAgents will list all attested nodes
+	// BanAgent prevents an agent from renewing its SVID and from attesting
+	// again until it is evicted
+	BanAgent(ctx context.Context, in *BanAgentRequest, opts ...grpc.CallOption) (*BanAgentResponse, error)
+	// PruneAgents removes the attestation entries of agents whose SVID
+	// expired before the given time from the attested nodes store
+	PruneAgents(ctx context.Context, in *PruneAgentsRequest, opts ...grpc.CallOption) (*PruneAgentsResponse, error)
+	// ForceAgentRotation makes agents rotate their SVID at their next
+	// synchronization, regardless of the lifetime of the SVID
+	ForceAgentRotation(ctx context.Context, in *ForceAgentRotationRequest, opts ...grpc.CallOption) (*ForceAgentRotationResponse, error)
+	// ListAgents will list the attested nodes matching the filter
 	ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+	// CountAgents will count the attested nodes matching the filter
+	CountAgents(ctx context.Context, in *CountAgentsRequest, opts ...grpc.CallOption) (*CountAgentsResponse, error)
 	// MintX509SVID mints an X509-SVID directly with the SPIRE server CA.
 	MintX509SVID(ctx context.Context, in *MintX509SVIDRequest, opts ...grpc.CallOption) (*MintX509SVIDResponse, error)
 	// MintJWTSVID mints a JWT-SVID directly with the SPIRE server CA.
 	MintJWTSVID(ctx context.Context, in *MintJWTSVIDRequest, opts ...grpc.CallOption) (*MintJWTSVIDResponse, error)
+	// ListIssuedX509SVIDs lists the records of the X509-SVIDs issued by the
+	// server, with pagination of default page size of 50. Issued X509-SVIDs
+	// are only recorded when tracking is enabled on the server.
+	ListIssuedX509SVIDs(ctx context.Context, in *ListIssuedX509SVIDsRequest, opts ...grpc.CallOption) (*ListIssuedX509SVIDsResponse, error)
 	// GetNodeSelectors gets node (agent) selectors
 	GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error)
 }
@@ -1411,6 +2373,15 @@ func (c *registrationClient) ListAllEntriesWithPages(ctx context.Context, in *Li
 	return out, nil
 }
 
+func (c *registrationClient) GetEntryStatistics(ctx context.Context, in *GetEntryStatisticsRequest, opts ...grpc.CallOption) (*GetEntryStatisticsResponse, error) {
+	out := new(GetEntryStatisticsResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/GetEntryStatistics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registrationClient) CreateFederatedBundle(ctx context.Context, in *FederatedBundle, opts ...grpc.CallOption) (*common.Empty, error) {
 	out := new(common.Empty)
 	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/CreateFederatedBundle", in, out, opts...)
@@ -1461,6 +2432,15 @@ func (x *registrationListFederatedBundlesClient) Recv() (*FederatedBundle, error
 	return m, nil
 }
 
+func (c *registrationClient) CountFederatedBundles(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*CountFederatedBundlesResponse, error) {
+	out := new(CountFederatedBundlesResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/CountFederatedBundles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registrationClient) UpdateFederatedBundle(ctx context.Context, in *FederatedBundle, opts ...grpc.CallOption) (*common.Empty, error) {
 	out := new(common.Empty)
 	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/UpdateFederatedBundle", in, out, opts...)
@@ -1506,6 +2486,33 @@ func (c *registrationClient) EvictAgent(ctx context.Context, in *EvictAgentReque
 	return out, nil
 }
 
+func (c *registrationClient) BanAgent(ctx context.Context, in *BanAgentRequest, opts ...grpc.CallOption) (*BanAgentResponse, error) {
+	out := new(BanAgentResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/BanAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) PruneAgents(ctx context.Context, in *PruneAgentsRequest, opts ...grpc.CallOption) (*PruneAgentsResponse, error) {
+	out := new(PruneAgentsResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/PruneAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) ForceAgentRotation(ctx context.Context, in *ForceAgentRotationRequest, opts ...grpc.CallOption) (*ForceAgentRotationResponse, error) {
+	out := new(ForceAgentRotationResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ForceAgentRotation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registrationClient) ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error) {
 	out := new(ListAgentsResponse)
 	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ListAgents", in, out, opts...)
@@ -1515,6 +2522,15 @@ func (c *registrationClient) ListAgents(ctx context.Context, in *ListAgentsReque
 	return out, nil
 }
 
+func (c *registrationClient) CountAgents(ctx context.Context, in *CountAgentsRequest, opts ...grpc.CallOption) (*CountAgentsResponse, error) {
+	out := new(CountAgentsResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/CountAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registrationClient) MintX509SVID(ctx context.Context, in *MintX509SVIDRequest, opts ...grpc.CallOption) (*MintX509SVIDResponse, error) {
 	out := new(MintX509SVIDResponse)
 	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/MintX509SVID", in, out, opts...)
@@ -1533,6 +2549,15 @@ func (c *registrationClient) MintJWTSVID(ctx context.Context, in *MintJWTSVIDReq
 	return out, nil
 }
 
+func (c *registrationClient) ListIssuedX509SVIDs(ctx context.Context, in *ListIssuedX509SVIDsRequest, opts ...grpc.CallOption) (*ListIssuedX509SVIDsResponse, error) {
+	out := new(ListIssuedX509SVIDsResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ListIssuedX509SVIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registrationClient) GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error) {
 	out := new(GetNodeSelectorsResponse)
 	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/GetNodeSelectors", in, out, opts...)
@@ -1566,12 +2591,17 @@ type RegistrationServer interface {
 	ListBySpiffeID(context.Context, *SpiffeID) (*common.RegistrationEntries, error)
 	// Return all registration entries with pagination of default page size of 50.
 	ListAllEntriesWithPages(context.Context, *ListAllEntriesRequest) (*ListAllEntriesResponse, error)
+	// Returns statistics about the registration entries, e.g. the number of
+	// entries per parent ID.
+	GetEntryStatistics(context.Context, *GetEntryStatisticsRequest) (*GetEntryStatisticsResponse, error)
 	// Creates an entry in the Federated bundle table to store the mappings of Federated SPIFFE IDs and their associated CA bundle.
 	CreateFederatedBundle(context.Context, *FederatedBundle) (*common.Empty, error)
 	// Retrieves a single federated bundle
 	FetchFederatedBundle(context.Context, *FederatedBundleID) (*FederatedBundle, error)
 	// Retrieves Federated bundles for all the Federated SPIFFE IDs.
 	ListFederatedBundles(*common.Empty, Registration_ListFederatedBundlesServer) error
+	// Counts the Federated bundles for all the Federated SPIFFE IDs.
+	CountFederatedBundles(context.Context, *common.Empty) (*CountFederatedBundlesResponse, error)
 	// Updates a particular Federated Bundle. Useful for rotation.
 	UpdateFederatedBundle(context.Context, *FederatedBundle) (*common.Empty, error)
 	// Delete a particular Federated Bundle. Used to destroy inter-domain trust.
@@ -1582,12 +2612,27 @@ type RegistrationServer interface {
 	FetchBundle(context.Context, *common.Empty) (*Bundle, error)
 	// EvictAgent removes an attestation entry from the attested nodes store
 	EvictAgent(context.Context, *EvictAgentRequest) (*EvictAgentResponse, error)
-	// ListAgents will list all attested nodes
+	// BanAgent prevents an agent from renewing its SVID and from attesting
+	// again until it is evicted
+	BanAgent(context.Context, *BanAgentRequest) (*BanAgentResponse, error)
+	// PruneAgents removes the attestation entries of agents whose SVID
+	// expired before the given time from the attested nodes store
+	PruneAgents(context.Context, *PruneAgentsRequest) (*PruneAgentsResponse, error)
+	// ForceAgentRotation makes agents rotate their SVID at their next
+	// synchronization, regardless of the lifetime of the SVID
+	ForceAgentRotation(context.Context, *ForceAgentRotationRequest) (*ForceAgentRotationResponse, error)
+	// ListAgents will list the attested nodes matching the filter
 	ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error)
+	// CountAgents will count the attested nodes matching the filter
+	CountAgents(context.Context, *CountAgentsRequest) (*CountAgentsResponse, error)
 	// MintX509SVID mints an X509-SVID directly with the SPIRE server CA.
 	MintX509SVID(context.Context, *MintX509SVIDRequest) (*MintX509SVIDResponse, error)
 	// MintJWTSVID mints a JWT-SVID directly with the SPIRE server CA.
 	MintJWTSVID(context.Context, *MintJWTSVIDRequest) (*MintJWTSVIDResponse, error)
+	// ListIssuedX509SVIDs lists the records of the X509-SVIDs issued by the
+	// server, with pagination of default page size of 50. Issued X509-SVIDs
+	// are only recorded when tracking is enabled on the server.
+	ListIssuedX509SVIDs(context.Context, *ListIssuedX509SVIDsRequest) (*ListIssuedX509SVIDsResponse, error)
 	// GetNodeSelectors gets node (agent) selectors
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
 }
@@ -1629,6 +2674,9 @@ func (*UnimplementedRegistrationServer) ListBySpiffeID(ctx context.Context, req
 func (*UnimplementedRegistrationServer) ListAllEntriesWithPages(ctx context.Context, req *ListAllEntriesRequest) (*ListAllEntriesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAllEntriesWithPages not implemented")
 }
+func (*UnimplementedRegistrationServer) GetEntryStatistics(ctx context.Context, req *GetEntryStatisticsRequest) (*GetEntryStatisticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntryStatistics not implemented")
+}
 func (*UnimplementedRegistrationServer) CreateFederatedBundle(ctx context.Context, req *FederatedBundle) (*common.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateFederatedBundle not implemented")
 }
@@ -1638,6 +2686,9 @@ func (*UnimplementedRegistrationServer) FetchFederatedBundle(ctx context.Context
 func (*UnimplementedRegistrationServer) ListFederatedBundles(req *common.Empty, srv Registration_ListFederatedBundlesServer) error {
 	return status.Errorf(codes.Unimplemented, "method ListFederatedBundles not implemented")
 }
+func (*UnimplementedRegistrationServer) CountFederatedBundles(ctx context.Context, req *common.Empty) (*CountFederatedBundlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountFederatedBundles not implemented")
+}
 func (*UnimplementedRegistrationServer) UpdateFederatedBundle(ctx context.Context, req *FederatedBundle) (*common.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateFederatedBundle not implemented")
 }
@@ -1653,15 +2704,30 @@ func (*UnimplementedRegistrationServer) FetchBundle(ctx context.Context, req *co
 func (*UnimplementedRegistrationServer) EvictAgent(ctx context.Context, req *EvictAgentRequest) (*EvictAgentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EvictAgent not implemented")
 }
+func (*UnimplementedRegistrationServer) BanAgent(ctx context.Context, req *BanAgentRequest) (*BanAgentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanAgent not implemented")
+}
+func (*UnimplementedRegistrationServer) PruneAgents(ctx context.Context, req *PruneAgentsRequest) (*PruneAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneAgents not implemented")
+}
+func (*UnimplementedRegistrationServer) ForceAgentRotation(ctx context.Context, req *ForceAgentRotationRequest) (*ForceAgentRotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceAgentRotation not implemented")
+}
 func (*UnimplementedRegistrationServer) ListAgents(ctx context.Context, req *ListAgentsRequest) (*ListAgentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAgents not implemented")
 }
+func (*UnimplementedRegistrationServer) CountAgents(ctx context.Context, req *CountAgentsRequest) (*CountAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountAgents not implemented")
+}
 func (*UnimplementedRegistrationServer) MintX509SVID(ctx context.Context, req *MintX509SVIDRequest) (*MintX509SVIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MintX509SVID not implemented")
 }
 func (*UnimplementedRegistrationServer) MintJWTSVID(ctx context.Context, req *MintJWTSVIDRequest) (*MintJWTSVIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MintJWTSVID not implemented")
 }
+func (*UnimplementedRegistrationServer) ListIssuedX509SVIDs(ctx context.Context, req *ListIssuedX509SVIDsRequest) (*ListIssuedX509SVIDsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIssuedX509SVIDs not implemented")
+}
 func (*UnimplementedRegistrationServer) GetNodeSelectors(ctx context.Context, req *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNodeSelectors not implemented")
 }
@@ -1868,6 +2934,24 @@ func _Registration_ListAllEntriesWithPages_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_GetEntryStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntryStatisticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).GetEntryStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/GetEntryStatistics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).GetEntryStatistics(ctx, req.(*GetEntryStatisticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registration_CreateFederatedBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FederatedBundle)
 	if err := dec(in); err != nil {
@@ -1925,6 +3009,24 @@ func (x *registrationListFederatedBundlesServer) Send(m *FederatedBundle) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Registration_CountFederatedBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).CountFederatedBundles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/CountFederatedBundles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).CountFederatedBundles(ctx, req.(*common.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registration_UpdateFederatedBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FederatedBundle)
 	if err := dec(in); err != nil {
@@ -2015,6 +3117,60 @@ func _Registration_EvictAgent_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_BanAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).BanAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/BanAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).BanAgent(ctx, req.(*BanAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_PruneAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).PruneAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/PruneAgents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).PruneAgents(ctx, req.(*PruneAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_ForceAgentRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceAgentRotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ForceAgentRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/ForceAgentRotation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).ForceAgentRotation(ctx, req.(*ForceAgentRotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registration_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListAgentsRequest)
 	if err := dec(in); err != nil {
@@ -2033,6 +3189,24 @@ func _Registration_ListAgents_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_CountAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).CountAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/CountAgents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).CountAgents(ctx, req.(*CountAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registration_MintX509SVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MintX509SVIDRequest)
 	if err := dec(in); err != nil {
@@ -2069,6 +3243,24 @@ func _Registration_MintJWTSVID_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_ListIssuedX509SVIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIssuedX509SVIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ListIssuedX509SVIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/ListIssuedX509SVIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).ListIssuedX509SVIDs(ctx, req.(*ListIssuedX509SVIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registration_GetNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetNodeSelectorsRequest)
 	if err := dec(in); err != nil {
@@ -2135,6 +3327,10 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListAllEntriesWithPages",
 			Handler:    _Registration_ListAllEntriesWithPages_Handler,
 		},
+		{
+			MethodName: "GetEntryStatistics",
+			Handler:    _Registration_GetEntryStatistics_Handler,
+		},
 		{
 			MethodName: "CreateFederatedBundle",
 			Handler:    _Registration_CreateFederatedBundle_Handler,
@@ -2143,6 +3339,10 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FetchFederatedBundle",
 			Handler:    _Registration_FetchFederatedBundle_Handler,
 		},
+		{
+			MethodName: "CountFederatedBundles",
+			Handler:    _Registration_CountFederatedBundles_Handler,
+		},
 		{
 			MethodName: "UpdateFederatedBundle",
 			Handler:    _Registration_UpdateFederatedBundle_Handler,
@@ -2163,10 +3363,26 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 			MethodName: "EvictAgent",
 			Handler:    _Registration_EvictAgent_Handler,
 		},
+		{
+			MethodName: "BanAgent",
+			Handler:    _Registration_BanAgent_Handler,
+		},
+		{
+			MethodName: "PruneAgents",
+			Handler:    _Registration_PruneAgents_Handler,
+		},
+		{
+			MethodName: "ForceAgentRotation",
+			Handler:    _Registration_ForceAgentRotation_Handler,
+		},
 		{
 			MethodName: "ListAgents",
 			Handler:    _Registration_ListAgents_Handler,
 		},
+		{
+			MethodName: "CountAgents",
+			Handler:    _Registration_CountAgents_Handler,
+		},
 		{
 			MethodName: "MintX509SVID",
 			Handler:    _Registration_MintX509SVID_Handler,
@@ -2175,6 +3391,10 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 			MethodName: "MintJWTSVID",
 			Handler:    _Registration_MintJWTSVID_Handler,
 		},
+		{
+			MethodName: "ListIssuedX509SVIDs",
+			Handler:    _Registration_ListIssuedX509SVIDs_Handler,
+		},
 		{
 			MethodName: "GetNodeSelectors",
 			Handler:    _Registration_GetNodeSelectors_Handler,
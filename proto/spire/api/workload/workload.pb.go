@@ -0,0 +1,240 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: spire/api/workload/workload.proto
+
+package workload
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// * Represents a request for the X.509 bundles.
+type X509BundlesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509BundlesRequest) Reset()         { *m = X509BundlesRequest{} }
+func (m *X509BundlesRequest) String() string { return proto.CompactTextString(m) }
+func (*X509BundlesRequest) ProtoMessage()    {}
+func (*X509BundlesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7ad255b090f189e, []int{0}
+}
+
+func (m *X509BundlesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509BundlesRequest.Unmarshal(m, b)
+}
+func (m *X509BundlesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509BundlesRequest.Marshal(b, m, deterministic)
+}
+func (m *X509BundlesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509BundlesRequest.Merge(m, src)
+}
+func (m *X509BundlesRequest) XXX_Size() int {
+	return xxx_messageInfo_X509BundlesRequest.Size(m)
+}
+func (m *X509BundlesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509BundlesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509BundlesRequest proto.InternalMessageInfo
+
+// * Represents the X.509 bundles a workload may use to validate X509-SVIDs.
+type X509BundlesResponse struct {
+	// X.509 bundles keyed by the SPIFFE ID of their trust domain. Each
+	// bundle is a set of ASN.1 DER encoded certificates concatenated with
+	// no intermediate padding.
+	Bundles              map[string][]byte `protobuf:"bytes,1,rep,name=bundles,proto3" json:"bundles,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *X509BundlesResponse) Reset()         { *m = X509BundlesResponse{} }
+func (m *X509BundlesResponse) String() string { return proto.CompactTextString(m) }
+func (*X509BundlesResponse) ProtoMessage()    {}
+func (*X509BundlesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7ad255b090f189e, []int{1}
+}
+
+func (m *X509BundlesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509BundlesResponse.Unmarshal(m, b)
+}
+func (m *X509BundlesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509BundlesResponse.Marshal(b, m, deterministic)
+}
+func (m *X509BundlesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509BundlesResponse.Merge(m, src)
+}
+func (m *X509BundlesResponse) XXX_Size() int {
+	return xxx_messageInfo_X509BundlesResponse.Size(m)
+}
+func (m *X509BundlesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509BundlesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509BundlesResponse proto.InternalMessageInfo
+
+func (m *X509BundlesResponse) GetBundles() map[string][]byte {
+	if m != nil {
+		return m.Bundles
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*X509BundlesRequest)(nil), "spire.api.workload.X509BundlesRequest")
+	proto.RegisterType((*X509BundlesResponse)(nil), "spire.api.workload.X509BundlesResponse")
+	proto.RegisterMapType((map[string][]byte)(nil), "spire.api.workload.X509BundlesResponse.BundlesEntry")
+}
+
+func init() { proto.RegisterFile("spire/api/workload/workload.proto", fileDescriptor_a7ad255b090f189e) }
+
+var fileDescriptor_a7ad255b090f189e = []byte{
+	// 236 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x2c, 0x2e, 0xc8, 0x2c,
+	0x4a, 0xd5, 0x4f, 0x2c, 0xc8, 0xd4, 0x2f, 0xcf, 0x2f, 0xca, 0xce, 0xc9, 0x4f, 0x4c, 0x81, 0x33,
+	0xf4, 0x0a, 0x8a, 0xf2, 0x4b, 0xf2, 0x85, 0x84, 0xc0, 0x4a, 0xf4, 0x12, 0x0b, 0x32, 0xf5, 0x60,
+	0x32, 0x4a, 0x22, 0x5c, 0x42, 0x11, 0xa6, 0x06, 0x96, 0x4e, 0xa5, 0x79, 0x29, 0x39, 0xa9, 0xc5,
+	0x41, 0xa9, 0x85, 0xa5, 0xa9, 0xc5, 0x25, 0x4a, 0x0b, 0x19, 0xb9, 0x84, 0x51, 0x84, 0x8b, 0x0b,
+	0xf2, 0xf3, 0x8a, 0x53, 0x85, 0xfc, 0xb8, 0xd8, 0x93, 0x20, 0x42, 0x12, 0x8c, 0x0a, 0xcc, 0x1a,
+	0xdc, 0x46, 0x26, 0x7a, 0x98, 0x66, 0xea, 0x61, 0xd1, 0xa9, 0x07, 0xe5, 0xbb, 0xe6, 0x95, 0x14,
+	0x55, 0x06, 0xc1, 0x0c, 0x91, 0xb2, 0xe2, 0xe2, 0x41, 0x96, 0x10, 0x12, 0xe0, 0x62, 0xce, 0x4e,
+	0xad, 0x94, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0c, 0x02, 0x31, 0x85, 0x44, 0xb8, 0x58, 0xcb, 0x12,
+	0x73, 0x4a, 0x53, 0x25, 0x98, 0x14, 0x18, 0x35, 0x78, 0x82, 0x20, 0x1c, 0x2b, 0x26, 0x0b, 0x46,
+	0xa3, 0x02, 0x2e, 0x76, 0xa8, 0x5e, 0xa1, 0x54, 0x2e, 0x01, 0xb7, 0xd4, 0x92, 0xe4, 0x0c, 0x24,
+	0x8b, 0x85, 0xd4, 0x08, 0xba, 0x0c, 0xec, 0x55, 0x29, 0x75, 0x22, 0x7d, 0x60, 0xc0, 0xe8, 0x64,
+	0x14, 0x65, 0x90, 0x9e, 0x59, 0x92, 0x51, 0x9a, 0xa4, 0x97, 0x9c, 0x9f, 0xab, 0x5f, 0x5c, 0x90,
+	0x99, 0x96, 0x96, 0xaa, 0x0f, 0x09, 0x76, 0x70, 0x00, 0xeb, 0x63, 0x46, 0x41, 0x12, 0x1b, 0x58,
+	0xc6, 0x18, 0x30, 0x00, 0xa0, 0xc3, 0x82, 0x61, 0x9f, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// BundlesClient is the client API for Bundles service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BundlesClient interface {
+	//* Fetches the X.509 bundles, and streams updates when they change.
+	//Callers are not required to be registered when the agent allows
+	//unauthenticated verifiers.
+	FetchX509Bundles(ctx context.Context, in *X509BundlesRequest, opts ...grpc.CallOption) (Bundles_FetchX509BundlesClient, error)
+}
+
+type bundlesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBundlesClient(cc *grpc.ClientConn) BundlesClient {
+	return &bundlesClient{cc}
+}
+
+func (c *bundlesClient) FetchX509Bundles(ctx context.Context, in *X509BundlesRequest, opts ...grpc.CallOption) (Bundles_FetchX509BundlesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Bundles_serviceDesc.Streams[0], "/spire.api.workload.Bundles/FetchX509Bundles", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bundlesFetchX509BundlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Bundles_FetchX509BundlesClient interface {
+	Recv() (*X509BundlesResponse, error)
+	grpc.ClientStream
+}
+
+type bundlesFetchX509BundlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *bundlesFetchX509BundlesClient) Recv() (*X509BundlesResponse, error) {
+	m := new(X509BundlesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BundlesServer is the server API for Bundles service.
+type BundlesServer interface {
+	//* Fetches the X.509 bundles, and streams updates when they change.
+	//Callers are not required to be registered when the agent allows
+	//unauthenticated verifiers.
+	FetchX509Bundles(*X509BundlesRequest, Bundles_FetchX509BundlesServer) error
+}
+
+// UnimplementedBundlesServer can be embedded to have forward compatible implementations.
+type UnimplementedBundlesServer struct {
+}
+
+func (*UnimplementedBundlesServer) FetchX509Bundles(req *X509BundlesRequest, srv Bundles_FetchX509BundlesServer) error {
+	return status.Errorf(codes.Unimplemented, "method FetchX509Bundles not implemented")
+}
+
+func RegisterBundlesServer(s *grpc.Server, srv BundlesServer) {
+	s.RegisterService(&_Bundles_serviceDesc, srv)
+}
+
+func _Bundles_FetchX509Bundles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(X509BundlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BundlesServer).FetchX509Bundles(m, &bundlesFetchX509BundlesServer{stream})
+}
+
+type Bundles_FetchX509BundlesServer interface {
+	Send(*X509BundlesResponse) error
+	grpc.ServerStream
+}
+
+type bundlesFetchX509BundlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *bundlesFetchX509BundlesServer) Send(m *X509BundlesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Bundles_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.workload.Bundles",
+	HandlerType: (*BundlesServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FetchX509Bundles",
+			Handler:       _Bundles_FetchX509Bundles_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "spire/api/workload/workload.proto",
+}
@@ -210,10 +210,13 @@ type AttestedNode struct {
 	// Node certificate not_after (seconds since unix epoch)
 	NewCertNotAfter int64 `protobuf:"varint,6,opt,name=new_cert_not_after,json=newCertNotAfter,proto3" json:"new_cert_not_after,omitempty"`
 	// Node selectors
-	Selectors            []*Selector `protobuf:"bytes,7,rep,name=selectors,proto3" json:"selectors,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Selectors []*Selector `protobuf:"bytes,7,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// Whether the agent SVID is rotated at the next synchronization of the
+	// agent, regardless of its lifetime
+	ForceRotation        bool     `protobuf:"varint,8,opt,name=force_rotation,json=forceRotation,proto3" json:"force_rotation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *AttestedNode) Reset()         { *m = AttestedNode{} }
@@ -290,6 +293,13 @@ func (m *AttestedNode) GetSelectors() []*Selector {
 	return nil
 }
 
+func (m *AttestedNode) GetForceRotation() bool {
+	if m != nil {
+		return m.ForceRotation
+	}
+	return false
+}
+
 //* This is a curated record that the Server uses to set up and
 //manage the various registered nodes and workloads that are controlled by it.
 type RegistrationEntry struct {
@@ -306,7 +316,9 @@ type RegistrationEntry struct {
 	Ttl int32 `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
 	//* A list of federated trust domain SPIFFE IDs.
 	FederatesWith []string `protobuf:"bytes,5,rep,name=federates_with,json=federatesWith,proto3" json:"federates_with,omitempty"`
-	//* Entry ID
+	//* Entry ID. Generated by the server when the entry is created, unless
+	//supplied by the creator of the entry, e.g. derived from the parent ID,
+	//SPIFFE ID and selectors. Unique among the entries.
 	EntryId string `protobuf:"bytes,6,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
 	//* Whether or not the workload is an admin workload. Admin workloads
 	//can use their SVID's to authenticate with the Registration API, for
@@ -317,7 +329,19 @@ type RegistrationEntry struct {
 	//* Expiration of this entry, in seconds from epoch
 	EntryExpiry int64 `protobuf:"varint,9,opt,name=entryExpiry,proto3" json:"entryExpiry,omitempty"`
 	//* DNS entries
-	DnsNames             []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	DnsNames []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	//* Time to live for JWT-SVIDs. If unset, the server default JWT-SVID
+	//TTL is used.
+	JwtSvidTtl int32 `protobuf:"varint,11,opt,name=jwt_svid_ttl,json=jwtSvidTtl,proto3" json:"jwt_svid_ttl,omitempty"`
+	//* An operator-specified string used by workloads to select the right
+	//SVID when they are issued more than one, e.g. "internal" or
+	//"external".
+	Hint string `protobuf:"bytes,12,opt,name=hint,proto3" json:"hint,omitempty"`
+	//* Revision number of the entry, incremented each time the entry is
+	//updated. Updates and deletions through the Registration API must carry
+	//the revision number of the entry they are based on, and fail when the
+	//entry has been changed since.
+	RevisionNumber       int64    `protobuf:"varint,13,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -418,6 +442,27 @@ func (m *RegistrationEntry) GetDnsNames() []string {
 	return nil
 }
 
+func (m *RegistrationEntry) GetJwtSvidTtl() int32 {
+	if m != nil {
+		return m.JwtSvidTtl
+	}
+	return 0
+}
+
+func (m *RegistrationEntry) GetHint() string {
+	if m != nil {
+		return m.Hint
+	}
+	return ""
+}
+
+func (m *RegistrationEntry) GetRevisionNumber() int64 {
+	if m != nil {
+		return m.RevisionNumber
+	}
+	return 0
+}
+
 //* A list of registration entries.
 type RegistrationEntries struct {
 	//* A list of RegistrationEntry.
@@ -567,7 +612,10 @@ type Bundle struct {
 	JwtSigningKeys []*PublicKey `protobuf:"bytes,3,rep,name=jwt_signing_keys,json=jwtSigningKeys,proto3" json:"jwt_signing_keys,omitempty"`
 	//* refresh hint is a hint, in seconds, on how often a bundle consumer
 	// should poll for bundle updates
-	RefreshHint          int64    `protobuf:"varint,4,opt,name=refresh_hint,json=refreshHint,proto3" json:"refresh_hint,omitempty"`
+	RefreshHint int64 `protobuf:"varint,4,opt,name=refresh_hint,json=refreshHint,proto3" json:"refresh_hint,omitempty"`
+	//* sequence number of the bundle. It is incremented by the server each
+	// time the bundle contents change
+	SequenceNumber       uint64   `protobuf:"varint,5,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -626,10 +674,18 @@ func (m *Bundle) GetRefreshHint() int64 {
 	return 0
 }
 
+func (m *Bundle) GetSequenceNumber() uint64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
+}
+
 type BundleMask struct {
 	RootCas              bool     `protobuf:"varint,1,opt,name=root_cas,json=rootCas,proto3" json:"root_cas,omitempty"`
 	JwtSigningKeys       bool     `protobuf:"varint,2,opt,name=jwt_signing_keys,json=jwtSigningKeys,proto3" json:"jwt_signing_keys,omitempty"`
 	RefreshHint          bool     `protobuf:"varint,3,opt,name=refresh_hint,json=refreshHint,proto3" json:"refresh_hint,omitempty"`
+	SequenceNumber       bool     `protobuf:"varint,4,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -681,6 +737,64 @@ func (m *BundleMask) GetRefreshHint() bool {
 	return false
 }
 
+func (m *BundleMask) GetSequenceNumber() bool {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return false
+}
+
+// * ErrorDetails is attached to the status of failed Node and Workload API
+// calls so that clients can tell failure modes apart.
+type ErrorDetails struct {
+	//* stable reason of the failure, e.g. "no_entry"
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	//* additional properties of the failure, e.g. the exhausted limit
+	Metadata             map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ErrorDetails) Reset()         { *m = ErrorDetails{} }
+func (m *ErrorDetails) String() string { return proto.CompactTextString(m) }
+func (*ErrorDetails) ProtoMessage()    {}
+func (*ErrorDetails) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c11412a53cc81147, []int{11}
+}
+
+func (m *ErrorDetails) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ErrorDetails.Unmarshal(m, b)
+}
+func (m *ErrorDetails) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ErrorDetails.Marshal(b, m, deterministic)
+}
+func (m *ErrorDetails) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorDetails.Merge(m, src)
+}
+func (m *ErrorDetails) XXX_Size() int {
+	return xxx_messageInfo_ErrorDetails.Size(m)
+}
+func (m *ErrorDetails) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorDetails.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ErrorDetails proto.InternalMessageInfo
+
+func (m *ErrorDetails) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ErrorDetails) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Empty)(nil), "spire.common.Empty")
 	proto.RegisterType((*AttestationData)(nil), "spire.common.AttestationData")
@@ -693,56 +807,68 @@ func init() {
 	proto.RegisterType((*PublicKey)(nil), "spire.common.PublicKey")
 	proto.RegisterType((*Bundle)(nil), "spire.common.Bundle")
 	proto.RegisterType((*BundleMask)(nil), "spire.common.BundleMask")
+	proto.RegisterType((*ErrorDetails)(nil), "spire.common.ErrorDetails")
+	proto.RegisterMapType((map[string]string)(nil), "spire.common.ErrorDetails.MetadataEntry")
 }
 
 func init() { proto.RegisterFile("spire/common/common.proto", fileDescriptor_c11412a53cc81147) }
 
 var fileDescriptor_c11412a53cc81147 = []byte{
-	// 733 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdf, 0x6f, 0xe3, 0x44,
-	0x10, 0x96, 0xe3, 0x4b, 0x62, 0x4f, 0x72, 0x6d, 0xd9, 0x83, 0xc3, 0x15, 0x02, 0x82, 0x05, 0x28,
-	0x3a, 0x4e, 0x09, 0xba, 0xeb, 0xcb, 0x3d, 0xf0, 0xd0, 0x5e, 0x2b, 0x11, 0x55, 0x44, 0x95, 0x8b,
-	0x84, 0xe0, 0xc5, 0xda, 0x64, 0x27, 0xc9, 0x36, 0xf1, 0xda, 0xda, 0x9d, 0x90, 0xfa, 0x6f, 0xa4,
-	0x7f, 0x14, 0xda, 0x75, 0x7e, 0x53, 0x01, 0x4f, 0xde, 0xfd, 0x76, 0xe6, 0xdb, 0x6f, 0x66, 0x3e,
-	0x2f, 0x9c, 0x9b, 0x42, 0x6a, 0xec, 0x8f, 0xf3, 0x2c, 0xcb, 0xd5, 0xfa, 0xd3, 0x2b, 0x74, 0x4e,
-	0x39, 0x6b, 0xbb, 0xa3, 0x5e, 0x85, 0xc5, 0x4d, 0xa8, 0xdf, 0x64, 0x05, 0x95, 0xf1, 0x07, 0x38,
-	0xbd, 0x24, 0x42, 0x43, 0x9c, 0x64, 0xae, 0xae, 0x39, 0x71, 0xc6, 0xe0, 0x05, 0x95, 0x05, 0x46,
-	0x5e, 0xc7, 0xeb, 0x86, 0x89, 0x5b, 0x5b, 0x4c, 0x70, 0xe2, 0x51, 0xad, 0xe3, 0x75, 0xdb, 0x89,
-	0x5b, 0xc7, 0x17, 0x10, 0xdc, 0xe3, 0x02, 0xc7, 0x94, 0xeb, 0x67, 0x73, 0x3e, 0x85, 0xfa, 0x9f,
-	0x7c, 0xb1, 0x44, 0x97, 0x14, 0x26, 0xd5, 0x26, 0xfe, 0x09, 0xc2, 0x4d, 0x96, 0x61, 0x3f, 0x42,
-	0x13, 0x15, 0x69, 0x89, 0x26, 0xf2, 0x3a, 0x7e, 0xb7, 0xf5, 0xee, 0x75, 0x6f, 0x5f, 0x66, 0x6f,
-	0x13, 0x99, 0x6c, 0xc2, 0xe2, 0xbf, 0x6a, 0xd0, 0xae, 0x04, 0xa3, 0x18, 0xe6, 0x02, 0xd9, 0x17,
-	0x10, 0x9a, 0x42, 0x4e, 0x26, 0x98, 0x4a, 0xb1, 0xbe, 0x3e, 0xa8, 0x80, 0x81, 0x60, 0xef, 0xe0,
-	0x33, 0xbe, 0xab, 0x2e, 0xb5, 0xb2, 0x53, 0xa7, 0xb3, 0x92, 0xf4, 0x8a, 0x1f, 0x96, 0xfe, 0xab,
-	0x95, 0xfd, 0x16, 0xd8, 0x18, 0x35, 0xa5, 0x06, 0xb5, 0xe4, 0x8b, 0x54, 0x2d, 0xb3, 0x11, 0xea,
-	0xc8, 0x77, 0x09, 0x67, 0xf6, 0xe4, 0xde, 0x1d, 0x0c, 0x1d, 0xce, 0xbe, 0x85, 0x13, 0x17, 0xad,
-	0x72, 0x4a, 0xf9, 0x84, 0x50, 0x47, 0x2f, 0x3a, 0x5e, 0xd7, 0x4f, 0xda, 0x16, 0x1d, 0xe6, 0x74,
-	0x69, 0x31, 0xf6, 0x1e, 0x5e, 0x2b, 0x5c, 0xa5, 0xcf, 0xf0, 0xd6, 0x2b, 0x21, 0x0a, 0x57, 0x1f,
-	0x8f, 0xa9, 0x7f, 0x00, 0xb6, 0x4d, 0xda, 0xd1, 0x37, 0x1c, 0xfd, 0xe9, 0x3a, 0x61, 0x7b, 0xc3,
-	0x05, 0x84, 0x66, 0xd3, 0xd6, 0xa8, 0xf9, 0xaf, 0xbd, 0xdc, 0x05, 0xc6, 0x4f, 0x35, 0xf8, 0x24,
-	0xc1, 0xa9, 0x34, 0xa4, 0x5d, 0x13, 0x6e, 0x14, 0xe9, 0xf2, 0x90, 0xcb, 0xfb, 0x9f, 0x5c, 0x76,
-	0x10, 0x05, 0xd7, 0xa8, 0xc8, 0x0e, 0xa2, 0xea, 0x6f, 0x50, 0x01, 0x03, 0x71, 0x38, 0x25, 0xff,
-	0x68, 0x4a, 0x67, 0xe0, 0x13, 0x2d, 0x5c, 0xe3, 0xea, 0x89, 0x5d, 0xb2, 0xef, 0xe0, 0x64, 0x82,
-	0x02, 0x35, 0x27, 0x34, 0xe9, 0x4a, 0xd2, 0x2c, 0xaa, 0x77, 0xfc, 0x6e, 0x98, 0xbc, 0xdc, 0xa2,
-	0xbf, 0x49, 0x9a, 0xb1, 0x73, 0x08, 0xac, 0x2f, 0x4a, 0x4b, 0xda, 0x70, 0xa4, 0xce, 0x27, 0xe5,
-	0x40, 0x58, 0xf3, 0x71, 0x91, 0x49, 0x15, 0x35, 0x3b, 0x5e, 0x37, 0x48, 0xaa, 0x0d, 0xfb, 0x0a,
-	0x40, 0xe4, 0x2b, 0x65, 0x48, 0x23, 0xcf, 0xa2, 0xc0, 0x1d, 0xed, 0x21, 0xac, 0x03, 0x2d, 0x47,
-	0x70, 0xf3, 0x58, 0x48, 0x5d, 0x46, 0xa1, 0xeb, 0xf5, 0x3e, 0x64, 0x0b, 0x11, 0xca, 0xa4, 0x8a,
-	0x67, 0x68, 0x22, 0x70, 0xa2, 0x02, 0xa1, 0xcc, 0xd0, 0xee, 0xe3, 0x3b, 0x78, 0x75, 0xdc, 0x4d,
-	0x89, 0x86, 0x7d, 0x38, 0x76, 0xf9, 0xd7, 0x87, 0xdd, 0xfc, 0xc7, 0x04, 0x76, 0x76, 0x7f, 0x03,
-	0x2d, 0x3b, 0x66, 0x39, 0x91, 0x63, 0x4e, 0xce, 0xec, 0x02, 0x75, 0x3a, 0x2a, 0xc9, 0x71, 0xd9,
-	0x7f, 0x31, 0x10, 0xa8, 0xaf, 0xec, 0x3e, 0xfe, 0x1d, 0xc2, 0xbb, 0xe5, 0x68, 0x21, 0xc7, 0xb7,
-	0x58, 0xb2, 0x2f, 0x01, 0x8a, 0xb9, 0x7c, 0x3c, 0x08, 0x0d, 0x2d, 0xe2, 0x62, 0x6d, 0xcb, 0xe7,
-	0xdb, 0x31, 0xd9, 0xa5, 0xa5, 0xde, 0x99, 0xcc, 0x77, 0x85, 0x07, 0x6a, 0xed, 0xae, 0xf8, 0xc9,
-	0x83, 0xc6, 0xd5, 0x52, 0x89, 0x05, 0xb2, 0xef, 0xe1, 0x94, 0xf4, 0xd2, 0x50, 0x2a, 0xf2, 0x8c,
-	0x4b, 0xb5, 0xfb, 0xeb, 0x5e, 0x3a, 0xf8, 0xda, 0xa1, 0x03, 0xc1, 0x2e, 0x20, 0xd0, 0x79, 0x4e,
-	0xe9, 0x98, 0x9b, 0xa8, 0xe6, 0xaa, 0x3e, 0x3f, 0xac, 0x7a, 0xaf, 0xae, 0xa4, 0x69, 0x43, 0x3f,
-	0x72, 0xc3, 0x2e, 0xe1, 0xec, 0x61, 0x45, 0xa9, 0x91, 0x53, 0x25, 0xd5, 0x34, 0x9d, 0x63, 0x69,
-	0x22, 0xdf, 0x65, 0x7f, 0x7e, 0x98, 0xbd, 0xad, 0x34, 0x39, 0x79, 0x58, 0xd1, 0x7d, 0x15, 0x7f,
-	0x8b, 0xa5, 0x61, 0xdf, 0x40, 0x5b, 0xe3, 0x44, 0xa3, 0x99, 0xa5, 0x33, 0xa9, 0x68, 0xfd, 0x3f,
-	0xb6, 0xd6, 0xd8, 0xcf, 0x52, 0x51, 0x4c, 0x00, 0x55, 0x35, 0xbf, 0x70, 0x33, 0xb7, 0x2e, 0xda,
-	0x2a, 0xf5, 0x9c, 0x25, 0xb6, 0x72, 0xba, 0xcf, 0xc8, 0xa9, 0xb9, 0x90, 0xff, 0xba, 0xd5, 0x77,
-	0x51, 0xfb, 0xb7, 0x5e, 0xbd, 0xfd, 0xe3, 0xcd, 0x54, 0xd2, 0x6c, 0x39, 0xb2, 0x35, 0xf4, 0x2b,
-	0xf7, 0xf7, 0xab, 0x07, 0xdb, 0x3d, 0xd1, 0xfd, 0xfd, 0xc7, 0x7b, 0xd4, 0x70, 0xd8, 0xfb, 0xbf,
-	0x03, 0x00, 0x00, 0xff, 0xff, 0x97, 0x6b, 0x15, 0x4a, 0xd3, 0x05, 0x00, 0x00,
+	// 889 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x5d, 0x6f, 0x23, 0x35,
+	0x14, 0xd5, 0x64, 0x9a, 0x64, 0xe6, 0xe6, 0xa3, 0xc5, 0x0b, 0x65, 0xca, 0x0a, 0x08, 0x11, 0x1f,
+	0xd1, 0xb2, 0x4a, 0xd1, 0x6e, 0x1f, 0x58, 0x10, 0x0f, 0xed, 0xb6, 0x12, 0xd5, 0x6a, 0xab, 0xd5,
+	0x74, 0x25, 0x04, 0x2f, 0x23, 0x27, 0xbe, 0x69, 0xbc, 0x4d, 0x3c, 0xc1, 0x76, 0x9a, 0x9d, 0x1f,
+	0xc3, 0x0f, 0x40, 0xfc, 0x3b, 0x24, 0xde, 0x91, 0xaf, 0x27, 0x9f, 0x54, 0xec, 0x3e, 0x8d, 0x7d,
+	0xe6, 0xde, 0xeb, 0x73, 0x7d, 0x8e, 0x6d, 0x38, 0x32, 0x33, 0xa9, 0xf1, 0x78, 0x98, 0x4f, 0xa7,
+	0xb9, 0x2a, 0x3f, 0xfd, 0x99, 0xce, 0x6d, 0xce, 0x9a, 0xf4, 0xab, 0xef, 0xb1, 0x6e, 0x1d, 0xaa,
+	0x17, 0xd3, 0x99, 0x2d, 0xba, 0xcf, 0x60, 0xff, 0xd4, 0x5a, 0x34, 0x96, 0x5b, 0x99, 0xab, 0x73,
+	0x6e, 0x39, 0x63, 0xb0, 0x67, 0x8b, 0x19, 0x26, 0x41, 0x27, 0xe8, 0xc5, 0x29, 0x8d, 0x1d, 0x26,
+	0xb8, 0xe5, 0x49, 0xa5, 0x13, 0xf4, 0x9a, 0x29, 0x8d, 0xbb, 0x27, 0x10, 0x5d, 0xe3, 0x04, 0x87,
+	0x36, 0xd7, 0xf7, 0xe6, 0x7c, 0x08, 0xd5, 0x3b, 0x3e, 0x99, 0x23, 0x25, 0xc5, 0xa9, 0x9f, 0x74,
+	0x7f, 0x82, 0x78, 0x99, 0x65, 0xd8, 0x77, 0x50, 0x47, 0x65, 0xb5, 0x44, 0x93, 0x04, 0x9d, 0xb0,
+	0xd7, 0x78, 0x72, 0xd8, 0xdf, 0xa4, 0xd9, 0x5f, 0x46, 0xa6, 0xcb, 0xb0, 0xee, 0xdf, 0x15, 0x68,
+	0x7a, 0xc2, 0x28, 0xae, 0x72, 0x81, 0xec, 0x21, 0xc4, 0x66, 0x26, 0x47, 0x23, 0xcc, 0xa4, 0x28,
+	0x97, 0x8f, 0x3c, 0x70, 0x29, 0xd8, 0x13, 0xf8, 0x88, 0xaf, 0xbb, 0xcb, 0x1c, 0xed, 0x8c, 0x78,
+	0x7a, 0x4a, 0x0f, 0xf8, 0x76, 0xeb, 0xaf, 0x1d, 0xed, 0xc7, 0xc0, 0x86, 0xa8, 0x6d, 0x66, 0x50,
+	0x4b, 0x3e, 0xc9, 0xd4, 0x7c, 0x3a, 0x40, 0x9d, 0x84, 0x94, 0x70, 0xe0, 0xfe, 0x5c, 0xd3, 0x8f,
+	0x2b, 0xc2, 0xd9, 0x97, 0xd0, 0xa6, 0x68, 0x95, 0xdb, 0x8c, 0x8f, 0x2c, 0xea, 0x64, 0xaf, 0x13,
+	0xf4, 0xc2, 0xb4, 0xe9, 0xd0, 0xab, 0xdc, 0x9e, 0x3a, 0x8c, 0x3d, 0x85, 0x43, 0x85, 0x8b, 0xec,
+	0x9e, 0xba, 0x55, 0x4f, 0x44, 0xe1, 0xe2, 0xf9, 0x6e, 0xe9, 0x6f, 0x81, 0xad, 0x92, 0xd6, 0xe5,
+	0x6b, 0x54, 0x7e, 0xbf, 0x4c, 0x58, 0xad, 0x70, 0x02, 0xb1, 0x59, 0x6e, 0x6b, 0x52, 0xff, 0xdf,
+	0xbd, 0x5c, 0x07, 0xb2, 0xaf, 0xa0, 0x3d, 0xca, 0xf5, 0x10, 0x33, 0x9d, 0xfb, 0x5d, 0x48, 0xa2,
+	0x4e, 0xd0, 0x8b, 0xd2, 0x16, 0xa1, 0x69, 0x09, 0x76, 0xff, 0x0a, 0xe1, 0x83, 0x14, 0x6f, 0xa4,
+	0xb1, 0x9a, 0x80, 0x0b, 0x65, 0x75, 0xb1, 0xbd, 0x64, 0xf0, 0xbe, 0x4b, 0x3e, 0x84, 0x78, 0xc6,
+	0x35, 0x2a, 0xeb, 0xf4, 0xf2, 0x32, 0x44, 0x1e, 0xb8, 0x14, 0xdb, 0x62, 0x86, 0x3b, 0x62, 0x1e,
+	0x40, 0x68, 0xed, 0x84, 0xf6, 0xb7, 0x9a, 0xba, 0x21, 0xd1, 0x47, 0x81, 0x9a, 0x5b, 0x34, 0xd9,
+	0x42, 0xda, 0x71, 0x52, 0xed, 0x84, 0xbd, 0x38, 0x6d, 0xad, 0xd0, 0x5f, 0xa4, 0x1d, 0xb3, 0x23,
+	0x88, 0x9c, 0x7d, 0x0a, 0x57, 0xb4, 0x46, 0x45, 0xc9, 0x4e, 0xc5, 0xa5, 0x70, 0x1e, 0xe5, 0x62,
+	0x2a, 0x55, 0x52, 0xa7, 0xbe, 0xfd, 0x84, 0x7d, 0x06, 0x20, 0xf2, 0x85, 0x32, 0x56, 0x23, 0x9f,
+	0x96, 0x5b, 0xb2, 0x81, 0xb0, 0x0e, 0x34, 0xa8, 0xc0, 0xc5, 0xdb, 0x99, 0xd4, 0x45, 0x12, 0x93,
+	0x24, 0x9b, 0x90, 0x6b, 0x44, 0x28, 0x93, 0x29, 0x3e, 0x45, 0x93, 0x00, 0x91, 0x8a, 0x84, 0x32,
+	0x57, 0x6e, 0xce, 0x3a, 0xd0, 0x7c, 0xb3, 0xb0, 0x99, 0xb9, 0x93, 0x22, 0x73, 0x1d, 0x35, 0xa8,
+	0x23, 0x78, 0xb3, 0xb0, 0xd7, 0x77, 0x52, 0xbc, 0xb6, 0x13, 0x77, 0x9c, 0xc6, 0x52, 0xd9, 0xa4,
+	0xe9, 0x8f, 0x93, 0x1b, 0xb3, 0x6f, 0x60, 0x5f, 0xe3, 0x9d, 0x34, 0xce, 0xc8, 0xa5, 0x79, 0x5a,
+	0xb4, 0x70, 0x7b, 0x09, 0x7b, 0xdf, 0x74, 0x5f, 0xc1, 0x83, 0x5d, 0xb1, 0x24, 0x1a, 0xf6, 0x6c,
+	0xf7, 0xac, 0x7d, 0xbe, 0x2d, 0xd6, 0x7f, 0x04, 0x5e, 0x1f, 0xba, 0x47, 0xd0, 0x70, 0x66, 0x93,
+	0x23, 0x39, 0xe4, 0x96, 0x8e, 0x9c, 0x40, 0x9d, 0x0d, 0x0a, 0x4b, 0xb5, 0xdc, 0x8d, 0x10, 0x09,
+	0xd4, 0x67, 0x6e, 0xde, 0xfd, 0x15, 0xe2, 0x57, 0xf3, 0xc1, 0x44, 0x0e, 0x5f, 0x60, 0xc1, 0x3e,
+	0x05, 0x98, 0xdd, 0xca, 0xb7, 0x5b, 0xa1, 0xb1, 0x43, 0x28, 0xd6, 0x29, 0x7a, 0xbb, 0x72, 0x81,
+	0x1b, 0xba, 0xd2, 0x6b, 0xab, 0x87, 0xd4, 0x5e, 0xa4, 0x4a, 0x8f, 0x77, 0xff, 0x09, 0xa0, 0x76,
+	0x36, 0x57, 0x62, 0x82, 0xec, 0x6b, 0xd8, 0xb7, 0x7a, 0x6e, 0x6c, 0x26, 0xf2, 0x29, 0x97, 0x6a,
+	0x7d, 0xf6, 0x5b, 0x04, 0x9f, 0x13, 0x7a, 0x29, 0xd8, 0x09, 0x44, 0x3a, 0xcf, 0x6d, 0x36, 0xe4,
+	0x26, 0xa9, 0x50, 0xd7, 0x47, 0xdb, 0x5d, 0x6f, 0xf4, 0x95, 0xd6, 0x5d, 0xe8, 0x73, 0x6e, 0xd8,
+	0x29, 0x1c, 0x90, 0x40, 0xf2, 0x46, 0x49, 0x75, 0x93, 0xdd, 0x62, 0x61, 0x92, 0x90, 0xb2, 0x3f,
+	0xde, 0xce, 0x5e, 0x75, 0x9a, 0xb6, 0x9d, 0x7a, 0x3e, 0xfe, 0x05, 0x16, 0x86, 0x7d, 0x01, 0x4d,
+	0x8d, 0x23, 0x8d, 0x66, 0x9c, 0x91, 0x92, 0xfe, 0x56, 0x68, 0x94, 0xd8, 0xcf, 0xa5, 0xa0, 0x06,
+	0x7f, 0x9f, 0xa3, 0x1a, 0xe2, 0xe6, 0x6d, 0xb0, 0x97, 0xb6, 0x97, 0x70, 0x29, 0xe8, 0x1f, 0x01,
+	0x80, 0xef, 0xfb, 0x25, 0x37, 0xb7, 0xce, 0xce, 0xab, 0x9e, 0x02, 0xf2, 0xe6, 0x8a, 0x78, 0xef,
+	0x1e, 0xe2, 0x15, 0x0a, 0x79, 0x17, 0xbf, 0x90, 0xa2, 0xde, 0xc5, 0x6f, 0xcf, 0xd7, 0xda, 0xe1,
+	0xf7, 0x67, 0x00, 0xcd, 0x0b, 0xad, 0x73, 0x7d, 0x8e, 0x96, 0xcb, 0x89, 0x61, 0x87, 0x50, 0xd3,
+	0xc8, 0x4d, 0xae, 0x4a, 0x51, 0xca, 0x19, 0x3b, 0x87, 0x68, 0x8a, 0x96, 0x97, 0x2f, 0x89, 0xdb,
+	0xcf, 0xde, 0xf6, 0x7e, 0x6e, 0x56, 0xe9, 0xbf, 0x2c, 0x43, 0xbd, 0x19, 0x57, 0x99, 0x9f, 0xfc,
+	0x08, 0xad, 0xad, 0x5f, 0x64, 0x23, 0x2c, 0xca, 0xb5, 0xdc, 0xf0, 0xfe, 0xa7, 0xe7, 0x87, 0xca,
+	0xf7, 0xc1, 0xd9, 0xe3, 0xdf, 0x1e, 0xdd, 0x48, 0x3b, 0x9e, 0x0f, 0xdc, 0x92, 0xc7, 0xfe, 0x6e,
+	0x39, 0xf6, 0xaf, 0x26, 0xbd, 0x93, 0xc7, 0x9b, 0x2f, 0xe8, 0xa0, 0x46, 0xd8, 0xd3, 0x7f, 0x07,
+	0x00, 0x8c, 0x5e, 0xf5, 0xf1, 0x58, 0x07, 0x00, 0x00,
 }
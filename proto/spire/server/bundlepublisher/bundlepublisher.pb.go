@@ -0,0 +1,288 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: spire/server/bundlepublisher/bundlepublisher.proto
+
+package bundlepublisher
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	common "github.com/spiffe/spire/proto/spire/common"
+	plugin "github.com/spiffe/spire/proto/spire/common/plugin"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type PublishBundleRequest struct {
+	// The trust domain bundle to publish.
+	Bundle               *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PublishBundleRequest) Reset()         { *m = PublishBundleRequest{} }
+func (m *PublishBundleRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishBundleRequest) ProtoMessage()    {}
+func (*PublishBundleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e99e6dedb04fa330, []int{0}
+}
+
+func (m *PublishBundleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishBundleRequest.Unmarshal(m, b)
+}
+func (m *PublishBundleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishBundleRequest.Marshal(b, m, deterministic)
+}
+func (m *PublishBundleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishBundleRequest.Merge(m, src)
+}
+func (m *PublishBundleRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishBundleRequest.Size(m)
+}
+func (m *PublishBundleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishBundleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishBundleRequest proto.InternalMessageInfo
+
+func (m *PublishBundleRequest) GetBundle() *common.Bundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+type PublishBundleResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishBundleResponse) Reset()         { *m = PublishBundleResponse{} }
+func (m *PublishBundleResponse) String() string { return proto.CompactTextString(m) }
+func (*PublishBundleResponse) ProtoMessage()    {}
+func (*PublishBundleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e99e6dedb04fa330, []int{1}
+}
+
+func (m *PublishBundleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishBundleResponse.Unmarshal(m, b)
+}
+func (m *PublishBundleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishBundleResponse.Marshal(b, m, deterministic)
+}
+func (m *PublishBundleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishBundleResponse.Merge(m, src)
+}
+func (m *PublishBundleResponse) XXX_Size() int {
+	return xxx_messageInfo_PublishBundleResponse.Size(m)
+}
+func (m *PublishBundleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishBundleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishBundleResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*PublishBundleRequest)(nil), "spire.server.bundlepublisher.PublishBundleRequest")
+	proto.RegisterType((*PublishBundleResponse)(nil), "spire.server.bundlepublisher.PublishBundleResponse")
+}
+
+func init() {
+	proto.RegisterFile("spire/server/bundlepublisher/bundlepublisher.proto", fileDescriptor_e99e6dedb04fa330)
+}
+
+var fileDescriptor_e99e6dedb04fa330 = []byte{
+	// 266 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xcf, 0x4b, 0xc3, 0x30,
+	0x14, 0xc7, 0x99, 0x87, 0x81, 0x4f, 0x86, 0x10, 0x26, 0x6a, 0xf1, 0x30, 0x06, 0x8a, 0x8a, 0x24,
+	0xd0, 0xdd, 0xc4, 0xd3, 0x14, 0xc4, 0x5b, 0xf1, 0xb8, 0x63, 0xf5, 0xa5, 0x0b, 0x74, 0x49, 0x4c,
+	0x1a, 0xf1, 0xbf, 0xf4, 0x5f, 0x12, 0xf3, 0x52, 0x21, 0x73, 0x4c, 0x77, 0x0a, 0xed, 0xfb, 0x7c,
+	0x7f, 0xa4, 0xaf, 0x50, 0x7a, 0xab, 0x1c, 0x0a, 0x8f, 0xee, 0x1d, 0x9d, 0xa8, 0x83, 0x7e, 0x6d,
+	0xd1, 0x86, 0xba, 0x55, 0x7e, 0xf9, 0xfb, 0x99, 0x5b, 0x67, 0x3a, 0xc3, 0xce, 0xa2, 0x86, 0x93,
+	0x86, 0xaf, 0x31, 0xc5, 0x29, 0x39, 0xbe, 0x98, 0xd5, 0xca, 0xe8, 0x74, 0x90, 0xb0, 0x98, 0x64,
+	0x23, 0xdb, 0x86, 0x46, 0xf5, 0x07, 0x11, 0xd3, 0x07, 0x18, 0x57, 0xe4, 0x34, 0x8f, 0xb6, 0xcf,
+	0xf8, 0x16, 0xd0, 0x77, 0xec, 0x06, 0x86, 0x94, 0x73, 0x32, 0x98, 0x0c, 0x2e, 0x0f, 0xca, 0x31,
+	0xa7, 0x0e, 0xc9, 0x3e, 0xc1, 0x89, 0x99, 0x1e, 0xc3, 0xd1, 0x9a, 0x8b, 0xb7, 0x46, 0x7b, 0x2c,
+	0x3f, 0xf7, 0xe0, 0x90, 0x5e, 0x55, 0x7d, 0x5f, 0xf6, 0x01, 0xa3, 0x0c, 0x66, 0x25, 0xdf, 0x76,
+	0x3f, 0xbe, 0xa9, 0x5f, 0x31, 0xdb, 0x49, 0x43, 0x6d, 0xd8, 0x02, 0xf6, 0xef, 0x8d, 0x96, 0xaa,
+	0x09, 0x0e, 0xd9, 0x79, 0x7e, 0xa3, 0xf4, 0x55, 0x7e, 0xe6, 0x7d, 0xd0, 0xc5, 0x5f, 0x58, 0xf2,
+	0x96, 0x30, 0x7a, 0xc4, 0xae, 0x8a, 0xe3, 0x27, 0x2d, 0x0d, 0xbb, 0xda, 0x28, 0xcc, 0x98, 0x3e,
+	0xe3, 0xfa, 0x3f, 0x28, 0xe5, 0xcc, 0xef, 0x16, 0xb7, 0x8d, 0xea, 0x96, 0xa1, 0xfe, 0xa6, 0x85,
+	0xb7, 0x4a, 0x4a, 0x14, 0xb4, 0xe6, 0xb8, 0x51, 0xb1, 0xed, 0xff, 0xaa, 0x87, 0x91, 0x99, 0x7d,
+	0x0d, 0x00, 0x27, 0x26, 0xe9, 0x25, 0x86, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// BundlePublisherClient is the client API for BundlePublisher service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BundlePublisherClient interface {
+	// PublishBundle is called on startup after SPIRE server creates/loads
+	// the trust bundle and whenever SPIRE server changes the trust bundle.
+	// Errors returned by the plugin are logged but otherwise ignored; the
+	// bundle is published again on the next change.
+	PublishBundle(ctx context.Context, in *PublishBundleRequest, opts ...grpc.CallOption) (*PublishBundleResponse, error)
+	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
+	GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error)
+}
+
+type bundlePublisherClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBundlePublisherClient(cc *grpc.ClientConn) BundlePublisherClient {
+	return &bundlePublisherClient{cc}
+}
+
+func (c *bundlePublisherClient) PublishBundle(ctx context.Context, in *PublishBundleRequest, opts ...grpc.CallOption) (*PublishBundleResponse, error) {
+	out := new(PublishBundleResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.bundlepublisher.BundlePublisher/PublishBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundlePublisherClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
+	out := new(plugin.ConfigureResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.bundlepublisher.BundlePublisher/Configure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundlePublisherClient) GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error) {
+	out := new(plugin.GetPluginInfoResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.bundlepublisher.BundlePublisher/GetPluginInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BundlePublisherServer is the server API for BundlePublisher service.
+type BundlePublisherServer interface {
+	// PublishBundle is called on startup after SPIRE server creates/loads
+	// the trust bundle and whenever SPIRE server changes the trust bundle.
+	// Errors returned by the plugin are logged but otherwise ignored; the
+	// bundle is published again on the next change.
+	PublishBundle(context.Context, *PublishBundleRequest) (*PublishBundleResponse, error)
+	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
+	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
+}
+
+// UnimplementedBundlePublisherServer can be embedded to have forward compatible implementations.
+type UnimplementedBundlePublisherServer struct {
+}
+
+func (*UnimplementedBundlePublisherServer) PublishBundle(ctx context.Context, req *PublishBundleRequest) (*PublishBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishBundle not implemented")
+}
+func (*UnimplementedBundlePublisherServer) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
+}
+func (*UnimplementedBundlePublisherServer) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPluginInfo not implemented")
+}
+
+func RegisterBundlePublisherServer(s *grpc.Server, srv BundlePublisherServer) {
+	s.RegisterService(&_BundlePublisher_serviceDesc, srv)
+}
+
+func _BundlePublisher_PublishBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundlePublisherServer).PublishBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.bundlepublisher.BundlePublisher/PublishBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundlePublisherServer).PublishBundle(ctx, req.(*PublishBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BundlePublisher_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(plugin.ConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundlePublisherServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.bundlepublisher.BundlePublisher/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundlePublisherServer).Configure(ctx, req.(*plugin.ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BundlePublisher_GetPluginInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(plugin.GetPluginInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundlePublisherServer).GetPluginInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.bundlepublisher.BundlePublisher/GetPluginInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundlePublisherServer).GetPluginInfo(ctx, req.(*plugin.GetPluginInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _BundlePublisher_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.server.bundlepublisher.BundlePublisher",
+	HandlerType: (*BundlePublisherServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PublishBundle",
+			Handler:    _BundlePublisher_PublishBundle_Handler,
+		},
+		{
+			MethodName: "Configure",
+			Handler:    _BundlePublisher_Configure_Handler,
+		},
+		{
+			MethodName: "GetPluginInfo",
+			Handler:    _BundlePublisher_GetPluginInfo_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/server/bundlepublisher/bundlepublisher.proto",
+}
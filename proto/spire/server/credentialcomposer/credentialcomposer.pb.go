@@ -0,0 +1,721 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: spire/server/credentialcomposer/credentialcomposer.proto
+
+package credentialcomposer
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	common "github.com/spiffe/spire/proto/spire/common"
+	plugin "github.com/spiffe/spire/proto/spire/common/plugin"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ComposeJWTSVIDRequest struct {
+	// SPIFFE ID of the JWT-SVID being minted.
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Audience of the JWT-SVID being minted.
+	Audience []string `protobuf:"bytes,2,rep,name=audience,proto3" json:"audience,omitempty"`
+	// Selectors of the registration entry the JWT-SVID is minted for. Empty
+	// when the JWT-SVID is not minted on behalf of a registration entry
+	// (e.g. through the MintJWTSVID API).
+	Selectors            []*common.Selector `protobuf:"bytes,3,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ComposeJWTSVIDRequest) Reset()         { *m = ComposeJWTSVIDRequest{} }
+func (m *ComposeJWTSVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*ComposeJWTSVIDRequest) ProtoMessage()    {}
+func (*ComposeJWTSVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c2158bdb6ce245f5, []int{0}
+}
+
+func (m *ComposeJWTSVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComposeJWTSVIDRequest.Unmarshal(m, b)
+}
+func (m *ComposeJWTSVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComposeJWTSVIDRequest.Marshal(b, m, deterministic)
+}
+func (m *ComposeJWTSVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComposeJWTSVIDRequest.Merge(m, src)
+}
+func (m *ComposeJWTSVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_ComposeJWTSVIDRequest.Size(m)
+}
+func (m *ComposeJWTSVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComposeJWTSVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComposeJWTSVIDRequest proto.InternalMessageInfo
+
+func (m *ComposeJWTSVIDRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *ComposeJWTSVIDRequest) GetAudience() []string {
+	if m != nil {
+		return m.Audience
+	}
+	return nil
+}
+
+func (m *ComposeJWTSVIDRequest) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+type ComposeJWTSVIDResponse struct {
+	// Additional claims to include in the JWT-SVID. Claims that are not
+	// allowed by the server configuration are discarded. Registered claims
+	// (e.g. sub, aud, exp) can never be overridden.
+	Claims               *_struct.Struct `protobuf:"bytes,1,opt,name=claims,proto3" json:"claims,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ComposeJWTSVIDResponse) Reset()         { *m = ComposeJWTSVIDResponse{} }
+func (m *ComposeJWTSVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*ComposeJWTSVIDResponse) ProtoMessage()    {}
+func (*ComposeJWTSVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c2158bdb6ce245f5, []int{1}
+}
+
+func (m *ComposeJWTSVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComposeJWTSVIDResponse.Unmarshal(m, b)
+}
+func (m *ComposeJWTSVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComposeJWTSVIDResponse.Marshal(b, m, deterministic)
+}
+func (m *ComposeJWTSVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComposeJWTSVIDResponse.Merge(m, src)
+}
+func (m *ComposeJWTSVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_ComposeJWTSVIDResponse.Size(m)
+}
+func (m *ComposeJWTSVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComposeJWTSVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComposeJWTSVIDResponse proto.InternalMessageInfo
+
+func (m *ComposeJWTSVIDResponse) GetClaims() *_struct.Struct {
+	if m != nil {
+		return m.Claims
+	}
+	return nil
+}
+
+type X509Subject struct {
+	Country              []string `protobuf:"bytes,1,rep,name=country,proto3" json:"country,omitempty"`
+	Organization         []string `protobuf:"bytes,2,rep,name=organization,proto3" json:"organization,omitempty"`
+	OrganizationalUnit   []string `protobuf:"bytes,3,rep,name=organizational_unit,json=organizationalUnit,proto3" json:"organizational_unit,omitempty"`
+	Locality             []string `protobuf:"bytes,4,rep,name=locality,proto3" json:"locality,omitempty"`
+	Province             []string `protobuf:"bytes,5,rep,name=province,proto3" json:"province,omitempty"`
+	StreetAddress        []string `protobuf:"bytes,6,rep,name=street_address,json=streetAddress,proto3" json:"street_address,omitempty"`
+	PostalCode           []string `protobuf:"bytes,7,rep,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	SerialNumber         string   `protobuf:"bytes,8,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	CommonName           string   `protobuf:"bytes,9,opt,name=common_name,json=commonName,proto3" json:"common_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509Subject) Reset()         { *m = X509Subject{} }
+func (m *X509Subject) String() string { return proto.CompactTextString(m) }
+func (*X509Subject) ProtoMessage()    {}
+func (*X509Subject) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c2158bdb6ce245f5, []int{2}
+}
+
+func (m *X509Subject) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509Subject.Unmarshal(m, b)
+}
+func (m *X509Subject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509Subject.Marshal(b, m, deterministic)
+}
+func (m *X509Subject) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509Subject.Merge(m, src)
+}
+func (m *X509Subject) XXX_Size() int {
+	return xxx_messageInfo_X509Subject.Size(m)
+}
+func (m *X509Subject) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509Subject.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509Subject proto.InternalMessageInfo
+
+func (m *X509Subject) GetCountry() []string {
+	if m != nil {
+		return m.Country
+	}
+	return nil
+}
+
+func (m *X509Subject) GetOrganization() []string {
+	if m != nil {
+		return m.Organization
+	}
+	return nil
+}
+
+func (m *X509Subject) GetOrganizationalUnit() []string {
+	if m != nil {
+		return m.OrganizationalUnit
+	}
+	return nil
+}
+
+func (m *X509Subject) GetLocality() []string {
+	if m != nil {
+		return m.Locality
+	}
+	return nil
+}
+
+func (m *X509Subject) GetProvince() []string {
+	if m != nil {
+		return m.Province
+	}
+	return nil
+}
+
+func (m *X509Subject) GetStreetAddress() []string {
+	if m != nil {
+		return m.StreetAddress
+	}
+	return nil
+}
+
+func (m *X509Subject) GetPostalCode() []string {
+	if m != nil {
+		return m.PostalCode
+	}
+	return nil
+}
+
+func (m *X509Subject) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+func (m *X509Subject) GetCommonName() string {
+	if m != nil {
+		return m.CommonName
+	}
+	return ""
+}
+
+type X509Extension struct {
+	// Object identifier of the extension, in dotted notation (e.g.
+	// "1.3.6.1.4.1.99999.1").
+	Oid string `protobuf:"bytes,1,opt,name=oid,proto3" json:"oid,omitempty"`
+	// Whether the extension is marked critical.
+	Critical bool `protobuf:"varint,2,opt,name=critical,proto3" json:"critical,omitempty"`
+	// DER encoded value of the extension.
+	Value                []byte   `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509Extension) Reset()         { *m = X509Extension{} }
+func (m *X509Extension) String() string { return proto.CompactTextString(m) }
+func (*X509Extension) ProtoMessage()    {}
+func (*X509Extension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c2158bdb6ce245f5, []int{3}
+}
+
+func (m *X509Extension) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509Extension.Unmarshal(m, b)
+}
+func (m *X509Extension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509Extension.Marshal(b, m, deterministic)
+}
+func (m *X509Extension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509Extension.Merge(m, src)
+}
+func (m *X509Extension) XXX_Size() int {
+	return xxx_messageInfo_X509Extension.Size(m)
+}
+func (m *X509Extension) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509Extension.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509Extension proto.InternalMessageInfo
+
+func (m *X509Extension) GetOid() string {
+	if m != nil {
+		return m.Oid
+	}
+	return ""
+}
+
+func (m *X509Extension) GetCritical() bool {
+	if m != nil {
+		return m.Critical
+	}
+	return false
+}
+
+func (m *X509Extension) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type X509SVIDAttributes struct {
+	// Subject of the X509-SVID.
+	Subject *X509Subject `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// DNS names of the X509-SVID.
+	DnsNames []string `protobuf:"bytes,2,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	// Extensions of the X509-SVID, besides the ones set by SPIRE server.
+	Extensions           []*X509Extension `protobuf:"bytes,3,rep,name=extensions,proto3" json:"extensions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *X509SVIDAttributes) Reset()         { *m = X509SVIDAttributes{} }
+func (m *X509SVIDAttributes) String() string { return proto.CompactTextString(m) }
+func (*X509SVIDAttributes) ProtoMessage()    {}
+func (*X509SVIDAttributes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c2158bdb6ce245f5, []int{4}
+}
+
+func (m *X509SVIDAttributes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509SVIDAttributes.Unmarshal(m, b)
+}
+func (m *X509SVIDAttributes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509SVIDAttributes.Marshal(b, m, deterministic)
+}
+func (m *X509SVIDAttributes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509SVIDAttributes.Merge(m, src)
+}
+func (m *X509SVIDAttributes) XXX_Size() int {
+	return xxx_messageInfo_X509SVIDAttributes.Size(m)
+}
+func (m *X509SVIDAttributes) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509SVIDAttributes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509SVIDAttributes proto.InternalMessageInfo
+
+func (m *X509SVIDAttributes) GetSubject() *X509Subject {
+	if m != nil {
+		return m.Subject
+	}
+	return nil
+}
+
+func (m *X509SVIDAttributes) GetDnsNames() []string {
+	if m != nil {
+		return m.DnsNames
+	}
+	return nil
+}
+
+func (m *X509SVIDAttributes) GetExtensions() []*X509Extension {
+	if m != nil {
+		return m.Extensions
+	}
+	return nil
+}
+
+type ComposeX509SVIDRequest struct {
+	// SPIFFE ID of the X509-SVID being signed.
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Selectors of the registration entry the X509-SVID is signed for.
+	// Empty when the X509-SVID is not signed on behalf of a registration
+	// entry (e.g. agent SVIDs or through the MintX509SVID API).
+	Selectors []*common.Selector `protobuf:"bytes,2,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// Attributes of the X509-SVID, as composed so far by SPIRE server and
+	// the credential composers called before this one.
+	Attributes           *X509SVIDAttributes `protobuf:"bytes,3,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ComposeX509SVIDRequest) Reset()         { *m = ComposeX509SVIDRequest{} }
+func (m *ComposeX509SVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*ComposeX509SVIDRequest) ProtoMessage()    {}
+func (*ComposeX509SVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c2158bdb6ce245f5, []int{5}
+}
+
+func (m *ComposeX509SVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComposeX509SVIDRequest.Unmarshal(m, b)
+}
+func (m *ComposeX509SVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComposeX509SVIDRequest.Marshal(b, m, deterministic)
+}
+func (m *ComposeX509SVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComposeX509SVIDRequest.Merge(m, src)
+}
+func (m *ComposeX509SVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_ComposeX509SVIDRequest.Size(m)
+}
+func (m *ComposeX509SVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComposeX509SVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComposeX509SVIDRequest proto.InternalMessageInfo
+
+func (m *ComposeX509SVIDRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *ComposeX509SVIDRequest) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *ComposeX509SVIDRequest) GetAttributes() *X509SVIDAttributes {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+type ComposeX509SVIDResponse struct {
+	// Attributes of the X509-SVID. When set, the subject replaces the
+	// current subject, the DNS names are added to the current DNS names and
+	// the extensions are attached to the X509-SVID. Extensions that are not
+	// allowed by the server configuration are discarded. The SPIFFE ID, key,
+	// validity and the extensions set by SPIRE server can never be changed.
+	Attributes           *X509SVIDAttributes `protobuf:"bytes,1,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ComposeX509SVIDResponse) Reset()         { *m = ComposeX509SVIDResponse{} }
+func (m *ComposeX509SVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*ComposeX509SVIDResponse) ProtoMessage()    {}
+func (*ComposeX509SVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c2158bdb6ce245f5, []int{6}
+}
+
+func (m *ComposeX509SVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComposeX509SVIDResponse.Unmarshal(m, b)
+}
+func (m *ComposeX509SVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComposeX509SVIDResponse.Marshal(b, m, deterministic)
+}
+func (m *ComposeX509SVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComposeX509SVIDResponse.Merge(m, src)
+}
+func (m *ComposeX509SVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_ComposeX509SVIDResponse.Size(m)
+}
+func (m *ComposeX509SVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComposeX509SVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComposeX509SVIDResponse proto.InternalMessageInfo
+
+func (m *ComposeX509SVIDResponse) GetAttributes() *X509SVIDAttributes {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ComposeJWTSVIDRequest)(nil), "spire.server.credentialcomposer.ComposeJWTSVIDRequest")
+	proto.RegisterType((*ComposeJWTSVIDResponse)(nil), "spire.server.credentialcomposer.ComposeJWTSVIDResponse")
+	proto.RegisterType((*X509Subject)(nil), "spire.server.credentialcomposer.X509Subject")
+	proto.RegisterType((*X509Extension)(nil), "spire.server.credentialcomposer.X509Extension")
+	proto.RegisterType((*X509SVIDAttributes)(nil), "spire.server.credentialcomposer.X509SVIDAttributes")
+	proto.RegisterType((*ComposeX509SVIDRequest)(nil), "spire.server.credentialcomposer.ComposeX509SVIDRequest")
+	proto.RegisterType((*ComposeX509SVIDResponse)(nil), "spire.server.credentialcomposer.ComposeX509SVIDResponse")
+}
+
+func init() {
+	proto.RegisterFile("spire/server/credentialcomposer/credentialcomposer.proto", fileDescriptor_c2158bdb6ce245f5)
+}
+
+var fileDescriptor_c2158bdb6ce245f5 = []byte{
+	// 724 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xdd, 0x6e, 0xd3, 0x4a,
+	0x10, 0x96, 0x93, 0xd3, 0x36, 0x99, 0x34, 0x3d, 0x47, 0x7b, 0xce, 0x69, 0x4d, 0x40, 0x6a, 0x64,
+	0x54, 0x14, 0x10, 0xb2, 0x51, 0xca, 0x4f, 0xb9, 0x40, 0xa8, 0xa4, 0x80, 0xc2, 0x45, 0x85, 0x1c,
+	0x28, 0xa8, 0x37, 0x91, 0x63, 0x4f, 0xc2, 0x22, 0x67, 0xd7, 0xec, 0xae, 0x2b, 0xca, 0x1d, 0x12,
+	0xe2, 0xb9, 0x10, 0x77, 0x3c, 0x07, 0x2f, 0x82, 0xbc, 0x6b, 0x87, 0xb8, 0xad, 0x94, 0x54, 0xe2,
+	0x2a, 0x99, 0x99, 0x6f, 0x76, 0xf6, 0xfb, 0x66, 0x66, 0x0d, 0x7b, 0x32, 0xa1, 0x02, 0x3d, 0x89,
+	0xe2, 0x04, 0x85, 0x17, 0x0a, 0x8c, 0x90, 0x29, 0x1a, 0xc4, 0x21, 0x9f, 0x26, 0x5c, 0x5e, 0xe8,
+	0x72, 0x13, 0xc1, 0x15, 0x27, 0xdb, 0x3a, 0xd3, 0x35, 0x99, 0xee, 0x79, 0x58, 0xeb, 0xda, 0x84,
+	0xf3, 0x49, 0x8c, 0x9e, 0x86, 0x8f, 0xd2, 0xb1, 0x27, 0x95, 0x48, 0x43, 0x65, 0xd2, 0x5b, 0x57,
+	0x4c, 0xe1, 0x90, 0x4f, 0xa7, 0x9c, 0xe5, 0x3f, 0x79, 0xa8, 0x5d, 0x0a, 0x25, 0x71, 0x3a, 0xa1,
+	0xc5, 0x8f, 0x41, 0x38, 0x5f, 0x2d, 0xf8, 0xbf, 0x67, 0xea, 0xbc, 0x78, 0xf3, 0x6a, 0x70, 0xd4,
+	0x3f, 0xf0, 0xf1, 0x43, 0x8a, 0x52, 0x91, 0xab, 0x50, 0x97, 0x09, 0x1d, 0x8f, 0x71, 0x48, 0x23,
+	0xdb, 0x6a, 0x5b, 0x9d, 0xba, 0x5f, 0x33, 0x8e, 0x7e, 0x44, 0x5a, 0x50, 0x0b, 0xd2, 0x88, 0x22,
+	0x0b, 0xd1, 0xae, 0xb4, 0xab, 0x59, 0xac, 0xb0, 0xc9, 0x5d, 0xa8, 0x4b, 0x8c, 0x31, 0x54, 0x5c,
+	0x48, 0xbb, 0xda, 0xae, 0x76, 0x1a, 0xdd, 0x4d, 0xd7, 0x50, 0xcc, 0x2f, 0x37, 0xc8, 0xc3, 0xfe,
+	0x6f, 0xa0, 0xd3, 0x87, 0xcd, 0xb3, 0xf7, 0x90, 0x09, 0x67, 0x12, 0x89, 0x07, 0xab, 0x61, 0x1c,
+	0xd0, 0xa9, 0xd4, 0xb7, 0x68, 0x74, 0xb7, 0x5c, 0x23, 0x87, 0x5b, 0xc8, 0xe1, 0x0e, 0xb4, 0x1c,
+	0x7e, 0x0e, 0x73, 0xbe, 0x57, 0xa0, 0xf1, 0xf6, 0xde, 0x9d, 0x87, 0x83, 0x74, 0xf4, 0x1e, 0x43,
+	0x45, 0x6c, 0x58, 0x0b, 0x79, 0xca, 0x94, 0x38, 0xb5, 0x2d, 0x7d, 0xd7, 0xc2, 0x24, 0x0e, 0xac,
+	0x73, 0x31, 0x09, 0x18, 0xfd, 0x14, 0x28, 0xca, 0x59, 0x4e, 0xa5, 0xe4, 0x23, 0x1e, 0xfc, 0x3b,
+	0x6f, 0x07, 0xf1, 0x30, 0x65, 0x54, 0x69, 0x62, 0x75, 0x9f, 0x94, 0x43, 0xaf, 0x19, 0x55, 0x99,
+	0x36, 0x31, 0x0f, 0x83, 0x98, 0xaa, 0x53, 0xfb, 0x2f, 0xa3, 0x4d, 0x61, 0x67, 0xb1, 0x44, 0xf0,
+	0x13, 0x9a, 0xe9, 0xb6, 0x62, 0x62, 0x85, 0x4d, 0x76, 0x60, 0x43, 0x2a, 0x81, 0xa8, 0x86, 0x41,
+	0x14, 0x09, 0x94, 0xd2, 0x5e, 0xd5, 0x88, 0xa6, 0xf1, 0xee, 0x1b, 0x27, 0xd9, 0x86, 0x46, 0xc2,
+	0xa5, 0x0a, 0xe2, 0x61, 0xc8, 0x23, 0xb4, 0xd7, 0x34, 0x06, 0x8c, 0xab, 0xc7, 0x23, 0x24, 0xd7,
+	0xa1, 0x29, 0x51, 0xd0, 0x20, 0x1e, 0xb2, 0x74, 0x3a, 0x42, 0x61, 0xd7, 0x74, 0xf3, 0xd6, 0x8d,
+	0xf3, 0x50, 0xfb, 0xb2, 0x53, 0x4c, 0x33, 0x86, 0x2c, 0x98, 0xa2, 0x5d, 0xd7, 0x10, 0x30, 0xae,
+	0xc3, 0x60, 0x8a, 0xce, 0x00, 0x9a, 0x99, 0x86, 0x4f, 0x3f, 0x2a, 0x64, 0x32, 0xd3, 0xe1, 0x1f,
+	0xa8, 0xf2, 0xd9, 0x24, 0x64, 0x7f, 0x33, 0x32, 0xa1, 0xa0, 0x8a, 0x86, 0x41, 0x6c, 0x57, 0xda,
+	0x56, 0xa7, 0xe6, 0xcf, 0x6c, 0xf2, 0x1f, 0xac, 0x9c, 0x04, 0x71, 0x8a, 0x76, 0xb5, 0x6d, 0x75,
+	0xd6, 0x7d, 0x63, 0x38, 0x3f, 0x2c, 0x20, 0xba, 0x33, 0x47, 0xfd, 0x83, 0x7d, 0xa5, 0x04, 0x1d,
+	0xa5, 0x0a, 0x25, 0x79, 0x06, 0x6b, 0xd2, 0xf4, 0x2a, 0x6f, 0xf1, 0x6d, 0x77, 0xc1, 0x4a, 0xb8,
+	0x73, 0xfd, 0xf5, 0x8b, 0xe4, 0x6c, 0x64, 0x23, 0x26, 0x35, 0x23, 0x59, 0x8c, 0x65, 0xc4, 0x64,
+	0xc6, 0x47, 0x92, 0x43, 0x00, 0x2c, 0xc8, 0x14, 0x73, 0xe9, 0x2e, 0x55, 0x67, 0xa6, 0x81, 0x3f,
+	0x77, 0x82, 0xf3, 0xcd, 0x9a, 0x4d, 0x6c, 0x41, 0x69, 0xa9, 0xd5, 0x29, 0xad, 0x47, 0x65, 0xc9,
+	0xf5, 0x20, 0x03, 0x80, 0x60, 0x26, 0x98, 0x16, 0xb5, 0xd1, 0xdd, 0x5d, 0x4e, 0xa5, 0x92, 0xd6,
+	0xfe, 0xdc, 0x31, 0x0e, 0x83, 0xad, 0x73, 0x0c, 0xf2, 0xa5, 0x2b, 0xd7, 0xb3, 0xfe, 0x48, 0xbd,
+	0xee, 0xcf, 0x2a, 0x90, 0xde, 0x2c, 0x2b, 0x2f, 0x2d, 0xc8, 0x67, 0x0b, 0x36, 0xca, 0xbb, 0x4f,
+	0xee, 0x2f, 0x2c, 0x75, 0xe1, 0xa3, 0xd5, 0x7a, 0x70, 0xe9, 0xbc, 0x9c, 0xef, 0x17, 0x0b, 0xfe,
+	0x3e, 0xa3, 0x05, 0x59, 0xfa, 0xb0, 0x33, 0xfd, 0x6f, 0xed, 0x5d, 0x3e, 0x31, 0xbf, 0xc6, 0x31,
+	0xd4, 0x7b, 0x9c, 0x8d, 0xe9, 0x24, 0x15, 0x48, 0x76, 0xca, 0x63, 0x91, 0xbf, 0xdb, 0xb3, 0x78,
+	0x51, 0xed, 0xc6, 0x22, 0x58, 0x7e, 0xf6, 0x18, 0x9a, 0xcf, 0x51, 0xbd, 0xd4, 0xe1, 0x3e, 0x1b,
+	0x73, 0x72, 0xf3, 0xc2, 0xc4, 0x12, 0xa6, 0xa8, 0x71, 0x6b, 0x19, 0xa8, 0xa9, 0xf3, 0xe4, 0xf1,
+	0xf1, 0xa3, 0x09, 0x55, 0xef, 0xd2, 0x51, 0x86, 0xf6, 0xcc, 0xdc, 0x7b, 0xe6, 0x43, 0xa4, 0x5f,
+	0x6c, 0x6f, 0xc1, 0x87, 0x72, 0xb4, 0xaa, 0x61, 0xbb, 0xbf, 0x06, 0x00, 0xd5, 0x70, 0xdd, 0x67,
+	0x52, 0x07, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// CredentialComposerClient is the client API for CredentialComposer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CredentialComposerClient interface {
+	// ComposeJWTSVID is called before SPIRE server signs a JWT-SVID and
+	// returns additional claims for it. If an error is returned the
+	// JWT-SVID is not minted.
+	ComposeJWTSVID(ctx context.Context, in *ComposeJWTSVIDRequest, opts ...grpc.CallOption) (*ComposeJWTSVIDResponse, error)
+	// ComposeX509SVID is called before SPIRE server signs an X509-SVID and
+	// returns attributes for it. If an error is returned the X509-SVID is
+	// not signed. Plugins that don't implement it leave X509-SVIDs
+	// untouched.
+	ComposeX509SVID(ctx context.Context, in *ComposeX509SVIDRequest, opts ...grpc.CallOption) (*ComposeX509SVIDResponse, error)
+	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
+	GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error)
+}
+
+type credentialComposerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCredentialComposerClient(cc *grpc.ClientConn) CredentialComposerClient {
+	return &credentialComposerClient{cc}
+}
+
+func (c *credentialComposerClient) ComposeJWTSVID(ctx context.Context, in *ComposeJWTSVIDRequest, opts ...grpc.CallOption) (*ComposeJWTSVIDResponse, error) {
+	out := new(ComposeJWTSVIDResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.credentialcomposer.CredentialComposer/ComposeJWTSVID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *credentialComposerClient) ComposeX509SVID(ctx context.Context, in *ComposeX509SVIDRequest, opts ...grpc.CallOption) (*ComposeX509SVIDResponse, error) {
+	out := new(ComposeX509SVIDResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.credentialcomposer.CredentialComposer/ComposeX509SVID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *credentialComposerClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
+	out := new(plugin.ConfigureResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.credentialcomposer.CredentialComposer/Configure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *credentialComposerClient) GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error) {
+	out := new(plugin.GetPluginInfoResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.credentialcomposer.CredentialComposer/GetPluginInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CredentialComposerServer is the server API for CredentialComposer service.
+type CredentialComposerServer interface {
+	// ComposeJWTSVID is called before SPIRE server signs a JWT-SVID and
+	// returns additional claims for it. If an error is returned the
+	// JWT-SVID is not minted.
+	ComposeJWTSVID(context.Context, *ComposeJWTSVIDRequest) (*ComposeJWTSVIDResponse, error)
+	// ComposeX509SVID is called before SPIRE server signs an X509-SVID and
+	// returns attributes for it. If an error is returned the X509-SVID is
+	// not signed. Plugins that don't implement it leave X509-SVIDs
+	// untouched.
+	ComposeX509SVID(context.Context, *ComposeX509SVIDRequest) (*ComposeX509SVIDResponse, error)
+	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
+	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
+}
+
+// UnimplementedCredentialComposerServer can be embedded to have forward compatible implementations.
+type UnimplementedCredentialComposerServer struct {
+}
+
+func (*UnimplementedCredentialComposerServer) ComposeJWTSVID(ctx context.Context, req *ComposeJWTSVIDRequest) (*ComposeJWTSVIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ComposeJWTSVID not implemented")
+}
+func (*UnimplementedCredentialComposerServer) ComposeX509SVID(ctx context.Context, req *ComposeX509SVIDRequest) (*ComposeX509SVIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ComposeX509SVID not implemented")
+}
+func (*UnimplementedCredentialComposerServer) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
+}
+func (*UnimplementedCredentialComposerServer) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPluginInfo not implemented")
+}
+
+func RegisterCredentialComposerServer(s *grpc.Server, srv CredentialComposerServer) {
+	s.RegisterService(&_CredentialComposer_serviceDesc, srv)
+}
+
+func _CredentialComposer_ComposeJWTSVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComposeJWTSVIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialComposerServer).ComposeJWTSVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.credentialcomposer.CredentialComposer/ComposeJWTSVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialComposerServer).ComposeJWTSVID(ctx, req.(*ComposeJWTSVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CredentialComposer_ComposeX509SVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComposeX509SVIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialComposerServer).ComposeX509SVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.credentialcomposer.CredentialComposer/ComposeX509SVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialComposerServer).ComposeX509SVID(ctx, req.(*ComposeX509SVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CredentialComposer_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(plugin.ConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialComposerServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.credentialcomposer.CredentialComposer/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialComposerServer).Configure(ctx, req.(*plugin.ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CredentialComposer_GetPluginInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(plugin.GetPluginInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CredentialComposerServer).GetPluginInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.credentialcomposer.CredentialComposer/GetPluginInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CredentialComposerServer).GetPluginInfo(ctx, req.(*plugin.GetPluginInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CredentialComposer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.server.credentialcomposer.CredentialComposer",
+	HandlerType: (*CredentialComposerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ComposeJWTSVID",
+			Handler:    _CredentialComposer_ComposeJWTSVID_Handler,
+		},
+		{
+			MethodName: "ComposeX509SVID",
+			Handler:    _CredentialComposer_ComposeX509SVID_Handler,
+		},
+		{
+			MethodName: "Configure",
+			Handler:    _CredentialComposer_Configure_Handler,
+		},
+		{
+			MethodName: "GetPluginInfo",
+			Handler:    _CredentialComposer_GetPluginInfo_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/server/credentialcomposer/credentialcomposer.proto",
+}
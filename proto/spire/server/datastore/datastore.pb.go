@@ -82,7 +82,7 @@ func (x BySelectors_MatchBehavior) String() string {
 }
 
 func (BySelectors_MatchBehavior) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{35, 0}
+	return fileDescriptor_4d9f80f01a852be0, []int{39, 0}
 }
 
 type CreateBundleRequest struct {
@@ -164,7 +164,9 @@ func (m *CreateBundleResponse) GetBundle() *common.Bundle {
 }
 
 type FetchBundleRequest struct {
-	TrustDomainId        string   `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	// When enabled, read-only connection will be used to connect to database read instances. Some staleness of data will be observed.
+	TolerateStale        bool     `protobuf:"varint,2,opt,name=tolerate_stale,json=tolerateStale,proto3" json:"tolerate_stale,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -202,6 +204,13 @@ func (m *FetchBundleRequest) GetTrustDomainId() string {
 	return ""
 }
 
+func (m *FetchBundleRequest) GetTolerateStale() bool {
+	if m != nil {
+		return m.TolerateStale
+	}
+	return false
+}
+
 type FetchBundleResponse struct {
 	Bundle               *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
@@ -242,10 +251,12 @@ func (m *FetchBundleResponse) GetBundle() *common.Bundle {
 }
 
 type ListBundlesRequest struct {
-	Pagination           *Pagination `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Pagination *Pagination `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	// When enabled, read-only connection will be used to connect to database read instances. Some staleness of data will be observed.
+	TolerateStale        bool     `protobuf:"varint,2,opt,name=tolerate_stale,json=tolerateStale,proto3" json:"tolerate_stale,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListBundlesRequest) Reset()         { *m = ListBundlesRequest{} }
@@ -280,6 +291,13 @@ func (m *ListBundlesRequest) GetPagination() *Pagination {
 	return nil
 }
 
+func (m *ListBundlesRequest) GetTolerateStale() bool {
+	if m != nil {
+		return m.TolerateStale
+	}
+	return false
+}
+
 type ListBundlesResponse struct {
 	Bundles              []*common.Bundle `protobuf:"bytes,1,rep,name=bundles,proto3" json:"bundles,omitempty"`
 	Pagination           *Pagination      `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
@@ -1112,6 +1130,7 @@ type ListAttestedNodesRequest struct {
 	BySelectorMatch      *BySelectors         `protobuf:"bytes,4,opt,name=by_selector_match,json=bySelectorMatch,proto3" json:"by_selector_match,omitempty"`
 	ByBanned             *wrappers.BoolValue  `protobuf:"bytes,5,opt,name=by_banned,json=byBanned,proto3" json:"by_banned,omitempty"`
 	FetchSelectors       bool                 `protobuf:"varint,6,opt,name=fetch_selectors,json=fetchSelectors,proto3" json:"fetch_selectors,omitempty"`
+	ByExpiresAfter       *wrappers.Int64Value `protobuf:"bytes,7,opt,name=by_expires_after,json=byExpiresAfter,proto3" json:"by_expires_after,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -1184,6 +1203,13 @@ func (m *ListAttestedNodesRequest) GetFetchSelectors() bool {
 	return false
 }
 
+func (m *ListAttestedNodesRequest) GetByExpiresAfter() *wrappers.Int64Value {
+	if m != nil {
+		return m.ByExpiresAfter
+	}
+	return nil
+}
+
 type ListAttestedNodesResponse struct {
 	Nodes                []*common.AttestedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
 	Pagination           *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
@@ -1237,6 +1263,7 @@ type UpdateAttestedNodeRequest struct {
 	CertNotAfter         int64    `protobuf:"varint,3,opt,name=cert_not_after,json=certNotAfter,proto3" json:"cert_not_after,omitempty"`
 	NewCertSerialNumber  string   `protobuf:"bytes,4,opt,name=new_cert_serial_number,json=newCertSerialNumber,proto3" json:"new_cert_serial_number,omitempty"`
 	NewCertNotAfter      int64    `protobuf:"varint,5,opt,name=new_cert_not_after,json=newCertNotAfter,proto3" json:"new_cert_not_after,omitempty"`
+	ForceRotation        bool     `protobuf:"varint,6,opt,name=force_rotation,json=forceRotation,proto3" json:"force_rotation,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1302,6 +1329,13 @@ func (m *UpdateAttestedNodeRequest) GetNewCertNotAfter() int64 {
 	return 0
 }
 
+func (m *UpdateAttestedNodeRequest) GetForceRotation() bool {
+	if m != nil {
+		return m.ForceRotation
+	}
+	return false
+}
+
 type UpdateAttestedNodeResponse struct {
 	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
@@ -1341,6 +1375,84 @@ func (m *UpdateAttestedNodeResponse) GetNode() *common.AttestedNode {
 	return nil
 }
 
+type ForceAttestedNodeRotationRequest struct {
+	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ForceAttestedNodeRotationRequest) Reset()         { *m = ForceAttestedNodeRotationRequest{} }
+func (m *ForceAttestedNodeRotationRequest) String() string { return proto.CompactTextString(m) }
+func (*ForceAttestedNodeRotationRequest) ProtoMessage()    {}
+func (*ForceAttestedNodeRotationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{29}
+}
+
+func (m *ForceAttestedNodeRotationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForceAttestedNodeRotationRequest.Unmarshal(m, b)
+}
+func (m *ForceAttestedNodeRotationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForceAttestedNodeRotationRequest.Marshal(b, m, deterministic)
+}
+func (m *ForceAttestedNodeRotationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForceAttestedNodeRotationRequest.Merge(m, src)
+}
+func (m *ForceAttestedNodeRotationRequest) XXX_Size() int {
+	return xxx_messageInfo_ForceAttestedNodeRotationRequest.Size(m)
+}
+func (m *ForceAttestedNodeRotationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForceAttestedNodeRotationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForceAttestedNodeRotationRequest proto.InternalMessageInfo
+
+func (m *ForceAttestedNodeRotationRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+type ForceAttestedNodeRotationResponse struct {
+	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ForceAttestedNodeRotationResponse) Reset()         { *m = ForceAttestedNodeRotationResponse{} }
+func (m *ForceAttestedNodeRotationResponse) String() string { return proto.CompactTextString(m) }
+func (*ForceAttestedNodeRotationResponse) ProtoMessage()    {}
+func (*ForceAttestedNodeRotationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{30}
+}
+
+func (m *ForceAttestedNodeRotationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForceAttestedNodeRotationResponse.Unmarshal(m, b)
+}
+func (m *ForceAttestedNodeRotationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForceAttestedNodeRotationResponse.Marshal(b, m, deterministic)
+}
+func (m *ForceAttestedNodeRotationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForceAttestedNodeRotationResponse.Merge(m, src)
+}
+func (m *ForceAttestedNodeRotationResponse) XXX_Size() int {
+	return xxx_messageInfo_ForceAttestedNodeRotationResponse.Size(m)
+}
+func (m *ForceAttestedNodeRotationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForceAttestedNodeRotationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForceAttestedNodeRotationResponse proto.InternalMessageInfo
+
+func (m *ForceAttestedNodeRotationResponse) GetNode() *common.AttestedNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
 type DeleteAttestedNodeRequest struct {
 	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1352,7 +1464,7 @@ func (m *DeleteAttestedNodeRequest) Reset()         { *m = DeleteAttestedNodeReq
 func (m *DeleteAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteAttestedNodeRequest) ProtoMessage()    {}
 func (*DeleteAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{29}
+	return fileDescriptor_4d9f80f01a852be0, []int{31}
 }
 
 func (m *DeleteAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
@@ -1391,7 +1503,7 @@ func (m *DeleteAttestedNodeResponse) Reset()         { *m = DeleteAttestedNodeRe
 func (m *DeleteAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteAttestedNodeResponse) ProtoMessage()    {}
 func (*DeleteAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{30}
+	return fileDescriptor_4d9f80f01a852be0, []int{32}
 }
 
 func (m *DeleteAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
@@ -1419,6 +1531,79 @@ func (m *DeleteAttestedNodeResponse) GetNode() *common.AttestedNode {
 	return nil
 }
 
+type PruneAttestedNodesRequest struct {
+	// Attested nodes whose SVID expired before this time (seconds since the
+	// Unix epoch) are removed, along with their selectors. Banned nodes are
+	// kept so they cannot attest again.
+	ExpiresBefore        int64    `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneAttestedNodesRequest) Reset()         { *m = PruneAttestedNodesRequest{} }
+func (m *PruneAttestedNodesRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneAttestedNodesRequest) ProtoMessage()    {}
+func (*PruneAttestedNodesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{33}
+}
+
+func (m *PruneAttestedNodesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneAttestedNodesRequest.Unmarshal(m, b)
+}
+func (m *PruneAttestedNodesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneAttestedNodesRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneAttestedNodesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneAttestedNodesRequest.Merge(m, src)
+}
+func (m *PruneAttestedNodesRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneAttestedNodesRequest.Size(m)
+}
+func (m *PruneAttestedNodesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneAttestedNodesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneAttestedNodesRequest proto.InternalMessageInfo
+
+func (m *PruneAttestedNodesRequest) GetExpiresBefore() int64 {
+	if m != nil {
+		return m.ExpiresBefore
+	}
+	return 0
+}
+
+type PruneAttestedNodesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneAttestedNodesResponse) Reset()         { *m = PruneAttestedNodesResponse{} }
+func (m *PruneAttestedNodesResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneAttestedNodesResponse) ProtoMessage()    {}
+func (*PruneAttestedNodesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{34}
+}
+
+func (m *PruneAttestedNodesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneAttestedNodesResponse.Unmarshal(m, b)
+}
+func (m *PruneAttestedNodesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneAttestedNodesResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneAttestedNodesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneAttestedNodesResponse.Merge(m, src)
+}
+func (m *PruneAttestedNodesResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneAttestedNodesResponse.Size(m)
+}
+func (m *PruneAttestedNodesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneAttestedNodesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneAttestedNodesResponse proto.InternalMessageInfo
+
 type CreateRegistrationEntryRequest struct {
 	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
@@ -1430,7 +1615,7 @@ func (m *CreateRegistrationEntryRequest) Reset()         { *m = CreateRegistrati
 func (m *CreateRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRegistrationEntryRequest) ProtoMessage()    {}
 func (*CreateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{31}
+	return fileDescriptor_4d9f80f01a852be0, []int{35}
 }
 
 func (m *CreateRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1469,7 +1654,7 @@ func (m *CreateRegistrationEntryResponse) Reset()         { *m = CreateRegistrat
 func (m *CreateRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateRegistrationEntryResponse) ProtoMessage()    {}
 func (*CreateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{32}
+	return fileDescriptor_4d9f80f01a852be0, []int{36}
 }
 
 func (m *CreateRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1508,7 +1693,7 @@ func (m *FetchRegistrationEntryRequest) Reset()         { *m = FetchRegistration
 func (m *FetchRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchRegistrationEntryRequest) ProtoMessage()    {}
 func (*FetchRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{33}
+	return fileDescriptor_4d9f80f01a852be0, []int{37}
 }
 
 func (m *FetchRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1547,7 +1732,7 @@ func (m *FetchRegistrationEntryResponse) Reset()         { *m = FetchRegistratio
 func (m *FetchRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchRegistrationEntryResponse) ProtoMessage()    {}
 func (*FetchRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{34}
+	return fileDescriptor_4d9f80f01a852be0, []int{38}
 }
 
 func (m *FetchRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1587,7 +1772,7 @@ func (m *BySelectors) Reset()         { *m = BySelectors{} }
 func (m *BySelectors) String() string { return proto.CompactTextString(m) }
 func (*BySelectors) ProtoMessage()    {}
 func (*BySelectors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{35}
+	return fileDescriptor_4d9f80f01a852be0, []int{39}
 }
 
 func (m *BySelectors) XXX_Unmarshal(b []byte) error {
@@ -1634,7 +1819,7 @@ func (m *Pagination) Reset()         { *m = Pagination{} }
 func (m *Pagination) String() string { return proto.CompactTextString(m) }
 func (*Pagination) ProtoMessage()    {}
 func (*Pagination) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{36}
+	return fileDescriptor_4d9f80f01a852be0, []int{40}
 }
 
 func (m *Pagination) XXX_Unmarshal(b []byte) error {
@@ -1685,7 +1870,7 @@ func (m *ListRegistrationEntriesRequest) Reset()         { *m = ListRegistration
 func (m *ListRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRegistrationEntriesRequest) ProtoMessage()    {}
 func (*ListRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{37}
+	return fileDescriptor_4d9f80f01a852be0, []int{41}
 }
 
 func (m *ListRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
@@ -1753,7 +1938,7 @@ func (m *ListRegistrationEntriesResponse) Reset()         { *m = ListRegistratio
 func (m *ListRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListRegistrationEntriesResponse) ProtoMessage()    {}
 func (*ListRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{38}
+	return fileDescriptor_4d9f80f01a852be0, []int{42}
 }
 
 func (m *ListRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
@@ -1789,17 +1974,20 @@ func (m *ListRegistrationEntriesResponse) GetPagination() *Pagination {
 }
 
 type UpdateRegistrationEntryRequest struct {
-	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Entry *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// If set, the update fails with ABORTED unless the entry is at this
+	// revision number.
+	RevisionNumber       *wrappers.Int64Value `protobuf:"bytes,2,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *UpdateRegistrationEntryRequest) Reset()         { *m = UpdateRegistrationEntryRequest{} }
 func (m *UpdateRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRegistrationEntryRequest) ProtoMessage()    {}
 func (*UpdateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{39}
+	return fileDescriptor_4d9f80f01a852be0, []int{43}
 }
 
 func (m *UpdateRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1827,6 +2015,13 @@ func (m *UpdateRegistrationEntryRequest) GetEntry() *common.RegistrationEntry {
 	return nil
 }
 
+func (m *UpdateRegistrationEntryRequest) GetRevisionNumber() *wrappers.Int64Value {
+	if m != nil {
+		return m.RevisionNumber
+	}
+	return nil
+}
+
 type UpdateRegistrationEntryResponse struct {
 	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
@@ -1838,7 +2033,7 @@ func (m *UpdateRegistrationEntryResponse) Reset()         { *m = UpdateRegistrat
 func (m *UpdateRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateRegistrationEntryResponse) ProtoMessage()    {}
 func (*UpdateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{40}
+	return fileDescriptor_4d9f80f01a852be0, []int{44}
 }
 
 func (m *UpdateRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1867,17 +2062,20 @@ func (m *UpdateRegistrationEntryResponse) GetEntry() *common.RegistrationEntry {
 }
 
 type DeleteRegistrationEntryRequest struct {
-	EntryId              string   `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	EntryId string `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	// If set, the deletion fails with ABORTED unless the entry is at this
+	// revision number.
+	RevisionNumber       *wrappers.Int64Value `protobuf:"bytes,2,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *DeleteRegistrationEntryRequest) Reset()         { *m = DeleteRegistrationEntryRequest{} }
 func (m *DeleteRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRegistrationEntryRequest) ProtoMessage()    {}
 func (*DeleteRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{41}
+	return fileDescriptor_4d9f80f01a852be0, []int{45}
 }
 
 func (m *DeleteRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1905,6 +2103,13 @@ func (m *DeleteRegistrationEntryRequest) GetEntryId() string {
 	return ""
 }
 
+func (m *DeleteRegistrationEntryRequest) GetRevisionNumber() *wrappers.Int64Value {
+	if m != nil {
+		return m.RevisionNumber
+	}
+	return nil
+}
+
 type DeleteRegistrationEntryResponse struct {
 	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
@@ -1916,7 +2121,7 @@ func (m *DeleteRegistrationEntryResponse) Reset()         { *m = DeleteRegistrat
 func (m *DeleteRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteRegistrationEntryResponse) ProtoMessage()    {}
 func (*DeleteRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{42}
+	return fileDescriptor_4d9f80f01a852be0, []int{46}
 }
 
 func (m *DeleteRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1955,7 +2160,7 @@ func (m *PruneRegistrationEntriesRequest) Reset()         { *m = PruneRegistrati
 func (m *PruneRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*PruneRegistrationEntriesRequest) ProtoMessage()    {}
 func (*PruneRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{43}
+	return fileDescriptor_4d9f80f01a852be0, []int{47}
 }
 
 func (m *PruneRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
@@ -1993,7 +2198,7 @@ func (m *PruneRegistrationEntriesResponse) Reset()         { *m = PruneRegistrat
 func (m *PruneRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*PruneRegistrationEntriesResponse) ProtoMessage()    {}
 func (*PruneRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{44}
+	return fileDescriptor_4d9f80f01a852be0, []int{48}
 }
 
 func (m *PruneRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
@@ -2018,7 +2223,12 @@ type JoinToken struct {
 	// Token value
 	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	// Expiration in seconds since unix epoch
-	Expiry               int64    `protobuf:"varint,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	Expiry int64 `protobuf:"varint,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	// Maximum number of times the token can be used. Zero means the token
+	// can only be used once.
+	MaxUses int32 `protobuf:"varint,3,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	// Number of times the token has been used
+	UseCount             int32    `protobuf:"varint,4,opt,name=use_count,json=useCount,proto3" json:"use_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2028,7 +2238,7 @@ func (m *JoinToken) Reset()         { *m = JoinToken{} }
 func (m *JoinToken) String() string { return proto.CompactTextString(m) }
 func (*JoinToken) ProtoMessage()    {}
 func (*JoinToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{45}
+	return fileDescriptor_4d9f80f01a852be0, []int{49}
 }
 
 func (m *JoinToken) XXX_Unmarshal(b []byte) error {
@@ -2063,6 +2273,20 @@ func (m *JoinToken) GetExpiry() int64 {
 	return 0
 }
 
+func (m *JoinToken) GetMaxUses() int32 {
+	if m != nil {
+		return m.MaxUses
+	}
+	return 0
+}
+
+func (m *JoinToken) GetUseCount() int32 {
+	if m != nil {
+		return m.UseCount
+	}
+	return 0
+}
+
 type CreateJoinTokenRequest struct {
 	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -2074,7 +2298,7 @@ func (m *CreateJoinTokenRequest) Reset()         { *m = CreateJoinTokenRequest{}
 func (m *CreateJoinTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateJoinTokenRequest) ProtoMessage()    {}
 func (*CreateJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{46}
+	return fileDescriptor_4d9f80f01a852be0, []int{50}
 }
 
 func (m *CreateJoinTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -2113,7 +2337,7 @@ func (m *CreateJoinTokenResponse) Reset()         { *m = CreateJoinTokenResponse
 func (m *CreateJoinTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateJoinTokenResponse) ProtoMessage()    {}
 func (*CreateJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{47}
+	return fileDescriptor_4d9f80f01a852be0, []int{51}
 }
 
 func (m *CreateJoinTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -2152,7 +2376,7 @@ func (m *FetchJoinTokenRequest) Reset()         { *m = FetchJoinTokenRequest{} }
 func (m *FetchJoinTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchJoinTokenRequest) ProtoMessage()    {}
 func (*FetchJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{48}
+	return fileDescriptor_4d9f80f01a852be0, []int{52}
 }
 
 func (m *FetchJoinTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -2191,7 +2415,7 @@ func (m *FetchJoinTokenResponse) Reset()         { *m = FetchJoinTokenResponse{}
 func (m *FetchJoinTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchJoinTokenResponse) ProtoMessage()    {}
 func (*FetchJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{49}
+	return fileDescriptor_4d9f80f01a852be0, []int{53}
 }
 
 func (m *FetchJoinTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -2230,7 +2454,7 @@ func (m *DeleteJoinTokenRequest) Reset()         { *m = DeleteJoinTokenRequest{}
 func (m *DeleteJoinTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteJoinTokenRequest) ProtoMessage()    {}
 func (*DeleteJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{50}
+	return fileDescriptor_4d9f80f01a852be0, []int{54}
 }
 
 func (m *DeleteJoinTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -2269,7 +2493,7 @@ func (m *DeleteJoinTokenResponse) Reset()         { *m = DeleteJoinTokenResponse
 func (m *DeleteJoinTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteJoinTokenResponse) ProtoMessage()    {}
 func (*DeleteJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{51}
+	return fileDescriptor_4d9f80f01a852be0, []int{55}
 }
 
 func (m *DeleteJoinTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -2297,6 +2521,86 @@ func (m *DeleteJoinTokenResponse) GetJoinToken() *JoinToken {
 	return nil
 }
 
+type ConsumeJoinTokenRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConsumeJoinTokenRequest) Reset()         { *m = ConsumeJoinTokenRequest{} }
+func (m *ConsumeJoinTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*ConsumeJoinTokenRequest) ProtoMessage()    {}
+func (*ConsumeJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{56}
+}
+
+func (m *ConsumeJoinTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsumeJoinTokenRequest.Unmarshal(m, b)
+}
+func (m *ConsumeJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsumeJoinTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *ConsumeJoinTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsumeJoinTokenRequest.Merge(m, src)
+}
+func (m *ConsumeJoinTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_ConsumeJoinTokenRequest.Size(m)
+}
+func (m *ConsumeJoinTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsumeJoinTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsumeJoinTokenRequest proto.InternalMessageInfo
+
+func (m *ConsumeJoinTokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type ConsumeJoinTokenResponse struct {
+	// The join token with the use recorded. Unset if the token does not
+	// exist. The token is deleted once it has been used max_uses times.
+	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ConsumeJoinTokenResponse) Reset()         { *m = ConsumeJoinTokenResponse{} }
+func (m *ConsumeJoinTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*ConsumeJoinTokenResponse) ProtoMessage()    {}
+func (*ConsumeJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{57}
+}
+
+func (m *ConsumeJoinTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsumeJoinTokenResponse.Unmarshal(m, b)
+}
+func (m *ConsumeJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsumeJoinTokenResponse.Marshal(b, m, deterministic)
+}
+func (m *ConsumeJoinTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsumeJoinTokenResponse.Merge(m, src)
+}
+func (m *ConsumeJoinTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_ConsumeJoinTokenResponse.Size(m)
+}
+func (m *ConsumeJoinTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsumeJoinTokenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsumeJoinTokenResponse proto.InternalMessageInfo
+
+func (m *ConsumeJoinTokenResponse) GetJoinToken() *JoinToken {
+	if m != nil {
+		return m.JoinToken
+	}
+	return nil
+}
+
 type PruneJoinTokensRequest struct {
 	ExpiresBefore        int64    `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2308,7 +2612,7 @@ func (m *PruneJoinTokensRequest) Reset()         { *m = PruneJoinTokensRequest{}
 func (m *PruneJoinTokensRequest) String() string { return proto.CompactTextString(m) }
 func (*PruneJoinTokensRequest) ProtoMessage()    {}
 func (*PruneJoinTokensRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{52}
+	return fileDescriptor_4d9f80f01a852be0, []int{58}
 }
 
 func (m *PruneJoinTokensRequest) XXX_Unmarshal(b []byte) error {
@@ -2346,7 +2650,7 @@ func (m *PruneJoinTokensResponse) Reset()         { *m = PruneJoinTokensResponse
 func (m *PruneJoinTokensResponse) String() string { return proto.CompactTextString(m) }
 func (*PruneJoinTokensResponse) ProtoMessage()    {}
 func (*PruneJoinTokensResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4d9f80f01a852be0, []int{53}
+	return fileDescriptor_4d9f80f01a852be0, []int{59}
 }
 
 func (m *PruneJoinTokensResponse) XXX_Unmarshal(b []byte) error {
@@ -2367,29 +2671,460 @@ func (m *PruneJoinTokensResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PruneJoinTokensResponse proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterEnum("spire.server.datastore.DeleteBundleRequest_Mode", DeleteBundleRequest_Mode_name, DeleteBundleRequest_Mode_value)
-	proto.RegisterEnum("spire.server.datastore.BySelectors_MatchBehavior", BySelectors_MatchBehavior_name, BySelectors_MatchBehavior_value)
-	proto.RegisterType((*CreateBundleRequest)(nil), "spire.server.datastore.CreateBundleRequest")
-	proto.RegisterType((*CreateBundleResponse)(nil), "spire.server.datastore.CreateBundleResponse")
-	proto.RegisterType((*FetchBundleRequest)(nil), "spire.server.datastore.FetchBundleRequest")
-	proto.RegisterType((*FetchBundleResponse)(nil), "spire.server.datastore.FetchBundleResponse")
-	proto.RegisterType((*ListBundlesRequest)(nil), "spire.server.datastore.ListBundlesRequest")
-	proto.RegisterType((*ListBundlesResponse)(nil), "spire.server.datastore.ListBundlesResponse")
-	proto.RegisterType((*UpdateBundleRequest)(nil), "spire.server.datastore.UpdateBundleRequest")
-	proto.RegisterType((*UpdateBundleResponse)(nil), "spire.server.datastore.UpdateBundleResponse")
-	proto.RegisterType((*SetBundleRequest)(nil), "spire.server.datastore.SetBundleRequest")
-	proto.RegisterType((*SetBundleResponse)(nil), "spire.server.datastore.SetBundleResponse")
-	proto.RegisterType((*AppendBundleRequest)(nil), "spire.server.datastore.AppendBundleRequest")
-	proto.RegisterType((*AppendBundleResponse)(nil), "spire.server.datastore.AppendBundleResponse")
-	proto.RegisterType((*DeleteBundleRequest)(nil), "spire.server.datastore.DeleteBundleRequest")
-	proto.RegisterType((*DeleteBundleResponse)(nil), "spire.server.datastore.DeleteBundleResponse")
-	proto.RegisterType((*PruneBundleRequest)(nil), "spire.server.datastore.PruneBundleRequest")
-	proto.RegisterType((*PruneBundleResponse)(nil), "spire.server.datastore.PruneBundleResponse")
-	proto.RegisterType((*NodeSelectors)(nil), "spire.server.datastore.NodeSelectors")
-	proto.RegisterType((*SetNodeSelectorsRequest)(nil), "spire.server.datastore.SetNodeSelectorsRequest")
-	proto.RegisterType((*SetNodeSelectorsResponse)(nil), "spire.server.datastore.SetNodeSelectorsResponse")
-	proto.RegisterType((*GetNodeSelectorsRequest)(nil), "spire.server.datastore.GetNodeSelectorsRequest")
+type IssuedX509SVID struct {
+	// SPIFFE ID of the SVID
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Serial number of the SVID, in decimal
+	SerialNumber string `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	// Issuance time in seconds since unix epoch
+	IssuedAt int64 `protobuf:"varint,3,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	// Expiration in seconds since unix epoch
+	ExpiresAt int64 `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Key ID (hex encoded authority key identifier) of the CA that signed
+	// the SVID
+	CaKeyId string `protobuf:"bytes,5,opt,name=ca_key_id,json=caKeyId,proto3" json:"ca_key_id,omitempty"`
+	// SPIFFE ID of the agent the SVID was issued to, if any. Empty for
+	// SVIDs minted through the registration API.
+	AgentId string `protobuf:"bytes,6,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// ID of the registration entry the SVID was issued for, if any. Empty
+	// for agent SVIDs.
+	EntryId              string   `protobuf:"bytes,7,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssuedX509SVID) Reset()         { *m = IssuedX509SVID{} }
+func (m *IssuedX509SVID) String() string { return proto.CompactTextString(m) }
+func (*IssuedX509SVID) ProtoMessage()    {}
+func (*IssuedX509SVID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{60}
+}
+
+func (m *IssuedX509SVID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssuedX509SVID.Unmarshal(m, b)
+}
+func (m *IssuedX509SVID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssuedX509SVID.Marshal(b, m, deterministic)
+}
+func (m *IssuedX509SVID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssuedX509SVID.Merge(m, src)
+}
+func (m *IssuedX509SVID) XXX_Size() int {
+	return xxx_messageInfo_IssuedX509SVID.Size(m)
+}
+func (m *IssuedX509SVID) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssuedX509SVID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssuedX509SVID proto.InternalMessageInfo
+
+func (m *IssuedX509SVID) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *IssuedX509SVID) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+func (m *IssuedX509SVID) GetIssuedAt() int64 {
+	if m != nil {
+		return m.IssuedAt
+	}
+	return 0
+}
+
+func (m *IssuedX509SVID) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *IssuedX509SVID) GetCaKeyId() string {
+	if m != nil {
+		return m.CaKeyId
+	}
+	return ""
+}
+
+func (m *IssuedX509SVID) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+func (m *IssuedX509SVID) GetEntryId() string {
+	if m != nil {
+		return m.EntryId
+	}
+	return ""
+}
+
+type CreateIssuedX509SVIDRequest struct {
+	Svid                 *IssuedX509SVID `protobuf:"bytes,1,opt,name=svid,proto3" json:"svid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CreateIssuedX509SVIDRequest) Reset()         { *m = CreateIssuedX509SVIDRequest{} }
+func (m *CreateIssuedX509SVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateIssuedX509SVIDRequest) ProtoMessage()    {}
+func (*CreateIssuedX509SVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{61}
+}
+
+func (m *CreateIssuedX509SVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateIssuedX509SVIDRequest.Unmarshal(m, b)
+}
+func (m *CreateIssuedX509SVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateIssuedX509SVIDRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateIssuedX509SVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateIssuedX509SVIDRequest.Merge(m, src)
+}
+func (m *CreateIssuedX509SVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateIssuedX509SVIDRequest.Size(m)
+}
+func (m *CreateIssuedX509SVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateIssuedX509SVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateIssuedX509SVIDRequest proto.InternalMessageInfo
+
+func (m *CreateIssuedX509SVIDRequest) GetSvid() *IssuedX509SVID {
+	if m != nil {
+		return m.Svid
+	}
+	return nil
+}
+
+type CreateIssuedX509SVIDResponse struct {
+	Svid                 *IssuedX509SVID `protobuf:"bytes,1,opt,name=svid,proto3" json:"svid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CreateIssuedX509SVIDResponse) Reset()         { *m = CreateIssuedX509SVIDResponse{} }
+func (m *CreateIssuedX509SVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateIssuedX509SVIDResponse) ProtoMessage()    {}
+func (*CreateIssuedX509SVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{62}
+}
+
+func (m *CreateIssuedX509SVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateIssuedX509SVIDResponse.Unmarshal(m, b)
+}
+func (m *CreateIssuedX509SVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateIssuedX509SVIDResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateIssuedX509SVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateIssuedX509SVIDResponse.Merge(m, src)
+}
+func (m *CreateIssuedX509SVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateIssuedX509SVIDResponse.Size(m)
+}
+func (m *CreateIssuedX509SVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateIssuedX509SVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateIssuedX509SVIDResponse proto.InternalMessageInfo
+
+func (m *CreateIssuedX509SVIDResponse) GetSvid() *IssuedX509SVID {
+	if m != nil {
+		return m.Svid
+	}
+	return nil
+}
+
+type ListIssuedX509SVIDsRequest struct {
+	BySpiffeId *wrappers.StringValue `protobuf:"bytes,1,opt,name=by_spiffe_id,json=bySpiffeId,proto3" json:"by_spiffe_id,omitempty"`
+	ByAgentId  *wrappers.StringValue `protobuf:"bytes,2,opt,name=by_agent_id,json=byAgentId,proto3" json:"by_agent_id,omitempty"`
+	// Only SVIDs that expire after this time (seconds since the Unix epoch)
+	// are returned
+	ByExpiresAfter       *wrappers.Int64Value `protobuf:"bytes,3,opt,name=by_expires_after,json=byExpiresAfter,proto3" json:"by_expires_after,omitempty"`
+	Pagination           *Pagination          `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListIssuedX509SVIDsRequest) Reset()         { *m = ListIssuedX509SVIDsRequest{} }
+func (m *ListIssuedX509SVIDsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListIssuedX509SVIDsRequest) ProtoMessage()    {}
+func (*ListIssuedX509SVIDsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{63}
+}
+
+func (m *ListIssuedX509SVIDsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListIssuedX509SVIDsRequest.Unmarshal(m, b)
+}
+func (m *ListIssuedX509SVIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListIssuedX509SVIDsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListIssuedX509SVIDsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListIssuedX509SVIDsRequest.Merge(m, src)
+}
+func (m *ListIssuedX509SVIDsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListIssuedX509SVIDsRequest.Size(m)
+}
+func (m *ListIssuedX509SVIDsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListIssuedX509SVIDsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListIssuedX509SVIDsRequest proto.InternalMessageInfo
+
+func (m *ListIssuedX509SVIDsRequest) GetBySpiffeId() *wrappers.StringValue {
+	if m != nil {
+		return m.BySpiffeId
+	}
+	return nil
+}
+
+func (m *ListIssuedX509SVIDsRequest) GetByAgentId() *wrappers.StringValue {
+	if m != nil {
+		return m.ByAgentId
+	}
+	return nil
+}
+
+func (m *ListIssuedX509SVIDsRequest) GetByExpiresAfter() *wrappers.Int64Value {
+	if m != nil {
+		return m.ByExpiresAfter
+	}
+	return nil
+}
+
+func (m *ListIssuedX509SVIDsRequest) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type ListIssuedX509SVIDsResponse struct {
+	Svids                []*IssuedX509SVID `protobuf:"bytes,1,rep,name=svids,proto3" json:"svids,omitempty"`
+	Pagination           *Pagination       `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ListIssuedX509SVIDsResponse) Reset()         { *m = ListIssuedX509SVIDsResponse{} }
+func (m *ListIssuedX509SVIDsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListIssuedX509SVIDsResponse) ProtoMessage()    {}
+func (*ListIssuedX509SVIDsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{64}
+}
+
+func (m *ListIssuedX509SVIDsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListIssuedX509SVIDsResponse.Unmarshal(m, b)
+}
+func (m *ListIssuedX509SVIDsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListIssuedX509SVIDsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListIssuedX509SVIDsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListIssuedX509SVIDsResponse.Merge(m, src)
+}
+func (m *ListIssuedX509SVIDsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListIssuedX509SVIDsResponse.Size(m)
+}
+func (m *ListIssuedX509SVIDsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListIssuedX509SVIDsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListIssuedX509SVIDsResponse proto.InternalMessageInfo
+
+func (m *ListIssuedX509SVIDsResponse) GetSvids() []*IssuedX509SVID {
+	if m != nil {
+		return m.Svids
+	}
+	return nil
+}
+
+func (m *ListIssuedX509SVIDsResponse) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type PruneIssuedX509SVIDsRequest struct {
+	// Records of SVIDs that expired before this time (seconds since the Unix
+	// epoch) are removed
+	ExpiresBefore        int64    `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneIssuedX509SVIDsRequest) Reset()         { *m = PruneIssuedX509SVIDsRequest{} }
+func (m *PruneIssuedX509SVIDsRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneIssuedX509SVIDsRequest) ProtoMessage()    {}
+func (*PruneIssuedX509SVIDsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{65}
+}
+
+func (m *PruneIssuedX509SVIDsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneIssuedX509SVIDsRequest.Unmarshal(m, b)
+}
+func (m *PruneIssuedX509SVIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneIssuedX509SVIDsRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneIssuedX509SVIDsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneIssuedX509SVIDsRequest.Merge(m, src)
+}
+func (m *PruneIssuedX509SVIDsRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneIssuedX509SVIDsRequest.Size(m)
+}
+func (m *PruneIssuedX509SVIDsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneIssuedX509SVIDsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneIssuedX509SVIDsRequest proto.InternalMessageInfo
+
+func (m *PruneIssuedX509SVIDsRequest) GetExpiresBefore() int64 {
+	if m != nil {
+		return m.ExpiresBefore
+	}
+	return 0
+}
+
+type PruneIssuedX509SVIDsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneIssuedX509SVIDsResponse) Reset()         { *m = PruneIssuedX509SVIDsResponse{} }
+func (m *PruneIssuedX509SVIDsResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneIssuedX509SVIDsResponse) ProtoMessage()    {}
+func (*PruneIssuedX509SVIDsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{66}
+}
+
+func (m *PruneIssuedX509SVIDsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneIssuedX509SVIDsResponse.Unmarshal(m, b)
+}
+func (m *PruneIssuedX509SVIDsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneIssuedX509SVIDsResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneIssuedX509SVIDsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneIssuedX509SVIDsResponse.Merge(m, src)
+}
+func (m *PruneIssuedX509SVIDsResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneIssuedX509SVIDsResponse.Size(m)
+}
+func (m *PruneIssuedX509SVIDsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneIssuedX509SVIDsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneIssuedX509SVIDsResponse proto.InternalMessageInfo
+
+type GetInterfaceVersionRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetInterfaceVersionRequest) Reset()         { *m = GetInterfaceVersionRequest{} }
+func (m *GetInterfaceVersionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetInterfaceVersionRequest) ProtoMessage()    {}
+func (*GetInterfaceVersionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{67}
+}
+
+func (m *GetInterfaceVersionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetInterfaceVersionRequest.Unmarshal(m, b)
+}
+func (m *GetInterfaceVersionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetInterfaceVersionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetInterfaceVersionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetInterfaceVersionRequest.Merge(m, src)
+}
+func (m *GetInterfaceVersionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetInterfaceVersionRequest.Size(m)
+}
+func (m *GetInterfaceVersionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetInterfaceVersionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetInterfaceVersionRequest proto.InternalMessageInfo
+
+type GetInterfaceVersionResponse struct {
+	// The version of the DataStore service implemented by the plugin. SPIRE
+	// server refuses to use plugins implementing a different version.
+	Version              uint32   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetInterfaceVersionResponse) Reset()         { *m = GetInterfaceVersionResponse{} }
+func (m *GetInterfaceVersionResponse) String() string { return proto.CompactTextString(m) }
+func (*GetInterfaceVersionResponse) ProtoMessage()    {}
+func (*GetInterfaceVersionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d9f80f01a852be0, []int{68}
+}
+
+func (m *GetInterfaceVersionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetInterfaceVersionResponse.Unmarshal(m, b)
+}
+func (m *GetInterfaceVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetInterfaceVersionResponse.Marshal(b, m, deterministic)
+}
+func (m *GetInterfaceVersionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetInterfaceVersionResponse.Merge(m, src)
+}
+func (m *GetInterfaceVersionResponse) XXX_Size() int {
+	return xxx_messageInfo_GetInterfaceVersionResponse.Size(m)
+}
+func (m *GetInterfaceVersionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetInterfaceVersionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetInterfaceVersionResponse proto.InternalMessageInfo
+
+func (m *GetInterfaceVersionResponse) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("spire.server.datastore.DeleteBundleRequest_Mode", DeleteBundleRequest_Mode_name, DeleteBundleRequest_Mode_value)
+	proto.RegisterEnum("spire.server.datastore.BySelectors_MatchBehavior", BySelectors_MatchBehavior_name, BySelectors_MatchBehavior_value)
+	proto.RegisterType((*CreateBundleRequest)(nil), "spire.server.datastore.CreateBundleRequest")
+	proto.RegisterType((*CreateBundleResponse)(nil), "spire.server.datastore.CreateBundleResponse")
+	proto.RegisterType((*FetchBundleRequest)(nil), "spire.server.datastore.FetchBundleRequest")
+	proto.RegisterType((*FetchBundleResponse)(nil), "spire.server.datastore.FetchBundleResponse")
+	proto.RegisterType((*ListBundlesRequest)(nil), "spire.server.datastore.ListBundlesRequest")
+	proto.RegisterType((*ListBundlesResponse)(nil), "spire.server.datastore.ListBundlesResponse")
+	proto.RegisterType((*UpdateBundleRequest)(nil), "spire.server.datastore.UpdateBundleRequest")
+	proto.RegisterType((*UpdateBundleResponse)(nil), "spire.server.datastore.UpdateBundleResponse")
+	proto.RegisterType((*SetBundleRequest)(nil), "spire.server.datastore.SetBundleRequest")
+	proto.RegisterType((*SetBundleResponse)(nil), "spire.server.datastore.SetBundleResponse")
+	proto.RegisterType((*AppendBundleRequest)(nil), "spire.server.datastore.AppendBundleRequest")
+	proto.RegisterType((*AppendBundleResponse)(nil), "spire.server.datastore.AppendBundleResponse")
+	proto.RegisterType((*DeleteBundleRequest)(nil), "spire.server.datastore.DeleteBundleRequest")
+	proto.RegisterType((*DeleteBundleResponse)(nil), "spire.server.datastore.DeleteBundleResponse")
+	proto.RegisterType((*PruneBundleRequest)(nil), "spire.server.datastore.PruneBundleRequest")
+	proto.RegisterType((*PruneBundleResponse)(nil), "spire.server.datastore.PruneBundleResponse")
+	proto.RegisterType((*NodeSelectors)(nil), "spire.server.datastore.NodeSelectors")
+	proto.RegisterType((*SetNodeSelectorsRequest)(nil), "spire.server.datastore.SetNodeSelectorsRequest")
+	proto.RegisterType((*SetNodeSelectorsResponse)(nil), "spire.server.datastore.SetNodeSelectorsResponse")
+	proto.RegisterType((*GetNodeSelectorsRequest)(nil), "spire.server.datastore.GetNodeSelectorsRequest")
 	proto.RegisterType((*GetNodeSelectorsResponse)(nil), "spire.server.datastore.GetNodeSelectorsResponse")
 	proto.RegisterType((*CreateAttestedNodeRequest)(nil), "spire.server.datastore.CreateAttestedNodeRequest")
 	proto.RegisterType((*CreateAttestedNodeResponse)(nil), "spire.server.datastore.CreateAttestedNodeResponse")
@@ -2399,8 +3134,12 @@ func init() {
 	proto.RegisterType((*ListAttestedNodesResponse)(nil), "spire.server.datastore.ListAttestedNodesResponse")
 	proto.RegisterType((*UpdateAttestedNodeRequest)(nil), "spire.server.datastore.UpdateAttestedNodeRequest")
 	proto.RegisterType((*UpdateAttestedNodeResponse)(nil), "spire.server.datastore.UpdateAttestedNodeResponse")
+	proto.RegisterType((*ForceAttestedNodeRotationRequest)(nil), "spire.server.datastore.ForceAttestedNodeRotationRequest")
+	proto.RegisterType((*ForceAttestedNodeRotationResponse)(nil), "spire.server.datastore.ForceAttestedNodeRotationResponse")
 	proto.RegisterType((*DeleteAttestedNodeRequest)(nil), "spire.server.datastore.DeleteAttestedNodeRequest")
 	proto.RegisterType((*DeleteAttestedNodeResponse)(nil), "spire.server.datastore.DeleteAttestedNodeResponse")
+	proto.RegisterType((*PruneAttestedNodesRequest)(nil), "spire.server.datastore.PruneAttestedNodesRequest")
+	proto.RegisterType((*PruneAttestedNodesResponse)(nil), "spire.server.datastore.PruneAttestedNodesResponse")
 	proto.RegisterType((*CreateRegistrationEntryRequest)(nil), "spire.server.datastore.CreateRegistrationEntryRequest")
 	proto.RegisterType((*CreateRegistrationEntryResponse)(nil), "spire.server.datastore.CreateRegistrationEntryResponse")
 	proto.RegisterType((*FetchRegistrationEntryRequest)(nil), "spire.server.datastore.FetchRegistrationEntryRequest")
@@ -2422,8 +3161,19 @@ func init() {
 	proto.RegisterType((*FetchJoinTokenResponse)(nil), "spire.server.datastore.FetchJoinTokenResponse")
 	proto.RegisterType((*DeleteJoinTokenRequest)(nil), "spire.server.datastore.DeleteJoinTokenRequest")
 	proto.RegisterType((*DeleteJoinTokenResponse)(nil), "spire.server.datastore.DeleteJoinTokenResponse")
+	proto.RegisterType((*ConsumeJoinTokenRequest)(nil), "spire.server.datastore.ConsumeJoinTokenRequest")
+	proto.RegisterType((*ConsumeJoinTokenResponse)(nil), "spire.server.datastore.ConsumeJoinTokenResponse")
 	proto.RegisterType((*PruneJoinTokensRequest)(nil), "spire.server.datastore.PruneJoinTokensRequest")
 	proto.RegisterType((*PruneJoinTokensResponse)(nil), "spire.server.datastore.PruneJoinTokensResponse")
+	proto.RegisterType((*IssuedX509SVID)(nil), "spire.server.datastore.IssuedX509SVID")
+	proto.RegisterType((*CreateIssuedX509SVIDRequest)(nil), "spire.server.datastore.CreateIssuedX509SVIDRequest")
+	proto.RegisterType((*CreateIssuedX509SVIDResponse)(nil), "spire.server.datastore.CreateIssuedX509SVIDResponse")
+	proto.RegisterType((*ListIssuedX509SVIDsRequest)(nil), "spire.server.datastore.ListIssuedX509SVIDsRequest")
+	proto.RegisterType((*ListIssuedX509SVIDsResponse)(nil), "spire.server.datastore.ListIssuedX509SVIDsResponse")
+	proto.RegisterType((*PruneIssuedX509SVIDsRequest)(nil), "spire.server.datastore.PruneIssuedX509SVIDsRequest")
+	proto.RegisterType((*PruneIssuedX509SVIDsResponse)(nil), "spire.server.datastore.PruneIssuedX509SVIDsResponse")
+	proto.RegisterType((*GetInterfaceVersionRequest)(nil), "spire.server.datastore.GetInterfaceVersionRequest")
+	proto.RegisterType((*GetInterfaceVersionResponse)(nil), "spire.server.datastore.GetInterfaceVersionResponse")
 }
 
 func init() {
@@ -2431,126 +3181,158 @@ func init() {
 }
 
 var fileDescriptor_4d9f80f01a852be0 = []byte{
-	// 1891 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0xef, 0x72, 0xdb, 0xc6,
-	0x11, 0x2f, 0x24, 0x51, 0x11, 0x97, 0x12, 0x29, 0x1d, 0x5d, 0x89, 0x64, 0x5a, 0x49, 0x45, 0x2b,
-	0x37, 0x89, 0x1d, 0x50, 0x56, 0x1c, 0x29, 0x69, 0x33, 0x4d, 0x48, 0x8a, 0x51, 0xd8, 0x4a, 0x8e,
-	0x06, 0x64, 0x1a, 0x8d, 0x33, 0x2d, 0x0a, 0x88, 0x47, 0x0a, 0x36, 0x05, 0xa0, 0xc0, 0xd1, 0x36,
-	0xed, 0x7e, 0xef, 0xd8, 0x33, 0xfd, 0xd0, 0x37, 0xe8, 0x4b, 0xf4, 0x7b, 0xdf, 0xa1, 0xef, 0xd1,
-	0x67, 0xe8, 0xe0, 0x0e, 0x20, 0x00, 0x02, 0x07, 0x91, 0x94, 0xf2, 0x49, 0xc2, 0xde, 0xfe, 0xf9,
-	0xdd, 0xde, 0xed, 0xde, 0xee, 0x0e, 0xe1, 0xbe, 0x63, 0xe9, 0x36, 0xae, 0x3a, 0xd8, 0x7e, 0x81,
-	0xed, 0x6a, 0x57, 0x25, 0xaa, 0x43, 0x4c, 0x1b, 0x07, 0xff, 0x49, 0x96, 0x6d, 0x12, 0x13, 0x6d,
-	0x52, 0x3e, 0x89, 0xf1, 0x49, 0xe3, 0xd5, 0xca, 0x76, 0xdf, 0x34, 0xfb, 0x03, 0x5c, 0xa5, 0x5c,
-	0xda, 0xb0, 0x57, 0x7d, 0x69, 0xab, 0x96, 0x85, 0x6d, 0x87, 0xc9, 0x55, 0x76, 0x99, 0xfe, 0x4b,
-	0xf3, 0xfa, 0xda, 0x34, 0xaa, 0xd6, 0x60, 0xd8, 0xd7, 0xfd, 0x3f, 0x1e, 0x47, 0x39, 0xc2, 0xc1,
-	0xfe, 0xb0, 0x25, 0xb1, 0x01, 0xc5, 0x86, 0x8d, 0x55, 0x82, 0xeb, 0x43, 0xa3, 0x3b, 0xc0, 0x32,
-	0xfe, 0xeb, 0x10, 0x3b, 0x04, 0x3d, 0x84, 0x65, 0x8d, 0x12, 0x4a, 0xc2, 0xae, 0xf0, 0x41, 0xee,
-	0xe0, 0x9e, 0xc4, 0xc0, 0x79, 0xb2, 0x1e, 0xb3, 0xc7, 0x23, 0x1e, 0xc3, 0xbd, 0xa8, 0x12, 0xc7,
-	0x32, 0x0d, 0x07, 0xcf, 0xa8, 0xe5, 0x0b, 0x40, 0x5f, 0x63, 0x72, 0x79, 0x15, 0x45, 0x72, 0x1f,
-	0x0a, 0xc4, 0x1e, 0x3a, 0x44, 0xe9, 0x9a, 0xd7, 0xaa, 0x6e, 0x28, 0x7a, 0x97, 0x2a, 0xcb, 0xca,
-	0x6b, 0x94, 0x7c, 0x4c, 0xa9, 0xad, 0xae, 0xbb, 0x91, 0x88, 0xf4, 0x5c, 0x10, 0x2e, 0x00, 0x9d,
-	0xea, 0x0e, 0x61, 0x54, 0xc7, 0x87, 0x50, 0x07, 0xb0, 0xd4, 0xbe, 0x6e, 0xa8, 0x44, 0x37, 0x0d,
-	0x4f, 0x8f, 0x28, 0x25, 0x9f, 0x96, 0x74, 0x3e, 0xe6, 0x94, 0x43, 0x52, 0xe2, 0x5b, 0x01, 0x8a,
-	0x11, 0xd5, 0x1e, 0x3e, 0x09, 0xde, 0x63, 0xb6, 0x9d, 0x92, 0xb0, 0xbb, 0xc8, 0x05, 0xe8, 0x33,
-	0x4d, 0x60, 0x59, 0x98, 0x0b, 0xcb, 0xdf, 0xa0, 0xf8, 0x9d, 0xd5, 0xbd, 0xdd, 0x99, 0xa3, 0x23,
-	0x00, 0xdd, 0xb0, 0x86, 0x44, 0xb9, 0x56, 0x9d, 0xe7, 0x1e, 0x90, 0x52, 0x92, 0xc4, 0x99, 0xea,
-	0x3c, 0x97, 0xb3, 0x94, 0xd7, 0xfd, 0xd7, 0xbd, 0x2c, 0x51, 0xeb, 0x73, 0x9d, 0xd4, 0x57, 0xb0,
-	0xde, 0xc6, 0xe4, 0x36, 0x97, 0xb6, 0x06, 0x1b, 0x21, 0x0d, 0x73, 0x81, 0x68, 0x40, 0xb1, 0x66,
-	0x59, 0xd8, 0xe8, 0xde, 0x32, 0x78, 0xa2, 0x4a, 0xe6, 0x82, 0xf2, 0x6f, 0x01, 0x8a, 0xc7, 0x78,
-	0x80, 0x27, 0x0f, 0x75, 0xca, 0xf0, 0x41, 0xc7, 0xb0, 0x74, 0x6d, 0x76, 0x31, 0x3d, 0xc8, 0xfc,
-	0xc1, 0x3e, 0xef, 0x46, 0x25, 0x98, 0x90, 0xce, 0xcc, 0x2e, 0x96, 0xa9, 0xb4, 0xb8, 0x0f, 0x4b,
-	0xee, 0x17, 0x5a, 0x85, 0x15, 0xb9, 0xd9, 0xee, 0xc8, 0xad, 0x46, 0x67, 0xfd, 0x27, 0x08, 0x60,
-	0xf9, 0xb8, 0x79, 0xda, 0xec, 0x34, 0xd7, 0x05, 0x94, 0x07, 0x38, 0x6e, 0xb5, 0xdb, 0xdf, 0x36,
-	0x5a, 0xb5, 0x4e, 0x73, 0x7d, 0xc1, 0xdd, 0x7d, 0x54, 0xe7, 0x5c, 0xbb, 0xbf, 0x04, 0x74, 0x6e,
-	0x0f, 0x8d, 0x39, 0xf7, 0xbe, 0x07, 0x79, 0xfc, 0xca, 0xd5, 0xee, 0x28, 0x1a, 0xee, 0x99, 0x36,
-	0xf3, 0xc2, 0xa2, 0xbc, 0xe6, 0x51, 0xeb, 0x94, 0x28, 0x7e, 0x01, 0xc5, 0x88, 0x11, 0x0f, 0xe9,
-	0x1e, 0xe4, 0x19, 0x0a, 0xe5, 0xf2, 0x4a, 0x35, 0xfa, 0x98, 0x19, 0x59, 0x91, 0xd7, 0x18, 0xb5,
-	0xc1, 0x88, 0xa2, 0x06, 0x6b, 0x4f, 0xcc, 0x2e, 0x6e, 0xe3, 0x01, 0xbe, 0x24, 0xa6, 0xed, 0xa0,
-	0xf7, 0x21, 0xeb, 0x58, 0x7a, 0xaf, 0x87, 0x03, 0x5c, 0x2b, 0x8c, 0xd0, 0xea, 0xa2, 0xc7, 0x90,
-	0x75, 0x7c, 0xce, 0xd2, 0x02, 0x4d, 0x0c, 0x9b, 0x51, 0x0f, 0xf8, 0x8a, 0xe4, 0x80, 0x51, 0xfc,
-	0x33, 0x6c, 0xb5, 0x31, 0x89, 0x98, 0xf1, 0x7d, 0xd1, 0x08, 0x2b, 0x64, 0x2e, 0xdd, 0xe3, 0x1d,
-	0x72, 0x54, 0x41, 0x48, 0x7f, 0x05, 0x4a, 0x71, 0xfd, 0xcc, 0x0d, 0xe2, 0x9f, 0x60, 0xeb, 0x84,
-	0x63, 0x3b, 0x75, 0xa7, 0x7b, 0x90, 0x27, 0xe6, 0x00, 0xdb, 0x2a, 0xc1, 0x8a, 0x43, 0xd4, 0x01,
-	0x73, 0xfe, 0x8a, 0xbc, 0xe6, 0x53, 0xdb, 0x2e, 0x51, 0x54, 0xa0, 0x74, 0xc2, 0x31, 0x7d, 0x37,
-	0x7b, 0xfb, 0x03, 0x94, 0xd9, 0x1b, 0x56, 0x23, 0x04, 0x3b, 0x04, 0x77, 0x5d, 0x4e, 0x7f, 0x07,
-	0x12, 0x2c, 0x19, 0x6e, 0x74, 0x30, 0xe5, 0x95, 0xe8, 0x49, 0x44, 0x04, 0x28, 0x9f, 0x78, 0x0a,
-	0x95, 0x24, 0x65, 0xe3, 0x9c, 0x3f, 0x9b, 0xb6, 0x23, 0x28, 0xd1, 0xa7, 0x2d, 0x09, 0x59, 0x9a,
-	0x6f, 0xdd, 0x3d, 0x25, 0x08, 0xce, 0x89, 0xe2, 0xdd, 0x22, 0x94, 0xdc, 0x17, 0x2c, 0xbc, 0x34,
-	0x3e, 0xe2, 0x13, 0xd8, 0xd0, 0x46, 0xca, 0x44, 0x14, 0x31, 0xcd, 0xef, 0x4b, 0xac, 0x7e, 0x91,
-	0xfc, 0xfa, 0x45, 0x6a, 0x19, 0xe4, 0xf0, 0xf1, 0x1f, 0xd5, 0xc1, 0x10, 0xcb, 0x05, 0x6d, 0xd4,
-	0x0c, 0x07, 0xd9, 0x5d, 0xbc, 0x6f, 0x48, 0x82, 0xa2, 0x36, 0x52, 0x54, 0x8a, 0x93, 0x52, 0x14,
-	0x32, 0xb2, 0x70, 0x69, 0x91, 0x7a, 0x67, 0x43, 0x1b, 0xd5, 0x82, 0x95, 0xce, 0xc8, 0xc2, 0xe8,
-	0x5b, 0x0a, 0xde, 0xbf, 0x0a, 0xca, 0xb5, 0x4a, 0x2e, 0xaf, 0x4a, 0x4b, 0xd4, 0xf4, 0x2f, 0x79,
-	0xa6, 0xeb, 0xa3, 0xe0, 0x16, 0x15, 0xb4, 0xf1, 0xc7, 0x99, 0x2b, 0x8b, 0x8e, 0x20, 0xab, 0x8d,
-	0x14, 0x4d, 0x35, 0x0c, 0xdc, 0x2d, 0x65, 0x3c, 0xff, 0x4e, 0x7a, 0xa1, 0x6e, 0x9a, 0x03, 0xe6,
-	0x84, 0x15, 0x6d, 0x54, 0xa7, 0xbc, 0xe8, 0xd7, 0x50, 0xe8, 0xb9, 0x07, 0xa6, 0x04, 0xf7, 0x79,
-	0x99, 0x46, 0x43, 0x9e, 0x92, 0xc7, 0x26, 0xc5, 0x7f, 0x0a, 0x50, 0x4e, 0x38, 0x0c, 0xef, 0x68,
-	0xf7, 0x21, 0xe3, 0x1e, 0x99, 0x5f, 0x52, 0xa4, 0x9d, 0x2d, 0x63, 0xbc, 0x93, 0xb2, 0xe2, 0x7f,
-	0x02, 0x94, 0xd9, 0xcb, 0x3e, 0xeb, 0x45, 0x45, 0x0f, 0x01, 0x5d, 0x62, 0x9b, 0x28, 0x0e, 0xb6,
-	0x75, 0x75, 0xa0, 0x18, 0xc3, 0x6b, 0x0d, 0xdb, 0x14, 0x46, 0x56, 0x5e, 0x77, 0x57, 0xda, 0x74,
-	0xe1, 0x09, 0xa5, 0xa3, 0x5f, 0x41, 0x9e, 0x72, 0x1b, 0x26, 0x51, 0xd4, 0x1e, 0xc1, 0x36, 0x3d,
-	0xda, 0x45, 0x79, 0xd5, 0xa5, 0x3e, 0x31, 0x49, 0xcd, 0xa5, 0xa1, 0x4f, 0x60, 0xd3, 0xc0, 0x2f,
-	0x95, 0x04, 0xbd, 0x4b, 0x54, 0x6f, 0xd1, 0xc0, 0x2f, 0x1b, 0x93, 0xaa, 0x1f, 0x00, 0x1a, 0x0b,
-	0x05, 0xea, 0x33, 0x54, 0x7d, 0xc1, 0x13, 0xf0, 0x2d, 0xb8, 0x51, 0x9e, 0xb4, 0xdf, 0x39, 0xe3,
-	0xeb, 0x33, 0x28, 0xb3, 0x97, 0x70, 0xe6, 0x30, 0x3f, 0x85, 0x4a, 0x92, 0xe4, 0x9c, 0x38, 0xbe,
-	0x87, 0x6d, 0x96, 0xbb, 0x64, 0xdc, 0xd7, 0x1d, 0x62, 0xd3, 0xc3, 0x6d, 0x1a, 0xc4, 0x1e, 0xf9,
-	0x60, 0x3e, 0x85, 0x0c, 0x76, 0xbf, 0x3d, 0x95, 0x3b, 0x51, 0x95, 0x71, 0x31, 0xc6, 0x2d, 0x5e,
-	0xc0, 0x0e, 0x57, 0xb1, 0x87, 0x75, 0x4e, 0xcd, 0xbf, 0x81, 0x9f, 0xd3, 0x3c, 0xc7, 0x45, 0x5c,
-	0x86, 0x15, 0xca, 0x19, 0x78, 0xef, 0x3d, 0xfa, 0xdd, 0xea, 0xba, 0xdb, 0xe5, 0xc9, 0xde, 0x0e,
-	0xd4, 0x7f, 0x04, 0xc8, 0x85, 0xb2, 0x44, 0xf4, 0x49, 0x17, 0xa6, 0x7c, 0xd2, 0xd1, 0x09, 0x64,
-	0x58, 0x3e, 0x62, 0x85, 0xd9, 0xa3, 0x29, 0xf2, 0x91, 0x44, 0x93, 0x50, 0x1d, 0x5f, 0xa9, 0x2f,
-	0x74, 0xd3, 0x96, 0x99, 0xbc, 0x78, 0x00, 0x6b, 0x11, 0x3a, 0x2a, 0x40, 0xee, 0xac, 0xd6, 0x69,
-	0x7c, 0xa3, 0x34, 0x2f, 0x6a, 0xb4, 0x4c, 0x5b, 0x87, 0x55, 0x46, 0x68, 0x7f, 0x57, 0x6f, 0x37,
-	0x3b, 0xeb, 0x82, 0xf8, 0x25, 0x40, 0x10, 0xeb, 0xe8, 0x1e, 0x64, 0x88, 0xf9, 0x1c, 0x1b, 0x9e,
-	0x07, 0xd9, 0x87, 0x7b, 0x33, 0x2d, 0xb5, 0x8f, 0x15, 0x47, 0x7f, 0xcd, 0x9e, 0xee, 0x8c, 0xbc,
-	0xe2, 0x12, 0xda, 0xfa, 0x6b, 0x2c, 0xfe, 0x77, 0x01, 0xb6, 0xdd, 0x34, 0x35, 0xe9, 0x24, 0x3d,
-	0x78, 0x39, 0x7e, 0x07, 0xab, 0xda, 0x48, 0xb1, 0x54, 0x1b, 0x1b, 0xc4, 0x3f, 0x9e, 0xdc, 0xc1,
-	0xcf, 0x62, 0xe9, 0xb2, 0x4d, 0x6c, 0xdd, 0xe8, 0xb3, 0x84, 0x09, 0xda, 0xe8, 0x9c, 0x0a, 0xb4,
-	0xba, 0xe8, 0x6b, 0x2a, 0x1f, 0x2e, 0x96, 0xa6, 0xce, 0xdb, 0x39, 0x2d, 0x74, 0x3c, 0x0c, 0x47,
-	0x10, 0x64, 0x8b, 0xd3, 0xe1, 0x68, 0xfb, 0x29, 0x2c, 0x9a, 0x41, 0x97, 0xe6, 0x7a, 0xb8, 0xe2,
-	0xb5, 0x50, 0x26, 0xa9, 0x16, 0xfa, 0x97, 0x00, 0x3b, 0x5c, 0xaf, 0x7a, 0x97, 0xf6, 0x73, 0xa0,
-	0x37, 0x5c, 0x1f, 0x3f, 0x02, 0x37, 0x5e, 0x5b, 0x9f, 0xff, 0x4e, 0xde, 0x82, 0xef, 0x61, 0x9b,
-	0xa5, 0xc6, 0x1f, 0x21, 0x89, 0x70, 0x15, 0xdf, 0x2e, 0x5e, 0x7f, 0x0b, 0xdb, 0x2c, 0x8b, 0xce,
-	0x93, 0x45, 0x2e, 0x60, 0x87, 0x2b, 0x7c, 0x3b, 0x58, 0xdf, 0xc0, 0x0e, 0xed, 0x3a, 0x52, 0x42,
-	0x28, 0xde, 0xbf, 0x08, 0x49, 0xfd, 0x8b, 0x08, 0xbb, 0x7c, 0x4d, 0x5e, 0x15, 0xff, 0x39, 0x64,
-	0x7f, 0x6f, 0xea, 0x46, 0x87, 0x86, 0x76, 0x72, 0xc0, 0x6f, 0xc2, 0x32, 0xd5, 0x3b, 0xf2, 0xba,
-	0x24, 0xef, 0x4b, 0x7c, 0x0a, 0x9b, 0x2c, 0xbd, 0x8f, 0x15, 0xf8, 0xf8, 0xbe, 0x02, 0x78, 0x66,
-	0xea, 0x86, 0x12, 0x28, 0xcb, 0x1d, 0xfc, 0x82, 0x77, 0xa1, 0x02, 0xe9, 0xec, 0x33, 0xff, 0x5f,
-	0xf1, 0x07, 0xd8, 0x8a, 0xe9, 0xf6, 0xdc, 0x7a, 0x7b, 0xe5, 0x1f, 0xc3, 0x4f, 0xe9, 0x0b, 0x10,
-	0xc3, 0x9d, 0xb8, 0x7f, 0x77, 0x9f, 0x93, 0xec, 0x77, 0x06, 0x45, 0x82, 0x4d, 0x76, 0x8d, 0xa6,
-	0xc4, 0xf2, 0x03, 0x6c, 0xc5, 0xf8, 0xef, 0x0c, 0xcc, 0x97, 0xb0, 0x49, 0xef, 0xcb, 0x78, 0x71,
-	0xd6, 0x0b, 0x57, 0x86, 0xad, 0x98, 0x02, 0x86, 0xee, 0xe0, 0x6d, 0x19, 0xb2, 0xc7, 0x2a, 0x51,
-	0xdb, 0xae, 0x79, 0xa4, 0xc3, 0x6a, 0x78, 0x7e, 0x88, 0x1e, 0xf0, 0x70, 0x26, 0x8c, 0x2a, 0x2b,
-	0x0f, 0xa7, 0x63, 0xf6, 0xdc, 0xd2, 0x83, 0x5c, 0x68, 0x4c, 0x88, 0x3e, 0xe2, 0x09, 0xc7, 0x27,
-	0x91, 0x95, 0x07, 0x53, 0xf1, 0x06, 0x76, 0x42, 0xe3, 0x3e, 0xbe, 0x9d, 0xf8, 0xb8, 0x91, 0x6f,
-	0x27, 0x69, 0x7e, 0xa8, 0xc3, 0x6a, 0x78, 0x9a, 0xc6, 0x77, 0x5d, 0xc2, 0xc4, 0x8f, 0xef, 0xba,
-	0xc4, 0x01, 0xdd, 0x5f, 0x20, 0x3b, 0x1e, 0x98, 0xa1, 0x0f, 0x78, 0xa2, 0x93, 0x53, 0xb9, 0xca,
-	0x87, 0x53, 0x70, 0x06, 0x9b, 0x09, 0x8f, 0xc2, 0xf8, 0x9b, 0x49, 0x98, 0xba, 0xf1, 0x37, 0x93,
-	0x38, 0x5d, 0xd3, 0x61, 0x35, 0x3c, 0x77, 0xe2, 0x9b, 0x4a, 0x98, 0x78, 0xf1, 0x4d, 0x25, 0x8e,
-	0xb2, 0x7a, 0x90, 0x0b, 0xcd, 0x8d, 0xf8, 0x57, 0x21, 0x3e, 0xc1, 0xe2, 0x5f, 0x85, 0xa4, 0x41,
-	0xd4, 0x1b, 0x40, 0xf1, 0xa1, 0x03, 0x7a, 0x94, 0x1e, 0x1e, 0x09, 0xcd, 0x46, 0xe5, 0x60, 0x16,
-	0x11, 0xcf, 0xf8, 0x2b, 0xd8, 0x88, 0x8d, 0x1a, 0xd0, 0x7e, 0x6a, 0xc4, 0x24, 0x99, 0x7e, 0x34,
-	0x83, 0x44, 0x60, 0x39, 0xd6, 0x09, 0xf3, 0x2d, 0xf3, 0x26, 0x18, 0x7c, 0xcb, 0xfc, 0x36, 0xfb,
-	0x0d, 0xa0, 0x78, 0xff, 0xc7, 0x77, 0x38, 0xb7, 0x37, 0xe6, 0x3b, 0x3c, 0xa5, 0xbd, 0x7c, 0x03,
-	0x28, 0xde, 0xf4, 0xf1, 0x8d, 0x73, 0x5b, 0x4b, 0xbe, 0xf1, 0x94, 0x9e, 0x72, 0x48, 0xa7, 0xef,
-	0xd1, 0x79, 0x66, 0x35, 0x25, 0xce, 0x93, 0xc6, 0x82, 0x95, 0xfd, 0xe9, 0x05, 0x02, 0xb3, 0x27,
-	0x53, 0x9b, 0x3d, 0x99, 0xd5, 0x2c, 0x77, 0xbe, 0xf8, 0x4e, 0xf0, 0xcb, 0x8f, 0x58, 0x95, 0x86,
-	0x0e, 0xd3, 0x63, 0x85, 0x57, 0x4b, 0x56, 0x8e, 0x66, 0x96, 0xf3, 0xc0, 0xfc, 0x5d, 0xf0, 0xea,
-	0x8f, 0x38, 0x96, 0x4f, 0x53, 0x83, 0x87, 0x0b, 0xe5, 0x70, 0x56, 0xb1, 0x90, 0x5b, 0x38, 0x6d,
-	0x08, 0xdf, 0x2d, 0xe9, 0xdd, 0x20, 0xdf, 0x2d, 0x37, 0xf5, 0x3b, 0x2e, 0x18, 0x4e, 0x63, 0xc0,
-	0x07, 0x93, 0xde, 0xa2, 0xf0, 0xc1, 0xdc, 0xd4, 0x81, 0xb8, 0x60, 0x38, 0xed, 0x00, 0x1f, 0x4c,
-	0x7a, 0xf3, 0xc1, 0x07, 0x73, 0x53, 0xdf, 0xf1, 0x0f, 0x01, 0x4a, 0xbc, 0xba, 0x1f, 0x1d, 0xa5,
-	0x3e, 0x30, 0x29, 0x07, 0xf5, 0xd9, 0xec, 0x82, 0x1e, 0x1e, 0x1b, 0x0a, 0x13, 0xb5, 0x3c, 0x92,
-	0xd2, 0x83, 0x61, 0xb2, 0x18, 0xae, 0x54, 0xa7, 0xe6, 0xf7, 0x6c, 0x9a, 0x90, 0x8f, 0xd6, 0xec,
-	0xe8, 0xe3, 0xd4, 0x4b, 0x1f, 0xb3, 0x28, 0x4d, 0xcb, 0x1e, 0x6c, 0x72, 0xa2, 0x30, 0xe7, 0x6f,
-	0x32, 0xb9, 0xe2, 0xe7, 0x6f, 0x92, 0x57, 0xf1, 0xdb, 0x50, 0x98, 0x28, 0xb7, 0xf9, 0x36, 0x93,
-	0x0b, 0x7b, 0xbe, 0x4d, 0x4e, 0x1d, 0x8f, 0x9e, 0x42, 0xb6, 0x61, 0x1a, 0x3d, 0xbd, 0x3f, 0xb4,
-	0x31, 0xda, 0x8b, 0xb6, 0xb4, 0xde, 0x4f, 0x10, 0xc6, 0xeb, 0xbe, 0x91, 0xfb, 0x37, 0xb1, 0x8d,
-	0xeb, 0xa6, 0xb5, 0x13, 0x4c, 0xce, 0xe9, 0x72, 0xcb, 0xe8, 0x99, 0xe8, 0xc3, 0x44, 0xc1, 0x08,
-	0x8f, 0x6f, 0xe3, 0xa3, 0x69, 0x58, 0x99, 0x9d, 0xfa, 0xe1, 0xd3, 0xc7, 0x7d, 0x9d, 0x5c, 0x0d,
-	0x35, 0x97, 0xbb, 0xca, 0x26, 0x40, 0x55, 0xf6, 0x8b, 0x09, 0x3a, 0xf5, 0xa9, 0x26, 0xff, 0x7e,
-	0x43, 0x5b, 0xa6, 0xab, 0x9f, 0xfc, 0x3f, 0x00, 0x00, 0xff, 0xff, 0x04, 0xca, 0x44, 0x6a, 0xe0,
-	0x21, 0x00, 0x00,
+	// 2413 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xdd, 0x72, 0xdb, 0xc6,
+	0x15, 0x2e, 0x24, 0x51, 0x12, 0x8f, 0x7e, 0xbd, 0x4a, 0x25, 0x0a, 0x72, 0x64, 0x05, 0xa9, 0x5d,
+	0x27, 0x56, 0x48, 0x99, 0xfe, 0x91, 0x9d, 0xf1, 0xd4, 0xa1, 0x28, 0x59, 0x61, 0x63, 0x3b, 0x1e,
+	0x50, 0x76, 0x5d, 0xa7, 0x2d, 0x0a, 0x92, 0x4b, 0x1a, 0x31, 0x09, 0x30, 0xc0, 0xd2, 0x36, 0xd3,
+	0x4e, 0x3b, 0xb9, 0xea, 0xb4, 0x33, 0x9d, 0x69, 0x2f, 0x3a, 0xd3, 0x9b, 0x4e, 0x7b, 0xd3, 0x47,
+	0xe8, 0x7d, 0x5f, 0xa1, 0xd3, 0x17, 0xe8, 0xa3, 0x74, 0xb0, 0xbb, 0x20, 0x00, 0x02, 0x0b, 0x03,
+	0x14, 0xaf, 0x24, 0xec, 0x9e, 0x9f, 0x6f, 0xcf, 0xee, 0xf9, 0xd9, 0xb3, 0x84, 0x2b, 0x4e, 0xdf,
+	0xb0, 0x71, 0xc9, 0xc1, 0xf6, 0x6b, 0x6c, 0x97, 0x5a, 0x3a, 0xd1, 0x1d, 0x62, 0xd9, 0xd8, 0xff,
+	0xaf, 0xd8, 0xb7, 0x2d, 0x62, 0xa1, 0x4d, 0x4a, 0x57, 0x64, 0x74, 0xc5, 0xd1, 0xac, 0xbc, 0xdb,
+	0xb1, 0xac, 0x4e, 0x17, 0x97, 0x28, 0x55, 0x63, 0xd0, 0x2e, 0xbd, 0xb1, 0xf5, 0x7e, 0x1f, 0xdb,
+	0x0e, 0xe3, 0x93, 0xf7, 0x98, 0xfc, 0xa6, 0xd5, 0xeb, 0x59, 0x66, 0xa9, 0xdf, 0x1d, 0x74, 0x0c,
+	0xef, 0x0f, 0xa7, 0xd8, 0x0e, 0x51, 0xb0, 0x3f, 0x6c, 0x4a, 0xa9, 0xc2, 0x46, 0xd5, 0xc6, 0x3a,
+	0xc1, 0x47, 0x03, 0xb3, 0xd5, 0xc5, 0x2a, 0xfe, 0x66, 0x80, 0x1d, 0x82, 0xf6, 0x61, 0xbe, 0x41,
+	0x07, 0x0a, 0xd2, 0x9e, 0x74, 0x75, 0xa9, 0xfc, 0x5e, 0x91, 0x81, 0xe3, 0xbc, 0x9c, 0x98, 0xd3,
+	0x28, 0xc7, 0xf0, 0x5e, 0x58, 0x88, 0xd3, 0xb7, 0x4c, 0x07, 0x67, 0x94, 0xd2, 0x04, 0xf4, 0x00,
+	0x93, 0xe6, 0xcb, 0x30, 0x92, 0x2b, 0xb0, 0x46, 0xec, 0x81, 0x43, 0xb4, 0x96, 0xd5, 0xd3, 0x0d,
+	0x53, 0x33, 0x5a, 0x54, 0x58, 0x5e, 0x5d, 0xa1, 0xc3, 0xc7, 0x74, 0xb4, 0xd6, 0x42, 0x97, 0x61,
+	0x95, 0x58, 0x5d, 0x6c, 0xeb, 0x04, 0x6b, 0x0e, 0xd1, 0xbb, 0xb8, 0x30, 0xb3, 0x27, 0x5d, 0x5d,
+	0x54, 0x57, 0xbc, 0xd1, 0xba, 0x3b, 0xe8, 0xae, 0x37, 0xa4, 0x64, 0x22, 0xa4, 0xbf, 0x05, 0xf4,
+	0xd0, 0x70, 0x08, 0x1b, 0x75, 0x3c, 0xa4, 0x47, 0x00, 0x7d, 0xbd, 0x63, 0x98, 0x3a, 0x31, 0x2c,
+	0x93, 0xcb, 0x51, 0x8a, 0xf1, 0x9b, 0x5a, 0x7c, 0x32, 0xa2, 0x54, 0x03, 0x5c, 0x69, 0x57, 0xf1,
+	0x7b, 0x09, 0x36, 0x42, 0x08, 0xf8, 0x32, 0x8a, 0xb0, 0xc0, 0x20, 0x3a, 0x05, 0x69, 0x6f, 0x56,
+	0xb8, 0x0e, 0x8f, 0x68, 0x0c, 0xf2, 0xcc, 0x24, 0x90, 0x95, 0x5f, 0xc3, 0xc6, 0xd3, 0x7e, 0xeb,
+	0x7c, 0x27, 0x08, 0x1d, 0x02, 0x18, 0x66, 0x7f, 0x40, 0xb4, 0x9e, 0xee, 0xbc, 0xe2, 0x40, 0x0a,
+	0x71, 0x1c, 0x8f, 0x74, 0xe7, 0x95, 0x9a, 0xa7, 0xb4, 0xee, 0xbf, 0xee, 0xd1, 0x0b, 0x6b, 0x9f,
+	0x68, 0x43, 0x3f, 0x83, 0xf5, 0x3a, 0x26, 0xe7, 0x71, 0x81, 0x0a, 0x5c, 0x08, 0x48, 0x98, 0x08,
+	0x44, 0x15, 0x36, 0x2a, 0xfd, 0x3e, 0x36, 0x5b, 0xe7, 0x74, 0xc5, 0xb0, 0x90, 0x89, 0xa0, 0xfc,
+	0x4b, 0x82, 0x8d, 0x63, 0xdc, 0xc5, 0x04, 0x4f, 0xe6, 0x8c, 0xc7, 0x30, 0xd7, 0xb3, 0x5a, 0xec,
+	0xf0, 0xae, 0x96, 0x0f, 0x44, 0x27, 0x2a, 0x46, 0x45, 0xf1, 0x91, 0xd5, 0xc2, 0x2a, 0xe5, 0x56,
+	0x0e, 0x60, 0xce, 0xfd, 0x42, 0xcb, 0xb0, 0xa8, 0x9e, 0xd4, 0xcf, 0xd4, 0x5a, 0xf5, 0x6c, 0xfd,
+	0x7b, 0x08, 0x60, 0xfe, 0xf8, 0xe4, 0xe1, 0xc9, 0xd9, 0xc9, 0xba, 0x84, 0x56, 0x01, 0x8e, 0x6b,
+	0xf5, 0xfa, 0x97, 0xd5, 0x5a, 0xe5, 0xec, 0x64, 0x7d, 0xc6, 0x5d, 0x7d, 0x58, 0xe6, 0xa4, 0x81,
+	0xe8, 0x89, 0x3d, 0x30, 0xf1, 0xc4, 0x81, 0x08, 0xbf, 0x75, 0xa5, 0x3b, 0x5a, 0x03, 0xb7, 0x2d,
+	0x9b, 0x59, 0x61, 0x56, 0x5d, 0xe1, 0xa3, 0x47, 0x74, 0x50, 0xb9, 0x07, 0x1b, 0x21, 0x25, 0x1c,
+	0xe9, 0x65, 0x58, 0x65, 0x28, 0xb4, 0xe6, 0x4b, 0xdd, 0xec, 0x60, 0xa6, 0x64, 0x51, 0x5d, 0x61,
+	0xa3, 0x55, 0x36, 0xa8, 0x34, 0x60, 0xe5, 0xb1, 0xd5, 0xc2, 0x75, 0xdc, 0xc5, 0x4d, 0x62, 0xd9,
+	0x0e, 0xda, 0x81, 0xbc, 0xd3, 0x37, 0xda, 0x6d, 0xec, 0xe3, 0x5a, 0x64, 0x03, 0xb5, 0x16, 0xba,
+	0x09, 0x79, 0xc7, 0xa3, 0x2c, 0xcc, 0xd0, 0xc0, 0xb0, 0x19, 0xb6, 0x80, 0x27, 0x48, 0xf5, 0x09,
+	0x95, 0x5f, 0xc0, 0x56, 0x1d, 0x93, 0x90, 0x1a, 0xcf, 0x16, 0xd5, 0xa0, 0x40, 0x66, 0xd2, 0xcb,
+	0xa2, 0x4d, 0x0e, 0x0b, 0x08, 0xc8, 0x97, 0xa1, 0x10, 0x95, 0xcf, 0xcc, 0xa0, 0xfc, 0x1c, 0xb6,
+	0x4e, 0x05, 0xba, 0x13, 0x57, 0x9a, 0x32, 0x7e, 0x6a, 0x50, 0x38, 0x15, 0xa8, 0x9e, 0xce, 0xda,
+	0xbe, 0x80, 0x6d, 0x96, 0x11, 0x2b, 0x84, 0x60, 0x87, 0xe0, 0x96, 0x4b, 0xe9, 0xad, 0xa0, 0x08,
+	0x73, 0xa6, 0xeb, 0x1d, 0x4c, 0xb8, 0x1c, 0xde, 0x89, 0x10, 0x03, 0xa5, 0x53, 0x1e, 0x82, 0x1c,
+	0x27, 0x6c, 0x14, 0xf3, 0xb3, 0x49, 0x3b, 0x84, 0x02, 0xcd, 0x80, 0x71, 0xc8, 0x92, 0x6c, 0xeb,
+	0xae, 0x29, 0x86, 0x71, 0x42, 0x14, 0xff, 0x99, 0x85, 0x82, 0x9b, 0xc1, 0x82, 0x53, 0xa3, 0x2d,
+	0x3e, 0x85, 0x0b, 0x8d, 0xa1, 0x36, 0xe6, 0x45, 0x4c, 0xf2, 0x4e, 0x91, 0x55, 0x43, 0x45, 0xaf,
+	0x1a, 0x2a, 0xd6, 0x4c, 0x72, 0xfb, 0xe6, 0x33, 0xbd, 0x3b, 0xc0, 0xea, 0x5a, 0x63, 0x78, 0x12,
+	0x74, 0xb2, 0x69, 0xe4, 0x37, 0x54, 0x84, 0x8d, 0xc6, 0x50, 0xd3, 0x29, 0x4e, 0x3a, 0xa2, 0x91,
+	0x61, 0x1f, 0x17, 0x66, 0xa9, 0x75, 0x2e, 0x34, 0x86, 0x15, 0x7f, 0xe6, 0x6c, 0xd8, 0xc7, 0xe8,
+	0x4b, 0x0a, 0xde, 0x3b, 0x0a, 0x5a, 0x4f, 0x27, 0xcd, 0x97, 0x85, 0x39, 0xaa, 0xfa, 0x43, 0x91,
+	0xea, 0xa3, 0xa1, 0x7f, 0x8a, 0xd6, 0x1a, 0xa3, 0x8f, 0x47, 0x2e, 0x2f, 0x3a, 0x84, 0x7c, 0x63,
+	0xa8, 0x35, 0x74, 0xd3, 0xc4, 0xad, 0x42, 0x8e, 0xdb, 0x77, 0xdc, 0x0a, 0x47, 0x96, 0xd5, 0x65,
+	0x46, 0x58, 0x6c, 0x0c, 0x8f, 0x28, 0x2d, 0xfa, 0x21, 0xac, 0xb5, 0xdd, 0x0d, 0xd3, 0xfc, 0xf3,
+	0x3c, 0x4f, 0xbd, 0x61, 0x95, 0x0e, 0xfb, 0xc1, 0xe3, 0x04, 0xd6, 0x03, 0xf6, 0xd6, 0xdb, 0x04,
+	0xdb, 0x85, 0x85, 0x77, 0x9b, 0x7b, 0x75, 0x64, 0xee, 0x8a, 0xcb, 0xa2, 0xfc, 0x59, 0x82, 0xed,
+	0x98, 0x3d, 0xe5, 0x27, 0xe4, 0x00, 0x72, 0xee, 0xce, 0x7b, 0x95, 0x49, 0xd2, 0x11, 0x61, 0x84,
+	0x53, 0xa9, 0x4e, 0xfe, 0x32, 0x03, 0xdb, 0xac, 0x40, 0xc8, 0x7a, 0xde, 0xd1, 0x3e, 0xa0, 0x26,
+	0xb6, 0x89, 0xe6, 0x60, 0xdb, 0xd0, 0xbb, 0x9a, 0x39, 0xe8, 0x35, 0xb0, 0x4d, 0x61, 0xe4, 0xd5,
+	0x75, 0x77, 0xa6, 0x4e, 0x27, 0x1e, 0xd3, 0x71, 0xf4, 0x03, 0x58, 0xa5, 0xd4, 0xa6, 0x45, 0xb8,
+	0x05, 0x67, 0x69, 0xd8, 0x5f, 0x76, 0x47, 0x1f, 0x5b, 0x84, 0x9a, 0x08, 0xdd, 0x80, 0x4d, 0x13,
+	0xbf, 0xd1, 0x62, 0xe4, 0xce, 0x51, 0xb9, 0x1b, 0x26, 0x7e, 0x53, 0x1d, 0x17, 0x7d, 0x0d, 0xd0,
+	0x88, 0xc9, 0x17, 0x9f, 0xa3, 0xe2, 0xd7, 0x38, 0xc3, 0x48, 0xc3, 0x65, 0x58, 0x6d, 0x5b, 0x76,
+	0x13, 0x6b, 0xb6, 0xc5, 0x0e, 0x25, 0xdf, 0xf3, 0x15, 0x3a, 0xaa, 0xf2, 0x41, 0x37, 0xa6, 0xc4,
+	0x99, 0x65, 0x42, 0x6f, 0xbe, 0x0f, 0x7b, 0x0f, 0x5c, 0xf1, 0xa1, 0x29, 0xae, 0x2a, 0x55, 0x6c,
+	0xa9, 0xc3, 0x07, 0x09, 0x02, 0x26, 0x44, 0x75, 0x07, 0xb6, 0x59, 0x35, 0x90, 0x39, 0xd4, 0x3d,
+	0x04, 0x39, 0x8e, 0x73, 0x42, 0x1c, 0x47, 0xb0, 0x4d, 0x53, 0x7d, 0x6c, 0xac, 0x8b, 0x96, 0x0b,
+	0x52, 0x5c, 0xb9, 0x70, 0x11, 0xe4, 0x38, 0x19, 0x3c, 0x5d, 0xfe, 0x04, 0x76, 0x59, 0x86, 0x50,
+	0x71, 0xc7, 0x70, 0x88, 0x4d, 0xed, 0x76, 0x62, 0x12, 0x7b, 0xe8, 0xa9, 0xb9, 0x05, 0x39, 0xec,
+	0x7e, 0x73, 0xd0, 0x97, 0xc2, 0xa0, 0xa3, 0x6c, 0x8c, 0x5a, 0x79, 0x0e, 0x97, 0x84, 0x82, 0xb9,
+	0x35, 0x26, 0x94, 0xfc, 0x29, 0xbc, 0x4f, 0xb3, 0x89, 0x10, 0xf1, 0x36, 0x2c, 0x52, 0x4a, 0x7f,
+	0x7f, 0x16, 0xe8, 0x77, 0xad, 0xe5, 0x2e, 0x57, 0xc4, 0x7b, 0x3e, 0x50, 0xff, 0x96, 0x60, 0x29,
+	0x10, 0x8b, 0xc3, 0x85, 0x93, 0x94, 0xb2, 0x70, 0x42, 0xa7, 0x90, 0x63, 0x51, 0x9f, 0x95, 0xbf,
+	0xd7, 0x53, 0x44, 0xfd, 0x22, 0x0d, 0xf5, 0x47, 0xf8, 0xa5, 0xfe, 0xda, 0xb0, 0x6c, 0x95, 0xf1,
+	0x2b, 0x65, 0x58, 0x09, 0x8d, 0xa3, 0x35, 0x58, 0x7a, 0x54, 0x39, 0xab, 0x7e, 0xae, 0x9d, 0x3c,
+	0xaf, 0xd0, 0x62, 0x78, 0x1d, 0x96, 0xd9, 0x40, 0xfd, 0xe9, 0x51, 0xfd, 0xe4, 0x6c, 0x5d, 0x52,
+	0xee, 0x03, 0xf8, 0xa1, 0x10, 0xbd, 0x07, 0x39, 0x62, 0xbd, 0xc2, 0x26, 0xb7, 0x20, 0xfb, 0x70,
+	0xcf, 0x7e, 0x5f, 0xef, 0x60, 0xcd, 0x31, 0xbe, 0x65, 0x05, 0x52, 0x4e, 0x5d, 0x74, 0x07, 0xea,
+	0xc6, 0xb7, 0x58, 0xf9, 0xef, 0x0c, 0xec, 0xba, 0x51, 0x7c, 0xdc, 0x48, 0x86, 0x7f, 0x66, 0x7f,
+	0x04, 0xcb, 0x8d, 0xa1, 0xd6, 0xd7, 0x6d, 0x6c, 0x12, 0x6f, 0x7b, 0x96, 0xca, 0x17, 0x23, 0xb9,
+	0xa2, 0x4e, 0x6c, 0xc3, 0xec, 0xb0, 0x64, 0x01, 0x8d, 0xe1, 0x13, 0xca, 0x50, 0x6b, 0xa1, 0x07,
+	0x94, 0x3f, 0x58, 0x92, 0xa6, 0xce, 0x8e, 0x4b, 0x7e, 0x76, 0x74, 0x38, 0x0e, 0xdf, 0x8d, 0x67,
+	0xd3, 0xe1, 0xa8, 0x7b, 0x11, 0x3e, 0x9c, 0x60, 0xe6, 0xa6, 0x74, 0x63, 0xcf, 0xc5, 0x55, 0x9c,
+	0xff, 0x90, 0xe0, 0x92, 0xd0, 0xaa, 0xfc, 0xd0, 0xde, 0x05, 0x7a, 0xc2, 0x8d, 0x51, 0x8e, 0x7c,
+	0xe7, 0xb1, 0xf5, 0xe8, 0xa7, 0x92, 0x2a, 0xff, 0x26, 0xc1, 0x2e, 0xcb, 0x09, 0x53, 0x8e, 0x22,
+	0xe8, 0x18, 0xd6, 0x6c, 0xfc, 0xda, 0x70, 0xdc, 0xe2, 0x29, 0x90, 0x46, 0xdf, 0x55, 0x5e, 0x78,
+	0x3c, 0x2c, 0x0d, 0xba, 0xb1, 0x48, 0x08, 0xef, 0x7c, 0x6e, 0xff, 0x9d, 0x04, 0xbb, 0x2c, 0xde,
+	0x4f, 0x10, 0x8d, 0xa6, 0xb7, 0x3a, 0x21, 0x84, 0xf3, 0xad, 0xee, 0x73, 0xb8, 0x44, 0x53, 0x47,
+	0x82, 0x43, 0xa7, 0x4c, 0x42, 0x0a, 0xec, 0x89, 0x25, 0xf1, 0x54, 0xf4, 0x0d, 0xe4, 0x7f, 0x6c,
+	0x19, 0xe6, 0x19, 0x0d, 0x34, 0xf1, 0xe1, 0x67, 0x13, 0xe6, 0xa9, 0xdc, 0x21, 0xbf, 0x19, 0xf3,
+	0x2f, 0xd7, 0xc6, 0x3d, 0xfd, 0xad, 0x36, 0x70, 0xb0, 0x43, 0x5d, 0x39, 0xa7, 0x2e, 0xf4, 0xf4,
+	0xb7, 0x4f, 0x1d, 0x4c, 0xaf, 0xb7, 0x03, 0x07, 0x6b, 0x4d, 0x6b, 0x60, 0x12, 0xea, 0xa8, 0x39,
+	0x75, 0x71, 0xe0, 0xe0, 0xaa, 0xfb, 0xad, 0xbc, 0x80, 0x4d, 0x96, 0xa4, 0x46, 0x8a, 0xbd, 0x75,
+	0x7d, 0x06, 0xf0, 0xb5, 0x65, 0x98, 0x9a, 0x0f, 0x62, 0xa9, 0xfc, 0x81, 0xc8, 0x2d, 0x7c, 0xee,
+	0xfc, 0xd7, 0xde, 0xbf, 0xca, 0x57, 0xb0, 0x15, 0x91, 0xcd, 0xb7, 0xe3, 0xfc, 0xc2, 0x3f, 0x81,
+	0xef, 0xd3, 0x3c, 0x16, 0xc1, 0x1d, 0x6b, 0x37, 0x77, 0x9d, 0xe3, 0xe4, 0x53, 0x83, 0x52, 0x84,
+	0x4d, 0x76, 0xfc, 0x52, 0x62, 0xf9, 0x0a, 0xb6, 0x22, 0xf4, 0x53, 0x03, 0x53, 0x82, 0xad, 0xaa,
+	0x65, 0x3a, 0x83, 0x5e, 0x5a, 0x34, 0x3f, 0x83, 0x42, 0x94, 0x61, 0x6a, 0x70, 0xee, 0xc3, 0x26,
+	0x3d, 0xf6, 0xa3, 0xc9, 0xac, 0x7e, 0xb3, 0x0d, 0x5b, 0x11, 0x01, 0xdc, 0x5d, 0xfe, 0x27, 0xc1,
+	0x6a, 0xcd, 0x71, 0x06, 0xb8, 0xf5, 0xfc, 0xd6, 0xc1, 0xdd, 0xfa, 0xb3, 0xda, 0x71, 0xf2, 0xa5,
+	0xe4, 0x43, 0x58, 0x89, 0xbb, 0x8f, 0x2c, 0x3b, 0xc1, 0x0b, 0xc3, 0x0e, 0xe4, 0x0d, 0x2a, 0x53,
+	0xd3, 0x09, 0xbf, 0x86, 0x2c, 0xb2, 0x81, 0x0a, 0x41, 0xef, 0x03, 0x8c, 0x6e, 0x7a, 0xcc, 0x97,
+	0x66, 0xd5, 0x3c, 0x1f, 0xa9, 0x10, 0x24, 0x43, 0xbe, 0xa9, 0x6b, 0xaf, 0x30, 0x8d, 0x74, 0x39,
+	0x16, 0xe9, 0x9a, 0xfa, 0x17, 0xd8, 0x8d, 0x74, 0xdb, 0xb0, 0xa8, 0x77, 0x78, 0xce, 0x9f, 0x67,
+	0x53, 0xf4, 0x9b, 0x4d, 0x8d, 0xe2, 0xe3, 0x42, 0xb8, 0x5a, 0xfb, 0x29, 0xec, 0x30, 0x17, 0x0a,
+	0xaf, 0xd3, 0xb3, 0xe1, 0xa7, 0x30, 0xe7, 0xbc, 0x1e, 0x15, 0x11, 0x57, 0x44, 0x3b, 0x33, 0xc6,
+	0x4c, 0x79, 0x94, 0x17, 0x70, 0x31, 0x5e, 0x34, 0xdf, 0xfb, 0xf3, 0xc8, 0xfe, 0xe7, 0x0c, 0xc8,
+	0x6e, 0xc6, 0x0e, 0x4f, 0x8e, 0xd5, 0x40, 0xe1, 0x8d, 0xca, 0x52, 0x7b, 0xdc, 0x83, 0x25, 0xb7,
+	0xad, 0xe0, 0x99, 0x73, 0x26, 0x05, 0x7b, 0xbe, 0x31, 0xac, 0x70, 0x73, 0xc7, 0xdd, 0xd8, 0x67,
+	0x33, 0xdf, 0xd8, 0xa7, 0x51, 0x00, 0x29, 0x7f, 0x97, 0x60, 0x27, 0xd6, 0x4e, 0x7c, 0x0f, 0xee,
+	0x41, 0xce, 0xb5, 0xa7, 0x57, 0xd3, 0xa4, 0xdd, 0x04, 0xc6, 0x34, 0x95, 0xc2, 0xe6, 0x18, 0x76,
+	0xa8, 0xfb, 0x09, 0x76, 0x32, 0xa5, 0x13, 0xef, 0xc2, 0xc5, 0x78, 0x29, 0xdc, 0x93, 0x2f, 0x82,
+	0x7c, 0x8a, 0x49, 0xcd, 0x24, 0xd8, 0x6e, 0xeb, 0x4d, 0xfc, 0x0c, 0xdb, 0x8e, 0x7f, 0xfb, 0x55,
+	0x0e, 0x61, 0x27, 0x76, 0x96, 0x1b, 0xa9, 0x00, 0x0b, 0xaf, 0xd9, 0x10, 0x55, 0xbe, 0xa2, 0x7a,
+	0x9f, 0xe5, 0xbf, 0xee, 0x41, 0xfe, 0x58, 0x27, 0x7a, 0xdd, 0x5d, 0x21, 0x32, 0x60, 0x39, 0xf8,
+	0xd2, 0x86, 0xae, 0x89, 0x4c, 0x11, 0xf3, 0xa8, 0x27, 0xef, 0xa7, 0x23, 0xe6, 0x90, 0xda, 0xb0,
+	0x14, 0x78, 0x29, 0x43, 0x1f, 0x8b, 0x98, 0xa3, 0x6f, 0x76, 0xf2, 0xb5, 0x54, 0xb4, 0xbe, 0x9e,
+	0xc0, 0x53, 0x96, 0x58, 0x4f, 0xf4, 0xc5, 0x4d, 0xbe, 0x96, 0x8a, 0x96, 0xeb, 0x31, 0x60, 0x39,
+	0xf8, 0x52, 0x24, 0x36, 0x5d, 0xcc, 0x6b, 0x96, 0xbc, 0x9f, 0x8e, 0x98, 0xab, 0xfa, 0x25, 0xe4,
+	0x47, 0x8f, 0x41, 0xe8, 0xaa, 0x88, 0x75, 0xfc, 0xc5, 0x49, 0xfe, 0x28, 0x05, 0xa5, 0xbf, 0x98,
+	0xe0, 0x33, 0x8f, 0x78, 0x31, 0x31, 0x2f, 0x4a, 0xf2, 0x7e, 0x3a, 0x62, 0x5f, 0x55, 0xf0, 0x4d,
+	0x45, 0xac, 0x2a, 0xe6, 0x35, 0x47, 0xde, 0x4f, 0x47, 0xec, 0x1f, 0x85, 0xc0, 0x9b, 0x88, 0xf8,
+	0x28, 0x44, 0x5f, 0x67, 0xe4, 0x6b, 0xa9, 0x68, 0xb9, 0x9e, 0x5f, 0x01, 0x8a, 0x36, 0xd4, 0xd1,
+	0xf5, 0x64, 0xf7, 0x88, 0x69, 0x22, 0xc9, 0xe5, 0x2c, 0x2c, 0x5c, 0xf9, 0x5b, 0xb8, 0x10, 0x69,
+	0xa3, 0xa3, 0x83, 0x44, 0x8f, 0x89, 0x53, 0x7d, 0x3d, 0x03, 0x87, 0xaf, 0x39, 0xd2, 0x9e, 0x15,
+	0x6b, 0x16, 0x75, 0xe7, 0xe5, 0xeb, 0x19, 0x38, 0x7c, 0x83, 0x47, 0xbb, 0x8d, 0x62, 0x83, 0x0b,
+	0x1b, 0xb6, 0x72, 0x39, 0x0b, 0x0b, 0x57, 0xfe, 0x27, 0x09, 0xb6, 0x85, 0xcd, 0x45, 0x74, 0x47,
+	0x68, 0xc7, 0x77, 0x34, 0x34, 0xe5, 0xbb, 0x13, 0x70, 0xfa, 0xf6, 0x88, 0xf6, 0x17, 0xc5, 0xf6,
+	0x10, 0x76, 0x31, 0xe5, 0x72, 0x16, 0x16, 0x5f, 0x79, 0xb4, 0x95, 0x28, 0x56, 0x2e, 0x6c, 0x5d,
+	0xca, 0xe5, 0x2c, 0x2c, 0x5c, 0xf9, 0x80, 0xbe, 0xb4, 0x87, 0xdf, 0x2e, 0x4b, 0x09, 0x71, 0x2f,
+	0xee, 0x09, 0x50, 0x3e, 0x48, 0xcf, 0xe0, 0xab, 0x3d, 0x4d, 0xad, 0xf6, 0x34, 0xab, 0x5a, 0xe1,
+	0x5b, 0xe2, 0x1f, 0x24, 0xef, 0xfa, 0x18, 0xb9, 0x9d, 0xa3, 0xdb, 0xc9, 0xb1, 0x43, 0xd4, 0x89,
+	0x90, 0x0f, 0x33, 0xf3, 0x71, 0x30, 0xbf, 0x93, 0xf8, 0xfd, 0x31, 0x8a, 0xe5, 0x56, 0x62, 0x30,
+	0x11, 0x42, 0xb9, 0x9d, 0x95, 0x2d, 0x60, 0x16, 0x41, 0x33, 0x4c, 0x6c, 0x96, 0xe4, 0x9e, 0xa4,
+	0x7c, 0x98, 0x99, 0x2f, 0x00, 0x46, 0xd0, 0x57, 0x12, 0x83, 0x49, 0xee, 0x93, 0xc9, 0x87, 0x99,
+	0xf9, 0x02, 0x60, 0x04, 0x6d, 0x20, 0x31, 0x98, 0xe4, 0xd6, 0x95, 0x7c, 0x98, 0x99, 0x8f, 0x83,
+	0xf9, 0xa3, 0x04, 0x05, 0x51, 0xbf, 0x07, 0x1d, 0x26, 0x3a, 0x7f, 0xc2, 0x46, 0xdd, 0xc9, 0xce,
+	0xc8, 0xf1, 0xd8, 0xb0, 0x36, 0xd6, 0x8b, 0x41, 0xc5, 0x64, 0x67, 0x18, 0x6f, 0x1f, 0xc8, 0xa5,
+	0xd4, 0xf4, 0x5c, 0xa7, 0x05, 0xab, 0xe1, 0x9e, 0x0b, 0xfa, 0x24, 0xf1, 0xd0, 0x47, 0x34, 0x16,
+	0xd3, 0x92, 0xfb, 0x8b, 0x1c, 0x6b, 0xac, 0x88, 0x17, 0x19, 0xdf, 0xb1, 0x91, 0x4b, 0xa9, 0xe9,
+	0xfd, 0xe8, 0x38, 0xde, 0x3e, 0x11, 0x47, 0x47, 0x41, 0x67, 0x46, 0x3e, 0x48, 0xcf, 0xe0, 0x2f,
+	0x75, 0xac, 0x2d, 0x22, 0x5e, 0x6a, 0x7c, 0x03, 0x46, 0x2e, 0xa5, 0xa6, 0xe7, 0x3a, 0xbf, 0x93,
+	0xbc, 0xdf, 0x2a, 0x8e, 0x75, 0x5d, 0x6e, 0x24, 0x9f, 0x8c, 0xd8, 0xde, 0x85, 0x7c, 0x33, 0x1b,
+	0x13, 0xc7, 0xf0, 0x1b, 0xf6, 0xe3, 0xbd, 0xf0, 0xac, 0x83, 0xca, 0x49, 0x11, 0x2c, 0xfe, 0xee,
+	0x2a, 0xdf, 0xc8, 0xc4, 0x13, 0xb0, 0x41, 0xdc, 0x55, 0x56, 0x6c, 0x83, 0x84, 0xeb, 0xb3, 0x7c,
+	0x33, 0x1b, 0x13, 0xc7, 0xf0, 0x02, 0xf2, 0x55, 0xcb, 0x6c, 0x1b, 0x9d, 0x81, 0x8d, 0xd1, 0xe5,
+	0x70, 0x27, 0x9b, 0xff, 0x76, 0x75, 0x34, 0xef, 0x69, 0xba, 0xf2, 0x2e, 0xb2, 0xd1, 0x35, 0x62,
+	0xe5, 0x14, 0x93, 0x27, 0x74, 0xba, 0x66, 0xb6, 0x2d, 0xf4, 0x51, 0x2c, 0x63, 0x88, 0xc6, 0xd3,
+	0xf1, 0x71, 0x1a, 0x52, 0x7f, 0x1f, 0x63, 0xee, 0xf4, 0xe2, 0x7d, 0x14, 0xb7, 0x07, 0xe4, 0x1b,
+	0x99, 0x78, 0x98, 0xfe, 0xa3, 0xdb, 0x2f, 0x6e, 0x76, 0x0c, 0xf2, 0x72, 0xd0, 0x70, 0xd1, 0x96,
+	0x58, 0x2b, 0xaa, 0xc4, 0x7e, 0xea, 0x4b, 0x9b, 0x3f, 0xa5, 0xf8, 0x1f, 0x1e, 0x37, 0xe6, 0xe9,
+	0xec, 0x8d, 0xff, 0x0f, 0x00, 0x58, 0x13, 0x76, 0xa8, 0x99, 0x2c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2589,8 +3371,12 @@ type DataStoreClient interface {
 	ListAttestedNodes(ctx context.Context, in *ListAttestedNodesRequest, opts ...grpc.CallOption) (*ListAttestedNodesResponse, error)
 	// Updates a specific attested node
 	UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error)
+	// Forces a specific attested node to rotate its SVID, leaving the rest of the node unchanged
+	ForceAttestedNodeRotation(ctx context.Context, in *ForceAttestedNodeRotationRequest, opts ...grpc.CallOption) (*ForceAttestedNodeRotationResponse, error)
 	// Deletes a specific attested node
 	DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error)
+	// Prunes all attested nodes whose SVID expired before the specified timestamp
+	PruneAttestedNodes(ctx context.Context, in *PruneAttestedNodesRequest, opts ...grpc.CallOption) (*PruneAttestedNodesResponse, error)
 	// Sets the set of selectors for a specific node id
 	SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error)
 	// Gets the set of node selectors for a specific node id
@@ -2613,12 +3399,22 @@ type DataStoreClient interface {
 	FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error)
 	// Delete a specific join token
 	DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error)
+	// Records a use of a specific join token
+	ConsumeJoinToken(ctx context.Context, in *ConsumeJoinTokenRequest, opts ...grpc.CallOption) (*ConsumeJoinTokenResponse, error)
 	// Prunes all join tokens that expire before the specified timestamp
 	PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error)
+	// Records an issued X509-SVID
+	CreateIssuedX509SVID(ctx context.Context, in *CreateIssuedX509SVIDRequest, opts ...grpc.CallOption) (*CreateIssuedX509SVIDResponse, error)
+	// Lists issued X509-SVIDs (optionally filtered)
+	ListIssuedX509SVIDs(ctx context.Context, in *ListIssuedX509SVIDsRequest, opts ...grpc.CallOption) (*ListIssuedX509SVIDsResponse, error)
+	// Prunes all issued X509-SVID records that expire before the specified timestamp
+	PruneIssuedX509SVIDs(ctx context.Context, in *PruneIssuedX509SVIDsRequest, opts ...grpc.CallOption) (*PruneIssuedX509SVIDsResponse, error)
 	// Applies the plugin configuration
 	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
 	// Returns the version and related metadata of the installed plugin
 	GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error)
+	// Returns the version of the DataStore service implemented by the plugin
+	GetInterfaceVersion(ctx context.Context, in *GetInterfaceVersionRequest, opts ...grpc.CallOption) (*GetInterfaceVersionResponse, error)
 }
 
 type dataStoreClient struct {
@@ -2737,6 +3533,15 @@ func (c *dataStoreClient) UpdateAttestedNode(ctx context.Context, in *UpdateAtte
 	return out, nil
 }
 
+func (c *dataStoreClient) ForceAttestedNodeRotation(ctx context.Context, in *ForceAttestedNodeRotationRequest, opts ...grpc.CallOption) (*ForceAttestedNodeRotationResponse, error) {
+	out := new(ForceAttestedNodeRotationResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ForceAttestedNodeRotation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error) {
 	out := new(DeleteAttestedNodeResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteAttestedNode", in, out, opts...)
@@ -2746,6 +3551,15 @@ func (c *dataStoreClient) DeleteAttestedNode(ctx context.Context, in *DeleteAtte
 	return out, nil
 }
 
+func (c *dataStoreClient) PruneAttestedNodes(ctx context.Context, in *PruneAttestedNodesRequest, opts ...grpc.CallOption) (*PruneAttestedNodesResponse, error) {
+	out := new(PruneAttestedNodesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneAttestedNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error) {
 	out := new(SetNodeSelectorsResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/SetNodeSelectors", in, out, opts...)
@@ -2845,6 +3659,15 @@ func (c *dataStoreClient) DeleteJoinToken(ctx context.Context, in *DeleteJoinTok
 	return out, nil
 }
 
+func (c *dataStoreClient) ConsumeJoinToken(ctx context.Context, in *ConsumeJoinTokenRequest, opts ...grpc.CallOption) (*ConsumeJoinTokenResponse, error) {
+	out := new(ConsumeJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ConsumeJoinToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error) {
 	out := new(PruneJoinTokensResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneJoinTokens", in, out, opts...)
@@ -2854,6 +3677,33 @@ func (c *dataStoreClient) PruneJoinTokens(ctx context.Context, in *PruneJoinToke
 	return out, nil
 }
 
+func (c *dataStoreClient) CreateIssuedX509SVID(ctx context.Context, in *CreateIssuedX509SVIDRequest, opts ...grpc.CallOption) (*CreateIssuedX509SVIDResponse, error) {
+	out := new(CreateIssuedX509SVIDResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateIssuedX509SVID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListIssuedX509SVIDs(ctx context.Context, in *ListIssuedX509SVIDsRequest, opts ...grpc.CallOption) (*ListIssuedX509SVIDsResponse, error) {
+	out := new(ListIssuedX509SVIDsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListIssuedX509SVIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneIssuedX509SVIDs(ctx context.Context, in *PruneIssuedX509SVIDsRequest, opts ...grpc.CallOption) (*PruneIssuedX509SVIDsResponse, error) {
+	out := new(PruneIssuedX509SVIDsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneIssuedX509SVIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
 	out := new(plugin.ConfigureResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/Configure", in, out, opts...)
@@ -2872,6 +3722,15 @@ func (c *dataStoreClient) GetPluginInfo(ctx context.Context, in *plugin.GetPlugi
 	return out, nil
 }
 
+func (c *dataStoreClient) GetInterfaceVersion(ctx context.Context, in *GetInterfaceVersionRequest, opts ...grpc.CallOption) (*GetInterfaceVersionResponse, error) {
+	out := new(GetInterfaceVersionResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/GetInterfaceVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DataStoreServer is the server API for DataStore service.
 type DataStoreServer interface {
 	// Creates a bundle
@@ -2898,8 +3757,12 @@ type DataStoreServer interface {
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	// Updates a specific attested node
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
+	// Forces a specific attested node to rotate its SVID, leaving the rest of the node unchanged
+	ForceAttestedNodeRotation(context.Context, *ForceAttestedNodeRotationRequest) (*ForceAttestedNodeRotationResponse, error)
 	// Deletes a specific attested node
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
+	// Prunes all attested nodes whose SVID expired before the specified timestamp
+	PruneAttestedNodes(context.Context, *PruneAttestedNodesRequest) (*PruneAttestedNodesResponse, error)
 	// Sets the set of selectors for a specific node id
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
 	// Gets the set of node selectors for a specific node id
@@ -2922,12 +3785,22 @@ type DataStoreServer interface {
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	// Delete a specific join token
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
+	// Records a use of a specific join token
+	ConsumeJoinToken(context.Context, *ConsumeJoinTokenRequest) (*ConsumeJoinTokenResponse, error)
 	// Prunes all join tokens that expire before the specified timestamp
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
+	// Records an issued X509-SVID
+	CreateIssuedX509SVID(context.Context, *CreateIssuedX509SVIDRequest) (*CreateIssuedX509SVIDResponse, error)
+	// Lists issued X509-SVIDs (optionally filtered)
+	ListIssuedX509SVIDs(context.Context, *ListIssuedX509SVIDsRequest) (*ListIssuedX509SVIDsResponse, error)
+	// Prunes all issued X509-SVID records that expire before the specified timestamp
+	PruneIssuedX509SVIDs(context.Context, *PruneIssuedX509SVIDsRequest) (*PruneIssuedX509SVIDsResponse, error)
 	// Applies the plugin configuration
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	// Returns the version and related metadata of the installed plugin
 	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
+	// Returns the version of the DataStore service implemented by the plugin
+	GetInterfaceVersion(context.Context, *GetInterfaceVersionRequest) (*GetInterfaceVersionResponse, error)
 }
 
 // UnimplementedDataStoreServer can be embedded to have forward compatible implementations.
@@ -2970,9 +3843,15 @@ func (*UnimplementedDataStoreServer) ListAttestedNodes(ctx context.Context, req
 func (*UnimplementedDataStoreServer) UpdateAttestedNode(ctx context.Context, req *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateAttestedNode not implemented")
 }
+func (*UnimplementedDataStoreServer) ForceAttestedNodeRotation(ctx context.Context, req *ForceAttestedNodeRotationRequest) (*ForceAttestedNodeRotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceAttestedNodeRotation not implemented")
+}
 func (*UnimplementedDataStoreServer) DeleteAttestedNode(ctx context.Context, req *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttestedNode not implemented")
 }
+func (*UnimplementedDataStoreServer) PruneAttestedNodes(ctx context.Context, req *PruneAttestedNodesRequest) (*PruneAttestedNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneAttestedNodes not implemented")
+}
 func (*UnimplementedDataStoreServer) SetNodeSelectors(ctx context.Context, req *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetNodeSelectors not implemented")
 }
@@ -3006,15 +3885,30 @@ func (*UnimplementedDataStoreServer) FetchJoinToken(ctx context.Context, req *Fe
 func (*UnimplementedDataStoreServer) DeleteJoinToken(ctx context.Context, req *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteJoinToken not implemented")
 }
+func (*UnimplementedDataStoreServer) ConsumeJoinToken(ctx context.Context, req *ConsumeJoinTokenRequest) (*ConsumeJoinTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConsumeJoinToken not implemented")
+}
 func (*UnimplementedDataStoreServer) PruneJoinTokens(ctx context.Context, req *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PruneJoinTokens not implemented")
 }
+func (*UnimplementedDataStoreServer) CreateIssuedX509SVID(ctx context.Context, req *CreateIssuedX509SVIDRequest) (*CreateIssuedX509SVIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateIssuedX509SVID not implemented")
+}
+func (*UnimplementedDataStoreServer) ListIssuedX509SVIDs(ctx context.Context, req *ListIssuedX509SVIDsRequest) (*ListIssuedX509SVIDsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIssuedX509SVIDs not implemented")
+}
+func (*UnimplementedDataStoreServer) PruneIssuedX509SVIDs(ctx context.Context, req *PruneIssuedX509SVIDsRequest) (*PruneIssuedX509SVIDsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneIssuedX509SVIDs not implemented")
+}
 func (*UnimplementedDataStoreServer) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
 }
 func (*UnimplementedDataStoreServer) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPluginInfo not implemented")
 }
+func (*UnimplementedDataStoreServer) GetInterfaceVersion(ctx context.Context, req *GetInterfaceVersionRequest) (*GetInterfaceVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInterfaceVersion not implemented")
+}
 
 func RegisterDataStoreServer(s *grpc.Server, srv DataStoreServer) {
 	s.RegisterService(&_DataStore_serviceDesc, srv)
@@ -3236,6 +4130,24 @@ func _DataStore_UpdateAttestedNode_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_ForceAttestedNodeRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceAttestedNodeRotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ForceAttestedNodeRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ForceAttestedNodeRotation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ForceAttestedNodeRotation(ctx, req.(*ForceAttestedNodeRotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_DeleteAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteAttestedNodeRequest)
 	if err := dec(in); err != nil {
@@ -3254,6 +4166,24 @@ func _DataStore_DeleteAttestedNode_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_PruneAttestedNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneAttestedNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneAttestedNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/PruneAttestedNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneAttestedNodes(ctx, req.(*PruneAttestedNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_SetNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetNodeSelectorsRequest)
 	if err := dec(in); err != nil {
@@ -3452,6 +4382,24 @@ func _DataStore_DeleteJoinToken_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_ConsumeJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsumeJoinTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ConsumeJoinToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ConsumeJoinToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ConsumeJoinToken(ctx, req.(*ConsumeJoinTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PruneJoinTokensRequest)
 	if err := dec(in); err != nil {
@@ -3470,6 +4418,60 @@ func _DataStore_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_CreateIssuedX509SVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateIssuedX509SVIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateIssuedX509SVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CreateIssuedX509SVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateIssuedX509SVID(ctx, req.(*CreateIssuedX509SVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListIssuedX509SVIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIssuedX509SVIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListIssuedX509SVIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListIssuedX509SVIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListIssuedX509SVIDs(ctx, req.(*ListIssuedX509SVIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_PruneIssuedX509SVIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneIssuedX509SVIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneIssuedX509SVIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/PruneIssuedX509SVIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneIssuedX509SVIDs(ctx, req.(*PruneIssuedX509SVIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(plugin.ConfigureRequest)
 	if err := dec(in); err != nil {
@@ -3506,6 +4508,24 @@ func _DataStore_GetPluginInfo_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_GetInterfaceVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInterfaceVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).GetInterfaceVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/GetInterfaceVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).GetInterfaceVersion(ctx, req.(*GetInterfaceVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DataStore_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.server.datastore.DataStore",
 	HandlerType: (*DataStoreServer)(nil),
@@ -3558,10 +4578,18 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateAttestedNode",
 			Handler:    _DataStore_UpdateAttestedNode_Handler,
 		},
+		{
+			MethodName: "ForceAttestedNodeRotation",
+			Handler:    _DataStore_ForceAttestedNodeRotation_Handler,
+		},
 		{
 			MethodName: "DeleteAttestedNode",
 			Handler:    _DataStore_DeleteAttestedNode_Handler,
 		},
+		{
+			MethodName: "PruneAttestedNodes",
+			Handler:    _DataStore_PruneAttestedNodes_Handler,
+		},
 		{
 			MethodName: "SetNodeSelectors",
 			Handler:    _DataStore_SetNodeSelectors_Handler,
@@ -3606,10 +4634,26 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteJoinToken",
 			Handler:    _DataStore_DeleteJoinToken_Handler,
 		},
+		{
+			MethodName: "ConsumeJoinToken",
+			Handler:    _DataStore_ConsumeJoinToken_Handler,
+		},
 		{
 			MethodName: "PruneJoinTokens",
 			Handler:    _DataStore_PruneJoinTokens_Handler,
 		},
+		{
+			MethodName: "CreateIssuedX509SVID",
+			Handler:    _DataStore_CreateIssuedX509SVID_Handler,
+		},
+		{
+			MethodName: "ListIssuedX509SVIDs",
+			Handler:    _DataStore_ListIssuedX509SVIDs_Handler,
+		},
+		{
+			MethodName: "PruneIssuedX509SVIDs",
+			Handler:    _DataStore_PruneIssuedX509SVIDs_Handler,
+		},
 		{
 			MethodName: "Configure",
 			Handler:    _DataStore_Configure_Handler,
@@ -3618,6 +4662,10 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetPluginInfo",
 			Handler:    _DataStore_GetPluginInfo_Handler,
 		},
+		{
+			MethodName: "GetInterfaceVersion",
+			Handler:    _DataStore_GetInterfaceVersion_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "spire/server/datastore/datastore.proto",
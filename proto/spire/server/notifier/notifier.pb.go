@@ -104,9 +104,168 @@ func (m *BundleUpdated) GetBundle() *common.Bundle {
 	return nil
 }
 
+type X509CAActivated struct {
+	// ASN.1 DER encoded X509 CA certificate
+	Certificate          []byte   `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509CAActivated) Reset()         { *m = X509CAActivated{} }
+func (m *X509CAActivated) String() string { return proto.CompactTextString(m) }
+func (*X509CAActivated) ProtoMessage()    {}
+func (*X509CAActivated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c27428e9e6d193e9, []int{2}
+}
+
+func (m *X509CAActivated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509CAActivated.Unmarshal(m, b)
+}
+func (m *X509CAActivated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509CAActivated.Marshal(b, m, deterministic)
+}
+func (m *X509CAActivated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509CAActivated.Merge(m, src)
+}
+func (m *X509CAActivated) XXX_Size() int {
+	return xxx_messageInfo_X509CAActivated.Size(m)
+}
+func (m *X509CAActivated) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509CAActivated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509CAActivated proto.InternalMessageInfo
+
+func (m *X509CAActivated) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+type JWTKeyActivated struct {
+	JwtKey               *common.PublicKey `protobuf:"bytes,1,opt,name=jwt_key,json=jwtKey,proto3" json:"jwt_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *JWTKeyActivated) Reset()         { *m = JWTKeyActivated{} }
+func (m *JWTKeyActivated) String() string { return proto.CompactTextString(m) }
+func (*JWTKeyActivated) ProtoMessage()    {}
+func (*JWTKeyActivated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c27428e9e6d193e9, []int{3}
+}
+
+func (m *JWTKeyActivated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JWTKeyActivated.Unmarshal(m, b)
+}
+func (m *JWTKeyActivated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JWTKeyActivated.Marshal(b, m, deterministic)
+}
+func (m *JWTKeyActivated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JWTKeyActivated.Merge(m, src)
+}
+func (m *JWTKeyActivated) XXX_Size() int {
+	return xxx_messageInfo_JWTKeyActivated.Size(m)
+}
+func (m *JWTKeyActivated) XXX_DiscardUnknown() {
+	xxx_messageInfo_JWTKeyActivated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JWTKeyActivated proto.InternalMessageInfo
+
+func (m *JWTKeyActivated) GetJwtKey() *common.PublicKey {
+	if m != nil {
+		return m.JwtKey
+	}
+	return nil
+}
+
+type EntryCreating struct {
+	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *EntryCreating) Reset()         { *m = EntryCreating{} }
+func (m *EntryCreating) String() string { return proto.CompactTextString(m) }
+func (*EntryCreating) ProtoMessage()    {}
+func (*EntryCreating) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c27428e9e6d193e9, []int{4}
+}
+
+func (m *EntryCreating) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EntryCreating.Unmarshal(m, b)
+}
+func (m *EntryCreating) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EntryCreating.Marshal(b, m, deterministic)
+}
+func (m *EntryCreating) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EntryCreating.Merge(m, src)
+}
+func (m *EntryCreating) XXX_Size() int {
+	return xxx_messageInfo_EntryCreating.Size(m)
+}
+func (m *EntryCreating) XXX_DiscardUnknown() {
+	xxx_messageInfo_EntryCreating.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EntryCreating proto.InternalMessageInfo
+
+func (m *EntryCreating) GetEntry() *common.RegistrationEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+type EntryUpdating struct {
+	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *EntryUpdating) Reset()         { *m = EntryUpdating{} }
+func (m *EntryUpdating) String() string { return proto.CompactTextString(m) }
+func (*EntryUpdating) ProtoMessage()    {}
+func (*EntryUpdating) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c27428e9e6d193e9, []int{5}
+}
+
+func (m *EntryUpdating) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EntryUpdating.Unmarshal(m, b)
+}
+func (m *EntryUpdating) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EntryUpdating.Marshal(b, m, deterministic)
+}
+func (m *EntryUpdating) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EntryUpdating.Merge(m, src)
+}
+func (m *EntryUpdating) XXX_Size() int {
+	return xxx_messageInfo_EntryUpdating.Size(m)
+}
+func (m *EntryUpdating) XXX_DiscardUnknown() {
+	xxx_messageInfo_EntryUpdating.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EntryUpdating proto.InternalMessageInfo
+
+func (m *EntryUpdating) GetEntry() *common.RegistrationEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
 type NotifyRequest struct {
 	// Types that are valid to be assigned to Event:
 	//	*NotifyRequest_BundleUpdated
+	//	*NotifyRequest_X509CaActivated
+	//	*NotifyRequest_JwtKeyActivated
 	Event                isNotifyRequest_Event `protobuf_oneof:"event"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
@@ -117,7 +276,7 @@ func (m *NotifyRequest) Reset()         { *m = NotifyRequest{} }
 func (m *NotifyRequest) String() string { return proto.CompactTextString(m) }
 func (*NotifyRequest) ProtoMessage()    {}
 func (*NotifyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c27428e9e6d193e9, []int{2}
+	return fileDescriptor_c27428e9e6d193e9, []int{6}
 }
 
 func (m *NotifyRequest) XXX_Unmarshal(b []byte) error {
@@ -146,8 +305,20 @@ type NotifyRequest_BundleUpdated struct {
 	BundleUpdated *BundleUpdated `protobuf:"bytes,1,opt,name=bundle_updated,json=bundleUpdated,proto3,oneof"`
 }
 
+type NotifyRequest_X509CaActivated struct {
+	X509CaActivated *X509CAActivated `protobuf:"bytes,2,opt,name=x509_ca_activated,json=x509CaActivated,proto3,oneof"`
+}
+
+type NotifyRequest_JwtKeyActivated struct {
+	JwtKeyActivated *JWTKeyActivated `protobuf:"bytes,3,opt,name=jwt_key_activated,json=jwtKeyActivated,proto3,oneof"`
+}
+
 func (*NotifyRequest_BundleUpdated) isNotifyRequest_Event() {}
 
+func (*NotifyRequest_X509CaActivated) isNotifyRequest_Event() {}
+
+func (*NotifyRequest_JwtKeyActivated) isNotifyRequest_Event() {}
+
 func (m *NotifyRequest) GetEvent() isNotifyRequest_Event {
 	if m != nil {
 		return m.Event
@@ -162,10 +333,26 @@ func (m *NotifyRequest) GetBundleUpdated() *BundleUpdated {
 	return nil
 }
 
+func (m *NotifyRequest) GetX509CaActivated() *X509CAActivated {
+	if x, ok := m.GetEvent().(*NotifyRequest_X509CaActivated); ok {
+		return x.X509CaActivated
+	}
+	return nil
+}
+
+func (m *NotifyRequest) GetJwtKeyActivated() *JWTKeyActivated {
+	if x, ok := m.GetEvent().(*NotifyRequest_JwtKeyActivated); ok {
+		return x.JwtKeyActivated
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*NotifyRequest) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*NotifyRequest_BundleUpdated)(nil),
+		(*NotifyRequest_X509CaActivated)(nil),
+		(*NotifyRequest_JwtKeyActivated)(nil),
 	}
 }
 
@@ -179,7 +366,7 @@ func (m *NotifyResponse) Reset()         { *m = NotifyResponse{} }
 func (m *NotifyResponse) String() string { return proto.CompactTextString(m) }
 func (*NotifyResponse) ProtoMessage()    {}
 func (*NotifyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c27428e9e6d193e9, []int{3}
+	return fileDescriptor_c27428e9e6d193e9, []int{7}
 }
 
 func (m *NotifyResponse) XXX_Unmarshal(b []byte) error {
@@ -203,6 +390,8 @@ var xxx_messageInfo_NotifyResponse proto.InternalMessageInfo
 type NotifyAndAdviseRequest struct {
 	// Types that are valid to be assigned to Event:
 	//	*NotifyAndAdviseRequest_BundleLoaded
+	//	*NotifyAndAdviseRequest_EntryCreating
+	//	*NotifyAndAdviseRequest_EntryUpdating
 	Event                isNotifyAndAdviseRequest_Event `protobuf_oneof:"event"`
 	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
 	XXX_unrecognized     []byte                         `json:"-"`
@@ -213,7 +402,7 @@ func (m *NotifyAndAdviseRequest) Reset()         { *m = NotifyAndAdviseRequest{}
 func (m *NotifyAndAdviseRequest) String() string { return proto.CompactTextString(m) }
 func (*NotifyAndAdviseRequest) ProtoMessage()    {}
 func (*NotifyAndAdviseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c27428e9e6d193e9, []int{4}
+	return fileDescriptor_c27428e9e6d193e9, []int{8}
 }
 
 func (m *NotifyAndAdviseRequest) XXX_Unmarshal(b []byte) error {
@@ -242,8 +431,20 @@ type NotifyAndAdviseRequest_BundleLoaded struct {
 	BundleLoaded *BundleLoaded `protobuf:"bytes,1,opt,name=bundle_loaded,json=bundleLoaded,proto3,oneof"`
 }
 
+type NotifyAndAdviseRequest_EntryCreating struct {
+	EntryCreating *EntryCreating `protobuf:"bytes,2,opt,name=entry_creating,json=entryCreating,proto3,oneof"`
+}
+
+type NotifyAndAdviseRequest_EntryUpdating struct {
+	EntryUpdating *EntryUpdating `protobuf:"bytes,3,opt,name=entry_updating,json=entryUpdating,proto3,oneof"`
+}
+
 func (*NotifyAndAdviseRequest_BundleLoaded) isNotifyAndAdviseRequest_Event() {}
 
+func (*NotifyAndAdviseRequest_EntryCreating) isNotifyAndAdviseRequest_Event() {}
+
+func (*NotifyAndAdviseRequest_EntryUpdating) isNotifyAndAdviseRequest_Event() {}
+
 func (m *NotifyAndAdviseRequest) GetEvent() isNotifyAndAdviseRequest_Event {
 	if m != nil {
 		return m.Event
@@ -258,10 +459,26 @@ func (m *NotifyAndAdviseRequest) GetBundleLoaded() *BundleLoaded {
 	return nil
 }
 
+func (m *NotifyAndAdviseRequest) GetEntryCreating() *EntryCreating {
+	if x, ok := m.GetEvent().(*NotifyAndAdviseRequest_EntryCreating); ok {
+		return x.EntryCreating
+	}
+	return nil
+}
+
+func (m *NotifyAndAdviseRequest) GetEntryUpdating() *EntryUpdating {
+	if x, ok := m.GetEvent().(*NotifyAndAdviseRequest_EntryUpdating); ok {
+		return x.EntryUpdating
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*NotifyAndAdviseRequest) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*NotifyAndAdviseRequest_BundleLoaded)(nil),
+		(*NotifyAndAdviseRequest_EntryCreating)(nil),
+		(*NotifyAndAdviseRequest_EntryUpdating)(nil),
 	}
 }
 
@@ -275,7 +492,7 @@ func (m *NotifyAndAdviseResponse) Reset()         { *m = NotifyAndAdviseResponse
 func (m *NotifyAndAdviseResponse) String() string { return proto.CompactTextString(m) }
 func (*NotifyAndAdviseResponse) ProtoMessage()    {}
 func (*NotifyAndAdviseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c27428e9e6d193e9, []int{5}
+	return fileDescriptor_c27428e9e6d193e9, []int{9}
 }
 
 func (m *NotifyAndAdviseResponse) XXX_Unmarshal(b []byte) error {
@@ -299,6 +516,10 @@ var xxx_messageInfo_NotifyAndAdviseResponse proto.InternalMessageInfo
 func init() {
 	proto.RegisterType((*BundleLoaded)(nil), "spire.server.notifier.BundleLoaded")
 	proto.RegisterType((*BundleUpdated)(nil), "spire.server.notifier.BundleUpdated")
+	proto.RegisterType((*X509CAActivated)(nil), "spire.server.notifier.X509CAActivated")
+	proto.RegisterType((*JWTKeyActivated)(nil), "spire.server.notifier.JWTKeyActivated")
+	proto.RegisterType((*EntryCreating)(nil), "spire.server.notifier.EntryCreating")
+	proto.RegisterType((*EntryUpdating)(nil), "spire.server.notifier.EntryUpdating")
 	proto.RegisterType((*NotifyRequest)(nil), "spire.server.notifier.NotifyRequest")
 	proto.RegisterType((*NotifyResponse)(nil), "spire.server.notifier.NotifyResponse")
 	proto.RegisterType((*NotifyAndAdviseRequest)(nil), "spire.server.notifier.NotifyAndAdviseRequest")
@@ -310,31 +531,44 @@ func init() {
 }
 
 var fileDescriptor_c27428e9e6d193e9 = []byte{
-	// 381 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0x4f, 0x4f, 0xea, 0x40,
-	0x14, 0xc5, 0x21, 0x2f, 0x8f, 0xf7, 0xde, 0x7d, 0x14, 0xcd, 0xc4, 0x7f, 0x74, 0x45, 0x2a, 0x18,
-	0x35, 0xda, 0x26, 0x10, 0x77, 0xba, 0x00, 0x17, 0xa2, 0x51, 0x62, 0x9a, 0xb0, 0x61, 0x43, 0xa8,
-	0xbd, 0xad, 0x93, 0xc0, 0x4c, 0xed, 0x1f, 0x12, 0x3f, 0x89, 0x5f, 0xd7, 0xd0, 0x3b, 0x55, 0x8a,
-	0x08, 0xb8, 0x6a, 0x67, 0xce, 0xef, 0x9e, 0x73, 0xe7, 0x66, 0x06, 0xea, 0x51, 0xc0, 0x43, 0xb4,
-	0x22, 0x0c, 0xa7, 0x18, 0x5a, 0x42, 0xc6, 0xdc, 0xe3, 0x73, 0x3f, 0x66, 0x10, 0xca, 0x58, 0xb2,
-	0xdd, 0x94, 0x32, 0x89, 0x32, 0x33, 0x51, 0xaf, 0x52, 0xf1, 0x93, 0x9c, 0x4c, 0xa4, 0x50, 0x1f,
-	0xaa, 0xd0, 0x6b, 0x39, 0x29, 0x18, 0x27, 0x3e, 0xcf, 0x3e, 0x44, 0x18, 0x97, 0x50, 0xee, 0x24,
-	0xc2, 0x1d, 0xe3, 0xbd, 0x1c, 0xb9, 0xe8, 0xb2, 0x33, 0x28, 0x39, 0xe9, 0xfa, 0xa0, 0x58, 0x2b,
-	0x1e, 0xff, 0x6f, 0xee, 0x98, 0x14, 0xaa, 0x6c, 0x89, 0xb5, 0x15, 0x63, 0x5c, 0x81, 0x46, 0x3b,
-	0xfd, 0xc0, 0x1d, 0xc5, 0x3f, 0x2e, 0xf7, 0x41, 0xeb, 0xcd, 0x4e, 0xf1, 0x6a, 0xe3, 0x4b, 0x82,
-	0x51, 0xcc, 0x1e, 0xa0, 0x42, 0xd2, 0x30, 0x21, 0x43, 0x65, 0x53, 0x37, 0x97, 0x1e, 0xdd, 0xcc,
-	0x85, 0x77, 0x0b, 0xb6, 0xe6, 0xcc, 0x6f, 0x74, 0xfe, 0xc0, 0x6f, 0x9c, 0xa2, 0x88, 0x8d, 0x6d,
-	0xa8, 0x64, 0x41, 0x51, 0x20, 0x45, 0x84, 0xc6, 0x04, 0xf6, 0x68, 0xa7, 0x2d, 0xdc, 0xb6, 0x3b,
-	0xe5, 0x11, 0x66, 0x3d, 0xdc, 0x81, 0x72, 0x19, 0x8e, 0xd3, 0x91, 0xa8, 0x16, 0x0e, 0x57, 0xb6,
-	0x40, 0xd3, 0xeb, 0x16, 0xec, 0xb2, 0x33, 0xb7, 0xfe, 0x6c, 0xa0, 0x0a, 0xfb, 0x5f, 0xe2, 0xa8,
-	0x93, 0xe6, 0xdb, 0x2f, 0xf8, 0xdb, 0x53, 0x6e, 0xac, 0x0f, 0x25, 0xe2, 0xd8, 0x77, 0x47, 0xce,
-	0x0d, 0x4c, 0x6f, 0xac, 0xa1, 0x28, 0x83, 0x05, 0xb0, 0xb5, 0x10, 0xcf, 0xce, 0x57, 0x56, 0x2e,
-	0x4e, 0x45, 0x37, 0x37, 0xc5, 0x55, 0xe2, 0x00, 0xfe, 0x5d, 0x4b, 0xe1, 0x71, 0x3f, 0x09, 0x91,
-	0x35, 0xf2, 0xb7, 0x40, 0x5d, 0xc0, 0x0f, 0x3d, 0xcb, 0x38, 0x5a, 0x87, 0x29, 0x6f, 0x0f, 0xb4,
-	0x1b, 0x8c, 0x1f, 0x53, 0xf9, 0x56, 0x78, 0x92, 0x9d, 0x2c, 0x2d, 0xcc, 0x31, 0x59, 0xc6, 0xe9,
-	0x26, 0x28, 0xe5, 0x74, 0x2e, 0x06, 0x2d, 0x9f, 0xc7, 0xcf, 0x89, 0x33, 0xa3, 0xad, 0x28, 0xe0,
-	0x9e, 0x87, 0x16, 0xbd, 0xa8, 0xf4, 0xf1, 0x58, 0x4b, 0x5f, 0xad, 0x53, 0x4a, 0xc5, 0xd6, 0x7b,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xe1, 0x06, 0x0b, 0xf9, 0xd5, 0x03, 0x00, 0x00,
+	// 586 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x95, 0x4d, 0x6f, 0xd3, 0x4c,
+	0x10, 0xc7, 0xdb, 0x44, 0x4d, 0x9f, 0x67, 0x9a, 0x17, 0x6a, 0x01, 0x4d, 0x73, 0x21, 0x32, 0x6d,
+	0x05, 0x08, 0x9c, 0xa8, 0x51, 0x0e, 0x95, 0xe0, 0x90, 0x44, 0x40, 0x68, 0x68, 0x55, 0x59, 0xa9,
+	0x40, 0xbd, 0x58, 0x7e, 0x19, 0x87, 0x2d, 0xe9, 0xda, 0xd8, 0xeb, 0x14, 0x7f, 0x06, 0x8e, 0x1c,
+	0xf8, 0xba, 0xc8, 0xbb, 0xeb, 0x24, 0x0e, 0x69, 0x52, 0xc4, 0xc9, 0xc9, 0xcc, 0x7f, 0x7e, 0xa3,
+	0xfd, 0xcf, 0x78, 0x0d, 0x07, 0xa1, 0x4f, 0x02, 0x6c, 0x84, 0x18, 0x4c, 0x30, 0x68, 0x50, 0x8f,
+	0x11, 0x97, 0xcc, 0xfd, 0xd0, 0xfc, 0xc0, 0x63, 0x9e, 0xf2, 0x88, 0xab, 0x34, 0xa1, 0xd2, 0xd2,
+	0x64, 0x6d, 0x5f, 0x14, 0xdb, 0xde, 0xcd, 0x8d, 0x47, 0xe5, 0x43, 0x54, 0xd4, 0xea, 0x99, 0x94,
+	0x3f, 0x8e, 0x46, 0x24, 0x7d, 0x08, 0x85, 0xfa, 0x1a, 0x8a, 0xdd, 0x88, 0x3a, 0x63, 0xfc, 0xe8,
+	0x99, 0x0e, 0x3a, 0xca, 0x4b, 0x28, 0x58, 0xfc, 0x7f, 0x75, 0xb3, 0xbe, 0xf9, 0x6c, 0xe7, 0xf8,
+	0xa1, 0x26, 0x9a, 0x4a, 0xac, 0xd0, 0xea, 0x52, 0xa3, 0xbe, 0x81, 0x92, 0x88, 0x5c, 0xfa, 0x8e,
+	0xc9, 0xfe, 0xba, 0xbc, 0x05, 0x95, 0xcf, 0xed, 0xe6, 0x49, 0xaf, 0xd3, 0xb1, 0x19, 0x99, 0x70,
+	0x40, 0x1d, 0x76, 0x6c, 0x0c, 0x92, 0x93, 0xd9, 0x26, 0x13, 0x94, 0xa2, 0x3e, 0x1f, 0x52, 0x7b,
+	0x50, 0x39, 0xfd, 0x34, 0x1c, 0x60, 0x3c, 0x2b, 0x6a, 0xc2, 0xf6, 0xf5, 0x2d, 0x33, 0xbe, 0x62,
+	0x2c, 0xdb, 0xee, 0x65, 0xdb, 0x5e, 0x44, 0xd6, 0x98, 0xd8, 0x03, 0x8c, 0xf5, 0xc2, 0xf5, 0x2d,
+	0x1b, 0x60, 0xac, 0xbe, 0x83, 0xd2, 0x5b, 0xca, 0x82, 0xb8, 0x17, 0xa0, 0xc9, 0x08, 0x1d, 0x29,
+	0x6d, 0xd8, 0xc2, 0x24, 0x20, 0x01, 0x4f, 0xb2, 0x00, 0x1d, 0x47, 0x24, 0x64, 0x81, 0xc9, 0x88,
+	0x47, 0x79, 0x9d, 0x2e, 0xd4, 0x53, 0x0e, 0x3f, 0xff, 0x3f, 0x70, 0x7e, 0xe6, 0xa0, 0x74, 0x9e,
+	0x0c, 0x34, 0xd6, 0xf1, 0x5b, 0x84, 0x21, 0x53, 0xce, 0xa0, 0x2c, 0x5c, 0x32, 0x22, 0xe1, 0xad,
+	0x24, 0x1e, 0x68, 0x4b, 0xb7, 0x40, 0xcb, 0xcc, 0xa1, 0xbf, 0xa1, 0x97, 0xac, 0xcc, 0x60, 0x86,
+	0xb0, 0xfb, 0xbd, 0xdd, 0x3c, 0x31, 0x6c, 0xd3, 0x30, 0x53, 0xdf, 0xaa, 0x39, 0x4e, 0x3c, 0xba,
+	0x83, 0xb8, 0x30, 0x9a, 0xfe, 0x86, 0x5e, 0x49, 0x10, 0x3d, 0x73, 0x66, 0xfc, 0x10, 0x76, 0xa5,
+	0xf1, 0x73, 0xd4, 0xfc, 0x4a, 0xea, 0xc2, 0xec, 0x12, 0xaa, 0x98, 0xc9, 0x34, 0xd4, 0xdd, 0x86,
+	0x2d, 0x9c, 0x20, 0x65, 0xea, 0x03, 0x28, 0xa7, 0xa6, 0x84, 0xbe, 0x47, 0x43, 0x54, 0x7f, 0xe4,
+	0xe0, 0xb1, 0x08, 0x75, 0xa8, 0xd3, 0x71, 0x26, 0x24, 0xc4, 0xd4, 0xb0, 0x53, 0x90, 0x47, 0x36,
+	0xc6, 0x7c, 0x95, 0xa5, 0x5f, 0x4f, 0x57, 0xfa, 0x25, 0xb6, 0xbe, 0xbf, 0xa1, 0x17, 0xad, 0xf9,
+	0xb7, 0xe0, 0x0c, 0xca, 0x7c, 0x2e, 0x86, 0x2d, 0xf7, 0xa3, 0x9a, 0x5b, 0x69, 0x7e, 0x66, 0x97,
+	0x12, 0xf3, 0x31, 0xb3, 0x5c, 0x53, 0x5c, 0x24, 0xd7, 0xa4, 0x9a, 0x5f, 0x8f, 0x4b, 0x57, 0x6a,
+	0x8a, 0x4b, 0x03, 0x33, 0x7f, 0xf6, 0x61, 0xef, 0x0f, 0x33, 0x84, 0x51, 0xc7, 0xbf, 0xf2, 0xf0,
+	0xdf, 0xb9, 0xe4, 0x29, 0x97, 0x50, 0x10, 0x3a, 0xe5, 0xae, 0x8e, 0x99, 0xdd, 0xab, 0x1d, 0xae,
+	0x51, 0x89, 0x1e, 0x8a, 0x0f, 0x95, 0x85, 0xf6, 0xca, 0xab, 0x95, 0x95, 0x8b, 0x33, 0xab, 0x69,
+	0xf7, 0x95, 0xcb, 0x8e, 0x57, 0xf0, 0x7f, 0xcf, 0xa3, 0x2e, 0x19, 0x45, 0x01, 0x2a, 0x87, 0xd9,
+	0x77, 0x4b, 0x5e, 0x6b, 0xd3, 0x7c, 0xda, 0xe3, 0x68, 0x9d, 0x4c, 0xb2, 0x5d, 0x28, 0xbd, 0x47,
+	0x76, 0xc1, 0xd3, 0x1f, 0xa8, 0xeb, 0x29, 0xcf, 0x97, 0x16, 0x66, 0x34, 0x69, 0x8f, 0x17, 0xf7,
+	0x91, 0x8a, 0x3e, 0xdd, 0xf6, 0x55, 0x6b, 0x44, 0xd8, 0x97, 0xc8, 0x4a, 0xd4, 0x8d, 0xd0, 0x27,
+	0xae, 0x8b, 0x0d, 0x71, 0x4f, 0xf3, 0x2b, 0xb9, 0xb1, 0xf4, 0x5b, 0x60, 0x15, 0x78, 0xb2, 0xf5,
+	0x7b, 0x00, 0xa0, 0xa3, 0xce, 0x19, 0x2b, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2,6 +2,8 @@ package main
 
 import (
 	"io/ioutil"
+	"text/template"
+	"time"
 
 	"github.com/hashicorp/hcl"
 	"github.com/zeebo/errs"
@@ -13,6 +15,11 @@ const (
 	defaultCertPath   = "cert.pem"
 	defaultKeyPath    = "key.pem"
 	defaultCaCertPath = "cacert.pem"
+
+	defaultResyncInterval = time.Minute
+
+	modeWebhook   = "webhook"
+	modeReconcile = "reconcile"
 )
 
 type Config struct {
@@ -29,6 +36,12 @@ type Config struct {
 	Cluster                        string `hcl:"cluster"`
 	PodLabel                       string `hcl:"pod_label"`
 	PodAnnotation                  string `hcl:"pod_annotation"`
+	SpiffeIDTemplate               string `hcl:"spiffe_id_template"`
+	Mode                           string `hcl:"mode"`
+	KubeConfigPath                 string `hcl:"kubeconfig_path"`
+	ResyncInterval                 string `hcl:"resync_interval"`
+	ServiceAccountEntries          bool   `hcl:"service_account_entries"`
+	NodeEntries                    bool   `hcl:"node_entries"`
 }
 
 func LoadConfig(path string) (*Config, error) {
@@ -72,6 +85,62 @@ func ParseConfig(hclConfig string) (*Config, error) {
 	if c.PodLabel != "" && c.PodAnnotation != "" {
 		return nil, errs.New("workload registration mode specification is incorrect, can't specify both pod_label and pod_annotation")
 	}
+	if c.SpiffeIDTemplate != "" && (c.PodLabel != "" || c.PodAnnotation != "") {
+		return nil, errs.New("workload registration mode specification is incorrect, can't specify spiffe_id_template with pod_label or pod_annotation")
+	}
+	if _, err := parseSpiffeIDTemplate(c.SpiffeIDTemplate); err != nil {
+		return nil, errs.New("invalid spiffe_id_template: %v", err)
+	}
+
+	switch c.Mode {
+	case "":
+		c.Mode = modeWebhook
+	case modeWebhook, modeReconcile:
+	default:
+		return nil, errs.New("mode must be %q or %q", modeWebhook, modeReconcile)
+	}
+	if c.Mode == modeReconcile {
+		if c.ResyncInterval == "" {
+			c.ResyncInterval = defaultResyncInterval.String()
+		}
+		if d, err := time.ParseDuration(c.ResyncInterval); err != nil || d <= 0 {
+			return nil, errs.New("invalid resync_interval %q: must be a positive duration", c.ResyncInterval)
+		}
+	}
+	if c.ServiceAccountEntries {
+		if c.Mode != modeReconcile {
+			return nil, errs.New("service_account_entries requires the reconcile mode")
+		}
+		if c.PodLabel != "" || c.PodAnnotation != "" {
+			return nil, errs.New("service_account_entries can't be used with pod_label or pod_annotation")
+		}
+	}
+	if c.NodeEntries {
+		if c.Mode != modeReconcile {
+			return nil, errs.New("node_entries requires the reconcile mode")
+		}
+		if c.ServiceAccountEntries {
+			return nil, errs.New("node_entries can't be used with service_account_entries")
+		}
+	}
 
 	return c, nil
 }
+
+// ResyncDuration returns the interval between full reconciliations.
+func (c *Config) ResyncDuration() time.Duration {
+	d, err := time.ParseDuration(c.ResyncInterval)
+	if err != nil {
+		return defaultResyncInterval
+	}
+	return d
+}
+
+// parseSpiffeIDTemplate parses the template of the SPIFFE ID path of the
+// workloads, returning nil if there is none
+func parseSpiffeIDTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	return template.New("spiffe_id").Option("missingkey=zero").Parse(text)
+}
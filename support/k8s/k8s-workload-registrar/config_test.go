@@ -45,6 +45,7 @@ func TestLoadConfig(t *testing.T) {
 		ServerSocketPath: "SOCKETPATH",
 		TrustDomain:      "TRUSTDOMAIN",
 		Cluster:          "CLUSTER",
+		Mode:             modeWebhook,
 	}, config)
 }
 
@@ -68,6 +69,7 @@ func TestParseConfig(t *testing.T) {
 				ServerSocketPath:               "SOCKETPATH",
 				TrustDomain:                    "TRUSTDOMAIN",
 				Cluster:                        "CLUSTER",
+				Mode:                           modeWebhook,
 			},
 		},
 		{
@@ -97,6 +99,31 @@ func TestParseConfig(t *testing.T) {
 				TrustDomain:                    "TRUSTDOMAINOVERRIDE",
 				Cluster:                        "CLUSTEROVERRIDE",
 				PodLabel:                       "PODLABEL",
+				Mode:                           modeWebhook,
+			},
+		},
+		{
+			name: "reconcile mode",
+			in: testMinimalConfig + `
+				mode = "reconcile"
+				kubeconfig_path = "KUBECONFIG"
+				spiffe_id_template = "ns/{{.Namespace}}/sa/{{.ServiceAccount}}"
+				service_account_entries = true
+			`,
+			out: &Config{
+				LogLevel:              defaultLogLevel,
+				Addr:                  ":8443",
+				CertPath:              defaultCertPath,
+				KeyPath:               defaultKeyPath,
+				CaCertPath:            defaultCaCertPath,
+				ServerSocketPath:      "SOCKETPATH",
+				TrustDomain:           "TRUSTDOMAIN",
+				Cluster:               "CLUSTER",
+				SpiffeIDTemplate:      "ns/{{.Namespace}}/sa/{{.ServiceAccount}}",
+				Mode:                  modeReconcile,
+				KubeConfigPath:        "KUBECONFIG",
+				ResyncInterval:        "1m0s",
+				ServiceAccountEntries: true,
 			},
 		},
 		{
@@ -136,6 +163,59 @@ func TestParseConfig(t *testing.T) {
 			`,
 			err: "workload registration mode specification is incorrect, can't specify both pod_label and pod_annotation",
 		},
+		{
+			name: "spiffe_id_template with pod_label",
+			in: testMinimalConfig + `
+				pod_label = "PODLABEL"
+				spiffe_id_template = "{{.PodName}}"
+			`,
+			err: "workload registration mode specification is incorrect, can't specify spiffe_id_template with pod_label or pod_annotation",
+		},
+		{
+			name: "invalid spiffe_id_template",
+			in: testMinimalConfig + `
+				spiffe_id_template = "{{.PodName"
+			`,
+			err: "invalid spiffe_id_template",
+		},
+		{
+			name: "invalid mode",
+			in: testMinimalConfig + `
+				mode = "MODE"
+			`,
+			err: `mode must be "webhook" or "reconcile"`,
+		},
+		{
+			name: "invalid resync_interval",
+			in: testMinimalConfig + `
+				mode = "reconcile"
+				resync_interval = "0s"
+			`,
+			err: `invalid resync_interval "0s": must be a positive duration`,
+		},
+		{
+			name: "service_account_entries without the reconcile mode",
+			in: testMinimalConfig + `
+				service_account_entries = true
+			`,
+			err: "service_account_entries requires the reconcile mode",
+		},
+		{
+			name: "node_entries without the reconcile mode",
+			in: testMinimalConfig + `
+				node_entries = true
+			`,
+			err: "node_entries requires the reconcile mode",
+		},
+		{
+			name: "node_entries with service_account_entries",
+			in: testMinimalConfig + `
+				mode = "reconcile"
+				service_account_entries = true
+				node_entries = true
+			`,
+			err: "node_entries can't be used with service_account_entries",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strings"
+	"text/template"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/idutil"
@@ -27,6 +29,10 @@ type ControllerConfig struct {
 	Cluster       string
 	PodLabel      string
 	PodAnnotation string
+
+	// SpiffeIDTemplate, if set, is executed with the pod details to build
+	// the path of the SPIFFE ID of the pod
+	SpiffeIDTemplate *template.Template
 }
 
 type Controller struct {
@@ -126,11 +132,71 @@ func (c *Controller) podSpiffeID(pod *corev1.Pod) string {
 		return ""
 	}
 
+	if c.c.SpiffeIDTemplate != nil {
+		return c.templateID(spiffeIDTemplateData{
+			TrustDomain:    c.c.TrustDomain,
+			Cluster:        c.c.Cluster,
+			Namespace:      pod.Namespace,
+			ServiceAccount: pod.Spec.ServiceAccountName,
+			PodName:        pod.Name,
+			Labels:         pod.Labels,
+			Annotations:    pod.Annotations,
+		})
+	}
+
 	// the controller has not been configured with a pod label or a pod annotation.
 	// create an entry based on the service account.
 	return c.makeID("ns/%s/sa/%s", pod.Namespace, pod.Spec.ServiceAccountName)
 }
 
+// serviceAccountSpiffeID returns the desired spiffe ID for the workloads
+// running as the service account, or an empty string if it should be ignored
+func (c *Controller) serviceAccountSpiffeID(sa *corev1.ServiceAccount) string {
+	if c.c.SpiffeIDTemplate != nil {
+		return c.templateID(spiffeIDTemplateData{
+			TrustDomain:    c.c.TrustDomain,
+			Cluster:        c.c.Cluster,
+			Namespace:      sa.Namespace,
+			ServiceAccount: sa.Name,
+			Labels:         sa.Labels,
+			Annotations:    sa.Annotations,
+		})
+	}
+	return c.makeID("ns/%s/sa/%s", sa.Namespace, sa.Name)
+}
+
+// spiffeIDTemplateData is the data the SPIFFE ID template is executed with.
+// PodName is empty for service account entries, and Labels and Annotations
+// are then those of the service account.
+type spiffeIDTemplateData struct {
+	TrustDomain    string
+	Cluster        string
+	Namespace      string
+	ServiceAccount string
+	PodName        string
+	Labels         map[string]string
+	Annotations    map[string]string
+}
+
+// templateID executes the SPIFFE ID template. Workloads for which the
+// template fails or yields an empty path are ignored.
+func (c *Controller) templateID(data spiffeIDTemplateData) string {
+	var buf bytes.Buffer
+	if err := c.c.SpiffeIDTemplate.Execute(&buf, data); err != nil {
+		c.c.Log.WithError(err).WithFields(logrus.Fields{
+			"ns":              data.Namespace,
+			"pod":             data.PodName,
+			"service_account": data.ServiceAccount,
+		}).Warn("Unable to execute the SPIFFE ID template")
+		return ""
+	}
+	idPath := strings.TrimSpace(buf.String())
+	if idPath == "" {
+		return ""
+	}
+	return c.makeID("%s", idPath)
+}
+
 func (c *Controller) createPodEntry(ctx context.Context, pod *corev1.Pod) error {
 	spiffeID := c.podSpiffeID(pod)
 	// If we have no spiffe ID for the pod, do nothing
@@ -172,7 +238,8 @@ func (c *Controller) deletePodEntry(ctx context.Context, namespace, name string)
 	var errGroup errs.Group
 	for _, entry := range entries.Entries {
 		_, err := c.c.R.DeleteEntry(ctx, &registration.RegistrationEntryID{
-			Id: entry.EntryId,
+			Id:             entry.EntryId,
+			RevisionNumber: entry.RevisionNumber,
 		})
 		if err != nil {
 			log.WithError(err).Error("Failed deleting pod entry")
@@ -186,6 +253,25 @@ func (c *Controller) nodeID() string {
 	return c.makeID("k8s-workload-registrar/%s/node", c.c.Cluster)
 }
 
+// nodeEntryID returns the SPIFFE ID of the node entry of a single node of
+// the cluster
+func (c *Controller) nodeEntryID(nodeName string) string {
+	return c.makeID("k8s-workload-registrar/%s/node/%s", c.c.Cluster, nodeName)
+}
+
+// nodeEntry returns the node entry matching the PSAT agent running on the
+// node
+func (c *Controller) nodeEntry(nodeName string) *common.RegistrationEntry {
+	return &common.RegistrationEntry{
+		ParentId: idutil.ServerID(c.c.TrustDomain),
+		SpiffeId: c.nodeEntryID(nodeName),
+		Selectors: []*common.Selector{
+			{Type: "k8s_psat", Value: fmt.Sprintf("cluster:%s", c.c.Cluster)},
+			{Type: "k8s_psat", Value: fmt.Sprintf("agent_node_name:%s", nodeName)},
+		},
+	}
+}
+
 func (c *Controller) makeID(pathFmt string, pathArgs ...interface{}) string {
 	id := url.URL{
 		Scheme: "spiffe",
@@ -220,6 +306,13 @@ func namespaceSelector(namespace string) *common.Selector {
 	}
 }
 
+func serviceAccountSelector(serviceAccount string) *common.Selector {
+	return &common.Selector{
+		Type:  "k8s",
+		Value: fmt.Sprintf("sa:%s", serviceAccount),
+	}
+}
+
 func podNameSelector(podName string) *common.Selector {
 	return &common.Selector{
 		Type:  "k8s",
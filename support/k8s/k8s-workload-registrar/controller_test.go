@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"testing"
 
@@ -290,6 +291,7 @@ func TestPodSpiffeId(t *testing.T) {
 		podLabel          string
 		configAnnotation  string
 		podAnnotation     string
+		configTemplate    string
 		podNamespace      string
 		podServiceAccount string
 	}{
@@ -311,6 +313,24 @@ func TestPodSpiffeId(t *testing.T) {
 			configAnnotation: "spiffe.io/annotation",
 			podAnnotation:    "ANNOTATION",
 		},
+		{
+			name:              "using template",
+			expectedSpiffeID:  "spiffe://domain.test/CLUSTER/NS/SA/LABEL",
+			configTemplate:    `{{.Cluster}}/{{.Namespace}}/{{.ServiceAccount}}/{{index .Labels "app"}}`,
+			podNamespace:      "NS",
+			podServiceAccount: "SA",
+			podLabel:          "LABEL",
+		},
+		{
+			name:             "ignore empty template result",
+			configTemplate:   `{{index .Labels "app"}}`,
+			expectedSpiffeID: "",
+		},
+		{
+			name:             "ignore failed template",
+			configTemplate:   `{{.Bogus}}`,
+			expectedSpiffeID: "",
+		},
 		{
 			name:             "ignore unannotated",
 			configAnnotation: "someannotation",
@@ -325,6 +345,7 @@ func TestPodSpiffeId(t *testing.T) {
 		testCase := testCase
 		t.Run(testCase.name, func(t *testing.T) {
 			c, _ := newTestController(testCase.configLabel, testCase.configAnnotation)
+			c.c.SpiffeIDTemplate, _ = parseSpiffeIDTemplate(testCase.configTemplate)
 
 			// Set up pod:
 			pod := &corev1.Pod{
@@ -340,6 +361,9 @@ func TestPodSpiffeId(t *testing.T) {
 			if testCase.configLabel != "" && testCase.podLabel != "" {
 				pod.Labels[testCase.configLabel] = testCase.podLabel
 			}
+			if testCase.configTemplate != "" && testCase.podLabel != "" {
+				pod.Labels["app"] = testCase.podLabel
+			}
 			if testCase.configAnnotation != "" && testCase.podAnnotation != "" {
 				pod.Annotations[testCase.configAnnotation] = testCase.podAnnotation
 			}
@@ -488,18 +512,54 @@ func (c *fakeRegistrationClient) DeleteEntry(ctx context.Context, id *registrati
 	return entry, nil
 }
 
+func (c *fakeRegistrationClient) ListByParentID(ctx context.Context, parentID *registration.ParentID, opts ...grpc.CallOption) (*common.RegistrationEntries, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &common.RegistrationEntries{
+		Entries: c.listEntries(func(entry *common.RegistrationEntry) bool {
+			return entry.ParentId == parentID.Id
+		}),
+	}, nil
+}
+
+func (c *fakeRegistrationClient) ListBySpiffeID(ctx context.Context, spiffeID *registration.SpiffeID, opts ...grpc.CallOption) (*common.RegistrationEntries, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &common.RegistrationEntries{
+		Entries: c.listEntries(func(entry *common.RegistrationEntry) bool {
+			return entry.SpiffeId == spiffeID.Id
+		}),
+	}, nil
+}
+
 func (c *fakeRegistrationClient) ListBySelectors(ctx context.Context, selectors *common.Selectors, opts ...grpc.CallOption) (*common.RegistrationEntries, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// peform an exact match check against selectors
+	return &common.RegistrationEntries{
+		Entries: c.listEntries(func(entry *common.RegistrationEntry) bool {
+			return areSelectorsEqual(selectors.Entries, entry.Selectors)
+		}),
+	}, nil
+}
+
+// listEntries returns the entries matching the given function, sorted by ID
+// so that the results do not depend on the map iteration order. The caller
+// must hold the lock.
+func (c *fakeRegistrationClient) listEntries(match func(*common.RegistrationEntry) bool) []*common.RegistrationEntry {
 	var entries []*common.RegistrationEntry
 	for _, entry := range c.entries {
-		if areSelectorsEqual(selectors.Entries, entry.Selectors) {
+		if match(entry) {
 			entries = append(entries, cloneRegistrationEntry(entry))
 		}
 	}
-
-	return &common.RegistrationEntries{
-		Entries: entries,
-	}, nil
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].EntryId < entries[j].EntryId
+	})
+	return entries
 }
 
 func requireEntriesEqual(t *testing.T, expected, actual []*common.RegistrationEntry) {
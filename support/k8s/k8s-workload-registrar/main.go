@@ -43,15 +43,38 @@ func run(ctx context.Context, configPath string) error {
 	}
 	defer serverConn.Close()
 
+	spiffeIDTemplate, err := parseSpiffeIDTemplate(config.SpiffeIDTemplate)
+	if err != nil {
+		return errs.New("invalid spiffe_id_template: %v", err)
+	}
+
 	controller := NewController(ControllerConfig{
-		Log:           log,
-		R:             registration.NewRegistrationClient(serverConn),
-		TrustDomain:   config.TrustDomain,
-		Cluster:       config.Cluster,
-		PodLabel:      config.PodLabel,
-		PodAnnotation: config.PodAnnotation,
+		Log:              log,
+		R:                registration.NewRegistrationClient(serverConn),
+		TrustDomain:      config.TrustDomain,
+		Cluster:          config.Cluster,
+		PodLabel:         config.PodLabel,
+		PodAnnotation:    config.PodAnnotation,
+		SpiffeIDTemplate: spiffeIDTemplate,
 	})
 
+	if config.Mode == modeReconcile {
+		kube, err := newKubeClient(config.KubeConfigPath)
+		if err != nil {
+			return err
+		}
+
+		log.Info("Reconciling registration entries with the cluster")
+		return NewReconciler(ReconcilerConfig{
+			Log:                   log,
+			Controller:            controller,
+			Kube:                  kube,
+			ResyncInterval:        config.ResyncDuration(),
+			ServiceAccountEntries: config.ServiceAccountEntries,
+			NodeEntries:           config.NodeEntries,
+		}).Run(ctx)
+	}
+
 	log.Info("Initializing registrar")
 	if err := controller.Initialize(ctx); err != nil {
 		return err
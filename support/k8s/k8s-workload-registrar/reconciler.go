@@ -0,0 +1,422 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/zeebo/errs"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// reconcileDelay batches the watch events received in a short period
+	// of time into a single reconciliation
+	reconcileDelay = time.Second
+)
+
+type ReconcilerConfig struct {
+	Log        logrus.FieldLogger
+	Controller *Controller
+	Kube       kubeClient
+
+	// ResyncInterval is the interval between full reconciliations, made on
+	// top of the ones triggered by the watch events
+	ResyncInterval time.Duration
+
+	// ServiceAccountEntries creates an entry per service account, selected
+	// by namespace and service account, rather than an entry per pod
+	ServiceAccountEntries bool
+
+	// NodeEntries creates a node entry per node, selecting the PSAT agent
+	// running on it, and parents the pod entries to the node entry of their
+	// node rather than to the cluster node entry
+	NodeEntries bool
+}
+
+// Reconciler keeps the registration entries in sync with the pods, or the
+// service accounts, of the cluster. It owns the entries parented to the
+// cluster node entry, and to the node entries of the nodes: entries that
+// don't match a workload are deleted.
+type Reconciler struct {
+	c ReconcilerConfig
+}
+
+func NewReconciler(config ReconcilerConfig) *Reconciler {
+	return &Reconciler{
+		c: config,
+	}
+}
+
+// Run reconciles the entries on every change of the watched objects and every
+// resync interval, until the context is done.
+func (r *Reconciler) Run(ctx context.Context) error {
+	resync := time.NewTicker(r.c.ResyncInterval)
+	defer resync.Stop()
+
+	var w watch.Interface
+	defer func() {
+		if w != nil {
+			w.Stop()
+		}
+	}()
+
+	for {
+		var events <-chan watch.Event
+		if w == nil {
+			var err error
+			w, err = r.watch()
+			if err != nil {
+				r.c.Log.WithError(err).Error("Failed to watch the cluster; relying on the resync interval")
+			}
+		}
+		if w != nil {
+			events = w.ResultChan()
+		}
+
+		if err := r.Reconcile(ctx); err != nil {
+			r.c.Log.WithError(err).Error("Failed to reconcile the registration entries")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-resync.C:
+		case _, ok := <-events:
+			if !ok {
+				// the watch expired; restart it
+				w = nil
+				continue
+			}
+			if !drainEvents(ctx, events, reconcileDelay) {
+				w.Stop()
+				w = nil
+			}
+		}
+	}
+}
+
+// Reconcile ensures there is a node entry for the cluster and a workload entry
+// for each workload, and deletes the workload entries that match no workload.
+// With NodeEntries, it also ensures there is a node entry for each node and
+// deletes the node entries of the nodes that are gone.
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	parentIDs, err := r.reconcileNodeEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	desired, err := r.desiredEntries()
+	if err != nil {
+		return err
+	}
+
+	var entries []*common.RegistrationEntry
+	for _, parentID := range parentIDs {
+		resp, err := r.c.Controller.c.R.ListByParentID(ctx, &registration.ParentID{
+			Id: parentID,
+		})
+		if err != nil {
+			return errs.New("unable to list workload entries: %v", err)
+		}
+		entries = append(entries, resp.Entries...)
+	}
+
+	return r.syncEntries(ctx, entries, desired)
+}
+
+// reconcileNodeEntries ensures there is a node entry for the cluster and, with
+// NodeEntries, one for each node, deleting the node entries of the nodes that
+// are gone. It returns the SPIFFE IDs of the node entries owned by the
+// reconciler, including the deleted ones so the workload entries parented to
+// them are deleted as well.
+func (r *Reconciler) reconcileNodeEntries(ctx context.Context) ([]string, error) {
+	c := r.c.Controller
+	resp, err := c.c.R.ListByParentID(ctx, &registration.ParentID{
+		Id: idutil.ServerID(c.c.TrustDomain),
+	})
+	if err != nil {
+		return nil, errs.New("unable to list node entries: %v", err)
+	}
+
+	hasClusterEntry := false
+	var nodeEntries []*common.RegistrationEntry
+	for _, entry := range resp.Entries {
+		switch {
+		case entry.SpiffeId == c.nodeID():
+			hasClusterEntry = true
+		case strings.HasPrefix(entry.SpiffeId, c.nodeID()+"/"):
+			nodeEntries = append(nodeEntries, entry)
+		}
+	}
+	if !hasClusterEntry {
+		if err := c.Initialize(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	parentIDs := []string{c.nodeID()}
+	if !r.c.NodeEntries {
+		return parentIDs, nil
+	}
+
+	nodes, err := r.c.Kube.ListNodes()
+	if err != nil {
+		return nil, errs.New("unable to list nodes: %v", err)
+	}
+	desired := make(map[string]*common.RegistrationEntry)
+	for _, node := range nodes {
+		entry := c.nodeEntry(node.Name)
+		desired[entryKey(entry)] = entry
+	}
+
+	// node entries are listed even if they are stale so their workload
+	// entries are deleted, e.g. if the node left while the registrar was down
+	seen := make(map[string]bool)
+	for _, entry := range nodeEntries {
+		if !seen[entry.SpiffeId] {
+			seen[entry.SpiffeId] = true
+			parentIDs = append(parentIDs, entry.SpiffeId)
+		}
+	}
+	for _, entry := range desired {
+		if !seen[entry.SpiffeId] {
+			seen[entry.SpiffeId] = true
+			parentIDs = append(parentIDs, entry.SpiffeId)
+		}
+	}
+	sort.Strings(parentIDs[1:])
+
+	if err := r.syncEntries(ctx, nodeEntries, desired); err != nil {
+		return nil, err
+	}
+	return parentIDs, nil
+}
+
+// syncEntries deletes the current entries that are not desired, or are
+// duplicates, and creates the desired entries that are missing. Of duplicate
+// entries, the one with the lowest ID is kept.
+func (r *Reconciler) syncEntries(ctx context.Context, entries []*common.RegistrationEntry, desired map[string]*common.RegistrationEntry) error {
+	entries = append([]*common.RegistrationEntry(nil), entries...)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].EntryId < entries[j].EntryId
+	})
+
+	current := make(map[string]bool)
+	var errGroup errs.Group
+	for _, entry := range entries {
+		key := entryKey(entry)
+		if _, ok := desired[key]; ok && !current[key] {
+			current[key] = true
+			continue
+		}
+
+		// the entry matches no workload or node, or is a duplicate
+		log := r.c.Log.WithFields(logrus.Fields{
+			"entry_id":  entry.EntryId,
+			"spiffe_id": entry.SpiffeId,
+			"selectors": selectorsField(entry.Selectors),
+		})
+		if _, err := r.c.Controller.c.R.DeleteEntry(ctx, &registration.RegistrationEntryID{
+			Id:             entry.EntryId,
+			RevisionNumber: entry.RevisionNumber,
+		}); err != nil {
+			log.WithError(err).Error("Failed deleting stale entry")
+			errGroup.Add(errs.New("unable to delete entry %q: %v", entry.EntryId, err))
+			continue
+		}
+		log.Info("Deleted stale entry")
+	}
+
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		if !current[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := r.c.Controller.createEntry(ctx, desired[key]); err != nil {
+			errGroup.Add(err)
+		}
+	}
+
+	return errGroup.Err()
+}
+
+// desiredEntries returns the workload entries of the cluster, by entry key
+func (r *Reconciler) desiredEntries() (map[string]*common.RegistrationEntry, error) {
+	c := r.c.Controller
+	entries := make(map[string]*common.RegistrationEntry)
+	add := func(parentID, spiffeID string, selectors ...*common.Selector) {
+		if spiffeID == "" {
+			return
+		}
+		entry := &common.RegistrationEntry{
+			ParentId:  parentID,
+			SpiffeId:  spiffeID,
+			Selectors: selectors,
+		}
+		entries[entryKey(entry)] = entry
+	}
+
+	if r.c.ServiceAccountEntries {
+		serviceAccounts, err := r.c.Kube.ListServiceAccounts()
+		if err != nil {
+			return nil, errs.New("unable to list service accounts: %v", err)
+		}
+		for i := range serviceAccounts {
+			sa := &serviceAccounts[i]
+			if isKubeNamespace(sa.Namespace) {
+				continue
+			}
+			add(c.nodeID(), c.serviceAccountSpiffeID(sa), namespaceSelector(sa.Namespace), serviceAccountSelector(sa.Name))
+		}
+		return entries, nil
+	}
+
+	pods, err := r.c.Kube.ListPods()
+	if err != nil {
+		return nil, errs.New("unable to list pods: %v", err)
+	}
+	for i := range pods {
+		pod := &pods[i]
+		if isKubeNamespace(pod.Namespace) || !isPodActive(pod) {
+			continue
+		}
+		parentID := c.nodeID()
+		if r.c.NodeEntries {
+			// the entry is created once the pod is scheduled, so it is
+			// only granted to the agent running on its node
+			if pod.Spec.NodeName == "" {
+				continue
+			}
+			parentID = c.nodeEntryID(pod.Spec.NodeName)
+		}
+		add(parentID, c.podSpiffeID(pod), namespaceSelector(pod.Namespace), podNameSelector(pod.Name))
+	}
+	return entries, nil
+}
+
+func (r *Reconciler) watch() (watch.Interface, error) {
+	if r.c.ServiceAccountEntries {
+		return r.c.Kube.WatchServiceAccounts()
+	}
+	return r.c.Kube.WatchPods()
+}
+
+// drainEvents consumes the events received within the delay. It returns false
+// if the watch expired meanwhile.
+func drainEvents(ctx context.Context, events <-chan watch.Event, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-timer.C:
+			return true
+		case _, ok := <-events:
+			if !ok {
+				return false
+			}
+		}
+	}
+}
+
+// entryKey identifies an entry by its parent ID, SPIFFE ID and selectors
+func entryKey(entry *common.RegistrationEntry) string {
+	selectors := make([]string, 0, len(entry.Selectors))
+	for _, selector := range entry.Selectors {
+		selectors = append(selectors, selector.Type+":"+selector.Value)
+	}
+	sort.Strings(selectors)
+	return entry.ParentId + " " + entry.SpiffeId + " " + strings.Join(selectors, ",")
+}
+
+func isKubeNamespace(namespace string) bool {
+	return namespace == metav1.NamespacePublic || namespace == metav1.NamespaceSystem
+}
+
+// isPodActive returns whether the pod is running, or will be, so its entry is
+// only removed when it is being deleted or has terminated
+func isPodActive(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	return pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed
+}
+
+type kubeClient interface {
+	ListNodes() ([]corev1.Node, error)
+	ListPods() ([]corev1.Pod, error)
+	WatchPods() (watch.Interface, error)
+	ListServiceAccounts() ([]corev1.ServiceAccount, error)
+	WatchServiceAccounts() (watch.Interface, error)
+}
+
+func newKubeClient(configPath string) (kubeClient, error) {
+	config, err := getKubeConfig(configPath)
+	if err != nil {
+		return nil, errs.New("unable to load kubeconfig: %v", err)
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, errs.New("unable to create the kubernetes client: %v", err)
+	}
+	return kubeClientset{Clientset: client}, nil
+}
+
+func getKubeConfig(configPath string) (*rest.Config, error) {
+	if configPath != "" {
+		return clientcmd.BuildConfigFromFlags("", configPath)
+	}
+	return rest.InClusterConfig()
+}
+
+type kubeClientset struct {
+	*kubernetes.Clientset
+}
+
+func (c kubeClientset) ListNodes() ([]corev1.Node, error) {
+	nodes, err := c.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return nodes.Items, nil
+}
+
+func (c kubeClientset) ListPods() ([]corev1.Pod, error) {
+	pods, err := c.CoreV1().Pods(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+func (c kubeClientset) WatchPods() (watch.Interface, error) {
+	return c.CoreV1().Pods(metav1.NamespaceAll).Watch(metav1.ListOptions{})
+}
+
+func (c kubeClientset) ListServiceAccounts() ([]corev1.ServiceAccount, error) {
+	serviceAccounts, err := c.CoreV1().ServiceAccounts(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return serviceAccounts.Items, nil
+}
+
+func (c kubeClientset) WatchServiceAccounts() (watch.Interface, error) {
+	return c.CoreV1().ServiceAccounts(metav1.NamespaceAll).Watch(metav1.ListOptions{})
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+var (
+	nodeEntry = &common.RegistrationEntry{
+		ParentId: "spiffe://domain.test/spire/server",
+		SpiffeId: "spiffe://domain.test/k8s-workload-registrar/CLUSTER/node",
+		Selectors: []*common.Selector{
+			{Type: "k8s_psat", Value: "cluster:CLUSTER"},
+		},
+	}
+)
+
+func TestReconcilerCreatesEntries(t *testing.T) {
+	reconciler, r, kube := newTestReconciler(false)
+	kube.setPods(
+		newPod("NAMESPACE", "POD1", "SA1"),
+		newPod("NAMESPACE", "POD2", "SA2"),
+		newPod("kube-system", "POD3", "SA3"),
+	)
+
+	require.NoError(t, reconciler.Reconcile(context.Background()))
+	requireEntriesEqual(t, []*common.RegistrationEntry{
+		withEntryID("00000001", nodeEntry),
+		podEntry("00000002", "NAMESPACE", "POD1", "SA1"),
+		podEntry("00000003", "NAMESPACE", "POD2", "SA2"),
+	}, r.GetEntries())
+
+	// reconciling again is a no-op
+	require.NoError(t, reconciler.Reconcile(context.Background()))
+	require.Len(t, r.GetEntries(), 3)
+}
+
+func TestReconcilerDeletesStaleEntries(t *testing.T) {
+	reconciler, r, kube := newTestReconciler(false)
+	terminated := newPod("NAMESPACE", "POD2", "SA2")
+	terminated.Status.Phase = corev1.PodSucceeded
+	deleted := newPod("NAMESPACE", "POD3", "SA3")
+	deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	kube.setPods(newPod("NAMESPACE", "POD1", "SA1"), terminated, deleted)
+
+	// entries of pods that are gone, duplicates and entries of other parents
+	_, _ = r.CreateEntry(context.Background(), nodeEntry)
+	_, _ = r.CreateEntry(context.Background(), podEntry("", "NAMESPACE", "POD1", "SA1"))
+	_, _ = r.CreateEntry(context.Background(), podEntry("", "NAMESPACE", "POD1", "SA1"))
+	_, _ = r.CreateEntry(context.Background(), podEntry("", "NAMESPACE", "POD2", "SA2"))
+	_, _ = r.CreateEntry(context.Background(), podEntry("", "NAMESPACE", "POD3", "SA3"))
+	_, _ = r.CreateEntry(context.Background(), podEntry("", "NAMESPACE", "POD4", "SA4"))
+	_, _ = r.CreateEntry(context.Background(), &common.RegistrationEntry{
+		ParentId:  "spiffe://domain.test/OTHER",
+		SpiffeId:  "spiffe://domain.test/ns/NAMESPACE/sa/SA4",
+		Selectors: []*common.Selector{{Type: "k8s", Value: "ns:NAMESPACE"}},
+	})
+
+	require.NoError(t, reconciler.Reconcile(context.Background()))
+	requireEntriesEqual(t, []*common.RegistrationEntry{
+		withEntryID("00000001", nodeEntry),
+		podEntry("00000002", "NAMESPACE", "POD1", "SA1"),
+		{
+			EntryId:   "00000007",
+			ParentId:  "spiffe://domain.test/OTHER",
+			SpiffeId:  "spiffe://domain.test/ns/NAMESPACE/sa/SA4",
+			Selectors: []*common.Selector{{Type: "k8s", Value: "ns:NAMESPACE"}},
+		},
+	}, r.GetEntries())
+}
+
+func TestReconcilerServiceAccountEntries(t *testing.T) {
+	reconciler, r, kube := newTestReconciler(true)
+	kube.setServiceAccounts(
+		corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "NAMESPACE", Name: "SA1"}},
+		corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-public", Name: "SA2"}},
+	)
+
+	require.NoError(t, reconciler.Reconcile(context.Background()))
+	requireEntriesEqual(t, []*common.RegistrationEntry{
+		withEntryID("00000001", nodeEntry),
+		{
+			EntryId:  "00000002",
+			ParentId: "spiffe://domain.test/k8s-workload-registrar/CLUSTER/node",
+			SpiffeId: "spiffe://domain.test/ns/NAMESPACE/sa/SA1",
+			Selectors: []*common.Selector{
+				{Type: "k8s", Value: "ns:NAMESPACE"},
+				{Type: "k8s", Value: "sa:SA1"},
+			},
+		},
+	}, r.GetEntries())
+}
+
+func TestReconcilerNodeEntries(t *testing.T) {
+	reconciler, r, kube := newTestReconciler(false)
+	reconciler.c.NodeEntries = true
+	kube.setNodes(newNode("NODE1"), newNode("NODE2"))
+	scheduled := newPod("NAMESPACE", "POD1", "SA1")
+	scheduled.Spec.NodeName = "NODE1"
+	kube.setPods(scheduled, newPod("NAMESPACE", "POD2", "SA2"))
+
+	// the node entry of a node that is gone, its workload entry, and a
+	// workload entry parented to the cluster node entry
+	_, _ = r.CreateEntry(context.Background(), nodeEntry)
+	_, _ = r.CreateEntry(context.Background(), nodeNameEntry("", "NODE3"))
+	_, _ = r.CreateEntry(context.Background(), withParentID("spiffe://domain.test/k8s-workload-registrar/CLUSTER/node/NODE3", podEntry("", "NAMESPACE", "POD3", "SA3")))
+	_, _ = r.CreateEntry(context.Background(), podEntry("", "NAMESPACE", "POD1", "SA1"))
+
+	require.NoError(t, reconciler.Reconcile(context.Background()))
+	requireEntriesEqual(t, []*common.RegistrationEntry{
+		withEntryID("00000001", nodeEntry),
+		nodeNameEntry("00000005", "NODE1"),
+		nodeNameEntry("00000006", "NODE2"),
+		withParentID("spiffe://domain.test/k8s-workload-registrar/CLUSTER/node/NODE1", podEntry("00000007", "NAMESPACE", "POD1", "SA1")),
+	}, r.GetEntries())
+
+	// reconciling again is a no-op
+	require.NoError(t, reconciler.Reconcile(context.Background()))
+	require.Len(t, r.GetEntries(), 4)
+}
+
+func TestReconcilerFailsIfListingFails(t *testing.T) {
+	reconciler, _, kube := newTestReconciler(false)
+	kube.listErr = errors.New("oh no")
+
+	err := reconciler.Reconcile(context.Background())
+	require.EqualError(t, err, "unable to list pods: oh no")
+}
+
+func TestReconcilerRunReconcilesOnWatchEvents(t *testing.T) {
+	reconciler, r, kube := newTestReconciler(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- reconciler.Run(ctx)
+	}()
+
+	// the node entry is created by the initial reconciliation
+	require.Eventually(t, func() bool {
+		return len(r.GetEntries()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	pod := newPod("NAMESPACE", "POD1", "SA1")
+	kube.setPods(pod)
+	kube.watcher.Add(&pod)
+	require.Eventually(t, func() bool {
+		return len(r.GetEntries()) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	kube.setPods()
+	kube.watcher.Delete(&pod)
+	require.Eventually(t, func() bool {
+		return len(r.GetEntries()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func newTestReconciler(serviceAccountEntries bool) (*Reconciler, *fakeRegistrationClient, *fakeKubeClient) {
+	log, _ := test.NewNullLogger()
+	controller, r := newTestController("", "")
+	kube := newFakeKubeClient()
+	return NewReconciler(ReconcilerConfig{
+		Log:                   log,
+		Controller:            controller,
+		Kube:                  kube,
+		ResyncInterval:        time.Hour,
+		ServiceAccountEntries: serviceAccountEntries,
+	}), r, kube
+}
+
+func newPod(namespace, name, serviceAccount string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: serviceAccount,
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+}
+
+func podEntry(entryID, namespace, name, serviceAccount string) *common.RegistrationEntry {
+	return &common.RegistrationEntry{
+		EntryId:  entryID,
+		ParentId: "spiffe://domain.test/k8s-workload-registrar/CLUSTER/node",
+		SpiffeId: "spiffe://domain.test/ns/" + namespace + "/sa/" + serviceAccount,
+		Selectors: []*common.Selector{
+			{Type: "k8s", Value: "ns:" + namespace},
+			{Type: "k8s", Value: "pod-name:" + name},
+		},
+	}
+}
+
+func newNode(name string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
+
+func nodeNameEntry(entryID, nodeName string) *common.RegistrationEntry {
+	return &common.RegistrationEntry{
+		EntryId:  entryID,
+		ParentId: "spiffe://domain.test/spire/server",
+		SpiffeId: "spiffe://domain.test/k8s-workload-registrar/CLUSTER/node/" + nodeName,
+		Selectors: []*common.Selector{
+			{Type: "k8s_psat", Value: "cluster:CLUSTER"},
+			{Type: "k8s_psat", Value: "agent_node_name:" + nodeName},
+		},
+	}
+}
+
+func withParentID(parentID string, entry *common.RegistrationEntry) *common.RegistrationEntry {
+	entry = cloneRegistrationEntry(entry)
+	entry.ParentId = parentID
+	return entry
+}
+
+func withEntryID(entryID string, entry *common.RegistrationEntry) *common.RegistrationEntry {
+	entry = cloneRegistrationEntry(entry)
+	entry.EntryId = entryID
+	return entry
+}
+
+type fakeKubeClient struct {
+	mu              sync.Mutex
+	nodes           []corev1.Node
+	pods            []corev1.Pod
+	serviceAccounts []corev1.ServiceAccount
+	listErr         error
+	watcher         *watch.FakeWatcher
+}
+
+func newFakeKubeClient() *fakeKubeClient {
+	return &fakeKubeClient{
+		watcher: watch.NewFake(),
+	}
+}
+
+func (c *fakeKubeClient) setNodes(nodes ...corev1.Node) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nodes = nodes
+}
+
+func (c *fakeKubeClient) setPods(pods ...corev1.Pod) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pods = pods
+}
+
+func (c *fakeKubeClient) setServiceAccounts(serviceAccounts ...corev1.ServiceAccount) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.serviceAccounts = serviceAccounts
+}
+
+func (c *fakeKubeClient) ListNodes() ([]corev1.Node, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.nodes, c.listErr
+}
+
+func (c *fakeKubeClient) ListPods() ([]corev1.Pod, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pods, c.listErr
+}
+
+func (c *fakeKubeClient) WatchPods() (watch.Interface, error) {
+	return c.watcher, nil
+}
+
+func (c *fakeKubeClient) ListServiceAccounts() ([]corev1.ServiceAccount, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.serviceAccounts, c.listErr
+}
+
+func (c *fakeKubeClient) WatchServiceAccounts() (watch.Interface, error) {
+	return c.watcher, nil
+}
@@ -137,6 +137,13 @@ func (s *DataStore) UpdateAttestedNode(ctx context.Context, req *datastore.Updat
 	return s.ds.UpdateAttestedNode(ctx, req)
 }
 
+func (s *DataStore) ForceAttestedNodeRotation(ctx context.Context, req *datastore.ForceAttestedNodeRotationRequest) (*datastore.ForceAttestedNodeRotationResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.ForceAttestedNodeRotation(ctx, req)
+}
+
 func (s *DataStore) DeleteAttestedNode(ctx context.Context, req *datastore.DeleteAttestedNodeRequest) (*datastore.DeleteAttestedNodeResponse, error) {
 	if err := s.getNextError(); err != nil {
 		return nil, err
@@ -144,6 +151,13 @@ func (s *DataStore) DeleteAttestedNode(ctx context.Context, req *datastore.Delet
 	return s.ds.DeleteAttestedNode(ctx, req)
 }
 
+func (s *DataStore) PruneAttestedNodes(ctx context.Context, req *datastore.PruneAttestedNodesRequest) (*datastore.PruneAttestedNodesResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.PruneAttestedNodes(ctx, req)
+}
+
 func (s *DataStore) SetNodeSelectors(ctx context.Context, req *datastore.SetNodeSelectorsRequest) (*datastore.SetNodeSelectorsResponse, error) {
 	if err := s.getNextError(); err != nil {
 		return nil, err
@@ -231,6 +245,13 @@ func (s *DataStore) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJo
 	return s.ds.DeleteJoinToken(ctx, req)
 }
 
+func (s *DataStore) ConsumeJoinToken(ctx context.Context, req *datastore.ConsumeJoinTokenRequest) (*datastore.ConsumeJoinTokenResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.ConsumeJoinToken(ctx, req)
+}
+
 func (s *DataStore) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
 	if err := s.getNextError(); err != nil {
 		return nil, err
@@ -238,6 +259,27 @@ func (s *DataStore) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoi
 	return s.ds.PruneJoinTokens(ctx, req)
 }
 
+func (s *DataStore) CreateIssuedX509SVID(ctx context.Context, req *datastore.CreateIssuedX509SVIDRequest) (*datastore.CreateIssuedX509SVIDResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.CreateIssuedX509SVID(ctx, req)
+}
+
+func (s *DataStore) ListIssuedX509SVIDs(ctx context.Context, req *datastore.ListIssuedX509SVIDsRequest) (*datastore.ListIssuedX509SVIDsResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.ListIssuedX509SVIDs(ctx, req)
+}
+
+func (s *DataStore) PruneIssuedX509SVIDs(ctx context.Context, req *datastore.PruneIssuedX509SVIDsRequest) (*datastore.PruneIssuedX509SVIDsResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.PruneIssuedX509SVIDs(ctx, req)
+}
+
 func (s *DataStore) SetNextError(err error) {
 	s.errs = []error{err}
 }
@@ -262,3 +304,7 @@ func (s *DataStore) Configure(ctx context.Context, req *spi.ConfigureRequest) (*
 func (s *DataStore) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
 	return &spi.GetPluginInfoResponse{}, nil
 }
+
+func (s *DataStore) GetInterfaceVersion(context.Context, *datastore.GetInterfaceVersionRequest) (*datastore.GetInterfaceVersionResponse, error) {
+	return &datastore.GetInterfaceVersionResponse{Version: datastore.InterfaceVersion}, nil
+}
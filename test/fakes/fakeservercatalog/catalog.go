@@ -3,6 +3,8 @@ package fakeservercatalog
 import (
 	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/bundlepublisher"
+	"github.com/spiffe/spire/pkg/server/plugin/credentialcomposer"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
@@ -55,6 +57,28 @@ func Notifier(name string, notifier notifier.Notifier) catalog.Notifier {
 	}
 }
 
+func (c *Catalog) AddCredentialComposer(credentialComposer catalog.CredentialComposer) {
+	c.CredentialComposers = append(c.CredentialComposers, credentialComposer)
+}
+
+func CredentialComposer(name string, credentialComposer credentialcomposer.CredentialComposer) catalog.CredentialComposer {
+	return catalog.CredentialComposer{
+		PluginInfo:         pluginInfo{name: name, typ: credentialcomposer.Type},
+		CredentialComposer: credentialComposer,
+	}
+}
+
+func (c *Catalog) AddBundlePublisher(bundlePublisher catalog.BundlePublisher) {
+	c.BundlePublishers = append(c.BundlePublishers, bundlePublisher)
+}
+
+func BundlePublisher(name string, bundlePublisher bundlepublisher.BundlePublisher) catalog.BundlePublisher {
+	return catalog.BundlePublisher{
+		PluginInfo:      pluginInfo{name: name, typ: bundlepublisher.Type},
+		BundlePublisher: bundlePublisher,
+	}
+}
+
 func UpstreamAuthority(name string, ua upstreamauthority.UpstreamAuthority) *catalog.UpstreamAuthority {
 	return &catalog.UpstreamAuthority{
 		PluginInfo:        pluginInfo{name: name},
@@ -13,6 +13,8 @@ import (
 	"github.com/spiffe/go-spiffe/proto/spiffe/workload"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -51,6 +53,16 @@ func FetchX509SVIDResponses(responses ...*workload.X509SVIDResponse) Result {
 	})
 }
 
+type healthStatuses map[string]healthpb.HealthCheckResponse_ServingStatus
+
+func (healthStatuses) result() {}
+
+// HealthStatuses serves the gRPC health service alongside the workload API,
+// reporting the given statuses.
+func HealthStatuses(statuses map[string]healthpb.HealthCheckResponse_ServingStatus) Result {
+	return healthStatuses(statuses)
+}
+
 type WorkloadAPI struct {
 	dir    string
 	addr   *net.UnixAddr
@@ -58,6 +70,7 @@ type WorkloadAPI struct {
 
 	mu                   sync.Mutex
 	fetchX509SVIDResults []fetchX509SVIDResult
+	healthStatuses       healthStatuses
 }
 
 func New(t *testing.T, results ...Result) *WorkloadAPI {
@@ -67,6 +80,8 @@ func New(t *testing.T, results ...Result) *WorkloadAPI {
 		switch result := result.(type) {
 		case fetchX509SVIDResult:
 			w.fetchX509SVIDResults = append(w.fetchX509SVIDResults, result)
+		case healthStatuses:
+			w.healthStatuses = result
 		default:
 			require.FailNow(t, "unexpected result type %T", result)
 		}
@@ -91,6 +106,13 @@ func New(t *testing.T, results ...Result) *WorkloadAPI {
 
 	w.server = grpc.NewServer()
 	workload.RegisterSpiffeWorkloadAPIServer(w.server, w)
+	if w.healthStatuses != nil {
+		healthServer := health.NewServer()
+		for service, status := range w.healthStatuses {
+			healthServer.SetServingStatus(service, status)
+		}
+		healthpb.RegisterHealthServer(w.server, healthServer)
+	}
 	go func() { _ = w.server.Serve(listener) }()
 	return w
 }
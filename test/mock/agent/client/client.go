@@ -76,3 +76,17 @@ func (mr *MockClientMockRecorder) Release() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockClient)(nil).Release))
 }
+
+// SubscribeToSyncEvents mocks base method
+func (m *MockClient) SubscribeToSyncEvents(arg0 context.Context, arg1 func()) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeToSyncEvents", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeToSyncEvents indicates an expected call of SubscribeToSyncEvents
+func (mr *MockClientMockRecorder) SubscribeToSyncEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToSyncEvents", reflect.TypeOf((*MockClient)(nil).SubscribeToSyncEvents), arg0, arg1)
+}
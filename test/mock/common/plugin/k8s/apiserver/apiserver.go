@@ -49,6 +49,21 @@ func (mr *MockClientMockRecorder) GetNode(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNode", reflect.TypeOf((*MockClient)(nil).GetNode), arg0)
 }
 
+// GetNodesWithLabel mocks base method
+func (m *MockClient) GetNodesWithLabel(arg0, arg1 string) ([]v10.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodesWithLabel", arg0, arg1)
+	ret0, _ := ret[0].([]v10.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodesWithLabel indicates an expected call of GetNodesWithLabel
+func (mr *MockClientMockRecorder) GetNodesWithLabel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodesWithLabel", reflect.TypeOf((*MockClient)(nil).GetNodesWithLabel), arg0, arg1)
+}
+
 // GetPod mocks base method
 func (m *MockClient) GetPod(arg0, arg1 string) (*v10.Pod, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +79,34 @@ func (mr *MockClientMockRecorder) GetPod(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPod", reflect.TypeOf((*MockClient)(nil).GetPod), arg0, arg1)
 }
 
+// LabelNode mocks base method
+func (m *MockClient) LabelNode(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LabelNode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LabelNode indicates an expected call of LabelNode
+func (mr *MockClientMockRecorder) LabelNode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LabelNode", reflect.TypeOf((*MockClient)(nil).LabelNode), arg0, arg1, arg2)
+}
+
+// UnlabelNode mocks base method
+func (m *MockClient) UnlabelNode(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlabelNode", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlabelNode indicates an expected call of UnlabelNode
+func (mr *MockClientMockRecorder) UnlabelNode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlabelNode", reflect.TypeOf((*MockClient)(nil).UnlabelNode), arg0, arg1)
+}
+
 // ValidateToken mocks base method
 func (m *MockClient) ValidateToken(arg0 string, arg1 []string) (*v1.TokenReviewStatus, error) {
 	m.ctrl.T.Helper()
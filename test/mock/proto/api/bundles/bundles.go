@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/spiffe/spire/proto/spire/api/workload (interfaces: Bundles_FetchX509BundlesServer)
+
+// Package mock_bundles is a generated GoMock package.
+package mock_bundles
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	workload "github.com/spiffe/spire/proto/spire/api/workload"
+	metadata "google.golang.org/grpc/metadata"
+	reflect "reflect"
+)
+
+// MockBundles_FetchX509BundlesServer is a mock of Bundles_FetchX509BundlesServer interface
+type MockBundles_FetchX509BundlesServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockBundles_FetchX509BundlesServerMockRecorder
+}
+
+// MockBundles_FetchX509BundlesServerMockRecorder is the mock recorder for MockBundles_FetchX509BundlesServer
+type MockBundles_FetchX509BundlesServerMockRecorder struct {
+	mock *MockBundles_FetchX509BundlesServer
+}
+
+// NewMockBundles_FetchX509BundlesServer creates a new mock instance
+func NewMockBundles_FetchX509BundlesServer(ctrl *gomock.Controller) *MockBundles_FetchX509BundlesServer {
+	mock := &MockBundles_FetchX509BundlesServer{ctrl: ctrl}
+	mock.recorder = &MockBundles_FetchX509BundlesServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockBundles_FetchX509BundlesServer) EXPECT() *MockBundles_FetchX509BundlesServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method
+func (m *MockBundles_FetchX509BundlesServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockBundles_FetchX509BundlesServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockBundles_FetchX509BundlesServer)(nil).Context))
+}
+
+// RecvMsg mocks base method
+func (m *MockBundles_FetchX509BundlesServer) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockBundles_FetchX509BundlesServerMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockBundles_FetchX509BundlesServer)(nil).RecvMsg), arg0)
+}
+
+// Send mocks base method
+func (m *MockBundles_FetchX509BundlesServer) Send(arg0 *workload.X509BundlesResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send
+func (mr *MockBundles_FetchX509BundlesServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockBundles_FetchX509BundlesServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method
+func (m *MockBundles_FetchX509BundlesServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader
+func (mr *MockBundles_FetchX509BundlesServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockBundles_FetchX509BundlesServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method
+func (m *MockBundles_FetchX509BundlesServer) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg
+func (mr *MockBundles_FetchX509BundlesServerMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockBundles_FetchX509BundlesServer)(nil).SendMsg), arg0)
+}
+
+// SetHeader mocks base method
+func (m *MockBundles_FetchX509BundlesServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader
+func (mr *MockBundles_FetchX509BundlesServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockBundles_FetchX509BundlesServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method
+func (m *MockBundles_FetchX509BundlesServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer
+func (mr *MockBundles_FetchX509BundlesServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockBundles_FetchX509BundlesServer)(nil).SetTrailer), arg0)
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/spiffe/spire/proto/spire/api/node (interfaces: NodeClient,Node_AttestClient,Node_AttestServer,Node_FetchX509SVIDClient,NodeServer,Node_FetchX509SVIDServer)
+// Source: github.com/spiffe/spire/proto/spire/api/node (interfaces: NodeClient,Node_AttestClient,Node_AttestServer,Node_FetchX509SVIDClient,NodeServer,Node_FetchX509SVIDServer,Node_SubscribeToSyncEventsClient)
 
 // Package mock_node is a generated GoMock package.
 package mock_node
@@ -156,6 +156,26 @@ func (mr *MockNodeClientMockRecorder) PushJWTKeyUpstream(arg0, arg1 interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushJWTKeyUpstream", reflect.TypeOf((*MockNodeClient)(nil).PushJWTKeyUpstream), varargs...)
 }
 
+// SubscribeToSyncEvents mocks base method
+func (m *MockNodeClient) SubscribeToSyncEvents(arg0 context.Context, arg1 *node.SubscribeToSyncEventsRequest, arg2 ...grpc.CallOption) (node.Node_SubscribeToSyncEventsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SubscribeToSyncEvents", varargs...)
+	ret0, _ := ret[0].(node.Node_SubscribeToSyncEventsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeToSyncEvents indicates an expected call of SubscribeToSyncEvents
+func (mr *MockNodeClientMockRecorder) SubscribeToSyncEvents(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToSyncEvents", reflect.TypeOf((*MockNodeClient)(nil).SubscribeToSyncEvents), varargs...)
+}
+
 // MockNode_AttestClient is a mock of Node_AttestClient interface
 type MockNode_AttestClient struct {
 	ctrl     *gomock.Controller
@@ -675,6 +695,20 @@ func (mr *MockNodeServerMockRecorder) PushJWTKeyUpstream(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushJWTKeyUpstream", reflect.TypeOf((*MockNodeServer)(nil).PushJWTKeyUpstream), arg0, arg1)
 }
 
+// SubscribeToSyncEvents mocks base method
+func (m *MockNodeServer) SubscribeToSyncEvents(arg0 *node.SubscribeToSyncEventsRequest, arg1 node.Node_SubscribeToSyncEventsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeToSyncEvents", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeToSyncEvents indicates an expected call of SubscribeToSyncEvents
+func (mr *MockNodeServerMockRecorder) SubscribeToSyncEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToSyncEvents", reflect.TypeOf((*MockNodeServer)(nil).SubscribeToSyncEvents), arg0, arg1)
+}
+
 // MockNode_FetchX509SVIDServer is a mock of Node_FetchX509SVIDServer interface
 type MockNode_FetchX509SVIDServer struct {
 	ctrl     *gomock.Controller
@@ -808,3 +842,126 @@ func (mr *MockNode_FetchX509SVIDServerMockRecorder) SetTrailer(arg0 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockNode_FetchX509SVIDServer)(nil).SetTrailer), arg0)
 }
+
+// MockNode_SubscribeToSyncEventsClient is a mock of Node_SubscribeToSyncEventsClient interface
+type MockNode_SubscribeToSyncEventsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockNode_SubscribeToSyncEventsClientMockRecorder
+}
+
+// MockNode_SubscribeToSyncEventsClientMockRecorder is the mock recorder for MockNode_SubscribeToSyncEventsClient
+type MockNode_SubscribeToSyncEventsClientMockRecorder struct {
+	mock *MockNode_SubscribeToSyncEventsClient
+}
+
+// NewMockNode_SubscribeToSyncEventsClient creates a new mock instance
+func NewMockNode_SubscribeToSyncEventsClient(ctrl *gomock.Controller) *MockNode_SubscribeToSyncEventsClient {
+	mock := &MockNode_SubscribeToSyncEventsClient{ctrl: ctrl}
+	mock.recorder = &MockNode_SubscribeToSyncEventsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockNode_SubscribeToSyncEventsClient) EXPECT() *MockNode_SubscribeToSyncEventsClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method
+func (m *MockNode_SubscribeToSyncEventsClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend
+func (mr *MockNode_SubscribeToSyncEventsClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockNode_SubscribeToSyncEventsClient)(nil).CloseSend))
+}
+
+// Context mocks base method
+func (m *MockNode_SubscribeToSyncEventsClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockNode_SubscribeToSyncEventsClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockNode_SubscribeToSyncEventsClient)(nil).Context))
+}
+
+// Header mocks base method
+func (m *MockNode_SubscribeToSyncEventsClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header
+func (mr *MockNode_SubscribeToSyncEventsClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockNode_SubscribeToSyncEventsClient)(nil).Header))
+}
+
+// Recv mocks base method
+func (m *MockNode_SubscribeToSyncEventsClient) Recv() (*node.SyncEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*node.SyncEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv
+func (mr *MockNode_SubscribeToSyncEventsClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockNode_SubscribeToSyncEventsClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method
+func (m *MockNode_SubscribeToSyncEventsClient) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockNode_SubscribeToSyncEventsClientMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockNode_SubscribeToSyncEventsClient)(nil).RecvMsg), arg0)
+}
+
+// SendMsg mocks base method
+func (m *MockNode_SubscribeToSyncEventsClient) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg
+func (mr *MockNode_SubscribeToSyncEventsClientMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockNode_SubscribeToSyncEventsClient)(nil).SendMsg), arg0)
+}
+
+// Trailer mocks base method
+func (m *MockNode_SubscribeToSyncEventsClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer
+func (mr *MockNode_SubscribeToSyncEventsClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockNode_SubscribeToSyncEventsClient)(nil).Trailer))
+}
@@ -36,6 +36,66 @@ func (m *MockRegistrationClient) EXPECT() *MockRegistrationClientMockRecorder {
 	return m.recorder
 }
 
+// BanAgent mocks base method
+func (m *MockRegistrationClient) BanAgent(arg0 context.Context, arg1 *registration.BanAgentRequest, arg2 ...grpc.CallOption) (*registration.BanAgentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BanAgent", varargs...)
+	ret0, _ := ret[0].(*registration.BanAgentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanAgent indicates an expected call of BanAgent
+func (mr *MockRegistrationClientMockRecorder) BanAgent(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanAgent", reflect.TypeOf((*MockRegistrationClient)(nil).BanAgent), varargs...)
+}
+
+// CountAgents mocks base method
+func (m *MockRegistrationClient) CountAgents(arg0 context.Context, arg1 *registration.CountAgentsRequest, arg2 ...grpc.CallOption) (*registration.CountAgentsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountAgents", varargs...)
+	ret0, _ := ret[0].(*registration.CountAgentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAgents indicates an expected call of CountAgents
+func (mr *MockRegistrationClientMockRecorder) CountAgents(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAgents", reflect.TypeOf((*MockRegistrationClient)(nil).CountAgents), varargs...)
+}
+
+// CountFederatedBundles mocks base method
+func (m *MockRegistrationClient) CountFederatedBundles(arg0 context.Context, arg1 *common.Empty, arg2 ...grpc.CallOption) (*registration.CountFederatedBundlesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountFederatedBundles", varargs...)
+	ret0, _ := ret[0].(*registration.CountFederatedBundlesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFederatedBundles indicates an expected call of CountFederatedBundles
+func (mr *MockRegistrationClientMockRecorder) CountFederatedBundles(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFederatedBundles", reflect.TypeOf((*MockRegistrationClient)(nil).CountFederatedBundles), varargs...)
+}
+
 // CreateEntry mocks base method
 func (m *MockRegistrationClient) CreateEntry(arg0 context.Context, arg1 *common.RegistrationEntry, arg2 ...grpc.CallOption) (*registration.RegistrationEntryID, error) {
 	m.ctrl.T.Helper()
@@ -256,6 +316,46 @@ func (mr *MockRegistrationClientMockRecorder) FetchFederatedBundle(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchFederatedBundle", reflect.TypeOf((*MockRegistrationClient)(nil).FetchFederatedBundle), varargs...)
 }
 
+// ForceAgentRotation mocks base method
+func (m *MockRegistrationClient) ForceAgentRotation(arg0 context.Context, arg1 *registration.ForceAgentRotationRequest, arg2 ...grpc.CallOption) (*registration.ForceAgentRotationResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ForceAgentRotation", varargs...)
+	ret0, _ := ret[0].(*registration.ForceAgentRotationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceAgentRotation indicates an expected call of ForceAgentRotation
+func (mr *MockRegistrationClientMockRecorder) ForceAgentRotation(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceAgentRotation", reflect.TypeOf((*MockRegistrationClient)(nil).ForceAgentRotation), varargs...)
+}
+
+// GetEntryStatistics mocks base method
+func (m *MockRegistrationClient) GetEntryStatistics(arg0 context.Context, arg1 *registration.GetEntryStatisticsRequest, arg2 ...grpc.CallOption) (*registration.GetEntryStatisticsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetEntryStatistics", varargs...)
+	ret0, _ := ret[0].(*registration.GetEntryStatisticsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntryStatistics indicates an expected call of GetEntryStatistics
+func (mr *MockRegistrationClientMockRecorder) GetEntryStatistics(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntryStatistics", reflect.TypeOf((*MockRegistrationClient)(nil).GetEntryStatistics), varargs...)
+}
+
 // GetNodeSelectors mocks base method
 func (m *MockRegistrationClient) GetNodeSelectors(arg0 context.Context, arg1 *registration.GetNodeSelectorsRequest, arg2 ...grpc.CallOption) (*registration.GetNodeSelectorsResponse, error) {
 	m.ctrl.T.Helper()
@@ -416,6 +516,26 @@ func (mr *MockRegistrationClientMockRecorder) ListFederatedBundles(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFederatedBundles", reflect.TypeOf((*MockRegistrationClient)(nil).ListFederatedBundles), varargs...)
 }
 
+// ListIssuedX509SVIDs mocks base method
+func (m *MockRegistrationClient) ListIssuedX509SVIDs(arg0 context.Context, arg1 *registration.ListIssuedX509SVIDsRequest, arg2 ...grpc.CallOption) (*registration.ListIssuedX509SVIDsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListIssuedX509SVIDs", varargs...)
+	ret0, _ := ret[0].(*registration.ListIssuedX509SVIDsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIssuedX509SVIDs indicates an expected call of ListIssuedX509SVIDs
+func (mr *MockRegistrationClientMockRecorder) ListIssuedX509SVIDs(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssuedX509SVIDs", reflect.TypeOf((*MockRegistrationClient)(nil).ListIssuedX509SVIDs), varargs...)
+}
+
 // MintJWTSVID mocks base method
 func (m *MockRegistrationClient) MintJWTSVID(arg0 context.Context, arg1 *registration.MintJWTSVIDRequest, arg2 ...grpc.CallOption) (*registration.MintJWTSVIDResponse, error) {
 	m.ctrl.T.Helper()
@@ -456,6 +576,26 @@ func (mr *MockRegistrationClientMockRecorder) MintX509SVID(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MintX509SVID", reflect.TypeOf((*MockRegistrationClient)(nil).MintX509SVID), varargs...)
 }
 
+// PruneAgents mocks base method
+func (m *MockRegistrationClient) PruneAgents(arg0 context.Context, arg1 *registration.PruneAgentsRequest, arg2 ...grpc.CallOption) (*registration.PruneAgentsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PruneAgents", varargs...)
+	ret0, _ := ret[0].(*registration.PruneAgentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneAgents indicates an expected call of PruneAgents
+func (mr *MockRegistrationClientMockRecorder) PruneAgents(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneAgents", reflect.TypeOf((*MockRegistrationClient)(nil).PruneAgents), varargs...)
+}
+
 // UpdateEntry mocks base method
 func (m *MockRegistrationClient) UpdateEntry(arg0 context.Context, arg1 *registration.UpdateEntryRequest, arg2 ...grpc.CallOption) (*common.RegistrationEntry, error) {
 	m.ctrl.T.Helper()
@@ -519,6 +659,51 @@ func (m *MockRegistrationServer) EXPECT() *MockRegistrationServerMockRecorder {
 	return m.recorder
 }
 
+// BanAgent mocks base method
+func (m *MockRegistrationServer) BanAgent(arg0 context.Context, arg1 *registration.BanAgentRequest) (*registration.BanAgentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanAgent", arg0, arg1)
+	ret0, _ := ret[0].(*registration.BanAgentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanAgent indicates an expected call of BanAgent
+func (mr *MockRegistrationServerMockRecorder) BanAgent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanAgent", reflect.TypeOf((*MockRegistrationServer)(nil).BanAgent), arg0, arg1)
+}
+
+// CountAgents mocks base method
+func (m *MockRegistrationServer) CountAgents(arg0 context.Context, arg1 *registration.CountAgentsRequest) (*registration.CountAgentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAgents", arg0, arg1)
+	ret0, _ := ret[0].(*registration.CountAgentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAgents indicates an expected call of CountAgents
+func (mr *MockRegistrationServerMockRecorder) CountAgents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAgents", reflect.TypeOf((*MockRegistrationServer)(nil).CountAgents), arg0, arg1)
+}
+
+// CountFederatedBundles mocks base method
+func (m *MockRegistrationServer) CountFederatedBundles(arg0 context.Context, arg1 *common.Empty) (*registration.CountFederatedBundlesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFederatedBundles", arg0, arg1)
+	ret0, _ := ret[0].(*registration.CountFederatedBundlesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFederatedBundles indicates an expected call of CountFederatedBundles
+func (mr *MockRegistrationServerMockRecorder) CountFederatedBundles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFederatedBundles", reflect.TypeOf((*MockRegistrationServer)(nil).CountFederatedBundles), arg0, arg1)
+}
+
 // CreateEntry mocks base method
 func (m *MockRegistrationServer) CreateEntry(arg0 context.Context, arg1 *common.RegistrationEntry) (*registration.RegistrationEntryID, error) {
 	m.ctrl.T.Helper()
@@ -684,6 +869,36 @@ func (mr *MockRegistrationServerMockRecorder) FetchFederatedBundle(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchFederatedBundle", reflect.TypeOf((*MockRegistrationServer)(nil).FetchFederatedBundle), arg0, arg1)
 }
 
+// ForceAgentRotation mocks base method
+func (m *MockRegistrationServer) ForceAgentRotation(arg0 context.Context, arg1 *registration.ForceAgentRotationRequest) (*registration.ForceAgentRotationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceAgentRotation", arg0, arg1)
+	ret0, _ := ret[0].(*registration.ForceAgentRotationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceAgentRotation indicates an expected call of ForceAgentRotation
+func (mr *MockRegistrationServerMockRecorder) ForceAgentRotation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceAgentRotation", reflect.TypeOf((*MockRegistrationServer)(nil).ForceAgentRotation), arg0, arg1)
+}
+
+// GetEntryStatistics mocks base method
+func (m *MockRegistrationServer) GetEntryStatistics(arg0 context.Context, arg1 *registration.GetEntryStatisticsRequest) (*registration.GetEntryStatisticsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntryStatistics", arg0, arg1)
+	ret0, _ := ret[0].(*registration.GetEntryStatisticsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntryStatistics indicates an expected call of GetEntryStatistics
+func (mr *MockRegistrationServerMockRecorder) GetEntryStatistics(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntryStatistics", reflect.TypeOf((*MockRegistrationServer)(nil).GetEntryStatistics), arg0, arg1)
+}
+
 // GetNodeSelectors mocks base method
 func (m *MockRegistrationServer) GetNodeSelectors(arg0 context.Context, arg1 *registration.GetNodeSelectorsRequest) (*registration.GetNodeSelectorsResponse, error) {
 	m.ctrl.T.Helper()
@@ -803,6 +1018,21 @@ func (mr *MockRegistrationServerMockRecorder) ListFederatedBundles(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFederatedBundles", reflect.TypeOf((*MockRegistrationServer)(nil).ListFederatedBundles), arg0, arg1)
 }
 
+// ListIssuedX509SVIDs mocks base method
+func (m *MockRegistrationServer) ListIssuedX509SVIDs(arg0 context.Context, arg1 *registration.ListIssuedX509SVIDsRequest) (*registration.ListIssuedX509SVIDsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIssuedX509SVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*registration.ListIssuedX509SVIDsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIssuedX509SVIDs indicates an expected call of ListIssuedX509SVIDs
+func (mr *MockRegistrationServerMockRecorder) ListIssuedX509SVIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssuedX509SVIDs", reflect.TypeOf((*MockRegistrationServer)(nil).ListIssuedX509SVIDs), arg0, arg1)
+}
+
 // MintJWTSVID mocks base method
 func (m *MockRegistrationServer) MintJWTSVID(arg0 context.Context, arg1 *registration.MintJWTSVIDRequest) (*registration.MintJWTSVIDResponse, error) {
 	m.ctrl.T.Helper()
@@ -833,6 +1063,21 @@ func (mr *MockRegistrationServerMockRecorder) MintX509SVID(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MintX509SVID", reflect.TypeOf((*MockRegistrationServer)(nil).MintX509SVID), arg0, arg1)
 }
 
+// PruneAgents mocks base method
+func (m *MockRegistrationServer) PruneAgents(arg0 context.Context, arg1 *registration.PruneAgentsRequest) (*registration.PruneAgentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneAgents", arg0, arg1)
+	ret0, _ := ret[0].(*registration.PruneAgentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneAgents indicates an expected call of PruneAgents
+func (mr *MockRegistrationServerMockRecorder) PruneAgents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneAgents", reflect.TypeOf((*MockRegistrationServer)(nil).PruneAgents), arg0, arg1)
+}
+
 // UpdateEntry mocks base method
 func (m *MockRegistrationServer) UpdateEntry(arg0 context.Context, arg1 *registration.UpdateEntryRequest) (*common.RegistrationEntry, error) {
 	m.ctrl.T.Helper()